@@ -4,6 +4,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -21,6 +22,9 @@ type mysqlAgentRequest struct {
 	DBName    string                       `json:"dbName,omitempty"`
 	TableName string                       `json:"tableName,omitempty"`
 	Changes   *connection.ChangeSet        `json:"changes,omitempty"`
+	Trigger   *connection.TriggerSpec      `json:"trigger,omitempty"`
+	Index     *connection.IndexSpec        `json:"index,omitempty"`
+	Params    []interface{}                `json:"params,omitempty"`
 }
 
 type mysqlAgentResponse struct {
@@ -46,7 +50,14 @@ const (
 	mysqlAgentMethodGetIndexes    = "getIndexes"
 	mysqlAgentMethodGetForeignKey = "getForeignKeys"
 	mysqlAgentMethodGetTriggers   = "getTriggers"
+	mysqlAgentMethodGetTriggerDef = "getTriggerDefinition"
+	mysqlAgentMethodCreateTrigger = "createTrigger"
+	mysqlAgentMethodDropTrigger   = "dropTrigger"
+	mysqlAgentMethodCreateIndex   = "createIndex"
+	mysqlAgentMethodDropIndex     = "dropIndex"
+	mysqlAgentMethodEstimateIndex = "estimateIndexSize"
 	mysqlAgentMethodApplyChanges  = "applyChanges"
+	mysqlAgentMethodPlanChanges   = "planChanges"
 )
 
 func main() {
@@ -128,14 +139,27 @@ func handleRequest(inst **db.MySQLDB, req mysqlAgentRequest) mysqlAgentResponse
 			return fail(resp, err.Error())
 		}
 	case mysqlAgentMethodQuery:
-		data, fields, err := (*inst).Query(req.Query)
+		var data []map[string]interface{}
+		var fields []string
+		var err error
+		if len(req.Params) > 0 {
+			data, fields, err = (*inst).QueryParams(context.Background(), req.Query, db.NormalizeJSONParams(req.Params))
+		} else {
+			data, fields, err = (*inst).Query(req.Query)
+		}
 		if err != nil {
 			return fail(resp, err.Error())
 		}
 		resp.Data = data
 		resp.Fields = fields
 	case mysqlAgentMethodExec:
-		affected, err := (*inst).Exec(req.Query)
+		var affected int64
+		var err error
+		if len(req.Params) > 0 {
+			affected, err = (*inst).ExecParams(context.Background(), req.Query, db.NormalizeJSONParams(req.Params))
+		} else {
+			affected, err = (*inst).Exec(req.Query)
+		}
 		if err != nil {
 			return fail(resp, err.Error())
 		}
@@ -188,6 +212,50 @@ func handleRequest(inst **db.MySQLDB, req mysqlAgentRequest) mysqlAgentResponse
 			return fail(resp, err.Error())
 		}
 		resp.Data = data
+	case mysqlAgentMethodCreateIndex, mysqlAgentMethodDropIndex, mysqlAgentMethodEstimateIndex:
+		if req.Index == nil {
+			return fail(resp, "索引参数为空")
+		}
+		switch strings.TrimSpace(req.Method) {
+		case mysqlAgentMethodCreateIndex:
+			if err := (*inst).CreateIndex(req.DBName, req.TableName, *req.Index); err != nil {
+				return fail(resp, err.Error())
+			}
+		case mysqlAgentMethodDropIndex:
+			if err := (*inst).DropIndex(req.DBName, req.TableName, req.Index.Name); err != nil {
+				return fail(resp, err.Error())
+			}
+		default:
+			data, err := (*inst).EstimateIndexSize(req.DBName, req.TableName, *req.Index)
+			if err != nil {
+				return fail(resp, err.Error())
+			}
+			resp.Data = data
+		}
+	case mysqlAgentMethodGetTriggerDef, mysqlAgentMethodCreateTrigger, mysqlAgentMethodDropTrigger:
+		if req.Trigger == nil {
+			return fail(resp, "触发器参数为空")
+		}
+		manager, ok := db.TriggerManagerFor(*inst, "mysql")
+		if !ok {
+			return fail(resp, "当前驱动不支持维护触发器")
+		}
+		switch strings.TrimSpace(req.Method) {
+		case mysqlAgentMethodGetTriggerDef:
+			data, err := manager.GetTriggerDefinition(req.Trigger.Schema, req.Trigger.TableName, req.Trigger.Name)
+			if err != nil {
+				return fail(resp, err.Error())
+			}
+			resp.Data = data
+		case mysqlAgentMethodCreateTrigger:
+			if err := manager.CreateTrigger(*req.Trigger); err != nil {
+				return fail(resp, err.Error())
+			}
+		default:
+			if err := manager.DropTrigger(req.Trigger.Schema, req.Trigger.TableName, req.Trigger.Name); err != nil {
+				return fail(resp, err.Error())
+			}
+		}
 	case mysqlAgentMethodApplyChanges:
 		if req.Changes == nil {
 			return fail(resp, "变更集为空")
@@ -201,6 +269,18 @@ func handleRequest(inst **db.MySQLDB, req mysqlAgentRequest) mysqlAgentResponse
 		if err := applier.ApplyChanges(req.TableName, *req.Changes); err != nil {
 			return fail(resp, err.Error())
 		}
+	case mysqlAgentMethodPlanChanges:
+		if req.Changes == nil {
+			return fail(resp, "变更集为空")
+		}
+		if *inst == nil {
+			return fail(resp, "connection not open")
+		}
+		statements, err := (*inst).PlanChanges(req.TableName, *req.Changes)
+		if err != nil {
+			return fail(resp, err.Error())
+		}
+		resp.Data = statements
 	default:
 		return fail(resp, "不支持的方法")
 	}
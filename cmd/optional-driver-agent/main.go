@@ -2,7 +2,9 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -19,15 +21,22 @@ type agentRequest struct {
 	DBName    string                       `json:"dbName,omitempty"`
 	TableName string                       `json:"tableName,omitempty"`
 	Changes   *connection.ChangeSet        `json:"changes,omitempty"`
+	Trigger   *connection.TriggerSpec      `json:"trigger,omitempty"`
+	Index     *connection.IndexSpec        `json:"index,omitempty"`
+	Columns   []string                     `json:"columns,omitempty"`
+	Rows      [][]interface{}              `json:"rows,omitempty"`
+	Limit     *db.TransferLimit            `json:"limit,omitempty"`
+	Params    []interface{}                `json:"params,omitempty"`
 }
 
 type agentResponse struct {
-	ID           int64       `json:"id"`
-	Success      bool        `json:"success"`
-	Error        string      `json:"error,omitempty"`
-	Data         interface{} `json:"data,omitempty"`
-	Fields       []string    `json:"fields,omitempty"`
-	RowsAffected int64       `json:"rowsAffected,omitempty"`
+	ID           int64                  `json:"id"`
+	Success      bool                   `json:"success"`
+	Error        string                 `json:"error,omitempty"`
+	Data         interface{}            `json:"data,omitempty"`
+	Fields       []string               `json:"fields,omitempty"`
+	RowsAffected int64                  `json:"rowsAffected,omitempty"`
+	LimitReached *db.TransferLimitError `json:"limitReached,omitempty"`
 }
 
 const (
@@ -44,7 +53,15 @@ const (
 	agentMethodGetIndexes    = "getIndexes"
 	agentMethodGetForeignKey = "getForeignKeys"
 	agentMethodGetTriggers   = "getTriggers"
+	agentMethodGetTriggerDef = "getTriggerDefinition"
+	agentMethodCreateTrigger = "createTrigger"
+	agentMethodDropTrigger   = "dropTrigger"
+	agentMethodCreateIndex   = "createIndex"
+	agentMethodDropIndex     = "dropIndex"
+	agentMethodEstimateIndex = "estimateIndexSize"
 	agentMethodApplyChanges  = "applyChanges"
+	agentMethodPlanChanges   = "planChanges"
+	agentMethodBulkLoad      = "bulkLoad"
 )
 
 var (
@@ -137,14 +154,17 @@ func handleRequest(inst *db.Database, req agentRequest) agentResponse {
 			return fail(resp, err.Error())
 		}
 	case agentMethodQuery:
-		data, fields, err := (*inst).Query(req.Query)
-		if err != nil {
+		data, fields, err := queryWithLimit(*inst, req.Query, req.Params, req.Limit)
+		var limitErr *db.TransferLimitError
+		if errors.As(err, &limitErr) {
+			resp.LimitReached = limitErr
+		} else if err != nil {
 			return fail(resp, err.Error())
 		}
 		resp.Data = data
 		resp.Fields = fields
 	case agentMethodExec:
-		affected, err := (*inst).Exec(req.Query)
+		affected, err := execWithParams(*inst, req.Query, req.Params)
 		if err != nil {
 			return fail(resp, err.Error())
 		}
@@ -197,6 +217,50 @@ func handleRequest(inst *db.Database, req agentRequest) agentResponse {
 			return fail(resp, err.Error())
 		}
 		resp.Data = data
+	case agentMethodCreateIndex, agentMethodDropIndex, agentMethodEstimateIndex:
+		if req.Index == nil {
+			return fail(resp, "索引参数为空")
+		}
+		switch strings.TrimSpace(req.Method) {
+		case agentMethodCreateIndex:
+			if err := (*inst).CreateIndex(req.DBName, req.TableName, *req.Index); err != nil {
+				return fail(resp, err.Error())
+			}
+		case agentMethodDropIndex:
+			if err := (*inst).DropIndex(req.DBName, req.TableName, req.Index.Name); err != nil {
+				return fail(resp, err.Error())
+			}
+		default:
+			data, err := (*inst).EstimateIndexSize(req.DBName, req.TableName, *req.Index)
+			if err != nil {
+				return fail(resp, err.Error())
+			}
+			resp.Data = data
+		}
+	case agentMethodGetTriggerDef, agentMethodCreateTrigger, agentMethodDropTrigger:
+		if req.Trigger == nil {
+			return fail(resp, "触发器参数为空")
+		}
+		manager, ok := db.TriggerManagerFor(*inst, agentDriverType)
+		if !ok {
+			return fail(resp, "当前驱动不支持维护触发器")
+		}
+		switch method {
+		case agentMethodGetTriggerDef:
+			data, err := manager.GetTriggerDefinition(req.Trigger.Schema, req.Trigger.TableName, req.Trigger.Name)
+			if err != nil {
+				return fail(resp, err.Error())
+			}
+			resp.Data = data
+		case agentMethodCreateTrigger:
+			if err := manager.CreateTrigger(*req.Trigger); err != nil {
+				return fail(resp, err.Error())
+			}
+		default:
+			if err := manager.DropTrigger(req.Trigger.Schema, req.Trigger.TableName, req.Trigger.Name); err != nil {
+				return fail(resp, err.Error())
+			}
+		}
 	case agentMethodApplyChanges:
 		if req.Changes == nil {
 			return fail(resp, "变更集为空")
@@ -210,6 +274,29 @@ func handleRequest(inst *db.Database, req agentRequest) agentResponse {
 		if err := applier.ApplyChanges(req.TableName, *req.Changes); err != nil {
 			return fail(resp, err.Error())
 		}
+	case agentMethodPlanChanges:
+		if req.Changes == nil {
+			return fail(resp, "变更集为空")
+		}
+		planner, ok := (*inst).(db.ChangePlanner)
+		if !ok {
+			return fail(resp, "当前驱动不支持 PlanChanges")
+		}
+		statements, err := planner.PlanChanges(req.TableName, *req.Changes)
+		if err != nil {
+			return fail(resp, err.Error())
+		}
+		resp.Data = statements
+	case agentMethodBulkLoad:
+		loader, ok := (*inst).(db.BulkLoader)
+		if !ok || loader.BulkLoadMethod() == "" {
+			return fail(resp, "当前驱动不支持批量写入通道")
+		}
+		affected, err := loader.BulkLoad(context.Background(), req.TableName, req.Columns, normalizeBulkRows(req.Rows))
+		if err != nil {
+			return fail(resp, err.Error())
+		}
+		resp.RowsAffected = affected
 	default:
 		return fail(resp, "不支持的方法")
 	}
@@ -217,6 +304,14 @@ func handleRequest(inst *db.Database, req agentRequest) agentResponse {
 	return resp
 }
 
+// normalizeBulkRows 将 JSON 解码得到的整数值 float64 还原为 int64，避免驱动按浮点写入整数列。
+func normalizeBulkRows(rows [][]interface{}) [][]interface{} {
+	for _, row := range rows {
+		db.NormalizeJSONParams(row)
+	}
+	return rows
+}
+
 func writeResponse(writer *bufio.Writer, resp agentResponse) error {
 	payload, err := json.Marshal(resp)
 	if err != nil {
@@ -229,6 +324,40 @@ func writeResponse(writer *bufio.Writer, resp agentResponse) error {
 	return writer.Flush()
 }
 
+// queryWithLimit 携带拉取限制与参数执行查询；驱动未实现 QueryContext 时不做限制。
+func queryWithLimit(inst db.Database, query string, params []interface{}, limit *db.TransferLimit) ([]map[string]interface{}, []string, error) {
+	if len(params) > 0 {
+		q, ok := inst.(db.ParamQuerier)
+		if !ok {
+			return nil, nil, db.ErrParamsUnsupported
+		}
+		ctx := context.Background()
+		if limit != nil {
+			ctx = db.WithTransferLimit(ctx, *limit)
+		}
+		return q.QueryParams(ctx, query, db.NormalizeJSONParams(params))
+	}
+	if limit != nil {
+		if q, ok := inst.(interface {
+			QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+		}); ok {
+			return q.QueryContext(db.WithTransferLimit(context.Background(), *limit), query)
+		}
+	}
+	return inst.Query(query)
+}
+
+func execWithParams(inst db.Database, query string, params []interface{}) (int64, error) {
+	if len(params) == 0 {
+		return inst.Exec(query)
+	}
+	q, ok := inst.(db.ParamQuerier)
+	if !ok {
+		return 0, db.ErrParamsUnsupported
+	}
+	return q.ExecParams(context.Background(), query, db.NormalizeJSONParams(params))
+}
+
 func fail(resp agentResponse, errText string) agentResponse {
 	resp.Success = false
 	resp.Error = strings.TrimSpace(errText)
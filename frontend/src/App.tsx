@@ -1,5 +1,5 @@
 import React, { useState, useEffect } from 'react';
-import { Layout, Button, ConfigProvider, theme, Dropdown, MenuProps, message, Modal, Spin, Slider, Progress } from 'antd';
+import { Layout, Button, ConfigProvider, theme, Dropdown, MenuProps, message, Modal, Spin, Slider, Progress, Input, Select } from 'antd';
 import zhCN from 'antd/locale/zh_CN';
 import { PlusOutlined, BulbOutlined, BulbFilled, ConsoleSqlOutlined, UploadOutlined, DownloadOutlined, CloudDownloadOutlined, BugOutlined, ToolOutlined, InfoCircleOutlined, GithubOutlined, SkinOutlined, CheckOutlined, MinusOutlined, BorderOutlined, CloseOutlined, SettingOutlined } from '@ant-design/icons';
 import { Environment, EventsOn } from '../wailsjs/runtime/runtime';
@@ -7,9 +7,12 @@ import Sidebar from './components/Sidebar';
 import TabManager from './components/TabManager';
 import ConnectionModal from './components/ConnectionModal';
 import DataSyncModal from './components/DataSyncModal';
+import TransferModal from './components/TransferModal';
+import SchemaCompareModal from './components/SchemaCompareModal';
+import DataCompareModal from './components/DataCompareModal';
 import DriverManagerModal from './components/DriverManagerModal';
 import LogPanel from './components/LogPanel';
-import { useStore } from './store';
+import { useStore, markConnectionStoreReady } from './store';
 import { SavedConnection } from './types';
 import { blurToFilter, normalizeBlurForPlatform, normalizeOpacityForPlatform, isWindowsPlatform } from './utils/appearance';
 import { SetWindowTranslucency } from '../wailsjs/go/app/App';
@@ -20,12 +23,19 @@ const { Sider, Content } = Layout;
 function App() {
   const [isModalOpen, setIsModalOpen] = useState(false);
   const [isSyncModalOpen, setIsSyncModalOpen] = useState(false);
+  const [isTransferModalOpen, setIsTransferModalOpen] = useState(false);
+  const [isSchemaCompareOpen, setIsSchemaCompareOpen] = useState(false);
+  const [isDataCompareOpen, setIsDataCompareOpen] = useState(false);
   const [isDriverModalOpen, setIsDriverModalOpen] = useState(false);
   const [editingConnection, setEditingConnection] = useState<SavedConnection | null>(null);
   const themeMode = useStore(state => state.theme);
   const setTheme = useStore(state => state.setTheme);
   const appearance = useStore(state => state.appearance);
   const setAppearance = useStore(state => state.setAppearance);
+  const exportLocale = useStore(state => state.exportLocale);
+  const setExportLocale = useStore(state => state.setExportLocale);
+  const startupConnectionTest = useStore(state => state.startupConnectionTest);
+  const setStartupConnectionTest = useStore(state => state.setStartupConnectionTest);
   const darkMode = themeMode === 'dark';
   const effectiveOpacity = normalizeOpacityForPlatform(appearance.opacity);
   const effectiveBlur = normalizeBlurForPlatform(appearance.blur);
@@ -331,12 +341,20 @@ function App() {
               const imported = JSON.parse(res.data);
               if (Array.isArray(imported)) {
                   let count = 0;
+                  const gridPreferences: any[] = [];
                   imported.forEach((conn: any) => {
+                      if (Array.isArray(conn.gridPreferences)) {
+                          gridPreferences.push(...conn.gridPreferences.map((p: any) => ({ ...p, connectionId: conn.id })));
+                      }
                       if (!connections.some(c => c.id === conn.id)) {
-                          addConnection(conn);
+                          const { gridPreferences: _prefs, ...profile } = conn;
+                          addConnection(profile);
                           count++;
                       }
                   });
+                  if (gridPreferences.length > 0) {
+                      await (window as any).go.app.App.ImportGridPreferences(gridPreferences);
+                  }
                   message.success(`成功导入 ${count} 个连接`);
               } else {
                   message.error("文件格式错误：需要 JSON 数组");
@@ -349,12 +367,59 @@ function App() {
       }
   };
 
+  const handleImportExternalConnections = async () => {
+      const res = await (window as any).go.app.App.ImportConnectionsFromFile('', '');
+      if (!res.success) {
+          if (res.message !== "Cancelled") {
+              message.error("导入失败: " + res.message);
+          }
+          return;
+      }
+      const imported: any[] = res.data?.connections || [];
+      const exists = (conn: any) => connections.some(c =>
+          c.config.type === conn.config.type &&
+          c.config.host === conn.config.host &&
+          Number(c.config.port || 0) === Number(conn.config.port || 0) &&
+          (c.config.user || '') === (conn.config.user || '') &&
+          (c.config.database || '') === (conn.config.database || '')
+      );
+      let count = 0;
+      imported.forEach((conn: any) => {
+          if (!exists(conn)) {
+              addConnection(conn);
+              count++;
+          }
+      });
+      const skipped = imported.length - count;
+      const warnings: string[] = res.data?.warnings || [];
+      const summary = `成功导入 ${count} 个连接` + (skipped > 0 ? `，跳过已存在的 ${skipped} 个` : '');
+      if (warnings.length > 0) {
+          Modal.warning({
+              title: summary,
+              content: (
+                  <div style={{ maxHeight: 300, overflow: 'auto' }}>
+                      {warnings.map((w, i) => <div key={i}>{w}</div>)}
+                  </div>
+              ),
+          });
+      } else {
+          message.success(summary);
+      }
+  };
+
   const handleExportConnections = async () => {
       if (connections.length === 0) {
           message.warning("没有连接可导出");
           return;
       }
-      const res = await (window as any).go.app.App.ExportData(connections, [], "connections", "json");
+      // 表格偏好随各自的连接一起导出
+      const prefRes = await (window as any).go.app.App.ListGridPreferences('');
+      const prefs: any[] = prefRes?.success && Array.isArray(prefRes.data) ? prefRes.data : [];
+      const exported = connections.map(conn => {
+          const gridPreferences = prefs.filter(p => p.connectionId === conn.id);
+          return gridPreferences.length > 0 ? { ...conn, gridPreferences } : conn;
+      });
+      const res = await (window as any).go.app.App.ExportData(exported, [], "connections", "json");
       if (res.success) {
           message.success("导出成功");
       } else if (res.message !== "Cancelled") {
@@ -369,6 +434,12 @@ function App() {
           icon: <UploadOutlined />,
           onClick: handleImportConnections
       },
+      {
+          key: 'import-external',
+          label: '从 Navicat/DBeaver/URI 列表导入',
+          icon: <UploadOutlined />,
+          onClick: handleImportExternalConnections
+      },
       {
           key: 'export',
           label: '导出连接配置',
@@ -381,6 +452,30 @@ function App() {
           icon: <UploadOutlined rotate={90} />,
           onClick: () => setIsSyncModalOpen(true)
       },
+      {
+          key: 'transfer',
+          label: '数据传输',
+          icon: <UploadOutlined rotate={90} />,
+          onClick: () => setIsTransferModalOpen(true)
+      },
+      {
+          key: 'schema-compare',
+          label: '结构比较与同步',
+          icon: <UploadOutlined rotate={90} />,
+          onClick: () => setIsSchemaCompareOpen(true)
+      },
+      {
+          key: 'data-compare',
+          label: '表数据比较',
+          icon: <UploadOutlined rotate={90} />,
+          onClick: () => setIsDataCompareOpen(true)
+      },
+      {
+          key: 'startup-connection-test',
+          label: '启动时测试全部连接',
+          icon: startupConnectionTest ? <CheckOutlined /> : undefined,
+          onClick: () => setStartupConnectionTest(!startupConnectionTest)
+      },
       {
           key: 'drivers',
           label: '驱动管理',
@@ -408,10 +503,17 @@ function App() {
           label: '外观设置...',
           icon: <SettingOutlined />,
           onClick: () => setIsAppearanceModalOpen(true)
+      },
+      {
+          key: 'export-locale',
+          label: '导出格式设置...',
+          icon: <SettingOutlined />,
+          onClick: () => setIsExportLocaleModalOpen(true)
       }
   ];
 
   const [isAppearanceModalOpen, setIsAppearanceModalOpen] = useState(false);
+  const [isExportLocaleModalOpen, setIsExportLocaleModalOpen] = useState(false);
 
 
   // Log Panel: 最小高度按“工具栏 + 1 条日志行（微增）”限制
@@ -555,6 +657,58 @@ function App() {
     document.body.setAttribute('data-theme', darkMode ? 'dark' : 'light');
   }, [darkMode]);
 
+  // 已保存连接以后端加密存储为准；后端为空而本地有旧数据时迁移过去，迁移后本地不再保存密码
+  useEffect(() => {
+      const api = (window as any).go?.app?.App;
+      if (!api?.ListConnections) return;
+      const loadBackendConnections = async (): Promise<void> => {
+          const res = await api.ListConnections();
+          if (!res?.success) {
+              if (res?.data?.locked) {
+                  let password = '';
+                  Modal.confirm({
+                      title: '解锁已保存的连接',
+                      content: <Input.Password autoFocus placeholder="主密码" onChange={(e) => { password = e.target.value; }} />,
+                      okText: '解锁',
+                      cancelText: '稍后',
+                      onOk: async () => {
+                          const unlock = await api.UnlockConnectionStore(password);
+                          if (!unlock?.success) {
+                              message.error(unlock?.message || '主密码错误');
+                              throw new Error(unlock?.message);
+                          }
+                          await loadBackendConnections();
+                      },
+                  });
+              } else {
+                  message.warning('读取已保存连接失败: ' + (res?.message || '未知错误'));
+              }
+              return;
+          }
+          const remote = Array.isArray(res.data) ? res.data as SavedConnection[] : [];
+          const local = useStore.getState().connections;
+          if (remote.length === 0 && local.length > 0) {
+              for (const conn of local) {
+                  const saved = await api.SaveConnection(conn);
+                  if (!saved?.success) {
+                      message.warning('迁移已保存连接失败: ' + (saved?.message || conn.name));
+                      return;
+                  }
+              }
+              markConnectionStoreReady();
+              useStore.getState().setConnections(local);
+              return;
+          }
+          markConnectionStoreReady();
+          useStore.getState().setConnections(remote);
+          // 逐个结果通过 connection:status 事件推送给连接树，这里无需等待
+          if (useStore.getState().startupConnectionTest && remote.length > 0 && api.TestSavedConnections) {
+              api.TestSavedConnections([]).catch((err: any) => console.warn('启动连接测试失败:', err));
+          }
+      };
+      loadBackendConnections().catch((err) => console.warn('读取已保存连接失败:', err));
+  }, []);
+
   useEffect(() => {
       if (isAboutOpen) {
           if (lastUpdateInfo?.hasUpdate) {
@@ -813,6 +967,18 @@ function App() {
             open={isSyncModalOpen}
             onClose={() => setIsSyncModalOpen(false)}
           />
+          <TransferModal
+            open={isTransferModalOpen}
+            onClose={() => setIsTransferModalOpen(false)}
+          />
+          <SchemaCompareModal
+            open={isSchemaCompareOpen}
+            onClose={() => setIsSchemaCompareOpen(false)}
+          />
+          <DataCompareModal
+            open={isDataCompareOpen}
+            onClose={() => setIsDataCompareOpen(false)}
+          />
           <DriverManagerModal
             open={isDriverModalOpen}
             onClose={() => setIsDriverModalOpen(false)}
@@ -918,6 +1084,62 @@ function App() {
               </div>
           </Modal>
 
+          <Modal
+              title="导出格式设置"
+              open={isExportLocaleModalOpen}
+              onCancel={() => setIsExportLocaleModalOpen(false)}
+              footer={null}
+              width={420}
+          >
+              <div style={{ display: 'flex', flexDirection: 'column', gap: 16, padding: '12px 0' }}>
+                  <div>
+                      <div style={{ marginBottom: 8, fontWeight: 500 }}>小数点</div>
+                      <Select
+                        style={{ width: '100%' }}
+                        value={exportLocale.decimalSeparator}
+                        onChange={(v) => setExportLocale({ decimalSeparator: v })}
+                        options={[
+                            { value: '', label: '默认 (1234.5)' },
+                            { value: ',', label: '逗号 (1234,5)，CSV 改用分号分隔' },
+                        ]}
+                      />
+                  </div>
+                  <div>
+                      <div style={{ marginBottom: 8, fontWeight: 500 }}>千分位分隔符</div>
+                      <Select
+                        style={{ width: '100%' }}
+                        value={exportLocale.thousandsSeparator}
+                        onChange={(v) => setExportLocale({ thousandsSeparator: v })}
+                        options={[
+                            { value: '', label: '不使用' },
+                            { value: ',', label: '逗号 (1,234,567)' },
+                            { value: '.', label: '句点 (1.234.567)' },
+                            { value: ' ', label: '空格 (1 234 567)' },
+                        ]}
+                      />
+                  </div>
+                  <div>
+                      <div style={{ marginBottom: 8, fontWeight: 500 }}>日期格式</div>
+                      <Input
+                        placeholder="默认 yyyy-MM-dd，例如 yyyy/MM/dd"
+                        value={exportLocale.datePattern}
+                        onChange={(e) => setExportLocale({ datePattern: e.target.value })}
+                      />
+                  </div>
+                  <div>
+                      <div style={{ marginBottom: 8, fontWeight: 500 }}>日期时间格式</div>
+                      <Input
+                        placeholder="默认 yyyy-MM-dd HH:mm:ss，例如 yyyy/MM/dd HH:mm:ss"
+                        value={exportLocale.dateTimePattern}
+                        onChange={(e) => setExportLocale({ dateTimePattern: e.target.value })}
+                      />
+                  </div>
+                  <div style={{ fontSize: 12, color: '#888' }}>
+                      * 作用于 CSV / Excel / Markdown 导出，JSON 与 SQL 保持原始值；中文 Excel 建议日期使用 yyyy/MM/dd
+                  </div>
+              </div>
+          </Modal>
+
           <Modal
               title={updateDownloadProgress.version ? `下载更新 ${updateDownloadProgress.version}` : '下载更新'}
               open={updateDownloadProgress.open}
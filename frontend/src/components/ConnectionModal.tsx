@@ -19,6 +19,7 @@ const getDefaultPortByType = (type: string) => {
     case 'postgres': return 5432;
     case 'redis': return 6379;
     case 'tdengine': return 6041;
+    case 'clickhouse': return 9000;
     case 'oracle': return 1521;
     case 'dameng': return 5236;
     case 'kingbase': return 54321;
@@ -35,6 +36,15 @@ const getDefaultPortByType = (type: string) => {
 
 const isFileDatabaseType = (type: string) => type === 'sqlite' || type === 'duckdb';
 
+const SSL_SUPPORTED_TYPES = ['mysql', 'mariadb', 'postgres', 'sqlserver', 'mongodb'];
+const supportsSSL = (type: string) => SSL_SUPPORTED_TYPES.includes(String(type || '').toLowerCase());
+const sslModeHelp: Record<string, string> = {
+    disable: '',
+    require: '仅加密；配置 CA 证书后等同 verify-ca',
+    'verify-ca': '校验服务端证书由可信 CA 签发，不校验主机名',
+    'verify-full': '校验证书链与主机名，云数据库推荐使用',
+};
+
 type DriverStatusSnapshot = {
   type: string;
   name: string;
@@ -78,6 +88,7 @@ const ConnectionModal: React.FC<{
   const mysqlTopology = Form.useWatch('mysqlTopology', form) || 'single';
   const mongoTopology = Form.useWatch('mongoTopology', form) || 'single';
   const mongoSrv = Form.useWatch('mongoSrv', form) || false;
+  const sslMode = Form.useWatch('sslMode', form) || 'disable';
 
   const fetchDriverStatusMap = async (): Promise<Record<string, DriverStatusSnapshot>> => {
       const result: Record<string, DriverStatusSnapshot> = {};
@@ -623,6 +634,8 @@ const ConnectionModal: React.FC<{
                   database: config.database,
                   uri: config.uri || '',
                   includeDatabases: initialValues.includeDatabases,
+                  hideSystemDatabases: !!initialValues.databaseList?.hideSystem,
+                  databasePattern: initialValues.databaseList?.pattern || '',
                   includeRedisDatabases: initialValues.includeRedisDatabases,
                   useSSH: config.useSSH,
                   sshHost: config.ssh?.host,
@@ -630,6 +643,7 @@ const ConnectionModal: React.FC<{
                   sshUser: config.ssh?.user,
                   sshPassword: config.ssh?.password,
                   sshKeyPath: config.ssh?.keyPath,
+                  sshJumpHosts: Array.isArray(config.ssh?.jumpHosts) ? config.ssh.jumpHosts : [],
                   driver: config.driver,
                   dsn: config.dsn,
                   timeout: config.timeout || 30,
@@ -646,7 +660,21 @@ const ConnectionModal: React.FC<{
                   mongoAuthMechanism: config.mongoAuthMechanism || '',
                   savePassword: config.savePassword !== false,
                   mongoReplicaUser: config.mongoReplicaUser || '',
-                  mongoReplicaPassword: config.mongoReplicaPassword || ''
+                  mongoReplicaPassword: config.mongoReplicaPassword || '',
+                  sslMode: config.sslMode || 'disable',
+                  sslCA: config.sslCA || '',
+                  sslCert: config.sslCert || '',
+                  sslKey: config.sslKey || '',
+                  sslSkipVerify: !!config.sslSkipVerify,
+                  sslServerName: config.sslServerName || '',
+                  keepaliveInterval: config.keepaliveInterval || undefined,
+                  keepaliveSQL: config.keepaliveSQL || '',
+                  maxOpenConns: config.maxOpenConns || undefined,
+                  maxIdleConns: config.maxIdleConns || undefined,
+                  connMaxLifetime: config.connMaxLifetime || undefined,
+                  readOnly: !!config.readOnly,
+                  sqliteJournalMode: config.sqliteJournalMode || '',
+                  sqliteBusyTimeout: config.sqliteBusyTimeout || undefined,
               });
               setUseSSH(config.useSSH || false);
               setDbType(configType);
@@ -694,6 +722,9 @@ const ConnectionModal: React.FC<{
         name: values.name || (isFileDatabaseType(values.type) ? (values.type === 'duckdb' ? 'DuckDB DB' : 'SQLite DB') : (values.type === 'redis' ? `Redis ${displayHost}` : displayHost)),
         config: config,
         includeDatabases: values.includeDatabases,
+        databaseList: (values.hideSystemDatabases || String(values.databasePattern || '').trim())
+            ? { hideSystem: !!values.hideSystemDatabases, pattern: String(values.databasePattern || '').trim() }
+            : undefined,
         includeRedisDatabases: isRedisType ? values.includeRedisDatabases : undefined
       };
 
@@ -924,7 +955,16 @@ const ConnectionModal: React.FC<{
           port: Number(mergedValues.sshPort),
           user: mergedValues.sshUser,
           password: mergedValues.sshPassword || "",
-          keyPath: mergedValues.sshKeyPath || ""
+          keyPath: mergedValues.sshKeyPath || "",
+          jumpHosts: (Array.isArray(mergedValues.sshJumpHosts) ? mergedValues.sshJumpHosts : [])
+              .filter((hop: any) => String(hop?.host || '').trim() !== '')
+              .map((hop: any) => ({
+                  host: String(hop.host).trim(),
+                  port: Number(hop.port || 22),
+                  user: hop.user || "",
+                  password: hop.password || "",
+                  keyPath: hop.keyPath || ""
+              }))
       } : { host: "", port: 22, user: "", password: "", keyPath: "" };
 
       const keepPassword = !forPersist || savePassword;
@@ -954,6 +994,24 @@ const ConnectionModal: React.FC<{
           mongoAuthMechanism: mongoAuthMechanism,
           mongoReplicaUser: mongoReplicaUser,
           mongoReplicaPassword: keepPassword ? mongoReplicaPassword : "",
+          keepaliveInterval: Number(mergedValues.keepaliveInterval || 0) || undefined,
+          keepaliveSQL: String(mergedValues.keepaliveSQL || '').trim() || undefined,
+          maxOpenConns: Number(mergedValues.maxOpenConns || 0) || undefined,
+          maxIdleConns: Number(mergedValues.maxIdleConns || 0) || undefined,
+          connMaxLifetime: Number(mergedValues.connMaxLifetime || 0) || undefined,
+          readOnly: mergedValues.type !== 'redis' && !!mergedValues.readOnly,
+          ...(mergedValues.type === 'sqlite' ? {
+              sqliteJournalMode: String(mergedValues.sqliteJournalMode || '') || undefined,
+              sqliteBusyTimeout: Number(mergedValues.sqliteBusyTimeout || 0) || undefined,
+          } : {}),
+          ...(supportsSSL(mergedValues.type) && mergedValues.sslMode && mergedValues.sslMode !== 'disable' ? {
+              sslMode: mergedValues.sslMode,
+              sslCA: String(mergedValues.sslCA || '').trim(),
+              sslCert: String(mergedValues.sslCert || '').trim(),
+              sslKey: String(mergedValues.sslKey || '').trim(),
+              sslSkipVerify: !!mergedValues.sslSkipVerify,
+              sslServerName: String(mergedValues.sslServerName || '').trim(),
+          } : {}),
       };
   };
 
@@ -984,6 +1042,7 @@ const ConnectionModal: React.FC<{
               sshUser: '',
               sshPassword: '',
               sshKeyPath: '',
+              sshJumpHosts: [],
               mysqlTopology: 'single',
               mongoTopology: 'single',
               mongoSrv: false,
@@ -1057,6 +1116,9 @@ const ConnectionModal: React.FC<{
           { key: 'mongodb', name: 'MongoDB', icon: <CloudServerOutlined style={{ fontSize: 24, color: '#47A248' }} /> },
           { key: 'redis', name: 'Redis', icon: <CloudOutlined style={{ fontSize: 24, color: '#DC382D' }} /> },
       ]},
+      { label: '分析型数据库', items: [
+          { key: 'clickhouse', name: 'ClickHouse', icon: <DatabaseOutlined style={{ fontSize: 24, color: '#FFCC01' }} /> },
+      ]},
       { label: '时序数据库', items: [
           { key: 'tdengine', name: 'TDengine', icon: <DatabaseOutlined style={{ fontSize: 24, color: '#2F54EB' }} /> },
       ]},
@@ -1156,6 +1218,7 @@ const ConnectionModal: React.FC<{
             mysqlReplicaPassword: '',
             mongoReplicaUser: '',
             mongoReplicaPassword: '',
+            sslMode: 'disable',
         }}
         onValuesChange={(changed) => {
             if (testResult) {
@@ -1460,6 +1523,54 @@ const ConnectionModal: React.FC<{
         </Form.Item>
         )}
 
+        {!isRedis && (
+        <Form.Item
+            name="readOnly"
+            valuePropName="checked"
+            help="拒绝执行 INSERT/UPDATE/DELETE/DDL 等写操作，并在支持的数据库上以只读会话连接，适合连接生产库或只读副本"
+        >
+            <Checkbox>只读模式</Checkbox>
+        </Form.Item>
+        )}
+
+        {dbType === 'sqlite' && (
+        <div style={{ display: 'flex', gap: 16 }}>
+            <Form.Item
+                name="sqliteJournalMode"
+                label="日志模式"
+                help="WAL 模式下读写互不阻塞，适合多个标签页同时编辑；留空保持文件当前设置"
+                style={{ flex: 1 }}
+            >
+                <Select allowClear placeholder="保持当前">
+                    <Select.Option value="wal">WAL</Select.Option>
+                    <Select.Option value="delete">DELETE</Select.Option>
+                    <Select.Option value="truncate">TRUNCATE</Select.Option>
+                    <Select.Option value="persist">PERSIST</Select.Option>
+                    <Select.Option value="memory">MEMORY</Select.Option>
+                </Select>
+            </Form.Item>
+            <Form.Item
+                name="sqliteBusyTimeout"
+                label="锁等待 (毫秒)"
+                help="数据库被其他连接锁定时的等待时间，默认 5000"
+                style={{ width: 200 }}
+            >
+                <InputNumber style={{ width: '100%' }} min={0} max={600000} placeholder="5000" />
+            </Form.Item>
+        </div>
+        )}
+
+        {!isFileDb && !isRedis && (
+        <div style={{ display: 'flex', gap: 16, alignItems: 'flex-start' }}>
+            <Form.Item name="databasePattern" label="数据库过滤" help="逗号分隔的通配模式，! 开头表示排除，如 app_*, !app_test*" style={{ flex: 1 }}>
+                <Input placeholder="留空显示全部" allowClear />
+            </Form.Item>
+            <Form.Item name="hideSystemDatabases" valuePropName="checked" label=" ">
+                <Checkbox>隐藏系统库</Checkbox>
+            </Form.Item>
+        </div>
+        )}
+
         {!isFileDb && !isRedis && (
         <Form.Item name="includeDatabases" label="显示数据库 (留空显示全部)" help="连接测试成功后可选择">
             <Select mode="multiple" placeholder="选择显示的数据库" allowClear>
@@ -1468,6 +1579,43 @@ const ConnectionModal: React.FC<{
         </Form.Item>
         )}
 
+        {supportsSSL(dbType) && (
+        <>
+            <Divider style={{ margin: '12px 0' }} />
+            <Form.Item name="sslMode" label="SSL/TLS" help={sslModeHelp[sslMode]} style={{ marginBottom: 12 }}>
+                <Select options={[
+                    { value: 'disable', label: '不启用 (disable)' },
+                    { value: 'require', label: '加密 (require)' },
+                    { value: 'verify-ca', label: '校验 CA (verify-ca)' },
+                    { value: 'verify-full', label: '校验 CA 与主机名 (verify-full)' },
+                ]} />
+            </Form.Item>
+            {sslMode !== 'disable' && (
+                <div style={{ padding: '12px', background: '#f5f5f5', borderRadius: 6, marginBottom: 12 }}>
+                    <Form.Item name="sslCA" label="CA 证书路径 (PEM，留空使用系统根证书)">
+                        <Input placeholder="例如: /path/to/rds-ca.pem" />
+                    </Form.Item>
+                    {dbType !== 'sqlserver' && (
+                    <div style={{ display: 'flex', gap: 16 }}>
+                        <Form.Item name="sslCert" label="客户端证书路径" style={{ flex: 1 }}>
+                            <Input placeholder="双向认证时填写" />
+                        </Form.Item>
+                        <Form.Item name="sslKey" label="客户端私钥路径" style={{ flex: 1 }}>
+                            <Input placeholder="双向认证时填写" />
+                        </Form.Item>
+                    </div>
+                    )}
+                    <Form.Item name="sslServerName" label="证书主机名 (留空使用连接主机)">
+                        <Input placeholder="例如: mydb.xxxx.rds.amazonaws.com" />
+                    </Form.Item>
+                    <Form.Item name="sslSkipVerify" valuePropName="checked" style={{ marginBottom: 0 }}>
+                        <Checkbox>跳过证书校验 (仅用于自签名证书的测试环境)</Checkbox>
+                    </Form.Item>
+                </div>
+            )}
+        </>
+        )}
+
         {!isFileDb && (
         <>
             <Divider style={{ margin: '12px 0' }} />
@@ -1496,6 +1644,42 @@ const ConnectionModal: React.FC<{
                      <Form.Item name="sshKeyPath" label="私钥路径 (可选)" help="例如: /Users/name/.ssh/id_rsa">
                         <Input placeholder="绝对路径" />
                     </Form.Item>
+                    <Form.List name="sshJumpHosts">
+                        {(fields, { add, remove }) => (
+                            <>
+                                {fields.map((field, index) => (
+                                    <div key={field.key} style={{ borderTop: '1px dashed #d9d9d9', paddingTop: 8, marginTop: 8 }}>
+                                        <div style={{ display: 'flex', justifyContent: 'space-between', marginBottom: 4 }}>
+                                            <Typography.Text type="secondary">跳板机 {index + 1}（先于上方 SSH 主机连接）</Typography.Text>
+                                            <Button type="link" size="small" danger onClick={() => remove(field.name)}>移除</Button>
+                                        </div>
+                                        <div style={{ display: 'flex', gap: 16 }}>
+                                            <Form.Item name={[field.name, 'host']} label="主机" rules={[{ required: true, message: '请输入跳板机主机' }]} style={{ flex: 1 }}>
+                                                <Input placeholder="jump.example.com" />
+                                            </Form.Item>
+                                            <Form.Item name={[field.name, 'port']} label="端口" style={{ width: 100 }}>
+                                                <InputNumber style={{ width: '100%' }} />
+                                            </Form.Item>
+                                        </div>
+                                        <div style={{ display: 'flex', gap: 16 }}>
+                                            <Form.Item name={[field.name, 'user']} label="用户" style={{ flex: 1 }}>
+                                                <Input placeholder="root" />
+                                            </Form.Item>
+                                            <Form.Item name={[field.name, 'password']} label="密码" style={{ flex: 1 }}>
+                                                <Input.Password placeholder="密码" />
+                                            </Form.Item>
+                                        </div>
+                                        <Form.Item name={[field.name, 'keyPath']} label="私钥路径 (可选)">
+                                            <Input placeholder="绝对路径" />
+                                        </Form.Item>
+                                    </div>
+                                ))}
+                                <Button type="dashed" size="small" block onClick={() => add({ host: '', port: 22, user: '', password: '', keyPath: '' })}>
+                                    添加跳板机（多级跳转时按连接顺序依次添加）
+                                </Button>
+                            </>
+                        )}
+                    </Form.List>
                 </div>
             )}
 
@@ -1507,6 +1691,7 @@ const ConnectionModal: React.FC<{
                     key: 'advanced',
                     label: '高级连接',
                     children: (
+                        <>
                         <Form.Item 
                             name="timeout" 
                             label="连接超时 (秒)" 
@@ -1515,6 +1700,35 @@ const ConnectionModal: React.FC<{
                         >
                             <InputNumber style={{ width: '100%' }} min={1} max={300} placeholder="30" />
                         </Form.Item>
+                        {!isRedis && (
+                        <div style={{ display: 'flex', gap: 16 }}>
+                            <Form.Item
+                                name="keepaliveInterval"
+                                label="保活间隔 (秒)"
+                                help="空闲连接定期保活，避免被防火墙/NAT 断开；留空或 0 不启用，最小 10 秒"
+                                style={{ width: 200 }}
+                            >
+                                <InputNumber style={{ width: '100%' }} min={0} max={3600} placeholder="0" />
+                            </Form.Item>
+                            <Form.Item name="keepaliveSQL" label="保活语句" help="留空使用驱动自带的 Ping" style={{ flex: 1 }}>
+                                <Input placeholder="例如: SELECT 1" />
+                            </Form.Item>
+                        </div>
+                        )}
+                        {!isRedis && dbType !== 'mongodb' && (
+                        <div style={{ display: 'flex', gap: 16 }}>
+                            <Form.Item name="maxOpenConns" label="最大连接数" help="留空使用驱动默认" style={{ flex: 1 }}>
+                                <InputNumber style={{ width: '100%' }} min={0} max={1000} />
+                            </Form.Item>
+                            <Form.Item name="maxIdleConns" label="最大空闲连接数" help="留空使用驱动默认" style={{ flex: 1 }}>
+                                <InputNumber style={{ width: '100%' }} min={0} max={1000} />
+                            </Form.Item>
+                            <Form.Item name="connMaxLifetime" label="连接最长存活 (秒)" help="到期后重建，留空不限" style={{ flex: 1 }}>
+                                <InputNumber style={{ width: '100%' }} min={0} max={86400} />
+                            </Form.Item>
+                        </div>
+                        )}
+                        </>
                     )
                 }]}
             />
@@ -0,0 +1,253 @@
+import React, { useEffect, useState } from 'react';
+import { Modal, Form, Select, Button, Checkbox, Table, Tabs, Alert, Typography, message } from 'antd';
+import { useStore } from '../store';
+import { DBGetDatabasesWithOptions, DBGetTables, CompareTableData, DBQueryScript, CancelQuery } from '../../wailsjs/go/app/App';
+import { SavedConnection } from '../types';
+
+const { Text } = Typography;
+
+type RowDiff = { key: Record<string, any>; source?: Record<string, any>; target?: Record<string, any>; columns?: string[] };
+type TableDataCompareResult = {
+  keys: string[];
+  columns: string[];
+  sourceRows: number;
+  targetRows: number;
+  same: number;
+  inserted: number;
+  updated: number;
+  deleted: number;
+  inserts: RowDiff[];
+  updates: RowDiff[];
+  deletes: RowDiff[];
+  truncated?: boolean;
+  script?: string;
+  warnings?: string[];
+};
+
+const formatCell = (v: any) => v === null || v === undefined ? <Text type="secondary">NULL</Text> : String(v);
+
+const DataCompareModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open, onClose }) => {
+  const connections = useStore((state) => state.connections);
+  const [sourceConnId, setSourceConnId] = useState('');
+  const [targetConnId, setTargetConnId] = useState('');
+  const [sourceDb, setSourceDb] = useState('');
+  const [targetDb, setTargetDb] = useState('');
+  const [sourceDbs, setSourceDbs] = useState<string[]>([]);
+  const [targetDbs, setTargetDbs] = useState<string[]>([]);
+  const [tables, setTables] = useState<string[]>([]);
+  const [table, setTable] = useState('');
+  const [keys, setKeys] = useState<string[]>([]);
+  const [ignoreColumns, setIgnoreColumns] = useState<string[]>([]);
+  const [generateScript, setGenerateScript] = useState(true);
+  const [running, setRunning] = useState(false);
+  const [applying, setApplying] = useState(false);
+  const [result, setResult] = useState<TableDataCompareResult | null>(null);
+  const [summary, setSummary] = useState('');
+  const [queryId, setQueryId] = useState('');
+
+  const connConfig = (conn: SavedConnection | undefined, database: string) => conn ? ({
+      ...conn.config,
+      port: Number((conn.config as any).port),
+      password: conn.config.password || "",
+      useSSH: conn.config.useSSH || false,
+      ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" },
+      database,
+  }) : null;
+
+  useEffect(() => {
+      if (!open) return;
+      setSourceConnId('');
+      setTargetConnId('');
+      setSourceDb('');
+      setTargetDb('');
+      setTable('');
+      setKeys([]);
+      setIgnoreColumns([]);
+      setResult(null);
+      setSummary('');
+  }, [open]);
+
+  const loadDbs = async (connId: string, setDbs: (dbs: string[]) => void) => {
+      setDbs([]);
+      const conn = connections.find(c => c.id === connId);
+      if (!conn) return;
+      const res = await DBGetDatabasesWithOptions(connConfig(conn, conn.config.database || '') as any, (conn.databaseList || {}) as any);
+      if (res.success) setDbs((res.data as any[]).map((row: any) => row.Database || row.database));
+      else message.error(res.message);
+  };
+
+  useEffect(() => { if (sourceConnId) loadDbs(sourceConnId, setSourceDbs); }, [sourceConnId]);
+  useEffect(() => { if (targetConnId) loadDbs(targetConnId, setTargetDbs); }, [targetConnId]);
+
+  useEffect(() => {
+      setTables([]);
+      setTable('');
+      const conn = connections.find(c => c.id === sourceConnId);
+      if (!conn || !sourceDb) return;
+      DBGetTables(connConfig(conn, sourceDb) as any, sourceDb).then((res: any) => {
+          if (res.success) setTables((res.data as any[]).map((row: any) => String(row.Table || row.table || Object.values(row)[0])));
+          else message.error(res.message);
+      });
+  }, [sourceConnId, sourceDb]);
+
+  const handleCompare = async () => {
+      const source = connections.find(c => c.id === sourceConnId);
+      const target = connections.find(c => c.id === targetConnId);
+      if (!source || !target || !sourceDb || !targetDb || !table) {
+          message.error('请选择源与目标连接、数据库及要比较的表');
+          return;
+      }
+      const id = `data-compare-${Date.now()}`;
+      setQueryId(id);
+      setRunning(true);
+      setResult(null);
+      setSummary('');
+      try {
+          const res = await CompareTableData(connConfig(source, sourceDb) as any, connConfig(target, targetDb) as any, table, keys, {
+              sourceDb, targetDb, ignoreColumns, generateScript, queryId: id,
+          } as any);
+          if (!res.success) {
+              message.error(res.message);
+              return;
+          }
+          setResult(res.data as TableDataCompareResult);
+          setSummary(res.message);
+      } catch (e: any) {
+          message.error('数据比较失败：' + (e?.message || String(e)));
+      } finally {
+          setRunning(false);
+      }
+  };
+
+  const handleApply = () => {
+      const target = connections.find(c => c.id === targetConnId);
+      if (!target || !result?.script) return;
+      Modal.confirm({
+          title: '在目标表执行同步脚本',
+          content: `将在 ${target.name} / ${targetDb} 上执行同步脚本，遇到错误即停止。执行后不可撤销。`,
+          okText: '执行',
+          cancelText: '取消',
+          onOk: async () => {
+              setApplying(true);
+              try {
+                  const res = await DBQueryScript(connConfig(target, targetDb) as any, targetDb, result.script || '', { stopOnError: true } as any);
+                  if (res.success) {
+                      message.success(res.message || '同步完成');
+                      handleCompare();
+                  } else {
+                      message.error(res.message);
+                  }
+              } finally {
+                  setApplying(false);
+              }
+          },
+      });
+  };
+
+  const diffColumns = (side: 'source' | 'target') => (result?.columns || []).map(col => ({
+      title: col,
+      key: col,
+      ellipsis: true,
+      render: (_: any, r: RowDiff) => formatCell(((side === 'source' ? r.source : r.target) || {})[col]),
+  }));
+
+  const renderUpdates = () => (
+      <Table
+          size="small"
+          rowKey={(r) => JSON.stringify(r.key)}
+          dataSource={result?.updates || []}
+          pagination={{ pageSize: 10, size: 'small' }}
+          scroll={{ x: 'max-content' }}
+          columns={[
+              { title: '键', key: '_key', render: (_: any, r: RowDiff) => JSON.stringify(r.key) },
+              { title: '不同的列', key: '_cols', render: (_: any, r: RowDiff) => (r.columns || []).join(', ') },
+              ...(result?.columns || []).map(col => ({
+                  title: col,
+                  key: col,
+                  render: (_: any, r: RowDiff) => (r.columns || []).includes(col)
+                      ? <span><span style={{ color: '#fa541c' }}>{formatCell(r.source?.[col])}</span> ← {formatCell(r.target?.[col])}</span>
+                      : formatCell(r.source?.[col]),
+              })),
+          ]}
+      />
+  );
+
+  const connectionOptions = connections.map(c => ({ value: c.id, label: `${c.name} (${c.config.type})` }));
+
+  return (
+      <Modal
+          title="表数据比较"
+          open={open}
+          width={1000}
+          onCancel={() => { if (!running && !applying) onClose(); }}
+          maskClosable={false}
+          footer={[
+              running
+                  ? <Button key="cancel" danger onClick={() => CancelQuery(queryId)}>取消比较</Button>
+                  : <Button key="close" onClick={onClose} disabled={applying}>关闭</Button>,
+              <Button key="compare" loading={running} onClick={handleCompare} disabled={applying}>比较</Button>,
+              <Button key="apply" type="primary" danger loading={applying} disabled={!result?.script || running} onClick={handleApply}>在目标表执行同步脚本</Button>,
+          ]}
+      >
+          <Form layout="vertical" disabled={running || applying}>
+              <div style={{ display: 'flex', gap: 16 }}>
+                  <Form.Item label="源连接" style={{ flex: 1 }}>
+                      <Select value={sourceConnId || undefined} onChange={(v) => { setSourceConnId(v); setSourceDb(''); }} options={connectionOptions} />
+                  </Form.Item>
+                  <Form.Item label="源数据库" style={{ flex: 1 }}>
+                      <Select value={sourceDb || undefined} onChange={setSourceDb} options={sourceDbs.map(d => ({ value: d, label: d }))} showSearch />
+                  </Form.Item>
+                  <Form.Item label="目标连接" style={{ flex: 1 }}>
+                      <Select value={targetConnId || undefined} onChange={(v) => { setTargetConnId(v); setTargetDb(''); }} options={connectionOptions} />
+                  </Form.Item>
+                  <Form.Item label="目标数据库" style={{ flex: 1 }}>
+                      <Select value={targetDb || undefined} onChange={setTargetDb} options={targetDbs.map(d => ({ value: d, label: d }))} showSearch />
+                  </Form.Item>
+              </div>
+              <div style={{ display: 'flex', gap: 16, alignItems: 'flex-end' }}>
+                  <Form.Item label="表" style={{ flex: 1 }}>
+                      <Select value={table || undefined} onChange={setTable} options={tables.map(t => ({ value: t, label: t }))} showSearch />
+                  </Form.Item>
+                  <Form.Item label="键列（留空使用主键）" style={{ flex: 1 }}>
+                      <Select mode="tags" value={keys} onChange={setKeys} open={false} />
+                  </Form.Item>
+                  <Form.Item label="忽略的列" style={{ flex: 1 }}>
+                      <Select mode="tags" value={ignoreColumns} onChange={setIgnoreColumns} open={false} placeholder="如 updated_at" />
+                  </Form.Item>
+                  <Form.Item>
+                      <Checkbox checked={generateScript} onChange={(e) => setGenerateScript(e.target.checked)}>生成同步脚本</Checkbox>
+                  </Form.Item>
+              </div>
+          </Form>
+
+          {summary && <Alert type={result && (result.inserted + result.updated + result.deleted) > 0 ? 'warning' : 'success'} message={summary} style={{ marginBottom: 12 }} />}
+          {result?.truncated && <Alert type="info" showIcon message="差异行较多，明细只展示部分行" style={{ marginBottom: 12 }} />}
+          {(result?.warnings || []).map((w, i) => <div key={i}><Text type="warning">{w}</Text></div>)}
+          {result && (
+              <Tabs
+                  items={[
+                      { key: 'updates', label: `不同 (${result.updated})`, children: renderUpdates() },
+                      {
+                          key: 'inserts', label: `目标缺少 (${result.inserted})`,
+                          children: <Table size="small" rowKey={(r) => JSON.stringify(r.key)} dataSource={result.inserts} columns={diffColumns('source')} pagination={{ pageSize: 10, size: 'small' }} scroll={{ x: 'max-content' }} />,
+                      },
+                      {
+                          key: 'deletes', label: `目标多出 (${result.deleted})`,
+                          children: <Table size="small" rowKey={(r) => JSON.stringify(r.key)} dataSource={result.deletes} columns={diffColumns('target')} pagination={{ pageSize: 10, size: 'small' }} scroll={{ x: 'max-content' }} />,
+                      },
+                      ...(result.script ? [{
+                          key: 'script', label: '同步脚本',
+                          children: (
+                              <pre style={{ maxHeight: 300, overflow: 'auto', background: '#f5f5f5', padding: 10, borderRadius: 4, border: '1px solid #eee', whiteSpace: 'pre-wrap' }}>
+                                  {result.script}
+                              </pre>
+                          ),
+                      }] : []),
+                  ]}
+              />
+          )}
+      </Modal>
+  );
+};
+
+export default DataCompareModal;
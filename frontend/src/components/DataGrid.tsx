@@ -4,7 +4,7 @@ import { Table, message, Input, Button, Dropdown, MenuProps, Form, Pagination, S
 import type { SortOrder } from 'antd/es/table/interface';
 import { ReloadOutlined, ImportOutlined, ExportOutlined, DownOutlined, PlusOutlined, DeleteOutlined, SaveOutlined, UndoOutlined, FilterOutlined, CloseOutlined, ConsoleSqlOutlined, FileTextOutlined, CopyOutlined, ClearOutlined, EditOutlined, VerticalAlignBottomOutlined } from '@ant-design/icons';
 import Editor from '@monaco-editor/react';
-import { ImportData, ExportTable, ExportData, ExportQuery, ApplyChanges, DBGetColumns } from '../../wailsjs/go/app/App';
+import { ImportData, ExportTableWithOptions, ExportDataWithLocale, ExportQueryWithLocale, ApplyChanges, DBGetColumns } from '../../wailsjs/go/app/App';
 import ImportPreviewModal from './ImportPreviewModal';
 import { useStore } from '../store';
 import type { ColumnDefinition } from '../types';
@@ -507,6 +507,10 @@ interface DataGridProps {
     showFilter?: boolean;
     onToggleFilter?: () => void;
     onApplyFilter?: (conditions: GridFilterCondition[]) => void;
+    // 表格偏好：恢复上次保存的筛选条件与列宽，列宽调整后回调
+    filterConditionsExternal?: FilterCondition[];
+    columnWidthsExternal?: Record<string, number>;
+    onColumnWidthChange?: (column: string, width: number) => void;
 }
 
 type GridFilterCondition = FilterCondition & {
@@ -526,7 +530,8 @@ type ColumnMeta = {
 
 const DataGrid: React.FC<DataGridProps> = ({ 
     data, columnNames, loading, tableName, dbName, connectionId, pkColumns = [], readOnly = false,
-    onReload, onSort, onPageChange, pagination, sortInfoExternal, showFilter, onToggleFilter, onApplyFilter
+    onReload, onSort, onPageChange, pagination, sortInfoExternal, showFilter, onToggleFilter, onApplyFilter,
+    filterConditionsExternal, columnWidthsExternal, onColumnWidthChange
 }) => {
   const connections = useStore(state => state.connections);
   const addSqlLog = useStore(state => state.addSqlLog);
@@ -534,6 +539,7 @@ const DataGrid: React.FC<DataGridProps> = ({
   const appearance = useStore(state => state.appearance);
   const queryOptions = useStore(state => state.queryOptions);
   const setQueryOptions = useStore(state => state.setQueryOptions);
+  const exportLocale = useStore(state => state.exportLocale);
   const isMacLike = useMemo(() => isMacLikePlatform(), []);
   const darkMode = theme === 'dark';
   const opacity = normalizeOpacityForPlatform(appearance.opacity);
@@ -669,13 +675,19 @@ const DataGrid: React.FC<DataGridProps> = ({
       const hide = message.loading(`正在导出 ${rows.length} 条数据...`, 0);
       const cleanRows = rows.map(({ [GONAVI_ROW_KEY]: _rowKey, ...rest }) => rest);
       // Pass tableName (or 'export') as default filename
-      const res = await ExportData(cleanRows, columnNames, tableName || 'export', format);
+      const res = await ExportDataWithLocale(cleanRows, columnNames, tableName || 'export', format, exportLocale as any);
       hide();
       if (res.success) { message.success("导出成功"); } else if (res.message !== "Cancelled") { message.error("导出失败: " + res.message); }
   };
   
   const [sortInfo, setSortInfo] = useState<{ columnKey: string, order: string } | null>(null);
   const [columnWidths, setColumnWidths] = useState<Record<string, number>>({});
+  const onColumnWidthChangeRef = useRef(onColumnWidthChange);
+  onColumnWidthChangeRef.current = onColumnWidthChange;
+
+  useEffect(() => {
+      if (columnWidthsExternal) setColumnWidths(columnWidthsExternal);
+  }, [columnWidthsExternal]);
   const [columnMetaMap, setColumnMetaMap] = useState<Record<string, ColumnMeta>>({});
   const columnMetaCacheRef = useRef<Record<string, Record<string, ColumnMeta>>>({});
   const columnMetaSeqRef = useRef(0);
@@ -912,6 +924,19 @@ const DataGrid: React.FC<DataGridProps> = ({
   const [filterConditions, setFilterConditions] = useState<GridFilterCondition[]>([]);
   const [nextFilterId, setNextFilterId] = useState(1);
 
+  useEffect(() => {
+      if (!filterConditionsExternal) return;
+      setFilterConditions(filterConditionsExternal.map((c, index) => ({
+          id: index + 1,
+          enabled: c.enabled !== false,
+          column: c.column || '',
+          op: c.op || '=',
+          value: c.value || '',
+          value2: c.value2 || '',
+      })));
+      setNextFilterId(filterConditionsExternal.length + 1);
+  }, [filterConditionsExternal]);
+
   const selectedRowKeysRef = useRef(selectedRowKeys);
   const displayDataRef = useRef<any[]>([]);
 
@@ -1373,6 +1398,7 @@ const DataGrid: React.FC<DataGridProps> = ({
 
       // Commit State
       setColumnWidths(prev => ({ ...prev, [key]: newWidth }));
+      onColumnWidthChangeRef.current?.(key, newWidth);
 
       // Cleanup
       if (resizeRafRef.current !== null) {
@@ -1968,14 +1994,14 @@ const DataGrid: React.FC<DataGridProps> = ({
       const config = buildConnConfig();
       if (!config) return;
       const hide = message.loading(`正在导出...`, 0);
-      const res = await ExportQuery(config as any, dbName || '', sql, defaultName || 'export', format);
+      const res = await ExportQueryWithLocale(config as any, dbName || '', sql, defaultName || 'export', format, exportLocale as any);
       hide();
       if (res.success) {
           message.success("导出成功");
       } else if (res.message !== "Cancelled") {
           message.error("导出失败: " + res.message);
       }
-  }, [buildConnConfig, dbName]);
+  }, [buildConnConfig, dbName, exportLocale]);
 
   const buildPkWhereSql = useCallback((rows: any[], dbType: string) => {
       if (!tableName || pkColumns.length === 0) return '';
@@ -2065,7 +2091,7 @@ const DataGrid: React.FC<DataGridProps> = ({
           const config = buildConnConfig();
           if (!config) return;
           const hide = message.loading(`正在导出全部数据...`, 0);
-          const res = await ExportTable(config as any, dbName || '', tableName, format);
+          const res = await ExportTableWithOptions(config as any, dbName || '', tableName, format, { binaryAsFiles: false, locale: exportLocale } as any);
           hide();
           if (res.success) { message.success("导出成功"); } else if (res.message !== "Cancelled") { message.error("导出失败: " + res.message); }
       };
@@ -2191,11 +2217,37 @@ const DataGrid: React.FC<DataGridProps> = ({
       if (onApplyFilter) onApplyFilter(filterConditions);
   };
 
+  const handleViewSpatialValue = async (value: any) => {
+      if (value === null || value === undefined || value === '') {
+          message.info('当前单元格为空');
+          return;
+      }
+      const res = await (window as any).go.app.App.DecodeSpatialValue(String(value));
+      if (!res?.success) {
+          message.info(res?.message || '当前值不是可识别的空间数据');
+          return;
+      }
+      const { wkt, geojson } = res.data || {};
+      Modal.info({
+          title: '空间值',
+          width: 640,
+          content: (
+              <div style={{ display: 'flex', flexDirection: 'column', gap: 8 }}>
+                  <div>WKT</div>
+                  <Input.TextArea value={wkt} readOnly autoSize={{ minRows: 2, maxRows: 8 }} />
+                  <div>GeoJSON</div>
+                  <Input.TextArea value={geojson} readOnly autoSize={{ minRows: 2, maxRows: 8 }} />
+              </div>
+          ),
+      });
+  };
+
   const exportMenu: MenuProps['items'] = [
       { key: 'csv', label: 'CSV', onClick: () => handleExport('csv') },
       { key: 'xlsx', label: 'Excel (XLSX)', onClick: () => handleExport('xlsx') },
       { key: 'json', label: 'JSON', onClick: () => handleExport('json') },
       { key: 'md', label: 'Markdown', onClick: () => handleExport('md') },
+      { key: 'geojson', label: 'GeoJSON', onClick: () => handleExport('geojson') },
   ];
 
   const columnInfoSettingContent = (
@@ -2820,6 +2872,21 @@ const DataGrid: React.FC<DataGridProps> = ({
                 >
                     复制为 Markdown
                 </div>
+                <div
+                    style={{
+                        padding: '8px 12px',
+                        cursor: 'pointer',
+                        transition: 'background 0.2s',
+                    }}
+                    onMouseEnter={(e) => e.currentTarget.style.background = darkMode ? '#303030' : '#f5f5f5'}
+                    onMouseLeave={(e) => e.currentTarget.style.background = 'transparent'}
+                    onClick={() => {
+                        if (cellContextMenu.record) handleViewSpatialValue(cellContextMenu.record[cellContextMenu.dataIndex]);
+                        setCellContextMenu(prev => ({ ...prev, visible: false }));
+                    }}
+                >
+                    查看空间值 (WKT/GeoJSON)
+                </div>
                 <div style={{ height: 1, background: darkMode ? '#303030' : '#f0f0f0', margin: '4px 0' }} />
                 <div
                     style={{
@@ -53,6 +53,7 @@ const DataSyncModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open,
   const [syncContent, setSyncContent] = useState<'data' | 'schema' | 'both'>('data');
   const [syncMode, setSyncMode] = useState<string>('insert_update');
   const [autoAddColumns, setAutoAddColumns] = useState<boolean>(true);
+  const [compareRule, setCompareRule] = useState<{ ignoreCase: boolean; ignoreTrailingSpace: boolean }>({ ignoreCase: false, ignoreTrailingSpace: false });
   const [showSameTables, setShowSameTables] = useState<boolean>(false);
   const [analyzing, setAnalyzing] = useState<boolean>(false);
   const [diffTables, setDiffTables] = useState<TableDiffSummary[]>([]);
@@ -131,6 +132,7 @@ const DataSyncModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open,
         setSyncContent('data');
         setSyncMode('insert_update');
         setAutoAddColumns(true);
+        setCompareRule({ ignoreCase: false, ignoreTrailingSpace: false });
         setShowSameTables(false);
         setAnalyzing(false);
         setDiffTables([]);
@@ -236,6 +238,7 @@ const DataSyncModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open,
           content: syncContent,
           mode: "insert_update",
           autoAddColumns,
+          compareRules: { default: compareRule },
           jobId,
       };
 
@@ -286,6 +289,7 @@ const DataSyncModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open,
           content: "data",
           mode: "insert_update",
           autoAddColumns,
+          compareRules: { default: compareRule },
       };
 
       try {
@@ -362,6 +366,7 @@ const DataSyncModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open,
           content: syncContent,
           mode: syncMode,
           autoAddColumns,
+          compareRules: { default: compareRule },
           tableOptions,
           jobId,
       };
@@ -482,6 +487,16 @@ const DataSyncModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open,
                               自动补齐目标表缺失字段（仅 MySQL 目标）
                           </Checkbox>
                       </Form.Item>
+                      {syncContent !== 'schema' && syncMode === 'insert_update' && (
+                          <Form.Item label="数据对比规则" tooltip="源/目标排序规则不一致时（如 MySQL *_ci 与 PostgreSQL），可放宽比较避免误报差异">
+                              <Checkbox checked={compareRule.ignoreCase} onChange={(e) => setCompareRule({ ...compareRule, ignoreCase: e.target.checked })}>
+                                  忽略大小写
+                              </Checkbox>
+                              <Checkbox checked={compareRule.ignoreTrailingSpace} onChange={(e) => setCompareRule({ ...compareRule, ignoreTrailingSpace: e.target.checked })}>
+                                  忽略尾部空格
+                              </Checkbox>
+                          </Form.Item>
+                      )}
                       {syncContent !== 'schema' && syncMode === 'full_overwrite' && (
                           <Alert
                               type="warning"
@@ -2,7 +2,8 @@ import React, { useEffect, useState, useCallback, useRef } from 'react';
 import { message } from 'antd';
 import { TabData, ColumnDefinition } from '../types';
 import { useStore } from '../store';
-import { DBQuery, DBGetColumns } from '../../wailsjs/go/app/App';
+import { DBQuery, DBGetColumns, GetGridPreference, SaveGridPreference } from '../../wailsjs/go/app/App';
+import { queryWithCompression } from '../utils/resultTransfer';
 import DataGrid, { GONAVI_ROW_KEY } from './DataGrid';
 import { buildOrderBySQL, buildWhereSQL, quoteQualifiedIdent, withSortBufferTuningSQL, type FilterCondition } from '../utils/sql';
 
@@ -30,8 +31,14 @@ const DataViewer: React.FC<{ tab: TabData }> = ({ tab }) => {
   
   const [showFilter, setShowFilter] = useState(false);
   const [filterConditions, setFilterConditions] = useState<FilterCondition[]>([]);
+  // 表格偏好（排序、筛选、列宽）保存在后端连接存储中，打开表时先恢复再查询
+  const [prefReady, setPrefReady] = useState(false);
+  const [savedFilters, setSavedFilters] = useState<FilterCondition[] | undefined>(undefined);
+  const [savedColumnWidths, setSavedColumnWidths] = useState<Record<string, number> | undefined>(undefined);
+  const prefRef = useRef<any>(null);
+  const prefSaveTimerRef = useRef<number | null>(null);
   const currentConnType = (connections.find(c => c.id === tab.connectionId)?.config?.type || '').toLowerCase();
-  const forceReadOnly = currentConnType === 'tdengine';
+  const forceReadOnly = currentConnType === 'tdengine' || currentConnType === 'clickhouse';
 
   useEffect(() => {
     setPkColumns([]);
@@ -40,6 +47,45 @@ const DataViewer: React.FC<{ tab: TabData }> = ({ tab }) => {
     setPagination(prev => ({ ...prev, current: 1, total: 0, totalKnown: false }));
   }, [tab.connectionId, tab.dbName, tab.tableName]);
 
+  useEffect(() => {
+    let cancelled = false;
+    setPrefReady(false);
+    prefRef.current = { connectionId: tab.connectionId, database: tab.dbName || '', table: tab.tableName || '' };
+    GetGridPreference(tab.connectionId, tab.dbName || '', tab.tableName || '')
+      .then((res: any) => {
+        if (cancelled || !res?.success || !res.data) return;
+        const pref = res.data;
+        prefRef.current = { ...prefRef.current, ...pref };
+        const sort = Array.isArray(pref.sort) ? pref.sort[0] : null;
+        if (sort?.column && (sort.order === 'ascend' || sort.order === 'descend')) {
+          setSortInfo({ columnKey: sort.column, order: sort.order });
+        }
+        if (Array.isArray(pref.filters) && pref.filters.length > 0) {
+          setSavedFilters(pref.filters);
+          setFilterConditions(pref.filters);
+          setShowFilter(true);
+        }
+        if (pref.columnWidths) setSavedColumnWidths(pref.columnWidths);
+      })
+      .catch(() => {})
+      .finally(() => {
+        if (!cancelled) setPrefReady(true);
+      });
+    return () => {
+      cancelled = true;
+    };
+  }, [tab.connectionId, tab.dbName, tab.tableName]);
+
+  const saveGridPreference = useCallback((patch: Record<string, any>) => {
+    if (!prefRef.current || !tab.tableName) return;
+    prefRef.current = { ...prefRef.current, ...patch };
+    if (prefSaveTimerRef.current !== null) window.clearTimeout(prefSaveTimerRef.current);
+    prefSaveTimerRef.current = window.setTimeout(() => {
+      prefSaveTimerRef.current = null;
+      SaveGridPreference(prefRef.current).catch(() => {});
+    }, 500);
+  }, [tab.tableName]);
+
   const fetchData = useCallback(async (page = pagination.current, size = pagination.pageSize) => {
     const seq = ++fetchSeqRef.current;
     setLoading(true);
@@ -81,7 +127,7 @@ const DataViewer: React.FC<{ tab: TabData }> = ({ tab }) => {
     try {
         const executeDataQuery = async (querySql: string, attemptLabel: string) => {
             const startTime = Date.now();
-            const result = await DBQuery(config as any, dbName, querySql);
+            const result = await queryWithCompression(config as any, dbName, querySql);
             addSqlLog({
                 id: `log-${Date.now()}-data`,
                 timestamp: Date.now(),
@@ -240,17 +286,28 @@ const DataViewer: React.FC<{ tab: TabData }> = ({ tab }) => {
     const normalizedField = String(field || '').trim();
     if (!normalizedField || !normalizedOrder) {
       setSortInfo(null);
+      saveGridPreference({ sort: [] });
       return;
     }
     setSortInfo({ columnKey: normalizedField, order: normalizedOrder });
-  }, []);
+    saveGridPreference({ sort: [{ column: normalizedField, order: normalizedOrder }] });
+  }, [saveGridPreference]);
   const handlePageChange = useCallback((page: number, size: number) => fetchData(page, size), [fetchData]);
   const handleToggleFilter = useCallback(() => setShowFilter(prev => !prev), []);
-  const handleApplyFilter = useCallback((conditions: FilterCondition[]) => setFilterConditions(conditions), []);
+  const handleApplyFilter = useCallback((conditions: FilterCondition[]) => {
+    setFilterConditions(conditions);
+    saveGridPreference({
+      filters: conditions.map(c => ({ enabled: c.enabled, column: c.column, op: c.op, value: c.value, value2: c.value2 })),
+    });
+  }, [saveGridPreference]);
+  const handleColumnWidthChange = useCallback((column: string, width: number) => {
+    saveGridPreference({ columnWidths: { ...(prefRef.current?.columnWidths || {}), [column]: width } });
+  }, [saveGridPreference]);
 
   useEffect(() => {
+    if (!prefReady) return;
     fetchData(1, pagination.pageSize); 
-  }, [tab, sortInfo, filterConditions]); // Initial load and re-load on sort/filter
+  }, [tab, sortInfo, filterConditions, prefReady]); // Initial load and re-load on sort/filter
 
   return (
     <div style={{ flex: '1 1 auto', minHeight: 0, minWidth: 0, height: '100%', width: '100%', overflow: 'hidden', display: 'flex', flexDirection: 'column' }}>
@@ -271,6 +328,9 @@ const DataViewer: React.FC<{ tab: TabData }> = ({ tab }) => {
           onApplyFilter={handleApplyFilter}
           readOnly={forceReadOnly}
           sortInfoExternal={sortInfo}
+          filterConditionsExternal={savedFilters}
+          columnWidthsExternal={savedColumnWidths}
+          onColumnWidthChange={handleColumnWidthChange}
       />
     </div>
   );
@@ -1,11 +1,18 @@
 import React, { useCallback, useEffect, useMemo, useState } from 'react';
-import { Button, Modal, Progress, Space, Table, Tag, Typography, message } from 'antd';
-import { DeleteOutlined, DownloadOutlined, ReloadOutlined } from '@ant-design/icons';
+import { Button, Modal, Progress, Space, Switch, Table, Tag, Typography, message } from 'antd';
+import { AppstoreAddOutlined, ClearOutlined, DeleteOutlined, DownloadOutlined, ReloadOutlined } from '@ant-design/icons';
 import { EventsOn } from '../../wailsjs/runtime/runtime';
 import {
+  CleanOrphanedDriverFiles,
   DownloadDriverPackage,
+  GetDriverNetworkSettings,
   GetDriverStatusList,
+  InstallDriverPackages,
+  ListOrphanedDriverFiles,
+  RefreshDriverStatus,
+  ReloadDriverStatusList,
   RemoveDriverPackage,
+  SaveDriverNetworkSettings,
 } from '../../wailsjs/go/app/App';
 
 const { Text } = Typography;
@@ -29,23 +36,53 @@ type DriverProgressEvent = {
   percent?: number;
 };
 
+type BulkInstallResult = {
+  driverType: string;
+  driverName: string;
+  status: 'installed' | 'skipped' | 'failed';
+  message?: string;
+};
+
+const bulkStatusTag: Record<string, { color: string; text: string }> = {
+  installed: { color: 'success', text: '已安装' },
+  skipped: { color: 'default', text: '跳过' },
+  failed: { color: 'error', text: '失败' },
+};
+
 type ProgressState = {
   status: 'start' | 'downloading' | 'done' | 'error';
   message: string;
   percent: number;
 };
 
+const toDriverStatusRow = (item: any): DriverStatusRow => ({
+  type: String(item.type || '').trim(),
+  name: String(item.name || item.type || '').trim(),
+  builtIn: !!item.builtIn,
+  packageSizeText: String(item.packageSizeText || '').trim() || undefined,
+  runtimeAvailable: !!item.runtimeAvailable,
+  packageInstalled: !!item.packageInstalled,
+  connectable: !!item.connectable,
+  defaultDownloadUrl: String(item.defaultDownloadUrl || '').trim() || undefined,
+  message: String(item.message || '').trim() || undefined,
+});
+
 const DriverManagerModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open, onClose }) => {
   const [loading, setLoading] = useState(false);
   const [downloadDir, setDownloadDir] = useState('');
   const [rows, setRows] = useState<DriverStatusRow[]>([]);
   const [actionDriver, setActionDriver] = useState('');
   const [progressMap, setProgressMap] = useState<Record<string, ProgressState>>({});
+  const [networkSettings, setNetworkSettings] = useState<Record<string, any>>({});
+  const [savingNetwork, setSavingNetwork] = useState(false);
+  const [cleaning, setCleaning] = useState(false);
+  const [bulkProgress, setBulkProgress] = useState<{ percent: number; message: string } | null>(null);
 
-  const refreshStatus = useCallback(async (toastOnError = true) => {
+  // reload 为 true 时丢弃后端缓存重新探测全部驱动
+  const refreshStatus = useCallback(async (toastOnError = true, reload = false) => {
     setLoading(true);
     try {
-      const res = await GetDriverStatusList(downloadDir, '');
+      const res = reload ? await ReloadDriverStatusList(downloadDir, '') : await GetDriverStatusList(downloadDir, '');
       if (!res?.success) {
         if (toastOnError) {
           message.error(res?.message || '拉取驱动状态失败');
@@ -61,18 +98,7 @@ const DriverManagerModal: React.FC<{ open: boolean; onClose: () => void }> = ({
         setDownloadDir(resolvedDir);
       }
 
-      const nextRows: DriverStatusRow[] = drivers.map((item: any) => ({
-        type: String(item.type || '').trim(),
-        name: String(item.name || item.type || '').trim(),
-        builtIn: !!item.builtIn,
-        packageSizeText: String(item.packageSizeText || '').trim() || undefined,
-        runtimeAvailable: !!item.runtimeAvailable,
-        packageInstalled: !!item.packageInstalled,
-        connectable: !!item.connectable,
-        defaultDownloadUrl: String(item.defaultDownloadUrl || '').trim() || undefined,
-        message: String(item.message || '').trim() || undefined,
-      }));
-      setRows(nextRows);
+      setRows(drivers.map(toDriverStatusRow));
     } catch (err: any) {
       if (toastOnError) {
         message.error(`拉取驱动状态失败：${err?.message || String(err)}`);
@@ -82,6 +108,17 @@ const DriverManagerModal: React.FC<{ open: boolean; onClose: () => void }> = ({
     }
   }, [downloadDir]);
 
+  // 安装、移除后只刷新对应驱动的一行
+  const refreshDriverRow = useCallback(async (driverType: string) => {
+    const res = await RefreshDriverStatus(driverType, downloadDir, '');
+    if (!res?.success || !res.data) {
+      refreshStatus(false);
+      return;
+    }
+    const nextRow = toDriverStatusRow(res.data);
+    setRows((prev) => prev.map((row) => (row.type === nextRow.type ? nextRow : row)));
+  }, [downloadDir, refreshStatus]);
+
   useEffect(() => {
     if (!open) {
       return;
@@ -89,6 +126,32 @@ const DriverManagerModal: React.FC<{ open: boolean; onClose: () => void }> = ({
     refreshStatus(false);
   }, [open, refreshStatus]);
 
+  useEffect(() => {
+    if (!open) {
+      return;
+    }
+    GetDriverNetworkSettings().then((res) => {
+      if (res?.success && res.data) {
+        setNetworkSettings(res.data as Record<string, any>);
+      }
+    });
+  }, [open]);
+
+  const toggleOffline = useCallback(async (offline: boolean) => {
+    setSavingNetwork(true);
+    try {
+      const res = await SaveDriverNetworkSettings({ ...networkSettings, offline } as any);
+      if (!res?.success) {
+        message.error(res?.message || '保存离线模式失败');
+        return;
+      }
+      setNetworkSettings((res.data || { ...networkSettings, offline }) as Record<string, any>);
+      refreshStatus(false);
+    } finally {
+      setSavingNetwork(false);
+    }
+  }, [networkSettings, refreshStatus]);
+
   useEffect(() => {
     if (!open) {
       return;
@@ -135,11 +198,11 @@ const DriverManagerModal: React.FC<{ open: boolean; onClose: () => void }> = ({
         return;
       }
       message.success(`${row.name} 已安装启用`);
-      refreshStatus(false);
+      refreshDriverRow(row.type);
     } finally {
       setActionDriver('');
     }
-  }, [downloadDir, refreshStatus]);
+  }, [downloadDir, refreshDriverRow]);
 
   const removeDriver = useCallback(async (row: DriverStatusRow) => {
     setActionDriver(row.type);
@@ -155,12 +218,101 @@ const DriverManagerModal: React.FC<{ open: boolean; onClose: () => void }> = ({
         delete next[row.type];
         return next;
       });
-      refreshStatus(false);
+      refreshDriverRow(row.type);
     } finally {
       setActionDriver('');
     }
+  }, [downloadDir, refreshDriverRow]);
+
+  // 依次安装全部推荐驱动（当前构建包含、尚未启用的可选驱动），结束后汇总每个驱动的结果
+  const installRecommended = useCallback(async () => {
+    setBulkProgress({ percent: 0, message: '准备安装' });
+    const off = EventsOn('driver:bulk-install-progress', (event: any) => {
+      if (!event) {
+        return;
+      }
+      setBulkProgress({
+        percent: Math.max(0, Math.min(100, Number(event.percent || 0))),
+        message: String(event.message || '').trim(),
+      });
+    });
+    try {
+      const res = await InstallDriverPackages([], downloadDir);
+      if (!res?.success) {
+        message.error(res?.message || '批量安装失败');
+        return;
+      }
+      const results = (Array.isArray(res.data) ? res.data : []) as BulkInstallResult[];
+      if (results.length === 0) {
+        message.info(res.message || '推荐驱动均已安装');
+        return;
+      }
+      const hasFailure = results.some((item) => item.status === 'failed');
+      (hasFailure ? Modal.warning : Modal.success)({
+        title: res.message,
+        width: 560,
+        content: (
+          <div style={{ maxHeight: 320, overflow: 'auto' }}>
+            {results.map((item) => (
+              <div key={item.driverType} style={{ marginBottom: 4 }}>
+                <Tag color={bulkStatusTag[item.status]?.color}>{bulkStatusTag[item.status]?.text || item.status}</Tag>
+                <Text strong>{item.driverName || item.driverType}</Text>
+                {item.message && <Text type="secondary">{` ${item.message}`}</Text>}
+              </div>
+            ))}
+          </div>
+        ),
+      });
+    } finally {
+      off();
+      setBulkProgress(null);
+      refreshStatus(false);
+    }
   }, [downloadDir, refreshStatus]);
 
+  const cleanOrphans = useCallback(async () => {
+    setCleaning(true);
+    try {
+      const res = await ListOrphanedDriverFiles(downloadDir);
+      if (!res?.success) {
+        message.error(res?.message || '扫描驱动目录失败');
+        return;
+      }
+      const files = (res.data?.files || []) as Array<{ path: string; relativePath: string; reason: string; sizeText: string }>;
+      if (files.length === 0) {
+        message.info('驱动目录中没有残留文件');
+        return;
+      }
+      Modal.confirm({
+        title: `清理 ${files.length} 个残留文件（共 ${res.data?.totalText || '-'}）？`,
+        width: 640,
+        content: (
+          <div style={{ maxHeight: 280, overflow: 'auto' }}>
+            {files.map((file) => (
+              <div key={file.path}>
+                <Text code>{file.relativePath}</Text>
+                <Text type="secondary">{` ${file.reason}，${file.sizeText}`}</Text>
+              </div>
+            ))}
+          </div>
+        ),
+        okText: '清理',
+        okButtonProps: { danger: true },
+        cancelText: '取消',
+        onOk: async () => {
+          const result = await CleanOrphanedDriverFiles(downloadDir, files.map((file) => file.path));
+          if (!result?.success) {
+            message.error(result?.message || '清理残留文件失败');
+            return;
+          }
+          message.success(`${result.message}，释放 ${result.data?.freedText || '-'}`);
+        },
+      });
+    } finally {
+      setCleaning(false);
+    }
+  }, [downloadDir]);
+
   const columns = useMemo(() => {
     return [
       {
@@ -272,7 +424,13 @@ const DriverManagerModal: React.FC<{ open: boolean; onClose: () => void }> = ({
       width={980}
       destroyOnClose
       footer={[
-        <Button key="refresh" icon={<ReloadOutlined />} onClick={() => refreshStatus(true)} loading={loading}>
+        <Button key="install-all" icon={<AppstoreAddOutlined />} onClick={installRecommended} loading={!!bulkProgress} disabled={!!actionDriver}>
+          安装全部推荐
+        </Button>,
+        <Button key="clean" icon={<ClearOutlined />} onClick={cleanOrphans} loading={cleaning}>
+          清理残留文件
+        </Button>,
+        <Button key="refresh" icon={<ReloadOutlined />} onClick={() => refreshStatus(true, true)} loading={loading}>
           刷新
         </Button>,
         <Button key="close" type="primary" onClick={onClose}>
@@ -281,7 +439,20 @@ const DriverManagerModal: React.FC<{ open: boolean; onClose: () => void }> = ({
       ]}
     >
       <Space direction="vertical" size={12} style={{ width: '100%' }}>
-        <Text type="secondary">除 MySQL / Redis / Oracle / PostgreSQL 外，其他数据源需先安装启用后再连接。</Text>
+        <Space style={{ width: '100%', justifyContent: 'space-between' }}>
+          <Text type="secondary">除 MySQL / Redis / Oracle / PostgreSQL 外，其他数据源需先安装启用后再连接。</Text>
+          <Space size={8}>
+            <Switch size="small" checked={!!networkSettings.offline} loading={savingNetwork} onChange={toggleOffline} />
+            <Text type="secondary">离线模式（不访问网络，仅使用本地驱动包）</Text>
+          </Space>
+        </Space>
+
+        {bulkProgress && (
+          <div>
+            <Text type="secondary">{bulkProgress.message}</Text>
+            <Progress percent={Math.round(bulkProgress.percent)} status="active" size="small" />
+          </div>
+        )}
 
         <Table
           rowKey="type"
@@ -1,7 +1,7 @@
-import React, { useState, useEffect } from 'react';
-import { Modal, Table, Alert, Progress, Button, Space } from 'antd';
+import React, { useState, useEffect, useRef } from 'react';
+import { Modal, Table, Alert, Progress, Button, Space, Select, Input, InputNumber, Checkbox } from 'antd';
 import { CheckCircleOutlined, CloseCircleOutlined } from '@ant-design/icons';
-import { PreviewImportFile, ImportDataWithProgress } from '../../wailsjs/go/app/App';
+import { PreviewImportFileWithOptions, PreviewImportMapping, ImportDataWithOptions, CancelQuery } from '../../wailsjs/go/app/App';
 import { EventsOn, EventsOff } from '../../wailsjs/runtime/runtime';
 import { useStore } from '../store';
 
@@ -19,6 +19,7 @@ interface PreviewData {
     columns: string[];
     totalRows: number;
     previewRows: any[];
+    sheets?: string[];
 }
 
 interface ImportProgress {
@@ -44,10 +45,24 @@ const ImportPreviewModal: React.FC<ImportPreviewModalProps> = ({
     const [importing, setImporting] = useState(false);
     const [progress, setProgress] = useState<ImportProgress | null>(null);
     const [importResult, setImportResult] = useState<any>(null);
+    const importIdRef = useRef<string>('');
+    const [tableColumns, setTableColumns] = useState<string[]>([]);
+    const [columnTargets, setColumnTargets] = useState<Record<string, string>>({});
+    const [nullToken, setNullToken] = useState('NULL');
+    const [trimValues, setTrimValues] = useState(false);
+    const [emptyAsNull, setEmptyAsNull] = useState(false);
+    const [dateFormat, setDateFormat] = useState('');
+    const [sheet, setSheet] = useState('');
+    const [headerRow, setHeaderRow] = useState(0);
+    const [sheets, setSheets] = useState<string[]>([]);
+    const [mappedPreview, setMappedPreview] = useState<{ columns: string[]; rows: any[]; errors: string[] } | null>(null);
 
     useEffect(() => {
         if (visible && filePath) {
-            loadPreview();
+            setSheet('');
+            setHeaderRow(0);
+            setSheets([]);
+            loadPreview({});
         }
     }, [visible, filePath]);
 
@@ -62,17 +77,77 @@ const ImportPreviewModal: React.FC<ImportPreviewModalProps> = ({
         }
     }, [importing]);
 
-    const loadPreview = async () => {
+    const buildConfig = () => {
+        const conn = connections.find(c => c.id === connectionId);
+        if (!conn) return null;
+        return {
+            ...conn.config,
+            port: Number(conn.config.port),
+            password: conn.config.password || '',
+            database: conn.config.database || '',
+            useSSH: conn.config.useSSH || false,
+            ssh: conn.config.ssh || { host: '', port: 22, user: '', password: '', keyPath: '' }
+        };
+    };
+
+    const buildMapping = () => ({
+        columns: (previewData?.columns || []).map(col => (
+            columnTargets[col] ? { source: col, target: columnTargets[col] } : { source: col, skip: true }
+        )),
+        nullToken,
+        trim: trimValues,
+        emptyAsNull,
+        dateFormat: dateFormat.trim(),
+    });
+
+    // 读取表结构并按同名列生成默认映射
+    const loadMapping = async (fileOptions: { sheet?: string; headerRow?: number }) => {
+        const config = buildConfig();
+        if (!config) return;
+        try {
+            const res = await PreviewImportMapping(config as any, dbName, tableName, filePath, fileOptions as any, {} as any, 5);
+            if (!res.success || !res.data) return;
+            setTableColumns((res.data.tableColumns || []).map((col: any) => col.name));
+            const targets: Record<string, string> = {};
+            (res.data.mappings || []).forEach((m: any) => {
+                if (m.source) targets[m.source] = m.skipped ? '' : m.target;
+            });
+            setColumnTargets(targets);
+        } catch {
+            setTableColumns([]);
+        }
+    };
+
+    const handlePreviewMapping = async () => {
+        const config = buildConfig();
+        if (!config) return;
+        const res = await PreviewImportMapping(config as any, dbName, tableName, filePath, { sheet, headerRow } as any, buildMapping() as any, 5);
+        if (!res.success || !res.data) {
+            setError(res.message || '预览转换结果失败');
+            return;
+        }
+        setError(null);
+        setMappedPreview({ columns: res.data.columns || [], rows: res.data.previewRows || [], errors: res.data.errors || [] });
+    };
+
+    const loadPreview = async (fileOptions: { sheet?: string; headerRow?: number }) => {
         setLoading(true);
         setError(null);
         try {
-            const res = await PreviewImportFile(filePath);
+            const res = await PreviewImportFileWithOptions(filePath, fileOptions as any);
+            if (res.data?.sheets) {
+                setSheets(res.data.sheets);
+            }
             if (res.success && res.data) {
+                if (res.data.sheet) setSheet(res.data.sheet);
+                if (res.data.headerRow) setHeaderRow(res.data.headerRow);
                 setPreviewData({
                     columns: res.data.columns || [],
                     totalRows: res.data.totalRows || 0,
                     previewRows: res.data.previewRows || []
                 });
+                setMappedPreview(null);
+                loadMapping({ sheet: res.data.sheet, headerRow: res.data.headerRow });
             } else {
                 setError(res.message || '预览失败');
             }
@@ -91,23 +166,20 @@ const ImportPreviewModal: React.FC<ImportPreviewModalProps> = ({
         setImportResult(null);
 
         try {
-            const conn = connections.find(c => c.id === connectionId);
-            if (!conn) {
+            const config = buildConfig();
+            if (!config) {
                 setError('连接配置未找到');
                 setImporting(false);
                 return;
             }
 
-            const config = {
-                ...conn.config,
-                port: Number(conn.config.port),
-                password: conn.config.password || '',
-                database: conn.config.database || '',
-                useSSH: conn.config.useSSH || false,
-                ssh: conn.config.ssh || { host: '', port: 22, user: '', password: '', keyPath: '' }
-            };
-
-            const res = await ImportDataWithProgress(config as any, dbName, tableName, filePath);
+            const importId = `import-${Date.now()}-${Math.random().toString(36).slice(2, 8)}`;
+            importIdRef.current = importId;
+            const res = await ImportDataWithOptions(config as any, dbName, tableName, filePath, {
+                importId,
+                file: { sheet, headerRow },
+                mapping: tableColumns.length > 0 ? buildMapping() : undefined,
+            } as any);
 
             if (res.success && res.data) {
                 setImportResult(res.data);
@@ -116,14 +188,24 @@ const ImportPreviewModal: React.FC<ImportPreviewModalProps> = ({
                 }
             } else {
                 setError(res.message || '导入失败');
+                if (res.data?.errorLogs?.length) {
+                    setMappedPreview(prev => ({ columns: prev?.columns || [], rows: prev?.rows || [], errors: res.data.errorLogs }));
+                }
             }
         } catch (e: any) {
             setError('导入失败: ' + e.message);
         } finally {
+            importIdRef.current = '';
             setImporting(false);
         }
     };
 
+    const handleCancelImport = () => {
+        if (importIdRef.current) {
+            CancelQuery(importIdRef.current);
+        }
+    };
+
     const columns = previewData?.columns.map(col => ({
         title: col,
         dataIndex: col,
@@ -145,7 +227,11 @@ const ImportPreviewModal: React.FC<ImportPreviewModalProps> = ({
                     <Space>
                         <Button onClick={onClose}>关闭</Button>
                     </Space>
-                ) : importing ? null : (
+                ) : importing ? (
+                    <Space>
+                        <Button danger onClick={handleCancelImport}>取消导入</Button>
+                    </Space>
+                ) : (
                     <Space>
                         <Button onClick={onClose}>取消</Button>
                         <Button
@@ -163,6 +249,22 @@ const ImportPreviewModal: React.FC<ImportPreviewModalProps> = ({
 
             {loading && <div style={{ textAlign: 'center', padding: 40 }}>加载预览数据...</div>}
 
+            {!importing && !importResult && sheets.length > 0 && (
+                <Space wrap style={{ marginBottom: 12 }}>
+                    <span>工作表</span>
+                    <Select
+                        size="small"
+                        style={{ width: 180 }}
+                        value={sheet || undefined}
+                        options={sheets.map(name => ({ label: name, value: name }))}
+                        onChange={(value: string) => { setSheet(value); setHeaderRow(0); loadPreview({ sheet: value }); }}
+                    />
+                    <span>表头行</span>
+                    <InputNumber size="small" min={1} value={headerRow || undefined} onChange={value => setHeaderRow(Number(value) || 0)} />
+                    <Button size="small" onClick={() => loadPreview({ sheet, headerRow })}>重新解析</Button>
+                </Space>
+            )}
+
             {!loading && previewData && !importing && !importResult && (
                 <>
                     <Alert
@@ -185,6 +287,56 @@ const ImportPreviewModal: React.FC<ImportPreviewModalProps> = ({
                         size="small"
                         bordered
                     />
+                    {tableColumns.length > 0 && (
+                        <>
+                            <div style={{ margin: '16px 0 8px', fontWeight: 600 }}>列映射：</div>
+                            <div style={{ display: 'grid', gridTemplateColumns: 'repeat(2, 1fr)', gap: 8, marginBottom: 12 }}>
+                                {previewData.columns.map(col => (
+                                    <Space key={col} size={8}>
+                                        <span style={{ display: 'inline-block', width: 140, overflow: 'hidden', textOverflow: 'ellipsis' }} title={col}>{col}</span>
+                                        <span>→</span>
+                                        <Select
+                                            size="small"
+                                            style={{ width: 200 }}
+                                            value={columnTargets[col] || ''}
+                                            onChange={(value: string) => setColumnTargets(prev => ({ ...prev, [col]: value }))}
+                                            options={[{ label: '（跳过）', value: '' }, ...tableColumns.map(name => ({ label: name, value: name }))]}
+                                            showSearch
+                                        />
+                                    </Space>
+                                ))}
+                            </div>
+                            <Space wrap style={{ marginBottom: 12 }}>
+                                <span>NULL 标记</span>
+                                <Input size="small" style={{ width: 90 }} value={nullToken} onChange={e => setNullToken(e.target.value)} />
+                                <span>日期格式</span>
+                                <Input size="small" style={{ width: 180 }} placeholder="如 dd/MM/yyyy HH:mm:ss" value={dateFormat} onChange={e => setDateFormat(e.target.value)} />
+                                <Checkbox checked={trimValues} onChange={e => setTrimValues(e.target.checked)}>去除首尾空白</Checkbox>
+                                <Checkbox checked={emptyAsNull} onChange={e => setEmptyAsNull(e.target.checked)}>空值视为 NULL</Checkbox>
+                                <Button size="small" onClick={handlePreviewMapping}>预览转换结果</Button>
+                            </Space>
+                            {mappedPreview && mappedPreview.errors.length > 0 && (
+                                <Alert
+                                    type="error"
+                                    style={{ marginBottom: 12 }}
+                                    message={`转换出错 ${mappedPreview.errors.length} 处，导入前请调整映射或格式`}
+                                    description={<div style={{ whiteSpace: 'pre-line' }}>{mappedPreview.errors.slice(0, 5).join('\n')}</div>}
+                                    showIcon
+                                />
+                            )}
+                            {mappedPreview && (
+                                <Table
+                                    dataSource={mappedPreview.rows.map((row, idx) => ({ ...row, __key: idx }))}
+                                    rowKey="__key"
+                                    columns={mappedPreview.columns.map(col => ({ title: col, dataIndex: col, key: col, ellipsis: true, width: 150, render: (v: any) => (v === null || v === undefined ? 'NULL' : String(v)) }))}
+                                    pagination={false}
+                                    scroll={{ x: 'max-content' }}
+                                    size="small"
+                                    bordered
+                                />
+                            )}
+                        </>
+                    )}
                 </>
             )}
 
@@ -212,11 +364,12 @@ const ImportPreviewModal: React.FC<ImportPreviewModalProps> = ({
                 <div style={{ padding: 20 }}>
                     <Alert
                         type={importResult.failed === 0 ? 'success' : 'warning'}
-                        message="导入完成"
+                        message={importResult.canceled ? '导入已取消' : '导入完成'}
                         description={
                             <div>
                                 <div>成功导入 {importResult.success} 行</div>
                                 {importResult.failed > 0 && <div>失败 {importResult.failed} 行</div>}
+                                {importResult.transaction && importResult.failed > 0 && <div>导入在事务中执行，出错后已整体回滚</div>}
                             </div>
                         }
                         showIcon
@@ -1,11 +1,12 @@
 import React, { useState, useEffect, useRef } from 'react';
 import Editor, { OnMount } from '@monaco-editor/react';
-import { Button, message, Modal, Input, Form, Dropdown, MenuProps, Tooltip, Select, Tabs } from 'antd';
-import { PlayCircleOutlined, SaveOutlined, FormatPainterOutlined, SettingOutlined, CloseOutlined } from '@ant-design/icons';
+import { Button, message, Modal, Input, Form, Dropdown, MenuProps, Tooltip, Select, Tabs, Table } from 'antd';
+import { PlayCircleOutlined, SaveOutlined, FormatPainterOutlined, SettingOutlined, CloseOutlined, EyeOutlined } from '@ant-design/icons';
 import { format } from 'sql-formatter';
 import { TabData, ColumnDefinition } from '../types';
 import { useStore } from '../store';
-import { DBQuery, DBGetTables, DBGetAllColumns, DBGetDatabases, DBGetColumns } from '../../wailsjs/go/app/App';
+import { DBGetTables, DBGetAllColumnsBySchema, DBGetDatabases, DBGetColumns, PreviewAffectedRows } from '../../wailsjs/go/app/App';
+import { queryWithCompression } from '../utils/resultTransfer';
 import DataGrid, { GONAVI_ROW_KEY } from './DataGrid';
 
 const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
@@ -26,6 +27,7 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
   // Result Sets
   const [resultSets, setResultSets] = useState<ResultSet[]>([]);
   const [activeResultKey, setActiveResultKey] = useState<string>('');
+  const [previewing, setPreviewing] = useState(false);
   
   const [loading, setLoading] = useState(false);
   const runSeqRef = useRef(0);
@@ -43,7 +45,8 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
   const monacoRef = useRef<any>(null);
   const dragRef = useRef<{ startY: number, startHeight: number } | null>(null);
   const tablesRef = useRef<{dbName: string, tableName: string}[]>([]); // Store tables for autocomplete (cross-db)
-  const allColumnsRef = useRef<{dbName: string, tableName: string, name: string, type: string}[]>([]); // Store all columns (cross-db)
+  const allColumnsRef = useRef<{dbName: string, tableName: string, table?: string, schema?: string, name: string, type: string}[]>([]); // Store all columns (cross-db)
+  const defaultSchemaRef = useRef<Record<string, string>>({}); // dbName -> 默认 schema（PG/SQL Server 等）
   const visibleDbsRef = useRef<string[]>([]); // Store visible databases for cross-db intellisense
 
   const connections = useStore(state => state.connections);
@@ -138,7 +141,8 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
 
           // 加载所有可见数据库的表
           const allTables: {dbName: string, tableName: string}[] = [];
-          const allColumns: {dbName: string, tableName: string, name: string, type: string}[] = [];
+          const allColumns: {dbName: string, tableName: string, table?: string, schema?: string, name: string, type: string}[] = [];
+          const defaultSchemas: Record<string, string> = {};
 
           for (const dbName of visibleDbs) {
               // 获取表
@@ -150,13 +154,16 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
                   });
               }
 
-              // 获取列 (所有数据库类型都支持 DBGetAllColumns)
-              const resCols = await DBGetAllColumns(config as any, dbName);
-              if (resCols.success && Array.isArray(resCols.data)) {
-                  resCols.data.forEach((col: any) => {
+              // 获取列 (所有数据库类型都支持；有 schema 的库 tableName 为 schema.table，并返回默认 schema)
+              const resCols = await DBGetAllColumnsBySchema(config as any, dbName, "");
+              if (resCols.success && resCols.data && Array.isArray(resCols.data.columns)) {
+                  if (resCols.data.defaultSchema) defaultSchemas[dbName] = resCols.data.defaultSchema;
+                  resCols.data.columns.forEach((col: any) => {
                       allColumns.push({
                           dbName,
                           tableName: col.tableName,
+                          table: col.table,
+                          schema: col.schema,
                           name: col.name,
                           type: col.type
                       });
@@ -166,6 +173,7 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
 
           tablesRef.current = allTables;
           allColumnsRef.current = allColumns;
+          defaultSchemaRef.current = defaultSchemas;
       };
       fetchMetadata();
   }, [currentConnectionId, connections, dbList]); // dbList 变化时触发重新加载
@@ -238,6 +246,16 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
                   return parts[parts.length - 1] || raw;
               };
 
+              // 列是否属于给定表：支持 schema.table 全名，以及默认 schema 下省略前缀的裸表名
+              const columnInTable = (c: { dbName: string, tableName: string, table?: string, schema?: string }, dbName: string, tableIdent: string) => {
+                  if ((c.dbName || '').toLowerCase() !== (dbName || '').toLowerCase()) return false;
+                  const ident = (tableIdent || '').toLowerCase();
+                  if ((c.tableName || '').toLowerCase() === ident) return true;
+                  if (!c.table || ident.includes('.')) return false;
+                  const defaultSchema = (defaultSchemaRef.current[c.dbName] || '').toLowerCase();
+                  return (c.table || '').toLowerCase() === ident && (c.schema || '').toLowerCase() === defaultSchema;
+              };
+
               const buildConnConfig = () => {
                   const connId = currentConnectionIdRef.current;
                   const conn = connectionsRef.current.find(c => c.id === connId);
@@ -285,9 +303,11 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
                   const colPrefix = (threePartMatch[3] || '').toLowerCase();
 
                   // 在 allColumnsRef 中查找匹配的列
-                  const cols = allColumnsRef.current.filter(c =>
-                      (c.dbName || '').toLowerCase() === dbPart.toLowerCase() &&
-                      (c.tableName || '').toLowerCase() === tablePart.toLowerCase()
+                  // db.table.column，或当前库下的 schema.table.column
+                  const isDbPart = visibleDbsRef.current.some(db => db.toLowerCase() === dbPart.toLowerCase());
+                  const cols = allColumnsRef.current.filter(c => isDbPart
+                      ? columnInTable(c, dbPart, tablePart)
+                      : columnInTable(c, currentDbRef.current || '', `${dbPart}.${tablePart}`)
                   );
 
                   const filtered = colPrefix
@@ -348,11 +368,11 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
                       const tableIdent = normalizeQualifiedName(m[1] || '');
                       if (!tableIdent) continue;
 
-                      // 解析 db.table 或 table 格式
+                      // 解析 db.table 或 table 格式；首段不是数据库名时按当前库的 schema.table 处理
                       const parts = tableIdent.split('.');
                       let dbName = currentDbRef.current || '';
                       let tableName = tableIdent;
-                      if (parts.length === 2) {
+                      if (parts.length === 2 && visibleDbsRef.current.some(db => db.toLowerCase() === parts[0].toLowerCase())) {
                           dbName = parts[0];
                           tableName = parts[1];
                       }
@@ -374,10 +394,7 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
                       let cols: { name: string, type?: string, tableName?: string, dbName?: string }[] = [];
                       if (allColumnsRef.current.length > 0) {
                           cols = allColumnsRef.current
-                              .filter(c =>
-                                  (c.dbName || '').toLowerCase() === (tableInfo.dbName || '').toLowerCase() &&
-                                  (c.tableName || '').toLowerCase() === (tableInfo.tableName || '').toLowerCase()
-                              )
+                              .filter(c => columnInTable(c, tableInfo.dbName, tableInfo.tableName))
                               .map(c => ({ name: c.name, type: c.type, tableName: c.tableName, dbName: c.dbName }));
                       } else {
                           const dbCols = await getColumnsByDB(tableInfo.tableName);
@@ -419,7 +436,9 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
                   .filter(c => {
                       const fullIdent = `${c.dbName}.${c.tableName}`.toLowerCase();
                       const shortIdent = (c.tableName || '').toLowerCase();
-                      return foundTables.has(fullIdent) || foundTables.has(shortIdent);
+                      if (foundTables.has(fullIdent) || foundTables.has(shortIdent)) return true;
+                      // 默认 schema 下的表可能以裸表名引用
+                      return !!c.table && columnInTable(c, c.dbName, c.table) && foundTables.has(c.table.toLowerCase());
                   })
                   .map(c => {
                       // 当前库的表字段优先级更高
@@ -922,7 +941,7 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
 
   const applyAutoLimit = (sql: string, dbType: string, maxRows: number): { sql: string; applied: boolean; maxRows: number } => {
       const normalizedType = (dbType || 'mysql').toLowerCase();
-      const supportsLimit = normalizedType === 'mysql' || normalizedType === 'mariadb' || normalizedType === 'diros' || normalizedType === 'sphinx' || normalizedType === 'postgres' || normalizedType === 'kingbase' || normalizedType === 'sqlite' || normalizedType === 'duckdb' || normalizedType === 'tdengine' || normalizedType === '';
+      const supportsLimit = normalizedType === 'mysql' || normalizedType === 'mariadb' || normalizedType === 'diros' || normalizedType === 'sphinx' || normalizedType === 'postgres' || normalizedType === 'kingbase' || normalizedType === 'sqlite' || normalizedType === 'duckdb' || normalizedType === 'tdengine' || normalizedType === 'clickhouse' || normalizedType === '';
       if (!supportsLimit) return { sql, applied: false, maxRows };
       if (!Number.isFinite(maxRows) || maxRows <= 0) return { sql, applied: false, maxRows };
 
@@ -962,6 +981,42 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
       return selected;
   };
 
+  // 在编辑器中标记出错位置；error 为后端返回的结构化错误，行列相对于 statementOffset 处的语句。
+  const markQueryError = (error: any, statementOffset: number) => {
+      const editor = editorRef.current;
+      const monaco = monacoRef.current;
+      const model = editor?.getModel?.();
+      if (!model || !monaco) return;
+      if (!error || !(Number(error.position) > 0 || Number(error.line) > 0)) {
+          monaco.editor.setModelMarkers(model, 'gonavi-query-error', []);
+          return;
+      }
+      let startLineNumber: number;
+      let startColumn: number;
+      let endColumn: number;
+      if (Number(error.position) > 0) {
+          const start = model.getPositionAt(statementOffset + Number(error.position) - 1);
+          const word = model.getWordAtPosition(start);
+          startLineNumber = start.lineNumber;
+          startColumn = start.column;
+          endColumn = word && word.startColumn === start.column ? word.endColumn : start.column + 1;
+      } else {
+          const base = model.getPositionAt(statementOffset);
+          startLineNumber = Math.min(base.lineNumber + Number(error.line) - 1, model.getLineCount());
+          startColumn = Number(error.line) === 1 ? base.column : 1;
+          endColumn = model.getLineMaxColumn(startLineNumber);
+      }
+      monaco.editor.setModelMarkers(model, 'gonavi-query-error', [{
+          severity: monaco.MarkerSeverity.Error,
+          message: String(error.message || ''),
+          startLineNumber,
+          startColumn,
+          endLineNumber: startLineNumber,
+          endColumn,
+      }]);
+      editor.revealPositionInCenterIfOutsideViewport?.({ lineNumber: startLineNumber, column: startColumn });
+  };
+
   const handleRun = async () => {
     if (!query.trim()) return;
     if (!currentDb) {
@@ -988,8 +1043,14 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
     };
 
     try {
-        const rawSQL = getSelectedSQL() || query;
+        const selectedSQL = getSelectedSQL();
+        const rawSQL = selectedSQL || query;
         const statements = splitSQLStatements(rawSQL);
+        const editorModel = editorRef.current?.getModel?.();
+        const selectionStart = editorRef.current?.getSelection?.()?.getStartPosition?.();
+        const baseOffset = selectedSQL && editorModel && selectionStart ? editorModel.getOffsetAt(selectionStart) : 0;
+        let searchFrom = 0;
+        markQueryError(null, 0);
         if (statements.length === 0) {
             message.info('没有可执行的 SQL。');
             setResultSets([]);
@@ -1001,14 +1062,19 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
         const maxRows = Number(queryOptions?.maxRows) || 0;
         const dbType = String((config as any).type || 'mysql');
         const normalizedDbType = dbType.toLowerCase();
-        const forceReadOnlyResult = normalizedDbType === 'tdengine';
+        const forceReadOnlyResult = normalizedDbType === 'tdengine' || normalizedDbType === 'clickhouse';
         const wantsLimitProbe = Number.isFinite(maxRows) && maxRows > 0;
         const probeLimit = wantsLimitProbe ? (maxRows + 1) : 0;
         let anyTruncated = false;
         const pendingPk: Array<{ resultKey: string; tableName: string }> = [];
+        // USE / \c 会切换后续语句所在的数据库
+        let activeDb = currentDb;
 
         for (let idx = 0; idx < statements.length; idx++) {
             const rawStatement = statements[idx];
+            const foundAt = rawSQL.indexOf(rawStatement, searchFrom);
+            const statementOffset = baseOffset + (foundAt >= 0 ? foundAt : 0);
+            if (foundAt >= 0) searchFrom = foundAt + rawStatement.length;
             const leadingKeyword = getLeadingKeyword(rawStatement);
             const shouldAutoLimit = leadingKeyword === 'select' || leadingKeyword === 'with';
 
@@ -1016,7 +1082,7 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
             const limited = limitApplied ? applyAutoLimit(rawStatement, dbType, probeLimit) : { sql: rawStatement, applied: false, maxRows: probeLimit };
             const executedSql = limited.sql;
             const startTime = Date.now();
-            const res = await DBQuery(config as any, currentDb, executedSql);
+            const res = await queryWithCompression(config as any, activeDb, executedSql);
             const duration = Date.now() - startTime;
 
             addSqlLog({
@@ -1027,17 +1093,26 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
                 duration,
                 message: res.success ? '' : res.message,
                 affectedRows: (res.success && !Array.isArray(res.data)) ? (res.data as any).affectedRows : (Array.isArray(res.data) ? res.data.length : undefined),
-                dbName: currentDb
+                dbName: activeDb
             });
 
             if (!res.success) {
                 const prefix = statements.length > 1 ? `第 ${idx + 1} 条语句执行失败：` : '';
+                markQueryError(res.error, statementOffset);
                 message.error(prefix + res.message);
                 setResultSets([]);
                 setActiveResultKey('');
                 return;
             }
 
+            const switchedDb = !Array.isArray(res.data) ? (res.data as any)?.activeDatabase : undefined;
+            if (switchedDb) {
+                activeDb = String(switchedDb);
+                setCurrentDb(activeDb);
+                message.info(res.message || `已切换到数据库 ${activeDb}`);
+                continue;
+            }
+
             if (Array.isArray(res.data)) {
                 let rows = (res.data as any[]) || [];
                 let truncated = false;
@@ -1075,6 +1150,13 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
                     truncated
                 });
             } else {
+                const schemaBackup = (res as any).schemaBackup;
+                if (schemaBackup?.rollbackPath) {
+                    message.info(`已备份变更前的结构定义，回退脚本：${schemaBackup.rollbackPath}`, 6);
+                }
+                if (res.message) {
+                    message.warning(res.message);
+                }
                 const affected = Number((res.data as any)?.affectedRows);
                 if (Number.isFinite(affected)) {
                     const row = { affectedRows: affected };
@@ -1095,7 +1177,7 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
         setActiveResultKey(nextResultSets[0]?.key || '');
 
         pendingPk.forEach(({ resultKey, tableName }) => {
-            DBGetColumns(config as any, currentDb, tableName)
+            DBGetColumns(config as any, activeDb, tableName)
                 .then((resCols: any) => {
                     if (runSeqRef.current !== runSeq) return;
                     if (!resCols?.success) {
@@ -1137,6 +1219,69 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
     }
   };
 
+  // 执行 UPDATE/DELETE 前预览将受影响的行：取选中内容（或整个编辑器）中的第一条 UPDATE/DELETE
+  const handlePreviewAffected = async () => {
+      const conn = connections.find(c => c.id === currentConnectionId);
+      if (!conn || !currentDb) {
+          message.error("请先选择数据库");
+          return;
+      }
+      const statement = splitSQLStatements(getSelectedSQL() || query).find(stmt => {
+          const keyword = getLeadingKeyword(stmt);
+          return keyword === 'update' || keyword === 'delete' || (keyword === 'with' && /\b(update|delete)\b/i.test(stmt));
+      });
+      if (!statement) {
+          message.info('没有找到 UPDATE 或 DELETE 语句');
+          return;
+      }
+      const config = {
+          ...conn.config,
+          port: Number(conn.config.port),
+          password: conn.config.password || "",
+          database: conn.config.database || "",
+          useSSH: conn.config.useSSH || false,
+          ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" }
+      };
+      setPreviewing(true);
+      try {
+          const res = await PreviewAffectedRows(config as any, currentDb, statement, 100);
+          if (!res.success) {
+              message.error('预览失败：' + res.message);
+              return;
+          }
+          const data = res.data as any;
+          const columns: string[] = data.columns || [];
+          Modal.info({
+              title: res.message,
+              width: 900,
+              okText: '关闭',
+              content: (
+                  <div>
+                      {(data.warnings || []).map((w: string, i: number) => <div key={i} style={{ color: '#faad14' }}>{w}</div>)}
+                      {data.truncated && <div style={{ color: '#888', marginBottom: 8 }}>仅展示前 {data.rows.length} 行</div>}
+                      <Table
+                          size="small"
+                          rowKey={(_, i) => String(i)}
+                          dataSource={data.rows || []}
+                          pagination={{ pageSize: 10, size: 'small' }}
+                          scroll={{ x: 'max-content' }}
+                          columns={columns.map(col => ({
+                              title: col,
+                              dataIndex: col,
+                              key: col,
+                              ellipsis: true,
+                              render: (v: any) => v === null || v === undefined ? <span style={{ color: '#bbb' }}>NULL</span> : String(v),
+                          }))}
+                      />
+                      <pre style={{ marginTop: 8, whiteSpace: 'pre-wrap', color: '#888', fontSize: 12 }}>{data.selectSql}</pre>
+                  </div>
+              ),
+          });
+      } finally {
+          setPreviewing(false);
+      }
+  };
+
   const handleSave = async () => {
       try {
           const values = await saveForm.validateFields();
@@ -1251,6 +1396,11 @@ const QueryEditor: React.FC<{ tab: TabData }> = ({ tab }) => {
         <Button type="primary" icon={<PlayCircleOutlined />} onClick={handleRun} loading={loading}>
           运行
         </Button>
+        <Tooltip title="执行前预览 UPDATE/DELETE 将影响的行">
+            <Button icon={<EyeOutlined />} onClick={handlePreviewAffected} loading={previewing}>
+              影响预览
+            </Button>
+        </Tooltip>
         <Button icon={<SaveOutlined />} onClick={() => {
             saveForm.setFieldsValue({ name: tab.title.replace('Query (', '').replace(')', '') });
             setIsSaveModalOpen(true);
@@ -1,6 +1,6 @@
-import React, { useState, useCallback, useRef } from 'react';
-import { Button, Space, message } from 'antd';
-import { PlayCircleOutlined, ClearOutlined } from '@ant-design/icons';
+import React, { useState, useCallback, useEffect, useMemo, useRef } from 'react';
+import { Button, Space, Select, Tag, Modal, message } from 'antd';
+import { PlayCircleOutlined, ClearOutlined, HistoryOutlined } from '@ant-design/icons';
 import { useStore } from '../store';
 import Editor, { OnMount } from '@monaco-editor/react';
 
@@ -9,21 +9,131 @@ interface RedisCommandEditorProps {
     redisDB: number;
 }
 
+interface CommandInfo {
+    name: string;
+    category: 'read' | 'write' | 'admin' | 'dangerous' | 'unsupported';
+    reason?: string;
+}
+
+interface CommandReply {
+    type: 'nil' | 'string' | 'integer' | 'double' | 'boolean' | 'array' | 'map' | 'error';
+    value?: any;
+    elements?: CommandReply[];
+}
+
 interface CommandResult {
     command: string;
-    result: any;
+    info?: CommandInfo;
+    reply?: CommandReply;
     error?: string;
+    durationMs?: number;
     timestamp: number;
 }
 
+const CATEGORY_TAGS: Record<string, { color: string; label: string }> = {
+    read: { color: 'green', label: '只读' },
+    write: { color: 'blue', label: '写入' },
+    admin: { color: 'purple', label: '管理' },
+    dangerous: { color: 'red', label: '危险' },
+    unsupported: { color: 'default', label: '不支持' },
+};
+
+// 命令分类表由后端提供，所有控制台共用一份
+let catalogCache: Record<string, CommandInfo> | null = null;
+
+const loadCatalog = async (): Promise<Record<string, CommandInfo>> => {
+    if (catalogCache) return catalogCache;
+    const res = await (window as any).go.app.App.RedisCommandCatalog();
+    catalogCache = res?.success ? (res.data || {}) : {};
+    return catalogCache!;
+};
+
+const classifyCommand = (catalog: Record<string, CommandInfo>, command: string): CommandInfo => {
+    const tokens = command.trim().split(/\s+/).map(t => t.replace(/^["']|["']$/g, '').toUpperCase());
+    const name = tokens[0] || '';
+    const full = tokens.length > 1 ? `${name} ${tokens[1]}` : name;
+    const info = catalog[full] || catalog[name];
+    if (info) return { ...info, name: catalog[full] ? full : name };
+    return { name, category: 'write' };
+};
+
+// AUTH 等命令的参数不写入历史
+const maskCommand = (command: string): string => {
+    const tokens = command.trim().split(/\s+/);
+    const name = (tokens[0] || '').toUpperCase();
+    if (name === 'AUTH' || (name === 'HELLO' && tokens.some(t => t.toUpperCase() === 'AUTH')) || (name === 'CONFIG' && (tokens[1] || '').toUpperCase() === 'SET' && /pass/i.test(tokens[2] || ''))) {
+        return `${tokens[0]} ******`;
+    }
+    return command;
+};
+
+const formatReply = (reply: CommandReply | undefined, indent = ''): string => {
+    if (!reply) return '(nil)';
+    switch (reply.type) {
+        case 'nil':
+            return '(nil)';
+        case 'integer':
+            return `(integer) ${reply.value}`;
+        case 'double':
+            return `(double) ${reply.value}`;
+        case 'boolean':
+            return `(boolean) ${reply.value ? 'true' : 'false'}`;
+        case 'error':
+            return `(error) ${reply.value}`;
+        case 'string':
+            return `"${String(reply.value ?? '')}"`;
+        case 'array': {
+            const elements = reply.elements || [];
+            if (elements.length === 0) return '(empty array)';
+            const width = String(elements.length).length;
+            return elements.map((item, index) => {
+                const prefix = `${String(index + 1).padStart(width, ' ')}) `;
+                return `${index === 0 ? '' : indent}${prefix}${formatReply(item, indent + ' '.repeat(prefix.length))}`;
+            }).join('\n');
+        }
+        case 'map': {
+            const elements = reply.elements || [];
+            if (elements.length === 0) return '(empty hash)';
+            const lines: string[] = [];
+            for (let i = 0; i + 1 < elements.length; i += 2) {
+                const prefix = `${Math.floor(i / 2) + 1}# `;
+                const key = formatReply(elements[i]);
+                lines.push(`${i === 0 ? '' : indent}${prefix}${key} => ${formatReply(elements[i + 1], indent + ' '.repeat(prefix.length + key.length + 4))}`);
+            }
+            return lines.join('\n');
+        }
+    }
+    return String(reply.value ?? '');
+};
+
 const RedisCommandEditor: React.FC<RedisCommandEditorProps> = ({ connectionId, redisDB }) => {
     const { connections } = useStore();
+    const sqlLogs = useStore(state => state.sqlLogs);
+    const addSqlLog = useStore(state => state.addSqlLog);
     const connection = connections.find(c => c.id === connectionId);
 
     const [command, setCommand] = useState('');
     const [results, setResults] = useState<CommandResult[]>([]);
     const [loading, setLoading] = useState(false);
+    const [catalog, setCatalog] = useState<Record<string, CommandInfo>>({});
     const editorRef = useRef<any>(null);
+    const executeRef = useRef<() => void>(() => {});
+
+    useEffect(() => {
+        loadCatalog().then(setCatalog).catch(() => setCatalog({}));
+    }, []);
+
+    // 共享 SQL 日志中本连接执行过的 Redis 命令，去重后最新的在前
+    const history = useMemo(() => {
+        const seen = new Set<string>();
+        const items: string[] = [];
+        sqlLogs.forEach(log => {
+            if (log.connectionId !== connectionId || seen.has(log.sql)) return;
+            seen.add(log.sql);
+            items.push(log.sql);
+        });
+        return items.slice(0, 100);
+    }, [sqlLogs, connectionId]);
 
     const getConfig = useCallback(() => {
         if (!connection) return null;
@@ -43,10 +153,31 @@ const RedisCommandEditor: React.FC<RedisCommandEditorProps> = ({ connectionId, r
         editor.addCommand(
             // Ctrl/Cmd + Enter
             2048 | 3, // KeyMod.CtrlCmd | KeyCode.Enter
-            () => handleExecute()
+            () => executeRef.current()
         );
     };
 
+    const confirmDangerous = (dangerous: Array<{ command: string; info: CommandInfo }>) => new Promise<boolean>((resolve) => {
+        Modal.confirm({
+            title: '确认执行危险命令',
+            width: 560,
+            content: (
+                <div>
+                    {dangerous.map(({ command, info }, idx) => (
+                        <div key={idx} style={{ marginBottom: 8 }}>
+                            <code>{command}</code>
+                            <div style={{ color: '#cf1322', fontSize: 12 }}>{info.reason}</div>
+                        </div>
+                    ))}
+                </div>
+            ),
+            okText: '执行',
+            okButtonProps: { danger: true },
+            onOk: () => resolve(true),
+            onCancel: () => resolve(false),
+        });
+    });
+
     const handleExecute = async () => {
         const config = getConfig();
         if (!config) return;
@@ -58,63 +189,61 @@ const RedisCommandEditor: React.FC<RedisCommandEditorProps> = ({ connectionId, r
         }
 
         // Support multiple commands separated by newlines
-        const commands = cmdToExecute.split('\n').filter(c => c.trim() && !c.trim().startsWith('//') && !c.trim().startsWith('#'));
+        const commands = cmdToExecute.split('\n').map(c => c.trim()).filter(c => c && !c.startsWith('//') && !c.startsWith('#'));
+        const dangerous = commands
+            .map(c => ({ command: c, info: classifyCommand(catalog, c) }))
+            .filter(item => item.info.category === 'dangerous');
+        if (dangerous.length > 0 && !(await confirmDangerous(dangerous))) {
+            return;
+        }
+        const confirmedCommands = new Set(dangerous.map(item => item.command));
 
         setLoading(true);
         const newResults: CommandResult[] = [];
 
         for (const cmd of commands) {
-            const trimmedCmd = cmd.trim();
-            if (!trimmedCmd) continue;
-
+            const started = Date.now();
+            let item: CommandResult;
             try {
-                const res = await (window as any).go.app.App.RedisExecuteCommand(config, trimmedCmd);
-                newResults.push({
-                    command: trimmedCmd,
-                    result: res.success ? res.data : null,
+                const run = (confirmed: boolean) => (window as any).go.app.App.RedisRunCommand(config, cmd, confirmed);
+                let res = await run(confirmedCommands.has(cmd));
+                // 分类表未加载时由后端识别危险命令，确认后重试
+                if (res.data?.requiresConfirm && await confirmDangerous([{ command: cmd, info: res.data.info }])) {
+                    res = await run(true);
+                }
+                item = {
+                    command: cmd,
+                    info: res.data?.info,
+                    reply: res.success ? res.data?.reply : undefined,
                     error: res.success ? undefined : res.message,
+                    durationMs: res.data?.durationMs,
                     timestamp: Date.now()
-                });
+                };
             } catch (e: any) {
-                newResults.push({
-                    command: trimmedCmd,
-                    result: null,
-                    error: e?.message || String(e),
-                    timestamp: Date.now()
-                });
+                item = { command: cmd, error: e?.message || String(e), timestamp: Date.now() };
             }
+            newResults.push(item);
+            addSqlLog({
+                id: `log-${Date.now()}-redis-${newResults.length}`,
+                timestamp: started,
+                sql: maskCommand(cmd),
+                status: item.error ? 'error' : 'success',
+                duration: item.durationMs ?? (Date.now() - started),
+                message: item.error,
+                dbName: `db${redisDB}`,
+                connectionId
+            });
         }
 
         setResults(prev => [...newResults, ...prev]);
         setLoading(false);
     };
+    executeRef.current = handleExecute;
 
     const handleClear = () => {
         setResults([]);
     };
 
-    const formatResult = (result: any): string => {
-        if (result === null || result === undefined) {
-            return '(nil)';
-        }
-        if (typeof result === 'string') {
-            return `"${result}"`;
-        }
-        if (typeof result === 'number') {
-            return `(integer) ${result}`;
-        }
-        if (Array.isArray(result)) {
-            if (result.length === 0) {
-                return '(empty array)';
-            }
-            return result.map((item, index) => `${index + 1}) ${formatResult(item)}`).join('\n');
-        }
-        if (typeof result === 'object') {
-            return JSON.stringify(result, null, 2);
-        }
-        return String(result);
-    };
-
     if (!connection) {
         return <div style={{ padding: 20 }}>连接不存在</div>;
     }
@@ -127,8 +256,21 @@ const RedisCommandEditor: React.FC<RedisCommandEditorProps> = ({ connectionId, r
                     <Space>
                         <span style={{ fontWeight: 500 }}>Redis 命令</span>
                         <span style={{ color: '#999', fontSize: 12 }}>db{redisDB}</span>
+                        {connection.config.readOnly && <Tag color="orange">只读连接</Tag>}
                     </Space>
                     <Space>
+                        <Select
+                            style={{ width: 260 }}
+                            placeholder={<span><HistoryOutlined /> 历史命令</span>}
+                            value={null}
+                            showSearch
+                            options={history.map(h => ({ value: h, label: h }))}
+                            onSelect={(value: string) => {
+                                setCommand(value);
+                                editorRef.current?.focus();
+                            }}
+                            notFoundContent="暂无历史"
+                        />
                         <Button
                             type="primary"
                             icon={<PlayCircleOutlined />}
@@ -164,25 +306,30 @@ const RedisCommandEditor: React.FC<RedisCommandEditorProps> = ({ connectionId, r
                     <div style={{ padding: 20, color: '#666', textAlign: 'center' }}>
                         输入 Redis 命令并按 Ctrl+Enter 执行
                         <br />
-                        <span style={{ fontSize: 12 }}>支持多行命令，每行一个命令</span>
+                        <span style={{ fontSize: 12 }}>支持多行命令，每行一个命令；FLUSHALL、KEYS 等危险命令执行前需要确认</span>
                     </div>
                 ) : (
-                    results.map((item, index) => (
-                        <div key={item.timestamp + index} style={{ padding: '8px 12px', borderBottom: '1px solid #333' }}>
-                            <div style={{ color: '#569cd6', marginBottom: 4 }}>
-                                &gt; {item.command}
-                            </div>
-                            {item.error ? (
-                                <div style={{ color: '#f14c4c', whiteSpace: 'pre-wrap' }}>
-                                    (error) {item.error}
-                                </div>
-                            ) : (
-                                <div style={{ color: '#ce9178', whiteSpace: 'pre-wrap' }}>
-                                    {formatResult(item.result)}
+                    results.map((item, index) => {
+                        const tag = item.info ? CATEGORY_TAGS[item.info.category] : undefined;
+                        return (
+                            <div key={item.timestamp + index} style={{ padding: '8px 12px', borderBottom: '1px solid #333' }}>
+                                <div style={{ color: '#569cd6', marginBottom: 4, display: 'flex', alignItems: 'center', gap: 8 }}>
+                                    <span style={{ flex: 1 }}>&gt; {item.command}</span>
+                                    {tag && <Tag color={tag.color} style={{ marginRight: 0 }}>{tag.label}</Tag>}
+                                    {item.durationMs !== undefined && <span style={{ color: '#666', fontSize: 12 }}>{item.durationMs} ms</span>}
                                 </div>
-                            )}
-                        </div>
-                    ))
+                                {item.error ? (
+                                    <div style={{ color: '#f14c4c', whiteSpace: 'pre-wrap' }}>
+                                        (error) {item.error}
+                                    </div>
+                                ) : (
+                                    <div style={{ color: item.reply?.type === 'error' ? '#f14c4c' : '#ce9178', whiteSpace: 'pre-wrap' }}>
+                                        {formatReply(item.reply)}
+                                    </div>
+                                )}
+                            </div>
+                        );
+                    })
                 )}
             </div>
 
@@ -190,7 +337,7 @@ const RedisCommandEditor: React.FC<RedisCommandEditorProps> = ({ connectionId, r
             <div style={{ padding: '8px 12px', borderTop: '1px solid #f0f0f0', background: '#fafafa', fontSize: 12, color: '#666' }}>
                 常用命令:
                 <span style={{ marginLeft: 8 }}>
-                    <code>KEYS *</code> |
+                    <code>SCAN 0 MATCH * COUNT 100</code> |
                     <code style={{ marginLeft: 8 }}>GET key</code> |
                     <code style={{ marginLeft: 8 }}>SET key value</code> |
                     <code style={{ marginLeft: 8 }}>HGETALL key</code> |
@@ -0,0 +1,187 @@
+import React, { useEffect, useState } from 'react';
+import { Modal, Form, Select, Button, Checkbox, Table, Tag, Alert, Typography, message } from 'antd';
+import { useStore } from '../store';
+import { DBGetDatabasesWithOptions, CompareSchemas, DBQueryScript } from '../../wailsjs/go/app/App';
+import { SavedConnection } from '../types';
+
+const { Text } = Typography;
+
+type SchemaDiffItem = { table: string; kind: string; name?: string; action: string; source?: string; target?: string };
+type SchemaCompareResult = {
+  items: SchemaDiffItem[];
+  statements: string[];
+  script: string;
+  warnings?: string[];
+  tablesCompared: number;
+  tablesIdentical: number;
+};
+
+const kindLabel: Record<string, string> = { table: '表', column: '列', index: '索引', foreignKey: '外键', primaryKey: '主键' };
+const actionTag: Record<string, { color: string; label: string }> = {
+  create: { color: 'green', label: '目标缺少' },
+  drop: { color: 'red', label: '目标多余' },
+  alter: { color: 'orange', label: '定义不同' },
+};
+
+const SchemaCompareModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open, onClose }) => {
+  const connections = useStore((state) => state.connections);
+  const [sourceConnId, setSourceConnId] = useState('');
+  const [targetConnId, setTargetConnId] = useState('');
+  const [sourceDb, setSourceDb] = useState('');
+  const [targetDb, setTargetDb] = useState('');
+  const [sourceDbs, setSourceDbs] = useState<string[]>([]);
+  const [targetDbs, setTargetDbs] = useState<string[]>([]);
+  const [dropExtra, setDropExtra] = useState(false);
+  const [comparing, setComparing] = useState(false);
+  const [applying, setApplying] = useState(false);
+  const [result, setResult] = useState<SchemaCompareResult | null>(null);
+  const [summary, setSummary] = useState('');
+
+  const connConfig = (conn: SavedConnection | undefined, database: string) => conn ? ({
+      ...conn.config,
+      port: Number((conn.config as any).port),
+      password: conn.config.password || "",
+      useSSH: conn.config.useSSH || false,
+      ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" },
+      database,
+  }) : null;
+
+  useEffect(() => {
+      if (!open) return;
+      setSourceConnId('');
+      setTargetConnId('');
+      setSourceDb('');
+      setTargetDb('');
+      setResult(null);
+      setSummary('');
+  }, [open]);
+
+  const loadDbs = async (connId: string, setDbs: (dbs: string[]) => void) => {
+      setDbs([]);
+      const conn = connections.find(c => c.id === connId);
+      if (!conn) return;
+      const res = await DBGetDatabasesWithOptions(connConfig(conn, conn.config.database || '') as any, (conn.databaseList || {}) as any);
+      if (res.success) setDbs((res.data as any[]).map((row: any) => row.Database || row.database));
+      else message.error(res.message);
+  };
+
+  useEffect(() => { if (sourceConnId) loadDbs(sourceConnId, setSourceDbs); }, [sourceConnId]);
+  useEffect(() => { if (targetConnId) loadDbs(targetConnId, setTargetDbs); }, [targetConnId]);
+  useEffect(() => { setResult(null); setSummary(''); }, [sourceConnId, targetConnId, sourceDb, targetDb, dropExtra]);
+
+  const handleCompare = async () => {
+      const source = connections.find(c => c.id === sourceConnId);
+      const target = connections.find(c => c.id === targetConnId);
+      if (!source || !target || !sourceDb || !targetDb) {
+          message.error('请选择源与目标连接及数据库');
+          return;
+      }
+      setComparing(true);
+      try {
+          const res = await CompareSchemas(connConfig(source, sourceDb) as any, connConfig(target, targetDb) as any, {
+              sourceDb, targetDb, dropExtra,
+          } as any);
+          if (!res.success) {
+              message.error(res.message);
+              return;
+          }
+          setResult(res.data as SchemaCompareResult);
+          setSummary(res.message);
+      } catch (e: any) {
+          message.error('结构比较失败：' + (e?.message || String(e)));
+      } finally {
+          setComparing(false);
+      }
+  };
+
+  const handleApply = () => {
+      const target = connections.find(c => c.id === targetConnId);
+      if (!target || !result?.script) return;
+      Modal.confirm({
+          title: '在目标库执行同步脚本',
+          content: `将在 ${target.name} / ${targetDb} 上依次执行 ${result.statements.length} 条语句，遇到错误即停止。执行后不可撤销，请确认已审阅脚本。`,
+          okText: '执行',
+          cancelText: '取消',
+          onOk: async () => {
+              setApplying(true);
+              try {
+                  const res = await DBQueryScript(connConfig(target, targetDb) as any, targetDb, result.script, { stopOnError: true } as any);
+                  if (res.success) {
+                      message.success('结构同步完成');
+                      handleCompare();
+                  } else {
+                      const failed = ((res.data as any)?.statements || []).find((s: any) => s.executed && !s.success);
+                      message.error(failed ? `第 ${failed.index + 1} 条语句执行失败：${failed.message}` : res.message);
+                  }
+              } finally {
+                  setApplying(false);
+              }
+          },
+      });
+  };
+
+  const connectionOptions = connections.map(c => ({ value: c.id, label: `${c.name} (${c.config.type})` }));
+
+  return (
+      <Modal
+          title="结构比较与同步"
+          open={open}
+          width={960}
+          onCancel={() => { if (!applying) onClose(); }}
+          maskClosable={false}
+          footer={[
+              <Button key="close" onClick={onClose} disabled={applying}>关闭</Button>,
+              <Button key="compare" loading={comparing} onClick={handleCompare} disabled={applying}>比较</Button>,
+              <Button key="apply" type="primary" danger loading={applying} disabled={!result?.script || comparing} onClick={handleApply}>在目标库执行</Button>,
+          ]}
+      >
+          <Form layout="vertical" disabled={comparing || applying}>
+              <div style={{ display: 'flex', gap: 16 }}>
+                  <Form.Item label="源连接（期望结构）" style={{ flex: 1 }}>
+                      <Select value={sourceConnId || undefined} onChange={(v) => { setSourceConnId(v); setSourceDb(''); }} options={connectionOptions} />
+                  </Form.Item>
+                  <Form.Item label="源数据库" style={{ flex: 1 }}>
+                      <Select value={sourceDb || undefined} onChange={setSourceDb} options={sourceDbs.map(d => ({ value: d, label: d }))} showSearch />
+                  </Form.Item>
+              </div>
+              <div style={{ display: 'flex', gap: 16 }}>
+                  <Form.Item label="目标连接（待同步）" style={{ flex: 1 }}>
+                      <Select value={targetConnId || undefined} onChange={(v) => { setTargetConnId(v); setTargetDb(''); }} options={connectionOptions} />
+                  </Form.Item>
+                  <Form.Item label="目标数据库" style={{ flex: 1 }}>
+                      <Select value={targetDb || undefined} onChange={setTargetDb} options={targetDbs.map(d => ({ value: d, label: d }))} showSearch />
+                  </Form.Item>
+              </div>
+              <Checkbox checked={dropExtra} onChange={(e) => setDropExtra(e.target.checked)}>
+                  删除目标端多余的表、列、索引与外键
+              </Checkbox>
+          </Form>
+
+          {summary && <Alert type={result && result.items.length > 0 ? 'warning' : 'success'} message={summary} style={{ margin: '12px 0' }} />}
+          {result && result.items.length > 0 && (
+              <Table
+                  size="small"
+                  rowKey={(r) => `${r.table}/${r.kind}/${r.name || ''}/${r.action}`}
+                  pagination={{ pageSize: 8, size: 'small' }}
+                  dataSource={result.items}
+                  columns={[
+                      { title: '表', dataIndex: 'table', width: 160 },
+                      { title: '对象', dataIndex: 'kind', width: 70, render: (v: string) => kindLabel[v] || v },
+                      { title: '名称', dataIndex: 'name', width: 140 },
+                      { title: '差异', dataIndex: 'action', width: 90, render: (v: string) => <Tag color={actionTag[v]?.color}>{actionTag[v]?.label || v}</Tag> },
+                      { title: '源', dataIndex: 'source', ellipsis: true },
+                      { title: '目标', dataIndex: 'target', ellipsis: true },
+                  ]}
+              />
+          )}
+          {(result?.warnings || []).map((w, i) => <div key={i}><Text type="warning">{w}</Text></div>)}
+          {result?.script && (
+              <pre style={{ marginTop: 12, maxHeight: 260, overflow: 'auto', background: '#f5f5f5', padding: 10, borderRadius: 4, border: '1px solid #eee', whiteSpace: 'pre-wrap' }}>
+                  {result.script}
+              </pre>
+          )}
+      </Modal>
+  );
+};
+
+export default SchemaCompareModal;
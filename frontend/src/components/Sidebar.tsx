@@ -1,5 +1,5 @@
 import React, { useEffect, useState, useMemo, useRef } from 'react';
-import { Tree, message, Dropdown, MenuProps, Input, Button, Modal, Form, Badge, Checkbox, Space, Select } from 'antd';
+import { Tree, message, Dropdown, MenuProps, Input, Button, Modal, Form, Badge, Checkbox, Space, Select, Table, Tag } from 'antd';
 	import {
 	  DatabaseOutlined,
 	  TableOutlined,
@@ -26,11 +26,17 @@ import { Tree, message, Dropdown, MenuProps, Input, Button, Modal, Form, Badge,
   DisconnectOutlined,
   CloudOutlined,
   CheckSquareOutlined,
-  CodeOutlined
+  CodeOutlined,
+  BugOutlined,
+  FieldNumberOutlined,
+  PlayCircleOutlined,
+  ClockCircleOutlined,
+  OrderedListOutlined
 	} from '@ant-design/icons';
 	import { useStore } from '../store';
 	import { SavedConnection } from '../types';
-	import { DBGetDatabases, DBGetTables, DBQuery, DBShowCreateTable, ExportTable, OpenSQLFile, CreateDatabase, RenameDatabase, DropDatabase, RenameTable, DropTable, DropView, DropFunction, RenameView } from '../../wailsjs/go/app/App';
+	import { DBGetDatabasesWithOptions, DBGetTables, DBQuery, DBShowCreateTable, ExportTableWithOptions, OpenSQLFile, CreateDatabase, RenameDatabase, DropDatabase, RenameTable, DropTable, DropView, DropFunction, RenameView, GetRoutineDefinition, CallRoutine, GetEvents, SetEventEnabled, DropEvent, GetSequences, CreateSequence, AlterSequence, RestartSequence, ReleaseTempWorkspace } from '../../wailsjs/go/app/App';
+	import { EventsOn } from '../../wailsjs/runtime/runtime';
   import { normalizeOpacityForPlatform } from '../utils/appearance';
 
 const { Search } = Input;
@@ -42,7 +48,7 @@ interface TreeNode {
   children?: TreeNode[];
   icon?: React.ReactNode;
   dataRef?: any;
-  type?: 'connection' | 'database' | 'table' | 'view' | 'db-trigger' | 'routine' | 'object-group' | 'queries-folder' | 'saved-query' | 'folder-columns' | 'folder-indexes' | 'folder-fks' | 'folder-triggers' | 'redis-db';
+  type?: 'connection' | 'database' | 'table' | 'view' | 'db-trigger' | 'routine' | 'db-event' | 'db-sequence' | 'object-group' | 'queries-folder' | 'saved-query' | 'folder-columns' | 'folder-indexes' | 'folder-fks' | 'folder-triggers' | 'redis-db';
 }
 
 type BatchTableExportMode = 'schema' | 'backup' | 'dataOnly';
@@ -56,6 +62,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
   const closeTabsByConnection = useStore(state => state.closeTabsByConnection);
   const closeTabsByDatabase = useStore(state => state.closeTabsByDatabase);
   const theme = useStore(state => state.theme);
+  const exportLocale = useStore(state => state.exportLocale);
   const appearance = useStore(state => state.appearance);
   const tableAccessCount = useStore(state => state.tableAccessCount);
   const tableSortPreference = useStore(state => state.tableSortPreference);
@@ -101,6 +108,46 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
   
   // Connection Status State: key -> 'success' | 'error'
   const [connectionStates, setConnectionStates] = useState<Record<string, 'success' | 'error'>>({});
+  // 启动连接测试或后台健康检查发现的连接错误信息，悬停连接节点时显示
+  const [connectionProbeErrors, setConnectionProbeErrors] = useState<Record<string, string>>({});
+
+  useEffect(() => {
+      const off = EventsOn('connection:status', (event: any) => {
+          const id = String(event?.id || '');
+          if (!id) return;
+          setConnectionStates(prev => ({ ...prev, [id]: event.reachable ? 'success' : 'error' }));
+          setConnectionProbeErrors(prev => {
+              const next = { ...prev };
+              if (event.reachable) delete next[id];
+              else next[id] = String(event.message || '连接失败');
+              return next;
+          });
+      });
+      return () => off();
+  }, []);
+
+  // 后台健康检查发现缓存连接断开时，提前把对应连接标记为异常
+  useEffect(() => {
+      const off = EventsOn('connection:lost', (event: any) => {
+          const lost = connections.filter(conn => conn.config.type === event?.type
+              && conn.config.host === event?.host
+              && Number(conn.config.port) === Number(event?.port)
+              && (conn.config.user || '') === (event?.user || ''));
+          if (lost.length === 0) return;
+          const reason = `连接已断开：${String(event?.message || '健康检查失败')}`;
+          setConnectionStates(prev => {
+              const next = { ...prev };
+              lost.forEach(conn => { next[conn.id] = 'error'; });
+              return next;
+          });
+          setConnectionProbeErrors(prev => {
+              const next = { ...prev };
+              lost.forEach(conn => { next[conn.id] = reason; });
+              return next;
+          });
+      });
+      return () => off();
+  }, [connections]);
 
   // Create Database Modal
   const [isCreateDbModalOpen, setIsCreateDbModalOpen] = useState(false);
@@ -112,6 +159,24 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
   const [isRenameTableModalOpen, setIsRenameTableModalOpen] = useState(false);
   const [renameTableForm] = Form.useForm();
   const [renameTableTarget, setRenameTableTarget] = useState<any>(null);
+  const [isArchiveModalOpen, setIsArchiveModalOpen] = useState(false);
+  const [archiveForm] = Form.useForm();
+  const [archiveTarget, setArchiveTarget] = useState<any>(null);
+  const [isSandboxModalOpen, setIsSandboxModalOpen] = useState(false);
+  const [sandboxForm] = Form.useForm();
+  const [sandboxTarget, setSandboxTarget] = useState<any>(null);
+  const [isIdentityModalOpen, setIsIdentityModalOpen] = useState(false);
+  const [identityForm] = Form.useForm();
+  const [identityTarget, setIdentityTarget] = useState<any>(null);
+  const [identityList, setIdentityList] = useState<any[]>([]);
+  const [isRoutineCallModalOpen, setIsRoutineCallModalOpen] = useState(false);
+  const [routineCallForm] = Form.useForm();
+  const [routineCallTarget, setRoutineCallTarget] = useState<any>(null);
+  const [routineCallDef, setRoutineCallDef] = useState<any>(null);
+  const [routineCallResult, setRoutineCallResult] = useState<any>(null);
+  const [routineCallRunning, setRoutineCallRunning] = useState(false);
+  const [sequenceModal, setSequenceModal] = useState<{ mode: 'create' | 'alter' | 'restart'; node: any; family: string } | null>(null);
+  const [sequenceForm] = Form.useForm();
   const [isRenameViewModalOpen, setIsRenameViewModalOpen] = useState(false);
   const [renameViewForm] = Form.useForm();
   const [renameViewTarget, setRenameViewTarget] = useState<any>(null);
@@ -605,6 +670,36 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
       return { triggers, supported: hasSuccessfulQuery };
   };
 
+  // MySQL 事件与 PostgreSQL 的 pg_cron 任务；PostgreSQL 未安装 pg_cron 时不显示分组
+  const loadEvents = async (conn: any, dbName: string): Promise<{ events: any[]; supported: boolean }> => {
+      const dialect = getMetadataDialect(conn as SavedConnection);
+      if ((dialect !== 'mysql' && dialect !== 'postgres') || isSphinxConnection(conn as SavedConnection)) {
+          return { events: [], supported: false };
+      }
+      try {
+          const res = await GetEvents(buildRuntimeConfig(conn, dbName) as any, dbName);
+          if (!res.success) return { events: [], supported: false };
+          return { events: Array.isArray(res.data) ? res.data : [], supported: true };
+      } catch (e) {
+          return { events: [], supported: false };
+      }
+  };
+
+  // PostgreSQL 系与 Oracle 的序列；其他数据源不显示序列分组
+  const loadSequences = async (conn: any, dbName: string): Promise<{ sequences: any[]; supported: boolean }> => {
+      const dialect = getMetadataDialect(conn as SavedConnection);
+      if (!['postgres', 'kingbase', 'highgo', 'vastbase', 'oracle'].includes(dialect)) {
+          return { sequences: [], supported: false };
+      }
+      try {
+          const res = await GetSequences(buildRuntimeConfig(conn, dbName) as any, dbName);
+          if (!res.success) return { sequences: [], supported: false };
+          return { sequences: Array.isArray(res.data) ? res.data : [], supported: true };
+      } catch (e) {
+          return { sequences: [], supported: false };
+      }
+  };
+
   const loadFunctions = async (
       conn: any,
       dbName: string
@@ -681,7 +776,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
           }
 
 	      try {
-	          const res = await DBGetDatabases(config as any);
+	          const res = await DBGetDatabasesWithOptions(config as any, (conn.databaseList || {}) as any);
 	          if (res.success) {
 	            setConnectionStates(prev => ({ ...prev, [conn.id]: 'success' }));
 	            let dbs = (res.data as any[]).map((row: any) => ({
@@ -757,10 +852,12 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
 	                };
 	            });
 
-            const [viewsResult, triggersResult, routinesResult] = await Promise.all([
+            const [viewsResult, triggersResult, routinesResult, eventsResult, sequencesResult] = await Promise.all([
                 loadViews(conn, conn.dbName),
                 loadDatabaseTriggers(conn, conn.dbName),
                 loadFunctions(conn, conn.dbName),
+                loadEvents(conn, conn.dbName),
+                loadSequences(conn, conn.dbName),
             ]);
 
             const viewEntries = viewsResult.views.map((viewName) => {
@@ -891,6 +988,24 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
 	                isLeaf: true,
 	            });
 
+	            const buildEventNode = (event: any): TreeNode => ({
+	                title: event.enabled ? event.name : `${event.name} (已禁用)`,
+	                key: `${conn.id}-${conn.dbName}-event-${event.id}`,
+	                icon: <ClockCircleOutlined />,
+	                type: 'db-event',
+	                dataRef: { ...conn, event },
+	                isLeaf: true,
+	            });
+
+	            const buildSequenceNode = (seq: any): TreeNode => ({
+	                title: seq.ownedBy ? `${seq.name} (${seq.ownedBy})` : seq.name,
+	                key: `${conn.id}-${conn.dbName}-sequence-${seq.schema}.${seq.name}`,
+	                icon: <OrderedListOutlined />,
+	                type: 'db-sequence',
+	                dataRef: { ...conn, sequence: seq, schemaName: seq.schema },
+	                isLeaf: true,
+	            });
+
 	            const buildObjectGroup = (
 	                parentKey: string,
 	                groupKey: string,
@@ -916,6 +1031,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
 	                    views: TreeNode[];
 	                    routines: TreeNode[];
 	                    triggers: TreeNode[];
+	                    sequences: TreeNode[];
 	                };
 
 	                const schemaMap = new Map<string, SchemaBucket>();
@@ -930,6 +1046,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
 	                            views: [],
 	                            routines: [],
 	                            triggers: [],
+	                            sequences: [],
 	                        };
 	                        schemaMap.set(schemaKey, bucket);
 	                    }
@@ -940,6 +1057,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
 	                viewEntries.forEach((entry) => getSchemaBucket(entry.schemaName).views.push(buildViewNode(entry)));
 	                routineEntries.forEach((entry) => getSchemaBucket(entry.schemaName).routines.push(buildRoutineNode(entry)));
 	                triggerEntries.forEach((entry) => getSchemaBucket(entry.schemaName).triggers.push(buildTriggerNode(entry)));
+	                sequencesResult.sequences.forEach((seq) => getSchemaBucket(seq.schema).sequences.push(buildSequenceNode(seq)));
 
 	                const schemaNodes: TreeNode[] = Array.from(schemaMap.values())
 	                    .sort((a, b) => {
@@ -957,6 +1075,9 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
 	                            buildObjectGroup(schemaNodeKey, 'routines', '函数', <CodeOutlined />, bucket.routines, { schemaName: bucket.schemaName }),
 	                            buildObjectGroup(schemaNodeKey, 'triggers', '触发器', <FunctionOutlined />, bucket.triggers, { schemaName: bucket.schemaName }),
 	                        ];
+	                        if (sequencesResult.supported) {
+	                            groupedNodes.push(buildObjectGroup(schemaNodeKey, 'sequences', '序列', <OrderedListOutlined />, bucket.sequences, { schemaName: bucket.schemaName }));
+	                        }
 
 	                        return {
 	                            title: schemaTitle,
@@ -969,7 +1090,10 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
 	                        };
 	                    });
 
-	                setTreeData(origin => updateTreeData(origin, key, [queriesNode, ...schemaNodes]));
+	                const eventNodes: TreeNode[] = eventsResult.supported
+	                    ? [buildObjectGroup(key as string, 'events', '定时任务 (pg_cron)', <ClockCircleOutlined />, eventsResult.events.map(buildEventNode))]
+	                    : [];
+	                setTreeData(origin => updateTreeData(origin, key, [queriesNode, ...schemaNodes, ...eventNodes]));
 	            } else {
 	                const groupedNodes: TreeNode[] = [
 	                    buildObjectGroup(key as string, 'tables', '表', <TableOutlined />, tableEntries.map(buildTableNode)),
@@ -977,6 +1101,9 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
 	                    buildObjectGroup(key as string, 'routines', '函数', <CodeOutlined />, routineEntries.map(buildRoutineNode)),
 	                    buildObjectGroup(key as string, 'triggers', '触发器', <FunctionOutlined />, triggerEntries.map(buildTriggerNode)),
 	                ];
+	                if (eventsResult.supported) {
+	                    groupedNodes.push(buildObjectGroup(key as string, 'events', '事件', <ClockCircleOutlined />, eventsResult.events.map(buildEventNode)));
+	                }
 
 	                setTreeData(origin => updateTreeData(origin, key, [queriesNode, ...groupedNodes]));
 	            }
@@ -1086,7 +1213,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
           setActiveContext({ connectionId: dataRef.id, dbName: title });
       } else if (type === 'table') {
           setActiveContext({ connectionId: dataRef.id, dbName: dataRef.dbName });
-      } else if (type === 'view' || type === 'db-trigger' || type === 'routine') {
+      } else if (type === 'view' || type === 'db-trigger' || type === 'routine' || type === 'db-event' || type === 'db-sequence') {
           setActiveContext({ connectionId: dataRef.id, dbName: dataRef.dbName });
       } else if (type === 'saved-query') {
           setActiveContext({ connectionId: dataRef.connectionId, dbName: dataRef.dbName });
@@ -1162,6 +1289,12 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
               triggerName
           });
           return;
+      } else if (node.type === 'db-sequence') {
+          openSequenceModal('alter', node);
+          return;
+      } else if (node.type === 'db-event') {
+          openEventDefinition(node);
+          return;
       } else if (node.type === 'routine') {
           const { routineName, routineType, dbName, id } = node.dataRef;
           const typeLabel = routineType === 'PROCEDURE' ? '存储过程' : '函数';
@@ -1208,14 +1341,14 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
   const handleExport = async (node: any, format: string) => {
       const { config, dbName, tableName } = node.dataRef;
       const hide = message.loading(`正在导出 ${tableName} 为 ${format.toUpperCase()}...`, 0);
-      const res = await ExportTable({ 
+      const res = await ExportTableWithOptions({ 
           ...config, 
           port: Number(config.port),
           password: config.password || "",
           database: config.database || "",
           useSSH: config.useSSH || false,
           ssh: config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" }
-      } as any, dbName, tableName, format);
+      } as any, dbName, tableName, format, { binaryAsFiles: false, locale: exportLocale } as any);
       hide();
       if (res.success) {
           message.success('导出成功');
@@ -1251,6 +1384,117 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
       }
   };
 
+  const handleBackupDatabase = async (node: any) => {
+      const conn = node.dataRef;
+      const dbName = conn.dbName || node.title;
+      const jobId = `backup-${Date.now()}`;
+      const key = `backup-${dbName}`;
+      message.loading({ content: `正在备份数据库 ${dbName}...`, key, duration: 0 });
+      const off = EventsOn('backup:progress', (event: any) => {
+          if (event?.jobId !== jobId || !event.object) return;
+          message.loading({ content: `正在备份 ${dbName}：${event.current}/${event.total} ${event.object}（${event.stage}，已写入 ${event.rows} 行）`, key, duration: 0 });
+      });
+      try {
+          const res = await (window as any).go.app.App.BackupDatabase(normalizeConnConfig(conn.config), dbName, {
+              includeData: true,
+              dropIfExists: true,
+              includeViews: true,
+              includeRoutines: true,
+              includeTriggers: true,
+              gzip: true,
+              jobId,
+          });
+          if (res.success) {
+              message.success({ content: res.message || '备份完成', key });
+          } else if (res.message === 'Cancelled') {
+              message.destroy(key);
+          } else {
+              message.error({ content: '备份失败: ' + res.message, key });
+          }
+      } catch (e: any) {
+          message.error({ content: '备份失败: ' + (e?.message || String(e)), key });
+      } finally {
+          off();
+      }
+  };
+
+  const handleExecuteSQLFile = async (node: any) => {
+      const conn = node.dataRef;
+      const dbName = conn.dbName || node.title;
+      const jobId = `sqlfile-${Date.now()}`;
+      const key = `sqlfile-${dbName}`;
+      const off = EventsOn('sqlfile:progress', (event: any) => {
+          if (event?.jobId !== jobId) return;
+          const percent = event.totalBytes > 0 ? Math.floor((event.bytesRead / event.totalBytes) * 100) : 0;
+          message.loading({ content: `正在执行 SQL 文件：${percent}%（已执行 ${event.statements} 条，失败 ${event.failed} 条）`, key, duration: 0 });
+      });
+      try {
+          const res = await (window as any).go.app.App.ExecuteSQLFileWithOptions(normalizeConnConfig(conn.config), dbName, '', {
+              jobId,
+              continueOnError: true,
+          });
+          const failures = (res.data?.errors || []).slice(0, 3).map((e: any) => `第 ${e.line} 行：${e.message}`).join('；');
+          if (res.success) {
+              message.success({ content: res.message || '执行完成', key });
+          } else if (res.message === 'Cancelled') {
+              message.destroy(key);
+          } else {
+              message.error({ content: (res.message || '执行失败') + (failures ? `。${failures}` : ''), key, duration: 8 });
+          }
+      } catch (e: any) {
+          message.error({ content: '执行失败: ' + (e?.message || String(e)), key });
+      } finally {
+          off();
+      }
+  };
+
+  const handleDumpWithTool = async (node: any) => {
+      const conn = node.dataRef;
+      const dbName = conn.dbName || node.title;
+      const jobId = `dumptool-${Date.now()}`;
+      const key = `dumptool-${dbName}`;
+      message.loading({ content: `正在调用外部工具导出 ${dbName}...`, key, duration: 0 });
+      const off = EventsOn('dumptool:progress', (event: any) => {
+          if (event?.jobId !== jobId || !event.message) return;
+          const size = event.bytes > 0 ? `（已写入 ${(event.bytes / 1024 / 1024).toFixed(1)} MB）` : '';
+          message.loading({ content: `${event.message}${size}`, key, duration: 0 });
+      });
+      try {
+          const res = await (window as any).go.app.App.DumpDatabaseWithTool(normalizeConnConfig(conn.config), dbName, { jobId });
+          if (res.success) {
+              message.success({ content: res.message || '导出完成', key });
+          } else if (res.message === 'Cancelled') {
+              message.destroy(key);
+          } else {
+              message.error({ content: '导出失败: ' + res.message, key, duration: 8 });
+          }
+      } catch (e: any) {
+          message.error({ content: '导出失败: ' + (e?.message || String(e)), key });
+      } finally {
+          off();
+      }
+  };
+
+  const handleToggleWireDebug = async (node: any) => {
+      const config = normalizeConnConfig(node.dataRef.config);
+      try {
+          const status = await (window as any).go.app.App.GetWireDebugStatus(config);
+          const enable = !status?.data?.enabled;
+          const res = await (window as any).go.app.App.SetWireDebug(config, enable);
+          if (!res.success) {
+              message.error(res.message || '切换调试日志失败');
+              return;
+          }
+          if (enable) {
+              message.success(`已开启调试语句日志，文件：${res.data?.path}`, 6);
+          } else {
+              message.success('已关闭调试语句日志');
+          }
+      } catch (e: any) {
+          message.error('切换调试日志失败: ' + (e?.message || String(e)));
+      }
+  };
+
   const handleExportTablesSQL = async (nodes: any[], includeData: boolean) => {
       if (!nodes || nodes.length === 0) return;
       const first = nodes[0].dataRef;
@@ -1323,7 +1567,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
           ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" }
       };
 
-      const res = await DBGetDatabases(config as any);
+      const res = await DBGetDatabasesWithOptions(config as any, (conn.databaseList || {}) as any);
       if (res.success) {
           let dbs = (res.data as any[]).map((row: any) => {
               const dbName = row.Database || row.database;
@@ -1486,7 +1730,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
           ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" }
       };
 
-      const res = await DBGetDatabases(config as any);
+      const res = await DBGetDatabasesWithOptions(config as any, (conn.databaseList || {}) as any);
       if (res.success) {
           let dbs = (res.data as any[]).map((row: any) => {
               const dbName = row.Database || row.database;
@@ -1730,6 +1974,177 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
       }
   };
 
+  const handleArchiveRows = async () => {
+      if (!archiveTarget) return;
+      let values: any;
+      try {
+          values = await archiveForm.validateFields();
+      } catch (e) {
+          return;
+      }
+      const conn = archiveTarget.dataRef;
+      const tableName = String(conn.tableName || '').trim();
+      const config = buildRuntimeConfig(conn, conn.dbName);
+      const request = {
+          table: tableName,
+          dateColumn: String(values.dateColumn || '').trim(),
+          cutoff: String(values.cutoff || '').trim(),
+          targetTable: String(values.targetTable || '').trim(),
+          batchSize: Number(values.batchSize) || 0,
+          createTarget: !!values.createTarget,
+      };
+      const preview = await (window as any).go.app.App.ArchiveRows(config, conn.dbName, { ...request, dryRun: true });
+      if (!preview.success) {
+          message.error('归档预检失败: ' + preview.message);
+          return;
+      }
+      if (!preview.data?.matched) {
+          message.info('没有需要归档的行');
+          return;
+      }
+      Modal.confirm({
+          title: '确认归档',
+          content: `${preview.message}。条件：${preview.data.condition}。数据写入归档表并校验后将从 ${tableName} 删除，是否继续？`,
+          okButtonProps: { danger: true },
+          onOk: async () => {
+              const jobId = `archive-${Date.now()}`;
+              const key = `archive-${tableName}`;
+              message.loading({ content: `正在归档 ${tableName}...`, key, duration: 0 });
+              const off = EventsOn('archive:progress', (event: any) => {
+                  if (event?.jobId !== jobId) return;
+                  message.loading({ content: `正在归档 ${tableName}：${event.deleted}/${event.matched} 行`, key, duration: 0 });
+              });
+              try {
+                  const res = await (window as any).go.app.App.ArchiveRows(config, conn.dbName, { ...request, jobId });
+                  if (res.success) {
+                      message.success({ content: res.message, key });
+                      setIsArchiveModalOpen(false);
+                      setArchiveTarget(null);
+                      archiveForm.resetFields();
+                      if (res.data?.created) {
+                          await loadTables(getDatabaseNodeRef(conn, conn.dbName));
+                      }
+                  } else {
+                      message.error({ content: '归档失败: ' + res.message, key, duration: 10 });
+                  }
+              } finally {
+                  off();
+              }
+          }
+      });
+  };
+
+  const closeSandboxModal = () => {
+      setIsSandboxModalOpen(false);
+      setSandboxTarget(null);
+      sandboxForm.resetFields();
+  };
+
+  const handleCreateSandbox = async () => {
+      if (!sandboxTarget) return;
+      let values: any;
+      try {
+          values = await sandboxForm.validateFields();
+      } catch (e) {
+          return;
+      }
+      const conn = sandboxTarget.dataRef;
+      const tableName = String(conn.tableName || '').trim();
+      const key = `sandbox-${tableName}`;
+      message.loading({ content: `正在创建 ${tableName} 的沙箱副本...`, key, duration: 0 });
+      const res = await (window as any).go.app.App.CreateSandboxTable(buildRuntimeConfig(conn, conn.dbName), conn.dbName, tableName, {
+          schema: String(values.schema || '').trim(),
+          tableName: String(values.tableName || '').trim(),
+          sampleRows: Number(values.sampleRows) || 0,
+          random: !!values.random,
+          replace: !!values.replace,
+      });
+      if (!res.success) {
+          message.error({ content: '创建沙箱副本失败: ' + res.message, key, duration: 10 });
+          return;
+      }
+      message.success({ content: res.message, key });
+      (res.data?.warnings || []).forEach((w: string) => message.warning(w));
+      closeSandboxModal();
+      await loadTables(getDatabaseNodeRef(conn, conn.dbName));
+      addTab({
+          id: `query-${Date.now()}`,
+          title: `沙箱 ${res.data.table}`,
+          type: 'query',
+          connectionId: conn.id,
+          dbName: conn.dbName,
+          query: `SELECT * FROM ${res.data.table};\n`
+      });
+  };
+
+  const openIdentityModal = async (node: any) => {
+      const conn = node.dataRef;
+      const tableName = String(conn.tableName || '').trim();
+      const res = await (window as any).go.app.App.GetTableIdentity(buildRuntimeConfig(conn, conn.dbName), conn.dbName, tableName);
+      if (!res.success) {
+          message.error('读取自增信息失败: ' + res.message);
+          return;
+      }
+      const list = Array.isArray(res.data) ? res.data : [];
+      if (list.length === 0) {
+          message.info('该表没有自增列或序列');
+          return;
+      }
+      const first = list[0];
+      setIdentityList(list);
+      setIdentityTarget(node);
+      identityForm.setFieldsValue({
+          column: first.column,
+          nextValue: first.maxValue != null ? Math.max(first.maxValue + 1, first.nextValue ?? 1) : (first.nextValue ?? 1),
+          force: false,
+      });
+      setIsIdentityModalOpen(true);
+  };
+
+  const closeIdentityModal = () => {
+      setIsIdentityModalOpen(false);
+      setIdentityTarget(null);
+      setIdentityList([]);
+      identityForm.resetFields();
+  };
+
+  const handleResetIdentity = async () => {
+      if (!identityTarget) return;
+      let values: any;
+      try {
+          values = await identityForm.validateFields();
+      } catch (e) {
+          return;
+      }
+      const conn = identityTarget.dataRef;
+      const config = buildRuntimeConfig(conn, conn.dbName);
+      const request = {
+          table: String(conn.tableName || '').trim(),
+          column: values.column,
+          nextValue: Number(values.nextValue),
+          force: !!values.force,
+      };
+      const preview = await (window as any).go.app.App.ResetTableIdentity(config, conn.dbName, { ...request, dryRun: true });
+      if (!preview.success) {
+          message.error(preview.message);
+          return;
+      }
+      Modal.confirm({
+          title: '确认重置自增值',
+          content: `将执行：${preview.message}`,
+          okButtonProps: { danger: request.force },
+          onOk: async () => {
+              const res = await (window as any).go.app.App.ResetTableIdentity(config, conn.dbName, request);
+              if (res.success) {
+                  message.success(res.message);
+                  closeIdentityModal();
+              } else {
+                  message.error('重置失败: ' + res.message);
+              }
+          }
+      });
+  };
+
   const handleDeleteTable = (node: any) => {
       const conn = node.dataRef;
       const tableName = String(conn.tableName || '').trim();
@@ -2047,6 +2462,168 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
       });
   };
 
+  // --- 序列操作 ---
+  const openSequenceModal = (mode: 'create' | 'alter' | 'restart', node: any) => {
+      const family = getMetadataDialect(node.dataRef as SavedConnection) === 'oracle' ? 'oracle' : 'postgres';
+      const seq = node.dataRef.sequence || {};
+      sequenceForm.resetFields();
+      if (mode === 'create') {
+          sequenceForm.setFieldsValue({ name: '', dataType: family === 'postgres' ? 'bigint' : undefined, startValue: '1', increment: '1', cycle: false });
+      } else if (mode === 'alter') {
+          sequenceForm.setFieldsValue({ ...seq });
+      } else {
+          sequenceForm.setFieldsValue({ value: seq.startValue || '' });
+      }
+      setSequenceModal({ mode, node, family });
+  };
+
+  const handleSequenceSubmit = async () => {
+      if (!sequenceModal) return;
+      let values: any;
+      try {
+          values = await sequenceForm.validateFields();
+      } catch (e) {
+          return;
+      }
+      const { mode, node, family } = sequenceModal;
+      const conn = node.dataRef;
+      const config = buildRuntimeConfig(conn, conn.dbName) as any;
+      const trimmed = (v: any) => String(v ?? '').trim();
+      const base = mode === 'create'
+          ? { schema: trimmed(conn.schemaName) || (family === 'oracle' ? String(conn.dbName || '').toUpperCase() : 'public'), name: trimmed(values.name) }
+          : { schema: conn.sequence.schema, name: conn.sequence.name };
+      let res: any;
+      if (mode === 'restart') {
+          res = await RestartSequence(config, conn.dbName, base as any, trimmed(values.value));
+      } else {
+          const seq = {
+              ...base,
+              dataType: trimmed(values.dataType),
+              // Oracle 不能修改起始值
+              startValue: mode === 'alter' && family === 'oracle' ? '' : trimmed(values.startValue),
+              increment: trimmed(values.increment),
+              minValue: trimmed(values.minValue),
+              maxValue: trimmed(values.maxValue),
+              cache: trimmed(values.cache),
+              cycle: !!values.cycle,
+          };
+          res = mode === 'create'
+              ? await CreateSequence(config, conn.dbName, seq as any)
+              : await AlterSequence(config, conn.dbName, seq as any);
+      }
+      if (!res.success) {
+          message.error(res.message);
+          return;
+      }
+      message.success(res.message);
+      setSequenceModal(null);
+      await loadTables(getDatabaseNodeRef(conn, conn.dbName));
+  };
+
+  // --- 定时任务（MySQL 事件 / pg_cron）操作 ---
+  const openEventDefinition = (node: any) => {
+      const { event, dbName, id } = node.dataRef;
+      const lines = [
+          `-- 定时任务: ${event.schema ? `${event.schema}.` : ''}${event.name}`,
+          `-- 调度: ${event.schedule}${event.starts ? ` STARTS ${event.starts}` : ''}${event.ends ? ` ENDS ${event.ends}` : ''}`,
+          `-- 状态: ${event.status}${event.lastExecuted ? `，上次执行 ${event.lastExecuted}` : ''}`,
+      ];
+      if (event.database) lines.push(`-- 执行库: ${event.database}${event.owner ? `，用户 ${event.owner}` : ''}`);
+      if (event.comment) lines.push(`-- 注释: ${event.comment}`);
+      addTab({
+          id: `event-def-${node.key}`,
+          title: `定时任务: ${event.name}`,
+          type: 'query',
+          connectionId: id,
+          dbName,
+          query: `${lines.join('\n')}\n${event.definition || ''}`
+      });
+  };
+
+  const handleToggleEvent = async (node: any) => {
+      const conn = node.dataRef;
+      const event = conn.event;
+      const res = await SetEventEnabled(buildRuntimeConfig(conn, conn.dbName) as any, conn.dbName, event, !event.enabled);
+      if (res.success) {
+          message.success(res.message);
+          await loadTables(getDatabaseNodeRef(conn, conn.dbName));
+      } else {
+          message.error(res.message);
+      }
+  };
+
+  const handleDropEvent = (node: any) => {
+      const conn = node.dataRef;
+      const event = conn.event;
+      Modal.confirm({
+          title: '确认删除定时任务',
+          content: `确定删除定时任务 "${event.name}" 吗？该操作不可恢复。`,
+          okButtonProps: { danger: true },
+          onOk: async () => {
+              const res = await DropEvent(buildRuntimeConfig(conn, conn.dbName) as any, conn.dbName, event);
+              if (res.success) {
+                  message.success(res.message);
+                  await loadTables(getDatabaseNodeRef(conn, conn.dbName));
+              } else {
+                  message.error("删除失败: " + res.message);
+              }
+          }
+      });
+  };
+
+  const openRoutineCall = async (node: any) => {
+      const conn = node.dataRef;
+      const routineType = String(conn.routineType || 'FUNCTION').trim();
+      const parsed = splitQualifiedName(String(conn.routineName || ''));
+      const res = await GetRoutineDefinition(buildRuntimeConfig(conn, conn.dbName) as any, conn.dbName, {
+          id: '',
+          schema: parsed.schemaName,
+          name: parsed.objectName,
+          type: routineType,
+      } as any);
+      if (!res.success) {
+          message.error('读取参数失败: ' + res.message);
+          return;
+      }
+      setRoutineCallTarget(node);
+      setRoutineCallDef(res.data);
+      setRoutineCallResult(null);
+      routineCallForm.resetFields();
+      setIsRoutineCallModalOpen(true);
+  };
+
+  const closeRoutineCallModal = () => {
+      setIsRoutineCallModalOpen(false);
+      setRoutineCallTarget(null);
+      setRoutineCallDef(null);
+      setRoutineCallResult(null);
+  };
+
+  const handleCallRoutine = async () => {
+      if (!routineCallTarget || !routineCallDef) return;
+      const conn = routineCallTarget.dataRef;
+      const values = routineCallForm.getFieldsValue();
+      const args: Record<string, any> = {};
+      (routineCallDef.parameters || []).forEach((p: any, idx: number) => {
+          if (p.mode === 'OUT') return;
+          const field = values[`p${idx}`];
+          // 未勾选 NULL 时空输入按空字符串传入
+          args[p.name] = values[`null${idx}`] ? null : (field ?? '');
+      });
+      setRoutineCallRunning(true);
+      try {
+          const res = await CallRoutine(buildRuntimeConfig(conn, conn.dbName) as any, conn.dbName, { routine: routineCallDef, args } as any);
+          setRoutineCallResult(res.data || null);
+          if (res.success) {
+              message.success(res.message);
+          } else {
+              message.error('执行失败: ' + res.message);
+          }
+      } finally {
+          setRoutineCallRunning(false);
+      }
+  };
+
   const handleDropRoutine = (node: any) => {
       const conn = node.dataRef;
       const routineName = String(conn.routineName || '').trim();
@@ -2150,6 +2727,17 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
         ];
     }
 
+    if (node.type === 'object-group' && node.dataRef?.groupKey === 'sequences') {
+        return [
+            {
+                key: 'create-sequence',
+                label: '新建序列',
+                icon: <PlusOutlined />,
+                onClick: () => openSequenceModal('create', node)
+            },
+        ];
+    }
+
     // 函数分组节点的右键菜单
     if (node.type === 'object-group' && node.dataRef?.groupKey === 'routines') {
         const dialect = getMetadataDialect(node.dataRef as SavedConnection);
@@ -2224,6 +2812,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
                         setLoadedKeys(prev => prev.filter(k => k !== node.key && !k.toString().startsWith(`${node.key}-`)));
                         setTreeData(origin => updateTreeData(origin, node.key, undefined));
                         closeTabsByConnection(String(node.key));
+                        ReleaseTempWorkspace(buildRuntimeConfig(node.dataRef, undefined, true) as any).catch(() => {});
                         message.success("已断开连接");
                     }
                 },
@@ -2288,6 +2877,26 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
                      if (onEditConnection) onEditConnection(node.dataRef);
                  }
              },
+             {
+                 key: 'clone',
+                 label: '复制连接',
+                 icon: <CopyOutlined />,
+                 onClick: async () => {
+                     const res = await (window as any).go.app.App.CloneConnection(String(node.key), {});
+                     if (!res?.success) {
+                         message.error(res?.message || '复制连接失败');
+                         return;
+                     }
+                     useStore.getState().addConnection(res.data);
+                     message.success(`已复制为 "${res.data.name}"`);
+                 }
+             },
+             {
+                 key: 'wire-debug',
+                 label: '开启/关闭调试语句日志',
+                 icon: <BugOutlined />,
+                 onClick: () => handleToggleWireDebug(node)
+             },
              {
                  key: 'disconnect',
                  label: '断开连接',
@@ -2310,6 +2919,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
                      // Clear children (undefined to trigger reload)
                      setTreeData(origin => updateTreeData(origin, node.key, undefined));
                      closeTabsByConnection(String(node.key));
+                     ReleaseTempWorkspace(buildRuntimeConfig(node.dataRef, undefined, true) as any).catch(() => {});
                      message.success("已断开连接");
                  }
              },
@@ -2406,6 +3016,24 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
                icon: <SaveOutlined />,
                onClick: () => handleExportDatabaseSQL(node, true)
            },
+           {
+               key: 'execute-sql-file',
+               label: '运行 SQL 文件...',
+               icon: <ConsoleSqlOutlined />,
+               onClick: () => handleExecuteSQLFile(node)
+           },
+           ...(['mysql', 'mariadb', 'diros', 'postgres', 'kingbase', 'highgo', 'vastbase', 'sqlite'].includes(String(node.dataRef?.config?.type || '').toLowerCase()) ? [{
+               key: 'backup-db-full',
+               label: '完整备份 (含视图/存储过程/触发器, gzip)',
+               icon: <SaveOutlined />,
+               onClick: () => handleBackupDatabase(node)
+           }] : []),
+           ...(['mysql', 'mariadb', 'diros', 'postgres'].includes(String(node.dataRef?.config?.type || '').toLowerCase()) ? [{
+               key: 'dump-db-tool',
+               label: String(node.dataRef?.config?.type || '').toLowerCase() === 'postgres' ? '使用 pg_dump 导出' : '使用 mysqldump 导出',
+               icon: <ExportOutlined />,
+               onClick: () => handleDumpWithTool(node)
+           }] : []),
            { type: 'divider' },
            {
                key: 'disconnect-db',
@@ -2505,6 +3133,45 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
                 onClick: () => handleDropView(node)
             },
         ];
+    } else if (node.type === 'db-sequence') {
+        return [
+            {
+                key: 'alter-sequence',
+                label: '修改序列...',
+                icon: <EditOutlined />,
+                onClick: () => openSequenceModal('alter', node)
+            },
+            {
+                key: 'restart-sequence',
+                label: '重置当前值...',
+                icon: <ReloadOutlined />,
+                onClick: () => openSequenceModal('restart', node)
+            },
+        ];
+    } else if (node.type === 'db-event') {
+        const enabled = !!node.dataRef?.event?.enabled;
+        return [
+            {
+                key: 'view-event-def',
+                label: '查看定义',
+                icon: <CodeOutlined />,
+                onClick: () => openEventDefinition(node)
+            },
+            {
+                key: 'toggle-event',
+                label: enabled ? '禁用' : '启用',
+                icon: <ClockCircleOutlined />,
+                onClick: () => handleToggleEvent(node)
+            },
+            { type: 'divider' },
+            {
+                key: 'drop-event',
+                label: '删除定时任务',
+                icon: <DeleteOutlined />,
+                danger: true,
+                onClick: () => handleDropEvent(node)
+            },
+        ];
     } else if (node.type === 'routine') {
         const routineType = node.dataRef?.routineType || 'FUNCTION';
         const typeLabel = routineType === 'PROCEDURE' ? '存储过程' : '函数';
@@ -2521,6 +3188,12 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
                 icon: <EditOutlined />,
                 onClick: () => openEditRoutine(node)
             },
+            {
+                key: 'call-routine',
+                label: '执行...',
+                icon: <PlayCircleOutlined />,
+                onClick: () => openRoutineCall(node)
+            },
             { type: 'divider' },
             {
                 key: 'drop-routine',
@@ -2576,6 +3249,36 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
                     setIsRenameTableModalOpen(true);
                 }
             },
+            {
+                key: 'archive-rows',
+                label: '归档旧数据...',
+                icon: <SaveOutlined />,
+                onClick: () => {
+                    setArchiveTarget(node);
+                    archiveForm.setFieldsValue({
+                        targetTable: `${extractObjectName(node.dataRef?.tableName || node.title)}_archive`,
+                        batchSize: 1000,
+                        createTarget: true,
+                    });
+                    setIsArchiveModalOpen(true);
+                }
+            },
+            {
+                key: 'sandbox-table',
+                label: '创建沙箱副本...',
+                icon: <CopyOutlined />,
+                onClick: () => {
+                    setSandboxTarget(node);
+                    sandboxForm.setFieldsValue({ schema: 'gonavi_sandbox', tableName: '', sampleRows: 1000, random: true, replace: false });
+                    setIsSandboxModalOpen(true);
+                }
+            },
+            {
+                key: 'table-identity',
+                label: '自增值管理...',
+                icon: <FieldNumberOutlined />,
+                onClick: () => { void openIdentityModal(node); }
+            },
             {
                 key: 'drop-table',
                 label: '删除表',
@@ -2595,6 +3298,7 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
                     { key: 'export-xlsx', label: '导出 Excel (XLSX)', onClick: () => handleExport(node, 'xlsx') },
                     { key: 'export-json', label: '导出 JSON', onClick: () => handleExport(node, 'json') },
                     { key: 'export-md', label: '导出 Markdown', onClick: () => handleExport(node, 'md') },
+                    { key: 'export-geojson', label: '导出 GeoJSON', onClick: () => handleExport(node, 'geojson') },
                 ]
             }
         ];
@@ -2615,6 +3319,9 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
 
     const displayTitle = String(node.title ?? '');
     let hoverTitle = displayTitle;
+    if (node.type === 'connection' && status === 'error' && connectionProbeErrors[node.key]) {
+        hoverTitle = `${displayTitle}\n不可达：${connectionProbeErrors[node.key]}`;
+    }
     if (node.type === 'table' || node.type === 'view') {
         const rawTableName = String(node?.dataRef?.tableName || node?.dataRef?.viewName || '').trim();
         const conn = node?.dataRef as SavedConnection | undefined;
@@ -2745,6 +3452,211 @@ const Sidebar: React.FC<{ onEditConnection?: (conn: SavedConnection) => void }>
             </Form>
         </Modal>
 
+        <Modal
+            title={`归档旧数据${archiveTarget?.dataRef?.tableName ? ` (${archiveTarget.dataRef.tableName})` : ''}`}
+            open={isArchiveModalOpen}
+            onOk={handleArchiveRows}
+            okText="预检并归档"
+            onCancel={() => {
+                setIsArchiveModalOpen(false);
+                setArchiveTarget(null);
+                archiveForm.resetFields();
+            }}
+        >
+            <Form form={archiveForm} layout="vertical">
+                <Form.Item name="dateColumn" label="日期列" rules={[{ required: true, message: '请输入日期列' }]}>
+                    <Input placeholder="例如 created_at" />
+                </Form.Item>
+                <Form.Item name="cutoff" label="截止时间（早于该时间的行将被归档）" rules={[{ required: true, message: '请输入截止时间' }]}>
+                    <Input placeholder="2024-01-01 或 2024-01-01 00:00:00" />
+                </Form.Item>
+                <Form.Item name="targetTable" label="归档表">
+                    <Input />
+                </Form.Item>
+                <Form.Item name="batchSize" label="每批行数">
+                    <Input type="number" min={1} />
+                </Form.Item>
+                <Form.Item name="createTarget" valuePropName="checked">
+                    <Checkbox>归档表不存在时按源表结构创建</Checkbox>
+                </Form.Item>
+            </Form>
+        </Modal>
+
+        <Modal
+            title={`创建沙箱副本${sandboxTarget?.dataRef?.tableName ? ` (${sandboxTarget.dataRef.tableName})` : ''}`}
+            open={isSandboxModalOpen}
+            onOk={handleCreateSandbox}
+            okText="创建"
+            onCancel={closeSandboxModal}
+        >
+            <div style={{ marginBottom: 12, color: '#888' }}>
+                复制表结构（列、非空与主键）并抽样复制部分数据，可在副本上放心演练 UPDATE/DELETE 等语句。
+            </div>
+            <Form form={sandboxForm} layout="vertical">
+                <Form.Item name="schema" label="沙箱库/Schema（不存在时自动创建；SQLite、Oracle 与源表放在一起）">
+                    <Input />
+                </Form.Item>
+                <Form.Item name="tableName" label="沙箱表名">
+                    <Input placeholder="留空沿用源表名" />
+                </Form.Item>
+                <Form.Item name="sampleRows" label="抽样行数" rules={[{ required: true, message: '请输入抽样行数' }]}>
+                    <Input type="number" min={1} max={100000} />
+                </Form.Item>
+                <Form.Item name="random" valuePropName="checked" style={{ marginBottom: 4 }}>
+                    <Checkbox>随机抽样（否则取前 N 行）</Checkbox>
+                </Form.Item>
+                <Form.Item name="replace" valuePropName="checked">
+                    <Checkbox>沙箱表已存在时先删除</Checkbox>
+                </Form.Item>
+            </Form>
+        </Modal>
+
+        <Modal
+            title={`执行${routineCallDef?.type === 'PROCEDURE' ? '存储过程' : '函数'}${routineCallDef?.name ? ` (${routineCallDef.name})` : ''}`}
+            open={isRoutineCallModalOpen}
+            onOk={handleCallRoutine}
+            okText="执行"
+            confirmLoading={routineCallRunning}
+            onCancel={closeRoutineCallModal}
+            width={760}
+        >
+            <Form form={routineCallForm} layout="vertical">
+                {(routineCallDef?.parameters || []).length === 0 && (
+                    <div style={{ marginBottom: 12, color: '#888' }}>没有参数</div>
+                )}
+                {(routineCallDef?.parameters || []).map((p: any, idx: number) => p.mode === 'OUT' ? (
+                    <div key={idx} style={{ marginBottom: 8, color: '#888' }}>
+                        <Tag>OUT</Tag>{p.name}（{p.type}）执行后返回
+                    </div>
+                ) : (
+                    <Form.Item key={idx} label={<span><Tag>{p.mode}</Tag>{p.name}（{p.type}）</span>} style={{ marginBottom: 8 }}>
+                        <Space.Compact style={{ width: '100%' }}>
+                            <Form.Item name={`p${idx}`} noStyle>
+                                <Input />
+                            </Form.Item>
+                            <Form.Item name={`null${idx}`} valuePropName="checked" noStyle>
+                                <Checkbox style={{ marginLeft: 8, whiteSpace: 'nowrap' }}>NULL</Checkbox>
+                            </Form.Item>
+                        </Space.Compact>
+                    </Form.Item>
+                ))}
+            </Form>
+            {routineCallResult && (
+                <div style={{ marginTop: 12 }}>
+                    <div style={{ marginBottom: 8, fontFamily: 'monospace', whiteSpace: 'pre-wrap', color: '#888' }}>{routineCallResult.sql}</div>
+                    {routineCallResult.returnValue !== undefined && routineCallResult.returnValue !== null && (
+                        <div style={{ marginBottom: 8 }}>返回值：{String(routineCallResult.returnValue)}</div>
+                    )}
+                    {Object.entries(routineCallResult.outParams || {}).map(([name, value]) => (
+                        <div key={name} style={{ marginBottom: 4 }}><Tag>OUT</Tag>{name} = {value === null || value === undefined ? 'NULL' : String(value)}</div>
+                    ))}
+                    {(routineCallResult.resultSets || []).map((set: any, setIdx: number) => (
+                        <Table
+                            key={setIdx}
+                            size="small"
+                            style={{ marginTop: 8 }}
+                            title={() => `结果集 ${setIdx + 1}（${(set.rows || []).length} 行）`}
+                            dataSource={(set.rows || []).map((row: any, i: number) => ({ ...row, __key: i }))}
+                            rowKey="__key"
+                            pagination={{ pageSize: 10, size: 'small' }}
+                            scroll={{ x: 'max-content' }}
+                            columns={(set.columns || []).map((col: string) => ({
+                                title: col,
+                                dataIndex: col,
+                                key: col,
+                                render: (v: any) => v === null || v === undefined ? 'NULL' : String(v),
+                            }))}
+                        />
+                    ))}
+                </div>
+            )}
+        </Modal>
+
+        <Modal
+            title={sequenceModal?.mode === 'create' ? '新建序列' : sequenceModal?.mode === 'alter' ? `修改序列 (${sequenceModal.node.dataRef.sequence?.name})` : `重置序列 (${sequenceModal?.node.dataRef.sequence?.name || ''})`}
+            open={!!sequenceModal}
+            onOk={handleSequenceSubmit}
+            okText={sequenceModal?.mode === 'restart' ? '重置' : '确定'}
+            onCancel={() => setSequenceModal(null)}
+        >
+            <Form form={sequenceForm} layout="vertical">
+                {sequenceModal?.mode === 'restart' ? (
+                    <>
+                        <div style={{ marginBottom: 12, color: '#888' }}>
+                            当前值：{sequenceModal.node.dataRef.sequence?.lastValue || '尚未取值'}。重置后下一次取值从该值开始{sequenceModal.family === 'oracle' ? '（需要 Oracle 18c 及以上）' : ''}。
+                        </div>
+                        <Form.Item name="value" label="下一个值（留空回到起始值）" rules={[{ pattern: /^-?\d+$/, message: '请输入整数' }]}>
+                            <Input />
+                        </Form.Item>
+                    </>
+                ) : (
+                    <>
+                        {sequenceModal?.mode === 'create' && (
+                            <Form.Item name="name" label="序列名" rules={[{ required: true, message: '请输入序列名' }]}>
+                                <Input />
+                            </Form.Item>
+                        )}
+                        {sequenceModal?.family === 'postgres' && (
+                            <Form.Item name="dataType" label="类型">
+                                <Select allowClear options={['smallint', 'integer', 'bigint'].map(t => ({ value: t, label: t }))} />
+                            </Form.Item>
+                        )}
+                        <Space wrap>
+                            {!(sequenceModal?.mode === 'alter' && sequenceModal?.family === 'oracle') && (
+                                <Form.Item name="startValue" label="起始值" rules={[{ pattern: /^-?\d+$/, message: '请输入整数' }]}>
+                                    <Input style={{ width: 140 }} />
+                                </Form.Item>
+                            )}
+                            <Form.Item name="increment" label="步长" rules={[{ pattern: /^-?\d+$/, message: '请输入整数' }]}>
+                                <Input style={{ width: 140 }} />
+                            </Form.Item>
+                            <Form.Item name="cache" label="缓存" rules={[{ pattern: /^\d+$/, message: '请输入非负整数' }]}>
+                                <Input style={{ width: 140 }} />
+                            </Form.Item>
+                            <Form.Item name="minValue" label="最小值" rules={[{ pattern: /^-?\d+$/, message: '请输入整数' }]}>
+                                <Input style={{ width: 140 }} placeholder="默认" />
+                            </Form.Item>
+                            <Form.Item name="maxValue" label="最大值" rules={[{ pattern: /^-?\d+$/, message: '请输入整数' }]}>
+                                <Input style={{ width: 140 }} placeholder="默认" />
+                            </Form.Item>
+                        </Space>
+                        <Form.Item name="cycle" valuePropName="checked">
+                            <Checkbox>达到上限后循环</Checkbox>
+                        </Form.Item>
+                    </>
+                )}
+            </Form>
+        </Modal>
+
+        <Modal
+            title={`自增值管理${identityTarget?.dataRef?.tableName ? ` (${identityTarget.dataRef.tableName})` : ''}`}
+            open={isIdentityModalOpen}
+            onOk={handleResetIdentity}
+            okText="重置"
+            onCancel={closeIdentityModal}
+        >
+            <div style={{ marginBottom: 12 }}>
+                {identityList.map((item: any) => (
+                    <div key={item.column}>
+                        {item.column}{item.sequence ? `（序列 ${item.sequence}）` : ''}：下一个值 {item.nextValue ?? '未知'}，当前最大值 {item.maxValue ?? '空表'}，步长 {item.increment}
+                    </div>
+                ))}
+            </div>
+            <Form form={identityForm} layout="vertical">
+                {identityList.length > 1 && (
+                    <Form.Item name="column" label="列">
+                        <Select options={identityList.map((item: any) => ({ label: item.column, value: item.column }))} />
+                    </Form.Item>
+                )}
+                <Form.Item name="nextValue" label="下一个自增值" rules={[{ required: true, message: '请输入新的自增值' }]}>
+                    <Input type="number" min={1} />
+                </Form.Item>
+                <Form.Item name="force" valuePropName="checked">
+                    <Checkbox>允许不大于当前最大值（后续插入可能主键冲突）</Checkbox>
+                </Form.Item>
+            </Form>
+        </Modal>
+
         <Modal
             title={`重命名视图${renameViewTarget?.dataRef?.viewName ? ` (${renameViewTarget.dataRef.viewName})` : ''}`}
             open={isRenameViewModalOpen}
@@ -7,7 +7,7 @@ import { CSS } from '@dnd-kit/utilities';
 import Editor, { loader } from '@monaco-editor/react';
 import { TabData, ColumnDefinition, IndexDefinition, ForeignKeyDefinition, TriggerDefinition } from '../types';
 import { useStore } from '../store';
-import { DBGetColumns, DBGetIndexes, DBQuery, DBGetForeignKeys, DBGetTriggers, DBShowCreateTable } from '../../wailsjs/go/app/App';
+import { DBCreateIndex, DBDropIndex, DBEstimateIndexSize, DBGetColumns, DBGetIndexes, DBQuery, DBQueryScript, DBGetForeignKeys, DBGetTriggers, DBShowCreateTable, GenerateAlterTable, GenerateDesignerDDL } from '../../wailsjs/go/app/App';
 
 interface EditableColumn extends ColumnDefinition {
     _key: string;
@@ -198,6 +198,16 @@ const TableDesigner: React.FC<{ tab: TabData }> = ({ tab }) => {
   const [loading, setLoading] = useState(false);
   const [previewSql, setPreviewSql] = useState<string>('');
   const [isPreviewOpen, setIsPreviewOpen] = useState(false);
+  // 批量操作生成的 DDL 可能含多条语句（重建表），按脚本逐条执行
+  const [previewAsScript, setPreviewAsScript] = useState(false);
+  const [previewWarnings, setPreviewWarnings] = useState<string[]>([]);
+  const [isBatchOpsOpen, setIsBatchOpsOpen] = useState(false);
+  const [batchGenerating, setBatchGenerating] = useState(false);
+  const [batchReorder, setBatchReorder] = useState(false);
+  const [batchCharset, setBatchCharset] = useState(false);
+  const [batchCharsetName, setBatchCharsetName] = useState('utf8mb4');
+  const [batchCollation, setBatchCollation] = useState('utf8mb4_unicode_ci');
+  const [batchTimestamps, setBatchTimestamps] = useState(false);
   const [activeKey, setActiveKey] = useState(tab.initialTab || "columns");
   const [selectedColumnRowKeys, setSelectedColumnRowKeys] = useState<string[]>([]);
   const [isCopyColumnsModalOpen, setIsCopyColumnsModalOpen] = useState(false);
@@ -213,6 +223,7 @@ const TableDesigner: React.FC<{ tab: TabData }> = ({ tab }) => {
   const [isIndexModalOpen, setIsIndexModalOpen] = useState(false);
   const [indexModalMode, setIndexModalMode] = useState<'create' | 'edit'>('create');
   const [indexSaving, setIndexSaving] = useState(false);
+  const [indexEstimate, setIndexEstimate] = useState<{ loading: boolean; text: string }>({ loading: false, text: '' });
   const [indexForm, setIndexForm] = useState<IndexFormState>({
       name: '',
       columnNames: [],
@@ -1129,13 +1140,10 @@ ${selectedTrigger.statement}`;
 
   const supportsMysqlSchemaOps = () => getDbType() === 'mysql';
 
-  const executeSchemaSql = async (sql: string, successMessage: string): Promise<boolean> => {
+  const getSchemaOpsConfig = () => {
       const conn = connections.find(c => c.id === tab.connectionId);
-      if (!conn) {
-          message.error('未找到连接');
-          return false;
-      }
-      const config = {
+      if (!conn) return null;
+      return {
           ...conn.config,
           port: Number(conn.config.port),
           password: conn.config.password || "",
@@ -1143,6 +1151,14 @@ ${selectedTrigger.statement}`;
           useSSH: conn.config.useSSH || false,
           ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" }
       };
+  };
+
+  const executeSchemaSql = async (sql: string, successMessage: string): Promise<boolean> => {
+      const config = getSchemaOpsConfig();
+      if (!config) {
+          message.error('未找到连接');
+          return false;
+      }
       try {
           const res = await DBQuery(config as any, tab.dbName || '', sql);
           if (res.success) {
@@ -1187,6 +1203,7 @@ ${selectedTrigger.statement}`;
           kind: 'NORMAL',
           indexType: 'DEFAULT',
       });
+      setIndexEstimate({ loading: false, text: '' });
       setIsIndexModalOpen(true);
   };
 
@@ -1218,6 +1235,7 @@ ${selectedTrigger.statement}`;
               ? (selectedTypeUpper || 'DEFAULT')
               : 'DEFAULT',
       });
+      setIndexEstimate({ loading: false, text: '' });
       setIsIndexModalOpen(true);
   };
 
@@ -1260,11 +1278,78 @@ ${selectedTrigger.statement}`;
       return `DROP INDEX \`${escapeBacktickIdentifier(indexName)}\``;
   };
 
-  const handleSubmitIndex = async () => {
-      if (!supportsMysqlSchemaOps()) {
-          message.warning('当前数据库暂不支持在此维护索引');
+  // 非 MySQL 数据源通过后端索引接口维护，由后端按方言生成 DDL。
+  const buildIndexSpec = (form: IndexFormState, name: string) => {
+      const normalizedType = String(form.indexType || '').trim().toUpperCase();
+      return {
+          name,
+          columns: [...form.columnNames],
+          unique: form.kind === 'UNIQUE',
+          method: form.kind === 'FULLTEXT' || form.kind === 'SPATIAL'
+              ? form.kind
+              : (normalizedType && normalizedType !== 'DEFAULT' ? normalizedType : ''),
+          where: '',
+      };
+  };
+
+  const executeIndexApi = async (call: (config: any) => Promise<any>, successMessage: string): Promise<boolean> => {
+      const config = getSchemaOpsConfig();
+      if (!config) {
+          message.error('未找到连接');
+          return false;
+      }
+      try {
+          const res = await call(config);
+          if (res.success) {
+              message.success(successMessage);
+              await fetchData();
+              return true;
+          }
+          message.error('执行失败: ' + res.message);
+          return false;
+      } catch (e: any) {
+          message.error('执行失败: ' + (e?.message || String(e)));
+          return false;
+      }
+  };
+
+  const formatIndexBytes = (bytes: number) => {
+      if (bytes >= 1024 * 1024 * 1024) return `${(bytes / 1024 / 1024 / 1024).toFixed(2)} GB`;
+      if (bytes >= 1024 * 1024) return `${(bytes / 1024 / 1024).toFixed(1)} MB`;
+      if (bytes >= 1024) return `${(bytes / 1024).toFixed(1)} KB`;
+      return `${bytes} B`;
+  };
+
+  const handleEstimateIndex = async () => {
+      if (!tab.tableName) return;
+      if (indexForm.columnNames.length === 0) {
+          message.warning('请至少选择一个字段');
+          return;
+      }
+      const config = getSchemaOpsConfig();
+      if (!config) {
+          message.error('未找到连接');
           return;
       }
+      setIndexEstimate({ loading: true, text: '' });
+      try {
+          const res = await DBEstimateIndexSize(config as any, tab.dbName || '', tab.tableName, buildIndexSpec(indexForm, String(indexForm.name || '').trim()) as any);
+          if (!res.success) {
+              setIndexEstimate({ loading: false, text: '估算失败：' + res.message });
+              return;
+          }
+          const estimate = (res.data || {}) as { rows?: number; estimatedBytes?: number; note?: string };
+          const parts: string[] = [];
+          if (Number(estimate.estimatedBytes) > 0) parts.push(`预计索引大小约 ${formatIndexBytes(Number(estimate.estimatedBytes))}`);
+          if (Number(estimate.rows) >= 0) parts.push(`表行数约 ${Number(estimate.rows).toLocaleString()}`);
+          if (estimate.note) parts.push(estimate.note);
+          setIndexEstimate({ loading: false, text: parts.join('，') || '暂无可用的统计信息' });
+      } catch (e: any) {
+          setIndexEstimate({ loading: false, text: '估算失败：' + (e?.message || String(e)) });
+      }
+  };
+
+  const handleSubmitIndex = async () => {
       if (!tab.tableName) return;
       const nextName = indexForm.kind === 'PRIMARY' ? 'PRIMARY' : String(indexForm.name || '').trim();
       if (indexForm.kind !== 'PRIMARY' && !nextName) {
@@ -1286,6 +1371,34 @@ ${selectedTrigger.statement}`;
           return;
       }
 
+      if (!supportsMysqlSchemaOps()) {
+          if (indexForm.kind === 'PRIMARY') {
+              message.warning('当前数据库暂不支持在此维护主键，请在字段中设置');
+              return;
+          }
+          const tableName = tab.tableName;
+          setIndexSaving(true);
+          if (indexModalMode === 'edit' && selectedIndex) {
+              const dropped = await executeIndexApi(
+                  config => DBDropIndex(config, tab.dbName || '', tableName, selectedIndex.name),
+                  '旧索引已删除',
+              );
+              if (!dropped) {
+                  setIndexSaving(false);
+                  return;
+              }
+          }
+          const ok = await executeIndexApi(
+              config => DBCreateIndex(config, tab.dbName || '', tableName, buildIndexSpec(indexForm, nextName) as any),
+              indexModalMode === 'create' ? '索引新增成功' : '索引修改成功',
+          );
+          setIndexSaving(false);
+          if (ok) {
+              setIsIndexModalOpen(false);
+          }
+          return;
+      }
+
       setIndexSaving(true);
       const addClause = buildIndexAddClause({ ...indexForm, name: nextName });
       if (!addClause) {
@@ -1311,10 +1424,6 @@ ${selectedTrigger.statement}`;
           message.warning('请先选择一个索引');
           return;
       }
-      if (!supportsMysqlSchemaOps()) {
-          message.warning('当前数据库暂不支持在此维护索引');
-          return;
-      }
       Modal.confirm({
           title: '确认删除索引',
           icon: <ExclamationCircleOutlined />,
@@ -1323,6 +1432,13 @@ ${selectedTrigger.statement}`;
           okType: 'danger',
           cancelText: '取消',
           onOk: async () => {
+              if (!supportsMysqlSchemaOps()) {
+                  await executeIndexApi(
+                      config => DBDropIndex(config, tab.dbName || '', tab.tableName || '', selectedIndex.name),
+                      '索引删除成功',
+                  );
+                  return;
+              }
               const dropClause = buildIndexDropClause(selectedIndex.name);
               const sql = `ALTER TABLE ${getMysqlTableRef()}\n${dropClause};`;
               await executeSchemaSql(sql, '索引删除成功');
@@ -1471,84 +1587,114 @@ ${selectedTrigger.statement}`;
           return;
       }
 
-      const tableName = `\`${isNewTable ? newTableName : tab.tableName}\``;
-      
       if (isNewTable) {
           // CREATE TABLE
           const sql = buildCreateTableSql(isNewTable ? newTableName : tab.tableName || '', columns, charset, collation);
           setPreviewSql(sql);
+          setPreviewAsScript(false);
+          setPreviewWarnings([]);
           setIsPreviewOpen(true);
       } else {
-          // ALTER TABLE (Existing logic)
-          const alters: string[] = [];
-          
-          originalColumns.forEach(orig => {
-              if (!columns.find(c => c._key === orig._key)) {
-                  alters.push(`DROP COLUMN \`${orig.name}\``);
-              }
-          });
-
-          columns.forEach((curr, index) => {
-              const orig = originalColumns.find(c => c._key === curr._key);
-              const prevCol = index > 0 ? columns[index - 1] : null;
-              const positionSql = prevCol ? `AFTER \`${prevCol.name}\`` : 'FIRST';
-              
-              let extra = curr.extra || "";
-              if (curr.isAutoIncrement) {
-                  if (!extra.toLowerCase().includes('auto_increment')) extra += " AUTO_INCREMENT";
-              } else {
-                  extra = extra.replace(/auto_increment/gi, "").trim();
-              }
+          void generateAlterDDL();
+      }
+  };
 
-              const colDef = `\`${curr.name}\` ${curr.type} ${curr.nullable === 'NO' ? 'NOT NULL' : 'NULL'} ${curr.default ? `DEFAULT '${curr.default}'` : ''} ${extra} COMMENT '${curr.comment}'`;
-
-              if (!orig) {
-                  alters.push(`ADD COLUMN ${colDef} ${positionSql}`);
-              } else {
-                  const origIndex = originalColumns.findIndex(c => c._key === curr._key);
-                  const origPrevCol = origIndex > 0 ? originalColumns[origIndex - 1] : null;
-                  
-                  let positionChanged = false;
-                  if (index === 0 && origIndex !== 0) positionChanged = true;
-                  if (index > 0 && (!origPrevCol || origPrevCol._key !== prevCol?._key)) positionChanged = true;
-
-                  const isNameChanged = orig.name !== curr.name;
-                  const isTypeChanged = orig.type !== curr.type;
-                  const isNullableChanged = orig.nullable !== curr.nullable;
-                  const isDefaultChanged = orig.default !== curr.default;
-                  const isCommentChanged = orig.comment !== curr.comment;
-                  const isAIChanged = orig.isAutoIncrement !== curr.isAutoIncrement;
-
-                  if (isNameChanged || isTypeChanged || isNullableChanged || isDefaultChanged || isCommentChanged || positionChanged || isAIChanged) {
-                      if (isNameChanged) {
-                          alters.push(`CHANGE COLUMN \`${orig.name}\` ${colDef} ${positionSql}`);
-                      } else {
-                          alters.push(`MODIFY COLUMN ${colDef} ${positionSql}`);
-                      }
-                  }
-              }
-          });
+  // 修改已有表：把修改前后的结构交给后端比较，按连接的数据库类型生成 ALTER（SQLite 必要时重建表）
+  const generateAlterDDL = async () => {
+      const conn = connections.find(c => c.id === tab.connectionId);
+      if (!conn) return;
+      const toDefinition = (c: EditableColumn) => {
+          const orig = originalColumns.find(o => o._key === c._key);
+          const identity = c.isAutoIncrement ? ((c as any).identity || 'BY DEFAULT') : (orig?.isAutoIncrement ? '' : ((c as any).identity || ''));
+          return {
+              name: c.name,
+              type: c.type,
+              nullable: c.nullable,
+              key: c.key,
+              default: c.default === '' ? undefined : c.default,
+              extra: c.extra || '',
+              comment: c.comment || '',
+              identity,
+              generated: (c as any).generated,
+          };
+      };
+      // renames：新列名 -> 原列名；索引与外键中的列名随之改为新列名
+      const renames: Record<string, string> = {};
+      const renamedTo: Record<string, string> = {};
+      columns.forEach(curr => {
+          const orig = originalColumns.find(c => c._key === curr._key);
+          if (orig && orig.name !== curr.name) {
+              renames[curr.name] = orig.name;
+              renamedTo[orig.name] = curr.name;
+          }
+      });
+      const oldDef = { columns: originalColumns.map(toDefinition), indexes, foreignKeys: fks };
+      const newDef = {
+          columns: columns.map(toDefinition),
+          indexes: indexes.map(idx => ({ ...idx, columnName: renamedTo[idx.columnName] || idx.columnName })),
+          foreignKeys: fks.map(fk => ({ ...fk, columnName: renamedTo[fk.columnName] || fk.columnName })),
+          renames,
+      };
+      const config = { ...conn.config, port: Number(conn.config.port), password: conn.config.password || "", database: conn.config.database || "", useSSH: conn.config.useSSH || false, ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" } };
+      const res = await GenerateAlterTable(config as any, tab.dbName || '', tab.tableName || '', oldDef as any, newDef as any);
+      if (!res.success) {
+          if (res.message === '没有检测到变更') message.info(res.message);
+          else message.error(res.message);
+          return;
+      }
+      const data = res.data as any;
+      setPreviewSql(data.script || '');
+      setPreviewWarnings(data.warnings || []);
+      setPreviewAsScript(true);
+      setIsPreviewOpen(true);
+  };
 
-          const origPKKeys = originalColumns.filter(c => c.key === 'PRI').map(c => c._key);
-          const newPKKeys = columns.filter(c => c.key === 'PRI').map(c => c._key);
-          const keysChanged = origPKKeys.length !== newPKKeys.length || !origPKKeys.every(k => newPKKeys.includes(k));
+  // 批量操作的列顺序取自拖拽后的当前顺序，仅在未增删字段时可用
+  const batchReorderColumns = (): string[] | null => {
+      if (columns.length !== originalColumns.length) return null;
+      const names: string[] = [];
+      for (const col of columns) {
+          const orig = originalColumns.find(c => c._key === col._key);
+          if (!orig) return null;
+          names.push(orig.name);
+      }
+      return names;
+  };
 
-          if (keysChanged) {
-              if (origPKKeys.length > 0) alters.push(`DROP PRIMARY KEY`);
-              if (newPKKeys.length > 0) {
-                  const pkNames = columns.filter(c => c.key === 'PRI').map(c => `\`${c.name}\``).join(', ');
-                  alters.push(`ADD PRIMARY KEY (${pkNames})`);
-              }
+  const handleGenerateBatchDDL = async () => {
+      const conn = connections.find(c => c.id === tab.connectionId);
+      if (!conn) return;
+      const operations: any[] = [];
+      if (batchCharset) operations.push({ kind: 'charset', charset: batchCharsetName, collation: batchCollation });
+      if (batchReorder) {
+          const order = batchReorderColumns();
+          if (!order) {
+              message.error("调整列顺序前请先保存或撤销字段的增删");
+              return;
           }
-
-          if (alters.length === 0) {
-              message.info("没有检测到变更");
+          operations.push({ kind: 'reorder', columns: order });
+      }
+      if (batchTimestamps) operations.push({ kind: 'timestamps' });
+      if (operations.length === 0) {
+          message.info("请选择要执行的批量操作");
+          return;
+      }
+      const config = { ...conn.config, port: Number(conn.config.port), password: conn.config.password || "", database: conn.config.database || "", useSSH: conn.config.useSSH || false, ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" } };
+      setBatchGenerating(true);
+      try {
+          const res = await GenerateDesignerDDL(config as any, tab.dbName || '', tab.tableName || '', operations);
+          if (!res.success) {
+              message.error(res.message);
               return;
           }
-
-          const sql = `ALTER TABLE ${tableName}\n` + alters.join(",\n");
-          setPreviewSql(sql);
+          const data = res.data as any;
+          setPreviewSql(data.script || '');
+          setPreviewWarnings(data.warnings || []);
+          setPreviewAsScript(true);
+          setIsBatchOpsOpen(false);
           setIsPreviewOpen(true);
+      } finally {
+          setBatchGenerating(false);
       }
   };
 
@@ -1556,7 +1702,9 @@ ${selectedTrigger.statement}`;
 	      const conn = connections.find(c => c.id === tab.connectionId);
 	      if (!conn) return;
 	      const config = { ...conn.config, port: Number(conn.config.port), password: conn.config.password || "", database: conn.config.database || "", useSSH: conn.config.useSSH || false, ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" } };
-	      const res = await DBQuery(config as any, tab.dbName || '', previewSql);
+	      const res = previewAsScript
+	          ? await DBQueryScript(config as any, tab.dbName || '', previewSql, { stopOnError: true } as any)
+	          : await DBQuery(config as any, tab.dbName || '', previewSql);
 	      if (res.success) {
 	          message.success(isNewTable ? "表创建成功！" : "表结构修改成功！");
 	          setIsPreviewOpen(false);
@@ -1681,6 +1829,7 @@ ${selectedTrigger.statement}`;
                 <Button icon={<EditOutlined />} onClick={openTableCommentModal}>表备注</Button>
             )}
             {!readOnly && <Button icon={<PlusOutlined />} onClick={handleAddColumn}>添加字段</Button>}
+            {!isNewTable && !readOnly && <Button icon={<FileTextOutlined />} onClick={() => setIsBatchOpsOpen(true)}>批量操作</Button>}
             {!readOnly && (
                 <Button
                     icon={<CopyOutlined />}
@@ -1713,12 +1862,7 @@ ${selectedTrigger.statement}`;
                                         <Button size="small" icon={<PlusOutlined />} onClick={openCreateIndexModal}>新增</Button>
                                         <Button size="small" icon={<EditOutlined />} disabled={!selectedIndex} onClick={openEditIndexModal}>修改</Button>
                                         <Button size="small" icon={<DeleteOutlined />} danger disabled={!selectedIndex} onClick={handleDeleteIndex}>删除</Button>
-                                        {!supportsMysqlSchemaOps() && (
-                                            <span style={{ marginLeft: 'auto', color: '#faad14', fontSize: 12, alignSelf: 'center' }}>
-                                                当前数据库暂不支持索引编辑，仅支持查看
-                                            </span>
-                                        )}
-                                        {supportsMysqlSchemaOps() && selectedIndex && (
+                                        {selectedIndex && (
                                             <span style={{ marginLeft: 'auto', color: '#888', fontSize: 12, alignSelf: 'center' }}>
                                                 已选择：{selectedIndex.name}
                                             </span>
@@ -2105,6 +2249,10 @@ ${selectedTrigger.statement}`;
                 <div style={{ color: '#888', fontSize: 12 }}>
                     修改索引会执行“先删除旧索引，再创建新索引”。
                 </div>
+                <Space>
+                    <Button size="small" loading={indexEstimate.loading} disabled={indexForm.columnNames.length === 0} onClick={handleEstimateIndex}>估算索引大小</Button>
+                    {indexEstimate.text && <span style={{ color: '#888', fontSize: 12 }}>{indexEstimate.text}</span>}
+                </Space>
             </Space>
         </Modal>
 
@@ -2154,6 +2302,51 @@ ${selectedTrigger.statement}`;
             </Space>
         </Modal>
 
+        <Modal
+            title="批量操作"
+            open={isBatchOpsOpen}
+            onOk={handleGenerateBatchDDL}
+            onCancel={() => setIsBatchOpsOpen(false)}
+            confirmLoading={batchGenerating}
+            okText="生成 SQL"
+            cancelText="取消"
+        >
+            <Space direction="vertical" style={{ width: '100%' }}>
+                <Checkbox checked={batchReorder} onChange={(e) => setBatchReorder(e.target.checked)}>
+                    按当前拖拽顺序调整列顺序
+                </Checkbox>
+                <div style={{ color: '#888', fontSize: 12, marginLeft: 24 }}>
+                    MySQL 使用 FIRST/AFTER 原地调整；不支持的数据库将重建表并复制数据。
+                </div>
+                {supportsMysqlSchemaOps() && (
+                    <>
+                        <Checkbox checked={batchCharset} onChange={(e) => setBatchCharset(e.target.checked)}>
+                            修改全部字符列的字符集
+                        </Checkbox>
+                        <Space style={{ marginLeft: 24 }}>
+                            <Select
+                                value={batchCharsetName}
+                                disabled={!batchCharset}
+                                onChange={(v) => { setBatchCharsetName(v); setBatchCollation(((COLLATIONS as any)[v] || [])[0]?.value || ''); }}
+                                options={CHARSETS}
+                                style={{ width: 150 }}
+                            />
+                            <Select
+                                value={batchCollation}
+                                disabled={!batchCharset}
+                                onChange={setBatchCollation}
+                                options={(COLLATIONS as any)[batchCharsetName] || []}
+                                style={{ width: 200 }}
+                            />
+                        </Space>
+                    </>
+                )}
+                <Checkbox checked={batchTimestamps} onChange={(e) => setBatchTimestamps(e.target.checked)}>
+                    添加 created_at / updated_at 时间戳列
+                </Checkbox>
+            </Space>
+        </Modal>
+
         <Modal
             title="确认 SQL 变更"
             open={isPreviewOpen}
@@ -2168,6 +2361,7 @@ ${selectedTrigger.statement}`;
                     {previewSql}
                 </pre>
             </div>
+            {previewWarnings.map((w, i) => <p key={i} style={{ margin: '4px 0', color: '#fa8c16' }}>{w}</p>)}
             <p style={{ marginTop: 10, color: '#faad14' }}>请仔细检查 SQL，执行后不可撤销。</p>
         </Modal>
 
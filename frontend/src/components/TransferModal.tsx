@@ -0,0 +1,232 @@
+import React, { useEffect, useRef, useState } from 'react';
+import { Modal, Form, Select, Button, InputNumber, Radio, Progress, Table, Tag, Alert, Typography, message } from 'antd';
+import { useStore } from '../store';
+import { DBGetDatabasesWithOptions, DBGetTables, TransferTables, CancelQuery } from '../../wailsjs/go/app/App';
+import { SavedConnection } from '../types';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+const { Text } = Typography;
+
+type TransferProgressEvent = { jobId: string; table: string; index: number; total: number; stage: string; rows: number; tableRows: number };
+type TransferTableResult = {
+  source: string;
+  target: string;
+  status: string;
+  created?: boolean;
+  method?: string;
+  rows: number;
+  failedRows?: number;
+  errors?: string[];
+  warnings?: string[];
+  durationMs: number;
+};
+
+const statusTag: Record<string, { color: string; label: string }> = {
+  done: { color: 'green', label: '完成' },
+  failed: { color: 'red', label: '失败' },
+  skipped: { color: 'default', label: '跳过' },
+  canceled: { color: 'orange', label: '已取消' },
+};
+
+const TransferModal: React.FC<{ open: boolean; onClose: () => void }> = ({ open, onClose }) => {
+  const connections = useStore((state) => state.connections);
+  const [sourceConnId, setSourceConnId] = useState('');
+  const [targetConnId, setTargetConnId] = useState('');
+  const [sourceDb, setSourceDb] = useState('');
+  const [targetDb, setTargetDb] = useState('');
+  const [sourceDbs, setSourceDbs] = useState<string[]>([]);
+  const [targetDbs, setTargetDbs] = useState<string[]>([]);
+  const [allTables, setAllTables] = useState<string[]>([]);
+  const [tables, setTables] = useState<string[]>([]);
+  const [content, setContent] = useState<'both' | 'schema' | 'data'>('both');
+  const [existing, setExisting] = useState<'append' | 'truncate' | 'replace'>('append');
+  const [errorPolicy, setErrorPolicy] = useState<'abort' | 'skip'>('abort');
+  const [batchRows, setBatchRows] = useState<number>(1000);
+  const [running, setRunning] = useState(false);
+  const [progress, setProgress] = useState<TransferProgressEvent | null>(null);
+  const [results, setResults] = useState<TransferTableResult[]>([]);
+  const [summary, setSummary] = useState('');
+  const jobIdRef = useRef('');
+
+  const connConfig = (conn: SavedConnection | undefined, database: string) => conn ? ({
+      ...conn.config,
+      port: Number((conn.config as any).port),
+      password: conn.config.password || "",
+      useSSH: conn.config.useSSH || false,
+      ssh: conn.config.ssh || { host: "", port: 22, user: "", password: "", keyPath: "" },
+      database,
+  }) : null;
+
+  useEffect(() => {
+      if (!open) return;
+      setSourceConnId('');
+      setTargetConnId('');
+      setSourceDb('');
+      setTargetDb('');
+      setTables([]);
+      setResults([]);
+      setSummary('');
+      setProgress(null);
+      const off = EventsOn('transfer:progress', (event: TransferProgressEvent) => {
+          if (!event || event.jobId !== jobIdRef.current) return;
+          setProgress(event);
+      });
+      return () => off();
+  }, [open]);
+
+  const loadDbs = async (connId: string, setDbs: (dbs: string[]) => void) => {
+      setDbs([]);
+      const conn = connections.find(c => c.id === connId);
+      if (!conn) return;
+      const res = await DBGetDatabasesWithOptions(connConfig(conn, conn.config.database || '') as any, (conn.databaseList || {}) as any);
+      if (res.success) setDbs((res.data as any[]).map((row: any) => row.Database || row.database || row.username));
+      else message.error(res.message);
+  };
+
+  useEffect(() => { if (sourceConnId) loadDbs(sourceConnId, setSourceDbs); }, [sourceConnId]);
+  useEffect(() => { if (targetConnId) loadDbs(targetConnId, setTargetDbs); }, [targetConnId]);
+
+  useEffect(() => {
+      setAllTables([]);
+      setTables([]);
+      const conn = connections.find(c => c.id === sourceConnId);
+      if (!conn || !sourceDb) return;
+      DBGetTables(connConfig(conn, sourceDb) as any, sourceDb).then((res: any) => {
+          if (res.success) setAllTables((res.data as any[]).map((row: any) => String(row.Table || row.table || row.TABLE_NAME || Object.values(row)[0])));
+          else message.error(res.message);
+      });
+  }, [sourceConnId, sourceDb]);
+
+  const handleRun = async () => {
+      const source = connections.find(c => c.id === sourceConnId);
+      const target = connections.find(c => c.id === targetConnId);
+      if (!source || !target || !sourceDb || !targetDb) {
+          message.error('请选择源与目标连接及数据库');
+          return;
+      }
+      if (tables.length === 0) {
+          message.error('请选择要传输的表');
+          return;
+      }
+      const jobId = `transfer-${Date.now()}`;
+      jobIdRef.current = jobId;
+      setRunning(true);
+      setResults([]);
+      setSummary('');
+      setProgress(null);
+      try {
+          const res = await TransferTables(connConfig(source, source.config.database || "") as any, connConfig(target, target.config.database || "") as any, tables, {
+              jobId, sourceDb, targetDb, content, existing, errorPolicy, batchRows,
+          } as any);
+          setResults(((res.data as any)?.tables || []) as TransferTableResult[]);
+          setSummary(res.message);
+          if (res.success) message.success(res.message);
+          else message.error(res.message);
+      } catch (e: any) {
+          message.error('传输失败：' + (e?.message || String(e)));
+      } finally {
+          setRunning(false);
+      }
+  };
+
+  const tablePercent = progress && progress.tableRows > 0 ? Math.min(100, Math.round(progress.rows * 100 / progress.tableRows)) : 0;
+  const connectionOptions = connections.map(c => ({ value: c.id, label: `${c.name} (${c.config.type})` }));
+
+  return (
+      <Modal
+          title="数据传输"
+          open={open}
+          width={820}
+          onCancel={() => { if (!running) onClose(); }}
+          maskClosable={false}
+          footer={[
+              running
+                  ? <Button key="cancel" danger onClick={() => CancelQuery(jobIdRef.current)}>取消传输</Button>
+                  : <Button key="close" onClick={onClose}>关闭</Button>,
+              <Button key="run" type="primary" loading={running} onClick={handleRun}>开始传输</Button>,
+          ]}
+      >
+          <Form layout="vertical" disabled={running}>
+              <div style={{ display: 'flex', gap: 16 }}>
+                  <Form.Item label="源连接" style={{ flex: 1 }}>
+                      <Select value={sourceConnId || undefined} onChange={(v) => { setSourceConnId(v); setSourceDb(''); }} options={connectionOptions} />
+                  </Form.Item>
+                  <Form.Item label="源数据库" style={{ flex: 1 }}>
+                      <Select value={sourceDb || undefined} onChange={setSourceDb} options={sourceDbs.map(d => ({ value: d, label: d }))} showSearch />
+                  </Form.Item>
+              </div>
+              <div style={{ display: 'flex', gap: 16 }}>
+                  <Form.Item label="目标连接" style={{ flex: 1 }}>
+                      <Select value={targetConnId || undefined} onChange={(v) => { setTargetConnId(v); setTargetDb(''); }} options={connectionOptions} />
+                  </Form.Item>
+                  <Form.Item label="目标数据库" style={{ flex: 1 }}>
+                      <Select value={targetDb || undefined} onChange={setTargetDb} options={targetDbs.map(d => ({ value: d, label: d }))} showSearch />
+                  </Form.Item>
+              </div>
+              <Form.Item label={<span>表 <Button type="link" size="small" onClick={() => setTables(allTables)}>全选</Button></span>}>
+                  <Select mode="multiple" value={tables} onChange={setTables} options={allTables.map(t => ({ value: t, label: t }))} maxTagCount={8} />
+              </Form.Item>
+              <div style={{ display: 'flex', gap: 16, flexWrap: 'wrap' }}>
+                  <Form.Item label="传输内容">
+                      <Radio.Group value={content} onChange={(e) => setContent(e.target.value)}>
+                          <Radio.Button value="both">结构+数据</Radio.Button>
+                          <Radio.Button value="schema">仅结构</Radio.Button>
+                          <Radio.Button value="data">仅数据</Radio.Button>
+                      </Radio.Group>
+                  </Form.Item>
+                  <Form.Item label="目标表已存在">
+                      <Select value={existing} onChange={setExisting} style={{ width: 150 }} options={[
+                          { value: 'append', label: '追加数据' },
+                          { value: 'truncate', label: '清空后写入' },
+                          { value: 'replace', label: '删除并重建' },
+                      ]} />
+                  </Form.Item>
+                  <Form.Item label="出错时">
+                      <Select value={errorPolicy} onChange={setErrorPolicy} style={{ width: 150 }} options={[
+                          { value: 'abort', label: '停止传输' },
+                          { value: 'skip', label: '跳过并继续' },
+                      ]} />
+                  </Form.Item>
+                  <Form.Item label="每批行数">
+                      <InputNumber min={1} max={20000} value={batchRows} onChange={(v) => setBatchRows(Number(v) || 1000)} />
+                  </Form.Item>
+              </div>
+          </Form>
+
+          {running && progress && (
+              <div style={{ marginBottom: 12 }}>
+                  <Text>正在传输 {progress.table}（{progress.index + 1}/{progress.total}），已写入 {progress.rows} 行{progress.tableRows >= 0 ? ` / ${progress.tableRows}` : ''}</Text>
+                  <Progress percent={Math.round((progress.index + tablePercent / 100) * 100 / Math.max(progress.total, 1))} size="small" />
+              </div>
+          )}
+          {summary && <Alert type={results.some(r => r.status !== 'done') ? 'warning' : 'success'} message={summary} style={{ marginBottom: 12 }} />}
+          {results.length > 0 && (
+              <Table
+                  size="small"
+                  rowKey="source"
+                  pagination={false}
+                  dataSource={results}
+                  columns={[
+                      { title: '源表', dataIndex: 'source' },
+                      { title: '目标表', dataIndex: 'target', render: (v: string, r: TransferTableResult) => <span>{v}{r.created ? <Tag style={{ marginLeft: 4 }}>新建</Tag> : null}</span> },
+                      { title: '状态', dataIndex: 'status', width: 80, render: (v: string) => <Tag color={statusTag[v]?.color}>{statusTag[v]?.label || v}</Tag> },
+                      { title: '行数', dataIndex: 'rows', width: 90, render: (v: number, r: TransferTableResult) => r.failedRows ? `${v}（失败 ${r.failedRows}）` : v },
+                      { title: '方式', dataIndex: 'method', width: 90 },
+                      { title: '耗时', dataIndex: 'durationMs', width: 80, render: (v: number) => `${v} ms` },
+                  ]}
+                  expandable={{
+                      rowExpandable: (r) => !!(r.errors?.length || r.warnings?.length),
+                      expandedRowRender: (r) => (
+                          <div>
+                              {(r.errors || []).map((e, i) => <div key={`e${i}`}><Text type="danger">{e}</Text></div>)}
+                              {(r.warnings || []).map((w, i) => <div key={`w${i}`}><Text type="warning">{w}</Text></div>)}
+                          </div>
+                      ),
+                  }}
+              />
+          )}
+      </Modal>
+  );
+};
+
+export default TransferModal;
@@ -106,6 +106,8 @@ LIMIT 1`];
                 return [`-- DuckDB 不支持触发器`];
             case 'tdengine':
                 return [`-- TDengine 不支持触发器`];
+            case 'clickhouse':
+                return [`-- ClickHouse 不支持触发器`];
             case 'mongodb':
                 return [`-- MongoDB 不支持触发器`];
             default:
@@ -19,6 +19,7 @@ const SUPPORTED_CONNECTION_TYPES = new Set([
   'postgres',
   'redis',
   'tdengine',
+  'clickhouse',
   'oracle',
   'dameng',
   'kingbase',
@@ -49,6 +50,8 @@ const getDefaultPortByType = (type: string): number => {
       return 6379;
     case 'tdengine':
       return 6041;
+    case 'clickhouse':
+      return 9000;
     case 'oracle':
       return 1521;
     case 'dameng':
@@ -232,6 +235,46 @@ const sanitizeConnections = (value: unknown): SavedConnection[] => {
   return result;
 };
 
+// 后端连接存储（~/.gonavi/connections.json，密码加密）确认可用后，本地持久化不再保存密码类字段。
+let backendConnectionStoreReady = false;
+
+export const markConnectionStoreReady = () => {
+  backendConnectionStoreReady = true;
+};
+
+const stripConnectionSecrets = (conn: SavedConnection): SavedConnection => ({
+  ...conn,
+  config: {
+    ...conn.config,
+    password: '',
+    mysqlReplicaPassword: '',
+    mongoReplicaPassword: '',
+    uri: '',
+    dsn: '',
+    ssh: conn.config.ssh ? {
+      ...conn.config.ssh,
+      password: '',
+      jumpHosts: conn.config.ssh.jumpHosts?.map(hop => ({ ...hop, password: '' })),
+    } : conn.config.ssh,
+  },
+});
+
+const backendApp = () => (window as any).go?.app?.App;
+
+const saveConnectionToBackend = (conn: SavedConnection) => {
+  const api = backendApp();
+  if (!api?.SaveConnection) return;
+  Promise.resolve(api.SaveConnection(conn)).then((res: any) => {
+    if (res && !res.success) console.warn('保存连接到后端失败:', res.message);
+  }).catch((err: any) => console.warn('保存连接到后端失败:', err));
+};
+
+const deleteConnectionFromBackend = (id: string) => {
+  const api = backendApp();
+  if (!api?.DeleteConnection) return;
+  Promise.resolve(api.DeleteConnection(id)).catch((err: any) => console.warn('删除后端连接失败:', err));
+};
+
 const isLegacyDefaultAppearance = (appearance: Partial<{ opacity: number; blur: number }> | undefined): boolean => {
   if (!appearance) {
     return true;
@@ -250,6 +293,7 @@ export interface SqlLog {
   message?: string;
   dbName?: string;
   affectedRows?: number;
+  connectionId?: string; // 用于按连接筛选历史，如 Redis 控制台
 }
 
 export interface QueryOptions {
@@ -258,6 +302,21 @@ export interface QueryOptions {
   showColumnType: boolean;
 }
 
+// 导出文件的数字/日期本地化格式，空串表示保持默认输出
+export interface ExportLocaleOptions {
+  decimalSeparator: string;
+  thousandsSeparator: string;
+  datePattern: string;
+  dateTimePattern: string;
+}
+
+export const DEFAULT_EXPORT_LOCALE: ExportLocaleOptions = {
+  decimalSeparator: '',
+  thousandsSeparator: '',
+  datePattern: '',
+  dateTimePattern: '',
+};
+
 interface AppState {
   connections: SavedConnection[];
   tabs: TabData[];
@@ -268,6 +327,8 @@ interface AppState {
   appearance: { opacity: number; blur: number };
   sqlFormatOptions: { keywordCase: 'upper' | 'lower' };
   queryOptions: QueryOptions;
+  exportLocale: ExportLocaleOptions;
+  startupConnectionTest: boolean;
   sqlLogs: SqlLog[];
   tableAccessCount: Record<string, number>;
   tableSortPreference: Record<string, 'name' | 'frequency'>;
@@ -275,6 +336,7 @@ interface AppState {
   addConnection: (conn: SavedConnection) => void;
   updateConnection: (conn: SavedConnection) => void;
   removeConnection: (id: string) => void;
+  setConnections: (conns: SavedConnection[]) => void;
 
   addTab: (tab: TabData) => void;
   closeTab: (id: string) => void;
@@ -294,6 +356,8 @@ interface AppState {
   setAppearance: (appearance: Partial<{ opacity: number; blur: number }>) => void;
   setSqlFormatOptions: (options: { keywordCase: 'upper' | 'lower' }) => void;
   setQueryOptions: (options: Partial<QueryOptions>) => void;
+  setExportLocale: (options: Partial<ExportLocaleOptions>) => void;
+  setStartupConnectionTest: (enabled: boolean) => void;
 
   addSqlLog: (log: SqlLog) => void;
   clearSqlLogs: () => void;
@@ -332,6 +396,17 @@ const sanitizeSqlFormatOptions = (value: unknown): { keywordCase: 'upper' | 'low
   return { keywordCase: raw.keywordCase === 'lower' ? 'lower' : 'upper' };
 };
 
+const sanitizeExportLocale = (value: unknown): ExportLocaleOptions => {
+  const raw = (value && typeof value === 'object') ? value as Record<string, unknown> : {};
+  const text = (v: unknown) => (typeof v === 'string' ? v : '');
+  return {
+    decimalSeparator: text(raw.decimalSeparator),
+    thousandsSeparator: text(raw.thousandsSeparator),
+    datePattern: text(raw.datePattern),
+    dateTimePattern: text(raw.dateTimePattern),
+  };
+};
+
 const sanitizeQueryOptions = (value: unknown): QueryOptions => {
   const raw = (value && typeof value === 'object') ? value as Record<string, unknown> : {};
   const maxRows = Number(raw.maxRows);
@@ -392,15 +467,27 @@ export const useStore = create<AppState>()(
       appearance: { ...DEFAULT_APPEARANCE },
       sqlFormatOptions: { keywordCase: 'upper' },
       queryOptions: { maxRows: 5000, showColumnComment: true, showColumnType: true },
+      exportLocale: { ...DEFAULT_EXPORT_LOCALE },
+      startupConnectionTest: false,
       sqlLogs: [],
       tableAccessCount: {},
       tableSortPreference: {},
 
-      addConnection: (conn) => set((state) => ({ connections: [...state.connections, conn] })),
-      updateConnection: (conn) => set((state) => ({
-          connections: state.connections.map(c => c.id === conn.id ? conn : c)
-      })),
-      removeConnection: (id) => set((state) => ({ connections: state.connections.filter(c => c.id !== id) })),
+      addConnection: (conn) => {
+          saveConnectionToBackend(conn);
+          set((state) => ({ connections: [...state.connections, conn] }));
+      },
+      updateConnection: (conn) => {
+          saveConnectionToBackend(conn);
+          set((state) => ({
+              connections: state.connections.map(c => c.id === conn.id ? conn : c)
+          }));
+      },
+      removeConnection: (id) => {
+          deleteConnectionFromBackend(id);
+          set((state) => ({ connections: state.connections.filter(c => c.id !== id) }));
+      },
+      setConnections: (conns) => set({ connections: sanitizeConnections(conns) }),
 
       addTab: (tab) => set((state) => {
         const index = state.tabs.findIndex(t => t.id === tab.id);
@@ -503,6 +590,8 @@ export const useStore = create<AppState>()(
       setAppearance: (appearance) => set((state) => ({ appearance: { ...state.appearance, ...appearance } })),
       setSqlFormatOptions: (options) => set({ sqlFormatOptions: options }),
       setQueryOptions: (options) => set((state) => ({ queryOptions: { ...state.queryOptions, ...options } })),
+      setExportLocale: (options) => set((state) => ({ exportLocale: { ...state.exportLocale, ...options } })),
+      setStartupConnectionTest: (enabled) => set({ startupConnectionTest: enabled }),
 
       addSqlLog: (log) => set((state) => ({ sqlLogs: [log, ...state.sqlLogs].slice(0, 1000) })), // Keep last 1000 logs
       clearSqlLogs: () => set({ sqlLogs: [] }),
@@ -543,6 +632,8 @@ export const useStore = create<AppState>()(
         nextState.appearance = sanitizeAppearance(state.appearance, version);
         nextState.sqlFormatOptions = sanitizeSqlFormatOptions(state.sqlFormatOptions);
         nextState.queryOptions = sanitizeQueryOptions(state.queryOptions);
+        nextState.exportLocale = sanitizeExportLocale(state.exportLocale);
+        nextState.startupConnectionTest = state.startupConnectionTest === true;
         nextState.tableAccessCount = sanitizeTableAccessCount(state.tableAccessCount);
         nextState.tableSortPreference = sanitizeTableSortPreference(state.tableSortPreference);
         return nextState as AppState;
@@ -560,17 +651,21 @@ export const useStore = create<AppState>()(
           appearance: sanitizeAppearance(state.appearance, 3),
           sqlFormatOptions: sanitizeSqlFormatOptions(state.sqlFormatOptions),
           queryOptions: sanitizeQueryOptions(state.queryOptions),
+          exportLocale: sanitizeExportLocale(state.exportLocale),
+          startupConnectionTest: state.startupConnectionTest === true,
           tableAccessCount: sanitizeTableAccessCount(state.tableAccessCount),
           tableSortPreference: sanitizeTableSortPreference(state.tableSortPreference),
         };
       },
       partialize: (state) => ({
-        connections: state.connections,
+        connections: backendConnectionStoreReady ? state.connections.map(stripConnectionSecrets) : state.connections,
         savedQueries: state.savedQueries,
         theme: state.theme,
         appearance: state.appearance,
         sqlFormatOptions: state.sqlFormatOptions,
         queryOptions: state.queryOptions,
+        exportLocale: state.exportLocale,
+        startupConnectionTest: state.startupConnectionTest,
         tableAccessCount: state.tableAccessCount,
         tableSortPreference: state.tableSortPreference
       }), // Don't persist logs
@@ -4,6 +4,7 @@ export interface SSHConfig {
   user: string;
   password?: string;
   keyPath?: string;
+  jumpHosts?: SSHConfig[]; // 依次经过的跳板机，最后一跳再连接上面的 SSH 主机
 }
 
 export interface ConnectionConfig {
@@ -32,6 +33,20 @@ export interface ConnectionConfig {
   mongoAuthMechanism?: string;
   mongoReplicaUser?: string;
   mongoReplicaPassword?: string;
+  sslMode?: 'disable' | 'require' | 'verify-ca' | 'verify-full';
+  sslCA?: string;
+  sslCert?: string;
+  sslKey?: string;
+  sslSkipVerify?: boolean;
+  sslServerName?: string;
+  keepaliveInterval?: number; // 空闲连接保活间隔（秒），0 不启用
+  keepaliveSQL?: string;
+  maxOpenConns?: number;
+  maxIdleConns?: number;
+  connMaxLifetime?: number; // 秒
+  readOnly?: boolean; // 只读模式：后端拒绝写语句
+  sqliteJournalMode?: string; // SQLite 日志模式：wal/delete/truncate/persist/memory，空为保持当前
+  sqliteBusyTimeout?: number; // SQLite 锁等待毫秒数，默认 5000
 }
 
 export interface MongoMemberInfo {
@@ -49,6 +64,7 @@ export interface SavedConnection {
   config: ConnectionConfig;
   includeDatabases?: string[];
   includeRedisDatabases?: number[]; // Redis databases to show (0-15)
+  databaseList?: { hideSystem?: boolean; pattern?: string }; // 后端过滤数据库列表：隐藏系统库、按名称模式筛选
 }
 
 export interface ColumnDefinition {
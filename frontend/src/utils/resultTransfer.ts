@@ -0,0 +1,38 @@
+// 大结果集压缩传输：后端结果 JSON 超过阈值时返回压缩描述（compressed=true），
+// 这里按块拉取 base64 文本并用 DecompressionStream 解压，还原为普通 QueryResult。
+
+type QueryResultLike = { success: boolean; message: string; data: any; fields?: string[]; [key: string]: any };
+
+const canDecompress = () => typeof (window as any).DecompressionStream !== 'undefined';
+
+const base64ToBytes = (text: string): Uint8Array => {
+    const binary = atob(text);
+    const bytes = new Uint8Array(binary.length);
+    for (let i = 0; i < binary.length; i++) bytes[i] = binary.charCodeAt(i);
+    return bytes;
+};
+
+export const inflateQueryResult = async (res: QueryResultLike): Promise<QueryResultLike> => {
+    const desc = res?.data;
+    if (!res?.success || !desc || desc.compressed !== true) return res;
+    const app = (window as any).go.app.App;
+    const parts: Uint8Array[] = [];
+    for (let i = 0; i < Number(desc.chunks || 0); i++) {
+        const chunk = await app.FetchResultChunk(desc.payloadId, i);
+        if (!chunk?.success) {
+            app.ReleaseResultPayload(desc.payloadId);
+            return { ...res, success: false, data: [], message: chunk?.message || '拉取查询结果失败' };
+        }
+        parts.push(base64ToBytes(String(chunk.data || '')));
+    }
+    const stream = new Blob(parts).stream().pipeThrough(new (window as any).DecompressionStream(desc.encoding || 'gzip'));
+    const body = JSON.parse(await new Response(stream).text());
+    return { ...res, data: Array.isArray(body?.data) ? body.data : [], fields: body?.fields || [] };
+};
+
+// queryWithCompression 等同 DBQuery；WebView 不支持 DecompressionStream 时退回普通传输。
+export const queryWithCompression = async (config: any, dbName: string, sql: string, queryId = ''): Promise<QueryResultLike> => {
+    const app = (window as any).go.app.App;
+    if (!canDecompress()) return app.DBQuery(config, dbName, sql);
+    return inflateQueryResult(await app.DBQueryCompressed(config, dbName, sql, queryId));
+};
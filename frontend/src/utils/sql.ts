@@ -12,7 +12,7 @@ const normalizeIdentPart = (ident: string) => {
   if (!raw) return raw;
   const first = raw[0];
   const last = raw[raw.length - 1];
-  if ((first === '"' && last === '"') || (first === '`' && last === '`')) {
+  if ((first === '"' && last === '"') || (first === '`' && last === '`') || (first === '[' && last === ']')) {
     raw = raw.slice(1, -1).trim();
   }
   raw = raw.replace(/["`]/g, '').trim();
@@ -31,15 +31,20 @@ const needsQuote = (ident: string): boolean => {
   return reserved.includes(ident.toLowerCase());
 };
 
+// 引号规则与后端 QuoteIdentifier（internal/dialect）一致；PostgreSQL/KingBase 仅在必要时加引号
 export const quoteIdentPart = (dbType: string, ident: string) => {
   const raw = normalizeIdentPart(ident);
   if (!raw) return raw;
   const dbTypeLower = (dbType || '').toLowerCase();
 
-  if (dbTypeLower === 'mysql' || dbTypeLower === 'mariadb' || dbTypeLower === 'diros' || dbTypeLower === 'sphinx' || dbTypeLower === 'tdengine') {
+  if (dbTypeLower === 'mysql' || dbTypeLower === 'mariadb' || dbTypeLower === 'diros' || dbTypeLower === 'sphinx' || dbTypeLower === 'tdengine' || dbTypeLower === 'clickhouse') {
     return `\`${raw.replace(/`/g, '``')}\``;
   }
 
+  if (dbTypeLower === 'sqlserver' || dbTypeLower === 'mssql') {
+    return `[${raw.replace(/]/g, ']]')}]`;
+  }
+
   // 对于 KingBase/PostgreSQL，只在必要时加引号
   if (dbTypeLower === 'kingbase' || dbTypeLower === 'postgres') {
     if (needsQuote(raw)) {
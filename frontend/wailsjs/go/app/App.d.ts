@@ -1,25 +1,77 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {connection} from '../models';
+import {app} from '../models';
 import {sync} from '../models';
+import {connectionstore} from '../models';
+import {queryhistory} from '../models';
 import {redis} from '../models';
+import {snippetstore} from '../models';
+
+export function AdviseIndexes(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function AdviseIndexesForSlowQueries(arg1:connection.ConnectionConfig,arg2:string,arg3:number):Promise<connection.QueryResult>;
+
+export function AlterSequence(arg1:connection.ConnectionConfig,arg2:string,arg3:connection.SequenceDefinition):Promise<connection.QueryResult>;
 
 export function ApplyChanges(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:connection.ChangeSet):Promise<connection.QueryResult>;
 
+export function ApplyChangesWithOptions(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:connection.ChangeSet,arg5:app.ApplyChangesOptions):Promise<connection.QueryResult>;
+
+export function ArchiveRows(arg1:connection.ConnectionConfig,arg2:string,arg3:app.ArchiveRowsRequest):Promise<connection.QueryResult>;
+
+export function BackupDatabase(arg1:connection.ConnectionConfig,arg2:string,arg3:app.BackupOptions):Promise<connection.QueryResult>;
+
+export function CallRoutine(arg1:connection.ConnectionConfig,arg2:string,arg3:connection.RoutineCall):Promise<connection.QueryResult>;
+
+export function CancelQuery(arg1:string):Promise<connection.QueryResult>;
+
 export function CheckForUpdates():Promise<connection.QueryResult>;
 
+export function CleanOrphanedDriverFiles(arg1:string,arg2:Array<string>):Promise<connection.QueryResult>;
+
+export function ClearRecentErrors():Promise<connection.QueryResult>;
+
+export function CloneConnection(arg1:string,arg2:app.ConnectionOverrides):Promise<connection.QueryResult>;
+
+export function ClosePortForward(arg1:string):Promise<connection.QueryResult>;
+
+export function CloseSSHTunnel(arg1:string):Promise<connection.QueryResult>;
+
+export function CompareSchemas(arg1:connection.ConnectionConfig,arg2:connection.ConnectionConfig,arg3:app.SchemaCompareOptions):Promise<connection.QueryResult>;
+
+export function CompareTableData(arg1:connection.ConnectionConfig,arg2:connection.ConnectionConfig,arg3:string,arg4:Array<string>,arg5:app.TableDataCompareOptions):Promise<connection.QueryResult>;
+
 export function ConfigureDriverRuntimeDirectory(arg1:string):Promise<connection.QueryResult>;
 
+export function ConfirmSQLPlan(arg1:string,arg2:string):Promise<connection.QueryResult>;
+
 export function CreateDatabase(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
 
+export function CreateSandboxTable(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:app.SandboxOptions):Promise<connection.QueryResult>;
+
+export function CreateSequence(arg1:connection.ConnectionConfig,arg2:string,arg3:connection.SequenceDefinition):Promise<connection.QueryResult>;
+
+export function CreateTrigger(arg1:connection.ConnectionConfig,arg2:string,arg3:connection.TriggerSpec):Promise<connection.QueryResult>;
+
 export function DBConnect(arg1:connection.ConnectionConfig):Promise<connection.QueryResult>;
 
+export function DBCreateIndex(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:connection.IndexSpec):Promise<connection.QueryResult>;
+
+export function DBDropIndex(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
+export function DBEstimateIndexSize(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:connection.IndexSpec):Promise<connection.QueryResult>;
+
 export function DBGetAllColumns(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
 
+export function DBGetAllColumnsBySchema(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
 export function DBGetColumns(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
 export function DBGetDatabases(arg1:connection.ConnectionConfig):Promise<connection.QueryResult>;
 
+export function DBGetDatabasesWithOptions(arg1:connection.ConnectionConfig,arg2:connection.DatabaseListOptions):Promise<connection.QueryResult>;
+
 export function DBGetForeignKeys(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
 export function DBGetIndexes(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
@@ -30,6 +82,24 @@ export function DBGetTriggers(arg1:connection.ConnectionConfig,arg2:string,arg3:
 
 export function DBQuery(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
+export function DBQueryCompressed(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
+export function DBQueryIgnoreLimit(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function DBQueryInTab(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
+export function DBQueryRendered(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
+export function DBQueryRouted(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
+export function DBQueryScript(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:app.ScriptOptions):Promise<connection.QueryResult>;
+
+export function DBQueryTablePage(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:number,arg5:number,arg6:Array<app.TableSort>,arg7:Array<app.TableFilter>):Promise<connection.QueryResult>;
+
+export function DBQueryWithID(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
+export function DBQueryWithParams(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:Array<any>):Promise<connection.QueryResult>;
+
 export function DBShowCreateTable(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
 export function DataSync(arg1:sync.SyncConfig):Promise<sync.SyncResult>;
@@ -38,44 +108,178 @@ export function DataSyncAnalyze(arg1:sync.SyncConfig):Promise<connection.QueryRe
 
 export function DataSyncPreview(arg1:sync.SyncConfig,arg2:string,arg3:number):Promise<connection.QueryResult>;
 
+export function DecodeSpatialValue(arg1:string):Promise<connection.QueryResult>;
+
+export function DeleteConnection(arg1:string):Promise<connection.QueryResult>;
+
+export function DeleteGridPreference(arg1:string,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function DeleteQueryHistory(arg1:Array<number>):Promise<connection.QueryResult>;
+
+export function DeleteQuerySnapshot(arg1:string):Promise<connection.QueryResult>;
+
+export function DeleteSchemaBackup(arg1:string):Promise<connection.QueryResult>;
+
+export function DeleteSnippet(arg1:string):Promise<connection.QueryResult>;
+
+export function DetectDumpTool(arg1:string,arg2:string):Promise<connection.QueryResult>;
+
+export function DiscardSQLPlan(arg1:string):Promise<connection.QueryResult>;
+
 export function DownloadDriverPackage(arg1:string,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
 export function DownloadUpdate():Promise<connection.QueryResult>;
 
 export function DropDatabase(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
 
+export function DropEvent(arg1:connection.ConnectionConfig,arg2:string,arg3:connection.EventDefinition):Promise<connection.QueryResult>;
+
 export function DropFunction(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
 
+export function DropObjectWithBackup(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string,arg5:app.RecycleBinOptions):Promise<connection.QueryResult>;
+
 export function DropTable(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
+export function DropTrigger(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
 export function DropView(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
+export function DumpDatabaseWithTool(arg1:connection.ConnectionConfig,arg2:string,arg3:app.DumpToolOptions):Promise<connection.QueryResult>;
+
+export function EstimateQueryRows(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:number,arg5:number):Promise<connection.QueryResult>;
+
+export function ExecuteSQLFile(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function ExecuteSQLFileWithOptions(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:app.SQLFileOptions):Promise<connection.QueryResult>;
+
+export function ExplainQuery(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:boolean):Promise<connection.QueryResult>;
+
 export function ExportData(arg1:Array<Record<string, any>>,arg2:Array<string>,arg3:string,arg4:string):Promise<connection.QueryResult>;
 
+export function ExportDataWithLocale(arg1:Array<Record<string, any>>,arg2:Array<string>,arg3:string,arg4:string,arg5:app.ExportLocaleOptions):Promise<connection.QueryResult>;
+
 export function ExportDatabaseSQL(arg1:connection.ConnectionConfig,arg2:string,arg3:boolean):Promise<connection.QueryResult>;
 
 export function ExportQuery(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string,arg5:string):Promise<connection.QueryResult>;
 
+export function ExportQueryWithLocale(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string,arg5:string,arg6:app.ExportLocaleOptions):Promise<connection.QueryResult>;
+
+export function ExportSnippets(arg1:string,arg2:Array<string>):Promise<connection.QueryResult>;
+
 export function ExportTable(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
 
+export function ExportTableWithOptions(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string,arg5:app.TableExportOptions):Promise<connection.QueryResult>;
+
 export function ExportTablesDataSQL(arg1:connection.ConnectionConfig,arg2:string,arg3:Array<string>):Promise<connection.QueryResult>;
 
 export function ExportTablesSQL(arg1:connection.ConnectionConfig,arg2:string,arg3:Array<string>,arg4:boolean):Promise<connection.QueryResult>;
 
+export function FederatedQuery(arg1:Array<app.FederatedSource>,arg2:string):Promise<connection.QueryResult>;
+
+export function FetchResultChunk(arg1:string,arg2:number):Promise<connection.QueryResult>;
+
+export function GenerateAlterTable(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:app.TableDefinition,arg5:app.TableDefinition):Promise<connection.QueryResult>;
+
+export function GenerateDesignerDDL(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:Array<app.DesignerOperation>):Promise<connection.QueryResult>;
+
 export function GetAppInfo():Promise<connection.QueryResult>;
 
+export function GetConnectionStoreStatus():Promise<connection.QueryResult>;
+
+export function GetCustomDriverTypes():Promise<connection.QueryResult>;
+
+export function GetDatabaseDependencies(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function GetDatabaseOverview(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function GetDriverNetworkSettings():Promise<connection.QueryResult>;
+
 export function GetDriverStatusList(arg1:string,arg2:string):Promise<connection.QueryResult>;
 
+export function GetERModel(arg1:connection.ConnectionConfig,arg2:string,arg3:boolean):Promise<connection.QueryResult>;
+
+export function GetEvents(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function GetGridPreference(arg1:string,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function GetMigrationStatus(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function GetObjectDependencies(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
+export function GetPrimaryKey(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function GetRecentErrors(arg1:string):Promise<connection.QueryResult>;
+
+export function GetReplicaStatus(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function GetRoutineDefinition(arg1:connection.ConnectionConfig,arg2:string,arg3:connection.RoutineDefinition):Promise<connection.QueryResult>;
+
+export function GetRoutines(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function GetSQLPlan(arg1:string):Promise<connection.QueryResult>;
+
+export function GetSchemaBackupRollback(arg1:string):Promise<connection.QueryResult>;
+
+export function GetSeedScripts(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function GetSequences(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function GetStatusServerSettings():Promise<connection.QueryResult>;
+
+export function GetTableIdentity(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function GetTableStats(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function GetTempWorkspaceUsage():Promise<connection.QueryResult>;
+
+export function GetTimeTravelCapability(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function GetTriggerDefinition(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
+export function GetValueRenderers(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function GetWireDebugStatus(arg1:connection.ConnectionConfig):Promise<connection.QueryResult>;
+
 export function ImportConfigFile():Promise<connection.QueryResult>;
 
+export function ImportConnectionsFromFile(arg1:string,arg2:string):Promise<connection.QueryResult>;
+
 export function ImportData(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
+export function ImportDataWithOptions(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string,arg5:app.ImportOptions):Promise<connection.QueryResult>;
+
 export function ImportDataWithProgress(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
 
+export function ImportGridPreferences(arg1:Array<connectionstore.GridPreference>):Promise<connection.QueryResult>;
+
+export function ImportSnippets(arg1:string,arg2:boolean):Promise<connection.QueryResult>;
+
+export function InstallDriverPackages(arg1:Array<string>,arg2:string):Promise<connection.QueryResult>;
+
 export function InstallLocalDriverPackage(arg1:string,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
 export function InstallUpdateAndRestart():Promise<connection.QueryResult>;
 
+export function ListConnections():Promise<connection.QueryResult>;
+
+export function ListGridPreferences(arg1:string):Promise<connection.QueryResult>;
+
+export function ListOrphanedDriverFiles(arg1:string):Promise<connection.QueryResult>;
+
+export function ListPortForwards():Promise<connection.QueryResult>;
+
+export function ListQuerySnapshots():Promise<connection.QueryResult>;
+
+export function ListRecycleBin():Promise<connection.QueryResult>;
+
+export function ListSSHTunnels():Promise<connection.QueryResult>;
+
+export function ListSchemaBackups():Promise<connection.QueryResult>;
+
+export function ListSnippets():Promise<connection.QueryResult>;
+
+export function ListTabSessions():Promise<connection.QueryResult>;
+
 export function MongoDiscoverMembers(arg1:connection.ConnectionConfig):Promise<connection.QueryResult>;
 
 export function MySQLConnect(arg1:connection.ConnectionConfig):Promise<connection.QueryResult>;
@@ -88,10 +292,42 @@ export function MySQLQuery(arg1:connection.ConnectionConfig,arg2:string,arg3:str
 
 export function MySQLShowCreateTable(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
+export function OpenPortForward(arg1:app.PortForwardRequest):Promise<connection.QueryResult>;
+
 export function OpenSQLFile():Promise<connection.QueryResult>;
 
+export function OpenSiblingDatabase(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function PinTabSession(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function PreviewAffectedRows(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:number):Promise<connection.QueryResult>;
+
+export function PreviewChanges(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:connection.ChangeSet):Promise<connection.QueryResult>;
+
+export function PreviewImportDataPlan(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string,arg5:app.ImportOptions):Promise<connection.QueryResult>;
+
 export function PreviewImportFile(arg1:string):Promise<connection.QueryResult>;
 
+export function PreviewImportFileWithOptions(arg1:string,arg2:app.ImportFileOptions):Promise<connection.QueryResult>;
+
+export function PreviewImportMapping(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string,arg5:app.ImportFileOptions,arg6:app.ImportMapping,arg7:number):Promise<connection.QueryResult>;
+
+export function PreviewSQLPlan(arg1:app.SQLPlanRequest):Promise<connection.QueryResult>;
+
+export function PruneQueryHistory(arg1:queryhistory.PruneOptions):Promise<connection.QueryResult>;
+
+export function PurgeRecycleBin(arg1:string):Promise<connection.QueryResult>;
+
+export function QualifyTable(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function QueryAcrossTenants(arg1:connection.ConnectionConfig,arg2:string,arg3:app.TenantQueryRequest):Promise<connection.QueryResult>;
+
+export function QuoteIdentifier(arg1:string,arg2:string):Promise<string>;
+
+export function ReconnectSSHTunnel(arg1:string):Promise<connection.QueryResult>;
+
+export function RedisCommandCatalog():Promise<connection.QueryResult>;
+
 export function RedisConnect(arg1:connection.ConnectionConfig):Promise<connection.QueryResult>;
 
 export function RedisDeleteHashField(arg1:connection.ConnectionConfig,arg2:string,arg3:Array<string>):Promise<connection.QueryResult>;
@@ -114,6 +350,8 @@ export function RedisListSet(arg1:connection.ConnectionConfig,arg2:string,arg3:n
 
 export function RedisRenameKey(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
+export function RedisRunCommand(arg1:connection.ConnectionConfig,arg2:string,arg3:boolean):Promise<connection.QueryResult>;
+
 export function RedisScanKeys(arg1:connection.ConnectionConfig,arg2:string,arg3:number,arg4:number):Promise<connection.QueryResult>;
 
 export function RedisSelectDB(arg1:connection.ConnectionConfig,arg2:number):Promise<connection.QueryResult>;
@@ -138,24 +376,100 @@ export function RedisZSetAdd(arg1:connection.ConnectionConfig,arg2:string,arg3:A
 
 export function RedisZSetRemove(arg1:connection.ConnectionConfig,arg2:string,arg3:Array<string>):Promise<connection.QueryResult>;
 
+export function RefreshDriverStatus(arg1:string,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function RefreshERModelTables(arg1:connection.ConnectionConfig,arg2:string,arg3:Array<string>):Promise<connection.QueryResult>;
+
+export function ReleaseResultPayload(arg1:string):Promise<void>;
+
+export function ReleaseTabSession(arg1:string):Promise<connection.QueryResult>;
+
+export function ReleaseTempWorkspace(arg1:connection.ConnectionConfig):Promise<connection.QueryResult>;
+
+export function ReloadCustomDriverTypes():Promise<connection.QueryResult>;
+
+export function ReloadDriverStatusList(arg1:string,arg2:string):Promise<connection.QueryResult>;
+
 export function RemoveDriverPackage(arg1:string,arg2:string):Promise<connection.QueryResult>;
 
 export function RenameDatabase(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
 
+export function RenameSnippetFolder(arg1:string,arg2:string):Promise<connection.QueryResult>;
+
 export function RenameTable(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
 
 export function RenameView(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
 
+export function RenderSnippet(arg1:string,arg2:Record<string, string>):Promise<connection.QueryResult>;
+
+export function ResetSeedScriptState(arg1:connection.ConnectionConfig,arg2:string):Promise<connection.QueryResult>;
+
+export function ResetTableIdentity(arg1:connection.ConnectionConfig,arg2:string,arg3:app.ResetIdentityRequest):Promise<connection.QueryResult>;
+
 export function ResolveDriverDownloadDirectory(arg1:string):Promise<connection.QueryResult>;
 
 export function ResolveDriverPackageDownloadURL(arg1:string,arg2:string):Promise<connection.QueryResult>;
 
 export function ResolveDriverRepositoryURL(arg1:string):Promise<connection.QueryResult>;
 
+export function RestartSequence(arg1:connection.ConnectionConfig,arg2:string,arg3:connection.SequenceDefinition,arg4:string):Promise<connection.QueryResult>;
+
+export function RestoreFromRecycleBin(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function RunInsertLoadTest(arg1:connection.ConnectionConfig,arg2:string,arg3:app.LoadTestOptions):Promise<connection.QueryResult>;
+
+export function RunMigrations(arg1:connection.ConnectionConfig,arg2:string,arg3:app.MigrationRunRequest):Promise<connection.QueryResult>;
+
+export function RunSeedScripts(arg1:connection.ConnectionConfig,arg2:string,arg3:app.SeedRunOptions):Promise<connection.QueryResult>;
+
+export function RunSnippet(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:Record<string, string>,arg5:app.ScriptOptions):Promise<connection.QueryResult>;
+
+export function SaveBinaryValueToWorkspace(arg1:connection.ConnectionConfig,arg2:string,arg3:string):Promise<connection.QueryResult>;
+
+export function SaveConnection(arg1:connectionstore.Profile):Promise<connection.QueryResult>;
+
+export function SaveDriverNetworkSettings(arg1:app.DriverNetworkSettings):Promise<connection.QueryResult>;
+
+export function SaveGridPreference(arg1:connectionstore.GridPreference):Promise<connection.QueryResult>;
+
+export function SaveQueryResultAsTable(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:app.MaterializeOptions):Promise<connection.QueryResult>;
+
+export function SaveSeedScripts(arg1:connection.ConnectionConfig,arg2:string,arg3:Array<string>):Promise<connection.QueryResult>;
+
+export function SaveSnippet(arg1:snippetstore.Snippet):Promise<connection.QueryResult>;
+
+export function SaveStatusServerSettings(arg1:app.StatusServerSettings):Promise<connection.QueryResult>;
+
+export function SaveValueRenderers(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:Array<app.ValueRendererRule>):Promise<connection.QueryResult>;
+
+export function SearchQueryHistory(arg1:queryhistory.Filter):Promise<connection.QueryResult>;
+
 export function SelectDriverDownloadDirectory(arg1:string):Promise<connection.QueryResult>;
 
 export function SelectDriverPackageFile(arg1:string):Promise<connection.QueryResult>;
 
+export function SelectMigrationDirectory():Promise<connection.QueryResult>;
+
+export function SelectSeedScriptFiles():Promise<connection.QueryResult>;
+
+export function SetConnectionStoreMasterPassword(arg1:string,arg2:string):Promise<connection.QueryResult>;
+
+export function SetEventEnabled(arg1:connection.ConnectionConfig,arg2:string,arg3:connection.EventDefinition,arg4:boolean):Promise<connection.QueryResult>;
+
+export function SetQueryHistoryFavorite(arg1:number,arg2:boolean):Promise<connection.QueryResult>;
+
 export function SetWindowTranslucency(arg1:number,arg2:number):Promise<void>;
 
+export function SetWireDebug(arg1:connection.ConnectionConfig,arg2:boolean):Promise<connection.QueryResult>;
+
+export function SnapshotQueryToDuckDB(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string):Promise<connection.QueryResult>;
+
 export function TestConnection(arg1:connection.ConnectionConfig):Promise<connection.QueryResult>;
+
+export function TestSavedConnections(arg1:Array<string>):Promise<connection.QueryResult>;
+
+export function TimeTravelQuery(arg1:connection.ConnectionConfig,arg2:string,arg3:string,arg4:string,arg5:number):Promise<connection.QueryResult>;
+
+export function TransferTables(arg1:connection.ConnectionConfig,arg2:connection.ConnectionConfig,arg3:Array<string>,arg4:app.TransferOptions):Promise<connection.QueryResult>;
+
+export function UnlockConnectionStore(arg1:string):Promise<connection.QueryResult>;
@@ -2,30 +2,122 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AdviseIndexes(arg1, arg2, arg3) {
+  return window['go']['app']['App']['AdviseIndexes'](arg1, arg2, arg3);
+}
+
+export function AdviseIndexesForSlowQueries(arg1, arg2, arg3) {
+  return window['go']['app']['App']['AdviseIndexesForSlowQueries'](arg1, arg2, arg3);
+}
+
+export function AlterSequence(arg1, arg2, arg3) {
+  return window['go']['app']['App']['AlterSequence'](arg1, arg2, arg3);
+}
+
 export function ApplyChanges(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['ApplyChanges'](arg1, arg2, arg3, arg4);
 }
 
+export function ApplyChangesWithOptions(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['ApplyChangesWithOptions'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function ArchiveRows(arg1, arg2, arg3) {
+  return window['go']['app']['App']['ArchiveRows'](arg1, arg2, arg3);
+}
+
+export function BackupDatabase(arg1, arg2, arg3) {
+  return window['go']['app']['App']['BackupDatabase'](arg1, arg2, arg3);
+}
+
+export function CallRoutine(arg1, arg2, arg3) {
+  return window['go']['app']['App']['CallRoutine'](arg1, arg2, arg3);
+}
+
+export function CancelQuery(arg1) {
+  return window['go']['app']['App']['CancelQuery'](arg1);
+}
+
 export function CheckForUpdates() {
   return window['go']['app']['App']['CheckForUpdates']();
 }
 
+export function CleanOrphanedDriverFiles(arg1, arg2) {
+  return window['go']['app']['App']['CleanOrphanedDriverFiles'](arg1, arg2);
+}
+
+export function ClearRecentErrors() {
+  return window['go']['app']['App']['ClearRecentErrors']();
+}
+
+export function CloneConnection(arg1, arg2) {
+  return window['go']['app']['App']['CloneConnection'](arg1, arg2);
+}
+
+export function ClosePortForward(arg1) {
+  return window['go']['app']['App']['ClosePortForward'](arg1);
+}
+
+export function CloseSSHTunnel(arg1) {
+  return window['go']['app']['App']['CloseSSHTunnel'](arg1);
+}
+
+export function CompareSchemas(arg1, arg2, arg3) {
+  return window['go']['app']['App']['CompareSchemas'](arg1, arg2, arg3);
+}
+
+export function CompareTableData(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['CompareTableData'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function ConfigureDriverRuntimeDirectory(arg1) {
   return window['go']['app']['App']['ConfigureDriverRuntimeDirectory'](arg1);
 }
 
+export function ConfirmSQLPlan(arg1, arg2) {
+  return window['go']['app']['App']['ConfirmSQLPlan'](arg1, arg2);
+}
+
 export function CreateDatabase(arg1, arg2) {
   return window['go']['app']['App']['CreateDatabase'](arg1, arg2);
 }
 
+export function CreateSandboxTable(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['CreateSandboxTable'](arg1, arg2, arg3, arg4);
+}
+
+export function CreateSequence(arg1, arg2, arg3) {
+  return window['go']['app']['App']['CreateSequence'](arg1, arg2, arg3);
+}
+
+export function CreateTrigger(arg1, arg2, arg3) {
+  return window['go']['app']['App']['CreateTrigger'](arg1, arg2, arg3);
+}
+
 export function DBConnect(arg1) {
   return window['go']['app']['App']['DBConnect'](arg1);
 }
 
+export function DBCreateIndex(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBCreateIndex'](arg1, arg2, arg3, arg4);
+}
+
+export function DBDropIndex(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBDropIndex'](arg1, arg2, arg3, arg4);
+}
+
+export function DBEstimateIndexSize(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBEstimateIndexSize'](arg1, arg2, arg3, arg4);
+}
+
 export function DBGetAllColumns(arg1, arg2) {
   return window['go']['app']['App']['DBGetAllColumns'](arg1, arg2);
 }
 
+export function DBGetAllColumnsBySchema(arg1, arg2, arg3) {
+  return window['go']['app']['App']['DBGetAllColumnsBySchema'](arg1, arg2, arg3);
+}
+
 export function DBGetColumns(arg1, arg2, arg3) {
   return window['go']['app']['App']['DBGetColumns'](arg1, arg2, arg3);
 }
@@ -34,6 +126,10 @@ export function DBGetDatabases(arg1) {
   return window['go']['app']['App']['DBGetDatabases'](arg1);
 }
 
+export function DBGetDatabasesWithOptions(arg1, arg2) {
+  return window['go']['app']['App']['DBGetDatabasesWithOptions'](arg1, arg2);
+}
+
 export function DBGetForeignKeys(arg1, arg2, arg3) {
   return window['go']['app']['App']['DBGetForeignKeys'](arg1, arg2, arg3);
 }
@@ -54,6 +150,42 @@ export function DBQuery(arg1, arg2, arg3) {
   return window['go']['app']['App']['DBQuery'](arg1, arg2, arg3);
 }
 
+export function DBQueryCompressed(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBQueryCompressed'](arg1, arg2, arg3, arg4);
+}
+
+export function DBQueryIgnoreLimit(arg1, arg2, arg3) {
+  return window['go']['app']['App']['DBQueryIgnoreLimit'](arg1, arg2, arg3);
+}
+
+export function DBQueryInTab(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBQueryInTab'](arg1, arg2, arg3, arg4);
+}
+
+export function DBQueryRendered(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBQueryRendered'](arg1, arg2, arg3, arg4);
+}
+
+export function DBQueryRouted(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBQueryRouted'](arg1, arg2, arg3, arg4);
+}
+
+export function DBQueryScript(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBQueryScript'](arg1, arg2, arg3, arg4);
+}
+
+export function DBQueryTablePage(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['app']['App']['DBQueryTablePage'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function DBQueryWithID(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBQueryWithID'](arg1, arg2, arg3, arg4);
+}
+
+export function DBQueryWithParams(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DBQueryWithParams'](arg1, arg2, arg3, arg4);
+}
+
 export function DBShowCreateTable(arg1, arg2, arg3) {
   return window['go']['app']['App']['DBShowCreateTable'](arg1, arg2, arg3);
 }
@@ -70,6 +202,42 @@ export function DataSyncPreview(arg1, arg2, arg3) {
   return window['go']['app']['App']['DataSyncPreview'](arg1, arg2, arg3);
 }
 
+export function DecodeSpatialValue(arg1) {
+  return window['go']['app']['App']['DecodeSpatialValue'](arg1);
+}
+
+export function DeleteConnection(arg1) {
+  return window['go']['app']['App']['DeleteConnection'](arg1);
+}
+
+export function DeleteGridPreference(arg1, arg2, arg3) {
+  return window['go']['app']['App']['DeleteGridPreference'](arg1, arg2, arg3);
+}
+
+export function DeleteQueryHistory(arg1) {
+  return window['go']['app']['App']['DeleteQueryHistory'](arg1);
+}
+
+export function DeleteQuerySnapshot(arg1) {
+  return window['go']['app']['App']['DeleteQuerySnapshot'](arg1);
+}
+
+export function DeleteSchemaBackup(arg1) {
+  return window['go']['app']['App']['DeleteSchemaBackup'](arg1);
+}
+
+export function DeleteSnippet(arg1) {
+  return window['go']['app']['App']['DeleteSnippet'](arg1);
+}
+
+export function DetectDumpTool(arg1, arg2) {
+  return window['go']['app']['App']['DetectDumpTool'](arg1, arg2);
+}
+
+export function DiscardSQLPlan(arg1) {
+  return window['go']['app']['App']['DiscardSQLPlan'](arg1);
+}
+
 export function DownloadDriverPackage(arg1, arg2, arg3) {
   return window['go']['app']['App']['DownloadDriverPackage'](arg1, arg2, arg3);
 }
@@ -82,22 +250,58 @@ export function DropDatabase(arg1, arg2) {
   return window['go']['app']['App']['DropDatabase'](arg1, arg2);
 }
 
+export function DropEvent(arg1, arg2, arg3) {
+  return window['go']['app']['App']['DropEvent'](arg1, arg2, arg3);
+}
+
 export function DropFunction(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['DropFunction'](arg1, arg2, arg3, arg4);
 }
 
+export function DropObjectWithBackup(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['DropObjectWithBackup'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function DropTable(arg1, arg2, arg3) {
   return window['go']['app']['App']['DropTable'](arg1, arg2, arg3);
 }
 
+export function DropTrigger(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DropTrigger'](arg1, arg2, arg3, arg4);
+}
+
 export function DropView(arg1, arg2, arg3) {
   return window['go']['app']['App']['DropView'](arg1, arg2, arg3);
 }
 
+export function DumpDatabaseWithTool(arg1, arg2, arg3) {
+  return window['go']['app']['App']['DumpDatabaseWithTool'](arg1, arg2, arg3);
+}
+
+export function EstimateQueryRows(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['EstimateQueryRows'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function ExecuteSQLFile(arg1, arg2, arg3) {
+  return window['go']['app']['App']['ExecuteSQLFile'](arg1, arg2, arg3);
+}
+
+export function ExecuteSQLFileWithOptions(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['ExecuteSQLFileWithOptions'](arg1, arg2, arg3, arg4);
+}
+
+export function ExplainQuery(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['ExplainQuery'](arg1, arg2, arg3, arg4);
+}
+
 export function ExportData(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['ExportData'](arg1, arg2, arg3, arg4);
 }
 
+export function ExportDataWithLocale(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['ExportDataWithLocale'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function ExportDatabaseSQL(arg1, arg2, arg3) {
   return window['go']['app']['App']['ExportDatabaseSQL'](arg1, arg2, arg3);
 }
@@ -106,10 +310,22 @@ export function ExportQuery(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['app']['App']['ExportQuery'](arg1, arg2, arg3, arg4, arg5);
 }
 
+export function ExportQueryWithLocale(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['app']['App']['ExportQueryWithLocale'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function ExportSnippets(arg1, arg2) {
+  return window['go']['app']['App']['ExportSnippets'](arg1, arg2);
+}
+
 export function ExportTable(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['ExportTable'](arg1, arg2, arg3, arg4);
 }
 
+export function ExportTableWithOptions(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['ExportTableWithOptions'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function ExportTablesDataSQL(arg1, arg2, arg3) {
   return window['go']['app']['App']['ExportTablesDataSQL'](arg1, arg2, arg3);
 }
@@ -118,26 +334,170 @@ export function ExportTablesSQL(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['ExportTablesSQL'](arg1, arg2, arg3, arg4);
 }
 
+export function FederatedQuery(arg1, arg2) {
+  return window['go']['app']['App']['FederatedQuery'](arg1, arg2);
+}
+
+export function FetchResultChunk(arg1, arg2) {
+  return window['go']['app']['App']['FetchResultChunk'](arg1, arg2);
+}
+
+export function GenerateAlterTable(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['GenerateAlterTable'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GenerateDesignerDDL(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['GenerateDesignerDDL'](arg1, arg2, arg3, arg4);
+}
+
 export function GetAppInfo() {
   return window['go']['app']['App']['GetAppInfo']();
 }
 
+export function GetConnectionStoreStatus() {
+  return window['go']['app']['App']['GetConnectionStoreStatus']();
+}
+
+export function GetCustomDriverTypes() {
+  return window['go']['app']['App']['GetCustomDriverTypes']();
+}
+
+export function GetDatabaseDependencies(arg1, arg2) {
+  return window['go']['app']['App']['GetDatabaseDependencies'](arg1, arg2);
+}
+
+export function GetDatabaseOverview(arg1, arg2) {
+  return window['go']['app']['App']['GetDatabaseOverview'](arg1, arg2);
+}
+
+export function GetDriverNetworkSettings() {
+  return window['go']['app']['App']['GetDriverNetworkSettings']();
+}
+
 export function GetDriverStatusList(arg1, arg2) {
   return window['go']['app']['App']['GetDriverStatusList'](arg1, arg2);
 }
 
+export function GetERModel(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetERModel'](arg1, arg2, arg3);
+}
+
+export function GetEvents(arg1, arg2) {
+  return window['go']['app']['App']['GetEvents'](arg1, arg2);
+}
+
+export function GetGridPreference(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetGridPreference'](arg1, arg2, arg3);
+}
+
+export function GetMigrationStatus(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetMigrationStatus'](arg1, arg2, arg3);
+}
+
+export function GetObjectDependencies(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['GetObjectDependencies'](arg1, arg2, arg3, arg4);
+}
+
+export function GetPrimaryKey(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetPrimaryKey'](arg1, arg2, arg3);
+}
+
+export function GetRecentErrors(arg1) {
+  return window['go']['app']['App']['GetRecentErrors'](arg1);
+}
+
+export function GetReplicaStatus(arg1, arg2) {
+  return window['go']['app']['App']['GetReplicaStatus'](arg1, arg2);
+}
+
+export function GetRoutineDefinition(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetRoutineDefinition'](arg1, arg2, arg3);
+}
+
+export function GetRoutines(arg1, arg2) {
+  return window['go']['app']['App']['GetRoutines'](arg1, arg2);
+}
+
+export function GetSQLPlan(arg1) {
+  return window['go']['app']['App']['GetSQLPlan'](arg1);
+}
+
+export function GetSchemaBackupRollback(arg1) {
+  return window['go']['app']['App']['GetSchemaBackupRollback'](arg1);
+}
+
+export function GetSeedScripts(arg1, arg2) {
+  return window['go']['app']['App']['GetSeedScripts'](arg1, arg2);
+}
+
+export function GetSequences(arg1, arg2) {
+  return window['go']['app']['App']['GetSequences'](arg1, arg2);
+}
+
+export function GetStatusServerSettings() {
+  return window['go']['app']['App']['GetStatusServerSettings']();
+}
+
+export function GetTableIdentity(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetTableIdentity'](arg1, arg2, arg3);
+}
+
+export function GetTableStats(arg1, arg2) {
+  return window['go']['app']['App']['GetTableStats'](arg1, arg2);
+}
+
+export function GetTempWorkspaceUsage() {
+  return window['go']['app']['App']['GetTempWorkspaceUsage']();
+}
+
+export function GetTimeTravelCapability(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetTimeTravelCapability'](arg1, arg2, arg3);
+}
+
+export function GetTriggerDefinition(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['GetTriggerDefinition'](arg1, arg2, arg3, arg4);
+}
+
+export function GetValueRenderers(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetValueRenderers'](arg1, arg2, arg3);
+}
+
+export function GetWireDebugStatus(arg1) {
+  return window['go']['app']['App']['GetWireDebugStatus'](arg1);
+}
+
 export function ImportConfigFile() {
   return window['go']['app']['App']['ImportConfigFile']();
 }
 
+export function ImportConnectionsFromFile(arg1, arg2) {
+  return window['go']['app']['App']['ImportConnectionsFromFile'](arg1, arg2);
+}
+
 export function ImportData(arg1, arg2, arg3) {
   return window['go']['app']['App']['ImportData'](arg1, arg2, arg3);
 }
 
+export function ImportDataWithOptions(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['ImportDataWithOptions'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function ImportDataWithProgress(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['ImportDataWithProgress'](arg1, arg2, arg3, arg4);
 }
 
+export function ImportGridPreferences(arg1) {
+  return window['go']['app']['App']['ImportGridPreferences'](arg1);
+}
+
+export function ImportSnippets(arg1, arg2) {
+  return window['go']['app']['App']['ImportSnippets'](arg1, arg2);
+}
+
+export function InstallDriverPackages(arg1, arg2) {
+  return window['go']['app']['App']['InstallDriverPackages'](arg1, arg2);
+}
+
 export function InstallLocalDriverPackage(arg1, arg2, arg3) {
   return window['go']['app']['App']['InstallLocalDriverPackage'](arg1, arg2, arg3);
 }
@@ -146,6 +506,46 @@ export function InstallUpdateAndRestart() {
   return window['go']['app']['App']['InstallUpdateAndRestart']();
 }
 
+export function ListConnections() {
+  return window['go']['app']['App']['ListConnections']();
+}
+
+export function ListGridPreferences(arg1) {
+  return window['go']['app']['App']['ListGridPreferences'](arg1);
+}
+
+export function ListOrphanedDriverFiles(arg1) {
+  return window['go']['app']['App']['ListOrphanedDriverFiles'](arg1);
+}
+
+export function ListPortForwards() {
+  return window['go']['app']['App']['ListPortForwards']();
+}
+
+export function ListQuerySnapshots() {
+  return window['go']['app']['App']['ListQuerySnapshots']();
+}
+
+export function ListRecycleBin() {
+  return window['go']['app']['App']['ListRecycleBin']();
+}
+
+export function ListSSHTunnels() {
+  return window['go']['app']['App']['ListSSHTunnels']();
+}
+
+export function ListSchemaBackups() {
+  return window['go']['app']['App']['ListSchemaBackups']();
+}
+
+export function ListSnippets() {
+  return window['go']['app']['App']['ListSnippets']();
+}
+
+export function ListTabSessions() {
+  return window['go']['app']['App']['ListTabSessions']();
+}
+
 export function MongoDiscoverMembers(arg1) {
   return window['go']['app']['App']['MongoDiscoverMembers'](arg1);
 }
@@ -170,14 +570,78 @@ export function MySQLShowCreateTable(arg1, arg2, arg3) {
   return window['go']['app']['App']['MySQLShowCreateTable'](arg1, arg2, arg3);
 }
 
+export function OpenPortForward(arg1) {
+  return window['go']['app']['App']['OpenPortForward'](arg1);
+}
+
 export function OpenSQLFile() {
   return window['go']['app']['App']['OpenSQLFile']();
 }
 
+export function OpenSiblingDatabase(arg1, arg2) {
+  return window['go']['app']['App']['OpenSiblingDatabase'](arg1, arg2);
+}
+
+export function PinTabSession(arg1, arg2, arg3) {
+  return window['go']['app']['App']['PinTabSession'](arg1, arg2, arg3);
+}
+
+export function PreviewAffectedRows(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['PreviewAffectedRows'](arg1, arg2, arg3, arg4);
+}
+
+export function PreviewChanges(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['PreviewChanges'](arg1, arg2, arg3, arg4);
+}
+
+export function PreviewImportDataPlan(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['PreviewImportDataPlan'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function PreviewImportFile(arg1) {
   return window['go']['app']['App']['PreviewImportFile'](arg1);
 }
 
+export function PreviewImportFileWithOptions(arg1, arg2) {
+  return window['go']['app']['App']['PreviewImportFileWithOptions'](arg1, arg2);
+}
+
+export function PreviewImportMapping(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['app']['App']['PreviewImportMapping'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function PreviewSQLPlan(arg1) {
+  return window['go']['app']['App']['PreviewSQLPlan'](arg1);
+}
+
+export function PruneQueryHistory(arg1) {
+  return window['go']['app']['App']['PruneQueryHistory'](arg1);
+}
+
+export function PurgeRecycleBin(arg1) {
+  return window['go']['app']['App']['PurgeRecycleBin'](arg1);
+}
+
+export function QualifyTable(arg1, arg2, arg3) {
+  return window['go']['app']['App']['QualifyTable'](arg1, arg2, arg3);
+}
+
+export function QueryAcrossTenants(arg1, arg2, arg3) {
+  return window['go']['app']['App']['QueryAcrossTenants'](arg1, arg2, arg3);
+}
+
+export function QuoteIdentifier(arg1, arg2) {
+  return window['go']['app']['App']['QuoteIdentifier'](arg1, arg2);
+}
+
+export function ReconnectSSHTunnel(arg1) {
+  return window['go']['app']['App']['ReconnectSSHTunnel'](arg1);
+}
+
+export function RedisCommandCatalog() {
+  return window['go']['app']['App']['RedisCommandCatalog']();
+}
+
 export function RedisConnect(arg1) {
   return window['go']['app']['App']['RedisConnect'](arg1);
 }
@@ -222,6 +686,10 @@ export function RedisRenameKey(arg1, arg2, arg3) {
   return window['go']['app']['App']['RedisRenameKey'](arg1, arg2, arg3);
 }
 
+export function RedisRunCommand(arg1, arg2, arg3) {
+  return window['go']['app']['App']['RedisRunCommand'](arg1, arg2, arg3);
+}
+
 export function RedisScanKeys(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['RedisScanKeys'](arg1, arg2, arg3, arg4);
 }
@@ -270,6 +738,34 @@ export function RedisZSetRemove(arg1, arg2, arg3) {
   return window['go']['app']['App']['RedisZSetRemove'](arg1, arg2, arg3);
 }
 
+export function RefreshDriverStatus(arg1, arg2, arg3) {
+  return window['go']['app']['App']['RefreshDriverStatus'](arg1, arg2, arg3);
+}
+
+export function RefreshERModelTables(arg1, arg2, arg3) {
+  return window['go']['app']['App']['RefreshERModelTables'](arg1, arg2, arg3);
+}
+
+export function ReleaseResultPayload(arg1) {
+  return window['go']['app']['App']['ReleaseResultPayload'](arg1);
+}
+
+export function ReleaseTabSession(arg1) {
+  return window['go']['app']['App']['ReleaseTabSession'](arg1);
+}
+
+export function ReleaseTempWorkspace(arg1) {
+  return window['go']['app']['App']['ReleaseTempWorkspace'](arg1);
+}
+
+export function ReloadCustomDriverTypes() {
+  return window['go']['app']['App']['ReloadCustomDriverTypes']();
+}
+
+export function ReloadDriverStatusList(arg1, arg2) {
+  return window['go']['app']['App']['ReloadDriverStatusList'](arg1, arg2);
+}
+
 export function RemoveDriverPackage(arg1, arg2) {
   return window['go']['app']['App']['RemoveDriverPackage'](arg1, arg2);
 }
@@ -278,6 +774,10 @@ export function RenameDatabase(arg1, arg2, arg3) {
   return window['go']['app']['App']['RenameDatabase'](arg1, arg2, arg3);
 }
 
+export function RenameSnippetFolder(arg1, arg2) {
+  return window['go']['app']['App']['RenameSnippetFolder'](arg1, arg2);
+}
+
 export function RenameTable(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['RenameTable'](arg1, arg2, arg3, arg4);
 }
@@ -286,6 +786,18 @@ export function RenameView(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['RenameView'](arg1, arg2, arg3, arg4);
 }
 
+export function RenderSnippet(arg1, arg2) {
+  return window['go']['app']['App']['RenderSnippet'](arg1, arg2);
+}
+
+export function ResetSeedScriptState(arg1, arg2) {
+  return window['go']['app']['App']['ResetSeedScriptState'](arg1, arg2);
+}
+
+export function ResetTableIdentity(arg1, arg2, arg3) {
+  return window['go']['app']['App']['ResetTableIdentity'](arg1, arg2, arg3);
+}
+
 export function ResolveDriverDownloadDirectory(arg1) {
   return window['go']['app']['App']['ResolveDriverDownloadDirectory'](arg1);
 }
@@ -298,6 +810,70 @@ export function ResolveDriverRepositoryURL(arg1) {
   return window['go']['app']['App']['ResolveDriverRepositoryURL'](arg1);
 }
 
+export function RestartSequence(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['RestartSequence'](arg1, arg2, arg3, arg4);
+}
+
+export function RestoreFromRecycleBin(arg1, arg2, arg3) {
+  return window['go']['app']['App']['RestoreFromRecycleBin'](arg1, arg2, arg3);
+}
+
+export function RunInsertLoadTest(arg1, arg2, arg3) {
+  return window['go']['app']['App']['RunInsertLoadTest'](arg1, arg2, arg3);
+}
+
+export function RunMigrations(arg1, arg2, arg3) {
+  return window['go']['app']['App']['RunMigrations'](arg1, arg2, arg3);
+}
+
+export function RunSeedScripts(arg1, arg2, arg3) {
+  return window['go']['app']['App']['RunSeedScripts'](arg1, arg2, arg3);
+}
+
+export function RunSnippet(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['RunSnippet'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SaveBinaryValueToWorkspace(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SaveBinaryValueToWorkspace'](arg1, arg2, arg3);
+}
+
+export function SaveConnection(arg1) {
+  return window['go']['app']['App']['SaveConnection'](arg1);
+}
+
+export function SaveDriverNetworkSettings(arg1) {
+  return window['go']['app']['App']['SaveDriverNetworkSettings'](arg1);
+}
+
+export function SaveGridPreference(arg1) {
+  return window['go']['app']['App']['SaveGridPreference'](arg1);
+}
+
+export function SaveQueryResultAsTable(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['SaveQueryResultAsTable'](arg1, arg2, arg3, arg4);
+}
+
+export function SaveSeedScripts(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SaveSeedScripts'](arg1, arg2, arg3);
+}
+
+export function SaveSnippet(arg1) {
+  return window['go']['app']['App']['SaveSnippet'](arg1);
+}
+
+export function SaveStatusServerSettings(arg1) {
+  return window['go']['app']['App']['SaveStatusServerSettings'](arg1);
+}
+
+export function SaveValueRenderers(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['SaveValueRenderers'](arg1, arg2, arg3, arg4);
+}
+
+export function SearchQueryHistory(arg1) {
+  return window['go']['app']['App']['SearchQueryHistory'](arg1);
+}
+
 export function SelectDriverDownloadDirectory(arg1) {
   return window['go']['app']['App']['SelectDriverDownloadDirectory'](arg1);
 }
@@ -306,10 +882,54 @@ export function SelectDriverPackageFile(arg1) {
   return window['go']['app']['App']['SelectDriverPackageFile'](arg1);
 }
 
+export function SelectMigrationDirectory() {
+  return window['go']['app']['App']['SelectMigrationDirectory']();
+}
+
+export function SelectSeedScriptFiles() {
+  return window['go']['app']['App']['SelectSeedScriptFiles']();
+}
+
+export function SetConnectionStoreMasterPassword(arg1, arg2) {
+  return window['go']['app']['App']['SetConnectionStoreMasterPassword'](arg1, arg2);
+}
+
+export function SetEventEnabled(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['SetEventEnabled'](arg1, arg2, arg3, arg4);
+}
+
+export function SetQueryHistoryFavorite(arg1, arg2) {
+  return window['go']['app']['App']['SetQueryHistoryFavorite'](arg1, arg2);
+}
+
 export function SetWindowTranslucency(arg1, arg2) {
   return window['go']['app']['App']['SetWindowTranslucency'](arg1, arg2);
 }
 
+export function SetWireDebug(arg1, arg2) {
+  return window['go']['app']['App']['SetWireDebug'](arg1, arg2);
+}
+
+export function SnapshotQueryToDuckDB(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['SnapshotQueryToDuckDB'](arg1, arg2, arg3, arg4);
+}
+
 export function TestConnection(arg1) {
   return window['go']['app']['App']['TestConnection'](arg1);
 }
+
+export function TestSavedConnections(arg1) {
+  return window['go']['app']['App']['TestSavedConnections'](arg1);
+}
+
+export function TimeTravelQuery(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['TimeTravelQuery'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function TransferTables(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['TransferTables'](arg1, arg2, arg3, arg4);
+}
+
+export function UnlockConnectionStore(arg1) {
+  return window['go']['app']['App']['UnlockConnectionStore'](arg1);
+}
@@ -1,33 +1,1374 @@
+export namespace app {
+	
+	export class ApplyChangesOptions {
+	    saveUndo: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApplyChangesOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.saveUndo = source["saveUndo"];
+	    }
+	}
+	export class ArchiveRowsRequest {
+	    table: string;
+	    dateColumn: string;
+	    cutoff: string;
+	    targetConfig?: connection.ConnectionConfig;
+	    targetDbName?: string;
+	    targetTable?: string;
+	    createTarget?: boolean;
+	    batchSize?: number;
+	    dryRun?: boolean;
+	    jobId?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ArchiveRowsRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.table = source["table"];
+	        this.dateColumn = source["dateColumn"];
+	        this.cutoff = source["cutoff"];
+	        this.targetConfig = this.convertValues(source["targetConfig"], connection.ConnectionConfig);
+	        this.targetDbName = source["targetDbName"];
+	        this.targetTable = source["targetTable"];
+	        this.createTarget = source["createTarget"];
+	        this.batchSize = source["batchSize"];
+	        this.dryRun = source["dryRun"];
+	        this.jobId = source["jobId"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BackupOptions {
+	    filePath?: string;
+	    tables?: string[];
+	    includeData: boolean;
+	    dropIfExists?: boolean;
+	    includeViews?: boolean;
+	    includeRoutines?: boolean;
+	    includeTriggers?: boolean;
+	    batchSize?: number;
+	    gzip?: boolean;
+	    jobId?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.tables = source["tables"];
+	        this.includeData = source["includeData"];
+	        this.dropIfExists = source["dropIfExists"];
+	        this.includeViews = source["includeViews"];
+	        this.includeRoutines = source["includeRoutines"];
+	        this.includeTriggers = source["includeTriggers"];
+	        this.batchSize = source["batchSize"];
+	        this.gzip = source["gzip"];
+	        this.jobId = source["jobId"];
+	    }
+	}
+	export class ConnectionOverrides {
+	    name?: string;
+	    database?: string;
+	    user?: string;
+	    password?: string;
+	    redisDB?: number;
+	    readOnly?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectionOverrides(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.database = source["database"];
+	        this.user = source["user"];
+	        this.password = source["password"];
+	        this.redisDB = source["redisDB"];
+	        this.readOnly = source["readOnly"];
+	    }
+	}
+	export class DesignerOperation {
+	    kind: string;
+	    columns?: string[];
+	    charset?: string;
+	    collation?: string;
+	    createdColumn?: string;
+	    updatedColumn?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DesignerOperation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.columns = source["columns"];
+	        this.charset = source["charset"];
+	        this.collation = source["collation"];
+	        this.createdColumn = source["createdColumn"];
+	        this.updatedColumn = source["updatedColumn"];
+	    }
+	}
+	export class DriverNetworkSettings {
+	    offline: boolean;
+	    manifestTimeoutSeconds?: number;
+	    probeTimeoutSeconds?: number;
+	    downloadTimeoutSeconds?: number;
+	    maxAttempts?: number;
+	    retryBackoffMs?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DriverNetworkSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.offline = source["offline"];
+	        this.manifestTimeoutSeconds = source["manifestTimeoutSeconds"];
+	        this.probeTimeoutSeconds = source["probeTimeoutSeconds"];
+	        this.downloadTimeoutSeconds = source["downloadTimeoutSeconds"];
+	        this.maxAttempts = source["maxAttempts"];
+	        this.retryBackoffMs = source["retryBackoffMs"];
+	    }
+	}
+	export class DumpToolOptions {
+	    toolPath?: string;
+	    filePath?: string;
+	    tables?: string[];
+	    schemaOnly?: boolean;
+	    dataOnly?: boolean;
+	    format?: string;
+	    jobId?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DumpToolOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.toolPath = source["toolPath"];
+	        this.filePath = source["filePath"];
+	        this.tables = source["tables"];
+	        this.schemaOnly = source["schemaOnly"];
+	        this.dataOnly = source["dataOnly"];
+	        this.format = source["format"];
+	        this.jobId = source["jobId"];
+	    }
+	}
+	export class ExportLocaleOptions {
+	    decimalSeparator?: string;
+	    thousandsSeparator?: string;
+	    datePattern?: string;
+	    dateTimePattern?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportLocaleOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.decimalSeparator = source["decimalSeparator"];
+	        this.thousandsSeparator = source["thousandsSeparator"];
+	        this.datePattern = source["datePattern"];
+	        this.dateTimePattern = source["dateTimePattern"];
+	    }
+	}
+	export class FederatedSource {
+	    alias: string;
+	    config: connection.ConnectionConfig;
+	    database?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FederatedSource(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.alias = source["alias"];
+	        this.config = this.convertValues(source["config"], connection.ConnectionConfig);
+	        this.database = source["database"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportColumnMapping {
+	    source: string;
+	    target?: string;
+	    skip?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportColumnMapping(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.target = source["target"];
+	        this.skip = source["skip"];
+	    }
+	}
+	export class ImportFileOptions {
+	    sheet?: string;
+	    headerRow?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportFileOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sheet = source["sheet"];
+	        this.headerRow = source["headerRow"];
+	    }
+	}
+	export class ImportMapping {
+	    columns?: ImportColumnMapping[];
+	    nullToken?: string;
+	    emptyAsNull?: boolean;
+	    trim?: boolean;
+	    dateFormat?: string;
+	    defaults?: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportMapping(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.columns = this.convertValues(source["columns"], ImportColumnMapping);
+	        this.nullToken = source["nullToken"];
+	        this.emptyAsNull = source["emptyAsNull"];
+	        this.trim = source["trim"];
+	        this.dateFormat = source["dateFormat"];
+	        this.defaults = source["defaults"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportOptions {
+	    importId?: string;
+	    batchRows?: number;
+	    file?: ImportFileOptions;
+	    mapping?: ImportMapping;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.importId = source["importId"];
+	        this.batchRows = source["batchRows"];
+	        this.file = this.convertValues(source["file"], ImportFileOptions);
+	        this.mapping = this.convertValues(source["mapping"], ImportMapping);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LoadTestOptions {
+	    jobId?: string;
+	    tableName: string;
+	    rows: number;
+	    batchSize?: number;
+	    columns?: string[];
+	    forceSql?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadTestOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.jobId = source["jobId"];
+	        this.tableName = source["tableName"];
+	        this.rows = source["rows"];
+	        this.batchSize = source["batchSize"];
+	        this.columns = source["columns"];
+	        this.forceSql = source["forceSql"];
+	    }
+	}
+	export class MaterializeOptions {
+	    targetConfig?: connection.ConnectionConfig;
+	    targetDb?: string;
+	    tableName: string;
+	    temporary?: boolean;
+	    tabId?: string;
+	    replace?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MaterializeOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.targetConfig = this.convertValues(source["targetConfig"], connection.ConnectionConfig);
+	        this.targetDb = source["targetDb"];
+	        this.tableName = source["tableName"];
+	        this.temporary = source["temporary"];
+	        this.tabId = source["tabId"];
+	        this.replace = source["replace"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MigrationRunRequest {
+	    dir: string;
+	    direction: string;
+	    target?: string;
+	    steps?: number;
+	    dryRun: boolean;
+	    jobId?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MigrationRunRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dir = source["dir"];
+	        this.direction = source["direction"];
+	        this.target = source["target"];
+	        this.steps = source["steps"];
+	        this.dryRun = source["dryRun"];
+	        this.jobId = source["jobId"];
+	    }
+	}
+	export class PortForwardRequest {
+	    ssh: connection.SSHConfig;
+	    remoteHost: string;
+	    remotePort: number;
+	    localHost?: string;
+	    localPort?: number;
+	    label?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PortForwardRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ssh = this.convertValues(source["ssh"], connection.SSHConfig);
+	        this.remoteHost = source["remoteHost"];
+	        this.remotePort = source["remotePort"];
+	        this.localHost = source["localHost"];
+	        this.localPort = source["localPort"];
+	        this.label = source["label"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecycleBinOptions {
+	    includeData: boolean;
+	    maxRows?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecycleBinOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.includeData = source["includeData"];
+	        this.maxRows = source["maxRows"];
+	    }
+	}
+	export class ResetIdentityRequest {
+	    table: string;
+	    column?: string;
+	    nextValue: number;
+	    force: boolean;
+	    dryRun: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResetIdentityRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.table = source["table"];
+	        this.column = source["column"];
+	        this.nextValue = source["nextValue"];
+	        this.force = source["force"];
+	        this.dryRun = source["dryRun"];
+	    }
+	}
+	export class SQLFileOptions {
+	    jobId?: string;
+	    continueOnError?: boolean;
+	    batchSize?: number;
+	    noTransaction?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SQLFileOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.jobId = source["jobId"];
+	        this.continueOnError = source["continueOnError"];
+	        this.batchSize = source["batchSize"];
+	        this.noTransaction = source["noTransaction"];
+	    }
+	}
+	export class SQLPlanRequest {
+	    config: connection.ConnectionConfig;
+	    dbName: string;
+	    source: string;
+	    title?: string;
+	    statements: string[];
+	    stopOnError?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SQLPlanRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.config = this.convertValues(source["config"], connection.ConnectionConfig);
+	        this.dbName = source["dbName"];
+	        this.source = source["source"];
+	        this.title = source["title"];
+	        this.statements = source["statements"];
+	        this.stopOnError = source["stopOnError"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SandboxOptions {
+	    schema?: string;
+	    tableName?: string;
+	    sampleRows?: number;
+	    random?: boolean;
+	    replace?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SandboxOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.schema = source["schema"];
+	        this.tableName = source["tableName"];
+	        this.sampleRows = source["sampleRows"];
+	        this.random = source["random"];
+	        this.replace = source["replace"];
+	    }
+	}
+	export class SchemaCompareOptions {
+	    sourceDb: string;
+	    targetDb: string;
+	    tables?: string[];
+	    dropExtra?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SchemaCompareOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sourceDb = source["sourceDb"];
+	        this.targetDb = source["targetDb"];
+	        this.tables = source["tables"];
+	        this.dropExtra = source["dropExtra"];
+	    }
+	}
+	export class ScriptOptions {
+	    tabId?: string;
+	    queryId?: string;
+	    stopOnError?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScriptOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tabId = source["tabId"];
+	        this.queryId = source["queryId"];
+	        this.stopOnError = source["stopOnError"];
+	    }
+	}
+	export class SeedRunOptions {
+	    jobId?: string;
+	    force: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SeedRunOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.jobId = source["jobId"];
+	        this.force = source["force"];
+	    }
+	}
+	export class StatusServerSettings {
+	    enabled: boolean;
+	    addr?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusServerSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.addr = source["addr"];
+	    }
+	}
+	export class TableDataCompareOptions {
+	    sourceDb?: string;
+	    targetDb?: string;
+	    targetTable?: string;
+	    chunkRows?: number;
+	    maxDetailRows?: number;
+	    ignoreColumns?: string[];
+	    generateScript?: boolean;
+	    queryId?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TableDataCompareOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sourceDb = source["sourceDb"];
+	        this.targetDb = source["targetDb"];
+	        this.targetTable = source["targetTable"];
+	        this.chunkRows = source["chunkRows"];
+	        this.maxDetailRows = source["maxDetailRows"];
+	        this.ignoreColumns = source["ignoreColumns"];
+	        this.generateScript = source["generateScript"];
+	        this.queryId = source["queryId"];
+	    }
+	}
+	export class TableDefinition {
+	    columns: connection.ColumnDefinition[];
+	    indexes?: connection.IndexDefinition[];
+	    foreignKeys?: connection.ForeignKeyDefinition[];
+	    renames?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TableDefinition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.columns = this.convertValues(source["columns"], connection.ColumnDefinition);
+	        this.indexes = this.convertValues(source["indexes"], connection.IndexDefinition);
+	        this.foreignKeys = this.convertValues(source["foreignKeys"], connection.ForeignKeyDefinition);
+	        this.renames = source["renames"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TableExportOptions {
+	    binaryAsFiles: boolean;
+	    locale: ExportLocaleOptions;
+	
+	    static createFrom(source: any = {}) {
+	        return new TableExportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.binaryAsFiles = source["binaryAsFiles"];
+	        this.locale = this.convertValues(source["locale"], ExportLocaleOptions);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TableFilter {
+	    column: string;
+	    op: string;
+	    value: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new TableFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.column = source["column"];
+	        this.op = source["op"];
+	        this.value = source["value"];
+	    }
+	}
+	export class TableSort {
+	    column: string;
+	    desc: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TableSort(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.column = source["column"];
+	        this.desc = source["desc"];
+	    }
+	}
+	export class TenantQueryRequest {
+	    pattern: string;
+	    scope?: string;
+	    query: string;
+	    sourceColumn?: string;
+	    maxRowsPerTenant?: number;
+	    concurrency?: number;
+	    queryId?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TenantQueryRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pattern = source["pattern"];
+	        this.scope = source["scope"];
+	        this.query = source["query"];
+	        this.sourceColumn = source["sourceColumn"];
+	        this.maxRowsPerTenant = source["maxRowsPerTenant"];
+	        this.concurrency = source["concurrency"];
+	        this.queryId = source["queryId"];
+	    }
+	}
+	export class TransferOptions {
+	    jobId?: string;
+	    sourceDb?: string;
+	    targetDb?: string;
+	    content?: string;
+	    existing?: string;
+	    errorPolicy?: string;
+	    batchRows?: number;
+	    targetTables?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TransferOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.jobId = source["jobId"];
+	        this.sourceDb = source["sourceDb"];
+	        this.targetDb = source["targetDb"];
+	        this.content = source["content"];
+	        this.existing = source["existing"];
+	        this.errorPolicy = source["errorPolicy"];
+	        this.batchRows = source["batchRows"];
+	        this.targetTables = source["targetTables"];
+	    }
+	}
+	export class ValueRendererRule {
+	    column: string;
+	    renderer: string;
+	    values?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ValueRendererRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.column = source["column"];
+	        this.renderer = source["renderer"];
+	        this.values = source["values"];
+	    }
+	}
+
+}
+
 export namespace connection {
 	
-	export class UpdateRow {
-	    keys: Record<string, any>;
-	    values: Record<string, any>;
+	export class UpdateRow {
+	    keys: Record<string, any>;
+	    values: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateRow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.keys = source["keys"];
+	        this.values = source["values"];
+	    }
+	}
+	export class ChangeSet {
+	    inserts: any[];
+	    updates: UpdateRow[];
+	    deletes: any[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangeSet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.inserts = source["inserts"];
+	        this.updates = this.convertValues(source["updates"], UpdateRow);
+	        this.deletes = source["deletes"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ColumnDefinition {
+	    name: string;
+	    type: string;
+	    nullable: string;
+	    key: string;
+	    default?: string;
+	    extra: string;
+	    comment: string;
+	    defaultIsExpression?: boolean;
+	    generated?: string;
+	    generationExpr?: string;
+	    identity?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ColumnDefinition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.nullable = source["nullable"];
+	        this.key = source["key"];
+	        this.default = source["default"];
+	        this.extra = source["extra"];
+	        this.comment = source["comment"];
+	        this.defaultIsExpression = source["defaultIsExpression"];
+	        this.generated = source["generated"];
+	        this.generationExpr = source["generationExpr"];
+	        this.identity = source["identity"];
+	    }
+	}
+	export class SSHConfig {
+	    host: string;
+	    port: number;
+	    user: string;
+	    password: string;
+	    keyPath: string;
+	    jumpHosts?: SSHConfig[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SSHConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.user = source["user"];
+	        this.password = source["password"];
+	        this.keyPath = source["keyPath"];
+	        this.jumpHosts = this.convertValues(source["jumpHosts"], SSHConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ConnectionConfig {
+	    type: string;
+	    host: string;
+	    port: number;
+	    user: string;
+	    password: string;
+	    savePassword?: boolean;
+	    database: string;
+	    useSSH: boolean;
+	    ssh: SSHConfig;
+	    driver?: string;
+	    dsn?: string;
+	    timeout?: number;
+	    redisDB?: number;
+	    uri?: string;
+	    hosts?: string[];
+	    topology?: string;
+	    mysqlReplicaUser?: string;
+	    mysqlReplicaPassword?: string;
+	    replicaSet?: string;
+	    authSource?: string;
+	    readPreference?: string;
+	    mongoSrv?: boolean;
+	    mongoAuthMechanism?: string;
+	    mongoReplicaUser?: string;
+	    mongoReplicaPassword?: string;
+	    ldapAuth?: boolean;
+	    ldapDomain?: string;
+	    autoReconnect?: boolean;
+	    charset?: string;
+	    collation?: string;
+	    legacyTextEncoding?: string;
+	    readFromReplica?: boolean;
+	    maxRowsPerMinute?: number;
+	    maxFetchMB?: number;
+	    serverQueryTimeout?: number;
+	    queryTag?: boolean;
+	    queryTagTemplate?: string;
+	    sslMode?: string;
+	    sslCA?: string;
+	    sslCert?: string;
+	    sslKey?: string;
+	    sslSkipVerify?: boolean;
+	    sslServerName?: string;
+	    keepaliveInterval?: number;
+	    keepaliveSQL?: string;
+	    tabSessionAffinity?: boolean;
+	    tabSessionIdleTimeout?: number;
+	    maxOpenConns?: number;
+	    maxIdleConns?: number;
+	    connMaxLifetime?: number;
+	    readOnly?: boolean;
+	    sqliteJournalMode?: string;
+	    sqliteBusyTimeout?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectionConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.user = source["user"];
+	        this.password = source["password"];
+	        this.savePassword = source["savePassword"];
+	        this.database = source["database"];
+	        this.useSSH = source["useSSH"];
+	        this.ssh = this.convertValues(source["ssh"], SSHConfig);
+	        this.driver = source["driver"];
+	        this.dsn = source["dsn"];
+	        this.timeout = source["timeout"];
+	        this.redisDB = source["redisDB"];
+	        this.uri = source["uri"];
+	        this.hosts = source["hosts"];
+	        this.topology = source["topology"];
+	        this.mysqlReplicaUser = source["mysqlReplicaUser"];
+	        this.mysqlReplicaPassword = source["mysqlReplicaPassword"];
+	        this.replicaSet = source["replicaSet"];
+	        this.authSource = source["authSource"];
+	        this.readPreference = source["readPreference"];
+	        this.mongoSrv = source["mongoSrv"];
+	        this.mongoAuthMechanism = source["mongoAuthMechanism"];
+	        this.mongoReplicaUser = source["mongoReplicaUser"];
+	        this.mongoReplicaPassword = source["mongoReplicaPassword"];
+	        this.ldapAuth = source["ldapAuth"];
+	        this.ldapDomain = source["ldapDomain"];
+	        this.autoReconnect = source["autoReconnect"];
+	        this.charset = source["charset"];
+	        this.collation = source["collation"];
+	        this.legacyTextEncoding = source["legacyTextEncoding"];
+	        this.readFromReplica = source["readFromReplica"];
+	        this.maxRowsPerMinute = source["maxRowsPerMinute"];
+	        this.maxFetchMB = source["maxFetchMB"];
+	        this.serverQueryTimeout = source["serverQueryTimeout"];
+	        this.queryTag = source["queryTag"];
+	        this.queryTagTemplate = source["queryTagTemplate"];
+	        this.sslMode = source["sslMode"];
+	        this.sslCA = source["sslCA"];
+	        this.sslCert = source["sslCert"];
+	        this.sslKey = source["sslKey"];
+	        this.sslSkipVerify = source["sslSkipVerify"];
+	        this.sslServerName = source["sslServerName"];
+	        this.keepaliveInterval = source["keepaliveInterval"];
+	        this.keepaliveSQL = source["keepaliveSQL"];
+	        this.tabSessionAffinity = source["tabSessionAffinity"];
+	        this.tabSessionIdleTimeout = source["tabSessionIdleTimeout"];
+	        this.maxOpenConns = source["maxOpenConns"];
+	        this.maxIdleConns = source["maxIdleConns"];
+	        this.connMaxLifetime = source["connMaxLifetime"];
+	        this.readOnly = source["readOnly"];
+	        this.sqliteJournalMode = source["sqliteJournalMode"];
+	        this.sqliteBusyTimeout = source["sqliteBusyTimeout"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DatabaseListOptions {
+	    hideSystem?: boolean;
+	    pattern?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseListOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hideSystem = source["hideSystem"];
+	        this.pattern = source["pattern"];
+	    }
+	}
+	export class EventDefinition {
+	    id: string;
+	    schema?: string;
+	    name: string;
+	    enabled: boolean;
+	    status: string;
+	    schedule: string;
+	    starts?: string;
+	    ends?: string;
+	    lastExecuted?: string;
+	    definition: string;
+	    comment?: string;
+	    owner?: string;
+	    database?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EventDefinition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.schema = source["schema"];
+	        this.name = source["name"];
+	        this.enabled = source["enabled"];
+	        this.status = source["status"];
+	        this.schedule = source["schedule"];
+	        this.starts = source["starts"];
+	        this.ends = source["ends"];
+	        this.lastExecuted = source["lastExecuted"];
+	        this.definition = source["definition"];
+	        this.comment = source["comment"];
+	        this.owner = source["owner"];
+	        this.database = source["database"];
+	    }
+	}
+	export class ForeignKeyDefinition {
+	    name: string;
+	    columnName: string;
+	    refTableName: string;
+	    refColumnName: string;
+	    constraintName: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ForeignKeyDefinition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.columnName = source["columnName"];
+	        this.refTableName = source["refTableName"];
+	        this.refColumnName = source["refColumnName"];
+	        this.constraintName = source["constraintName"];
+	    }
+	}
+	export class IndexDefinition {
+	    name: string;
+	    columnName: string;
+	    nonUnique: number;
+	    seqInIndex: number;
+	    indexType: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IndexDefinition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.columnName = source["columnName"];
+	        this.nonUnique = source["nonUnique"];
+	        this.seqInIndex = source["seqInIndex"];
+	        this.indexType = source["indexType"];
+	    }
+	}
+	export class IndexSpec {
+	    name: string;
+	    columns: string[];
+	    unique?: boolean;
+	    method?: string;
+	    where?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IndexSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.columns = source["columns"];
+	        this.unique = source["unique"];
+	        this.method = source["method"];
+	        this.where = source["where"];
+	    }
+	}
+	export class QueryError {
+	    class: string;
+	    code?: string;
+	    sqlState?: string;
+	    message: string;
+	    line?: number;
+	    column?: number;
+	    position?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.class = source["class"];
+	        this.code = source["code"];
+	        this.sqlState = source["sqlState"];
+	        this.message = source["message"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.position = source["position"];
+	    }
+	}
+	export class SchemaChangeBackup {
+	    id: string;
+	    createdAt: number;
+	    dbType: string;
+	    host?: string;
+	    database?: string;
+	    statement: string;
+	    objects: string[];
+	    backupPath: string;
+	    rollbackPath: string;
+	    rollback?: string;
+	    warnings?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SchemaChangeBackup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.createdAt = source["createdAt"];
+	        this.dbType = source["dbType"];
+	        this.host = source["host"];
+	        this.database = source["database"];
+	        this.statement = source["statement"];
+	        this.objects = source["objects"];
+	        this.backupPath = source["backupPath"];
+	        this.rollbackPath = source["rollbackPath"];
+	        this.rollback = source["rollback"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class QueryResult {
+	    success: boolean;
+	    message: string;
+	    data: any;
+	    fields?: string[];
+	    limitReached?: boolean;
+	    error?: QueryError;
+	    schemaBackup?: SchemaChangeBackup;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = source["data"];
+	        this.fields = source["fields"];
+	        this.limitReached = source["limitReached"];
+	        this.error = this.convertValues(source["error"], QueryError);
+	        this.schemaBackup = this.convertValues(source["schemaBackup"], SchemaChangeBackup);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoutineParameter {
+	    name: string;
+	    mode: string;
+	    type: string;
+	    position: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutineParameter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.mode = source["mode"];
+	        this.type = source["type"];
+	        this.position = source["position"];
+	    }
+	}
+	export class RoutineDefinition {
+	    id: string;
+	    schema: string;
+	    name: string;
+	    type: string;
+	    returnType?: string;
+	    arguments?: string;
+	    comment?: string;
+	    parameters?: RoutineParameter[];
+	    definition?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new UpdateRow(source);
+	        return new RoutineDefinition(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.keys = source["keys"];
-	        this.values = source["values"];
+	        this.id = source["id"];
+	        this.schema = source["schema"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.returnType = source["returnType"];
+	        this.arguments = source["arguments"];
+	        this.comment = source["comment"];
+	        this.parameters = this.convertValues(source["parameters"], RoutineParameter);
+	        this.definition = source["definition"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class ChangeSet {
-	    inserts: any[];
-	    updates: UpdateRow[];
-	    deletes: any[];
+	export class RoutineCall {
+	    routine: RoutineDefinition;
+	    args: Record<string, any>;
 	
 	    static createFrom(source: any = {}) {
-	        return new ChangeSet(source);
+	        return new RoutineCall(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.inserts = source["inserts"];
-	        this.updates = this.convertValues(source["updates"], UpdateRow);
-	        this.deletes = source["deletes"];
+	        this.routine = this.convertValues(source["routine"], RoutineDefinition);
+	        this.args = source["args"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -48,84 +1389,135 @@ export namespace connection {
 		    return a;
 		}
 	}
-	export class SSHConfig {
-	    host: string;
-	    port: number;
-	    user: string;
-	    password: string;
-	    keyPath: string;
+	
+	
+	
+	
+	export class SequenceDefinition {
+	    schema: string;
+	    name: string;
+	    dataType?: string;
+	    startValue?: string;
+	    minValue?: string;
+	    maxValue?: string;
+	    increment?: string;
+	    cache?: string;
+	    cycle: boolean;
+	    lastValue?: string;
+	    ownedBy?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new SSHConfig(source);
+	        return new SequenceDefinition(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.host = source["host"];
-	        this.port = source["port"];
-	        this.user = source["user"];
-	        this.password = source["password"];
-	        this.keyPath = source["keyPath"];
+	        this.schema = source["schema"];
+	        this.name = source["name"];
+	        this.dataType = source["dataType"];
+	        this.startValue = source["startValue"];
+	        this.minValue = source["minValue"];
+	        this.maxValue = source["maxValue"];
+	        this.increment = source["increment"];
+	        this.cache = source["cache"];
+	        this.cycle = source["cycle"];
+	        this.lastValue = source["lastValue"];
+	        this.ownedBy = source["ownedBy"];
 	    }
 	}
-	export class ConnectionConfig {
-	    type: string;
-	    host: string;
-	    port: number;
-	    user: string;
-	    password: string;
-	    savePassword?: boolean;
-	    database: string;
-	    useSSH: boolean;
-	    ssh: SSHConfig;
-	    driver?: string;
-	    dsn?: string;
-	    timeout?: number;
-	    redisDB?: number;
-	    uri?: string;
-	    hosts?: string[];
-	    topology?: string;
-	    mysqlReplicaUser?: string;
-	    mysqlReplicaPassword?: string;
-	    replicaSet?: string;
-	    authSource?: string;
-	    readPreference?: string;
-	    mongoSrv?: boolean;
-	    mongoAuthMechanism?: string;
-	    mongoReplicaUser?: string;
-	    mongoReplicaPassword?: string;
+	export class TriggerSpec {
+	    schema?: string;
+	    tableName: string;
+	    name: string;
+	    timing?: string;
+	    events?: string[];
+	    forEach?: string;
+	    when?: string;
+	    body?: string;
+	    replace?: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new ConnectionConfig(source);
+	        return new TriggerSpec(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.type = source["type"];
-	        this.host = source["host"];
-	        this.port = source["port"];
-	        this.user = source["user"];
-	        this.password = source["password"];
-	        this.savePassword = source["savePassword"];
+	        this.schema = source["schema"];
+	        this.tableName = source["tableName"];
+	        this.name = source["name"];
+	        this.timing = source["timing"];
+	        this.events = source["events"];
+	        this.forEach = source["forEach"];
+	        this.when = source["when"];
+	        this.body = source["body"];
+	        this.replace = source["replace"];
+	    }
+	}
+
+}
+
+export namespace connectionstore {
+	
+	export class GridFilter {
+	    enabled?: boolean;
+	    column?: string;
+	    op?: string;
+	    value?: string;
+	    value2?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GridFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.column = source["column"];
+	        this.op = source["op"];
+	        this.value = source["value"];
+	        this.value2 = source["value2"];
+	    }
+	}
+	export class GridSort {
+	    column: string;
+	    order: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GridSort(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.column = source["column"];
+	        this.order = source["order"];
+	    }
+	}
+	export class GridPreference {
+	    connectionId: string;
+	    database?: string;
+	    table: string;
+	    hiddenColumns?: string[];
+	    columnOrder?: string[];
+	    columnWidths?: Record<string, number>;
+	    sort?: GridSort[];
+	    filters?: GridFilter[];
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GridPreference(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.connectionId = source["connectionId"];
 	        this.database = source["database"];
-	        this.useSSH = source["useSSH"];
-	        this.ssh = this.convertValues(source["ssh"], SSHConfig);
-	        this.driver = source["driver"];
-	        this.dsn = source["dsn"];
-	        this.timeout = source["timeout"];
-	        this.redisDB = source["redisDB"];
-	        this.uri = source["uri"];
-	        this.hosts = source["hosts"];
-	        this.topology = source["topology"];
-	        this.mysqlReplicaUser = source["mysqlReplicaUser"];
-	        this.mysqlReplicaPassword = source["mysqlReplicaPassword"];
-	        this.replicaSet = source["replicaSet"];
-	        this.authSource = source["authSource"];
-	        this.readPreference = source["readPreference"];
-	        this.mongoSrv = source["mongoSrv"];
-	        this.mongoAuthMechanism = source["mongoAuthMechanism"];
-	        this.mongoReplicaUser = source["mongoReplicaUser"];
-	        this.mongoReplicaPassword = source["mongoReplicaPassword"];
+	        this.table = source["table"];
+	        this.hiddenColumns = source["hiddenColumns"];
+	        this.columnOrder = source["columnOrder"];
+	        this.columnWidths = source["columnWidths"];
+	        this.sort = this.convertValues(source["sort"], GridSort);
+	        this.filters = this.convertValues(source["filters"], GridFilter);
+	        this.updatedAt = source["updatedAt"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -146,25 +1538,98 @@ export namespace connection {
 		    return a;
 		}
 	}
-	export class QueryResult {
-	    success: boolean;
-	    message: string;
-	    data: any;
-	    fields?: string[];
+	
+	export class Profile {
+	    id: string;
+	    name: string;
+	    config: connection.ConnectionConfig;
+	    includeDatabases?: string[];
+	    includeRedisDatabases?: number[];
+	    databaseList?: connection.DatabaseListOptions;
 	
 	    static createFrom(source: any = {}) {
-	        return new QueryResult(source);
+	        return new Profile(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.success = source["success"];
-	        this.message = source["message"];
-	        this.data = source["data"];
-	        this.fields = source["fields"];
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.config = this.convertValues(source["config"], connection.ConnectionConfig);
+	        this.includeDatabases = source["includeDatabases"];
+	        this.includeRedisDatabases = source["includeRedisDatabases"];
+	        this.databaseList = this.convertValues(source["databaseList"], connection.DatabaseListOptions);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace queryhistory {
+	
+	export class Filter {
+	    keyword?: string;
+	    connType?: string;
+	    connAddr?: string;
+	    database?: string;
+	    status?: string;
+	    onlyFavorites?: boolean;
+	    since?: number;
+	    until?: number;
+	    page?: number;
+	    pageSize?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Filter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.keyword = source["keyword"];
+	        this.connType = source["connType"];
+	        this.connAddr = source["connAddr"];
+	        this.database = source["database"];
+	        this.status = source["status"];
+	        this.onlyFavorites = source["onlyFavorites"];
+	        this.since = source["since"];
+	        this.until = source["until"];
+	        this.page = source["page"];
+	        this.pageSize = source["pageSize"];
 	    }
 	}
+	export class PruneOptions {
+	    before?: number;
+	    keepLatest?: number;
+	    keepFavorites: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PruneOptions(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.before = source["before"];
+	        this.keepLatest = source["keepLatest"];
+	        this.keepFavorites = source["keepFavorites"];
+	    }
+	}
 
 }
 
@@ -187,8 +1652,123 @@ export namespace redis {
 
 }
 
+export namespace snippetstore {
+	
+	export class Variable {
+	    name: string;
+	    default?: string;
+	    hasDefault?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Variable(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.default = source["default"];
+	        this.hasDefault = source["hasDefault"];
+	    }
+	}
+	export class Snippet {
+	    id: string;
+	    name: string;
+	    folder?: string;
+	    sql: string;
+	    description?: string;
+	    dbType?: string;
+	    createdAt: number;
+	    updatedAt: number;
+	    variables?: Variable[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Snippet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.folder = source["folder"];
+	        this.sql = source["sql"];
+	        this.description = source["description"];
+	        this.dbType = source["dbType"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	        this.variables = this.convertValues(source["variables"], Variable);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace sync {
 	
+	export class CompareRule {
+	    ignoreCase?: boolean;
+	    ignoreTrailingSpace?: boolean;
+	    skip?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CompareRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ignoreCase = source["ignoreCase"];
+	        this.ignoreTrailingSpace = source["ignoreTrailingSpace"];
+	        this.skip = source["skip"];
+	    }
+	}
+	export class CompareRules {
+	    default: CompareRule;
+	    columns?: Record<string, CompareRule>;
+	
+	    static createFrom(source: any = {}) {
+	        return new CompareRules(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.default = this.convertValues(source["default"], CompareRule);
+	        this.columns = this.convertValues(source["columns"], CompareRule, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class TableOptions {
 	    insert?: boolean;
 	    update?: boolean;
@@ -220,6 +1800,7 @@ export namespace sync {
 	    jobId?: string;
 	    autoAddColumns?: boolean;
 	    tableOptions?: Record<string, TableOptions>;
+	    compareRules?: CompareRules;
 	
 	    static createFrom(source: any = {}) {
 	        return new SyncConfig(source);
@@ -235,6 +1816,7 @@ export namespace sync {
 	        this.jobId = source["jobId"];
 	        this.autoAddColumns = source["autoAddColumns"];
 	        this.tableOptions = this.convertValues(source["tableOptions"], TableOptions, true);
+	        this.compareRules = this.convertValues(source["compareRules"], CompareRules);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -10,11 +10,16 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/connectionstore"
 	"GoNavi-Wails/internal/db"
 	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/queryhistory"
+	"GoNavi-Wails/internal/snippetstore"
+	"GoNavi-Wails/internal/ssh"
 )
 
 const dbCachePingInterval = 30 * time.Second
@@ -22,21 +27,61 @@ const dbCachePingInterval = 30 * time.Second
 type cachedDatabase struct {
 	inst     db.Database
 	lastPing time.Time
+	config   connection.ConnectionConfig
+	lastUsed *atomic.Int64 // 最近一次取用的时间（UnixNano），用于关闭最久未用的数据库连接池
+
+	keepaliveFailures int // 连续保活失败次数，达到 keepaliveMaxFailures 时移出缓存
 }
 
 // App struct
 type App struct {
-	ctx         context.Context
-	dbCache     map[string]cachedDatabase // Cache for DB connections
-	mu          sync.RWMutex              // Mutex for cache access
-	updateMu    sync.Mutex
-	updateState updateState
+	ctx            context.Context
+	dbCache        map[string]cachedDatabase  // Cache for DB connections
+	retiredDBs     []retiredDatabase          // 保活失败移出缓存、等待在途语句结束后关闭的连接，受 mu 保护
+	connecting     map[string]*pendingConnect // 正在建立的连接，按缓存 Key 合并并发建连
+	mu             sync.RWMutex               // Mutex for cache access
+	updateMu       sync.Mutex
+	updateState    updateState
+	sqlPlanMu      sync.Mutex
+	sqlPlans       map[string]*sqlPlan
+	sessionMu      sync.Mutex
+	tabSessions    map[string]*tabSession
+	transferMu     sync.Mutex
+	transfers      map[string][]transferRecord // 各连接最近一分钟的拉取行数，用于 MaxRowsPerMinute
+	queryMu        sync.Mutex
+	runningQueries map[string]*runningQuery
+	payloadMu      sync.Mutex
+	payloads       map[string]*resultPayload // 待前端分块拉取的压缩结果
+	connStore      *connectionstore.Store    // 已保存连接（密码加密存储）
+	wireLog        *wireLogger               // 按连接开启的调试语句日志
+	history        *queryhistory.Store       // 执行历史，Startup 时创建，未启动（如测试中）为 nil
+	snippets       *snippetstore.Store       // SQL 片段库
+	recentErrors   *recentErrorLog           // 最近的查询/连接/驱动代理错误，供前端排查
+	sqliteLocks    *sqliteWriteLocks         // 按 SQLite 文件串行化应用内的写语句
+	startedAt      time.Time                 // Startup 时间，用于连接探测的首连保护窗口
+	driverBulkMu   sync.Mutex
+	driverBulk     *driverBulkInstall    // 进行中的批量驱动安装，单个驱动的进度汇总到整体进度
+	tempWorkspaces *tempWorkspaceManager // 按连接划分的临时文件目录，断开连接或退出时清理
+	statusMu       sync.Mutex
+	status         *statusServer // 本地 /healthz、/metrics 监控端点，未开启时为 nil
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
 	return &App{
-		dbCache: make(map[string]cachedDatabase),
+		dbCache:        make(map[string]cachedDatabase),
+		connecting:     make(map[string]*pendingConnect),
+		sqlPlans:       make(map[string]*sqlPlan),
+		tabSessions:    make(map[string]*tabSession),
+		transfers:      make(map[string][]transferRecord),
+		runningQueries: make(map[string]*runningQuery),
+		payloads:       make(map[string]*resultPayload),
+		connStore:      connectionstore.New(""),
+		snippets:       snippetstore.New(""),
+		wireLog:        newWireLogger(),
+		recentErrors:   newRecentErrorLog(recentErrorCapacity),
+		sqliteLocks:    newSQLiteWriteLocks(),
+		tempWorkspaces: newTempWorkspaceManager(""),
 	}
 }
 
@@ -44,8 +89,24 @@ func NewApp() *App {
 // so we can call the runtime methods
 func (a *App) Startup(ctx context.Context) {
 	a.ctx = ctx
+	a.startedAt = time.Now()
+	a.startHealthMonitor(ctx)
 	logger.Init()
 	applyMacWindowTranslucencyFix()
+	a.history = queryhistory.New("")
+	if removed := a.tempWorkspaces.sweepStale(time.Now()); removed > 0 {
+		logger.Infof("已清理遗留临时工作区 %d 个", removed)
+	}
+	if settings := currentStatusServerSettings(); settings.Enabled {
+		if err := a.startStatusServer(settings); err != nil {
+			logger.Error(err, "启动监控端点失败")
+		}
+	}
+	if types, err := db.ReloadCustomDriverTypes(""); err != nil {
+		logger.Warnf("加载自定义数据源类型配置存在问题：%v", err)
+	} else if len(types) > 0 {
+		logger.Infof("已加载自定义数据源类型 %d 个", len(types))
+	}
 	logger.Infof("应用启动完成")
 }
 
@@ -59,6 +120,8 @@ func (a *App) SetWindowTranslucency(opacity float64, blur float64) {
 // Shutdown is called when the app terminates
 func (a *App) Shutdown(ctx context.Context) {
 	logger.Infof("应用开始关闭，准备释放资源")
+	a.stopStatusServer()
+	a.releaseAllTabSessions()
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	for _, dbInst := range a.dbCache {
@@ -66,8 +129,21 @@ func (a *App) Shutdown(ctx context.Context) {
 			logger.Error(err, "关闭数据库连接失败")
 		}
 	}
+	for _, retired := range a.retiredDBs {
+		_ = retired.inst.Close()
+	}
+	a.retiredDBs = nil
 	// Close all Redis connections
 	CloseAllRedisClients()
+	ssh.CloseAllForwarders()
+	ssh.CloseAllSSHClients()
+	a.wireLog.close()
+	if a.history != nil {
+		if err := a.history.Close(); err != nil {
+			logger.Error(err, "关闭执行历史库失败")
+		}
+	}
+	a.tempWorkspaces.releaseAll()
 	logger.Infof("资源释放完成，应用已关闭")
 	logger.Close()
 }
@@ -87,6 +163,21 @@ func getCacheKey(config connection.ConnectionConfig) string {
 	return hex.EncodeToString(sum[:])
 }
 
+// closeCachedDatabase 关闭并移除指定配置对应的缓存连接（如存在）。
+func (a *App) closeCachedDatabase(config connection.ConnectionConfig) {
+	key := getCacheKey(applyCustomDriverType(config))
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if cur, exists := a.dbCache[key]; exists {
+		if cur.inst != nil {
+			if err := cur.inst.Close(); err != nil {
+				logger.Error(err, "关闭缓存连接失败：%s", formatConnSummary(config))
+			}
+		}
+		delete(a.dbCache, key)
+	}
+}
+
 func wrapConnectError(config connection.ConnectionConfig, err error) error {
 	if err == nil {
 		return nil
@@ -174,6 +265,9 @@ func formatConnSummary(config connection.ConnectionConfig) string {
 
 	if config.UseSSH {
 		b.WriteString(fmt.Sprintf(" SSH=%s:%d 用户=%s", config.SSH.Host, config.SSH.Port, config.SSH.User))
+		if n := len(config.SSH.JumpHosts); n > 0 {
+			b.WriteString(fmt.Sprintf(" 跳板机=%d级", n))
+		}
 	}
 
 	if config.Type == "custom" {
@@ -201,6 +295,7 @@ func (a *App) getDatabase(config connection.ConnectionConfig) (db.Database, erro
 }
 
 func (a *App) getDatabaseWithPing(config connection.ConnectionConfig, forcePing bool) (db.Database, error) {
+	config = applyCustomDriverType(config)
 	key := getCacheKey(config)
 	shortKey := key
 	if len(shortKey) > 12 {
@@ -234,6 +329,7 @@ func (a *App) getDatabaseWithPing(config connection.ConnectionConfig, forcePing
 		}
 
 		if !needPing {
+			entry.touch(time.Now())
 			return entry.inst, nil
 		}
 
@@ -245,6 +341,7 @@ func (a *App) getDatabaseWithPing(config connection.ConnectionConfig, forcePing
 				a.dbCache[key] = cur
 			}
 			a.mu.Unlock()
+			entry.touch(time.Now())
 			return entry.inst, nil
 		} else {
 			logger.Error(err, "缓存连接不可用，准备重建：%s 缓存Key=%s", formatConnSummary(config), shortKey)
@@ -261,6 +358,21 @@ func (a *App) getDatabaseWithPing(config connection.ConnectionConfig, forcePing
 		a.mu.Unlock()
 	}
 
+	return a.connectOnce(key, func() (db.Database, error) {
+		return a.connectAndCache(config, key, shortKey)
+	})
+}
+
+// connectAndCache 建立新连接并写入缓存；同一连接的数据库连接池过多时顺带关闭空闲的连接池。
+func (a *App) connectAndCache(config connection.ConnectionConfig, key string, shortKey string) (db.Database, error) {
+	a.mu.RLock()
+	existing, exists := a.dbCache[key]
+	a.mu.RUnlock()
+	if exists && existing.inst != nil {
+		// 等待合并建连期间其它请求已写入缓存
+		existing.touch(time.Now())
+		return existing.inst, nil
+	}
 	logger.Infof("获取数据库连接：%s 缓存Key=%s", formatConnSummary(config), shortKey)
 	logger.Infof("创建数据库驱动实例：类型=%s 缓存Key=%s", config.Type, shortKey)
 	dbInst, err := db.NewDatabase(config.Type)
@@ -272,6 +384,7 @@ func (a *App) getDatabaseWithPing(config connection.ConnectionConfig, forcePing
 	if err := dbInst.Connect(config); err != nil {
 		wrapped := wrapConnectError(config, err)
 		logger.Error(wrapped, "建立数据库连接失败：%s 缓存Key=%s", formatConnSummary(config), shortKey)
+		a.recentErrors.record(recentErrorKindConnect, config, config.Database, wrapped, "")
 		return nil, wrapped
 	}
 
@@ -284,9 +397,10 @@ func (a *App) getDatabaseWithPing(config connection.ConnectionConfig, forcePing
 		_ = dbInst.Close()
 		return existing.inst, nil
 	}
-	a.dbCache[key] = cachedDatabase{inst: dbInst, lastPing: now}
+	a.dbCache[key] = cachedDatabase{inst: dbInst, lastPing: now, config: config, lastUsed: newLastUsed(now)}
 	a.mu.Unlock()
 
 	logger.Infof("数据库连接成功并写入缓存：%s 缓存Key=%s", formatConnSummary(config), shortKey)
+	a.evictIdleDatabasePools(config, key, now)
 	return dbInst, nil
 }
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package app
+
+import "os/exec"
+
+func configureBackgroundProcess(cmd *exec.Cmd) {
+	_ = cmd
+}
@@ -0,0 +1,21 @@
+//go:build windows
+
+package app
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+const windowsCreateNoWindow = 0x08000000
+
+// configureBackgroundProcess 隐藏外部命令行工具的控制台窗口。
+func configureBackgroundProcess(cmd *exec.Cmd) {
+	if cmd == nil {
+		return
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: windowsCreateNoWindow,
+	}
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	// changeUndoMaxRows 一次提交超过该行数时不再逐行读取原值，避免大批量修改被撤销准备拖慢。
+	changeUndoMaxRows     = 1000
+	changeUndoMaxEntries  = 500
+	changeUndoFileSuffix  = ".sql"
+	changeUndoScriptTitle = "-- GoNavi 表格编辑撤销脚本，执行前请确认数据未被再次修改"
+)
+
+// ApplyChangesOptions ApplyChangesWithOptions 的选项。SaveUndo 为 true 时把撤销脚本写入 ~/.gonavi/change_undo。
+type ApplyChangesOptions struct {
+	SaveUndo bool `json:"saveUndo"`
+}
+
+// ChangeUndo 一次 ApplyChanges 的撤销信息。Changes 为逆向 ChangeSet，可直接交给 ApplyChanges 回退；
+// Script 为对应的 SQL 脚本，Path 为保存的脚本文件。
+type ChangeUndo struct {
+	Changes  connection.ChangeSet `json:"changes"`
+	Script   string               `json:"script"`
+	Path     string               `json:"path,omitempty"`
+	Warnings []string             `json:"warnings,omitempty"`
+}
+
+func changeUndoDirectory() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "change_undo")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-change_undo")
+}
+
+// captureChangeUndo 在提交前读取将被更新、删除的行的原值并生成逆向 ChangeSet：插入的行删除，更新的行改回原值，删除的行重新插入。
+// 驱动不通过 SQL 提交时返回 nil；读取失败只给出提示，不阻止提交。
+func captureChangeUndo(dbInst db.Database, runConfig connection.ConnectionConfig, dbName, tableName string, changes connection.ChangeSet, defs []connection.ColumnDefinition) *ChangeUndo {
+	planner, ok := dbInst.(db.ChangePlanner)
+	if !ok {
+		return nil
+	}
+	undo := &ChangeUndo{}
+	if total := len(changes.Inserts) + len(changes.Updates) + len(changes.Deletes); total > changeUndoMaxRows {
+		undo.Warnings = append(undo.Warnings, fmt.Sprintf("本次修改 %d 行，超过 %d 行，未生成撤销脚本", total, changeUndoMaxRows))
+		return undo
+	}
+
+	inverse, warnings, err := buildChangeUndo(dbInst, runConfig, dbName, tableName, changes, defs)
+	undo.Warnings = append(undo.Warnings, warnings...)
+	if err != nil {
+		logger.Warnf("读取修改前的数据失败：%s 表=%s 原因=%s", formatConnSummary(runConfig), tableName, normalizeErrorMessage(err))
+		undo.Warnings = append(undo.Warnings, "读取修改前的数据失败，未生成撤销脚本："+normalizeErrorMessage(err))
+		return undo
+	}
+	inverse, _ = stripReadOnlyColumns(inverse, readOnlyColumns(defs))
+	statements, err := planner.PlanChanges(tableName, inverse)
+	if err != nil {
+		undo.Warnings = append(undo.Warnings, "生成撤销脚本失败："+err.Error())
+		return undo
+	}
+	undo.Changes = inverse
+	if len(statements) == 0 {
+		return undo
+	}
+	var script strings.Builder
+	script.WriteString(changeUndoScriptTitle + "\n")
+	fmt.Fprintf(&script, "-- 时间：%s\n-- 连接：%s\n-- 表：%s\n", time.Now().Format("2006-01-02 15:04:05"), formatConnSummary(runConfig), tableName)
+	for _, warning := range undo.Warnings {
+		script.WriteString("-- 注意：" + warning + "\n")
+	}
+	script.WriteString("\n")
+	for _, stmt := range statements {
+		script.WriteString(stmt.Rendered + ";\n")
+	}
+	undo.Script = script.String()
+	return undo
+}
+
+// buildChangeUndo 生成逆向 ChangeSet。逆向语句仍按删除、更新、插入的顺序执行，恰好先撤销插入、最后恢复删除的行。
+func buildChangeUndo(dbInst db.Database, runConfig connection.ConnectionConfig, dbName, tableName string, changes connection.ChangeSet, defs []connection.ColumnDefinition) (connection.ChangeSet, []string, error) {
+	dbType := resolveDDLDBType(runConfig)
+	schemaName, pureTableName := normalizeSchemaAndTable(runConfig, dbName, tableName)
+	table := quoteTableIdentByType(dbType, schemaName, pureTableName)
+	var inverse connection.ChangeSet
+	var warnings []string
+
+	var primaryKeys []string
+	for _, def := range defs {
+		if strings.EqualFold(def.Key, "PRI") {
+			primaryKeys = append(primaryKeys, def.Name)
+		}
+	}
+	for _, row := range changes.Inserts {
+		key, exact := insertedRowKey(row, primaryKeys)
+		if len(key) == 0 {
+			warnings = append(warnings, "插入的行没有可定位的列值，无法撤销")
+			continue
+		}
+		if !exact {
+			warnings = append(warnings, "插入的行未包含完整主键（如自增列），撤销时按插入的列值匹配")
+		}
+		inverse.Deletes = append(inverse.Deletes, key)
+	}
+
+	// 同一行多次更新时需按相反顺序恢复
+	for i := len(changes.Updates) - 1; i >= 0; i-- {
+		update := changes.Updates[i]
+		if len(update.Values) == 0 {
+			continue
+		}
+		rows, err := selectRowsByKeys(dbInst, dbType, table, update.Keys)
+		if err != nil {
+			return inverse, warnings, err
+		}
+		if len(rows) == 0 {
+			warnings = append(warnings, "待更新的行已不存在，跳过")
+			continue
+		}
+		if len(rows) > 1 {
+			warnings = append(warnings, fmt.Sprintf("更新条件匹配 %d 行，撤销时按第一行的原值恢复", len(rows)))
+		}
+		reverse := connection.UpdateRow{Keys: map[string]interface{}{}, Values: map[string]interface{}{}}
+		for col, value := range update.Keys {
+			if updated, ok := update.Values[col]; ok {
+				value = updated // 主键本身被修改时按新值定位
+			}
+			reverse.Keys[col] = value
+		}
+		for col := range update.Values {
+			reverse.Values[col] = rowValue(rows[0], col)
+		}
+		inverse.Updates = append(inverse.Updates, reverse)
+	}
+
+	for _, key := range changes.Deletes {
+		if len(key) == 0 {
+			continue
+		}
+		rows, err := selectRowsByKeys(dbInst, dbType, table, key)
+		if err != nil {
+			return inverse, warnings, err
+		}
+		if len(rows) == 0 {
+			warnings = append(warnings, "待删除的行已不存在，跳过")
+		}
+		inverse.Inserts = append(inverse.Inserts, rows...)
+	}
+	return inverse, warnings, nil
+}
+
+// insertedRowKey 有主键且插入时给出了全部主键值时按主键定位，否则退回到全部非空列；
+// exact 为 false 表示表有主键但插入时未给出（如自增列），按列值匹配可能命中其他行。
+func insertedRowKey(row map[string]interface{}, primaryKeys []string) (key map[string]interface{}, exact bool) {
+	if len(primaryKeys) > 0 {
+		key = map[string]interface{}{}
+		for _, col := range primaryKeys {
+			value, ok := lookupRowColumn(row, col)
+			if !ok || value == nil {
+				key = nil
+				break
+			}
+			key[col] = value
+		}
+		if key != nil {
+			return key, true
+		}
+	}
+	key = map[string]interface{}{}
+	for col, value := range row {
+		if value != nil {
+			key[col] = value
+		}
+	}
+	return key, len(primaryKeys) == 0
+}
+
+// selectRowsByKeys 按等值条件读取当前行，NULL 值使用 IS NULL。
+func selectRowsByKeys(dbInst db.Database, dbType string, table string, keys map[string]interface{}) ([]map[string]interface{}, error) {
+	cols := make([]string, 0, len(keys))
+	for col := range keys {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+	conds := make([]string, 0, len(cols))
+	for _, col := range cols {
+		if keys[col] == nil {
+			conds = append(conds, fmt.Sprintf("%s IS NULL", quoteIdentByType(dbType, col)))
+			continue
+		}
+		conds = append(conds, fmt.Sprintf("%s = %s", quoteIdentByType(dbType, col), formatSQLValue(dbType, keys[col])))
+	}
+	rows, _, err := dbInst.Query(fmt.Sprintf("SELECT * FROM %s WHERE %s", table, strings.Join(conds, " AND ")))
+	return rows, err
+}
+
+// lookupRowColumn 先精确匹配列名，再忽略大小写匹配（Oracle 等返回大写列名）。
+func lookupRowColumn(row map[string]interface{}, col string) (interface{}, bool) {
+	if value, ok := row[col]; ok {
+		return value, true
+	}
+	for name, value := range row {
+		if strings.EqualFold(name, col) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func rowValue(row map[string]interface{}, col string) interface{} {
+	value, _ := lookupRowColumn(row, col)
+	return value
+}
+
+// writeChangeUndo 保存撤销脚本并清理超出保留数量的旧文件，返回文件路径。
+func writeChangeUndo(dbName, tableName, script string) (string, error) {
+	dir := changeUndoDirectory()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("创建撤销脚本目录失败：%w", err)
+	}
+	name := fmt.Sprintf("%s-%s", time.Now().Format("20060102-150405.000"), snapshotNameSanitizer.ReplaceAllString(strings.Trim(dbName+"."+tableName, "."), "_"))
+	path := filepath.Join(dir, name+changeUndoFileSuffix)
+	if err := os.WriteFile(path, []byte(script), 0o600); err != nil {
+		return "", err
+	}
+	pruneChangeUndo(changeUndoMaxEntries)
+	return path, nil
+}
+
+// pruneChangeUndo 只保留最近的 keep 个撤销脚本；文件名以时间开头，按名称排序即按时间排序。
+func pruneChangeUndo(keep int) {
+	items, err := os.ReadDir(changeUndoDirectory())
+	if err != nil {
+		return
+	}
+	var files []string
+	for _, item := range items {
+		if !item.IsDir() && strings.HasSuffix(item.Name(), changeUndoFileSuffix) {
+			files = append(files, item.Name())
+		}
+	}
+	if len(files) <= keep {
+		return
+	}
+	sort.Strings(files)
+	for _, name := range files[:len(files)-keep] {
+		_ = os.Remove(filepath.Join(changeUndoDirectory(), name))
+	}
+}
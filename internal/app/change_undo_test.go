@@ -0,0 +1,100 @@
+package app
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type changeUndoFakeDB struct {
+	db.Database
+	applied []connection.ChangeSet
+}
+
+func (f *changeUndoFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	switch {
+	case strings.Contains(query, "`id` = 1"):
+		return []map[string]interface{}{{"id": int64(1), "name": "old", "total": "9.50"}}, []string{"id", "name", "total"}, nil
+	case strings.Contains(query, "`id` = 2"):
+		return []map[string]interface{}{{"id": int64(2), "name": "gone", "total": "3.00"}}, []string{"id", "name", "total"}, nil
+	}
+	return nil, nil, nil
+}
+
+func (f *changeUndoFakeDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	return []connection.ColumnDefinition{
+		{Name: "id", Key: "PRI"},
+		{Name: "name"},
+		{Name: "total", Generated: "STORED"},
+	}, nil
+}
+
+func (f *changeUndoFakeDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
+	f.applied = append(f.applied, changes)
+	return nil
+}
+
+func (f *changeUndoFakeDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]db.ChangeStatement, error) {
+	return (&db.MySQLDB{}).PlanChanges(tableName, changes)
+}
+
+func TestApplyChangesReturnsUndo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root", Database: "shop"}
+	fake := &changeUndoFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	changes := connection.ChangeSet{
+		Inserts: []map[string]interface{}{{"id": 3, "name": "new"}},
+		Updates: []connection.UpdateRow{{Keys: map[string]interface{}{"id": 1}, Values: map[string]interface{}{"name": "renamed"}}},
+		Deletes: []map[string]interface{}{{"id": 2}},
+	}
+	res := a.ApplyChangesWithOptions(config, "shop", "orders", changes, ApplyChangesOptions{SaveUndo: true})
+	if !res.Success {
+		t.Fatal(res.Message)
+	}
+	if len(fake.applied) != 1 {
+		t.Fatalf("expected changes to be applied once, got %d", len(fake.applied))
+	}
+	undo, ok := res.Data.(*ChangeUndo)
+	if !ok || undo == nil {
+		t.Fatalf("expected undo data, got %#v", res.Data)
+	}
+	for _, want := range []string{
+		"DELETE FROM `orders` WHERE `id` = 3;",
+		"UPDATE `orders` SET `name` = 'old' WHERE `id` = 1;",
+		"INSERT INTO `orders` (`id`, `name`) VALUES (2, 'gone');",
+	} {
+		if !strings.Contains(undo.Script, want) {
+			t.Fatalf("undo script missing %q:\n%s", want, undo.Script)
+		}
+	}
+	if undo.Path == "" {
+		t.Fatalf("expected undo script to be saved, warnings=%v", undo.Warnings)
+	}
+	if content, err := os.ReadFile(undo.Path); err != nil || string(content) != undo.Script {
+		t.Fatalf("saved undo script mismatch: %v", err)
+	}
+	if info, err := os.Stat(undo.Path); err != nil {
+		t.Fatal(err)
+	} else if runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Fatalf("undo script should be private, got %v", info.Mode().Perm())
+	}
+}
+
+func TestInsertedRowKeyFallsBackToValues(t *testing.T) {
+	key, exact := insertedRowKey(map[string]interface{}{"name": "x", "note": nil}, []string{"id"})
+	if exact || len(key) != 1 || key["name"] != "x" {
+		t.Fatalf("key = %v exact = %v", key, exact)
+	}
+	key, exact = insertedRowKey(map[string]interface{}{"ID": 7, "name": "x"}, []string{"id"})
+	if !exact || len(key) != 1 || key["id"] != 7 {
+		t.Fatalf("key = %v exact = %v", key, exact)
+	}
+}
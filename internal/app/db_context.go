@@ -1,12 +1,28 @@
 package app
 
 import (
+	"fmt"
 	"strings"
 
 	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
 )
 
+// applyCustomDriverType 将配置文件注册的自定义类型还原为其协议族，并补齐默认端口。
+func applyCustomDriverType(config connection.ConnectionConfig) connection.ConnectionConfig {
+	custom, ok := db.LookupCustomDriverType(config.Type)
+	if !ok {
+		return config
+	}
+	config.Type = custom.Family
+	if config.Port <= 0 && custom.DefaultPort > 0 {
+		config.Port = custom.DefaultPort
+	}
+	return config
+}
+
 func normalizeRunConfig(config connection.ConnectionConfig, dbName string) connection.ConnectionConfig {
+	config = applyCustomDriverType(config)
 	runConfig := config
 	name := strings.TrimSpace(dbName)
 	if name == "" {
@@ -14,7 +30,7 @@ func normalizeRunConfig(config connection.ConnectionConfig, dbName string) conne
 	}
 
 	switch strings.ToLower(strings.TrimSpace(config.Type)) {
-	case "mysql", "mariadb", "diros", "sphinx", "postgres", "kingbase", "highgo", "vastbase", "sqlserver", "mongodb", "tdengine":
+	case "mysql", "mariadb", "diros", "sphinx", "postgres", "kingbase", "highgo", "vastbase", "sqlserver", "mongodb", "tdengine", "clickhouse":
 		// 这些类型的 dbName 表示"数据库"，需要写入连接配置以选择目标库。
 		runConfig.Database = name
 	case "dameng":
@@ -30,6 +46,7 @@ func normalizeRunConfig(config connection.ConnectionConfig, dbName string) conne
 }
 
 func normalizeSchemaAndTable(config connection.ConnectionConfig, dbName string, tableName string) (string, string) {
+	config = applyCustomDriverType(config)
 	rawTable := strings.TrimSpace(tableName)
 	rawDB := strings.TrimSpace(dbName)
 	if rawTable == "" {
@@ -56,3 +73,13 @@ func normalizeSchemaAndTable(config connection.ConnectionConfig, dbName string,
 		return rawDB, rawTable
 	}
 }
+
+// connectionStoreKey 以 类型/主机/端口/库 标识本地持久化数据归属，不含凭据。
+func connectionStoreKey(config connection.ConnectionConfig, dbName string) string {
+	config = applyCustomDriverType(config)
+	database := strings.TrimSpace(dbName)
+	if database == "" {
+		database = strings.TrimSpace(config.Database)
+	}
+	return strings.ToLower(fmt.Sprintf("%s://%s:%d/%s", strings.TrimSpace(config.Type), strings.TrimSpace(config.Host), config.Port, database))
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	// maxDatabasePoolsPerConnection 同一连接下按数据库分别建立的连接池上限，超出时关闭最久未用的空闲池。
+	maxDatabasePoolsPerConnection = 6
+	// databasePoolMinIdle 空闲不足该时长的连接池不会被关闭，避免关掉正在执行语句的连接。
+	databasePoolMinIdle = 2 * time.Minute
+)
+
+// pendingConnect 同一缓存 Key 正在建立的连接；并发请求等待首个请求的结果，不重复建连。
+type pendingConnect struct {
+	done chan struct{}
+	inst db.Database
+	err  error
+}
+
+// usesPerDatabasePools 连接只能访问建连时指定的数据库，浏览其它数据库需要单独的连接池。
+func usesPerDatabasePools(dbType string) bool {
+	switch strings.ToLower(strings.TrimSpace(dbType)) {
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return true
+	}
+	return false
+}
+
+// poolOwnerKey 同一连接（不区分数据库）的各连接池共用的 Key。
+func poolOwnerKey(config connection.ConnectionConfig) string {
+	config.Database = ""
+	return getCacheKey(config)
+}
+
+func newLastUsed(now time.Time) *atomic.Int64 {
+	v := &atomic.Int64{}
+	v.Store(now.UnixNano())
+	return v
+}
+
+func (c cachedDatabase) touch(now time.Time) {
+	if c.lastUsed != nil {
+		c.lastUsed.Store(now.UnixNano())
+	}
+}
+
+func (c cachedDatabase) lastUsedAt() time.Time {
+	if c.lastUsed == nil {
+		return c.lastPing
+	}
+	return time.Unix(0, c.lastUsed.Load())
+}
+
+// connectOnce 合并同一缓存 Key 的并发建连：展开多个数据库节点或批量请求时只建立一次连接。
+func (a *App) connectOnce(key string, connect func() (db.Database, error)) (db.Database, error) {
+	a.mu.Lock()
+	if pending, ok := a.connecting[key]; ok {
+		a.mu.Unlock()
+		<-pending.done
+		return pending.inst, pending.err
+	}
+	if a.connecting == nil {
+		a.connecting = make(map[string]*pendingConnect)
+	}
+	pending := &pendingConnect{done: make(chan struct{})}
+	a.connecting[key] = pending
+	a.mu.Unlock()
+
+	pending.inst, pending.err = connect()
+
+	a.mu.Lock()
+	delete(a.connecting, key)
+	a.mu.Unlock()
+	close(pending.done)
+	return pending.inst, pending.err
+}
+
+// pinnedCacheKeys 返回被标签页固定会话占用的缓存 Key，这些连接池不能被关闭。
+func (a *App) pinnedCacheKeys() map[string]bool {
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+	keys := make(map[string]bool, len(a.tabSessions))
+	for _, pinned := range a.tabSessions {
+		if pinned != nil {
+			keys[pinned.cacheKey] = true
+		}
+	}
+	return keys
+}
+
+// evictIdleDatabasePools 同一连接的数据库连接池超过上限时，按最久未用顺序关闭空闲的连接池。
+// keepKey 为刚建立的连接池，始终保留；仍不足时允许暂时超出上限。
+func (a *App) evictIdleDatabasePools(config connection.ConnectionConfig, keepKey string, now time.Time) int {
+	if !usesPerDatabasePools(config.Type) {
+		return 0
+	}
+	owner := poolOwnerKey(config)
+	pinned := a.pinnedCacheKeys()
+
+	type sibling struct {
+		key   string
+		entry cachedDatabase
+	}
+	var evicted []cachedDatabase
+	a.mu.Lock()
+	var siblings []sibling
+	for key, entry := range a.dbCache {
+		if entry.inst != nil && poolOwnerKey(entry.config) == owner {
+			siblings = append(siblings, sibling{key: key, entry: entry})
+		}
+	}
+	if len(siblings) > maxDatabasePoolsPerConnection {
+		sort.Slice(siblings, func(i, j int) bool {
+			return siblings[i].entry.lastUsedAt().Before(siblings[j].entry.lastUsedAt())
+		})
+		remaining := len(siblings)
+		for _, s := range siblings {
+			if remaining <= maxDatabasePoolsPerConnection {
+				break
+			}
+			if s.key == keepKey || pinned[s.key] || now.Sub(s.entry.lastUsedAt()) < databasePoolMinIdle {
+				continue
+			}
+			delete(a.dbCache, s.key)
+			evicted = append(evicted, s.entry)
+			remaining--
+		}
+	}
+	a.mu.Unlock()
+
+	for _, entry := range evicted {
+		logger.Infof("关闭空闲的数据库连接池：%s", formatConnSummary(entry.config))
+		if err := entry.inst.Close(); err != nil {
+			logger.Error(err, "关闭空闲连接池失败：%s", formatConnSummary(entry.config))
+		}
+	}
+	return len(evicted)
+}
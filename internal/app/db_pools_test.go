@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type poolFakeDB struct {
+	db.Database
+	closed bool
+}
+
+func (f *poolFakeDB) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestEvictIdleDatabasePools(t *testing.T) {
+	a := NewApp()
+	base := connection.ConnectionConfig{Type: "postgres", Host: "pg", Port: 5432, User: "app"}
+	now := time.Now()
+	fakes := map[string]*poolFakeDB{}
+	for i := 0; i < maxDatabasePoolsPerConnection+2; i++ {
+		config := normalizeRunConfig(base, fmt.Sprintf("db%d", i))
+		fake := &poolFakeDB{}
+		fakes[config.Database] = fake
+		// db0 最久未用，db1 被标签页固定，其余刚刚使用
+		used := now.Add(-time.Duration(i) * time.Second)
+		if i < 3 {
+			used = now.Add(-time.Duration(10-i) * time.Minute)
+		}
+		a.dbCache[getCacheKey(config)] = cachedDatabase{inst: fake, lastPing: now, config: config, lastUsed: newLastUsed(used)}
+	}
+	a.tabSessions["tab-1"] = &tabSession{cacheKey: getCacheKey(normalizeRunConfig(base, "db1"))}
+	other := normalizeRunConfig(connection.ConnectionConfig{Type: "postgres", Host: "other", Port: 5432}, "db0")
+	otherFake := &poolFakeDB{}
+	a.dbCache[getCacheKey(other)] = cachedDatabase{inst: otherFake, lastPing: now, config: other, lastUsed: newLastUsed(now.Add(-time.Hour))}
+
+	latest := normalizeRunConfig(base, "db7")
+	if evicted := a.evictIdleDatabasePools(latest, getCacheKey(latest), now); evicted != 2 {
+		t.Fatalf("evicted = %d", evicted)
+	}
+	if !fakes["db0"].closed || fakes["db1"].closed || !fakes["db2"].closed || fakes["db3"].closed {
+		t.Fatalf("closed: db0=%v db1=%v db2=%v db3=%v", fakes["db0"].closed, fakes["db1"].closed, fakes["db2"].closed, fakes["db3"].closed)
+	}
+	if otherFake.closed {
+		t.Fatal("pools of another connection must not be evicted")
+	}
+
+	// MySQL 在同一连接上切换库，不按数据库分池
+	if evicted := a.evictIdleDatabasePools(connection.ConnectionConfig{Type: "mysql"}, "", now); evicted != 0 {
+		t.Fatalf("mysql evicted = %d", evicted)
+	}
+}
+
+func TestConnectOnceMergesConcurrentConnects(t *testing.T) {
+	a := &App{}
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fake := &poolFakeDB{}
+	var wg sync.WaitGroup
+	results := make([]db.Database, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = a.connectOnce("k", func() (db.Database, error) {
+				calls.Add(1)
+				<-release
+				return fake, nil
+			})
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Fatalf("connect called %d times", calls.Load())
+	}
+	for _, inst := range results {
+		if inst != fake {
+			t.Fatal("all callers should share the same instance")
+		}
+	}
+}
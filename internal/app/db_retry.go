@@ -0,0 +1,73 @@
+package app
+
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+const reconnectRetryWarning = "连接已断开，已自动重连并重试"
+
+var connectionLostMarkers = []string{
+	"server has gone away",
+	"lost connection",
+	"invalid connection",
+	"bad connection",
+	"broken pipe",
+	"connection reset",
+	"use of closed network connection",
+	"connection is closed",
+	"database is closed",
+	"terminating connection",
+	"unexpected eof",
+	"ora-03113",
+	"ora-03114",
+	"ora-03135",
+}
+
+// isConnectionLostError 判断错误是否由连接中断引起（驱动错误或代理进程转发的错误文本）。
+func isConnectionLostError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) {
+		return true
+	}
+	text := strings.ToLower(err.Error())
+	if strings.HasSuffix(text, ": eof") || text == "eof" {
+		return true
+	}
+	for _, marker := range connectionLostMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// runWithReconnect 执行幂等（只读/元数据）操作。启用 AutoReconnect 且因连接中断失败时，
+// 丢弃缓存连接、重新建立后重试一次，成功时返回提示信息供前端展示。写操作不得使用。
+func (a *App) runWithReconnect(config connection.ConnectionConfig, dbInst db.Database, op string, fn func(db.Database) error) (string, error) {
+	err := fn(dbInst)
+	if err == nil || !config.AutoReconnect || !isConnectionLostError(err) {
+		return "", err
+	}
+	logger.Warnf("%s 连接已断开，尝试重连后重试：%s 原因=%v", op, formatConnSummary(config), err)
+	a.closeCachedDatabase(config)
+	fresh, connErr := a.getDatabaseWithPing(config, true)
+	if connErr != nil {
+		logger.Error(connErr, "%s 自动重连失败：%s", op, formatConnSummary(config))
+		return "", err
+	}
+	if retryErr := fn(fresh); retryErr != nil {
+		return "", retryErr
+	}
+	logger.Infof("%s 自动重连后重试成功：%s", op, formatConnSummary(config))
+	return reconnectRetryWarning, nil
+}
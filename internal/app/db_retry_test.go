@@ -0,0 +1,31 @@
+package app
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsConnectionLostError(t *testing.T) {
+	lost := []error{
+		driver.ErrBadConn,
+		fmt.Errorf("查询失败：%w", driver.ErrBadConn),
+		errors.New("Error 2006: MySQL server has gone away"),
+		errors.New("invalid connection"),
+		errors.New("write tcp 127.0.0.1:5000->127.0.0.1:3306: write: broken pipe"),
+		errors.New("ORA-03113: end-of-file on communication channel"),
+		errors.New("read: EOF"),
+	}
+	for _, err := range lost {
+		if !isConnectionLostError(err) {
+			t.Fatalf("expected connection lost: %v", err)
+		}
+	}
+	kept := []error{nil, errors.New("Error 1064: You have an error in your SQL syntax"), errors.New("table geoffrey not found")}
+	for _, err := range kept {
+		if isConnectionLostError(err) {
+			t.Fatalf("unexpected connection lost: %v", err)
+		}
+	}
+}
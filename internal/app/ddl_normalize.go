@@ -0,0 +1,260 @@
+package app
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DDL 规范化：导出与备份写出的建表语句先经过 normalizeDDL，使同一结构在不同时间、不同实例上导出的文本一致，
+// 在 git 或 Beyond Compare 等外部比对工具中只显示真实的结构变化：
+//   - 换行统一为 \n，去掉行尾空白，合并引号外的连续空格与连续空行；
+//   - 去掉 MySQL 表选项中随数据变化的 AUTO_INCREMENT=N 计数器；
+//   - CREATE TABLE 定义体每项一行，列保持原有顺序（列序属于表结构），其后的主键、唯一键、索引、外键、CHECK 约束按类别和名称排序。
+// 定义体内含注释时（如 SQLite 保存的原始建表语句）不重排，只规范空白。
+
+var (
+	ddlCreateTablePattern   = regexp.MustCompile(`(?i)^CREATE\s+(?:[A-Z_]+\s+)*?TABLE\s`)
+	ddlCreateAsPattern      = regexp.MustCompile(`(?i)\s(?:AS|SELECT|PARTITION\s+OF|OF)\s`)
+	ddlAutoIncrementPattern = regexp.MustCompile(`(?i)\s*\bAUTO_INCREMENT\s*=\s*\d+`)
+)
+
+// ddlIndexItemTypes 中的方言允许在定义体内直接声明 KEY/INDEX 等索引项；其他方言中这些词可能是未加引号的列名。
+var ddlIndexItemTypes = map[string]struct{}{
+	"mysql": {}, "mariadb": {}, "diros": {}, "sphinx": {}, "clickhouse": {},
+}
+
+// normalizeDDL 规范化单个对象的 DDL 文本，结果不含结尾空白。
+func normalizeDDL(dbType string, ddl string) string {
+	dbType = strings.ToLower(strings.TrimSpace(dbType))
+	_, mysqlLike := ddlIndexItemTypes[dbType]
+	normalized := normalizeDDLWhitespace(strings.TrimSpace(ddl), mysqlLike)
+	if !ddlCreateTablePattern.MatchString(normalized) {
+		return normalized
+	}
+
+	open := -1
+	for i := 0; i < len(normalized); i++ {
+		if end := ddlQuoteEnd(normalized, i, mysqlLike); end > 0 {
+			i = end - 1
+			continue
+		}
+		if normalized[i] == '(' {
+			open = i
+			break
+		}
+	}
+	if open < 0 || ddlCreateAsPattern.MatchString(normalized[:open]+" ") {
+		return normalized
+	}
+	items, closeAt, ok := splitDDLBody(normalized, open+1, mysqlLike)
+	if !ok {
+		return normalized
+	}
+
+	// 只排序列定义之后连续的约束项，夹在列之间的约束保持原位
+	keysFrom := len(items)
+	for keysFrom > 0 && ddlItemRank(items[keysFrom-1], mysqlLike) >= 0 {
+		keysFrom--
+	}
+	keys := items[keysFrom:]
+	sort.SliceStable(keys, func(i, j int) bool {
+		ri, rj := ddlItemRank(keys[i], mysqlLike), ddlItemRank(keys[j], mysqlLike)
+		if ri != rj {
+			return ri < rj
+		}
+		return strings.ToLower(keys[i]) < strings.ToLower(keys[j])
+	})
+
+	suffix := normalized[closeAt+1:]
+	if mysqlLike {
+		suffix = ddlAutoIncrementPattern.ReplaceAllString(suffix, "")
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimSpace(normalized[:open]))
+	b.WriteString(" (\n  ")
+	b.WriteString(strings.Join(items, ",\n  "))
+	b.WriteString("\n)")
+	b.WriteString(strings.TrimRight(suffix, " \t\n"))
+	return b.String()
+}
+
+// normalizeDDLWhitespace 统一换行并压缩引号外的空白；行首缩进保留，连续空行合并为一行。
+func normalizeDDLWhitespace(ddl string, backslashEscape bool) string {
+	ddl = strings.ReplaceAll(ddl, "\r\n", "\n")
+	ddl = strings.ReplaceAll(ddl, "\r", "\n")
+
+	var b strings.Builder
+	b.Grow(len(ddl))
+	pending := "" // 下一个可见字符前需写出的空白
+	newlines := 0
+	lineStart := true
+	flush := func() {
+		if b.Len() > 0 && newlines > 0 {
+			b.WriteString(strings.Repeat("\n", min(newlines, 2)))
+		}
+		b.WriteString(pending)
+		pending, newlines, lineStart = "", 0, false
+	}
+	for i := 0; i < len(ddl); {
+		if end := ddlQuoteEnd(ddl, i, backslashEscape); end > 0 {
+			flush()
+			b.WriteString(ddl[i:end])
+			i = end
+			continue
+		}
+		switch c := ddl[i]; c {
+		case ' ', '\t':
+			j := i
+			for j < len(ddl) && (ddl[j] == ' ' || ddl[j] == '\t') {
+				j++
+			}
+			if lineStart {
+				pending = ddl[i:j]
+			} else {
+				pending = " "
+			}
+			i = j
+		case '\n':
+			pending = ""
+			newlines++
+			lineStart = true
+			i++
+		default:
+			flush()
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// splitDDLBody 从 start 开始按顶层逗号拆分定义体，返回各项（空白压缩为单个空格）与右括号位置；含注释或括号不匹配时 ok 为 false。
+func splitDDLBody(s string, start int, backslashEscape bool) (items []string, closeAt int, ok bool) {
+	depth := 0
+	itemStart := start
+	for i := start; i < len(s); i++ {
+		if end := ddlQuoteEnd(s, i, backslashEscape); end > 0 {
+			i = end - 1
+			continue
+		}
+		switch s[i] {
+		case '-', '/':
+			if strings.HasPrefix(s[i:], "--") || strings.HasPrefix(s[i:], "/*") {
+				return nil, 0, false
+			}
+		case '#':
+			if backslashEscape {
+				return nil, 0, false
+			}
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				items = append(items, collapseDDLSpaces(s[itemStart:i], backslashEscape))
+				return items, i, true
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, collapseDDLSpaces(s[itemStart:i], backslashEscape))
+				itemStart = i + 1
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+func collapseDDLSpaces(s string, backslashEscape bool) string {
+	var b strings.Builder
+	space := false
+	for i := 0; i < len(s); {
+		if end := ddlQuoteEnd(s, i, backslashEscape); end > 0 {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteString(s[i:end])
+			i = end
+			continue
+		}
+		if c := s[i]; c == ' ' || c == '\t' || c == '\n' {
+			space = true
+		} else {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteByte(c)
+		}
+		i++
+	}
+	return b.String()
+}
+
+// ddlItemRank 返回定义体中约束项的排序类别，列定义返回 -1。
+func ddlItemRank(item string, indexItems bool) int {
+	word := ddlLeadingWord(item)
+	if word == "CONSTRAINT" {
+		rest := strings.TrimSpace(item[len(word):])
+		if rest != "" && ddlQuoteEnd(rest, 0, false) > 0 {
+			rest = rest[ddlQuoteEnd(rest, 0, false):]
+		} else {
+			rest = rest[len(ddlLeadingWord(rest)):]
+		}
+		if rank := ddlItemRank(strings.TrimSpace(rest), false); rank >= 0 {
+			return rank
+		}
+		return 3
+	}
+	switch word {
+	case "PRIMARY":
+		return 0
+	case "UNIQUE":
+		return 1
+	case "KEY", "INDEX", "FULLTEXT", "SPATIAL", "PROJECTION":
+		if indexItems {
+			return 2
+		}
+	case "FOREIGN":
+		return 3
+	case "CHECK":
+		return 4
+	}
+	return -1
+}
+
+func ddlLeadingWord(s string) string {
+	end := 0
+	for end < len(s) {
+		c := s[end]
+		if c != '_' && (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			break
+		}
+		end++
+	}
+	return strings.ToUpper(s[:end])
+}
+
+// ddlQuoteEnd s[i] 为字符串或带引号标识符的起始字符时返回其结束位置之后的下标，否则返回 -1。
+func ddlQuoteEnd(s string, i int, backslashEscape bool) int {
+	var closer byte
+	switch s[i] {
+	case '\'', '"', '`':
+		closer = s[i]
+	case '[':
+		closer = ']'
+	default:
+		return -1
+	}
+	for j := i + 1; j < len(s); j++ {
+		if backslashEscape && closer != '`' && closer != ']' && s[j] == '\\' {
+			j++
+			continue
+		}
+		if s[j] == closer {
+			return j + 1
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,42 @@
+package app
+
+import "testing"
+
+func TestNormalizeDDL(t *testing.T) {
+	mysqlDDL := "CREATE TABLE `orders` (\r\n" +
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\r\n" +
+		"  `note`   varchar(64)  DEFAULT 'a,  b',   \r\n" +
+		"  `user_id` bigint DEFAULT NULL,\r\n" +
+		"  KEY `idx_user` (`user_id`),\r\n" +
+		"  CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`),\r\n" +
+		"  UNIQUE KEY `uk_note` (`note`),\r\n" +
+		"  KEY `idx_created` (`id`,`note`),\r\n" +
+		"  PRIMARY KEY (`id`)\r\n" +
+		") ENGINE=InnoDB AUTO_INCREMENT=1042 DEFAULT CHARSET=utf8mb4"
+	want := "CREATE TABLE `orders` (\n" +
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n" +
+		"  `note` varchar(64) DEFAULT 'a,  b',\n" +
+		"  `user_id` bigint DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_note` (`note`),\n" +
+		"  KEY `idx_created` (`id`,`note`),\n" +
+		"  KEY `idx_user` (`user_id`),\n" +
+		"  CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+	if got := normalizeDDL("mysql", mysqlDDL); got != want {
+		t.Fatalf("mysql:\n%s\nwant:\n%s", got, want)
+	}
+
+	// SQLite 中 key 可以是未加引号的列名，不能当作索引项排序
+	sqliteDDL := "CREATE TABLE kv (\n\tkey VARCHAR(32),\n\tvalue TEXT,\n\tUNIQUE (value),\n\tPRIMARY KEY (key)\n)"
+	want = "CREATE TABLE kv (\n  key VARCHAR(32),\n  value TEXT,\n  PRIMARY KEY (key),\n  UNIQUE (value)\n)"
+	if got := normalizeDDL("sqlite", sqliteDDL); got != want {
+		t.Fatalf("sqlite:\n%s\nwant:\n%s", got, want)
+	}
+
+	// 定义体内有注释时只规范空白
+	commented := "CREATE TABLE t (\n  b INT, -- 第二列\n  a INT   \n)\n\n\n"
+	if got := normalizeDDL("sqlite", commented); got != "CREATE TABLE t (\n  b INT, -- 第二列\n  a INT\n)" {
+		t.Fatalf("commented:\n%q", got)
+	}
+}
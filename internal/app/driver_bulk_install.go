@@ -0,0 +1,166 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const driverBulkInstallProgressEvent = "driver:bulk-install-progress"
+
+const (
+	driverBulkStatusInstalled = "installed"
+	driverBulkStatusSkipped   = "skipped"
+	driverBulkStatusFailed    = "failed"
+)
+
+// driverBulkInstallResult 批量安装中单个驱动的结果。
+type driverBulkInstallResult struct {
+	DriverType string `json:"driverType"`
+	DriverName string `json:"driverName"`
+	Status     string `json:"status"` // installed / skipped / failed
+	Message    string `json:"message,omitempty"`
+}
+
+// driverBulkInstallProgressPayload 批量安装的整体进度：Percent 按已完成驱动数加当前驱动的下载进度折算。
+type driverBulkInstallProgressPayload struct {
+	Index      int     `json:"index"` // 当前驱动序号，从 1 开始
+	Total      int     `json:"total"`
+	DriverType string  `json:"driverType"`
+	DriverName string  `json:"driverName"`
+	Status     string  `json:"status"` // start / downloading / installed / skipped / failed / done
+	Percent    float64 `json:"percent"`
+	Message    string  `json:"message,omitempty"`
+}
+
+type driverBulkInstall struct {
+	index      int
+	total      int
+	driverType string
+	driverName string
+}
+
+// InstallDriverPackages 依次安装多个可选驱动，单个驱动失败不影响后续驱动。
+// driverTypes 为空时安装全部推荐驱动：当前构建包含、尚未启用的纯 Go 可选驱动。
+func (a *App) InstallDriverPackages(driverTypes []string, downloadDir string) connection.QueryResult {
+	resolvedDir, err := resolveDriverDownloadDirectory(downloadDir)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	db.SetExternalDriverDownloadDirectory(resolvedDir)
+	if len(driverTypes) == 0 {
+		driverTypes = recommendedDriverTypes()
+	}
+	if len(driverTypes) == 0 {
+		return connection.QueryResult{Success: true, Message: "推荐驱动均已安装", Data: []driverBulkInstallResult{}}
+	}
+
+	a.driverBulkMu.Lock()
+	if a.driverBulk != nil {
+		a.driverBulkMu.Unlock()
+		return connection.QueryResult{Success: false, Message: "已有批量安装正在进行"}
+	}
+	a.driverBulk = &driverBulkInstall{total: len(driverTypes)}
+	a.driverBulkMu.Unlock()
+	defer func() {
+		a.driverBulkMu.Lock()
+		a.driverBulk = nil
+		a.driverBulkMu.Unlock()
+	}()
+
+	results := make([]driverBulkInstallResult, 0, len(driverTypes))
+	counts := map[string]int{}
+	for i, driverType := range driverTypes {
+		result := driverBulkInstallResult{DriverType: normalizeDriverType(driverType), DriverName: strings.TrimSpace(driverType)}
+		definition, ok := resolveDriverDefinition(driverType)
+		if ok {
+			result.DriverType = definition.Type
+			result.DriverName = definition.Name
+		}
+		a.driverBulkMu.Lock()
+		a.driverBulk.index, a.driverBulk.driverType, a.driverBulk.driverName = i+1, result.DriverType, result.DriverName
+		a.driverBulkMu.Unlock()
+		a.emitDriverBulkProgress("start", 0, fmt.Sprintf("正在安装 %s（%d/%d）", result.DriverName, i+1, len(driverTypes)))
+
+		available, _ := db.DriverRuntimeSupportStatus(result.DriverType)
+		switch {
+		case !ok:
+			result.Status, result.Message = driverBulkStatusFailed, "不支持的驱动类型"
+		case definition.BuiltIn:
+			result.Status, result.Message = driverBulkStatusSkipped, "内置驱动无需安装"
+		case available:
+			result.Status, result.Message = driverBulkStatusSkipped, "已启用"
+		default:
+			if res := a.DownloadDriverPackage(definition.Type, "", resolvedDir); res.Success {
+				result.Status, result.Message = driverBulkStatusInstalled, res.Message
+			} else {
+				result.Status, result.Message = driverBulkStatusFailed, res.Message
+			}
+		}
+		counts[result.Status]++
+		results = append(results, result)
+		a.emitDriverBulkProgress(result.Status, 100, result.Message)
+	}
+
+	summary := fmt.Sprintf("批量安装完成：成功 %d，跳过 %d，失败 %d",
+		counts[driverBulkStatusInstalled], counts[driverBulkStatusSkipped], counts[driverBulkStatusFailed])
+	a.emitDriverBulkProgress("done", 100, summary)
+	return connection.QueryResult{Success: true, Message: summary, Data: results}
+}
+
+// recommendedDriverTypes 返回当前构建包含但尚未启用的纯 Go 可选驱动。
+func recommendedDriverTypes() []string {
+	var types []string
+	for _, definition := range allDriverDefinitionsWithPackages(nil) {
+		if definition.BuiltIn || !db.IsOptionalGoDriver(definition.Type) || !db.IsOptionalGoDriverBuildIncluded(definition.Type) {
+			continue
+		}
+		if available, _ := db.DriverRuntimeSupportStatus(definition.Type); available {
+			continue
+		}
+		types = append(types, definition.Type)
+	}
+	return types
+}
+
+// emitDriverBulkProgress 按当前驱动的进度 driverPercent（0-100）折算并发送整体进度。
+func (a *App) emitDriverBulkProgress(status string, driverPercent float64, message string) {
+	a.driverBulkMu.Lock()
+	state := a.driverBulk
+	var payload driverBulkInstallProgressPayload
+	if state != nil {
+		payload = driverBulkInstallProgressPayload{
+			Index:      state.index,
+			Total:      state.total,
+			DriverType: state.driverType,
+			DriverName: state.driverName,
+			Status:     status,
+			Message:    message,
+		}
+	}
+	a.driverBulkMu.Unlock()
+	if state == nil || a.ctx == nil || payload.Total == 0 {
+		return
+	}
+	if status == "done" {
+		payload.Percent = 100
+	} else {
+		payload.Percent = (float64(payload.Index-1) + driverPercent/100) / float64(payload.Total) * 100
+	}
+	runtime.EventsEmit(a.ctx, driverBulkInstallProgressEvent, payload)
+}
+
+// forwardDriverBulkProgress 把批量安装中当前驱动的下载进度汇总为整体进度；结束状态由批量流程自行发送。
+func (a *App) forwardDriverBulkProgress(payload driverDownloadProgressPayload) {
+	a.driverBulkMu.Lock()
+	active := a.driverBulk != nil && a.driverBulk.driverType == payload.DriverType
+	a.driverBulkMu.Unlock()
+	if !active || payload.Status == "done" || payload.Status == "error" {
+		return
+	}
+	a.emitDriverBulkProgress("downloading", payload.Percent, payload.Message)
+}
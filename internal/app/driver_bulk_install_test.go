@@ -0,0 +1,27 @@
+package app
+
+import "testing"
+
+func TestInstallDriverPackagesReportsPerDriverResults(t *testing.T) {
+	useDriverNetworkSettings(t, DriverNetworkSettings{Offline: true})
+	a := &App{}
+	res := a.InstallDriverPackages([]string{"mysql", "no-such-driver"}, t.TempDir())
+	if !res.Success {
+		t.Fatalf("InstallDriverPackages failed: %s", res.Message)
+	}
+	results := res.Data.([]driverBulkInstallResult)
+	if len(results) != 2 || results[0].Status != driverBulkStatusSkipped || results[1].Status != driverBulkStatusFailed {
+		t.Fatalf("results = %+v", results)
+	}
+	if res.Message != "批量安装完成：成功 0，跳过 1，失败 1" {
+		t.Fatalf("message = %q", res.Message)
+	}
+	if a.driverBulk != nil {
+		t.Fatal("bulk install state should be cleared")
+	}
+
+	a.driverBulk = &driverBulkInstall{total: 1}
+	if res := a.InstallDriverPackages([]string{"mysql"}, t.TempDir()); res.Success {
+		t.Fatal("expected concurrent bulk install to be rejected")
+	}
+}
@@ -0,0 +1,251 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	defaultDriverManifestTimeoutSeconds = 12
+	defaultDriverProbeTimeoutSeconds    = 4
+	defaultDriverDownloadTimeoutSeconds = 600
+	defaultDriverMaxAttempts            = 3
+	defaultDriverRetryBackoffMs         = 500
+	driverRetryBackoffMax               = 8 * time.Second
+)
+
+// errDriverNetworkOffline 离线模式下跳过所有驱动相关的网络请求。
+var errDriverNetworkOffline = errors.New("已开启离线模式，跳过网络请求；可使用内置清单或本地驱动包")
+
+// DriverNetworkSettings 驱动清单、Release 信息与驱动包下载的网络设置，保存在 ~/.gonavi/driver_network.json。
+type DriverNetworkSettings struct {
+	// Offline 离线模式：不访问网络，驱动管理页不再等待清单与安装包大小查询超时。
+	Offline                bool `json:"offline"`
+	ManifestTimeoutSeconds int  `json:"manifestTimeoutSeconds,omitempty"` // 远程清单请求超时，默认 12 秒
+	ProbeTimeoutSeconds    int  `json:"probeTimeoutSeconds,omitempty"`    // Release 信息与安装包大小查询超时，默认 4 秒
+	DownloadTimeoutSeconds int  `json:"downloadTimeoutSeconds,omitempty"` // 单次驱动包下载超时，默认 600 秒
+	MaxAttempts            int  `json:"maxAttempts,omitempty"`            // 含首次在内的最大尝试次数，默认 3
+	RetryBackoffMs         int  `json:"retryBackoffMs,omitempty"`         // 首次重试前的等待，之后每次翻倍，最长 8 秒；默认 500
+}
+
+var (
+	driverNetworkMu       sync.RWMutex
+	driverNetworkSettings *DriverNetworkSettings // 首次使用时从磁盘加载
+	driverNetworkSleep    = time.Sleep
+)
+
+func driverNetworkSettingsPath() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "driver_network.json")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-driver_network.json")
+}
+
+// withDefaults 未设置或越界的字段回落到默认值。
+func (s DriverNetworkSettings) withDefaults() DriverNetworkSettings {
+	if s.ManifestTimeoutSeconds <= 0 {
+		s.ManifestTimeoutSeconds = defaultDriverManifestTimeoutSeconds
+	}
+	if s.ProbeTimeoutSeconds <= 0 {
+		s.ProbeTimeoutSeconds = defaultDriverProbeTimeoutSeconds
+	}
+	if s.DownloadTimeoutSeconds <= 0 {
+		s.DownloadTimeoutSeconds = defaultDriverDownloadTimeoutSeconds
+	}
+	if s.MaxAttempts <= 0 {
+		s.MaxAttempts = defaultDriverMaxAttempts
+	}
+	s.MaxAttempts = min(s.MaxAttempts, 10)
+	if s.RetryBackoffMs <= 0 {
+		s.RetryBackoffMs = defaultDriverRetryBackoffMs
+	}
+	return s
+}
+
+// currentDriverNetworkSettings 返回生效中的网络设置；配置文件损坏时按默认值处理。
+func currentDriverNetworkSettings() DriverNetworkSettings {
+	driverNetworkMu.RLock()
+	loaded := driverNetworkSettings
+	driverNetworkMu.RUnlock()
+	if loaded != nil {
+		return loaded.withDefaults()
+	}
+
+	settings := DriverNetworkSettings{}
+	if content, err := os.ReadFile(driverNetworkSettingsPath()); err == nil && len(strings.TrimSpace(string(content))) > 0 {
+		if err := json.Unmarshal(content, &settings); err != nil {
+			logger.Warnf("解析驱动网络设置失败，使用默认值：%v", err)
+			settings = DriverNetworkSettings{}
+		}
+	}
+	driverNetworkMu.Lock()
+	if driverNetworkSettings == nil {
+		driverNetworkSettings = &settings
+	}
+	settings = *driverNetworkSettings
+	driverNetworkMu.Unlock()
+	return settings.withDefaults()
+}
+
+// GetDriverNetworkSettings 返回驱动下载与清单拉取的网络设置（已填充默认值）。
+func (a *App) GetDriverNetworkSettings() connection.QueryResult {
+	return connection.QueryResult{Success: true, Data: currentDriverNetworkSettings()}
+}
+
+// SaveDriverNetworkSettings 保存网络设置并立即生效；切换离线模式会清空清单与安装包大小缓存。
+func (a *App) SaveDriverNetworkSettings(settings DriverNetworkSettings) connection.QueryResult {
+	if settings.ManifestTimeoutSeconds < 0 || settings.ProbeTimeoutSeconds < 0 || settings.DownloadTimeoutSeconds < 0 ||
+		settings.MaxAttempts < 0 || settings.RetryBackoffMs < 0 {
+		return connection.QueryResult{Success: false, Message: "超时、重试次数与等待时间不能为负数"}
+	}
+	previous := currentDriverNetworkSettings()
+
+	path := driverNetworkSettingsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("创建配置目录失败：%v", err)}
+	}
+	content, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("保存驱动网络设置失败：%v", err)}
+	}
+
+	driverNetworkMu.Lock()
+	saved := settings
+	driverNetworkSettings = &saved
+	driverNetworkMu.Unlock()
+	if previous.Offline != settings.Offline {
+		clearDriverNetworkCaches()
+	}
+	return connection.QueryResult{Success: true, Message: "驱动网络设置已保存", Data: settings.withDefaults()}
+}
+
+func clearDriverNetworkCaches() {
+	driverManifestCacheMu.Lock()
+	driverManifestCache = make(map[string]driverManifestCacheEntry)
+	driverManifestCacheMu.Unlock()
+	driverReleaseSizeMu.Lock()
+	driverReleaseSizeMap = make(map[string]driverReleaseAssetSizeCacheEntry)
+	driverReleaseSizeMu.Unlock()
+	invalidateDriverStatus("")
+}
+
+// httpStatusError 服务端返回非 200 状态码。
+type httpStatusError struct {
+	action string
+	code   int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("%s：HTTP %d", e.action, e.code)
+}
+
+// isRetryableDriverNetworkError 网络错误、超时、429 与 5xx 可重试；其他 4xx、离线模式与非法地址直接返回。
+func isRetryableDriverNetworkError(err error) bool {
+	if err == nil || errors.Is(err, errDriverNetworkOffline) {
+		return false
+	}
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code == http.StatusTooManyRequests || statusErr.code >= 500
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && strings.Contains(urlErr.Err.Error(), "unsupported protocol scheme") {
+		return false
+	}
+	return true
+}
+
+// retryDriverNetwork 按设置重试 fn，重试间隔指数退避。离线模式下不调用 fn。
+func retryDriverNetwork(settings DriverNetworkSettings, target string, fn func() error) error {
+	if settings.Offline {
+		return errDriverNetworkOffline
+	}
+	backoff := time.Duration(settings.RetryBackoffMs) * time.Millisecond
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= settings.MaxAttempts || !isRetryableDriverNetworkError(err) {
+			return err
+		}
+		logger.Warnf("驱动网络请求失败（第 %d/%d 次），%s 后重试：%s：%v", attempt, settings.MaxAttempts, backoff, target, err)
+		driverNetworkSleep(backoff)
+		backoff = min(backoff*2, driverRetryBackoffMax)
+	}
+}
+
+// fetchDriverNetworkJSON 以 GET 拉取 JSON 并解码到 out，响应体最多读取 maxSize 字节（0 为不限）。
+func fetchDriverNetworkJSON(urlText string, timeout time.Duration, accept string, action string, maxSize int64, out interface{}) error {
+	body, err := fetchDriverNetworkBody(urlText, timeout, accept, action, maxSize)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+// fetchDriverNetworkBody 以 GET 拉取响应体，失败时按网络设置重试；超过 maxSize 时返回错误。
+func fetchDriverNetworkBody(urlText string, timeout time.Duration, accept string, action string, maxSize int64) ([]byte, error) {
+	settings := currentDriverNetworkSettings()
+	client := &http.Client{Timeout: timeout}
+	var body []byte
+	err := retryDriverNetwork(settings, urlText, func() error {
+		req, err := http.NewRequest(http.MethodGet, urlText, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("User-Agent", "GoNavi-DriverManager")
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return &httpStatusError{action: action, code: resp.StatusCode}
+		}
+		body, err = readLimitedBody(resp, maxSize)
+		return err
+	})
+	return body, err
+}
+
+func readLimitedBody(resp *http.Response, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		return io.ReadAll(resp.Body)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, fmt.Errorf("响应超过大小限制（%d 字节）", maxSize)
+	}
+	return body, nil
+}
+
+// downloadDriverFile 下载驱动包并返回 SHA256，中断或服务端错误时按网络设置重试，每次重试从头下载。
+func downloadDriverFile(urlText, filePath string, onProgress func(downloaded, total int64)) (string, error) {
+	settings := currentDriverNetworkSettings()
+	timeout := time.Duration(settings.DownloadTimeoutSeconds) * time.Second
+	var hash string
+	err := retryDriverNetwork(settings, urlText, func() (downloadErr error) {
+		hash, downloadErr = downloadFileWithHashTimeout(urlText, filePath, timeout, onProgress)
+		return downloadErr
+	})
+	return hash, err
+}
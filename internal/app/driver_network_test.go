@@ -0,0 +1,83 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func useDriverNetworkSettings(t *testing.T, settings DriverNetworkSettings) {
+	t.Helper()
+	driverNetworkMu.Lock()
+	previous := driverNetworkSettings
+	driverNetworkSettings = &settings
+	driverNetworkMu.Unlock()
+	previousSleep := driverNetworkSleep
+	driverNetworkSleep = func(time.Duration) {}
+	t.Cleanup(func() {
+		driverNetworkMu.Lock()
+		driverNetworkSettings = previous
+		driverNetworkMu.Unlock()
+		driverNetworkSleep = previousSleep
+	})
+}
+
+func TestFetchDriverNetworkBodyRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/missing":
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		case calls.Add(1) < 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"engine":"go"}`))
+		}
+	}))
+	defer server.Close()
+	useDriverNetworkSettings(t, DriverNetworkSettings{MaxAttempts: 3})
+
+	body, err := fetchDriverNetworkBody(server.URL+"/manifest.json", time.Second, "", "拉取驱动清单失败", driverManifestMaxSize)
+	if err != nil || string(body) != `{"engine":"go"}` || calls.Load() != 3 {
+		t.Fatalf("body=%q err=%v calls=%d", body, err, calls.Load())
+	}
+
+	// 4xx 不重试
+	calls.Store(0)
+	_, err = fetchDriverNetworkBody(server.URL+"/missing", time.Second, "", "拉取驱动清单失败", driverManifestMaxSize)
+	var statusErr *httpStatusError
+	if !errors.As(err, &statusErr) || statusErr.code != http.StatusNotFound || calls.Load() != 1 {
+		t.Fatalf("err=%v calls=%d", err, calls.Load())
+	}
+}
+
+func TestDriverNetworkOfflineSkipsRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+	useDriverNetworkSettings(t, DriverNetworkSettings{Offline: true})
+
+	if _, err := loadManifestContent(server.URL + "/manifest.json"); !errors.Is(err, errDriverNetworkOffline) {
+		t.Fatalf("manifest err = %v", err)
+	}
+	if _, err := downloadDriverFile(server.URL+"/agent", filepath.Join(t.TempDir(), "agent"), nil); !errors.Is(err, errDriverNetworkOffline) {
+		t.Fatalf("download err = %v", err)
+	}
+	if sizes := preloadOptionalDriverPackageSizes([]driverDefinition{{Type: "sqlite"}}); len(sizes) != 0 {
+		t.Fatalf("sizes = %v", sizes)
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("离线模式不应发起请求，实际 %d 次", calls.Load())
+	}
+	// 内置清单不受离线模式影响
+	if _, err := loadManifestContent(defaultDriverManifestURLValue); err != nil {
+		t.Fatalf("builtin manifest err = %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package app
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"fmt"
+	"os"
+	"path/filepath"
+	stdRuntime "runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+// orphanedDriverFile 驱动目录中不再被使用的残留文件（旧版本、其他平台的代理或未完成的下载）。
+type orphanedDriverFile struct {
+	Path         string `json:"path"`
+	RelativePath string `json:"relativePath"`
+	DriverType   string `json:"driverType"`
+	Reason       string `json:"reason"`
+	SizeBytes    int64  `json:"sizeBytes"`
+	SizeText     string `json:"sizeText"`
+}
+
+// ListOrphanedDriverFiles 扫描驱动目录，列出与已知驱动或当前平台不匹配的代理文件及其大小。
+func (a *App) ListOrphanedDriverFiles(downloadDir string) connection.QueryResult {
+	resolvedDir, err := resolveDriverDownloadDirectory(downloadDir)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	items, err := scanOrphanedDriverFiles(resolvedDir, time.Now())
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("扫描驱动目录失败：%v", err)}
+	}
+	var total int64
+	for _, item := range items {
+		total += item.SizeBytes
+	}
+	return connection.QueryResult{Success: true, Data: map[string]interface{}{
+		"downloadDir": resolvedDir,
+		"files":       items,
+		"totalBytes":  total,
+		"totalText":   formatSizeMB(total),
+	}}
+}
+
+// CleanOrphanedDriverFiles 删除残留文件；paths 为空时删除全部扫描结果。
+// 只会删除本次扫描确认为残留的文件，传入其他路径会被忽略。
+func (a *App) CleanOrphanedDriverFiles(downloadDir string, paths []string) connection.QueryResult {
+	resolvedDir, err := resolveDriverDownloadDirectory(downloadDir)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	items, err := scanOrphanedDriverFiles(resolvedDir, time.Now())
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("扫描驱动目录失败：%v", err)}
+	}
+	selected := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		if strings.TrimSpace(p) != "" {
+			selected[filepath.Clean(p)] = true
+		}
+	}
+
+	known := knownDriverTypes()
+	removed := 0
+	var freed int64
+	failures := make([]string, 0)
+	for _, item := range items {
+		if len(selected) > 0 && !selected[item.Path] {
+			continue
+		}
+		if err := os.Remove(item.Path); err != nil && !os.IsNotExist(err) {
+			failures = append(failures, fmt.Sprintf("%s：%v", item.RelativePath, err))
+			continue
+		}
+		removed++
+		freed += item.SizeBytes
+		// 未知驱动类型的目录清空后一并移除，非空时 Remove 会失败并保留目录
+		if dir := filepath.Dir(item.Path); dir != resolvedDir {
+			if _, ok := known[filepath.Base(dir)]; !ok {
+				_ = os.Remove(dir)
+			}
+		}
+	}
+
+	data := map[string]interface{}{
+		"removed":    removed,
+		"freedBytes": freed,
+		"freedText":  formatSizeMB(freed),
+		"failures":   failures,
+	}
+	if len(failures) > 0 {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("已删除 %d 个文件，%d 个删除失败", removed, len(failures)), Data: data}
+	}
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已清理 %d 个残留文件", removed), Data: data}
+}
+
+func knownDriverTypes() map[string]struct{} {
+	known := make(map[string]struct{})
+	for _, definition := range allDriverDefinitionsWithPackages(nil) {
+		known[normalizeDriverType(definition.Type)] = struct{}{}
+	}
+	return known
+}
+
+// scanOrphanedDriverFiles 只检查驱动根目录及其下一层目录中的代理相关文件（*-driver-agent*、installed.json、*.tmp），
+// 用户放在驱动目录里的其他文件不会被列出。仍可能在下载中的临时文件（未超过下载超时）会被跳过。
+func scanOrphanedDriverFiles(root string, now time.Time) ([]orphanedDriverFile, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	known := knownDriverTypes()
+	downloadWindow := time.Duration(currentDriverNetworkSettings().DownloadTimeoutSeconds) * time.Second
+	items := make([]orphanedDriverFile, 0)
+
+	check := func(path string, driverType string, knownType bool) {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			return
+		}
+		name := info.Name()
+		reason := ""
+		switch {
+		case strings.HasSuffix(name, ".tmp"):
+			if now.Sub(info.ModTime()) < downloadWindow {
+				return
+			}
+			reason = "未完成的下载临时文件"
+		case !knownType:
+			if name != "installed.json" && !strings.Contains(name, "-driver-agent") {
+				return
+			}
+			reason = "未知驱动类型的残留文件"
+		case name == "installed.json":
+			return
+		case !strings.Contains(name, "-driver-agent"):
+			return
+		case name != optionalDriverExecutableBaseName(driverType):
+			reason = "旧版本或其他平台的驱动代理"
+		default:
+			goos, goarch, ok := agentBinaryPlatform(path)
+			if !ok || (goos == stdRuntime.GOOS && goarch == stdRuntime.GOARCH) {
+				return
+			}
+			reason = fmt.Sprintf("非当前平台的驱动代理（%s/%s）", goos, goarch)
+		}
+		rel, _ := filepath.Rel(root, path)
+		items = append(items, orphanedDriverFile{
+			Path:         path,
+			RelativePath: filepath.ToSlash(rel),
+			DriverType:   driverType,
+			Reason:       reason,
+			SizeBytes:    info.Size(),
+			SizeText:     formatSizeMB(info.Size()),
+		})
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(root, entry.Name())
+		if !entry.IsDir() {
+			if strings.HasSuffix(entry.Name(), ".tmp") || strings.Contains(entry.Name(), "-driver-agent") {
+				check(path, "", false)
+			}
+			continue
+		}
+		driverType := entry.Name()
+		_, knownType := known[driverType]
+		children, err := os.ReadDir(path)
+		if err != nil {
+			continue
+		}
+		for _, child := range children {
+			if !child.IsDir() {
+				check(filepath.Join(path, child.Name()), driverType, knownType)
+			}
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].RelativePath < items[j].RelativePath })
+	return items, nil
+}
+
+// agentBinaryPlatform 从可执行文件头识别目标平台；无法识别时 ok 为 false，调用方应视为匹配。
+func agentBinaryPlatform(path string) (goos string, goarch string, ok bool) {
+	if f, err := elf.Open(path); err == nil {
+		defer f.Close()
+		goos = "linux"
+		if f.OSABI == elf.ELFOSABI_FREEBSD {
+			goos = "freebsd"
+		}
+		switch f.Machine {
+		case elf.EM_X86_64:
+			return goos, "amd64", true
+		case elf.EM_AARCH64:
+			return goos, "arm64", true
+		case elf.EM_386:
+			return goos, "386", true
+		case elf.EM_ARM:
+			return goos, "arm", true
+		case elf.EM_RISCV:
+			return goos, "riscv64", true
+		case elf.EM_LOONGARCH:
+			return goos, "loong64", true
+		}
+		return "", "", false
+	}
+	if f, err := macho.Open(path); err == nil {
+		defer f.Close()
+		return machoArch(f.Cpu)
+	}
+	if fat, err := macho.OpenFat(path); err == nil {
+		defer fat.Close()
+		// 通用二进制只要包含当前架构即视为匹配
+		for _, arch := range fat.Arches {
+			if _, name, ok := machoArch(arch.Cpu); ok && name == stdRuntime.GOARCH {
+				return "darwin", name, true
+			}
+		}
+		if len(fat.Arches) > 0 {
+			return machoArch(fat.Arches[0].Cpu)
+		}
+		return "", "", false
+	}
+	if f, err := pe.Open(path); err == nil {
+		defer f.Close()
+		switch f.Machine {
+		case pe.IMAGE_FILE_MACHINE_AMD64:
+			return "windows", "amd64", true
+		case pe.IMAGE_FILE_MACHINE_ARM64:
+			return "windows", "arm64", true
+		case pe.IMAGE_FILE_MACHINE_I386:
+			return "windows", "386", true
+		}
+	}
+	return "", "", false
+}
+
+func machoArch(cpu macho.Cpu) (string, string, bool) {
+	switch cpu {
+	case macho.CpuAmd64:
+		return "darwin", "amd64", true
+	case macho.CpuArm64:
+		return "darwin", "arm64", true
+	}
+	return "", "", false
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	stdRuntime "runtime"
+	"testing"
+	"time"
+)
+
+func TestScanOrphanedDriverFiles(t *testing.T) {
+	useDriverNetworkSettings(t, DriverNetworkSettings{})
+	root := t.TempDir()
+	write := func(rel string, modTime time.Time) string {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("agent"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	now := time.Now()
+	old := now.Add(-time.Hour)
+	write("sqlite/installed.json", old)
+	write("sqlite/"+optionalDriverExecutableBaseName("sqlite"), old)
+	write("sqlite/sqlite-driver-agent-plan9-amd64", old)
+	write("sqlite/"+optionalDriverExecutableBaseName("sqlite")+".tmp", old)
+	write("duckdb/"+optionalDriverExecutableBaseName("duckdb")+".tmp", now) // 可能仍在下载
+	write("doris/installed.json", old)
+	write("doris/doris-driver-agent", old)
+	write("notes/readme.txt", old)
+	write("backup.sql", old)
+
+	items, err := scanOrphanedDriverFiles(root, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, 0, len(items))
+	for _, item := range items {
+		got = append(got, item.RelativePath)
+	}
+	want := []string{
+		"doris/doris-driver-agent",
+		"doris/installed.json",
+		"sqlite/sqlite-driver-agent-plan9-amd64",
+		"sqlite/" + optionalDriverExecutableBaseName("sqlite") + ".tmp",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	a := NewApp()
+	res := a.CleanOrphanedDriverFiles(root, nil)
+	if !res.Success || res.Data.(map[string]interface{})["removed"] != 4 {
+		t.Fatalf("clean = %+v", res)
+	}
+	if _, err := os.Stat(filepath.Join(root, "doris")); !os.IsNotExist(err) {
+		t.Fatalf("清空后的未知驱动目录应被移除：%v", err)
+	}
+	if !fileExists(filepath.Join(root, "sqlite", optionalDriverExecutableBaseName("sqlite"))) || !fileExists(filepath.Join(root, "notes", "readme.txt")) {
+		t.Fatalf("不应删除当前驱动代理或用户文件")
+	}
+}
+
+func TestAgentBinaryPlatformCurrentExecutable(t *testing.T) {
+	self, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	// 测试二进制即当前平台的可执行文件
+	if goos, goarch, ok := agentBinaryPlatform(self); ok && (goos != stdRuntime.GOOS || goarch != stdRuntime.GOARCH) {
+		t.Fatalf("platform = %s/%s", goos, goarch)
+	}
+	if _, _, ok := agentBinaryPlatform(filepath.Join(t.TempDir(), "missing")); ok {
+		t.Fatalf("不存在的文件不应识别出平台")
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"strings"
+	"sync"
+)
+
+// driverStatusCacheEntry 一个下载目录 + 清单地址组合下的驱动状态快照，Items 按规范化的驱动类型索引。
+type driverStatusCacheEntry struct {
+	Definitions []driverDefinition
+	Items       map[string]driverStatusItem
+}
+
+var (
+	driverStatusCacheMu  sync.Mutex
+	driverStatusCache    = make(map[string]*driverStatusCacheEntry)
+	driverStatusCacheGen uint64 // 每次失效递增，避免失效前开始的计算把旧状态写回缓存
+)
+
+func driverStatusCacheKey(resolvedDir string, manifestURL string) string {
+	return resolvedDir + "\n" + strings.TrimSpace(manifestURL)
+}
+
+// loadDriverStatusCache 返回缓存的驱动定义与状态副本；未缓存时 definitions 为 nil。
+func loadDriverStatusCache(key string) ([]driverDefinition, map[string]driverStatusItem, uint64) {
+	driverStatusCacheMu.Lock()
+	defer driverStatusCacheMu.Unlock()
+	items := make(map[string]driverStatusItem)
+	entry, ok := driverStatusCache[key]
+	if !ok {
+		return nil, items, driverStatusCacheGen
+	}
+	for driverType, item := range entry.Items {
+		items[driverType] = item
+	}
+	return entry.Definitions, items, driverStatusCacheGen
+}
+
+func storeDriverStatusCache(key string, definitions []driverDefinition, items map[string]driverStatusItem, generation uint64) {
+	driverStatusCacheMu.Lock()
+	defer driverStatusCacheMu.Unlock()
+	if generation != driverStatusCacheGen {
+		return
+	}
+	driverStatusCache[key] = &driverStatusCacheEntry{Definitions: definitions, Items: items}
+}
+
+// updateDriverStatusCache 写回单个驱动的最新状态；该组合尚未缓存时忽略，等完整列表时再计算。
+func updateDriverStatusCache(key string, item driverStatusItem) {
+	driverStatusCacheMu.Lock()
+	defer driverStatusCacheMu.Unlock()
+	if entry, ok := driverStatusCache[key]; ok {
+		entry.Items[normalizeDriverType(item.Type)] = item
+	}
+}
+
+// invalidateDriverStatus 让指定驱动的缓存状态失效，driverType 为空时清空全部缓存。
+func invalidateDriverStatus(driverType string) {
+	driverStatusCacheMu.Lock()
+	defer driverStatusCacheMu.Unlock()
+	driverStatusCacheGen++
+	normalized := normalizeDriverType(driverType)
+	if normalized == "" {
+		driverStatusCache = make(map[string]*driverStatusCacheEntry)
+		return
+	}
+	for _, entry := range driverStatusCache {
+		delete(entry.Items, normalized)
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"testing"
+)
+
+func driverStatusItemFromResult(t *testing.T, res map[string]interface{}, driverType string) driverStatusItem {
+	t.Helper()
+	for _, item := range res["drivers"].([]driverStatusItem) {
+		if item.Type == driverType {
+			return item
+		}
+	}
+	t.Fatalf("driver %s not found", driverType)
+	return driverStatusItem{}
+}
+
+func TestDriverStatusListCachesUntilInvalidated(t *testing.T) {
+	useDriverNetworkSettings(t, DriverNetworkSettings{Offline: true})
+	invalidateDriverStatus("")
+	t.Cleanup(func() { invalidateDriverStatus("") })
+
+	a := &App{}
+	dir := t.TempDir()
+	first := a.GetDriverStatusList(dir, "")
+	if !first.Success {
+		t.Fatalf("GetDriverStatusList failed: %s", first.Message)
+	}
+	if item := driverStatusItemFromResult(t, first.Data.(map[string]interface{}), "duckdb"); item.DownloadedAt != "" {
+		t.Fatalf("unexpected installed package: %+v", item)
+	}
+
+	meta := installedDriverPackage{FileName: "embedded-go-driver", DownloadedAt: "2026-01-02T03:04:05Z"}
+	if err := writeInstalledDriverPackage(dir, "duckdb", meta); err != nil {
+		t.Fatal(err)
+	}
+	cached := a.GetDriverStatusList(dir, "")
+	if item := driverStatusItemFromResult(t, cached.Data.(map[string]interface{}), "duckdb"); item.DownloadedAt != "" {
+		t.Fatalf("expected cached status, got %+v", item)
+	}
+
+	refreshed := a.RefreshDriverStatus("duckdb", dir, "")
+	if !refreshed.Success || refreshed.Data.(driverStatusItem).DownloadedAt != meta.DownloadedAt {
+		t.Fatalf("RefreshDriverStatus = %+v", refreshed)
+	}
+	after := a.GetDriverStatusList(dir, "")
+	if item := driverStatusItemFromResult(t, after.Data.(map[string]interface{}), "duckdb"); item.DownloadedAt != meta.DownloadedAt {
+		t.Fatalf("expected refreshed status in list, got %+v", item)
+	}
+
+	if res := a.RemoveDriverPackage("duckdb", dir); !res.Success {
+		t.Fatalf("RemoveDriverPackage failed: %s", res.Message)
+	}
+	removed := a.GetDriverStatusList(dir, "")
+	if item := driverStatusItemFromResult(t, removed.Data.(map[string]interface{}), "duckdb"); item.DownloadedAt != "" {
+		t.Fatalf("expected removal to invalidate status, got %+v", item)
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"GoNavi-Wails/internal/geo"
+)
+
+// geoJSONSampleRows 识别空间列时最多检查的行数。
+const geoJSONSampleRows = 100
+
+// detectGeometryColumn 返回第一个能解码为空间值（WKB/EWKB）的列，按 columns 顺序检查。
+func detectGeometryColumn(data []map[string]interface{}, columns []string) string {
+	for _, col := range columns {
+		for i, row := range data {
+			if i >= geoJSONSampleRows {
+				break
+			}
+			val := row[col]
+			if val == nil {
+				continue
+			}
+			if _, err := geo.Decode(val); err == nil {
+				return col
+			}
+			break
+		}
+	}
+	return ""
+}
+
+// writeRowsToGeoJSON 将结果集写为 GeoJSON FeatureCollection：空间列作为 geometry，其余列原样作为 properties。
+// 无法解码的空间值写为 null geometry，不中断导出。
+func writeRowsToGeoJSON(w io.Writer, data []map[string]interface{}, columns []string) error {
+	geomColumn := detectGeometryColumn(data, columns)
+	if geomColumn == "" {
+		return fmt.Errorf("结果中没有可识别的空间列，无法导出 GeoJSON")
+	}
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(`{"type":"FeatureCollection","features":[` + "\n"); err != nil {
+		return err
+	}
+	for i, row := range data {
+		var geometry interface{}
+		if g, err := geo.Decode(row[geomColumn]); err == nil {
+			geometry = g.GeoJSON()
+		}
+		properties := make(map[string]interface{}, len(columns))
+		for _, col := range columns {
+			if col != geomColumn {
+				properties[col] = row[col]
+			}
+		}
+		feature, err := json.Marshal(map[string]interface{}{
+			"type":       "Feature",
+			"geometry":   geometry,
+			"properties": properties,
+		})
+		if err != nil {
+			return fmt.Errorf("第 %d 行无法编码为 GeoJSON：%w", i+1, err)
+		}
+		if i > 0 {
+			if _, err := bw.WriteString(",\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := bw.Write(feature); err != nil {
+			return err
+		}
+	}
+	if _, err := bw.WriteString("\n]}\n"); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteRowsToGeoJSON(t *testing.T) {
+	data := []map[string]interface{}{
+		{"id": int64(1), "name": "a", "geom": "0xE61000000101000000000000000000F03F0000000000000040"},
+		{"id": int64(2), "name": "b", "geom": nil},
+	}
+	var buf bytes.Buffer
+	if err := writeRowsToGeoJSON(&buf, data, []string{"id", "name", "geom"}); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry   map[string]interface{} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, buf.String())
+	}
+	if doc.Type != "FeatureCollection" || len(doc.Features) != 2 {
+		t.Fatalf("unexpected doc: %s", buf.String())
+	}
+	first := doc.Features[0]
+	if first.Geometry["type"] != "Point" || first.Properties["name"] != "a" || first.Properties["geom"] != nil {
+		t.Fatalf("unexpected feature: %+v", first)
+	}
+	if doc.Features[1].Geometry != nil {
+		t.Fatalf("null geometry expected: %+v", doc.Features[1])
+	}
+
+	if err := writeRowsToGeoJSON(&buf, []map[string]interface{}{{"id": int64(1)}}, []string{"id"}); err == nil {
+		t.Fatal("expected error without spatial column")
+	}
+}
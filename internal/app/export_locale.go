@@ -0,0 +1,171 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportLocaleOptions 导出时的本地化格式，作用于 CSV/Markdown/XLSX 的单元格文本；JSON 保持原始值。
+// 字段均为空时输出与未设置时完全一致。
+type ExportLocaleOptions struct {
+	DecimalSeparator   string `json:"decimalSeparator,omitempty"`   // 小数点，如 ","；为 "," 时 CSV 改用 ";" 分隔列
+	ThousandsSeparator string `json:"thousandsSeparator,omitempty"` // 千分位分隔符，如 "," 或 " "
+	DatePattern        string `json:"datePattern,omitempty"`        // 日期格式，如 yyyy/MM/dd
+	DateTimePattern    string `json:"dateTimePattern,omitempty"`    // 日期时间格式，如 yyyy/MM/dd HH:mm:ss
+}
+
+// decimalTextPattern 只匹配带小数部分的数字文本（驱动常以字符串返回 DECIMAL），
+// 纯整数字符串可能是编号、手机号，不做千分位处理。
+var decimalTextPattern = regexp.MustCompile(`^-?\d+\.\d+$`)
+
+var datePatternReplacer = strings.NewReplacer(
+	"yyyy", "2006", "yy", "06",
+	"MM", "01", "dd", "02",
+	"HH", "15", "hh", "03",
+	"mm", "04", "ss", "05",
+	"SSS", "000",
+)
+
+// exportCellFormatter 按 ExportLocaleOptions 将单元格值转为文本。
+type exportCellFormatter struct {
+	decimal        string
+	thousands      string
+	localizeNumber bool
+	dateLayout     string
+	dateTimeLayout string
+}
+
+func newExportCellFormatter(opts ExportLocaleOptions) exportCellFormatter {
+	f := exportCellFormatter{
+		decimal:        opts.DecimalSeparator,
+		thousands:      opts.ThousandsSeparator,
+		localizeNumber: opts.DecimalSeparator != "" || opts.ThousandsSeparator != "",
+		dateLayout:     toGoTimeLayout(opts.DatePattern),
+		dateTimeLayout: toGoTimeLayout(opts.DateTimePattern),
+	}
+	if f.decimal == "" {
+		f.decimal = "."
+	}
+	if f.dateTimeLayout == "" && f.dateLayout != "" {
+		f.dateTimeLayout = f.dateLayout + " 15:04:05"
+	}
+	return f
+}
+
+// toGoTimeLayout 将 yyyy/MM/dd HH:mm:ss 风格转换为 Go 布局；已是 Go 布局（含 2006）时原样返回。
+func toGoTimeLayout(pattern string) string {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" || strings.Contains(pattern, "2006") {
+		return pattern
+	}
+	return datePatternReplacer.Replace(pattern)
+}
+
+func (f exportCellFormatter) csvDelimiter() rune {
+	if f.decimal == "," {
+		return ';'
+	}
+	return ','
+}
+
+func (f exportCellFormatter) format(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return "NULL"
+	case time.Time:
+		if f.dateTimeLayout != "" {
+			return v.Format(f.dateTimeLayout)
+		}
+	case *time.Time:
+		if v != nil && f.dateTimeLayout != "" {
+			return v.Format(f.dateTimeLayout)
+		}
+	case string:
+		if text, ok := f.formatText(v); ok {
+			return text
+		}
+	}
+	if f.localizeNumber {
+		if text, ok := numberText(val); ok {
+			return f.groupNumber(text)
+		}
+	}
+	return formatExportCellText(val)
+}
+
+// formatText 处理以字符串返回的小数与日期时间值。
+func (f exportCellFormatter) formatText(v string) (string, bool) {
+	if f.localizeNumber && decimalTextPattern.MatchString(v) {
+		return f.groupNumber(v), true
+	}
+	if f.dateLayout == "" && f.dateTimeLayout == "" {
+		return "", false
+	}
+	// 仅识别带日期部分的文本，纯时间值保持原样
+	if len(v) < 10 || v[4] != '-' || v[7] != '-' {
+		return "", false
+	}
+	parsed, ok := parseTemporalString(v)
+	if !ok {
+		return "", false
+	}
+	if len(strings.TrimSpace(v)) == 10 && f.dateLayout != "" {
+		return parsed.Format(f.dateLayout), true
+	}
+	if f.dateTimeLayout != "" {
+		return parsed.Format(f.dateTimeLayout), true
+	}
+	return "", false
+}
+
+func numberText(val interface{}) (string, bool) {
+	switch v := val.(type) {
+	case int:
+		return strconv.FormatInt(int64(v), 10), true
+	case int8, int16, int32, int64:
+		return fmt.Sprintf("%d", v), true
+	case uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// groupNumber 对十进制文本（可带负号与小数部分）应用千分位与小数点替换。
+func (f exportCellFormatter) groupNumber(text string) string {
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(text, ".")
+	for _, r := range intPart {
+		if r < '0' || r > '9' {
+			// NaN/Inf 等非常规值
+			return sign + text
+		}
+	}
+	if f.thousands != "" && len(intPart) > 3 {
+		var b strings.Builder
+		head := len(intPart) % 3
+		if head > 0 {
+			b.WriteString(intPart[:head])
+		}
+		for i := head; i < len(intPart); i += 3 {
+			if b.Len() > 0 {
+				b.WriteString(f.thousands)
+			}
+			b.WriteString(intPart[i : i+3])
+		}
+		intPart = b.String()
+	}
+	if !hasFrac {
+		return sign + intPart
+	}
+	return sign + intPart + f.decimal + fracPart
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExportCellFormatterLocalizesNumbersAndDates(t *testing.T) {
+	cells := newExportCellFormatter(ExportLocaleOptions{
+		DecimalSeparator:   ",",
+		ThousandsSeparator: ".",
+		DatePattern:        "yyyy/MM/dd",
+		DateTimePattern:    "dd.MM.yyyy HH:mm",
+	})
+	cases := []struct {
+		in   interface{}
+		want string
+	}{
+		{int64(1234567), "1.234.567"},
+		{-9876.5, "-9.876,5"},
+		{"12345.678", "12.345,678"},
+		{"13800138000", "13800138000"},
+		{"2024-03-05", "2024/03/05"},
+		{"2024-03-05 08:09:10", "05.03.2024 08:09"},
+		{"08:09:10", "08:09:10"},
+		{time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC), "31.12.2024 23:59"},
+		{nil, "NULL"},
+	}
+	for _, c := range cases {
+		if got := cells.format(c.in); got != c.want {
+			t.Errorf("format(%#v) = %q, want %q", c.in, got, c.want)
+		}
+	}
+	if cells.csvDelimiter() != ';' {
+		t.Fatalf("decimal comma should switch CSV delimiter to ';'")
+	}
+}
+
+func TestExportCellFormatterDefaultsUnchanged(t *testing.T) {
+	cells := newExportCellFormatter(ExportLocaleOptions{})
+	for _, v := range []interface{}{1e6, int64(1234567), "12345.678", "2024-03-05", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)} {
+		if got, want := cells.format(v), formatExportCellText(v); got != want {
+			t.Errorf("format(%#v) = %q, want %q", v, got, want)
+		}
+	}
+}
+
+func TestWriteRowsToFileWithLocaleCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.csv")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := []map[string]interface{}{{"name": "a", "amount": 1234.5}}
+	err = writeRowsToFileWithLocale(f, rows, []string{"name", "amount"}, "csv", ExportLocaleOptions{DecimalSeparator: ","})
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(path)
+	if got, want := string(raw), "\ufeffname;amount\na;1234,5\n"; got != want {
+		t.Fatalf("csv = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"sort"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+// readOnlyColumns 返回不能显式写入的列：生成列，以及 GENERATED ALWAYS 标识列（含 SQL Server IDENTITY）。
+// 列名统一转小写，便于与前端提交的键做大小写无关匹配。
+func readOnlyColumns(defs []connection.ColumnDefinition) map[string]struct{} {
+	result := make(map[string]struct{})
+	for _, def := range defs {
+		if def.Generated != "" || strings.EqualFold(def.Identity, "ALWAYS") {
+			result[strings.ToLower(def.Name)] = struct{}{}
+		}
+	}
+	return result
+}
+
+// stripReadOnlyColumns 从新增与更新的值中去掉只读列，返回新的 ChangeSet 与被跳过的列名。
+// 更新行去掉只读列后若无剩余值则整行跳过；WHERE 条件（Keys）与删除不受影响。
+func stripReadOnlyColumns(changes connection.ChangeSet, readOnly map[string]struct{}) (connection.ChangeSet, []string) {
+	if len(readOnly) == 0 {
+		return changes, nil
+	}
+	skipped := make(map[string]struct{})
+	filter := func(values map[string]interface{}) map[string]interface{} {
+		kept := make(map[string]interface{}, len(values))
+		for k, v := range values {
+			if _, ok := readOnly[strings.ToLower(k)]; ok {
+				skipped[k] = struct{}{}
+				continue
+			}
+			kept[k] = v
+		}
+		return kept
+	}
+
+	result := connection.ChangeSet{Deletes: changes.Deletes}
+	for _, row := range changes.Inserts {
+		result.Inserts = append(result.Inserts, filter(row))
+	}
+	for _, upd := range changes.Updates {
+		values := filter(upd.Values)
+		if len(values) == 0 {
+			continue
+		}
+		result.Updates = append(result.Updates, connection.UpdateRow{Keys: upd.Keys, Values: values})
+	}
+
+	names := make([]string, 0, len(skipped))
+	for k := range skipped {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return result, names
+}
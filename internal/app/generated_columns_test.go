@@ -0,0 +1,42 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestStripReadOnlyColumns(t *testing.T) {
+	defs := []connection.ColumnDefinition{
+		{Name: "id", Identity: "ALWAYS"},
+		{Name: "seq", Identity: "BY DEFAULT"},
+		{Name: "total", Generated: "STORED"},
+		{Name: "qty"},
+	}
+	changes := connection.ChangeSet{
+		Inserts: []map[string]interface{}{{"ID": 1, "seq": 2, "total": 30, "qty": 3}},
+		Updates: []connection.UpdateRow{
+			{Keys: map[string]interface{}{"id": 1}, Values: map[string]interface{}{"total": 40}},
+			{Keys: map[string]interface{}{"id": 2}, Values: map[string]interface{}{"total": 40, "qty": 4}},
+		},
+		Deletes: []map[string]interface{}{{"id": 3}},
+	}
+
+	got, skipped := stripReadOnlyColumns(changes, readOnlyColumns(defs))
+	if !reflect.DeepEqual(skipped, []string{"ID", "total"}) {
+		t.Fatalf("跳过列不符合预期：%v", skipped)
+	}
+	if !reflect.DeepEqual(got.Inserts[0], map[string]interface{}{"seq": 2, "qty": 3}) {
+		t.Fatalf("新增行未剔除只读列：%v", got.Inserts[0])
+	}
+	if len(got.Updates) != 1 || !reflect.DeepEqual(got.Updates[0].Values, map[string]interface{}{"qty": 4}) {
+		t.Fatalf("更新行处理不符合预期：%+v", got.Updates)
+	}
+	if len(got.Deletes) != 1 {
+		t.Fatalf("删除不应受影响：%+v", got.Deletes)
+	}
+	if _, ok := changes.Inserts[0]["total"]; !ok {
+		t.Fatalf("不应修改原始 ChangeSet")
+	}
+}
@@ -0,0 +1,446 @@
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const defaultImportBatchRows = 500
+
+// ImportOptions 导入执行选项。
+type ImportOptions struct {
+	// ImportID 非空时登记为可取消任务，CancelQuery(ImportID) 会中止导入并回滚未提交的数据。
+	ImportID  string            `json:"importId,omitempty"`
+	BatchRows int               `json:"batchRows,omitempty"` // 每条多行 INSERT 的行数，默认 500，会按方言的参数个数上限下调
+	File      ImportFileOptions `json:"file,omitempty"`      // Excel 工作表与表头行
+	// Mapping 列映射与取值转换；为空时文件列名需与表列名一致。
+	Mapping *ImportMapping `json:"mapping,omitempty"`
+}
+
+// importDialect 参数化批量导入的方言差异。
+type importDialect struct {
+	placeholder func(n int) string // 第 n 个参数（1 起）的占位符
+	maxParams   int                // 单条语句的参数个数上限
+	multiRow    bool               // 是否支持 INSERT ... VALUES (...), (...)
+	begin       string             // 在专用会话上开启事务的语句，空表示不支持显式事务
+	commit      string
+	rollback    string
+}
+
+// resolveImportDialect 返回方言的批量导入参数；不支持参数化导入的类型返回 false，回退为逐行字面量 INSERT。
+func resolveImportDialect(dbType string) (importDialect, bool) {
+	question := func(int) string { return "?" }
+	switch dbType {
+	case "mysql", "mariadb", "diros", "sphinx":
+		return importDialect{placeholder: question, maxParams: 65535, multiRow: true, begin: "START TRANSACTION", commit: "COMMIT", rollback: "ROLLBACK"}, true
+	case "postgres", "kingbase", "highgo", "vastbase", "duckdb":
+		return importDialect{placeholder: func(n int) string { return fmt.Sprintf("$%d", n) }, maxParams: 65535, multiRow: true, begin: "BEGIN", commit: "COMMIT", rollback: "ROLLBACK"}, true
+	case "sqlite":
+		return importDialect{placeholder: question, maxParams: 32766, multiRow: true, begin: "BEGIN IMMEDIATE", commit: "COMMIT", rollback: "ROLLBACK"}, true
+	case "sqlserver":
+		return importDialect{placeholder: func(n int) string { return fmt.Sprintf("@p%d", n) }, maxParams: 2000, multiRow: true, begin: "BEGIN TRANSACTION", commit: "COMMIT TRANSACTION", rollback: "ROLLBACK TRANSACTION"}, true
+	case "oracle", "dameng":
+		// 驱动在非事务连接上逐条自动提交，且旧版本不支持多行 VALUES
+		return importDialect{placeholder: func(n int) string { return fmt.Sprintf(":%d", n) }, maxParams: 65535}, true
+	case "clickhouse":
+		return importDialect{placeholder: question, maxParams: 65535, multiRow: true}, true
+	}
+	return importDialect{}, false
+}
+
+// rowsPerStatement 在不超过参数个数上限的前提下，单条 INSERT 包含的行数。
+func (d importDialect) rowsPerStatement(batchRows int, columnCount int) int {
+	if !d.multiRow || columnCount == 0 {
+		return 1
+	}
+	return max(1, min(batchRows, d.maxParams/columnCount))
+}
+
+// buildImportBatchInsert 生成 rowCount 行的参数化 INSERT。
+func buildImportBatchInsert(dbType string, dialect importDialect, tableName string, columns []string, rowCount int) string {
+	quotedCols := make([]string, len(columns))
+	for i, c := range columns {
+		quotedCols[i] = quoteIdentByType(dbType, c)
+	}
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(quoteQualifiedIdentByType(dbType, tableName))
+	sb.WriteString(" (")
+	sb.WriteString(strings.Join(quotedCols, ", "))
+	sb.WriteString(") VALUES ")
+	n := 0
+	for r := 0; r < rowCount; r++ {
+		if r > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteByte('(')
+		for c := range columns {
+			if c > 0 {
+				sb.WriteString(", ")
+			}
+			n++
+			sb.WriteString(dialect.placeholder(n))
+		}
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
+
+// importParamValue 将导入文件中的值转换为绑定参数，语义与 formatImportSQLValue 生成的字面量一致。
+func importParamValue(dbType, columnType string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if isTemporalColumnType(dbType, columnType) {
+		if t, ok := value.(time.Time); ok {
+			value = t.Format("2006-01-02 15:04:05")
+		}
+		return normalizeImportTemporalValue(dbType, columnType, fmt.Sprintf("%v", value))
+	}
+	switch val := value.(type) {
+	case bool:
+		if val {
+			return int64(1)
+		}
+		return int64(0)
+	case float32:
+		if math.IsNaN(float64(val)) || math.IsInf(float64(val), 0) {
+			return nil
+		}
+		return val
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return nil
+		}
+		return val
+	case time.Time:
+		return val.Format("2006-01-02 15:04:05")
+	case string:
+		if (dbType == "mysql" || dbType == "diros") && isMySQLHexLiteral(val) {
+			digits := val[2:]
+			if len(digits)%2 == 1 {
+				digits = "0" + digits
+			}
+			if decoded, err := hex.DecodeString(digits); err == nil {
+				return decoded
+			}
+		}
+		return val
+	case map[string]interface{}, []interface{}:
+		if text, err := json.Marshal(val); err == nil {
+			return string(text)
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, []byte:
+		return val
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// ImportDataWithOptions 按批执行参数化多行 INSERT 导入数据：值由驱动绑定而不拼接进 SQL；
+// 支持事务的数据库在专用连接上整体提交，任一批失败或被取消时回滚全部数据。
+func (a *App) ImportDataWithOptions(config connection.ConnectionConfig, dbName, tableName, filePath string, options ImportOptions) connection.QueryResult {
+	job, done, ok := a.loadImportJob(config, dbName, tableName, filePath, options)
+	if !ok {
+		return done
+	}
+	if job.querier == nil {
+		return a.importDataByStatements(job.runConfig, job.dbInst, tableName, job.plan.columns, job.plan.rows, job.plan.columnTypeMap)
+	}
+
+	ctx := context.Background()
+	if importID := strings.TrimSpace(options.ImportID); importID != "" {
+		var tracked *runningQuery
+		var err error
+		ctx, tracked, err = a.registerQuery(ctx, importID, job.runConfig)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		defer a.unregisterQuery(tracked)
+	}
+	summary, err := a.runImportJob(ctx, job)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return importBatchResult(summary, len(job.plan.rows))
+}
+
+// importJob 解析并转换完成、待写入的导入任务，ImportDataWithOptions 与导入计划共用。
+type importJob struct {
+	runConfig connection.ConnectionConfig
+	dbInst    db.Database
+	querier   db.ParamQuerier // 为空时数据源不支持参数化导入，回退为逐行字面量 INSERT
+	plan      importBatchPlan
+}
+
+// loadImportJob 读取并转换导入文件。ok 为 false 时 done 即应直接返回的结果（出错或没有数据）。
+func (a *App) loadImportJob(config connection.ConnectionConfig, dbName, tableName, filePath string, options ImportOptions) (job importJob, done connection.QueryResult, ok bool) {
+	if err := ensureWritable(config, "导入数据"); err != nil {
+		return job, connection.QueryResult{Success: false, Message: err.Error()}, false
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return job, connection.QueryResult{Success: false, Message: err.Error()}, false
+	}
+	dbType := resolveDDLDBType(runConfig)
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	prepared, err := prepareImport(dbInst, dbType, schemaName, pureTableName, filePath, options.File, options.Mapping)
+	if err != nil {
+		return job, connection.QueryResult{Success: false, Message: err.Error()}, false
+	}
+	if len(prepared.errors) > 0 {
+		return job, connection.QueryResult{
+			Success: false,
+			Message: fmt.Sprintf("数据转换失败，未写入任何数据：%s", prepared.errors[0]),
+			Data:    map[string]interface{}{"errorLogs": prepared.errors},
+		}, false
+	}
+	if len(prepared.rows) == 0 {
+		return job, connection.QueryResult{Success: true, Message: "No data to import"}, false
+	}
+
+	batchRows := options.BatchRows
+	if batchRows <= 0 {
+		batchRows = defaultImportBatchRows
+	}
+	job = importJob{runConfig: runConfig, dbInst: dbInst, plan: importBatchPlan{
+		dbType:        dbType,
+		tableName:     tableName,
+		columns:       prepared.columns,
+		rows:          prepared.rows,
+		columnTypeMap: prepared.columnTypeMap,
+		batchRows:     batchRows,
+	}}
+	dialect, supported := resolveImportDialect(dbType)
+	if querier, isParamQuerier := dbInst.(db.ParamQuerier); supported && isParamQuerier {
+		job.plan.dialect = dialect
+		job.querier = querier
+	}
+	return job, connection.QueryResult{}, true
+}
+
+// runImportJob 在 SQLite 写锁内逐批写入参数化导入任务。
+func (a *App) runImportJob(ctx context.Context, job importJob) (summary importBatchSummary, err error) {
+	err = a.withSQLiteWriteLock(job.runConfig, func() error {
+		summary = a.runImportBatches(ctx, job.dbInst, job.querier, job.plan)
+		return nil
+	})
+	return summary, err
+}
+
+// importBatchResult 汇总导入结果。事务模式下任一批失败都会回滚全部，此时不能当作成功返回。
+func importBatchResult(summary importBatchSummary, total int) connection.QueryResult {
+	message := fmt.Sprintf("Imported: %d, Failed: %d", summary.imported, total-summary.imported)
+	rolledBack := summary.transactional && len(summary.errorLogs) > 0
+	if rolledBack {
+		message = fmt.Sprintf("导入失败，事务已回滚，未写入任何行：%s", summary.errorLogs[0])
+	}
+	if summary.canceled {
+		message = fmt.Sprintf("导入已取消，已写入 %d 行", summary.imported)
+	}
+	result := map[string]interface{}{
+		"success":      summary.imported,
+		"failed":       total - summary.imported,
+		"total":        total,
+		"errorLogs":    summary.errorLogs,
+		"errorSummary": message,
+		"transaction":  summary.transactional,
+		"canceled":     summary.canceled,
+	}
+	return connection.QueryResult{Success: !rolledBack, Data: result, Message: message}
+}
+
+// importPlanReport 把参数化导入的结果转换为计划报告，每条语句对应一个批次。事务回滚时已执行的批次记为跳过。
+func importPlanReport(statements []string, perStatement int, summary importBatchSummary) SQLPlanReport {
+	report := SQLPlanReport{
+		Total:    len(statements),
+		Affected: int64(summary.imported),
+		Results:  make([]SQLPlanStatementResult, 0, len(statements)),
+	}
+	failed := -1
+	if len(summary.errorLogs) > 0 {
+		failed = summary.failedRow / perStatement
+	}
+	for i, stmt := range statements {
+		item := SQLPlanStatementResult{Index: i, SQL: stmt}
+		start := i * perStatement
+		switch {
+		case i == failed:
+			item.Error = summary.errorLogs[0]
+			report.Failed++
+		case start < summary.imported:
+			item.Success = true
+			item.AffectedRows = int64(min(perStatement, summary.imported-start))
+			report.Executed++
+		default:
+			item.Skipped = true
+			report.Skipped++
+		}
+		report.Results = append(report.Results, item)
+	}
+	report.Success = report.Failed == 0 && report.Skipped == 0
+	return report
+}
+
+type importBatchPlan struct {
+	dbType        string
+	dialect       importDialect
+	tableName     string
+	columns       []string
+	rows          []map[string]interface{}
+	columnTypeMap map[string]string
+	batchRows     int
+}
+
+// statements 按批次生成与 runImportBatches 实际执行一致的参数化 INSERT，用于计划预览。
+func (p importBatchPlan) statements() []string {
+	total := len(p.rows)
+	perStatement := p.dialect.rowsPerStatement(p.batchRows, len(p.columns))
+	fullStatement := buildImportBatchInsert(p.dbType, p.dialect, p.tableName, p.columns, perStatement)
+	out := make([]string, 0, (total+perStatement-1)/perStatement)
+	for start := 0; start < total; start += perStatement {
+		if n := min(perStatement, total-start); n != perStatement {
+			out = append(out, buildImportBatchInsert(p.dbType, p.dialect, p.tableName, p.columns, n))
+			continue
+		}
+		out = append(out, fullStatement)
+	}
+	return out
+}
+
+type importBatchSummary struct {
+	imported      int // 已提交（或无事务时已写入）的行数
+	failedRow     int // 失败批次的首行下标（0 起），仅在 errorLogs 非空时有效
+	errorLogs     []string
+	transactional bool
+	canceled      bool
+}
+
+// runImportBatches 逐批写入。能开启事务时任一批失败即回滚全部；否则在第一个失败的批次处停止，之前的批次已写入。
+func (a *App) runImportBatches(ctx context.Context, dbInst db.Database, querier db.ParamQuerier, plan importBatchPlan) (summary importBatchSummary) {
+	exec := querier.ExecParams
+	if opener, ok := dbInst.(db.SessionOpener); ok && plan.dialect.begin != "" {
+		session, err := opener.OpenSession(ctx)
+		if err == nil {
+			defer session.Close()
+			if _, err = session.ExecContext(ctx, plan.dialect.begin); err == nil {
+				exec = session.ExecParams
+				summary.transactional = true
+				defer func() {
+					end := plan.dialect.commit
+					if len(summary.errorLogs) > 0 || summary.canceled {
+						end = plan.dialect.rollback
+						summary.imported = 0
+					}
+					// 取消后 ctx 已失效，结束事务改用独立 context
+					endCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer cancel()
+					if _, endErr := session.ExecContext(endCtx, end); endErr != nil {
+						logger.Error(endErr, "导入结束事务失败：表=%s 语句=%s", plan.tableName, end)
+						if end == plan.dialect.commit {
+							summary.errorLogs = append(summary.errorLogs, fmt.Sprintf("提交事务失败：%s", endErr.Error()))
+							summary.imported = 0
+						}
+					}
+				}()
+			}
+		}
+		if err != nil {
+			logger.Warnf("导入无法开启事务，改为逐批提交：表=%s 原因=%v", plan.tableName, err)
+		}
+	}
+
+	total := len(plan.rows)
+	perStatement := plan.dialect.rowsPerStatement(plan.batchRows, len(plan.columns))
+	fullStatement := buildImportBatchInsert(plan.dbType, plan.dialect, plan.tableName, plan.columns, perStatement)
+	args := make([]interface{}, 0, perStatement*len(plan.columns))
+	written := 0
+	for start := 0; start < total; start += perStatement {
+		if ctx.Err() != nil {
+			summary.canceled = true
+			break
+		}
+		end := min(start+perStatement, total)
+		query := fullStatement
+		if end-start != perStatement {
+			query = buildImportBatchInsert(plan.dbType, plan.dialect, plan.tableName, plan.columns, end-start)
+		}
+		args = args[:0]
+		for _, row := range plan.rows[start:end] {
+			for _, col := range plan.columns {
+				args = append(args, importParamValue(plan.dbType, plan.columnTypeMap[normalizeColumnName(col)], row[col]))
+			}
+		}
+		if _, err := exec(ctx, query, args); err != nil {
+			if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+				summary.canceled = true
+				break
+			}
+			summary.failedRow = start
+			summary.errorLogs = append(summary.errorLogs, fmt.Sprintf("Rows %d-%d: %s", start+1, end, err.Error()))
+			break
+		}
+		written = end
+		summary.imported = written
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "import:progress", map[string]interface{}{
+				"current": written,
+				"total":   total,
+				"success": written,
+				"errors":  0,
+			})
+		}
+	}
+	return summary
+}
+
+// importDataByStatements 不支持参数绑定的数据源逐行执行字面量 INSERT。
+func (a *App) importDataByStatements(runConfig connection.ConnectionConfig, dbInst db.Database, tableName string, columns []string, rows []map[string]interface{}, columnTypeMap map[string]string) connection.QueryResult {
+	totalRows := len(rows)
+	successCount := 0
+	var errorLogs []string
+
+	statements := buildImportInsertStatements(resolveDDLDBType(runConfig), tableName, columns, rows, columnTypeMap)
+	for idx, query := range statements {
+		err := a.withSQLiteWriteLock(runConfig, func() error {
+			_, execErr := dbInst.Exec(query)
+			return execErr
+		})
+		if err != nil {
+			errorLogs = append(errorLogs, fmt.Sprintf("Row %d: %s", idx+1, err.Error()))
+		} else {
+			successCount++
+		}
+
+		// 每 10 行发送一次进度事件
+		if a.ctx != nil && ((idx+1)%10 == 0 || idx == totalRows-1) {
+			runtime.EventsEmit(a.ctx, "import:progress", map[string]interface{}{
+				"current": idx + 1,
+				"total":   totalRows,
+				"success": successCount,
+				"errors":  len(errorLogs),
+			})
+		}
+	}
+
+	result := map[string]interface{}{
+		"success":      successCount,
+		"failed":       len(errorLogs),
+		"total":        totalRows,
+		"errorLogs":    errorLogs,
+		"errorSummary": fmt.Sprintf("Imported: %d, Failed: %d", successCount, len(errorLogs)),
+	}
+	return connection.QueryResult{Success: true, Data: result, Message: fmt.Sprintf("Imported: %d, Failed: %d", successCount, len(errorLogs))}
+}
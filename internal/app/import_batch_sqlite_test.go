@@ -0,0 +1,54 @@
+//go:build gonavi_full_drivers || gonavi_sqlite_driver
+
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+func TestRunImportBatchesRollsBackSQLite(t *testing.T) {
+	sqliteDB := &db.SQLiteDB{}
+	if err := sqliteDB.Connect(connection.ConnectionConfig{Type: "sqlite", Host: filepath.Join(t.TempDir(), "import.sqlite")}); err != nil {
+		t.Fatal(err)
+	}
+	defer sqliteDB.Close()
+	if _, err := sqliteDB.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	dialect, _ := resolveImportDialect("sqlite")
+	plan := importBatchPlan{
+		dbType:    "sqlite",
+		dialect:   dialect,
+		tableName: "users",
+		columns:   []string{"id", "name"},
+		batchRows: 2,
+		rows: []map[string]interface{}{
+			{"id": "1", "name": "a"}, {"id": "2", "name": "b'c"}, {"id": "3", "name": "d"}, {"id": "3", "name": "e"},
+		},
+	}
+	a := NewApp()
+	// 第二批中的重复主键使整个导入回滚
+	summary := a.runImportBatches(context.Background(), sqliteDB, sqliteDB, plan)
+	if !summary.transactional || summary.imported != 0 || len(summary.errorLogs) != 1 {
+		t.Fatalf("summary = %+v", summary)
+	}
+	rows, _, err := sqliteDB.Query("SELECT COUNT(*) AS n FROM users")
+	if err != nil || rows[0]["n"] != int64(0) {
+		t.Fatalf("回滚后应无数据：%v %v", rows, err)
+	}
+
+	plan.rows = plan.rows[:3]
+	if summary := a.runImportBatches(context.Background(), sqliteDB, sqliteDB, plan); summary.imported != 3 || len(summary.errorLogs) != 0 {
+		t.Fatalf("summary = %+v", summary)
+	}
+	rows, _, err = sqliteDB.Query("SELECT name FROM users WHERE id = 2")
+	if err != nil || rows[0]["name"] != "b'c" {
+		t.Fatalf("rows = %v %v", rows, err)
+	}
+}
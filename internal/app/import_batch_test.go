@@ -0,0 +1,164 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type importFakeDB struct {
+	db.Database
+	queries []string
+	args    [][]interface{}
+	failAt  int // 第几次执行返回错误（1 起），0 为不失败
+}
+
+func (f *importFakeDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	return []connection.ColumnDefinition{{Name: "id", Type: "int"}, {Name: "name", Type: "varchar(20)"}, {Name: "created_at", Type: "datetime"}}, nil
+}
+
+func (f *importFakeDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return nil, nil, errors.New("unexpected query")
+}
+
+func (f *importFakeDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	f.queries = append(f.queries, query)
+	f.args = append(f.args, append([]interface{}(nil), args...))
+	if f.failAt == len(f.queries) {
+		return 0, errors.New("Duplicate entry '3' for key 'PRIMARY'")
+	}
+	return int64(len(args) / 3), nil
+}
+
+func TestBuildImportBatchInsert(t *testing.T) {
+	columns := []string{"id", "name"}
+	pg, _ := resolveImportDialect("postgres")
+	if got := buildImportBatchInsert("postgres", pg, "public.users", columns, 2); got != `INSERT INTO "public"."users" ("id", "name") VALUES ($1, $2), ($3, $4)` {
+		t.Fatalf("postgres = %s", got)
+	}
+	mssql, _ := resolveImportDialect("sqlserver")
+	if got := buildImportBatchInsert("sqlserver", mssql, "users", columns, 1); got != "INSERT INTO [users] ([id], [name]) VALUES (@p1, @p2)" {
+		t.Fatalf("sqlserver = %s", got)
+	}
+	// SQL Server 单条语句最多约 2100 个参数，Oracle 不使用多行 VALUES
+	if n := mssql.rowsPerStatement(500, 10); n != 200 {
+		t.Fatalf("sqlserver rows = %d", n)
+	}
+	oracle, _ := resolveImportDialect("oracle")
+	if n := oracle.rowsPerStatement(500, 10); n != 1 {
+		t.Fatalf("oracle rows = %d", n)
+	}
+	if _, ok := resolveImportDialect("mongodb"); ok {
+		t.Fatalf("mongodb 不应走参数化导入")
+	}
+}
+
+func TestImportParamValue(t *testing.T) {
+	if v := importParamValue("mysql", "varchar(20)", "O'Brien"); v != "O'Brien" {
+		t.Fatalf("string = %#v", v)
+	}
+	if v := importParamValue("mysql", "varbinary(4)", "0xABC"); string(v.([]byte)) != "\x0a\xbc" {
+		t.Fatalf("hex = %#v", v)
+	}
+	if v := importParamValue("postgres", "boolean", true); v != int64(1) {
+		t.Fatalf("bool = %#v", v)
+	}
+	if v := importParamValue("postgres", "jsonb", map[string]interface{}{"a": 1.0}); v != `{"a":1}` {
+		t.Fatalf("json = %#v", v)
+	}
+}
+
+func TestImportDataWithOptionsBatches(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.csv")
+	lines := []string{"id,name,created_at"}
+	for i := 1; i <= 5; i++ {
+		lines = append(lines, strings.Join([]string{string(rune('0' + i)), "n'" + string(rune('a'+i)), "2024-01-02 03:04:05"}, ","))
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	fake := &importFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	res := a.ImportDataWithOptions(config, "shop", "users", path, ImportOptions{BatchRows: 2})
+	data := res.Data.(map[string]interface{})
+	if !res.Success || data["success"] != 5 || data["failed"] != 0 || len(fake.queries) != 3 {
+		t.Fatalf("result = %+v queries = %v", res, fake.queries)
+	}
+	if fake.queries[0] != "INSERT INTO `users` (`id`, `name`, `created_at`) VALUES (?, ?, ?), (?, ?, ?)" ||
+		fake.queries[2] != "INSERT INTO `users` (`id`, `name`, `created_at`) VALUES (?, ?, ?)" {
+		t.Fatalf("queries = %v", fake.queries)
+	}
+	if len(fake.args[0]) != 6 || fake.args[0][1] != "n'b" {
+		t.Fatalf("args = %#v", fake.args[0])
+	}
+
+	// 没有事务时在失败的批次处停止，之前的批次已写入
+	fake.queries, fake.args, fake.failAt = nil, nil, 2
+	res = a.ImportDataWithOptions(config, "shop", "users", path, ImportOptions{BatchRows: 2})
+	data = res.Data.(map[string]interface{})
+	logs := data["errorLogs"].([]string)
+	if data["success"] != 2 || data["failed"] != 3 || len(logs) != 1 || !strings.HasPrefix(logs[0], "Rows 3-4:") || len(fake.queries) != 2 {
+		t.Fatalf("result = %+v", data)
+	}
+}
+
+func TestPreviewImportDataPlanRunsBatches(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.csv")
+	lines := []string{"id,name,created_at"}
+	for i := 1; i <= 5; i++ {
+		lines = append(lines, strings.Join([]string{string(rune('0' + i)), "n" + string(rune('a'+i)), "2024-01-02 03:04:05"}, ","))
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	fake := &importFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	res := a.PreviewImportDataPlan(config, "shop", "users", path, ImportOptions{BatchRows: 2})
+	preview, _ := res.Data.(SQLPlanPreview)
+	if !res.Success || preview.StatementCount != 3 || !preview.StopOnError ||
+		preview.Statements[0] != "INSERT INTO `users` (`id`, `name`, `created_at`) VALUES (?, ?, ?), (?, ?, ?)" {
+		t.Fatalf("preview = %+v", res)
+	}
+
+	// 确认后执行的正是预览中的参数化批次
+	res = a.ConfirmSQLPlan(preview.PlanID, "execute")
+	report, _ := res.Data.(SQLPlanReport)
+	if !res.Success || report.Executed != 3 || report.Affected != 5 || strings.Join(fake.queries, "\n") != strings.Join(preview.Statements, "\n") {
+		t.Fatalf("report = %+v queries = %v", report, fake.queries)
+	}
+
+	fake.queries, fake.args, fake.failAt = nil, nil, 2
+	preview = a.PreviewImportDataPlan(config, "shop", "users", path, ImportOptions{BatchRows: 2}).Data.(SQLPlanPreview)
+	report = a.ConfirmSQLPlan(preview.PlanID, "execute").Data.(SQLPlanReport)
+	if report.Success || report.Executed != 1 || report.Failed != 1 || report.Skipped != 1 || !strings.HasPrefix(report.Results[1].Error, "Rows 3-4:") {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestImportBatchResultReportsRollback(t *testing.T) {
+	res := importBatchResult(importBatchSummary{transactional: true, errorLogs: []string{"Rows 3-4: duplicate"}}, 5)
+	if res.Success || !strings.Contains(res.Message, "回滚") {
+		t.Fatalf("rolled back import should fail: %+v", res)
+	}
+	res = importBatchResult(importBatchSummary{imported: 2, errorLogs: []string{"Rows 3-4: duplicate"}}, 5)
+	if !res.Success || res.Message != "Imported: 2, Failed: 3" {
+		t.Fatalf("partial import without transaction keeps written rows: %+v", res)
+	}
+}
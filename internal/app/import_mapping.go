@@ -0,0 +1,289 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+const (
+	defaultImportPreviewRows = 20
+	maxImportPreviewRows     = 200
+	maxImportConvertErrors   = 100
+)
+
+// ImportMapping 导入文件列到表列的映射与取值转换规则。
+type ImportMapping struct {
+	// Columns 显式映射；未列出的文件列按同名（忽略大小写）匹配表列，表中没有同名列时跳过。
+	Columns     []ImportColumnMapping  `json:"columns,omitempty"`
+	NullToken   string                 `json:"nullToken,omitempty"`   // 视为 NULL 的单元格文本，默认 "NULL"
+	EmptyAsNull bool                   `json:"emptyAsNull,omitempty"` // 空字符串视为 NULL
+	Trim        bool                   `json:"trim,omitempty"`        // 去除文本首尾空白
+	DateFormat  string                 `json:"dateFormat,omitempty"`  // 日期时间列的源格式，如 dd/MM/yyyy HH:mm:ss，也可直接使用 Go 布局
+	Defaults    map[string]interface{} `json:"defaults,omitempty"`    // 表列 → 值为 NULL 或文件中没有对应列时使用的默认值
+}
+
+// ImportColumnMapping 单个文件列的映射。
+type ImportColumnMapping struct {
+	Source string `json:"source"`
+	Target string `json:"target,omitempty"` // 为空时映射到同名表列
+	Skip   bool   `json:"skip,omitempty"`
+}
+
+// resolvedImportColumn 解析后的列映射；Source 为空表示仅由默认值填充的表列。
+type resolvedImportColumn struct {
+	Source  string `json:"source"`
+	Target  string `json:"target,omitempty"`
+	Skipped bool   `json:"skipped,omitempty"`
+	Note    string `json:"note,omitempty"`
+}
+
+// preparedImport 解析并按映射转换后的导入数据。
+type preparedImport struct {
+	rows          []map[string]interface{}
+	columns       []string // 写入的表列
+	fileColumns   []string
+	tableColumns  []connection.ColumnDefinition
+	columnTypeMap map[string]string
+	mappings      []resolvedImportColumn
+	errors        []string // 值转换错误，存在时不应写入
+}
+
+// prepareImport 解析导入文件；mapping 为 nil 时保持文件列名原样写入。
+func prepareImport(dbInst db.Database, dbType, schemaName, tableName, filePath string, fileOptions ImportFileOptions, mapping *ImportMapping) (preparedImport, error) {
+	nullToken := "NULL"
+	if mapping != nil {
+		// 由映射统一处理 NULL 标记，以便先去除空白再比较
+		nullToken = ""
+	}
+	rows, fileColumns, err := parseImportFileWithOptions(filePath, nullToken, fileOptions)
+	if err != nil {
+		return preparedImport{}, err
+	}
+	prepared := preparedImport{rows: rows, columns: fileColumns, fileColumns: fileColumns, columnTypeMap: map[string]string{}}
+	if defs, colErr := dbInst.GetColumns(schemaName, tableName); colErr == nil {
+		prepared.tableColumns = defs
+		prepared.columnTypeMap = buildImportColumnTypeMap(defs)
+	}
+	if mapping == nil {
+		return prepared, nil
+	}
+
+	mappings, targets, err := resolveImportMapping(fileColumns, prepared.tableColumns, *mapping)
+	if err != nil {
+		return preparedImport{}, err
+	}
+	prepared.mappings = mappings
+	prepared.columns = targets
+	prepared.rows, prepared.errors = applyImportMapping(rows, mappings, *mapping, dbType, prepared.columnTypeMap)
+	return prepared, nil
+}
+
+// resolveImportMapping 确定每个文件列写入的表列。表结构未知时不校验目标列是否存在。
+func resolveImportMapping(fileColumns []string, tableColumns []connection.ColumnDefinition, mapping ImportMapping) ([]resolvedImportColumn, []string, error) {
+	tableNames := make(map[string]string, len(tableColumns))
+	for _, def := range tableColumns {
+		tableNames[normalizeColumnName(def.Name)] = def.Name
+	}
+	lookupTarget := func(name string) (string, bool) {
+		if len(tableNames) == 0 {
+			return strings.TrimSpace(name), true
+		}
+		actual, ok := tableNames[normalizeColumnName(name)]
+		return actual, ok
+	}
+
+	fileNames := make(map[string]bool, len(fileColumns))
+	for _, col := range fileColumns {
+		fileNames[normalizeColumnName(col)] = true
+	}
+	explicit := make(map[string]ImportColumnMapping, len(mapping.Columns))
+	for _, m := range mapping.Columns {
+		key := normalizeColumnName(m.Source)
+		if !fileNames[key] {
+			return nil, nil, fmt.Errorf("导入文件中没有列 %s", m.Source)
+		}
+		explicit[key] = m
+	}
+
+	resolved := make([]resolvedImportColumn, 0, len(fileColumns))
+	targets := make([]string, 0, len(fileColumns))
+	used := make(map[string]string)
+	addTarget := func(source, target string) error {
+		key := normalizeColumnName(target)
+		if previous, dup := used[key]; dup {
+			return fmt.Errorf("文件列 %s 与 %s 映射到了同一表列 %s", previous, source, target)
+		}
+		used[key] = source
+		targets = append(targets, target)
+		return nil
+	}
+	for _, col := range fileColumns {
+		if strings.TrimSpace(col) == "" {
+			continue
+		}
+		m, listed := explicit[normalizeColumnName(col)]
+		switch {
+		case listed && m.Skip:
+			resolved = append(resolved, resolvedImportColumn{Source: col, Skipped: true, Note: "已跳过"})
+			continue
+		case listed:
+			name := strings.TrimSpace(m.Target)
+			if name == "" {
+				name = col
+			}
+			target, ok := lookupTarget(name)
+			if !ok {
+				return nil, nil, fmt.Errorf("表中没有列 %s", name)
+			}
+			if err := addTarget(col, target); err != nil {
+				return nil, nil, err
+			}
+			resolved = append(resolved, resolvedImportColumn{Source: col, Target: target})
+		default:
+			target, ok := lookupTarget(col)
+			if !ok {
+				resolved = append(resolved, resolvedImportColumn{Source: col, Skipped: true, Note: "表中没有同名列，已跳过"})
+				continue
+			}
+			if _, dup := used[normalizeColumnName(target)]; dup {
+				resolved = append(resolved, resolvedImportColumn{Source: col, Skipped: true, Note: "同名表列已被其他文件列占用，已跳过"})
+				continue
+			}
+			_ = addTarget(col, target)
+			resolved = append(resolved, resolvedImportColumn{Source: col, Target: target})
+		}
+	}
+
+	defaultNames := make([]string, 0, len(mapping.Defaults))
+	for name := range mapping.Defaults {
+		defaultNames = append(defaultNames, name)
+	}
+	sort.Strings(defaultNames)
+	for _, name := range defaultNames {
+		target, ok := lookupTarget(name)
+		if !ok {
+			return nil, nil, fmt.Errorf("默认值指定的列 %s 在表中不存在", name)
+		}
+		if _, covered := used[normalizeColumnName(target)]; covered {
+			continue
+		}
+		used[normalizeColumnName(target)] = ""
+		targets = append(targets, target)
+		resolved = append(resolved, resolvedImportColumn{Target: target, Note: "使用默认值"})
+	}
+	if len(targets) == 0 {
+		return nil, nil, fmt.Errorf("没有可导入的列，请检查列映射")
+	}
+	return resolved, targets, nil
+}
+
+// applyImportMapping 按映射生成以表列为键的行，并应用 NULL 标记、去空白、默认值与日期格式。
+func applyImportMapping(rows []map[string]interface{}, mappings []resolvedImportColumn, mapping ImportMapping, dbType string, columnTypeMap map[string]string) ([]map[string]interface{}, []string) {
+	nullToken := mapping.NullToken
+	if nullToken == "" {
+		nullToken = "NULL"
+	}
+	defaults := make(map[string]interface{}, len(mapping.Defaults))
+	for name, value := range mapping.Defaults {
+		defaults[normalizeColumnName(name)] = value
+	}
+	layout := importDateLayout(mapping.DateFormat)
+
+	out := make([]map[string]interface{}, 0, len(rows))
+	var errs []string
+	for idx, row := range rows {
+		mapped := make(map[string]interface{}, len(mappings))
+		for _, m := range mappings {
+			if m.Skipped {
+				continue
+			}
+			var value interface{}
+			if m.Source != "" {
+				value = row[m.Source]
+			}
+			if text, ok := value.(string); ok {
+				if mapping.Trim {
+					text = strings.TrimSpace(text)
+				}
+				value = text
+				if text == nullToken || (mapping.EmptyAsNull && text == "") {
+					value = nil
+				}
+			}
+			if value == nil {
+				if def, ok := defaults[normalizeColumnName(m.Target)]; ok {
+					value = def
+				}
+			}
+			if text, ok := value.(string); ok && layout != "" && text != "" && isTemporalColumnType(dbType, columnTypeMap[normalizeColumnName(m.Target)]) {
+				parsed, err := time.ParseInLocation(layout, text, time.Local)
+				if err != nil {
+					if len(errs) < maxImportConvertErrors {
+						errs = append(errs, fmt.Sprintf("Row %d: 列 %s 的值 %q 不符合日期格式 %s", idx+1, m.Source, text, mapping.DateFormat))
+					}
+					continue
+				}
+				value = parsed.Format("2006-01-02 15:04:05.999999999")
+			}
+			mapped[m.Target] = value
+		}
+		out = append(out, mapped)
+	}
+	return out, errs
+}
+
+// importDateLayout 将 yyyy-MM-dd HH:mm:ss 风格的格式转换为 Go 布局；已是 Go 布局时原样返回。
+func importDateLayout(format string) string {
+	format = strings.TrimSpace(format)
+	if format == "" || strings.Contains(format, "2006") {
+		return format
+	}
+	return strings.NewReplacer(
+		"yyyy", "2006", "yy", "06",
+		"MM", "01", "dd", "02",
+		"HH", "15", "hh", "03",
+		"mm", "04", "ss", "05",
+		"SSS", "000",
+	).Replace(format)
+}
+
+// PreviewImportMapping 按映射解析导入文件并返回前 limit 行的转换结果与全部转换错误，不写入数据。
+func (a *App) PreviewImportMapping(config connection.ConnectionConfig, dbName, tableName, filePath string, fileOptions ImportFileOptions, mapping ImportMapping, limit int) connection.QueryResult {
+	if strings.TrimSpace(filePath) == "" {
+		return connection.QueryResult{Success: false, Message: "File path required"}
+	}
+	if limit <= 0 {
+		limit = defaultImportPreviewRows
+	}
+	limit = min(limit, maxImportPreviewRows)
+
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	prepared, err := prepareImport(dbInst, resolveDDLDBType(runConfig), schemaName, pureTableName, filePath, fileOptions, &mapping)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	previewRows := prepared.rows
+	if len(previewRows) > limit {
+		previewRows = previewRows[:limit]
+	}
+	return connection.QueryResult{Success: true, Data: map[string]interface{}{
+		"fileColumns":  prepared.fileColumns,
+		"tableColumns": prepared.tableColumns,
+		"mappings":     prepared.mappings,
+		"columns":      prepared.columns,
+		"previewRows":  previewRows,
+		"totalRows":    len(prepared.rows),
+		"errors":       prepared.errors,
+	}}
+}
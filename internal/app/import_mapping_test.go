@@ -0,0 +1,115 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestResolveImportMapping(t *testing.T) {
+	table := []connection.ColumnDefinition{{Name: "id"}, {Name: "user_name"}, {Name: "status"}, {Name: "created_at"}}
+	mappings, targets, err := resolveImportMapping(
+		[]string{"ID", "Name", "Remark", "Extra"},
+		table,
+		ImportMapping{
+			Columns:  []ImportColumnMapping{{Source: "name", Target: "USER_NAME"}, {Source: "Extra", Skip: true}},
+			Defaults: map[string]interface{}{"status": "active", "id": 0},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(targets, ",") != "id,user_name,status" {
+		t.Fatalf("targets = %v", targets)
+	}
+	if !mappings[2].Skipped || mappings[2].Source != "Remark" || !mappings[3].Skipped || mappings[4].Source != "" {
+		t.Fatalf("mappings = %+v", mappings)
+	}
+
+	if _, _, err := resolveImportMapping([]string{"a", "b"}, table, ImportMapping{Columns: []ImportColumnMapping{{Source: "a", Target: "id"}, {Source: "b", Target: "ID"}}}); err == nil {
+		t.Fatalf("重复映射到同一表列应报错")
+	}
+	if _, _, err := resolveImportMapping([]string{"a"}, table, ImportMapping{Columns: []ImportColumnMapping{{Source: "a", Target: "missing"}}}); err == nil {
+		t.Fatalf("映射到不存在的表列应报错")
+	}
+	if _, _, err := resolveImportMapping([]string{"a"}, table, ImportMapping{}); err == nil {
+		t.Fatalf("没有可导入的列应报错")
+	}
+}
+
+func TestApplyImportMappingConversions(t *testing.T) {
+	mappings := []resolvedImportColumn{{Source: "when", Target: "created_at"}, {Source: "name", Target: "name"}, {Target: "status"}}
+	rows := []map[string]interface{}{
+		{"when": " 02/01/2024 03:04 ", "name": " \\N "},
+		{"when": "", "name": "bob"},
+		{"when": "2024-01-02", "name": "x"},
+	}
+	out, errs := applyImportMapping(rows, mappings, ImportMapping{
+		NullToken:   `\N`,
+		EmptyAsNull: true,
+		Trim:        true,
+		DateFormat:  "dd/MM/yyyy HH:mm",
+		Defaults:    map[string]interface{}{"name": "anonymous", "status": "active"},
+	}, "mysql", map[string]string{"created_at": "datetime"})
+
+	if out[0]["created_at"] != "2024-01-02 03:04:00" || out[0]["name"] != "anonymous" || out[0]["status"] != "active" {
+		t.Fatalf("row 1 = %#v", out[0])
+	}
+	if out[1]["created_at"] != nil || out[1]["name"] != "bob" {
+		t.Fatalf("row 2 = %#v", out[1])
+	}
+	if len(errs) != 1 || !strings.HasPrefix(errs[0], "Row 3:") {
+		t.Fatalf("errs = %v", errs)
+	}
+	if got := importDateLayout("yyyy/MM/dd HH:mm:ss.SSS"); got != "2006/01/02 15:04:05.000" {
+		t.Fatalf("layout = %s", got)
+	}
+}
+
+func TestImportDataWithOptionsMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.csv")
+	content := "用户编号,姓名,登记时间,备注\n1, alice ,15/03/2024,x\n2,NULL,16/03/2024,y\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	fake := &importFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	mapping := &ImportMapping{
+		Columns: []ImportColumnMapping{
+			{Source: "用户编号", Target: "id"}, {Source: "姓名", Target: "name"}, {Source: "登记时间", Target: "created_at"},
+		},
+		Trim:       true,
+		DateFormat: "dd/MM/yyyy",
+	}
+	res := a.ImportDataWithOptions(config, "shop", "users", path, ImportOptions{Mapping: mapping})
+	if !res.Success || res.Data.(map[string]interface{})["success"] != 2 || len(fake.queries) != 1 {
+		t.Fatalf("result = %+v queries = %v", res, fake.queries)
+	}
+	if fake.queries[0] != "INSERT INTO `users` (`id`, `name`, `created_at`) VALUES (?, ?, ?), (?, ?, ?)" {
+		t.Fatalf("query = %s", fake.queries[0])
+	}
+	if args := fake.args[0]; args[1] != "alice" || args[2] != "2024-03-15 00:00:00" || args[4] != nil {
+		t.Fatalf("args = %#v", args)
+	}
+
+	// 转换失败时不写入任何数据
+	fake.queries = nil
+	mapping.DateFormat = "yyyy-MM-dd"
+	res = a.ImportDataWithOptions(config, "shop", "users", path, ImportOptions{Mapping: mapping})
+	if res.Success || len(fake.queries) != 0 || len(res.Data.(map[string]interface{})["errorLogs"].([]string)) != 2 {
+		t.Fatalf("result = %+v", res)
+	}
+
+	preview := a.PreviewImportMapping(config, "shop", "users", path, ImportFileOptions{}, ImportMapping{Columns: []ImportColumnMapping{{Source: "用户编号", Target: "id"}}}, 1)
+	data := preview.Data.(map[string]interface{})
+	if !preview.Success || data["totalRows"] != 2 || len(data["previewRows"].([]map[string]interface{})) != 1 || len(data["columns"].([]string)) != 1 {
+		t.Fatalf("preview = %+v", preview)
+	}
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// headerDetectRows 自动识别表头时检查的前若干行。
+const headerDetectRows = 20
+
+// ImportFileOptions 导入文件的解析选项，目前仅对 Excel 生效。
+type ImportFileOptions struct {
+	Sheet     string `json:"sheet,omitempty"`     // 工作表名称，为空时使用第一个有数据的工作表
+	HeaderRow int    `json:"headerRow,omitempty"` // 表头所在行（1 起），0 为自动识别
+}
+
+// importSheetInfo Excel 解析结果中的工作表信息，供前端切换工作表与表头行。
+type importSheetInfo struct {
+	Sheets    []string `json:"sheets"`
+	Sheet     string   `json:"sheet"`
+	HeaderRow int      `json:"headerRow"`
+}
+
+func parseImportXLSX(filePath string, nullToken string, options ImportFileOptions) ([]map[string]interface{}, []string, error) {
+	rows, columns, _, err := parseImportXLSXSheet(filePath, nullToken, options)
+	return rows, columns, err
+}
+
+// parseImportXLSXSheet 读取指定工作表，以表头行的单元格作为列名，表头之前的标题行会被忽略。
+func parseImportXLSXSheet(filePath string, nullToken string, options ImportFileOptions) ([]map[string]interface{}, []string, importSheetInfo, error) {
+	xlsx, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return nil, nil, importSheetInfo{}, fmt.Errorf("Excel Parse Error: %w", err)
+	}
+	defer xlsx.Close()
+
+	info := importSheetInfo{Sheets: xlsx.GetSheetList()}
+	if len(info.Sheets) == 0 {
+		return nil, nil, info, fmt.Errorf("Excel file has no sheets")
+	}
+
+	var records [][]string
+	if sheet := strings.TrimSpace(options.Sheet); sheet != "" {
+		if idx, _ := xlsx.GetSheetIndex(sheet); idx < 0 {
+			return nil, nil, info, fmt.Errorf("工作表 %s 不存在，可选：%s", sheet, strings.Join(info.Sheets, ", "))
+		}
+		info.Sheet = sheet
+		if records, err = xlsx.GetRows(sheet); err != nil {
+			return nil, nil, info, fmt.Errorf("Excel Read Error: %w", err)
+		}
+	} else {
+		for _, sheet := range info.Sheets {
+			if records, err = xlsx.GetRows(sheet); err != nil {
+				return nil, nil, info, fmt.Errorf("Excel Read Error: %w", err)
+			}
+			if len(records) > 0 {
+				info.Sheet = sheet
+				break
+			}
+		}
+		if info.Sheet == "" {
+			info.Sheet = info.Sheets[0]
+		}
+	}
+
+	headerIdx := options.HeaderRow - 1
+	if options.HeaderRow <= 0 {
+		headerIdx = detectImportHeaderRow(records)
+	}
+	if headerIdx >= len(records) {
+		return nil, nil, info, fmt.Errorf("表头行 %d 超出工作表 %s 的行数（%d）", headerIdx+1, info.Sheet, len(records))
+	}
+	info.HeaderRow = headerIdx + 1
+	if len(records)-headerIdx < 2 {
+		return nil, nil, info, fmt.Errorf("Excel empty or missing header")
+	}
+
+	columns := uniqueImportHeaders(records[headerIdx])
+	rows := make([]map[string]interface{}, 0, len(records)-headerIdx-1)
+	for _, record := range records[headerIdx+1:] {
+		row := make(map[string]interface{})
+		blank := true
+		for i, val := range record {
+			if i >= len(columns) || columns[i] == "" {
+				continue
+			}
+			if strings.TrimSpace(val) != "" {
+				blank = false
+			}
+			if nullToken != "" && val == nullToken {
+				row[columns[i]] = nil
+			} else {
+				row[columns[i]] = val
+			}
+		}
+		// 跳过空行（含仅有格式、没有内容的行）
+		if !blank {
+			rows = append(rows, row)
+		}
+	}
+	return rows, columns, info, nil
+}
+
+// uniqueImportHeaders 去除表头首尾空白，重名列追加 _2、_3 后缀，空表头保留为空字符串（该列不导入）。
+func uniqueImportHeaders(header []string) []string {
+	columns := make([]string, len(header))
+	seen := make(map[string]int, len(header))
+	for i, cell := range header {
+		name := strings.TrimSpace(cell)
+		if name == "" {
+			continue
+		}
+		key := normalizeColumnName(name)
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+		columns[i] = name
+	}
+	return columns
+}
+
+// detectImportHeaderRow 在前若干行中找出表头：非空单元格数接近最宽的一行、且全部为不重复的非数字文本，
+// 以跳过报表顶部的标题、说明等行。找不到时使用第一个足够宽的行，默认第一行。
+func detectImportHeaderRow(records [][]string) int {
+	limit := min(len(records), headerDetectRows)
+	widths := make([]int, limit)
+	maxWidth := 0
+	for i := 0; i < limit; i++ {
+		for _, cell := range records[i] {
+			if strings.TrimSpace(cell) != "" {
+				widths[i]++
+			}
+		}
+		maxWidth = max(maxWidth, widths[i])
+	}
+	if maxWidth == 0 {
+		return 0
+	}
+	minWidth := max(1, (maxWidth*4+4)/5)
+	fallback := -1
+	for i := 0; i < limit; i++ {
+		if widths[i] < minWidth {
+			continue
+		}
+		if fallback < 0 {
+			fallback = i
+		}
+		if looksLikeHeaderRow(records[i]) {
+			return i
+		}
+	}
+	return max(fallback, 0)
+}
+
+func looksLikeHeaderRow(record []string) bool {
+	seen := make(map[string]bool, len(record))
+	for _, cell := range record {
+		text := strings.TrimSpace(cell)
+		if text == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", ""), 64); err == nil {
+			return false
+		}
+		if _, ok := parseTemporalString(text); ok {
+			return false
+		}
+		key := strings.ToLower(text)
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+	}
+	return len(seen) > 0
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func writeImportTestWorkbook(t *testing.T) string {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+	// 第一个工作表为空，第二个工作表顶部有标题与说明行
+	if _, err := f.NewSheet("订单"); err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]interface{}{
+		{"2024 年订单报表"},
+		{"导出时间", "2024-03-01"},
+		{"id", "name", "amount", "name"},
+		{1, "alice", 12.5, "a"},
+		{},
+		{2, "NULL", 3, "b"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("订单", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "orders.xlsx")
+	if err := f.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseImportXLSXDetectsHeader(t *testing.T) {
+	path := writeImportTestWorkbook(t)
+
+	rows, columns, info, err := parseImportXLSXSheet(path, "NULL", ImportFileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Sheet != "订单" || info.HeaderRow != 3 || strings.Join(info.Sheets, ",") != "Sheet1,订单" {
+		t.Fatalf("info = %+v", info)
+	}
+	if strings.Join(columns, ",") != "id,name,amount,name_2" {
+		t.Fatalf("columns = %v", columns)
+	}
+	if len(rows) != 2 || rows[0]["name"] != "alice" || rows[0]["name_2"] != "a" || rows[1]["name"] != nil {
+		t.Fatalf("rows = %v", rows)
+	}
+
+	// 手动指定表头行
+	_, columns, info, err = parseImportXLSXSheet(path, "NULL", ImportFileOptions{Sheet: "订单", HeaderRow: 2})
+	if err != nil || info.HeaderRow != 2 || columns[0] != "导出时间" {
+		t.Fatalf("columns = %v info = %+v err = %v", columns, info, err)
+	}
+	if _, _, _, err := parseImportXLSXSheet(path, "NULL", ImportFileOptions{Sheet: "missing"}); err == nil {
+		t.Fatalf("不存在的工作表应报错")
+	}
+}
+
+func TestPreviewImportFileWithOptionsReturnsSheets(t *testing.T) {
+	path := writeImportTestWorkbook(t)
+	res := NewApp().PreviewImportFileWithOptions(path, ImportFileOptions{})
+	data, _ := res.Data.(map[string]interface{})
+	if !res.Success || data["sheet"] != "订单" || data["headerRow"] != 3 || data["totalRows"] != 2 {
+		t.Fatalf("result = %+v", res)
+	}
+}
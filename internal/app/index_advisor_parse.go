@@ -0,0 +1,314 @@
+package app
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 索引建议所需的轻量 SQL 解析：只识别表引用、WHERE/ON 谓词列与 ORDER BY 列，不追求完整语法。
+
+type sqlTokenKind int
+
+const (
+	sqlTokenIdent sqlTokenKind = iota
+	sqlTokenQuotedIdent
+	sqlTokenString
+	sqlTokenNumber
+	sqlTokenOperator
+	sqlTokenPunct
+)
+
+type sqlToken struct {
+	kind  sqlTokenKind
+	text  string // 引号标识符为去引号后的名称
+	upper string
+}
+
+func tokenizeSQL(query string) []sqlToken {
+	tokens := make([]sqlToken, 0, 64)
+	runes := []rune(query)
+	n := len(runes)
+	for i := 0; i < n; {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < n && runes[i+1] == '-':
+			for i < n && runes[i] != '\n' {
+				i++
+			}
+		case r == '#':
+			for i < n && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < n && runes[i+1] == '*':
+			i += 2
+			for i+1 < n && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case r == '\'':
+			j := i + 1
+			var b strings.Builder
+			for j < n {
+				if runes[j] == '\\' && j+1 < n {
+					b.WriteRune(runes[j+1])
+					j += 2
+					continue
+				}
+				if runes[j] == '\'' {
+					if j+1 < n && runes[j+1] == '\'' {
+						b.WriteRune('\'')
+						j += 2
+						continue
+					}
+					break
+				}
+				b.WriteRune(runes[j])
+				j++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlTokenString, text: b.String()})
+			i = j + 1
+		case r == '`' || r == '"' || r == '[':
+			closing := r
+			if r == '[' {
+				closing = ']'
+			}
+			j := i + 1
+			var b strings.Builder
+			for j < n {
+				if runes[j] == closing {
+					if j+1 < n && runes[j+1] == closing && closing != ']' {
+						b.WriteRune(closing)
+						j += 2
+						continue
+					}
+					break
+				}
+				b.WriteRune(runes[j])
+				j++
+			}
+			text := b.String()
+			tokens = append(tokens, sqlToken{kind: sqlTokenQuotedIdent, text: text, upper: strings.ToUpper(text)})
+			i = j + 1
+		case unicode.IsLetter(r) || r == '_' || r == '$' || r == '@':
+			j := i + 1
+			for j < n && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '$') {
+				j++
+			}
+			text := string(runes[i:j])
+			tokens = append(tokens, sqlToken{kind: sqlTokenIdent, text: text, upper: strings.ToUpper(text)})
+			i = j
+		case unicode.IsDigit(r):
+			j := i + 1
+			for j < n && (unicode.IsDigit(runes[j]) || runes[j] == '.' || runes[j] == 'e' || runes[j] == 'E') {
+				j++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlTokenNumber, text: string(runes[i:j])})
+			i = j
+		case strings.ContainsRune("<>=!", r):
+			j := i + 1
+			for j < n && strings.ContainsRune("<>=", runes[j]) {
+				j++
+			}
+			text := string(runes[i:j])
+			tokens = append(tokens, sqlToken{kind: sqlTokenOperator, text: text, upper: text})
+			i = j
+		default:
+			text := string(r)
+			tokens = append(tokens, sqlToken{kind: sqlTokenPunct, text: text, upper: text})
+			i++
+		}
+	}
+	return tokens
+}
+
+var sqlReservedAfterTable = map[string]struct{}{
+	"WHERE": {}, "JOIN": {}, "INNER": {}, "LEFT": {}, "RIGHT": {}, "FULL": {}, "CROSS": {}, "OUTER": {},
+	"ON": {}, "USING": {}, "GROUP": {}, "ORDER": {}, "LIMIT": {}, "OFFSET": {}, "HAVING": {}, "UNION": {},
+	"SET": {}, "WINDOW": {}, "FETCH": {}, "FOR": {}, "NATURAL": {}, "STRAIGHT_JOIN": {}, "VALUES": {},
+	"EXCEPT": {}, "INTERSECT": {}, "AS": {}, "WITH": {}, "FORCE": {}, "USE": {}, "IGNORE": {},
+}
+
+type advisorTableRef struct {
+	schema string
+	name   string
+	alias  string
+}
+
+// advisorPredicate 谓词列引用；usage 取值 eq/range/join/order。
+type advisorPredicate struct {
+	qualifier string
+	column    string
+	usage     string
+}
+
+type advisorParseResult struct {
+	tables     []advisorTableRef
+	predicates []advisorPredicate
+}
+
+func isIdentToken(tok sqlToken) bool {
+	return tok.kind == sqlTokenIdent || tok.kind == sqlTokenQuotedIdent
+}
+
+// readQualifiedName 读取 a.b.c 形式的名称，返回各段与下一个位置。
+func readQualifiedName(tokens []sqlToken, i int) ([]string, int) {
+	if i >= len(tokens) || !isIdentToken(tokens[i]) {
+		return nil, i
+	}
+	parts := []string{tokens[i].text}
+	i++
+	for i+1 < len(tokens) && tokens[i].text == "." && isIdentToken(tokens[i+1]) {
+		parts = append(parts, tokens[i+1].text)
+		i += 2
+	}
+	return parts, i
+}
+
+func parseIndexAdvisorQuery(query string) advisorParseResult {
+	tokens := tokenizeSQL(query)
+	var result advisorParseResult
+	clause := ""
+
+	addTable := func(i int) int {
+		for {
+			if i < len(tokens) && tokens[i].text == "(" {
+				// 子查询/派生表：跳过，由括号内的 FROM 单独处理
+				return i
+			}
+			parts, next := readQualifiedName(tokens, i)
+			if len(parts) == 0 {
+				return i
+			}
+			ref := advisorTableRef{name: parts[len(parts)-1]}
+			if len(parts) >= 2 {
+				ref.schema = parts[len(parts)-2]
+			}
+			i = next
+			if i < len(tokens) && tokens[i].upper == "AS" {
+				i++
+			}
+			if i < len(tokens) && isIdentToken(tokens[i]) {
+				if _, reserved := sqlReservedAfterTable[tokens[i].upper]; !reserved || tokens[i].kind == sqlTokenQuotedIdent {
+					ref.alias = tokens[i].text
+					i++
+				}
+			}
+			result.tables = append(result.tables, ref)
+			if i < len(tokens) && tokens[i].text == "," && clause == "FROM" {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+
+	for i := 0; i < len(tokens); {
+		tok := tokens[i]
+		if tok.kind == sqlTokenIdent {
+			switch tok.upper {
+			case "FROM", "JOIN", "UPDATE", "INTO":
+				clause = "FROM"
+				if tok.upper == "UPDATE" {
+					clause = "UPDATE"
+				}
+				i = addTable(i + 1)
+				continue
+			case "WHERE", "ON", "HAVING":
+				clause = tok.upper
+				i++
+				continue
+			case "ORDER":
+				if i+1 < len(tokens) && tokens[i+1].upper == "BY" {
+					clause = "ORDER"
+					i += 2
+					continue
+				}
+			case "GROUP", "LIMIT", "OFFSET", "FETCH", "UNION", "SET", "WINDOW":
+				clause = tok.upper
+				i++
+				continue
+			}
+		}
+
+		if clause == "WHERE" || clause == "ON" {
+			if parts, next := readQualifiedName(tokens, i); len(parts) > 0 && !(next < len(tokens) && tokens[next].text == "(") {
+				if pred, ok := classifyAdvisorPredicate(tokens, next, clause); ok {
+					result.predicates = append(result.predicates, newAdvisorPredicate(parts, pred))
+					// ON a.x = b.y：右侧列同样是连接列
+					if pred == "join" {
+						if rparts, rnext := readQualifiedName(tokens, next+1); len(rparts) > 0 && !(rnext < len(tokens) && tokens[rnext].text == "(") {
+							result.predicates = append(result.predicates, newAdvisorPredicate(rparts, "join"))
+							i = rnext
+							continue
+						}
+					}
+				}
+				i = next
+				continue
+			}
+		}
+
+		if clause == "ORDER" {
+			if parts, next := readQualifiedName(tokens, i); len(parts) > 0 && !(next < len(tokens) && tokens[next].text == "(") {
+				if _, reserved := sqlReservedAfterTable[strings.ToUpper(parts[0])]; !reserved || len(parts) > 1 {
+					upper := strings.ToUpper(parts[len(parts)-1])
+					if upper != "ASC" && upper != "DESC" && upper != "NULLS" && upper != "FIRST" && upper != "LAST" {
+						result.predicates = append(result.predicates, newAdvisorPredicate(parts, "order"))
+					}
+				}
+				i = next
+				continue
+			}
+			if tok.text == ")" {
+				clause = ""
+			}
+		}
+		i++
+	}
+	return result
+}
+
+func newAdvisorPredicate(parts []string, usage string) advisorPredicate {
+	pred := advisorPredicate{column: parts[len(parts)-1], usage: usage}
+	if len(parts) >= 2 {
+		pred.qualifier = parts[len(parts)-2]
+	}
+	return pred
+}
+
+// classifyAdvisorPredicate 判断列引用后的运算符是否可用索引：等值/范围/连接。
+func classifyAdvisorPredicate(tokens []sqlToken, i int, clause string) (string, bool) {
+	if i >= len(tokens) {
+		return "", false
+	}
+	tok := tokens[i]
+	switch tok.upper {
+	case "=", "<=>":
+		if clause == "ON" && i+1 < len(tokens) && isIdentToken(tokens[i+1]) {
+			return "join", true
+		}
+		if i+1 < len(tokens) && isIdentToken(tokens[i+1]) && i+2 < len(tokens) && tokens[i+2].text == "." {
+			return "join", true
+		}
+		return "eq", true
+	case "<", ">", "<=", ">=", "BETWEEN":
+		return "range", true
+	case "IN":
+		return "eq", true
+	case "IS":
+		if i+1 < len(tokens) && tokens[i+1].upper == "NULL" {
+			return "eq", true
+		}
+	case "LIKE":
+		// 仅前缀匹配可利用索引
+		if i+1 < len(tokens) && tokens[i+1].kind == sqlTokenString {
+			pattern := tokens[i+1].text
+			if pattern != "" && !strings.HasPrefix(pattern, "%") && !strings.HasPrefix(pattern, "_") {
+				return "range", true
+			}
+		}
+	}
+	return "", false
+}
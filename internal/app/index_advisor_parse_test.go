@@ -0,0 +1,39 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIndexAdvisorQuery(t *testing.T) {
+	query := "SELECT o.id FROM `orders` o INNER JOIN customers AS c ON o.customer_id = c.id " +
+		"WHERE o.status = 'paid' AND o.created_at >= '2024-01-01' AND c.name LIKE '%x' AND LOWER(c.email) = 'a' " +
+		"ORDER BY o.created_at DESC, c.name LIMIT 10"
+	parsed := parseIndexAdvisorQuery(query)
+
+	wantTables := []advisorTableRef{{name: "orders", alias: "o"}, {name: "customers", alias: "c"}}
+	if !reflect.DeepEqual(parsed.tables, wantTables) {
+		t.Fatalf("tables = %#v", parsed.tables)
+	}
+	wantPreds := []advisorPredicate{
+		{qualifier: "o", column: "customer_id", usage: "join"},
+		{qualifier: "c", column: "id", usage: "join"},
+		{qualifier: "o", column: "status", usage: "eq"},
+		{qualifier: "o", column: "created_at", usage: "range"},
+		{qualifier: "o", column: "created_at", usage: "order"},
+		{qualifier: "c", column: "name", usage: "order"},
+	}
+	if !reflect.DeepEqual(parsed.predicates, wantPreds) {
+		t.Fatalf("predicates = %#v", parsed.predicates)
+	}
+}
+
+func TestFindCoveringIndex(t *testing.T) {
+	existing := map[string][]string{"idx_a_b": {"a", "b", "c"}}
+	if got := findCoveringIndex(existing, []string{"B", "a"}); got != "idx_a_b" {
+		t.Fatalf("expected covering index, got %q", got)
+	}
+	if got := findCoveringIndex(existing, []string{"b", "c"}); got != "" {
+		t.Fatalf("expected no covering index, got %q", got)
+	}
+}
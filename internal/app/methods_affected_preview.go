@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+	"GoNavi-Wails/internal/utils"
+)
+
+const (
+	affectedPreviewDefaultRows = 100
+	affectedPreviewMaxRows     = 1000
+	affectedPreviewTimeout     = 30 * time.Second
+)
+
+// AffectedRowsPreview UPDATE/DELETE 执行前的影响范围预览。
+type AffectedRowsPreview struct {
+	Keyword   string                   `json:"keyword"` // UPDATE 或 DELETE
+	Total     int64                    `json:"total"`   // 将受影响的行数，-1 表示统计失败
+	Columns   []string                 `json:"columns"`
+	Rows      []map[string]interface{} `json:"rows"`
+	Truncated bool                     `json:"truncated"` // Total 多于返回的行
+	FullTable bool                     `json:"fullTable"` // 语句没有 WHERE 条件
+	SelectSQL string                   `json:"selectSql"`
+	CountSQL  string                   `json:"countSql"`
+	Warnings  []string                 `json:"warnings,omitempty"`
+}
+
+// PreviewAffectedRows 把单条 UPDATE/DELETE 改写为相同表引用与 WHERE 条件的 SELECT，返回最多 limit 行样本与总行数，
+// 便于执行前确认影响范围。多表 UPDATE/DELETE 返回关联后的全部列；改写只截取原文，语句中的函数副作用不会被执行。
+func (a *App) PreviewAffectedRows(config connection.ConnectionConfig, dbName string, query string, limit int) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbType := resolveDDLDBType(runConfig)
+	statements := sqlstmt.Split(query, dbType)
+	if len(statements) == 0 {
+		return connection.QueryResult{Success: false, Message: "查询语句不能为空"}
+	}
+	if len(statements) > 1 {
+		return connection.QueryResult{Success: false, Message: "一次只能预览一条 UPDATE 或 DELETE 语句"}
+	}
+	target, err := sqlstmt.ParseAffectedRows(dbType, statements[0])
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if limit <= 0 {
+		limit = affectedPreviewDefaultRows
+	}
+	limit = min(limit, affectedPreviewMaxRows)
+
+	preview := AffectedRowsPreview{
+		Keyword:   target.Keyword,
+		Total:     -1,
+		FullTable: target.Where == "",
+		SelectSQL: target.SelectSQL(limit),
+		CountSQL:  target.CountSQL(),
+	}
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	ctx, cancel := utils.ContextWithTimeout(affectedPreviewTimeout)
+	defer cancel()
+
+	rows, columns, err := affectedPreviewQuery(ctx, dbInst, sanitizeSQLForPgLike(runConfig.Type, preview.SelectSQL))
+	if err != nil {
+		logger.Error(err, "影响行预览查询失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(preview.SelectSQL))
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("预览查询失败：%s", normalizeErrorMessage(err))}
+	}
+	preview.Rows, preview.Columns = rows, columns
+	if preview.Rows == nil {
+		preview.Rows = []map[string]interface{}{}
+	}
+
+	if len(rows) < limit {
+		// 样本未达上限即为全部受影响行，无需再统计
+		preview.Total = int64(len(rows))
+	} else if countRows, _, err := affectedPreviewQuery(ctx, dbInst, sanitizeSQLForPgLike(runConfig.Type, preview.CountSQL)); err != nil {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("统计受影响行数失败：%s", normalizeErrorMessage(err)))
+	} else if len(countRows) > 0 {
+		for _, v := range countRows[0] {
+			if n, ok := identityInt(v); ok {
+				preview.Total = n
+			}
+		}
+	}
+	preview.Truncated = preview.Total < 0 || preview.Total > int64(len(preview.Rows))
+
+	message := fmt.Sprintf("%s 将影响 %d 行", target.Keyword, preview.Total)
+	if preview.Total < 0 {
+		message = fmt.Sprintf("%s 将影响至少 %d 行", target.Keyword, len(preview.Rows))
+	}
+	if preview.FullTable {
+		message += "（没有 WHERE 条件，将作用于整张表）"
+	}
+	return connection.QueryResult{Success: true, Message: message, Data: preview}
+}
+
+func affectedPreviewQuery(ctx context.Context, inst db.Database, query string) ([]map[string]interface{}, []string, error) {
+	if q, ok := inst.(interface {
+		QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+	}); ok {
+		return q.QueryContext(ctx, query)
+	}
+	return inst.Query(query)
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type affectedPreviewFakeDB struct {
+	db.Database
+	queries []string
+}
+
+func (f *affectedPreviewFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	f.queries = append(f.queries, query)
+	if strings.HasPrefix(query, "SELECT COUNT(*)") {
+		return []map[string]interface{}{{"COUNT(*)": []byte("1234")}}, []string{"COUNT(*)"}, nil
+	}
+	return []map[string]interface{}{{"id": int64(1)}, {"id": int64(2)}}, []string{"id"}, nil
+}
+
+func TestPreviewAffectedRows(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root", Database: "shop", ReadOnly: true}
+	fake := &affectedPreviewFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	res := a.PreviewAffectedRows(config, "shop", "DELETE FROM orders WHERE status = 'x';", 2)
+	if !res.Success {
+		t.Fatal(res.Message)
+	}
+	preview := res.Data.(AffectedRowsPreview)
+	if preview.Total != 1234 || !preview.Truncated || preview.FullTable || len(preview.Rows) != 2 {
+		t.Fatalf("preview = %+v", preview)
+	}
+	if fake.queries[0] != "SELECT * FROM orders WHERE status = 'x' LIMIT 2" {
+		t.Fatalf("queries = %q", fake.queries)
+	}
+
+	// 样本未达上限时不再统计
+	fake.queries = nil
+	res = a.PreviewAffectedRows(config, "shop", "UPDATE orders SET a = 1", 10)
+	if preview := res.Data.(AffectedRowsPreview); !res.Success || preview.Total != 2 || preview.Truncated || !preview.FullTable || len(fake.queries) != 1 {
+		t.Fatalf("res = %+v queries = %q", res, fake.queries)
+	}
+
+	if res := a.PreviewAffectedRows(config, "shop", "DELETE FROM a; DELETE FROM b", 10); res.Success {
+		t.Fatal("expected multi-statement error")
+	}
+}
@@ -0,0 +1,356 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	defaultArchiveBatchSize = 1000
+	maxArchiveBatchSize     = 50000
+)
+
+// ArchiveRowsRequest 归档请求：把 Table 中 DateColumn < Cutoff 的行分批复制到归档表，校验后再从源表删除。
+// TargetConfig 为空时归档到同一连接；TargetTable 为空时使用 <表名>_archive。
+type ArchiveRowsRequest struct {
+	Table        string                       `json:"table"`
+	DateColumn   string                       `json:"dateColumn"`
+	Cutoff       string                       `json:"cutoff"` // 2024-01-01 或 2024-01-01 00:00:00
+	TargetConfig *connection.ConnectionConfig `json:"targetConfig,omitempty"`
+	TargetDBName string                       `json:"targetDbName,omitempty"`
+	TargetTable  string                       `json:"targetTable,omitempty"`
+	CreateTarget bool                         `json:"createTarget,omitempty"` // 目标表不存在时按源表结构创建（仅同连接）
+	BatchSize    int                          `json:"batchSize,omitempty"`
+	DryRun       bool                         `json:"dryRun,omitempty"`
+	JobID        string                       `json:"jobId,omitempty"`
+}
+
+// ArchiveRowsResult 归档结果；DryRun 时只有 Matched 与 TargetExists 有意义。
+type ArchiveRowsResult struct {
+	DryRun       bool   `json:"dryRun"`
+	Matched      int64  `json:"matched"`
+	Copied       int64  `json:"copied"`
+	Deleted      int64  `json:"deleted"`
+	Batches      int    `json:"batches"`
+	TargetTable  string `json:"targetTable"`
+	TargetExists bool   `json:"targetExists"`
+	Created      bool   `json:"created,omitempty"`
+	Condition    string `json:"condition"`
+}
+
+// archiveCutoffLiteral 校验截止时间并生成字面量；只有日期时保留日期形式，避免 SQLite 文本比较时把当天的行也算进去。
+func archiveCutoffLiteral(dbType string, cutoff string) (string, error) {
+	text := strings.TrimSpace(cutoff)
+	parsed, ok := parseTemporalString(text)
+	if !ok || len(text) < 10 {
+		return "", fmt.Errorf("截止时间格式不正确：%s（示例：2024-01-01 或 2024-01-01 00:00:00）", cutoff)
+	}
+	dateOnly := len(text) == 10
+	literal := parsed.Format("2006-01-02 15:04:05")
+	if dateOnly {
+		literal = parsed.Format("2006-01-02")
+	}
+	switch dbType {
+	case "oracle", "dameng":
+		if dateOnly {
+			return fmt.Sprintf("TO_DATE('%s', 'YYYY-MM-DD')", literal), nil
+		}
+		return fmt.Sprintf("TO_TIMESTAMP('%s', 'YYYY-MM-DD HH24:MI:SS')", literal), nil
+	default:
+		return "'" + literal + "'", nil
+	}
+}
+
+// archiveKeyPredicate 按主键生成 WHERE 条件：单列主键用 IN，复合主键用 (a = .. AND b = ..) OR ...。
+func archiveKeyPredicate(dbType string, pkColumns []string, rows []map[string]interface{}) (string, error) {
+	if len(rows) == 0 {
+		return "", fmt.Errorf("没有需要处理的行")
+	}
+	if len(pkColumns) == 1 {
+		col := quoteIdentByType(dbType, pkColumns[0])
+		values := make([]string, 0, len(rows))
+		for _, row := range rows {
+			value, ok := archiveRowValue(row, pkColumns[0])
+			if !ok || value == nil {
+				return "", fmt.Errorf("主键列 %s 缺少值", pkColumns[0])
+			}
+			values = append(values, pageFilterLiteral(dbType, value))
+		}
+		return fmt.Sprintf("%s IN (%s)", col, strings.Join(values, ", ")), nil
+	}
+	clauses := make([]string, 0, len(rows))
+	for _, row := range rows {
+		parts := make([]string, 0, len(pkColumns))
+		for _, pk := range pkColumns {
+			value, ok := archiveRowValue(row, pk)
+			if !ok || value == nil {
+				return "", fmt.Errorf("主键列 %s 缺少值", pk)
+			}
+			parts = append(parts, fmt.Sprintf("%s = %s", quoteIdentByType(dbType, pk), pageFilterLiteral(dbType, value)))
+		}
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return strings.Join(clauses, " OR "), nil
+}
+
+// archiveRowValue 结果集列名大小写可能与元数据不一致（如 Oracle 大写），按不区分大小写查找。
+func archiveRowValue(row map[string]interface{}, column string) (interface{}, bool) {
+	if value, ok := row[column]; ok {
+		return value, true
+	}
+	for k, v := range row {
+		if strings.EqualFold(k, column) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// buildArchiveTableDDL 按源表结构创建空归档表。MySQL 系用 LIKE 保留索引，SQL Server 用 SELECT INTO，其余用 CTAS。
+func buildArchiveTableDDL(dbType string, source string, target string) string {
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		return fmt.Sprintf("CREATE TABLE %s LIKE %s", target, source)
+	case "sqlserver":
+		return fmt.Sprintf("SELECT * INTO %s FROM %s WHERE 1 = 0", target, source)
+	default:
+		return fmt.Sprintf("CREATE TABLE %s AS SELECT * FROM %s WHERE 1 = 0", target, source)
+	}
+}
+
+func (a *App) archiveCount(ctx context.Context, tracked *runningQuery, inst db.Database, query string) (int64, error) {
+	data, _, err := a.queryTracked(ctx, tracked, inst, query)
+	if err != nil {
+		return 0, err
+	}
+	if len(data) == 0 {
+		return 0, nil
+	}
+	for _, v := range data[0] {
+		n, err := strconv.ParseInt(strings.TrimSpace(fmt.Sprintf("%v", v)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("无法解析计数结果：%v", v)
+		}
+		return n, nil
+	}
+	return 0, nil
+}
+
+func (a *App) emitArchiveProgress(jobID string, result *ArchiveRowsResult, stage string) {
+	if a.ctx == nil || jobID == "" {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "archive:progress", map[string]interface{}{
+		"jobId":   jobID,
+		"stage":   stage,
+		"matched": result.Matched,
+		"copied":  result.Copied,
+		"deleted": result.Deleted,
+		"batches": result.Batches,
+	})
+}
+
+// ArchiveRows 归档旧数据。先 DryRun 查看匹配行数；正式执行时每批依次：读取一批、写入归档表、
+// 按主键回查归档表确认行数一致、从源表按主键删除。任一步失败即停止，已完成的批次保持有效。
+func (a *App) ArchiveRows(config connection.ConnectionConfig, dbName string, req ArchiveRowsRequest) connection.QueryResult {
+	if err := ensureWritable(config, "归档数据"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	table := strings.TrimSpace(req.Table)
+	dateColumn := strings.TrimSpace(req.DateColumn)
+	if table == "" || dateColumn == "" {
+		return connection.QueryResult{Success: false, Message: "表名与日期列不能为空"}
+	}
+	dbType := resolveDDLDBType(config)
+	cutoff, err := archiveCutoffLiteral(dbType, req.Cutoff)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultArchiveBatchSize
+	}
+	if batchSize > maxArchiveBatchSize {
+		batchSize = maxArchiveBatchSize
+	}
+
+	runConfig := buildRunConfigForDDL(config, dbType, dbName)
+	srcInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	schemaName, pureName := normalizeSchemaAndTableByType(dbType, dbName, table)
+	columns, err := srcInst.GetColumns(schemaName, pureName)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("读取表结构失败：%v", err)}
+	}
+	var pkColumns []string
+	dateFound := false
+	for _, col := range columns {
+		if col.Key == "PRI" || col.Key == "PK" {
+			pkColumns = append(pkColumns, col.Name)
+		}
+		if strings.EqualFold(col.Name, dateColumn) {
+			dateColumn = col.Name
+			dateFound = true
+		}
+	}
+	if !dateFound {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("表 %s 中不存在列 %s", table, dateColumn)}
+	}
+	if len(pkColumns) == 0 {
+		return connection.QueryResult{Success: false, Message: "表没有主键，无法按行校验与删除，已拒绝归档"}
+	}
+
+	// 目标连接
+	targetConfig, targetDBName, sameConnection := runConfig, dbName, true
+	if req.TargetConfig != nil {
+		targetDBName = req.TargetDBName
+		targetConfig = buildRunConfigForDDL(*req.TargetConfig, resolveDDLDBType(*req.TargetConfig), targetDBName)
+		sameConnection = getCacheKey(applyCustomDriverType(targetConfig)) == getCacheKey(applyCustomDriverType(runConfig))
+	} else if strings.TrimSpace(req.TargetDBName) != "" {
+		targetDBName = req.TargetDBName
+		targetConfig = buildRunConfigForDDL(config, dbType, targetDBName)
+		sameConnection = targetDBName == dbName
+	}
+	targetType := resolveDDLDBType(targetConfig)
+	targetTable := strings.TrimSpace(req.TargetTable)
+	if targetTable == "" {
+		targetTable = table + "_archive"
+	}
+	if strings.EqualFold(targetTable, table) && sameConnection {
+		return connection.QueryResult{Success: false, Message: "归档表不能与源表相同"}
+	}
+	dstInst := srcInst
+	if !sameConnection {
+		if dstInst, err = a.getDatabase(targetConfig); err != nil {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("连接归档目标失败：%v", err)}
+		}
+	}
+	targetSchema, targetPure := normalizeSchemaAndTableByType(targetType, targetDBName, targetTable)
+	targetCols, targetErr := dstInst.GetColumns(targetSchema, targetPure)
+
+	qualifiedSource := quoteQualifiedIdentByType(dbType, table)
+	qualifiedTarget := quoteQualifiedIdentByType(targetType, targetTable)
+	condition := fmt.Sprintf("%s < %s", quoteIdentByType(dbType, dateColumn), cutoff)
+	result := &ArchiveRowsResult{
+		DryRun:       req.DryRun,
+		TargetTable:  targetTable,
+		TargetExists: targetErr == nil && len(targetCols) > 0,
+		Condition:    condition,
+	}
+
+	ctx := context.Background()
+	var tracked *runningQuery
+	if req.JobID != "" {
+		ctx, tracked, err = a.registerQuery(ctx, req.JobID, runConfig)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		defer a.unregisterQuery(tracked)
+	}
+
+	result.Matched, err = a.archiveCount(ctx, tracked, srcInst, fmt.Sprintf("SELECT COUNT(*) AS cnt FROM %s WHERE %s", qualifiedSource, condition))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("统计待归档行数失败：%v", err)}
+	}
+	if req.DryRun {
+		msg := fmt.Sprintf("预计归档 %d 行到 %s", result.Matched, targetTable)
+		if !result.TargetExists {
+			msg += "（归档表不存在）"
+		}
+		return connection.QueryResult{Success: true, Message: msg, Data: result}
+	}
+	if result.Matched == 0 {
+		return connection.QueryResult{Success: true, Message: "没有需要归档的行", Data: result}
+	}
+
+	if !result.TargetExists {
+		if !req.CreateTarget || !sameConnection {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("归档表 %s 不存在，请先创建（仅同一连接内支持自动创建）", targetTable), Data: result}
+		}
+		if _, err := a.execTracked(ctx, tracked, srcInst, buildArchiveTableDDL(dbType, qualifiedSource, qualifiedTarget)); err != nil {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("创建归档表失败：%v", err), Data: result}
+		}
+		result.Created = true
+		result.TargetExists = true
+	}
+	applier, ok := dstInst.(db.BatchApplier)
+	if !ok {
+		return connection.QueryResult{Success: false, Message: "归档目标数据库不支持批量写入", Data: result}
+	}
+
+	logger.Infof("开始归档：%s 表=%s 条件=%s 目标=%s 预计=%d 行", formatConnSummary(runConfig), table, condition, targetTable, result.Matched)
+	started := time.Now()
+	selectBatch := applyRowLimit(dbType, fmt.Sprintf("SELECT * FROM %s WHERE %s", qualifiedSource, condition), batchSize)
+	for {
+		if ctx.Err() != nil {
+			return a.archiveFailure(result, runConfig, table, errQueryCanceled)
+		}
+		rows, _, err := a.queryTracked(ctx, tracked, srcInst, selectBatch)
+		if err != nil {
+			return a.archiveFailure(result, runConfig, table, fmt.Errorf("读取待归档数据失败：%w", err))
+		}
+		if len(rows) == 0 {
+			break
+		}
+		if err := applier.ApplyChanges(targetTable, connection.ChangeSet{Inserts: rows}); err != nil {
+			return a.archiveFailure(result, runConfig, table, fmt.Errorf("写入归档表失败：%w", err))
+		}
+		result.Copied += int64(len(rows))
+
+		targetPredicate, err := archiveKeyPredicate(targetType, pkColumns, rows)
+		if err != nil {
+			return a.archiveFailure(result, runConfig, table, err)
+		}
+		verified, err := a.archiveCount(ctx, nil, dstInst, fmt.Sprintf("SELECT COUNT(*) AS cnt FROM %s WHERE %s", qualifiedTarget, targetPredicate))
+		if err != nil {
+			return a.archiveFailure(result, runConfig, table, fmt.Errorf("校验归档数据失败：%w", err))
+		}
+		if verified < int64(len(rows)) {
+			return a.archiveFailure(result, runConfig, table, fmt.Errorf("校验失败：本批 %d 行在归档表中只找到 %d 行，源数据未删除", len(rows), verified))
+		}
+
+		sourcePredicate, err := archiveKeyPredicate(dbType, pkColumns, rows)
+		if err != nil {
+			return a.archiveFailure(result, runConfig, table, err)
+		}
+		deleted, err := a.execTracked(ctx, tracked, srcInst, fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedSource, sourcePredicate))
+		if err != nil {
+			return a.archiveFailure(result, runConfig, table, fmt.Errorf("删除已归档数据失败：%w", err))
+		}
+		if deleted == 0 {
+			// 删除未生效时继续循环会重复读取同一批数据
+			return a.archiveFailure(result, runConfig, table, fmt.Errorf("删除已归档数据未生效，已停止"))
+		}
+		result.Deleted += deleted
+		result.Batches++
+		a.emitArchiveProgress(req.JobID, result, "running")
+		if len(rows) < batchSize {
+			break
+		}
+	}
+	a.emitArchiveProgress(req.JobID, result, "done")
+	logger.Infof("归档完成：%s 表=%s 复制=%d 删除=%d 批次=%d 耗时=%s", formatConnSummary(runConfig), table, result.Copied, result.Deleted, result.Batches, time.Since(started).Round(time.Millisecond))
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已归档 %d 行到 %s", result.Deleted, targetTable), Data: result}
+}
+
+func (a *App) archiveFailure(result *ArchiveRowsResult, config connection.ConnectionConfig, table string, err error) connection.QueryResult {
+	logger.Error(err, "归档中断：%s 表=%s 已复制=%d 已删除=%d", formatConnSummary(config), table, result.Copied, result.Deleted)
+	msg := err.Error()
+	if result.Copied > result.Deleted {
+		msg = fmt.Sprintf("%s（已归档 %d 行；最后一批 %d 行已写入归档表但未从源表删除，重试前请先清理归档表中的这些行）", msg, result.Deleted, result.Copied-result.Deleted)
+	} else if result.Deleted > 0 {
+		msg = fmt.Sprintf("%s（此前已归档 %d 行）", msg, result.Deleted)
+	}
+	return connection.QueryResult{Success: false, Message: msg, Data: result}
+}
@@ -0,0 +1,203 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+func TestArchiveCutoffLiteral(t *testing.T) {
+	cases := []struct {
+		dbType, cutoff, want string
+	}{
+		{"mysql", "2024-01-01", "'2024-01-01'"},
+		{"postgres", " 2024-01-01 08:30:00 ", "'2024-01-01 08:30:00'"},
+		{"oracle", "2024-01-01", "TO_DATE('2024-01-01', 'YYYY-MM-DD')"},
+		{"oracle", "2024-01-01 08:30:00", "TO_TIMESTAMP('2024-01-01 08:30:00', 'YYYY-MM-DD HH24:MI:SS')"},
+	}
+	for _, c := range cases {
+		got, err := archiveCutoffLiteral(c.dbType, c.cutoff)
+		if err != nil || got != c.want {
+			t.Errorf("archiveCutoffLiteral(%q, %q) = %q, %v; want %q", c.dbType, c.cutoff, got, err, c.want)
+		}
+	}
+	for _, bad := range []string{"", "08:30:00", "2024-01-01'; DROP TABLE t; --"} {
+		if _, err := archiveCutoffLiteral("mysql", bad); err == nil {
+			t.Errorf("cutoff %q should be rejected", bad)
+		}
+	}
+}
+
+func TestArchiveKeyPredicateComposite(t *testing.T) {
+	rows := []map[string]interface{}{{"A": int64(1), "b": "x'y"}, {"A": int64(2), "b": "z"}}
+	got, err := archiveKeyPredicate("postgres", []string{"a", "b"}, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `("a" = 1 AND "b" = 'x''y') OR ("a" = 2 AND "b" = 'z')`
+	if got != want {
+		t.Fatalf("predicate = %s, want %s", got, want)
+	}
+	if _, err := archiveKeyPredicate("postgres", []string{"id"}, []map[string]interface{}{{"id": nil}}); err == nil {
+		t.Fatal("nil key should be rejected")
+	}
+}
+
+// archiveFakeDB 模拟 MySQL 上的 events 表与归档表，按语句形态处理计数、分批读取、删除与写入。
+type archiveFakeDB struct {
+	db.Database
+	source        map[int64]string // id -> created_at
+	archive       map[int64]bool
+	archiveExists bool
+	dropInserts   bool
+}
+
+var archiveIDList = regexp.MustCompile(`IN \(([^)]*)\)`)
+
+func archiveParseIDs(query string) []int64 {
+	var ids []int64
+	if m := archiveIDList.FindStringSubmatch(query); m != nil {
+		for _, part := range strings.Split(m[1], ",") {
+			id, _ := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (f *archiveFakeDB) matched() []int64 {
+	var ids []int64
+	for id, created := range f.source {
+		if created < "2024-01-01" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (f *archiveFakeDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	if tableName == "events_archive" && !f.archiveExists {
+		return nil, fmt.Errorf("table not found")
+	}
+	return []connection.ColumnDefinition{{Name: "id", Key: "PRI"}, {Name: "created_at"}}, nil
+}
+
+func (f *archiveFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	switch {
+	case strings.HasPrefix(query, "SELECT COUNT(*) AS cnt FROM `events_archive`"):
+		n := 0
+		for _, id := range archiveParseIDs(query) {
+			if f.archive[id] {
+				n++
+			}
+		}
+		return []map[string]interface{}{{"cnt": int64(n)}}, []string{"cnt"}, nil
+	case strings.HasPrefix(query, "SELECT COUNT(*) AS cnt FROM `events` WHERE `created_at` < '2024-01-01'"):
+		return []map[string]interface{}{{"cnt": int64(len(f.matched()))}}, []string{"cnt"}, nil
+	case strings.HasPrefix(query, "SELECT * FROM `events` WHERE `created_at` < '2024-01-01' LIMIT 2"):
+		var rows []map[string]interface{}
+		for _, id := range f.matched() {
+			if len(rows) == 2 {
+				break
+			}
+			rows = append(rows, map[string]interface{}{"id": id, "created_at": f.source[id]})
+		}
+		return rows, []string{"id", "created_at"}, nil
+	}
+	return nil, nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+func (f *archiveFakeDB) Exec(query string) (int64, error) {
+	switch {
+	case query == "CREATE TABLE `events_archive` LIKE `events`":
+		f.archiveExists = true
+		return 0, nil
+	case strings.HasPrefix(query, "DELETE FROM `events` WHERE `id` IN"):
+		var n int64
+		for _, id := range archiveParseIDs(query) {
+			if _, ok := f.source[id]; ok {
+				delete(f.source, id)
+				n++
+			}
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("unexpected exec: %s", query)
+}
+
+func (f *archiveFakeDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
+	if tableName != "events_archive" {
+		return fmt.Errorf("unexpected table %s", tableName)
+	}
+	for i, row := range changes.Inserts {
+		if f.dropInserts && i == len(changes.Inserts)-1 {
+			continue
+		}
+		f.archive[row["id"].(int64)] = true
+	}
+	return nil
+}
+
+func newArchiveTestApp(t *testing.T, fake *archiveFakeDB) (*App, connection.ConnectionConfig) {
+	t.Helper()
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "archive-test", Port: 3306, User: "root"}
+	key := getCacheKey(applyCustomDriverType(buildRunConfigForDDL(config, "mysql", "shop")))
+	a.dbCache[key] = cachedDatabase{inst: fake, lastPing: time.Now()}
+	return a, config
+}
+
+func TestArchiveRowsCopiesVerifiesAndDeletesInBatches(t *testing.T) {
+	fake := &archiveFakeDB{source: map[int64]string{}, archive: map[int64]bool{}}
+	for i := int64(1); i <= 6; i++ {
+		fake.source[i] = fmt.Sprintf("2023-12-%02d", 24+i)
+	}
+	fake.source[7] = "2024-01-02"
+	a, config := newArchiveTestApp(t, fake)
+
+	req := ArchiveRowsRequest{Table: "events", DateColumn: "created_at", Cutoff: "2024-01-01", BatchSize: 2, DryRun: true}
+	res := a.ArchiveRows(config, "shop", req)
+	if dry, ok := res.Data.(*ArchiveRowsResult); !res.Success || !ok || dry.Matched != 6 || dry.TargetExists {
+		t.Fatalf("unexpected dry run: %+v", res)
+	}
+	if len(fake.source) != 7 {
+		t.Fatal("dry run must not modify data")
+	}
+
+	req.DryRun = false
+	if res := a.ArchiveRows(config, "shop", req); res.Success {
+		t.Fatal("missing target without CreateTarget should fail")
+	}
+	req.CreateTarget = true
+	res = a.ArchiveRows(config, "shop", req)
+	if !res.Success {
+		t.Fatalf("archive failed: %s", res.Message)
+	}
+	result := res.Data.(*ArchiveRowsResult)
+	if result.Copied != 6 || result.Deleted != 6 || result.Batches != 3 || !result.Created {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(fake.source) != 1 || len(fake.archive) != 6 {
+		t.Fatalf("source=%v archive=%v", fake.source, fake.archive)
+	}
+}
+
+func TestArchiveRowsStopsWhenVerificationFails(t *testing.T) {
+	fake := &archiveFakeDB{source: map[int64]string{1: "2023-01-01", 2: "2023-01-02"}, archive: map[int64]bool{}, archiveExists: true, dropInserts: true}
+	a, config := newArchiveTestApp(t, fake)
+	res := a.ArchiveRows(config, "shop", ArchiveRowsRequest{Table: "events", DateColumn: "created_at", Cutoff: "2024-01-01", BatchSize: 2})
+	if res.Success || !strings.Contains(res.Message, "校验失败") {
+		t.Fatalf("expected verification failure, got %+v", res)
+	}
+	if len(fake.source) != 2 {
+		t.Fatal("source rows must not be deleted when verification fails")
+	}
+}
@@ -0,0 +1,860 @@
+package app
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	backupProgressEvent    = "backup:progress"
+	defaultBackupBatchSize = 200
+	maxBackupBatchSize     = 5000
+	backupReadPageSize     = 5000
+)
+
+// BackupOptions 逻辑备份选项。
+type BackupOptions struct {
+	FilePath        string   `json:"filePath,omitempty"` // 为空时弹出保存对话框
+	Tables          []string `json:"tables,omitempty"`   // 为空表示全部表；PostgreSQL 可写 schema.table
+	IncludeData     bool     `json:"includeData"`
+	DropIfExists    bool     `json:"dropIfExists,omitempty"`
+	IncludeViews    bool     `json:"includeViews,omitempty"`
+	IncludeRoutines bool     `json:"includeRoutines,omitempty"`
+	IncludeTriggers bool     `json:"includeTriggers,omitempty"`
+	BatchSize       int      `json:"batchSize,omitempty"` // 每条 INSERT 合并的行数，默认 200
+	Gzip            bool     `json:"gzip,omitempty"`
+	JobID           string   `json:"jobId,omitempty"`
+}
+
+// BackupResult 备份结果统计。
+type BackupResult struct {
+	FilePath string `json:"filePath"`
+	Tables   int    `json:"tables"`
+	Views    int    `json:"views"`
+	Routines int    `json:"routines"`
+	Triggers int    `json:"triggers"`
+	Rows     int64  `json:"rows"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// backupObject 备份目录中的一个对象；DDL 非空时为已查询到的定义。
+type backupObject struct {
+	schema string
+	name   string
+	table  string // 触发器/索引所属表
+	kind   string // 存储过程/函数：PROCEDURE、FUNCTION
+	ddl    string
+}
+
+type backupCatalog struct {
+	schemas   []string
+	sequences []backupObject
+	tables    []backupObject
+	views     []backupObject
+	routines  []backupObject
+	triggers  []backupObject
+	indexes   []backupObject
+}
+
+// backupFamily 返回备份支持的方言族：mysql、postgres、sqlite，不支持时为空。
+func backupFamily(dbType string) string {
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		return "mysql"
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return "postgres"
+	case "sqlite":
+		return "sqlite"
+	}
+	return ""
+}
+
+// backupRowString 按列名取字符串值，精确匹配优先，其次忽略大小写。
+func backupRowString(row map[string]interface{}, key string) string {
+	value, ok := row[key]
+	if !ok {
+		for k, v := range row {
+			if strings.EqualFold(k, key) {
+				value, ok = v, true
+				break
+			}
+		}
+	}
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+const pgBackupSchemaFilter = "NOT IN ('pg_catalog', 'information_schema') AND %s NOT LIKE 'pg_toast%%' AND %s NOT LIKE 'pg_temp%%'"
+
+func pgSchemaFilter(column string) string {
+	return column + " " + fmt.Sprintf(pgBackupSchemaFilter, column, column)
+}
+
+// listBackupCatalog 读取需要备份的对象清单。
+func listBackupCatalog(inst db.Database, family string, dbName string, opts BackupOptions) (backupCatalog, error) {
+	var catalog backupCatalog
+	query := func(sql string) ([]map[string]interface{}, error) {
+		data, _, err := inst.Query(sql)
+		return data, err
+	}
+
+	switch family {
+	case "mysql":
+		schema := dialect.QuoteLiteral(family, dbName)
+		rows, err := query(fmt.Sprintf("SELECT TABLE_NAME AS name, TABLE_TYPE AS type FROM information_schema.TABLES WHERE TABLE_SCHEMA = %s ORDER BY TABLE_NAME", schema))
+		if err != nil {
+			return catalog, err
+		}
+		for _, row := range rows {
+			obj := backupObject{schema: dbName, name: backupRowString(row, "name")}
+			if strings.EqualFold(backupRowString(row, "type"), "VIEW") {
+				catalog.views = append(catalog.views, obj)
+			} else {
+				catalog.tables = append(catalog.tables, obj)
+			}
+		}
+		if opts.IncludeRoutines {
+			rows, err := query(fmt.Sprintf("SELECT ROUTINE_NAME AS name, ROUTINE_TYPE AS type FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA = %s ORDER BY ROUTINE_TYPE, ROUTINE_NAME", schema))
+			if err != nil {
+				return catalog, err
+			}
+			for _, row := range rows {
+				catalog.routines = append(catalog.routines, backupObject{schema: dbName, name: backupRowString(row, "name"), kind: strings.ToUpper(backupRowString(row, "type"))})
+			}
+		}
+		if opts.IncludeTriggers {
+			rows, err := query(fmt.Sprintf("SELECT TRIGGER_NAME AS name, EVENT_OBJECT_TABLE AS tbl FROM information_schema.TRIGGERS WHERE TRIGGER_SCHEMA = %s ORDER BY EVENT_OBJECT_TABLE, ACTION_ORDER", schema))
+			if err != nil {
+				return catalog, err
+			}
+			for _, row := range rows {
+				catalog.triggers = append(catalog.triggers, backupObject{schema: dbName, name: backupRowString(row, "name"), table: backupRowString(row, "tbl")})
+			}
+		}
+
+	case "postgres":
+		rows, err := query(fmt.Sprintf("SELECT table_schema AS schema_name, table_name AS name, table_type AS type FROM information_schema.tables WHERE %s AND table_type IN ('BASE TABLE', 'VIEW') ORDER BY table_schema, table_name", pgSchemaFilter("table_schema")))
+		if err != nil {
+			return catalog, err
+		}
+		seenSchema := map[string]bool{}
+		for _, row := range rows {
+			obj := backupObject{schema: backupRowString(row, "schema_name"), name: backupRowString(row, "name")}
+			if !seenSchema[obj.schema] {
+				seenSchema[obj.schema] = true
+				catalog.schemas = append(catalog.schemas, obj.schema)
+			}
+			if strings.EqualFold(backupRowString(row, "type"), "VIEW") {
+				catalog.views = append(catalog.views, obj)
+			} else {
+				catalog.tables = append(catalog.tables, obj)
+			}
+		}
+		rows, err = query(fmt.Sprintf("SELECT sequence_schema AS schema_name, sequence_name AS name FROM information_schema.sequences WHERE %s ORDER BY 1, 2", pgSchemaFilter("sequence_schema")))
+		if err != nil {
+			return catalog, err
+		}
+		for _, row := range rows {
+			catalog.sequences = append(catalog.sequences, backupObject{schema: backupRowString(row, "schema_name"), name: backupRowString(row, "name")})
+		}
+		rows, err = query(fmt.Sprintf(`SELECT n.nspname AS schema_name, t.relname AS table_name, i.relname AS name, pg_get_indexdef(x.indexrelid) AS ddl
+FROM pg_index x
+JOIN pg_class i ON i.oid = x.indexrelid
+JOIN pg_class t ON t.oid = x.indrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE %s AND NOT x.indisprimary
+  AND NOT EXISTS (SELECT 1 FROM pg_constraint con WHERE con.conindid = x.indexrelid)
+ORDER BY 1, 2, 3`, pgSchemaFilter("n.nspname")))
+		if err != nil {
+			return catalog, err
+		}
+		for _, row := range rows {
+			catalog.indexes = append(catalog.indexes, backupObject{schema: backupRowString(row, "schema_name"), table: backupRowString(row, "table_name"), name: backupRowString(row, "name"), ddl: backupRowString(row, "ddl")})
+		}
+		if opts.IncludeRoutines {
+			// 排除聚合函数（pg_get_functiondef 不支持）与扩展自带的函数
+			rows, err := query(fmt.Sprintf(`SELECT n.nspname AS schema_name, p.proname AS name, pg_get_functiondef(p.oid) AS ddl
+FROM pg_proc p
+JOIN pg_namespace n ON n.oid = p.pronamespace
+LEFT JOIN pg_aggregate ag ON ag.aggfnoid = p.oid
+WHERE %s AND ag.aggfnoid IS NULL
+  AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.objid = p.oid AND d.deptype = 'e')
+ORDER BY 1, 2`, pgSchemaFilter("n.nspname")))
+			if err != nil {
+				return catalog, err
+			}
+			for _, row := range rows {
+				catalog.routines = append(catalog.routines, backupObject{schema: backupRowString(row, "schema_name"), name: backupRowString(row, "name"), ddl: backupRowString(row, "ddl")})
+			}
+		}
+		if opts.IncludeTriggers {
+			rows, err := query(fmt.Sprintf(`SELECT n.nspname AS schema_name, c.relname AS table_name, t.tgname AS name, pg_get_triggerdef(t.oid, true) AS ddl
+FROM pg_trigger t
+JOIN pg_class c ON c.oid = t.tgrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE NOT t.tgisinternal AND %s
+ORDER BY 1, 2, 3`, pgSchemaFilter("n.nspname")))
+			if err != nil {
+				return catalog, err
+			}
+			for _, row := range rows {
+				catalog.triggers = append(catalog.triggers, backupObject{schema: backupRowString(row, "schema_name"), table: backupRowString(row, "table_name"), name: backupRowString(row, "name"), ddl: backupRowString(row, "ddl")})
+			}
+		}
+
+	case "sqlite":
+		rows, err := query("SELECT type, name, tbl_name, sql FROM sqlite_master WHERE name NOT LIKE 'sqlite_%' AND sql IS NOT NULL ORDER BY name")
+		if err != nil {
+			return catalog, err
+		}
+		for _, row := range rows {
+			obj := backupObject{name: backupRowString(row, "name"), table: backupRowString(row, "tbl_name"), ddl: backupRowString(row, "sql")}
+			switch strings.ToLower(backupRowString(row, "type")) {
+			case "table":
+				catalog.tables = append(catalog.tables, obj)
+			case "view":
+				catalog.views = append(catalog.views, obj)
+			case "index":
+				catalog.indexes = append(catalog.indexes, obj)
+			case "trigger":
+				if opts.IncludeTriggers {
+					catalog.triggers = append(catalog.triggers, obj)
+				}
+			}
+		}
+	}
+
+	if !opts.IncludeViews {
+		catalog.views = nil
+	}
+	if len(opts.Tables) > 0 {
+		catalog.filterTables(opts.Tables)
+	}
+	return catalog, nil
+}
+
+// filterTables 只保留指定的表及其索引、触发器。
+func (c *backupCatalog) filterTables(names []string) {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			wanted[name] = true
+		}
+	}
+	match := func(schema, table string) bool {
+		return wanted[strings.ToLower(table)] || wanted[strings.ToLower(qualifyTable(schema, table))]
+	}
+	tables := c.tables[:0]
+	for _, t := range c.tables {
+		if match(t.schema, t.name) {
+			tables = append(tables, t)
+		}
+	}
+	c.tables = tables
+	keep := func(objs []backupObject) []backupObject {
+		out := objs[:0]
+		for _, o := range objs {
+			if match(o.schema, o.table) {
+				out = append(out, o)
+			}
+		}
+		return out
+	}
+	c.indexes = keep(c.indexes)
+	c.triggers = keep(c.triggers)
+}
+
+// backupSQLValue 生成备份用字面量：比 formatSQLValue 更严格地保留精度与转义，保证可原样恢复。
+// binary 表示列定义为二进制类型：驱动把其中的非文本字节转为 0x 十六进制字符串，需要还原后按二进制字面量写出；
+// 其他列的字符串即使形如 0x.. 也按文本写出。
+func backupSQLValue(family string, dbType string, v interface{}, binary bool) string {
+	if binary {
+		if raw, ok := binaryValueBytes(v); ok {
+			v = raw
+		}
+	}
+	switch val := v.(type) {
+	case bool:
+		if family == "postgres" {
+			if val {
+				return "TRUE"
+			}
+			return "FALSE"
+		}
+	case []byte:
+		switch family {
+		case "mysql":
+			if len(val) == 0 {
+				return "''"
+			}
+			return "0x" + hex.EncodeToString(val)
+		case "postgres":
+			return `'\x` + hex.EncodeToString(val) + "'::bytea"
+		default:
+			return "X'" + hex.EncodeToString(val) + "'"
+		}
+	case time.Time:
+		if family == "postgres" {
+			return "'" + val.Format("2006-01-02 15:04:05.999999-07:00") + "'"
+		}
+		return "'" + val.Format("2006-01-02 15:04:05.999999") + "'"
+	case string:
+		// MySQL 默认把反斜杠当转义符，需额外转义
+		if family == "mysql" {
+			return "'" + strings.ReplaceAll(strings.ReplaceAll(val, `\`, `\\`), "'", "''") + "'"
+		}
+	}
+	return formatSQLValue(dbType, v)
+}
+
+// backupWriter 负责写出备份脚本并统计进度。
+type backupWriter struct {
+	app    *App
+	inst   db.Database
+	w      *bufio.Writer
+	dbType string
+	family string
+	opts   BackupOptions
+	result BackupResult
+
+	current int
+	total   int
+}
+
+func (b *backupWriter) printf(format string, args ...interface{}) error {
+	_, err := fmt.Fprintf(b.w, format, args...)
+	return err
+}
+
+func (b *backupWriter) progress(object string, stage string) {
+	if b.app == nil || b.app.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(b.app.ctx, backupProgressEvent, map[string]interface{}{
+		"jobId":   b.opts.JobID,
+		"current": b.current,
+		"total":   b.total,
+		"object":  object,
+		"stage":   stage,
+		"rows":    b.result.Rows,
+	})
+}
+
+// objectIdent 返回脚本中使用的对象名：MySQL/SQLite 不带库名，便于恢复到其他库；PostgreSQL 带 schema。
+func (b *backupWriter) objectIdent(schema, name string) string {
+	if b.family == "postgres" {
+		return quoteTableIdentByType(b.dbType, schema, name)
+	}
+	return quoteIdentByType(b.dbType, name)
+}
+
+func (b *backupWriter) section(kind, name string) error {
+	return b.printf("\n-- ----------------------------\n-- %s: %s\n-- ----------------------------\n", kind, name)
+}
+
+func (b *backupWriter) writeHeader(dbName string) error {
+	if err := b.printf("-- GoNavi SQL Backup\n-- Time: %s\n-- Type: %s\n-- Database: %s\n\n", time.Now().Format("2006-01-02 15:04:05"), b.dbType, dbName); err != nil {
+		return err
+	}
+	switch b.family {
+	case "mysql":
+		return b.printf("SET NAMES utf8mb4;\nSET FOREIGN_KEY_CHECKS=0;\n")
+	case "postgres":
+		return b.printf("SET client_encoding = 'UTF8';\nSET standard_conforming_strings = on;\n")
+	case "sqlite":
+		return b.printf("PRAGMA foreign_keys=OFF;\nBEGIN TRANSACTION;\n")
+	}
+	return nil
+}
+
+func (b *backupWriter) writeFooter() error {
+	switch b.family {
+	case "mysql":
+		return b.printf("\nSET FOREIGN_KEY_CHECKS=1;\n")
+	case "sqlite":
+		return b.printf("\nCOMMIT;\nPRAGMA foreign_keys=ON;\n")
+	}
+	return nil
+}
+
+func (b *backupWriter) writeTable(t backupObject) error {
+	display := qualifyTable(t.schema, t.name)
+	if b.family == "sqlite" {
+		display = t.name
+	}
+	b.progress(display, "结构")
+	if err := b.section("Table", display); err != nil {
+		return err
+	}
+	if b.opts.DropIfExists {
+		cascade := ""
+		if b.family == "postgres" {
+			cascade = " CASCADE"
+		}
+		if err := b.printf("DROP TABLE IF EXISTS %s%s;\n", b.objectIdent(t.schema, t.name), cascade); err != nil {
+			return err
+		}
+	}
+	ddl := t.ddl
+	if ddl == "" {
+		var err error
+		if ddl, err = backupTableDDL(b.inst, b.dbType, t.schema, t.name); err != nil {
+			return fmt.Errorf("读取表 %s 结构失败：%w", display, err)
+		}
+	}
+	if err := b.printf("%s\n", ensureSQLTerminator(normalizeDDL(b.dbType, ddl))); err != nil {
+		return err
+	}
+	b.result.Tables++
+	if !b.opts.IncludeData {
+		return nil
+	}
+	b.progress(display, "数据")
+	return b.writeTableData(t, display)
+}
+
+// writeTableData 读取表数据并按批写出 INSERT：有主键时按主键续读分页，保证结果稳定且越往后读取不会变慢；
+// 没有主键时在专用连接上流式读取整张表，不把全部数据载入内存。
+func (b *backupWriter) writeTableData(t backupObject, display string) error {
+	readIdent := quoteTableIdentByType(b.dbType, t.schema, t.name)
+	if b.family == "sqlite" {
+		readIdent = quoteIdentByType(b.dbType, t.name)
+	}
+	var keys []string
+	skip := map[string]bool{}
+	binary := map[string]bool{}
+	overriding := ""
+	if defs, err := b.inst.GetColumns(t.schema, t.name); err == nil {
+		for _, def := range defs {
+			if strings.EqualFold(def.Key, "PRI") {
+				keys = append(keys, def.Name)
+			}
+			// 生成列由数据库计算，不能显式写入
+			if def.Generated != "" {
+				skip[strings.ToLower(def.Name)] = true
+			}
+			if isBinaryColumnType(def.Type) {
+				binary[strings.ToLower(def.Name)] = true
+			}
+			if b.family == "postgres" && strings.EqualFold(def.Identity, "ALWAYS") {
+				overriding = " OVERRIDING SYSTEM VALUE"
+			}
+		}
+	}
+
+	batchSize := b.opts.BatchSize
+	var columns []string
+	var insertPrefix string
+	var batch []string
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := b.printf("%s\n%s;\n", insertPrefix, strings.Join(batch, ",\n"))
+		batch = batch[:0]
+		return err
+	}
+	writeRow := func(cols []string, row map[string]interface{}) error {
+		if columns == nil {
+			for _, c := range cols {
+				if !skip[strings.ToLower(c)] {
+					columns = append(columns, c)
+				}
+			}
+			quoted := make([]string, 0, len(columns))
+			for _, c := range columns {
+				quoted = append(quoted, quoteIdentByType(b.dbType, c))
+			}
+			insertPrefix = fmt.Sprintf("INSERT INTO %s (%s)%s VALUES", b.objectIdent(t.schema, t.name), strings.Join(quoted, ", "), overriding)
+		}
+		values := make([]string, 0, len(columns))
+		for _, c := range columns {
+			values = append(values, backupSQLValue(b.family, b.dbType, row[c], binary[strings.ToLower(c)]))
+		}
+		batch = append(batch, "("+strings.Join(values, ", ")+")")
+		b.result.Rows++
+		if len(batch) >= batchSize {
+			return flush()
+		}
+		return nil
+	}
+
+	if len(keys) == 0 {
+		if err := b.streamTableData(fmt.Sprintf("SELECT * FROM %s", readIdent), writeRow); err != nil {
+			return fmt.Errorf("读取表 %s 数据失败：%w", display, err)
+		}
+		return flush()
+	}
+
+	var after []string
+	for {
+		data, cols, err := b.inst.Query(buildKeysetPageSQL(b.dbType, readIdent, keys, after, backupReadPageSize))
+		if err != nil {
+			return fmt.Errorf("读取表 %s 数据失败：%w", display, err)
+		}
+		for _, row := range data {
+			if err := writeRow(cols, row); err != nil {
+				return err
+			}
+		}
+		if len(data) < backupReadPageSize {
+			break
+		}
+		last := data[len(data)-1]
+		after = make([]string, 0, len(keys))
+		for _, key := range keys {
+			v, _ := dataCompareColumn(last, key)
+			after = append(after, backupSQLValue(b.family, b.dbType, v, binary[strings.ToLower(key)]))
+		}
+		b.progress(display, "数据")
+	}
+	return flush()
+}
+
+// streamTableData 在专用连接上逐行读取查询结果；驱动不支持会话时退回一次性读取。
+func (b *backupWriter) streamTableData(query string, fn func(cols []string, row map[string]interface{}) error) error {
+	opener, ok := b.inst.(db.SessionOpener)
+	if !ok {
+		data, cols, err := b.inst.Query(query)
+		if err != nil {
+			return err
+		}
+		for _, row := range data {
+			if err := fn(cols, row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	session, err := opener.OpenSession(context.Background())
+	if err != nil {
+		return err
+	}
+	defer session.Release()
+	_, err = session.QueryEach(context.Background(), query, fn)
+	return err
+}
+
+func (b *backupWriter) writeView(v backupObject) error {
+	display := qualifyTable(v.schema, v.name)
+	if b.family == "sqlite" {
+		display = v.name
+	}
+	b.progress(display, "视图")
+	if err := b.section("View", display); err != nil {
+		return err
+	}
+	if b.opts.DropIfExists {
+		if err := b.printf("DROP VIEW IF EXISTS %s;\n", b.objectIdent(v.schema, v.name)); err != nil {
+			return err
+		}
+	}
+	ddl := v.ddl
+	if ddl == "" {
+		var err error
+		if ddl, err = backupViewDDL(b.inst, b.dbType, v.schema, v.name); err != nil {
+			return fmt.Errorf("读取视图 %s 定义失败：%w", display, err)
+		}
+	}
+	b.result.Views++
+	return b.printf("%s\n", ensureSQLTerminator(strings.TrimSpace(ddl)))
+}
+
+// showCreateMySQL 执行 SHOW CREATE PROCEDURE/FUNCTION/TRIGGER 并取定义列；权限不足时定义为 NULL。
+func (b *backupWriter) showCreateMySQL(kind string, schema string, name string, column string) (string, error) {
+	data, _, err := b.inst.Query(fmt.Sprintf("SHOW CREATE %s %s", kind, quoteTableIdentByType(b.dbType, schema, name)))
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 0 {
+		return "", nil
+	}
+	return strings.TrimSpace(backupRowString(data[0], column)), nil
+}
+
+// writeMySQLBlock 用 DELIMITER ;; 包裹含分号的过程体，与 mysqldump 输出一致。
+func (b *backupWriter) writeMySQLBlock(drop string, ddl string) error {
+	if b.opts.DropIfExists {
+		if err := b.printf("%s;\n", drop); err != nil {
+			return err
+		}
+	}
+	return b.printf("DELIMITER ;;\n%s ;;\nDELIMITER ;\n", strings.TrimSuffix(ddl, ";"))
+}
+
+func (b *backupWriter) writeRoutine(r backupObject) error {
+	display := qualifyTable(r.schema, r.name)
+	b.progress(display, "存储过程")
+	if err := b.section("Routine", display); err != nil {
+		return err
+	}
+	if b.family == "mysql" {
+		kind := "PROCEDURE"
+		if r.kind == "FUNCTION" {
+			kind = "FUNCTION"
+		}
+		column := "Create Procedure"
+		if kind == "FUNCTION" {
+			column = "Create Function"
+		}
+		ddl, err := b.showCreateMySQL(kind, r.schema, r.name, column)
+		if err != nil {
+			return fmt.Errorf("读取%s %s 定义失败：%w", kind, display, err)
+		}
+		if ddl == "" {
+			return b.printf("-- 无权限读取定义，已跳过\n")
+		}
+		b.result.Routines++
+		return b.writeMySQLBlock(fmt.Sprintf("DROP %s IF EXISTS %s", kind, quoteIdentByType(b.dbType, r.name)), ddl)
+	}
+	// pg_get_functiondef 输出 CREATE OR REPLACE，无需额外 DROP
+	b.result.Routines++
+	return b.printf("%s\n", ensureSQLTerminator(strings.TrimSpace(r.ddl)))
+}
+
+func (b *backupWriter) writeTrigger(t backupObject) error {
+	display := qualifyTable(t.schema, t.name)
+	if b.family == "sqlite" {
+		display = t.name
+	}
+	b.progress(display, "触发器")
+	if err := b.section("Trigger", display); err != nil {
+		return err
+	}
+	switch b.family {
+	case "mysql":
+		ddl, err := b.showCreateMySQL("TRIGGER", t.schema, t.name, "SQL Original Statement")
+		if err != nil {
+			return fmt.Errorf("读取触发器 %s 定义失败：%w", display, err)
+		}
+		if ddl == "" {
+			return b.printf("-- 无权限读取定义，已跳过\n")
+		}
+		b.result.Triggers++
+		return b.writeMySQLBlock(fmt.Sprintf("DROP TRIGGER IF EXISTS %s", quoteIdentByType(b.dbType, t.name)), ddl)
+	case "postgres":
+		if b.opts.DropIfExists {
+			if err := b.printf("DROP TRIGGER IF EXISTS %s ON %s;\n", quoteIdentByType(b.dbType, t.name), b.objectIdent(t.schema, t.table)); err != nil {
+				return err
+			}
+		}
+	default:
+		if b.opts.DropIfExists {
+			if err := b.printf("DROP TRIGGER IF EXISTS %s;\n", quoteIdentByType(b.dbType, t.name)); err != nil {
+				return err
+			}
+		}
+	}
+	b.result.Triggers++
+	return b.printf("%s\n", ensureSQLTerminator(strings.TrimSpace(t.ddl)))
+}
+
+// writeSequences 写出 PostgreSQL 序列：建表前创建，数据写完后用 setval 恢复当前值。
+func (b *backupWriter) writeSequences(seqs []backupObject, restoreValues bool) error {
+	for _, s := range seqs {
+		ident := quoteTableIdentByType(b.dbType, s.schema, s.name)
+		if !restoreValues {
+			if err := b.printf("CREATE SEQUENCE IF NOT EXISTS %s;\n", ident); err != nil {
+				return err
+			}
+			continue
+		}
+		data, _, err := b.inst.Query(fmt.Sprintf("SELECT last_value, is_called FROM %s", ident))
+		if err != nil || len(data) == 0 {
+			continue
+		}
+		isCalled := strings.EqualFold(backupRowString(data[0], "is_called"), "true")
+		if err := b.printf("SELECT setval(%s, %s, %t);\n", dialect.QuoteLiteral(b.dbType, ident), backupRowString(data[0], "last_value"), isCalled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *backupWriter) run(dbName string, catalog backupCatalog) error {
+	b.total = len(catalog.tables) + len(catalog.views) + len(catalog.routines) + len(catalog.triggers)
+	if err := b.writeHeader(dbName); err != nil {
+		return err
+	}
+	for _, schema := range catalog.schemas {
+		if schema == "public" {
+			continue
+		}
+		if err := b.printf("CREATE SCHEMA IF NOT EXISTS %s;\n", quoteIdentByType(b.dbType, schema)); err != nil {
+			return err
+		}
+	}
+	if err := b.writeSequences(catalog.sequences, false); err != nil {
+		return err
+	}
+	for _, t := range catalog.tables {
+		if err := b.writeTable(t); err != nil {
+			return err
+		}
+		b.current++
+	}
+	if len(catalog.indexes) > 0 {
+		if err := b.section("Indexes", dbName); err != nil {
+			return err
+		}
+		for _, idx := range catalog.indexes {
+			if err := b.printf("%s\n", ensureSQLTerminator(strings.TrimSpace(idx.ddl))); err != nil {
+				return err
+			}
+		}
+	}
+	if b.opts.IncludeData && len(catalog.sequences) > 0 {
+		if err := b.section("Sequence values", dbName); err != nil {
+			return err
+		}
+		if err := b.writeSequences(catalog.sequences, true); err != nil {
+			return err
+		}
+	}
+	// 函数先于视图与触发器写出：视图可能调用函数，PostgreSQL 触发器依赖触发器函数
+	for _, r := range catalog.routines {
+		if err := b.writeRoutine(r); err != nil {
+			return err
+		}
+		b.current++
+	}
+	for _, v := range catalog.views {
+		if err := b.writeView(v); err != nil {
+			return err
+		}
+		b.current++
+	}
+	for _, t := range catalog.triggers {
+		if err := b.writeTrigger(t); err != nil {
+			return err
+		}
+		b.current++
+	}
+	return b.writeFooter()
+}
+
+// countingWriter 统计写入文件的字节数（压缩后）。
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// BackupDatabase 生成 mysqldump 风格的逻辑备份脚本（建表 + 批量 INSERT，可选 DROP IF EXISTS、视图、存储过程与触发器），
+// 支持 MySQL 系、PostgreSQL 系与 SQLite，进度通过 backup:progress 事件推送，可选 gzip 压缩。
+func (a *App) BackupDatabase(config connection.ConnectionConfig, dbName string, opts BackupOptions) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbType := resolveDDLDBType(runConfig)
+	family := backupFamily(dbType)
+	if family == "" {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源(%s)暂不支持逻辑备份", dbType)}
+	}
+	if family == "mysql" && strings.TrimSpace(dbName) == "" {
+		return connection.QueryResult{Success: false, Message: "请选择要备份的数据库"}
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBackupBatchSize
+	}
+	if opts.BatchSize > maxBackupBatchSize {
+		opts.BatchSize = maxBackupBatchSize
+	}
+
+	filename := strings.TrimSpace(opts.FilePath)
+	if filename == "" {
+		base := strings.TrimSpace(dbName)
+		if base == "" {
+			base = "backup"
+		}
+		ext := ".sql"
+		if opts.Gzip {
+			ext = ".sql.gz"
+		}
+		var err error
+		filename, err = runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+			Title:           fmt.Sprintf("Backup %s", base),
+			DefaultFilename: fmt.Sprintf("%s_%s%s", snapshotNameSanitizer.ReplaceAllString(base, "_"), time.Now().Format("20060102_150405"), ext),
+		})
+		if err != nil || filename == "" {
+			return connection.QueryResult{Success: false, Message: "Cancelled"}
+		}
+	}
+
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		logger.Error(err, "BackupDatabase 获取连接失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	catalog, err := listBackupCatalog(dbInst, family, dbName, opts)
+	if err != nil {
+		logger.Error(err, "BackupDatabase 读取对象清单失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: "读取对象清单失败：" + err.Error()}
+	}
+
+	result, err := a.writeBackupFile(filename, dbInst, dbType, family, dbName, opts, catalog)
+	if err != nil {
+		logger.Error(err, "BackupDatabase 备份失败：%s 文件=%s", formatConnSummary(runConfig), filename)
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	logger.Infof("数据库备份完成：%s 文件=%s 表=%d 行=%d", formatConnSummary(runConfig), filename, result.Tables, result.Rows)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("备份完成：%d 张表，%d 行", result.Tables, result.Rows), Data: result}
+}
+
+// writeBackupFile 先写入临时文件，成功后再改名，避免失败时留下不完整的备份。
+func (a *App) writeBackupFile(filename string, dbInst db.Database, dbType string, family string, dbName string, opts BackupOptions, catalog backupCatalog) (BackupResult, error) {
+	tmpPath := filename + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return BackupResult{}, err
+	}
+	counter := &countingWriter{w: f}
+	var out io.Writer = counter
+	var gz *gzip.Writer
+	if opts.Gzip {
+		gz = gzip.NewWriter(counter)
+		out = gz
+	}
+	bw := &backupWriter{app: a, inst: dbInst, w: bufio.NewWriterSize(out, 1024*1024), dbType: dbType, family: family, opts: opts}
+
+	err = bw.run(dbName, catalog)
+	if err == nil {
+		err = bw.w.Flush()
+	}
+	if err == nil && gz != nil {
+		err = gz.Close()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, filename)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return BackupResult{}, err
+	}
+	bw.progress("", "完成")
+	bw.result.FilePath = filename
+	bw.result.Bytes = counter.n
+	return bw.result, nil
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+// backupFakeDB 模拟 SQLite：一张带生成列的表、一个索引和一个触发器。
+type backupFakeDB struct {
+	db.Database
+	queries []string
+}
+
+func (f *backupFakeDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	return []connection.ColumnDefinition{
+		{Name: "id", Key: "PRI"},
+		{Name: "name"},
+		{Name: "upper_name", Generated: "VIRTUAL"},
+	}, nil
+}
+
+func (f *backupFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	f.queries = append(f.queries, query)
+	if strings.Contains(query, "sqlite_master") {
+		return []map[string]interface{}{
+			{"type": "table", "name": "users", "tbl_name": "users", "sql": "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, upper_name TEXT AS (upper(name)))"},
+			{"type": "index", "name": "idx_users_name", "tbl_name": "users", "sql": "CREATE INDEX idx_users_name ON users(name)"},
+			{"type": "trigger", "name": "trg_users", "tbl_name": "users", "sql": "CREATE TRIGGER trg_users AFTER INSERT ON users BEGIN SELECT 1; END"},
+			{"type": "table", "name": "logs", "tbl_name": "logs", "sql": "CREATE TABLE logs (id INTEGER)"},
+		}, []string{"type", "name", "tbl_name", "sql"}, nil
+	}
+	if strings.Contains(query, "LIMIT") && !strings.Contains(query, "WHERE") {
+		return []map[string]interface{}{
+			{"id": int64(1), "name": "it's", "upper_name": "IT'S"},
+			{"id": int64(2), "name": nil, "upper_name": nil},
+			{"id": int64(3), "name": []byte{0xca, 0xfe}, "upper_name": nil},
+		}, []string{"id", "name", "upper_name"}, nil
+	}
+	return nil, []string{"id", "name", "upper_name"}, nil
+}
+
+func TestWriteBackupFileSQLite(t *testing.T) {
+	fake := &backupFakeDB{}
+	opts := BackupOptions{Tables: []string{"users"}, IncludeData: true, IncludeTriggers: true, DropIfExists: true, BatchSize: 2, Gzip: true}
+	catalog, err := listBackupCatalog(fake, "sqlite", "", opts)
+	if err != nil {
+		t.Fatalf("listBackupCatalog: %v", err)
+	}
+	if len(catalog.tables) != 1 || len(catalog.indexes) != 1 || len(catalog.triggers) != 1 {
+		t.Fatalf("unexpected catalog: %+v", catalog)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.sql.gz")
+	result, err := (&App{}).writeBackupFile(path, fake, "sqlite", "sqlite", "", opts, catalog)
+	if err != nil {
+		t.Fatalf("writeBackupFile: %v", err)
+	}
+	if result.Tables != 1 || result.Triggers != 1 || result.Rows != 3 || result.Bytes == 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("temp file should be renamed")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	raw, _ := io.ReadAll(gz)
+	text := string(raw)
+	for _, want := range []string{
+		"BEGIN TRANSACTION;",
+		`DROP TABLE IF EXISTS "users";`,
+		"CREATE TABLE users (\n  id INTEGER PRIMARY KEY,\n  name TEXT,\n  upper_name TEXT AS (upper(name))\n);",
+		"INSERT INTO \"users\" (\"id\", \"name\") VALUES\n(1, 'it''s'),\n(2, NULL);",
+		"INSERT INTO \"users\" (\"id\", \"name\") VALUES\n(3, X'cafe');",
+		"CREATE INDEX idx_users_name ON users(name);",
+		`DROP TRIGGER IF EXISTS "trg_users";`,
+		"COMMIT;",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("backup missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "logs") {
+		t.Fatalf("unselected table should be skipped:\n%s", text)
+	}
+	if strings.Index(text, "CREATE INDEX") < strings.Index(text, "INSERT INTO") {
+		t.Fatalf("indexes should be created after data:\n%s", text)
+	}
+}
+
+func TestBackupSQLValue(t *testing.T) {
+	cases := []struct {
+		family string
+		value  interface{}
+		binary bool
+		want   string
+	}{
+		{"mysql", `C:\dir's`, false, `'C:\\dir''s'`},
+		{"mysql", []byte{0x01, 0xff}, false, "0x01ff"},
+		{"mysql", "0xcafe", false, "'0xcafe'"},
+		{"mysql", "0xcafe", true, "0xcafe"},
+		{"postgres", true, false, "TRUE"},
+		{"postgres", []byte{0xab}, false, `'\xab'::bytea`},
+		{"postgres", "0xab", true, `'\xab'::bytea`},
+		{"sqlite", int64(5), false, "5"},
+	}
+	for _, c := range cases {
+		if got := backupSQLValue(c.family, c.family, c.value, c.binary); got != c.want {
+			t.Errorf("backupSQLValue(%s, %v) = %s, want %s", c.family, c.value, got, c.want)
+		}
+	}
+}
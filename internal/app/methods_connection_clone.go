@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/connectionstore"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/ssh"
+)
+
+// ConnectionOverrides 克隆连接或打开同服务器上其他数据库时要替换的字段，空值表示沿用原连接。
+type ConnectionOverrides struct {
+	Name     string `json:"name,omitempty"`
+	Database string `json:"database,omitempty"`
+	User     string `json:"user,omitempty"`
+	// Password 更换用户时为空则清空密码，不沿用原用户的密码。
+	Password string `json:"password,omitempty"`
+	RedisDB  *int   `json:"redisDB,omitempty"`
+	ReadOnly *bool  `json:"readOnly,omitempty"`
+}
+
+// SiblingDatabaseHandle 同服务器其他数据库的连接句柄，后续调用以 Config 作为连接配置。
+type SiblingDatabaseHandle struct {
+	Config   connection.ConnectionConfig `json:"config"`
+	Database string                      `json:"database"`
+	// SharedPool 与原连接使用同一个缓存连接池（如 Oracle 以 schema 区分，无需新建连接）。
+	SharedPool bool `json:"sharedPool"`
+	// SSHTunnel 使用的本地转发隧道；ReusedTunnel 表示复用了已有隧道而非新建。
+	SSHTunnel    string `json:"sshTunnel,omitempty"`
+	ReusedTunnel bool   `json:"reusedTunnel,omitempty"`
+}
+
+// applyConnectionOverrides 返回替换了数据库、用户等字段的配置副本。
+// 连接串（URI/DSN）会覆盖单独的字段，修改数据库或用户时清空 URI；自定义 DSN 连接无法安全改写，直接报错。
+func applyConnectionOverrides(config connection.ConnectionConfig, overrides ConnectionOverrides) (connection.ConnectionConfig, error) {
+	database := strings.TrimSpace(overrides.Database)
+	user := strings.TrimSpace(overrides.User)
+	if database == "" && user == "" && overrides.RedisDB == nil && overrides.ReadOnly == nil {
+		return config, nil
+	}
+	if (database != "" || user != "") && strings.TrimSpace(config.DSN) != "" {
+		return config, fmt.Errorf("使用自定义 DSN 的连接不支持替换数据库或用户，请直接修改 DSN")
+	}
+	if database != "" && strings.EqualFold(strings.TrimSpace(config.Type), "sqlite") {
+		return config, fmt.Errorf("SQLite 连接没有同服务器的其他数据库，请改用新的文件路径")
+	}
+
+	cloned := config
+	cloned.Hosts = append([]string(nil), config.Hosts...)
+	cloned.SSH.JumpHosts = append([]connection.SSHConfig(nil), config.SSH.JumpHosts...)
+	if database != "" {
+		cloned.Database = database
+		cloned.URI = ""
+	}
+	if user != "" {
+		cloned.User = user
+		cloned.Password = overrides.Password
+		cloned.URI = ""
+	} else if overrides.Password != "" {
+		cloned.Password = overrides.Password
+	}
+	if overrides.RedisDB != nil {
+		cloned.RedisDB = *overrides.RedisDB
+	}
+	if overrides.ReadOnly != nil {
+		cloned.ReadOnly = *overrides.ReadOnly
+	}
+	return cloned, nil
+}
+
+// cloneConnectionProfile 基于已保存的连接生成新连接（ID 留空，由存储生成）。
+func cloneConnectionProfile(source connectionstore.Profile, overrides ConnectionOverrides) (connectionstore.Profile, error) {
+	config, err := applyConnectionOverrides(source.Config, overrides)
+	if err != nil {
+		return connectionstore.Profile{}, err
+	}
+	name := strings.TrimSpace(overrides.Name)
+	if name == "" {
+		switch {
+		case strings.TrimSpace(overrides.Database) != "":
+			name = fmt.Sprintf("%s - %s", source.Name, strings.TrimSpace(overrides.Database))
+		case strings.TrimSpace(overrides.User) != "":
+			name = fmt.Sprintf("%s (%s)", source.Name, strings.TrimSpace(overrides.User))
+		default:
+			name = source.Name + " 副本"
+		}
+	}
+	clone := connectionstore.Profile{
+		Name:                  name,
+		Config:                config,
+		IncludeDatabases:      append([]string(nil), source.IncludeDatabases...),
+		IncludeRedisDatabases: append([]int(nil), source.IncludeRedisDatabases...),
+	}
+	if source.DatabaseList != nil {
+		listOptions := *source.DatabaseList
+		clone.DatabaseList = &listOptions
+	}
+	// 连到了其他数据库时，原连接的数据库过滤不再适用
+	if strings.TrimSpace(overrides.Database) != "" {
+		clone.IncludeDatabases = nil
+	}
+	return clone, nil
+}
+
+// CloneConnection 复制已保存的连接并按 overrides 替换数据库、用户等字段，保存为新连接。
+func (a *App) CloneConnection(id string, overrides ConnectionOverrides) connection.QueryResult {
+	profiles, err := a.connStore.List()
+	if err != nil {
+		return connectionStoreError("读取已保存连接", err)
+	}
+	id = strings.TrimSpace(id)
+	for _, profile := range profiles {
+		if profile.ID != id {
+			continue
+		}
+		clone, err := cloneConnectionProfile(profile, overrides)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		saved, err := a.connStore.Save(clone)
+		if err != nil {
+			return connectionStoreError("保存连接", err)
+		}
+		logger.Infof("已复制连接：%s -> %s", profile.Name, saved.Name)
+		return connection.QueryResult{Success: true, Message: "连接已复制", Data: saved}
+	}
+	return connection.QueryResult{Success: false, Message: fmt.Sprintf("连接不存在：%s", id)}
+}
+
+// OpenSiblingDatabase 以当前连接的主机、账号与 SSH 设置打开同服务器上的另一个数据库。
+// SSH 隧道按跳板机与目标地址共享，新数据库会复用已有隧道；以 schema 区分数据库的类型直接复用原连接池。
+func (a *App) OpenSiblingDatabase(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	dbName = strings.TrimSpace(dbName)
+	if dbName == "" {
+		return connection.QueryResult{Success: false, Message: "数据库名不能为空"}
+	}
+	if strings.EqualFold(strings.TrimSpace(config.Type), "redis") {
+		return connection.QueryResult{Success: false, Message: "Redis 请通过 redisDB 切换数据库"}
+	}
+	if _, err := applyConnectionOverrides(config, ConnectionOverrides{Database: dbName}); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	runConfig := normalizeRunConfig(config, dbName)
+	key := getCacheKey(runConfig)
+	a.mu.RLock()
+	_, sharedPool := a.dbCache[key]
+	a.mu.RUnlock()
+	tunnelID := sshTunnelIDForConfig(runConfig)
+	reusedTunnel := false
+	if tunnelID != "" {
+		for _, status := range ssh.ListTunnels() {
+			if status.ID == tunnelID {
+				reusedTunnel = true
+				break
+			}
+		}
+	}
+
+	if _, err := a.getDatabase(runConfig); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: SiblingDatabaseHandle{
+		Config:       runConfig,
+		Database:     dbName,
+		SharedPool:   sharedPool,
+		SSHTunnel:    tunnelID,
+		ReusedTunnel: reusedTunnel,
+	}}
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/connectionstore"
+	"GoNavi-Wails/internal/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// connectionProbeGrace 首连保护窗口：应用启动后的这段时间内不发起批量探测，
+	// 避免与界面初始化、用户第一次点开连接争抢驱动代理与网络。
+	connectionProbeGrace       = 3 * time.Second
+	connectionProbeTimeout     = 10 * time.Second
+	connectionProbeConcurrency = 4
+)
+
+// ConnectionProbeResult 单个已保存连接的探测结果。
+type ConnectionProbeResult struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Reachable bool   `json:"reachable"`
+	Message   string `json:"message,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// TestSavedConnections 并发测试已保存的连接，每完成一个发送 connection:status 事件，
+// 供连接树标记不可达的环境。ids 为空时测试全部连接。
+func (a *App) TestSavedConnections(ids []string) connection.QueryResult {
+	profiles, err := a.connStore.List()
+	if err != nil {
+		return connectionStoreError("读取已保存连接", err)
+	}
+	if len(ids) > 0 {
+		wanted := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			wanted[strings.TrimSpace(id)] = true
+		}
+		filtered := profiles[:0]
+		for _, p := range profiles {
+			if wanted[p.ID] {
+				filtered = append(filtered, p)
+			}
+		}
+		profiles = filtered
+	}
+	if len(profiles) == 0 {
+		return connection.QueryResult{Success: true, Message: "没有需要测试的连接", Data: []ConnectionProbeResult{}}
+	}
+
+	if wait := a.probeGraceRemaining(); wait > 0 {
+		logger.Infof("连接探测处于首连保护窗口，%s 后开始", wait.Round(time.Millisecond))
+		time.Sleep(wait)
+	}
+
+	results := a.probeConnections(profiles, connectionProbeConcurrency, connectionProbeTimeout)
+	reachable := 0
+	for _, r := range results {
+		if r.Reachable {
+			reachable++
+		}
+	}
+	logger.Infof("连接探测完成：共 %d 个，可达 %d 个，不可达 %d 个", len(results), reachable, len(results)-reachable)
+	return connection.QueryResult{
+		Success: true,
+		Message: fmt.Sprintf("可达 %d / %d", reachable, len(results)),
+		Data:    results,
+	}
+}
+
+func (a *App) probeGraceRemaining() time.Duration {
+	if a.startedAt.IsZero() {
+		return 0
+	}
+	return connectionProbeGrace - time.Since(a.startedAt)
+}
+
+// probeConnections 以有限并发逐个建立（或复用）连接；结果顺序与 profiles 一致。
+func (a *App) probeConnections(profiles []connectionstore.Profile, concurrency int, timeout time.Duration) []ConnectionProbeResult {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	results := make([]ConnectionProbeResult, len(profiles))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func(i int, profile connectionstore.Profile) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = a.probeConnection(profile, timeout)
+			a.emitConnectionStatus(results[i])
+		}(i, profile)
+	}
+	wg.Wait()
+	return results
+}
+
+func (a *App) probeConnection(profile connectionstore.Profile, timeout time.Duration) ConnectionProbeResult {
+	result := ConnectionProbeResult{ID: profile.ID, Name: profile.Name}
+	started := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		_, err := a.getDatabaseForcePing(profile.Config)
+		done <- err
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(timeout):
+		// 驱动的 Connect 不接受 ctx，超时后放弃等待；连接若稍后建立成功仍会进入缓存
+		err = fmt.Errorf("连接超时（%s）", timeout)
+	}
+	result.LatencyMs = time.Since(started).Milliseconds()
+	if err != nil {
+		result.Message = normalizeErrorMessage(err)
+		logger.Warnf("连接探测失败：%s 原因=%s", formatConnSummary(profile.Config), result.Message)
+		return result
+	}
+	result.Reachable = true
+	return result
+}
+
+func (a *App) emitConnectionStatus(result ConnectionProbeResult) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "connection:status", result)
+}
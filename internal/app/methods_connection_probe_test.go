@@ -0,0 +1,63 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/connectionstore"
+	"GoNavi-Wails/internal/db"
+)
+
+type probeFakeDB struct {
+	db.Database
+	delay time.Duration
+}
+
+func (f *probeFakeDB) Ping() error {
+	time.Sleep(f.delay)
+	return nil
+}
+
+func (f *probeFakeDB) Close() error { return nil }
+
+func TestProbeConnectionsReportsEachProfile(t *testing.T) {
+	a := NewApp()
+	injectProbeDB := func(config connection.ConnectionConfig, delay time.Duration) {
+		a.dbCache[getCacheKey(applyCustomDriverType(config))] = cachedDatabase{inst: &probeFakeDB{delay: delay}, lastPing: time.Now()}
+	}
+	okConfig := connection.ConnectionConfig{Type: "mysql", Host: "ok.local", Port: 3306, User: "root"}
+	slowConfig := connection.ConnectionConfig{Type: "mysql", Host: "slow.local", Port: 3306, User: "root"}
+	injectProbeDB(okConfig, 0)
+	injectProbeDB(slowConfig, 500*time.Millisecond)
+
+	profiles := []connectionstore.Profile{
+		{ID: "ok", Name: "ok", Config: okConfig},
+		{ID: "bad", Name: "bad", Config: connection.ConnectionConfig{Type: "no-such-driver", Host: "x"}},
+		{ID: "slow", Name: "slow", Config: slowConfig},
+	}
+	results := a.probeConnections(profiles, 2, 100*time.Millisecond)
+	if len(results) != 3 {
+		t.Fatalf("got %d results", len(results))
+	}
+	if !results[0].Reachable || results[0].ID != "ok" {
+		t.Fatalf("ok: %+v", results[0])
+	}
+	if results[1].Reachable || results[1].Message == "" {
+		t.Fatalf("bad: %+v", results[1])
+	}
+	if results[2].Reachable || results[2].ID != "slow" {
+		t.Fatalf("slow should time out: %+v", results[2])
+	}
+}
+
+func TestProbeGraceRemaining(t *testing.T) {
+	a := NewApp()
+	if a.probeGraceRemaining() > 0 {
+		t.Fatal("no grace before Startup")
+	}
+	a.startedAt = time.Now()
+	if wait := a.probeGraceRemaining(); wait <= 0 || wait > connectionProbeGrace {
+		t.Fatalf("grace = %s", wait)
+	}
+}
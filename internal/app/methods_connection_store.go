@@ -0,0 +1,155 @@
+package app
+
+import (
+	"errors"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/connectionstore"
+	"GoNavi-Wails/internal/connimport"
+	"GoNavi-Wails/internal/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+func connectionStoreError(action string, err error) connection.QueryResult {
+	if !errors.Is(err, connectionstore.ErrLocked) {
+		logger.Error(err, "%s失败", action)
+	}
+	return connection.QueryResult{Success: false, Message: err.Error(), Data: map[string]bool{"locked": errors.Is(err, connectionstore.ErrLocked)}}
+}
+
+// ListConnections 返回后端保存的全部连接（密码已解密）。主密码模式未解锁时返回 locked。
+func (a *App) ListConnections() connection.QueryResult {
+	profiles, err := a.connStore.List()
+	if err != nil {
+		return connectionStoreError("读取已保存连接", err)
+	}
+	return connection.QueryResult{Success: true, Data: profiles}
+}
+
+// SaveConnection 新增或按 ID 覆盖一条连接，密码类字段加密后落盘。
+func (a *App) SaveConnection(profile connectionstore.Profile) connection.QueryResult {
+	if strings.TrimSpace(profile.Name) == "" {
+		return connection.QueryResult{Success: false, Message: "连接名称不能为空"}
+	}
+	saved, err := a.connStore.Save(profile)
+	if err != nil {
+		return connectionStoreError("保存连接", err)
+	}
+	return connection.QueryResult{Success: true, Data: saved}
+}
+
+// DeleteConnection 删除已保存的连接。
+func (a *App) DeleteConnection(id string) connection.QueryResult {
+	if err := a.connStore.Delete(id); err != nil {
+		return connectionStoreError("删除连接", err)
+	}
+	return connection.QueryResult{Success: true}
+}
+
+// GetConnectionStoreStatus 返回连接存储的路径、加密模式与是否需要主密码解锁。
+func (a *App) GetConnectionStoreStatus() connection.QueryResult {
+	return connection.QueryResult{Success: true, Data: a.connStore.Status()}
+}
+
+// UnlockConnectionStore 使用主密码解锁连接存储。
+func (a *App) UnlockConnectionStore(password string) connection.QueryResult {
+	if err := a.connStore.Unlock(password); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true}
+}
+
+// SetConnectionStoreMasterPassword 启用、修改或关闭（next 为空）主密码。
+func (a *App) SetConnectionStoreMasterPassword(current string, next string) connection.QueryResult {
+	if err := a.connStore.SetMasterPassword(current, next); err != nil {
+		return connectionStoreError("设置主密码", err)
+	}
+	if next == "" {
+		logger.Infof("已关闭连接存储主密码")
+		return connection.QueryResult{Success: true, Message: "已关闭主密码"}
+	}
+	logger.Infof("已设置连接存储主密码")
+	return connection.QueryResult{Success: true, Message: "已设置主密码"}
+}
+
+// ImportConnectionsFromFile 解析 Navicat NCX、DBeaver data-sources.json 或 URI 列表文件，
+// 返回转换后的连接（不落盘，由前端确认后逐条保存）。path 为空时弹出文件选择框，format 为空时自动识别。
+func (a *App) ImportConnectionsFromFile(path string, format string) connection.QueryResult {
+	if strings.TrimSpace(path) == "" {
+		selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title: "Select Connection File",
+			Filters: []runtime.FileFilter{
+				{DisplayName: "Connection Files (*.ncx;*.json;*.txt)", Pattern: "*.ncx;*.json;*.txt"},
+				{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+			},
+		})
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		if selection == "" {
+			return connection.QueryResult{Success: false, Message: "Cancelled"}
+		}
+		path = selection
+	}
+	result, err := connimport.ParseFile(path, format)
+	if err != nil {
+		logger.Error(err, "导入连接配置失败：文件=%s 格式=%s", path, format)
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	logger.Infof("解析连接配置完成：文件=%s 格式=%s 连接数=%d 警告数=%d", path, result.Format, len(result.Connections), len(result.Warnings))
+	return connection.QueryResult{Success: true, Data: result}
+}
+
+// GetGridPreference 返回表格视图偏好（可见列、列顺序与宽度、排序、筛选），未保存过时 Data 为 nil。
+func (a *App) GetGridPreference(connectionID string, dbName string, tableName string) connection.QueryResult {
+	pref, ok, err := a.connStore.GridPreference(connectionID, dbName, tableName)
+	if err != nil {
+		return connectionStoreError("读取表格偏好", err)
+	}
+	if !ok {
+		return connection.QueryResult{Success: true}
+	}
+	return connection.QueryResult{Success: true, Data: pref}
+}
+
+// SaveGridPreference 保存单张表的视图偏好，覆盖该表已有的偏好。
+func (a *App) SaveGridPreference(pref connectionstore.GridPreference) connection.QueryResult {
+	pref.UpdatedAt = 0
+	saved, err := a.connStore.SaveGridPreferences(pref)
+	if err != nil {
+		return connectionStoreError("保存表格偏好", err)
+	}
+	return connection.QueryResult{Success: true, Data: saved[0]}
+}
+
+// DeleteGridPreference 删除表格视图偏好，恢复默认显示。
+func (a *App) DeleteGridPreference(connectionID string, dbName string, tableName string) connection.QueryResult {
+	if err := a.connStore.DeleteGridPreference(connectionID, dbName, tableName); err != nil {
+		return connectionStoreError("删除表格偏好", err)
+	}
+	return connection.QueryResult{Success: true}
+}
+
+// ListGridPreferences 返回连接的全部表格偏好（connectionID 为空时返回全部），供导出连接配置时一并导出。
+func (a *App) ListGridPreferences(connectionID string) connection.QueryResult {
+	prefs, err := a.connStore.GridPreferences(connectionID)
+	if err != nil {
+		return connectionStoreError("读取表格偏好", err)
+	}
+	return connection.QueryResult{Success: true, Data: prefs}
+}
+
+// ImportGridPreferences 写入导入的表格偏好，同一张表以导入的为准。
+func (a *App) ImportGridPreferences(prefs []connectionstore.GridPreference) connection.QueryResult {
+	if len(prefs) == 0 {
+		return connection.QueryResult{Success: true, Data: 0}
+	}
+	saved, err := a.connStore.SaveGridPreferences(prefs...)
+	if err != nil {
+		return connectionStoreError("导入表格偏好", err)
+	}
+	logger.Infof("已导入表格偏好：%d 条", len(saved))
+	return connection.QueryResult{Success: true, Data: len(saved)}
+}
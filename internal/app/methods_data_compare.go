@@ -0,0 +1,576 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	defaultDataCompareChunkRows  = 1000
+	maxDataCompareChunkRows      = 5000
+	defaultDataCompareDetailRows = 200
+	maxDataCompareScriptRows     = 10000
+)
+
+// TableDataCompareOptions 表数据比较选项。
+type TableDataCompareOptions struct {
+	SourceDB    string `json:"sourceDb,omitempty"`
+	TargetDB    string `json:"targetDb,omitempty"`
+	TargetTable string `json:"targetTable,omitempty"` // 留空与源表同名
+	// ChunkRows 每批按键读取的行数；MaxDetailRows 每类差异最多返回的明细行数，计数不受限制。
+	ChunkRows      int      `json:"chunkRows,omitempty"`
+	MaxDetailRows  int      `json:"maxDetailRows,omitempty"`
+	IgnoreColumns  []string `json:"ignoreColumns,omitempty"`  // 不参与比较的列，如 updated_at
+	GenerateScript bool     `json:"generateScript,omitempty"` // 生成把目标表同步为源表数据的脚本
+	QueryID        string   `json:"queryId,omitempty"`        // 非空时可通过 CancelQuery 中止比较
+}
+
+// TableDataRowDiff 一行数据差异。Inserted 只有 Source，Deleted 只有 Target，Updated 两者都有。
+type TableDataRowDiff struct {
+	Key     map[string]interface{} `json:"key"`
+	Source  map[string]interface{} `json:"source,omitempty"`
+	Target  map[string]interface{} `json:"target,omitempty"`
+	Columns []string               `json:"columns,omitempty"` // Updated 中取值不同的列
+}
+
+// TableDataCompareResult 表数据比较结果。Inserted/Updated/Deleted 指把目标表同步为源表需要的操作。
+type TableDataCompareResult struct {
+	Keys       []string           `json:"keys"`
+	Columns    []string           `json:"columns"` // 参与比较的列
+	SourceRows int64              `json:"sourceRows"`
+	TargetRows int64              `json:"targetRows"`
+	Same       int64              `json:"same"`
+	Inserted   int64              `json:"inserted"`
+	Updated    int64              `json:"updated"`
+	Deleted    int64              `json:"deleted"`
+	Inserts    []TableDataRowDiff `json:"inserts"`
+	Updates    []TableDataRowDiff `json:"updates"`
+	Deletes    []TableDataRowDiff `json:"deletes"`
+	Truncated  bool               `json:"truncated,omitempty"` // 明细行数达到上限
+	Script     string             `json:"script,omitempty"`
+	Warnings   []string           `json:"warnings,omitempty"`
+	DurationMs int64              `json:"durationMs"`
+	// Plan 在目标表执行同步脚本的变更计划，通过 ConfirmSQLPlan 执行或复制
+	Plan *SQLPlanPreview `json:"plan,omitempty"`
+}
+
+// dataCompareSide 比较的一端。
+type dataCompareSide struct {
+	dbType  string
+	inst    db.Database
+	table   string // 已加引号的表名
+	columns map[string]connection.ColumnDefinition
+	kinds   map[string]string // 小写列名 -> 通用类型，用于生成字面量
+}
+
+func (s *dataCompareSide) query(ctx context.Context, sql string) ([]map[string]interface{}, error) {
+	if q, ok := s.inst.(interface {
+		QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+	}); ok {
+		data, _, err := q.QueryContext(ctx, sql)
+		return data, err
+	}
+	data, _, err := s.inst.Query(sql)
+	return data, err
+}
+
+// dataCompareColumn 按列名（忽略大小写）读取行中的值，Oracle 等返回大写列名时也能取到。
+func dataCompareColumn(row map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := row[name]; ok {
+		return v, true
+	}
+	for k, v := range row {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// CompareTableData 比较两个连接中同一张表的数据：按键分批读取源表并到目标表按键查找，再反向分批查找目标表多出的行，
+// 报告目标表相对源表需要插入、更新、删除的行，可选生成同步脚本。用于校验复制与迁移结果。
+// keys 为比较使用的键列，留空使用源表主键。
+func (a *App) CompareTableData(sourceConfig connection.ConnectionConfig, targetConfig connection.ConnectionConfig, table string, keys []string, options TableDataCompareOptions) connection.QueryResult {
+	started := time.Now()
+	if strings.TrimSpace(table) == "" {
+		return connection.QueryResult{Success: false, Message: "请指定要比较的表"}
+	}
+	sourceDB := strings.TrimSpace(options.SourceDB)
+	if sourceDB == "" {
+		sourceDB = strings.TrimSpace(sourceConfig.Database)
+	}
+	targetDB := strings.TrimSpace(options.TargetDB)
+	if targetDB == "" {
+		targetDB = strings.TrimSpace(targetConfig.Database)
+	}
+	targetTable := strings.TrimSpace(options.TargetTable)
+	if targetTable == "" {
+		targetTable = table
+	}
+	sourceRun := normalizeRunConfig(sourceConfig, sourceDB)
+	targetRun := normalizeRunConfig(targetConfig, targetDB)
+
+	source := &dataCompareSide{dbType: resolveDDLDBType(sourceRun)}
+	target := &dataCompareSide{dbType: resolveDDLDBType(targetRun)}
+	for _, dbType := range []string{source.dbType, target.dbType} {
+		if dbType == "mongodb" || dbType == "redis" {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("%s 不支持表数据比较", dbType)}
+		}
+	}
+
+	var sourceColumns, targetColumns []connection.ColumnDefinition
+	for _, item := range []struct {
+		side    *dataCompareSide
+		config  connection.ConnectionConfig
+		dbName  string
+		table   string
+		columns *[]connection.ColumnDefinition
+		label   string
+	}{
+		{source, sourceRun, sourceDB, table, &sourceColumns, "源表"},
+		{target, targetRun, targetDB, targetTable, &targetColumns, "目标表"},
+	} {
+		inst, err := a.getDatabase(item.config)
+		if err != nil {
+			logger.Error(err, "CompareTableData 获取连接失败：%s", formatConnSummary(item.config))
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		item.side.inst = inst
+		schema, pure := normalizeSchemaAndTable(item.config, item.dbName, item.table)
+		item.side.table = quoteTableIdentByType(item.side.dbType, schema, pure)
+		columns, err := inst.GetColumns(schema, pure)
+		if err != nil || len(columns) == 0 {
+			if err == nil {
+				err = fmt.Errorf("没有字段")
+			}
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("读取%s %s 字段失败：%s", item.label, item.table, normalizeErrorMessage(err))}
+		}
+		*item.columns = columns
+		item.side.columns = make(map[string]connection.ColumnDefinition, len(columns))
+		item.side.kinds = make(map[string]string, len(columns))
+		for _, col := range columns {
+			item.side.columns[normalizeColumnName(col.Name)] = col
+			parsed, _ := parseTransferColumnType(item.side.dbType, col.Type)
+			item.side.kinds[normalizeColumnName(col.Name)] = parsed.kind
+		}
+	}
+
+	result := TableDataCompareResult{Inserts: []TableDataRowDiff{}, Updates: []TableDataRowDiff{}, Deletes: []TableDataRowDiff{}}
+	if len(keys) == 0 {
+		for _, col := range sourceColumns {
+			if strings.EqualFold(col.Key, "PRI") {
+				keys = append(keys, col.Name)
+			}
+		}
+		if len(keys) == 0 {
+			return connection.QueryResult{Success: false, Message: "源表没有主键，请指定用于比较的键列"}
+		}
+	}
+	for i, key := range keys {
+		col, ok := source.columns[normalizeColumnName(key)]
+		if !ok {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("源表中没有键列 %s", key)}
+		}
+		if _, ok := target.columns[normalizeColumnName(key)]; !ok {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("目标表中没有键列 %s", key)}
+		}
+		keys[i] = col.Name
+	}
+	result.Keys = keys
+
+	ignored := make(map[string]struct{}, len(options.IgnoreColumns))
+	for _, name := range options.IgnoreColumns {
+		ignored[normalizeColumnName(name)] = struct{}{}
+	}
+	var missing, shared []string
+	for _, col := range sourceColumns {
+		name := normalizeColumnName(col.Name)
+		if strings.TrimSpace(col.Generated) != "" {
+			continue
+		}
+		if targetCol, ok := target.columns[name]; !ok || strings.TrimSpace(targetCol.Generated) != "" {
+			missing = append(missing, col.Name)
+			continue
+		}
+		shared = append(shared, col.Name)
+		if _, skip := ignored[name]; !skip {
+			result.Columns = append(result.Columns, col.Name)
+		}
+	}
+	if len(missing) > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("目标表中没有列 %s，不参与比较", strings.Join(missing, ", ")))
+	}
+	for _, col := range targetColumns {
+		if _, ok := source.columns[normalizeColumnName(col.Name)]; !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("源表中没有列 %s，不参与比较", col.Name))
+		}
+	}
+
+	ctx := context.Background()
+	if queryID := strings.TrimSpace(options.QueryID); queryID != "" {
+		tracked, rq, err := a.registerQuery(ctx, queryID, sourceRun)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		defer a.unregisterQuery(rq)
+		ctx = tracked
+	}
+
+	cmp := &dataComparer{source: source, target: target, result: &result, insertColumns: shared, chunkRows: options.ChunkRows, detailRows: options.MaxDetailRows, script: options.GenerateScript}
+	if err := cmp.run(ctx); err != nil {
+		if ctx.Err() != nil {
+			return connection.QueryResult{Success: false, Message: errQueryCanceled.Error()}
+		}
+		logger.Error(err, "表数据比较失败：源=%s 目标=%s 表=%s", formatConnSummary(sourceRun), formatConnSummary(targetRun), table)
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	result.Script = cmp.scriptText()
+	result.Plan = a.attachSQLPlan(SQLPlanRequest{Config: targetConfig, DBName: targetDB, Source: "data_compare",
+		Title: fmt.Sprintf("同步表 %s 的数据", targetTable), Statements: cmp.statements, StopOnError: true}, &result.Warnings)
+	result.DurationMs = time.Since(started).Milliseconds()
+
+	message := fmt.Sprintf("源表 %d 行，目标表 %d 行：一致 %d，目标缺少 %d，不同 %d，目标多出 %d",
+		result.SourceRows, result.TargetRows, result.Same, result.Inserted, result.Updated, result.Deleted)
+	return connection.QueryResult{Success: true, Message: message, Data: result}
+}
+
+// dataComparer 执行两轮分批比较：第一轮遍历源表找出缺少与不同的行，第二轮遍历目标表的键找出多出的行。
+type dataComparer struct {
+	source, target *dataCompareSide
+	result         *TableDataCompareResult
+	insertColumns  []string // 插入时写入的列：两端都有的非计算列，包括不参与比较的列
+	chunkRows      int
+	detailRows     int
+	script         bool
+	statements     []string
+	scriptOverflow bool
+}
+
+func (c *dataComparer) run(ctx context.Context) error {
+	if c.chunkRows <= 0 {
+		c.chunkRows = defaultDataCompareChunkRows
+	}
+	c.chunkRows = min(c.chunkRows, maxDataCompareChunkRows)
+	if c.detailRows <= 0 {
+		c.detailRows = defaultDataCompareDetailRows
+	}
+
+	keys := c.result.Keys
+	var after []string
+	for page := 1; ; page++ {
+		rows, err := c.readPage(ctx, c.source, after)
+		if err != nil {
+			return fmt.Errorf("读取源表第 %d 批失败：%s", page, normalizeErrorMessage(err))
+		}
+		c.result.SourceRows += int64(len(rows))
+		if len(rows) > 0 {
+			matches, err := c.lookup(ctx, c.target, rows, false)
+			if err != nil {
+				return fmt.Errorf("按键读取目标表失败：%s", normalizeErrorMessage(err))
+			}
+			for _, row := range rows {
+				targetRow, ok := matches[c.rowKey(row)]
+				if !ok {
+					c.result.Inserted++
+					if len(c.result.Inserts) < c.detailRows {
+						c.result.Inserts = append(c.result.Inserts, TableDataRowDiff{Key: dataCompareKeyValues(row, keys), Source: row})
+					} else {
+						c.result.Truncated = true
+					}
+					c.addStatement(func() string { return c.insertSQL(row) })
+					continue
+				}
+				changed := c.changedColumns(row, targetRow)
+				if len(changed) == 0 {
+					c.result.Same++
+					continue
+				}
+				c.result.Updated++
+				if len(c.result.Updates) < c.detailRows {
+					c.result.Updates = append(c.result.Updates, TableDataRowDiff{Key: dataCompareKeyValues(row, keys), Source: row, Target: targetRow, Columns: changed})
+				} else {
+					c.result.Truncated = true
+				}
+				c.addStatement(func() string { return c.updateSQL(row, changed) })
+			}
+		}
+		if len(rows) < c.chunkRows {
+			break
+		}
+		after = c.lastKey(c.source, rows)
+	}
+
+	after = nil
+	for page := 1; ; page++ {
+		rows, err := c.readPage(ctx, c.target, after)
+		if err != nil {
+			return fmt.Errorf("读取目标表第 %d 批失败：%s", page, normalizeErrorMessage(err))
+		}
+		c.result.TargetRows += int64(len(rows))
+		if len(rows) > 0 {
+			matches, err := c.lookup(ctx, c.source, rows, true)
+			if err != nil {
+				return fmt.Errorf("按键读取源表失败：%s", normalizeErrorMessage(err))
+			}
+			for _, row := range rows {
+				if _, ok := matches[c.rowKey(row)]; ok {
+					continue
+				}
+				c.result.Deleted++
+				if len(c.result.Deletes) < c.detailRows {
+					c.result.Deletes = append(c.result.Deletes, TableDataRowDiff{Key: dataCompareKeyValues(row, keys), Target: row})
+				} else {
+					c.result.Truncated = true
+				}
+				c.addStatement(func() string { return c.deleteSQL(row) })
+			}
+		}
+		if len(rows) < c.chunkRows {
+			break
+		}
+		after = c.lastKey(c.target, rows)
+	}
+	if c.scriptOverflow {
+		c.result.Warnings = append(c.result.Warnings, fmt.Sprintf("差异超过 %d 行，同步脚本只包含前 %d 条语句，建议使用数据传输或数据同步", maxDataCompareScriptRows, maxDataCompareScriptRows))
+	}
+	return nil
+}
+
+// readPage 按键续读一端的下一批整行数据，after 为上一批最后一行键值的字面量，为空时读取第一批。
+func (c *dataComparer) readPage(ctx context.Context, side *dataCompareSide, after []string) ([]map[string]interface{}, error) {
+	keys := make([]string, 0, len(c.result.Keys))
+	for _, key := range c.result.Keys {
+		keys = append(keys, side.columnName(key))
+	}
+	return side.query(ctx, buildKeysetPageSQL(side.dbType, side.table, keys, after, c.chunkRows))
+}
+
+// lastKey 返回一批数据最后一行的键值字面量，作为读取下一批的起点。
+func (c *dataComparer) lastKey(side *dataCompareSide, rows []map[string]interface{}) []string {
+	last := rows[len(rows)-1]
+	after := make([]string, 0, len(c.result.Keys))
+	for _, key := range c.result.Keys {
+		v, _ := dataCompareColumn(last, key)
+		after = append(after, side.literal(key, v))
+	}
+	return after
+}
+
+// lookup 在 side 中按 rows（来自另一端）的键查找对应行，返回以键文本索引的结果；keysOnly 时只读取键列。
+func (c *dataComparer) lookup(ctx context.Context, side *dataCompareSide, rows []map[string]interface{}, keysOnly bool) (map[string]map[string]interface{}, error) {
+	keys := c.result.Keys
+	selectList := "*"
+	if keysOnly {
+		quoted := make([]string, 0, len(keys))
+		for _, key := range keys {
+			quoted = append(quoted, quoteIdentByType(side.dbType, side.columnName(key)))
+		}
+		selectList = strings.Join(quoted, ", ")
+	}
+	out := make(map[string]map[string]interface{}, len(rows))
+	// 每次查询的键数量受 IN 列表与语句长度限制，按 500 个一组查询
+	for start := 0; start < len(rows); start += 500 {
+		end := min(start+500, len(rows))
+		where := c.keyCondition(side, rows[start:end])
+		data, err := side.query(ctx, fmt.Sprintf("SELECT %s FROM %s WHERE %s", selectList, side.table, where))
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range data {
+			out[c.rowKey(row)] = row
+		}
+	}
+	return out, nil
+}
+
+// keyCondition 生成按键匹配多行的条件：单列键用 IN，复合键用 OR 连接。
+func (c *dataComparer) keyCondition(side *dataCompareSide, rows []map[string]interface{}) string {
+	keys := c.result.Keys
+	if len(keys) == 1 {
+		values := make([]string, 0, len(rows))
+		for _, row := range rows {
+			v, _ := dataCompareColumn(row, keys[0])
+			values = append(values, side.literal(keys[0], v))
+		}
+		return quoteIdentByType(side.dbType, side.columnName(keys[0])) + " IN (" + strings.Join(values, ", ") + ")"
+	}
+	conds := make([]string, 0, len(rows))
+	for _, row := range rows {
+		conds = append(conds, "("+c.rowKeyPredicate(side, row)+")")
+	}
+	return strings.Join(conds, " OR ")
+}
+
+func (c *dataComparer) rowKeyPredicate(side *dataCompareSide, row map[string]interface{}) string {
+	parts := make([]string, 0, len(c.result.Keys))
+	for _, key := range c.result.Keys {
+		v, _ := dataCompareColumn(row, key)
+		ident := quoteIdentByType(side.dbType, side.columnName(key))
+		if v == nil {
+			parts = append(parts, ident+" IS NULL")
+			continue
+		}
+		parts = append(parts, ident+" = "+side.literal(key, v))
+	}
+	return strings.Join(parts, " AND ")
+}
+
+func (c *dataComparer) changedColumns(sourceRow, targetRow map[string]interface{}) []string {
+	var changed []string
+	for _, col := range c.result.Columns {
+		sv, _ := dataCompareColumn(sourceRow, col)
+		tv, _ := dataCompareColumn(targetRow, col)
+		if !dataCompareValuesEqual(sv, tv, c.numeric(col)) {
+			changed = append(changed, col)
+		}
+	}
+	return changed
+}
+
+func (c *dataComparer) addStatement(build func() string) {
+	if !c.script {
+		return
+	}
+	if len(c.statements) >= maxDataCompareScriptRows {
+		c.scriptOverflow = true
+		return
+	}
+	c.statements = append(c.statements, build())
+}
+
+func (c *dataComparer) scriptText() string {
+	if len(c.statements) == 0 {
+		return ""
+	}
+	return strings.Join(c.statements, ";\n") + ";"
+}
+
+func (c *dataComparer) insertSQL(row map[string]interface{}) string {
+	t := c.target
+	columns := make([]string, 0, len(c.insertColumns))
+	values := make([]string, 0, len(c.insertColumns))
+	for _, col := range c.insertColumns {
+		v, _ := dataCompareColumn(row, col)
+		columns = append(columns, quoteIdentByType(t.dbType, t.columnName(col)))
+		values = append(values, t.literal(col, v))
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", t.table, strings.Join(columns, ", "), strings.Join(values, ", "))
+}
+
+func (c *dataComparer) updateSQL(row map[string]interface{}, changed []string) string {
+	t := c.target
+	sets := make([]string, 0, len(changed))
+	for _, col := range changed {
+		v, _ := dataCompareColumn(row, col)
+		sets = append(sets, quoteIdentByType(t.dbType, t.columnName(col))+" = "+t.literal(col, v))
+	}
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s", t.table, strings.Join(sets, ", "), c.rowKeyPredicate(t, row))
+}
+
+func (c *dataComparer) deleteSQL(row map[string]interface{}) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s", c.target.table, c.rowKeyPredicate(c.target, row))
+}
+
+// columnName 返回该端实际的列名（大小写以该端为准）。
+func (s *dataCompareSide) columnName(name string) string {
+	if col, ok := s.columns[normalizeColumnName(name)]; ok {
+		return col.Name
+	}
+	return name
+}
+
+func (s *dataCompareSide) literal(column string, value interface{}) string {
+	return transferLiteral(s.dbType, s.kinds[normalizeColumnName(column)], value)
+}
+
+func dataCompareKeyValues(row map[string]interface{}, keys []string) map[string]interface{} {
+	out := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		out[key], _ = dataCompareColumn(row, key)
+	}
+	return out
+}
+
+// rowKey 把键值拼成文本，用于两端行的匹配；数值列按精确值规范化，避免 1 与 "1"、1.0 不匹配。
+func (c *dataComparer) rowKey(row map[string]interface{}) string {
+	parts := make([]string, 0, len(c.result.Keys))
+	for _, key := range c.result.Keys {
+		v, _ := dataCompareColumn(row, key)
+		text, null := dataCompareValue(v, c.numeric(key))
+		if null {
+			text = "\x00"
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "\x1f")
+}
+
+// numeric 判断列在任一端是否为数值类型，数值列按数值比较，其余列按文本比较（"007" 与 "7" 不同）。
+func (c *dataComparer) numeric(column string) bool {
+	name := normalizeColumnName(column)
+	return dataCompareNumericKind(c.source.kinds[name]) || dataCompareNumericKind(c.target.kinds[name])
+}
+
+func dataCompareNumericKind(kind string) bool {
+	switch kind {
+	case "int8", "int16", "int32", "int64", "decimal", "float32", "float64":
+		return true
+	}
+	return false
+}
+
+// dataCompareValue 返回值的比较文本；numeric 时能解析为数值的文本规范化为精确的有理数表示，
+// 不经过 float64，超过 2^53 的 BIGINT 与高精度 DECIMAL 也不会误判相等。
+func dataCompareValue(value interface{}, numeric bool) (string, bool) {
+	text, null := dataCompareText(value)
+	if null || !numeric {
+		return text, null
+	}
+	if r, ok := new(big.Rat).SetString(strings.TrimSpace(text)); ok {
+		return r.RatString(), false
+	}
+	return text, false
+}
+
+// dataCompareText 把驱动返回的值转换为可比较的文本。
+func dataCompareText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", true
+	case []byte:
+		return string(v), false
+	case string:
+		return v, false
+	case bool:
+		if v {
+			return "1", false
+		}
+		return "0", false
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), false
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), false
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999999"), false
+	}
+	return fmt.Sprintf("%v", value), false
+}
+
+// dataCompareValuesEqual 比较两端的值：数值列按精确数值比较，以容忍不同驱动对 DECIMAL 的表示差异（如 "10.50" 与 10.5）；
+// 其余列按文本比较。
+func dataCompareValuesEqual(a, b interface{}, numeric bool) bool {
+	ta, nullA := dataCompareValue(a, numeric)
+	tb, nullB := dataCompareValue(b, numeric)
+	if nullA || nullB {
+		return nullA == nullB
+	}
+	return ta == tb
+}
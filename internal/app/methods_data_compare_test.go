@@ -0,0 +1,137 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+var (
+	dataCompareInPattern     = regexp.MustCompile("WHERE `id` IN \\(([^)]*)\\)")
+	dataCompareKeysetPattern = regexp.MustCompile("(?:WHERE \\(`id` > (\\d+)\\) )?ORDER BY `id` ASC LIMIT (\\d+)$")
+)
+
+type dataCompareFakeDB struct {
+	db.Database
+	rows    []map[string]interface{} // 按 id 升序
+	queries []string
+}
+
+func (f *dataCompareFakeDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	return []connection.ColumnDefinition{
+		{Name: "id", Type: "int", Key: "PRI", Nullable: "NO"},
+		{Name: "name", Type: "varchar(20)", Nullable: "YES"},
+		{Name: "price", Type: "decimal(10,2)", Nullable: "YES"},
+	}, nil
+}
+
+func (f *dataCompareFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	f.queries = append(f.queries, query)
+	if m := dataCompareKeysetPattern.FindStringSubmatch(query); m != nil {
+		after, _ := strconv.ParseInt(m[1], 10, 64)
+		limit, _ := strconv.Atoi(m[2])
+		var out []map[string]interface{}
+		for _, row := range f.rows {
+			if row["id"].(int64) > after && len(out) < limit {
+				out = append(out, row)
+			}
+		}
+		return out, nil, nil
+	}
+	if m := dataCompareInPattern.FindStringSubmatch(query); m != nil {
+		wanted := make(map[string]bool)
+		for _, v := range strings.Split(m[1], ",") {
+			wanted[strings.TrimSpace(v)] = true
+		}
+		var out []map[string]interface{}
+		for _, row := range f.rows {
+			if wanted[fmt.Sprint(row["id"])] {
+				out = append(out, row)
+			}
+		}
+		return out, nil, nil
+	}
+	return nil, nil, fmt.Errorf("unexpected query %s", query)
+}
+
+func TestCompareTableData(t *testing.T) {
+	a := NewApp()
+	sourceConfig := connection.ConnectionConfig{Type: "mysql", Host: "10.0.0.1", Port: 3306, User: "root", Database: "shop"}
+	targetConfig := connection.ConnectionConfig{Type: "mysql", Host: "10.0.0.2", Port: 3306, User: "root", Database: "shop"}
+	source := &dataCompareFakeDB{rows: []map[string]interface{}{
+		{"id": int64(1), "name": "a", "price": "10.50"},
+		{"id": int64(2), "name": "b", "price": "3.00"},
+		{"id": int64(3), "name": "c", "price": nil},
+		{"id": int64(5), "name": "e", "price": "1.00"},
+	}}
+	target := &dataCompareFakeDB{rows: []map[string]interface{}{
+		{"id": int64(1), "name": []byte("a"), "price": 10.5},
+		{"id": int64(2), "name": "B", "price": "3.00"},
+		{"id": int64(4), "name": "d", "price": nil},
+		{"id": int64(5), "name": "e", "price": "1.00"},
+	}}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(sourceConfig, "shop")))] = cachedDatabase{inst: source, lastPing: time.Now()}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(targetConfig, "shop")))] = cachedDatabase{inst: target, lastPing: time.Now()}
+
+	res := a.CompareTableData(sourceConfig, targetConfig, "products", nil, TableDataCompareOptions{ChunkRows: 2, GenerateScript: true})
+	if !res.Success {
+		t.Fatal(res.Message)
+	}
+	result := res.Data.(TableDataCompareResult)
+	if result.SourceRows != 4 || result.TargetRows != 4 || result.Same != 2 || result.Inserted != 1 || result.Updated != 1 || result.Deleted != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+	if len(result.Updates) != 1 || strings.Join(result.Updates[0].Columns, ",") != "name" {
+		t.Fatalf("updates = %+v", result.Updates)
+	}
+	wantScript := "UPDATE `shop`.`products` SET `name` = 'b' WHERE `id` = 2;\n" +
+		"INSERT INTO `shop`.`products` (`id`, `name`, `price`) VALUES (3, 'c', NULL);\n" +
+		"DELETE FROM `shop`.`products` WHERE `id` = 4;"
+	if result.Script != wantScript {
+		t.Fatalf("script =\n%s", result.Script)
+	}
+	if !strings.Contains(strings.Join(source.queries, "\n"), "WHERE (`id` > 2) ORDER BY `id` ASC LIMIT 2") {
+		t.Fatalf("second chunk should continue after the last key: %q", source.queries)
+	}
+	if !strings.Contains(source.queries[len(source.queries)-1], "SELECT `id` FROM") {
+		t.Fatalf("reverse lookup should read keys only: %q", source.queries)
+	}
+
+	// 忽略列后不再视为不同
+	res = a.CompareTableData(sourceConfig, targetConfig, "products", []string{"id"}, TableDataCompareOptions{IgnoreColumns: []string{"NAME"}})
+	if result := res.Data.(TableDataCompareResult); result.Updated != 0 || result.Script != "" {
+		t.Fatalf("ignore result = %+v", result)
+	}
+
+	if res := a.CompareTableData(sourceConfig, targetConfig, "products", []string{"missing"}, TableDataCompareOptions{}); res.Success {
+		t.Fatal("expected unknown key error")
+	}
+}
+
+func TestDataCompareValuesEqual(t *testing.T) {
+	cases := []struct {
+		a, b    interface{}
+		numeric bool
+		want    bool
+	}{
+		{"10.50", 10.5, true, true},
+		{int64(1), "1.0", true, true},
+		{int64(9007199254740993), int64(9007199254740992), true, false},
+		{"12345678901234567890.12", "12345678901234567890.13", true, false},
+		{"007", "7", false, false},
+		{"007", "7", true, true},
+		{nil, "", false, false},
+		{nil, nil, true, true},
+	}
+	for _, tc := range cases {
+		if got := dataCompareValuesEqual(tc.a, tc.b, tc.numeric); got != tc.want {
+			t.Errorf("dataCompareValuesEqual(%v, %v, %v) = %v, want %v", tc.a, tc.b, tc.numeric, got, tc.want)
+		}
+	}
+}
@@ -2,12 +2,16 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
 	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
 	"GoNavi-Wails/internal/utils"
 )
 
@@ -72,6 +76,9 @@ func (a *App) MongoDiscoverMembers(config connection.ConnectionConfig) connectio
 }
 
 func (a *App) CreateDatabase(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	if err := ensureWritable(config, "创建数据库"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	runConfig := config
 	runConfig.Database = ""
 
@@ -80,18 +87,9 @@ func (a *App) CreateDatabase(config connection.ConnectionConfig, dbName string)
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
-	escapedDbName := strings.ReplaceAll(dbName, "`", "``")
-	query := fmt.Sprintf("CREATE DATABASE `%s` CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci", escapedDbName)
-	dbType := strings.ToLower(strings.TrimSpace(runConfig.Type))
-	if dbType == "postgres" || dbType == "kingbase" || dbType == "highgo" || dbType == "vastbase" {
-		escapedDbName = strings.ReplaceAll(dbName, `"`, `""`)
-		query = fmt.Sprintf("CREATE DATABASE \"%s\"", escapedDbName)
-	} else if dbType == "tdengine" {
-		query = fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", quoteIdentByType(dbType, dbName))
-	} else if dbType == "mariadb" || dbType == "diros" {
-		// MariaDB uses same syntax as MySQL
-	} else if dbType == "sphinx" {
-		return connection.QueryResult{Success: false, Message: "Sphinx 暂不支持创建数据库"}
+	query, err := createDatabaseSQL(resolveDDLDBType(runConfig), dbName)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
 	_, err = dbInst.Exec(query)
@@ -102,7 +100,26 @@ func (a *App) CreateDatabase(config connection.ConnectionConfig, dbName string)
 	return connection.QueryResult{Success: true, Message: "Database created successfully"}
 }
 
+// createDatabaseSQL 按方言生成建库语句；库即文件或以用户/模式代替库的数据库返回错误。
+func createDatabaseSQL(dbType string, dbName string) (string, error) {
+	quoted := quoteIdentByType(dbType, dbName)
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		return fmt.Sprintf("CREATE DATABASE %s CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci", quoted), nil
+	case "tdengine", "clickhouse":
+		return fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", quoted), nil
+	case "sphinx":
+		return "", fmt.Errorf("Sphinx 暂不支持创建数据库")
+	case "sqlite", "duckdb":
+		return "", fmt.Errorf("%s 的数据库即文件，请新建连接指定新的文件路径", dbType)
+	case "oracle", "dameng":
+		return "", fmt.Errorf("%s 以用户（模式）区分数据库，请通过 CREATE USER 创建", dbType)
+	}
+	return fmt.Sprintf("CREATE DATABASE %s", quoted), nil
+}
+
 func resolveDDLDBType(config connection.ConnectionConfig) string {
+	config = applyCustomDriverType(config)
 	dbType := strings.ToLower(strings.TrimSpace(config.Type))
 	if dbType != "custom" {
 		return dbType
@@ -149,12 +166,7 @@ func normalizeSchemaAndTableByType(dbType string, dbName string, tableName strin
 }
 
 func quoteTableIdentByType(dbType string, schema string, table string) string {
-	s := strings.TrimSpace(schema)
-	t := strings.TrimSpace(table)
-	if s == "" {
-		return quoteIdentByType(dbType, t)
-	}
-	return fmt.Sprintf("%s.%s", quoteIdentByType(dbType, s), quoteIdentByType(dbType, t))
+	return dialect.QualifyTable(dbType, schema, table)
 }
 
 func buildRunConfigForDDL(config connection.ConnectionConfig, dbType string, dbName string) connection.ConnectionConfig {
@@ -172,6 +184,9 @@ func buildRunConfigForDDL(config connection.ConnectionConfig, dbType string, dbN
 }
 
 func (a *App) RenameDatabase(config connection.ConnectionConfig, oldName string, newName string) connection.QueryResult {
+	if err := ensureWritable(config, "重命名数据库"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	oldName = strings.TrimSpace(oldName)
 	newName = strings.TrimSpace(newName)
 	if oldName == "" || newName == "" {
@@ -208,6 +223,9 @@ func (a *App) RenameDatabase(config connection.ConnectionConfig, oldName string,
 }
 
 func (a *App) DropDatabase(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	if err := ensureWritable(config, "删除数据库"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	dbName = strings.TrimSpace(dbName)
 	if dbName == "" {
 		return connection.QueryResult{Success: false, Message: "数据库名称不能为空"}
@@ -219,7 +237,7 @@ func (a *App) DropDatabase(config connection.ConnectionConfig, dbName string) co
 		sql       string
 	)
 	switch dbType {
-	case "mysql", "mariadb", "diros", "tdengine":
+	case "mysql", "mariadb", "diros", "tdengine", "clickhouse":
 		runConfig = config
 		runConfig.Database = ""
 		sql = fmt.Sprintf("DROP DATABASE %s", quoteIdentByType(dbType, dbName))
@@ -247,6 +265,9 @@ func (a *App) DropDatabase(config connection.ConnectionConfig, dbName string) co
 }
 
 func (a *App) RenameTable(config connection.ConnectionConfig, dbName string, oldTableName string, newTableName string) connection.QueryResult {
+	if err := ensureWritable(config, "重命名表"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	oldTableName = strings.TrimSpace(oldTableName)
 	newTableName = strings.TrimSpace(newTableName)
 	if oldTableName == "" || newTableName == "" {
@@ -293,13 +314,18 @@ func (a *App) RenameTable(config connection.ConnectionConfig, dbName string, old
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
+	backup, backupWarning := backupSchemaChanges(dbInst, runConfig, dbType, dbName, sql, []sqlstmt.SchemaChange{{Action: "RENAME", Object: "TABLE", Schema: schemaName, Name: pureOldTableName, NewName: newTableName}})
 	if _, err := dbInst.Exec(sql); err != nil {
+		discardSchemaBackup(backup)
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
-	return connection.QueryResult{Success: true, Message: "表重命名成功"}
+	return withSchemaBackup(connection.QueryResult{Success: true, Message: "表重命名成功"}, backup, backupWarning)
 }
 
 func (a *App) DropTable(config connection.ConnectionConfig, dbName string, tableName string) connection.QueryResult {
+	if err := ensureWritable(config, "删除表"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	tableName = strings.TrimSpace(tableName)
 	if tableName == "" {
 		return connection.QueryResult{Success: false, Message: "表名不能为空"}
@@ -307,7 +333,7 @@ func (a *App) DropTable(config connection.ConnectionConfig, dbName string, table
 
 	dbType := resolveDDLDBType(config)
 	switch dbType {
-	case "mysql", "mariadb", "diros", "sphinx", "postgres", "kingbase", "sqlite", "duckdb", "oracle", "dameng", "highgo", "vastbase", "sqlserver", "tdengine":
+	case "mysql", "mariadb", "diros", "sphinx", "postgres", "kingbase", "sqlite", "duckdb", "oracle", "dameng", "highgo", "vastbase", "sqlserver", "tdengine", "clickhouse":
 	default:
 		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源(%s)暂不支持删除表", dbType)}
 	}
@@ -324,10 +350,12 @@ func (a *App) DropTable(config connection.ConnectionConfig, dbName string, table
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
+	backup, backupWarning := backupSchemaChanges(dbInst, runConfig, dbType, dbName, sql, []sqlstmt.SchemaChange{{Action: "DROP", Object: "TABLE", Schema: schemaName, Name: pureTableName}})
 	if _, err := dbInst.Exec(sql); err != nil {
+		discardSchemaBackup(backup)
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
-	return connection.QueryResult{Success: true, Message: "表删除成功"}
+	return withSchemaBackup(connection.QueryResult{Success: true, Message: "表删除成功"}, backup, backupWarning)
 }
 
 func (a *App) MySQLConnect(config connection.ConnectionConfig) connection.QueryResult {
@@ -356,58 +384,177 @@ func (a *App) MySQLShowCreateTable(config connection.ConnectionConfig, dbName st
 }
 
 func (a *App) DBQuery(config connection.ConnectionConfig, dbName string, query string) connection.QueryResult {
+	return a.dbQuery(config, dbName, query, dbQueryOptions{route: queryRouteAuto})
+}
+
+// dbQueryOptions DBQuery 系列入口的执行选项。
+type dbQueryOptions struct {
+	route       string // 读语句执行节点：auto/primary/replica
+	ignoreLimit bool   // 用户确认后不受连接级拉取限制
+	queryID     string // 非空时登记为可取消语句
+}
+
+func (a *App) dbQuery(config connection.ConnectionConfig, dbName string, query string, opts dbQueryOptions) connection.QueryResult {
 	runConfig := normalizeRunConfig(config, dbName)
+	if target, ok := sqlstmt.DatabaseSwitch(runConfig.Type, query); ok {
+		return a.switchDatabase(config, target)
+	}
 
-	dbInst, err := a.getDatabase(runConfig)
+	query = sanitizeSQLForPgLike(runConfig.Type, query)
+	timeoutSeconds := runConfig.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 30
+	}
+	ctx, cancel := utils.ContextWithTimeout(time.Duration(timeoutSeconds) * time.Second)
+	defer cancel()
+
+	var tracked *runningQuery
+	if opts.queryID != "" {
+		var err error
+		ctx, tracked, err = a.registerQuery(ctx, opts.queryID, runConfig)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		defer a.unregisterQuery(tracked)
+	}
+	unlock, err := a.lockSQLiteWrite(ctx, runConfig, query)
 	if err != nil {
-		logger.Error(err, "DBQuery 获取连接失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	defer unlock()
+
+	req := queryRequest{config: runConfig, dbName: dbName, query: query, opts: opts}
+	return a.runQuery(ctx, req, &poolExecutor{app: a, config: runConfig, tracked: tracked})
+}
+
+// queryErrorResult 语句执行失败时在 Message 之外附带结构化错误，供编辑器标记出错位置。
+func queryErrorResult(err error, execSQL string, query string) connection.QueryResult {
+	qe := db.DescribeQueryError(err, execSQL, query)
+	if errors.Is(err, errQueryCanceled) {
+		qe.Class = db.QueryErrorCanceled
+	}
+	return connection.QueryResult{Success: false, Message: err.Error(), Error: qe}
+}
+
+// connectErrorResult 获取连接失败时的结果，无法细分的错误归为连接错误。
+func connectErrorResult(err error) connection.QueryResult {
+	qe := db.DescribeQueryError(err, "", "")
+	if qe.Class == db.QueryErrorOther {
+		qe.Class = db.QueryErrorConnection
+	}
+	return connection.QueryResult{Success: false, Message: err.Error(), Error: qe}
+}
+
+// DBQueryWithParams 执行带占位符的语句，参数由驱动绑定而不拼接进 SQL；占位符写法随数据库而定（?、$1、@p1、:1）。
+// 与 DBQuery 一样按语句是否返回结果集分别走查询或执行，并遵循只读模式、读写分离与拉取限制。
+func (a *App) DBQueryWithParams(config connection.ConnectionConfig, dbName string, query string, params []interface{}) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	if err := checkReadOnlySQL(runConfig, query); err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		logger.Error(err, "DBQueryWithParams 获取连接失败：%s", formatConnSummary(runConfig))
+		return connectErrorResult(err)
+	}
+
 	query = sanitizeSQLForPgLike(runConfig.Type, query)
+	execSQL := tagQuery(runConfig, dbName, "", query)
+	args := db.NormalizeJSONParams(params)
 	timeoutSeconds := runConfig.Timeout
 	if timeoutSeconds <= 0 {
 		timeoutSeconds = 30
 	}
 	ctx, cancel := utils.ContextWithTimeout(time.Duration(timeoutSeconds) * time.Second)
 	defer cancel()
+	unlock, err := a.lockSQLiteWrite(ctx, runConfig, query)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	defer unlock()
 
-	lowerQuery := strings.TrimSpace(strings.ToLower(query))
-	isReadQuery := strings.HasPrefix(lowerQuery, "select") || strings.HasPrefix(lowerQuery, "show") || strings.HasPrefix(lowerQuery, "describe") || strings.HasPrefix(lowerQuery, "explain")
-	// MongoDB JSON 命令中的 find/count/aggregate 也属于读查询
-	if !isReadQuery && strings.ToLower(strings.TrimSpace(runConfig.Type)) == "mongodb" && strings.HasPrefix(strings.TrimSpace(query), "{") {
-		isReadQuery = true
-	}
-	if isReadQuery {
-		var data []map[string]interface{}
-		var columns []string
-		if q, ok := dbInst.(interface {
-			QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
-		}); ok {
-			data, columns, err = q.QueryContext(ctx, query)
-		} else {
-			data, columns, err = dbInst.Query(query)
+	if isReadQuery(runConfig.Type, query) {
+		route := queryRouteAuto
+		if readOnlyViolation(runConfig.Type, query) != "" {
+			route = queryRoutePrimary
 		}
+		readInst, readConfig, routeNote, err := a.resolveReadTarget(runConfig, dbInst, route)
 		if err != nil {
-			logger.Error(err, "DBQuery 查询失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(query))
 			return connection.QueryResult{Success: false, Message: err.Error()}
 		}
-		return connection.QueryResult{Success: true, Data: data, Fields: columns}
-	} else {
-		var affected int64
-		if e, ok := dbInst.(interface {
-			ExecContext(context.Context, string) (int64, error)
-		}); ok {
-			affected, err = e.ExecContext(ctx, query)
-		} else {
-			affected, err = dbInst.Exec(query)
+		querier, ok := readInst.(db.ParamQuerier)
+		if !ok {
+			return connection.QueryResult{Success: false, Message: db.ErrParamsUnsupported.Error()}
+		}
+		now := time.Now()
+		limit, used, exhausted := a.transferLimitFor(runConfig, now)
+		if exhausted {
+			return connection.QueryResult{
+				Success:      true,
+				Message:      fmt.Sprintf("最近一分钟已读取 %d 行，达到每分钟行数上限 %d。是否继续执行？", used, runConfig.MaxRowsPerMinute),
+				Data:         []map[string]interface{}{},
+				LimitReached: true,
+			}
+		}
+		readSQL, setup := limitParamReadQuery(runConfig, execSQL)
+		started := time.Now()
+		data, columns, err := queryParamsLimited(db.WithTransferLimit(ctx, limit), readInst, querier, setup, readSQL, args)
+		a.wireLog.record(readConfig, dbName, "query", readSQL, started, int64(len(data)), err)
+		a.recordHistory(readConfig, dbName, "query", query, started, int64(len(data)), err)
+		a.recordTransferredRows(runConfig, len(data), now)
+		if res, ok := transferLimitResult(readConfig, err, data, columns); ok {
+			return res
 		}
 		if err != nil {
-			logger.Error(err, "DBQuery 执行失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(query))
-			return connection.QueryResult{Success: false, Message: err.Error()}
+			logger.Error(err, "DBQueryWithParams 查询失败：%s SQL片段=%q", formatConnSummary(readConfig), sqlSnippet(query))
+			a.recentErrors.record(recentErrorKindQuery, readConfig, dbName, err, query)
+			return queryErrorResult(err, limitedQueryErrorSQL(readSQL, execSQL, query), query)
 		}
-		return connection.QueryResult{Success: true, Data: map[string]int64{"affectedRows": affected}}
+		return connection.QueryResult{Success: true, Message: routeNote, Data: data, Fields: columns}
+	}
+
+	querier, ok := dbInst.(db.ParamQuerier)
+	if !ok {
+		return connection.QueryResult{Success: false, Message: db.ErrParamsUnsupported.Error()}
+	}
+	started := time.Now()
+	affected, err := querier.ExecParams(ctx, execSQL, args)
+	a.wireLog.record(runConfig, dbName, "exec", execSQL, started, affected, err)
+	a.recordHistory(runConfig, dbName, "exec", query, started, affected, err)
+	if err != nil {
+		logger.Error(err, "DBQueryWithParams 执行失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(query))
+		a.recentErrors.record(recentErrorKindQuery, runConfig, dbName, err, query)
+		return queryErrorResult(err, execSQL, query)
+	}
+	return connection.QueryResult{Success: true, Data: map[string]int64{"affectedRows": affected}}
+}
+
+// queryParamsLimited 执行参数化查询；setup 非空时在独立会话上先执行会话设置，会话用后丢弃。
+// 数据源不支持独立会话时不做服务端限制。
+func queryParamsLimited(ctx context.Context, inst db.Database, querier db.ParamQuerier, setup string, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	opener, ok := inst.(db.SessionOpener)
+	if setup == "" || !ok {
+		return querier.QueryParams(ctx, query, args)
+	}
+	session, err := opener.OpenSession(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer session.Close()
+	if _, err := session.ExecContext(ctx, setup); err != nil {
+		return nil, nil, err
 	}
+	return session.QueryParams(ctx, query, args)
+}
+
+// isReadQuery 判断语句是否返回结果集（走 Query 而非 Exec），按语句分类识别 WITH、VALUES、RETURNING、CALL 与前导注释。
+func isReadQuery(dbType string, query string) bool {
+	// MongoDB JSON 命令中的 find/count/aggregate 也属于读查询
+	if strings.ToLower(strings.TrimSpace(dbType)) == "mongodb" && strings.HasPrefix(strings.TrimSpace(query), "{") {
+		return true
+	}
+	return sqlstmt.Classify(dbType, query).ReturnsRows
 }
 
 func sqlSnippet(query string) string {
@@ -426,7 +573,11 @@ func (a *App) DBGetDatabases(config connection.ConnectionConfig) connection.Quer
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
-	dbs, err := dbInst.GetDatabases()
+	var dbs []string
+	warning, err := a.runWithReconnect(config, dbInst, "DBGetDatabases", func(inst db.Database) (getErr error) {
+		dbs, getErr = inst.GetDatabases()
+		return getErr
+	})
 	if err != nil {
 		logger.Error(err, "DBGetDatabases 获取数据库列表失败：%s", formatConnSummary(config))
 		return connection.QueryResult{Success: false, Message: err.Error()}
@@ -437,7 +588,7 @@ func (a *App) DBGetDatabases(config connection.ConnectionConfig) connection.Quer
 		resData = append(resData, map[string]string{"Database": name})
 	}
 
-	return connection.QueryResult{Success: true, Data: resData}
+	return connection.QueryResult{Success: true, Message: warning, Data: resData}
 }
 
 func (a *App) DBGetTables(config connection.ConnectionConfig, dbName string) connection.QueryResult {
@@ -449,7 +600,11 @@ func (a *App) DBGetTables(config connection.ConnectionConfig, dbName string) con
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
-	tables, err := dbInst.GetTables(dbName)
+	var tables []string
+	warning, err := a.runWithReconnect(runConfig, dbInst, "DBGetTables", func(inst db.Database) (getErr error) {
+		tables, getErr = inst.GetTables(dbName)
+		return getErr
+	})
 	if err != nil {
 		logger.Error(err, "DBGetTables 获取表列表失败：%s", formatConnSummary(runConfig))
 		return connection.QueryResult{Success: false, Message: err.Error()}
@@ -460,7 +615,7 @@ func (a *App) DBGetTables(config connection.ConnectionConfig, dbName string) con
 		resData = append(resData, map[string]string{"Table": name})
 	}
 
-	return connection.QueryResult{Success: true, Data: resData}
+	return connection.QueryResult{Success: true, Message: warning, Data: resData}
 }
 
 func (a *App) DBShowCreateTable(config connection.ConnectionConfig, dbName string, tableName string) connection.QueryResult {
@@ -520,8 +675,10 @@ func shouldFallbackCreateStatement(dbType string, ddl string) bool {
 	return true
 }
 
+// hasCreateTableHead 判断首条语句是否为 CREATE TABLE；PostgreSQL 建表脚本前置的 CREATE SEQUENCE 会被跳过。
 func hasCreateTableHead(sqlText string) bool {
 	lines := strings.Split(sqlText, "\n")
+	inSequence := false
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		if line == "" {
@@ -530,7 +687,12 @@ func hasCreateTableHead(sqlText string) bool {
 		if strings.HasPrefix(line, "--") || strings.HasPrefix(line, "/*") || strings.HasPrefix(line, "*") {
 			continue
 		}
-		return strings.HasPrefix(strings.ToLower(line), "create table")
+		lower := strings.ToLower(line)
+		if inSequence || strings.HasPrefix(lower, "create sequence") {
+			inSequence = !strings.HasSuffix(line, ";")
+			continue
+		}
+		return strings.HasPrefix(lower, "create table")
 	}
 	return false
 }
@@ -570,6 +732,14 @@ func buildFallbackCreateStatement(dbType string, schemaName string, tableName st
 				defParts = append(defParts, "DEFAULT "+defVal)
 			}
 		}
+		switch dbType {
+		case "postgres", "kingbase", "highgo", "vastbase":
+			if col.GenerationExpr != "" {
+				defParts = append(defParts, "GENERATED ALWAYS AS ("+strings.TrimSpace(col.GenerationExpr)+") STORED")
+			} else if col.Default == nil && col.Identity != "" {
+				defParts = append(defParts, "GENERATED "+col.Identity+" AS IDENTITY")
+			}
+		}
 
 		columnLines = append(columnLines, "  "+strings.Join(defParts, " "))
 		if strings.EqualFold(strings.TrimSpace(col.Key), "PRI") {
@@ -602,12 +772,16 @@ func (a *App) DBGetColumns(config connection.ConnectionConfig, dbName string, ta
 	}
 
 	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
-	columns, err := dbInst.GetColumns(schemaName, pureTableName)
+	var columns []connection.ColumnDefinition
+	warning, err := a.runWithReconnect(runConfig, dbInst, "DBGetColumns", func(inst db.Database) (getErr error) {
+		columns, getErr = inst.GetColumns(schemaName, pureTableName)
+		return getErr
+	})
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
-	return connection.QueryResult{Success: true, Data: columns}
+	return connection.QueryResult{Success: true, Message: warning, Data: columns}
 }
 
 func (a *App) DBGetIndexes(config connection.ConnectionConfig, dbName string, tableName string) connection.QueryResult {
@@ -619,12 +793,16 @@ func (a *App) DBGetIndexes(config connection.ConnectionConfig, dbName string, ta
 	}
 
 	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
-	indexes, err := dbInst.GetIndexes(schemaName, pureTableName)
+	var indexes []connection.IndexDefinition
+	warning, err := a.runWithReconnect(runConfig, dbInst, "DBGetIndexes", func(inst db.Database) (getErr error) {
+		indexes, getErr = inst.GetIndexes(schemaName, pureTableName)
+		return getErr
+	})
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
-	return connection.QueryResult{Success: true, Data: indexes}
+	return connection.QueryResult{Success: true, Message: warning, Data: indexes}
 }
 
 func (a *App) DBGetForeignKeys(config connection.ConnectionConfig, dbName string, tableName string) connection.QueryResult {
@@ -636,12 +814,16 @@ func (a *App) DBGetForeignKeys(config connection.ConnectionConfig, dbName string
 	}
 
 	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
-	fks, err := dbInst.GetForeignKeys(schemaName, pureTableName)
+	var fks []connection.ForeignKeyDefinition
+	warning, err := a.runWithReconnect(runConfig, dbInst, "DBGetForeignKeys", func(inst db.Database) (getErr error) {
+		fks, getErr = inst.GetForeignKeys(schemaName, pureTableName)
+		return getErr
+	})
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
-	return connection.QueryResult{Success: true, Data: fks}
+	return connection.QueryResult{Success: true, Message: warning, Data: fks}
 }
 
 func (a *App) DBGetTriggers(config connection.ConnectionConfig, dbName string, tableName string) connection.QueryResult {
@@ -653,15 +835,22 @@ func (a *App) DBGetTriggers(config connection.ConnectionConfig, dbName string, t
 	}
 
 	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
-	triggers, err := dbInst.GetTriggers(schemaName, pureTableName)
+	var triggers []connection.TriggerDefinition
+	warning, err := a.runWithReconnect(runConfig, dbInst, "DBGetTriggers", func(inst db.Database) (getErr error) {
+		triggers, getErr = inst.GetTriggers(schemaName, pureTableName)
+		return getErr
+	})
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
-	return connection.QueryResult{Success: true, Data: triggers}
+	return connection.QueryResult{Success: true, Message: warning, Data: triggers}
 }
 
 func (a *App) DropView(config connection.ConnectionConfig, dbName string, viewName string) connection.QueryResult {
+	if err := ensureWritable(config, "删除视图"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	viewName = strings.TrimSpace(viewName)
 	if viewName == "" {
 		return connection.QueryResult{Success: false, Message: "视图名称不能为空"}
@@ -686,13 +875,18 @@ func (a *App) DropView(config connection.ConnectionConfig, dbName string, viewNa
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
+	backup, backupWarning := backupSchemaChanges(dbInst, runConfig, dbType, dbName, sql, []sqlstmt.SchemaChange{{Action: "DROP", Object: "VIEW", Schema: schemaName, Name: pureViewName}})
 	if _, err := dbInst.Exec(sql); err != nil {
+		discardSchemaBackup(backup)
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
-	return connection.QueryResult{Success: true, Message: "视图删除成功"}
+	return withSchemaBackup(connection.QueryResult{Success: true, Message: "视图删除成功"}, backup, backupWarning)
 }
 
 func (a *App) DropFunction(config connection.ConnectionConfig, dbName string, routineName string, routineType string) connection.QueryResult {
+	if err := ensureWritable(config, "删除函数/存储过程"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	routineName = strings.TrimSpace(routineName)
 	routineType = strings.TrimSpace(strings.ToUpper(routineType))
 	if routineName == "" {
@@ -736,6 +930,9 @@ func (a *App) DropFunction(config connection.ConnectionConfig, dbName string, ro
 }
 
 func (a *App) RenameView(config connection.ConnectionConfig, dbName string, oldName string, newName string) connection.QueryResult {
+	if err := ensureWritable(config, "重命名视图"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	oldName = strings.TrimSpace(oldName)
 	newName = strings.TrimSpace(newName)
 	if oldName == "" || newName == "" {
@@ -777,10 +974,12 @@ func (a *App) RenameView(config connection.ConnectionConfig, dbName string, oldN
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
+	backup, backupWarning := backupSchemaChanges(dbInst, runConfig, dbType, dbName, sql, []sqlstmt.SchemaChange{{Action: "RENAME", Object: "VIEW", Schema: schemaName, Name: pureOldName, NewName: newName}})
 	if _, err := dbInst.Exec(sql); err != nil {
+		discardSchemaBackup(backup)
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
-	return connection.QueryResult{Success: true, Message: "视图重命名成功"}
+	return withSchemaBackup(connection.QueryResult{Success: true, Message: "视图重命名成功"}, backup, backupWarning)
 }
 
 func (a *App) DBGetAllColumns(config connection.ConnectionConfig, dbName string) connection.QueryResult {
@@ -791,10 +990,15 @@ func (a *App) DBGetAllColumns(config connection.ConnectionConfig, dbName string)
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
-	cols, err := dbInst.GetAllColumns(dbName)
+	var cols []connection.ColumnDefinitionWithTable
+	warning, err := a.runWithReconnect(runConfig, dbInst, "DBGetAllColumns", func(inst db.Database) (getErr error) {
+		cols, getErr = inst.GetAllColumns(dbName)
+		return getErr
+	})
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
+	fillColumnSchema(cols)
 
-	return connection.QueryResult{Success: true, Data: cols}
+	return connection.QueryResult{Success: true, Message: warning, Data: cols}
 }
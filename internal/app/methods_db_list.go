@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+// systemDatabaseNames 各数据库自带的系统库/schema（小写）。
+var systemDatabaseNames = map[string][]string{
+	"mysql":      {"information_schema", "performance_schema", "mysql", "sys"},
+	"diros":      {"information_schema", "mysql", "__internal_schema"},
+	"postgres":   {"template0", "template1", "information_schema", "pg_catalog", "pg_toast"},
+	"sqlserver":  {"master", "tempdb", "model", "msdb", "information_schema", "sys", "guest"},
+	"clickhouse": {"system", "information_schema"},
+	"mongodb":    {"admin", "config", "local"},
+	"oracle": {"sys", "system", "outln", "xdb", "dbsnmp", "appqossys", "audsys", "ctxsys", "dbsfwuser", "dvf", "dvsys",
+		"gsmadmin_internal", "gsmcatuser", "gsmuser", "lbacsys", "mdsys", "ojvmsys", "olapsys", "orddata", "ordplugins",
+		"ordsys", "remote_scheduler_agent", "si_informtn_schema", "sysbackup", "sysdg", "syskm", "sysrac", "wmsys",
+		"anonymous", "mddata", "xs$null", "ggsys", "dip", "sys$umf", "gsmrootuser", "pdbadmin", "apex_public_user", "flows_files"},
+	"dameng": {"sys", "sysdba", "sysauditor", "syssso", "ctisys", "sysjob"},
+	"duckdb": {"information_schema", "pg_catalog"},
+}
+
+// isSystemDatabaseName 判断名称是否为该类型数据库的系统库/schema。
+func isSystemDatabaseName(dbType string, name string) bool {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	family := dbType
+	switch dbType {
+	case "mariadb", "sphinx":
+		family = "mysql"
+	case "kingbase", "highgo", "vastbase":
+		family = "postgres"
+	}
+	if family == "postgres" && (strings.HasPrefix(lower, "pg_temp_") || strings.HasPrefix(lower, "pg_toast_temp_")) {
+		return true
+	}
+	for _, system := range systemDatabaseNames[family] {
+		if lower == system {
+			return true
+		}
+	}
+	return false
+}
+
+// filterDatabaseNames 按选项隐藏系统库并按模式筛选，保持原顺序。
+// 模式以逗号分隔，支持 * ? 通配（% 视同 *），忽略大小写；以 ! 开头的模式表示排除。
+// 只有排除模式时保留其余全部名称。
+func filterDatabaseNames(dbType string, names []string, options connection.DatabaseListOptions) ([]string, error) {
+	var includes, excludes []string
+	for _, raw := range strings.Split(options.Pattern, ",") {
+		pattern := strings.ToLower(strings.TrimSpace(strings.ReplaceAll(raw, "%", "*")))
+		exclude := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimSpace(strings.TrimPrefix(pattern, "!"))
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("数据库过滤模式无效：%s", strings.TrimSpace(raw))
+		}
+		if exclude {
+			excludes = append(excludes, pattern)
+		} else {
+			includes = append(includes, pattern)
+		}
+	}
+	matchAny := func(patterns []string, name string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if options.HideSystem && isSystemDatabaseName(dbType, name) {
+			continue
+		}
+		lower := strings.ToLower(name)
+		if len(includes) > 0 && !matchAny(includes, lower) {
+			continue
+		}
+		if matchAny(excludes, lower) {
+			continue
+		}
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+// DBGetDatabasesWithOptions 返回按连接设置过滤后的数据库列表：可隐藏系统库/schema，并按名称模式筛选，
+// 共享实例上数据库很多时无需把全部名称传给前端。Message 中注明被过滤的数量。
+func (a *App) DBGetDatabasesWithOptions(config connection.ConnectionConfig, options connection.DatabaseListOptions) connection.QueryResult {
+	res := a.DBGetDatabases(config)
+	if !res.Success || (!options.HideSystem && strings.TrimSpace(options.Pattern) == "") {
+		return res
+	}
+	rows, _ := res.Data.([]map[string]string)
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row["Database"])
+	}
+	filtered, err := filterDatabaseNames(resolveDDLDBType(config), names, options)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	data := make([]map[string]string, 0, len(filtered))
+	for _, name := range filtered {
+		data = append(data, map[string]string{"Database": name})
+	}
+	message := res.Message
+	if hidden := len(names) - len(filtered); hidden > 0 {
+		note := fmt.Sprintf("已按连接设置隐藏 %d 个数据库", hidden)
+		if message != "" {
+			message += "；" + note
+		} else {
+			message = note
+		}
+	}
+	return connection.QueryResult{Success: true, Message: message, Data: data}
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestFilterDatabaseNames(t *testing.T) {
+	names := []string{"information_schema", "mysql", "app_main", "app_test1", "App_Report", "crm", "performance_schema", "sys"}
+	cases := []struct {
+		dbType  string
+		options connection.DatabaseListOptions
+		want    []string
+	}{
+		{"mysql", connection.DatabaseListOptions{HideSystem: true}, []string{"app_main", "app_test1", "App_Report", "crm"}},
+		{"mysql", connection.DatabaseListOptions{Pattern: "app_*, !app_test*"}, []string{"app_main", "App_Report"}},
+		{"mariadb", connection.DatabaseListOptions{HideSystem: true, Pattern: "!crm"}, []string{"app_main", "app_test1", "App_Report"}},
+		{"mysql", connection.DatabaseListOptions{Pattern: "%report"}, []string{"App_Report"}},
+	}
+	for _, c := range cases {
+		got, err := filterDatabaseNames(c.dbType, names, c.options)
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s %+v = %v (%v), want %v", c.dbType, c.options, got, err, c.want)
+		}
+	}
+
+	pg, _ := filterDatabaseNames("kingbase", []string{"postgres", "template1", "pg_catalog", "pg_temp_3", "public", "shop"}, connection.DatabaseListOptions{HideSystem: true})
+	if !reflect.DeepEqual(pg, []string{"postgres", "public", "shop"}) {
+		t.Fatalf("pg = %v", pg)
+	}
+	if _, err := filterDatabaseNames("mysql", names, connection.DatabaseListOptions{Pattern: "app_["}); err == nil {
+		t.Fatal("expected invalid pattern error")
+	}
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+// databaseOverviewTopTables 概览中列出的最大表数量。
+const databaseOverviewTopTables = 10
+
+// DatabaseOverview 数据库仪表盘的汇总信息。数量与大小为 -1 表示数据库未提供。
+type DatabaseOverview struct {
+	Database      string      `json:"database"`
+	SizeBytes     int64       `json:"sizeBytes"` // 数据库文件或各表合计大小
+	DataBytes     int64       `json:"dataBytes"`
+	IndexBytes    int64       `json:"indexBytes"`
+	TableCount    int         `json:"tableCount"`
+	ViewCount     int64       `json:"viewCount"`
+	RoutineCount  int64       `json:"routineCount"` // 函数、存储过程（Oracle 含包）
+	Charset       string      `json:"charset,omitempty"`
+	Collation     string      `json:"collation,omitempty"`
+	LargestTables []TableStat `json:"largestTables"`
+}
+
+// GetDatabaseOverview 在服务端一次汇总数据库大小、表/视图/函数数量、默认字符集与排序规则以及最大的若干张表，
+// 前端仪表盘不必再分别查询。
+func (a *App) GetDatabaseOverview(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+	var overview DatabaseOverview
+	var warnings []string
+	warning, err := a.runWithReconnect(runConfig, dbInst, "GetDatabaseOverview", func(inst db.Database) error {
+		stats, statsErr := loadTableStats(inst, dbType, dbName)
+		if statsErr != nil {
+			return statsErr
+		}
+		overview = buildDatabaseOverview(dbName, stats)
+		if query := databaseOverviewSQL(dbType, dbName); query != "" {
+			rows, _, queryErr := inst.Query(query)
+			if queryErr != nil {
+				// 缺少系统视图权限时仍返回表统计
+				warnings = append(warnings, "读取视图、函数数量与字符集失败："+normalizeErrorMessage(queryErr))
+			} else if len(rows) > 0 {
+				applyDatabaseOverviewRow(&overview, rows[0])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "读取数据库概览失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	if warning != "" {
+		warnings = append([]string{warning}, warnings...)
+	}
+	return connection.QueryResult{Success: true, Message: strings.Join(warnings, "；"), Data: overview}
+}
+
+// buildDatabaseOverview 由各表统计汇总大小与最大的表。
+func buildDatabaseOverview(dbName string, stats []TableStat) DatabaseOverview {
+	overview := DatabaseOverview{
+		Database:     dbName,
+		SizeBytes:    -1,
+		DataBytes:    -1,
+		IndexBytes:   -1,
+		TableCount:   len(stats),
+		ViewCount:    -1,
+		RoutineCount: -1,
+	}
+	for _, stat := range stats {
+		if stat.DataBytes >= 0 {
+			overview.DataBytes = max(overview.DataBytes, 0) + stat.DataBytes
+		}
+		if stat.IndexBytes >= 0 {
+			overview.IndexBytes = max(overview.IndexBytes, 0) + stat.IndexBytes
+		}
+	}
+	if overview.DataBytes >= 0 || overview.IndexBytes >= 0 {
+		overview.SizeBytes = max(overview.DataBytes, 0) + max(overview.IndexBytes, 0)
+	}
+
+	sorted := make([]TableStat, 0, len(stats))
+	for _, stat := range stats {
+		if stat.TotalBytes > 0 || stat.Rows > 0 {
+			sorted = append(sorted, stat)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].TotalBytes != sorted[j].TotalBytes {
+			return sorted[i].TotalBytes > sorted[j].TotalBytes
+		}
+		return sorted[i].Rows > sorted[j].Rows
+	})
+	overview.LargestTables = sorted[:min(len(sorted), databaseOverviewTopTables)]
+	return overview
+}
+
+// databaseOverviewSQL 返回单行汇总查询，列别名为 view_count、routine_count、charset_name、collation_name、size_bytes，
+// 数据库不提供的列省略。
+func databaseOverviewSQL(dbType string, dbName string) string {
+	schema := dialect.QuoteLiteral(dbType, dbName)
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		return fmt.Sprintf("SELECT (SELECT COUNT(*) FROM information_schema.VIEWS WHERE TABLE_SCHEMA = %[1]s) AS view_count, "+
+			"(SELECT COUNT(*) FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA = %[1]s) AS routine_count, "+
+			"DEFAULT_CHARACTER_SET_NAME AS charset_name, DEFAULT_COLLATION_NAME AS collation_name "+
+			"FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = %[1]s", schema)
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return "SELECT (SELECT COUNT(*) FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace " +
+			"WHERE c.relkind IN ('v', 'm') AND n.nspname NOT IN ('pg_catalog', 'information_schema')) AS view_count, " +
+			"(SELECT COUNT(*) FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace " +
+			"WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')) AS routine_count, " +
+			"pg_encoding_to_char(encoding) AS charset_name, datcollate AS collation_name, pg_database_size(datname) AS size_bytes " +
+			"FROM pg_database WHERE datname = current_database()"
+	case "sqlserver":
+		return "SELECT (SELECT COUNT(*) FROM sys.views) AS view_count, " +
+			"(SELECT COUNT(*) FROM sys.objects WHERE type IN ('P', 'FN', 'IF', 'TF')) AS routine_count, " +
+			"CAST(DATABASEPROPERTYEX(DB_NAME(), 'Collation') AS NVARCHAR(128)) AS collation_name, " +
+			"(SELECT SUM(CAST(size AS BIGINT)) * 8192 FROM sys.database_files) AS size_bytes"
+	case "oracle", "dameng":
+		owner := dialect.QuoteLiteral(dbType, strings.ToUpper(dbName))
+		return fmt.Sprintf("SELECT (SELECT COUNT(*) FROM ALL_VIEWS WHERE OWNER = %[1]s) AS view_count, "+
+			"(SELECT COUNT(*) FROM ALL_OBJECTS WHERE OWNER = %[1]s AND OBJECT_TYPE IN ('FUNCTION', 'PROCEDURE', 'PACKAGE')) AS routine_count, "+
+			"(SELECT VALUE FROM NLS_DATABASE_PARAMETERS WHERE PARAMETER = 'NLS_CHARACTERSET') AS charset_name FROM DUAL", owner)
+	case "clickhouse":
+		return fmt.Sprintf("SELECT (SELECT count() FROM system.tables WHERE database = %s AND engine LIKE '%%View') AS view_count", schema)
+	case "sqlite":
+		return "SELECT (SELECT COUNT(*) FROM sqlite_master WHERE type = 'view') AS view_count, " +
+			"(SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()) AS size_bytes, " +
+			"(SELECT encoding FROM pragma_encoding()) AS charset_name"
+	case "duckdb":
+		return "SELECT (SELECT COUNT(*) FROM duckdb_views() WHERE NOT internal) AS view_count, " +
+			"(SELECT COUNT(*) FROM duckdb_functions() WHERE NOT internal AND function_type IN ('macro', 'table_macro')) AS routine_count"
+	}
+	return ""
+}
+
+func applyDatabaseOverviewRow(overview *DatabaseOverview, row map[string]interface{}) {
+	if n, ok := tableStatInt(statRowText(row, "view_count")); ok {
+		overview.ViewCount = n
+	}
+	if n, ok := tableStatInt(statRowText(row, "routine_count")); ok {
+		overview.RoutineCount = n
+	}
+	if n, ok := tableStatInt(statRowText(row, "size_bytes")); ok && n >= 0 {
+		overview.SizeBytes = n
+	}
+	overview.Charset = statRowText(row, "charset_name")
+	overview.Collation = statRowText(row, "collation_name")
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBuildDatabaseOverview(t *testing.T) {
+	var stats []TableStat
+	for i := 0; i < databaseOverviewTopTables+3; i++ {
+		stats = append(stats, TableStat{Name: fmt.Sprintf("t%02d", i), Rows: int64(i), DataBytes: int64(i * 100), IndexBytes: 10, TotalBytes: int64(i*100 + 10)})
+	}
+	stats = append(stats, TableStat{Name: "empty", Rows: 0, DataBytes: -1, IndexBytes: -1, TotalBytes: -1})
+
+	overview := buildDatabaseOverview("shop", stats)
+	if overview.TableCount != len(stats) || overview.ViewCount != -1 || overview.RoutineCount != -1 {
+		t.Fatalf("overview = %+v", overview)
+	}
+	if overview.DataBytes != 7800 || overview.IndexBytes != 130 || overview.SizeBytes != 7930 {
+		t.Fatalf("sizes = %d %d %d", overview.DataBytes, overview.IndexBytes, overview.SizeBytes)
+	}
+	if len(overview.LargestTables) != databaseOverviewTopTables || overview.LargestTables[0].Name != "t12" {
+		t.Fatalf("largest = %+v", overview.LargestTables)
+	}
+
+	// 服务端报告的库大小优先于各表合计
+	applyDatabaseOverviewRow(&overview, map[string]interface{}{
+		"VIEW_COUNT": int64(3), "ROUTINE_COUNT": "2", "SIZE_BYTES": "81920", "CHARSET_NAME": []byte("utf8mb4"), "COLLATION_NAME": "utf8mb4_general_ci",
+	})
+	if overview.ViewCount != 3 || overview.RoutineCount != 2 || overview.SizeBytes != 81920 || overview.Charset != "utf8mb4" || overview.Collation != "utf8mb4_general_ci" {
+		t.Fatalf("overview = %+v", overview)
+	}
+}
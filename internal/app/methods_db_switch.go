@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+// DatabaseSwitchResult USE db / \c db 的执行结果；前端据 ActiveDatabase 切换标签页的当前数据库，后续语句以新库执行。
+type DatabaseSwitchResult struct {
+	AffectedRows   int64  `json:"affectedRows"`
+	ActiveDatabase string `json:"activeDatabase"`
+}
+
+func databaseSwitchResult(target string) connection.QueryResult {
+	return connection.QueryResult{
+		Success: true,
+		Message: fmt.Sprintf("已切换到数据库 %s", target),
+		Data:    DatabaseSwitchResult{ActiveDatabase: target},
+	}
+}
+
+// switchDatabase 共享连接池没有会话状态，把 USE 发给池中的某条连接只会改变那一条连接的当前库，
+// 之后的语句可能落在其他连接上。这里不执行语句，只确认目标库可以连接，由调用方改用新的 dbName。
+func (a *App) switchDatabase(config connection.ConnectionConfig, target string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, target)
+	if _, err := a.getDatabase(runConfig); err != nil {
+		logger.Error(err, "切换数据库失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return databaseSwitchResult(target)
+}
+
+// switchTabSessionDatabase 在已固定的标签页会话上切换数据库，调用方需持有 pinned.mu。
+// USE 直接在会话连接上执行，会话变量与临时表保持不变；PostgreSQL 的 \c 需要连到另一个库，会换用新连接，原会话状态丢弃。
+func (a *App) switchTabSessionDatabase(ctx context.Context, tabID string, pinned *tabSession, config connection.ConnectionConfig, target string, query string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, target)
+	switch strings.ToLower(strings.TrimSpace(runConfig.Type)) {
+	case "postgres", "kingbase", "highgo", "vastbase":
+		dbInst, err := a.getDatabase(runConfig)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		opener, ok := dbInst.(db.SessionOpener)
+		if !ok {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源（%s）不支持独立会话", runConfig.Type)}
+		}
+		session, err := opener.OpenSession(ctx)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+		}
+		if err := session.Ping(ctx); err != nil {
+			_ = session.Close()
+			return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+		}
+		if err := pinned.session.Close(); err != nil {
+			logger.Error(err, "切换数据库时释放原会话失败：%s 标签页=%s", formatConnSummary(pinned.config), tabID)
+		}
+		pinned.session = session
+	default:
+		_, err := pinned.session.ExecContext(ctx, query)
+		a.wireLog.record(runConfig, target, "exec", query, time.Now(), 0, err)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: a.tabSessionError(tabID, pinned, err)}
+		}
+	}
+
+	pinned.config = runConfig
+	pinned.dbName = target
+	pinned.cacheKey = getCacheKey(applyCustomDriverType(runConfig))
+	logger.Infof("标签页会话已切换数据库：%s 标签页=%s", formatConnSummary(runConfig), tabID)
+	return databaseSwitchResult(target)
+}
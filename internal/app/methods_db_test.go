@@ -0,0 +1,23 @@
+package app
+
+import "testing"
+
+func TestCreateDatabaseSQL(t *testing.T) {
+	cases := map[string]string{
+		"mysql":     "CREATE DATABASE `a``b` CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci",
+		"postgres":  `CREATE DATABASE "a` + "`" + `b"`,
+		"sqlserver": "CREATE DATABASE [a`b]",
+		"tdengine":  "CREATE DATABASE IF NOT EXISTS `a``b`",
+	}
+	for dbType, want := range cases {
+		got, err := createDatabaseSQL(dbType, "a`b")
+		if err != nil || got != want {
+			t.Errorf("createDatabaseSQL(%s) = %q, %v; want %q", dbType, got, err, want)
+		}
+	}
+	for _, dbType := range []string{"sqlite", "duckdb", "oracle", "sphinx"} {
+		if _, err := createDatabaseSQL(dbType, "x"); err == nil {
+			t.Errorf("expected %s to reject CREATE DATABASE", dbType)
+		}
+	}
+}
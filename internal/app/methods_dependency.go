@@ -0,0 +1,378 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	dependencyKindReads      = "reads"      // 视图/例程/触发器体内引用了目标对象
+	dependencyKindTriggerOn  = "trigger_on" // 触发器挂在目标表上
+	dependencyKindCalls      = "calls"      // 触发器执行目标函数（PostgreSQL）
+	dependencyKindForeignKey = "foreign_key"
+)
+
+// DependencyObject 数据库对象。Key 为 schema.name（无 schema 时为 name），用于 DependencyEdge 引用。
+type DependencyObject struct {
+	Key          string `json:"key"`
+	Schema       string `json:"schema,omitempty"`
+	Name         string `json:"name"`
+	Type         string `json:"type"` // table/view/trigger/procedure/function
+	DependsOn    int    `json:"dependsOn"`
+	ReferencedBy int    `json:"referencedBy"`
+}
+
+// DependencyEdge From 依赖 To（删除或修改 To 会影响 From）。
+type DependencyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"` // reads/trigger_on/calls/foreign_key
+}
+
+// DependencyGraph 数据库对象依赖图。
+type DependencyGraph struct {
+	Objects  []DependencyObject `json:"objects"`
+	Edges    []DependencyEdge   `json:"edges"`
+	Warnings []string           `json:"warnings,omitempty"`
+}
+
+type dependencyCollector struct {
+	objects  map[string]*DependencyObject
+	byName   map[string][]string // 小写对象名 -> Key，用于在定义文本中匹配引用
+	edges    map[DependencyEdge]struct{}
+	bodies   map[string]string // 对象 Key -> 已扫描的定义文本，用于列级影响分析
+	warnings []string
+}
+
+func newDependencyCollector() *dependencyCollector {
+	return &dependencyCollector{
+		objects: map[string]*DependencyObject{},
+		byName:  map[string][]string{},
+		edges:   map[DependencyEdge]struct{}{},
+		bodies:  map[string]string{},
+	}
+}
+
+func dependencyKey(schema string, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+func (c *dependencyCollector) addObject(schema string, name string, objectType string) string {
+	key := dependencyKey(schema, name)
+	if _, exists := c.objects[key]; !exists {
+		c.objects[key] = &DependencyObject{Key: key, Schema: schema, Name: name, Type: objectType}
+		lower := strings.ToLower(name)
+		c.byName[lower] = append(c.byName[lower], key)
+	}
+	return key
+}
+
+func (c *dependencyCollector) addEdge(from string, to string, kind string) {
+	if from == "" || to == "" || from == to {
+		return
+	}
+	if c.objects[from] == nil || c.objects[to] == nil {
+		return
+	}
+	c.edges[DependencyEdge{From: from, To: to, Kind: kind}] = struct{}{}
+}
+
+// resolve 按名称查找对象，同名对象优先取同 schema。
+func (c *dependencyCollector) resolve(schema string, name string) string {
+	keys := c.byName[strings.ToLower(name)]
+	if len(keys) == 0 {
+		return ""
+	}
+	for _, key := range keys {
+		if strings.EqualFold(c.objects[key].Schema, schema) {
+			return key
+		}
+	}
+	return keys[0]
+}
+
+// addBodyReferences 扫描视图/例程/触发器定义，识别其中出现的已知对象名。
+func (c *dependencyCollector) addBodyReferences(from string, body string) {
+	if strings.TrimSpace(body) == "" {
+		return
+	}
+	source := c.objects[from]
+	if source == nil {
+		return
+	}
+	c.bodies[from] += body + "\n"
+	tokens := tokenizeSQL(body)
+	for i := 0; i < len(tokens); i++ {
+		parts, next := readQualifiedName(tokens, i)
+		if len(parts) == 0 {
+			continue
+		}
+		i = next - 1
+		name := parts[len(parts)-1]
+		schema := source.Schema
+		if len(parts) >= 2 {
+			schema = parts[len(parts)-2]
+		}
+		if to := c.resolve(schema, name); to != "" {
+			c.addEdge(from, to, dependencyKindReads)
+		}
+	}
+}
+
+func (c *dependencyCollector) graph() DependencyGraph {
+	result := DependencyGraph{
+		Objects:  make([]DependencyObject, 0, len(c.objects)),
+		Edges:    make([]DependencyEdge, 0, len(c.edges)),
+		Warnings: c.warnings,
+	}
+	for edge := range c.edges {
+		c.objects[edge.From].DependsOn++
+		c.objects[edge.To].ReferencedBy++
+		result.Edges = append(result.Edges, edge)
+	}
+	for _, obj := range c.objects {
+		result.Objects = append(result.Objects, *obj)
+	}
+	sort.Slice(result.Objects, func(i, j int) bool { return result.Objects[i].Key < result.Objects[j].Key })
+	sort.Slice(result.Edges, func(i, j int) bool {
+		if result.Edges[i].From != result.Edges[j].From {
+			return result.Edges[i].From < result.Edges[j].From
+		}
+		if result.Edges[i].To != result.Edges[j].To {
+			return result.Edges[i].To < result.Edges[j].To
+		}
+		return result.Edges[i].Kind < result.Edges[j].Kind
+	})
+	return result
+}
+
+// dependencyRows 执行目录查询，按列顺序返回文本值；失败时记入 warnings 并返回空。
+func (c *dependencyCollector) dependencyRows(dbInst db.Database, label string, query string) [][]string {
+	data, columns, err := dbInst.Query(query)
+	if err != nil {
+		c.warnings = append(c.warnings, fmt.Sprintf("读取%s失败：%s", label, normalizeErrorMessage(err)))
+		return nil
+	}
+	rows := make([][]string, 0, len(data))
+	for _, row := range data {
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			if v := row[col]; v != nil {
+				values[i] = strings.TrimSpace(fmt.Sprintf("%v", v))
+			}
+		}
+		rows = append(rows, values)
+	}
+	return rows
+}
+
+// GetDatabaseDependencies 计算库内视图、表、触发器、存储过程/函数之间的依赖关系（谁引用了谁），
+// 用于删除或修改对象前评估影响范围。目录视图能直接给出依赖时优先使用，否则解析对象定义文本匹配对象名。
+func (a *App) GetDatabaseDependencies(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	c := newDependencyCollector()
+	if !collectDependencies(c, resolveDDLDBType(runConfig), dbInst, dbName) {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源（%s）暂不支持依赖分析", runConfig.Type)}
+	}
+	graph := c.graph()
+	logger.Infof("依赖分析完成：%s 对象数=%d 依赖数=%d 警告数=%d", formatConnSummary(runConfig), len(graph.Objects), len(graph.Edges), len(graph.Warnings))
+	return connection.QueryResult{Success: true, Data: graph}
+}
+
+// collectDependencies 按数据源读取对象与依赖关系，不支持的数据源返回 false。
+func collectDependencies(c *dependencyCollector, dbType string, dbInst db.Database, dbName string) bool {
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		collectMySQLDependencies(c, dbInst, dbName)
+	case "postgres", "kingbase", "highgo", "vastbase":
+		collectPostgresDependencies(c, dbInst)
+	case "sqlserver":
+		collectSQLServerDependencies(c, dbInst)
+	case "oracle", "dameng":
+		collectOracleDependencies(c, dbInst, dbName)
+	case "sqlite":
+		collectSQLiteDependencies(c, dbInst)
+	default:
+		return false
+	}
+	return true
+}
+
+func collectMySQLDependencies(c *dependencyCollector, dbInst db.Database, dbName string) {
+	schema := dialect.QuoteLiteral("mysql", dbName)
+	for _, row := range c.dependencyRows(dbInst, "表", fmt.Sprintf(
+		"SELECT TABLE_NAME, TABLE_TYPE FROM information_schema.TABLES WHERE TABLE_SCHEMA = %s", schema)) {
+		objectType := "table"
+		if strings.EqualFold(row[1], "VIEW") {
+			objectType = "view"
+		}
+		c.addObject(dbName, row[0], objectType)
+	}
+	routines := c.dependencyRows(dbInst, "存储过程/函数", fmt.Sprintf(
+		"SELECT ROUTINE_NAME, ROUTINE_TYPE, ROUTINE_DEFINITION FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA = %s", schema))
+	for _, row := range routines {
+		c.addObject(dbName, row[0], strings.ToLower(row[1]))
+	}
+	triggers := c.dependencyRows(dbInst, "触发器", fmt.Sprintf(
+		"SELECT TRIGGER_NAME, EVENT_OBJECT_TABLE, ACTION_STATEMENT FROM information_schema.TRIGGERS WHERE TRIGGER_SCHEMA = %s", schema))
+	for _, row := range triggers {
+		key := c.addObject(dbName, row[0], "trigger")
+		c.addEdge(key, c.resolve(dbName, row[1]), dependencyKindTriggerOn)
+	}
+
+	views := c.dependencyRows(dbInst, "视图定义", fmt.Sprintf(
+		"SELECT TABLE_NAME, VIEW_DEFINITION FROM information_schema.VIEWS WHERE TABLE_SCHEMA = %s", schema))
+	for _, row := range views {
+		c.addBodyReferences(dependencyKey(dbName, row[0]), row[1])
+	}
+	for _, row := range routines {
+		c.addBodyReferences(dependencyKey(dbName, row[0]), row[2])
+	}
+	for _, row := range triggers {
+		c.addBodyReferences(dependencyKey(dbName, row[0]), row[2])
+	}
+	for _, row := range c.dependencyRows(dbInst, "外键", fmt.Sprintf(
+		"SELECT DISTINCT TABLE_NAME, REFERENCED_TABLE_SCHEMA, REFERENCED_TABLE_NAME FROM information_schema.KEY_COLUMN_USAGE WHERE TABLE_SCHEMA = %s AND REFERENCED_TABLE_NAME IS NOT NULL", schema)) {
+		to := c.resolve(row[1], row[2])
+		if to == "" {
+			to = c.addObject(row[1], row[2], "table")
+		}
+		c.addEdge(dependencyKey(dbName, row[0]), to, dependencyKindForeignKey)
+	}
+}
+
+const postgresDependencySchemaFilter = "n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg_toast%' AND n.nspname NOT LIKE 'pg_temp%'"
+
+func collectPostgresDependencies(c *dependencyCollector, dbInst db.Database) {
+	for _, row := range c.dependencyRows(dbInst, "表", "SELECT n.nspname, c.relname, c.relkind FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f') AND "+postgresDependencySchemaFilter) {
+		objectType := "table"
+		if row[2] == "v" || row[2] == "m" {
+			objectType = "view"
+		}
+		c.addObject(row[0], row[1], objectType)
+	}
+	routines := c.dependencyRows(dbInst, "函数", "SELECT n.nspname, p.proname, CASE WHEN p.prokind = 'p' THEN 'procedure' ELSE 'function' END, p.prosrc FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace WHERE "+postgresDependencySchemaFilter)
+	for _, row := range routines {
+		c.addObject(row[0], row[1], row[2])
+	}
+	// 视图依赖直接取 pg_depend（经由视图的重写规则），比解析定义文本更准确
+	for _, row := range c.dependencyRows(dbInst, "视图依赖", "SELECT DISTINCT vn.nspname, v.relname, tn.nspname, t.relname FROM pg_depend d "+
+		"JOIN pg_rewrite r ON r.oid = d.objid JOIN pg_class v ON v.oid = r.ev_class JOIN pg_namespace vn ON vn.oid = v.relnamespace "+
+		"JOIN pg_class t ON t.oid = d.refobjid JOIN pg_namespace tn ON tn.oid = t.relnamespace "+
+		"WHERE d.classid = 'pg_rewrite'::regclass AND d.refclassid = 'pg_class'::regclass AND v.oid <> t.oid "+
+		"AND vn.nspname NOT IN ('pg_catalog', 'information_schema')") {
+		c.addEdge(dependencyKey(row[0], row[1]), dependencyKey(row[2], row[3]), dependencyKindReads)
+	}
+	for _, row := range c.dependencyRows(dbInst, "触发器", "SELECT n.nspname, t.tgname, c.relname, pn.nspname, p.proname FROM pg_trigger t "+
+		"JOIN pg_class c ON c.oid = t.tgrelid JOIN pg_namespace n ON n.oid = c.relnamespace "+
+		"JOIN pg_proc p ON p.oid = t.tgfoid JOIN pg_namespace pn ON pn.oid = p.pronamespace "+
+		"WHERE NOT t.tgisinternal AND "+postgresDependencySchemaFilter) {
+		key := c.addObject(row[0], row[1], "trigger")
+		c.addEdge(key, dependencyKey(row[0], row[2]), dependencyKindTriggerOn)
+		c.addEdge(key, dependencyKey(row[3], row[4]), dependencyKindCalls)
+	}
+	for _, row := range routines {
+		c.addBodyReferences(dependencyKey(row[0], row[1]), row[3])
+	}
+	for _, row := range c.dependencyRows(dbInst, "外键", "SELECT DISTINCT n.nspname, c.relname, rn.nspname, r.relname FROM pg_constraint k "+
+		"JOIN pg_class c ON c.oid = k.conrelid JOIN pg_namespace n ON n.oid = c.relnamespace "+
+		"JOIN pg_class r ON r.oid = k.confrelid JOIN pg_namespace rn ON rn.oid = r.relnamespace "+
+		"WHERE k.contype = 'f' AND "+postgresDependencySchemaFilter) {
+		c.addEdge(dependencyKey(row[0], row[1]), dependencyKey(row[2], row[3]), dependencyKindForeignKey)
+	}
+}
+
+func collectSQLServerDependencies(c *dependencyCollector, dbInst db.Database) {
+	typeNames := map[string]string{"U": "table", "V": "view", "P": "procedure", "FN": "function", "IF": "function", "TF": "function", "TR": "trigger"}
+	for _, row := range c.dependencyRows(dbInst, "对象", "SELECT s.name, o.name, RTRIM(o.type) FROM sys.objects o JOIN sys.schemas s ON s.schema_id = o.schema_id WHERE o.is_ms_shipped = 0 AND o.type IN ('U', 'V', 'P', 'FN', 'IF', 'TF', 'TR')") {
+		c.addObject(row[0], row[1], typeNames[row[2]])
+	}
+	for _, row := range c.dependencyRows(dbInst, "触发器", "SELECT s.name, t.name, ps.name, p.name FROM sys.triggers t "+
+		"JOIN sys.objects o ON o.object_id = t.object_id JOIN sys.schemas s ON s.schema_id = o.schema_id "+
+		"JOIN sys.objects p ON p.object_id = t.parent_id JOIN sys.schemas ps ON ps.schema_id = p.schema_id WHERE t.parent_class = 1") {
+		c.addEdge(dependencyKey(row[0], row[1]), dependencyKey(row[2], row[3]), dependencyKindTriggerOn)
+	}
+	for _, row := range c.dependencyRows(dbInst, "对象依赖", "SELECT DISTINCT s.name, o.name, COALESCE(d.referenced_schema_name, s.name), d.referenced_entity_name "+
+		"FROM sys.sql_expression_dependencies d JOIN sys.objects o ON o.object_id = d.referencing_id JOIN sys.schemas s ON s.schema_id = o.schema_id "+
+		"WHERE d.referenced_entity_name IS NOT NULL AND d.referenced_database_name IS NULL") {
+		c.addEdge(dependencyKey(row[0], row[1]), c.resolve(row[2], row[3]), dependencyKindReads)
+	}
+	for _, row := range c.dependencyRows(dbInst, "外键", "SELECT DISTINCT s.name, t.name, rs.name, r.name FROM sys.foreign_keys f "+
+		"JOIN sys.tables t ON t.object_id = f.parent_object_id JOIN sys.schemas s ON s.schema_id = t.schema_id "+
+		"JOIN sys.tables r ON r.object_id = f.referenced_object_id JOIN sys.schemas rs ON rs.schema_id = r.schema_id") {
+		c.addEdge(dependencyKey(row[0], row[1]), dependencyKey(row[2], row[3]), dependencyKindForeignKey)
+	}
+}
+
+func collectOracleDependencies(c *dependencyCollector, dbInst db.Database, dbName string) {
+	owner := dialect.QuoteLiteral("oracle", strings.ToUpper(strings.TrimSpace(dbName)))
+	for _, row := range c.dependencyRows(dbInst, "对象", fmt.Sprintf(
+		"SELECT OBJECT_NAME, OBJECT_TYPE FROM ALL_OBJECTS WHERE OWNER = %s AND OBJECT_TYPE IN ('TABLE', 'VIEW', 'MATERIALIZED VIEW', 'TRIGGER', 'PROCEDURE', 'FUNCTION', 'PACKAGE')", owner)) {
+		objectType := strings.ToLower(row[1])
+		if objectType == "materialized view" {
+			objectType = "view"
+		}
+		c.addObject(dbName, row[0], objectType)
+	}
+	for _, row := range c.dependencyRows(dbInst, "触发器", fmt.Sprintf(
+		"SELECT TRIGGER_NAME, TABLE_OWNER, TABLE_NAME FROM ALL_TRIGGERS WHERE OWNER = %s AND BASE_OBJECT_TYPE = 'TABLE'", owner)) {
+		c.addEdge(dependencyKey(dbName, row[0]), c.resolve(row[1], row[2]), dependencyKindTriggerOn)
+	}
+	for _, row := range c.dependencyRows(dbInst, "对象依赖", fmt.Sprintf(
+		"SELECT DISTINCT NAME, REFERENCED_OWNER, REFERENCED_NAME FROM ALL_DEPENDENCIES WHERE OWNER = %s AND REFERENCED_TYPE IN ('TABLE', 'VIEW', 'MATERIALIZED VIEW', 'PROCEDURE', 'FUNCTION', 'PACKAGE')", owner)) {
+		c.addEdge(dependencyKey(dbName, row[0]), c.resolve(row[1], row[2]), dependencyKindReads)
+	}
+	for _, row := range c.dependencyRows(dbInst, "外键", fmt.Sprintf(
+		"SELECT DISTINCT c.TABLE_NAME, r.OWNER, r.TABLE_NAME FROM ALL_CONSTRAINTS c JOIN ALL_CONSTRAINTS r ON r.OWNER = c.R_OWNER AND r.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME WHERE c.OWNER = %s AND c.CONSTRAINT_TYPE = 'R'", owner)) {
+		c.addEdge(dependencyKey(dbName, row[0]), c.resolve(row[1], row[2]), dependencyKindForeignKey)
+	}
+}
+
+func collectSQLiteDependencies(c *dependencyCollector, dbInst db.Database) {
+	rows := c.dependencyRows(dbInst, "对象", "SELECT type, name, tbl_name, sql FROM sqlite_master WHERE type IN ('table', 'view', 'trigger') AND name NOT LIKE 'sqlite_%'")
+	for _, row := range rows {
+		c.addObject("", row[1], row[0])
+	}
+	for _, row := range rows {
+		switch row[0] {
+		case "view":
+			c.addBodyReferences(row[1], row[3])
+		case "trigger":
+			c.addEdge(row[1], c.resolve("", row[2]), dependencyKindTriggerOn)
+			c.addBodyReferences(row[1], triggerBody(row[3]))
+		case "table":
+			// 外键写在建表语句的 REFERENCES 子句中
+			tokens := tokenizeSQL(row[3])
+			for i := 0; i+1 < len(tokens); i++ {
+				if tokens[i].upper != "REFERENCES" {
+					continue
+				}
+				if parts, _ := readQualifiedName(tokens, i+1); len(parts) > 0 {
+					c.addEdge(row[1], c.resolve("", parts[len(parts)-1]), dependencyKindForeignKey)
+				}
+			}
+		}
+	}
+}
+
+// triggerBody 截取触发器 BEGIN 之后的部分，避免把 ON <表> 再计为一次读取引用。
+func triggerBody(definition string) string {
+	upper := strings.ToUpper(definition)
+	if idx := strings.Index(upper, "BEGIN"); idx >= 0 {
+		return definition[idx:]
+	}
+	return definition
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDependencyCollectorBodyReferences(t *testing.T) {
+	c := newDependencyCollector()
+	c.addObject("shop", "orders", "table")
+	c.addObject("shop", "customers", "table")
+	c.addObject("audit", "orders", "table")
+	view := c.addObject("shop", "v_order_summary", "view")
+	trigger := c.addObject("shop", "trg_orders_ai", "trigger")
+
+	c.addBodyReferences(view, "SELECT o.id, c.name FROM orders o JOIN shop.customers c ON c.id = o.customer_id -- audit.orders")
+	c.addEdge(trigger, c.resolve("shop", "orders"), dependencyKindTriggerOn)
+	c.addBodyReferences(trigger, "BEGIN INSERT INTO audit.orders SELECT * FROM missing_table; END")
+	c.addEdge(trigger, "shop.unknown", dependencyKindReads)
+
+	graph := c.graph()
+	want := map[DependencyEdge]bool{
+		{From: "shop.trg_orders_ai", To: "audit.orders", Kind: dependencyKindReads}:     true,
+		{From: "shop.trg_orders_ai", To: "shop.orders", Kind: dependencyKindTriggerOn}:  true,
+		{From: "shop.v_order_summary", To: "shop.customers", Kind: dependencyKindReads}: true,
+		{From: "shop.v_order_summary", To: "shop.orders", Kind: dependencyKindReads}:    true,
+	}
+	if len(graph.Edges) != len(want) {
+		t.Fatalf("edges = %+v", graph.Edges)
+	}
+	for _, edge := range graph.Edges {
+		if !want[edge] {
+			t.Errorf("unexpected edge %+v", edge)
+		}
+	}
+	for _, obj := range graph.Objects {
+		if obj.Key == "shop.orders" && obj.ReferencedBy != 2 {
+			t.Errorf("shop.orders referencedBy = %d, want 2", obj.ReferencedBy)
+		}
+	}
+}
+
+func TestBuildObjectDependencies(t *testing.T) {
+	c := newDependencyCollector()
+	c.addObject("shop", "orders", "table")
+	c.addObject("shop", "order_items", "table")
+	c.addObject("shop", "refunds", "table")
+	view := c.addObject("shop", "v_orders", "view")
+	otherView := c.addObject("shop", "v_order_ids", "view")
+	report := c.addObject("shop", "sp_daily_report", "procedure")
+	c.addBodyReferences(view, "SELECT o.id, o.total FROM orders o")
+	c.addBodyReferences(otherView, "SELECT id FROM orders")
+	c.addBodyReferences(report, "BEGIN SELECT * FROM v_orders; END")
+	c.addEdge("shop.order_items", "shop.orders", dependencyKindForeignKey)
+	c.addEdge("shop.refunds", "shop.orders", dependencyKindForeignKey)
+
+	all := buildObjectDependencies(c, "shop.orders", "", nil)
+	var keys []string
+	for _, dep := range all.Dependents {
+		keys = append(keys, dep.Key)
+	}
+	if got := strings.Join(keys, ","); got != "shop.order_items,shop.refunds,shop.v_order_ids,shop.v_orders,shop.sp_daily_report" {
+		t.Fatalf("dependents = %s", got)
+	}
+	if last := all.Dependents[len(all.Dependents)-1]; last.Depth != 2 || last.Via != "shop.v_orders" {
+		t.Fatalf("indirect dependent = %+v", last)
+	}
+
+	// 只有引用 total 列的视图与外键计入，经由该视图的存储过程作为间接依赖保留
+	fkRows := [][]string{
+		{"SHOP", "order_items", "fk_items_order", "order_id", "SHOP", "orders", "id", "1"},
+		{"SHOP", "refunds", "fk_refunds_total", "amount", "SHOP", "orders", "total", "1"},
+	}
+	filter := &dependencyColumnFilter{fkColumns: dependencyFKColumns(fkRows, c.objects["shop.orders"], "total")}
+	byColumn := buildObjectDependencies(c, "shop.orders", "total", filter)
+	keys = keys[:0]
+	for _, dep := range byColumn.Dependents {
+		keys = append(keys, dep.Key)
+	}
+	if got := strings.Join(keys, ","); got != "shop.refunds,shop.v_orders,shop.sp_daily_report" {
+		t.Fatalf("column dependents = %s", got)
+	}
+	if refunds := byColumn.Dependents[0]; len(refunds.Columns) != 1 || refunds.Columns[0] != "amount" || refunds.Kinds[0] != dependencyKindForeignKey {
+		t.Fatalf("refunds = %+v", refunds)
+	}
+}
@@ -0,0 +1,405 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+// TableDefinition 表设计器中的表结构；索引与外键沿用 GetIndexes/GetForeignKeys 的逐列格式。
+type TableDefinition struct {
+	Columns     []connection.ColumnDefinition     `json:"columns"`
+	Indexes     []connection.IndexDefinition      `json:"indexes,omitempty"`
+	ForeignKeys []connection.ForeignKeyDefinition `json:"foreignKeys,omitempty"`
+	// Renames 重命名的列（新列名 -> 原列名），只在修改后的定义中使用；未列出的列按名称对应。
+	Renames map[string]string `json:"renames,omitempty"`
+}
+
+// GenerateAlterTable 比较表设计器修改前后的列、主键、索引与外键，生成对应方言的 DDL 供预览，不执行。
+// MySQL 用 CHANGE/MODIFY 改列，PostgreSQL 等逐项 ALTER，SQLite 不支持修改列定义时以重建表的方式完成。
+func (a *App) GenerateAlterTable(config connection.ConnectionConfig, dbName string, tableName string, oldDef TableDefinition, newDef TableDefinition) connection.QueryResult {
+	if strings.TrimSpace(tableName) == "" {
+		return connection.QueryResult{Success: false, Message: "表名不能为空"}
+	}
+	side := schemaSide{config: config, dbType: resolveDDLDBType(config), dbName: dbName}
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	result, err := buildAlterTableDDL(side, &schemaTable{key: tableName, schema: schemaName, table: pureTableName}, oldDef, newDef)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	result.Plan = a.attachSQLPlan(SQLPlanRequest{Config: config, DBName: dbName, Source: "table_designer",
+		Title: fmt.Sprintf("修改表 %s 结构", tableName), Statements: result.Statements, StopOnError: true}, &result.Warnings)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已生成 %d 条语句", len(result.Statements)), Data: result}
+}
+
+// alterTableState 修改前或修改后的表结构，索引与外键已聚合。
+func alterTableState(base *schemaTable, def TableDefinition) *schemaTable {
+	t := *base
+	t.columns = append([]connection.ColumnDefinition(nil), def.Columns...)
+	t.primaryKey = nil
+	for _, col := range t.columns {
+		if strings.EqualFold(col.Key, "PRI") {
+			t.primaryKey = append(t.primaryKey, col.Name)
+		}
+	}
+	t.indexes = groupSchemaIndexes(def.Indexes, t.primaryKey)
+	t.foreignKeys = groupSchemaForeignKeys(def.ForeignKeys)
+	return &t
+}
+
+func buildAlterTableDDL(side schemaSide, base *schemaTable, oldDef TableDefinition, newDef TableDefinition) (DesignerDDLResult, error) {
+	if len(newDef.Columns) == 0 {
+		return DesignerDDLResult{}, fmt.Errorf("表至少需要一列")
+	}
+	oldT := alterTableState(base, oldDef)
+	newT := alterTableState(base, newDef)
+	seen := make(map[string]struct{}, len(newT.columns))
+	for _, col := range newT.columns {
+		name := strings.ToLower(strings.TrimSpace(col.Name))
+		if name == "" {
+			return DesignerDDLResult{}, fmt.Errorf("列名不能为空")
+		}
+		if _, dup := seen[name]; dup {
+			return DesignerDDLResult{}, fmt.Errorf("列名 %s 重复", col.Name)
+		}
+		seen[name] = struct{}{}
+	}
+
+	// 校验重命名：原列须存在，且不能与保留的同名列冲突
+	renames := make(map[string]string, len(newDef.Renames)) // 小写原列名 -> 新列名
+	for newName, oldName := range newDef.Renames {
+		if strings.EqualFold(newName, oldName) {
+			continue
+		}
+		if alterColumnIndex(oldT.columns, oldName) < 0 {
+			return DesignerDDLResult{}, fmt.Errorf("重命名的原列 %s 不存在", oldName)
+		}
+		if alterColumnIndex(newT.columns, newName) < 0 {
+			return DesignerDDLResult{}, fmt.Errorf("重命名后的列 %s 不在新定义中", newName)
+		}
+		if alterColumnIndex(newT.columns, oldName) >= 0 {
+			return DesignerDDLResult{}, fmt.Errorf("列 %s 已重命名为 %s，不能再保留同名列", oldName, newName)
+		}
+		renames[strings.ToLower(oldName)] = newName
+	}
+	renamed := func(name string) string {
+		if to, ok := renames[strings.ToLower(name)]; ok {
+			return to
+		}
+		return name
+	}
+
+	if side.dbType == "sqlite" && alterNeedsRebuild(side, oldT, newT, renamed) {
+		statements, warnings, err := rebuildTableStatements(side, oldT, newT, renamed)
+		if err != nil {
+			return DesignerDDLResult{}, err
+		}
+		return DesignerDDLResult{
+			Statements: statements,
+			Script:     strings.Join(statements, ";\n") + ";",
+			Strategy:   "rebuild",
+			Warnings:   warnings,
+		}, nil
+	}
+
+	var warnings []string
+	warnf := func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+	plan := &schemaSyncPlan{}
+	ref := schemaTableRef(side.dbType, oldT)
+	mysqlLike := transferTypeFamily(side.dbType) == "mysql"
+
+	// current 跟踪执行重命名与新增列之后的表结构，其余差异交给结构比较生成
+	current := *oldT
+	current.columns = append([]connection.ColumnDefinition(nil), oldT.columns...)
+	for i, col := range current.columns {
+		to := renamed(col.Name)
+		if strings.EqualFold(to, col.Name) {
+			continue
+		}
+		target := newT.columns[alterColumnIndex(newT.columns, to)]
+		if mysqlLike {
+			def, err := schemaColumnDef(side.dbType, side.dbType, target, warnf)
+			if err != nil {
+				return DesignerDDLResult{}, err
+			}
+			plan.alterColumns = append(plan.alterColumns, fmt.Sprintf("ALTER TABLE %s CHANGE COLUMN %s %s", ref, quoteIdentByType(side.dbType, col.Name), def))
+			current.columns[i] = target
+			continue
+		}
+		stmt, err := alterRenameColumnSQL(side.dbType, oldT, col.Name, to)
+		if err != nil {
+			return DesignerDDLResult{}, err
+		}
+		plan.alterColumns = append(plan.alterColumns, stmt)
+		current.columns[i].Name = to
+	}
+	current.primaryKey = renameAlterNames(oldT.primaryKey, renamed)
+	current.indexes = make([]schemaIndex, len(oldT.indexes))
+	for i, index := range oldT.indexes {
+		index.columns = renameAlterNames(index.columns, renamed)
+		current.indexes[i] = index
+	}
+	current.foreignKeys = make([]schemaForeignKey, len(oldT.foreignKeys))
+	for i, fk := range oldT.foreignKeys {
+		fk.columns = renameAlterNames(fk.columns, renamed)
+		current.foreignKeys[i] = fk
+	}
+
+	// 按新定义的顺序逐列处理：order 模拟保留列的当前顺序，处理到第 i 列时前 i 列已与新定义一致。
+	// MySQL 用 FIRST/AFTER 定位新增列与移动的列，其他数据库新增列只能追加在末尾。
+	var order []string
+	for _, col := range current.columns {
+		if alterColumnIndex(newT.columns, col.Name) >= 0 {
+			order = append(order, strings.ToLower(col.Name))
+		}
+	}
+	reordered := false
+	for i, col := range newT.columns {
+		position := " FIRST"
+		if i > 0 {
+			position = " AFTER " + quoteIdentByType(side.dbType, newT.columns[i-1].Name)
+		}
+		pos := -1
+		for j, name := range order {
+			if name == strings.ToLower(col.Name) {
+				pos = j
+				break
+			}
+		}
+		if pos < 0 {
+			statements := schemaAddColumnSQL(side.dbType, side.dbType, &current, col, warnf)
+			if i < len(order) {
+				if mysqlLike && len(statements) == 1 {
+					statements[0] += position
+				} else {
+					reordered = true
+				}
+			}
+			plan.alterColumns = append(plan.alterColumns, statements...)
+			current.columns = append(current.columns, col)
+		} else if pos != i {
+			if !mysqlLike {
+				reordered = true
+				continue
+			}
+			statements := schemaAlterColumnSQL(side.dbType, side.dbType, &current, col, false, false, false, warnf)
+			if len(statements) == 1 {
+				statements[0] += position
+			}
+			plan.alterColumns = append(plan.alterColumns, statements...)
+			current.columns[alterColumnIndex(current.columns, col.Name)] = col
+			order = append(order[:pos], order[pos+1:]...)
+		} else {
+			continue
+		}
+		order = append(order[:i], append([]string{strings.ToLower(col.Name)}, order[i:]...)...)
+	}
+	if reordered {
+		warnf("%s 不支持原地调整列顺序，新增列将追加在表末尾；如需调整顺序请使用批量操作中的“调整列顺序”", side.dbType)
+	}
+
+	if !equalFoldNames(current.primaryKey, newT.primaryKey) {
+		quoted := make([]string, len(newT.primaryKey))
+		for i, name := range newT.primaryKey {
+			quoted[i] = quoteIdentByType(side.dbType, name)
+		}
+		switch {
+		case mysqlLike:
+			if len(current.primaryKey) > 0 {
+				plan.dropIndexes = append(plan.dropIndexes, fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY", ref))
+			}
+			if len(newT.primaryKey) > 0 {
+				plan.createIndexes = append(plan.createIndexes, fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", ref, strings.Join(quoted, ", ")))
+			}
+		case len(current.primaryKey) == 0 && side.dbType != "clickhouse":
+			plan.createIndexes = append(plan.createIndexes, fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", ref, strings.Join(quoted, ", ")))
+		default:
+			warnf("%s 删除或修改主键需要主键约束名，请手动调整（原主键：%s，新主键：%s）", side.dbType, strings.Join(current.primaryKey, ", "), strings.Join(newT.primaryKey, ", "))
+		}
+		current.primaryKey = newT.primaryKey
+	}
+
+	var compared SchemaCompareResult
+	compareSchemaTable(side, side, newT, &current, true, plan, &compared, warnf)
+	plan.alterColumns = append(plan.alterColumns, alterColumnAttrSQL(side, &current, newT, warnf)...)
+
+	result := DesignerDDLResult{Strategy: "alter", Statements: plan.statements(), Warnings: warnings}
+	if len(result.Statements) == 0 {
+		if len(warnings) > 0 {
+			return DesignerDDLResult{}, fmt.Errorf("%s", strings.Join(warnings, "；"))
+		}
+		return DesignerDDLResult{}, fmt.Errorf("没有检测到变更")
+	}
+	result.Script = strings.Join(result.Statements, ";\n") + ";"
+	return result, nil
+}
+
+func alterColumnIndex(columns []connection.ColumnDefinition, name string) int {
+	for i, col := range columns {
+		if strings.EqualFold(strings.TrimSpace(col.Name), strings.TrimSpace(name)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func renameAlterNames(names []string, renamed func(string) string) []string {
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = renamed(name)
+	}
+	return out
+}
+
+func alterRenameColumnSQL(dbType string, t *schemaTable, from, to string) (string, error) {
+	switch dbType {
+	case "sqlserver":
+		return fmt.Sprintf("EXEC sp_rename '%s', '%s', 'COLUMN'",
+			strings.ReplaceAll(qualifyTable(qualifyTable(t.schema, t.table), from), "'", "''"), strings.ReplaceAll(to, "'", "''")), nil
+	case "postgres", "kingbase", "highgo", "vastbase", "sqlite", "duckdb", "oracle", "dameng", "clickhouse":
+		return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", schemaTableRef(dbType, t), quoteIdentByType(dbType, from), quoteIdentByType(dbType, to)), nil
+	}
+	return "", fmt.Errorf("%s 暂不支持重命名列", dbType)
+}
+
+// alterColumnAttrSQL 生成只改了注释或自增属性的列的语句；MySQL 的注释与 AUTO_INCREMENT 随 MODIFY 一起修改，
+// 类型等已有差异时结构比较已生成 MODIFY，不再重复。
+func alterColumnAttrSQL(side schemaSide, current, newT *schemaTable, warnf func(string, ...interface{})) []string {
+	var out []string
+	ref := schemaTableRef(side.dbType, newT)
+	family := transferTypeFamily(side.dbType)
+	for _, col := range newT.columns {
+		idx := alterColumnIndex(current.columns, col.Name)
+		if idx < 0 {
+			continue
+		}
+		prev := current.columns[idx]
+		commentChanged := prev.Comment != col.Comment
+		identityChanged := (prev.Identity == "") != (col.Identity == "")
+		if !commentChanged && !identityChanged {
+			continue
+		}
+		if family == "mysql" {
+			if typeDiff, nullDiff, defaultDiff := schemaColumnDiff(side.dbType, side.dbType, col, prev); !typeDiff && !nullDiff && !defaultDiff {
+				out = append(out, schemaAlterColumnSQL(side.dbType, side.dbType, newT, col, false, false, false, warnf)...)
+			}
+			continue
+		}
+		if identityChanged {
+			warnf("%s 不支持通过设计器修改列 %s 的自增属性，请手动调整", side.dbType, col.Name)
+		}
+		if !commentChanged {
+			continue
+		}
+		comment := "'" + strings.ReplaceAll(col.Comment, "'", "''") + "'"
+		switch family {
+		case "postgres", "oracle", "dameng", "duckdb":
+			out = append(out, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", ref, quoteIdentByType(side.dbType, col.Name), comment))
+		case "clickhouse":
+			out = append(out, fmt.Sprintf("ALTER TABLE %s COMMENT COLUMN %s %s", ref, quoteIdentByType(side.dbType, col.Name), comment))
+		default:
+			warnf("%s 不支持通过 DDL 修改列注释，列 %s 的注释已忽略", side.dbType, col.Name)
+		}
+	}
+	return out
+}
+
+// alterNeedsRebuild 判断 SQLite 是否需要重建表：除新增列、重命名列与索引变更外的修改都无法原地完成。
+func alterNeedsRebuild(side schemaSide, oldT, newT *schemaTable, renamed func(string) string) bool {
+	if !equalFoldNames(renameAlterNames(oldT.primaryKey, renamed), newT.primaryKey) {
+		return true
+	}
+	for _, col := range oldT.columns {
+		idx := alterColumnIndex(newT.columns, renamed(col.Name))
+		if idx < 0 {
+			return true
+		}
+		if typeDiff, nullDiff, defaultDiff := schemaColumnDiff(side.dbType, side.dbType, newT.columns[idx], col); typeDiff || nullDiff || defaultDiff {
+			return true
+		}
+	}
+	if len(oldT.foreignKeys) != len(newT.foreignKeys) {
+		return true
+	}
+	for i, fk := range oldT.foreignKeys {
+		next := newT.foreignKeys[i]
+		if !equalFoldNames(renameAlterNames(fk.columns, renamed), next.columns) || !equalFoldNames(fk.refColumns, next.refColumns) ||
+			!strings.EqualFold(schemaBareName(fk.refTable), schemaBareName(next.refTable)) {
+			return true
+		}
+	}
+	return false
+}
+
+// rebuildTableStatements 以重建表的方式应用结构修改：按新结构建临时表、按列映射复制数据、删除原表、重命名临时表，再重建索引。
+// 外键写在新表的建表语句中。语句依次执行：建表或复制失败时原表不受影响；删除原表后重命名失败时数据保留在临时表中。
+// renamed 把原列名映射为新列名，新结构中不存在的列与计算列不复制。
+func rebuildTableStatements(side schemaSide, oldT, newT *schemaTable, renamed func(string) string) ([]string, []string, error) {
+	tempQualified := qualifyTable(oldT.schema, oldT.table+"_gonavi_rebuild")
+	var renameSQL string
+	switch side.dbType {
+	case "postgres", "kingbase", "highgo", "vastbase", "sqlite", "duckdb", "oracle", "dameng":
+		renameSQL = fmt.Sprintf("ALTER TABLE %s RENAME TO %s", quoteQualifiedIdentByType(side.dbType, tempQualified), quoteIdentByType(side.dbType, oldT.table))
+	case "sqlserver":
+		renameSQL = fmt.Sprintf("EXEC sp_rename '%s', '%s'", strings.ReplaceAll(tempQualified, "'", "''"), strings.ReplaceAll(oldT.table, "'", "''"))
+	default:
+		return nil, nil, fmt.Errorf("%s 暂不支持以重建表的方式修改表结构", side.dbType)
+	}
+
+	createSQL, warnings, err := buildSchemaCreateSQL(side.dbType, side.dbType, tempQualified, newT.columns)
+	if err != nil {
+		return nil, nil, err
+	}
+	quoteList := func(names []string) string {
+		out := make([]string, len(names))
+		for i, name := range names {
+			out[i] = quoteIdentByType(side.dbType, name)
+		}
+		return strings.Join(out, ", ")
+	}
+	if len(newT.foreignKeys) > 0 {
+		constraints := make([]string, 0, len(newT.foreignKeys))
+		for _, fk := range newT.foreignKeys {
+			// SQLite 外键只能引用同一数据库中的表，不能带 schema
+			refTable := quoteIdentByType(side.dbType, schemaBareName(fk.refTable))
+			if side.dbType != "sqlite" {
+				refSchema, refName := normalizeSchemaAndTable(side.config, side.dbName, fk.refTable)
+				refTable = quoteQualifiedIdentByType(side.dbType, qualifyTable(refSchema, refName))
+			}
+			constraints = append(constraints, fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+				quoteIdentByType(side.dbType, fk.name), quoteList(fk.columns), refTable, quoteList(fk.refColumns)))
+		}
+		createSQL = strings.TrimSuffix(createSQL, "\n)") + ",\n  " + strings.Join(constraints, ",\n  ") + "\n)"
+	}
+
+	var targetColumns, sourceColumns []string
+	for _, col := range oldT.columns {
+		to := renamed(col.Name)
+		idx := alterColumnIndex(newT.columns, to)
+		if idx < 0 || strings.TrimSpace(newT.columns[idx].Generated) != "" {
+			continue
+		}
+		targetColumns = append(targetColumns, newT.columns[idx].Name)
+		sourceColumns = append(sourceColumns, col.Name)
+	}
+	statements := []string{createSQL}
+	if len(targetColumns) > 0 {
+		statements = append(statements, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s",
+			quoteQualifiedIdentByType(side.dbType, tempQualified), quoteList(targetColumns), quoteList(sourceColumns), schemaTableRef(side.dbType, oldT)))
+	}
+	statements = append(statements, "DROP TABLE "+schemaTableRef(side.dbType, oldT), renameSQL)
+	warnf := func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+	for _, index := range newT.indexes {
+		statements = append(statements, schemaCreateIndexSQL(side, newT, index, warnf)...)
+	}
+	warning := fmt.Sprintf("%s 不支持原地完成该修改，将重建表：触发器、授权与引用该表的视图需在执行后重新创建；建议先备份", side.dbType)
+	if side.dbType == "sqlite" {
+		warning += "，并在执行期间关闭 PRAGMA foreign_keys"
+	}
+	return statements, append(warnings, warning), nil
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func alterTestDefs() (TableDefinition, TableDefinition) {
+	oldDef := TableDefinition{
+		Columns: []connection.ColumnDefinition{
+			{Name: "id", Type: "int", Key: "PRI", Nullable: "NO", Identity: "BY DEFAULT"},
+			{Name: "name", Type: "varchar(20)", Nullable: "YES"},
+			{Name: "legacy", Type: "text", Nullable: "YES"},
+		},
+		Indexes: []connection.IndexDefinition{{Name: "idx_name", ColumnName: "name", NonUnique: 1, SeqInIndex: 1}},
+	}
+	newDef := TableDefinition{
+		Columns: []connection.ColumnDefinition{
+			{Name: "id", Type: "int", Key: "PRI", Nullable: "NO", Identity: "BY DEFAULT"},
+			{Name: "email", Type: "varchar(100)", Nullable: "YES"},
+			{Name: "full_name", Type: "varchar(50)", Nullable: "NO"},
+		},
+		Indexes: []connection.IndexDefinition{{Name: "idx_name", ColumnName: "full_name", NonUnique: 0, SeqInIndex: 1}},
+		Renames: map[string]string{"full_name": "name"},
+	}
+	return oldDef, newDef
+}
+
+func TestBuildAlterTableDDLMySQL(t *testing.T) {
+	oldDef, newDef := alterTestDefs()
+	side := schemaSide{config: connection.ConnectionConfig{Type: "mysql"}, dbType: "mysql", dbName: "shop"}
+	res, err := buildAlterTableDDL(side, &schemaTable{key: "users", schema: "shop", table: "users"}, oldDef, newDef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"ALTER TABLE `shop`.`users` DROP INDEX `idx_name`",
+		"ALTER TABLE `shop`.`users` CHANGE COLUMN `name` `full_name` varchar(50) NOT NULL",
+		"ALTER TABLE `shop`.`users` ADD COLUMN `email` varchar(100) NULL AFTER `id`",
+		"ALTER TABLE `shop`.`users` DROP COLUMN `legacy`",
+		"ALTER TABLE `shop`.`users` ADD UNIQUE INDEX `idx_name` (`full_name`)",
+	}
+	if strings.Join(res.Statements, "\n") != strings.Join(want, "\n") || res.Strategy != "alter" {
+		t.Fatalf("statements =\n%s", strings.Join(res.Statements, "\n"))
+	}
+
+	// 调整已有列的顺序
+	moved := TableDefinition{Columns: []connection.ColumnDefinition{oldDef.Columns[0], oldDef.Columns[2], oldDef.Columns[1]}, Indexes: oldDef.Indexes}
+	res, err = buildAlterTableDDL(side, &schemaTable{key: "users", schema: "shop", table: "users"}, oldDef, moved)
+	if err != nil || strings.Join(res.Statements, "\n") != "ALTER TABLE `shop`.`users` MODIFY COLUMN `legacy` text NULL AFTER `id`" {
+		t.Fatalf("res = %+v err = %v", res, err)
+	}
+}
+
+func TestBuildAlterTableDDLPostgres(t *testing.T) {
+	oldDef, newDef := alterTestDefs()
+	newDef.Columns[2].Comment = "姓名"
+	side := schemaSide{config: connection.ConnectionConfig{Type: "postgres"}, dbType: "postgres", dbName: "shop"}
+	res, err := buildAlterTableDDL(side, &schemaTable{key: "users", schema: "public", table: "users"}, oldDef, newDef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`DROP INDEX "public"."idx_name"`,
+		`ALTER TABLE "public"."users" RENAME COLUMN "name" TO "full_name"`,
+		`ALTER TABLE "public"."users" ADD COLUMN "email" varchar(100)`,
+		`ALTER TABLE "public"."users" ALTER COLUMN "full_name" TYPE varchar(50)`,
+		`ALTER TABLE "public"."users" ALTER COLUMN "full_name" SET NOT NULL`,
+		`COMMENT ON COLUMN "public"."users"."full_name" IS '姓名'`,
+		`ALTER TABLE "public"."users" DROP COLUMN "legacy"`,
+		`CREATE UNIQUE INDEX "idx_name" ON "public"."users" ("full_name")`,
+	}
+	if strings.Join(res.Statements, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("statements =\n%s", strings.Join(res.Statements, "\n"))
+	}
+}
+
+func TestBuildAlterTableDDLSQLiteRebuild(t *testing.T) {
+	oldDef, newDef := alterTestDefs()
+	side := schemaSide{config: connection.ConnectionConfig{Type: "sqlite"}, dbType: "sqlite"}
+	res, err := buildAlterTableDDL(side, &schemaTable{key: "users", table: "users"}, oldDef, newDef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Strategy != "rebuild" || len(res.Statements) != 5 {
+		t.Fatalf("result = %+v", res)
+	}
+	if res.Statements[1] != `INSERT INTO "users_gonavi_rebuild" ("id", "full_name") SELECT "id", "name" FROM "users"` {
+		t.Fatalf("copy = %s", res.Statements[1])
+	}
+
+	// 只新增列时原地修改
+	newDef = TableDefinition{Columns: append(append([]connection.ColumnDefinition(nil), oldDef.Columns...), connection.ColumnDefinition{Name: "age", Type: "INTEGER", Nullable: "YES"}), Indexes: oldDef.Indexes}
+	res, err = buildAlterTableDDL(side, &schemaTable{key: "users", table: "users"}, oldDef, newDef)
+	if err != nil || res.Strategy != "alter" || strings.Join(res.Statements, "\n") != `ALTER TABLE "users" ADD COLUMN "age" INTEGER` {
+		t.Fatalf("res = %+v err = %v", res, err)
+	}
+
+	if _, err := buildAlterTableDDL(side, &schemaTable{key: "users", table: "users"}, oldDef, oldDef); err == nil {
+		t.Fatal("expected no-change error")
+	}
+}
+
+func TestGenerateAlterTableRegistersPlan(t *testing.T) {
+	a := NewApp()
+	oldDef, newDef := alterTestDefs()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306}
+	res := a.GenerateAlterTable(config, "shop", "users", oldDef, newDef)
+	result, _ := res.Data.(DesignerDDLResult)
+	if !res.Success || result.Plan == nil || result.Plan.Source != "table_designer" || result.Plan.StatementCount != len(result.Statements) {
+		t.Fatalf("expected a registered plan, got %+v", res)
+	}
+	copied := a.ConfirmSQLPlan(result.Plan.PlanID, "copy")
+	report, _ := copied.Data.(SQLPlanReport)
+	if !copied.Success || report.Script != result.Plan.Script || report.Skipped != len(result.Statements) {
+		t.Fatalf("copy should return the script without executing, got %+v", copied)
+	}
+
+	// 只读连接仍可预览 DDL，但不登记计划
+	config.ReadOnly = true
+	res = a.GenerateAlterTable(config, "shop", "users", oldDef, newDef)
+	result, _ = res.Data.(DesignerDDLResult)
+	if !res.Success || result.Plan != nil || len(result.Warnings) == 0 || !strings.Contains(result.Warnings[len(result.Warnings)-1], "只读") {
+		t.Fatalf("read-only connection should not register a plan, got %+v", result)
+	}
+}
@@ -0,0 +1,440 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+)
+
+// DesignerOperation 表设计器中的结构化批量操作，只生成 DDL 供预览，由前端确认后执行。
+type DesignerOperation struct {
+	// Kind 为 reorder（调整列顺序）、charset（批量修改字符列字符集，仅 MySQL）或 timestamps（添加创建/更新时间列）。
+	Kind string `json:"kind"`
+	// Columns 在 reorder 中为调整后的完整列顺序；在 charset 中为要修改的列，留空表示全部字符列。
+	Columns       []string `json:"columns,omitempty"`
+	Charset       string   `json:"charset,omitempty"`
+	Collation     string   `json:"collation,omitempty"`
+	CreatedColumn string   `json:"createdColumn,omitempty"` // 默认 created_at
+	UpdatedColumn string   `json:"updatedColumn,omitempty"` // 默认 updated_at
+}
+
+// DesignerDDLResult 批量操作生成的 DDL。
+type DesignerDDLResult struct {
+	Statements []string `json:"statements"`
+	Script     string   `json:"script"`   // 以分号连接的完整脚本，用于预览与执行
+	Strategy   string   `json:"strategy"` // alter：原地修改；rebuild：建新表复制数据后替换原表
+	Warnings   []string `json:"warnings,omitempty"`
+	// Plan 登记的变更计划，通过 ConfirmSQLPlan 执行或复制
+	Plan *SQLPlanPreview `json:"plan,omitempty"`
+}
+
+var (
+	designerCharsetClausePattern = regexp.MustCompile(`(?i)^(?:\s+(?:CHARACTER\s+SET|CHARSET)\s+\w+|\s+COLLATE\s+\w+)+`)
+	designerIdentPattern         = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+	designerCharTypePattern      = regexp.MustCompile(`(?i)^(?:national\s+)?(?:var)?char\b|^(?:tiny|medium|long)?text\b|^enum\b|^set\b`)
+)
+
+// designerTable 生成 DDL 过程中的表状态，按操作顺序依次更新。
+type designerTable struct {
+	config      connection.ConnectionConfig
+	dbName      string
+	dbType      string
+	schema      string
+	table       string
+	columns     []connection.ColumnDefinition
+	mysqlDefs   map[string]string // 小写列名 -> SHOW CREATE TABLE 中的完整列定义
+	indexes     []schemaIndex     // 重建表时需要重新创建的索引与外键
+	foreignKeys []schemaForeignKey
+}
+
+func (t *designerTable) ref() string {
+	return quoteQualifiedIdentByType(t.dbType, qualifyTable(t.schema, t.table))
+}
+
+func (t *designerTable) mysqlLike() bool {
+	return transferTypeFamily(t.dbType) == "mysql"
+}
+
+func (t *designerTable) columnIndex(name string) int {
+	for i, col := range t.columns {
+		if strings.EqualFold(col.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// GenerateDesignerDDL 把表设计器的批量操作（调整列顺序、批量修改字符集、添加时间戳列）转换为可审阅的 DDL，不执行。
+// MySQL 与 ClickHouse 通过 FIRST/AFTER 原地调整列顺序；其余数据库不支持时采用重建表方式：
+// 建新表、复制数据、删除原表、重命名新表，再重建索引与外键。
+func (a *App) GenerateDesignerDDL(config connection.ConnectionConfig, dbName string, tableName string, operations []DesignerOperation) connection.QueryResult {
+	if len(operations) == 0 {
+		return connection.QueryResult{Success: false, Message: "没有需要生成的操作"}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		logger.Error(err, "GenerateDesignerDDL 获取连接失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	columns, err := dbInst.GetColumns(schemaName, pureTableName)
+	if err != nil || len(columns) == 0 {
+		if err == nil {
+			err = fmt.Errorf("表 %s 没有字段", tableName)
+		}
+		return connection.QueryResult{Success: false, Message: "读取字段失败：" + normalizeErrorMessage(err)}
+	}
+	t := &designerTable{config: config, dbName: dbName, dbType: resolveDDLDBType(config), schema: schemaName, table: pureTableName, columns: columns}
+	var primaryKey []string
+	for _, col := range columns {
+		if strings.EqualFold(col.Key, "PRI") {
+			primaryKey = append(primaryKey, col.Name)
+		}
+	}
+	// 部分数据库不提供索引或外键信息，读取失败时视为没有
+	if indexes, err := dbInst.GetIndexes(schemaName, pureTableName); err == nil {
+		t.indexes = groupSchemaIndexes(indexes, primaryKey)
+	}
+	if foreignKeys, err := dbInst.GetForeignKeys(schemaName, pureTableName); err == nil {
+		t.foreignKeys = groupSchemaForeignKeys(foreignKeys)
+	}
+	if t.mysqlLike() {
+		createSQL, err := dbInst.GetCreateStatement(schemaName, pureTableName)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: "读取建表语句失败：" + normalizeErrorMessage(err)}
+		}
+		t.mysqlDefs = mysqlColumnDefinitions(createSQL)
+	}
+
+	result, err := buildDesignerDDL(t, operations)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	result.Plan = a.attachSQLPlan(SQLPlanRequest{Config: config, DBName: dbName, Source: "table_designer",
+		Title: fmt.Sprintf("修改表 %s 结构", tableName), Statements: result.Statements, StopOnError: true}, &result.Warnings)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已生成 %d 条语句", len(result.Statements)), Data: result}
+}
+
+// buildDesignerDDL 按顺序应用各操作，后一个操作基于前一个操作之后的表结构生成语句。
+func buildDesignerDDL(t *designerTable, operations []DesignerOperation) (DesignerDDLResult, error) {
+	result := DesignerDDLResult{Strategy: "alter"}
+	for _, op := range operations {
+		var (
+			statements []string
+			warnings   []string
+			rebuild    bool
+			err        error
+		)
+		switch strings.ToLower(strings.TrimSpace(op.Kind)) {
+		case "reorder":
+			statements, warnings, rebuild, err = designerReorder(t, op.Columns)
+		case "charset":
+			statements, warnings, err = designerCharset(t, op)
+		case "timestamps":
+			statements, warnings, err = designerTimestamps(t, op)
+		default:
+			err = fmt.Errorf("不支持的设计器操作：%s", op.Kind)
+		}
+		if err != nil {
+			return DesignerDDLResult{}, err
+		}
+		if rebuild {
+			result.Strategy = "rebuild"
+		}
+		result.Statements = append(result.Statements, statements...)
+		result.Warnings = append(result.Warnings, warnings...)
+	}
+	if len(result.Statements) == 0 {
+		return DesignerDDLResult{}, fmt.Errorf("没有检测到变更")
+	}
+	result.Script = strings.Join(result.Statements, ";\n") + ";"
+	return result, nil
+}
+
+// designerReorder 生成调整列顺序的语句；order 须包含表中全部列。
+func designerReorder(t *designerTable, order []string) ([]string, []string, bool, error) {
+	if len(order) != len(t.columns) {
+		return nil, nil, false, fmt.Errorf("列顺序须包含全部 %d 个列", len(t.columns))
+	}
+	seen := make(map[string]struct{}, len(order))
+	target := make([]connection.ColumnDefinition, 0, len(order))
+	for _, name := range order {
+		idx := t.columnIndex(name)
+		if idx < 0 {
+			return nil, nil, false, fmt.Errorf("列 %s 不存在", name)
+		}
+		key := strings.ToLower(t.columns[idx].Name)
+		if _, dup := seen[key]; dup {
+			return nil, nil, false, fmt.Errorf("列 %s 重复", name)
+		}
+		seen[key] = struct{}{}
+		target = append(target, t.columns[idx])
+	}
+
+	switch {
+	case t.mysqlLike() || t.dbType == "clickhouse":
+		var clauses []string
+		current := append([]connection.ColumnDefinition(nil), t.columns...)
+		for i, col := range target {
+			if strings.EqualFold(current[i].Name, col.Name) {
+				continue
+			}
+			// 把目标列移动到第 i 位，其后的列依次后移
+			from := i
+			for ; from < len(current) && !strings.EqualFold(current[from].Name, col.Name); from++ {
+			}
+			copy(current[i+1:from+1], current[i:from])
+			current[i] = col
+
+			position := "FIRST"
+			if i > 0 {
+				position = "AFTER " + quoteIdentByType(t.dbType, target[i-1].Name)
+			}
+			def := quoteIdentByType(t.dbType, col.Name)
+			if t.mysqlLike() {
+				// MySQL 的 MODIFY 需要完整列定义，取自 SHOW CREATE TABLE 以保留字符集、默认值与注释
+				full, ok := t.mysqlDefs[strings.ToLower(col.Name)]
+				if !ok {
+					return nil, nil, false, fmt.Errorf("未在建表语句中找到列 %s 的定义", col.Name)
+				}
+				def = full
+			}
+			clauses = append(clauses, "MODIFY COLUMN "+def+" "+position)
+		}
+		t.columns = target
+		if len(clauses) == 0 {
+			return nil, nil, false, nil
+		}
+		return []string{"ALTER TABLE " + t.ref() + "\n  " + strings.Join(clauses, ",\n  ")}, nil, false, nil
+	}
+
+	unchanged := true
+	for i := range target {
+		if !strings.EqualFold(target[i].Name, t.columns[i].Name) {
+			unchanged = false
+			break
+		}
+	}
+	if unchanged {
+		return nil, nil, false, nil
+	}
+	for _, col := range target {
+		if strings.TrimSpace(col.Generated) != "" {
+			return nil, nil, false, fmt.Errorf("表中含计算列 %s，无法通过重建表调整列顺序", col.Name)
+		}
+	}
+	side := schemaSide{config: t.config, dbType: t.dbType, dbName: t.dbName}
+	oldT := &schemaTable{key: t.table, schema: t.schema, table: t.table, columns: t.columns, indexes: t.indexes, foreignKeys: t.foreignKeys}
+	newT := *oldT
+	newT.columns = target
+	statements, warnings, err := rebuildTableStatements(side, oldT, &newT, func(name string) string { return name })
+	if err != nil {
+		return nil, nil, false, err
+	}
+	t.columns = target
+	return statements, warnings, true, nil
+}
+
+// designerCharset 批量修改字符列的字符集与排序规则。
+func designerCharset(t *designerTable, op DesignerOperation) ([]string, []string, error) {
+	if !t.mysqlLike() {
+		return nil, nil, fmt.Errorf("%s 不支持按列设置字符集", t.dbType)
+	}
+	charset := strings.TrimSpace(op.Charset)
+	collation := strings.TrimSpace(op.Collation)
+	if charset == "" || !designerIdentPattern.MatchString(charset) || (collation != "" && !designerIdentPattern.MatchString(collation)) {
+		return nil, nil, fmt.Errorf("字符集或排序规则无效")
+	}
+	clause := " CHARACTER SET " + charset
+	if collation != "" {
+		clause += " COLLATE " + collation
+	}
+
+	selected := t.columns
+	if len(op.Columns) > 0 {
+		selected = nil
+		for _, name := range op.Columns {
+			idx := t.columnIndex(name)
+			if idx < 0 {
+				return nil, nil, fmt.Errorf("列 %s 不存在", name)
+			}
+			selected = append(selected, t.columns[idx])
+		}
+	}
+
+	var clauses, warnings []string
+	for _, col := range selected {
+		if !designerCharTypePattern.MatchString(strings.TrimSpace(col.Type)) {
+			if len(op.Columns) > 0 {
+				warnings = append(warnings, fmt.Sprintf("列 %s 的类型 %s 不是字符类型，已跳过", col.Name, col.Type))
+			}
+			continue
+		}
+		key := strings.ToLower(col.Name)
+		def, ok := t.mysqlDefs[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("未在建表语句中找到列 %s 的定义", col.Name)
+		}
+		updated, ok := replaceMySQLColumnCharset(def, clause)
+		if !ok {
+			return nil, nil, fmt.Errorf("无法解析列 %s 的定义", col.Name)
+		}
+		if updated == def {
+			continue
+		}
+		t.mysqlDefs[key] = updated
+		clauses = append(clauses, "MODIFY COLUMN "+updated)
+	}
+	if len(clauses) == 0 {
+		return nil, warnings, nil
+	}
+	return []string{"ALTER TABLE " + t.ref() + "\n  " + strings.Join(clauses, ",\n  ")}, warnings, nil
+}
+
+// designerTimestamps 添加创建时间与更新时间列，已存在的列跳过。
+func designerTimestamps(t *designerTable, op DesignerOperation) ([]string, []string, error) {
+	created := strings.TrimSpace(op.CreatedColumn)
+	if created == "" {
+		created = "created_at"
+	}
+	updated := strings.TrimSpace(op.UpdatedColumn)
+	if updated == "" {
+		updated = "updated_at"
+	}
+
+	var (
+		colType, createdTail, updatedTail, addKeyword string
+		warnings                                      []string
+	)
+	addKeyword = "ADD COLUMN"
+	switch transferTypeFamily(t.dbType) {
+	case "mysql":
+		colType = "DATETIME"
+		createdTail = "NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间'"
+		updatedTail = "NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间'"
+	case "postgres":
+		colType = "TIMESTAMP"
+		createdTail = "NOT NULL DEFAULT CURRENT_TIMESTAMP"
+		updatedTail = createdTail
+		warnings = append(warnings, fmt.Sprintf("%s 没有 ON UPDATE 写法，%s 需由应用或触发器维护", t.dbType, updated))
+	case "sqlserver":
+		colType, addKeyword = "DATETIME2", "ADD"
+		createdTail = "NOT NULL DEFAULT SYSDATETIME()"
+		updatedTail = createdTail
+		warnings = append(warnings, fmt.Sprintf("SQL Server 没有 ON UPDATE 写法，%s 需由应用或触发器维护", updated))
+	case "oracle", "dameng":
+		colType, addKeyword = "TIMESTAMP", "ADD"
+		createdTail = "DEFAULT SYSTIMESTAMP NOT NULL"
+		updatedTail = createdTail
+		warnings = append(warnings, fmt.Sprintf("%s 没有 ON UPDATE 写法，%s 需由应用或触发器维护", t.dbType, updated))
+	case "duckdb":
+		colType = "TIMESTAMP"
+		createdTail = "DEFAULT CURRENT_TIMESTAMP"
+		updatedTail = createdTail
+		warnings = append(warnings, fmt.Sprintf("DuckDB 没有 ON UPDATE 写法，%s 需由应用维护", updated))
+	case "sqlite":
+		colType = "TEXT"
+		warnings = append(warnings, "SQLite 新增列不能使用 CURRENT_TIMESTAMP 默认值，时间戳列需由应用或触发器写入")
+	case "clickhouse":
+		colType = "DateTime"
+		createdTail = "DEFAULT now()"
+		updatedTail = createdTail
+		warnings = append(warnings, fmt.Sprintf("ClickHouse 没有 ON UPDATE 写法，%s 需由应用写入", updated))
+	default:
+		return nil, nil, fmt.Errorf("%s 暂不支持添加时间戳列", t.dbType)
+	}
+
+	var clauses []string
+	for _, pair := range [][2]string{{created, createdTail}, {updated, updatedTail}} {
+		name := pair[0]
+		if t.columnIndex(name) >= 0 {
+			warnings = append(warnings, fmt.Sprintf("列 %s 已存在，已跳过", name))
+			continue
+		}
+		def := strings.TrimSpace(quoteIdentByType(t.dbType, name) + " " + colType + " " + pair[1])
+		clauses = append(clauses, addKeyword+" "+def)
+		t.columns = append(t.columns, connection.ColumnDefinition{Name: name, Type: colType, Nullable: "NO"})
+		if t.mysqlLike() {
+			t.mysqlDefs[strings.ToLower(name)] = def
+		}
+	}
+	if len(clauses) == 0 {
+		return nil, warnings, nil
+	}
+	if t.mysqlLike() || t.dbType == "clickhouse" {
+		return []string{"ALTER TABLE " + t.ref() + "\n  " + strings.Join(clauses, ",\n  ")}, warnings, nil
+	}
+	// 其他数据库的多列 ALTER 写法不一致，逐列生成
+	statements := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		statements = append(statements, "ALTER TABLE "+t.ref()+" "+clause)
+	}
+	return statements, warnings, nil
+}
+
+// mysqlColumnDefinitions 从 SHOW CREATE TABLE 的结果中取出各列的完整定义，键为小写列名。
+func mysqlColumnDefinitions(createSQL string) map[string]string {
+	defs := make(map[string]string)
+	open := -1
+	for i := 0; i < len(createSQL); i++ {
+		if end := ddlQuoteEnd(createSQL, i, true); end > 0 {
+			i = end - 1
+			continue
+		}
+		if createSQL[i] == '(' {
+			open = i
+			break
+		}
+	}
+	if open < 0 {
+		return defs
+	}
+	items, _, ok := splitDDLBody(createSQL, open+1, true)
+	if !ok {
+		return defs
+	}
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if ddlItemRank(item, true) >= 0 || !strings.HasPrefix(item, "`") {
+			continue
+		}
+		end := ddlQuoteEnd(item, 0, true)
+		if end <= 0 {
+			continue
+		}
+		name := strings.ReplaceAll(item[1:end-1], "``", "`")
+		defs[strings.ToLower(name)] = item
+	}
+	return defs
+}
+
+// replaceMySQLColumnCharset 把列定义中类型之后的字符集与排序规则替换为 clause。
+func replaceMySQLColumnCharset(def string, clause string) (string, bool) {
+	nameEnd := ddlQuoteEnd(def, 0, true)
+	if nameEnd <= 0 || nameEnd >= len(def) {
+		return "", false
+	}
+	// 类型到顶层第一个空白为止，enum('a b') 等括号内的空白不算
+	typeEnd, depth := nameEnd+1, 0
+	for ; typeEnd < len(def); typeEnd++ {
+		if end := ddlQuoteEnd(def, typeEnd, true); end > 0 {
+			typeEnd = end - 1
+			continue
+		}
+		c := def[typeEnd]
+		if c == '(' {
+			depth++
+		} else if c == ')' {
+			depth--
+		} else if c == ' ' && depth == 0 {
+			break
+		}
+	}
+	rest := designerCharsetClausePattern.ReplaceAllString(def[typeEnd:], "")
+	return def[:typeEnd] + clause + rest, true
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+const designerMySQLCreate = "CREATE TABLE `users` (\n" +
+	"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+	"  `name` varchar(20) CHARACTER SET latin1 COLLATE latin1_swedish_ci NOT NULL DEFAULT '' COMMENT 'a, b',\n" +
+	"  `kind` enum('a b','c') DEFAULT NULL,\n" +
+	"  `score` decimal(10,2) DEFAULT NULL,\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  KEY `idx_name` (`name`)\n" +
+	") ENGINE=InnoDB AUTO_INCREMENT=5 DEFAULT CHARSET=utf8mb4"
+
+func designerMySQLTable() *designerTable {
+	return &designerTable{
+		dbType: "mysql", schema: "shop", table: "users",
+		columns: []connection.ColumnDefinition{
+			{Name: "id", Type: "int(11)", Key: "PRI", Nullable: "NO"},
+			{Name: "name", Type: "varchar(20)", Nullable: "NO"},
+			{Name: "kind", Type: "enum('a b','c')", Nullable: "YES"},
+			{Name: "score", Type: "decimal(10,2)", Nullable: "YES"},
+		},
+		mysqlDefs: mysqlColumnDefinitions(designerMySQLCreate),
+	}
+}
+
+func TestBuildDesignerDDLMySQL(t *testing.T) {
+	res, err := buildDesignerDDL(designerMySQLTable(), []DesignerOperation{
+		{Kind: "charset", Charset: "utf8mb4", Collation: "utf8mb4_general_ci"},
+		{Kind: "reorder", Columns: []string{"id", "score", "name", "kind"}},
+		{Kind: "timestamps"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"ALTER TABLE `shop`.`users`\n" +
+			"  MODIFY COLUMN `name` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'a, b',\n" +
+			"  MODIFY COLUMN `kind` enum('a b','c') CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci DEFAULT NULL",
+		"ALTER TABLE `shop`.`users`\n  MODIFY COLUMN `score` decimal(10,2) DEFAULT NULL AFTER `id`",
+		"ALTER TABLE `shop`.`users`\n" +
+			"  ADD COLUMN `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n" +
+			"  ADD COLUMN `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间'",
+	}
+	if res.Strategy != "alter" || strings.Join(res.Statements, "\n--\n") != strings.Join(want, "\n--\n") {
+		t.Fatalf("statements = %q", res.Statements)
+	}
+
+	// 重复添加时间戳列只给出提示
+	if _, err := buildDesignerDDL(designerMySQLTable(), []DesignerOperation{{Kind: "timestamps", CreatedColumn: "id", UpdatedColumn: "name"}}); err == nil {
+		t.Fatal("expected no-change error")
+	}
+}
+
+func TestBuildDesignerDDLRebuild(t *testing.T) {
+	table := &designerTable{
+		dbType: "postgres", schema: "public", table: "users",
+		columns: []connection.ColumnDefinition{
+			{Name: "id", Type: "integer", Key: "PRI", Nullable: "NO"},
+			{Name: "name", Type: "character varying(20)", Nullable: "YES"},
+		},
+		indexes: []schemaIndex{{name: "idx_name", columns: []string{"name"}}},
+	}
+	res, err := buildDesignerDDL(table, []DesignerOperation{{Kind: "reorder", Columns: []string{"name", "id"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"CREATE TABLE \"public\".\"users_gonavi_rebuild\" (\n  \"name\" character varying(20),\n  \"id\" integer NOT NULL,\n  PRIMARY KEY (\"id\")\n)",
+		`INSERT INTO "public"."users_gonavi_rebuild" ("id", "name") SELECT "id", "name" FROM "public"."users"`,
+		`DROP TABLE "public"."users"`,
+		`ALTER TABLE "public"."users_gonavi_rebuild" RENAME TO "users"`,
+		`CREATE INDEX "idx_name" ON "public"."users" ("name")`,
+	}
+	if res.Strategy != "rebuild" || len(res.Warnings) == 0 || strings.Join(res.Statements, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("result = %+v", res)
+	}
+
+	if _, err := buildDesignerDDL(table, []DesignerOperation{{Kind: "charset", Charset: "utf8"}}); err == nil {
+		t.Fatal("expected charset error on postgres")
+	}
+	if _, err := buildDesignerDDL(table, []DesignerOperation{{Kind: "reorder", Columns: []string{"id"}}}); err == nil {
+		t.Fatal("expected incomplete order error")
+	}
+}
@@ -0,0 +1,13 @@
+package app
+
+import "GoNavi-Wails/internal/dialect"
+
+// QuoteIdentifier 按数据库方言为标识符加引号，与后端生成 SQL 时的规则一致，供前端拼接 SQL 使用。
+func (a *App) QuoteIdentifier(dbType string, name string) string {
+	return dialect.QuoteIdent(dbType, name)
+}
+
+// QualifyTable 按数据库方言生成 schema.table 形式的表引用；schema 为空时只返回表名。
+func (a *App) QualifyTable(dbType string, schema string, table string) string {
+	return dialect.QualifyTable(dbType, schema, table)
+}
@@ -8,7 +8,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
@@ -20,6 +19,7 @@ import (
 
 	"GoNavi-Wails/internal/connection"
 	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -124,7 +124,6 @@ const (
 	driverManifestCacheTTL              = 5 * time.Minute
 	driverReleaseAssetSizeCacheTTL      = 30 * time.Minute
 	driverReleaseAssetSizeErrorCacheTTL = 30 * time.Second
-	driverReleaseAssetSizeProbeTimeout  = 4 * time.Second
 	driverBundleIndexMaxSize            = 1 << 20
 	driverManifestMaxSize               = 2 << 20
 	driverChecksumPolicyStrict          = "strict"
@@ -149,7 +148,8 @@ const builtinDriverManifestJSON = `{
     "highgo":    { "engine": "go", "version": "go-embedded", "checksumPolicy": "off", "downloadUrl": "builtin://activate/highgo" },
     "vastbase":  { "engine": "go", "version": "go-embedded", "checksumPolicy": "off", "downloadUrl": "builtin://activate/vastbase" },
     "mongodb":   { "engine": "go", "version": "go-embedded", "checksumPolicy": "off", "downloadUrl": "builtin://activate/mongodb" },
-    "tdengine":  { "engine": "go", "version": "go-embedded", "checksumPolicy": "off", "downloadUrl": "builtin://activate/tdengine" }
+    "tdengine":  { "engine": "go", "version": "go-embedded", "checksumPolicy": "off", "downloadUrl": "builtin://activate/tdengine" },
+    "clickhouse": { "engine": "go", "version": "go-embedded", "checksumPolicy": "off", "downloadUrl": "builtin://activate/clickhouse" }
   }
 }`
 
@@ -304,6 +304,8 @@ func (a *App) ResolveDriverPackageDownloadURL(driverType string, repositoryURL s
 	return connection.QueryResult{Success: false, Message: "当前仅支持纯 Go 可选驱动的安装启用"}
 }
 
+// GetDriverStatusList 返回驱动管理页的驱动状态。结果按下载目录与清单地址缓存，安装、移除驱动时只让对应驱动失效，
+// 再次打开时不必重新探测文件、Release 安装包大小与清单。
 func (a *App) GetDriverStatusList(downloadDir string, manifestURL string) connection.QueryResult {
 	resolvedDir, err := resolveDriverDownloadDirectory(downloadDir)
 	if err != nil {
@@ -311,60 +313,36 @@ func (a *App) GetDriverStatusList(downloadDir string, manifestURL string) connec
 	}
 	db.SetExternalDriverDownloadDirectory(resolvedDir)
 
-	effectivePackages, manifestErr := resolveEffectiveDriverPackages(manifestURL)
-	definitions := allDriverDefinitionsWithPackages(effectivePackages)
-	packageSizeBytesMap := preloadOptionalDriverPackageSizes(definitions)
+	key := driverStatusCacheKey(resolvedDir, manifestURL)
+	definitions, cached, generation := loadDriverStatusCache(key)
+	var manifestErr error
+	if definitions == nil {
+		var effectivePackages map[string]pinnedDriverPackage
+		effectivePackages, manifestErr = resolveEffectiveDriverPackages(manifestURL)
+		definitions = allDriverDefinitionsWithPackages(effectivePackages)
+	}
+
+	stale := make([]driverDefinition, 0, len(definitions))
+	for _, definition := range definitions {
+		if _, ok := cached[normalizeDriverType(definition.Type)]; !ok {
+			stale = append(stale, definition)
+		}
+	}
+	packageSizeBytesMap := preloadOptionalDriverPackageSizes(stale)
 	items := make([]driverStatusItem, 0, len(definitions))
 	for _, definition := range definitions {
-		engine := effectiveDriverEngine(definition)
-		runtimeAvailable, runtimeReason := db.DriverRuntimeSupportStatus(definition.Type)
-		pkg, packageMetaExists := readInstalledDriverPackage(resolvedDir, definition.Type)
-		packageInstalled := definition.BuiltIn || packageMetaExists
-		if runtimeAvailable && db.IsOptionalGoDriver(definition.Type) {
-			packageInstalled = true
-		}
-
-		item := driverStatusItem{
-			Type:               definition.Type,
-			Name:               definition.Name,
-			Engine:             engine,
-			BuiltIn:            definition.BuiltIn,
-			PinnedVersion:      definition.PinnedVersion,
-			PackageSizeText:    resolveDriverPackageSizeText(definition, pkg, packageMetaExists, packageSizeBytesMap),
-			RuntimeAvailable:   runtimeAvailable,
-			PackageInstalled:   packageInstalled,
-			Connectable:        runtimeAvailable,
-			DefaultDownloadURL: definition.DefaultDownloadURL,
-			InstallDir:         driverInstallDir(resolvedDir, definition.Type),
-		}
-		if packageMetaExists {
-			item.PackagePath = pkg.FilePath
-			item.PackageFileName = pkg.FileName
-			item.DownloadedAt = pkg.DownloadedAt
-			item.ExecutablePath = pkg.ExecutablePath
-		}
-
-		switch {
-		case definition.BuiltIn:
-			item.Message = "内置驱动，可直接连接"
-		case runtimeAvailable:
-			item.Message = "纯 Go 驱动已启用，可直接连接"
-		case packageInstalled && strings.TrimSpace(runtimeReason) != "":
-			item.Message = runtimeReason
-		case packageInstalled:
-			item.Message = "驱动已安装，待生效"
-		case strings.TrimSpace(runtimeReason) != "":
-			item.Message = runtimeReason
-		default:
-			if strings.TrimSpace(definition.PinnedVersion) != "" {
-				item.Message = fmt.Sprintf("未启用（版本：%s）", strings.TrimSpace(definition.PinnedVersion))
-			} else {
-				item.Message = "未启用"
-			}
+		normalizedType := normalizeDriverType(definition.Type)
+		item, ok := cached[normalizedType]
+		if !ok {
+			item = buildDriverStatusItem(definition, resolvedDir, packageSizeBytesMap)
+			cached[normalizedType] = item
 		}
-
 		items = append(items, item)
 	}
+	// 清单拉取失败时不缓存，下次打开重新尝试
+	if manifestErr == nil {
+		storeDriverStatusCache(key, definitions, cached, generation)
+	}
 
 	return connection.QueryResult{
 		Success: true,
@@ -373,11 +351,138 @@ func (a *App) GetDriverStatusList(downloadDir string, manifestURL string) connec
 			"drivers":       items,
 			"manifestURL":   resolveManifestURLForView(manifestURL),
 			"manifestError": errorMessage(manifestErr),
+			"offline":       currentDriverNetworkSettings().Offline,
+		},
+	}
+}
+
+// ReloadDriverStatusList 丢弃全部缓存后重新计算驱动状态，供驱动管理页的“刷新”按钮使用。
+func (a *App) ReloadDriverStatusList(downloadDir string, manifestURL string) connection.QueryResult {
+	invalidateDriverStatus("")
+	return a.GetDriverStatusList(downloadDir, manifestURL)
+}
+
+// RefreshDriverStatus 只重新计算单个驱动的状态并写回缓存，安装、移除后前端用它更新对应的一行。
+func (a *App) RefreshDriverStatus(driverType string, downloadDir string, manifestURL string) connection.QueryResult {
+	resolvedDir, err := resolveDriverDownloadDirectory(downloadDir)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	db.SetExternalDriverDownloadDirectory(resolvedDir)
+
+	key := driverStatusCacheKey(resolvedDir, manifestURL)
+	definitions, _, _ := loadDriverStatusCache(key)
+	var definition driverDefinition
+	found := false
+	for _, item := range definitions {
+		if normalizeDriverType(item.Type) == normalizeDriverType(driverType) {
+			definition, found = item, true
+			break
+		}
+	}
+	if !found {
+		effectivePackages, _ := resolveEffectiveDriverPackages(manifestURL)
+		definition, found = resolveDriverDefinitionWithPackages(driverType, effectivePackages)
+	}
+	if !found {
+		return connection.QueryResult{Success: false, Message: "不支持的驱动类型"}
+	}
+
+	invalidateDriverStatus(definition.Type)
+	item := buildDriverStatusItem(definition, resolvedDir, preloadOptionalDriverPackageSizes([]driverDefinition{definition}))
+	updateDriverStatusCache(key, item)
+	return connection.QueryResult{Success: true, Data: item}
+}
+
+func buildDriverStatusItem(definition driverDefinition, resolvedDir string, packageSizeBytesMap map[string]int64) driverStatusItem {
+	engine := effectiveDriverEngine(definition)
+	runtimeAvailable, runtimeReason := db.DriverRuntimeSupportStatus(definition.Type)
+	pkg, packageMetaExists := readInstalledDriverPackage(resolvedDir, definition.Type)
+	packageInstalled := definition.BuiltIn || packageMetaExists
+	if runtimeAvailable && db.IsOptionalGoDriver(definition.Type) {
+		packageInstalled = true
+	}
+
+	item := driverStatusItem{
+		Type:               definition.Type,
+		Name:               definition.Name,
+		Engine:             engine,
+		BuiltIn:            definition.BuiltIn,
+		PinnedVersion:      definition.PinnedVersion,
+		PackageSizeText:    resolveDriverPackageSizeText(definition, pkg, packageMetaExists, packageSizeBytesMap),
+		RuntimeAvailable:   runtimeAvailable,
+		PackageInstalled:   packageInstalled,
+		Connectable:        runtimeAvailable,
+		DefaultDownloadURL: definition.DefaultDownloadURL,
+		InstallDir:         driverInstallDir(resolvedDir, definition.Type),
+	}
+	if packageMetaExists {
+		item.PackagePath = pkg.FilePath
+		item.PackageFileName = pkg.FileName
+		item.DownloadedAt = pkg.DownloadedAt
+		item.ExecutablePath = pkg.ExecutablePath
+	}
+
+	switch {
+	case definition.BuiltIn:
+		item.Message = "内置驱动，可直接连接"
+	case runtimeAvailable:
+		item.Message = "纯 Go 驱动已启用，可直接连接"
+	case packageInstalled && strings.TrimSpace(runtimeReason) != "":
+		item.Message = runtimeReason
+	case packageInstalled:
+		item.Message = "驱动已安装，待生效"
+	case strings.TrimSpace(runtimeReason) != "":
+		item.Message = runtimeReason
+	default:
+		if strings.TrimSpace(definition.PinnedVersion) != "" {
+			item.Message = fmt.Sprintf("未启用（版本：%s）", strings.TrimSpace(definition.PinnedVersion))
+		} else {
+			item.Message = "未启用"
+		}
+	}
+	return item
+}
+
+// GetCustomDriverTypes 返回配置文件中注册的自定义数据源类型及其协议族可用状态。
+func (a *App) GetCustomDriverTypes() connection.QueryResult {
+	return buildCustomDriverTypesResult(db.CustomDriverTypes(), nil)
+}
+
+// ReloadCustomDriverTypes 重新读取自定义数据源类型配置（默认 ~/.gonavi/driver_types.json）。
+func (a *App) ReloadCustomDriverTypes() connection.QueryResult {
+	types, err := db.ReloadCustomDriverTypes("")
+	if err != nil {
+		logger.Warnf("重新加载自定义数据源类型配置存在问题：%v", err)
+	}
+	return buildCustomDriverTypesResult(types, err)
+}
+
+func buildCustomDriverTypesResult(types []db.CustomDriverType, loadErr error) connection.QueryResult {
+	items := make([]map[string]interface{}, 0, len(types))
+	for _, item := range types {
+		available, reason := db.DriverRuntimeSupportStatus(item.Family)
+		items = append(items, map[string]interface{}{
+			"type":             item.Type,
+			"family":           item.Family,
+			"displayName":      item.DisplayName,
+			"defaultPort":      item.DefaultPort,
+			"runtimeAvailable": available,
+			"message":          reason,
+		})
+	}
+	return connection.QueryResult{
+		Success: true,
+		Message: errorMessage(loadErr),
+		Data: map[string]interface{}{
+			"configPath":  db.DefaultCustomDriverTypesPath(),
+			"driverTypes": items,
 		},
 	}
 }
 
 func (a *App) InstallLocalDriverPackage(driverType string, filePath string, downloadDir string) connection.QueryResult {
+	defer invalidateDriverStatus(driverType)
 	definition, ok := resolveDriverDefinition(driverType)
 	if !ok {
 		return connection.QueryResult{Success: false, Message: "不支持的驱动类型"}
@@ -429,6 +534,7 @@ func (a *App) InstallLocalDriverPackage(driverType string, filePath string, down
 }
 
 func (a *App) DownloadDriverPackage(driverType string, downloadURL string, downloadDir string) connection.QueryResult {
+	defer invalidateDriverStatus(driverType)
 	definition, ok := resolveDriverDefinition(driverType)
 	if !ok {
 		return connection.QueryResult{Success: false, Message: "不支持的驱动类型"}
@@ -505,6 +611,7 @@ func (a *App) DownloadDriverPackage(driverType string, downloadURL string, downl
 }
 
 func (a *App) RemoveDriverPackage(driverType string, downloadDir string) connection.QueryResult {
+	defer invalidateDriverStatus(driverType)
 	definition, ok := resolveDriverDefinition(driverType)
 	if !ok {
 		return connection.QueryResult{Success: false, Message: "不支持的驱动类型"}
@@ -561,6 +668,7 @@ func (a *App) emitDriverDownloadProgress(driverType string, status string, downl
 		payload.Percent = 100
 	}
 	runtime.EventsEmit(a.ctx, driverDownloadProgressEvent, payload)
+	a.forwardDriverBulkProgress(payload)
 }
 
 func defaultDriverDownloadDirectory() string {
@@ -658,6 +766,7 @@ func allDriverDefinitionsWithPackages(packages map[string]pinnedDriverPackage) [
 		buildOptionalGoDriverDefinition("vastbase", "Vastbase", packages),
 		buildOptionalGoDriverDefinition("mongodb", "MongoDB", packages),
 		buildOptionalGoDriverDefinition("tdengine", "TDengine", packages),
+		buildOptionalGoDriverDefinition("clickhouse", "ClickHouse", packages),
 	}
 }
 
@@ -914,29 +1023,8 @@ func loadManifestContent(resolvedURL string) ([]byte, error) {
 		scheme := strings.ToLower(strings.TrimSpace(parsed.Scheme))
 		switch scheme {
 		case "http", "https":
-			client := &http.Client{Timeout: 12 * time.Second}
-			req, reqErr := http.NewRequest(http.MethodGet, parsed.String(), nil)
-			if reqErr != nil {
-				return nil, reqErr
-			}
-			req.Header.Set("User-Agent", "GoNavi-DriverManifest")
-			resp, doErr := client.Do(req)
-			if doErr != nil {
-				return nil, doErr
-			}
-			defer resp.Body.Close()
-			if resp.StatusCode != http.StatusOK {
-				return nil, fmt.Errorf("拉取驱动清单失败：HTTP %d", resp.StatusCode)
-			}
-			limited := io.LimitReader(resp.Body, driverManifestMaxSize+1)
-			body, readErr := io.ReadAll(limited)
-			if readErr != nil {
-				return nil, readErr
-			}
-			if int64(len(body)) > driverManifestMaxSize {
-				return nil, fmt.Errorf("驱动清单超过大小限制")
-			}
-			return body, nil
+			timeout := time.Duration(currentDriverNetworkSettings().ManifestTimeoutSeconds) * time.Second
+			return fetchDriverNetworkBody(parsed.String(), timeout, "", "拉取驱动清单失败", driverManifestMaxSize)
 		case "file":
 			pathText := strings.TrimSpace(parsed.Path)
 			if pathText == "" {
@@ -1171,7 +1259,7 @@ func downloadOptionalDriverAgentBinary(a *App, definition driverDefinition, urlT
 	tempPath := executablePath + ".tmp"
 	_ = os.Remove(tempPath)
 
-	hash, err := downloadFileWithHash(trimmedURL, tempPath, func(downloaded, total int64) {
+	hash, err := downloadDriverFile(trimmedURL, tempPath, func(downloaded, total int64) {
 		if a == nil {
 			return
 		}
@@ -1207,7 +1295,7 @@ func downloadOptionalDriverAgentFromBundle(a *App, definition driverDefinition,
 
 	bundleTempPath := executablePath + ".bundle.zip.tmp"
 	_ = os.Remove(bundleTempPath)
-	_, err := downloadFileWithHash(trimmedURL, bundleTempPath, func(downloaded, total int64) {
+	_, err := downloadDriverFile(trimmedURL, bundleTempPath, func(downloaded, total int64) {
 		if a == nil {
 			return
 		}
@@ -1364,6 +1452,8 @@ func optionalDriverBuildTag(driverType string) (string, error) {
 		return "gonavi_mongodb_driver", nil
 	case "tdengine":
 		return "gonavi_tdengine_driver", nil
+	case "clickhouse":
+		return "gonavi_clickhouse_driver", nil
 	default:
 		return "", fmt.Errorf("未配置驱动构建标签：%s", driverType)
 	}
@@ -1618,7 +1708,7 @@ func scaleProgress(downloaded, total, start, end int64) (int64, int64) {
 
 func preloadOptionalDriverPackageSizes(definitions []driverDefinition) map[string]int64 {
 	result := make(map[string]int64)
-	if len(definitions) == 0 {
+	if len(definitions) == 0 || currentDriverNetworkSettings().Offline {
 		return result
 	}
 
@@ -1759,28 +1849,10 @@ func fetchDriverBundleAssetSizeIndex(release *githubRelease) (map[string]int64,
 		return nil, fmt.Errorf("未找到驱动总包索引资产")
 	}
 
-	client := &http.Client{Timeout: driverReleaseAssetSizeProbeTimeout}
-	req, err := http.NewRequest(http.MethodGet, indexURL, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "GoNavi-DriverManager")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("拉取驱动总包索引失败：HTTP %d", resp.StatusCode)
-	}
-
-	limited := io.LimitReader(resp.Body, driverBundleIndexMaxSize)
-	decoder := json.NewDecoder(limited)
 	var index driverBundleAssetIndex
-	if err := decoder.Decode(&index); err != nil {
-		return nil, fmt.Errorf("解析驱动总包索引失败：%w", err)
+	timeout := time.Duration(currentDriverNetworkSettings().ProbeTimeoutSeconds) * time.Second
+	if err := fetchDriverNetworkJSON(indexURL, timeout, "application/json", "拉取驱动总包索引失败", driverBundleIndexMaxSize, &index); err != nil {
+		return nil, err
 	}
 	if len(index.Assets) == 0 {
 		return nil, fmt.Errorf("驱动总包索引为空")
@@ -1807,25 +1879,9 @@ func fetchDriverReleaseByURL(apiURL string) (*githubRelease, error) {
 		return nil, fmt.Errorf("API 地址为空")
 	}
 
-	client := &http.Client{Timeout: driverReleaseAssetSizeProbeTimeout}
-	req, err := http.NewRequest(http.MethodGet, urlText, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "GoNavi-DriverManager")
-	req.Header.Set("Accept", "application/vnd.github+json")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("拉取 Release 信息失败：HTTP %d", resp.StatusCode)
-	}
-
 	var release githubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+	timeout := time.Duration(currentDriverNetworkSettings().ProbeTimeoutSeconds) * time.Second
+	if err := fetchDriverNetworkJSON(urlText, timeout, "application/vnd.github+json", "拉取 Release 信息失败", 0, &release); err != nil {
 		return nil, err
 	}
 	return &release, nil
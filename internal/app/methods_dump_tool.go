@@ -0,0 +1,549 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/utils"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	dumpToolProgressEvent = "dumptool:progress"
+	dumpToolStderrKeep    = 20
+)
+
+// DumpToolOptions 调用 mysqldump / pg_dump 导出的选项。
+type DumpToolOptions struct {
+	ToolPath   string   `json:"toolPath,omitempty"` // 为空时在 PATH 与常见安装目录中查找
+	FilePath   string   `json:"filePath,omitempty"` // 为空时弹出保存对话框
+	Tables     []string `json:"tables,omitempty"`   // 为空表示整个库；PostgreSQL 可写 schema.table
+	SchemaOnly bool     `json:"schemaOnly,omitempty"`
+	DataOnly   bool     `json:"dataOnly,omitempty"`
+	Format     string   `json:"format,omitempty"` // pg_dump：plain（默认）或 custom
+	JobID      string   `json:"jobId,omitempty"`
+}
+
+// DumpToolInfo 找到的导出工具。
+type DumpToolInfo struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// DumpToolResult 导出结果。
+type DumpToolResult struct {
+	FilePath   string `json:"filePath"`
+	Tool       string `json:"tool"`
+	Bytes      int64  `json:"bytes"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// dumpToolFamily 返回支持外部导出工具的方言族：mysql 或 postgres，不支持时为空。
+func dumpToolFamily(dbType string) string {
+	switch strings.ToLower(strings.TrimSpace(dbType)) {
+	case "mysql", "mariadb", "diros":
+		return "mysql"
+	case "postgres":
+		return "postgres"
+	}
+	return ""
+}
+
+func dumpToolNames(family string) []string {
+	if family == "mysql" {
+		return []string{"mysqldump", "mariadb-dump"}
+	}
+	return []string{"pg_dump"}
+}
+
+// dumpToolSearchDirs 返回 PATH 之外的常见安装目录（按平台），目录名含版本号的用通配符匹配。
+func dumpToolSearchDirs(family string) []string {
+	var patterns []string
+	switch goruntime.GOOS {
+	case "windows":
+		if family == "mysql" {
+			patterns = []string{`C:\Program Files\MySQL\MySQL Server *\bin`, `C:\Program Files\MariaDB *\bin`}
+		} else {
+			patterns = []string{`C:\Program Files\PostgreSQL\*\bin`}
+		}
+	case "darwin":
+		patterns = []string{"/opt/homebrew/bin", "/usr/local/bin", "/usr/local/mysql/bin", "/opt/homebrew/opt/mysql-client/bin", "/opt/homebrew/opt/libpq/bin",
+			"/Applications/Postgres.app/Contents/Versions/latest/bin"}
+	default:
+		patterns = []string{"/usr/bin", "/usr/local/bin", "/usr/lib/postgresql/*/bin", "/usr/pgsql-*/bin"}
+	}
+	var dirs []string
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(pattern)
+		// 版本号目录倒序，优先使用新版本（pg_dump 不能导出比自身新的服务端）
+		for i := len(matches) - 1; i >= 0; i-- {
+			dirs = append(dirs, matches[i])
+		}
+	}
+	return dirs
+}
+
+// locateDumpTool 优先使用指定路径，其次 PATH，最后常见安装目录。
+func locateDumpTool(family string, override string) (string, error) {
+	if path := strings.TrimSpace(override); path != "" {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			return "", fmt.Errorf("导出工具不存在：%s", path)
+		}
+		return path, nil
+	}
+	names := dumpToolNames(family)
+	for _, name := range names {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	for _, dir := range dumpToolSearchDirs(family) {
+		for _, name := range names {
+			if goruntime.GOOS == "windows" {
+				name += ".exe"
+			}
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("未找到 %s，请安装客户端工具或手动指定路径", strings.Join(names, "/"))
+}
+
+func dumpToolVersion(path string) string {
+	ctx, cancel := utils.ContextWithTimeout(5 * time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path, "--version")
+	configureBackgroundProcess(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// mysqlOptionFileValue 按 MySQL 选项文件规则为值加引号并转义。
+func mysqlOptionFileValue(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// writeMySQLDefaultsFile 写入仅当前用户可读的临时选项文件保存密码与 SSL 选项，避免出现在进程参数中。
+func writeMySQLDefaultsFile(password string, options ...string) (string, error) {
+	content := "[client]\npassword=" + mysqlOptionFileValue(password) + "\n"
+	for _, option := range options {
+		content += option + "\n"
+	}
+	return writePrivateTempFile("gonavi-mysqldump-*.cnf", content)
+}
+
+// writePGPassFile 写入仅当前用户可读的临时 .pgpass 文件，通过 PGPASSFILE 交给 pg_dump。
+// PGPASSWORD 会出现在子进程环境中（/proc/<pid>/environ 可读），PostgreSQL 文档不建议使用。
+func writePGPassFile(config connection.ConnectionConfig, host string, dbName string) (string, error) {
+	port := "*"
+	if config.Port > 0 {
+		port = strconv.Itoa(config.Port)
+	}
+	fields := []string{host, port, dbName, config.User, config.Password}
+	for i, field := range fields {
+		if i == 1 {
+			continue
+		}
+		fields[i] = strings.NewReplacer(`\`, `\\`, ":", `\:`).Replace(field)
+	}
+	return writePrivateTempFile("gonavi-pgpass-*.conf", strings.Join(fields, ":")+"\n")
+}
+
+// writePrivateTempFile 创建权限为 0600 的临时文件并写入内容，返回路径；调用方负责删除。
+func writePrivateTempFile(pattern string, content string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	if err := f.Chmod(0o600); err != nil && goruntime.GOOS != "windows" {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// dumpToolSSLServerName 返回证书应匹配的主机名与连接主机不同时的主机名（SSH 隧道或显式配置了 SSLServerName），否则为空。
+func dumpToolSSLServerName(config connection.ConnectionConfig, host string) string {
+	name := strings.TrimSpace(config.SSLServerName)
+	if name == "" || strings.EqualFold(name, host) {
+		return ""
+	}
+	return name
+}
+
+// mysqlDumpSSLOptions 返回写入选项文件的 SSL 选项。mysqldump 与 mariadb-dump 的 SSL 选项不同，
+// 均加 loose- 前缀，客户端不认识的选项会被忽略。证书应匹配的主机名与连接主机不同时（如经 SSH 隧道），
+// 两者都无法指定校验用的主机名，verify-full 退为只校验证书链。
+func mysqlDumpSSLOptions(config connection.ConnectionConfig, host string) ([]string, error) {
+	mode, err := db.EffectiveSSLMode(config)
+	if err != nil || strings.TrimSpace(config.SSLMode) == "" {
+		return nil, err
+	}
+	if mode == "disable" {
+		return []string{"loose-ssl-mode=DISABLED", "loose-skip-ssl"}, nil
+	}
+	if mode == "verify-full" && dumpToolSSLServerName(config, host) != "" {
+		mode = "verify-ca"
+	}
+	mysqlModes := map[string]string{"require": "REQUIRED", "verify-ca": "VERIFY_CA", "verify-full": "VERIFY_IDENTITY"}
+	options := []string{"loose-ssl-mode=" + mysqlModes[mode], "loose-ssl"}
+	if mode == "verify-full" {
+		options = append(options, "loose-ssl-verify-server-cert")
+	}
+	for _, item := range []struct{ name, path string }{{"ssl-ca", config.SSLCA}, {"ssl-cert", config.SSLCert}, {"ssl-key", config.SSLKey}} {
+		if path := strings.TrimSpace(item.path); path != "" {
+			options = append(options, item.name+"="+mysqlOptionFileValue(path))
+		}
+	}
+	return options, nil
+}
+
+// pgDumpSSLEnv 返回 libpq 的 SSL 环境变量。verify 模式未配置 CA 时使用系统根证书（PGSSLROOTCERT=system，libpq 16 起支持）。
+func pgDumpSSLEnv(config connection.ConnectionConfig) ([]string, error) {
+	mode, err := db.EffectiveSSLMode(config)
+	if err != nil || strings.TrimSpace(config.SSLMode) == "" {
+		return nil, err
+	}
+	env := []string{"PGSSLMODE=" + mode}
+	if mode == "disable" {
+		return env, nil
+	}
+	caPath := strings.TrimSpace(config.SSLCA)
+	if caPath == "" && strings.HasPrefix(mode, "verify-") {
+		caPath = "system"
+	}
+	if caPath != "" {
+		env = append(env, "PGSSLROOTCERT="+caPath)
+	}
+	if certPath := strings.TrimSpace(config.SSLCert); certPath != "" {
+		env = append(env, "PGSSLCERT="+certPath)
+	}
+	if keyPath := strings.TrimSpace(config.SSLKey); keyPath != "" {
+		env = append(env, "PGSSLKEY="+keyPath)
+	}
+	return env, nil
+}
+
+// buildDumpToolArgs 按连接配置生成参数与附加环境变量；密码与 SSL 设置不会出现在参数中。
+// credentialFile 对 MySQL 为选项文件（必须作为第一个参数），对 PostgreSQL 为 PGPASSFILE。
+func buildDumpToolArgs(family string, config connection.ConnectionConfig, dbName string, opts DumpToolOptions, credentialFile string) ([]string, []string) {
+	host := strings.TrimSpace(config.Host)
+	if host == "" {
+		host = "localhost"
+	}
+	var args, env []string
+	if family == "mysql" {
+		if credentialFile != "" {
+			args = append(args, "--defaults-extra-file="+credentialFile)
+		}
+		charset := strings.TrimSpace(config.Charset)
+		if charset == "" {
+			charset = "utf8mb4"
+		}
+		args = append(args,
+			"--host="+host,
+			"--user="+config.User,
+			"--default-character-set="+charset,
+			"--single-transaction",
+			"--routines",
+			"--triggers",
+			"--hex-blob",
+			"--verbose",
+		)
+		if config.Port > 0 {
+			args = append(args, "--port="+strconv.Itoa(config.Port), "--protocol=TCP")
+		}
+		if opts.SchemaOnly {
+			args = append(args, "--no-data")
+		}
+		if opts.DataOnly {
+			args = append(args, "--no-create-info", "--skip-routines", "--skip-triggers")
+		}
+		args = append(args, "--", dbName)
+		for _, table := range opts.Tables {
+			if t := strings.TrimSpace(table); t != "" {
+				args = append(args, t)
+			}
+		}
+		return args, env
+	}
+
+	if name := dumpToolSSLServerName(config, host); name != "" && net.ParseIP(host) != nil {
+		// libpq 连接 hostaddr，按 host 校验证书主机名
+		env = append(env, "PGHOSTADDR="+host)
+		host = name
+	}
+	args = append(args,
+		"--host="+host,
+		"--username="+config.User,
+		"--dbname="+dbName,
+		"--no-password",
+		"--verbose",
+	)
+	if config.Port > 0 {
+		args = append(args, "--port="+strconv.Itoa(config.Port))
+	}
+	if strings.EqualFold(strings.TrimSpace(opts.Format), "custom") {
+		args = append(args, "--format=custom")
+	} else {
+		args = append(args, "--format=plain")
+	}
+	if opts.SchemaOnly {
+		args = append(args, "--schema-only")
+	}
+	if opts.DataOnly {
+		args = append(args, "--data-only")
+	}
+	for _, table := range opts.Tables {
+		if t := strings.TrimSpace(table); t != "" {
+			args = append(args, "--table="+t)
+		}
+	}
+	if credentialFile != "" {
+		env = append(env, "PGPASSFILE="+credentialFile)
+	}
+	sslEnv, _ := pgDumpSSLEnv(config)
+	env = append(env, sslEnv...)
+	if config.Timeout > 0 {
+		env = append(env, "PGCONNECT_TIMEOUT="+strconv.Itoa(config.Timeout))
+	}
+	return args, env
+}
+
+// DetectDumpTool 查找当前数据源可用的 mysqldump / pg_dump 及其版本。
+func (a *App) DetectDumpTool(dbType string, toolPath string) connection.QueryResult {
+	family := dumpToolFamily(dbType)
+	if family == "" {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源(%s)暂不支持外部导出工具", dbType)}
+	}
+	path, err := locateDumpTool(family, toolPath)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: DumpToolInfo{Path: path, Version: dumpToolVersion(path)}}
+}
+
+// DumpDatabaseWithTool 调用 mysqldump / pg_dump 生成与厂商工具一致的导出文件。
+// 启用 SSH 时通过本地端口转发连接；stderr 的 --verbose 输出作为进度事件 dumptool:progress 发送，
+// 指定 JobID 时可通过 CancelQuery 终止进程。
+func (a *App) DumpDatabaseWithTool(config connection.ConnectionConfig, dbName string, opts DumpToolOptions) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	family := dumpToolFamily(runConfig.Type)
+	if family == "" {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源(%s)暂不支持外部导出工具", runConfig.Type)}
+	}
+	dbName = strings.TrimSpace(dbName)
+	if dbName == "" {
+		dbName = strings.TrimSpace(runConfig.Database)
+	}
+	if dbName == "" {
+		return connection.QueryResult{Success: false, Message: "请选择要导出的数据库"}
+	}
+	if opts.SchemaOnly && opts.DataOnly {
+		return connection.QueryResult{Success: false, Message: "仅结构与仅数据不能同时选择"}
+	}
+	toolPath, err := locateDumpTool(family, opts.ToolPath)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	filename := strings.TrimSpace(opts.FilePath)
+	if filename == "" {
+		ext := ".sql"
+		if family == "postgres" && strings.EqualFold(strings.TrimSpace(opts.Format), "custom") {
+			ext = ".dump"
+		}
+		filename, err = runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+			Title:           fmt.Sprintf("Dump %s", dbName),
+			DefaultFilename: fmt.Sprintf("%s_%s%s", snapshotNameSanitizer.ReplaceAllString(dbName, "_"), time.Now().Format("20060102_150405"), ext),
+		})
+		if err != nil || filename == "" {
+			return connection.QueryResult{Success: false, Message: "Cancelled"}
+		}
+	}
+
+	endpoint, err := resolveFederatedEndpoint(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if runConfig.UseSSH && strings.TrimSpace(endpoint.SSLServerName) == "" {
+		// 经 SSH 隧道时连接的是本地转发端口，证书仍按原主机名校验
+		endpoint.SSLServerName = runConfig.Host
+	}
+
+	ctx := context.Background()
+	var tracked *runningQuery
+	if jobID := strings.TrimSpace(opts.JobID); jobID != "" {
+		ctx, tracked, err = a.registerQuery(ctx, jobID, runConfig)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		defer a.unregisterQuery(tracked)
+	}
+
+	result, err := a.runDumpTool(ctx, toolPath, family, endpoint, dbName, filename, opts)
+	if err != nil {
+		if ctx.Err() != nil {
+			logger.Infof("外部工具导出已取消：%s 工具=%s", formatConnSummary(runConfig), toolPath)
+			return connection.QueryResult{Success: false, Message: "已取消导出"}
+		}
+		logger.Error(err, "DumpDatabaseWithTool 导出失败：%s 工具=%s", formatConnSummary(runConfig), toolPath)
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	logger.Infof("外部工具导出完成：%s 工具=%s 文件=%s 大小=%d", formatConnSummary(runConfig), toolPath, filename, result.Bytes)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("导出完成：%s", filename), Data: result}
+}
+
+// runDumpTool 将工具输出写入临时文件，成功后改名；失败时附带 stderr 末尾若干行。
+func (a *App) runDumpTool(ctx context.Context, toolPath string, family string, config connection.ConnectionConfig, dbName string, filename string, opts DumpToolOptions) (DumpToolResult, error) {
+	started := time.Now()
+	result := DumpToolResult{FilePath: filename, Tool: toolPath}
+
+	host := strings.TrimSpace(config.Host)
+	if host == "" {
+		host = "localhost"
+	}
+	var credentialFile string
+	var err error
+	if family == "mysql" {
+		sslOptions, sslErr := mysqlDumpSSLOptions(config, host)
+		if sslErr != nil {
+			return result, sslErr
+		}
+		credentialFile, err = writeMySQLDefaultsFile(config.Password, sslOptions...)
+	} else {
+		if _, sslErr := pgDumpSSLEnv(config); sslErr != nil {
+			return result, sslErr
+		}
+		pgHost := host
+		if name := dumpToolSSLServerName(config, host); name != "" && net.ParseIP(host) != nil {
+			pgHost = name
+		}
+		credentialFile, err = writePGPassFile(config, pgHost, dbName)
+	}
+	if err != nil {
+		return result, fmt.Errorf("写入临时凭据文件失败：%w", err)
+	}
+	defer os.Remove(credentialFile)
+	args, env := buildDumpToolArgs(family, config, dbName, opts, credentialFile)
+
+	tmpPath := filename + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return result, err
+	}
+	counter := &dumpOutputWriter{f: f}
+
+	cmd := exec.CommandContext(ctx, toolPath, args...)
+	configureBackgroundProcess(cmd)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = counter
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return result, err
+	}
+	if err := cmd.Start(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return result, fmt.Errorf("启动 %s 失败：%w", filepath.Base(toolPath), err)
+	}
+
+	var tail []string
+	scanner := bufio.NewScanner(stderr)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		tail = append(tail, line)
+		if len(tail) > dumpToolStderrKeep {
+			tail = tail[1:]
+		}
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, dumpToolProgressEvent, map[string]interface{}{
+				"jobId":   opts.JobID,
+				"bytes":   counter.n.Load(),
+				"message": line,
+			})
+		}
+	}
+	err = cmd.Wait()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	result.Bytes = counter.n.Load()
+	result.DurationMs = time.Since(started).Milliseconds()
+	if err != nil {
+		os.Remove(tmpPath)
+		if len(tail) > 0 {
+			return result, fmt.Errorf("%s 执行失败：%v\n%s", filepath.Base(toolPath), err, strings.Join(dumpToolErrorLines(tail), "\n"))
+		}
+		return result, fmt.Errorf("%s 执行失败：%w", filepath.Base(toolPath), err)
+	}
+	if err := os.Rename(tmpPath, filename); err != nil {
+		os.Remove(tmpPath)
+		return result, err
+	}
+	return result, nil
+}
+
+// dumpOutputWriter 统计工具输出字节数；由 exec 的复制协程写入，进度读取需原子操作。
+type dumpOutputWriter struct {
+	f *os.File
+	n atomic.Int64
+}
+
+func (w *dumpOutputWriter) Write(p []byte) (int, error) {
+	n, err := w.f.Write(p)
+	w.n.Add(int64(n))
+	return n, err
+}
+
+// dumpToolErrorLines 优先返回含 error/错误 的行，没有时返回全部保留行。
+func dumpToolErrorLines(lines []string) []string {
+	var errs []string
+	for _, line := range lines {
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "error") || strings.Contains(lower, "fatal") || strings.Contains(line, "错误") {
+			errs = append(errs, line)
+		}
+	}
+	if len(errs) == 0 {
+		return lines
+	}
+	return errs
+}
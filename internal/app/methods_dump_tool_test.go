@@ -0,0 +1,93 @@
+package app
+
+import (
+	"os"
+	goruntime "runtime"
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBuildDumpToolArgsKeepsPasswordOutOfArgv(t *testing.T) {
+	config := connection.ConnectionConfig{Host: "db.local", Port: 3307, User: "root", Password: "s3cr\"et"}
+	for _, family := range []string{"mysql", "postgres"} {
+		args, env := buildDumpToolArgs(family, config, "shop", DumpToolOptions{Tables: []string{"orders"}, SchemaOnly: true}, "/tmp/x.cnf")
+		joined := strings.Join(args, " ")
+		if strings.Contains(joined, "s3cr") {
+			t.Fatalf("%s: password leaked into argv: %s", family, joined)
+		}
+		if family == "mysql" {
+			if args[0] != "--defaults-extra-file=/tmp/x.cnf" || !strings.HasSuffix(joined, "-- shop orders") || !strings.Contains(joined, "--no-data") {
+				t.Fatalf("unexpected mysqldump args: %q", args)
+			}
+			continue
+		}
+		if !strings.Contains(joined, "--dbname=shop") || !strings.Contains(joined, "--table=orders") || !strings.Contains(joined, "--schema-only") {
+			t.Fatalf("unexpected pg_dump args: %q", args)
+		}
+		if len(env) == 0 || env[0] != "PGPASSFILE=/tmp/x.cnf" || strings.Contains(strings.Join(env, " "), "s3cr") {
+			t.Fatalf("unexpected pg_dump env: %q", env)
+		}
+	}
+}
+
+func TestWriteMySQLDefaultsFileEscapesPassword(t *testing.T) {
+	path, err := writeMySQLDefaultsFile(`a"b\c`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "[client]\npassword=\"a\\\"b\\\\c\"\n" {
+		t.Fatalf("unexpected defaults file: %q", content)
+	}
+}
+
+func TestDumpToolSSLSettings(t *testing.T) {
+	config := connection.ConnectionConfig{Host: "127.0.0.1", Port: 5432, User: "u", SSLMode: "verify-full", SSLCA: "/certs/ca.pem", SSLServerName: "db.example.com"}
+	args, env := buildDumpToolArgs("postgres", config, "shop", DumpToolOptions{}, "/tmp/pgpass")
+	joined := strings.Join(env, " ")
+	if !strings.Contains(strings.Join(args, " "), "--host=db.example.com") || !strings.Contains(joined, "PGHOSTADDR=127.0.0.1") ||
+		!strings.Contains(joined, "PGSSLMODE=verify-full") || !strings.Contains(joined, "PGSSLROOTCERT=/certs/ca.pem") {
+		t.Fatalf("unexpected pg_dump SSL settings: args=%q env=%q", args, env)
+	}
+
+	options, err := mysqlDumpSSLOptions(connection.ConnectionConfig{SSLMode: "verify-full", SSLCA: "/certs/ca.pem"}, "db.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"loose-ssl-mode=VERIFY_IDENTITY", "loose-ssl", "loose-ssl-verify-server-cert", `ssl-ca="/certs/ca.pem"`}
+	if strings.Join(options, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected mysqldump SSL options: %q", options)
+	}
+	// 经隧道无法按原主机名校验，退为只校验证书链
+	options, _ = mysqlDumpSSLOptions(connection.ConnectionConfig{SSLMode: "verify-full", SSLServerName: "db.example.com"}, "127.0.0.1")
+	if options[0] != "loose-ssl-mode=VERIFY_CA" {
+		t.Fatalf("unexpected tunnelled mysqldump SSL options: %q", options)
+	}
+	if options, _ := mysqlDumpSSLOptions(connection.ConnectionConfig{}, "h"); options != nil {
+		t.Fatalf("SSL options without SSL settings: %q", options)
+	}
+}
+
+func TestWritePGPassFile(t *testing.T) {
+	path, err := writePGPassFile(connection.ConnectionConfig{Port: 5432, User: "u", Password: `p:a\ss`}, "db.local", "shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "db.local:5432:shop:u:p\\:a\\\\ss\n" {
+		t.Fatalf("unexpected pgpass content: %q", content)
+	}
+	if info, err := os.Stat(path); err == nil && goruntime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Fatalf("pgpass file should be private, got %v", info.Mode().Perm())
+	}
+}
@@ -0,0 +1,698 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	erCardinalityManyToOne = "many-to-one"
+	erCardinalityOneToOne  = "one-to-one" // 外键列本身构成主键或唯一键
+)
+
+// ERColumn ER 图中的列。
+type ERColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Nullable   bool   `json:"nullable"`
+	PrimaryKey bool   `json:"primaryKey"`
+	ForeignKey bool   `json:"foreignKey"`
+}
+
+// ERTable ER 图中的表。Key 为 schema.name（无 schema 时为 name），用于 ERRelation 引用。
+type ERTable struct {
+	Key     string     `json:"key"`
+	Schema  string     `json:"schema,omitempty"`
+	Name    string     `json:"name"`
+	Columns []ERColumn `json:"columns"`
+}
+
+// ERRelation 外键关系：From 表的 FromColumns 引用 To 表的 ToColumns。
+type ERRelation struct {
+	Name        string   `json:"name"`
+	From        string   `json:"from"`
+	FromColumns []string `json:"fromColumns"`
+	To          string   `json:"to"`
+	ToColumns   []string `json:"toColumns"`
+	Cardinality string   `json:"cardinality"` // many-to-one / one-to-one
+	Optional    bool     `json:"optional"`    // 外键列可为空，子表行可以不关联父表
+}
+
+// ERModel 绘制 ER 图所需的表、列与外键关系。
+type ERModel struct {
+	Database    string       `json:"database"`
+	Tables      []ERTable    `json:"tables"`
+	Relations   []ERRelation `json:"relations"`
+	LoadedAt    string       `json:"loadedAt"`
+	Cached      bool         `json:"cached"`              // 直接返回了缓存，未访问数据库
+	Refreshed   []string     `json:"refreshed,omitempty"` // 增量刷新时重新读取的表
+	Incremental bool         `json:"incremental"`
+	Warnings    []string     `json:"warnings,omitempty"`
+}
+
+// erTableState 单表的结构快照，增量刷新时按表替换。
+type erTableState struct {
+	table   ERTable
+	uniques [][]string // 主键与唯一键的列集合
+	fks     []ERRelation
+	version string
+}
+
+type erModelCacheEntry struct {
+	tables   map[string]*erTableState
+	loadedAt time.Time
+	dirty    bool // 执行过 DDL，下次读取时按版本增量刷新
+}
+
+var (
+	erModelCacheMu sync.Mutex
+	erModelCache   = make(map[string]*erModelCacheEntry)
+)
+
+// erModelIncrementalRatio 变化的表超过该比例时直接全量重读。
+const erModelIncrementalRatio = 0.5
+
+// GetERModel 读取库内全部表的列与外键，返回可直接绘制 ER 图的图模型，并附带一对一/多对一基数提示。
+// 结果按连接缓存；refresh 为 true 或期间执行过 DDL 时先比对各表的结构版本，只重读发生变化的表，
+// 适合有数百张表的库反复打开 ER 图。
+func (a *App) GetERModel(config connection.ConnectionConfig, dbName string, refresh bool) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	key := connectionStoreKey(runConfig, dbName)
+	erModelCacheMu.Lock()
+	entry := erModelCache[key]
+	if entry != nil && !refresh && !entry.dirty {
+		model := assembleERModel(dbName, entry.tables, entry.loadedAt)
+		erModelCacheMu.Unlock()
+		model.Cached = true
+		return connection.QueryResult{Success: true, Data: model}
+	}
+	var cached map[string]*erTableState
+	if entry != nil {
+		cached = make(map[string]*erTableState, len(entry.tables))
+		for k, state := range entry.tables {
+			cached[k] = state
+		}
+	}
+	erModelCacheMu.Unlock()
+	return a.loadERModel(runConfig, dbName, cached, nil)
+}
+
+// RefreshERModelTables 只重读指定的表（如在设计器中修改结构之后），合并进缓存后返回完整模型。
+func (a *App) RefreshERModelTables(config connection.ConnectionConfig, dbName string, tables []string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	key := connectionStoreKey(runConfig, dbName)
+	erModelCacheMu.Lock()
+	entry := erModelCache[key]
+	var cached map[string]*erTableState
+	if entry != nil {
+		cached = make(map[string]*erTableState, len(entry.tables))
+		for k, state := range entry.tables {
+			cached[k] = state
+		}
+	}
+	erModelCacheMu.Unlock()
+	if cached == nil {
+		return a.loadERModel(runConfig, dbName, nil, nil)
+	}
+	dbType := resolveDDLDBType(runConfig)
+	refs := make([]erTableRef, 0, len(tables))
+	for _, table := range tables {
+		schema, name := normalizeSchemaAndTableByType(dbType, dbName, table)
+		if name != "" {
+			refs = append(refs, erTableRef{schema: erRefSchema(dbType, schema), name: name})
+		}
+	}
+	return a.loadERModel(runConfig, dbName, cached, refs)
+}
+
+// markERModelDirty 执行 DDL 后标记缓存，下次读取 ER 模型时增量刷新。
+func markERModelDirty(config connection.ConnectionConfig, dbName string) {
+	erModelCacheMu.Lock()
+	defer erModelCacheMu.Unlock()
+	if entry, ok := erModelCache[connectionStoreKey(config, dbName)]; ok {
+		entry.dirty = true
+	}
+}
+
+type erTableRef struct {
+	schema string
+	name   string
+}
+
+func (r erTableRef) key() string {
+	return dependencyKey(r.schema, r.name)
+}
+
+// erRefSchema SQLite 的表没有 schema 层级；其余数据源与目录查询返回的 schema 保持一致。
+func erRefSchema(dbType string, schema string) string {
+	switch dbType {
+	case "sqlite":
+		return ""
+	case "oracle", "dameng":
+		return strings.ToUpper(schema)
+	}
+	return schema
+}
+
+// loadERModel cached 为空时全量读取；否则 refs 指定要重读的表，refs 为空时按结构版本找出变化的表。
+func (a *App) loadERModel(runConfig connection.ConnectionConfig, dbName string, cached map[string]*erTableState, refs []erTableRef) connection.QueryResult {
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+	var states map[string]*erTableState
+	var refreshed, warnings []string
+	incremental := false
+	warning, err := a.runWithReconnect(runConfig, dbInst, "GetERModel", func(inst db.Database) error {
+		var loadErr error
+		states, refreshed, incremental, warnings, loadErr = refreshERTables(inst, dbType, dbName, cached, refs)
+		return loadErr
+	})
+	if err != nil {
+		logger.Error(err, "读取 ER 模型失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	loadedAt := time.Now()
+	erModelCacheMu.Lock()
+	erModelCache[connectionStoreKey(runConfig, dbName)] = &erModelCacheEntry{tables: states, loadedAt: loadedAt}
+	erModelCacheMu.Unlock()
+
+	model := assembleERModel(dbName, states, loadedAt)
+	model.Refreshed = refreshed
+	model.Incremental = incremental
+	model.Warnings = warnings
+	logger.Infof("ER 模型读取完成：%s 表数=%d 关系数=%d 增量=%v 重读=%d", formatConnSummary(runConfig), len(model.Tables), len(model.Relations), incremental, len(refreshed))
+	return connection.QueryResult{Success: true, Message: warning, Data: model}
+}
+
+func refreshERTables(inst db.Database, dbType string, dbName string, cached map[string]*erTableState, refs []erTableRef) (states map[string]*erTableState, refreshed []string, incremental bool, warnings []string, err error) {
+	queries := erCatalogSQL(dbType, dbName)
+	if cached != nil && len(refs) == 0 && queries.versions != "" {
+		versionRows, versionErr := erRows(inst, queries.versions)
+		if versionErr != nil {
+			warnings = append(warnings, "读取表结构版本失败，已全量重读："+normalizeErrorMessage(versionErr))
+			cached = nil
+		} else {
+			versions := make(map[string]string, len(versionRows))
+			for _, row := range versionRows {
+				ref := erTableRef{schema: row[0], name: row[1]}
+				versions[ref.key()] = row[2]
+				if state, ok := cached[ref.key()]; !ok || state.version != row[2] {
+					refs = append(refs, ref)
+				}
+			}
+			states = make(map[string]*erTableState, len(versions))
+			for key, state := range cached {
+				if _, ok := versions[key]; ok {
+					states[key] = state
+				}
+			}
+			if len(refs) == 0 {
+				return states, nil, true, warnings, nil
+			}
+			if float64(len(refs)) <= float64(len(versions))*erModelIncrementalRatio {
+				cached = states
+			} else {
+				cached, refs = nil, nil
+			}
+		}
+	} else if cached != nil && len(refs) == 0 {
+		cached = nil
+	}
+
+	var loaded map[string]*erTableState
+	if queries.columns == "" {
+		loaded, warnings = loadERTablesGeneric(inst, dbType, dbName, refs, warnings)
+	} else {
+		loaded, err = loadERCatalog(inst, dbType, queries, refs)
+		if err != nil {
+			return nil, nil, false, warnings, err
+		}
+	}
+	if cached == nil {
+		return loaded, nil, false, warnings, nil
+	}
+	states = make(map[string]*erTableState, len(cached)+len(loaded))
+	for key, state := range cached {
+		states[key] = state
+	}
+	for _, ref := range refs {
+		// 已删除的表在重读结果中不存在
+		delete(states, ref.key())
+	}
+	for key, state := range loaded {
+		states[key] = state
+		refreshed = append(refreshed, key)
+	}
+	sort.Strings(refreshed)
+	return states, refreshed, true, warnings, nil
+}
+
+// erCatalogQueries 各目录查询的列（别名各不相同，驱动按列名返回行）：
+//   - columns：schema_name, table_name, column_name, data_type, is_nullable(YES/NO), column_position
+//   - keys：schema_name, table_name, key_name, key_kind(P/U), column_name
+//   - foreignKeys：schema_name, table_name, fk_name, column_name, ref_schema, ref_table, ref_column, column_position
+//   - versions：schema_name, table_name, struct_version（结构变化时改变的指纹）
+//
+// 查询均以 WHERE 条件结尾，表过滤条件直接追加在末尾；schemaExpr/tableExpr 为过滤所用的列表达式。
+type erCatalogQueries struct {
+	columns, keys, foreignKeys, versions string
+	schemaExpr, tableExpr                [4]string
+}
+
+func erCatalogSQL(dbType string, dbName string) erCatalogQueries {
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		schema := dialect.QuoteLiteral(dbType, dbName)
+		return erCatalogQueries{
+			columns: fmt.Sprintf("SELECT c.TABLE_SCHEMA AS schema_name, c.TABLE_NAME AS table_name, c.COLUMN_NAME AS column_name, c.COLUMN_TYPE AS data_type, "+
+				"c.IS_NULLABLE AS is_nullable, c.ORDINAL_POSITION AS column_position "+
+				"FROM information_schema.COLUMNS c JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME "+
+				"WHERE c.TABLE_SCHEMA = %s AND t.TABLE_TYPE = 'BASE TABLE'", schema),
+			keys: fmt.Sprintf("SELECT tc.TABLE_SCHEMA AS schema_name, tc.TABLE_NAME AS table_name, tc.CONSTRAINT_NAME AS key_name, "+
+				"CASE WHEN tc.CONSTRAINT_TYPE = 'PRIMARY KEY' THEN 'P' ELSE 'U' END AS key_kind, k.COLUMN_NAME AS column_name "+
+				"FROM information_schema.TABLE_CONSTRAINTS tc JOIN information_schema.KEY_COLUMN_USAGE k "+
+				"ON k.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND k.CONSTRAINT_NAME = tc.CONSTRAINT_NAME AND k.TABLE_NAME = tc.TABLE_NAME "+
+				"WHERE tc.TABLE_SCHEMA = %s AND tc.CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE')", schema),
+			foreignKeys: fmt.Sprintf("SELECT TABLE_SCHEMA AS schema_name, TABLE_NAME AS table_name, CONSTRAINT_NAME AS fk_name, COLUMN_NAME AS column_name, "+
+				"REFERENCED_TABLE_SCHEMA AS ref_schema, REFERENCED_TABLE_NAME AS ref_table, REFERENCED_COLUMN_NAME AS ref_column, ORDINAL_POSITION AS column_position "+
+				"FROM information_schema.KEY_COLUMN_USAGE WHERE TABLE_SCHEMA = %s AND REFERENCED_TABLE_NAME IS NOT NULL", schema),
+			// CREATE_TIME 不随所有 ALTER 变化，再加上列与键的校验和
+			versions: fmt.Sprintf("SELECT t.TABLE_SCHEMA AS schema_name, t.TABLE_NAME AS table_name, CONCAT_WS('/', t.CREATE_TIME, "+
+				"(SELECT CONCAT(COUNT(*), ':', SUM(CRC32(CONCAT_WS(' ', c.COLUMN_NAME, c.COLUMN_TYPE, c.IS_NULLABLE, c.ORDINAL_POSITION)))) "+
+				"FROM information_schema.COLUMNS c WHERE c.TABLE_SCHEMA = t.TABLE_SCHEMA AND c.TABLE_NAME = t.TABLE_NAME), "+
+				"(SELECT CONCAT(COUNT(*), ':', COALESCE(SUM(CRC32(CONCAT_WS(' ', k.CONSTRAINT_NAME, k.COLUMN_NAME, k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME))), 0)) "+
+				"FROM information_schema.KEY_COLUMN_USAGE k WHERE k.TABLE_SCHEMA = t.TABLE_SCHEMA AND k.TABLE_NAME = t.TABLE_NAME)) AS struct_version "+
+				"FROM information_schema.TABLES t WHERE t.TABLE_SCHEMA = %s AND t.TABLE_TYPE = 'BASE TABLE'", schema),
+			schemaExpr: [4]string{"c.TABLE_SCHEMA", "tc.TABLE_SCHEMA", "TABLE_SCHEMA", "t.TABLE_SCHEMA"},
+			tableExpr:  [4]string{"c.TABLE_NAME", "tc.TABLE_NAME", "TABLE_NAME", "t.TABLE_NAME"},
+		}
+	case "postgres", "kingbase", "highgo", "vastbase":
+		// 分区与继承子表不单独成为 ER 节点
+		tableFilter := "c.relkind IN ('r', 'p') AND NOT EXISTS (SELECT 1 FROM pg_inherits i WHERE i.inhrelid = c.oid) AND " + postgresDependencySchemaFilter
+		return erCatalogQueries{
+			columns: "SELECT n.nspname AS schema_name, c.relname AS table_name, a.attname AS column_name, format_type(a.atttypid, a.atttypmod) AS data_type, " +
+				"CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END AS is_nullable, a.attnum AS column_position " +
+				"FROM pg_attribute a JOIN pg_class c ON c.oid = a.attrelid JOIN pg_namespace n ON n.oid = c.relnamespace " +
+				"WHERE a.attnum > 0 AND NOT a.attisdropped AND " + tableFilter,
+			// 唯一索引也算唯一键（不含表达式与部分索引）
+			keys: "SELECT n.nspname AS schema_name, c.relname AS table_name, ic.relname AS key_name, " +
+				"CASE WHEN x.indisprimary THEN 'P' ELSE 'U' END AS key_kind, a.attname AS column_name " +
+				"FROM pg_index x JOIN pg_class ic ON ic.oid = x.indexrelid JOIN pg_class c ON c.oid = x.indrelid JOIN pg_namespace n ON n.oid = c.relnamespace " +
+				"JOIN pg_attribute a ON a.attrelid = x.indrelid AND a.attnum = ANY(x.indkey) " +
+				"WHERE x.indisunique AND x.indexprs IS NULL AND x.indpred IS NULL AND " + tableFilter,
+			foreignKeys: "SELECT n.nspname AS schema_name, c.relname AS table_name, k.conname AS fk_name, a.attname AS column_name, " +
+				"rn.nspname AS ref_schema, r.relname AS ref_table, ra.attname AS ref_column, u.ord AS column_position " +
+				"FROM pg_constraint k JOIN pg_class c ON c.oid = k.conrelid JOIN pg_namespace n ON n.oid = c.relnamespace " +
+				"JOIN pg_class r ON r.oid = k.confrelid JOIN pg_namespace rn ON rn.oid = r.relnamespace " +
+				"CROSS JOIN LATERAL unnest(k.conkey, k.confkey) WITH ORDINALITY AS u(attnum, refnum, ord) " +
+				"JOIN pg_attribute a ON a.attrelid = k.conrelid AND a.attnum = u.attnum JOIN pg_attribute ra ON ra.attrelid = k.confrelid AND ra.attnum = u.refnum " +
+				"WHERE k.contype = 'f' AND " + tableFilter,
+			// 修改列的可空性等只更新 pg_attribute，指纹需覆盖列与约束定义
+			versions: "SELECT n.nspname AS schema_name, c.relname AS table_name, md5(COALESCE((SELECT string_agg(a.attname || ':' || format_type(a.atttypid, a.atttypmod) || ':' || a.attnotnull::text, ',' ORDER BY a.attnum) " +
+				"FROM pg_attribute a WHERE a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped), '') || '|' || " +
+				"COALESCE((SELECT string_agg(k.conname || ':' || pg_get_constraintdef(k.oid), ',' ORDER BY k.conname) FROM pg_constraint k WHERE k.conrelid = c.oid AND k.contype IN ('p', 'u', 'f')), '') || '|' || " +
+				"COALESCE((SELECT string_agg(x.indexrelid::text, ',' ORDER BY x.indexrelid) FROM pg_index x WHERE x.indrelid = c.oid AND x.indisunique), '')) AS struct_version " +
+				"FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE " + tableFilter,
+			schemaExpr: [4]string{"n.nspname", "n.nspname", "n.nspname", "n.nspname"},
+			tableExpr:  [4]string{"c.relname", "c.relname", "c.relname", "c.relname"},
+		}
+	case "sqlserver":
+		return erCatalogQueries{
+			columns: "SELECT s.name AS schema_name, t.name AS table_name, c.name AS column_name, TYPE_NAME(c.user_type_id) AS data_type, " +
+				"CASE WHEN c.is_nullable = 1 THEN 'YES' ELSE 'NO' END AS is_nullable, c.column_id AS column_position " +
+				"FROM sys.columns c JOIN sys.tables t ON t.object_id = c.object_id JOIN sys.schemas s ON s.schema_id = t.schema_id WHERE t.is_ms_shipped = 0",
+			keys: "SELECT s.name AS schema_name, t.name AS table_name, i.name AS key_name, CASE WHEN i.is_primary_key = 1 THEN 'P' ELSE 'U' END AS key_kind, c.name AS column_name " +
+				"FROM sys.indexes i JOIN sys.tables t ON t.object_id = i.object_id JOIN sys.schemas s ON s.schema_id = t.schema_id " +
+				"JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id AND ic.is_included_column = 0 " +
+				"JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id WHERE i.is_unique = 1 AND i.has_filter = 0",
+			foreignKeys: "SELECT s.name AS schema_name, t.name AS table_name, f.name AS fk_name, pc.name AS column_name, " +
+				"rs.name AS ref_schema, r.name AS ref_table, rc.name AS ref_column, fkc.constraint_column_id AS column_position " +
+				"FROM sys.foreign_key_columns fkc JOIN sys.foreign_keys f ON f.object_id = fkc.constraint_object_id " +
+				"JOIN sys.tables t ON t.object_id = fkc.parent_object_id JOIN sys.schemas s ON s.schema_id = t.schema_id " +
+				"JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id " +
+				"JOIN sys.tables r ON r.object_id = fkc.referenced_object_id JOIN sys.schemas rs ON rs.schema_id = r.schema_id " +
+				"JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id WHERE 1 = 1",
+			// modify_date 随 ALTER TABLE 以及索引的创建、修改变化
+			versions: "SELECT s.name AS schema_name, t.name AS table_name, CONVERT(VARCHAR(33), t.modify_date, 126) AS struct_version " +
+				"FROM sys.tables t JOIN sys.schemas s ON s.schema_id = t.schema_id WHERE t.is_ms_shipped = 0",
+			schemaExpr: [4]string{"s.name", "s.name", "s.name", "s.name"},
+			tableExpr:  [4]string{"t.name", "t.name", "t.name", "t.name"},
+		}
+	case "oracle", "dameng":
+		owner := dialect.QuoteLiteral(dbType, strings.ToUpper(strings.TrimSpace(dbName)))
+		return erCatalogQueries{
+			columns: fmt.Sprintf("SELECT c.OWNER AS schema_name, c.TABLE_NAME AS table_name, c.COLUMN_NAME AS column_name, c.DATA_TYPE AS data_type, "+
+				"CASE c.NULLABLE WHEN 'Y' THEN 'YES' ELSE 'NO' END AS is_nullable, c.COLUMN_ID AS column_position "+
+				"FROM ALL_TAB_COLUMNS c JOIN ALL_TABLES t ON t.OWNER = c.OWNER AND t.TABLE_NAME = c.TABLE_NAME WHERE c.OWNER = %s", owner),
+			keys: fmt.Sprintf("SELECT c.OWNER AS schema_name, c.TABLE_NAME AS table_name, c.CONSTRAINT_NAME AS key_name, c.CONSTRAINT_TYPE AS key_kind, cc.COLUMN_NAME AS column_name "+
+				"FROM ALL_CONSTRAINTS c JOIN ALL_CONS_COLUMNS cc ON cc.OWNER = c.OWNER AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME AND cc.TABLE_NAME = c.TABLE_NAME "+
+				"WHERE c.OWNER = %s AND c.CONSTRAINT_TYPE IN ('P', 'U')", owner),
+			foreignKeys: fmt.Sprintf("SELECT c.OWNER AS schema_name, c.TABLE_NAME AS table_name, c.CONSTRAINT_NAME AS fk_name, cc.COLUMN_NAME AS column_name, "+
+				"r.OWNER AS ref_schema, r.TABLE_NAME AS ref_table, rc.COLUMN_NAME AS ref_column, cc.POSITION AS column_position FROM ALL_CONSTRAINTS c "+
+				"JOIN ALL_CONS_COLUMNS cc ON cc.OWNER = c.OWNER AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME "+
+				"JOIN ALL_CONSTRAINTS r ON r.OWNER = c.R_OWNER AND r.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME "+
+				"JOIN ALL_CONS_COLUMNS rc ON rc.OWNER = r.OWNER AND rc.CONSTRAINT_NAME = r.CONSTRAINT_NAME AND rc.POSITION = cc.POSITION "+
+				"WHERE c.OWNER = %s AND c.CONSTRAINT_TYPE = 'R'", owner),
+			versions: fmt.Sprintf("SELECT o.OWNER AS schema_name, o.OBJECT_NAME AS table_name, TO_CHAR(o.LAST_DDL_TIME, 'YYYY-MM-DD HH24:MI:SS') AS struct_version "+
+				"FROM ALL_OBJECTS o WHERE o.OWNER = %s AND o.OBJECT_TYPE = 'TABLE'", owner),
+			schemaExpr: [4]string{"c.OWNER", "c.OWNER", "c.OWNER", "o.OWNER"},
+			tableExpr:  [4]string{"c.TABLE_NAME", "c.TABLE_NAME", "c.TABLE_NAME", "o.OBJECT_NAME"},
+		}
+	case "sqlite":
+		const tables = "m.type = 'table' AND m.name NOT LIKE 'sqlite_%'"
+		return erCatalogQueries{
+			columns: "SELECT '' AS schema_name, m.name AS table_name, p.name AS column_name, p.type AS data_type, " +
+				"CASE WHEN p.\"notnull\" THEN 'NO' ELSE 'YES' END AS is_nullable, p.cid AS column_position " +
+				"FROM sqlite_master m JOIN pragma_table_info(m.name) p WHERE " + tables,
+			keys: "SELECT '' AS schema_name, m.name AS table_name, 'PRIMARY' AS key_name, 'P' AS key_kind, p.name AS column_name " +
+				"FROM sqlite_master m JOIN pragma_table_info(m.name) p WHERE p.pk > 0 AND " + tables +
+				" UNION ALL SELECT '', m.name, il.name, 'U', ii.name FROM sqlite_master m JOIN pragma_index_list(m.name) il JOIN pragma_index_info(il.name) ii " +
+				"WHERE il.\"unique\" AND il.origin <> 'pk' AND il.partial = 0 AND " + tables,
+			foreignKeys: "SELECT '' AS schema_name, m.name AS table_name, 'fk_' || m.name || '_' || f.id AS fk_name, f.\"from\" AS column_name, " +
+				"'' AS ref_schema, f.\"table\" AS ref_table, f.\"to\" AS ref_column, f.seq AS column_position " +
+				"FROM sqlite_master m JOIN pragma_foreign_key_list(m.name) f WHERE " + tables,
+			// 建表语句与唯一索引定义即为结构指纹
+			versions: "SELECT '' AS schema_name, m.name AS table_name, m.sql || COALESCE((SELECT group_concat(i.sql, ';') FROM sqlite_master i " +
+				"WHERE i.type = 'index' AND i.tbl_name = m.name), '') AS struct_version FROM sqlite_master m WHERE " + tables,
+			tableExpr: [4]string{"m.name", "m.name", "m.name", "m.name"},
+		}
+	}
+	return erCatalogQueries{}
+}
+
+// erTableFilterSQL 生成只读取指定表的附加条件，refs 为空时不过滤。
+func erTableFilterSQL(dbType string, schemaExpr string, tableExpr string, refs []erTableRef) string {
+	if len(refs) == 0 {
+		return ""
+	}
+	conditions := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		condition := fmt.Sprintf("%s = %s", tableExpr, dialect.QuoteLiteral(dbType, ref.name))
+		if schemaExpr != "" {
+			condition = fmt.Sprintf("(%s = %s AND %s)", schemaExpr, dialect.QuoteLiteral(dbType, ref.schema), condition)
+		}
+		conditions = append(conditions, condition)
+	}
+	return " AND (" + strings.Join(conditions, " OR ") + ")"
+}
+
+// erRows 执行目录查询，按列顺序返回文本值。
+func erRows(inst db.Database, query string) ([][]string, error) {
+	data, columns, err := inst.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]string, 0, len(data))
+	for _, row := range data {
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			values[i] = statRowText(row, col)
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+func loadERCatalog(inst db.Database, dbType string, queries erCatalogQueries, refs []erTableRef) (map[string]*erTableState, error) {
+	var results [4][][]string
+	for i, query := range []string{queries.columns, queries.keys, queries.foreignKeys, queries.versions} {
+		if query == "" {
+			continue
+		}
+		if strings.Contains(query, " UNION ALL ") {
+			// SQLite 的键查询由两段组成，过滤条件需分别追加
+			parts := strings.SplitN(query, " UNION ALL ", 2)
+			filter := erTableFilterSQL(dbType, queries.schemaExpr[i], queries.tableExpr[i], refs)
+			query = parts[0] + filter + " UNION ALL " + parts[1] + filter
+		} else {
+			query += erTableFilterSQL(dbType, queries.schemaExpr[i], queries.tableExpr[i], refs)
+		}
+		rows, err := erRows(inst, query)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = rows
+	}
+	return buildERTableStates(results[0], results[1], results[2], results[3]), nil
+}
+
+// buildERTableStates 由目录查询结果构建各表快照，行格式见 erCatalogQueries。
+func buildERTableStates(columnRows, keyRows, fkRows, versionRows [][]string) map[string]*erTableState {
+	states := make(map[string]*erTableState)
+	ordinals := make(map[string][]int)
+	for _, row := range columnRows {
+		key := dependencyKey(row[0], row[1])
+		state := states[key]
+		if state == nil {
+			state = &erTableState{table: ERTable{Key: key, Schema: row[0], Name: row[1]}}
+			states[key] = state
+		}
+		ordinal, _ := strconv.Atoi(row[5])
+		state.table.Columns = append(state.table.Columns, ERColumn{Name: row[2], Type: row[3], Nullable: !strings.EqualFold(row[4], "NO")})
+		ordinals[key] = append(ordinals[key], ordinal)
+	}
+	for key, state := range states {
+		sort.Sort(erColumnsByOrdinal{state.table.Columns, ordinals[key]})
+	}
+
+	uniques := make(map[string]map[string][]string) // 表 -> 约束 -> 列
+	var uniqueOrder []string
+	for _, row := range keyRows {
+		key := dependencyKey(row[0], row[1])
+		state := states[key]
+		if state == nil {
+			continue
+		}
+		if uniques[key] == nil {
+			uniques[key] = make(map[string][]string)
+		}
+		if _, seen := uniques[key][row[2]]; !seen {
+			uniqueOrder = append(uniqueOrder, key+"\x00"+row[2])
+		}
+		uniques[key][row[2]] = append(uniques[key][row[2]], row[4])
+		if strings.EqualFold(row[3], "P") {
+			for i := range state.table.Columns {
+				if state.table.Columns[i].Name == row[4] {
+					state.table.Columns[i].PrimaryKey = true
+				}
+			}
+		}
+	}
+	for _, item := range uniqueOrder {
+		key, constraint, _ := strings.Cut(item, "\x00")
+		states[key].uniques = append(states[key].uniques, uniques[key][constraint])
+	}
+
+	type fkColumn struct {
+		position            int
+		column, refColumn   string
+		refSchema, refTable string
+	}
+	fkColumns := make(map[string][]fkColumn)
+	var fkOrder []string
+	for _, row := range fkRows {
+		key := dependencyKey(row[0], row[1])
+		if states[key] == nil {
+			continue
+		}
+		id := key + "\x00" + row[2]
+		if _, seen := fkColumns[id]; !seen {
+			fkOrder = append(fkOrder, id)
+		}
+		position, _ := strconv.Atoi(row[7])
+		fkColumns[id] = append(fkColumns[id], fkColumn{position: position, column: row[3], refColumn: row[6], refSchema: row[4], refTable: row[5]})
+	}
+	for _, id := range fkOrder {
+		key, name, _ := strings.Cut(id, "\x00")
+		columns := fkColumns[id]
+		sort.SliceStable(columns, func(i, j int) bool { return columns[i].position < columns[j].position })
+		relation := ERRelation{Name: name, From: key, To: dependencyKey(columns[0].refSchema, columns[0].refTable)}
+		for _, col := range columns {
+			relation.FromColumns = append(relation.FromColumns, col.column)
+			if col.refColumn != "" {
+				relation.ToColumns = append(relation.ToColumns, col.refColumn)
+			}
+		}
+		states[key].fks = append(states[key].fks, relation)
+	}
+
+	for _, row := range versionRows {
+		if state := states[dependencyKey(row[0], row[1])]; state != nil {
+			state.version = row[2]
+		}
+	}
+	return states
+}
+
+type erColumnsByOrdinal struct {
+	columns  []ERColumn
+	ordinals []int
+}
+
+func (s erColumnsByOrdinal) Len() int           { return len(s.columns) }
+func (s erColumnsByOrdinal) Less(i, j int) bool { return s.ordinals[i] < s.ordinals[j] }
+func (s erColumnsByOrdinal) Swap(i, j int) {
+	s.columns[i], s.columns[j] = s.columns[j], s.columns[i]
+	s.ordinals[i], s.ordinals[j] = s.ordinals[j], s.ordinals[i]
+}
+
+// loadERTablesGeneric 没有批量目录查询的数据源逐表读取列与外键，只能识别单列主键/唯一键。
+func loadERTablesGeneric(inst db.Database, dbType string, dbName string, refs []erTableRef, warnings []string) (map[string]*erTableState, []string) {
+	if len(refs) == 0 {
+		tables, err := inst.GetTables(dbName)
+		if err != nil {
+			return map[string]*erTableState{}, append(warnings, "读取表列表失败："+normalizeErrorMessage(err))
+		}
+		for _, table := range tables {
+			schema, name := normalizeSchemaAndTableByType(dbType, dbName, table)
+			refs = append(refs, erTableRef{schema: schema, name: name})
+		}
+	}
+	states := make(map[string]*erTableState, len(refs))
+	for _, ref := range refs {
+		columns, err := inst.GetColumns(ref.schema, ref.name)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("读取表 %s 的列失败：%s", ref.key(), normalizeErrorMessage(err)))
+			continue
+		}
+		state := &erTableState{table: ERTable{Key: ref.key(), Schema: ref.schema, Name: ref.name}}
+		var primary []string
+		for _, col := range columns {
+			isPrimary := strings.EqualFold(col.Key, "PRI")
+			state.table.Columns = append(state.table.Columns, ERColumn{Name: col.Name, Type: col.Type, Nullable: !strings.EqualFold(col.Nullable, "NO"), PrimaryKey: isPrimary})
+			if isPrimary {
+				primary = append(primary, col.Name)
+			} else if strings.EqualFold(col.Key, "UNI") {
+				state.uniques = append(state.uniques, []string{col.Name})
+			}
+		}
+		if len(primary) > 0 {
+			state.uniques = append(state.uniques, primary)
+		}
+		if fks, err := inst.GetForeignKeys(ref.schema, ref.name); err == nil {
+			byName := make(map[string]*ERRelation)
+			var order []string
+			for _, fk := range fks {
+				name := fk.ConstraintName
+				if name == "" {
+					name = fk.Name
+				}
+				relation := byName[name]
+				if relation == nil {
+					refSchema, refTable := normalizeSchemaAndTableByType(dbType, ref.schema, fk.RefTableName)
+					relation = &ERRelation{Name: name, From: ref.key(), To: dependencyKey(refSchema, refTable)}
+					byName[name] = relation
+					order = append(order, name)
+				}
+				relation.FromColumns = append(relation.FromColumns, fk.ColumnName)
+				relation.ToColumns = append(relation.ToColumns, fk.RefColumnName)
+			}
+			for _, name := range order {
+				state.fks = append(state.fks, *byName[name])
+			}
+		} else {
+			warnings = append(warnings, fmt.Sprintf("读取表 %s 的外键失败：%s", ref.key(), normalizeErrorMessage(err)))
+		}
+		states[ref.key()] = state
+	}
+	return states, warnings
+}
+
+// assembleERModel 由各表快照生成图模型；引用了未加载表（如跨库外键）的关系不输出。
+func assembleERModel(dbName string, states map[string]*erTableState, loadedAt time.Time) ERModel {
+	model := ERModel{
+		Database:  dbName,
+		Tables:    make([]ERTable, 0, len(states)),
+		Relations: []ERRelation{},
+		LoadedAt:  loadedAt.Format(time.RFC3339),
+	}
+	keys := make([]string, 0, len(states))
+	for key := range states {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fkColumns := make(map[string]map[string]bool)
+	for _, key := range keys {
+		state := states[key]
+		for _, fk := range state.fks {
+			target := states[fk.To]
+			if target == nil {
+				continue
+			}
+			relation := fk
+			relation.FromColumns = append([]string(nil), fk.FromColumns...)
+			if len(fk.ToColumns) == 0 {
+				// SQLite 的 REFERENCES t 省略列时引用主键
+				for _, col := range target.table.Columns {
+					if col.PrimaryKey {
+						relation.ToColumns = append(relation.ToColumns, col.Name)
+					}
+				}
+			} else {
+				relation.ToColumns = append([]string(nil), fk.ToColumns...)
+			}
+			relation.Cardinality = erCardinality(state, relation.FromColumns)
+			for _, col := range state.table.Columns {
+				if col.Nullable && containsFold(relation.FromColumns, col.Name) {
+					relation.Optional = true
+				}
+			}
+			if fkColumns[key] == nil {
+				fkColumns[key] = make(map[string]bool)
+			}
+			for _, col := range relation.FromColumns {
+				fkColumns[key][strings.ToLower(col)] = true
+			}
+			model.Relations = append(model.Relations, relation)
+		}
+	}
+	for _, key := range keys {
+		table := states[key].table
+		table.Columns = append([]ERColumn(nil), table.Columns...)
+		for i := range table.Columns {
+			table.Columns[i].ForeignKey = fkColumns[key][strings.ToLower(table.Columns[i].Name)]
+		}
+		model.Tables = append(model.Tables, table)
+	}
+	return model
+}
+
+// erCardinality 外键列包含某个主键或唯一键的全部列时，每个父行至多对应一个子行。
+func erCardinality(state *erTableState, fromColumns []string) string {
+	for _, unique := range state.uniques {
+		if len(unique) == 0 {
+			continue
+		}
+		covered := true
+		for _, col := range unique {
+			if !containsFold(fromColumns, col) {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return erCardinalityOneToOne
+		}
+	}
+	return erCardinalityManyToOne
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
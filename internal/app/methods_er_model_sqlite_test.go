@@ -0,0 +1,67 @@
+//go:build gonavi_full_drivers || gonavi_sqlite_driver
+
+package app
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+func TestRefreshERTablesSQLite(t *testing.T) {
+	sqliteDB := &db.SQLiteDB{}
+	if err := sqliteDB.Connect(connection.ConnectionConfig{Type: "sqlite", Host: filepath.Join(t.TempDir(), "er.sqlite")}); err != nil {
+		t.Fatal(err)
+	}
+	defer sqliteDB.Close()
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE)",
+		"CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users, note TEXT)",
+		"CREATE TABLE settings (user_id INTEGER NOT NULL UNIQUE REFERENCES users(id))",
+	} {
+		if _, err := sqliteDB.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	states, _, incremental, warnings, err := refreshERTables(sqliteDB, "sqlite", "", nil, nil)
+	if err != nil || incremental || len(warnings) != 0 || len(states) != 3 {
+		t.Fatalf("states = %v incremental = %v warnings = %v err = %v", states, incremental, warnings, err)
+	}
+	model := assembleERModel("", states, time.Now())
+	if len(model.Relations) != 2 {
+		t.Fatalf("relations = %+v", model.Relations)
+	}
+	for _, relation := range model.Relations {
+		switch relation.From {
+		case "orders":
+			// REFERENCES users 省略列时引用主键
+			if relation.ToColumns[0] != "id" || relation.Cardinality != erCardinalityManyToOne || !relation.Optional {
+				t.Fatalf("orders relation = %+v", relation)
+			}
+		case "settings":
+			if relation.Cardinality != erCardinalityOneToOne || relation.Optional {
+				t.Fatalf("settings relation = %+v", relation)
+			}
+		default:
+			t.Fatalf("unexpected relation %+v", relation)
+		}
+	}
+
+	if _, err := sqliteDB.Exec("DROP TABLE settings"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sqliteDB.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, order_id INTEGER REFERENCES orders(id))"); err != nil {
+		t.Fatal(err)
+	}
+	states, refreshed, incremental, _, err := refreshERTables(sqliteDB, "sqlite", "", states, nil)
+	if err != nil || !incremental || len(refreshed) != 1 || refreshed[0] != "items" {
+		t.Fatalf("refreshed = %v incremental = %v err = %v", refreshed, incremental, err)
+	}
+	if _, ok := states["settings"]; ok || states["users"] == nil || len(states["items"].fks) != 1 {
+		t.Fatalf("states after refresh = %v", states)
+	}
+}
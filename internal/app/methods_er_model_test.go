@@ -0,0 +1,64 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssembleERModel(t *testing.T) {
+	states := buildERTableStates(
+		[][]string{
+			{"public", "users", "name", "text", "YES", "2"},
+			{"public", "users", "id", "bigint", "NO", "1"},
+			{"public", "profiles", "user_id", "bigint", "NO", "1"},
+			{"public", "orders", "id", "bigint", "NO", "1"},
+			{"public", "orders", "user_id", "bigint", "YES", "2"},
+			{"public", "orders", "archived_id", "bigint", "YES", "3"},
+		},
+		[][]string{
+			{"public", "users", "users_pkey", "P", "id"},
+			{"public", "profiles", "profiles_pkey", "P", "user_id"},
+			{"public", "orders", "orders_pkey", "P", "id"},
+		},
+		[][]string{
+			{"public", "orders", "orders_user_fk", "user_id", "public", "users", "id", "1"},
+			{"public", "profiles", "profiles_user_fk", "user_id", "public", "users", "id", "1"},
+			// 引用未加载的表（跨库外键）不输出
+			{"public", "orders", "orders_archive_fk", "archived_id", "archive", "orders", "id", "1"},
+		},
+		[][]string{{"public", "users", "v1"}},
+	)
+	if states["public.users"].version != "v1" {
+		t.Fatalf("version = %q", states["public.users"].version)
+	}
+	model := assembleERModel("app", states, time.Unix(0, 0))
+	if len(model.Tables) != 3 || model.Tables[2].Key != "public.users" {
+		t.Fatalf("tables = %+v", model.Tables)
+	}
+	users := model.Tables[2]
+	if users.Columns[0].Name != "id" || !users.Columns[0].PrimaryKey || users.Columns[1].PrimaryKey {
+		t.Fatalf("users columns = %+v", users.Columns)
+	}
+	if len(model.Relations) != 2 {
+		t.Fatalf("relations = %+v", model.Relations)
+	}
+	orders, profiles := model.Relations[0], model.Relations[1]
+	if orders.Cardinality != erCardinalityManyToOne || !orders.Optional || orders.To != "public.users" {
+		t.Fatalf("orders relation = %+v", orders)
+	}
+	if profiles.Cardinality != erCardinalityOneToOne || profiles.Optional {
+		t.Fatalf("profiles relation = %+v", profiles)
+	}
+	if !model.Tables[0].Columns[1].ForeignKey || model.Tables[0].Columns[2].ForeignKey {
+		t.Fatalf("orders columns = %+v", model.Tables[0].Columns)
+	}
+
+	filter := erTableFilterSQL("postgres", "n.nspname", "c.relname", []erTableRef{{schema: "public", name: "o'rders"}, {schema: "s", name: "t"}})
+	if filter != " AND ((n.nspname = 'public' AND c.relname = 'o''rders') OR (n.nspname = 's' AND c.relname = 't'))" {
+		t.Fatalf("filter = %s", filter)
+	}
+	if !strings.Contains(erCatalogSQL("mysql", "shop").versions, "t.TABLE_SCHEMA = 'shop'") {
+		t.Fatal("mysql versions query should filter by schema")
+	}
+}
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+// GetEvents 列出库中的定时任务：MySQL/MariaDB 的事件（information_schema.EVENTS），PostgreSQL 的 pg_cron 任务（cron.job，
+// 扩展所在库可见全部任务，Database 为任务实际执行的库）。事件调度器未开启时在 Message 中提示。
+func (a *App) GetEvents(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbType := resolveDDLDBType(runConfig)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	switch backupFamily(dbType) {
+	case "mysql":
+		rows, _, err := dbInst.Query(mysqlEventsSQL(dbName))
+		if err != nil {
+			logger.Error(err, "获取事件列表失败：%s", formatConnSummary(runConfig))
+			return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+		}
+		events := make([]connection.EventDefinition, 0, len(rows))
+		for _, row := range rows {
+			events = append(events, mysqlEventFromRow(row))
+		}
+		message := ""
+		if status, _, err := dbInst.Query("SELECT @@event_scheduler AS scheduler"); err == nil && len(status) > 0 {
+			if scheduler := strings.ToUpper(backupRowString(status[0], "scheduler")); scheduler != "ON" && scheduler != "1" {
+				message = fmt.Sprintf("事件调度器未开启（event_scheduler=%s），事件不会执行", scheduler)
+			}
+		}
+		return connection.QueryResult{Success: true, Message: message, Data: events}
+	case "postgres":
+		installed, _, err := dbInst.Query("SELECT 1 AS installed FROM pg_extension WHERE extname = 'pg_cron'")
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+		}
+		if len(installed) == 0 {
+			return connection.QueryResult{Success: false, Message: "当前库未安装 pg_cron 扩展"}
+		}
+		rows, _, err := dbInst.Query("SELECT jobid, jobname, schedule, command, database, username, active FROM cron.job ORDER BY jobid")
+		if err != nil {
+			logger.Error(err, "获取 pg_cron 任务失败：%s", formatConnSummary(runConfig))
+			return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+		}
+		events := make([]connection.EventDefinition, 0, len(rows))
+		for _, row := range rows {
+			events = append(events, pgCronJobFromRow(row))
+		}
+		return connection.QueryResult{Success: true, Data: events}
+	}
+	return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源(%s)暂不支持定时任务", dbType)}
+}
+
+// SetEventEnabled 启用或禁用定时任务。pg_cron 需要 1.3 及以上版本（cron.alter_job）。
+func (a *App) SetEventEnabled(config connection.ConnectionConfig, dbName string, event connection.EventDefinition, enabled bool) connection.QueryResult {
+	action := "禁用"
+	if enabled {
+		action = "启用"
+	}
+	return a.execEventStatement(config, dbName, event, action+"定时任务", func(dbType string) (string, error) {
+		return buildSetEventEnabledSQL(dbType, dbName, event, enabled)
+	})
+}
+
+// DropEvent 删除定时任务，pg_cron 任务通过 cron.unschedule 移除。
+func (a *App) DropEvent(config connection.ConnectionConfig, dbName string, event connection.EventDefinition) connection.QueryResult {
+	return a.execEventStatement(config, dbName, event, "删除定时任务", func(dbType string) (string, error) {
+		return buildDropEventSQL(dbType, dbName, event)
+	})
+}
+
+func (a *App) execEventStatement(config connection.ConnectionConfig, dbName string, event connection.EventDefinition, action string, build func(dbType string) (string, error)) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	if err := ensureWritable(runConfig, action); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	query, err := build(resolveDDLDBType(runConfig))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	// pg_cron 的管理函数以 SELECT 调用，统一走 Query
+	if _, _, err := dbInst.Query(query); err != nil {
+		logger.Error(err, "%s失败：%s SQL片段=%q", action, formatConnSummary(runConfig), sqlSnippet(query))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	logger.Infof("%s：%s 任务=%s", action, formatConnSummary(runConfig), event.Name)
+	return connection.QueryResult{Success: true, Message: action + "成功"}
+}
+
+func mysqlEventsSQL(dbName string) string {
+	return fmt.Sprintf("SELECT EVENT_SCHEMA, EVENT_NAME, STATUS, EVENT_TYPE, EXECUTE_AT, INTERVAL_VALUE, INTERVAL_FIELD, STARTS, ENDS, "+
+		"LAST_EXECUTED, EVENT_DEFINITION, EVENT_COMMENT, DEFINER FROM information_schema.EVENTS WHERE EVENT_SCHEMA = %s ORDER BY EVENT_NAME",
+		dialect.QuoteLiteral("mysql", dbName))
+}
+
+func mysqlEventFromRow(row map[string]interface{}) connection.EventDefinition {
+	name := backupRowString(row, "EVENT_NAME")
+	status := strings.ToUpper(backupRowString(row, "STATUS"))
+	event := connection.EventDefinition{
+		ID:           name,
+		Schema:       backupRowString(row, "EVENT_SCHEMA"),
+		Name:         name,
+		Status:       status,
+		Enabled:      status == "ENABLED",
+		LastExecuted: backupRowString(row, "LAST_EXECUTED"),
+		Definition:   backupRowString(row, "EVENT_DEFINITION"),
+		Comment:      backupRowString(row, "EVENT_COMMENT"),
+		Owner:        backupRowString(row, "DEFINER"),
+	}
+	if strings.EqualFold(backupRowString(row, "EVENT_TYPE"), "ONE TIME") {
+		event.Schedule = "AT " + backupRowString(row, "EXECUTE_AT")
+		return event
+	}
+	event.Schedule = strings.TrimSpace(fmt.Sprintf("EVERY %s %s", backupRowString(row, "INTERVAL_VALUE"), backupRowString(row, "INTERVAL_FIELD")))
+	event.Starts = backupRowString(row, "STARTS")
+	event.Ends = backupRowString(row, "ENDS")
+	return event
+}
+
+func pgCronJobFromRow(row map[string]interface{}) connection.EventDefinition {
+	id := backupRowString(row, "jobid")
+	name := backupRowString(row, "jobname")
+	if name == "" {
+		// 旧版 pg_cron 的任务没有名称
+		name = "job_" + id
+	}
+	active := strings.ToLower(backupRowString(row, "active"))
+	enabled := active == "true" || active == "t" || active == "1"
+	status := "DISABLED"
+	if enabled {
+		status = "ENABLED"
+	}
+	return connection.EventDefinition{
+		ID:         id,
+		Name:       name,
+		Enabled:    enabled,
+		Status:     status,
+		Schedule:   backupRowString(row, "schedule"),
+		Definition: backupRowString(row, "command"),
+		Owner:      backupRowString(row, "username"),
+		Database:   backupRowString(row, "database"),
+	}
+}
+
+func pgCronJobID(event connection.EventDefinition) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(event.ID), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("无效的 pg_cron 任务 ID：%s", event.ID)
+	}
+	return id, nil
+}
+
+func mysqlEventTarget(dbName string, event connection.EventDefinition) (string, error) {
+	if strings.TrimSpace(event.Name) == "" {
+		return "", fmt.Errorf("事件名称不能为空")
+	}
+	schema := strings.TrimSpace(event.Schema)
+	if schema == "" {
+		schema = dbName
+	}
+	return quoteTableIdentByType("mysql", schema, event.Name), nil
+}
+
+func buildSetEventEnabledSQL(dbType string, dbName string, event connection.EventDefinition, enabled bool) (string, error) {
+	switch backupFamily(dbType) {
+	case "mysql":
+		target, err := mysqlEventTarget(dbName, event)
+		if err != nil {
+			return "", err
+		}
+		if enabled {
+			return "ALTER EVENT " + target + " ENABLE", nil
+		}
+		return "ALTER EVENT " + target + " DISABLE", nil
+	case "postgres":
+		id, err := pgCronJobID(event)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("SELECT cron.alter_job(%d, active := %t)", id, enabled), nil
+	}
+	return "", fmt.Errorf("当前数据源(%s)暂不支持定时任务", dbType)
+}
+
+func buildDropEventSQL(dbType string, dbName string, event connection.EventDefinition) (string, error) {
+	switch backupFamily(dbType) {
+	case "mysql":
+		target, err := mysqlEventTarget(dbName, event)
+		if err != nil {
+			return "", err
+		}
+		return "DROP EVENT IF EXISTS " + target, nil
+	case "postgres":
+		id, err := pgCronJobID(event)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("SELECT cron.unschedule(%d::bigint)", id), nil
+	}
+	return "", fmt.Errorf("当前数据源(%s)暂不支持定时任务", dbType)
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type eventsFakeDB struct {
+	db.Database
+	queries []string
+}
+
+func (f *eventsFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	f.queries = append(f.queries, query)
+	switch {
+	case strings.Contains(query, "information_schema.EVENTS"):
+		return []map[string]interface{}{
+			{"EVENT_SCHEMA": "shop", "EVENT_NAME": "purge", "STATUS": "ENABLED", "EVENT_TYPE": "RECURRING", "INTERVAL_VALUE": "1", "INTERVAL_FIELD": "DAY", "STARTS": "2026-01-01 00:00:00", "EVENT_DEFINITION": "DELETE FROM logs"},
+			{"EVENT_SCHEMA": "shop", "EVENT_NAME": "once", "STATUS": "DISABLED", "EVENT_TYPE": "ONE TIME", "EXECUTE_AT": "2026-12-31 23:00:00"},
+		}, nil, nil
+	case strings.Contains(query, "@@event_scheduler"):
+		return []map[string]interface{}{{"scheduler": "OFF"}}, nil, nil
+	}
+	return nil, nil, nil
+}
+
+func TestGetEventsMySQL(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root", Database: "shop"}
+	fake := &eventsFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	res := a.GetEvents(config, "shop")
+	events, ok := res.Data.([]connection.EventDefinition)
+	if !res.Success || !ok || len(events) != 2 || !strings.Contains(res.Message, "event_scheduler=OFF") {
+		t.Fatalf("res = %+v", res)
+	}
+	if events[0].Schedule != "EVERY 1 DAY" || !events[0].Enabled || events[1].Schedule != "AT 2026-12-31 23:00:00" || events[1].Enabled {
+		t.Fatalf("events = %+v", events)
+	}
+
+	fake.queries = nil
+	if res := a.SetEventEnabled(config, "shop", events[1], true); !res.Success || fake.queries[0] != "ALTER EVENT `shop`.`once` ENABLE" {
+		t.Fatalf("res = %+v queries = %q", res, fake.queries)
+	}
+	config.ReadOnly = true
+	if res := a.DropEvent(config, "shop", events[0]); res.Success || len(fake.queries) != 1 {
+		t.Fatalf("read-only res = %+v", res)
+	}
+}
+
+func TestBuildPgCronEventSQL(t *testing.T) {
+	job := connection.EventDefinition{ID: "42", Name: "vacuum"}
+	if sql, err := buildSetEventEnabledSQL("postgres", "postgres", job, false); err != nil || sql != "SELECT cron.alter_job(42, active := false)" {
+		t.Fatalf("sql = %q err = %v", sql, err)
+	}
+	if sql, err := buildDropEventSQL("postgres", "postgres", job); err != nil || sql != "SELECT cron.unschedule(42::bigint)" {
+		t.Fatalf("sql = %q err = %v", sql, err)
+	}
+	if _, err := buildDropEventSQL("postgres", "postgres", connection.EventDefinition{ID: "1; DROP TABLE x"}); err == nil {
+		t.Fatal("expected invalid job id error")
+	}
+}
@@ -0,0 +1,554 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+// PlanNode 统一后的执行计划节点。数值为 -1 表示该数据库的计划中没有提供。
+type PlanNode struct {
+	Operation  string     `json:"operation"`        // 节点类型，如 Seq Scan、Nested Loop、Clustered Index Seek
+	Object     string     `json:"object,omitempty"` // 访问的表/索引
+	Detail     string     `json:"detail,omitempty"` // 访问方式、过滤与连接条件等补充说明
+	Rows       float64    `json:"rows"`             // 估算行数
+	Cost       float64    `json:"cost"`             // 估算成本（含子节点），单位随数据库而异
+	ActualRows float64    `json:"actualRows"`       // ANALYZE 实际行数（每次循环）
+	TimeMs     float64    `json:"timeMs"`           // ANALYZE 实际耗时（毫秒，每次循环）
+	Loops      int64      `json:"loops,omitempty"`
+	Children   []PlanNode `json:"children,omitempty"`
+}
+
+// ExplainResult 结构化的执行计划，Raw 保留数据库返回的原始输出。
+type ExplainResult struct {
+	Format      string   `json:"format"` // mysql-json/mysql-tree/mariadb-json/postgres-json/sqlserver-showplan/sqlite
+	Analyzed    bool     `json:"analyzed"`
+	Root        PlanNode `json:"root"`
+	PlanningMs  float64  `json:"planningMs"`
+	ExecutionMs float64  `json:"executionMs"`
+	Raw         string   `json:"raw"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+func newPlanNode(operation string) PlanNode {
+	return PlanNode{Operation: operation, Rows: -1, Cost: -1, ActualRows: -1, TimeMs: -1}
+}
+
+// ExplainQuery 读取语句的执行计划并归一为通用的计划树（节点类型、行数、成本、耗时），供前端可视化展示：
+// MySQL/MariaDB 使用 EXPLAIN FORMAT=JSON（MySQL 的 ANALYZE 为 EXPLAIN ANALYZE 树形文本），PostgreSQL 使用
+// EXPLAIN (FORMAT JSON)，SQL Server 在专用会话上开启 SHOWPLAN_XML，SQLite 使用 EXPLAIN QUERY PLAN。
+// analyze 会实际执行语句，因此只允许只读查询。
+func (a *App) ExplainQuery(config connection.ConnectionConfig, dbName string, query string, analyze bool) connection.QueryResult {
+	query = strings.TrimSpace(query)
+	for strings.HasSuffix(query, ";") {
+		query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+	}
+	if query == "" {
+		return connection.QueryResult{Success: false, Message: "查询语句不能为空"}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	if analyze && readOnlyViolation(runConfig.Type, query) != "" {
+		return connection.QueryResult{Success: false, Message: "ANALYZE 会实际执行语句，仅支持只读查询"}
+	}
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+	var result ExplainResult
+	warning, err := a.runWithReconnect(runConfig, dbInst, "ExplainQuery", func(inst db.Database) (explainErr error) {
+		result, explainErr = explainQuery(inst, dbType, query, analyze)
+		return explainErr
+	})
+	if err != nil {
+		logger.Error(err, "读取执行计划失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(query))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Message: warning, Data: result}
+}
+
+func explainQuery(inst db.Database, dbType string, query string, analyze bool) (ExplainResult, error) {
+	result := ExplainResult{PlanningMs: -1, ExecutionMs: -1}
+	var err error
+	switch dbType {
+	case "mysql":
+		if analyze {
+			// EXPLAIN ANALYZE（8.0.18+）只输出树形文本
+			result.Raw, err = explainText(inst, "EXPLAIN ANALYZE "+query)
+			if err == nil {
+				result.Format, result.Analyzed = "mysql-tree", true
+				result.Root, err = parseMySQLTreePlan(result.Raw)
+			}
+			break
+		}
+		result.Raw, err = explainText(inst, "EXPLAIN FORMAT=JSON "+query)
+		if err == nil {
+			result.Format = "mysql-json"
+			result.Root, err = parseMySQLJSONPlan(result.Raw)
+		}
+	case "mariadb":
+		prefix := "EXPLAIN FORMAT=JSON "
+		if analyze {
+			prefix = "ANALYZE FORMAT=JSON "
+		}
+		result.Raw, err = explainText(inst, prefix+query)
+		if err == nil {
+			result.Format, result.Analyzed = "mariadb-json", analyze
+			result.Root, err = parseMySQLJSONPlan(result.Raw)
+		}
+	case "postgres", "kingbase", "highgo", "vastbase":
+		options := "FORMAT JSON"
+		if analyze {
+			options += ", ANALYZE"
+		}
+		result.Raw, err = explainText(inst, "EXPLAIN ("+options+") "+query)
+		if err == nil {
+			result.Format, result.Analyzed = "postgres-json", analyze
+			result.Root, result.PlanningMs, result.ExecutionMs, err = parsePostgresJSONPlan(result.Raw)
+		}
+	case "sqlserver":
+		if analyze {
+			// 实际计划作为额外的结果集返回，当前只读取估算计划
+			result.Warnings = append(result.Warnings, "SQL Server 暂只提供估算执行计划")
+		}
+		result.Raw, err = sqlServerShowplan(inst, query)
+		if err == nil {
+			result.Format = "sqlserver-showplan"
+			result.Root, err = parseSQLServerShowplan(result.Raw)
+		}
+	case "sqlite":
+		if analyze {
+			result.Warnings = append(result.Warnings, "SQLite 不支持 ANALYZE 执行计划，已返回估算计划")
+		}
+		var rows [][]string
+		rows, err = erRows(inst, "EXPLAIN QUERY PLAN "+query)
+		if err == nil {
+			result.Format = "sqlite"
+			result.Root = buildSQLitePlan(rows)
+			lines := make([]string, 0, len(rows))
+			for _, row := range rows {
+				lines = append(lines, strings.Join(row, "|"))
+			}
+			result.Raw = strings.Join(lines, "\n")
+		}
+	default:
+		return result, fmt.Errorf("当前数据源（%s）暂不支持结构化执行计划", dbType)
+	}
+	return result, err
+}
+
+// explainText 读取 EXPLAIN 输出；多行输出（如逐行返回的文本计划）按行拼接。
+func explainText(inst db.Database, query string) (string, error) {
+	data, columns, err := inst.Query(query)
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 0 || len(columns) == 0 {
+		return "", fmt.Errorf("数据库未返回执行计划")
+	}
+	lines := make([]string, 0, len(data))
+	for _, row := range data {
+		lines = append(lines, statRowText(row, columns[0]))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// sqlServerShowplan SHOWPLAN_XML 是会话级设置，在专用会话上开启，用完直接丢弃该连接。
+func sqlServerShowplan(inst db.Database, query string) (string, error) {
+	opener, ok := inst.(db.SessionOpener)
+	if !ok {
+		return "", fmt.Errorf("当前驱动不支持专用会话，无法读取 SQL Server 执行计划")
+	}
+	ctx := context.Background()
+	session, err := opener.OpenSession(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+	if _, err := session.ExecContext(ctx, "SET SHOWPLAN_XML ON"); err != nil {
+		return "", err
+	}
+	data, columns, err := session.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 0 || len(columns) == 0 {
+		return "", fmt.Errorf("数据库未返回执行计划")
+	}
+	return statRowText(data[0], columns[0]), nil
+}
+
+// planNumber 读取 JSON 中的数值字段，缺失时返回 -1。
+func planNumber(obj map[string]interface{}, keys ...string) float64 {
+	for _, key := range keys {
+		switch v := obj[key].(type) {
+		case float64:
+			return v
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return f
+			}
+		}
+	}
+	return -1
+}
+
+func planString(obj map[string]interface{}, key string) string {
+	if v, ok := obj[key]; ok && v != nil {
+		return strings.TrimSpace(fmt.Sprintf("%v", v))
+	}
+	return ""
+}
+
+// mysqlPlanSkipKeys MySQL/MariaDB JSON 计划中不构成子节点的对象字段。
+var mysqlPlanSkipKeys = map[string]struct{}{"cost_info": {}, "used_columns": {}, "possible_keys": {}, "used_key_parts": {}, "ref": {}, "filesort_key": {}}
+
+func parseMySQLJSONPlan(raw string) (PlanNode, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		return PlanNode{}, fmt.Errorf("解析执行计划失败：%w", err)
+	}
+	block, ok := doc["query_block"].(map[string]interface{})
+	if !ok {
+		return PlanNode{}, fmt.Errorf("执行计划缺少 query_block")
+	}
+	return mysqlPlanNode("query_block", block), nil
+}
+
+func mysqlPlanNode(name string, obj map[string]interface{}) PlanNode {
+	node := newPlanNode(name)
+	costInfo, _ := obj["cost_info"].(map[string]interface{})
+	if costInfo != nil {
+		node.Cost = planNumber(costInfo, "prefix_cost", "query_cost")
+	}
+	if node.Cost < 0 {
+		node.Cost = planNumber(obj, "cost") // MariaDB 11+
+	}
+	if table := planString(obj, "table_name"); table != "" {
+		node.Operation = "table"
+		if access := planString(obj, "access_type"); access != "" {
+			node.Operation = "table (" + access + ")"
+		}
+		node.Object = table
+		if key := planString(obj, "key"); key != "" {
+			node.Object += " / " + key
+		}
+		node.Rows = planNumber(obj, "rows_produced_per_join", "rows")
+		node.ActualRows = planNumber(obj, "r_rows")
+		node.TimeMs = planNumber(obj, "r_total_time_ms")
+		if loops := planNumber(obj, "r_loops"); loops >= 0 {
+			node.Loops = int64(loops)
+		}
+		node.Detail = planString(obj, "attached_condition")
+	} else if id := planString(obj, "select_id"); id != "" && name == "query_block" {
+		node.Operation = "select #" + id
+		node.TimeMs = planNumber(obj, "r_total_time_ms")
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		if _, skip := mysqlPlanSkipKeys[key]; !skip {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := obj[key].(type) {
+		case map[string]interface{}:
+			node.Children = append(node.Children, mysqlPlanNode(key, value))
+		case []interface{}:
+			// nested_loop、query_specifications 等数组成为一个分组节点，元素多为 {"table": {...}} 形式的包装
+			group := newPlanNode(key)
+			for _, item := range value {
+				elem, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if _, isTable := elem["table_name"]; isTable {
+					group.Children = append(group.Children, mysqlPlanNode("table", elem))
+					continue
+				}
+				wrapped := false
+				for _, elemKey := range sortedPlanKeys(elem) {
+					if child, ok := elem[elemKey].(map[string]interface{}); ok {
+						if _, skip := mysqlPlanSkipKeys[elemKey]; !skip {
+							group.Children = append(group.Children, mysqlPlanNode(elemKey, child))
+							wrapped = true
+						}
+					}
+				}
+				if !wrapped {
+					group.Children = append(group.Children, mysqlPlanNode(key, elem))
+				}
+			}
+			if len(group.Children) > 0 {
+				node.Children = append(node.Children, group)
+			}
+		}
+	}
+	return node
+}
+
+func sortedPlanKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var (
+	mysqlTreeCostPattern   = regexp.MustCompile(`\(cost=(\d+(?:\.\d+)?)(?:\.\.(\d+(?:\.\d+)?))?\s+rows=(\d+(?:\.\d+)?(?:e[+-]?\d+)?)\)`)
+	mysqlTreeActualPattern = regexp.MustCompile(`\(actual time=(\d+(?:\.\d+)?)\.\.(\d+(?:\.\d+)?)\s+rows=(\d+(?:\.\d+)?(?:e[+-]?\d+)?)\s+loops=(\d+)\)`)
+)
+
+// parseMySQLTreePlan 解析 EXPLAIN ANALYZE 的树形文本：每个节点以 "-> " 开头，缩进表示层级。
+func parseMySQLTreePlan(raw string) (PlanNode, error) {
+	type frame struct {
+		indent int
+		node   *PlanNode
+	}
+	root := newPlanNode("query")
+	stack := []frame{{indent: -1, node: &root}}
+	for _, line := range strings.Split(raw, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if !strings.HasPrefix(trimmed, "-> ") {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+		text := strings.TrimPrefix(trimmed, "-> ")
+		node := newPlanNode(text)
+		if idx := strings.Index(text, "  ("); idx >= 0 {
+			node.Operation = strings.TrimSpace(text[:idx])
+		}
+		if m := mysqlTreeCostPattern.FindStringSubmatch(text); m != nil {
+			node.Cost, _ = strconv.ParseFloat(m[1], 64)
+			if m[2] != "" {
+				node.Cost, _ = strconv.ParseFloat(m[2], 64)
+			}
+			node.Rows, _ = strconv.ParseFloat(m[3], 64)
+		}
+		if m := mysqlTreeActualPattern.FindStringSubmatch(text); m != nil {
+			node.TimeMs, _ = strconv.ParseFloat(m[2], 64)
+			node.ActualRows, _ = strconv.ParseFloat(m[3], 64)
+			node.Loops, _ = strconv.ParseInt(m[4], 10, 64)
+		}
+		for len(stack) > 1 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1].node
+		parent.Children = append(parent.Children, node)
+		stack = append(stack, frame{indent: indent, node: &parent.Children[len(parent.Children)-1]})
+	}
+	if len(root.Children) == 0 {
+		return PlanNode{}, fmt.Errorf("未能解析执行计划")
+	}
+	if len(root.Children) == 1 {
+		return root.Children[0], nil
+	}
+	return root, nil
+}
+
+// postgresPlanDetailKeys 合并进 Detail 的 PostgreSQL 计划字段。
+var postgresPlanDetailKeys = []string{"Join Type", "Index Cond", "Hash Cond", "Merge Cond", "Recheck Cond", "Filter", "Join Filter", "Sort Key", "Group Key"}
+
+func parsePostgresJSONPlan(raw string) (PlanNode, float64, float64, error) {
+	var docs []map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &docs); err != nil {
+		return PlanNode{}, -1, -1, fmt.Errorf("解析执行计划失败：%w", err)
+	}
+	if len(docs) == 0 {
+		return PlanNode{}, -1, -1, fmt.Errorf("执行计划为空")
+	}
+	plan, ok := docs[0]["Plan"].(map[string]interface{})
+	if !ok {
+		return PlanNode{}, -1, -1, fmt.Errorf("执行计划缺少 Plan")
+	}
+	return postgresPlanNode(plan), planNumber(docs[0], "Planning Time"), planNumber(docs[0], "Execution Time"), nil
+}
+
+func postgresPlanNode(plan map[string]interface{}) PlanNode {
+	node := newPlanNode(planString(plan, "Node Type"))
+	if strategy := planString(plan, "Strategy"); strategy != "" && strategy != "Plain" {
+		node.Operation += " (" + strategy + ")"
+	}
+	object := planString(plan, "Relation Name")
+	if schema := planString(plan, "Schema"); schema != "" && object != "" {
+		object = schema + "." + object
+	}
+	if index := planString(plan, "Index Name"); index != "" {
+		if object != "" {
+			object += " / "
+		}
+		object += index
+	}
+	node.Object = object
+	var details []string
+	for _, key := range postgresPlanDetailKeys {
+		if value := plan[key]; value != nil {
+			if list, ok := value.([]interface{}); ok {
+				parts := make([]string, 0, len(list))
+				for _, item := range list {
+					parts = append(parts, fmt.Sprintf("%v", item))
+				}
+				value = strings.Join(parts, ", ")
+			}
+			details = append(details, fmt.Sprintf("%s: %v", key, value))
+		}
+	}
+	node.Detail = strings.Join(details, "; ")
+	node.Rows = planNumber(plan, "Plan Rows")
+	node.Cost = planNumber(plan, "Total Cost")
+	node.ActualRows = planNumber(plan, "Actual Rows")
+	node.TimeMs = planNumber(plan, "Actual Total Time")
+	if loops := planNumber(plan, "Actual Loops"); loops >= 0 {
+		node.Loops = int64(loops)
+	}
+	if children, ok := plan["Plans"].([]interface{}); ok {
+		for _, child := range children {
+			if childPlan, ok := child.(map[string]interface{}); ok {
+				node.Children = append(node.Children, postgresPlanNode(childPlan))
+			}
+		}
+	}
+	return node
+}
+
+// showplanElement 通用 XML 节点，SHOWPLAN 的算子元素种类繁多，按 RelOp 递归提取。
+type showplanElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr        `xml:",any,attr"`
+	Children []showplanElement `xml:",any"`
+}
+
+func (e *showplanElement) attr(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func parseSQLServerShowplan(raw string) (PlanNode, error) {
+	var doc showplanElement
+	if err := xml.Unmarshal([]byte(raw), &doc); err != nil {
+		return PlanNode{}, fmt.Errorf("解析执行计划失败：%w", err)
+	}
+	roots := showplanRelOps(&doc)
+	switch len(roots) {
+	case 0:
+		return PlanNode{}, fmt.Errorf("执行计划中没有算子")
+	case 1:
+		return roots[0], nil
+	}
+	root := newPlanNode("Batch")
+	root.Children = roots
+	return root, nil
+}
+
+// showplanRelOps 返回 e 之下最近一层的 RelOp（不穿过其它 RelOp）。
+func showplanRelOps(e *showplanElement) []PlanNode {
+	var nodes []PlanNode
+	for i := range e.Children {
+		child := &e.Children[i]
+		if child.XMLName.Local == "RelOp" {
+			nodes = append(nodes, showplanNode(child))
+			continue
+		}
+		nodes = append(nodes, showplanRelOps(child)...)
+	}
+	return nodes
+}
+
+func showplanNode(op *showplanElement) PlanNode {
+	node := newPlanNode(op.attr("PhysicalOp"))
+	if logical := op.attr("LogicalOp"); logical != "" && logical != node.Operation {
+		node.Detail = logical
+	}
+	if f, err := strconv.ParseFloat(op.attr("EstimateRows"), 64); err == nil {
+		node.Rows = f
+	}
+	if f, err := strconv.ParseFloat(op.attr("EstimatedTotalSubtreeCost"), 64); err == nil {
+		node.Cost = f
+	}
+	var findObject func(e *showplanElement)
+	findObject = func(e *showplanElement) {
+		for i := range e.Children {
+			child := &e.Children[i]
+			switch child.XMLName.Local {
+			case "RelOp":
+				continue
+			case "Object":
+				if node.Object == "" {
+					object := strings.Trim(child.attr("Table"), "[]")
+					if schema := strings.Trim(child.attr("Schema"), "[]"); schema != "" {
+						object = schema + "." + object
+					}
+					if index := strings.Trim(child.attr("Index"), "[]"); index != "" {
+						object += " / " + index
+					}
+					node.Object = object
+				}
+			case "RunTimeCountersPerThread":
+				// 实际计划按线程分别统计，行数求和、耗时取最大
+				if f, err := strconv.ParseFloat(child.attr("ActualRows"), 64); err == nil {
+					node.ActualRows = max(node.ActualRows, 0) + f
+				}
+				if f, err := strconv.ParseFloat(child.attr("ActualElapsedms"), 64); err == nil && f > node.TimeMs {
+					node.TimeMs = f
+				}
+			}
+			findObject(child)
+		}
+	}
+	findObject(op)
+	node.Children = showplanRelOps(op)
+	return node
+}
+
+// buildSQLitePlan 由 EXPLAIN QUERY PLAN 的 id、parent、notused、detail 四列按父子关系建树。
+func buildSQLitePlan(rows [][]string) PlanNode {
+	root := newPlanNode("QUERY PLAN")
+	children := make(map[string][]int)
+	for i, row := range rows {
+		if len(row) < 4 {
+			continue
+		}
+		parent := row[1]
+		if row[0] == parent {
+			// 旧版本（3.24 之前）的输出没有父子关系，全部挂在根节点下
+			parent = "0"
+		}
+		children[parent] = append(children[parent], i)
+	}
+	visited := make(map[int]bool)
+	var build func(parentID string) []PlanNode
+	build = func(parentID string) []PlanNode {
+		var nodes []PlanNode
+		for _, i := range children[parentID] {
+			if visited[i] {
+				continue
+			}
+			visited[i] = true
+			node := newPlanNode(rows[i][3])
+			if rows[i][0] != parentID {
+				node.Children = build(rows[i][0])
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	root.Children = build("0")
+	return root
+}
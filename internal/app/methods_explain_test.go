@@ -0,0 +1,109 @@
+package app
+
+import "testing"
+
+func TestParseMySQLJSONPlan(t *testing.T) {
+	raw := `{"query_block": {"select_id": 1, "cost_info": {"query_cost": "12.50"},
+		"nested_loop": [
+			{"table": {"table_name": "o", "access_type": "ALL", "rows_produced_per_join": 100, "cost_info": {"prefix_cost": "10.25"}, "attached_condition": "(o.total > 10)"}},
+			{"table": {"table_name": "c", "access_type": "eq_ref", "key": "PRIMARY", "rows_produced_per_join": 100, "cost_info": {"prefix_cost": "12.50"}, "used_key_parts": ["id"]}}
+		]}}`
+	root, err := parseMySQLJSONPlan(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Operation != "select #1" || root.Cost != 12.5 || len(root.Children) != 1 {
+		t.Fatalf("root = %+v", root)
+	}
+	loop := root.Children[0]
+	if loop.Operation != "nested_loop" || len(loop.Children) != 2 {
+		t.Fatalf("nested loop = %+v", loop)
+	}
+	if scan := loop.Children[0]; scan.Operation != "table (ALL)" || scan.Object != "o" || scan.Rows != 100 || scan.Cost != 10.25 || scan.Detail != "(o.total > 10)" {
+		t.Fatalf("scan = %+v", scan)
+	}
+	if lookup := loop.Children[1]; lookup.Object != "c / PRIMARY" || lookup.ActualRows != -1 {
+		t.Fatalf("lookup = %+v", lookup)
+	}
+}
+
+func TestParseMySQLTreePlan(t *testing.T) {
+	raw := "-> Limit: 10 row(s)  (cost=2.75 rows=10) (actual time=0.038..0.041 rows=10 loops=1)\n" +
+		"    -> Nested loop inner join  (cost=2.75 rows=25) (actual time=0.036..0.039 rows=10 loops=1)\n" +
+		"        -> Table scan on o  (cost=0.50..2.75 rows=25) (actual time=0.020..0.030 rows=10 loops=1)\n" +
+		"        -> Single-row index lookup on c using PRIMARY (id=o.customer_id)  (cost=0.25 rows=1) (actual time=0.001..0.001 rows=1 loops=10)\n"
+	root, err := parseMySQLTreePlan(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Operation != "Limit: 10 row(s)" || root.ActualRows != 10 || root.TimeMs != 0.041 || len(root.Children) != 1 {
+		t.Fatalf("root = %+v", root)
+	}
+	join := root.Children[0]
+	if len(join.Children) != 2 || join.Children[0].Cost != 2.75 || join.Children[1].Loops != 10 {
+		t.Fatalf("join = %+v", join)
+	}
+}
+
+func TestParsePostgresJSONPlan(t *testing.T) {
+	raw := `[{"Plan": {"Node Type": "Hash Join", "Join Type": "Inner", "Total Cost": 35.5, "Plan Rows": 120, "Actual Rows": 118, "Actual Total Time": 0.42, "Actual Loops": 1,
+		"Hash Cond": "(o.customer_id = c.id)",
+		"Plans": [
+			{"Node Type": "Seq Scan", "Relation Name": "orders", "Total Cost": 20, "Plan Rows": 120, "Filter": "(total > 10)"},
+			{"Node Type": "Hash", "Total Cost": 12, "Plan Rows": 50, "Plans": [{"Node Type": "Index Scan", "Relation Name": "customers", "Index Name": "customers_pkey", "Total Cost": 12, "Plan Rows": 50}]}
+		]}, "Planning Time": 0.1, "Execution Time": 0.5}]`
+	root, planning, execution, err := parsePostgresJSONPlan(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if planning != 0.1 || execution != 0.5 {
+		t.Fatalf("planning = %v execution = %v", planning, execution)
+	}
+	if root.Operation != "Hash Join" || root.ActualRows != 118 || root.Loops != 1 || root.Detail != "Join Type: Inner; Hash Cond: (o.customer_id = c.id)" {
+		t.Fatalf("root = %+v", root)
+	}
+	if scan := root.Children[0]; scan.Object != "orders" || scan.Detail != "Filter: (total > 10)" || scan.ActualRows != -1 {
+		t.Fatalf("scan = %+v", scan)
+	}
+	if index := root.Children[1].Children[0]; index.Object != "customers / customers_pkey" {
+		t.Fatalf("index = %+v", index)
+	}
+}
+
+func TestParseSQLServerShowplan(t *testing.T) {
+	raw := `<ShowPlanXML xmlns="http://schemas.microsoft.com/sqlserver/2004/07/showplan" Version="1.564"><BatchSequence><Batch><Statements>
+		<StmtSimple StatementText="SELECT ..."><QueryPlan>
+			<RelOp NodeId="0" PhysicalOp="Nested Loops" LogicalOp="Inner Join" EstimateRows="100" EstimatedTotalSubtreeCost="0.25">
+				<NestedLoops>
+					<RelOp NodeId="1" PhysicalOp="Clustered Index Scan" LogicalOp="Clustered Index Scan" EstimateRows="100" EstimatedTotalSubtreeCost="0.1">
+						<IndexScan><Object Database="[shop]" Schema="[dbo]" Table="[orders]" Index="[PK_orders]" /></IndexScan>
+					</RelOp>
+					<RelOp NodeId="2" PhysicalOp="Clustered Index Seek" LogicalOp="Clustered Index Seek" EstimateRows="1" EstimatedTotalSubtreeCost="0.12">
+						<IndexScan><Object Schema="[dbo]" Table="[customers]" Index="[PK_customers]" /></IndexScan>
+					</RelOp>
+				</NestedLoops>
+			</RelOp>
+		</QueryPlan></StmtSimple></Statements></Batch></BatchSequence></ShowPlanXML>`
+	root, err := parseSQLServerShowplan(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Operation != "Nested Loops" || root.Detail != "Inner Join" || root.Rows != 100 || root.Cost != 0.25 || root.Object != "" {
+		t.Fatalf("root = %+v", root)
+	}
+	if len(root.Children) != 2 || root.Children[0].Object != "dbo.orders / PK_orders" || root.Children[1].Rows != 1 {
+		t.Fatalf("children = %+v", root.Children)
+	}
+}
+
+func TestBuildSQLitePlan(t *testing.T) {
+	root := buildSQLitePlan([][]string{
+		{"3", "0", "0", "SCAN o"},
+		{"5", "0", "0", "SEARCH c USING INTEGER PRIMARY KEY (rowid=?)"},
+		{"8", "0", "0", "USE TEMP B-TREE FOR ORDER BY"},
+		{"9", "3", "0", "CORRELATED SCALAR SUBQUERY 1"},
+	})
+	if len(root.Children) != 3 || root.Children[0].Operation != "SCAN o" || len(root.Children[0].Children) != 1 {
+		t.Fatalf("root = %+v", root)
+	}
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+// TableExportOptions 整表导出选项。
+type TableExportOptions struct {
+	// BinaryAsFiles 为 true 时二进制列（BLOB/BYTEA/VARBINARY 等）的每个值写入
+	// <导出文件名>_files/<列名>/<主键>.<扩展名>，CSV/JSON 中改为该文件的相对路径。
+	BinaryAsFiles bool `json:"binaryAsFiles"`
+	// Locale 数字与日期的本地化格式
+	Locale ExportLocaleOptions `json:"locale"`
+}
+
+var sidecarUnsafeChars = regexp.MustCompile(`[^\p{L}\p{N}._-]+`)
+
+var sidecarExtensions = map[string]string{
+	"image/png":                 ".png",
+	"image/jpeg":                ".jpg",
+	"image/gif":                 ".gif",
+	"image/webp":                ".webp",
+	"image/bmp":                 ".bmp",
+	"application/pdf":           ".pdf",
+	"application/zip":           ".zip",
+	"application/x-gzip":        ".gz",
+	"audio/mpeg":                ".mp3",
+	"video/mp4":                 ".mp4",
+	"text/xml; charset=utf-8":   ".xml",
+	"text/plain; charset=utf-8": ".txt",
+}
+
+// isBinaryColumnType 判断列类型是否为二进制（不含 BIT 位类型）。
+func isBinaryColumnType(columnType string) bool {
+	t := strings.ToLower(strings.TrimSpace(columnType))
+	if idx := strings.Index(t, "("); idx >= 0 {
+		t = strings.TrimSpace(t[:idx])
+	}
+	switch t {
+	case "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary",
+		"bytea", "image", "raw", "long raw", "bfile", "binary varying":
+		return true
+	}
+	return false
+}
+
+// binaryValueBytes 还原驱动层归一化后的二进制值：非文本字节以 0x 十六进制字符串传输，可读文本保持原样。
+func binaryValueBytes(value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case []byte:
+		return v, true
+	case string:
+		if strings.HasPrefix(v, "0x") && len(v)%2 == 0 {
+			if decoded, err := hex.DecodeString(v[2:]); err == nil {
+				return decoded, true
+			}
+		}
+		return []byte(v), true
+	default:
+		return []byte(fmt.Sprintf("%v", v)), true
+	}
+}
+
+func sidecarExtension(content []byte) string {
+	if ext, ok := sidecarExtensions[http.DetectContentType(content)]; ok {
+		return ext
+	}
+	return ".bin"
+}
+
+// sidecarBaseName 用主键值拼接文件名；无主键或主键为空时使用行号。
+func sidecarBaseName(row map[string]interface{}, pkColumns []string, rowIndex int) string {
+	parts := make([]string, 0, len(pkColumns))
+	for _, col := range pkColumns {
+		value := row[col]
+		if value == nil {
+			continue
+		}
+		text := sidecarUnsafeChars.ReplaceAllString(formatExportCellText(value), "_")
+		if text = strings.Trim(text, "._"); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	if len(parts) == 0 || len(parts) != len(pkColumns) {
+		return "row_" + strconv.Itoa(rowIndex+1)
+	}
+	name := []rune(strings.Join(parts, "_"))
+	if len(name) > 120 {
+		name = name[:120]
+	}
+	return string(name)
+}
+
+// primaryKeyColumnsFromIndexes 在列信息未标注主键的驱动上，按主键索引命名习惯（PRIMARY/*_pkey/PK_*）识别主键列。
+func primaryKeyColumnsFromIndexes(dbInst db.Database, schemaName string, tableName string) []string {
+	indexes, err := dbInst.GetIndexes(schemaName, tableName)
+	if err != nil {
+		return nil
+	}
+	grouped := groupIndexColumns(indexes)
+	for name, columns := range grouped {
+		lower := strings.ToLower(name)
+		if lower == "primary" || strings.HasSuffix(lower, "_pkey") || strings.HasPrefix(lower, "pk_") {
+			return columns
+		}
+	}
+	return nil
+}
+
+// exportBinaryColumnsToFiles 将二进制列写为旁路文件，并把 data 中对应值替换为相对导出文件的路径。返回写入的文件数。
+func exportBinaryColumnsToFiles(dbInst db.Database, config connection.ConnectionConfig, dbName string, tableName string, exportPath string, data []map[string]interface{}) (int, error) {
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	defs, err := dbInst.GetColumns(schemaName, pureTableName)
+	if err != nil {
+		return 0, fmt.Errorf("读取列信息失败：%w", err)
+	}
+	var binaryColumns, pkColumns []string
+	for _, def := range defs {
+		if isBinaryColumnType(def.Type) {
+			binaryColumns = append(binaryColumns, def.Name)
+		}
+		if strings.EqualFold(def.Key, "PRI") {
+			pkColumns = append(pkColumns, def.Name)
+		}
+	}
+	if len(binaryColumns) == 0 {
+		return 0, nil
+	}
+	if len(pkColumns) == 0 {
+		pkColumns = primaryKeyColumnsFromIndexes(dbInst, schemaName, pureTableName)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(exportPath), filepath.Ext(exportPath))
+	sidecarDirName := base + "_files"
+	sidecarRoot := filepath.Join(filepath.Dir(exportPath), sidecarDirName)
+
+	written := 0
+	used := make(map[string]int)
+	for rowIndex, row := range data {
+		baseName := sidecarBaseName(row, pkColumns, rowIndex)
+		for _, col := range binaryColumns {
+			content, ok := binaryValueBytes(row[col])
+			if !ok {
+				continue
+			}
+			colDir := sidecarUnsafeChars.ReplaceAllString(col, "_")
+			name := baseName + sidecarExtension(content)
+			// 主键截断或规范化后可能重名，追加序号避免覆盖
+			key := colDir + "/" + name
+			if n := used[key]; n > 0 {
+				name = fmt.Sprintf("%s_%d%s", baseName, n+1, sidecarExtension(content))
+			}
+			used[key]++
+
+			if err := os.MkdirAll(filepath.Join(sidecarRoot, colDir), 0o755); err != nil {
+				return written, fmt.Errorf("创建目录失败：%w", err)
+			}
+			if err := os.WriteFile(filepath.Join(sidecarRoot, colDir, name), content, 0o644); err != nil {
+				return written, fmt.Errorf("写入二进制文件失败：%w", err)
+			}
+			row[col] = sidecarDirName + "/" + colDir + "/" + name
+			written++
+		}
+	}
+	logger.Infof("导出二进制列为文件：%s 表=%s 列=%v 文件数=%d 目录=%s", formatConnSummary(config), tableName, binaryColumns, written, sidecarRoot)
+	return written, nil
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBinaryValueBytes(t *testing.T) {
+	got, ok := binaryValueBytes("0x89504e47")
+	if !ok || !bytes.Equal(got, []byte{0x89, 0x50, 0x4e, 0x47}) {
+		t.Fatalf("hex value decoded to %v", got)
+	}
+	got, ok = binaryValueBytes("plain text")
+	if !ok || string(got) != "plain text" {
+		t.Fatalf("text value decoded to %q", got)
+	}
+	if _, ok := binaryValueBytes(nil); ok {
+		t.Fatal("nil value should be skipped")
+	}
+}
+
+func TestSidecarBaseName(t *testing.T) {
+	row := map[string]interface{}{"id": int64(42), "region": "cn/east"}
+	if got := sidecarBaseName(row, []string{"region", "id"}, 0); got != "cn_east_42" {
+		t.Fatalf("composite key name = %q", got)
+	}
+	if got := sidecarBaseName(row, nil, 4); got != "row_5" {
+		t.Fatalf("fallback name = %q", got)
+	}
+	if got := sidecarBaseName(map[string]interface{}{"id": nil}, []string{"id"}, 0); got != "row_1" {
+		t.Fatalf("null key name = %q", got)
+	}
+}
+
+func TestIsBinaryColumnType(t *testing.T) {
+	for _, typ := range []string{"longblob", "VARBINARY(16)", "bytea", "image", "RAW(2000)"} {
+		if !isBinaryColumnType(typ) {
+			t.Errorf("%s should be binary", typ)
+		}
+	}
+	for _, typ := range []string{"bit(1)", "varchar(20)", "text"} {
+		if isBinaryColumnType(typ) {
+			t.Errorf("%s should not be binary", typ)
+		}
+	}
+}
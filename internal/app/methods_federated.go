@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/ssh"
+	"GoNavi-Wails/internal/utils"
+)
+
+var federatedAliasPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}$`)
+
+// FederatedSource 联邦查询中的一个数据源，在 SQL 中以 Alias 作为 catalog 引用（如 alias.schema.table）。
+type FederatedSource struct {
+	Alias    string                      `json:"alias"`
+	Config   connection.ConnectionConfig `json:"config"`
+	Database string                      `json:"database,omitempty"`
+}
+
+// FederatedQuery 在临时内存 DuckDB 会话中以只读方式 ATTACH 多个 MySQL/PostgreSQL/SQLite/DuckDB 数据源，
+// 然后执行一条可跨源 JOIN 的查询。会话在查询结束后立即销毁。
+func (a *App) FederatedQuery(sources []FederatedSource, query string) connection.QueryResult {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return connection.QueryResult{Success: false, Message: "查询语句不能为空"}
+	}
+	if len(sources) == 0 {
+		return connection.QueryResult{Success: false, Message: "请至少选择一个数据源"}
+	}
+	if supported, reason := db.DriverRuntimeSupportStatus("duckdb"); !supported {
+		return connection.QueryResult{Success: false, Message: reason}
+	}
+
+	statements := make([]string, 0, len(sources)*2)
+	loaded := map[string]bool{}
+	var forwarders []string
+	seenAlias := map[string]struct{}{}
+	for _, src := range sources {
+		alias := strings.TrimSpace(src.Alias)
+		if !federatedAliasPattern.MatchString(alias) {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("数据源别名 %q 不合法（仅允许字母、数字、下划线）", alias)}
+		}
+		if _, dup := seenAlias[strings.ToLower(alias)]; dup {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("数据源别名 %s 重复", alias)}
+		}
+		seenAlias[strings.ToLower(alias)] = struct{}{}
+
+		config, err := resolveFederatedEndpoint(src.Config)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("数据源 %s：%s", alias, err.Error())}
+		}
+		if config.UseSSH {
+			forwarders = append(forwarders, alias)
+		}
+		extension, attachSQL, err := buildFederatedAttachSQL(alias, config, src.Database)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("数据源 %s：%s", alias, err.Error())}
+		}
+		if extension != "" && !loaded[extension] {
+			loaded[extension] = true
+			statements = append(statements, fmt.Sprintf("INSTALL %s", extension), fmt.Sprintf("LOAD %s", extension))
+		}
+		statements = append(statements, attachSQL)
+	}
+
+	session, err := db.NewDatabase("duckdb")
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if err := session.Connect(connection.ConnectionConfig{Type: "duckdb", Host: ":memory:"}); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	defer session.Close()
+
+	for _, stmt := range statements {
+		if _, err := session.Exec(stmt); err != nil {
+			logger.Error(err, "联邦查询初始化失败：语句=%q", federatedRedact(stmt))
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("挂载数据源失败：%s", normalizeErrorMessage(err))}
+		}
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(5 * time.Minute)
+	defer cancel()
+	var data []map[string]interface{}
+	var columns []string
+	if q, ok := session.(interface {
+		QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+	}); ok {
+		data, columns, err = q.QueryContext(ctx, query)
+	} else {
+		data, columns, err = session.Query(query)
+	}
+	if err != nil {
+		logger.Error(err, "联邦查询执行失败：数据源数=%d SQL片段=%q", len(sources), sqlSnippet(query))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	logger.Infof("联邦查询完成：数据源数=%d SSH转发=%v 返回行数=%d", len(sources), forwarders, len(data))
+	return connection.QueryResult{Success: true, Data: data, Fields: columns}
+}
+
+// resolveFederatedEndpoint 还原自定义类型并在启用 SSH 时改写为本地转发地址（DuckDB 扫描器只能直连）。
+func resolveFederatedEndpoint(config connection.ConnectionConfig) (connection.ConnectionConfig, error) {
+	config = applyCustomDriverType(config)
+	if !config.UseSSH {
+		return config, nil
+	}
+	forwarder, err := ssh.GetOrCreateLocalForwarder(config.SSH, config.Host, config.Port)
+	if err != nil {
+		return config, fmt.Errorf("创建 SSH 隧道失败：%w", err)
+	}
+	host, portText, err := splitFederatedHostPort(forwarder.LocalAddr)
+	if err != nil {
+		return config, err
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return config, fmt.Errorf("解析本地端口失败：%w", err)
+	}
+	config.Host = host
+	config.Port = port
+	return config, nil
+}
+
+func splitFederatedHostPort(addr string) (string, string, error) {
+	idx := strings.LastIndex(addr, ":")
+	if idx <= 0 || idx == len(addr)-1 {
+		return "", "", fmt.Errorf("解析本地转发地址失败：%s", addr)
+	}
+	return strings.Trim(addr[:idx], "[]"), addr[idx+1:], nil
+}
+
+// buildFederatedAttachSQL 返回所需的 DuckDB 扩展名与 ATTACH 语句。
+func buildFederatedAttachSQL(alias string, config connection.ConnectionConfig, database string) (string, string, error) {
+	dbType := resolveDDLDBType(config)
+	dbName := strings.TrimSpace(database)
+	if dbName == "" {
+		dbName = strings.TrimSpace(config.Database)
+	}
+	quotedAlias := quoteIdentByType("duckdb", alias)
+
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		port := config.Port
+		if port <= 0 {
+			port = 3306
+		}
+		dsn := buildFederatedKeyValueDSN([][2]string{
+			{"host", config.Host},
+			{"port", strconv.Itoa(port)},
+			{"user", config.User},
+			{"password", config.Password},
+			{"database", dbName},
+		})
+		return "mysql", fmt.Sprintf("ATTACH %s AS %s (TYPE mysql, READ_ONLY)", dialect.QuoteLiteral("duckdb", dsn), quotedAlias), nil
+	case "postgres", "kingbase", "highgo", "vastbase":
+		port := config.Port
+		if port <= 0 {
+			port = 5432
+		}
+		if dbName == "" {
+			dbName = "postgres"
+		}
+		dsn := buildFederatedKeyValueDSN([][2]string{
+			{"host", config.Host},
+			{"port", strconv.Itoa(port)},
+			{"user", config.User},
+			{"password", config.Password},
+			{"dbname", dbName},
+		})
+		return "postgres", fmt.Sprintf("ATTACH %s AS %s (TYPE postgres, READ_ONLY)", dialect.QuoteLiteral("duckdb", dsn), quotedAlias), nil
+	case "sqlite":
+		path := strings.TrimSpace(config.Host)
+		if path == "" {
+			path = strings.TrimSpace(config.Database)
+		}
+		if path == "" {
+			return "", "", fmt.Errorf("SQLite 数据源缺少文件路径")
+		}
+		return "sqlite", fmt.Sprintf("ATTACH %s AS %s (TYPE sqlite, READ_ONLY)", dialect.QuoteLiteral("duckdb", path), quotedAlias), nil
+	case "duckdb":
+		path := strings.TrimSpace(config.Host)
+		if path == "" {
+			path = strings.TrimSpace(config.Database)
+		}
+		if path == "" || path == ":memory:" {
+			return "", "", fmt.Errorf("DuckDB 数据源需要数据库文件路径")
+		}
+		return "", fmt.Sprintf("ATTACH %s AS %s (READ_ONLY)", dialect.QuoteLiteral("duckdb", path), quotedAlias), nil
+	default:
+		return "", "", fmt.Errorf("暂不支持 %s 类型参与联邦查询（支持 MySQL/PostgreSQL/SQLite/DuckDB）", dbType)
+	}
+}
+
+// buildFederatedKeyValueDSN 生成 libpq 风格的 key=value 连接串，含空格/引号/反斜杠的值使用单引号包裹并转义。
+func buildFederatedKeyValueDSN(pairs [][2]string) string {
+	parts := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		value := pair[1]
+		if value == "" {
+			continue
+		}
+		if strings.ContainsAny(value, " '\\\t") {
+			value = "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+		}
+		parts = append(parts, pair[0]+"="+value)
+	}
+	return strings.Join(parts, " ")
+}
+
+var federatedPasswordPattern = regexp.MustCompile(`password=('(?:[^'\\]|\\.)*'|\S+)`)
+
+// federatedRedact 日志输出前隐藏连接串中的密码。
+func federatedRedact(stmt string) string {
+	return federatedPasswordPattern.ReplaceAllString(stmt, "password=***")
+}
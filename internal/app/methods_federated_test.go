@@ -0,0 +1,54 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBuildFederatedAttachSQL(t *testing.T) {
+	ext, sql, err := buildFederatedAttachSQL("src", connection.ConnectionConfig{Type: "mysql", Host: "10.0.0.1", User: "root", Password: "p w'd", Database: "shop"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ext != "mysql" || sql != `ATTACH 'host=10.0.0.1 port=3306 user=root password=''p w\''d'' database=shop' AS "src" (TYPE mysql, READ_ONLY)` {
+		t.Fatalf("mysql attach = %s %s", ext, sql)
+	}
+
+	ext, sql, err = buildFederatedAttachSQL("pg", connection.ConnectionConfig{Type: "postgres", Host: "db", Port: 6432, User: "u"}, "")
+	if err != nil || ext != "postgres" || sql != `ATTACH 'host=db port=6432 user=u dbname=postgres' AS "pg" (TYPE postgres, READ_ONLY)` {
+		t.Fatalf("postgres attach = %s %s %v", ext, sql, err)
+	}
+
+	ext, sql, err = buildFederatedAttachSQL("lite", connection.ConnectionConfig{Type: "sqlite", Host: "/data/it's.db"}, "")
+	if err != nil || ext != "sqlite" || sql != `ATTACH '/data/it''s.db' AS "lite" (TYPE sqlite, READ_ONLY)` {
+		t.Fatalf("sqlite attach = %s %s %v", ext, sql, err)
+	}
+
+	if _, _, err := buildFederatedAttachSQL("mem", connection.ConnectionConfig{Type: "duckdb", Host: ":memory:"}, ""); err == nil {
+		t.Fatal("expected error for in-memory duckdb source")
+	}
+	if _, _, err := buildFederatedAttachSQL("ora", connection.ConnectionConfig{Type: "oracle"}, ""); err == nil {
+		t.Fatal("expected unsupported source error")
+	}
+}
+
+func TestFederatedRedact(t *testing.T) {
+	stmt := `ATTACH 'host=h password='secret pw' user=u' AS "a"`
+	if got := federatedRedact(stmt); strings.Contains(got, "secret") || !strings.Contains(got, "password=***") {
+		t.Fatalf("redacted = %s", got)
+	}
+	if got := federatedRedact("ATTACH 'host=h password=plain user=u'"); got != "ATTACH 'host=h password=*** user=u'" {
+		t.Fatalf("redacted = %s", got)
+	}
+}
+
+func TestSplitFederatedHostPort(t *testing.T) {
+	if host, port, err := splitFederatedHostPort("[::1]:15432"); err != nil || host != "::1" || port != "15432" {
+		t.Fatalf("split = %q %q %v", host, port, err)
+	}
+	if _, _, err := splitFederatedHostPort("127.0.0.1:"); err == nil {
+		t.Fatal("expected error for missing port")
+	}
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"bufio"
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -14,6 +15,9 @@ import (
 
 	"GoNavi-Wails/internal/connection"
 	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 	"github.com/xuri/excelize/v2"
@@ -79,12 +83,31 @@ func (a *App) ImportConfigFile() connection.QueryResult {
 
 // PreviewImportFile 解析导入文件，返回字段列表、总行数、前 5 行预览数据
 func (a *App) PreviewImportFile(filePath string) connection.QueryResult {
+	return a.PreviewImportFileWithOptions(filePath, ImportFileOptions{})
+}
+
+// PreviewImportFileWithOptions 同 PreviewImportFile，可指定 Excel 工作表与表头行；Excel 文件额外返回工作表列表与识别出的表头行。
+func (a *App) PreviewImportFileWithOptions(filePath string, options ImportFileOptions) connection.QueryResult {
 	if filePath == "" {
 		return connection.QueryResult{Success: false, Message: "File path required"}
 	}
 
-	rows, columns, err := parseImportFile(filePath)
+	var sheetInfo *importSheetInfo
+	var rows []map[string]interface{}
+	var columns []string
+	var err error
+	if lower := strings.ToLower(filePath); strings.HasSuffix(lower, ".xlsx") || strings.HasSuffix(lower, ".xls") {
+		var info importSheetInfo
+		rows, columns, info, err = parseImportXLSXSheet(filePath, "NULL", options)
+		sheetInfo = &info
+	} else {
+		rows, columns, err = parseImportFile(filePath)
+	}
 	if err != nil {
+		if sheetInfo != nil && len(sheetInfo.Sheets) > 0 {
+			// 仍返回工作表列表，便于前端切换到其他工作表
+			return connection.QueryResult{Success: false, Message: err.Error(), Data: map[string]interface{}{"sheets": sheetInfo.Sheets}}
+		}
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
@@ -100,11 +123,19 @@ func (a *App) PreviewImportFile(filePath string) connection.QueryResult {
 		"previewRows": previewRows,
 		"filePath":    filePath,
 	}
+	if sheetInfo != nil {
+		result["sheets"] = sheetInfo.Sheets
+		result["sheet"] = sheetInfo.Sheet
+		result["headerRow"] = sheetInfo.HeaderRow
+	}
 
 	return connection.QueryResult{Success: true, Data: result}
 }
 
 func (a *App) ImportData(config connection.ConnectionConfig, dbName, tableName string) connection.QueryResult {
+	if err := ensureWritable(config, "导入数据"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
 		Title: fmt.Sprintf("Import into %s", tableName),
 		Filters: []runtime.FileFilter{
@@ -129,6 +160,12 @@ func (a *App) ImportData(config connection.ConnectionConfig, dbName, tableName s
 
 // parseImportFile 解析导入文件，返回数据行和列名
 func parseImportFile(filePath string) ([]map[string]interface{}, []string, error) {
+	return parseImportFileWithOptions(filePath, "NULL", ImportFileOptions{})
+}
+
+// parseImportFileWithOptions 解析导入文件，CSV/Excel 中等于 nullToken 的单元格解析为 NULL；nullToken 为空时保留原文。
+// fileOptions 仅对 Excel 生效。
+func parseImportFileWithOptions(filePath string, nullToken string, fileOptions ImportFileOptions) ([]map[string]interface{}, []string, error) {
 	var rows []map[string]interface{}
 	var columns []string
 	lower := strings.ToLower(filePath)
@@ -167,7 +204,7 @@ func parseImportFile(filePath string) ([]map[string]interface{}, []string, error
 			row := make(map[string]interface{})
 			for i, val := range record {
 				if i < len(columns) {
-					if val == "NULL" {
+					if nullToken != "" && val == nullToken {
 						row[columns[i]] = nil
 					} else {
 						row[columns[i]] = val
@@ -177,41 +214,7 @@ func parseImportFile(filePath string) ([]map[string]interface{}, []string, error
 			rows = append(rows, row)
 		}
 	} else if strings.HasSuffix(lower, ".xlsx") || strings.HasSuffix(lower, ".xls") {
-		xlsx, err := excelize.OpenFile(filePath)
-		if err != nil {
-			return nil, nil, fmt.Errorf("Excel Parse Error: %w", err)
-		}
-		defer xlsx.Close()
-
-		sheetName := xlsx.GetSheetName(0)
-		if sheetName == "" {
-			return nil, nil, fmt.Errorf("Excel file has no sheets")
-		}
-
-		xlRows, err := xlsx.GetRows(sheetName)
-		if err != nil {
-			return nil, nil, fmt.Errorf("Excel Read Error: %w", err)
-		}
-		if len(xlRows) < 2 {
-			return nil, nil, fmt.Errorf("Excel empty or missing header")
-		}
-
-		columns = xlRows[0]
-		for _, record := range xlRows[1:] {
-			row := make(map[string]interface{})
-			for i, val := range record {
-				if i < len(columns) && columns[i] != "" {
-					if val == "NULL" {
-						row[columns[i]] = nil
-					} else {
-						row[columns[i]] = val
-					}
-				}
-			}
-			if len(row) > 0 {
-				rows = append(rows, row)
-			}
-		}
+		return parseImportXLSX(filePath, nullToken, fileOptions)
 	} else {
 		return nil, nil, fmt.Errorf("Unsupported file format")
 	}
@@ -363,79 +366,77 @@ func formatImportSQLValue(dbType, columnType string, value interface{}) string {
 
 // ImportDataWithProgress 执行导入并发送进度事件
 func (a *App) ImportDataWithProgress(config connection.ConnectionConfig, dbName, tableName, filePath string) connection.QueryResult {
-	rows, columns, err := parseImportFile(filePath)
-	if err != nil {
-		return connection.QueryResult{Success: false, Message: err.Error()}
-	}
-
-	if len(rows) == 0 {
-		return connection.QueryResult{Success: true, Message: "No data to import"}
-	}
-
-	runConfig := normalizeRunConfig(config, dbName)
-	dbInst, err := a.getDatabase(runConfig)
-	if err != nil {
-		return connection.QueryResult{Success: false, Message: err.Error()}
-	}
-
-	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
-	columnTypeMap := map[string]string{}
-	if defs, colErr := dbInst.GetColumns(schemaName, pureTableName); colErr == nil {
-		columnTypeMap = buildImportColumnTypeMap(defs)
-	}
-
-	totalRows := len(rows)
-	successCount := 0
-	var errorLogs []string
+	return a.ImportDataWithOptions(config, dbName, tableName, filePath, ImportOptions{})
+}
 
+func buildImportInsertStatements(dbType, tableName string, columns []string, rows []map[string]interface{}, columnTypeMap map[string]string) []string {
 	quotedCols := make([]string, len(columns))
 	for i, c := range columns {
-		quotedCols[i] = quoteIdentByType(runConfig.Type, c)
+		quotedCols[i] = quoteIdentByType(dbType, c)
 	}
 
-	for idx, row := range rows {
+	statements := make([]string, 0, len(rows))
+	for _, row := range rows {
 		var values []string
 		for _, col := range columns {
 			val := row[col]
 			colType := columnTypeMap[normalizeColumnName(col)]
-			values = append(values, formatImportSQLValue(runConfig.Type, colType, val))
+			values = append(values, formatImportSQLValue(dbType, colType, val))
 		}
 
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
-			quoteQualifiedIdentByType(runConfig.Type, tableName),
+		statements = append(statements, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			quoteQualifiedIdentByType(dbType, tableName),
 			strings.Join(quotedCols, ", "),
-			strings.Join(values, ", "))
-
-		_, err := dbInst.Exec(query)
-		if err != nil {
-			errorLogs = append(errorLogs, fmt.Sprintf("Row %d: %s", idx+1, err.Error()))
-		} else {
-			successCount++
-		}
+			strings.Join(values, ", ")))
+	}
+	return statements
+}
 
-		// 每 10 行发送一次进度事件
-		if (idx+1)%10 == 0 || idx == totalRows-1 {
-			runtime.EventsEmit(a.ctx, "import:progress", map[string]interface{}{
-				"current": idx + 1,
-				"total":   totalRows,
-				"success": successCount,
-				"errors":  len(errorLogs),
-			})
+// PreviewImportDataPlan 解析导入文件并登记为变更计划，由用户确认后执行或复制。计划中的语句即 ImportDataWithOptions 实际执行的语句：
+// 支持参数绑定的数据源为按批的参数化 INSERT（值在执行时绑定，确认后同样整体提交或回滚），其余为逐行字面量 INSERT。
+func (a *App) PreviewImportDataPlan(config connection.ConnectionConfig, dbName, tableName, filePath string, options ImportOptions) connection.QueryResult {
+	job, done, ok := a.loadImportJob(config, dbName, tableName, filePath, options)
+	if !ok {
+		if done.Success {
+			return connection.QueryResult{Success: false, Message: done.Message}
 		}
+		return done
 	}
-
-	result := map[string]interface{}{
-		"success":      successCount,
-		"failed":       len(errorLogs),
-		"total":        totalRows,
-		"errorLogs":    errorLogs,
-		"errorSummary": fmt.Sprintf("Imported: %d, Failed: %d", successCount, len(errorLogs)),
+	req := SQLPlanRequest{
+		Config: config,
+		DBName: dbName,
+		Source: "import",
+		Title:  fmt.Sprintf("导入数据到 %s", tableName),
+	}
+	if job.querier == nil {
+		req.Statements = buildImportInsertStatements(job.plan.dbType, tableName, job.plan.columns, job.plan.rows, job.plan.columnTypeMap)
+		return a.PreviewSQLPlan(req)
 	}
 
-	return connection.QueryResult{Success: true, Data: result, Message: fmt.Sprintf("Imported: %d, Failed: %d", successCount, len(errorLogs))}
+	req.Statements = job.plan.statements()
+	req.StopOnError = true
+	preview, err := a.registerSQLPlan(req, func(plan *sqlPlan) SQLPlanReport {
+		summary, err := a.runImportJob(context.Background(), job)
+		if err != nil {
+			summary = importBatchSummary{errorLogs: []string{err.Error()}}
+		}
+		return importPlanReport(plan.statements, job.plan.dialect.rowsPerStatement(job.plan.batchRows, len(job.plan.columns)), summary)
+	})
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: "OK", Data: preview}
 }
 
 func (a *App) ApplyChanges(config connection.ConnectionConfig, dbName, tableName string, changes connection.ChangeSet) connection.QueryResult {
+	return a.ApplyChangesWithOptions(config, dbName, tableName, changes, ApplyChangesOptions{})
+}
+
+// ApplyChangesWithOptions 在一个事务中提交表格编辑；提交前读取受影响行的原值，成功后在 Data 中返回撤销信息（ChangeUndo）。
+func (a *App) ApplyChangesWithOptions(config connection.ConnectionConfig, dbName, tableName string, changes connection.ChangeSet, options ApplyChangesOptions) connection.QueryResult {
+	if err := ensureWritable(config, "提交数据修改"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 	runConfig := normalizeRunConfig(config, dbName)
 
 	dbInst, err := a.getDatabase(runConfig)
@@ -444,17 +445,87 @@ func (a *App) ApplyChanges(config connection.ConnectionConfig, dbName, tableName
 	}
 
 	if applier, ok := dbInst.(db.BatchApplier); ok {
-		err := applier.ApplyChanges(tableName, changes)
+		changes, skipped, defs := prepareChangeSet(dbInst, runConfig, dbName, tableName, changes)
+		if len(skipped) > 0 {
+			logger.Infof("ApplyChanges 跳过只读列：表=%s 列=%s", tableName, strings.Join(skipped, ","))
+		}
+		var undo *ChangeUndo
+		err := a.withSQLiteWriteLock(runConfig, func() error {
+			undo = captureChangeUndo(dbInst, runConfig, dbName, tableName, changes, defs)
+			return applier.ApplyChanges(tableName, changes)
+		})
 		if err != nil {
 			return connection.QueryResult{Success: false, Message: err.Error()}
 		}
-		return connection.QueryResult{Success: true, Message: "事务提交成功"}
+		if undo != nil && options.SaveUndo && undo.Script != "" {
+			if path, err := writeChangeUndo(dbName, tableName, undo.Script); err != nil {
+				logger.Error(err, "保存撤销脚本失败：%s 表=%s", formatConnSummary(runConfig), tableName)
+				undo.Warnings = append(undo.Warnings, "保存撤销脚本失败："+err.Error())
+			} else {
+				undo.Path = path
+			}
+		}
+		return connection.QueryResult{Success: true, Message: "事务提交成功", Data: undo}
 	}
 
 	return connection.QueryResult{Success: false, Message: "当前数据库类型不支持批量提交"}
 }
 
+// ChangePreview ApplyChanges 将执行的语句。Script 为内联参数后的完整脚本，便于复核与复制；
+// Skipped 为提交时会被剔除的只读列。
+type ChangePreview struct {
+	Statements []db.ChangeStatement `json:"statements"`
+	Script     string               `json:"script"`
+	Skipped    []string             `json:"skipped,omitempty"`
+}
+
+// PreviewChanges 返回 ApplyChanges 对同一 ChangeSet 将执行的语句（参数化 SQL 与参数），不修改数据。
+func (a *App) PreviewChanges(config connection.ConnectionConfig, dbName, tableName string, changes connection.ChangeSet) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	planner, ok := dbInst.(db.ChangePlanner)
+	if !ok {
+		return connection.QueryResult{Success: false, Message: "当前数据库类型不支持预览提交语句"}
+	}
+	changes, skipped, _ := prepareChangeSet(dbInst, runConfig, dbName, tableName, changes)
+	statements, err := planner.PlanChanges(tableName, changes)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if statements == nil {
+		statements = []db.ChangeStatement{}
+	}
+	var script strings.Builder
+	for _, stmt := range statements {
+		script.WriteString(stmt.Rendered)
+		script.WriteString(";\n")
+	}
+	return connection.QueryResult{Success: true, Data: ChangePreview{Statements: statements, Script: script.String(), Skipped: skipped}}
+}
+
+// prepareChangeSet 读取表的列定义并剔除生成列与 GENERATED ALWAYS 标识列：这些列由数据库计算，显式写入会直接报错。
+// 读取列定义失败时原样返回，defs 为 nil。
+func prepareChangeSet(dbInst db.Database, runConfig connection.ConnectionConfig, dbName, tableName string, changes connection.ChangeSet) (connection.ChangeSet, []string, []connection.ColumnDefinition) {
+	schemaName, pureTableName := normalizeSchemaAndTable(runConfig, dbName, tableName)
+	defs, err := dbInst.GetColumns(schemaName, pureTableName)
+	if err != nil {
+		return changes, nil, nil
+	}
+	changes, skipped := stripReadOnlyColumns(changes, readOnlyColumns(defs))
+	return changes, skipped, defs
+}
+
 func (a *App) ExportTable(config connection.ConnectionConfig, dbName string, tableName string, format string) connection.QueryResult {
+	return a.ExportTableWithOptions(config, dbName, tableName, format, TableExportOptions{})
+}
+
+// ExportTableWithOptions 导出整表；BinaryAsFiles 为 true 时二进制列逐值写入旁路文件，导出文件中只保留相对路径。
+func (a *App) ExportTableWithOptions(config connection.ConnectionConfig, dbName string, tableName string, format string, options TableExportOptions) connection.QueryResult {
 	filename, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
 		Title:           fmt.Sprintf("Export %s", tableName),
 		DefaultFilename: fmt.Sprintf("%s.%s", tableName, format),
@@ -472,6 +543,9 @@ func (a *App) ExportTable(config connection.ConnectionConfig, dbName string, tab
 	}
 
 	format = strings.ToLower(format)
+	if format == "sql" && options.BinaryAsFiles {
+		return connection.QueryResult{Success: false, Message: "SQL 格式不支持将二进制列导出为文件"}
+	}
 	if format == "sql" {
 		f, err := os.Create(filename)
 		if err != nil {
@@ -495,23 +569,34 @@ func (a *App) ExportTable(config connection.ConnectionConfig, dbName string, tab
 		return connection.QueryResult{Success: true, Message: "Export successful"}
 	}
 
-	query := fmt.Sprintf("SELECT * FROM %s", quoteQualifiedIdentByType(runConfig.Type, tableName))
+	query := fmt.Sprintf("SELECT * FROM %s", quoteQualifiedIdentByType(resolveDDLDBType(runConfig), tableName))
 
 	data, columns, err := dbInst.Query(query)
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 
+	message := "Export successful"
+	if options.BinaryAsFiles {
+		written, err := exportBinaryColumnsToFiles(dbInst, runConfig, dbName, tableName, filename, data)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: "Write error: " + err.Error()}
+		}
+		if written > 0 {
+			message = fmt.Sprintf("Export successful (%d binary files)", written)
+		}
+	}
+
 	f, err := os.Create(filename)
 	if err != nil {
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 	defer f.Close()
-	if err := writeRowsToFile(f, data, columns, format); err != nil {
+	if err := writeRowsToFileWithLocale(f, data, columns, format, options.Locale); err != nil {
 		return connection.QueryResult{Success: false, Message: "Write error: " + err.Error()}
 	}
 
-	return connection.QueryResult{Success: true, Message: "Export successful"}
+	return connection.QueryResult{Success: true, Message: message}
 }
 
 func (a *App) ExportTablesSQL(config connection.ConnectionConfig, dbName string, tableNames []string, includeData bool) connection.QueryResult {
@@ -650,45 +735,11 @@ func (a *App) ExportDatabaseSQL(config connection.ConnectionConfig, dbName strin
 }
 
 func quoteIdentByType(dbType string, ident string) string {
-	if ident == "" {
-		return ident
-	}
-
-	switch dbType {
-	case "mysql", "mariadb", "diros", "sphinx", "tdengine":
-		return "`" + strings.ReplaceAll(ident, "`", "``") + "`"
-	case "sqlserver":
-		escaped := strings.ReplaceAll(ident, "]", "]]")
-		return "[" + escaped + "]"
-	default:
-		return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
-	}
+	return dialect.QuoteIdent(dbType, ident)
 }
 
 func quoteQualifiedIdentByType(dbType string, ident string) string {
-	raw := strings.TrimSpace(ident)
-	if raw == "" {
-		return raw
-	}
-
-	parts := strings.Split(raw, ".")
-	if len(parts) <= 1 {
-		return quoteIdentByType(dbType, raw)
-	}
-
-	quotedParts := make([]string, 0, len(parts))
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			continue
-		}
-		quotedParts = append(quotedParts, quoteIdentByType(dbType, part))
-	}
-
-	if len(quotedParts) == 0 {
-		return quoteIdentByType(dbType, raw)
-	}
-	return strings.Join(quotedParts, ".")
+	return dialect.QuoteQualified(dbType, ident)
 }
 
 func writeSQLHeader(w *bufio.Writer, config connection.ConnectionConfig, dbName string) error {
@@ -702,7 +753,7 @@ func writeSQLHeader(w *bufio.Writer, config connection.ConnectionConfig, dbName
 		}
 	}
 
-	if strings.ToLower(strings.TrimSpace(config.Type)) == "mysql" && strings.TrimSpace(dbName) != "" {
+	if dialect.IsMySQLFamily(resolveDDLDBType(config)) && strings.TrimSpace(dbName) != "" {
 		if _, err := w.WriteString(fmt.Sprintf("USE %s;\n\n", quoteIdentByType("mysql", dbName))); err != nil {
 			return err
 		}
@@ -715,7 +766,7 @@ func writeSQLHeader(w *bufio.Writer, config connection.ConnectionConfig, dbName
 }
 
 func writeSQLFooter(w *bufio.Writer, config connection.ConnectionConfig) error {
-	if strings.ToLower(strings.TrimSpace(config.Type)) == "mysql" {
+	if dialect.IsMySQLFamily(resolveDDLDBType(config)) {
 		if _, err := w.WriteString("\nSET FOREIGN_KEY_CHECKS=1;\n"); err != nil {
 			return err
 		}
@@ -790,16 +841,15 @@ func formatSQLValue(dbType string, v interface{}) string {
 		if (strings.ToLower(strings.TrimSpace(dbType)) == "mysql" || strings.ToLower(strings.TrimSpace(dbType)) == "diros") && isMySQLHexLiteral(val) {
 			return val
 		}
-		escaped := strings.ReplaceAll(val, "'", "''")
-		return "'" + escaped + "'"
+		return dialect.QuoteLiteral(dbType, val)
 	default:
-		escaped := strings.ReplaceAll(fmt.Sprintf("%v", v), "'", "''")
-		return "'" + escaped + "'"
+		return dialect.QuoteLiteral(dbType, fmt.Sprintf("%v", v))
 	}
 }
 
 func dumpTableSQL(w *bufio.Writer, dbInst db.Database, config connection.ConnectionConfig, dbName, tableName string, includeSchema bool, includeData bool) error {
 	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	dbType := resolveDDLDBType(config)
 
 	if _, err := w.WriteString("\n-- ----------------------------\n"); err != nil {
 		return err
@@ -816,7 +866,7 @@ func dumpTableSQL(w *bufio.Writer, dbInst db.Database, config connection.Connect
 		if err != nil {
 			return err
 		}
-		if _, err := w.WriteString(ensureSQLTerminator(createSQL)); err != nil {
+		if _, err := w.WriteString(ensureSQLTerminator(normalizeDDL(dbType, createSQL))); err != nil {
 			return err
 		}
 		if _, err := w.WriteString("\n\n"); err != nil {
@@ -829,7 +879,7 @@ func dumpTableSQL(w *bufio.Writer, dbInst db.Database, config connection.Connect
 	}
 
 	qualified := qualifyTable(schemaName, pureTableName)
-	selectSQL := fmt.Sprintf("SELECT * FROM %s", quoteQualifiedIdentByType(config.Type, qualified))
+	selectSQL := fmt.Sprintf("SELECT * FROM %s", quoteQualifiedIdentByType(dbType, qualified))
 	data, columns, err := dbInst.Query(selectSQL)
 	if err != nil {
 		return err
@@ -843,14 +893,14 @@ func dumpTableSQL(w *bufio.Writer, dbInst db.Database, config connection.Connect
 
 	quotedCols := make([]string, 0, len(columns))
 	for _, c := range columns {
-		quotedCols = append(quotedCols, quoteIdentByType(config.Type, c))
+		quotedCols = append(quotedCols, quoteIdentByType(dbType, c))
 	}
-	quotedTable := quoteQualifiedIdentByType(config.Type, qualified)
+	quotedTable := quoteQualifiedIdentByType(dbType, qualified)
 
 	for _, row := range data {
 		values := make([]string, 0, len(columns))
 		for _, c := range columns {
-			values = append(values, formatSQLValue(config.Type, row[c]))
+			values = append(values, formatSQLValue(dbType, row[c]))
 		}
 		if _, err := w.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);\n", quotedTable, strings.Join(quotedCols, ", "), strings.Join(values, ", "))); err != nil {
 			return err
@@ -862,6 +912,11 @@ func dumpTableSQL(w *bufio.Writer, dbInst db.Database, config connection.Connect
 
 // ExportData exports provided data to a file
 func (a *App) ExportData(data []map[string]interface{}, columns []string, defaultName string, format string) connection.QueryResult {
+	return a.ExportDataWithLocale(data, columns, defaultName, format, ExportLocaleOptions{})
+}
+
+// ExportDataWithLocale 同 ExportData，按 locale 格式化数字与日期。
+func (a *App) ExportDataWithLocale(data []map[string]interface{}, columns []string, defaultName string, format string, locale ExportLocaleOptions) connection.QueryResult {
 	if defaultName == "" {
 		defaultName = "export"
 	}
@@ -879,7 +934,7 @@ func (a *App) ExportData(data []map[string]interface{}, columns []string, defaul
 		return connection.QueryResult{Success: false, Message: err.Error()}
 	}
 	defer f.Close()
-	if err := writeRowsToFile(f, data, columns, format); err != nil {
+	if err := writeRowsToFileWithLocale(f, data, columns, format, locale); err != nil {
 		return connection.QueryResult{Success: false, Message: "Write error: " + err.Error()}
 	}
 
@@ -889,10 +944,18 @@ func (a *App) ExportData(data []map[string]interface{}, columns []string, defaul
 // ExportQuery exports by executing the provided SELECT query on backend side.
 // This avoids frontend IPC payload limits when exporting very large/long-text columns (e.g. base64).
 func (a *App) ExportQuery(config connection.ConnectionConfig, dbName string, query string, defaultName string, format string) connection.QueryResult {
+	return a.ExportQueryWithLocale(config, dbName, query, defaultName, format, ExportLocaleOptions{})
+}
+
+// ExportQueryWithLocale 同 ExportQuery，按 locale 格式化数字与日期。
+func (a *App) ExportQueryWithLocale(config connection.ConnectionConfig, dbName string, query string, defaultName string, format string, locale ExportLocaleOptions) connection.QueryResult {
 	query = strings.TrimSpace(query)
 	if query == "" {
 		return connection.QueryResult{Success: false, Message: "query required"}
 	}
+	if err := checkReadOnlySQL(config, query); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
 
 	if defaultName == "" {
 		defaultName = "export"
@@ -913,8 +976,7 @@ func (a *App) ExportQuery(config connection.ConnectionConfig, dbName string, que
 	}
 
 	query = sanitizeSQLForPgLike(runConfig.Type, query)
-	lowerQuery := strings.ToLower(strings.TrimSpace(query))
-	if !(strings.HasPrefix(lowerQuery, "select") || strings.HasPrefix(lowerQuery, "with")) {
+	if sqlstmt.Classify(runConfig.Type, query).Kind != sqlstmt.KindQuery {
 		return connection.QueryResult{Success: false, Message: "Only SELECT/WITH queries are supported"}
 	}
 
@@ -929,22 +991,26 @@ func (a *App) ExportQuery(config connection.ConnectionConfig, dbName string, que
 	}
 	defer f.Close()
 
-	if err := writeRowsToFile(f, data, columns, format); err != nil {
+	if err := writeRowsToFileWithLocale(f, data, columns, format, locale); err != nil {
 		return connection.QueryResult{Success: false, Message: "Write error: " + err.Error()}
 	}
 
 	return connection.QueryResult{Success: true, Message: "Export successful"}
 }
 
-func writeRowsToFile(f *os.File, data []map[string]interface{}, columns []string, format string) error {
+func writeRowsToFileWithLocale(f *os.File, data []map[string]interface{}, columns []string, format string, locale ExportLocaleOptions) error {
 	format = strings.ToLower(strings.TrimSpace(format))
 	if f == nil {
 		return fmt.Errorf("file required")
 	}
+	cells := newExportCellFormatter(locale)
 
 	// xlsx 使用 excelize 写入真正的 Excel 格式
 	if format == "xlsx" {
-		return writeRowsToXlsx(f.Name(), data, columns)
+		return writeRowsToXlsx(f.Name(), data, columns, cells)
+	}
+	if format == "geojson" {
+		return writeRowsToGeoJSON(f, data, columns)
 	}
 
 	var csvWriter *csv.Writer
@@ -957,6 +1023,7 @@ func writeRowsToFile(f *os.File, data []map[string]interface{}, columns []string
 			return err
 		}
 		csvWriter = csv.NewWriter(f)
+		csvWriter.Comma = cells.csvDelimiter()
 		if err := csvWriter.Write(columns); err != nil {
 			return err
 		}
@@ -990,7 +1057,7 @@ func writeRowsToFile(f *os.File, data []map[string]interface{}, columns []string
 				continue
 			}
 
-			s := formatExportCellText(val)
+			s := cells.format(val)
 			if format == "md" {
 				s = strings.ReplaceAll(s, "|", "\\|")
 				s = strings.ReplaceAll(s, "\n", "<br>")
@@ -1055,7 +1122,7 @@ func formatExportCellText(val interface{}) string {
 }
 
 // writeRowsToXlsx 使用 excelize 写入真正的 xlsx 格式文件
-func writeRowsToXlsx(filename string, data []map[string]interface{}, columns []string) error {
+func writeRowsToXlsx(filename string, data []map[string]interface{}, columns []string, cells exportCellFormatter) error {
 	xlsx := excelize.NewFile()
 	defer xlsx.Close()
 
@@ -1075,7 +1142,7 @@ func writeRowsToXlsx(filename string, data []map[string]interface{}, columns []s
 			if val == nil {
 				xlsx.SetCellValue(sheet, cell, "NULL")
 			} else {
-				xlsx.SetCellValue(sheet, cell, formatExportCellText(val))
+				xlsx.SetCellValue(sheet, cell, cells.format(val))
 			}
 		}
 	}
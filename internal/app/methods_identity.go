@@ -0,0 +1,280 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+// TableIdentity 表上一个自增列 / 标识列 / 序列列的当前状态。
+type TableIdentity struct {
+	Column    string `json:"column"`
+	Kind      string `json:"kind"`               // auto_increment、sequence、identity
+	Sequence  string `json:"sequence,omitempty"` // PostgreSQL 关联的序列名
+	NextValue *int64 `json:"nextValue"`          // 下一次插入将分配的值，未知时为 null
+	Increment int64  `json:"increment"`
+	MaxValue  *int64 `json:"maxValue"` // 列当前最大值，空表为 null
+
+	lastValue *int64 // SQL Server 的 last_value，为 null 时表示表建立/清空后尚未插入过
+}
+
+// ResetIdentityRequest 重置自增值的请求；NextValue 为重置后下一次插入使用的值。
+type ResetIdentityRequest struct {
+	Table     string `json:"table"`
+	Column    string `json:"column,omitempty"` // 表上有多个序列列时指定
+	NextValue int64  `json:"nextValue"`
+	Force     bool   `json:"force"`  // 允许不大于当前最大值（后续插入可能主键冲突）
+	DryRun    bool   `json:"dryRun"` // 只返回将执行的语句
+}
+
+func identitySupported(dbType string) bool {
+	switch dbType {
+	case "mysql", "mariadb", "diros", "postgres", "kingbase", "highgo", "vastbase", "sqlserver":
+		return true
+	}
+	return false
+}
+
+func identityInt(value interface{}) (int64, bool) {
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	return valueRendererInteger(value)
+}
+
+func identityIntPtr(row map[string]interface{}, column string) *int64 {
+	value, ok := archiveRowValue(row, column)
+	if !ok || value == nil {
+		return nil
+	}
+	n, ok := identityInt(value)
+	if !ok {
+		return nil
+	}
+	return &n
+}
+
+// loadTableIdentities 读取表上的自增列及其下一个值。
+// MySQL 8 的 information_schema.TABLES.AUTO_INCREMENT 受统计缓存影响可能滞后，前端应结合 MaxValue 判断。
+func loadTableIdentities(inst db.Database, dbType, schemaName, tableName string) ([]TableIdentity, error) {
+	qualified := quoteTableIdentByType(dbType, schemaName, tableName)
+	var identities []TableIdentity
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		rows, _, err := inst.Query(fmt.Sprintf(
+			"SELECT c.COLUMN_NAME AS column_name, t.AUTO_INCREMENT AS next_value "+
+				"FROM information_schema.COLUMNS c JOIN information_schema.TABLES t "+
+				"ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME "+
+				"WHERE c.TABLE_SCHEMA = %s AND c.TABLE_NAME = %s AND LOWER(c.EXTRA) LIKE '%%auto_increment%%'",
+			dialect.QuoteLiteral(dbType, schemaName), dialect.QuoteLiteral(dbType, tableName)))
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			column, _ := archiveRowValue(row, "column_name")
+			identities = append(identities, TableIdentity{
+				Column:    fmt.Sprintf("%v", column),
+				Kind:      "auto_increment",
+				NextValue: identityIntPtr(row, "next_value"),
+				Increment: 1,
+			})
+		}
+	case "postgres", "kingbase", "highgo", "vastbase":
+		regclass := dialect.QuoteLiteral(dbType, qualified)
+		rows, _, err := inst.Query(fmt.Sprintf(
+			"SELECT a.attname AS column_name, pg_get_serial_sequence(%s, a.attname) AS sequence_name "+
+				"FROM pg_attribute a WHERE a.attrelid = %s::regclass AND a.attnum > 0 AND NOT a.attisdropped "+
+				"AND pg_get_serial_sequence(%s, a.attname) IS NOT NULL ORDER BY a.attnum",
+			regclass, regclass, regclass))
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			column, _ := archiveRowValue(row, "column_name")
+			sequence, _ := archiveRowValue(row, "sequence_name")
+			identity := TableIdentity{Column: fmt.Sprintf("%v", column), Kind: "sequence", Sequence: fmt.Sprintf("%v", sequence), Increment: 1}
+			seqRows, _, err := inst.Query(fmt.Sprintf(
+				"SELECT s.last_value, s.is_called, p.seqincrement AS increment_by FROM %s s, pg_sequence p WHERE p.seqrelid = %s::regclass",
+				identity.Sequence, dialect.QuoteLiteral(dbType, identity.Sequence)))
+			if err != nil {
+				return nil, fmt.Errorf("读取序列 %s 失败：%w", identity.Sequence, err)
+			}
+			if len(seqRows) > 0 {
+				if inc := identityIntPtr(seqRows[0], "increment_by"); inc != nil {
+					identity.Increment = *inc
+				}
+				if last := identityIntPtr(seqRows[0], "last_value"); last != nil {
+					next := *last
+					if called, _ := archiveRowValue(seqRows[0], "is_called"); identityBool(called) {
+						next += identity.Increment
+					}
+					identity.NextValue = &next
+				}
+			}
+			identities = append(identities, identity)
+		}
+	case "sqlserver":
+		rows, _, err := inst.Query(fmt.Sprintf(
+			"SELECT ic.name AS column_name, CAST(ic.last_value AS BIGINT) AS last_value, "+
+				"CAST(ic.seed_value AS BIGINT) AS seed_value, CAST(ic.increment_value AS BIGINT) AS increment_value "+
+				"FROM sys.identity_columns ic WHERE ic.object_id = OBJECT_ID(N%s)",
+			dialect.QuoteLiteral(dbType, qualified)))
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			column, _ := archiveRowValue(row, "column_name")
+			identity := TableIdentity{Column: fmt.Sprintf("%v", column), Kind: "identity", Increment: 1}
+			if inc := identityIntPtr(row, "increment_value"); inc != nil && *inc != 0 {
+				identity.Increment = *inc
+			}
+			identity.lastValue = identityIntPtr(row, "last_value")
+			if identity.lastValue != nil {
+				next := *identity.lastValue + identity.Increment
+				identity.NextValue = &next
+			} else {
+				identity.NextValue = identityIntPtr(row, "seed_value")
+			}
+			identities = append(identities, identity)
+		}
+	}
+
+	for i := range identities {
+		rows, _, err := inst.Query(fmt.Sprintf("SELECT MAX(%s) AS max_value FROM %s", quoteIdentByType(dbType, identities[i].Column), qualified))
+		if err != nil {
+			return nil, fmt.Errorf("读取列 %s 最大值失败：%w", identities[i].Column, err)
+		}
+		if len(rows) > 0 {
+			identities[i].MaxValue = identityIntPtr(rows[0], "max_value")
+		}
+	}
+	return identities, nil
+}
+
+func identityBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case []byte:
+		return identityBool(string(v))
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "t", "true", "1", "y", "yes":
+			return true
+		}
+	}
+	if n, ok := identityInt(value); ok {
+		return n != 0
+	}
+	return false
+}
+
+// buildResetIdentitySQL 生成使下一次插入取得 nextValue 的语句。
+func buildResetIdentitySQL(dbType, qualifiedTable string, identity TableIdentity, nextValue int64) string {
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		return fmt.Sprintf("ALTER TABLE %s AUTO_INCREMENT = %d", qualifiedTable, nextValue)
+	case "sqlserver":
+		// RESEED 后：尚未插入过行时下一值即为 reseed 值，否则为 reseed 值 + 步长
+		reseed := nextValue
+		if identity.lastValue != nil {
+			reseed = nextValue - identity.Increment
+		}
+		return fmt.Sprintf("DBCC CHECKIDENT (%s, RESEED, %d)", dialect.QuoteLiteral(dbType, qualifiedTable), reseed)
+	default:
+		return fmt.Sprintf("SELECT setval(%s, %d, false)", dialect.QuoteLiteral(dbType, identity.Sequence), nextValue)
+	}
+}
+
+// GetTableIdentity 返回表上自增列 / 序列 / 标识列的下一个值与列当前最大值。
+func (a *App) GetTableIdentity(config connection.ConnectionConfig, dbName string, tableName string) connection.QueryResult {
+	dbType := resolveDDLDBType(config)
+	if !identitySupported(dbType) {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源(%s)暂不支持自增值管理", dbType)}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTableByType(dbType, dbName, tableName)
+	if pureTableName == "" {
+		return connection.QueryResult{Success: false, Message: "表名不能为空"}
+	}
+	dbInst, err := a.getDatabase(buildRunConfigForDDL(config, dbType, dbName))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	identities, err := loadTableIdentities(dbInst, dbType, schemaName, pureTableName)
+	if err != nil {
+		logger.Error(err, "GetTableIdentity 读取自增信息失败：%s 表=%s", formatConnSummary(config), tableName)
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if identities == nil {
+		identities = []TableIdentity{}
+	}
+	return connection.QueryResult{Success: true, Data: identities}
+}
+
+// ResetTableIdentity 重置自增值（ALTER TABLE ... AUTO_INCREMENT / setval / DBCC CHECKIDENT）。
+// 新值不大于列当前最大值时默认拒绝，避免后续插入主键冲突。
+func (a *App) ResetTableIdentity(config connection.ConnectionConfig, dbName string, req ResetIdentityRequest) connection.QueryResult {
+	if err := ensureWritable(config, "重置自增值"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(config)
+	if !identitySupported(dbType) {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源(%s)暂不支持自增值管理", dbType)}
+	}
+	if req.NextValue < 1 {
+		return connection.QueryResult{Success: false, Message: "新的自增值必须大于 0"}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTableByType(dbType, dbName, req.Table)
+	if pureTableName == "" {
+		return connection.QueryResult{Success: false, Message: "表名不能为空"}
+	}
+	dbInst, err := a.getDatabase(buildRunConfigForDDL(config, dbType, dbName))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	identities, err := loadTableIdentities(dbInst, dbType, schemaName, pureTableName)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	var target *TableIdentity
+	column := strings.TrimSpace(req.Column)
+	for i := range identities {
+		if column == "" || strings.EqualFold(identities[i].Column, column) {
+			target = &identities[i]
+			break
+		}
+	}
+	switch {
+	case len(identities) == 0:
+		return connection.QueryResult{Success: false, Message: "该表没有自增列或序列"}
+	case target == nil:
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("列 %s 不是自增列", column)}
+	case column == "" && len(identities) > 1:
+		return connection.QueryResult{Success: false, Message: "该表有多个序列列，请指定列名"}
+	}
+	if target.MaxValue != nil && req.NextValue <= *target.MaxValue && !req.Force {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("新的自增值 %d 不大于列 %s 当前最大值 %d，继续插入将产生主键冲突", req.NextValue, target.Column, *target.MaxValue)}
+	}
+
+	qualifiedTable := quoteTableIdentByType(dbType, schemaName, pureTableName)
+	sql := buildResetIdentitySQL(dbType, qualifiedTable, *target, req.NextValue)
+	if req.DryRun {
+		return connection.QueryResult{Success: true, Message: sql, Data: target}
+	}
+	if dbType == "postgres" || dbType == "kingbase" || dbType == "highgo" || dbType == "vastbase" {
+		_, _, err = dbInst.Query(sql)
+	} else {
+		_, err = dbInst.Exec(sql)
+	}
+	if err != nil {
+		logger.Error(err, "ResetTableIdentity 执行失败：%s SQL片段=%q", formatConnSummary(config), sqlSnippet(sql))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	logger.Infof("ResetTableIdentity 已重置：%s 表=%s 列=%s 下一值=%d", formatConnSummary(config), req.Table, target.Column, req.NextValue)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已将 %s 的下一个自增值设为 %d", target.Column, req.NextValue)}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+// identityFakeDB 模拟 MySQL 上带自增主键的 orders 表。
+type identityFakeDB struct {
+	db.Database
+	nextValue string
+	maxValue  interface{}
+	executed  []string
+}
+
+func (f *identityFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	switch {
+	case strings.Contains(query, "information_schema.COLUMNS"):
+		return []map[string]interface{}{{"COLUMN_NAME": "id", "next_value": f.nextValue}}, []string{"column_name", "next_value"}, nil
+	case strings.HasPrefix(query, "SELECT MAX("):
+		return []map[string]interface{}{{"max_value": f.maxValue}}, []string{"max_value"}, nil
+	}
+	return nil, nil, nil
+}
+
+func (f *identityFakeDB) Exec(query string) (int64, error) {
+	f.executed = append(f.executed, query)
+	return 0, nil
+}
+
+func (f *identityFakeDB) Ping() error  { return nil }
+func (f *identityFakeDB) Close() error { return nil }
+
+func TestResetTableIdentityGuardsAgainstCollisions(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "db", Port: 3306, User: "root"}
+	fake := &identityFakeDB{nextValue: "120", maxValue: int64(118)}
+	a.dbCache[getCacheKey(applyCustomDriverType(buildRunConfigForDDL(config, "mysql", "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	res := a.GetTableIdentity(config, "shop", "orders")
+	identities, _ := res.Data.([]TableIdentity)
+	if !res.Success || len(identities) != 1 || *identities[0].NextValue != 120 || *identities[0].MaxValue != 118 {
+		t.Fatalf("unexpected identity: %+v", res)
+	}
+
+	if res := a.ResetTableIdentity(config, "shop", ResetIdentityRequest{Table: "orders", NextValue: 100}); res.Success {
+		t.Fatal("reset below max value should be rejected")
+	}
+	if res := a.ResetTableIdentity(config, "shop", ResetIdentityRequest{Table: "orders", NextValue: 500, DryRun: true}); !res.Success || res.Message != "ALTER TABLE `shop`.`orders` AUTO_INCREMENT = 500" {
+		t.Fatalf("dry run: %+v", res)
+	}
+	if len(fake.executed) != 0 {
+		t.Fatalf("dry run must not execute: %v", fake.executed)
+	}
+	if res := a.ResetTableIdentity(config, "shop", ResetIdentityRequest{Table: "orders", NextValue: 100, Force: true}); !res.Success {
+		t.Fatalf("forced reset: %+v", res)
+	}
+	if res := a.ResetTableIdentity(config, "shop", ResetIdentityRequest{Table: "orders", Column: "name", NextValue: 500}); res.Success {
+		t.Fatal("non-identity column should be rejected")
+	}
+	if len(fake.executed) != 1 || fake.executed[0] != "ALTER TABLE `shop`.`orders` AUTO_INCREMENT = 100" {
+		t.Fatalf("executed: %v", fake.executed)
+	}
+}
+
+func TestBuildResetIdentitySQLSQLServerReseed(t *testing.T) {
+	last := int64(41)
+	used := TableIdentity{Column: "id", Increment: 1, lastValue: &last}
+	if got := buildResetIdentitySQL("sqlserver", "[dbo].[orders]", used, 100); got != "DBCC CHECKIDENT ('[dbo].[orders]', RESEED, 99)" {
+		t.Fatalf("used table: %s", got)
+	}
+	fresh := TableIdentity{Column: "id", Increment: 1}
+	if got := buildResetIdentitySQL("sqlserver", "[dbo].[orders]", fresh, 100); got != "DBCC CHECKIDENT ('[dbo].[orders]', RESEED, 100)" {
+		t.Fatalf("fresh table: %s", got)
+	}
+	seq := TableIdentity{Column: "id", Sequence: "public.orders_id_seq"}
+	if got := buildResetIdentitySQL("postgres", `"public"."orders"`, seq, 7); got != "SELECT setval('public.orders_id_seq', 7, false)" {
+		t.Fatalf("postgres: %s", got)
+	}
+}
@@ -0,0 +1,474 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	indexAdvisorSampleRows    = 10000
+	indexAdvisorRangeRatio    = 0.3
+	indexAdvisorMinUsefulness = 0.5
+	indexAdvisorMaxIndexName  = 60
+)
+
+// IndexAdviceColumn 候选索引中的一列及其统计。
+type IndexAdviceColumn struct {
+	Name        string  `json:"name"`
+	Usage       string  `json:"usage"`       // eq/join/range/order
+	Distinct    int64   `json:"distinct"`    // 采样中的不同值数量
+	Selectivity float64 `json:"selectivity"` // 不同值数/采样行数，越接近 1 区分度越高
+}
+
+// IndexSuggestion 单条索引建议。CreateSQL 仅供审阅，不会自动执行。
+type IndexSuggestion struct {
+	Table           string              `json:"table"`
+	Columns         []IndexAdviceColumn `json:"columns"`
+	MatchRatio      float64             `json:"matchRatio"` // 估算命中行占比，越小收益越大
+	SampledRows     int64               `json:"sampledRows"`
+	CoveredBy       string              `json:"coveredBy,omitempty"`
+	Reason          string              `json:"reason"`
+	IndexName       string              `json:"indexName,omitempty"`
+	CreateSQL       string              `json:"createSql,omitempty"`
+	ExistingIndexes []string            `json:"existingIndexes,omitempty"`
+	// 以下字段来自执行计划与表统计，仅 MySQL/MariaDB/PostgreSQL 提供
+	PlanAccess       string  `json:"planAccess,omitempty"`       // 计划中该表的访问方式，如 Seq Scan、table (ALL)
+	FullScan         bool    `json:"fullScan,omitempty"`         // 计划中该表为全表（全索引）扫描
+	TableRows        int64   `json:"tableRows,omitempty"`        // 统计信息中的表行数
+	ScanRows         float64 `json:"scanRows,omitempty"`         // 当前计划读取的行数估算
+	EstimatedRows    float64 `json:"estimatedRows,omitempty"`    // 使用建议索引后读取的行数估算
+	EstimatedBenefit float64 `json:"estimatedBenefit,omitempty"` // 减少读取行数的比例（0~1）
+}
+
+// IndexAdviceReport 索引建议结果。Warnings 记录无法解析或无法取统计的表/列。
+type IndexAdviceReport struct {
+	Suggestions []IndexSuggestion `json:"suggestions"`
+	Statements  []string          `json:"statements"`
+	Warnings    []string          `json:"warnings,omitempty"`
+	PlanFormat  string            `json:"planFormat,omitempty"` // 参与评估的执行计划格式，为空表示未使用执行计划
+}
+
+// AdviseIndexes 基于语句中 WHERE/JOIN/ORDER BY 引用的列、已有索引与采样统计给出候选索引。
+// 等值/连接列按区分度降序在前，随后最多一个范围列，再补 ORDER BY 列。MySQL/MariaDB/PostgreSQL 还会读取
+// EXPLAIN 与表统计，标注各表当前的访问方式并估算收益，建议按收益降序排列。
+func (a *App) AdviseIndexes(config connection.ConnectionConfig, dbName string, query string) connection.QueryResult {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return connection.QueryResult{Success: false, Message: "查询语句不能为空"}
+	}
+	parsed := parseIndexAdvisorQuery(query)
+	if len(parsed.tables) == 0 {
+		return connection.QueryResult{Success: false, Message: "未能从语句中识别出表"}
+	}
+
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+
+	report := adviseIndexes(dbInst, dbType, dbName, parsed)
+	annotateIndexAdviceWithPlan(dbInst, dbType, dbName, query, parsed, &report)
+	logger.Infof("索引建议完成：%s 表数=%d 建议数=%d SQL片段=%q", formatConnSummary(runConfig), len(parsed.tables), len(report.Statements), sqlSnippet(query))
+	message := fmt.Sprintf("生成 %d 条索引建议", len(report.Statements))
+	if len(report.Statements) == 0 {
+		message = "未发现需要新增的索引"
+	}
+	return connection.QueryResult{Success: true, Message: message, Data: report}
+}
+
+type indexAdvisorTable struct {
+	ref      advisorTableRef
+	schema   string
+	table    string
+	columns  map[string]string // 小写列名 -> 实际列名
+	usages   map[string]string // 实际列名 -> 最强用途
+	order    []string          // ORDER BY 列（保持顺序）
+	existing map[string][]string
+}
+
+func adviseIndexes(dbInst db.Database, dbType string, dbName string, parsed advisorParseResult) IndexAdviceReport {
+	report := IndexAdviceReport{Suggestions: []IndexSuggestion{}, Statements: []string{}}
+	tables := make([]*indexAdvisorTable, 0, len(parsed.tables))
+	seen := map[string]*indexAdvisorTable{}
+
+	for _, ref := range parsed.tables {
+		rawName := ref.name
+		if ref.schema != "" {
+			rawName = ref.schema + "." + ref.name
+		}
+		key := strings.ToLower(rawName)
+		if existing, ok := seen[key]; ok {
+			// 自连接：共用同一张表的统计，仅登记别名
+			tables = append(tables, &indexAdvisorTable{ref: ref, schema: existing.schema, table: existing.table,
+				columns: existing.columns, usages: existing.usages, existing: existing.existing})
+			continue
+		}
+		schemaName, pureTableName := normalizeSchemaAndTableByType(dbType, dbName, rawName)
+		cols, err := dbInst.GetColumns(schemaName, pureTableName)
+		if err != nil || len(cols) == 0 {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("无法读取表 %s 的列信息，已跳过", rawName))
+			continue
+		}
+		t := &indexAdvisorTable{ref: ref, schema: schemaName, table: pureTableName,
+			columns: map[string]string{}, usages: map[string]string{}, existing: map[string][]string{}}
+		for _, col := range cols {
+			t.columns[strings.ToLower(col.Name)] = col.Name
+		}
+		if indexes, err := dbInst.GetIndexes(schemaName, pureTableName); err == nil {
+			t.existing = groupIndexColumns(indexes)
+		}
+		seen[key] = t
+		tables = append(tables, t)
+	}
+
+	for _, pred := range parsed.predicates {
+		t, column := resolveAdvisorColumn(tables, pred)
+		if t == nil {
+			continue
+		}
+		if pred.usage == "order" {
+			t.order = appendUniqueFold(t.order, column)
+			continue
+		}
+		if advisorUsageRank(pred.usage) > advisorUsageRank(t.usages[column]) {
+			t.usages[column] = pred.usage
+		}
+	}
+
+	handled := map[*indexAdvisorTable]bool{}
+	for _, t := range tables {
+		owner := seen[strings.ToLower(joinSchemaTable(t.ref))]
+		if owner == nil || handled[owner] {
+			continue
+		}
+		handled[owner] = true
+		if len(owner.usages) == 0 && len(owner.order) == 0 {
+			continue
+		}
+		suggestion, warn := buildIndexSuggestion(dbInst, dbType, owner)
+		if warn != "" {
+			report.Warnings = append(report.Warnings, warn)
+		}
+		if suggestion == nil {
+			continue
+		}
+		report.Suggestions = append(report.Suggestions, *suggestion)
+		if suggestion.CreateSQL != "" {
+			report.Statements = append(report.Statements, suggestion.CreateSQL)
+		}
+	}
+	return report
+}
+
+func joinSchemaTable(ref advisorTableRef) string {
+	if ref.schema != "" {
+		return ref.schema + "." + ref.name
+	}
+	return ref.name
+}
+
+// groupIndexColumns 将按列展开的索引定义聚合为 索引名 -> 有序列名。
+func groupIndexColumns(indexes []connection.IndexDefinition) map[string][]string {
+	type seqCol struct {
+		seq  int
+		name string
+	}
+	grouped := map[string][]seqCol{}
+	for _, idx := range indexes {
+		if strings.TrimSpace(idx.Name) == "" || strings.TrimSpace(idx.ColumnName) == "" {
+			continue
+		}
+		grouped[idx.Name] = append(grouped[idx.Name], seqCol{seq: idx.SeqInIndex, name: idx.ColumnName})
+	}
+	result := make(map[string][]string, len(grouped))
+	for name, cols := range grouped {
+		sort.SliceStable(cols, func(i, j int) bool { return cols[i].seq < cols[j].seq })
+		names := make([]string, len(cols))
+		for i, c := range cols {
+			names[i] = c.name
+		}
+		result[name] = names
+	}
+	return result
+}
+
+func resolveAdvisorColumn(tables []*indexAdvisorTable, pred advisorPredicate) (*indexAdvisorTable, string) {
+	lowerCol := strings.ToLower(pred.column)
+	if pred.qualifier != "" {
+		for _, t := range tables {
+			if strings.EqualFold(t.ref.alias, pred.qualifier) || (t.ref.alias == "" && strings.EqualFold(t.ref.name, pred.qualifier)) {
+				if actual, ok := t.columns[lowerCol]; ok {
+					return t, actual
+				}
+				return nil, ""
+			}
+		}
+		return nil, ""
+	}
+	var found *indexAdvisorTable
+	actualName := ""
+	for _, t := range tables {
+		if actual, ok := t.columns[lowerCol]; ok {
+			if found != nil && found.columns[lowerCol] != "" && !strings.EqualFold(found.table, t.table) {
+				// 未限定且多表同名：无法判定归属
+				return nil, ""
+			}
+			found, actualName = t, actual
+		}
+	}
+	return found, actualName
+}
+
+func advisorUsageRank(usage string) int {
+	switch usage {
+	case "eq":
+		return 3
+	case "join":
+		return 2
+	case "range":
+		return 1
+	default:
+		return 0
+	}
+}
+
+func appendUniqueFold(list []string, value string) []string {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+func buildIndexSuggestion(dbInst db.Database, dbType string, t *indexAdvisorTable) (*IndexSuggestion, string) {
+	displayName := t.table
+	if t.schema != "" {
+		displayName = t.schema + "." + t.table
+	}
+
+	candidates := make([]string, 0, len(t.usages)+len(t.order))
+	for col := range t.usages {
+		candidates = append(candidates, col)
+	}
+	for _, col := range t.order {
+		if _, ok := t.usages[col]; !ok {
+			candidates = append(candidates, col)
+		}
+	}
+	sort.Strings(candidates)
+
+	sampled, distinct, err := sampleColumnDistinct(dbInst, dbType, quoteTableIdentByType(dbType, t.schema, t.table), candidates)
+	if err != nil {
+		return nil, fmt.Sprintf("采样表 %s 统计失败：%s", displayName, normalizeErrorMessage(err))
+	}
+
+	stat := func(col, usage string) IndexAdviceColumn {
+		c := IndexAdviceColumn{Name: col, Usage: usage, Distinct: distinct[col]}
+		if sampled > 0 {
+			c.Selectivity = float64(c.Distinct) / float64(sampled)
+		}
+		return c
+	}
+
+	var equality, ranges []IndexAdviceColumn
+	for col, usage := range t.usages {
+		if usage == "range" {
+			ranges = append(ranges, stat(col, usage))
+		} else {
+			equality = append(equality, stat(col, usage))
+		}
+	}
+	bySelectivity := func(list []IndexAdviceColumn) {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Selectivity != list[j].Selectivity {
+				return list[i].Selectivity > list[j].Selectivity
+			}
+			return list[i].Name < list[j].Name
+		})
+	}
+	bySelectivity(equality)
+	bySelectivity(ranges)
+
+	columns := append([]IndexAdviceColumn{}, equality...)
+	matchRatio := 1.0
+	for _, c := range equality {
+		if c.Distinct > 0 {
+			matchRatio /= float64(c.Distinct)
+		}
+	}
+	if len(ranges) > 0 {
+		// 复合索引中范围列之后的列无法继续用于过滤，只取区分度最高的一个
+		columns = append(columns, ranges[0])
+		matchRatio *= indexAdvisorRangeRatio
+	} else {
+		for _, col := range t.order {
+			if _, used := t.usages[col]; !used {
+				columns = append(columns, stat(col, "order"))
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return nil, ""
+	}
+
+	suggestion := &IndexSuggestion{Table: displayName, Columns: columns, MatchRatio: matchRatio, SampledRows: sampled}
+	for name := range t.existing {
+		suggestion.ExistingIndexes = append(suggestion.ExistingIndexes, name)
+	}
+	sort.Strings(suggestion.ExistingIndexes)
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	if covering := findCoveringIndex(t.existing, names); covering != "" {
+		suggestion.CoveredBy = covering
+		suggestion.Reason = fmt.Sprintf("已有索引 %s 可覆盖这些列", covering)
+		return suggestion, ""
+	}
+	if sampled == 0 {
+		suggestion.Reason = "表中暂无数据，无法评估区分度"
+		return suggestion, ""
+	}
+	if columns[0].Usage != "order" && columns[0].Selectivity < 0.01 && matchRatio > indexAdvisorMinUsefulness {
+		suggestion.Reason = fmt.Sprintf("首列 %s 区分度过低（%.4f），索引收益有限", columns[0].Name, columns[0].Selectivity)
+		return suggestion, ""
+	}
+
+	suggestion.IndexName = buildAdvisorIndexName(t.table, names)
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = quoteIdentByType(dbType, n)
+	}
+	suggestion.CreateSQL = fmt.Sprintf("CREATE INDEX %s ON %s (%s);",
+		quoteIdentByType(dbType, suggestion.IndexName), quoteTableIdentByType(dbType, t.schema, t.table), strings.Join(quoted, ", "))
+	suggestion.Reason = describeIndexSuggestion(columns, matchRatio)
+	return suggestion, ""
+}
+
+// findCoveringIndex 已有索引以候选列（等值部分不计顺序）为前缀时视为已覆盖。
+func findCoveringIndex(existing map[string][]string, columns []string) string {
+	names := make([]string, 0, len(existing))
+	for name := range existing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cols := existing[name]
+		if len(cols) < len(columns) {
+			continue
+		}
+		want := map[string]int{}
+		for _, c := range columns {
+			want[strings.ToLower(c)]++
+		}
+		for _, c := range cols[:len(columns)] {
+			want[strings.ToLower(c)]--
+		}
+		covered := true
+		for _, v := range want {
+			if v != 0 {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return name
+		}
+	}
+	return ""
+}
+
+func buildAdvisorIndexName(table string, columns []string) string {
+	var b strings.Builder
+	b.WriteString("idx_")
+	b.WriteString(table)
+	for _, c := range columns {
+		b.WriteString("_")
+		b.WriteString(c)
+	}
+	name := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '.' || r == '-' {
+			return '_'
+		}
+		return r
+	}, strings.ToLower(b.String()))
+	if runes := []rune(name); len(runes) > indexAdvisorMaxIndexName {
+		name = string(runes[:indexAdvisorMaxIndexName])
+	}
+	return name
+}
+
+func describeIndexSuggestion(columns []IndexAdviceColumn, matchRatio float64) string {
+	parts := make([]string, len(columns))
+	for i, c := range columns {
+		label := map[string]string{"eq": "等值", "join": "连接", "range": "范围", "order": "排序"}[c.Usage]
+		parts[i] = fmt.Sprintf("%s(%s, 区分度 %.4f)", c.Name, label, c.Selectivity)
+	}
+	return fmt.Sprintf("%s；估算命中比例 %.4f%%", strings.Join(parts, "、"), matchRatio*100)
+}
+
+// sampleColumnDistinct 在前 N 行样本上统计各列不同值数量，避免大表全量扫描。
+func sampleColumnDistinct(dbInst db.Database, dbType string, qualifiedTable string, columns []string) (int64, map[string]int64, error) {
+	distinct := make(map[string]int64, len(columns))
+	if len(columns) == 0 {
+		return 0, distinct, nil
+	}
+	query := buildIndexAdvisorSampleQuery(dbType, qualifiedTable, columns, indexAdvisorSampleRows)
+	data, _, err := dbInst.Query(query)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) == 0 {
+		return 0, distinct, nil
+	}
+	row := data[0]
+	value := func(key string) int64 {
+		for k, v := range row {
+			if strings.EqualFold(k, key) && v != nil {
+				text := strings.TrimSpace(fmt.Sprintf("%v", v))
+				if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+					return n
+				}
+				if f, err := strconv.ParseFloat(text, 64); err == nil {
+					return int64(f)
+				}
+			}
+		}
+		return 0
+	}
+	total := value("total_rows")
+	for i, col := range columns {
+		distinct[col] = value(fmt.Sprintf("d%d", i))
+	}
+	return total, distinct, nil
+}
+
+func buildIndexAdvisorSampleQuery(dbType string, qualifiedTable string, columns []string, sampleRows int) string {
+	selectCols := make([]string, len(columns))
+	countCols := make([]string, len(columns))
+	for i, col := range columns {
+		quoted := quoteIdentByType(dbType, col)
+		selectCols[i] = quoted
+		countCols[i] = fmt.Sprintf("COUNT(DISTINCT %s) AS d%d", quoted, i)
+	}
+	var inner string
+	switch dbType {
+	case "sqlserver":
+		inner = fmt.Sprintf("SELECT TOP %d %s FROM %s", sampleRows, strings.Join(selectCols, ", "), qualifiedTable)
+	case "oracle", "dameng":
+		inner = fmt.Sprintf("SELECT %s FROM %s WHERE ROWNUM <= %d", strings.Join(selectCols, ", "), qualifiedTable, sampleRows)
+	default:
+		inner = fmt.Sprintf("SELECT %s FROM %s LIMIT %d", strings.Join(selectCols, ", "), qualifiedTable, sampleRows)
+	}
+	return fmt.Sprintf("SELECT COUNT(*) AS total_rows, %s FROM (%s) advisor_sample", strings.Join(countCols, ", "), inner)
+}
@@ -0,0 +1,292 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	slowQueryAdviceDefaultLimit = 10
+	slowQueryAdviceMaxLimit     = 50
+)
+
+// SlowQueryAdvice 单条慢查询及其索引建议。Query 可能是参数化后的摘要文本（? 或 $n 占位）。
+type SlowQueryAdvice struct {
+	Query   string            `json:"query"`
+	Calls   int64             `json:"calls"`
+	TotalMs float64           `json:"totalMs"`
+	MeanMs  float64           `json:"meanMs"`
+	Report  IndexAdviceReport `json:"report"`
+}
+
+// SlowQueryAdviceReport 慢查询索引建议汇总。Statements 为去重后的全部建议语句。
+type SlowQueryAdviceReport struct {
+	Source     string            `json:"source"` // performance_schema/pg_stat_statements
+	Queries    []SlowQueryAdvice `json:"queries"`
+	Statements []string          `json:"statements"`
+	Warnings   []string          `json:"warnings,omitempty"`
+}
+
+type slowQuery struct {
+	text        string
+	explainable bool // 摘要文本含占位符时无法 EXPLAIN，只做启发式分析
+	calls       int64
+	totalMs     float64
+	meanMs      float64
+}
+
+// AdviseIndexesForSlowQueries 读取累计耗时最高的查询（MySQL/MariaDB 的 performance_schema 语句摘要、
+// PostgreSQL 的 pg_stat_statements），逐条给出索引建议。limit 为分析的语句数，默认 10，最多 50。
+func (a *App) AdviseIndexesForSlowQueries(config connection.ConnectionConfig, dbName string, limit int) connection.QueryResult {
+	if limit <= 0 {
+		limit = slowQueryAdviceDefaultLimit
+	}
+	if limit > slowQueryAdviceMaxLimit {
+		limit = slowQueryAdviceMaxLimit
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+
+	var queries []slowQuery
+	var source string
+	warning, err := a.runWithReconnect(runConfig, dbInst, "AdviseIndexesForSlowQueries", func(inst db.Database) (loadErr error) {
+		dbInst = inst
+		queries, source, loadErr = loadSlowQueries(inst, dbType, dbName, limit)
+		return loadErr
+	})
+	if err != nil {
+		logger.Error(err, "读取慢查询统计失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+
+	result := SlowQueryAdviceReport{Source: source, Queries: []SlowQueryAdvice{}, Statements: []string{}}
+	if warning != "" {
+		result.Warnings = append(result.Warnings, warning)
+	}
+	seen := map[string]bool{}
+	for _, q := range queries {
+		parsed := parseIndexAdvisorQuery(q.text)
+		if len(parsed.tables) == 0 {
+			continue
+		}
+		report := adviseIndexes(dbInst, dbType, dbName, parsed)
+		if q.explainable {
+			annotateIndexAdviceWithPlan(dbInst, dbType, dbName, q.text, parsed, &report)
+		}
+		for _, stmt := range report.Statements {
+			if !seen[stmt] {
+				seen[stmt] = true
+				result.Statements = append(result.Statements, stmt)
+			}
+		}
+		result.Queries = append(result.Queries, SlowQueryAdvice{Query: q.text, Calls: q.calls, TotalMs: q.totalMs, MeanMs: q.meanMs, Report: report})
+	}
+	logger.Infof("慢查询索引建议完成：%s 来源=%s 语句数=%d 建议数=%d", formatConnSummary(runConfig), source, len(result.Queries), len(result.Statements))
+	message := fmt.Sprintf("分析 %d 条慢查询，生成 %d 条索引建议", len(result.Queries), len(result.Statements))
+	return connection.QueryResult{Success: true, Message: message, Data: result}
+}
+
+var postgresParamPattern = regexp.MustCompile(`\$\d+`)
+
+// loadSlowQueries 按累计耗时降序读取语句统计，只保留 SELECT/WITH 查询。返回的列顺序为
+// 摘要文本、样例语句、执行次数、累计耗时（毫秒）、平均耗时（毫秒）。
+func loadSlowQueries(inst db.Database, dbType string, dbName string, limit int) ([]slowQuery, string, error) {
+	var attempts []string
+	var source, hint string
+	switch dbType {
+	case "mysql", "mariadb":
+		// 计时单位为皮秒；QUERY_SAMPLE_TEXT 为 MySQL 8.0.3+ 记录的原始语句，可直接 EXPLAIN
+		base := "SELECT DIGEST_TEXT AS digest_text, %s AS sample_text, COUNT_STAR AS calls, " +
+			"SUM_TIMER_WAIT / 1000000000 AS total_ms, AVG_TIMER_WAIT / 1000000000 AS mean_ms " +
+			"FROM performance_schema.events_statements_summary_by_digest " +
+			"WHERE SCHEMA_NAME = %s AND (DIGEST_TEXT LIKE 'SELECT%%' OR DIGEST_TEXT LIKE 'WITH%%') " +
+			"ORDER BY SUM_TIMER_WAIT DESC LIMIT %d"
+		schema := dialect.QuoteLiteral(dbType, dbName)
+		attempts = []string{fmt.Sprintf(base, "QUERY_SAMPLE_TEXT", schema, limit), fmt.Sprintf(base, "NULL", schema, limit)}
+		source, hint = "performance_schema", "需开启 performance_schema"
+	case "postgres", "kingbase", "highgo", "vastbase":
+		// PostgreSQL 13 起计时列更名为 total_exec_time/mean_exec_time
+		base := "SELECT s.query AS digest_text, NULL AS sample_text, s.calls AS calls, s.%[1]s AS total_ms, s.%[2]s AS mean_ms " +
+			"FROM pg_stat_statements s JOIN pg_database d ON d.oid = s.dbid " +
+			"WHERE d.datname = current_database() AND (s.query ILIKE 'select%%' OR s.query ILIKE 'with%%') " +
+			"AND s.query NOT ILIKE '%%pg_catalog%%' AND s.query NOT ILIKE '%%information_schema%%' " +
+			"ORDER BY s.%[1]s DESC LIMIT %[3]d"
+		attempts = []string{fmt.Sprintf(base, "total_exec_time", "mean_exec_time", limit), fmt.Sprintf(base, "total_time", "mean_time", limit)}
+		source, hint = "pg_stat_statements", "需安装并加载 pg_stat_statements 扩展"
+	default:
+		return nil, "", fmt.Errorf("当前数据源（%s）暂不支持慢查询分析", dbType)
+	}
+
+	var rows [][]string
+	var err error
+	for _, query := range attempts {
+		if rows, err = erRows(inst, query); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, source, fmt.Errorf("读取 %s 失败（%s）：%w", source, hint, err)
+	}
+	queries := make([]slowQuery, 0, len(rows))
+	for _, row := range rows {
+		q := slowQuery{text: strings.TrimSpace(row[0])}
+		if sample := strings.TrimSpace(row[1]); sample != "" {
+			q.text, q.explainable = sample, true
+		} else if source == "pg_stat_statements" {
+			q.explainable = !postgresParamPattern.MatchString(q.text)
+		}
+		if q.text == "" {
+			continue
+		}
+		q.calls, _ = tableStatInt(row[2])
+		q.totalMs, _ = strconv.ParseFloat(row[3], 64)
+		q.meanMs, _ = strconv.ParseFloat(row[4], 64)
+		queries = append(queries, q)
+	}
+	return queries, source, nil
+}
+
+// planTableAccess 执行计划中某张表的访问方式；同一张表出现多次时保留代价最大的一次。
+type planTableAccess struct {
+	operation string
+	index     string
+	fullScan  bool
+	rows      float64
+}
+
+// annotateIndexAdviceWithPlan 读取语句的估算执行计划与表统计，为建议标注当前访问方式并估算收益，
+// 随后按收益降序重排。执行计划不可用时只记录警告，保留启发式结果。
+func annotateIndexAdviceWithPlan(dbInst db.Database, dbType string, dbName string, query string, parsed advisorParseResult, report *IndexAdviceReport) {
+	switch dbType {
+	case "mysql", "mariadb", "postgres", "kingbase", "highgo", "vastbase":
+	default:
+		return
+	}
+	if len(report.Suggestions) == 0 {
+		return
+	}
+	plan, err := explainQuery(dbInst, dbType, query, false)
+	if err != nil {
+		report.Warnings = append(report.Warnings, "读取执行计划失败，未评估收益："+normalizeErrorMessage(err))
+		return
+	}
+	report.PlanFormat = plan.Format
+	access := collectPlanTableAccess(plan.Root, advisorTableAliases(parsed))
+	for i := range report.Suggestions {
+		s := &report.Suggestions[i]
+		tableRows, _ := estimateRowsByTableStats(dbInst, dbType, dbName, []string{s.Table})
+		applyPlanToSuggestion(s, access[advisorPlanTableKey(s.Table)], tableRows)
+	}
+	sortIndexSuggestionsByBenefit(report)
+}
+
+// advisorTableAliases 别名与表名（均小写）到表名的映射，用于匹配计划中的表（MySQL 计划使用别名）。
+func advisorTableAliases(parsed advisorParseResult) map[string]string {
+	aliases := make(map[string]string, len(parsed.tables)*2)
+	for _, ref := range parsed.tables {
+		name := strings.ToLower(ref.name)
+		aliases[name] = name
+		if ref.alias != "" {
+			aliases[strings.ToLower(ref.alias)] = name
+		}
+	}
+	return aliases
+}
+
+func advisorPlanTableKey(name string) string {
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return strings.ToLower(strings.Trim(name, "`\""))
+}
+
+func collectPlanTableAccess(root PlanNode, aliases map[string]string) map[string]*planTableAccess {
+	result := map[string]*planTableAccess{}
+	var walk func(node PlanNode)
+	walk = func(node PlanNode) {
+		if node.Object != "" {
+			// Object 形如 "表 / 索引"；PostgreSQL 的 Bitmap Index Scan 只有索引名，查不到表时忽略
+			table, index := node.Object, ""
+			if idx := strings.Index(table, " / "); idx >= 0 {
+				table, index = table[:idx], table[idx+3:]
+			}
+			key := advisorPlanTableKey(table)
+			if actual, ok := aliases[key]; ok {
+				key = actual
+			}
+			current := &planTableAccess{operation: node.Operation, index: index, fullScan: isFullScanOperation(node.Operation), rows: node.Rows}
+			if prev := result[key]; prev == nil || (current.fullScan && !prev.fullScan) || (current.fullScan == prev.fullScan && current.rows > prev.rows) {
+				result[key] = current
+			}
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+	return result
+}
+
+// isFullScanOperation MySQL/MariaDB 的 access_type 为 ALL（全表）或 index（全索引）、PostgreSQL 的 Seq Scan。
+func isFullScanOperation(operation string) bool {
+	return operation == "table (ALL)" || operation == "table (index)" || strings.Contains(operation, "Seq Scan")
+}
+
+// applyPlanToSuggestion 全表扫描时当前读取行数取表行数，否则取计划估算行数；建议索引后的读取行数按命中比例估算。
+func applyPlanToSuggestion(s *IndexSuggestion, access *planTableAccess, tableRows int64) {
+	if tableRows > 0 {
+		s.TableRows = tableRows
+	}
+	if access == nil {
+		return
+	}
+	s.PlanAccess = access.operation
+	if access.index != "" {
+		s.PlanAccess += " / " + access.index
+	}
+	s.FullScan = access.fullScan
+	scan := access.rows
+	if access.fullScan && tableRows > 0 {
+		scan = float64(tableRows)
+	}
+	if scan <= 0 {
+		return
+	}
+	base := scan
+	if tableRows > 0 {
+		base = float64(tableRows)
+	}
+	s.ScanRows = scan
+	s.EstimatedRows = base * s.MatchRatio
+	if s.EstimatedRows > scan {
+		s.EstimatedRows = scan
+	}
+	s.EstimatedBenefit = 1 - s.EstimatedRows/scan
+	if s.CreateSQL != "" && s.FullScan {
+		s.Reason += fmt.Sprintf("；当前计划全表扫描约 %.0f 行，使用索引后约 %.0f 行", s.ScanRows, s.EstimatedRows)
+	}
+}
+
+func sortIndexSuggestionsByBenefit(report *IndexAdviceReport) {
+	sort.SliceStable(report.Suggestions, func(i, j int) bool {
+		return report.Suggestions[i].EstimatedBenefit > report.Suggestions[j].EstimatedBenefit
+	})
+	report.Statements = report.Statements[:0]
+	for _, s := range report.Suggestions {
+		if s.CreateSQL != "" {
+			report.Statements = append(report.Statements, s.CreateSQL)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCollectPlanTableAccess(t *testing.T) {
+	raw := `{"query_block": {"select_id": 1, "nested_loop": [
+		{"table": {"table_name": "o", "access_type": "ALL", "rows_examined_per_scan": 5000, "rows_produced_per_join": 500}},
+		{"table": {"table_name": "c", "access_type": "eq_ref", "key": "PRIMARY", "rows_produced_per_join": 500}}
+	]}}`
+	root, err := parseMySQLJSONPlan(raw)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	parsed := parseIndexAdvisorQuery("SELECT * FROM orders o JOIN customers c ON o.customer_id = c.id WHERE o.status = 'paid'")
+	access := collectPlanTableAccess(root, advisorTableAliases(parsed))
+	if got := access["orders"]; got == nil || !got.fullScan || got.operation != "table (ALL)" {
+		t.Fatalf("orders access = %#v", got)
+	}
+	if got := access["customers"]; got == nil || got.fullScan || got.index != "PRIMARY" {
+		t.Fatalf("customers access = %#v", got)
+	}
+}
+
+func TestApplyPlanToSuggestion(t *testing.T) {
+	report := IndexAdviceReport{Suggestions: []IndexSuggestion{
+		{Table: "public.customers", MatchRatio: 0.5, CreateSQL: "CREATE INDEX b;"},
+		{Table: "public.orders", MatchRatio: 0.01, CreateSQL: "CREATE INDEX a;"},
+	}, Statements: []string{"CREATE INDEX b;", "CREATE INDEX a;"}}
+	applyPlanToSuggestion(&report.Suggestions[0], &planTableAccess{operation: "Index Scan", index: "customers_pkey", rows: 10}, 1000)
+	applyPlanToSuggestion(&report.Suggestions[1], &planTableAccess{operation: "Seq Scan", fullScan: true, rows: 20}, 100000)
+
+	orders := report.Suggestions[1]
+	if !orders.FullScan || orders.ScanRows != 100000 || orders.EstimatedRows != 1000 || math.Abs(orders.EstimatedBenefit-0.99) > 1e-9 {
+		t.Fatalf("orders = %#v", orders)
+	}
+	customers := report.Suggestions[0]
+	if customers.PlanAccess != "Index Scan / customers_pkey" || customers.EstimatedRows != 10 || customers.EstimatedBenefit != 0 {
+		t.Fatalf("customers = %#v", customers)
+	}
+	sortIndexSuggestionsByBenefit(&report)
+	if report.Statements[0] != "CREATE INDEX a;" || report.Suggestions[0].Table != "public.orders" {
+		t.Fatalf("order = %#v", report.Statements)
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+)
+
+// DBCreateIndex 按 index 在表上创建索引，供表设计器维护索引时使用。
+func (a *App) DBCreateIndex(config connection.ConnectionConfig, dbName string, tableName string, index connection.IndexSpec) connection.QueryResult {
+	if err := ensureWritable(config, "创建索引"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	index.Name = strings.TrimSpace(index.Name)
+	if err := dbInst.CreateIndex(schemaName, pureTableName, index); err != nil {
+		logger.Error(err, "创建索引失败：%s 表=%s 索引=%s", formatConnSummary(runConfig), pureTableName, index.Name)
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Message: "索引已创建"}
+}
+
+// DBDropIndex 删除表上的索引。
+func (a *App) DBDropIndex(config connection.ConnectionConfig, dbName string, tableName string, indexName string) connection.QueryResult {
+	if err := ensureWritable(config, "删除索引"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	indexName = strings.TrimSpace(indexName)
+	dbType := resolveDDLDBType(runConfig)
+	change := sqlstmt.SchemaChange{Action: "DROP", Object: "INDEX", Schema: schemaName, Name: indexName, Table: pureTableName}
+	backup, backupWarning := backupSchemaChanges(dbInst, runConfig, dbType, dbName, "DROP INDEX "+quoteIdentByType(dbType, indexName), []sqlstmt.SchemaChange{change})
+	if err := dbInst.DropIndex(schemaName, pureTableName, indexName); err != nil {
+		discardSchemaBackup(backup)
+		logger.Error(err, "删除索引失败：%s 表=%s 索引=%s", formatConnSummary(runConfig), pureTableName, indexName)
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return withSchemaBackup(connection.QueryResult{Success: true, Message: "索引已删除"}, backup, backupWarning)
+}
+
+// DBEstimateIndexSize 根据表行数与列宽估算新索引的大小，只读取统计信息，不扫描表数据。
+func (a *App) DBEstimateIndexSize(config connection.ConnectionConfig, dbName string, tableName string, index connection.IndexSpec) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	var estimate connection.IndexSizeEstimate
+	warning, err := a.runWithReconnect(runConfig, dbInst, "DBEstimateIndexSize", func(inst db.Database) (estimateErr error) {
+		estimate, estimateErr = inst.EstimateIndexSize(schemaName, pureTableName, index)
+		return estimateErr
+	})
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Message: warning, Data: estimate}
+}
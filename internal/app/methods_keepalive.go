@@ -0,0 +1,220 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/utils"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 连接保活与健康检查。防火墙/NAT 会静默丢弃长时间空闲的 TCP 连接，表现为午休回来后第一条查询失败。
+// 健康监视器定期检查空闲的缓存连接（设置了 KeepaliveInterval 的按该间隔执行保活语句，
+// 其余按 connectionHealthInterval 执行 Ping）以及标签页固定会话；缓存连接连续检查失败时移出缓存，
+// 下次使用时重建，并推送 connection:lost 事件让界面提前标记断开状态。移出的连接池可能仍有语句在其它连接上执行
+// （如耗时的报表），因此不立即关闭，等待 retiredDatabaseCloseDelay 后再关闭。空闲超时的标签页会话在保活之前释放。
+
+const (
+	healthMonitorTick    = 5 * time.Second
+	keepaliveMinInterval = 10 * time.Second
+	keepaliveTimeout     = 10 * time.Second
+
+	connectionHealthInterval = 2 * time.Minute
+	connectionLostEvent      = "connection:lost"
+
+	// keepaliveMaxFailures 连续失败达到该次数才移出缓存；失败后不更新检查时间，下一轮（约 5 秒后）立即重试
+	keepaliveMaxFailures      = 2
+	retiredDatabaseCloseDelay = 10 * time.Minute
+)
+
+// retiredDatabase 保活失败后移出缓存、尚未关闭的连接。
+type retiredDatabase struct {
+	inst      db.Database
+	config    connection.ConnectionConfig
+	retiredAt time.Time
+}
+
+// ConnectionLostEvent 健康检查发现缓存连接断开时推送给前端。
+type ConnectionLostEvent struct {
+	Type     string `json:"type"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	User     string `json:"user"`
+	Database string `json:"database"`
+	Message  string `json:"message"`
+	At       int64  `json:"at"` // Unix milli
+}
+
+// keepaliveInterval 返回连接的保活间隔，未启用时为 0（仍按 connectionHealthInterval 做健康检查）。
+func keepaliveInterval(config connection.ConnectionConfig) time.Duration {
+	if config.KeepaliveInterval <= 0 {
+		return 0
+	}
+	interval := time.Duration(config.KeepaliveInterval) * time.Second
+	if interval < keepaliveMinInterval {
+		interval = keepaliveMinInterval
+	}
+	return interval
+}
+
+// startHealthMonitor 启动后台健康监视器，ctx 结束时退出。
+func (a *App) startHealthMonitor(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(healthMonitorTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				a.runKeepalive(now)
+			}
+		}
+	}()
+}
+
+// healthCheckInterval 返回缓存连接的空闲检查间隔。
+func healthCheckInterval(config connection.ConnectionConfig) time.Duration {
+	if interval := keepaliveInterval(config); interval > 0 {
+		return interval
+	}
+	return connectionHealthInterval
+}
+
+// runKeepalive 对到期的缓存连接与固定会话各执行一次保活。
+func (a *App) runKeepalive(now time.Time) {
+	type dueEntry struct {
+		key   string
+		entry cachedDatabase
+	}
+	var due []dueEntry
+	a.mu.RLock()
+	for key, entry := range a.dbCache {
+		if entry.inst != nil && now.Sub(entry.lastPing) >= healthCheckInterval(entry.config) {
+			due = append(due, dueEntry{key: key, entry: entry})
+		}
+	}
+	a.mu.RUnlock()
+
+	for _, item := range due {
+		err := a.keepaliveDatabase(item.entry.inst, item.entry.config)
+		lost := false
+		a.mu.Lock()
+		if cur, exists := a.dbCache[item.key]; exists && cur.inst == item.entry.inst {
+			switch {
+			case err == nil:
+				cur.lastPing = time.Now()
+				cur.keepaliveFailures = 0
+				a.dbCache[item.key] = cur
+			case cur.keepaliveFailures+1 < keepaliveMaxFailures:
+				cur.keepaliveFailures++
+				a.dbCache[item.key] = cur
+				logger.Warnf("连接保活失败（第 %d 次），稍后重试：%s，原因：%v", cur.keepaliveFailures, formatConnSummary(cur.config), err)
+			default:
+				logger.Error(err, "连接保活连续失败，移除缓存连接：%s", formatConnSummary(cur.config))
+				delete(a.dbCache, item.key)
+				a.retiredDBs = append(a.retiredDBs, retiredDatabase{inst: cur.inst, config: cur.config, retiredAt: now})
+				lost = true
+			}
+		}
+		a.mu.Unlock()
+		if lost {
+			a.emitConnectionLost(item.entry.config, err)
+		}
+	}
+
+	a.closeRetiredDatabases(now)
+	a.releaseIdleTabSessions(now)
+	a.keepaliveTabSessions(now)
+}
+
+// closeRetiredDatabases 关闭移出缓存已超过 retiredDatabaseCloseDelay 的连接。
+func (a *App) closeRetiredDatabases(now time.Time) {
+	var expired []retiredDatabase
+	a.mu.Lock()
+	kept := a.retiredDBs[:0]
+	for _, retired := range a.retiredDBs {
+		if now.Sub(retired.retiredAt) >= retiredDatabaseCloseDelay {
+			expired = append(expired, retired)
+		} else {
+			kept = append(kept, retired)
+		}
+	}
+	a.retiredDBs = kept
+	a.mu.Unlock()
+
+	for _, retired := range expired {
+		if err := retired.inst.Close(); err != nil {
+			logger.Error(err, "关闭失效缓存连接失败：%s", formatConnSummary(retired.config))
+		}
+	}
+}
+
+// keepaliveDatabase 执行保活语句；未配置语句时使用驱动自身的 Ping。
+func (a *App) keepaliveDatabase(inst db.Database, config connection.ConnectionConfig) error {
+	statement := strings.TrimSpace(config.KeepaliveSQL)
+	if statement == "" {
+		return inst.Ping()
+	}
+	ctx, cancel := utils.ContextWithTimeout(keepaliveTimeout)
+	defer cancel()
+	_, _, err := a.queryTracked(ctx, nil, inst, statement)
+	return err
+}
+
+// keepaliveTabSessions 保活空闲的标签页固定会话。正在执行语句的会话跳过；失败只记录日志，
+// 会话状态已随连接丢失，由用户下次执行时看到错误后重新固定。
+func (a *App) keepaliveTabSessions(now time.Time) {
+	a.sessionMu.Lock()
+	sessions := make(map[string]*tabSession, len(a.tabSessions))
+	for tabID, pinned := range a.tabSessions {
+		sessions[tabID] = pinned
+	}
+	a.sessionMu.Unlock()
+
+	for tabID, pinned := range sessions {
+		interval := keepaliveInterval(pinned.config)
+		if interval <= 0 || !pinned.mu.TryLock() {
+			continue
+		}
+		idleSince := pinned.lastUsedAt
+		if pinned.keepaliveAt.After(idleSince) {
+			idleSince = pinned.keepaliveAt
+		}
+		if now.Sub(idleSince) >= interval && pinned.session != nil {
+			ctx, cancel := utils.ContextWithTimeout(keepaliveTimeout)
+			var err error
+			if statement := strings.TrimSpace(pinned.config.KeepaliveSQL); statement != "" {
+				_, _, err = pinned.session.QueryContext(ctx, statement)
+			} else {
+				err = pinned.session.Ping(ctx)
+			}
+			cancel()
+			pinned.keepaliveAt = time.Now()
+			if err != nil {
+				logger.Warnf("标签页会话保活失败：%s 标签页=%s，原因：%v", formatConnSummary(pinned.config), tabID, err)
+			}
+		}
+		pinned.mu.Unlock()
+	}
+}
+
+func (a *App) emitConnectionLost(config connection.ConnectionConfig, err error) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, connectionLostEvent, ConnectionLostEvent{
+		Type:     config.Type,
+		Host:     config.Host,
+		Port:     config.Port,
+		User:     config.User,
+		Database: config.Database,
+		Message:  normalizeErrorMessage(err),
+		At:       time.Now().UnixMilli(),
+	})
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type keepaliveFakeDB struct {
+	db.Database
+	pings   int
+	queries []string
+	fail    bool
+	closed  bool
+}
+
+func (f *keepaliveFakeDB) Ping() error {
+	f.pings++
+	if f.fail {
+		return errors.New("connection reset by peer")
+	}
+	return nil
+}
+
+func (f *keepaliveFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	f.queries = append(f.queries, query)
+	return nil, nil, nil
+}
+
+func (f *keepaliveFakeDB) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestRunKeepalive(t *testing.T) {
+	a := NewApp()
+	old := time.Now().Add(-time.Minute)
+	withSQL := &keepaliveFakeDB{}
+	failing := &keepaliveFakeDB{fail: true}
+	disabled := &keepaliveFakeDB{}
+	a.dbCache["sql"] = cachedDatabase{inst: withSQL, lastPing: old, config: connection.ConnectionConfig{KeepaliveInterval: 30, KeepaliveSQL: "SELECT 1"}}
+	a.dbCache["fail"] = cachedDatabase{inst: failing, lastPing: old, config: connection.ConnectionConfig{KeepaliveInterval: 1}}
+	a.dbCache["off"] = cachedDatabase{inst: disabled, lastPing: old}
+
+	a.runKeepalive(time.Now())
+	if len(withSQL.queries) != 1 || withSQL.queries[0] != "SELECT 1" || withSQL.pings != 0 {
+		t.Fatalf("keepalive statement not executed: %+v", withSQL)
+	}
+	if _, ok := a.dbCache["fail"]; !ok || failing.closed {
+		t.Fatal("a single failed keepalive should keep the cached connection")
+	}
+	if disabled.pings != 0 || len(disabled.queries) != 0 {
+		t.Fatal("connection without keepalive should wait for the default health interval")
+	}
+
+	// 刚保活过，未到间隔不再执行
+	a.runKeepalive(time.Now())
+	if len(withSQL.queries) != 1 {
+		t.Fatalf("keepalive ran again before interval: %d", len(withSQL.queries))
+	}
+	// 连续失败后移出缓存，但等在途语句结束后才关闭
+	a.runKeepalive(time.Now())
+	if _, ok := a.dbCache["fail"]; ok || failing.closed || len(a.retiredDBs) != 1 {
+		t.Fatalf("repeated keepalive failure should evict without closing, closed=%v retired=%d", failing.closed, len(a.retiredDBs))
+	}
+	a.runKeepalive(time.Now().Add(retiredDatabaseCloseDelay))
+	if !failing.closed || len(a.retiredDBs) != 0 {
+		t.Fatal("retired connection should be closed after the delay")
+	}
+
+	idle := &keepaliveFakeDB{}
+	a.dbCache["idle"] = cachedDatabase{inst: idle, lastPing: time.Now().Add(-connectionHealthInterval)}
+	a.runKeepalive(time.Now())
+	if idle.pings != 1 {
+		t.Fatalf("idle connection should be health checked, pings=%d", idle.pings)
+	}
+	if keepaliveInterval(connection.ConnectionConfig{KeepaliveInterval: 1}) != keepaliveMinInterval {
+		t.Fatal("interval should be clamped to the minimum")
+	}
+}
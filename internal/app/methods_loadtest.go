@@ -0,0 +1,394 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	loadTestProgressEvent   = "loadtest:progress"
+	loadTestMaxRows         = 100_000_000
+	loadTestDefaultBatch    = 5000
+	loadTestMaxBatch        = 50000
+	loadTestMaxInsertValues = 1000 // SQL Server 单条 INSERT VALUES 上限
+	loadTestMaxErrors       = 20
+)
+
+// LoadTestOptions 压测写入参数。
+type LoadTestOptions struct {
+	JobID     string   `json:"jobId,omitempty"` // 同时作为查询 ID，可通过 CancelQuery 取消
+	TableName string   `json:"tableName"`
+	Rows      int64    `json:"rows"`
+	BatchSize int      `json:"batchSize,omitempty"`
+	Columns   []string `json:"columns,omitempty"`  // 为空时写入全部非自增列
+	ForceSQL  bool     `json:"forceSql,omitempty"` // 强制使用批量 INSERT，便于对比
+}
+
+// LoadTestReport 压测写入结果。
+type LoadTestReport struct {
+	JobID         string   `json:"jobId"`
+	Table         string   `json:"table"`
+	Method        string   `json:"method"`
+	RequestedRows int64    `json:"requestedRows"`
+	InsertedRows  int64    `json:"insertedRows"`
+	Batches       int      `json:"batches"`
+	DurationMs    int64    `json:"durationMs"`
+	RowsPerSecond float64  `json:"rowsPerSecond"`
+	Cancelled     bool     `json:"cancelled,omitempty"`
+	Errors        []string `json:"errors,omitempty"`
+}
+
+// RunInsertLoadTest 向指定表写入 N 行生成数据，按驱动选择最快的写入通道，并报告吞吐。
+func (a *App) RunInsertLoadTest(config connection.ConnectionConfig, dbName string, options LoadTestOptions) connection.QueryResult {
+	if err := ensureWritable(config, "压测写入"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	tableName := strings.TrimSpace(options.TableName)
+	if tableName == "" {
+		return connection.QueryResult{Success: false, Message: "表名不能为空"}
+	}
+	if options.Rows <= 0 {
+		return connection.QueryResult{Success: false, Message: "写入行数必须大于 0"}
+	}
+	if options.Rows > loadTestMaxRows {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("单次写入行数不能超过 %d", loadTestMaxRows)}
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = loadTestDefaultBatch
+	}
+	if batchSize > loadTestMaxBatch {
+		batchSize = loadTestMaxBatch
+	}
+	jobID := strings.TrimSpace(options.JobID)
+	if jobID == "" {
+		jobID = fmt.Sprintf("loadtest-%d", time.Now().UnixNano())
+	}
+
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+
+	schemaName, pureTableName := normalizeSchemaAndTable(runConfig, dbName, tableName)
+	defs, err := dbInst.GetColumns(schemaName, pureTableName)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("获取表字段失败：%s", err.Error())}
+	}
+	columns, err := selectLoadTestColumns(defs, options.Columns)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	qualifiedTable := qualifyTable(schemaName, pureTableName)
+	seqStart := resolveLoadTestSeqStart(dbInst, dbType, qualifiedTable, columns)
+
+	var loader db.BulkLoader
+	method := "INSERT"
+	if l, ok := dbInst.(db.BulkLoader); ok && !options.ForceSQL && strings.TrimSpace(l.BulkLoadMethod()) != "" {
+		loader = l
+		method = l.BulkLoadMethod()
+	}
+	insertBatch := batchSize
+	if dbType == "sqlserver" && insertBatch > loadTestMaxInsertValues {
+		insertBatch = loadTestMaxInsertValues
+	}
+
+	ctx, rq, err := a.registerQuery(context.Background(), jobID, runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	defer a.unregisterQuery(rq)
+
+	timeoutSeconds := runConfig.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 30
+	}
+
+	report := LoadTestReport{JobID: jobID, Table: qualifiedTable, Method: method, RequestedRows: options.Rows}
+	logger.Infof("开始压测写入：%s 表=%s 行数=%d 批大小=%d 方式=%s", formatConnSummary(runConfig), qualifiedTable, options.Rows, batchSize, method)
+	start := time.Now()
+	lastEmit := time.Time{}
+
+	for offset := int64(0); offset < options.Rows; {
+		if ctx.Err() != nil {
+			report.Cancelled = true
+			break
+		}
+		size := int64(batchSize)
+		if loader == nil {
+			size = int64(insertBatch)
+		}
+		if remaining := options.Rows - offset; remaining < size {
+			size = remaining
+		}
+		rows := generateLoadTestRows(columns, seqStart+offset, size)
+
+		batchCtx, batchCancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second*10)
+		var affected int64
+		var batchErr error
+		if loader != nil {
+			affected, batchErr = loader.BulkLoad(batchCtx, qualifiedTable, loadTestColumnNames(columns), rows)
+			if batchErr != nil && ctx.Err() == nil && report.InsertedRows == 0 && report.Batches == 0 {
+				// 高速通道不可用（如未开启 local_infile），回退到批量 INSERT 并重试本批。
+				logger.Warnf("压测写入高速通道 %s 不可用，回退为批量 INSERT：%v", method, batchErr)
+				report.Errors = append(report.Errors, fmt.Sprintf("%s 不可用，已回退为 INSERT：%s", method, normalizeErrorMessage(batchErr)))
+				loader = nil
+				method = "INSERT"
+				report.Method = method
+				batchCancel()
+				continue
+			}
+		} else {
+			affected, batchErr = execLoadTestInsert(batchCtx, dbInst, dbType, qualifiedTable, columns, rows)
+		}
+		batchCancel()
+		if batchErr != nil && ctx.Err() != nil {
+			report.Cancelled = true
+			break
+		}
+
+		report.Batches++
+		offset += size
+		if batchErr != nil {
+			if len(report.Errors) < loadTestMaxErrors {
+				report.Errors = append(report.Errors, fmt.Sprintf("第 %d 批写入失败：%s", report.Batches, normalizeErrorMessage(batchErr)))
+			}
+		} else {
+			if affected <= 0 {
+				affected = size
+			}
+			report.InsertedRows += affected
+		}
+
+		if time.Since(lastEmit) >= 500*time.Millisecond || offset >= options.Rows {
+			lastEmit = time.Now()
+			elapsed := time.Since(start).Seconds()
+			rate := 0.0
+			if elapsed > 0 {
+				rate = float64(report.InsertedRows) / elapsed
+			}
+			if a.ctx != nil {
+				runtime.EventsEmit(a.ctx, loadTestProgressEvent, map[string]interface{}{
+					"jobId":         jobID,
+					"current":       offset,
+					"total":         options.Rows,
+					"inserted":      report.InsertedRows,
+					"rowsPerSecond": rate,
+					"method":        method,
+				})
+			}
+		}
+	}
+
+	report.DurationMs = time.Since(start).Milliseconds()
+	if seconds := time.Since(start).Seconds(); seconds > 0 {
+		report.RowsPerSecond = float64(report.InsertedRows) / seconds
+	}
+	logger.Infof("压测写入结束：表=%s 方式=%s 写入=%d/%d 耗时=%dms 吞吐=%.0f 行/秒", qualifiedTable, report.Method, report.InsertedRows, options.Rows, report.DurationMs, report.RowsPerSecond)
+
+	msg := fmt.Sprintf("写入 %d 行，耗时 %.2f 秒，吞吐 %.0f 行/秒（%s）", report.InsertedRows, float64(report.DurationMs)/1000, report.RowsPerSecond, report.Method)
+	if report.Cancelled {
+		msg = "已取消：" + msg
+	}
+	return connection.QueryResult{Success: report.InsertedRows > 0 || len(report.Errors) == 0, Message: msg, Data: report}
+}
+
+type loadTestColumn struct {
+	name     string
+	kind     string
+	maxLen   int
+	sequence bool
+}
+
+var loadTestLengthPattern = regexp.MustCompile(`\((\d+)`)
+
+func selectLoadTestColumns(defs []connection.ColumnDefinition, wanted []string) ([]loadTestColumn, error) {
+	wantedSet := make(map[string]struct{}, len(wanted))
+	for _, name := range wanted {
+		if trimmed := strings.ToLower(strings.TrimSpace(name)); trimmed != "" {
+			wantedSet[trimmed] = struct{}{}
+		}
+	}
+
+	columns := make([]loadTestColumn, 0, len(defs))
+	for _, def := range defs {
+		name := strings.TrimSpace(def.Name)
+		if name == "" {
+			continue
+		}
+		if len(wantedSet) > 0 {
+			if _, ok := wantedSet[strings.ToLower(name)]; !ok {
+				continue
+			}
+		} else if isLoadTestGeneratedColumn(def) {
+			continue
+		}
+
+		col := loadTestColumn{name: name, kind: classifyLoadTestType(def.Type)}
+		if m := loadTestLengthPattern.FindStringSubmatch(def.Type); len(m) == 2 && col.kind == "string" {
+			col.maxLen, _ = strconv.Atoi(m[1])
+		}
+		key := strings.ToUpper(strings.TrimSpace(def.Key))
+		col.sequence = (key == "PRI" || key == "PK") && col.kind == "int"
+		columns = append(columns, col)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("没有可写入的字段")
+	}
+	return columns, nil
+}
+
+func isLoadTestGeneratedColumn(def connection.ColumnDefinition) bool {
+	extra := strings.ToLower(def.Extra)
+	if strings.Contains(extra, "auto_increment") || strings.Contains(extra, "identity") || strings.Contains(extra, "generated") {
+		return true
+	}
+	if def.Default != nil && strings.Contains(strings.ToLower(*def.Default), "nextval(") {
+		return true
+	}
+	typ := strings.ToLower(def.Type)
+	return strings.Contains(typ, "serial")
+}
+
+func classifyLoadTestType(columnType string) string {
+	t := strings.ToLower(strings.TrimSpace(columnType))
+	switch {
+	case strings.HasPrefix(t, "bool"):
+		return "bool"
+	case strings.HasPrefix(t, "bit"):
+		return "bit"
+	case strings.Contains(t, "int") && !strings.Contains(t, "interval") && !strings.Contains(t, "point"):
+		return "int"
+	case strings.HasPrefix(t, "decimal"), strings.HasPrefix(t, "numeric"), strings.HasPrefix(t, "number"),
+		strings.HasPrefix(t, "float"), strings.HasPrefix(t, "double"), strings.HasPrefix(t, "real"), strings.HasPrefix(t, "money"):
+		return "float"
+	case strings.HasPrefix(t, "timestamp"), strings.HasPrefix(t, "datetime"):
+		return "datetime"
+	case strings.HasPrefix(t, "date"):
+		return "date"
+	case strings.HasPrefix(t, "time"):
+		return "time"
+	case strings.HasPrefix(t, "year"):
+		return "year"
+	case strings.Contains(t, "uuid"), strings.Contains(t, "uniqueidentifier"):
+		return "uuid"
+	case strings.HasPrefix(t, "json"):
+		return "json"
+	case strings.Contains(t, "blob"), strings.Contains(t, "binary"), strings.Contains(t, "bytea"):
+		return "binary"
+	default:
+		return "string"
+	}
+}
+
+func loadTestColumnNames(columns []loadTestColumn) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.name
+	}
+	return names
+}
+
+// resolveLoadTestSeqStart 读取整数主键当前最大值，生成数据从其后递增，避免主键冲突。
+func resolveLoadTestSeqStart(dbInst db.Database, dbType string, qualifiedTable string, columns []loadTestColumn) int64 {
+	for _, col := range columns {
+		if !col.sequence {
+			continue
+		}
+		query := fmt.Sprintf("SELECT MAX(%s) AS max_id FROM %s", quoteIdentByType(dbType, col.name), quoteQualifiedIdentByType(dbType, qualifiedTable))
+		data, _, err := dbInst.Query(query)
+		if err != nil || len(data) == 0 {
+			return 1
+		}
+		for _, v := range data[0] {
+			if v == nil {
+				return 1
+			}
+			if n, convErr := strconv.ParseInt(strings.TrimSpace(fmt.Sprintf("%v", v)), 10, 64); convErr == nil {
+				return n + 1
+			}
+		}
+		return 1
+	}
+	return 1
+}
+
+var loadTestBaseTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func generateLoadTestRows(columns []loadTestColumn, seqStart int64, count int64) [][]interface{} {
+	rows := make([][]interface{}, 0, count)
+	for i := int64(0); i < count; i++ {
+		seq := seqStart + i
+		row := make([]interface{}, len(columns))
+		for c, col := range columns {
+			row[c] = generateLoadTestValue(col, seq)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func generateLoadTestValue(col loadTestColumn, seq int64) interface{} {
+	switch col.kind {
+	case "int":
+		if col.sequence {
+			return seq
+		}
+		return seq % 100
+	case "bool":
+		if seq%2 == 0 {
+			return "1"
+		}
+		return "0"
+	case "bit":
+		return seq % 2
+	case "float":
+		return float64(seq%100000) / 100
+	case "datetime":
+		return loadTestBaseTime.Add(time.Duration(seq) * time.Second).Format("2006-01-02 15:04:05")
+	case "date":
+		return loadTestBaseTime.AddDate(0, 0, int(seq%3650)).Format("2006-01-02")
+	case "time":
+		return loadTestBaseTime.Add(time.Duration(seq%86400) * time.Second).Format("15:04:05")
+	case "year":
+		return 2000 + seq%50
+	case "uuid":
+		return fmt.Sprintf("00000000-0000-4000-8000-%012x", seq&0xffffffffffff)
+	case "json":
+		return fmt.Sprintf(`{"seq":%d}`, seq)
+	case "binary":
+		return fmt.Sprintf("b%d", seq)
+	default:
+		text := fmt.Sprintf("row-%d", seq)
+		if col.maxLen > 0 && len(text) > col.maxLen {
+			text = text[len(text)-col.maxLen:]
+		}
+		return text
+	}
+}
+
+// execLoadTestInsert 回退路径：单条多行 INSERT；Oracle/达梦使用 INSERT ALL。
+func execLoadTestInsert(ctx context.Context, dbInst db.Database, dbType string, qualifiedTable string, columns []loadTestColumn, rows [][]interface{}) (int64, error) {
+	values := make([][]string, len(rows))
+	for r, row := range rows {
+		literals := make([]string, len(row))
+		for i, v := range row {
+			literals[i] = formatSQLValue(dbType, v)
+		}
+		values[r] = literals
+	}
+	query := buildInsertRowsSQL(dbType, qualifiedTable, loadTestColumnNames(columns), values)
+	return databaseExecFunc(dbInst)(ctx, query)
+}
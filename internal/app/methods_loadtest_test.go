@@ -0,0 +1,85 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestSelectLoadTestColumns(t *testing.T) {
+	nextval := "nextval('users_id_seq'::regclass)"
+	defs := []connection.ColumnDefinition{
+		{Name: "id", Type: "int", Key: "PRI", Extra: "auto_increment"},
+		{Name: "code", Type: "bigint", Key: "PRI"},
+		{Name: "name", Type: "varchar(4)"},
+		{Name: "serial_id", Type: "integer", Default: &nextval},
+		{Name: "total", Type: "decimal(10,2)"},
+	}
+	columns, err := selectLoadTestColumns(defs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []loadTestColumn{
+		{name: "code", kind: "int", sequence: true},
+		{name: "name", kind: "string", maxLen: 4},
+		{name: "total", kind: "float"},
+	}
+	if !reflect.DeepEqual(columns, want) {
+		t.Fatalf("columns = %+v", columns)
+	}
+
+	// 显式指定时自增列也会写入
+	columns, err = selectLoadTestColumns(defs, []string{"ID", "name"})
+	if err != nil || len(columns) != 2 || columns[0].name != "id" || !columns[0].sequence {
+		t.Fatalf("columns = %+v err = %v", columns, err)
+	}
+	if _, err := selectLoadTestColumns(defs, []string{"missing"}); err == nil {
+		t.Fatal("expected error when no column matches")
+	}
+}
+
+func TestClassifyLoadTestType(t *testing.T) {
+	cases := map[string]string{
+		"tinyint(1)":               "int",
+		"boolean":                  "bool",
+		"bit(1)":                   "bit",
+		"interval":                 "string",
+		"point":                    "string",
+		"numeric(10,2)":            "float",
+		"double precision":         "float",
+		"timestamp with time zone": "datetime",
+		"date":                     "date",
+		"time":                     "time",
+		"year":                     "year",
+		"uniqueidentifier":         "uuid",
+		"jsonb":                    "json",
+		"varbinary(16)":            "binary",
+		"bytea":                    "binary",
+		"text":                     "string",
+	}
+	for typ, want := range cases {
+		if got := classifyLoadTestType(typ); got != want {
+			t.Errorf("classifyLoadTestType(%q) = %q, want %q", typ, got, want)
+		}
+	}
+}
+
+func TestGenerateLoadTestRows(t *testing.T) {
+	columns := []loadTestColumn{
+		{name: "id", kind: "int", sequence: true},
+		{name: "qty", kind: "int"},
+		{name: "flag", kind: "bool"},
+		{name: "code", kind: "string", maxLen: 4},
+		{name: "at", kind: "datetime"},
+		{name: "uid", kind: "uuid"},
+	}
+	rows := generateLoadTestRows(columns, 101, 2)
+	if len(rows) != 2 {
+		t.Fatalf("rows = %v", rows)
+	}
+	want := []interface{}{int64(102), int64(2), "1", "-102", "2020-01-01 00:01:42", "00000000-0000-4000-8000-000000000066"}
+	if !reflect.DeepEqual(rows[1], want) {
+		t.Fatalf("row = %#v", rows[1])
+	}
+}
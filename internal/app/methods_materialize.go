@@ -0,0 +1,462 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/utils"
+)
+
+const (
+	materializeTimeout     = 10 * time.Minute
+	materializeInsertBatch = 500
+	materializeBulkBatch   = 5000
+)
+
+// MaterializeOptions 查询结果另存为表的参数。
+type MaterializeOptions struct {
+	TargetConfig *connection.ConnectionConfig `json:"targetConfig,omitempty"` // 为空时写入源连接
+	TargetDB     string                       `json:"targetDb,omitempty"`     // 为空时沿用源库（仅同连接时）
+	TableName    string                       `json:"tableName"`
+	Temporary    bool                         `json:"temporary,omitempty"` // 临时表，需在已固定会话的标签页中创建
+	TabID        string                       `json:"tabId,omitempty"`
+	Replace      bool                         `json:"replace,omitempty"` // 目标表已存在时先删除
+}
+
+// MaterializeReport 另存为表的结果。
+type MaterializeReport struct {
+	Table      string                `json:"table"`
+	Temporary  bool                  `json:"temporary,omitempty"`
+	Method     string                `json:"method"`
+	Columns    []QuerySnapshotColumn `json:"columns"`
+	Rows       int64                 `json:"rows"`
+	DurationMs int64                 `json:"durationMs"`
+	CreateSQL  string                `json:"createSql"`
+}
+
+type sqlExecFunc func(ctx context.Context, query string) (int64, error)
+
+// SaveQueryResultAsTable 执行查询并把结果写入新表（CREATE TABLE + 批量写入），目标可以是同一连接或其他连接。
+// 列类型按值推断（规则同 DuckDB 快照）后映射为目标库类型；临时表只在会话内可见，因此要求标签页已固定会话。
+func (a *App) SaveQueryResultAsTable(config connection.ConnectionConfig, dbName string, query string, options MaterializeOptions) connection.QueryResult {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return connection.QueryResult{Success: false, Message: "查询语句不能为空"}
+	}
+	tableName := strings.TrimSpace(options.TableName)
+	if tableName == "" {
+		return connection.QueryResult{Success: false, Message: "表名不能为空"}
+	}
+
+	runConfig := normalizeRunConfig(config, dbName)
+	targetConfig, targetDB := runConfig, dbName
+	if options.TargetConfig != nil {
+		targetConfig = *options.TargetConfig
+		targetDB = ""
+	}
+	if name := strings.TrimSpace(options.TargetDB); name != "" {
+		targetDB = name
+	}
+	targetConfig = normalizeRunConfig(targetConfig, targetDB)
+	if err := checkReadOnlySQL(runConfig, query); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if err := ensureWritable(targetConfig, "创建结果表"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(targetConfig)
+	if _, err := materializeColumnType(dbType, "VARCHAR", 0); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if options.Temporary && dbType == "clickhouse" {
+		return connection.QueryResult{Success: false, Message: "ClickHouse 不支持在会话外使用临时表，请取消“临时表”选项"}
+	}
+
+	var pinned *tabSession
+	if options.Temporary {
+		a.sessionMu.Lock()
+		pinned = a.tabSessions[strings.TrimSpace(options.TabID)]
+		a.sessionMu.Unlock()
+		if pinned == nil {
+			return connection.QueryResult{Success: false, Message: "临时表仅在当前会话可见，请先为标签页固定会话"}
+		}
+		if getCacheKey(applyCustomDriverType(targetConfig)) != pinned.cacheKey {
+			return connection.QueryResult{Success: false, Message: "临时表只能创建在标签页固定会话所在的连接和数据库中"}
+		}
+	}
+
+	sourceInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	ctx, cancel := utils.ContextWithTimeout(materializeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	query = sanitizeSQLForPgLike(runConfig.Type, query)
+	var data []map[string]interface{}
+	var columns, driverTypes []string
+	queryCtx := db.WithColumnTypes(ctx, &driverTypes)
+	if q, ok := sourceInst.(interface {
+		QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+	}); ok {
+		data, columns, err = q.QueryContext(queryCtx, query)
+	} else {
+		data, columns, err = sourceInst.Query(query)
+	}
+	if err != nil {
+		logger.Error(err, "另存为表查询失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(query))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if len(columns) == 0 {
+		return connection.QueryResult{Success: false, Message: "查询未返回任何列"}
+	}
+
+	resultColumns := inferSnapshotColumns(resolveDDLDBType(runConfig), columns, driverTypes, data)
+	createSQL, qualifiedTable, err := buildMaterializeCreateSQL(dbType, targetConfig, targetDB, tableName, options.Temporary, resultColumns, data)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	var exec sqlExecFunc
+	var loader db.BulkLoader
+	if pinned != nil {
+		pinned.mu.Lock()
+		defer pinned.mu.Unlock()
+		if pinned.session == nil {
+			return connection.QueryResult{Success: false, Message: "标签页会话已释放"}
+		}
+		pinned.lastUsedAt = time.Now()
+		exec = pinned.session.ExecContext
+	} else {
+		targetInst, err := a.getDatabase(targetConfig)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		exec = databaseExecFunc(targetInst)
+		if l, ok := targetInst.(db.BulkLoader); ok && strings.TrimSpace(l.BulkLoadMethod()) != "" {
+			loader = l
+		}
+	}
+
+	if options.Replace {
+		dropSQL := fmt.Sprintf("DROP TABLE IF EXISTS %s", quoteQualifiedIdentByType(dbType, qualifiedTable))
+		if dbType == "oracle" {
+			dropSQL = fmt.Sprintf("DROP TABLE %s", quoteQualifiedIdentByType(dbType, qualifiedTable))
+		}
+		if _, err := exec(ctx, dropSQL); err != nil && dbType != "oracle" {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("删除已有表失败：%s", normalizeErrorMessage(err))}
+		}
+	}
+	if _, err := exec(ctx, createSQL); err != nil {
+		logger.Error(err, "另存为表建表失败：%s SQL片段=%q", formatConnSummary(targetConfig), sqlSnippet(createSQL))
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("创建表失败：%s", normalizeErrorMessage(err))}
+	}
+
+	report := MaterializeReport{Table: qualifiedTable, Temporary: options.Temporary, Method: "INSERT", Columns: resultColumns, CreateSQL: createSQL}
+	rows := materializeRows(resultColumns, data)
+	report.Rows, report.Method, err = loadMaterializedRows(ctx, exec, loader, dbType, qualifiedTable, resultColumns, rows)
+	report.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		logger.Error(err, "另存为表写入失败，删除已创建的表：%s 表=%s", formatConnSummary(targetConfig), qualifiedTable)
+		if _, dropErr := exec(ctx, fmt.Sprintf("DROP TABLE %s", quoteQualifiedIdentByType(dbType, qualifiedTable))); dropErr != nil {
+			logger.Error(dropErr, "删除未写完的表失败：表=%s", qualifiedTable)
+		}
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("写入数据失败：%s", normalizeErrorMessage(err))}
+	}
+
+	logger.Infof("查询结果已另存为表：%s 表=%s 行数=%d 方式=%s 耗时=%dms", formatConnSummary(targetConfig), qualifiedTable, report.Rows, report.Method, report.DurationMs)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已创建表 %s 并写入 %d 行", qualifiedTable, report.Rows), Data: report}
+}
+
+func databaseExecFunc(inst db.Database) sqlExecFunc {
+	if e, ok := inst.(interface {
+		ExecContext(context.Context, string) (int64, error)
+	}); ok {
+		return e.ExecContext
+	}
+	return func(_ context.Context, query string) (int64, error) {
+		return inst.Exec(query)
+	}
+}
+
+// buildMaterializeCreateSQL 生成建表语句，返回语句与未加引号的限定表名。
+// 临时表不带 schema：PostgreSQL/SQLite 的临时表有固定 schema，SQL Server 临时表在 tempdb 中并以 # 开头。
+func buildMaterializeCreateSQL(dbType string, config connection.ConnectionConfig, dbName string, tableName string, temporary bool, columns []QuerySnapshotColumn, data []map[string]interface{}) (string, string, error) {
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	qualifiedTable := qualifyTable(schemaName, pureTableName)
+	if temporary {
+		qualifiedTable = pureTableName
+		if dbType == "sqlserver" && !strings.HasPrefix(pureTableName, "#") {
+			qualifiedTable = "#" + pureTableName
+		}
+	}
+
+	defs := make([]string, len(columns))
+	for i, col := range columns {
+		maxLen := 0
+		if col.Type == "VARCHAR" {
+			for _, row := range data {
+				if v := row[col.Name]; v != nil {
+					if n := utf8.RuneCountInString(fmt.Sprintf("%v", v)); n > maxLen {
+						maxLen = n
+					}
+				}
+			}
+		}
+		colType, err := materializeColumnType(dbType, col.Type, maxLen)
+		if err != nil {
+			return "", "", err
+		}
+		defs[i] = fmt.Sprintf("%s %s", quoteIdentByType(dbType, col.Name), colType)
+	}
+
+	create := "CREATE TABLE"
+	suffix := ""
+	if temporary {
+		switch dbType {
+		case "oracle", "dameng":
+			// 全局临时表的定义是持久的，数据按会话隔离
+			create = "CREATE GLOBAL TEMPORARY TABLE"
+			suffix = " ON COMMIT PRESERVE ROWS"
+		case "sqlserver":
+		case "sqlite", "duckdb":
+			create = "CREATE TEMP TABLE"
+		default:
+			create = "CREATE TEMPORARY TABLE"
+		}
+	}
+	if dbType == "clickhouse" {
+		suffix = " ENGINE = MergeTree ORDER BY tuple()"
+	}
+	createSQL := fmt.Sprintf("%s %s (%s)%s", create, quoteQualifiedIdentByType(dbType, qualifiedTable), strings.Join(defs, ", "), suffix)
+	return createSQL, qualifiedTable, nil
+}
+
+// materializeColumnType 把推断出的通用类型（BOOLEAN/BIGINT/DOUBLE/DECIMAL(p,s)/DATE/TIMESTAMP/VARCHAR）映射为目标库类型，
+// maxLen 为文本列的最大字符数，用于选择定长或大文本类型。
+func materializeColumnType(dbType string, generic string, maxLen int) (string, error) {
+	if args, ok := strings.CutPrefix(generic, "DECIMAL"); ok {
+		switch dbType {
+		case "oracle":
+			return "NUMBER" + args, nil
+		case "clickhouse":
+			return "Nullable(Decimal" + args + ")", nil
+		case "sqlite":
+			// SQLite 的 NUMERIC 亲和性会把超出 DOUBLE 精度的值转为浮点，按文本保存
+			generic = "VARCHAR"
+		}
+	}
+	var types map[string]string
+	text := ""
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		types = map[string]string{"BOOLEAN": "TINYINT(1)", "BIGINT": "BIGINT", "DOUBLE": "DOUBLE", "DATE": "DATE", "TIMESTAMP": "DATETIME(6)"}
+		switch {
+		case maxLen <= 255:
+			text = "VARCHAR(255)"
+		case maxLen <= 16383:
+			text = "TEXT"
+		default:
+			text = "LONGTEXT"
+		}
+	case "postgres", "kingbase", "highgo", "vastbase":
+		types = map[string]string{"BOOLEAN": "BOOLEAN", "BIGINT": "BIGINT", "DOUBLE": "DOUBLE PRECISION", "DATE": "DATE", "TIMESTAMP": "TIMESTAMP"}
+		text = "TEXT"
+	case "sqlserver":
+		types = map[string]string{"BOOLEAN": "BIT", "BIGINT": "BIGINT", "DOUBLE": "FLOAT", "DATE": "DATE", "TIMESTAMP": "DATETIME2"}
+		switch {
+		case maxLen <= 255:
+			text = "NVARCHAR(255)"
+		case maxLen <= 4000:
+			text = "NVARCHAR(4000)"
+		default:
+			text = "NVARCHAR(MAX)"
+		}
+	case "oracle":
+		types = map[string]string{"BOOLEAN": "NUMBER(1)", "BIGINT": "NUMBER(19)", "DOUBLE": "BINARY_DOUBLE", "DATE": "DATE", "TIMESTAMP": "TIMESTAMP"}
+		switch {
+		case maxLen <= 255:
+			text = "VARCHAR2(255 CHAR)"
+		case maxLen <= 1000:
+			text = "VARCHAR2(1000 CHAR)"
+		default:
+			text = "CLOB"
+		}
+	case "dameng":
+		types = map[string]string{"BOOLEAN": "BIT", "BIGINT": "BIGINT", "DOUBLE": "DOUBLE", "DATE": "DATE", "TIMESTAMP": "TIMESTAMP"}
+		switch {
+		case maxLen <= 255:
+			text = "VARCHAR(255)"
+		case maxLen <= 2000:
+			text = "VARCHAR(2000)"
+		default:
+			text = "CLOB"
+		}
+	case "sqlite":
+		types = map[string]string{"BOOLEAN": "INTEGER", "BIGINT": "INTEGER", "DOUBLE": "REAL", "DATE": "TEXT", "TIMESTAMP": "TEXT"}
+		text = "TEXT"
+	case "duckdb":
+		types = map[string]string{"BOOLEAN": "BOOLEAN", "BIGINT": "BIGINT", "DOUBLE": "DOUBLE", "DATE": "DATE", "TIMESTAMP": "TIMESTAMP"}
+		text = "VARCHAR"
+	case "clickhouse":
+		types = map[string]string{"BOOLEAN": "Nullable(UInt8)", "BIGINT": "Nullable(Int64)", "DOUBLE": "Nullable(Float64)", "DATE": "Nullable(Date)", "TIMESTAMP": "Nullable(DateTime64(6))"}
+		text = "Nullable(String)"
+	default:
+		return "", fmt.Errorf("暂不支持在 %s 中创建结果表", dbType)
+	}
+	if t, ok := types[generic]; ok {
+		return t, nil
+	}
+	if strings.HasPrefix(generic, "DECIMAL(") {
+		return generic, nil
+	}
+	return text, nil
+}
+
+// materializeRows 按推断类型整理行数据：布尔文本转为 bool，时间文本转为 time.Time，便于高速通道按原生类型写入。
+func materializeRows(columns []QuerySnapshotColumn, data []map[string]interface{}) [][]interface{} {
+	rows := make([][]interface{}, len(data))
+	for r, record := range data {
+		row := make([]interface{}, len(columns))
+		for i, col := range columns {
+			value := record[col.Name]
+			switch col.Type {
+			case "BOOLEAN":
+				if b, ok := snapshotBool(value); ok {
+					value = b
+				}
+			case "DATE", "TIMESTAMP":
+				if s, ok := value.(string); ok {
+					if t, ok := parseTemporalString(strings.TrimSpace(s)); ok {
+						value = t
+					} else if t, err := time.Parse("2006-01-02", strings.TrimSpace(s)); err == nil {
+						value = t
+					}
+				}
+			}
+			row[i] = value
+		}
+		rows[r] = row
+	}
+	return rows
+}
+
+// loadMaterializedRows 写入整理后的行，优先使用驱动的高速通道，首批失败时回退为批量 INSERT。
+func loadMaterializedRows(ctx context.Context, exec sqlExecFunc, loader db.BulkLoader, dbType string, qualifiedTable string, columns []QuerySnapshotColumn, rows [][]interface{}) (int64, string, error) {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+	if loader != nil && len(rows) > 0 {
+		var loaded int64
+		for start := 0; start < len(rows); start += materializeBulkBatch {
+			end := start + materializeBulkBatch
+			if end > len(rows) {
+				end = len(rows)
+			}
+			affected, err := loader.BulkLoad(ctx, qualifiedTable, names, rows[start:end])
+			if err != nil {
+				if start > 0 {
+					return loaded, loader.BulkLoadMethod(), err
+				}
+				logger.Warnf("另存为表高速通道 %s 不可用，回退为批量 INSERT：%v", loader.BulkLoadMethod(), err)
+				break
+			}
+			if affected <= 0 {
+				affected = int64(end - start)
+			}
+			loaded += affected
+			if end == len(rows) {
+				return loaded, loader.BulkLoadMethod(), nil
+			}
+		}
+	}
+
+	var inserted int64
+	for start := 0; start < len(rows); start += materializeInsertBatch {
+		end := start + materializeInsertBatch
+		if end > len(rows) {
+			end = len(rows)
+		}
+		values := make([][]string, end-start)
+		for r, row := range rows[start:end] {
+			literals := make([]string, len(row))
+			for i, v := range row {
+				literals[i] = formatMaterializeValue(dbType, columns[i].Type, v)
+			}
+			values[r] = literals
+		}
+		if _, err := exec(ctx, buildInsertRowsSQL(dbType, qualifiedTable, names, values)); err != nil {
+			return inserted, "INSERT", err
+		}
+		inserted += int64(end - start)
+	}
+	return inserted, "INSERT", nil
+}
+
+func formatMaterializeValue(dbType string, columnType string, value interface{}) string {
+	if value == nil {
+		return "NULL"
+	}
+	switch columnType {
+	case "BOOLEAN":
+		if b, ok := value.(bool); ok {
+			switch dbType {
+			case "postgres", "kingbase", "highgo", "vastbase", "duckdb":
+				if b {
+					return "TRUE"
+				}
+				return "FALSE"
+			}
+		}
+	case "DATE", "TIMESTAMP":
+		if t, ok := value.(time.Time); ok {
+			layout, keyword := "2006-01-02 15:04:05.999999", "TIMESTAMP"
+			if columnType == "DATE" {
+				layout, keyword = "2006-01-02", "DATE"
+			}
+			literal := "'" + t.Format(layout) + "'"
+			if dbType == "oracle" || dbType == "dameng" {
+				return keyword + " " + literal
+			}
+			return literal
+		}
+	}
+	return formatSQLValue(dbType, value)
+}
+
+// buildInsertRowsSQL 生成单条多行 INSERT；Oracle/达梦不支持多行 VALUES，使用 INSERT ALL。values 为已格式化的字面量。
+func buildInsertRowsSQL(dbType string, qualifiedTable string, columns []string, values [][]string) string {
+	quotedTable := quoteQualifiedIdentByType(dbType, qualifiedTable)
+	quotedCols := make([]string, len(columns))
+	for i, col := range columns {
+		quotedCols[i] = quoteIdentByType(dbType, col)
+	}
+	colList := strings.Join(quotedCols, ", ")
+
+	var b strings.Builder
+	switch dbType {
+	case "oracle", "dameng":
+		b.WriteString("INSERT ALL")
+		for _, row := range values {
+			b.WriteString(fmt.Sprintf(" INTO %s (%s) VALUES (%s)", quotedTable, colList, strings.Join(row, ", ")))
+		}
+		b.WriteString(" SELECT 1 FROM DUAL")
+	default:
+		b.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quotedTable, colList))
+		for i, row := range values {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("(" + strings.Join(row, ", ") + ")")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type materializeFakeDB struct {
+	db.Database
+	data  []map[string]interface{}
+	cols  []string
+	execs []string
+}
+
+func (f *materializeFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	return f.data, f.cols, nil
+}
+
+func (f *materializeFakeDB) Exec(query string) (int64, error) {
+	f.execs = append(f.execs, query)
+	return 0, nil
+}
+
+func TestSaveQueryResultAsTable(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	fake := &materializeFakeDB{
+		cols: []string{"id", "active", "created_at", "name"},
+		data: []map[string]interface{}{
+			{"id": int64(1), "active": "true", "created_at": "2024-05-01 08:30:00", "name": "O'Brien"},
+			{"id": int64(2), "active": "false", "created_at": nil, "name": nil},
+		},
+	}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	res := a.SaveQueryResultAsTable(config, "shop", "SELECT * FROM users", MaterializeOptions{TableName: "users_copy", Replace: true})
+	if !res.Success {
+		t.Fatal(res.Message)
+	}
+	report := res.Data.(MaterializeReport)
+	if report.Rows != 2 || report.Method != "INSERT" || report.Table != "shop.users_copy" {
+		t.Fatalf("report = %+v", report)
+	}
+	if len(fake.execs) != 3 || fake.execs[0] != "DROP TABLE IF EXISTS `shop`.`users_copy`" {
+		t.Fatalf("execs = %q", fake.execs)
+	}
+	wantCreate := "CREATE TABLE `shop`.`users_copy` (`id` BIGINT, `active` TINYINT(1), `created_at` DATETIME(6), `name` VARCHAR(255))"
+	if fake.execs[1] != wantCreate {
+		t.Fatalf("create = %s", fake.execs[1])
+	}
+	wantInsert := "INSERT INTO `shop`.`users_copy` (`id`, `active`, `created_at`, `name`) VALUES (1, 1, '2024-05-01 08:30:00', 'O''Brien'), (2, 0, NULL, NULL)"
+	if fake.execs[2] != wantInsert {
+		t.Fatalf("insert = %s", fake.execs[2])
+	}
+
+	if res := a.SaveQueryResultAsTable(config, "shop", "SELECT 1", MaterializeOptions{TableName: "tmp", Temporary: true, TabID: "tab-1"}); res.Success || !strings.Contains(res.Message, "固定会话") {
+		t.Fatalf("temporary table without pinned session: %+v", res)
+	}
+}
+
+func TestMaterializeCreateSQLByDialect(t *testing.T) {
+	columns := []QuerySnapshotColumn{{Name: "id", Type: "BIGINT"}, {Name: "note", Type: "VARCHAR"}}
+	data := []map[string]interface{}{{"id": 1, "note": strings.Repeat("x", 300)}}
+
+	createSQL, table, err := buildMaterializeCreateSQL("sqlserver", connection.ConnectionConfig{Type: "sqlserver"}, "dbo", "report", true, columns, data)
+	if err != nil || table != "#report" || createSQL != "CREATE TABLE [#report] ([id] BIGINT, [note] NVARCHAR(4000))" {
+		t.Fatalf("sqlserver temp = %s (%s) %v", createSQL, table, err)
+	}
+	createSQL, _, _ = buildMaterializeCreateSQL("oracle", connection.ConnectionConfig{Type: "oracle"}, "SCOTT", "REPORT", true, columns, data)
+	if createSQL != `CREATE GLOBAL TEMPORARY TABLE "REPORT" ("id" NUMBER(19), "note" VARCHAR2(1000 CHAR)) ON COMMIT PRESERVE ROWS` {
+		t.Fatalf("oracle temp = %s", createSQL)
+	}
+	createSQL, _, _ = buildMaterializeCreateSQL("postgres", connection.ConnectionConfig{Type: "postgres"}, "app", "report", false, columns, data)
+	if createSQL != `CREATE TABLE "public"."report" ("id" BIGINT, "note" TEXT)` {
+		t.Fatalf("postgres = %s", createSQL)
+	}
+	if _, _, err := buildMaterializeCreateSQL("redis", connection.ConnectionConfig{Type: "redis"}, "", "report", false, columns, data); err == nil {
+		t.Fatal("不支持建表的数据源应报错")
+	}
+
+	ts := time.Date(2024, 5, 1, 8, 30, 0, 500000000, time.UTC)
+	if v := formatMaterializeValue("oracle", "TIMESTAMP", ts); v != "TIMESTAMP '2024-05-01 08:30:00.5'" {
+		t.Fatalf("oracle timestamp = %s", v)
+	}
+	if v := formatMaterializeValue("postgres", "BOOLEAN", true); v != "TRUE" {
+		t.Fatalf("postgres bool = %s", v)
+	}
+}
@@ -0,0 +1,548 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	migrationTableName        = "gonavi_schema_migrations"
+	migrationProgressEvent    = "migration:progress"
+	migrationStatementTimeout = 10 * time.Minute
+	migrationDirectionUp      = "up"
+	migrationDirectionDown    = "down"
+)
+
+var (
+	// Flyway: V1.2__desc.sql / U1.2__desc.sql
+	migrationFlywayPattern = regexp.MustCompile(`^([VU])(\d+(?:[._]\d+)*)__(.*)\.sql$`)
+	// golang-migrate: 001_desc.up.sql / 001_desc.down.sql
+	migrationSplitPattern = regexp.MustCompile(`^(\d+)_(.*)\.(up|down)\.sql$`)
+	// goose/dbmate: 001_desc.sql，文件内以注释标记区分 up/down
+	migrationSinglePattern = regexp.MustCompile(`^(\d+)_(.*)\.sql$`)
+	migrationMarkerPattern = regexp.MustCompile(`(?i)^\s*--\s*(\+goose\s+(up|down|statementbegin|statementend)|migrate:(up|down))\b`)
+)
+
+// MigrationStatus 单个迁移版本的状态。
+type MigrationStatus struct {
+	Version   string `json:"version"`
+	Name      string `json:"name"`
+	Applied   bool   `json:"applied"`
+	AppliedAt string `json:"appliedAt,omitempty"`
+	HasDown   bool   `json:"hasDown"`
+	Changed   bool   `json:"changed"` // 已执行后文件内容被修改
+	Missing   bool   `json:"missing"` // 已执行但目录中已无对应文件
+}
+
+// MigrationRunRequest 执行请求。Target 为空时 up 执行全部待执行版本、down 回滚 Steps 个版本（默认 1）；
+// 指定 Target 时 up 执行到该版本（含），down 回滚到该版本（不含，即该版本保持已执行）。
+type MigrationRunRequest struct {
+	Dir       string `json:"dir"`
+	Direction string `json:"direction"`
+	Target    string `json:"target,omitempty"`
+	Steps     int    `json:"steps,omitempty"`
+	DryRun    bool   `json:"dryRun"`
+	JobID     string `json:"jobId,omitempty"`
+}
+
+// MigrationStep 计划中的一步。
+type MigrationStep struct {
+	Version    string   `json:"version"`
+	Name       string   `json:"name"`
+	Direction  string   `json:"direction"`
+	Statements []string `json:"statements"`
+	Status     string   `json:"status"` // planned/applied/failed/not_executed
+	Error      string   `json:"error,omitempty"`
+	DurationMs int64    `json:"durationMs,omitempty"`
+}
+
+// MigrationRunReport 执行报告。DryRun 时仅包含计划与完整脚本。
+type MigrationRunReport struct {
+	Direction string          `json:"direction"`
+	DryRun    bool            `json:"dryRun"`
+	Steps     []MigrationStep `json:"steps"`
+	Script    string          `json:"script"`
+	Warnings  []string        `json:"warnings,omitempty"`
+}
+
+type migrationFile struct {
+	version string
+	name    string
+	up      string
+	down    string
+	hasDown bool
+	path    string
+}
+
+type migrationRecord struct {
+	checksum  string
+	appliedAt string
+	name      string
+}
+
+// SelectMigrationDirectory 选择迁移脚本目录。
+func (a *App) SelectMigrationDirectory() connection.QueryResult {
+	selection, err := runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{Title: "选择迁移脚本目录"})
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if selection == "" {
+		return connection.QueryResult{Success: false, Message: "Cancelled"}
+	}
+	return connection.QueryResult{Success: true, Data: selection}
+}
+
+// GetMigrationStatus 对比迁移目录与目标库的执行记录（gonavi_schema_migrations 表）。
+func (a *App) GetMigrationStatus(config connection.ConnectionConfig, dbName string, dir string) connection.QueryResult {
+	files, warnings, err := loadMigrationFiles(dir)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	applied, _ := readMigrationRecords(dbInst)
+	statuses := buildMigrationStatuses(files, applied)
+	message := ""
+	if len(warnings) > 0 {
+		message = strings.Join(warnings, "；")
+	}
+	return connection.QueryResult{Success: true, Message: message, Data: statuses}
+}
+
+// RunMigrations 执行或预演（DryRun）迁移。任一语句失败即停止，失败版本不记录为已执行。
+func (a *App) RunMigrations(config connection.ConnectionConfig, dbName string, req MigrationRunRequest) connection.QueryResult {
+	if err := ensureWritable(config, "执行迁移"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	direction := strings.ToLower(strings.TrimSpace(req.Direction))
+	if direction == "" {
+		direction = migrationDirectionUp
+	}
+	if direction != migrationDirectionUp && direction != migrationDirectionDown {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("不支持的迁移方向：%s", req.Direction)}
+	}
+	files, warnings, err := loadMigrationFiles(req.Dir)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+	applied, tableExists := readMigrationRecords(dbInst)
+
+	var selected []migrationFile
+	if direction == migrationDirectionUp {
+		selected, err = planMigrationsUp(files, applied, strings.TrimSpace(req.Target), req.Steps)
+	} else {
+		selected, err = planMigrationsDown(files, applied, strings.TrimSpace(req.Target), req.Steps)
+	}
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	report := MigrationRunReport{Direction: direction, DryRun: req.DryRun, Steps: make([]MigrationStep, 0, len(selected)), Warnings: warnings}
+	var script strings.Builder
+	if !tableExists && direction == migrationDirectionUp && len(selected) > 0 {
+		script.WriteString(ensureSQLTerminator(buildMigrationTableDDL(dbType)))
+		script.WriteString("\n\n")
+	}
+	for _, file := range selected {
+		body := file.up
+		if direction == migrationDirectionDown {
+			body = file.down
+		}
+		step := MigrationStep{Version: file.version, Name: file.name, Direction: direction, Statements: splitMigrationBody(body, dbType), Status: "planned"}
+		report.Steps = append(report.Steps, step)
+		script.WriteString(fmt.Sprintf("-- %s %s %s\n", strings.ToUpper(direction), file.version, file.name))
+		for _, stmt := range step.Statements {
+			script.WriteString(ensureSQLTerminator(stmt))
+			script.WriteString("\n")
+		}
+		script.WriteString(ensureSQLTerminator(buildMigrationRecordSQL(dbType, direction, file, time.Now())))
+		script.WriteString("\n\n")
+	}
+	report.Script = script.String()
+	if req.DryRun || len(selected) == 0 {
+		message := fmt.Sprintf("计划执行 %d 个迁移", len(selected))
+		if len(selected) == 0 {
+			message = "没有需要执行的迁移"
+		}
+		return connection.QueryResult{Success: true, Message: message, Data: report}
+	}
+
+	if !tableExists {
+		if _, err := execSQLPlanStatement(dbInst, dbType, buildMigrationTableDDL(dbType), migrationStatementTimeout); err != nil {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("创建迁移记录表失败：%s", normalizeErrorMessage(err)), Data: report}
+		}
+	}
+
+	failed := false
+	for i := range report.Steps {
+		step := &report.Steps[i]
+		if failed {
+			step.Status = "not_executed"
+			continue
+		}
+		started := time.Now()
+		for n, stmt := range step.Statements {
+			if _, err := execSQLPlanStatement(dbInst, dbType, stmt, migrationStatementTimeout); err != nil {
+				logger.Error(err, "迁移执行失败：%s 版本=%s 方向=%s SQL片段=%q", formatConnSummary(runConfig), step.Version, direction, sqlSnippet(stmt))
+				step.Error = fmt.Sprintf("第 %d 条语句失败：%s", n+1, normalizeErrorMessage(err))
+				break
+			}
+		}
+		if step.Error == "" {
+			if _, err := execSQLPlanStatement(dbInst, dbType, buildMigrationRecordSQL(dbType, direction, selected[i], time.Now()), migrationStatementTimeout); err != nil {
+				step.Error = fmt.Sprintf("更新迁移记录失败：%s", normalizeErrorMessage(err))
+			}
+		}
+		step.DurationMs = time.Since(started).Milliseconds()
+		if step.Error != "" {
+			step.Status = "failed"
+			failed = true
+		} else {
+			step.Status = "applied"
+		}
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, migrationProgressEvent, map[string]interface{}{
+				"jobId": req.JobID,
+				"index": i,
+				"total": len(report.Steps),
+				"step":  *step,
+			})
+		}
+	}
+
+	logger.Infof("迁移执行完成：%s 方向=%s 计划=%d 失败=%v", formatConnSummary(runConfig), direction, len(report.Steps), failed)
+	if failed {
+		return connection.QueryResult{Success: false, Message: "迁移执行失败，已停止后续版本", Data: report}
+	}
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已执行 %d 个迁移", len(report.Steps)), Data: report}
+}
+
+// loadMigrationFiles 读取目录中的迁移文件，支持 Flyway（V/U 前缀）、golang-migrate（.up/.down.sql）
+// 与 goose/dbmate（单文件内 -- +goose Up/Down 或 -- migrate:up/down 标记）三种布局。
+func loadMigrationFiles(dir string) ([]migrationFile, []string, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, nil, fmt.Errorf("请选择迁移脚本目录")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取迁移目录失败：%w", err)
+	}
+
+	byVersion := map[string]*migrationFile{}
+	var warnings []string
+	get := func(version, name, path string) (*migrationFile, error) {
+		key := normalizeMigrationVersion(version)
+		if existing, ok := byVersion[key]; ok {
+			if existing.name != name && name != "" && existing.name != "" {
+				return nil, fmt.Errorf("迁移版本 %s 重复：%s 与 %s", version, existing.name, name)
+			}
+			return existing, nil
+		}
+		file := &migrationFile{version: version, name: name, path: path}
+		byVersion[key] = file
+		return file, nil
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".sql") {
+			continue
+		}
+		name := entry.Name()
+		path := filepath.Join(dir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("读取迁移文件 %s 失败：%w", name, err)
+		}
+		text := string(content)
+
+		if m := migrationFlywayPattern.FindStringSubmatch(name); m != nil {
+			file, err := get(m[2], m[3], path)
+			if err != nil {
+				return nil, nil, err
+			}
+			if m[1] == "V" {
+				file.up = text
+			} else {
+				file.down, file.hasDown = text, true
+			}
+			continue
+		}
+		if m := migrationSplitPattern.FindStringSubmatch(name); m != nil {
+			file, err := get(m[1], m[2], path)
+			if err != nil {
+				return nil, nil, err
+			}
+			if m[3] == "up" {
+				file.up = text
+			} else {
+				file.down, file.hasDown = text, true
+			}
+			continue
+		}
+		if m := migrationSinglePattern.FindStringSubmatch(name); m != nil {
+			file, err := get(m[1], m[2], path)
+			if err != nil {
+				return nil, nil, err
+			}
+			file.up, file.down, file.hasDown = splitMigrationSections(text)
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("忽略无法识别版本号的文件 %s", name))
+	}
+
+	files := make([]migrationFile, 0, len(byVersion))
+	for _, file := range byVersion {
+		if strings.TrimSpace(file.up) == "" {
+			warnings = append(warnings, fmt.Sprintf("迁移 %s 缺少 up 脚本，已忽略", file.version))
+			continue
+		}
+		files = append(files, *file)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return compareMigrationVersion(files[i].version, files[j].version) < 0
+	})
+	return files, warnings, nil
+}
+
+// splitMigrationSections 拆分单文件中的 up/down 段；没有标记时整个文件视为 up。
+func splitMigrationSections(text string) (string, string, bool) {
+	var up, down strings.Builder
+	section := ""
+	marked := false
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if m := migrationMarkerPattern.FindStringSubmatch(line); m != nil {
+			marker := strings.ToLower(m[2] + m[3])
+			switch marker {
+			case "up", "down":
+				section = marker
+				marked = true
+				continue
+			}
+		}
+		switch section {
+		case "down":
+			down.WriteString(line)
+		case "up":
+			up.WriteString(line)
+		default:
+			if !marked {
+				up.WriteString(line)
+			}
+		}
+	}
+	return up.String(), down.String(), strings.TrimSpace(down.String()) != ""
+}
+
+// splitMigrationBody 拆分语句；goose StatementBegin/StatementEnd 之间的内容作为一条完整语句。
+func splitMigrationBody(body string, dbType string) []string {
+	var statements []string
+	var chunk, block strings.Builder
+	inBlock := false
+	for _, line := range strings.SplitAfter(body, "\n") {
+		if m := migrationMarkerPattern.FindStringSubmatch(line); m != nil {
+			switch strings.ToLower(m[2]) {
+			case "statementbegin":
+				statements = append(statements, sqlstmt.Split(chunk.String(), dbType)...)
+				chunk.Reset()
+				inBlock = true
+				continue
+			case "statementend":
+				stmt := strings.TrimSpace(block.String())
+				block.Reset()
+				inBlock = false
+				if dbType != "oracle" && dbType != "dameng" {
+					stmt = strings.TrimSpace(strings.TrimSuffix(stmt, ";"))
+				}
+				if stmt != "" {
+					statements = append(statements, stmt)
+				}
+				continue
+			}
+		}
+		if inBlock {
+			block.WriteString(line)
+		} else {
+			chunk.WriteString(line)
+		}
+	}
+	if inBlock {
+		chunk.WriteString(block.String())
+	}
+	return append(statements, sqlstmt.Split(chunk.String(), dbType)...)
+}
+
+func normalizeMigrationVersion(version string) string {
+	parts := splitVersionParts(strings.ReplaceAll(version, "_", "."))
+	texts := make([]string, len(parts))
+	for i, p := range parts {
+		texts[i] = fmt.Sprintf("%d", p)
+	}
+	return strings.Join(texts, ".")
+}
+
+func compareMigrationVersion(a, b string) int {
+	return compareVersion(normalizeMigrationVersion(a), normalizeMigrationVersion(b))
+}
+
+func migrationChecksum(file migrationFile) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(file.up)))
+	return hex.EncodeToString(sum[:])
+}
+
+func buildMigrationStatuses(files []migrationFile, applied map[string]migrationRecord) []MigrationStatus {
+	statuses := make([]MigrationStatus, 0, len(files)+len(applied))
+	seen := map[string]struct{}{}
+	for _, file := range files {
+		key := normalizeMigrationVersion(file.version)
+		seen[key] = struct{}{}
+		status := MigrationStatus{Version: file.version, Name: file.name, HasDown: file.hasDown}
+		if record, ok := applied[key]; ok {
+			status.Applied = true
+			status.AppliedAt = record.appliedAt
+			status.Changed = record.checksum != "" && record.checksum != migrationChecksum(file)
+		}
+		statuses = append(statuses, status)
+	}
+	for key, record := range applied {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		statuses = append(statuses, MigrationStatus{Version: key, Name: record.name, Applied: true, AppliedAt: record.appliedAt, Missing: true})
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return compareMigrationVersion(statuses[i].Version, statuses[j].Version) < 0
+	})
+	return statuses
+}
+
+func planMigrationsUp(files []migrationFile, applied map[string]migrationRecord, target string, steps int) ([]migrationFile, error) {
+	if target != "" && !migrationVersionExists(files, target) {
+		return nil, fmt.Errorf("目标版本 %s 不存在", target)
+	}
+	var selected []migrationFile
+	for _, file := range files {
+		if target != "" && compareMigrationVersion(file.version, target) > 0 {
+			break
+		}
+		if _, ok := applied[normalizeMigrationVersion(file.version)]; ok {
+			continue
+		}
+		selected = append(selected, file)
+		if steps > 0 && len(selected) >= steps {
+			break
+		}
+	}
+	return selected, nil
+}
+
+func planMigrationsDown(files []migrationFile, applied map[string]migrationRecord, target string, steps int) ([]migrationFile, error) {
+	if target == "" && steps <= 0 {
+		steps = 1
+	}
+	var selected []migrationFile
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		if target != "" && compareMigrationVersion(file.version, target) <= 0 {
+			break
+		}
+		if _, ok := applied[normalizeMigrationVersion(file.version)]; !ok {
+			continue
+		}
+		if !file.hasDown {
+			return nil, fmt.Errorf("迁移 %s 没有 down 脚本，无法回滚", file.version)
+		}
+		selected = append(selected, file)
+		if steps > 0 && len(selected) >= steps {
+			break
+		}
+	}
+	return selected, nil
+}
+
+func migrationVersionExists(files []migrationFile, version string) bool {
+	for _, file := range files {
+		if compareMigrationVersion(file.version, version) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// readMigrationRecords 读取执行记录；记录表不存在时返回空集合与 false。
+func readMigrationRecords(dbInst db.Database) (map[string]migrationRecord, bool) {
+	records := map[string]migrationRecord{}
+	data, _, err := dbInst.Query(fmt.Sprintf("SELECT version, name, checksum, applied_at FROM %s", migrationTableName))
+	if err != nil {
+		return records, false
+	}
+	get := func(row map[string]interface{}, key string) string {
+		for k, v := range row {
+			if strings.EqualFold(k, key) && v != nil {
+				return strings.TrimSpace(fmt.Sprintf("%v", v))
+			}
+		}
+		return ""
+	}
+	for _, row := range data {
+		version := get(row, "version")
+		if version == "" {
+			continue
+		}
+		records[normalizeMigrationVersion(version)] = migrationRecord{
+			name:      get(row, "name"),
+			checksum:  get(row, "checksum"),
+			appliedAt: get(row, "applied_at"),
+		}
+	}
+	return records, true
+}
+
+func buildMigrationTableDDL(dbType string) string {
+	varchar := "VARCHAR"
+	switch dbType {
+	case "oracle", "dameng":
+		varchar = "VARCHAR2"
+	case "sqlserver":
+		varchar = "NVARCHAR"
+	}
+	return fmt.Sprintf("CREATE TABLE %s (version %s(64) NOT NULL PRIMARY KEY, name %s(255), checksum %s(64), applied_at %s(32))",
+		migrationTableName, varchar, varchar, varchar, varchar)
+}
+
+func buildMigrationRecordSQL(dbType string, direction string, file migrationFile, now time.Time) string {
+	if direction == migrationDirectionDown {
+		return fmt.Sprintf("DELETE FROM %s WHERE version = %s", migrationTableName, dialect.QuoteLiteral(dbType, normalizeMigrationVersion(file.version)))
+	}
+	return fmt.Sprintf("INSERT INTO %s (version, name, checksum, applied_at) VALUES (%s, %s, '%s', '%s')",
+		migrationTableName,
+		dialect.QuoteLiteral(dbType, normalizeMigrationVersion(file.version)),
+		dialect.QuoteLiteral(dbType, file.name),
+		migrationChecksum(file),
+		now.Format("2006-01-02 15:04:05"))
+}
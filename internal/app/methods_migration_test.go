@@ -0,0 +1,62 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadMigrationFiles_MixedLayouts(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"V1__init.sql":     "CREATE TABLE a (id INT);",
+		"U1__init.sql":     "DROP TABLE a;",
+		"2_users.up.sql":   "CREATE TABLE users (id INT);",
+		"2_users.down.sql": "DROP TABLE users;",
+		"10_func.sql":      "-- +goose Up\n-- +goose StatementBegin\nCREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql;\n-- +goose StatementEnd\nSELECT 1;\n-- +goose Down\nDROP FUNCTION f;\n",
+		"notes.sql":        "SELECT 1;",
+		"V1.1__extra.sql":  "ALTER TABLE a ADD b INT;",
+		"README.md":        "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loaded, warnings, err := loadMigrationFiles(dir)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	var versions []string
+	for _, f := range loaded {
+		versions = append(versions, f.version)
+	}
+	if want := []string{"1", "1.1", "2", "10"}; !reflect.DeepEqual(versions, want) {
+		t.Fatalf("versions = %v, want %v", versions, want)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning for notes.sql, got %v", warnings)
+	}
+
+	goose := loaded[3]
+	stmts := splitMigrationBody(goose.up, "postgres")
+	wantStmts := []string{"CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql", "SELECT 1"}
+	if !reflect.DeepEqual(stmts, wantStmts) || !goose.hasDown {
+		t.Fatalf("goose statements = %#v hasDown=%v", stmts, goose.hasDown)
+	}
+
+	applied := map[string]migrationRecord{"1": {}, "1.1": {}}
+	up, err := planMigrationsUp(loaded, applied, "", 0)
+	if err != nil || len(up) != 2 || up[0].version != "2" {
+		t.Fatalf("plan up = %#v err=%v", up, err)
+	}
+	if _, err := planMigrationsDown(loaded, applied, "", 0); err == nil {
+		t.Fatalf("expected error rolling back version 1.1 without down script")
+	}
+	down, err := planMigrationsDown(loaded, map[string]migrationRecord{"1": {}, "2": {}}, "", 0)
+	if err != nil || len(down) != 1 || down[0].version != "2" {
+		t.Fatalf("plan down = %#v err=%v", down, err)
+	}
+}
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+// ObjectDependent 依赖目标对象的对象。Depth 为 1 表示直接引用目标，更大的值表示经由 Via 间接依赖。
+type ObjectDependent struct {
+	Key     string   `json:"key"`
+	Schema  string   `json:"schema,omitempty"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Kinds   []string `json:"kinds"` // reads/trigger_on/calls/foreign_key
+	Depth   int      `json:"depth"`
+	Via     string   `json:"via,omitempty"`
+	Columns []string `json:"columns,omitempty"` // 外键引用方的列
+}
+
+// ObjectDependencyReport 删除或重命名某个表/列之前的影响范围。
+type ObjectDependencyReport struct {
+	Object     string            `json:"object"`
+	Type       string            `json:"type"`
+	Column     string            `json:"column,omitempty"`
+	Dependents []ObjectDependent `json:"dependents"`
+	Warnings   []string          `json:"warnings,omitempty"`
+}
+
+// dependencyColumnFilter 列级分析时用于筛选直接依赖；字段为 nil 表示无法精确判断，按定义文本或保守地全部保留。
+type dependencyColumnFilter struct {
+	fkColumns map[string][]string // 引用了该列的外键所在表（小写 Key）-> 引用方的列
+	views     map[string]bool     // 目录视图确认引用了该列的视图（PostgreSQL pg_depend）
+}
+
+// GetObjectDependencies 报告哪些视图、存储过程/函数、触发器与外键引用了指定的表（columnName 不为空时为该表的列），
+// 包括经由视图、例程的间接依赖，供删除或重命名前评估影响。依赖来自 information_schema、pg_depend 等目录视图，
+// 目录不提供列级信息时按对象定义文本匹配列名。
+func (a *App) GetObjectDependencies(config connection.ConnectionConfig, dbName string, objectName string, columnName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+	c := newDependencyCollector()
+	if !collectDependencies(c, dbType, dbInst, dbName) {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源（%s）暂不支持依赖分析", runConfig.Type)}
+	}
+	schema, name := normalizeSchemaAndTableByType(dbType, dbName, objectName)
+	target := c.resolve(schema, name)
+	if target == "" {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("未找到对象 %s", objectName)}
+	}
+	columnName = strings.TrimSpace(columnName)
+	var filter *dependencyColumnFilter
+	if columnName != "" {
+		filter = &dependencyColumnFilter{}
+		obj := c.objects[target]
+		if query := erCatalogSQL(dbType, dbName).foreignKeys; query != "" {
+			if rows, fkErr := erRows(dbInst, query); fkErr != nil {
+				c.warnings = append(c.warnings, "读取外键列失败："+normalizeErrorMessage(fkErr))
+			} else {
+				filter.fkColumns = dependencyFKColumns(rows, obj, columnName)
+			}
+		}
+		if query := postgresColumnViewsSQL(dbType, obj.Schema, obj.Name, columnName); query != "" {
+			if rows, viewErr := erRows(dbInst, query); viewErr != nil {
+				c.warnings = append(c.warnings, "读取视图列依赖失败："+normalizeErrorMessage(viewErr))
+			} else {
+				filter.views = make(map[string]bool, len(rows))
+				for _, row := range rows {
+					filter.views[dependencyKey(row[0], row[1])] = true
+				}
+			}
+		}
+	}
+	report := buildObjectDependencies(c, target, columnName, filter)
+	logger.Infof("对象依赖分析完成：%s 对象=%s 列=%s 依赖数=%d", formatConnSummary(runConfig), target, columnName, len(report.Dependents))
+	return connection.QueryResult{Success: true, Data: report}
+}
+
+// dependencyFKColumns 从外键目录行（列顺序见 erCatalogQueries）中找出引用目标列的外键，按引用方表汇总其列。
+func dependencyFKColumns(rows [][]string, target *DependencyObject, column string) map[string][]string {
+	result := make(map[string][]string)
+	for _, row := range rows {
+		if !strings.EqualFold(row[5], target.Name) || (row[4] != "" && target.Schema != "" && !strings.EqualFold(row[4], target.Schema)) {
+			continue
+		}
+		// SQLite 省略引用列时引用的是主键，无法确定具体列，保守地计入
+		if row[6] != "" && !strings.EqualFold(row[6], column) {
+			continue
+		}
+		// 目录返回的 schema 大小写可能与用户传入的库名不同（Oracle owner）
+		from := strings.ToLower(dependencyKey(row[0], row[1]))
+		if !containsFold(result[from], row[3]) {
+			result[from] = append(result[from], row[3])
+		}
+	}
+	return result
+}
+
+// postgresColumnViewsSQL 经由视图重写规则在 pg_depend 中记录的列依赖，精确找出引用了指定列的视图。
+func postgresColumnViewsSQL(dbType string, schema string, table string, column string) string {
+	switch dbType {
+	case "postgres", "kingbase", "highgo", "vastbase":
+	default:
+		return ""
+	}
+	return fmt.Sprintf("SELECT DISTINCT vn.nspname AS view_schema, v.relname AS view_name FROM pg_depend d "+
+		"JOIN pg_rewrite r ON r.oid = d.objid JOIN pg_class v ON v.oid = r.ev_class JOIN pg_namespace vn ON vn.oid = v.relnamespace "+
+		"JOIN pg_class t ON t.oid = d.refobjid JOIN pg_namespace tn ON tn.oid = t.relnamespace "+
+		"JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid "+
+		"WHERE d.classid = 'pg_rewrite'::regclass AND d.refclassid = 'pg_class'::regclass AND v.oid <> t.oid "+
+		"AND tn.nspname = %s AND t.relname = %s AND a.attname = %s",
+		dialect.QuoteLiteral(dbType, schema), dialect.QuoteLiteral(dbType, table), dialect.QuoteLiteral(dbType, column))
+}
+
+// buildObjectDependencies 沿依赖图反向查找依赖目标的对象。column 不为空时只有第一层按列筛选，
+// 更深的层级依赖的是整个中间对象。外键所在的表不再向外展开：删除被引用对象只影响外键约束本身。
+func buildObjectDependencies(c *dependencyCollector, target string, column string, filter *dependencyColumnFilter) ObjectDependencyReport {
+	obj := c.objects[target]
+	report := ObjectDependencyReport{Object: target, Type: obj.Type, Column: column, Dependents: []ObjectDependent{}, Warnings: c.warnings}
+	dependents := make(map[string][]DependencyEdge)
+	for edge := range c.edges {
+		dependents[edge.To] = append(dependents[edge.To], edge)
+	}
+
+	found := make(map[string]*ObjectDependent)
+	var order []string
+	queue := []string{target}
+	for depth := 1; len(queue) > 0; depth++ {
+		var next []string
+		for _, key := range queue {
+			for _, edge := range dependents[key] {
+				if edge.From == target {
+					continue
+				}
+				var columns []string
+				if depth == 1 && column != "" {
+					var ok bool
+					if columns, ok = dependencyEdgeUsesColumn(c, edge, column, filter); !ok {
+						continue
+					}
+				}
+				dep := found[edge.From]
+				if dep == nil {
+					source := c.objects[edge.From]
+					dep = &ObjectDependent{Key: source.Key, Schema: source.Schema, Name: source.Name, Type: source.Type, Depth: depth}
+					if depth > 1 {
+						dep.Via = key
+					}
+					found[edge.From] = dep
+					order = append(order, edge.From)
+					if edge.Kind != dependencyKindForeignKey {
+						next = append(next, edge.From)
+					}
+				} else if dep.Depth != depth || (dep.Via != "" && dep.Via != key) {
+					continue
+				}
+				if !containsFold(dep.Kinds, edge.Kind) {
+					dep.Kinds = append(dep.Kinds, edge.Kind)
+				}
+				for _, col := range columns {
+					if !containsFold(dep.Columns, col) {
+						dep.Columns = append(dep.Columns, col)
+					}
+				}
+			}
+		}
+		queue = next
+	}
+	for _, key := range order {
+		dep := found[key]
+		sort.Strings(dep.Kinds)
+		report.Dependents = append(report.Dependents, *dep)
+	}
+	sort.SliceStable(report.Dependents, func(i, j int) bool {
+		if report.Dependents[i].Depth != report.Dependents[j].Depth {
+			return report.Dependents[i].Depth < report.Dependents[j].Depth
+		}
+		return report.Dependents[i].Key < report.Dependents[j].Key
+	})
+	return report
+}
+
+// dependencyEdgeUsesColumn 判断直接依赖是否涉及指定列；外键返回引用方的列。
+func dependencyEdgeUsesColumn(c *dependencyCollector, edge DependencyEdge, column string, filter *dependencyColumnFilter) ([]string, bool) {
+	if edge.Kind == dependencyKindForeignKey {
+		if filter == nil || filter.fkColumns == nil {
+			return nil, true
+		}
+		columns, ok := filter.fkColumns[strings.ToLower(edge.From)]
+		return columns, ok
+	}
+	if filter != nil && filter.views != nil && edge.Kind == dependencyKindReads && c.objects[edge.From].Type == "view" {
+		return nil, filter.views[edge.From]
+	}
+	body := c.bodies[edge.From]
+	if strings.TrimSpace(body) == "" {
+		// 没有定义文本（如 PostgreSQL 触发器只记录所调用的函数）时无法排除
+		return nil, true
+	}
+	for _, tok := range tokenizeSQL(body) {
+		if (tok.kind == sqlTokenIdent || tok.kind == sqlTokenQuotedIdent) && strings.EqualFold(tok.text, column) {
+			return nil, true
+		}
+	}
+	return nil, false
+}
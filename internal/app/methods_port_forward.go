@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/ssh"
+)
+
+// PortForwardRequest 独立端口转发：通过 SSH 配置把本地端口转发到跳板机可达的任意 host:port。
+type PortForwardRequest struct {
+	SSH        connection.SSHConfig `json:"ssh"`
+	RemoteHost string               `json:"remoteHost"`
+	RemotePort int                  `json:"remotePort"`
+	LocalHost  string               `json:"localHost,omitempty"` // 默认 127.0.0.1
+	LocalPort  int                  `json:"localPort,omitempty"` // 0 表示随机端口
+	Label      string               `json:"label,omitempty"`
+}
+
+// OpenPortForward 打开独立端口转发，生命周期与数据库连接无关，可在隧道面板中查看、重连与关闭。
+func (a *App) OpenPortForward(req PortForwardRequest) connection.QueryResult {
+	if strings.TrimSpace(req.SSH.Host) == "" {
+		return connection.QueryResult{Success: false, Message: "请填写 SSH 主机"}
+	}
+	if req.SSH.Port <= 0 {
+		req.SSH.Port = 22
+	}
+	remoteHost := strings.TrimSpace(req.RemoteHost)
+	if remoteHost == "" {
+		remoteHost = "127.0.0.1"
+	}
+	if req.RemotePort <= 0 || req.RemotePort > 65535 {
+		return connection.QueryResult{Success: false, Message: "远程端口不合法"}
+	}
+	if req.LocalPort < 0 || req.LocalPort > 65535 {
+		return connection.QueryResult{Success: false, Message: "本地端口不合法"}
+	}
+	localHost := strings.TrimSpace(req.LocalHost)
+	if localHost == "" {
+		localHost = "127.0.0.1"
+	}
+	if ip := net.ParseIP(localHost); ip == nil || !ip.IsLoopback() {
+		logger.Warnf("端口转发监听非回环地址：%s，局域网内其他主机也可访问该端口", localHost)
+	}
+	bindAddr := net.JoinHostPort(localHost, strconv.Itoa(req.LocalPort))
+
+	label := strings.TrimSpace(req.Label)
+	if label == "" {
+		label = fmt.Sprintf("%s:%d", remoteHost, req.RemotePort)
+	}
+	status, err := ssh.OpenPortForward(req.SSH, remoteHost, req.RemotePort, bindAddr, label)
+	if err != nil {
+		logger.Error(err, "打开端口转发失败：跳板机=%s:%d 目标=%s:%d 本地=%s", req.SSH.Host, req.SSH.Port, remoteHost, req.RemotePort, bindAddr)
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	logger.Infof("已打开端口转发：本地 %s -> %s（跳板机 %s:%d）", status.LocalAddr, status.RemoteAddr, req.SSH.Host, req.SSH.Port)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已转发到本地 %s", status.LocalAddr), Data: status}
+}
+
+// ListPortForwards 列出手动打开的端口转发。
+func (a *App) ListPortForwards() connection.QueryResult {
+	forwards := make([]ssh.TunnelStatus, 0)
+	for _, status := range ssh.ListTunnels() {
+		if status.Manual {
+			forwards = append(forwards, status)
+		}
+	}
+	return connection.QueryResult{Success: true, Data: forwards}
+}
+
+// ClosePortForward 关闭手动打开的端口转发。数据库连接创建的隧道请使用 CloseSSHTunnel。
+func (a *App) ClosePortForward(id string) connection.QueryResult {
+	id = strings.TrimSpace(id)
+	found := false
+	for _, status := range ssh.ListTunnels() {
+		if status.ID == id && status.Manual {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("端口转发不存在：%s", id)}
+	}
+	if err := ssh.CloseTunnel(id); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: "端口转发已关闭"}
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestOpenPortForwardValidation(t *testing.T) {
+	a := NewApp()
+	cases := []PortForwardRequest{
+		{RemotePort: 6379},
+		{SSH: connection.SSHConfig{Host: "bastion"}, RemotePort: 0},
+		{SSH: connection.SSHConfig{Host: "bastion"}, RemotePort: 70000},
+		{SSH: connection.SSHConfig{Host: "bastion"}, RemotePort: 6379, LocalPort: -1},
+	}
+	for _, req := range cases {
+		if res := a.OpenPortForward(req); res.Success {
+			t.Errorf("expected validation error for %+v", req)
+		}
+	}
+
+	if res := a.ClosePortForward("forward:127.0.0.1:1"); res.Success {
+		t.Fatal("closing an unknown forward should fail")
+	}
+	if res := a.ListPortForwards(); !res.Success {
+		t.Fatal(res.Message)
+	}
+}
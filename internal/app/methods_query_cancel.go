@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/utils"
+)
+
+var errQueryCanceled = errors.New("查询已取消")
+
+// runningQuery 一条由前端指定 ID 的运行中语句。
+type runningQuery struct {
+	id        string
+	config    connection.ConnectionConfig
+	cancel    context.CancelFunc
+	startedAt time.Time
+
+	// 以下字段在 queryMu 保护下更新
+	killer    db.Database // 用于下发服务端取消的连接池
+	backendID int64       // MySQL CONNECTION_ID() / PostgreSQL pg_backend_pid()
+	canceled  bool
+}
+
+// serverCancelKind 返回支持服务端取消的方言：mysql（KILL QUERY）或 postgres（pg_cancel_backend），其余为空。
+func serverCancelKind(dbType string) string {
+	switch strings.ToLower(strings.TrimSpace(dbType)) {
+	case "mysql", "mariadb", "diros":
+		return "mysql"
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return "postgres"
+	}
+	return ""
+}
+
+// registerQuery 为语句派生可取消的 context 并登记；同一 ID 仍在运行时拒绝重复登记。
+func (a *App) registerQuery(parent context.Context, queryID string, config connection.ConnectionConfig) (context.Context, *runningQuery, error) {
+	ctx, cancel := context.WithCancel(parent)
+	rq := &runningQuery{id: queryID, config: config, cancel: cancel, startedAt: time.Now()}
+	a.queryMu.Lock()
+	defer a.queryMu.Unlock()
+	if a.runningQueries == nil {
+		a.runningQueries = make(map[string]*runningQuery)
+	}
+	if _, exists := a.runningQueries[queryID]; exists {
+		cancel()
+		return nil, nil, fmt.Errorf("查询 ID 已在运行：%s", queryID)
+	}
+	a.runningQueries[queryID] = rq
+	return ctx, rq, nil
+}
+
+func (a *App) unregisterQuery(rq *runningQuery) {
+	if rq == nil {
+		return
+	}
+	rq.cancel()
+	a.queryMu.Lock()
+	if a.runningQueries[rq.id] == rq {
+		delete(a.runningQueries, rq.id)
+	}
+	a.queryMu.Unlock()
+}
+
+func (a *App) queryCanceled(rq *runningQuery) bool {
+	if rq == nil {
+		return false
+	}
+	a.queryMu.Lock()
+	defer a.queryMu.Unlock()
+	return rq.canceled
+}
+
+// trackedSession 对支持服务端取消的驱动检出专用连接并记录其会话 ID；不支持时返回 nil。
+func (a *App) trackedSession(ctx context.Context, rq *runningQuery, inst db.Database) *db.Session {
+	kind := serverCancelKind(rq.config.Type)
+	opener, ok := inst.(db.SessionOpener)
+	if kind == "" || !ok {
+		return nil
+	}
+	session, err := opener.OpenSession(ctx)
+	if err != nil {
+		return nil
+	}
+	idQuery := "SELECT CONNECTION_ID() AS id"
+	if kind == "postgres" {
+		idQuery = "SELECT pg_backend_pid() AS id"
+	}
+	data, _, err := session.QueryContext(ctx, idQuery)
+	if err != nil || len(data) == 0 {
+		_ = session.Release()
+		return nil
+	}
+	backendID, err := strconv.ParseInt(fmt.Sprintf("%v", data[0]["id"]), 10, 64)
+	if err != nil {
+		_ = session.Release()
+		return nil
+	}
+	a.queryMu.Lock()
+	rq.killer = inst
+	rq.backendID = backendID
+	a.queryMu.Unlock()
+	return session
+}
+
+func (a *App) finishTrackedSession(rq *runningQuery, session *db.Session, err error) {
+	a.queryMu.Lock()
+	rq.killer = nil
+	rq.backendID = 0
+	a.queryMu.Unlock()
+	if err != nil {
+		// 出错或被取消的连接状态不确定，直接丢弃
+		_ = session.Close()
+		return
+	}
+	_ = session.Release()
+}
+
+// queryTracked 执行读语句；rq 不为空时可被 CancelQuery 中止。
+func (a *App) queryTracked(ctx context.Context, rq *runningQuery, inst db.Database, query string) ([]map[string]interface{}, []string, error) {
+	if rq != nil {
+		if session := a.trackedSession(ctx, rq, inst); session != nil {
+			data, columns, err := session.QueryContext(ctx, query)
+			a.finishTrackedSession(rq, session, err)
+			return data, columns, a.canceledError(rq, err)
+		}
+	}
+	var data []map[string]interface{}
+	var columns []string
+	var err error
+	if q, ok := inst.(interface {
+		QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+	}); ok {
+		data, columns, err = q.QueryContext(ctx, query)
+	} else {
+		data, columns, err = inst.Query(query)
+	}
+	return data, columns, a.canceledError(rq, err)
+}
+
+// execTracked 执行写语句；rq 不为空时可被 CancelQuery 中止。
+func (a *App) execTracked(ctx context.Context, rq *runningQuery, inst db.Database, query string) (int64, error) {
+	if rq != nil {
+		if session := a.trackedSession(ctx, rq, inst); session != nil {
+			affected, err := session.ExecContext(ctx, query)
+			a.finishTrackedSession(rq, session, err)
+			return affected, a.canceledError(rq, err)
+		}
+	}
+	var affected int64
+	var err error
+	if e, ok := inst.(interface {
+		ExecContext(context.Context, string) (int64, error)
+	}); ok {
+		affected, err = e.ExecContext(ctx, query)
+	} else {
+		affected, err = inst.Exec(query)
+	}
+	return affected, a.canceledError(rq, err)
+}
+
+// canceledError 被用户取消的语句统一返回 errQueryCanceled，避免被当作连接中断触发自动重连重试。
+func (a *App) canceledError(rq *runningQuery, err error) error {
+	if err != nil && a.queryCanceled(rq) {
+		return errQueryCanceled
+	}
+	return err
+}
+
+// CancelQuery 中止以 queryID 运行中的语句：取消本地 context，MySQL 系另发 KILL QUERY，PostgreSQL 系另发 pg_cancel_backend。
+func (a *App) CancelQuery(queryID string) connection.QueryResult {
+	queryID = strings.TrimSpace(queryID)
+	a.queryMu.Lock()
+	rq := a.runningQueries[queryID]
+	if rq == nil {
+		a.queryMu.Unlock()
+		return connection.QueryResult{Success: false, Message: "查询不存在或已结束"}
+	}
+	rq.canceled = true
+	killer, backendID := rq.killer, rq.backendID
+	a.queryMu.Unlock()
+
+	// 先通知服务端停止执行：仅取消客户端 context 时服务端语句可能继续运行
+	if killer != nil && backendID > 0 {
+		if err := killServerQuery(killer, rq.config.Type, backendID); err != nil {
+			logger.Warnf("服务端取消查询失败：%s 会话=%d 原因=%v", formatConnSummary(rq.config), backendID, err)
+		}
+	}
+	rq.cancel()
+	logger.Infof("已取消查询：%s ID=%s 耗时=%s", formatConnSummary(rq.config), queryID, time.Since(rq.startedAt).Round(time.Millisecond))
+	return connection.QueryResult{Success: true, Message: "已取消查询"}
+}
+
+func killServerQuery(inst db.Database, dbType string, backendID int64) error {
+	ctx, cancel := utils.ContextWithTimeout(5 * time.Second)
+	defer cancel()
+	if serverCancelKind(dbType) == "postgres" {
+		query := fmt.Sprintf("SELECT pg_cancel_backend(%d)", backendID)
+		if q, ok := inst.(interface {
+			QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+		}); ok {
+			_, _, err := q.QueryContext(ctx, query)
+			return err
+		}
+		_, _, err := inst.Query(query)
+		return err
+	}
+	query := fmt.Sprintf("KILL QUERY %d", backendID)
+	if e, ok := inst.(interface {
+		ExecContext(context.Context, string) (int64, error)
+	}); ok {
+		_, err := e.ExecContext(ctx, query)
+		return err
+	}
+	_, err := inst.Exec(query)
+	return err
+}
+
+// DBQueryWithID 与 DBQuery 相同，但以前端生成的 queryID 登记，执行期间可调用 CancelQuery 中止。
+func (a *App) DBQueryWithID(config connection.ConnectionConfig, dbName string, query string, queryID string) connection.QueryResult {
+	return a.dbQuery(config, dbName, query, dbQueryOptions{route: queryRouteAuto, queryID: strings.TrimSpace(queryID)})
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+// blockingQueryDB 查询阻塞直到 context 结束，用于模拟长时间运行的语句。
+type blockingQueryDB struct {
+	db.Database
+	started chan struct{}
+}
+
+func (b *blockingQueryDB) QueryContext(ctx context.Context, query string) ([]map[string]interface{}, []string, error) {
+	close(b.started)
+	<-ctx.Done()
+	return nil, nil, ctx.Err()
+}
+
+func TestCancelQueryAbortsRunningStatement(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "sqlite", Host: "local"}
+	ctx, rq, err := a.registerQuery(context.Background(), "q1", config)
+	if err != nil {
+		t.Fatalf("registerQuery: %v", err)
+	}
+	defer a.unregisterQuery(rq)
+	if _, _, err := a.registerQuery(context.Background(), "q1", config); err == nil {
+		t.Fatal("duplicate query ID should be rejected")
+	}
+
+	inst := &blockingQueryDB{started: make(chan struct{})}
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := a.queryTracked(ctx, rq, inst, "SELECT 1")
+		done <- err
+	}()
+	<-inst.started
+
+	if res := a.CancelQuery("q1"); !res.Success {
+		t.Fatalf("CancelQuery failed: %s", res.Message)
+	}
+	select {
+	case err := <-done:
+		if !errors.Is(err, errQueryCanceled) {
+			t.Fatalf("err = %v, want errQueryCanceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("query was not canceled")
+	}
+
+	a.unregisterQuery(rq)
+	if res := a.CancelQuery("q1"); res.Success {
+		t.Fatal("finished query should no longer be cancelable")
+	}
+}
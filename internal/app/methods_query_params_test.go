@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type paramsFakeDB struct {
+	db.Database
+	method string
+	query  string
+	args   []interface{}
+}
+
+func (f *paramsFakeDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	f.method, f.query, f.args = "query", query, args
+	return []map[string]interface{}{{"id": args[0]}}, []string{"id"}, nil
+}
+
+func (f *paramsFakeDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	f.method, f.query, f.args = "exec", query, args
+	return 1, nil
+}
+
+func TestDBQueryWithParams(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	fake := &paramsFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	// 前端传入的 JSON 数字为 float64，整数应还原为 int64 绑定
+	res := a.DBQueryWithParams(config, "shop", "SELECT id FROM users WHERE id = ? AND name = ?", []interface{}{float64(7), "a'b"})
+	if !res.Success || fake.method != "query" || !strings.Contains(fake.query, "WHERE id = ? AND name = ?") {
+		t.Fatalf("query result = %+v, fake = %+v", res, fake)
+	}
+	if id, ok := fake.args[0].(int64); !ok || id != 7 || fake.args[1] != "a'b" {
+		t.Fatalf("args = %#v", fake.args)
+	}
+
+	res = a.DBQueryWithParams(config, "shop", "UPDATE users SET tags = ? WHERE id = ?", []interface{}{[]interface{}{"x"}, 1.5})
+	if !res.Success || fake.method != "exec" || res.Data.(map[string]int64)["affectedRows"] != 1 {
+		t.Fatalf("exec result = %+v, fake = %+v", res, fake)
+	}
+	if fake.args[0] != `["x"]` || fake.args[1] != 1.5 {
+		t.Fatalf("args = %#v", fake.args)
+	}
+}
+
+func TestDBQueryWithParamsUnsupported(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: &scriptFakeDB{}, lastPing: time.Now()}
+
+	res := a.DBQueryWithParams(config, "shop", "DELETE FROM users WHERE id = ?", []interface{}{float64(1)})
+	if res.Success || res.Message != db.ErrParamsUnsupported.Error() {
+		t.Fatalf("result = %+v", res)
+	}
+}
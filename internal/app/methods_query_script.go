@@ -0,0 +1,131 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/sqlstmt"
+)
+
+// ScriptOptions 多语句脚本的执行选项。
+type ScriptOptions struct {
+	// TabID 非空且标签页已固定会话时在该会话上执行，SET、USE、临时表等会话状态在语句之间保持；
+	// 否则每条语句从共享连接池取连接，依赖会话状态的脚本需先固定会话。
+	TabID string `json:"tabId,omitempty"`
+	// QueryID 非空时每条语句以该 ID 登记，CancelQuery 会中止当前语句并跳过其余语句（仅共享连接池执行时生效）。
+	QueryID     string `json:"queryId,omitempty"`
+	StopOnError bool   `json:"stopOnError,omitempty"` // 某条语句失败后不再执行后续语句
+}
+
+// ScriptStatementResult 脚本中单条语句的执行结果。
+type ScriptStatementResult struct {
+	Index        int                      `json:"index"` // 从 0 开始
+	Line         int                      `json:"line"`  // 在脚本中的起始行号
+	SQL          string                   `json:"sql"`
+	Success      bool                     `json:"success"`
+	Executed     bool                     `json:"executed"` // 因 StopOnError 或取消而跳过时为 false
+	Data         []map[string]interface{} `json:"data,omitempty"`
+	Fields       []string                 `json:"fields,omitempty"`
+	HasResultSet bool                     `json:"hasResultSet"`
+	AffectedRows int64                    `json:"affectedRows"`
+	LimitReached bool                     `json:"limitReached,omitempty"`
+	DurationMs   int64                    `json:"durationMs"`
+	Message      string                   `json:"message,omitempty"` // 失败原因或提示
+	// Error 结构化的失败原因，其中的行列位置相对于 SQL，加上 Line-1 即为脚本中的行号。
+	Error *connection.QueryError `json:"error,omitempty"`
+	// SchemaBackup 结构修改语句执行前备份的对象定义与回退脚本。
+	SchemaBackup *connection.SchemaChangeBackup `json:"schemaBackup,omitempty"`
+	// ActiveDatabase USE / \c 切换后的当前数据库，后续语句在该库上执行。
+	ActiveDatabase string `json:"activeDatabase,omitempty"`
+}
+
+// ScriptResult 脚本执行汇总。
+type ScriptResult struct {
+	Statements []ScriptStatementResult `json:"statements"`
+	Succeeded  int                     `json:"succeeded"`
+	Failed     int                     `json:"failed"`
+	Skipped    int                     `json:"skipped"`
+	Canceled   bool                    `json:"canceled,omitempty"`
+	DurationMs int64                   `json:"durationMs"`
+	// ActiveDatabase 脚本执行结束时的当前数据库，脚本中没有切换数据库时为空。
+	ActiveDatabase string `json:"activeDatabase,omitempty"`
+}
+
+// DBQueryScript 将脚本按语句拆分（识别字符串、注释、MySQL DELIMITER、SQL Server GO 与 PL/SQL 块）后逐条执行，
+// 每条语句单独返回结果集或影响行数、耗时与错误，而不是把整段脚本交给驱动执行。
+func (a *App) DBQueryScript(config connection.ConnectionConfig, dbName string, script string, options ScriptOptions) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	statements := sqlstmt.SplitWithLines(script, resolveDDLDBType(runConfig))
+	if len(statements) == 0 {
+		return connection.QueryResult{Success: false, Message: "脚本中没有可执行的语句"}
+	}
+	tabID := strings.TrimSpace(options.TabID)
+	queryID := strings.TrimSpace(options.QueryID)
+
+	started := time.Now()
+	result := ScriptResult{Statements: make([]ScriptStatementResult, 0, len(statements))}
+	stopped := false
+	for i, stmt := range statements {
+		item := ScriptStatementResult{Index: i, Line: stmt.Line, SQL: stmt.SQL}
+		if stopped {
+			result.Skipped++
+			result.Statements = append(result.Statements, item)
+			continue
+		}
+
+		stmtStarted := time.Now()
+		var res connection.QueryResult
+		if tabID != "" {
+			res = a.DBQueryInTab(config, dbName, tabID, stmt.SQL)
+		} else {
+			res = a.dbQuery(config, dbName, stmt.SQL, dbQueryOptions{route: queryRouteAuto, queryID: queryID})
+		}
+		item.DurationMs = time.Since(stmtStarted).Milliseconds()
+		item.Executed = true
+		applyScriptStatementResult(&item, res)
+		result.Statements = append(result.Statements, item)
+
+		if item.ActiveDatabase != "" {
+			dbName = item.ActiveDatabase
+			result.ActiveDatabase = dbName
+		}
+		if item.Success {
+			result.Succeeded++
+			continue
+		}
+		result.Failed++
+		if res.Message == errQueryCanceled.Error() {
+			result.Canceled = true
+			stopped = true
+		} else if options.StopOnError {
+			stopped = true
+		}
+	}
+	result.DurationMs = time.Since(started).Milliseconds()
+
+	message := fmt.Sprintf("共 %d 条语句，成功 %d 条，失败 %d 条", len(statements), result.Succeeded, result.Failed)
+	if result.Skipped > 0 {
+		message += fmt.Sprintf("，跳过 %d 条", result.Skipped)
+	}
+	return connection.QueryResult{Success: result.Failed == 0, Message: message, Data: result}
+}
+
+func applyScriptStatementResult(item *ScriptStatementResult, res connection.QueryResult) {
+	item.Success = res.Success
+	item.Message = res.Message
+	item.Error = res.Error
+	item.SchemaBackup = res.SchemaBackup
+	item.LimitReached = res.LimitReached
+	switch data := res.Data.(type) {
+	case []map[string]interface{}:
+		item.Data = data
+		item.Fields = res.Fields
+		item.HasResultSet = true
+	case map[string]int64:
+		item.AffectedRows = data["affectedRows"]
+	case DatabaseSwitchResult:
+		item.ActiveDatabase = data.ActiveDatabase
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type scriptFakeDB struct {
+	db.Database
+	executed []string
+}
+
+func (f *scriptFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	f.executed = append(f.executed, query)
+	return []map[string]interface{}{{"n": int64(1)}}, []string{"n"}, nil
+}
+
+func (f *scriptFakeDB) Exec(query string) (int64, error) {
+	f.executed = append(f.executed, query)
+	if strings.Contains(query, "missing") {
+		return 0, errors.New("Table 'shop.missing' doesn't exist")
+	}
+	return 2, nil
+}
+
+func TestDBQueryScript(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	fake := &scriptFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	script := "-- 准备数据\nUPDATE users SET note = 'a;b';\n/* 统计 */ SELECT COUNT(*) AS n FROM users;\n" +
+		"DELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; END//\nDELIMITER ;\nDELETE FROM missing;\nSELECT 2;"
+	res := a.DBQueryScript(config, "shop", script, ScriptOptions{})
+	report := res.Data.(ScriptResult)
+	if res.Success || report.Succeeded != 4 || report.Failed != 1 || len(report.Statements) != 5 {
+		t.Fatalf("result = %+v %+v", res, report)
+	}
+	first, second, failed := report.Statements[0], report.Statements[1], report.Statements[3]
+	if first.Line != 1 || first.AffectedRows != 2 || first.HasResultSet {
+		t.Fatalf("update = %+v", first)
+	}
+	if second.Line != 3 || !second.HasResultSet || len(second.Data) != 1 || second.Fields[0] != "n" {
+		t.Fatalf("select = %+v", second)
+	}
+	if report.Statements[2].SQL != "CREATE PROCEDURE p() BEGIN SELECT 1; END" {
+		t.Fatalf("procedure = %q", report.Statements[2].SQL)
+	}
+	if failed.Success || !failed.Executed || !strings.Contains(failed.Message, "doesn't exist") {
+		t.Fatalf("failed = %+v", failed)
+	}
+
+	fake.executed = nil
+	res = a.DBQueryScript(config, "shop", "DELETE FROM missing; SELECT 1", ScriptOptions{StopOnError: true})
+	report = res.Data.(ScriptResult)
+	if report.Skipped != 1 || report.Statements[1].Executed || len(fake.executed) != 1 {
+		t.Fatalf("stop on error = %+v executed=%q", report, fake.executed)
+	}
+}
+
+func TestDBQueryScriptUseSwitchesDatabase(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	shop, crm := &scriptFakeDB{}, &scriptFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: shop, lastPing: time.Now()}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "crm")))] = cachedDatabase{inst: crm, lastPing: time.Now()}
+
+	res := a.DBQueryScript(config, "shop", "UPDATE a SET x = 1;\nUSE `crm`;\nUPDATE b SET x = 1;", ScriptOptions{})
+	report := res.Data.(ScriptResult)
+	if !res.Success || report.ActiveDatabase != "crm" || report.Statements[1].ActiveDatabase != "crm" {
+		t.Fatalf("result = %+v %+v", res, report)
+	}
+	if len(shop.executed) != 1 || len(crm.executed) != 1 || crm.executed[0] != "UPDATE b SET x = 1" {
+		t.Fatalf("shop=%q crm=%q", shop.executed, crm.executed)
+	}
+
+	res = a.DBQuery(config, "shop", "use crm;")
+	if data, ok := res.Data.(DatabaseSwitchResult); !res.Success || !ok || data.ActiveDatabase != "crm" {
+		t.Fatalf("DBQuery use = %+v", res)
+	}
+}
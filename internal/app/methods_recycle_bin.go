@@ -0,0 +1,377 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+)
+
+const (
+	recycleBinMetaFile       = "meta.json"
+	recycleBinScriptFile     = "object.sql"
+	defaultRecycleBinMaxRows = 10000
+)
+
+// RecycleBinOptions 删除前备份选项。IncludeData 为 true 时，行数不超过 MaxRows（默认 10000）的表会连同数据一起备份。
+type RecycleBinOptions struct {
+	IncludeData bool `json:"includeData"`
+	MaxRows     int  `json:"maxRows,omitempty"`
+}
+
+// RecycleBinEntry 回收站中的一个已删除对象。
+type RecycleBinEntry struct {
+	ID          string `json:"id"`
+	ObjectType  string `json:"objectType"` // table | view
+	Name        string `json:"name"`
+	Schema      string `json:"schema,omitempty"`
+	Database    string `json:"database,omitempty"`
+	DBType      string `json:"dbType"`
+	Host        string `json:"host,omitempty"`
+	DroppedAt   int64  `json:"droppedAt"` // Unix milli
+	RowCount    int64  `json:"rowCount"`
+	DataSaved   bool   `json:"dataSaved"`
+	DataSkipped string `json:"dataSkipped,omitempty"` // 未备份数据的原因
+	ScriptPath  string `json:"scriptPath"`
+	SizeBytes   int64  `json:"sizeBytes"`
+}
+
+func recycleBinDirectory() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "recycle_bin")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-recycle_bin")
+}
+
+// recycleBinEntryDir 只接受 ListRecycleBin 返回的 ID，防止路径穿越。
+func recycleBinEntryDir(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || id != filepath.Base(id) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("无效的回收站条目：%s", id)
+	}
+	return filepath.Join(recycleBinDirectory(), id), nil
+}
+
+func readRecycleBinEntry(dir string) (RecycleBinEntry, error) {
+	var entry RecycleBinEntry
+	content, err := os.ReadFile(filepath.Join(dir, recycleBinMetaFile))
+	if err != nil {
+		return entry, err
+	}
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return entry, fmt.Errorf("解析回收站条目失败：%w", err)
+	}
+	return entry, nil
+}
+
+// viewDefinitionSQL 返回读取视图完整定义的查询，结果第一列为可直接执行的 CREATE VIEW 语句（PG 系仅为 SELECT 部分）。
+func viewDefinitionSQL(dbType string, schema string, view string) string {
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		return fmt.Sprintf("SHOW CREATE VIEW %s", quoteTableIdentByType(dbType, schema, view))
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return fmt.Sprintf("SELECT pg_get_viewdef(%s::regclass, true)", dialect.QuoteLiteral(dbType, quoteTableIdentByType(dbType, schema, view)))
+	case "sqlserver":
+		return fmt.Sprintf("SELECT OBJECT_DEFINITION(OBJECT_ID(N%s))", dialect.QuoteLiteral(dbType, quoteTableIdentByType(dbType, schema, view)))
+	case "oracle", "dameng":
+		return fmt.Sprintf("SELECT DBMS_METADATA.GET_DDL('VIEW', %s, %s) FROM DUAL", dialect.QuoteLiteral(dbType, view), dialect.QuoteLiteral(dbType, schema))
+	case "sqlite":
+		return fmt.Sprintf("SELECT sql FROM sqlite_master WHERE type = 'view' AND name = %s", dialect.QuoteLiteral(dbType, view))
+	case "duckdb":
+		return fmt.Sprintf("SELECT sql FROM duckdb_views() WHERE view_name = %s", dialect.QuoteLiteral(dbType, view))
+	}
+	return ""
+}
+
+func backupViewDDL(dbInst db.Database, dbType string, schema string, view string) (string, error) {
+	query := viewDefinitionSQL(dbType, schema, view)
+	if query == "" {
+		return "", fmt.Errorf("当前数据源(%s)暂不支持备份视图定义", dbType)
+	}
+	data, columns, err := dbInst.Query(query)
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 0 || len(columns) == 0 {
+		return "", fmt.Errorf("未找到视图定义：%s", view)
+	}
+	// SHOW CREATE VIEW 的定义在 "Create View" 列
+	value := data[0][columns[0]]
+	for _, col := range columns {
+		if strings.EqualFold(col, "Create View") {
+			value = data[0][col]
+		}
+	}
+	ddl := strings.TrimSpace(fmt.Sprintf("%v", value))
+	if value == nil || ddl == "" {
+		return "", fmt.Errorf("未找到视图定义：%s", view)
+	}
+	switch dbType {
+	case "postgres", "kingbase", "highgo", "vastbase":
+		ddl = fmt.Sprintf("CREATE VIEW %s AS\n%s", quoteTableIdentByType(dbType, schema, view), strings.TrimSuffix(ddl, ";"))
+	}
+	return ensureSQLTerminator(ddl), nil
+}
+
+func backupTableDDL(dbInst db.Database, dbType string, schema string, table string) (string, error) {
+	ddl, err := dbInst.GetCreateStatement(schema, table)
+	if err != nil {
+		return "", err
+	}
+	if shouldFallbackCreateStatement(dbType, ddl) {
+		columns, err := dbInst.GetColumns(schema, table)
+		if err != nil {
+			return "", err
+		}
+		if ddl, err = buildFallbackCreateStatement(dbType, schema, table, columns); err != nil {
+			return "", err
+		}
+	}
+	return ensureSQLTerminator(ddl), nil
+}
+
+// writeRecycleBinData 写入表数据 INSERT 语句，行数超过上限时不写数据并返回原因。
+func writeRecycleBinData(w *bufio.Writer, dbInst db.Database, dbType string, schema string, table string, maxRows int) (int64, string, error) {
+	qualified := quoteTableIdentByType(dbType, schema, table)
+	total, err := strconv.ParseInt(queryFirstString(dbInst, fmt.Sprintf("SELECT COUNT(*) FROM %s", qualified)), 10, 64)
+	if err != nil {
+		return 0, "无法统计表行数", nil
+	}
+	if total > int64(maxRows) {
+		return total, fmt.Sprintf("表有 %d 行，超过备份上限 %d 行，仅备份结构", total, maxRows), nil
+	}
+	data, columns, err := dbInst.Query(fmt.Sprintf("SELECT * FROM %s", qualified))
+	if err != nil {
+		return total, "", err
+	}
+	quotedCols := make([]string, 0, len(columns))
+	for _, c := range columns {
+		quotedCols = append(quotedCols, quoteIdentByType(dbType, c))
+	}
+	for _, row := range data {
+		values := make([]string, 0, len(columns))
+		for _, c := range columns {
+			values = append(values, formatSQLValue(dbType, row[c]))
+		}
+		if _, err := fmt.Fprintf(w, "INSERT INTO %s (%s) VALUES (%s);\n", qualified, strings.Join(quotedCols, ", "), strings.Join(values, ", ")); err != nil {
+			return total, "", err
+		}
+	}
+	return int64(len(data)), "", nil
+}
+
+// backupToRecycleBin 生成对象的 DDL（及可选数据）脚本并写入回收站目录。
+func backupToRecycleBin(dbInst db.Database, config connection.ConnectionConfig, dbType string, objectType string, schema string, name string, dbName string, opts RecycleBinOptions) (RecycleBinEntry, error) {
+	var ddl string
+	var err error
+	if objectType == "view" {
+		ddl, err = backupViewDDL(dbInst, dbType, schema, name)
+	} else {
+		ddl, err = backupTableDDL(dbInst, dbType, schema, name)
+	}
+	if err != nil {
+		return RecycleBinEntry{}, fmt.Errorf("读取对象定义失败：%w", err)
+	}
+
+	now := time.Now()
+	id := fmt.Sprintf("%s-%s-%s", now.Format("20060102-150405.000"), objectType, snapshotNameSanitizer.ReplaceAllString(name, "_"))
+	dir := filepath.Join(recycleBinDirectory(), id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return RecycleBinEntry{}, fmt.Errorf("创建回收站目录失败：%w", err)
+	}
+	entry := RecycleBinEntry{
+		ID:         id,
+		ObjectType: objectType,
+		Name:       name,
+		Schema:     schema,
+		Database:   dbName,
+		DBType:     dbType,
+		Host:       config.Host,
+		DroppedAt:  now.UnixMilli(),
+		ScriptPath: filepath.Join(dir, recycleBinScriptFile),
+	}
+
+	writeErr := func() error {
+		f, err := os.Create(entry.ScriptPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w := bufio.NewWriter(f)
+		fmt.Fprintf(w, "-- GoNavi 回收站备份\n-- 对象：%s %s\n-- 时间：%s\n\n", objectType, qualifyTable(schema, name), now.Format("2006-01-02 15:04:05"))
+		if _, err := w.WriteString(ddl + "\n\n"); err != nil {
+			return err
+		}
+		if objectType == "table" && opts.IncludeData {
+			maxRows := opts.MaxRows
+			if maxRows <= 0 {
+				maxRows = defaultRecycleBinMaxRows
+			}
+			rows, skipped, err := writeRecycleBinData(w, dbInst, dbType, schema, name, maxRows)
+			if err != nil {
+				return err
+			}
+			entry.RowCount, entry.DataSkipped, entry.DataSaved = rows, skipped, skipped == ""
+		}
+		return w.Flush()
+	}()
+	if writeErr == nil {
+		var meta []byte
+		if info, err := os.Stat(entry.ScriptPath); err == nil {
+			entry.SizeBytes = info.Size()
+		}
+		if meta, writeErr = json.MarshalIndent(entry, "", "  "); writeErr == nil {
+			writeErr = os.WriteFile(filepath.Join(dir, recycleBinMetaFile), meta, 0o644)
+		}
+	}
+	if writeErr != nil {
+		_ = os.RemoveAll(dir)
+		return RecycleBinEntry{}, fmt.Errorf("写入回收站备份失败：%w", writeErr)
+	}
+	return entry, nil
+}
+
+// DropObjectWithBackup 先把表/视图的定义（表可选含数据）备份到本地回收站，再执行删除；备份失败时不删除。
+func (a *App) DropObjectWithBackup(config connection.ConnectionConfig, dbName string, objectType string, name string, opts RecycleBinOptions) connection.QueryResult {
+	if err := ensureWritable(config, "删除对象"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	objectType = strings.ToLower(strings.TrimSpace(objectType))
+	name = strings.TrimSpace(name)
+	if objectType != "table" && objectType != "view" {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("不支持的对象类型：%s", objectType)}
+	}
+	if name == "" {
+		return connection.QueryResult{Success: false, Message: "对象名称不能为空"}
+	}
+
+	dbType := resolveDDLDBType(config)
+	schemaName, pureName := normalizeSchemaAndTableByType(dbType, dbName, name)
+	runConfig := buildRunConfigForDDL(config, dbType, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	entry, err := backupToRecycleBin(dbInst, runConfig, dbType, objectType, schemaName, pureName, dbName, opts)
+	if err != nil {
+		logger.Error(err, "回收站备份失败，已取消删除：%s 对象=%s", formatConnSummary(runConfig), name)
+		return connection.QueryResult{Success: false, Message: err.Error() + "，已取消删除"}
+	}
+
+	var res connection.QueryResult
+	if objectType == "view" {
+		res = a.DropView(config, dbName, name)
+	} else {
+		res = a.DropTable(config, dbName, name)
+	}
+	if !res.Success {
+		_ = os.RemoveAll(filepath.Dir(entry.ScriptPath))
+		return res
+	}
+	logger.Infof("已删除并备份到回收站：%s 对象=%s 条目=%s", formatConnSummary(runConfig), name, entry.ID)
+	res.Message += "（已备份到回收站）"
+	if entry.DataSkipped != "" {
+		res.Message += "：" + entry.DataSkipped
+	}
+	res.Data = entry
+	return res
+}
+
+// ListRecycleBin 按删除时间倒序列出回收站条目。
+func (a *App) ListRecycleBin() connection.QueryResult {
+	items, err := os.ReadDir(recycleBinDirectory())
+	if err != nil && !os.IsNotExist(err) {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	entries := make([]RecycleBinEntry, 0, len(items))
+	for _, item := range items {
+		if !item.IsDir() {
+			continue
+		}
+		entry, err := readRecycleBinEntry(filepath.Join(recycleBinDirectory(), item.Name()))
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].DroppedAt > entries[j].DroppedAt })
+	return connection.QueryResult{Success: true, Data: entries}
+}
+
+// RestoreFromRecycleBin 在指定连接上执行备份脚本恢复对象；dbName 为空时使用删除时的数据库。成功后移除该条目。
+func (a *App) RestoreFromRecycleBin(config connection.ConnectionConfig, dbName string, id string) connection.QueryResult {
+	if err := ensureWritable(config, "从回收站恢复对象"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dir, err := recycleBinEntryDir(id)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	entry, err := readRecycleBinEntry(dir)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("读取回收站条目失败：%s", err.Error())}
+	}
+	script, err := os.ReadFile(filepath.Join(dir, recycleBinScriptFile))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("读取备份脚本失败：%s", err.Error())}
+	}
+	if strings.TrimSpace(dbName) == "" {
+		dbName = entry.Database
+	}
+	dbType := resolveDDLDBType(config)
+	if dbType != entry.DBType {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("备份来自 %s，不能恢复到 %s 连接", entry.DBType, dbType)}
+	}
+
+	runConfig := buildRunConfigForDDL(config, dbType, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	executed := 0
+	for _, stmt := range sqlstmt.Split(string(script), dbType) {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+		if _, err := dbInst.Exec(stmt); err != nil {
+			logger.Error(err, "回收站恢复失败：%s 条目=%s SQL片段=%q", formatConnSummary(runConfig), entry.ID, sqlSnippet(stmt))
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("已执行 %d 条语句后失败：%s", executed, err.Error())}
+		}
+		executed++
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		logger.Warnf("移除已恢复的回收站条目失败：%s 原因=%v", dir, err)
+	}
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已恢复 %s（执行 %d 条语句）", qualifyTable(entry.Schema, entry.Name), executed), Data: entry}
+}
+
+// PurgeRecycleBin 永久删除回收站条目；id 为空时清空回收站。
+func (a *App) PurgeRecycleBin(id string) connection.QueryResult {
+	if strings.TrimSpace(id) == "" {
+		if err := os.RemoveAll(recycleBinDirectory()); err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		return connection.QueryResult{Success: true, Message: "回收站已清空"}
+	}
+	dir, err := recycleBinEntryDir(id)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: "已永久删除"}
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+// recycleFakeDB 返回固定建表语句与数据的最小 Database 实现。
+type recycleFakeDB struct {
+	db.Database
+}
+
+func (f *recycleFakeDB) GetCreateStatement(dbName, tableName string) (string, error) {
+	return "CREATE TABLE `orders` (`id` int, `note` varchar(20))", nil
+}
+
+func (f *recycleFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	if strings.HasPrefix(query, "SELECT COUNT(*)") {
+		return []map[string]interface{}{{"c": int64(2)}}, []string{"c"}, nil
+	}
+	return []map[string]interface{}{
+		{"id": int64(1), "note": "it's"},
+		{"id": int64(2), "note": nil},
+	}, []string{"id", "note"}, nil
+}
+
+func TestBackupToRecycleBinWritesScriptAndMeta(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config := connection.ConnectionConfig{Type: "mysql", Host: "db"}
+
+	entry, err := backupToRecycleBin(&recycleFakeDB{}, config, "mysql", "table", "shop", "orders", "shop", RecycleBinOptions{IncludeData: true})
+	if err != nil {
+		t.Fatalf("backupToRecycleBin: %v", err)
+	}
+	if !entry.DataSaved || entry.RowCount != 2 {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	script, err := os.ReadFile(entry.ScriptPath)
+	if err != nil {
+		t.Fatalf("read script: %v", err)
+	}
+	text := string(script)
+	if !strings.Contains(text, "CREATE TABLE `orders`") || !strings.Contains(text, "INSERT INTO `shop`.`orders` (`id`, `note`) VALUES (1, 'it''s');") {
+		t.Fatalf("unexpected script:\n%s", text)
+	}
+
+	list := (&App{}).ListRecycleBin()
+	entries, _ := list.Data.([]RecycleBinEntry)
+	if len(entries) != 1 || entries[0].ID != entry.ID {
+		t.Fatalf("list = %+v", list.Data)
+	}
+
+	entry, err = backupToRecycleBin(&recycleFakeDB{}, config, "mysql", "table", "shop", "orders", "shop", RecycleBinOptions{IncludeData: true, MaxRows: 1})
+	if err != nil {
+		t.Fatalf("backupToRecycleBin: %v", err)
+	}
+	if entry.DataSaved || entry.DataSkipped == "" {
+		t.Fatalf("data over the row limit should be skipped: %+v", entry)
+	}
+}
+
+func TestRecycleBinEntryDirRejectsTraversal(t *testing.T) {
+	for _, id := range []string{"", "../etc", "a/b", ".."} {
+		if _, err := recycleBinEntryDir(id); err == nil {
+			t.Fatalf("id %q should be rejected", id)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/redis"
+)
+
+// RedisCommandResult Redis 控制台单条命令的执行结果。
+type RedisCommandResult struct {
+	Command         string              `json:"command"`
+	Info            redis.CommandInfo   `json:"info"`
+	Reply           *redis.CommandReply `json:"reply,omitempty"`
+	RequiresConfirm bool                `json:"requiresConfirm,omitempty"` // 危险命令未确认，未执行
+	DurationMs      int64               `json:"durationMs"`
+}
+
+// RedisCommandCatalog 返回命令安全分类表，前端据此在发送前标记危险命令并要求确认。
+func (a *App) RedisCommandCatalog() connection.QueryResult {
+	return connection.QueryResult{Success: true, Data: redis.CommandCatalog()}
+}
+
+// RedisRunCommand 执行一条原始 Redis 命令，按回复类型返回结果。危险命令需 confirmed 为 true 才会执行，
+// 只读连接只允许只读命令，订阅类命令不支持。
+func (a *App) RedisRunCommand(config connection.ConnectionConfig, command string, confirmed bool) connection.QueryResult {
+	config.Type = "redis"
+	args := parseRedisCommand(command)
+	if len(args) == 0 {
+		return connection.QueryResult{Success: false, Message: "命令不能为空"}
+	}
+	result := RedisCommandResult{Command: command, Info: redis.ClassifyCommand(args)}
+	switch {
+	case result.Info.Category == redis.CommandUnsupported:
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("控制台不支持 %s：%s", result.Info.Name, result.Info.Reason), Data: result}
+	case config.ReadOnly && result.Info.Category != redis.CommandRead:
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前连接为只读模式，不允许执行 %s", result.Info.Name), Data: result}
+	case result.Info.Category == redis.CommandDangerous && !confirmed:
+		result.RequiresConfirm = true
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("%s 需要确认后执行：%s", result.Info.Name, result.Info.Reason), Data: result}
+	}
+
+	client, err := a.getRedisClient(config)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if result.Info.Category == redis.CommandDangerous {
+		logger.Warnf("执行危险 Redis 命令：%s 命令=%s", formatRedisConnSummary(config), result.Info.Name)
+	}
+	started := time.Now()
+	reply, err := client.RunCommand(args)
+	result.DurationMs = time.Since(started).Milliseconds()
+	kind := "exec"
+	if result.Info.Category == redis.CommandRead {
+		kind = "query"
+	}
+	a.recordHistory(config, fmt.Sprintf("db%d", config.RedisDB), kind, redisHistoryCommand(result.Info, command), started, redisReplyRows(kind, reply), err)
+	if err != nil {
+		logger.Error(err, "RedisRunCommand 执行失败：%s 命令=%s", formatRedisConnSummary(config), result.Info.Name)
+		return connection.QueryResult{Success: false, Message: err.Error(), Data: result}
+	}
+	result.Reply = reply
+	return connection.QueryResult{Success: true, Data: result}
+}
+
+// redisHistoryCommand 返回写入执行历史的命令文本，可能携带口令的命令只保留命令名。
+func redisHistoryCommand(info redis.CommandInfo, command string) string {
+	switch info.Name {
+	case "AUTH", "HELLO", "MIGRATE", "ACL SETUSER", "CONFIG SET":
+		return info.Name + " ***"
+	}
+	return command
+}
+
+// redisReplyRows 读命令取返回的元素数（map 按键值对计），写命令取整数回复（如 DEL 删除的键数）。
+func redisReplyRows(kind string, reply *redis.CommandReply) int64 {
+	if reply == nil || reply.Type == "nil" {
+		return 0
+	}
+	if kind == "exec" {
+		if n, ok := reply.Value.(int64); ok && reply.Type == "integer" {
+			return n
+		}
+		return 0
+	}
+	switch reply.Type {
+	case "array":
+		return int64(len(reply.Elements))
+	case "map":
+		return int64(len(reply.Elements) / 2)
+	}
+	return 1
+}
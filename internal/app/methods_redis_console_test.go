@@ -0,0 +1,29 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestRedisRunCommandGuards(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "redis", Host: "127.0.0.1", Port: 6379}
+
+	res := a.RedisRunCommand(config, "FLUSHALL", false)
+	if result, ok := res.Data.(RedisCommandResult); res.Success || !ok || !result.RequiresConfirm || result.Info.Name != "FLUSHALL" {
+		t.Fatalf("res = %+v", res)
+	}
+	if res := a.RedisRunCommand(config, "subscribe news", true); res.Success || !strings.Contains(res.Message, "不支持") {
+		t.Fatalf("res = %+v", res)
+	}
+
+	config.ReadOnly = true
+	if res := a.RedisRunCommand(config, `SET k "v 1"`, true); res.Success || !strings.Contains(res.Message, "只读") {
+		t.Fatalf("res = %+v", res)
+	}
+	if res := a.RedisRunCommand(config, "  ", false); res.Success {
+		t.Fatalf("res = %+v", res)
+	}
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	queryRouteAuto    = "auto"    // 按连接配置：启用 ReadFromReplica 时读语句走从库
+	queryRoutePrimary = "primary" // 强制主库
+	queryRouteReplica = "replica" // 强制从库（无可用从库时报错）
+)
+
+// ReplicaStatus 从库节点状态。LagSeconds 为 -1 表示无法获取（未配置复制或无权限）。
+type ReplicaStatus struct {
+	Address    string `json:"address"`
+	Connected  bool   `json:"connected"`
+	LagSeconds int64  `json:"lagSeconds"`
+	Running    bool   `json:"running"` // 复制线程是否在运行
+	Error      string `json:"error,omitempty"`
+}
+
+// supportsReplicaRouting 读写分离仅适用于 MySQL 系主从拓扑：Hosts[0] 为主库，其余为从库。
+func supportsReplicaRouting(config connection.ConnectionConfig) bool {
+	switch resolveDDLDBType(config) {
+	case "mysql", "mariadb", "diros":
+	default:
+		return false
+	}
+	return strings.EqualFold(strings.TrimSpace(config.Topology), "replica") && len(config.Hosts) > 1
+}
+
+// replicaConfigs 为每个从库节点生成单节点连接配置，使用从库账号（未配置时沿用主账号）。
+func replicaConfigs(config connection.ConnectionConfig) []connection.ConnectionConfig {
+	if !supportsReplicaRouting(config) {
+		return nil
+	}
+	defaultPort := config.Port
+	result := make([]connection.ConnectionConfig, 0, len(config.Hosts)-1)
+	for _, address := range config.Hosts[1:] {
+		host, port, ok := splitReplicaAddress(address, defaultPort)
+		if !ok {
+			continue
+		}
+		replica := config
+		replica.Host = host
+		replica.Port = port
+		replica.Hosts = nil
+		replica.URI = ""
+		replica.Topology = "single"
+		replica.ReadFromReplica = false
+		if user := strings.TrimSpace(config.MySQLReplicaUser); user != "" {
+			replica.User = user
+			replica.Password = config.MySQLReplicaPassword
+		}
+		replica.MySQLReplicaUser = ""
+		replica.MySQLReplicaPassword = ""
+		result = append(result, replica)
+	}
+	return result
+}
+
+func splitReplicaAddress(address string, defaultPort int) (string, int, bool) {
+	text := strings.TrimSpace(address)
+	if text == "" {
+		return "", 0, false
+	}
+	idx := strings.LastIndex(text, ":")
+	if idx < 0 || strings.HasSuffix(text, "]") {
+		return strings.Trim(text, "[]"), defaultPort, true
+	}
+	port, err := strconv.Atoi(text[idx+1:])
+	if err != nil || port <= 0 {
+		return "", 0, false
+	}
+	return strings.Trim(text[:idx], "[]"), port, true
+}
+
+// resolveReadTarget 为读语句选择执行节点。从库均不可用时回退主库并返回提示；强制从库时返回错误。
+func (a *App) resolveReadTarget(config connection.ConnectionConfig, primary db.Database, route string) (db.Database, connection.ConnectionConfig, string, error) {
+	route = strings.ToLower(strings.TrimSpace(route))
+	if route == "" {
+		route = queryRouteAuto
+	}
+	useReplica := route == queryRouteReplica || (route == queryRouteAuto && config.ReadFromReplica)
+	if !useReplica {
+		return primary, config, "", nil
+	}
+	replicas := replicaConfigs(config)
+	if len(replicas) == 0 {
+		if route == queryRouteReplica {
+			return nil, config, "", fmt.Errorf("当前连接未配置从库（需主从拓扑且填写多个节点）")
+		}
+		return primary, config, "", nil
+	}
+	var lastErr error
+	for _, replica := range replicas {
+		inst, err := a.getDatabase(replica)
+		if err == nil {
+			return inst, replica, "", nil
+		}
+		lastErr = err
+		logger.Warnf("从库不可用：%s 原因：%v", formatConnSummary(replica), err)
+	}
+	if route == queryRouteReplica {
+		return nil, config, "", fmt.Errorf("所有从库均不可用：%s", normalizeErrorMessage(lastErr))
+	}
+	return primary, config, "从库不可用，已在主库执行", nil
+}
+
+// DBQueryRouted 与 DBQuery 相同，但可按语句指定执行节点：auto（默认，遵循连接的读写分离配置）、
+// primary（强制主库，用于读取刚写入的数据）、replica（强制从库）。写语句始终在主库执行。
+func (a *App) DBQueryRouted(config connection.ConnectionConfig, dbName string, query string, route string) connection.QueryResult {
+	return a.dbQuery(config, dbName, query, dbQueryOptions{route: route})
+}
+
+// GetReplicaStatus 返回各从库节点的连接与复制延迟状态。
+func (a *App) GetReplicaStatus(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	replicas := replicaConfigs(runConfig)
+	if len(replicas) == 0 {
+		return connection.QueryResult{Success: false, Message: "当前连接未配置从库（需主从拓扑且填写多个节点）"}
+	}
+	result := make([]ReplicaStatus, 0, len(replicas))
+	for _, replica := range replicas {
+		status := ReplicaStatus{Address: fmt.Sprintf("%s:%d", replica.Host, replica.Port), LagSeconds: -1}
+		inst, err := a.getDatabase(replica)
+		if err != nil {
+			status.Error = normalizeErrorMessage(err)
+			result = append(result, status)
+			continue
+		}
+		status.Connected = true
+		lag, running, err := queryMySQLReplicaLag(inst)
+		if err != nil {
+			status.Error = normalizeErrorMessage(err)
+		}
+		status.LagSeconds, status.Running = lag, running
+		result = append(result, status)
+	}
+	return connection.QueryResult{Success: true, Data: result}
+}
+
+// queryMySQLReplicaLag 读取 Seconds_Behind_Source（8.0.22+）或 Seconds_Behind_Master。
+func queryMySQLReplicaLag(inst db.Database) (int64, bool, error) {
+	data, _, err := inst.Query("SHOW REPLICA STATUS")
+	if err != nil {
+		data, _, err = inst.Query("SHOW SLAVE STATUS")
+	}
+	if err != nil {
+		return -1, false, err
+	}
+	if len(data) == 0 {
+		return -1, false, fmt.Errorf("该节点未配置复制")
+	}
+	row := data[0]
+	text := func(keys ...string) string {
+		for _, key := range keys {
+			if v, ok := row[key]; ok && v != nil {
+				return strings.TrimSpace(fmt.Sprintf("%v", v))
+			}
+		}
+		return ""
+	}
+	running := strings.EqualFold(text("Replica_IO_Running", "Slave_IO_Running"), "Yes") &&
+		strings.EqualFold(text("Replica_SQL_Running", "Slave_SQL_Running"), "Yes")
+	lag, err := strconv.ParseInt(text("Seconds_Behind_Source", "Seconds_Behind_Master"), 10, 64)
+	if err != nil {
+		// 复制线程停止时该列为 NULL
+		return -1, running, nil
+	}
+	return lag, running, nil
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestReplicaConfigs(t *testing.T) {
+	config := connection.ConnectionConfig{
+		Type:                 "mysql",
+		Host:                 "primary",
+		Port:                 3306,
+		User:                 "app",
+		Password:             "secret",
+		Topology:             "replica",
+		Hosts:                []string{"primary:3306", "replica-a:3307", "replica-b"},
+		MySQLReplicaUser:     "reader",
+		MySQLReplicaPassword: "ro",
+		ReadFromReplica:      true,
+	}
+	replicas := replicaConfigs(config)
+	if len(replicas) != 2 {
+		t.Fatalf("replicas = %+v", replicas)
+	}
+	if replicas[0].Host != "replica-a" || replicas[0].Port != 3307 || replicas[1].Host != "replica-b" || replicas[1].Port != 3306 {
+		t.Fatalf("unexpected addresses: %+v", replicas)
+	}
+	for _, r := range replicas {
+		if r.User != "reader" || r.Password != "ro" || len(r.Hosts) != 0 || r.ReadFromReplica || r.Topology != "single" {
+			t.Fatalf("replica config not isolated: %+v", r)
+		}
+	}
+
+	config.Topology = "single"
+	if replicaConfigs(config) != nil {
+		t.Fatal("single topology should not route to replicas")
+	}
+	config.Topology = "replica"
+	config.Type = "postgres"
+	if replicaConfigs(config) != nil {
+		t.Fatal("postgres replica routing is not supported")
+	}
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+)
+
+// 大结果集的压缩传输。
+// Wails 桥接把 QueryResult 整体序列化为一条 JSON 消息，宽表结果可达数十 MB，
+// WebView 解析期间界面卡顿。超过阈值时改为 gzip 压缩后暂存在后端，
+// 前端按块拉取 base64 文本、解压后再解析，消息体积通常降到原来的十分之一左右。
+
+const (
+	resultPayloadThreshold = 1 << 20 // 结果 JSON 超过 1MB 才压缩，小结果直接返回
+	resultPayloadChunkSize = 2 << 20 // 每块压缩数据字节数（base64 前）
+	resultPayloadTTL       = 2 * time.Minute
+	resultPayloadMaxCount  = 16
+	resultPayloadEncoding  = "gzip"
+)
+
+var resultPayloadSeq uint64
+
+// CompressedResult 压缩结果的描述，替代原始行数据放在 QueryResult.Data 中返回。
+type CompressedResult struct {
+	Compressed bool   `json:"compressed"` // 恒为 true，供前端区分普通结果
+	PayloadID  string `json:"payloadId"`
+	Encoding   string `json:"encoding"`
+	Chunks     int    `json:"chunks"`
+	RawSize    int    `json:"rawSize"`
+	Size       int    `json:"size"`
+	Rows       int    `json:"rows"`
+}
+
+// resultPayloadBody 解压后的 JSON 结构，与 QueryResult 的 data/fields 一致。
+type resultPayloadBody struct {
+	Data   interface{} `json:"data"`
+	Fields []string    `json:"fields,omitempty"`
+}
+
+type resultPayload struct {
+	data      []byte
+	createdAt time.Time
+}
+
+// DBQueryCompressed 与 DBQueryWithID 相同；结果较大时 Data 为 CompressedResult，需通过 FetchResultChunk 拉取。
+func (a *App) DBQueryCompressed(config connection.ConnectionConfig, dbName string, query string, queryID string) connection.QueryResult {
+	res := a.dbQuery(config, dbName, query, dbQueryOptions{route: queryRouteAuto, queryID: strings.TrimSpace(queryID)})
+	return a.compressQueryResult(res)
+}
+
+// compressQueryResult 结果 JSON 超过阈值时压缩暂存，否则原样返回。
+func (a *App) compressQueryResult(res connection.QueryResult) connection.QueryResult {
+	rows, ok := res.Data.([]map[string]interface{})
+	if !res.Success || !ok || len(rows) == 0 {
+		return res
+	}
+	raw, err := json.Marshal(resultPayloadBody{Data: rows, Fields: res.Fields})
+	if err != nil || len(raw) < resultPayloadThreshold {
+		return res
+	}
+
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+	if _, err := zw.Write(raw); err != nil {
+		logger.Warnf("压缩查询结果失败，改为直接返回：%v", err)
+		return res
+	}
+	if err := zw.Close(); err != nil {
+		logger.Warnf("压缩查询结果失败，改为直接返回：%v", err)
+		return res
+	}
+
+	now := time.Now()
+	id := fmt.Sprintf("result-%d-%d", now.UnixNano(), atomic.AddUint64(&resultPayloadSeq, 1))
+	a.payloadMu.Lock()
+	if a.payloads == nil {
+		a.payloads = make(map[string]*resultPayload)
+	}
+	a.pruneResultPayloadsLocked(now)
+	a.payloads[id] = &resultPayload{data: buf.Bytes(), createdAt: now}
+	a.payloadMu.Unlock()
+
+	res.Data = CompressedResult{
+		Compressed: true,
+		PayloadID:  id,
+		Encoding:   resultPayloadEncoding,
+		Chunks:     (buf.Len() + resultPayloadChunkSize - 1) / resultPayloadChunkSize,
+		RawSize:    len(raw),
+		Size:       buf.Len(),
+		Rows:       len(rows),
+	}
+	res.Fields = nil
+	return res
+}
+
+// pruneResultPayloadsLocked 清理过期结果（前端崩溃或切走未拉取完的），并在数量超限时淘汰最早的。调用方需持有 payloadMu。
+func (a *App) pruneResultPayloadsLocked(now time.Time) {
+	for id, payload := range a.payloads {
+		if now.Sub(payload.createdAt) > resultPayloadTTL {
+			delete(a.payloads, id)
+		}
+	}
+	for len(a.payloads) >= resultPayloadMaxCount {
+		var oldestID string
+		var oldest time.Time
+		for id, payload := range a.payloads {
+			if oldestID == "" || payload.createdAt.Before(oldest) {
+				oldestID, oldest = id, payload.createdAt
+			}
+		}
+		delete(a.payloads, oldestID)
+	}
+}
+
+// FetchResultChunk 返回压缩结果第 index 块的 base64 文本；取完最后一块后释放暂存数据。
+func (a *App) FetchResultChunk(payloadID string, index int) connection.QueryResult {
+	payloadID = strings.TrimSpace(payloadID)
+	a.payloadMu.Lock()
+	defer a.payloadMu.Unlock()
+	a.pruneResultPayloadsLocked(time.Now())
+	payload, ok := a.payloads[payloadID]
+	if !ok {
+		return connection.QueryResult{Success: false, Message: "查询结果已过期，请重新执行查询"}
+	}
+	start := index * resultPayloadChunkSize
+	if index < 0 || start >= len(payload.data) {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("无效的结果分块序号：%d", index)}
+	}
+	end := start + resultPayloadChunkSize
+	if end >= len(payload.data) {
+		end = len(payload.data)
+		delete(a.payloads, payloadID)
+	}
+	return connection.QueryResult{Success: true, Message: "OK", Data: base64.StdEncoding.EncodeToString(payload.data[start:end])}
+}
+
+// ReleaseResultPayload 前端放弃拉取（如切换分页、关闭标签）时释放暂存结果。
+func (a *App) ReleaseResultPayload(payloadID string) {
+	a.payloadMu.Lock()
+	defer a.payloadMu.Unlock()
+	delete(a.payloads, strings.TrimSpace(payloadID))
+}
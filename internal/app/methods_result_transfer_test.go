@@ -0,0 +1,60 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestCompressQueryResultRoundTrip(t *testing.T) {
+	a := NewApp()
+	small := connection.QueryResult{Success: true, Data: []map[string]interface{}{{"id": 1}}, Fields: []string{"id"}}
+	if res := a.compressQueryResult(small); res.Fields == nil {
+		t.Fatal("小结果不应压缩")
+	}
+
+	rows := make([]map[string]interface{}, 0, 4000)
+	for i := 0; i < 4000; i++ {
+		rows = append(rows, map[string]interface{}{"id": i, "payload": strings.Repeat("宽表字段", 40)})
+	}
+	res := a.compressQueryResult(connection.QueryResult{Success: true, Data: rows, Fields: []string{"id", "payload"}})
+	desc, ok := res.Data.(CompressedResult)
+	if !ok || !desc.Compressed || desc.Rows != 4000 || desc.Size >= desc.RawSize {
+		t.Fatalf("desc = %+v", res.Data)
+	}
+
+	var compressed []byte
+	for i := 0; i < desc.Chunks; i++ {
+		chunk := a.FetchResultChunk(desc.PayloadID, i)
+		if !chunk.Success {
+			t.Fatalf("chunk %d: %s", i, chunk.Message)
+		}
+		b, err := base64.StdEncoding.DecodeString(chunk.Data.(string))
+		if err != nil {
+			t.Fatal(err)
+		}
+		compressed = append(compressed, b...)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(zr)
+	var body struct {
+		Data   []map[string]interface{} `json:"data"`
+		Fields []string                 `json:"fields"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil || len(body.Data) != 4000 || len(body.Fields) != 2 {
+		t.Fatalf("decoded %d rows, fields %v, err %v", len(body.Data), body.Fields, err)
+	}
+
+	if again := a.FetchResultChunk(desc.PayloadID, 0); again.Success {
+		t.Fatal("取完最后一块后应释放暂存结果")
+	}
+}
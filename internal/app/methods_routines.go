@@ -0,0 +1,82 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/utils"
+)
+
+func (a *App) routineBrowser(runConfig connection.ConnectionConfig) (db.RoutineBrowser, error) {
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return nil, err
+	}
+	browser, ok := dbInst.(db.RoutineBrowser)
+	if !ok {
+		return nil, fmt.Errorf("当前数据源（%s）不支持浏览存储过程与函数", resolveDDLDBType(runConfig))
+	}
+	return browser, nil
+}
+
+// GetRoutines 列出库中的存储过程与函数（不含参数与定义）。PostgreSQL 的 ID 为 oid，用于区分同名重载。
+func (a *App) GetRoutines(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	browser, err := a.routineBrowser(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	routines, err := browser.GetRoutines(dbName)
+	if err != nil {
+		logger.Error(err, "获取存储过程列表失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Data: routines}
+}
+
+// GetRoutineDefinition 返回存储过程或函数的参数列表与定义文本。
+func (a *App) GetRoutineDefinition(config connection.ConnectionConfig, dbName string, routine connection.RoutineDefinition) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	browser, err := a.routineBrowser(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	def, err := browser.GetRoutineDefinition(dbName, routine)
+	if err != nil {
+		logger.Error(err, "获取存储过程定义失败：%s 名称=%s", formatConnSummary(runConfig), routine.Name)
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Data: def}
+}
+
+// CallRoutine 调用存储过程或函数，返回全部结果集、OUT/INOUT 参数与返回值。
+// 存储过程可能修改数据，只读连接上拒绝执行。
+func (a *App) CallRoutine(config connection.ConnectionConfig, dbName string, call connection.RoutineCall) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	if err := ensureWritable(runConfig, "调用存储过程或函数"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	browser, err := a.routineBrowser(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	timeoutSeconds := runConfig.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 30
+	}
+	ctx, cancel := utils.ContextWithTimeout(time.Duration(timeoutSeconds) * time.Second)
+	defer cancel()
+
+	started := time.Now()
+	result, err := browser.CallRoutine(ctx, dbName, call)
+	a.wireLog.record(runConfig, dbName, "exec", result.SQL, started, 0, err)
+	if err != nil {
+		logger.Error(err, "调用存储过程失败：%s 名称=%s", formatConnSummary(runConfig), call.Routine.Name)
+		a.recentErrors.record(recentErrorKindQuery, runConfig, dbName, err, result.SQL)
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err), Data: result}
+	}
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("执行完成，返回 %d 个结果集", len(result.ResultSets)), Data: result}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type routineFakeDB struct {
+	db.Database
+	calls []connection.RoutineCall
+}
+
+func (f *routineFakeDB) GetRoutines(dbName string) ([]connection.RoutineDefinition, error) {
+	return []connection.RoutineDefinition{{ID: "calc", Schema: dbName, Name: "calc", Type: "PROCEDURE"}}, nil
+}
+
+func (f *routineFakeDB) GetRoutineDefinition(dbName string, routine connection.RoutineDefinition) (connection.RoutineDefinition, error) {
+	return routine, nil
+}
+
+func (f *routineFakeDB) CallRoutine(ctx context.Context, dbName string, call connection.RoutineCall) (connection.RoutineCallResult, error) {
+	f.calls = append(f.calls, call)
+	return connection.RoutineCallResult{SQL: "CALL `shop`.`calc`(?)", OutParams: map[string]interface{}{"total": int64(3)}}, nil
+}
+
+func TestCallRoutine(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root", Database: "shop"}
+	fake := &routineFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	res := a.GetRoutines(config, "shop")
+	if routines, ok := res.Data.([]connection.RoutineDefinition); !res.Success || !ok || len(routines) != 1 || routines[0].Schema != "shop" {
+		t.Fatalf("res = %+v", res)
+	}
+	res = a.CallRoutine(config, "shop", connection.RoutineCall{Routine: connection.RoutineDefinition{Name: "calc"}, Args: map[string]interface{}{"a": 1}})
+	if out, ok := res.Data.(connection.RoutineCallResult); !res.Success || !ok || out.OutParams["total"] != int64(3) || len(fake.calls) != 1 {
+		t.Fatalf("res = %+v", res)
+	}
+
+	config.ReadOnly = true
+	if res := a.CallRoutine(config, "shop", connection.RoutineCall{Routine: connection.RoutineDefinition{Name: "calc"}}); res.Success || !strings.Contains(res.Message, "只读") || len(fake.calls) != 1 {
+		t.Fatalf("read-only res = %+v", res)
+	}
+}
+
+func TestGetRoutinesUnsupported(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "redis", Host: "127.0.0.1", Port: 6379}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "")))] = cachedDatabase{inst: &affectedPreviewFakeDB{}, lastPing: time.Now()}
+	if res := a.GetRoutines(config, ""); res.Success || !strings.Contains(res.Message, "不支持浏览存储过程") {
+		t.Fatalf("res = %+v", res)
+	}
+}
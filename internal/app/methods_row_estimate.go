@@ -0,0 +1,241 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	rowEstimateDefaultThreshold = 100000
+	rowEstimateDefaultLimit     = 1000
+)
+
+var (
+	pgExplainRowsPattern  = regexp.MustCompile(`rows=(\d+)`)
+	rowEstimateAggregates = map[string]struct{}{"COUNT": {}, "SUM": {}, "AVG": {}, "MIN": {}, "MAX": {}}
+)
+
+// RowEstimate 查询返回行数估算结果。Warn 为 true 时建议提示用户并提供 SuggestedQuery（自动追加行数限制）。
+type RowEstimate struct {
+	Bounded        bool     `json:"bounded"`          // 语句自身已限制行数（LIMIT/TOP/FETCH/ROWNUM 或纯聚合）
+	Estimated      int64    `json:"estimated"`        // 估算行数，-1 表示无法估算
+	Source         string   `json:"source,omitempty"` // explain/table_stats/count
+	Tables         []string `json:"tables,omitempty"`
+	Threshold      int64    `json:"threshold"`
+	Warn           bool     `json:"warn"`
+	SuggestedQuery string   `json:"suggestedQuery,omitempty"`
+	SuggestedLimit int      `json:"suggestedLimit,omitempty"`
+}
+
+// EstimateQueryRows 在执行无行数限制的 SELECT 前估算返回行数：优先使用 EXPLAIN 估算，
+// 否则读取表统计信息。超过 threshold（默认 10 万）时返回带 LIMIT 的建议语句，避免误触发全表扫描。
+func (a *App) EstimateQueryRows(config connection.ConnectionConfig, dbName string, query string, threshold int64, limit int) connection.QueryResult {
+	query = strings.TrimSpace(query)
+	for strings.HasSuffix(query, ";") {
+		query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+	}
+	if query == "" {
+		return connection.QueryResult{Success: false, Message: "查询语句不能为空"}
+	}
+	if threshold <= 0 {
+		threshold = rowEstimateDefaultThreshold
+	}
+	if limit <= 0 {
+		limit = rowEstimateDefaultLimit
+	}
+
+	runConfig := normalizeRunConfig(config, dbName)
+	dbType := resolveDDLDBType(runConfig)
+	estimate := RowEstimate{Estimated: -1, Threshold: threshold}
+	tokens := tokenizeSQL(query)
+	if len(tokens) == 0 || tokens[0].upper != "SELECT" {
+		estimate.Bounded = true
+		return connection.QueryResult{Success: true, Data: estimate}
+	}
+	if isRowBoundedSelect(tokens) {
+		estimate.Bounded = true
+		return connection.QueryResult{Success: true, Data: estimate}
+	}
+
+	parsed := parseIndexAdvisorQuery(query)
+	for _, ref := range parsed.tables {
+		estimate.Tables = append(estimate.Tables, joinSchemaTable(ref))
+	}
+	if len(estimate.Tables) == 0 {
+		estimate.Bounded = true
+		return connection.QueryResult{Success: true, Data: estimate}
+	}
+
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	estimate.Estimated, estimate.Source = estimateRowsByExplain(dbInst, dbType, query)
+	if estimate.Estimated < 0 {
+		estimate.Estimated, estimate.Source = estimateRowsByTableStats(dbInst, dbType, dbName, estimate.Tables)
+	}
+	if estimate.Estimated >= threshold {
+		estimate.Warn = true
+		estimate.SuggestedLimit = limit
+		estimate.SuggestedQuery = applyRowLimit(dbType, query, limit)
+		logger.Infof("查询估算行数超过阈值：%s 估算=%d 来源=%s SQL片段=%q", formatConnSummary(runConfig), estimate.Estimated, estimate.Source, sqlSnippet(query))
+	}
+
+	message := ""
+	if estimate.Warn {
+		message = fmt.Sprintf("预计返回约 %d 行，建议限制为 %d 行", estimate.Estimated, limit)
+	}
+	return connection.QueryResult{Success: true, Message: message, Data: estimate}
+}
+
+// isRowBoundedSelect 判断语句外层是否已限制行数，或为不含 GROUP BY 的纯聚合查询。
+func isRowBoundedSelect(tokens []sqlToken) bool {
+	depth := 0
+	hasFrom := false
+	hasGroup := false
+	var selectList [][]sqlToken
+	var item []sqlToken
+	inSelectList := true
+	for i, tok := range tokens {
+		switch tok.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+		if depth != 0 || tok.kind != sqlTokenIdent {
+			if inSelectList && i > 0 {
+				if depth == 0 && tok.text == "," {
+					selectList = append(selectList, item)
+					item = nil
+				} else {
+					item = append(item, tok)
+				}
+			}
+			continue
+		}
+		switch tok.upper {
+		case "LIMIT", "TOP", "FETCH", "ROWNUM":
+			return true
+		case "FROM":
+			hasFrom = true
+			if inSelectList {
+				selectList = append(selectList, item)
+				inSelectList = false
+			}
+		case "GROUP":
+			hasGroup = true
+		case "UNION", "INTERSECT", "EXCEPT":
+			return false
+		}
+		if inSelectList && i > 0 {
+			item = append(item, tok)
+		}
+	}
+	if !hasFrom {
+		return true
+	}
+	if hasGroup || len(selectList) == 0 {
+		return false
+	}
+	for _, it := range selectList {
+		if len(it) < 2 || it[1].text != "(" {
+			return false
+		}
+		if _, ok := rowEstimateAggregates[it[0].upper]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// estimateRowsByExplain 读取优化器估算行数；不支持或失败时返回 -1。
+func estimateRowsByExplain(dbInst db.Database, dbType string, query string) (int64, string) {
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		data, _, err := dbInst.Query("EXPLAIN " + query)
+		if err != nil || len(data) == 0 {
+			return -1, ""
+		}
+		var maxRows int64 = -1
+		for _, row := range data {
+			for key, value := range row {
+				if !strings.EqualFold(key, "rows") || value == nil {
+					continue
+				}
+				if n, err := strconv.ParseInt(strings.TrimSpace(fmt.Sprintf("%v", value)), 10, 64); err == nil && n > maxRows {
+					maxRows = n
+				}
+			}
+		}
+		return maxRows, "explain"
+	case "postgres", "kingbase", "highgo", "vastbase":
+		data, columns, err := dbInst.Query("EXPLAIN " + query)
+		if err != nil || len(data) == 0 || len(columns) == 0 {
+			return -1, ""
+		}
+		// 首行为最外层计划节点，其 rows 即最终返回行数估算
+		match := pgExplainRowsPattern.FindStringSubmatch(fmt.Sprintf("%v", data[0][columns[0]]))
+		if match == nil {
+			return -1, ""
+		}
+		n, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return -1, ""
+		}
+		return n, "explain"
+	default:
+		return -1, ""
+	}
+}
+
+// estimateRowsByTableStats 读取表统计行数，多表时取最大值（不考虑过滤条件，偏保守）。
+func estimateRowsByTableStats(dbInst db.Database, dbType string, dbName string, tables []string) (int64, string) {
+	var maxRows int64 = -1
+	source := "table_stats"
+	for _, table := range tables {
+		schemaName, pureTableName := normalizeSchemaAndTableByType(dbType, dbName, table)
+		var query string
+		switch dbType {
+		case "mysql", "mariadb", "diros":
+			query = fmt.Sprintf("SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = %s AND TABLE_NAME = %s",
+				dialect.QuoteLiteral(dbType, schemaName), dialect.QuoteLiteral(dbType, pureTableName))
+		case "postgres", "kingbase", "highgo", "vastbase":
+			query = fmt.Sprintf("SELECT c.reltuples::bigint FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = %s AND c.relname = %s",
+				dialect.QuoteLiteral(dbType, schemaName), dialect.QuoteLiteral(dbType, pureTableName))
+		case "sqlserver":
+			query = fmt.Sprintf("SELECT SUM(p.rows) FROM sys.partitions p JOIN sys.tables t ON t.object_id = p.object_id JOIN sys.schemas s ON s.schema_id = t.schema_id WHERE p.index_id IN (0, 1) AND s.name = %s AND t.name = %s",
+				dialect.QuoteLiteral(dbType, schemaName), dialect.QuoteLiteral(dbType, pureTableName))
+		case "oracle", "dameng":
+			query = fmt.Sprintf("SELECT NUM_ROWS FROM ALL_TABLES WHERE OWNER = %s AND TABLE_NAME = %s",
+				dialect.QuoteLiteral(dbType, strings.ToUpper(schemaName)), dialect.QuoteLiteral(dbType, strings.ToUpper(pureTableName)))
+		default:
+			// 本地文件库（SQLite/DuckDB 等）直接计数
+			query = fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteTableIdentByType(dbType, schemaName, pureTableName))
+			source = "count"
+		}
+		text := queryFirstString(dbInst, query)
+		if text == "" {
+			continue
+		}
+		if f, err := strconv.ParseFloat(text, 64); err == nil && int64(f) > maxRows {
+			maxRows = int64(f)
+		}
+	}
+	if maxRows < 0 {
+		return -1, ""
+	}
+	return maxRows, source
+}
+
+// applyRowLimit 为语句追加返回行数限制。
+func applyRowLimit(dbType string, query string, limit int) string {
+	return dialect.LimitQuery(dbType, query, limit)
+}
@@ -0,0 +1,38 @@
+package app
+
+import "testing"
+
+func TestIsRowBoundedSelect(t *testing.T) {
+	cases := []struct {
+		query   string
+		bounded bool
+	}{
+		{"SELECT * FROM orders", false},
+		{"SELECT * FROM orders LIMIT 10", true},
+		{"SELECT TOP 10 * FROM orders", true},
+		{"SELECT * FROM orders FETCH FIRST 10 ROWS ONLY", true},
+		{"SELECT * FROM orders WHERE ROWNUM <= 10", true},
+		{"SELECT COUNT(*) FROM orders", true},
+		{"SELECT COUNT(*), MAX(id) FROM orders", true},
+		{"SELECT status, COUNT(*) FROM orders GROUP BY status", false},
+		{"SELECT * FROM (SELECT * FROM orders LIMIT 10) t", false},
+		{"SELECT 1", true},
+	}
+	for _, tc := range cases {
+		if got := isRowBoundedSelect(tokenizeSQL(tc.query)); got != tc.bounded {
+			t.Errorf("isRowBoundedSelect(%q) = %v, want %v", tc.query, got, tc.bounded)
+		}
+	}
+}
+
+func TestApplyRowLimit(t *testing.T) {
+	if got := applyRowLimit("mysql", "SELECT * FROM t", 100); got != "SELECT * FROM t LIMIT 100" {
+		t.Errorf("mysql: %s", got)
+	}
+	if got := applyRowLimit("sqlserver", "SELECT * FROM t", 100); got != "SELECT TOP 100 * FROM (SELECT * FROM t) AS limited_result" {
+		t.Errorf("sqlserver: %s", got)
+	}
+	if got := applyRowLimit("oracle", "SELECT * FROM t", 100); got != "SELECT * FROM (SELECT * FROM t) WHERE ROWNUM <= 100" {
+		t.Errorf("oracle: %s", got)
+	}
+}
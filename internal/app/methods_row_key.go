@@ -0,0 +1,148 @@
+package app
+
+import (
+	"sort"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+)
+
+// RowKey 描述表格编辑时用于定位行的列，前端以这些列的原值作为 ChangeSet 的 Keys。
+type RowKey struct {
+	Columns   []string `json:"columns"`
+	Source    string   `json:"source"` // primary / unique / rowid / none
+	IndexName string   `json:"indexName,omitempty"`
+	// Pseudo 为 true 时 Columns 是数据库伪列（ctid/rowid），查询数据时需要显式选出
+	Pseudo  bool   `json:"pseudo,omitempty"`
+	Warning string `json:"warning,omitempty"`
+}
+
+const (
+	rowKeySourcePrimary = "primary"
+	rowKeySourceUnique  = "unique"
+	rowKeySourceRowID   = "rowid"
+	rowKeySourceNone    = "none"
+)
+
+// GetPrimaryKey 返回编辑表格数据时可用作行定位的列：依次尝试主键、非空唯一索引、数据库伪列（PostgreSQL 系的 ctid、SQLite 的 rowid）。
+func (a *App) GetPrimaryKey(config connection.ConnectionConfig, dbName string, tableName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	dbType := resolveDDLDBType(runConfig)
+	var key RowKey
+	warning, err := a.runWithReconnect(runConfig, dbInst, "GetPrimaryKey", func(inst db.Database) (getErr error) {
+		key, getErr = detectRowKey(inst, dbType, schemaName, pureTableName)
+		return getErr
+	})
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	return connection.QueryResult{Success: true, Message: warning, Data: key}
+}
+
+func detectRowKey(dbInst db.Database, dbType string, schemaName string, tableName string) (RowKey, error) {
+	defs, err := dbInst.GetColumns(schemaName, tableName)
+	if err != nil {
+		return RowKey{}, err
+	}
+	var primaryKey []string
+	for _, def := range defs {
+		if strings.EqualFold(def.Key, "PRI") {
+			primaryKey = append(primaryKey, def.Name)
+		}
+	}
+
+	// 部分数据库不提供索引信息，读取失败时视为没有
+	indexes, _ := dbInst.GetIndexes(schemaName, tableName)
+	if len(primaryKey) > 0 {
+		// 复合主键按索引中的列顺序返回
+		for _, index := range groupIndexDefinitions(indexes) {
+			if index.unique && equalFoldNameSet(index.columns, primaryKey) {
+				primaryKey = index.columns
+				break
+			}
+		}
+		return RowKey{Columns: primaryKey, Source: rowKeySourcePrimary}, nil
+	}
+	for _, index := range groupIndexDefinitions(indexes) {
+		lower := strings.ToLower(index.name)
+		if index.unique && (lower == "primary" || strings.HasSuffix(lower, "_pkey") || strings.HasPrefix(lower, "pk_")) {
+			return RowKey{Columns: index.columns, Source: rowKeySourcePrimary, IndexName: index.name}, nil
+		}
+	}
+
+	if key, ok := uniqueRowKey(indexes, defs); ok {
+		return key, nil
+	}
+
+	switch dialect.Normalize(dbType) {
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return RowKey{
+			Columns: []string{"ctid"},
+			Source:  rowKeySourceRowID,
+			Pseudo:  true,
+			Warning: "表没有主键或唯一索引，将使用 ctid 定位行；ctid 会在行更新或 VACUUM FULL 后变化，编辑前请先刷新数据",
+		}, nil
+	case "sqlite":
+		createSQL, _ := dbInst.GetCreateStatement(schemaName, tableName)
+		if !strings.Contains(strings.ToUpper(strings.Join(strings.Fields(createSQL), " ")), "WITHOUT ROWID") {
+			return RowKey{Columns: []string{"rowid"}, Source: rowKeySourceRowID, Pseudo: true}, nil
+		}
+	}
+
+	return RowKey{
+		Columns: []string{},
+		Source:  rowKeySourceNone,
+		Warning: "表没有主键或唯一索引，无法安全地定位单行，修改或删除可能影响多行",
+	}, nil
+}
+
+// uniqueRowKey 选择列数最少的唯一索引，优先全部列非空的索引；可空列上的唯一索引允许多行同为 NULL，只在没有更好选择时使用并给出提示。
+func uniqueRowKey(indexes []connection.IndexDefinition, defs []connection.ColumnDefinition) (RowKey, bool) {
+	nullable := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		nullable[strings.ToLower(def.Name)] = !strings.EqualFold(strings.TrimSpace(def.Nullable), "NO")
+	}
+	var candidates []schemaIndex
+	for _, index := range groupIndexDefinitions(indexes) {
+		if index.unique {
+			candidates = append(candidates, index)
+		}
+	}
+	if len(candidates) == 0 {
+		return RowKey{}, false
+	}
+	hasNullable := func(index schemaIndex) bool {
+		for _, col := range index.columns {
+			if nullable[strings.ToLower(col)] {
+				return true
+			}
+		}
+		return false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ni, nj := hasNullable(candidates[i]), hasNullable(candidates[j])
+		if ni != nj {
+			return !ni
+		}
+		if len(candidates[i].columns) != len(candidates[j].columns) {
+			return len(candidates[i].columns) < len(candidates[j].columns)
+		}
+		return strings.ToLower(candidates[i].name) < strings.ToLower(candidates[j].name)
+	})
+	best := candidates[0]
+	key := RowKey{Columns: best.columns, Source: rowKeySourceUnique, IndexName: best.name}
+	if hasNullable(best) {
+		key.Warning = "唯一索引 " + best.name + " 包含可空列，值为 NULL 的行无法唯一定位"
+	}
+	return key, true
+}
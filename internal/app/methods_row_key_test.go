@@ -0,0 +1,119 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type rowKeyFakeDB struct {
+	db.Database
+	columns   []connection.ColumnDefinition
+	indexes   []connection.IndexDefinition
+	createSQL string
+}
+
+func (f *rowKeyFakeDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	return f.columns, nil
+}
+
+func (f *rowKeyFakeDB) GetIndexes(dbName, tableName string) ([]connection.IndexDefinition, error) {
+	return f.indexes, nil
+}
+
+func (f *rowKeyFakeDB) GetCreateStatement(dbName, tableName string) (string, error) {
+	return f.createSQL, nil
+}
+
+func TestDetectRowKey(t *testing.T) {
+	cases := []struct {
+		name   string
+		dbType string
+		fake   *rowKeyFakeDB
+		want   RowKey
+	}{
+		{
+			name:   "composite primary key follows index order",
+			dbType: "mysql",
+			fake: &rowKeyFakeDB{
+				columns: []connection.ColumnDefinition{{Name: "a", Key: "PRI"}, {Name: "b", Key: "PRI"}},
+				indexes: []connection.IndexDefinition{
+					{Name: "PRIMARY", ColumnName: "a", SeqInIndex: 2},
+					{Name: "PRIMARY", ColumnName: "b", SeqInIndex: 1},
+				},
+			},
+			want: RowKey{Columns: []string{"b", "a"}, Source: rowKeySourcePrimary},
+		},
+		{
+			name:   "primary key from index name",
+			dbType: "postgres",
+			fake: &rowKeyFakeDB{
+				columns: []connection.ColumnDefinition{{Name: "id"}},
+				indexes: []connection.IndexDefinition{{Name: "users_pkey", ColumnName: "id", SeqInIndex: 1}},
+			},
+			want: RowKey{Columns: []string{"id"}, Source: rowKeySourcePrimary, IndexName: "users_pkey"},
+		},
+		{
+			name:   "not null unique index preferred",
+			dbType: "mysql",
+			fake: &rowKeyFakeDB{
+				columns: []connection.ColumnDefinition{{Name: "email", Nullable: "YES"}, {Name: "code", Nullable: "NO"}, {Name: "region", Nullable: "NO"}},
+				indexes: []connection.IndexDefinition{
+					{Name: "uk_email", ColumnName: "email", SeqInIndex: 1},
+					{Name: "uk_code", ColumnName: "region", SeqInIndex: 1},
+					{Name: "uk_code", ColumnName: "code", SeqInIndex: 2},
+					{Name: "idx_region", ColumnName: "region", SeqInIndex: 1, NonUnique: 1},
+				},
+			},
+			want: RowKey{Columns: []string{"region", "code"}, Source: rowKeySourceUnique, IndexName: "uk_code"},
+		},
+		{
+			name:   "sqlite rowid",
+			dbType: "sqlite",
+			fake:   &rowKeyFakeDB{columns: []connection.ColumnDefinition{{Name: "v"}}, createSQL: "CREATE TABLE t (v TEXT)"},
+			want:   RowKey{Columns: []string{"rowid"}, Source: rowKeySourceRowID, Pseudo: true},
+		},
+	}
+	for _, c := range cases {
+		got, err := detectRowKey(c.fake, c.dbType, "", "t")
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %+v, want %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestDetectRowKeyFallbacks(t *testing.T) {
+	key, _ := detectRowKey(&rowKeyFakeDB{columns: []connection.ColumnDefinition{{Name: "v"}}}, "kingbase", "public", "t")
+	if key.Source != rowKeySourceRowID || !key.Pseudo || key.Columns[0] != "ctid" || key.Warning == "" {
+		t.Fatalf("expected ctid fallback, got %+v", key)
+	}
+
+	withoutRowID := &rowKeyFakeDB{columns: []connection.ColumnDefinition{{Name: "v"}}, createSQL: "CREATE TABLE t (v TEXT) without  rowid"}
+	key, _ = detectRowKey(withoutRowID, "sqlite", "", "t")
+	if key.Source != rowKeySourceNone || len(key.Columns) != 0 || key.Warning == "" {
+		t.Fatalf("expected no key for WITHOUT ROWID table, got %+v", key)
+	}
+
+	plainIndex := &rowKeyFakeDB{
+		columns: []connection.ColumnDefinition{{Name: "v"}},
+		indexes: []connection.IndexDefinition{{Name: "pk_lookup", ColumnName: "v", SeqInIndex: 1, NonUnique: 1}},
+	}
+	key, _ = detectRowKey(plainIndex, "mysql", "", "t")
+	if key.Source != rowKeySourceNone {
+		t.Fatalf("expected non-unique pk_ index to be ignored, got %+v", key)
+	}
+
+	nullableUnique := &rowKeyFakeDB{
+		columns: []connection.ColumnDefinition{{Name: "email", Nullable: "YES"}},
+		indexes: []connection.IndexDefinition{{Name: "uk_email", ColumnName: "email", SeqInIndex: 1}},
+	}
+	key, _ = detectRowKey(nullableUnique, "mysql", "", "t")
+	if key.Source != rowKeySourceUnique || key.Warning == "" {
+		t.Fatalf("expected nullable unique key with warning, got %+v", key)
+	}
+}
@@ -0,0 +1,207 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/utils"
+)
+
+const (
+	sandboxDefaultSchema = "gonavi_sandbox"
+	sandboxDefaultRows   = 1000
+	sandboxMaxRows       = 100000
+	sandboxTimeout       = 10 * time.Minute
+)
+
+// SandboxOptions 创建沙箱副本的参数。
+type SandboxOptions struct {
+	Schema     string `json:"schema,omitempty"`     // 沙箱所在 schema/库，留空为 gonavi_sandbox
+	TableName  string `json:"tableName,omitempty"`  // 留空沿用源表名（沙箱与源表同 schema 时加 _sandbox 后缀）
+	SampleRows int    `json:"sampleRows,omitempty"` // 复制的行数上限，<=0 取默认值
+	Random     bool   `json:"random,omitempty"`     // 随机抽样，否则按表的自然顺序取前 N 行
+	Replace    bool   `json:"replace,omitempty"`    // 沙箱表已存在时先删除
+}
+
+// SandboxReport 沙箱副本的创建结果。
+type SandboxReport struct {
+	Source     string   `json:"source"`
+	Table      string   `json:"table"` // 沙箱表的限定名（schema.table）
+	Rows       int64    `json:"rows"`
+	SchemaSQL  string   `json:"schemaSql,omitempty"`
+	CreateSQL  string   `json:"createSql"`
+	CopySQL    string   `json:"copySql"`
+	Warnings   []string `json:"warnings,omitempty"`
+	DurationMs int64    `json:"durationMs"`
+}
+
+// CreateSandboxTable 在同一连接的独立 schema 中复制表结构并抽样复制部分数据，
+// 供用户在接近真实的数据上演练 UPDATE/DELETE 等破坏性语句而不触碰源表。
+// 沙箱表只保留列类型、非空与主键（同 TransferTables 建表规则），不复制索引、外键、触发器与自增属性。
+func (a *App) CreateSandboxTable(config connection.ConnectionConfig, dbName string, tableName string, options SandboxOptions) connection.QueryResult {
+	if strings.TrimSpace(tableName) == "" {
+		return connection.QueryResult{Success: false, Message: "表名不能为空"}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	if err := ensureWritable(runConfig, "创建沙箱副本"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+	sampleRows := options.SampleRows
+	if sampleRows <= 0 {
+		sampleRows = sandboxDefaultRows
+	}
+	if sampleRows > sandboxMaxRows {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("抽样行数不能超过 %d", sandboxMaxRows)}
+	}
+
+	sourceSchema, sourceTable := normalizeSchemaAndTable(runConfig, dbName, tableName)
+	report := SandboxReport{Source: qualifyTable(sourceSchema, sourceTable)}
+	sandboxSchema := strings.TrimSpace(options.Schema)
+	if sandboxSchema == "" {
+		sandboxSchema = sandboxDefaultSchema
+	}
+	schemaSQL, separate := sandboxSchemaSQL(dbType, sandboxSchema)
+	if !separate {
+		if strings.TrimSpace(options.Schema) != "" {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s 不支持在当前连接中创建独立 schema，沙箱表将创建在源表所在位置", dbType))
+		}
+		sandboxSchema = sourceSchema
+	}
+	sandboxTable := strings.TrimSpace(options.TableName)
+	if sandboxTable == "" {
+		sandboxTable = sourceTable
+		if strings.EqualFold(sandboxSchema, sourceSchema) {
+			sandboxTable += "_sandbox"
+			if dbType == "oracle" || dbType == "dameng" {
+				sandboxTable = strings.ToUpper(sandboxTable)
+			}
+		}
+	}
+	report.Table = qualifyTable(sandboxSchema, sandboxTable)
+	if strings.EqualFold(report.Table, report.Source) {
+		return connection.QueryResult{Success: false, Message: "沙箱表不能与源表相同"}
+	}
+
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	columns, err := dbInst.GetColumns(sourceSchema, sourceTable)
+	if err != nil || len(columns) == 0 {
+		if err == nil {
+			err = fmt.Errorf("源表没有列")
+		}
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("读取源表字段失败：%s", normalizeErrorMessage(err))}
+	}
+	createSQL, warnings, err := buildTransferCreateSQL(dbType, dbType, report.Table, columns)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	report.CreateSQL = createSQL
+	report.Warnings = append(report.Warnings, warnings...)
+	copyColumns := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if strings.TrimSpace(col.Generated) == "" {
+			copyColumns = append(copyColumns, col.Name)
+		}
+	}
+	report.CopySQL = buildSandboxCopySQL(dbType, quoteTableIdentByType(dbType, sourceSchema, sourceTable), quoteQualifiedIdentByType(dbType, report.Table), copyColumns, sampleRows, options.Random)
+
+	ctx, cancel := utils.ContextWithTimeout(sandboxTimeout)
+	defer cancel()
+	exec := databaseExecFunc(dbInst)
+	start := time.Now()
+	if separate {
+		report.SchemaSQL = schemaSQL
+		if _, err := exec(ctx, schemaSQL); err != nil {
+			logger.Error(err, "创建沙箱 schema 失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(schemaSQL))
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("创建沙箱 schema 失败：%s", normalizeErrorMessage(err))}
+		}
+	}
+	if options.Replace {
+		dropSQL := "DROP TABLE IF EXISTS " + quoteQualifiedIdentByType(dbType, report.Table)
+		if dbType == "oracle" || dbType == "dameng" {
+			dropSQL = "DROP TABLE " + quoteQualifiedIdentByType(dbType, report.Table)
+		}
+		// Oracle 没有 IF EXISTS，表不存在时的报错忽略即可
+		if _, err := exec(ctx, dropSQL); err != nil && dbType != "oracle" && dbType != "dameng" {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("删除已有沙箱表失败：%s", normalizeErrorMessage(err))}
+		}
+	}
+	if _, err := exec(ctx, report.CreateSQL); err != nil {
+		logger.Error(err, "创建沙箱表失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(report.CreateSQL))
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("创建沙箱表失败：%s", normalizeErrorMessage(err))}
+	}
+	report.Rows, err = exec(ctx, report.CopySQL)
+	report.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		logger.Error(err, "沙箱表复制数据失败，删除已创建的表：%s 表=%s", formatConnSummary(runConfig), report.Table)
+		if _, dropErr := exec(ctx, "DROP TABLE "+quoteQualifiedIdentByType(dbType, report.Table)); dropErr != nil {
+			logger.Error(dropErr, "删除未写完的沙箱表失败：表=%s", report.Table)
+		}
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("复制抽样数据失败：%s", normalizeErrorMessage(err))}
+	}
+
+	logger.Infof("已创建沙箱副本：%s 源表=%s 沙箱表=%s 行数=%d 耗时=%dms", formatConnSummary(runConfig), report.Source, report.Table, report.Rows, report.DurationMs)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已创建沙箱表 %s 并复制 %d 行", report.Table, report.Rows), Data: report}
+}
+
+// sandboxSchemaSQL 返回创建沙箱 schema 的语句；第二个返回值为 false 表示该库不便单独建 schema
+// （SQLite 只有附加库，Oracle/达梦的 schema 即用户），此时沙箱表与源表放在一起。
+func sandboxSchemaSQL(dbType string, schema string) (string, bool) {
+	switch dbType {
+	case "mysql", "mariadb", "diros", "clickhouse":
+		return "CREATE DATABASE IF NOT EXISTS " + quoteIdentByType(dbType, schema), true
+	case "postgres", "kingbase", "highgo", "vastbase", "duckdb":
+		return "CREATE SCHEMA IF NOT EXISTS " + quoteIdentByType(dbType, schema), true
+	case "sqlserver":
+		return fmt.Sprintf("IF SCHEMA_ID(N'%s') IS NULL EXEC('CREATE SCHEMA %s')",
+			strings.ReplaceAll(schema, "'", "''"), strings.ReplaceAll(quoteIdentByType(dbType, schema), "'", "''")), true
+	}
+	return "", false
+}
+
+// buildSandboxCopySQL 生成 INSERT ... SELECT 抽样语句，数据在服务端复制，不经过客户端。
+func buildSandboxCopySQL(dbType string, quotedSource string, quotedTarget string, columns []string, limit int, random bool) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = quoteIdentByType(dbType, col)
+	}
+	colList := strings.Join(quoted, ", ")
+	insert := fmt.Sprintf("INSERT INTO %s (%s) ", quotedTarget, colList)
+
+	switch dbType {
+	case "sqlserver":
+		sql := fmt.Sprintf("%sSELECT TOP (%d) %s FROM %s", insert, limit, colList, quotedSource)
+		if random {
+			sql += " ORDER BY NEWID()"
+		}
+		return sql
+	case "oracle", "dameng":
+		if !random {
+			return fmt.Sprintf("%sSELECT %s FROM %s WHERE ROWNUM <= %d", insert, colList, quotedSource, limit)
+		}
+		order := "DBMS_RANDOM.VALUE"
+		if dbType == "dameng" {
+			order = "RAND()"
+		}
+		return fmt.Sprintf("%sSELECT %s FROM (SELECT %s FROM %s ORDER BY %s) WHERE ROWNUM <= %d", insert, colList, colList, quotedSource, order, limit)
+	}
+
+	sql := fmt.Sprintf("%sSELECT %s FROM %s", insert, colList, quotedSource)
+	if random {
+		switch dbType {
+		case "mysql", "mariadb", "diros":
+			sql += " ORDER BY RAND()"
+		case "clickhouse":
+			sql += " ORDER BY rand()"
+		default:
+			sql += " ORDER BY RANDOM()"
+		}
+	}
+	return fmt.Sprintf("%s LIMIT %d", sql, limit)
+}
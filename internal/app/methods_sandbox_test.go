@@ -0,0 +1,74 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+type sandboxFakeDB struct {
+	db.Database
+	execs []string
+}
+
+func (f *sandboxFakeDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	return []connection.ColumnDefinition{
+		{Name: "id", Type: "int", Key: "PRI", Nullable: "NO", Identity: "BY DEFAULT"},
+		{Name: "name", Type: "varchar(20)", Nullable: "YES"},
+		{Name: "name_len", Type: "int", Nullable: "YES", Generated: "STORED"},
+	}, nil
+}
+
+func (f *sandboxFakeDB) Exec(query string) (int64, error) {
+	f.execs = append(f.execs, query)
+	if strings.HasPrefix(query, "INSERT") {
+		return 42, nil
+	}
+	return 0, nil
+}
+
+func TestCreateSandboxTableMySQL(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root", Database: "shop"}
+	fake := &sandboxFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(config, "shop")))] = cachedDatabase{inst: fake, lastPing: time.Now()}
+
+	res := a.CreateSandboxTable(config, "shop", "orders", SandboxOptions{SampleRows: 500, Random: true, Replace: true})
+	if !res.Success {
+		t.Fatal(res.Message)
+	}
+	want := []string{
+		"CREATE DATABASE IF NOT EXISTS `gonavi_sandbox`",
+		"DROP TABLE IF EXISTS `gonavi_sandbox`.`orders`",
+		"CREATE TABLE `gonavi_sandbox`.`orders` (`id` int NOT NULL, `name` varchar(20), PRIMARY KEY (`id`))",
+		"INSERT INTO `gonavi_sandbox`.`orders` (`id`, `name`) SELECT `id`, `name` FROM `shop`.`orders` ORDER BY RAND() LIMIT 500",
+	}
+	if strings.Join(fake.execs, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("execs =\n%s", strings.Join(fake.execs, "\n"))
+	}
+	if report := res.Data.(SandboxReport); report.Rows != 42 || report.Table != "gonavi_sandbox.orders" {
+		t.Fatalf("report = %+v", report)
+	}
+
+	config.ReadOnly = true
+	if res := a.CreateSandboxTable(config, "shop", "orders", SandboxOptions{}); res.Success {
+		t.Fatal("read-only connection should be rejected")
+	}
+}
+
+func TestBuildSandboxCopySQL(t *testing.T) {
+	cases := map[string]string{
+		"sqlserver": "INSERT INTO [s].[t] ([id]) SELECT TOP (10) [id] FROM [dbo].[t] ORDER BY NEWID()",
+		"oracle":    `INSERT INTO "s"."t" ("id") SELECT "id" FROM (SELECT "id" FROM "dbo"."t" ORDER BY DBMS_RANDOM.VALUE) WHERE ROWNUM <= 10`,
+		"postgres":  `INSERT INTO "s"."t" ("id") SELECT "id" FROM "dbo"."t" ORDER BY RANDOM() LIMIT 10`,
+	}
+	for dbType, want := range cases {
+		got := buildSandboxCopySQL(dbType, quoteTableIdentByType(dbType, "dbo", "t"), quoteQualifiedIdentByType(dbType, "s.t"), []string{"id"}, 10, true)
+		if got != want {
+			t.Errorf("%s: got %s", dbType, got)
+		}
+	}
+}
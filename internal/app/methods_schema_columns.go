@@ -0,0 +1,97 @@
+package app
+
+import (
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+// SchemaColumns 供编辑器补全使用：DefaultSchema 为当前会话默认 schema，补全该 schema 下的表时可省略前缀，
+// 其余 schema 的表应插入 schema.table。
+type SchemaColumns struct {
+	DefaultSchema string                                 `json:"defaultSchema"`
+	Columns       []connection.ColumnDefinitionWithTable `json:"columns"`
+}
+
+// defaultSchemaQuery 返回查询当前默认 schema 的语句；无 schema 层级的数据库返回空。
+func defaultSchemaQuery(dbType string) string {
+	switch dbType {
+	case "postgres", "kingbase", "highgo", "vastbase", "duckdb":
+		return "SELECT current_schema()"
+	case "sqlserver":
+		return "SELECT SCHEMA_NAME()"
+	case "oracle", "dameng":
+		return "SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL"
+	}
+	return ""
+}
+
+// columnSchema 取列所属 schema：优先使用驱动填充的 Schema 字段，否则从 schema.table 形式的表名拆分。
+func columnSchema(col connection.ColumnDefinitionWithTable) string {
+	if col.Schema != "" {
+		return col.Schema
+	}
+	if idx := strings.Index(col.TableName, "."); idx > 0 {
+		return col.TableName[:idx]
+	}
+	return ""
+}
+
+// fillColumnSchema 为未填充 Schema/Table 的驱动结果补齐字段。
+func fillColumnSchema(cols []connection.ColumnDefinitionWithTable) {
+	for i := range cols {
+		if cols[i].Schema != "" {
+			continue
+		}
+		if idx := strings.Index(cols[i].TableName, "."); idx > 0 {
+			cols[i].Schema = cols[i].TableName[:idx]
+			cols[i].Table = cols[i].TableName[idx+1:]
+		}
+	}
+}
+
+// DBGetAllColumnsBySchema 返回带 schema 限定的列信息及默认 schema 提示；schema 非空时只返回该 schema 的列。
+func (a *App) DBGetAllColumnsBySchema(config connection.ConnectionConfig, dbName string, schema string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	schema = strings.TrimSpace(schema)
+	result := SchemaColumns{}
+	warning, err := a.runWithReconnect(runConfig, dbInst, "DBGetAllColumnsBySchema", func(inst db.Database) (getErr error) {
+		if lister, ok := inst.(db.SchemaColumnsLister); ok {
+			result.Columns, getErr = lister.GetAllColumnsInSchema(dbName, schema)
+			if getErr != nil {
+				return getErr
+			}
+			result.DefaultSchema, _ = lister.GetDefaultSchema()
+			return nil
+		}
+		cols, getErr := inst.GetAllColumns(dbName)
+		if getErr != nil {
+			return getErr
+		}
+		result.Columns = cols[:0]
+		for _, col := range cols {
+			if schema == "" || strings.EqualFold(columnSchema(col), schema) {
+				result.Columns = append(result.Columns, col)
+			}
+		}
+		if query := defaultSchemaQuery(resolveDDLDBType(runConfig)); query != "" {
+			result.DefaultSchema = queryFirstString(inst, query)
+		}
+		return nil
+	})
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	fillColumnSchema(result.Columns)
+	if result.Columns == nil {
+		result.Columns = []connection.ColumnDefinitionWithTable{}
+	}
+	return connection.QueryResult{Success: true, Message: warning, Data: result}
+}
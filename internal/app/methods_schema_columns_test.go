@@ -0,0 +1,34 @@
+package app
+
+import (
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestFillColumnSchemaSplitsQualifiedTableName(t *testing.T) {
+	cols := []connection.ColumnDefinitionWithTable{
+		{TableName: "sales.orders", Name: "id"},
+		{TableName: "users", Name: "id"},
+		{TableName: "dbo.items", Name: "id", Schema: "dbo", Table: "items"},
+	}
+	fillColumnSchema(cols)
+	if cols[0].Schema != "sales" || cols[0].Table != "orders" {
+		t.Fatalf("qualified name not split: %+v", cols[0])
+	}
+	if cols[1].Schema != "" || cols[1].Table != "" {
+		t.Fatalf("bare table name should stay unqualified: %+v", cols[1])
+	}
+	if columnSchema(cols[2]) != "dbo" || columnSchema(connection.ColumnDefinitionWithTable{TableName: "hr.staff"}) != "hr" {
+		t.Fatal("columnSchema should prefer Schema and fall back to the table prefix")
+	}
+}
+
+func TestDefaultSchemaQuery(t *testing.T) {
+	if defaultSchemaQuery("postgres") == "" || defaultSchemaQuery("sqlserver") == "" {
+		t.Fatal("schema-aware databases need a default schema query")
+	}
+	if defaultSchemaQuery("mysql") != "" {
+		t.Fatal("mysql has no schema level")
+	}
+}
@@ -0,0 +1,772 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+// SchemaCompareOptions 结构比较选项。
+type SchemaCompareOptions struct {
+	SourceDB string   `json:"sourceDb"`
+	TargetDB string   `json:"targetDb"`
+	Tables   []string `json:"tables,omitempty"` // 只比较这些源表，留空比较全部表（此时也列出目标端多余的表）
+	// DropExtra 为 true 时为目标端多余的表、列、索引与外键生成删除语句；否则只在差异中列出。
+	DropExtra bool `json:"dropExtra,omitempty"`
+}
+
+// SchemaDiffItem 一项结构差异。
+type SchemaDiffItem struct {
+	Table  string `json:"table"`
+	Kind   string `json:"kind"` // table/column/index/foreignKey/primaryKey
+	Name   string `json:"name,omitempty"`
+	Action string `json:"action"`           // create：目标缺少；drop：目标多余；alter：两端定义不同
+	Source string `json:"source,omitempty"` // 源端定义摘要
+	Target string `json:"target,omitempty"` // 目标端定义摘要
+}
+
+// SchemaCompareResult 结构比较结果与同步脚本。
+type SchemaCompareResult struct {
+	Items           []SchemaDiffItem `json:"items"`
+	Statements      []string         `json:"statements"`
+	Script          string           `json:"script"`
+	Warnings        []string         `json:"warnings,omitempty"`
+	TablesCompared  int              `json:"tablesCompared"`
+	TablesIdentical int              `json:"tablesIdentical"`
+	// Plan 在目标端执行同步脚本的变更计划，通过 ConfirmSQLPlan 执行或复制
+	Plan *SQLPlanPreview `json:"plan,omitempty"`
+}
+
+// schemaTable 比较用的单表结构快照；索引与外键已按名称聚合为多列。
+type schemaTable struct {
+	key         string // 比较用的表名：默认 schema 下为表名，其他 schema 带 schema 前缀
+	schema      string
+	table       string
+	columns     []connection.ColumnDefinition
+	primaryKey  []string
+	indexes     []schemaIndex
+	foreignKeys []schemaForeignKey
+}
+
+type schemaIndex struct {
+	name    string
+	unique  bool
+	columns []string
+}
+
+type schemaForeignKey struct {
+	name       string
+	columns    []string
+	refTable   string
+	refColumns []string
+}
+
+// schemaSide 比较的一端。
+type schemaSide struct {
+	config connection.ConnectionConfig
+	dbType string
+	dbName string
+	inst   db.Database
+}
+
+// schemaSyncPlan 按执行顺序分组收集同步语句：先删外键与索引，再删表、建表、改列，最后建索引与外键，
+// 使被依赖的对象先于依赖它的对象删除、后于依赖它的对象创建。
+type schemaSyncPlan struct {
+	dropForeignKeys []string
+	dropIndexes     []string
+	dropTables      []string
+	createTables    []string
+	alterColumns    []string
+	dropColumns     []string
+	createIndexes   []string
+	addForeignKeys  []string
+}
+
+func (p *schemaSyncPlan) statements() []string {
+	var out []string
+	for _, group := range [][]string{p.dropForeignKeys, p.dropIndexes, p.dropTables, p.createTables, p.alterColumns, p.dropColumns, p.createIndexes, p.addForeignKeys} {
+		out = append(out, group...)
+	}
+	return out
+}
+
+// CompareSchemas 比较两个连接（或同一连接的两个库）中表、列、索引与外键的结构差异，
+// 并生成把目标端同步为源端结构的有序脚本；只生成不执行，脚本登记为变更计划，由用户审阅后通过 ConfirmSQLPlan 执行或复制。
+func (a *App) CompareSchemas(sourceConfig connection.ConnectionConfig, targetConfig connection.ConnectionConfig, options SchemaCompareOptions) connection.QueryResult {
+	source := schemaSide{config: sourceConfig, dbType: resolveDDLDBType(sourceConfig), dbName: strings.TrimSpace(options.SourceDB)}
+	target := schemaSide{config: targetConfig, dbType: resolveDDLDBType(targetConfig), dbName: strings.TrimSpace(options.TargetDB)}
+	if source.dbName == "" {
+		source.dbName = sourceConfig.Database
+	}
+	if target.dbName == "" {
+		target.dbName = targetConfig.Database
+	}
+	for _, side := range []*schemaSide{&source, &target} {
+		runConfig := normalizeRunConfig(side.config, side.dbName)
+		inst, err := a.getDatabase(runConfig)
+		if err != nil {
+			logger.Error(err, "CompareSchemas 获取连接失败：%s", formatConnSummary(runConfig))
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		side.inst = inst
+	}
+
+	sourceTables, err := loadSchemaTables(source, options.Tables)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: "读取源库结构失败：" + normalizeErrorMessage(err)}
+	}
+	targetTables, err := loadSchemaTables(target, nil)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: "读取目标库结构失败：" + normalizeErrorMessage(err)}
+	}
+
+	result := compareSchemaTables(source, target, sourceTables, targetTables, len(options.Tables) == 0, options.DropExtra)
+	result.Plan = a.attachSQLPlan(SQLPlanRequest{Config: targetConfig, DBName: target.dbName, Source: "schema_diff",
+		Title: fmt.Sprintf("同步结构到 %s", formatConnSummary(normalizeRunConfig(targetConfig, target.dbName))), Statements: result.Statements, StopOnError: true}, &result.Warnings)
+	message := fmt.Sprintf("比较 %d 张表，%d 张一致，发现 %d 处差异", result.TablesCompared, result.TablesIdentical, len(result.Items))
+	return connection.QueryResult{Success: true, Message: message, Data: result}
+}
+
+// loadSchemaTables 读取一端的表结构；only 非空时只读取这些表，不存在的表跳过。
+func loadSchemaTables(side schemaSide, only []string) ([]*schemaTable, error) {
+	names := only
+	if len(names) == 0 {
+		all, err := side.inst.GetTables(side.dbName)
+		if err != nil {
+			return nil, err
+		}
+		names = all
+	}
+	defaultSchema, _ := normalizeSchemaAndTable(side.config, side.dbName, "_")
+	tables := make([]*schemaTable, 0, len(names))
+	for _, name := range names {
+		schema, table := normalizeSchemaAndTable(side.config, side.dbName, name)
+		columns, err := side.inst.GetColumns(schema, table)
+		if err != nil || len(columns) == 0 {
+			if len(only) > 0 {
+				continue
+			}
+			if err == nil {
+				err = fmt.Errorf("表 %s 没有字段", name)
+			}
+			return nil, err
+		}
+		key := table
+		if !strings.EqualFold(schema, defaultSchema) {
+			key = qualifyTable(schema, table)
+		}
+		t := &schemaTable{key: key, schema: schema, table: table, columns: columns}
+		for _, col := range columns {
+			if strings.EqualFold(col.Key, "PRI") {
+				t.primaryKey = append(t.primaryKey, col.Name)
+			}
+		}
+		// 部分数据库不提供索引或外键信息，读取失败时视为没有
+		if indexes, err := side.inst.GetIndexes(schema, table); err == nil {
+			t.indexes = groupSchemaIndexes(indexes, t.primaryKey)
+		}
+		if foreignKeys, err := side.inst.GetForeignKeys(schema, table); err == nil {
+			t.foreignKeys = groupSchemaForeignKeys(foreignKeys)
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// groupSchemaIndexes 把逐列返回的索引按名称聚合，去掉主键索引（主键单独比较）。
+func groupSchemaIndexes(defs []connection.IndexDefinition, primaryKey []string) []schemaIndex {
+	out := groupIndexDefinitions(defs)
+	filtered := out[:0]
+	for _, index := range out {
+		// MySQL 主键索引名为 PRIMARY，PostgreSQL、SQL Server 等以主键约束名出现
+		if strings.EqualFold(index.name, "PRIMARY") || (index.unique && len(primaryKey) > 0 && equalFoldNames(index.columns, primaryKey)) {
+			continue
+		}
+		filtered = append(filtered, index)
+	}
+	return filtered
+}
+
+// groupIndexDefinitions 把逐列返回的索引按名称聚合，列按 SeqInIndex 排序，索引保持首次出现的顺序。
+func groupIndexDefinitions(defs []connection.IndexDefinition) []schemaIndex {
+	sorted := append([]connection.IndexDefinition(nil), defs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].SeqInIndex < sorted[j].SeqInIndex })
+	var out []schemaIndex
+	byName := make(map[string]int)
+	for _, def := range sorted {
+		name := strings.TrimSpace(def.Name)
+		if name == "" || strings.TrimSpace(def.ColumnName) == "" {
+			continue
+		}
+		idx, ok := byName[strings.ToLower(name)]
+		if !ok {
+			idx = len(out)
+			byName[strings.ToLower(name)] = idx
+			out = append(out, schemaIndex{name: name, unique: def.NonUnique == 0})
+		}
+		out[idx].columns = append(out[idx].columns, def.ColumnName)
+	}
+	return out
+}
+
+// groupSchemaForeignKeys 把逐列返回的外键按约束名聚合。
+func groupSchemaForeignKeys(defs []connection.ForeignKeyDefinition) []schemaForeignKey {
+	var out []schemaForeignKey
+	byName := make(map[string]int)
+	for _, def := range defs {
+		name := strings.TrimSpace(def.ConstraintName)
+		if name == "" {
+			name = strings.TrimSpace(def.Name)
+		}
+		if name == "" {
+			continue
+		}
+		idx, ok := byName[strings.ToLower(name)]
+		if !ok {
+			idx = len(out)
+			byName[strings.ToLower(name)] = idx
+			out = append(out, schemaForeignKey{name: name, refTable: def.RefTableName})
+		}
+		out[idx].columns = append(out[idx].columns, def.ColumnName)
+		out[idx].refColumns = append(out[idx].refColumns, def.RefColumnName)
+	}
+	return out
+}
+
+func equalFoldNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(strings.TrimSpace(a[i]), strings.TrimSpace(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalFoldNameSet 与 equalFoldNames 相同，但忽略列的顺序。
+func equalFoldNameSet(a, b []string) bool {
+	sortedFold := func(names []string) []string {
+		out := make([]string, len(names))
+		for i, name := range names {
+			out[i] = strings.ToLower(strings.TrimSpace(name))
+		}
+		sort.Strings(out)
+		return out
+	}
+	return equalFoldNames(sortedFold(a), sortedFold(b))
+}
+
+// compareSchemaTables 逐表比较并生成同步语句。listExtraTables 为 true 时列出目标端多余的表。
+func compareSchemaTables(source, target schemaSide, sourceTables, targetTables []*schemaTable, listExtraTables bool, dropExtra bool) SchemaCompareResult {
+	result := SchemaCompareResult{Items: []SchemaDiffItem{}}
+	plan := &schemaSyncPlan{}
+	targetByKey := make(map[string]*schemaTable, len(targetTables))
+	for _, t := range targetTables {
+		targetByKey[strings.ToLower(t.key)] = t
+	}
+	sourceKeys := make(map[string]struct{}, len(sourceTables))
+	warnf := func(format string, args ...interface{}) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	for _, st := range sourceTables {
+		sourceKeys[strings.ToLower(st.key)] = struct{}{}
+		result.TablesCompared++
+		tt, exists := targetByKey[strings.ToLower(st.key)]
+		if !exists {
+			result.Items = append(result.Items, SchemaDiffItem{Table: st.key, Kind: "table", Name: st.key, Action: "create", Source: fmt.Sprintf("%d 列", len(st.columns))})
+			schema, table := normalizeSchemaAndTable(target.config, target.dbName, st.key)
+			created := &schemaTable{key: st.key, schema: schema, table: table}
+			createSQL, warnings, err := buildSchemaCreateSQL(source.dbType, target.dbType, qualifyTable(schema, table), st.columns)
+			if err != nil {
+				warnf("表 %s：%s", st.key, err.Error())
+				continue
+			}
+			result.Warnings = append(result.Warnings, warnings...)
+			plan.createTables = append(plan.createTables, createSQL)
+			for _, index := range st.indexes {
+				plan.createIndexes = append(plan.createIndexes, schemaCreateIndexSQL(target, created, index, warnf)...)
+			}
+			for _, fk := range st.foreignKeys {
+				plan.addForeignKeys = append(plan.addForeignKeys, schemaAddForeignKeySQL(target, created, fk, warnf)...)
+			}
+			continue
+		}
+
+		before := len(result.Items)
+		compareSchemaTable(source, target, st, tt, dropExtra, plan, &result, warnf)
+		if len(result.Items) == before {
+			result.TablesIdentical++
+		}
+	}
+
+	if listExtraTables {
+		for _, tt := range targetTables {
+			if _, ok := sourceKeys[strings.ToLower(tt.key)]; ok {
+				continue
+			}
+			result.Items = append(result.Items, SchemaDiffItem{Table: tt.key, Kind: "table", Name: tt.key, Action: "drop", Target: fmt.Sprintf("%d 列", len(tt.columns))})
+			if dropExtra {
+				// 删除表前先删除它自身的外键，避免与其他待删除的表互相引用
+				for _, fk := range tt.foreignKeys {
+					plan.dropForeignKeys = append(plan.dropForeignKeys, schemaDropForeignKeySQL(target, tt, fk, warnf)...)
+				}
+				plan.dropTables = append(plan.dropTables, "DROP TABLE "+schemaTableRef(target.dbType, tt))
+			}
+		}
+	}
+
+	result.Statements = plan.statements()
+	if len(result.Statements) > 0 {
+		result.Script = strings.Join(result.Statements, ";\n") + ";"
+	}
+	return result
+}
+
+// compareSchemaTable 比较两端都存在的表。
+func compareSchemaTable(source, target schemaSide, st, tt *schemaTable, dropExtra bool, plan *schemaSyncPlan, result *SchemaCompareResult, warnf func(string, ...interface{})) {
+	addItem := func(kind, name, action, src, dst string) {
+		result.Items = append(result.Items, SchemaDiffItem{Table: st.key, Kind: kind, Name: name, Action: action, Source: src, Target: dst})
+	}
+	targetColumns := make(map[string]connection.ColumnDefinition, len(tt.columns))
+	for _, col := range tt.columns {
+		targetColumns[strings.ToLower(col.Name)] = col
+	}
+	sourceColumns := make(map[string]struct{}, len(st.columns))
+	for _, col := range st.columns {
+		sourceColumns[strings.ToLower(col.Name)] = struct{}{}
+		targetCol, ok := targetColumns[strings.ToLower(col.Name)]
+		if !ok {
+			addItem("column", col.Name, "create", schemaColumnSummary(col), "")
+			plan.alterColumns = append(plan.alterColumns, schemaAddColumnSQL(source.dbType, target.dbType, tt, col, warnf)...)
+			continue
+		}
+		typeDiff, nullDiff, defaultDiff := schemaColumnDiff(source.dbType, target.dbType, col, targetCol)
+		if typeDiff || nullDiff || defaultDiff {
+			addItem("column", col.Name, "alter", schemaColumnSummary(col), schemaColumnSummary(targetCol))
+			plan.alterColumns = append(plan.alterColumns, schemaAlterColumnSQL(source.dbType, target.dbType, tt, col, typeDiff, nullDiff, defaultDiff, warnf)...)
+		}
+	}
+	for _, col := range tt.columns {
+		if _, ok := sourceColumns[strings.ToLower(col.Name)]; ok {
+			continue
+		}
+		addItem("column", col.Name, "drop", "", schemaColumnSummary(col))
+		if dropExtra {
+			plan.dropColumns = append(plan.dropColumns, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", schemaTableRef(target.dbType, tt), quoteIdentByType(target.dbType, col.Name)))
+		}
+	}
+
+	if !equalFoldNames(st.primaryKey, tt.primaryKey) {
+		addItem("primaryKey", "", "alter", strings.Join(st.primaryKey, ", "), strings.Join(tt.primaryKey, ", "))
+		warnf("表 %s 的主键不同（源：%s，目标：%s），主键变更涉及数据校验，请手动处理", st.key, strings.Join(st.primaryKey, ", "), strings.Join(tt.primaryKey, ", "))
+	}
+
+	targetIndexes := make(map[string]schemaIndex, len(tt.indexes))
+	for _, index := range tt.indexes {
+		targetIndexes[strings.ToLower(index.name)] = index
+	}
+	sourceIndexes := make(map[string]struct{}, len(st.indexes))
+	for _, index := range st.indexes {
+		sourceIndexes[strings.ToLower(index.name)] = struct{}{}
+		existing, ok := targetIndexes[strings.ToLower(index.name)]
+		switch {
+		case !ok:
+			addItem("index", index.name, "create", schemaIndexSummary(index), "")
+			plan.createIndexes = append(plan.createIndexes, schemaCreateIndexSQL(target, tt, index, warnf)...)
+		case existing.unique != index.unique || !equalFoldNames(existing.columns, index.columns):
+			addItem("index", index.name, "alter", schemaIndexSummary(index), schemaIndexSummary(existing))
+			plan.dropIndexes = append(plan.dropIndexes, schemaDropIndexSQL(target, tt, existing, warnf)...)
+			plan.createIndexes = append(plan.createIndexes, schemaCreateIndexSQL(target, tt, index, warnf)...)
+		}
+	}
+	for _, index := range tt.indexes {
+		if _, ok := sourceIndexes[strings.ToLower(index.name)]; ok {
+			continue
+		}
+		addItem("index", index.name, "drop", "", schemaIndexSummary(index))
+		if dropExtra {
+			plan.dropIndexes = append(plan.dropIndexes, schemaDropIndexSQL(target, tt, index, warnf)...)
+		}
+	}
+
+	targetForeignKeys := make(map[string]schemaForeignKey, len(tt.foreignKeys))
+	for _, fk := range tt.foreignKeys {
+		targetForeignKeys[strings.ToLower(fk.name)] = fk
+	}
+	sourceForeignKeys := make(map[string]struct{}, len(st.foreignKeys))
+	for _, fk := range st.foreignKeys {
+		sourceForeignKeys[strings.ToLower(fk.name)] = struct{}{}
+		existing, ok := targetForeignKeys[strings.ToLower(fk.name)]
+		switch {
+		case !ok:
+			addItem("foreignKey", fk.name, "create", schemaForeignKeySummary(fk), "")
+			plan.addForeignKeys = append(plan.addForeignKeys, schemaAddForeignKeySQL(target, tt, fk, warnf)...)
+		case !equalFoldNames(existing.columns, fk.columns) || !equalFoldNames(existing.refColumns, fk.refColumns) ||
+			!strings.EqualFold(schemaBareName(existing.refTable), schemaBareName(fk.refTable)):
+			addItem("foreignKey", fk.name, "alter", schemaForeignKeySummary(fk), schemaForeignKeySummary(existing))
+			plan.dropForeignKeys = append(plan.dropForeignKeys, schemaDropForeignKeySQL(target, tt, existing, warnf)...)
+			plan.addForeignKeys = append(plan.addForeignKeys, schemaAddForeignKeySQL(target, tt, fk, warnf)...)
+		}
+	}
+	for _, fk := range tt.foreignKeys {
+		if _, ok := sourceForeignKeys[strings.ToLower(fk.name)]; ok {
+			continue
+		}
+		addItem("foreignKey", fk.name, "drop", "", schemaForeignKeySummary(fk))
+		if dropExtra {
+			plan.dropForeignKeys = append(plan.dropForeignKeys, schemaDropForeignKeySQL(target, tt, fk, warnf)...)
+		}
+	}
+}
+
+func schemaTableRef(dbType string, t *schemaTable) string {
+	return quoteQualifiedIdentByType(dbType, qualifyTable(t.schema, t.table))
+}
+
+func schemaBareName(name string) string {
+	name = strings.TrimSpace(name)
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		return name[idx+1:]
+	}
+	return name
+}
+
+func schemaColumnSummary(col connection.ColumnDefinition) string {
+	summary := col.Type
+	if strings.EqualFold(col.Nullable, "NO") {
+		summary += " NOT NULL"
+	}
+	if col.Default != nil {
+		summary += " DEFAULT " + *col.Default
+	}
+	return summary
+}
+
+func schemaIndexSummary(index schemaIndex) string {
+	prefix := ""
+	if index.unique {
+		prefix = "UNIQUE "
+	}
+	return prefix + "(" + strings.Join(index.columns, ", ") + ")"
+}
+
+func schemaForeignKeySummary(fk schemaForeignKey) string {
+	return fmt.Sprintf("(%s) -> %s(%s)", strings.Join(fk.columns, ", "), fk.refTable, strings.Join(fk.refColumns, ", "))
+}
+
+// schemaNormalizeType 规范化同族数据库的类型写法：忽略大小写、空白与 MySQL 整数显示宽度。
+func schemaNormalizeType(dbType string, raw string) string {
+	t := strings.Join(strings.Fields(strings.ToLower(raw)), " ")
+	t = strings.ReplaceAll(t, ", ", ",")
+	if transferTypeFamily(dbType) == "mysql" {
+		for _, intType := range []string{"tinyint", "smallint", "mediumint", "int", "bigint"} {
+			if strings.HasPrefix(t, intType+"(") && !strings.HasPrefix(t, "tinyint(1)") {
+				if end := strings.Index(t, ")"); end > 0 {
+					t = intType + t[end+1:]
+				}
+				break
+			}
+		}
+	}
+	return t
+}
+
+// schemaColumnDiff 比较列的类型、可空与默认值。跨族比较时类型按通用类型比较，默认值不比较。
+func schemaColumnDiff(sourceType, targetType string, s, t connection.ColumnDefinition) (typeDiff, nullDiff, defaultDiff bool) {
+	nullDiff = strings.EqualFold(s.Nullable, "NO") != strings.EqualFold(t.Nullable, "NO")
+	if transferTypeFamily(sourceType) != transferTypeFamily(targetType) {
+		sp, _ := parseTransferColumnType(sourceType, s.Type)
+		tp, _ := parseTransferColumnType(targetType, t.Type)
+		typeDiff = sp != tp
+		return typeDiff, nullDiff, false
+	}
+	typeDiff = schemaNormalizeType(sourceType, s.Type) != schemaNormalizeType(targetType, t.Type)
+	if s.Identity == "" && t.Identity == "" {
+		switch {
+		case (s.Default == nil) != (t.Default == nil):
+			defaultDiff = true
+		case s.Default != nil:
+			defaultDiff = strings.TrimSpace(*s.Default) != strings.TrimSpace(*t.Default)
+		}
+	}
+	return typeDiff, nullDiff, defaultDiff
+}
+
+// schemaDefaultSQL 返回列默认值的 SQL 写法；跨族或自增列返回空。
+func schemaDefaultSQL(sourceType, targetType string, col connection.ColumnDefinition) string {
+	if col.Default == nil || col.Identity != "" || transferTypeFamily(sourceType) != transferTypeFamily(targetType) {
+		return ""
+	}
+	value := strings.TrimSpace(*col.Default)
+	if col.DefaultIsExpression || transferTypeFamily(targetType) == "postgres" || strings.EqualFold(value, "NULL") {
+		// PostgreSQL 读出的默认值本身就是表达式写法（如 'a'::character varying）
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// schemaColumnDef 生成目标库的列定义。
+func schemaColumnDef(sourceType, targetType string, col connection.ColumnDefinition, warnf func(string, ...interface{})) (string, error) {
+	colType, warning, err := transferColumnDDLType(sourceType, targetType, col)
+	if err != nil {
+		return "", err
+	}
+	if warning != "" {
+		warnf("%s", warning)
+	}
+	name := quoteIdentByType(targetType, col.Name)
+	notNull := strings.EqualFold(col.Nullable, "NO") || strings.EqualFold(col.Key, "PRI")
+	defaultSQL := schemaDefaultSQL(sourceType, targetType, col)
+	sameFamily := transferTypeFamily(sourceType) == transferTypeFamily(targetType)
+
+	var parts []string
+	switch transferTypeFamily(targetType) {
+	case "clickhouse":
+		if !notNull && !strings.HasPrefix(colType, "Nullable(") {
+			colType = "Nullable(" + colType + ")"
+		}
+		parts = []string{name, colType}
+		if defaultSQL != "" {
+			parts = append(parts, "DEFAULT", defaultSQL)
+		}
+		return strings.Join(parts, " "), nil
+	case "oracle", "dameng":
+		parts = []string{name, colType}
+		if defaultSQL != "" {
+			parts = append(parts, "DEFAULT", defaultSQL)
+		}
+		if notNull {
+			parts = append(parts, "NOT NULL")
+		}
+		return strings.Join(parts, " "), nil
+	}
+
+	parts = []string{name, colType}
+	if sameFamily && col.Identity != "" && transferTypeFamily(targetType) == "postgres" {
+		parts = append(parts, "GENERATED BY DEFAULT AS IDENTITY")
+	}
+	if notNull {
+		parts = append(parts, "NOT NULL")
+	} else if transferTypeFamily(targetType) == "mysql" || targetType == "sqlserver" {
+		parts = append(parts, "NULL")
+	}
+	if defaultSQL != "" {
+		parts = append(parts, "DEFAULT", defaultSQL)
+	}
+	if sameFamily && col.Identity != "" && transferTypeFamily(targetType) == "mysql" {
+		parts = append(parts, "AUTO_INCREMENT")
+	}
+	if sameFamily && transferTypeFamily(targetType) == "mysql" && col.Comment != "" {
+		parts = append(parts, "COMMENT", "'"+strings.ReplaceAll(col.Comment, "'", "''")+"'")
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// buildSchemaCreateSQL 生成目标端缺少的表。同族数据库保留默认值、自增与注释，跨族时与数据传输的建表规则一致。
+func buildSchemaCreateSQL(sourceType, targetType string, qualifiedTable string, columns []connection.ColumnDefinition) (string, []string, error) {
+	if targetType == "clickhouse" || transferTypeFamily(sourceType) != transferTypeFamily(targetType) {
+		createSQL, warnings, err := buildTransferCreateSQL(sourceType, targetType, qualifiedTable, columns)
+		if err == nil {
+			warnings = append(warnings, fmt.Sprintf("跨库新建表 %s 仅包含列类型、非空约束与主键，默认值与自增需手动补充", qualifiedTable))
+		}
+		return createSQL, warnings, err
+	}
+	var warnings []string
+	warnf := func(format string, args ...interface{}) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+	defs := make([]string, 0, len(columns)+1)
+	var primaryKeys []string
+	for _, col := range columns {
+		if strings.TrimSpace(col.Generated) != "" {
+			warnf("列 %s 为计算列，新建表时已跳过", col.Name)
+			continue
+		}
+		def, err := schemaColumnDef(sourceType, targetType, col, warnf)
+		if err != nil {
+			return "", nil, err
+		}
+		defs = append(defs, def)
+		if strings.EqualFold(col.Key, "PRI") {
+			primaryKeys = append(primaryKeys, quoteIdentByType(targetType, col.Name))
+		}
+	}
+	if len(primaryKeys) > 0 {
+		defs = append(defs, "PRIMARY KEY ("+strings.Join(primaryKeys, ", ")+")")
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n)", quoteQualifiedIdentByType(targetType, qualifiedTable), strings.Join(defs, ",\n  ")), warnings, nil
+}
+
+func schemaAddColumnSQL(sourceType, targetType string, t *schemaTable, col connection.ColumnDefinition, warnf func(string, ...interface{})) []string {
+	if strings.TrimSpace(col.Generated) != "" {
+		warnf("表 %s 缺少计算列 %s，需手动添加", t.key, col.Name)
+		return nil
+	}
+	def, err := schemaColumnDef(sourceType, targetType, col, warnf)
+	if err != nil {
+		warnf("表 %s 列 %s：%s", t.key, col.Name, err.Error())
+		return nil
+	}
+	if strings.EqualFold(col.Nullable, "NO") && schemaDefaultSQL(sourceType, targetType, col) == "" && col.Identity == "" {
+		warnf("表 %s 新增列 %s 为 NOT NULL 且没有默认值，目标表已有数据时会执行失败", t.key, col.Name)
+	}
+	ref := schemaTableRef(targetType, t)
+	switch transferTypeFamily(targetType) {
+	case "sqlserver":
+		return []string{fmt.Sprintf("ALTER TABLE %s ADD %s", ref, def)}
+	case "oracle", "dameng":
+		return []string{fmt.Sprintf("ALTER TABLE %s ADD (%s)", ref, def)}
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", ref, def)}
+}
+
+func schemaAlterColumnSQL(sourceType, targetType string, t *schemaTable, col connection.ColumnDefinition, typeDiff, nullDiff, defaultDiff bool, warnf func(string, ...interface{})) []string {
+	ref := schemaTableRef(targetType, t)
+	name := quoteIdentByType(targetType, col.Name)
+	colType, _, err := transferColumnDDLType(sourceType, targetType, col)
+	if err != nil {
+		warnf("表 %s 列 %s：%s", t.key, col.Name, err.Error())
+		return nil
+	}
+	notNull := strings.EqualFold(col.Nullable, "NO")
+	defaultSQL := schemaDefaultSQL(sourceType, targetType, col)
+
+	switch transferTypeFamily(targetType) {
+	case "mysql", "clickhouse":
+		def, err := schemaColumnDef(sourceType, targetType, col, warnf)
+		if err != nil {
+			warnf("表 %s 列 %s：%s", t.key, col.Name, err.Error())
+			return nil
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s", ref, def)}
+	case "postgres", "duckdb":
+		var out []string
+		if typeDiff {
+			out = append(out, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", ref, name, colType))
+		}
+		if nullDiff {
+			action := "DROP NOT NULL"
+			if notNull {
+				action = "SET NOT NULL"
+			}
+			out = append(out, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s", ref, name, action))
+		}
+		if defaultDiff {
+			if defaultSQL == "" {
+				out = append(out, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT", ref, name))
+			} else {
+				out = append(out, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", ref, name, defaultSQL))
+			}
+		}
+		return out
+	case "sqlserver":
+		var out []string
+		if typeDiff || nullDiff {
+			nullSQL := "NULL"
+			if notNull {
+				nullSQL = "NOT NULL"
+			}
+			out = append(out, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s", ref, name, colType, nullSQL))
+		}
+		if defaultDiff {
+			warnf("SQL Server 的默认值属于命名约束，表 %s 列 %s 的默认值需手动调整", t.key, col.Name)
+		}
+		return out
+	case "oracle", "dameng":
+		var out []string
+		if typeDiff {
+			out = append(out, fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s)", ref, name, colType))
+		}
+		if defaultDiff {
+			value := defaultSQL
+			if value == "" {
+				value = "NULL"
+			}
+			out = append(out, fmt.Sprintf("ALTER TABLE %s MODIFY (%s DEFAULT %s)", ref, name, value))
+		}
+		if nullDiff {
+			nullSQL := "NULL"
+			if notNull {
+				nullSQL = "NOT NULL"
+			}
+			out = append(out, fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s)", ref, name, nullSQL))
+		}
+		return out
+	}
+	warnf("%s 不支持修改列定义，表 %s 列 %s 需重建表后同步", targetType, t.key, col.Name)
+	return nil
+}
+
+func schemaCreateIndexSQL(target schemaSide, t *schemaTable, index schemaIndex, warnf func(string, ...interface{})) []string {
+	if target.dbType == "clickhouse" {
+		warnf("ClickHouse 不支持普通索引，表 %s 的索引 %s 已跳过", t.key, index.name)
+		return nil
+	}
+	columns := make([]string, 0, len(index.columns))
+	for _, col := range index.columns {
+		columns = append(columns, quoteIdentByType(target.dbType, col))
+	}
+	unique := ""
+	if index.unique {
+		unique = "UNIQUE "
+	}
+	ref := schemaTableRef(target.dbType, t)
+	if transferTypeFamily(target.dbType) == "mysql" {
+		return []string{fmt.Sprintf("ALTER TABLE %s ADD %sINDEX %s (%s)", ref, unique, quoteIdentByType(target.dbType, index.name), strings.Join(columns, ", "))}
+	}
+	return []string{fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)", unique, quoteIdentByType(target.dbType, index.name), ref, strings.Join(columns, ", "))}
+}
+
+func schemaDropIndexSQL(target schemaSide, t *schemaTable, index schemaIndex, warnf func(string, ...interface{})) []string {
+	name := quoteIdentByType(target.dbType, index.name)
+	ref := schemaTableRef(target.dbType, t)
+	switch transferTypeFamily(target.dbType) {
+	case "mysql":
+		return []string{fmt.Sprintf("ALTER TABLE %s DROP INDEX %s", ref, name)}
+	case "sqlserver":
+		return []string{fmt.Sprintf("DROP INDEX %s ON %s", name, ref)}
+	case "postgres", "oracle", "dameng":
+		return []string{"DROP INDEX " + quoteQualifiedIdentByType(target.dbType, qualifyTable(t.schema, index.name))}
+	case "clickhouse":
+		warnf("ClickHouse 不支持普通索引，表 %s 的索引 %s 已跳过", t.key, index.name)
+		return nil
+	}
+	return []string{"DROP INDEX " + name}
+}
+
+func schemaAddForeignKeySQL(target schemaSide, t *schemaTable, fk schemaForeignKey, warnf func(string, ...interface{})) []string {
+	switch target.dbType {
+	case "sqlite", "clickhouse", "duckdb":
+		warnf("%s 不支持通过 ALTER TABLE 添加外键，表 %s 的外键 %s 已跳过", target.dbType, t.key, fk.name)
+		return nil
+	}
+	quote := func(names []string) string {
+		out := make([]string, 0, len(names))
+		for _, name := range names {
+			out = append(out, quoteIdentByType(target.dbType, name))
+		}
+		return strings.Join(out, ", ")
+	}
+	refSchema, refTable := normalizeSchemaAndTable(target.config, target.dbName, fk.refTable)
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		schemaTableRef(target.dbType, t), quoteIdentByType(target.dbType, fk.name), quote(fk.columns),
+		quoteQualifiedIdentByType(target.dbType, qualifyTable(refSchema, refTable)), quote(fk.refColumns))}
+}
+
+func schemaDropForeignKeySQL(target schemaSide, t *schemaTable, fk schemaForeignKey, warnf func(string, ...interface{})) []string {
+	switch target.dbType {
+	case "sqlite", "clickhouse", "duckdb":
+		warnf("%s 不支持通过 ALTER TABLE 删除外键，表 %s 的外键 %s 需重建表处理", target.dbType, t.key, fk.name)
+		return nil
+	}
+	keyword := "CONSTRAINT"
+	if transferTypeFamily(target.dbType) == "mysql" {
+		keyword = "FOREIGN KEY"
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s DROP %s %s", schemaTableRef(target.dbType, t), keyword, quoteIdentByType(target.dbType, fk.name))}
+}
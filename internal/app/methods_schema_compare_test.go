@@ -0,0 +1,90 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func schemaStrPtr(s string) *string { return &s }
+
+func TestCompareSchemaTablesMySQL(t *testing.T) {
+	source := schemaSide{config: connection.ConnectionConfig{Type: "mysql"}, dbType: "mysql", dbName: "dev"}
+	target := schemaSide{config: connection.ConnectionConfig{Type: "mysql"}, dbType: "mysql", dbName: "prod"}
+	sourceTables := []*schemaTable{
+		{
+			key: "users", schema: "dev", table: "users", primaryKey: []string{"id"},
+			columns: []connection.ColumnDefinition{
+				{Name: "id", Type: "int", Key: "PRI", Nullable: "NO", Identity: "BY DEFAULT"},
+				{Name: "name", Type: "varchar(50)", Nullable: "NO"},
+				{Name: "status", Type: "tinyint", Nullable: "NO", Default: schemaStrPtr("1")},
+			},
+			indexes: []schemaIndex{{name: "idx_name", unique: true, columns: []string{"name"}}},
+		},
+		{
+			key: "orders", schema: "dev", table: "orders", primaryKey: []string{"id"},
+			columns: []connection.ColumnDefinition{
+				{Name: "id", Type: "bigint", Key: "PRI", Nullable: "NO"},
+				{Name: "user_id", Type: "int", Nullable: "YES"},
+			},
+			indexes:     []schemaIndex{{name: "idx_user", columns: []string{"user_id"}}},
+			foreignKeys: []schemaForeignKey{{name: "fk_user", columns: []string{"user_id"}, refTable: "users", refColumns: []string{"id"}}},
+		},
+	}
+	targetTables := []*schemaTable{
+		{
+			key: "users", schema: "prod", table: "users", primaryKey: []string{"id"},
+			columns: []connection.ColumnDefinition{
+				{Name: "id", Type: "int(11)", Key: "PRI", Nullable: "NO", Identity: "BY DEFAULT"},
+				{Name: "name", Type: "varchar(20)", Nullable: "YES"},
+				{Name: "legacy", Type: "text", Nullable: "YES"},
+			},
+			indexes: []schemaIndex{{name: "idx_name", columns: []string{"name"}}},
+		},
+		{key: "old_logs", schema: "prod", table: "old_logs", columns: []connection.ColumnDefinition{{Name: "id", Type: "int"}}},
+	}
+
+	res := compareSchemaTables(source, target, sourceTables, targetTables, true, true)
+	want := []string{
+		"ALTER TABLE `prod`.`users` DROP INDEX `idx_name`",
+		"DROP TABLE `prod`.`old_logs`",
+		"CREATE TABLE `prod`.`orders` (\n  `id` bigint NOT NULL,\n  `user_id` int NULL,\n  PRIMARY KEY (`id`)\n)",
+		"ALTER TABLE `prod`.`users` MODIFY COLUMN `name` varchar(50) NOT NULL",
+		"ALTER TABLE `prod`.`users` ADD COLUMN `status` tinyint NOT NULL DEFAULT '1'",
+		"ALTER TABLE `prod`.`users` DROP COLUMN `legacy`",
+		"ALTER TABLE `prod`.`users` ADD UNIQUE INDEX `idx_name` (`name`)",
+		"ALTER TABLE `prod`.`orders` ADD INDEX `idx_user` (`user_id`)",
+		"ALTER TABLE `prod`.`orders` ADD CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `prod`.`users` (`id`)",
+	}
+	if strings.Join(res.Statements, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("statements =\n%s", strings.Join(res.Statements, "\n"))
+	}
+	if res.TablesCompared != 2 || res.TablesIdentical != 0 || len(res.Items) != 6 {
+		t.Fatalf("result = %+v", res)
+	}
+
+	// 不删除多余对象时只列出差异
+	res = compareSchemaTables(source, target, sourceTables, targetTables, true, false)
+	for _, stmt := range res.Statements {
+		if strings.Contains(stmt, "DROP TABLE") || strings.Contains(stmt, "DROP COLUMN") {
+			t.Fatalf("unexpected drop: %s", stmt)
+		}
+	}
+}
+
+func TestSchemaAlterColumnSQLPostgres(t *testing.T) {
+	table := &schemaTable{key: "users", schema: "public", table: "users"}
+	var warnings []string
+	warnf := func(format string, args ...interface{}) { warnings = append(warnings, format) }
+	col := connection.ColumnDefinition{Name: "name", Type: "character varying(50)", Nullable: "NO", Default: schemaStrPtr("'x'::character varying"), DefaultIsExpression: true}
+	got := schemaAlterColumnSQL("postgres", "postgres", table, col, true, true, true, warnf)
+	want := []string{
+		`ALTER TABLE "public"."users" ALTER COLUMN "name" TYPE character varying(50)`,
+		`ALTER TABLE "public"."users" ALTER COLUMN "name" SET NOT NULL`,
+		`ALTER TABLE "public"."users" ALTER COLUMN "name" SET DEFAULT 'x'::character varying`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") || len(warnings) != 0 {
+		t.Fatalf("got %q warnings %q", got, warnings)
+	}
+}
@@ -0,0 +1,349 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	seedProgressEvent     = "seed:progress"
+	seedStatementTimeout  = 5 * time.Minute
+	seedStatusPending     = "pending"
+	seedStatusApplied     = "applied"
+	seedStatusChanged     = "changed"
+	seedStatusMissing     = "missing"
+	seedResultApplied     = "applied"
+	seedResultSkipped     = "skipped"
+	seedResultFailed      = "failed"
+	seedResultNotExecuted = "not_executed"
+)
+
+// SeedScriptStatus 种子脚本及其在目标库上的执行状态。
+type SeedScriptStatus struct {
+	Path      string `json:"path"`
+	Order     int    `json:"order"`
+	Status    string `json:"status"` // pending/applied/changed/missing
+	Checksum  string `json:"checksum,omitempty"`
+	AppliedAt int64  `json:"appliedAt,omitempty"`
+}
+
+// SeedRunOptions 执行选项。Force 为 true 时忽略已执行记录全部重跑。
+type SeedRunOptions struct {
+	JobID string `json:"jobId,omitempty"`
+	Force bool   `json:"force"`
+}
+
+// SeedRunResult 单个脚本的执行结果。
+type SeedRunResult struct {
+	Path       string `json:"path"`
+	Status     string `json:"status"` // applied/skipped/failed/not_executed
+	Statements int    `json:"statements"`
+	Executed   int    `json:"executed"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+type seedAppliedRecord struct {
+	Checksum  string `json:"checksum"`
+	AppliedAt int64  `json:"appliedAt"`
+}
+
+type seedScriptSet struct {
+	Files   []string                     `json:"files"`
+	Applied map[string]seedAppliedRecord `json:"applied,omitempty"`
+}
+
+var seedStoreMu sync.Mutex
+
+// SelectSeedScriptFiles 选择一个或多个 .sql 种子文件，按文件名排序返回。
+func (a *App) SelectSeedScriptFiles() connection.QueryResult {
+	selection, err := runtime.OpenMultipleFilesDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "选择种子脚本",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "SQL Files (*.sql)", Pattern: "*.sql"},
+		},
+	})
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if len(selection) == 0 {
+		return connection.QueryResult{Success: false, Message: "Cancelled"}
+	}
+	return connection.QueryResult{Success: true, Data: selection}
+}
+
+// GetSeedScripts 返回与连接/数据库关联的种子脚本及执行状态。
+func (a *App) GetSeedScripts(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	seedStoreMu.Lock()
+	defer seedStoreMu.Unlock()
+	store, err := readSeedStore()
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: buildSeedScriptStatuses(store[connectionStoreKey(config, dbName)])}
+}
+
+// SaveSeedScripts 保存种子脚本列表，数组顺序即执行顺序。已移出列表的脚本同时清除其执行记录。
+func (a *App) SaveSeedScripts(config connection.ConnectionConfig, dbName string, files []string) connection.QueryResult {
+	cleaned := make([]string, 0, len(files))
+	seen := map[string]struct{}{}
+	for _, file := range files {
+		path := strings.TrimSpace(file)
+		if path == "" {
+			continue
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		if _, dup := seen[abs]; dup {
+			continue
+		}
+		seen[abs] = struct{}{}
+		cleaned = append(cleaned, abs)
+	}
+
+	seedStoreMu.Lock()
+	defer seedStoreMu.Unlock()
+	store, err := readSeedStore()
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	key := connectionStoreKey(config, dbName)
+	set := store[key]
+	set.Files = cleaned
+	for path := range set.Applied {
+		if _, ok := seen[path]; !ok {
+			delete(set.Applied, path)
+		}
+	}
+	if len(set.Files) == 0 {
+		delete(store, key)
+	} else {
+		store[key] = set
+	}
+	if err := writeSeedStore(store); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: "种子脚本已保存", Data: buildSeedScriptStatuses(set)}
+}
+
+// ResetSeedScriptState 清除执行记录，下次执行时全部脚本重新运行。
+func (a *App) ResetSeedScriptState(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	seedStoreMu.Lock()
+	defer seedStoreMu.Unlock()
+	store, err := readSeedStore()
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	key := connectionStoreKey(config, dbName)
+	set, ok := store[key]
+	if !ok {
+		return connection.QueryResult{Success: true, Message: "没有执行记录", Data: []SeedScriptStatus{}}
+	}
+	set.Applied = nil
+	store[key] = set
+	if err := writeSeedStore(store); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: "执行记录已清除", Data: buildSeedScriptStatuses(set)}
+}
+
+// RunSeedScripts 按顺序执行种子脚本。已执行且内容未变化的脚本跳过；内容有变化的脚本重新执行。
+// 任一脚本失败即停止，失败脚本不记为已执行，后续脚本保持未执行状态。进度通过 seed:progress 事件推送。
+func (a *App) RunSeedScripts(config connection.ConnectionConfig, dbName string, options SeedRunOptions) connection.QueryResult {
+	if err := ensureWritable(config, "执行初始化脚本"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	key := connectionStoreKey(config, dbName)
+	seedStoreMu.Lock()
+	store, err := readSeedStore()
+	seedStoreMu.Unlock()
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	set := store[key]
+	if len(set.Files) == 0 {
+		return connection.QueryResult{Success: false, Message: "尚未配置种子脚本"}
+	}
+
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+
+	results := make([]SeedRunResult, 0, len(set.Files))
+	failed := false
+	for index, path := range set.Files {
+		result := SeedRunResult{Path: path}
+		if failed {
+			result.Status = seedResultNotExecuted
+			results = append(results, result)
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			result.Status = seedResultFailed
+			result.Error = fmt.Sprintf("读取脚本失败：%s", err.Error())
+			results = append(results, result)
+			failed = true
+			continue
+		}
+		checksum := seedChecksum(content)
+		if record, ok := set.Applied[path]; ok && record.Checksum == checksum && !options.Force {
+			result.Status = seedResultSkipped
+			results = append(results, result)
+			a.emitSeedProgress(options.JobID, index, len(set.Files), result)
+			continue
+		}
+
+		statements := sqlstmt.Split(string(content), dbType)
+		result.Statements = len(statements)
+		started := time.Now()
+		for _, stmt := range statements {
+			if _, err := execSQLPlanStatement(dbInst, dbType, stmt, seedStatementTimeout); err != nil {
+				logger.Error(err, "种子脚本执行失败：%s 文件=%s SQL片段=%q", formatConnSummary(runConfig), path, sqlSnippet(stmt))
+				result.Error = fmt.Sprintf("第 %d 条语句失败：%s", result.Executed+1, normalizeErrorMessage(err))
+				break
+			}
+			result.Executed++
+		}
+		result.DurationMs = time.Since(started).Milliseconds()
+		if result.Error != "" {
+			result.Status = seedResultFailed
+			failed = true
+		} else {
+			result.Status = seedResultApplied
+			if err := recordSeedApplied(key, path, checksum); err != nil {
+				logger.Warnf("记录种子脚本执行状态失败：%v", err)
+			}
+		}
+		results = append(results, result)
+		a.emitSeedProgress(options.JobID, index, len(set.Files), result)
+	}
+
+	applied := 0
+	for _, r := range results {
+		if r.Status == seedResultApplied {
+			applied++
+		}
+	}
+	logger.Infof("种子脚本执行完成：%s 脚本数=%d 执行=%d 失败=%v", formatConnSummary(runConfig), len(set.Files), applied, failed)
+	if failed {
+		return connection.QueryResult{Success: false, Message: "种子脚本执行失败，已停止后续脚本", Data: results}
+	}
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已执行 %d 个脚本，跳过 %d 个", applied, len(results)-applied), Data: results}
+}
+
+func (a *App) emitSeedProgress(jobID string, index int, total int, result SeedRunResult) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, seedProgressEvent, map[string]interface{}{
+		"jobId":  jobID,
+		"index":  index,
+		"total":  total,
+		"result": result,
+	})
+}
+
+func buildSeedScriptStatuses(set seedScriptSet) []SeedScriptStatus {
+	statuses := make([]SeedScriptStatus, 0, len(set.Files))
+	for i, path := range set.Files {
+		status := SeedScriptStatus{Path: path, Order: i + 1, Status: seedStatusPending}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			status.Status = seedStatusMissing
+			statuses = append(statuses, status)
+			continue
+		}
+		status.Checksum = seedChecksum(content)
+		if record, ok := set.Applied[path]; ok {
+			status.AppliedAt = record.AppliedAt
+			if record.Checksum == status.Checksum {
+				status.Status = seedStatusApplied
+			} else {
+				status.Status = seedStatusChanged
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func seedChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func recordSeedApplied(key string, path string, checksum string) error {
+	seedStoreMu.Lock()
+	defer seedStoreMu.Unlock()
+	store, err := readSeedStore()
+	if err != nil {
+		return err
+	}
+	set := store[key]
+	if set.Applied == nil {
+		set.Applied = map[string]seedAppliedRecord{}
+	}
+	set.Applied[path] = seedAppliedRecord{Checksum: checksum, AppliedAt: time.Now().UnixMilli()}
+	store[key] = set
+	return writeSeedStore(store)
+}
+
+func seedStorePath() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "seed_scripts.json")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-seed_scripts.json")
+}
+
+func readSeedStore() (map[string]seedScriptSet, error) {
+	store := map[string]seedScriptSet{}
+	content, err := os.ReadFile(seedStorePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("读取种子脚本配置失败：%w", err)
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return store, nil
+	}
+	if err := json.Unmarshal(content, &store); err != nil {
+		return nil, fmt.Errorf("解析种子脚本配置失败：%w", err)
+	}
+	return store, nil
+}
+
+func writeSeedStore(store map[string]seedScriptSet) error {
+	content, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := seedStorePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("创建配置目录失败：%w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o644); err != nil {
+		return fmt.Errorf("写入种子脚本配置失败：%w", err)
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,248 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+var sequenceNumberPattern = regexp.MustCompile(`^-?[0-9]+$`)
+
+// sequenceFamily 返回支持序列管理的方言族：postgres（含 kingbase/highgo/vastbase）或 oracle，不支持时为空。
+func sequenceFamily(dbType string) string {
+	switch dbType {
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return "postgres"
+	case "oracle":
+		return "oracle"
+	}
+	return ""
+}
+
+// GetSequences 列出序列及其当前值。PostgreSQL 系列出全部用户 schema 的序列，Oracle 列出 dbName 对应用户的序列。
+func (a *App) GetSequences(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbType := resolveDDLDBType(runConfig)
+	family := sequenceFamily(dbType)
+	if family == "" {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源(%s)暂不支持序列管理", dbType)}
+	}
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	var sequences []connection.SequenceDefinition
+	if family == "oracle" {
+		rows, _, err := dbInst.Query(fmt.Sprintf("SELECT SEQUENCE_OWNER, SEQUENCE_NAME, MIN_VALUE, MAX_VALUE, INCREMENT_BY, CYCLE_FLAG, CACHE_SIZE, LAST_NUMBER "+
+			"FROM ALL_SEQUENCES WHERE SEQUENCE_OWNER = %s ORDER BY SEQUENCE_NAME", dialect.QuoteLiteral(dbType, strings.ToUpper(strings.TrimSpace(dbName)))))
+		if err != nil {
+			logger.Error(err, "获取序列列表失败：%s", formatConnSummary(runConfig))
+			return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+		}
+		for _, row := range rows {
+			sequences = append(sequences, connection.SequenceDefinition{
+				Schema:    backupRowString(row, "SEQUENCE_OWNER"),
+				Name:      backupRowString(row, "SEQUENCE_NAME"),
+				MinValue:  backupRowString(row, "MIN_VALUE"),
+				MaxValue:  backupRowString(row, "MAX_VALUE"),
+				Increment: backupRowString(row, "INCREMENT_BY"),
+				Cache:     backupRowString(row, "CACHE_SIZE"),
+				Cycle:     strings.EqualFold(backupRowString(row, "CYCLE_FLAG"), "Y"),
+				LastValue: backupRowString(row, "LAST_NUMBER"),
+			})
+		}
+		return connection.QueryResult{Success: true, Data: sequences}
+	}
+
+	rows, _, err := dbInst.Query("SELECT schemaname, sequencename, data_type::text AS data_type, start_value, min_value, max_value, increment_by, " +
+		"cycle, cache_size, last_value FROM pg_sequences WHERE schemaname NOT IN ('pg_catalog', 'information_schema') ORDER BY 1, 2")
+	if err != nil {
+		// pg_sequences 自 PostgreSQL 10 提供，旧版本与部分兼容库退回 information_schema（无当前值与缓存）
+		rows, _, err = dbInst.Query("SELECT sequence_schema AS schemaname, sequence_name AS sequencename, data_type, start_value, " +
+			"minimum_value AS min_value, maximum_value AS max_value, increment AS increment_by, cycle_option AS cycle " +
+			"FROM information_schema.sequences WHERE sequence_schema NOT IN ('pg_catalog', 'information_schema') ORDER BY 1, 2")
+	}
+	if err != nil {
+		logger.Error(err, "获取序列列表失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	owners := map[string]string{}
+	if ownerRows, _, err := dbInst.Query("SELECT n.nspname AS schema_name, s.relname AS sequence_name, t.relname AS table_name, a.attname AS column_name " +
+		"FROM pg_class s JOIN pg_namespace n ON n.oid = s.relnamespace JOIN pg_depend d ON d.objid = s.oid AND d.classid = 'pg_class'::regclass " +
+		"AND d.deptype IN ('a', 'i') JOIN pg_class t ON t.oid = d.refobjid JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = d.refobjsubid " +
+		"WHERE s.relkind = 'S'"); err == nil {
+		for _, row := range ownerRows {
+			owners[backupRowString(row, "schema_name")+"."+backupRowString(row, "sequence_name")] = backupRowString(row, "table_name") + "." + backupRowString(row, "column_name")
+		}
+	}
+	for _, row := range rows {
+		seq := connection.SequenceDefinition{
+			Schema:     backupRowString(row, "schemaname"),
+			Name:       backupRowString(row, "sequencename"),
+			DataType:   backupRowString(row, "data_type"),
+			StartValue: backupRowString(row, "start_value"),
+			MinValue:   backupRowString(row, "min_value"),
+			MaxValue:   backupRowString(row, "max_value"),
+			Increment:  backupRowString(row, "increment_by"),
+			Cache:      backupRowString(row, "cache_size"),
+			LastValue:  backupRowString(row, "last_value"),
+		}
+		cycle := strings.ToLower(backupRowString(row, "cycle"))
+		seq.Cycle = cycle == "true" || cycle == "t" || cycle == "yes"
+		seq.OwnedBy = owners[seq.Schema+"."+seq.Name]
+		sequences = append(sequences, seq)
+	}
+	return connection.QueryResult{Success: true, Data: sequences}
+}
+
+// CreateSequence 按给定属性创建序列，未填写的属性使用数据库默认值。
+func (a *App) CreateSequence(config connection.ConnectionConfig, dbName string, seq connection.SequenceDefinition) connection.QueryResult {
+	return a.execSequenceDDL(config, dbName, "创建序列", func(dbType string) (string, error) {
+		return buildCreateSequenceSQL(dbType, seq)
+	})
+}
+
+// AlterSequence 修改序列的步长、上下限、缓存与循环属性；Oracle 不能修改起始值，请使用 RestartSequence。
+func (a *App) AlterSequence(config connection.ConnectionConfig, dbName string, seq connection.SequenceDefinition) connection.QueryResult {
+	return a.execSequenceDDL(config, dbName, "修改序列", func(dbType string) (string, error) {
+		return buildAlterSequenceSQL(dbType, seq)
+	})
+}
+
+// RestartSequence 让序列下一次从 value 开始取值，value 为空时回到起始值。Oracle 需要 18c 及以上版本。
+func (a *App) RestartSequence(config connection.ConnectionConfig, dbName string, seq connection.SequenceDefinition, value string) connection.QueryResult {
+	return a.execSequenceDDL(config, dbName, "重置序列", func(dbType string) (string, error) {
+		return buildRestartSequenceSQL(dbType, seq, value)
+	})
+}
+
+func (a *App) execSequenceDDL(config connection.ConnectionConfig, dbName string, action string, build func(dbType string) (string, error)) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	if err := ensureWritable(runConfig, action); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	query, err := build(resolveDDLDBType(runConfig))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if _, err := dbInst.Exec(query); err != nil {
+		logger.Error(err, "%s失败：%s SQL片段=%q", action, formatConnSummary(runConfig), sqlSnippet(query))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Message: action + "成功", Data: query}
+}
+
+func sequenceTarget(dbType string, seq connection.SequenceDefinition) (string, error) {
+	if sequenceFamily(dbType) == "" {
+		return "", fmt.Errorf("当前数据源(%s)暂不支持序列管理", dbType)
+	}
+	if strings.TrimSpace(seq.Name) == "" {
+		return "", fmt.Errorf("序列名不能为空")
+	}
+	return quoteTableIdentByType(dbType, strings.TrimSpace(seq.Schema), strings.TrimSpace(seq.Name)), nil
+}
+
+// sequenceClauses 生成 CREATE/ALTER SEQUENCE 的属性子句，数值只接受整数，防止拼入其他语句。
+func sequenceClauses(family string, seq connection.SequenceDefinition, withStart bool, withCycle bool) ([]string, error) {
+	var clauses []string
+	if dataType := strings.ToLower(strings.TrimSpace(seq.DataType)); dataType != "" && family == "postgres" {
+		switch dataType {
+		case "smallint", "integer", "int", "bigint":
+			clauses = append(clauses, "AS "+dataType)
+		default:
+			return nil, fmt.Errorf("序列类型只能是 smallint、integer 或 bigint")
+		}
+	}
+	numbers := []struct {
+		label, value, clause string
+	}{
+		{"步长", seq.Increment, "INCREMENT BY"},
+		{"最小值", seq.MinValue, "MINVALUE"},
+		{"最大值", seq.MaxValue, "MAXVALUE"},
+		{"起始值", seq.StartValue, "START WITH"},
+		{"缓存", seq.Cache, "CACHE"},
+	}
+	for _, n := range numbers {
+		value := strings.TrimSpace(n.value)
+		if value == "" || (n.clause == "START WITH" && !withStart) {
+			continue
+		}
+		if !sequenceNumberPattern.MatchString(value) {
+			return nil, fmt.Errorf("%s必须是整数：%s", n.label, value)
+		}
+		if n.clause == "CACHE" && family == "oracle" && value == "0" {
+			clauses = append(clauses, "NOCACHE")
+			continue
+		}
+		clauses = append(clauses, n.clause+" "+value)
+	}
+	if withCycle {
+		switch {
+		case seq.Cycle:
+			clauses = append(clauses, "CYCLE")
+		case family == "oracle":
+			clauses = append(clauses, "NOCYCLE")
+		default:
+			clauses = append(clauses, "NO CYCLE")
+		}
+	}
+	return clauses, nil
+}
+
+func buildCreateSequenceSQL(dbType string, seq connection.SequenceDefinition) (string, error) {
+	target, err := sequenceTarget(dbType, seq)
+	if err != nil {
+		return "", err
+	}
+	clauses, err := sequenceClauses(sequenceFamily(dbType), seq, true, true)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace("CREATE SEQUENCE " + target + " " + strings.Join(clauses, " ")), nil
+}
+
+func buildAlterSequenceSQL(dbType string, seq connection.SequenceDefinition) (string, error) {
+	target, err := sequenceTarget(dbType, seq)
+	if err != nil {
+		return "", err
+	}
+	family := sequenceFamily(dbType)
+	if family == "oracle" && strings.TrimSpace(seq.StartValue) != "" {
+		return "", fmt.Errorf("Oracle 不支持修改序列起始值，请使用重置序列")
+	}
+	clauses, err := sequenceClauses(family, seq, family == "postgres", true)
+	if err != nil {
+		return "", err
+	}
+	return "ALTER SEQUENCE " + target + " " + strings.Join(clauses, " "), nil
+}
+
+func buildRestartSequenceSQL(dbType string, seq connection.SequenceDefinition, value string) (string, error) {
+	target, err := sequenceTarget(dbType, seq)
+	if err != nil {
+		return "", err
+	}
+	value = strings.TrimSpace(value)
+	if value != "" && !sequenceNumberPattern.MatchString(value) {
+		return "", fmt.Errorf("重置值必须是整数：%s", value)
+	}
+	if sequenceFamily(dbType) == "oracle" {
+		if value == "" {
+			return "ALTER SEQUENCE " + target + " RESTART", nil
+		}
+		return "ALTER SEQUENCE " + target + " RESTART START WITH " + value, nil
+	}
+	if value == "" {
+		return "ALTER SEQUENCE " + target + " RESTART", nil
+	}
+	return "ALTER SEQUENCE " + target + " RESTART WITH " + value, nil
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBuildSequenceSQL(t *testing.T) {
+	seq := connection.SequenceDefinition{Schema: "public", Name: "order_no", DataType: "bigint", StartValue: "1000", Increment: "2", Cache: "10"}
+	if sql, err := buildCreateSequenceSQL("postgres", seq); err != nil || sql != `CREATE SEQUENCE "public"."order_no" AS bigint INCREMENT BY 2 START WITH 1000 CACHE 10 NO CYCLE` {
+		t.Fatalf("sql = %q err = %v", sql, err)
+	}
+	seq.Cycle = true
+	if sql, err := buildAlterSequenceSQL("kingbase", seq); err != nil || sql != `ALTER SEQUENCE "public"."order_no" AS bigint INCREMENT BY 2 START WITH 1000 CACHE 10 CYCLE` {
+		t.Fatalf("sql = %q err = %v", sql, err)
+	}
+	if sql, err := buildRestartSequenceSQL("postgres", seq, "1"); err != nil || sql != `ALTER SEQUENCE "public"."order_no" RESTART WITH 1` {
+		t.Fatalf("sql = %q err = %v", sql, err)
+	}
+
+	ora := connection.SequenceDefinition{Schema: "APP", Name: "SEQ_ORDER", Increment: "1", Cache: "0", MaxValue: "999999"}
+	if sql, err := buildCreateSequenceSQL("oracle", ora); err != nil || sql != `CREATE SEQUENCE "APP"."SEQ_ORDER" INCREMENT BY 1 MAXVALUE 999999 NOCACHE NOCYCLE` {
+		t.Fatalf("sql = %q err = %v", sql, err)
+	}
+	if sql, err := buildRestartSequenceSQL("oracle", ora, "50"); err != nil || sql != `ALTER SEQUENCE "APP"."SEQ_ORDER" RESTART START WITH 50` {
+		t.Fatalf("sql = %q err = %v", sql, err)
+	}
+	ora.StartValue = "5"
+	if _, err := buildAlterSequenceSQL("oracle", ora); err == nil {
+		t.Fatal("expected oracle start value error")
+	}
+
+	if _, err := buildCreateSequenceSQL("postgres", connection.SequenceDefinition{Name: "s", Increment: "1; DROP TABLE t"}); err == nil {
+		t.Fatal("expected invalid number error")
+	}
+	if _, err := buildCreateSequenceSQL("mysql", connection.SequenceDefinition{Name: "s"}); err == nil {
+		t.Fatal("expected unsupported dialect error")
+	}
+}
@@ -0,0 +1,479 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/utils"
+)
+
+const (
+	snapshotTableName    = "snapshot"
+	snapshotInsertBatch  = 500
+	snapshotFileExt      = ".duckdb"
+	snapshotQueryTimeout = 10 * time.Minute
+)
+
+var snapshotNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9_\-\p{Han}]+`)
+
+// QuerySnapshotColumn 快照表字段及推断出的 DuckDB 类型。
+type QuerySnapshotColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// QuerySnapshotInfo 描述一个已落地的本地 DuckDB 快照。
+type QuerySnapshotInfo struct {
+	Name       string                      `json:"name"`
+	FilePath   string                      `json:"filePath"`
+	TableName  string                      `json:"tableName"`
+	RowCount   int                         `json:"rowCount"`
+	Columns    []QuerySnapshotColumn       `json:"columns,omitempty"`
+	SizeBytes  int64                       `json:"sizeBytes"`
+	CreatedAt  int64                       `json:"createdAt"` // Unix milli
+	Connection connection.ConnectionConfig `json:"connection"`
+}
+
+func snapshotDirectory() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "snapshots")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-snapshots")
+}
+
+func snapshotConnectionConfig(filePath string) connection.ConnectionConfig {
+	return connection.ConnectionConfig{Type: "duckdb", Host: filePath}
+}
+
+// SnapshotQueryToDuckDB 执行查询并把结果物化为本地 DuckDB 文件（优先使用驱动返回的列类型，否则按值推断），
+// 返回可直接作为新连接打开的 DuckDB 连接配置，便于离线反复分析而不再访问源库。
+func (a *App) SnapshotQueryToDuckDB(config connection.ConnectionConfig, dbName string, query string, name string) connection.QueryResult {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return connection.QueryResult{Success: false, Message: "查询语句不能为空"}
+	}
+	if supported, reason := db.DriverRuntimeSupportStatus("duckdb"); !supported {
+		return connection.QueryResult{Success: false, Message: reason}
+	}
+
+	runConfig := normalizeRunConfig(config, dbName)
+	if err := checkReadOnlySQL(runConfig, query); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(snapshotQueryTimeout)
+	defer cancel()
+	query = sanitizeSQLForPgLike(runConfig.Type, query)
+	var data []map[string]interface{}
+	var columns, driverTypes []string
+	queryCtx := db.WithColumnTypes(ctx, &driverTypes)
+	if q, ok := dbInst.(interface {
+		QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+	}); ok {
+		data, columns, err = q.QueryContext(queryCtx, query)
+	} else {
+		data, columns, err = dbInst.Query(query)
+	}
+	if err != nil {
+		logger.Error(err, "快照查询失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(query))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if len(columns) == 0 {
+		return connection.QueryResult{Success: false, Message: "查询未返回任何列"}
+	}
+
+	dir := snapshotDirectory()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("创建快照目录失败：%s", err.Error())}
+	}
+	baseName := snapshotNameSanitizer.ReplaceAllString(strings.TrimSpace(name), "_")
+	if baseName == "" {
+		baseName = "query"
+	}
+	filePath := filepath.Join(dir, fmt.Sprintf("%s-%s%s", baseName, time.Now().Format("20060102-150405"), snapshotFileExt))
+
+	snapshotColumns := inferSnapshotColumns(resolveDDLDBType(runConfig), columns, driverTypes, data)
+	if err := writeSnapshotFile(filePath, snapshotColumns, data); err != nil {
+		_ = os.Remove(filePath)
+		logger.Error(err, "写入快照失败：文件=%s", filePath)
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("写入快照失败：%s", err.Error())}
+	}
+
+	info := QuerySnapshotInfo{
+		Name:       baseName,
+		FilePath:   filePath,
+		TableName:  snapshotTableName,
+		RowCount:   len(data),
+		Columns:    snapshotColumns,
+		CreatedAt:  time.Now().UnixMilli(),
+		Connection: snapshotConnectionConfig(filePath),
+	}
+	if stat, statErr := os.Stat(filePath); statErr == nil {
+		info.SizeBytes = stat.Size()
+	}
+	logger.Infof("查询结果已物化为 DuckDB 快照：文件=%s 行数=%d 列数=%d", filePath, len(data), len(columns))
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已生成快照（%d 行）", len(data)), Data: info}
+}
+
+func writeSnapshotFile(filePath string, columns []QuerySnapshotColumn, data []map[string]interface{}) error {
+	target, err := db.NewDatabase("duckdb")
+	if err != nil {
+		return err
+	}
+	if err := target.Connect(snapshotConnectionConfig(filePath)); err != nil {
+		return err
+	}
+	defer target.Close()
+
+	defs := make([]string, len(columns))
+	quotedCols := make([]string, len(columns))
+	for i, col := range columns {
+		quotedCols[i] = quoteIdentByType("duckdb", col.Name)
+		defs[i] = fmt.Sprintf("%s %s", quotedCols[i], col.Type)
+	}
+	if _, err := target.Exec(fmt.Sprintf("CREATE TABLE %s (%s)", quoteIdentByType("duckdb", snapshotTableName), strings.Join(defs, ", "))); err != nil {
+		return fmt.Errorf("创建快照表失败：%w", err)
+	}
+
+	for start := 0; start < len(data); start += snapshotInsertBatch {
+		end := start + snapshotInsertBatch
+		if end > len(data) {
+			end = len(data)
+		}
+		var b strings.Builder
+		b.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quoteIdentByType("duckdb", snapshotTableName), strings.Join(quotedCols, ", ")))
+		for r, row := range data[start:end] {
+			if r > 0 {
+				b.WriteString(", ")
+			}
+			values := make([]string, len(columns))
+			for i, col := range columns {
+				values[i] = formatSnapshotValue(col.Type, row[col.Name])
+			}
+			b.WriteString("(" + strings.Join(values, ", ") + ")")
+		}
+		if _, err := target.Exec(b.String()); err != nil {
+			return fmt.Errorf("写入快照数据失败：%w", err)
+		}
+	}
+	return nil
+}
+
+func formatSnapshotValue(columnType string, value interface{}) string {
+	if value == nil {
+		return "NULL"
+	}
+	switch columnType {
+	case "BOOLEAN":
+		if b, ok := snapshotBool(value); ok {
+			if b {
+				return "TRUE"
+			}
+			return "FALSE"
+		}
+	case "TIMESTAMP":
+		if t, ok := value.(time.Time); ok {
+			return "'" + t.Format("2006-01-02 15:04:05.999999") + "'"
+		}
+	}
+	return formatSQLValue("duckdb", value)
+}
+
+// inferSnapshotColumns 确定快照列的 DuckDB 类型：驱动提供了列类型（driverTypes 与 columns 一一对应）时按列类型映射，
+// 否则按列值推断：BOOLEAN → BIGINT → DOUBLE → DECIMAL → DATE → TIMESTAMP，无法统一时退化为 VARCHAR。
+func inferSnapshotColumns(dbType string, columns []string, driverTypes []string, data []map[string]interface{}) []QuerySnapshotColumn {
+	out := make([]QuerySnapshotColumn, 0, len(columns))
+	seen := make(map[string]int, len(columns))
+	for i, name := range columns {
+		key := strings.ToLower(name)
+		if _, dup := seen[key]; dup {
+			// DuckDB 列名大小写不敏感，重复列只保留第一个
+			continue
+		}
+		seen[key] = len(out)
+		columnType := ""
+		if len(driverTypes) == len(columns) {
+			columnType = snapshotDriverColumnType(dbType, driverTypes[i], name, data)
+		}
+		if columnType == "" {
+			columnType = inferSnapshotColumnType(name, data)
+		}
+		out = append(out, QuerySnapshotColumn{Name: name, Type: columnType})
+	}
+	return out
+}
+
+// snapshotDriverColumnType 把驱动列类型映射为 DuckDB 类型，无法识别时返回空字符串，由调用方按值推断。
+func snapshotDriverColumnType(dbType string, driverType string, column string, data []map[string]interface{}) string {
+	if strings.TrimSpace(driverType) == "" {
+		return ""
+	}
+	parsed, ok := parseTransferColumnType(dbType, driverType)
+	if !ok {
+		return ""
+	}
+	switch parsed.kind {
+	case "bool":
+		return "BOOLEAN"
+	case "int8", "int16", "int32", "int64":
+		return "BIGINT"
+	case "float32", "float64":
+		return "DOUBLE"
+	case "decimal":
+		// 驱动类型名通常不含精度，按实际值确定
+		if decimal, ok := snapshotDecimalType(column, data); ok {
+			return decimal
+		}
+		return "VARCHAR"
+	case "date":
+		return "DATE"
+	case "datetime", "timestamptz":
+		return "TIMESTAMP"
+	case "char", "varchar", "text", "json", "uuid", "time":
+		return "VARCHAR"
+	}
+	return ""
+}
+
+func inferSnapshotColumnType(column string, data []map[string]interface{}) string {
+	candidates := map[string]bool{"BOOLEAN": true, "BIGINT": true, "DOUBLE": true, "DATE": true, "TIMESTAMP": true}
+	nonNull := 0
+	for _, row := range data {
+		value := row[column]
+		if value == nil {
+			continue
+		}
+		nonNull++
+		if _, ok := snapshotBool(value); !ok {
+			candidates["BOOLEAN"] = false
+		}
+		if !snapshotIsInt(value) {
+			candidates["BIGINT"] = false
+		}
+		if !snapshotIsNumber(value) {
+			candidates["DOUBLE"] = false
+		}
+		kind := snapshotTemporalKind(value)
+		if kind != "date" {
+			candidates["DATE"] = false
+		}
+		if kind == "" {
+			candidates["TIMESTAMP"] = false
+		}
+	}
+	if nonNull == 0 {
+		return "VARCHAR"
+	}
+	for _, t := range []string{"BOOLEAN", "BIGINT", "DOUBLE"} {
+		if candidates[t] {
+			return t
+		}
+	}
+	// 有效数字超过 DOUBLE 精度的数值按 DECIMAL 保存
+	if decimal, ok := snapshotDecimalType(column, data); ok {
+		return decimal
+	}
+	for _, t := range []string{"DATE", "TIMESTAMP"} {
+		if candidates[t] {
+			return t
+		}
+	}
+	return "VARCHAR"
+}
+
+func snapshotBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// snapshotMaxDoubleDigits DOUBLE 能精确保存的十进制有效数字位数。
+const snapshotMaxDoubleDigits = 15
+
+func snapshotIsInt(value interface{}) bool {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		return true
+	case string:
+		if _, _, _, ok := snapshotDecimalText(v); !ok {
+			return false
+		}
+		_, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		return err == nil
+	}
+	return false
+}
+
+func snapshotIsNumber(value interface{}) bool {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	case string:
+		if _, _, significant, ok := snapshotDecimalText(v); ok {
+			return significant <= snapshotMaxDoubleDigits
+		}
+		return false
+	}
+	return false
+}
+
+// snapshotDecimalType 列中非空值都是十进制数时返回能容纳它们的 DECIMAL(p,s)，精度超过 DuckDB 上限 38 位时返回 false。
+func snapshotDecimalType(column string, data []map[string]interface{}) (string, bool) {
+	intDigits, scale, nonNull := 1, 0, 0
+	for _, row := range data {
+		value := row[column]
+		if value == nil {
+			continue
+		}
+		var text string
+		switch v := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			text = fmt.Sprintf("%d", v)
+		case string:
+			text = v
+		default:
+			return "", false
+		}
+		i, s, _, ok := snapshotDecimalText(text)
+		if !ok {
+			return "", false
+		}
+		nonNull++
+		intDigits, scale = max(intDigits, i), max(scale, s)
+	}
+	if nonNull == 0 || intDigits+scale > 38 {
+		return "", false
+	}
+	return fmt.Sprintf("DECIMAL(%d,%d)", intDigits+scale, scale), true
+}
+
+// snapshotDecimalText 解析十进制数字文本，返回整数位数、小数位数与有效数字位数。
+// 整数部分带前导零的文本（如 "007"）通常是编号而非数值，返回 false 以保留为 VARCHAR。
+func snapshotDecimalText(text string) (intDigits int, scale int, significant int, ok bool) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		text = text[1:]
+	}
+	intPart, frac, hasDot := strings.Cut(text, ".")
+	if intPart == "" || (hasDot && frac == "") || !snapshotAllDigits(intPart) || !snapshotAllDigits(frac) {
+		return 0, 0, 0, false
+	}
+	if len(intPart) > 1 && intPart[0] == '0' {
+		return 0, 0, 0, false
+	}
+	digits := strings.TrimLeft(intPart+frac, "0")
+	return len(intPart), len(frac), len(digits), true
+}
+
+func snapshotAllDigits(text string) bool {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// snapshotTemporalKind 返回 "date"/"timestamp"，非时间值返回空字符串。
+func snapshotTemporalKind(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return "date"
+		}
+		return "timestamp"
+	case string:
+		raw := strings.TrimSpace(v)
+		if len(raw) == 10 {
+			if _, err := time.Parse("2006-01-02", raw); err == nil {
+				return "date"
+			}
+			return ""
+		}
+		if len(raw) >= 19 {
+			if _, ok := parseTemporalString(raw); ok {
+				return "timestamp"
+			}
+		}
+	}
+	return ""
+}
+
+// ListQuerySnapshots 列出本地已生成的 DuckDB 快照文件。
+func (a *App) ListQuerySnapshots() connection.QueryResult {
+	dir := snapshotDirectory()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return connection.QueryResult{Success: true, Data: []QuerySnapshotInfo{}}
+		}
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	items := make([]QuerySnapshotInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), snapshotFileExt) {
+			continue
+		}
+		stat, statErr := entry.Info()
+		if statErr != nil {
+			continue
+		}
+		filePath := filepath.Join(dir, entry.Name())
+		items = append(items, QuerySnapshotInfo{
+			Name:       strings.TrimSuffix(entry.Name(), snapshotFileExt),
+			FilePath:   filePath,
+			TableName:  snapshotTableName,
+			SizeBytes:  stat.Size(),
+			CreatedAt:  stat.ModTime().UnixMilli(),
+			Connection: snapshotConnectionConfig(filePath),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].CreatedAt > items[j].CreatedAt })
+	return connection.QueryResult{Success: true, Data: items}
+}
+
+// DeleteQuerySnapshot 删除快照文件（仅允许删除快照目录内的文件）。
+func (a *App) DeleteQuerySnapshot(filePath string) connection.QueryResult {
+	dir, err := filepath.Abs(snapshotDirectory())
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	target, err := filepath.Abs(strings.TrimSpace(filePath))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if filepath.Dir(target) != dir || !strings.HasSuffix(target, snapshotFileExt) {
+		return connection.QueryResult{Success: false, Message: "只能删除快照目录中的 DuckDB 文件"}
+	}
+
+	a.closeCachedDatabase(snapshotConnectionConfig(target))
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	_ = os.Remove(target + ".wal")
+	return connection.QueryResult{Success: true, Message: "快照已删除"}
+}
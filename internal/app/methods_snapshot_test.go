@@ -0,0 +1,43 @@
+package app
+
+import "testing"
+
+func TestInferSnapshotColumns_TypesFromValues(t *testing.T) {
+	data := []map[string]interface{}{
+		{"id": "1", "price": "9.50", "born": "2024-01-02", "at": "2024-01-02 03:04:05", "flag": true, "name": "a"},
+		{"id": int64(2), "price": 3, "born": nil, "at": "2024-02-03 04:05:06", "flag": "false", "name": "b"},
+	}
+	got := inferSnapshotColumns("mysql", []string{"id", "price", "born", "at", "flag", "name", "ID"}, nil, data)
+	want := map[string]string{"id": "BIGINT", "price": "DOUBLE", "born": "DATE", "at": "TIMESTAMP", "flag": "BOOLEAN", "name": "VARCHAR"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d columns (duplicate ID dropped), got %d: %#v", len(want), len(got), got)
+	}
+	for _, col := range got {
+		if want[col.Name] != col.Type {
+			t.Fatalf("column %s: want %s, got %s", col.Name, want[col.Name], col.Type)
+		}
+	}
+}
+
+func TestInferSnapshotColumns_KeepsCodesAndPrecision(t *testing.T) {
+	data := []map[string]interface{}{
+		{"code": "007", "amount": "12345678901234567.89", "n": nil, "big": int64(1)},
+		{"code": "12", "amount": "-0.5", "n": nil, "big": "18446744073709551615"},
+	}
+	got := inferSnapshotColumns("mysql", []string{"code", "amount", "n", "big"}, nil, data)
+	want := []string{"VARCHAR", "DECIMAL(19,2)", "VARCHAR", "DECIMAL(20,0)"}
+	for i, col := range got {
+		if col.Type != want[i] {
+			t.Fatalf("column %s: want %s, got %s", col.Name, want[i], col.Type)
+		}
+	}
+
+	// 驱动提供列类型时优先使用，全空列也能得到正确类型
+	got = inferSnapshotColumns("postgres", []string{"code", "amount", "n", "big"}, []string{"VARCHAR", "NUMERIC", "INT8", "TEXT"}, data)
+	want = []string{"VARCHAR", "DECIMAL(19,2)", "BIGINT", "VARCHAR"}
+	for i, col := range got {
+		if col.Type != want[i] {
+			t.Fatalf("driver typed column %s: want %s, got %s", col.Name, want[i], col.Type)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/snippetstore"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ListSnippets 返回 SQL 片段库中的全部片段，按文件夹、名称排序，每条附带解析出的占位符。
+func (a *App) ListSnippets() connection.QueryResult {
+	snippets, err := a.snippets.List()
+	if err != nil {
+		logger.Error(err, "读取 SQL 片段失败")
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: snippets}
+}
+
+// SaveSnippet 新增（ID 为空）或覆盖一条片段。
+func (a *App) SaveSnippet(snippet snippetstore.Snippet) connection.QueryResult {
+	saved, err := a.snippets.Save(snippet)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: saved}
+}
+
+// DeleteSnippet 删除片段。
+func (a *App) DeleteSnippet(id string) connection.QueryResult {
+	if err := a.snippets.Delete(id); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true}
+}
+
+// RenameSnippetFolder 重命名或移动文件夹（含子文件夹）。
+func (a *App) RenameSnippetFolder(from string, to string) connection.QueryResult {
+	moved, err := a.snippets.RenameFolder(from, to)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: map[string]int{"moved": moved}}
+}
+
+// RenderSnippet 用前端收集的变量值替换片段中的 ${name} 占位符，返回可执行的 SQL。
+func (a *App) RenderSnippet(id string, values map[string]string) connection.QueryResult {
+	snippet, err := a.snippets.Get(id)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	rendered, err := snippetstore.Render(snippet.SQL, values)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error(), Data: snippet.Variables}
+	}
+	return connection.QueryResult{Success: true, Data: rendered}
+}
+
+// RunSnippet 替换占位符后按脚本逐条执行片段，结果与 DBQueryScript 相同。
+func (a *App) RunSnippet(config connection.ConnectionConfig, dbName string, id string, values map[string]string, options ScriptOptions) connection.QueryResult {
+	snippet, err := a.snippets.Get(id)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if dbType := strings.TrimSpace(snippet.DBType); dbType != "" && !strings.EqualFold(dbType, config.Type) {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("片段 %s 适用于 %s，当前连接为 %s", snippet.Name, dbType, config.Type)}
+	}
+	rendered, err := snippetstore.Render(snippet.SQL, values)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error(), Data: snippet.Variables}
+	}
+	logger.Infof("执行 SQL 片段：%s 片段=%s", formatConnSummary(normalizeRunConfig(config, dbName)), snippet.Name)
+	return a.DBQueryScript(config, dbName, rendered, options)
+}
+
+// ExportSnippets 将片段（ids 为空时全部）导出为片段文件，供其他用户导入。path 为空时弹出保存对话框。
+func (a *App) ExportSnippets(path string, ids []string) connection.QueryResult {
+	if strings.TrimSpace(path) == "" {
+		filename, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+			Title:           "Export Snippets",
+			DefaultFilename: "gonavi-snippets.json",
+			Filters:         []runtime.FileFilter{{DisplayName: "Snippet Files (*.json)", Pattern: "*.json"}},
+		})
+		if err != nil || filename == "" {
+			return connection.QueryResult{Success: false, Message: "Cancelled"}
+		}
+		path = filename
+	}
+	count, err := a.snippets.Export(path, ids)
+	if err != nil {
+		logger.Error(err, "导出 SQL 片段失败：文件=%s", path)
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	logger.Infof("导出 SQL 片段完成：文件=%s 数量=%d", path, count)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已导出 %d 个片段", count), Data: map[string]interface{}{"path": path, "count": count}}
+}
+
+// ImportSnippets 导入片段文件；ID 相同的片段在 overwrite 为 true 时覆盖，否则另存一份。path 为空时弹出文件选择框。
+func (a *App) ImportSnippets(path string, overwrite bool) connection.QueryResult {
+	if strings.TrimSpace(path) == "" {
+		selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title: "Select Snippet File",
+			Filters: []runtime.FileFilter{
+				{DisplayName: "Snippet Files (*.json)", Pattern: "*.json"},
+				{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+			},
+		})
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		if selection == "" {
+			return connection.QueryResult{Success: false, Message: "Cancelled"}
+		}
+		path = selection
+	}
+	result, err := a.snippets.Import(path, overwrite)
+	if err != nil {
+		logger.Error(err, "导入 SQL 片段失败：文件=%s", path)
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	logger.Infof("导入 SQL 片段完成：文件=%s 新增=%d 覆盖=%d 另存=%d", path, result.Added, result.Replaced, result.Renamed)
+	return connection.QueryResult{Success: true, Data: result}
+}
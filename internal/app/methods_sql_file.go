@@ -0,0 +1,513 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	sqlFileProgressEvent     = "sqlfile:progress"
+	defaultSQLFileBatchSize  = 500
+	maxSQLFileBatchSize      = 10000
+	maxSQLFileErrors         = 100
+	sqlFileProgressInterval  = 300 * time.Millisecond
+	sqlFileReaderBufferBytes = 1024 * 1024
+)
+
+// SQLFileOptions 执行 SQL 文件的选项。
+type SQLFileOptions struct {
+	JobID           string `json:"jobId,omitempty"`           // 进度事件标识，同时可用于 CancelQuery 中止
+	ContinueOnError bool   `json:"continueOnError,omitempty"` // 出错后跳过该语句继续执行
+	BatchSize       int    `json:"batchSize,omitempty"`       // 每个事务包含的 DML 语句数，默认 500
+	NoTransaction   bool   `json:"noTransaction,omitempty"`   // 逐条自动提交，不合并事务
+}
+
+// SQLFileError 单条语句的执行错误。
+type SQLFileError struct {
+	Line      int    `json:"line"`
+	Statement string `json:"statement"`
+	Message   string `json:"message"`
+}
+
+// SQLFileResult 执行统计。
+type SQLFileResult struct {
+	FilePath   string         `json:"filePath"`
+	Statements int            `json:"statements"`
+	Succeeded  int            `json:"succeeded"`
+	Failed     int            `json:"failed"`
+	Affected   int64          `json:"affected"`
+	BytesRead  int64          `json:"bytesRead"`
+	TotalBytes int64          `json:"totalBytes"`
+	DurationMs int64          `json:"durationMs"`
+	Canceled   bool           `json:"canceled,omitempty"`
+	Errors     []SQLFileError `json:"errors,omitempty"`
+}
+
+// sqlFileTxStatements 返回可合并事务的方言的 BEGIN 语句；Oracle/达梦等 BEGIN 会被当作 PL/SQL 块，按自动提交执行。
+func sqlFileTxStatements(dbType string) string {
+	switch strings.ToLower(strings.TrimSpace(dbType)) {
+	case "mysql", "mariadb", "diros":
+		return "START TRANSACTION"
+	case "postgres", "kingbase", "highgo", "vastbase", "sqlite":
+		return "BEGIN"
+	case "sqlserver":
+		return "BEGIN TRANSACTION"
+	}
+	return ""
+}
+
+// sqlFileStatementKind 粗分语句类别：dml 可合并进批量事务，tx 为脚本自带的事务控制，其余（DDL 等）单独执行。
+func sqlFileStatementKind(stmt string) string {
+	word := strings.ToLower(firstSQLWord(stmt))
+	switch word {
+	case "insert", "update", "delete", "replace", "merge", "upsert":
+		return "dml"
+	case "begin", "start", "commit", "rollback", "end", "savepoint", "release":
+		return "tx"
+	case "set":
+		if strings.Contains(strings.ToLower(stmt), "autocommit") {
+			return "tx"
+		}
+	}
+	return "other"
+}
+
+// firstSQLWord 跳过前导空白与注释，返回第一个单词。
+func firstSQLWord(stmt string) string {
+	s := stmt
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		switch {
+		case strings.HasPrefix(s, "--"), strings.HasPrefix(s, "#"):
+			idx := strings.IndexByte(s, '\n')
+			if idx < 0 {
+				return ""
+			}
+			s = s[idx+1:]
+			continue
+		case strings.HasPrefix(s, "/*"):
+			idx := strings.Index(s, "*/")
+			if idx < 0 {
+				return ""
+			}
+			s = s[idx+2:]
+			continue
+		}
+		break
+	}
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
+
+type sqlFileStatement struct {
+	text string
+	line int
+}
+
+// sqlFileRunner 按批执行拆分出的语句：DML 合并进事务，批内出错时回滚，
+// ContinueOnError 下再逐条重放以定位并跳过失败语句。
+type sqlFileRunner struct {
+	ctx     context.Context
+	exec    func(ctx context.Context, query string) (int64, error)
+	txBegin string // 为空时逐条自动提交
+	opts    SQLFileOptions
+	result  *SQLFileResult
+	onError func(stmt sqlFileStatement, err error)
+	// onExec 每条脚本语句执行后调用（批内语句在整批提交后调用），不包括事务控制语句
+	onExec func(stmt sqlFileStatement, started time.Time, affected int64, err error)
+
+	batch   []sqlFileStatement
+	stopped bool
+	err     error // 导致中止的错误
+}
+
+func (r *sqlFileRunner) recordError(stmt sqlFileStatement, err error) {
+	r.result.Failed++
+	if len(r.result.Errors) < maxSQLFileErrors {
+		r.result.Errors = append(r.result.Errors, SQLFileError{Line: stmt.line, Statement: sqlSnippet(stmt.text), Message: err.Error()})
+	}
+	if r.onError != nil {
+		r.onError(stmt, err)
+	}
+	if !r.opts.ContinueOnError {
+		r.stop(fmt.Errorf("第 %d 行语句执行失败：%w", stmt.line, err))
+	}
+}
+
+func (r *sqlFileRunner) stop(err error) {
+	r.stopped = true
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// checkCanceled 被取消时中止执行并返回 true。
+func (r *sqlFileRunner) checkCanceled() bool {
+	if r.ctx.Err() == nil {
+		return false
+	}
+	r.result.Canceled = true
+	r.stop(errQueryCanceled)
+	return true
+}
+
+// execOne 自动提交执行一条语句。
+func (r *sqlFileRunner) execOne(stmt sqlFileStatement) {
+	if r.checkCanceled() {
+		return
+	}
+	started := time.Now()
+	affected, err := r.exec(r.ctx, stmt.text)
+	if r.onExec != nil {
+		r.onExec(stmt, started, affected, err)
+	}
+	if err != nil {
+		if r.checkCanceled() {
+			return
+		}
+		r.recordError(stmt, err)
+		return
+	}
+	r.result.Succeeded++
+	r.result.Affected += affected
+}
+
+func (r *sqlFileRunner) add(stmt sqlFileStatement) {
+	if r.stopped {
+		return
+	}
+	r.result.Statements++
+	switch sqlFileStatementKind(stmt.text) {
+	case "dml":
+		if r.txBegin == "" {
+			r.execOne(stmt)
+			return
+		}
+		r.batch = append(r.batch, stmt)
+		if len(r.batch) >= r.opts.BatchSize {
+			r.flush()
+		}
+	case "tx":
+		// 脚本自行管理事务时不再额外包裹事务，避免嵌套 BEGIN 被隐式提交
+		r.flush()
+		r.txBegin = ""
+		r.execOne(stmt)
+	default:
+		r.flush()
+		r.execOne(stmt)
+	}
+}
+
+// flush 以一个事务提交当前批次。
+func (r *sqlFileRunner) flush() {
+	batch := r.batch
+	r.batch = nil
+	if len(batch) == 0 || r.stopped {
+		return
+	}
+	if len(batch) == 1 || r.txBegin == "" {
+		for _, stmt := range batch {
+			if r.stopped {
+				return
+			}
+			r.execOne(stmt)
+		}
+		return
+	}
+	if r.checkCanceled() {
+		return
+	}
+	if _, err := r.exec(r.ctx, r.txBegin); err != nil {
+		// 无法开启事务时退化为逐条执行
+		logger.Warnf("执行 SQL 文件开启事务失败，改为逐条执行：%v", err)
+		r.txBegin = ""
+		r.batch = batch
+		r.flush()
+		return
+	}
+	var affected int64
+	var failed error
+	started := make([]time.Time, len(batch))
+	counts := make([]int64, len(batch))
+	for i, stmt := range batch {
+		started[i] = time.Now()
+		n, err := r.exec(r.ctx, stmt.text)
+		if err != nil {
+			failed = err
+			break
+		}
+		counts[i] = n
+		affected += n
+	}
+	if failed == nil {
+		if _, err := r.exec(r.ctx, "COMMIT"); err != nil {
+			failed = err
+		}
+	}
+	if failed == nil {
+		r.result.Succeeded += len(batch)
+		r.result.Affected += affected
+		if r.onExec != nil {
+			for i, stmt := range batch {
+				r.onExec(stmt, started[i], counts[i], nil)
+			}
+		}
+		return
+	}
+	_, _ = r.exec(context.Background(), "ROLLBACK")
+	if r.checkCanceled() {
+		return
+	}
+	// 整批已回滚，逐条重放以定位失败语句；未开启 ContinueOnError 时在第一条失败处中止
+	for _, stmt := range batch {
+		if r.stopped {
+			return
+		}
+		r.execOne(stmt)
+	}
+}
+
+// finish 提交剩余批次。
+func (r *sqlFileRunner) finish() {
+	r.flush()
+}
+
+// ExecuteSQLFile 流式执行 SQL 文件，不把整个文件载入编辑器。
+func (a *App) ExecuteSQLFile(config connection.ConnectionConfig, dbName string, filePath string) connection.QueryResult {
+	return a.ExecuteSQLFileWithOptions(config, dbName, filePath, SQLFileOptions{})
+}
+
+// ExecuteSQLFileWithOptions 逐行读取文件并按方言拆分语句（识别 DELIMITER、GO、PL/SQL “/”、字符串与注释），
+// DML 按 BatchSize 合并进事务执行，DDL 单独执行；执行期间发送 sqlfile:progress 进度事件，
+// 指定 JobID 时可通过 CancelQuery 中止。
+func (a *App) ExecuteSQLFileWithOptions(config connection.ConnectionConfig, dbName string, filePath string, opts SQLFileOptions) connection.QueryResult {
+	if err := ensureWritable(config, "执行 SQL 文件"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	dbType := strings.ToLower(strings.TrimSpace(runConfig.Type))
+	switch dbType {
+	case "mongodb", "redis":
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("当前数据源(%s)不支持执行 SQL 文件", dbType)}
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultSQLFileBatchSize
+	}
+	if opts.BatchSize > maxSQLFileBatchSize {
+		opts.BatchSize = maxSQLFileBatchSize
+	}
+	opts.JobID = strings.TrimSpace(opts.JobID)
+
+	filename := strings.TrimSpace(filePath)
+	if filename == "" {
+		selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title: "Select SQL File",
+			Filters: []runtime.FileFilter{
+				{DisplayName: "SQL Files (*.sql)", Pattern: "*.sql"},
+				{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+			},
+		})
+		if err != nil || selection == "" {
+			return connection.QueryResult{Success: false, Message: "Cancelled"}
+		}
+		filename = selection
+	}
+
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		logger.Error(err, "ExecuteSQLFile 获取连接失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	ctx := context.Background()
+	var tracked *runningQuery
+	if opts.JobID != "" {
+		ctx, tracked, err = a.registerQuery(ctx, opts.JobID, runConfig)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		defer a.unregisterQuery(tracked)
+	}
+
+	// 事务语句必须落在同一物理连接上，因此优先检出专用会话
+	var session *db.Session
+	if tracked != nil {
+		session = a.trackedSession(ctx, tracked, dbInst)
+	}
+	if session == nil {
+		if opener, ok := dbInst.(db.SessionOpener); ok {
+			if s, err := opener.OpenSession(ctx); err == nil {
+				session = s
+			}
+		}
+	}
+	var exec func(ctx context.Context, query string) (int64, error)
+	txBegin := ""
+	if session != nil {
+		exec = session.ExecContext
+		if !opts.NoTransaction {
+			txBegin = sqlFileTxStatements(dbType)
+		}
+	} else if e, ok := dbInst.(interface {
+		ExecContext(context.Context, string) (int64, error)
+	}); ok {
+		exec = e.ExecContext
+	} else {
+		exec = func(_ context.Context, query string) (int64, error) { return dbInst.Exec(query) }
+	}
+
+	if a.wireLog.isEnabled(runConfig) {
+		inner := exec
+		exec = func(ctx context.Context, query string) (int64, error) {
+			started := time.Now()
+			affected, err := inner(ctx, query)
+			a.wireLog.record(runConfig, dbName, "exec", query, started, affected, err)
+			return affected, err
+		}
+	}
+
+	record := func(stmt sqlFileStatement, started time.Time, affected int64, err error) {
+		a.recordHistory(runConfig, dbName, "exec", stmt.text, started, affected, err)
+	}
+	result, err := a.runSQLFile(ctx, filename, dbType, exec, txBegin, opts, record)
+	if session != nil {
+		if tracked != nil {
+			a.finishTrackedSession(tracked, session, err)
+		} else if err != nil {
+			_ = session.Close()
+		} else {
+			_ = session.Release()
+		}
+	}
+	if result.Canceled {
+		logger.Infof("执行 SQL 文件已取消：%s 文件=%s 已执行=%d", formatConnSummary(runConfig), filename, result.Succeeded)
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("已取消：成功执行 %d 条语句", result.Succeeded), Data: result}
+	}
+	if err != nil {
+		logger.Error(err, "ExecuteSQLFile 执行失败：%s 文件=%s", formatConnSummary(runConfig), filename)
+		return connection.QueryResult{Success: false, Message: err.Error(), Data: result}
+	}
+	logger.Infof("执行 SQL 文件完成：%s 文件=%s 语句=%d 失败=%d 耗时=%dms", formatConnSummary(runConfig), filename, result.Statements, result.Failed, result.DurationMs)
+	msg := fmt.Sprintf("执行完成：成功 %d 条，影响 %d 行", result.Succeeded, result.Affected)
+	if result.Failed > 0 {
+		msg = fmt.Sprintf("执行完成：成功 %d 条，失败 %d 条", result.Succeeded, result.Failed)
+	}
+	return connection.QueryResult{Success: result.Failed == 0, Message: msg, Data: result}
+}
+
+// runSQLFile 流式读取并执行文件；返回的 error 为导致中止的错误（读取失败、语句失败且未开启 ContinueOnError）。
+// record 在每条语句执行后调用，用于写入执行历史，可为 nil。
+func (a *App) runSQLFile(ctx context.Context, filename string, dbType string, exec func(context.Context, string) (int64, error), txBegin string, opts SQLFileOptions, record func(sqlFileStatement, time.Time, int64, error)) (*SQLFileResult, error) {
+	started := time.Now()
+	result := &SQLFileResult{FilePath: filename}
+	defer func() { result.DurationMs = time.Since(started).Milliseconds() }()
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return result, err
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil {
+		result.TotalBytes = info.Size()
+	}
+
+	// T-SQL 需先确认脚本是否使用 GO 分批，以决定批内分号是否拆分
+	goBatches := false
+	if dbType == "sqlserver" {
+		goBatches, err = sqlFileHasGoBatches(f)
+		if err != nil {
+			return result, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return result, err
+		}
+	}
+
+	var lastEmit time.Time
+	line := 0
+	emitProgress := func(force bool) {
+		if a.ctx == nil || (!force && time.Since(lastEmit) < sqlFileProgressInterval) {
+			return
+		}
+		lastEmit = time.Now()
+		runtime.EventsEmit(a.ctx, sqlFileProgressEvent, map[string]interface{}{
+			"jobId":      opts.JobID,
+			"bytesRead":  result.BytesRead,
+			"totalBytes": result.TotalBytes,
+			"statements": result.Statements,
+			"succeeded":  result.Succeeded,
+			"failed":     result.Failed,
+			"line":       line,
+		})
+	}
+
+	runner := &sqlFileRunner{ctx: ctx, exec: exec, txBegin: txBegin, opts: opts, result: result, onExec: record}
+	runner.onError = func(stmt sqlFileStatement, err error) {
+		logger.Warnf("执行 SQL 文件语句失败：第 %d 行 原因=%v SQL片段=%q", stmt.line, err, sqlSnippet(stmt.text))
+	}
+	splitter := sqlstmt.NewSplitter(dbType, goBatches, func(stmt string, stmtLine int) {
+		runner.add(sqlFileStatement{text: sanitizeSQLForPgLike(dbType, stmt), line: stmtLine})
+	})
+
+	reader := bufio.NewReaderSize(f, sqlFileReaderBufferBytes)
+	for !runner.stopped {
+		text, readErr := reader.ReadString('\n')
+		result.BytesRead += int64(len(text))
+		if line == 0 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if text != "" {
+			line++
+			splitter.FeedLine(strings.Replace(text, "\r\n", "\n", 1))
+			emitProgress(false)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			runner.stop(readErr)
+			break
+		}
+	}
+	if !runner.stopped {
+		splitter.Finish()
+		runner.finish()
+	}
+	emitProgress(true)
+	return result, runner.err
+}
+
+// sqlFileHasGoBatches 扫描文件是否包含 GO 批分隔行。
+func sqlFileHasGoBatches(r io.Reader) (bool, error) {
+	reader := bufio.NewReaderSize(r, sqlFileReaderBufferBytes)
+	for {
+		text, err := reader.ReadString('\n')
+		if sqlstmt.IsGoSeparator(text) {
+			return true, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
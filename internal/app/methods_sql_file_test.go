@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSQLFileExec 记录执行的语句；包含 fail 子串的语句返回错误。
+type fakeSQLFileExec struct {
+	executed []string
+	fail     string
+}
+
+func (f *fakeSQLFileExec) exec(_ context.Context, query string) (int64, error) {
+	f.executed = append(f.executed, query)
+	if f.fail != "" && strings.Contains(query, f.fail) {
+		return 0, errors.New("boom")
+	}
+	return 1, nil
+}
+
+func writeSQLFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "script.sql")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunSQLFileBatchesDMLInTransactions(t *testing.T) {
+	path := writeSQLFile(t, "\ufeffCREATE TABLE t (id int);\r\nINSERT INTO t VALUES (1);\nINSERT INTO t VALUES (2);\nINSERT INTO t VALUES (3);\n-- tail\n")
+	fake := &fakeSQLFileExec{}
+	app := &App{}
+	result, err := app.runSQLFile(context.Background(), path, "mysql", fake.exec, "START TRANSACTION", SQLFileOptions{BatchSize: 2}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"CREATE TABLE t (id int)",
+		"START TRANSACTION", "INSERT INTO t VALUES (1)", "INSERT INTO t VALUES (2)", "COMMIT",
+		"INSERT INTO t VALUES (3)",
+	}
+	if !reflect.DeepEqual(fake.executed, want) {
+		t.Fatalf("executed = %q, want %q", fake.executed, want)
+	}
+	if result.Statements != 4 || result.Succeeded != 4 || result.Affected != 4 || result.BytesRead != result.TotalBytes {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestRunSQLFileContinueOnErrorReplaysBatch(t *testing.T) {
+	path := writeSQLFile(t, "INSERT INTO t VALUES (1);\nINSERT INTO t VALUES ('bad');\nINSERT INTO t VALUES (3);\n")
+	fake := &fakeSQLFileExec{fail: "'bad'"}
+	app := &App{}
+	var recorded []string
+	record := func(stmt sqlFileStatement, _ time.Time, _ int64, err error) {
+		recorded = append(recorded, fmt.Sprintf("%s ok=%v", stmt.text, err == nil))
+	}
+	result, err := app.runSQLFile(context.Background(), path, "postgres", fake.exec, "BEGIN", SQLFileOptions{BatchSize: 10, ContinueOnError: true}, record)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 回滚的批次不记录，只记录逐条重放的结果
+	wantRecorded := []string{"INSERT INTO t VALUES (1) ok=true", "INSERT INTO t VALUES ('bad') ok=false", "INSERT INTO t VALUES (3) ok=true"}
+	if !reflect.DeepEqual(recorded, wantRecorded) {
+		t.Fatalf("recorded = %q", recorded)
+	}
+	if result.Succeeded != 2 || result.Failed != 1 || len(result.Errors) != 1 || result.Errors[0].Line != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if !reflect.DeepEqual(fake.executed[2:4], []string{"INSERT INTO t VALUES ('bad')", "ROLLBACK"}) {
+		t.Fatalf("batch should roll back after failure: %q", fake.executed)
+	}
+}
+
+func TestRunSQLFileStopsOnFirstError(t *testing.T) {
+	path := writeSQLFile(t, "CREATE TABLE a (id int);\nCREATE TABLE bad (id int);\nCREATE TABLE c (id int);\n")
+	fake := &fakeSQLFileExec{fail: "bad"}
+	app := &App{}
+	result, err := app.runSQLFile(context.Background(), path, "sqlite", fake.exec, "BEGIN", SQLFileOptions{BatchSize: 10}, nil)
+	if err == nil || !strings.Contains(err.Error(), "第 2 行") {
+		t.Fatalf("expected line 2 error, got %v", err)
+	}
+	if len(fake.executed) != 2 || result.Succeeded != 1 || result.Failed != 1 {
+		t.Fatalf("unexpected result: %+v executed=%q", result, fake.executed)
+	}
+}
+
+func TestRunSQLFileScriptTransactionDisablesBatching(t *testing.T) {
+	path := writeSQLFile(t, "BEGIN;\nINSERT INTO t VALUES (1);\nINSERT INTO t VALUES (2);\nCOMMIT;\n")
+	fake := &fakeSQLFileExec{}
+	app := &App{}
+	if _, err := app.runSQLFile(context.Background(), path, "postgres", fake.exec, "BEGIN", SQLFileOptions{BatchSize: 10}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"BEGIN", "INSERT INTO t VALUES (1)", "INSERT INTO t VALUES (2)", "COMMIT"}
+	if !reflect.DeepEqual(fake.executed, want) {
+		t.Fatalf("executed = %q, want %q", fake.executed, want)
+	}
+}
@@ -0,0 +1,399 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+	"GoNavi-Wails/internal/utils"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// SQL 变更计划（预览 → 确认 → 执行 → 报告）。
+// 表设计器、结构对比、导入、同步、用户管理等所有会生成 DDL/DML 的功能，
+// 统一先登记为计划供用户预览，再由用户选择“执行”或“复制 SQL”。
+
+const (
+	sqlPlanTTL         = 30 * time.Minute
+	sqlPlanMaxPlans    = 64
+	sqlPlanModeExecute = "execute"
+	sqlPlanModeCopy    = "copy"
+
+	sqlPlanProgressEvent = "sqlplan:progress"
+)
+
+var sqlPlanSeq uint64
+
+// SQLPlanRequest 由各功能模块提交的待确认 SQL。
+type SQLPlanRequest struct {
+	Config      connection.ConnectionConfig `json:"config"`
+	DBName      string                      `json:"dbName"`
+	Source      string                      `json:"source"` // table_designer/schema_diff/import/sync/user_management...
+	Title       string                      `json:"title,omitempty"`
+	Statements  []string                    `json:"statements"`
+	StopOnError bool                        `json:"stopOnError,omitempty"`
+}
+
+// SQLPlanPreview 返回给前端用于确认的计划内容。
+type SQLPlanPreview struct {
+	PlanID         string   `json:"planId"`
+	Source         string   `json:"source"`
+	Title          string   `json:"title,omitempty"`
+	Statements     []string `json:"statements"`
+	StatementCount int      `json:"statementCount"`
+	Script         string   `json:"script"`
+	StopOnError    bool     `json:"stopOnError"`
+	ExpiresAt      int64    `json:"expiresAt"` // Unix milli
+}
+
+type SQLPlanStatementResult struct {
+	Index        int    `json:"index"`
+	SQL          string `json:"sql"`
+	Success      bool   `json:"success"`
+	Skipped      bool   `json:"skipped,omitempty"`
+	AffectedRows int64  `json:"affectedRows"`
+	Error        string `json:"error,omitempty"`
+	DurationMs   int64  `json:"durationMs"`
+}
+
+// SQLPlanReport 为计划确认后的执行报告；复制模式下仅包含 Script。
+type SQLPlanReport struct {
+	PlanID     string                   `json:"planId"`
+	Source     string                   `json:"source"`
+	Mode       string                   `json:"mode"`
+	Success    bool                     `json:"success"`
+	Total      int                      `json:"total"`
+	Executed   int                      `json:"executed"`
+	Failed     int                      `json:"failed"`
+	Skipped    int                      `json:"skipped"`
+	Affected   int64                    `json:"affectedRows"`
+	Results    []SQLPlanStatementResult `json:"results,omitempty"`
+	Script     string                   `json:"script,omitempty"`
+	DurationMs int64                    `json:"durationMs"`
+}
+
+type sqlPlan struct {
+	id          string
+	source      string
+	title       string
+	config      connection.ConnectionConfig
+	dbName      string
+	statements  []string
+	stopOnError bool
+	createdAt   time.Time
+	// runner 非空时由生成计划的模块自行执行（如参数化批量导入），statements 与其实际执行的语句一致，用于预览与复制
+	runner sqlPlanRunner
+}
+
+// sqlPlanRunner 执行计划并返回报告，PlanID、Source、Mode 与耗时由 executeSQLPlan 填写。
+type sqlPlanRunner func(plan *sqlPlan) SQLPlanReport
+
+// dbType 计划目标库的方言，用于拼接脚本。
+func (p *sqlPlan) dbType() string {
+	return resolveDDLDBType(p.config)
+}
+
+func (p *sqlPlan) preview() SQLPlanPreview {
+	return SQLPlanPreview{
+		PlanID:         p.id,
+		Source:         p.source,
+		Title:          p.title,
+		Statements:     p.statements,
+		StatementCount: len(p.statements),
+		Script:         buildSQLPlanScript(p.dbType(), p.statements),
+		StopOnError:    p.stopOnError,
+		ExpiresAt:      p.createdAt.Add(sqlPlanTTL).UnixMilli(),
+	}
+}
+
+// normalizeSQLPlanStatements 去除空语句与末尾的一个分号，保证逐条执行时语句完整独立。
+// 过程块（PL/SQL、CREATE PROCEDURE/TRIGGER ... END;）结尾的分号属于语句本身，原样保留。
+func normalizeSQLPlanStatements(dbType string, statements []string) []string {
+	out := make([]string, 0, len(statements))
+	for _, stmt := range statements {
+		trimmed := strings.TrimSpace(stmt)
+		if strings.Trim(trimmed, "; \t\r\n") == "" {
+			continue
+		}
+		if !sqlstmt.IsBlock(dbType, trimmed) {
+			trimmed = strings.TrimSpace(strings.TrimSuffix(trimmed, ";"))
+		}
+		out = append(out, trimmed)
+	}
+	return out
+}
+
+// buildSQLPlanScript 拼接为可在客户端执行的脚本；Oracle/达梦的过程块后另起一行 “/” 结束。
+func buildSQLPlanScript(dbType string, statements []string) string {
+	var b strings.Builder
+	for _, stmt := range statements {
+		b.WriteString(ensureSQLTerminator(stmt))
+		b.WriteString("\n")
+		if (dbType == "oracle" || dbType == "dameng") && sqlstmt.IsBlock(dbType, stmt) {
+			b.WriteString("/\n")
+		}
+	}
+	return b.String()
+}
+
+// registerSQLPlan 登记计划并返回预览；各功能模块在生成 SQL 后调用。runner 为空时确认后逐条执行 statements。
+func (a *App) registerSQLPlan(req SQLPlanRequest, runner sqlPlanRunner) (SQLPlanPreview, error) {
+	statements := normalizeSQLPlanStatements(resolveDDLDBType(req.Config), req.Statements)
+	if len(statements) == 0 {
+		return SQLPlanPreview{}, fmt.Errorf("没有需要执行的 SQL")
+	}
+	for _, stmt := range statements {
+		if err := checkReadOnlySQL(req.Config, stmt); err != nil {
+			return SQLPlanPreview{}, err
+		}
+	}
+	source := strings.TrimSpace(req.Source)
+	if source == "" {
+		source = "manual"
+	}
+
+	now := time.Now()
+	plan := &sqlPlan{
+		id:          fmt.Sprintf("plan-%d-%d", now.UnixNano(), atomic.AddUint64(&sqlPlanSeq, 1)),
+		source:      source,
+		title:       strings.TrimSpace(req.Title),
+		config:      req.Config,
+		dbName:      req.DBName,
+		statements:  statements,
+		stopOnError: req.StopOnError,
+		createdAt:   now,
+		runner:      runner,
+	}
+
+	a.sqlPlanMu.Lock()
+	defer a.sqlPlanMu.Unlock()
+	if a.sqlPlans == nil {
+		a.sqlPlans = make(map[string]*sqlPlan)
+	}
+	a.pruneSQLPlansLocked(now)
+	a.sqlPlans[plan.id] = plan
+	return plan.preview(), nil
+}
+
+// pruneSQLPlansLocked 清理过期计划，并在数量超限时淘汰最早的计划。调用方需持有 sqlPlanMu。
+func (a *App) pruneSQLPlansLocked(now time.Time) {
+	for id, plan := range a.sqlPlans {
+		if now.Sub(plan.createdAt) > sqlPlanTTL {
+			delete(a.sqlPlans, id)
+		}
+	}
+	for len(a.sqlPlans) >= sqlPlanMaxPlans {
+		var oldestID string
+		var oldest time.Time
+		for id, plan := range a.sqlPlans {
+			if oldestID == "" || plan.createdAt.Before(oldest) {
+				oldestID, oldest = id, plan.createdAt
+			}
+		}
+		delete(a.sqlPlans, oldestID)
+	}
+}
+
+func (a *App) takeSQLPlan(planID string) (*sqlPlan, bool) {
+	a.sqlPlanMu.Lock()
+	defer a.sqlPlanMu.Unlock()
+	a.pruneSQLPlansLocked(time.Now())
+	plan, ok := a.sqlPlans[planID]
+	if ok {
+		delete(a.sqlPlans, planID)
+	}
+	return plan, ok
+}
+
+// PreviewSQLPlan 登记一组待执行 SQL，返回预览供用户确认。
+func (a *App) PreviewSQLPlan(req SQLPlanRequest) connection.QueryResult {
+	preview, err := a.registerSQLPlan(req, nil)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: "OK", Data: preview}
+}
+
+// attachSQLPlan 把生成器产出的语句登记为计划，随生成结果返回，前端据此走统一的确认（执行或复制）流程。
+// 没有语句时返回 nil；登记失败（如目标为只读连接）时不附带计划，原因追加到 warnings。
+func (a *App) attachSQLPlan(req SQLPlanRequest, warnings *[]string) *SQLPlanPreview {
+	if len(normalizeSQLPlanStatements(req.Config.Type, req.Statements)) == 0 {
+		return nil
+	}
+	preview, err := a.registerSQLPlan(req, nil)
+	if err != nil {
+		*warnings = append(*warnings, "未登记执行计划："+err.Error())
+		return nil
+	}
+	return &preview
+}
+
+// GetSQLPlan 重新获取尚未确认的计划预览。
+func (a *App) GetSQLPlan(planID string) connection.QueryResult {
+	a.sqlPlanMu.Lock()
+	a.pruneSQLPlansLocked(time.Now())
+	plan, ok := a.sqlPlans[strings.TrimSpace(planID)]
+	a.sqlPlanMu.Unlock()
+	if !ok {
+		return connection.QueryResult{Success: false, Message: "计划不存在或已过期，请重新生成"}
+	}
+	return connection.QueryResult{Success: true, Message: "OK", Data: plan.preview()}
+}
+
+// ConfirmSQLPlan 确认计划：mode=execute 逐条执行并返回报告；mode=copy 仅复制 SQL 到剪贴板，不执行。
+func (a *App) ConfirmSQLPlan(planID string, mode string) connection.QueryResult {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = sqlPlanModeExecute
+	}
+	if mode != sqlPlanModeExecute && mode != sqlPlanModeCopy {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("不支持的确认方式：%s", mode)}
+	}
+
+	plan, ok := a.takeSQLPlan(strings.TrimSpace(planID))
+	if !ok {
+		return connection.QueryResult{Success: false, Message: "计划不存在或已过期，请重新生成"}
+	}
+
+	if mode == sqlPlanModeCopy {
+		script := buildSQLPlanScript(plan.dbType(), plan.statements)
+		if a.ctx != nil {
+			if err := runtime.ClipboardSetText(a.ctx, script); err != nil {
+				logger.Warnf("复制 SQL 到剪贴板失败：计划=%s 错误=%v", plan.id, err)
+			}
+		}
+		report := SQLPlanReport{
+			PlanID:  plan.id,
+			Source:  plan.source,
+			Mode:    sqlPlanModeCopy,
+			Success: true,
+			Total:   len(plan.statements),
+			Skipped: len(plan.statements),
+			Script:  script,
+		}
+		return connection.QueryResult{Success: true, Message: "SQL 已复制，未执行", Data: report}
+	}
+
+	report := a.executeSQLPlan(plan)
+	msg := fmt.Sprintf("执行完成：成功 %d 条，失败 %d 条，跳过 %d 条", report.Executed, report.Failed, report.Skipped)
+	return connection.QueryResult{Success: report.Success, Message: msg, Data: report}
+}
+
+// DiscardSQLPlan 放弃计划。
+func (a *App) DiscardSQLPlan(planID string) connection.QueryResult {
+	if _, ok := a.takeSQLPlan(strings.TrimSpace(planID)); !ok {
+		return connection.QueryResult{Success: false, Message: "计划不存在或已过期"}
+	}
+	return connection.QueryResult{Success: true, Message: "计划已取消"}
+}
+
+func (a *App) executeSQLPlan(plan *sqlPlan) SQLPlanReport {
+	start := time.Now()
+	if plan.runner != nil {
+		report := plan.runner(plan)
+		report.PlanID, report.Source, report.Mode = plan.id, plan.source, sqlPlanModeExecute
+		report.DurationMs = time.Since(start).Milliseconds()
+		// 执行器不单独计时每批语句，按整个计划的开始时间记录
+		runConfig := normalizeRunConfig(plan.config, plan.dbName)
+		for _, item := range report.Results {
+			if item.Skipped {
+				continue
+			}
+			var itemErr error
+			if !item.Success {
+				itemErr = errors.New(item.Error)
+			}
+			a.recordHistory(runConfig, plan.dbName, "exec", item.SQL, start, item.AffectedRows, itemErr)
+		}
+		return report
+	}
+	report := SQLPlanReport{
+		PlanID:  plan.id,
+		Source:  plan.source,
+		Mode:    sqlPlanModeExecute,
+		Total:   len(plan.statements),
+		Results: make([]SQLPlanStatementResult, 0, len(plan.statements)),
+	}
+
+	runConfig := normalizeRunConfig(plan.config, plan.dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		logger.Error(err, "执行 SQL 计划获取连接失败：计划=%s 来源=%s %s", plan.id, plan.source, formatConnSummary(runConfig))
+		for i, stmt := range plan.statements {
+			report.Results = append(report.Results, SQLPlanStatementResult{Index: i, SQL: stmt, Skipped: true, Error: err.Error()})
+		}
+		report.Skipped = len(plan.statements)
+		report.DurationMs = time.Since(start).Milliseconds()
+		return report
+	}
+
+	timeoutSeconds := runConfig.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 30
+	}
+
+	logger.Infof("开始执行 SQL 计划：计划=%s 来源=%s 语句数=%d %s", plan.id, plan.source, len(plan.statements), formatConnSummary(runConfig))
+	stopped := false
+	for i, stmt := range plan.statements {
+		item := SQLPlanStatementResult{Index: i, SQL: stmt}
+		if stopped {
+			item.Skipped = true
+			report.Skipped++
+			report.Results = append(report.Results, item)
+			continue
+		}
+
+		stmtStart := time.Now()
+		affected, execErr := execSQLPlanStatement(dbInst, runConfig.Type, stmt, time.Duration(timeoutSeconds)*time.Second)
+		item.DurationMs = time.Since(stmtStart).Milliseconds()
+		a.recordHistory(runConfig, plan.dbName, "exec", stmt, stmtStart, affected, execErr)
+		if execErr != nil {
+			item.Error = normalizeErrorMessage(execErr)
+			report.Failed++
+			logger.Error(execErr, "SQL 计划语句执行失败：计划=%s 序号=%d SQL片段=%q", plan.id, i+1, sqlSnippet(stmt))
+			if plan.stopOnError {
+				stopped = true
+			}
+		} else {
+			item.Success = true
+			item.AffectedRows = affected
+			report.Executed++
+			report.Affected += affected
+		}
+		report.Results = append(report.Results, item)
+
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, sqlPlanProgressEvent, map[string]interface{}{
+				"planId":  plan.id,
+				"current": i + 1,
+				"total":   len(plan.statements),
+				"failed":  report.Failed,
+			})
+		}
+	}
+
+	report.Success = report.Failed == 0 && report.Skipped == 0
+	report.DurationMs = time.Since(start).Milliseconds()
+	logger.Infof("SQL 计划执行结束：计划=%s 成功=%d 失败=%d 跳过=%d 耗时=%dms", plan.id, report.Executed, report.Failed, report.Skipped, report.DurationMs)
+	return report
+}
+
+func execSQLPlanStatement(dbInst db.Database, dbType string, stmt string, timeout time.Duration) (int64, error) {
+	stmt = sanitizeSQLForPgLike(dbType, stmt)
+	ctx, cancel := utils.ContextWithTimeout(timeout)
+	defer cancel()
+	if e, ok := dbInst.(interface {
+		ExecContext(context.Context, string) (int64, error)
+	}); ok {
+		return e.ExecContext(ctx, stmt)
+	}
+	return dbInst.Exec(stmt)
+}
@@ -0,0 +1,44 @@
+package app
+
+import "testing"
+
+func TestNormalizeSQLPlanStatements_DropsEmptyAndTrailingSemicolons(t *testing.T) {
+	got := normalizeSQLPlanStatements("mysql", []string{"  ALTER TABLE t ADD c INT; ", "", " ; ", ";;", "DROP TABLE x"})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 statements, got %d: %#v", len(got), got)
+	}
+	if got[0] != "ALTER TABLE t ADD c INT" || got[1] != "DROP TABLE x" {
+		t.Fatalf("unexpected statements: %#v", got)
+	}
+}
+
+func TestNormalizeSQLPlanStatements_KeepsBlockTerminators(t *testing.T) {
+	block := "BEGIN\n  UPDATE t SET a = 1;\nEND;"
+	got := normalizeSQLPlanStatements("oracle", []string{block, "UPDATE t SET b = 2;"})
+	if len(got) != 2 || got[0] != block || got[1] != "UPDATE t SET b = 2" {
+		t.Fatalf("unexpected statements: %#v", got)
+	}
+	if script := buildSQLPlanScript("oracle", got); script != block+"\n/\nUPDATE t SET b = 2;\n" {
+		t.Fatalf("unexpected script: %q", script)
+	}
+}
+
+func TestRegisterSQLPlan_TakeOnce(t *testing.T) {
+	a := NewApp()
+	preview, err := a.registerSQLPlan(SQLPlanRequest{Source: "table_designer", Statements: []string{"ALTER TABLE t ADD c INT"}}, nil)
+	if err != nil {
+		t.Fatalf("registerSQLPlan failed: %v", err)
+	}
+	if preview.Script != "ALTER TABLE t ADD c INT;\n" {
+		t.Fatalf("unexpected script: %q", preview.Script)
+	}
+	if _, ok := a.takeSQLPlan(preview.PlanID); !ok {
+		t.Fatalf("expected plan to exist")
+	}
+	if _, ok := a.takeSQLPlan(preview.PlanID); ok {
+		t.Fatalf("expected plan to be consumed")
+	}
+	if _, err := a.registerSQLPlan(SQLPlanRequest{Statements: []string{" ; "}}, nil); err == nil {
+		t.Fatalf("expected error for empty plan")
+	}
+}
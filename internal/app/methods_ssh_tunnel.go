@@ -0,0 +1,105 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/ssh"
+)
+
+// SSHTunnelInfo 隧道状态及正在使用它的连接。
+type SSHTunnelInfo struct {
+	ssh.TunnelStatus
+	Connections []string `json:"connections"`
+}
+
+// SSHTunnelOverview 隧道面板数据：同一跳板机的多个隧道共享一个 SSH 客户端。
+type SSHTunnelOverview struct {
+	Tunnels  []SSHTunnelInfo     `json:"tunnels"`
+	Bastions []ssh.BastionStatus `json:"bastions"`
+}
+
+// ListSSHTunnels 列出活动的 SSH 隧道（本地端口、远程目标、流量计数）及使用它们的缓存连接。
+func (a *App) ListSSHTunnels() connection.QueryResult {
+	users := a.sshTunnelUsers()
+	statuses := ssh.ListTunnels()
+	tunnels := make([]SSHTunnelInfo, 0, len(statuses))
+	for _, status := range statuses {
+		conns := users[status.ID]
+		if conns == nil {
+			conns = []string{}
+		}
+		tunnels = append(tunnels, SSHTunnelInfo{TunnelStatus: status, Connections: conns})
+	}
+	return connection.QueryResult{Success: true, Data: SSHTunnelOverview{Tunnels: tunnels, Bastions: ssh.ListBastions()}}
+}
+
+// ReconnectSSHTunnel 重连隧道背后的跳板机；本地端口保持不变，使用该隧道的连接无需重新配置。
+func (a *App) ReconnectSSHTunnel(id string) connection.QueryResult {
+	status, err := ssh.ReconnectTunnel(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(err, "重连 SSH 隧道失败：%s", id)
+		return connection.QueryResult{Success: false, Message: err.Error(), Data: status}
+	}
+	return connection.QueryResult{Success: true, Message: "隧道已重连", Data: status}
+}
+
+// CloseSSHTunnel 关闭隧道，并关闭正在通过它访问数据库的缓存连接（下次使用时自动重建）。
+func (a *App) CloseSSHTunnel(id string) connection.QueryResult {
+	id = strings.TrimSpace(id)
+	if err := ssh.CloseTunnel(id); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	closed := 0
+	a.mu.Lock()
+	for key, entry := range a.dbCache {
+		if sshTunnelIDForConfig(entry.config) != id {
+			continue
+		}
+		if entry.inst != nil {
+			if err := entry.inst.Close(); err != nil {
+				logger.Error(err, "关闭隧道上的缓存连接失败：%s", formatConnSummary(entry.config))
+			}
+		}
+		delete(a.dbCache, key)
+		closed++
+	}
+	a.mu.Unlock()
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("隧道已关闭，释放连接 %d 个", closed)}
+}
+
+func (a *App) sshTunnelUsers() map[string][]string {
+	users := map[string][]string{}
+	a.mu.RLock()
+	for _, entry := range a.dbCache {
+		id := sshTunnelIDForConfig(entry.config)
+		if id == "" {
+			continue
+		}
+		users[id] = append(users[id], sshTunnelConnLabel(entry.config))
+	}
+	a.mu.RUnlock()
+	for id := range users {
+		sort.Strings(users[id])
+	}
+	return users
+}
+
+// sshTunnelIDForConfig 与驱动层 GetOrCreateLocalForwarder 使用的键一致；MySQL 系驱动走 SSH 拨号器，不占用本地转发。
+func sshTunnelIDForConfig(config connection.ConnectionConfig) string {
+	if !config.UseSSH {
+		return ""
+	}
+	return ssh.ForwarderKey(config.SSH, config.Host, config.Port)
+}
+
+func sshTunnelConnLabel(config connection.ConnectionConfig) string {
+	database := strings.TrimSpace(config.Database)
+	if database == "" {
+		database = "(default)"
+	}
+	return fmt.Sprintf("%s %s@%s:%d/%s", config.Type, config.User, config.Host, config.Port, database)
+}
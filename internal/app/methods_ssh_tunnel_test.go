@@ -0,0 +1,39 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/ssh"
+)
+
+func TestSSHTunnelUsers(t *testing.T) {
+	bastion := connection.SSHConfig{Host: "bastion", Port: 22, User: "ops"}
+	orders := connection.ConnectionConfig{Type: "postgres", Host: "10.0.0.5", Port: 5432, User: "app", Database: "orders", UseSSH: true, SSH: bastion}
+	billing := orders
+	billing.Database = "billing"
+	direct := connection.ConnectionConfig{Type: "postgres", Host: "10.0.0.5", Port: 5432, User: "app"}
+
+	if sshTunnelIDForConfig(direct) != "" {
+		t.Fatal("direct connection should not use a tunnel")
+	}
+	id := sshTunnelIDForConfig(orders)
+	if id != ssh.ForwarderKey(bastion, "10.0.0.5", 5432) || sshTunnelIDForConfig(billing) != id {
+		t.Fatalf("tunnel id = %s", id)
+	}
+
+	a := NewApp()
+	a.dbCache["orders"] = cachedDatabase{config: orders}
+	a.dbCache["billing"] = cachedDatabase{config: billing}
+	a.dbCache["direct"] = cachedDatabase{config: direct}
+	users := a.sshTunnelUsers()
+	want := map[string][]string{id: {"postgres app@10.0.0.5:5432/billing", "postgres app@10.0.0.5:5432/orders"}}
+	if !reflect.DeepEqual(users, want) {
+		t.Fatalf("users = %v", users)
+	}
+
+	if res := a.CloseSSHTunnel("missing"); res.Success {
+		t.Fatal("closing an unknown tunnel should fail")
+	}
+}
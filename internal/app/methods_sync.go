@@ -13,6 +13,9 @@ import (
 
 // DataSync executes a data synchronization task
 func (a *App) DataSync(config sync.SyncConfig) sync.SyncResult {
+	if err := ensureWritable(config.TargetConfig, "作为同步目标"); err != nil {
+		return sync.SyncResult{Success: false, Message: err.Error()}
+	}
 	jobID := strings.TrimSpace(config.JobID)
 	if jobID == "" {
 		jobID = fmt.Sprintf("sync-%d", time.Now().UnixNano())
@@ -0,0 +1,325 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+	"GoNavi-Wails/internal/utils"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// tabSession 编辑器标签页独占的一条数据库连接。mu 保证同一标签页的语句按顺序执行。
+type tabSession struct {
+	mu         sync.Mutex
+	session    *db.Session
+	cacheKey   string
+	config     connection.ConnectionConfig
+	dbName     string
+	pinnedAt   time.Time
+	lastUsedAt time.Time
+	statements int64
+
+	keepaliveAt time.Time // 最近一次保活时间，不计入 lastUsedAt
+	auto        bool      // 由 TabSessionAffinity 在首次执行时自动检出
+	closed      bool      // 已从标签页移除；持有 mu 后需检查，避免在即将关闭的连接上执行
+}
+
+const (
+	defaultTabSessionIdleTimeout = 30 * time.Minute
+	tabSessionReleasedEvent      = "tabsession:released"
+)
+
+// TabSessionReleasedEvent 空闲超时释放标签页会话时推送给前端，会话变量、临时表与未提交的事务随之丢失。
+type TabSessionReleasedEvent struct {
+	TabID      string `json:"tabId"`
+	Connection string `json:"connection"`
+	Database   string `json:"database"`
+	Reason     string `json:"reason"`
+	At         int64  `json:"at"` // Unix milli
+}
+
+// TabSessionInfo 已固定会话的标签页信息。
+type TabSessionInfo struct {
+	TabID      string `json:"tabId"`
+	Connection string `json:"connection"`
+	Database   string `json:"database"`
+	PinnedAt   int64  `json:"pinnedAt"`
+	LastUsedAt int64  `json:"lastUsedAt"`
+	Statements int64  `json:"statements"`
+	Auto       bool   `json:"auto,omitempty"`
+}
+
+func (s *tabSession) info(tabID string) TabSessionInfo {
+	return TabSessionInfo{
+		TabID:      tabID,
+		Connection: formatConnSummary(s.config),
+		Database:   s.dbName,
+		PinnedAt:   s.pinnedAt.UnixMilli(),
+		LastUsedAt: s.lastUsedAt.UnixMilli(),
+		Statements: s.statements,
+		Auto:       s.auto,
+	}
+}
+
+// PinTabSession 为编辑器标签页检出一条专用连接，之后通过 DBQueryInTab 执行的语句都在该连接上运行，
+// SET @var / SET search_path / 临时表等会话状态不会影响其他标签页。重复固定时先释放旧会话。
+func (a *App) PinTabSession(config connection.ConnectionConfig, dbName string, tabID string) connection.QueryResult {
+	tabID = strings.TrimSpace(tabID)
+	if tabID == "" {
+		return connection.QueryResult{Success: false, Message: "标签页 ID 不能为空"}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	pinned, err := a.pinTabSession(runConfig, dbName, tabID, false)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: "已为当前标签页分配独立会话", Data: pinned.info(tabID)}
+}
+
+// pinTabSession 检出一条专用连接并登记到标签页，替换并释放该标签页原有的会话。
+func (a *App) pinTabSession(runConfig connection.ConnectionConfig, dbName string, tabID string, auto bool) (*tabSession, error) {
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return nil, err
+	}
+	opener, ok := dbInst.(db.SessionOpener)
+	if !ok {
+		return nil, fmt.Errorf("当前数据源（%s）不支持独立会话", runConfig.Type)
+	}
+
+	timeoutSeconds := runConfig.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 30
+	}
+	ctx, cancel := utils.ContextWithTimeout(time.Duration(timeoutSeconds) * time.Second)
+	defer cancel()
+	session, err := opener.OpenSession(ctx)
+	if err != nil {
+		logger.Error(err, "固定标签页会话失败：%s 标签页=%s", formatConnSummary(runConfig), tabID)
+		return nil, errors.New(normalizeErrorMessage(err))
+	}
+	if err := session.Ping(ctx); err != nil {
+		_ = session.Close()
+		return nil, errors.New(normalizeErrorMessage(err))
+	}
+
+	now := time.Now()
+	pinned := &tabSession{
+		session:    session,
+		cacheKey:   getCacheKey(applyCustomDriverType(runConfig)),
+		config:     runConfig,
+		dbName:     dbName,
+		pinnedAt:   now,
+		lastUsedAt: now,
+		auto:       auto,
+	}
+	a.sessionMu.Lock()
+	previous := a.tabSessions[tabID]
+	a.tabSessions[tabID] = pinned
+	a.sessionMu.Unlock()
+	if previous != nil {
+		previous.close()
+	}
+	if auto {
+		logger.Infof("已为标签页自动检出会话：%s 标签页=%s", formatConnSummary(runConfig), tabID)
+	} else {
+		logger.Infof("已固定标签页会话：%s 标签页=%s", formatConnSummary(runConfig), tabID)
+	}
+	return pinned, nil
+}
+
+// ReleaseTabSession 释放标签页的专用连接。连接上的会话状态随之丢弃，不会回到连接池被其他标签页复用。
+func (a *App) ReleaseTabSession(tabID string) connection.QueryResult {
+	a.sessionMu.Lock()
+	pinned, exists := a.tabSessions[tabID]
+	delete(a.tabSessions, tabID)
+	a.sessionMu.Unlock()
+	if !exists {
+		return connection.QueryResult{Success: true, Message: "标签页未固定会话"}
+	}
+	pinned.close()
+	logger.Infof("已释放标签页会话：%s 标签页=%s 执行语句数=%d", formatConnSummary(pinned.config), tabID, pinned.statements)
+	return connection.QueryResult{Success: true, Message: "已释放独立会话"}
+}
+
+// ListTabSessions 返回当前所有已固定会话的标签页。
+func (a *App) ListTabSessions() connection.QueryResult {
+	a.sessionMu.Lock()
+	result := make([]TabSessionInfo, 0, len(a.tabSessions))
+	for tabID, pinned := range a.tabSessions {
+		pinned.mu.Lock()
+		result = append(result, pinned.info(tabID))
+		pinned.mu.Unlock()
+	}
+	a.sessionMu.Unlock()
+	sort.Slice(result, func(i, j int) bool { return result[i].PinnedAt < result[j].PinnedAt })
+	return connection.QueryResult{Success: true, Data: result}
+}
+
+// DBQueryInTab 在标签页固定的会话上执行语句；标签页未固定会话时等同于 DBQuery（使用共享连接池）。
+// 连接开启 TabSessionAffinity 时首次执行即自动检出会话，空闲超过 TabSessionIdleTimeout 后释放。
+func (a *App) DBQueryInTab(config connection.ConnectionConfig, dbName string, tabID string, query string) connection.QueryResult {
+	a.sessionMu.Lock()
+	pinned := a.tabSessions[tabID]
+	a.sessionMu.Unlock()
+	runConfig := normalizeRunConfig(config, dbName)
+	if pinned == nil || (pinned.auto && getCacheKey(applyCustomDriverType(runConfig)) != pinned.cacheKey) {
+		// 自动检出的会话随标签页切换连接而重新检出；检出失败时退回共享连接池
+		if runConfig.TabSessionAffinity && strings.TrimSpace(tabID) != "" {
+			var err error
+			if pinned, err = a.pinTabSession(runConfig, dbName, tabID, true); err != nil {
+				logger.Warnf("自动检出标签页会话失败，改用共享连接池：%s 标签页=%s，原因：%v", formatConnSummary(runConfig), tabID, err)
+			}
+		} else if pinned != nil {
+			a.releaseTabSessionIfCurrent(tabID, pinned)
+			pinned = nil
+		}
+	}
+	if pinned == nil {
+		return a.DBQuery(config, dbName, query)
+	}
+
+	target, isSwitch := sqlstmt.DatabaseSwitch(runConfig.Type, query)
+	if getCacheKey(applyCustomDriverType(runConfig)) != pinned.cacheKey {
+		return connection.QueryResult{Success: false, Message: "标签页会话已绑定其他连接或数据库，请先释放会话"}
+	}
+
+	query = sanitizeSQLForPgLike(runConfig.Type, query)
+	timeoutSeconds := runConfig.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 30
+	}
+	ctx, cancel := utils.ContextWithTimeout(time.Duration(timeoutSeconds) * time.Second)
+	defer cancel()
+	unlock, err := a.lockSQLiteWrite(ctx, runConfig, query)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	defer unlock()
+
+	pinned.mu.Lock()
+	defer pinned.mu.Unlock()
+	if pinned.closed || pinned.session == nil {
+		// 取得会话后、加锁前会话可能已被空闲释放
+		return connection.QueryResult{Success: false, Message: "标签页会话已释放，请重新执行"}
+	}
+	pinned.lastUsedAt = time.Now()
+	pinned.statements++
+	if isSwitch {
+		return a.switchTabSessionDatabase(ctx, tabID, pinned, config, target, query)
+	}
+
+	req := queryRequest{config: runConfig, dbName: dbName, tabID: tabID, query: query, opts: dbQueryOptions{route: queryRoutePrimary}}
+	return a.runQuery(ctx, req, &sessionExecutor{app: a, tabID: tabID, pinned: pinned, config: runConfig})
+}
+
+// tabSessionError 会话连接已断开时不做自动重连（会话状态无法恢复），直接释放并提示用户重新固定。
+// 调用方需持有 pinned.mu。
+func (a *App) tabSessionError(tabID string, pinned *tabSession, err error) string {
+	if !isConnectionLostError(err) {
+		return err.Error()
+	}
+	a.sessionMu.Lock()
+	if a.tabSessions[tabID] == pinned {
+		delete(a.tabSessions, tabID)
+	}
+	a.sessionMu.Unlock()
+	_ = pinned.session.Close()
+	pinned.session = nil
+	pinned.closed = true
+	return fmt.Sprintf("独立会话连接已断开，会话变量已丢失，请重新固定会话：%s", normalizeErrorMessage(err))
+}
+
+func (s *tabSession) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.session == nil {
+		return
+	}
+	if err := s.session.Close(); err != nil {
+		logger.Error(err, "释放标签页会话失败：%s", formatConnSummary(s.config))
+	}
+	s.session = nil
+}
+
+// releaseTabSessionIfCurrent 标签页仍登记着该会话时移除并释放。
+func (a *App) releaseTabSessionIfCurrent(tabID string, pinned *tabSession) {
+	a.sessionMu.Lock()
+	current := a.tabSessions[tabID] == pinned
+	if current {
+		delete(a.tabSessions, tabID)
+	}
+	a.sessionMu.Unlock()
+	if current {
+		pinned.close()
+	}
+}
+
+// tabSessionIdleTimeout 返回会话的空闲释放时间，0 表示不自动释放。
+func tabSessionIdleTimeout(s *tabSession) time.Duration {
+	if s.config.TabSessionIdleTimeout > 0 {
+		return time.Duration(s.config.TabSessionIdleTimeout) * time.Second
+	}
+	if s.auto {
+		return defaultTabSessionIdleTimeout
+	}
+	return 0
+}
+
+// releaseIdleTabSessions 释放空闲超时的标签页会话（保活不计为使用），正在执行语句的会话跳过。
+func (a *App) releaseIdleTabSessions(now time.Time) {
+	a.sessionMu.Lock()
+	var idle []string
+	for tabID, pinned := range a.tabSessions {
+		timeout := tabSessionIdleTimeout(pinned)
+		if timeout <= 0 || !pinned.mu.TryLock() {
+			continue
+		}
+		if now.Sub(pinned.lastUsedAt) >= timeout {
+			// 解锁前标记关闭：已取得该会话、正等待 mu 的 DBQueryInTab 不会再在其上执行
+			pinned.closed = true
+			idle = append(idle, tabID)
+		}
+		pinned.mu.Unlock()
+	}
+	released := make(map[string]*tabSession, len(idle))
+	for _, tabID := range idle {
+		released[tabID] = a.tabSessions[tabID]
+		delete(a.tabSessions, tabID)
+	}
+	a.sessionMu.Unlock()
+
+	for tabID, pinned := range released {
+		pinned.close()
+		logger.Infof("标签页会话空闲超时，已释放：%s 标签页=%s 执行语句数=%d", formatConnSummary(pinned.config), tabID, pinned.statements)
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, tabSessionReleasedEvent, TabSessionReleasedEvent{
+				TabID:      tabID,
+				Connection: formatConnSummary(pinned.config),
+				Database:   pinned.dbName,
+				Reason:     fmt.Sprintf("空闲超过 %d 秒", int64(tabSessionIdleTimeout(pinned)/time.Second)),
+				At:         now.UnixMilli(),
+			})
+		}
+	}
+}
+
+func (a *App) releaseAllTabSessions() {
+	a.sessionMu.Lock()
+	sessions := a.tabSessions
+	a.tabSessions = make(map[string]*tabSession)
+	a.sessionMu.Unlock()
+	for _, pinned := range sessions {
+		pinned.close()
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestReleaseIdleTabSessions(t *testing.T) {
+	now := time.Now()
+	a := NewApp()
+	a.tabSessions["auto-idle"] = &tabSession{auto: true, lastUsedAt: now.Add(-31 * time.Minute)}
+	a.tabSessions["auto-active"] = &tabSession{auto: true, lastUsedAt: now.Add(-time.Minute)}
+	a.tabSessions["pinned"] = &tabSession{lastUsedAt: now.Add(-24 * time.Hour)}
+	a.tabSessions["pinned-timeout"] = &tabSession{lastUsedAt: now.Add(-2 * time.Minute),
+		config: connection.ConnectionConfig{TabSessionIdleTimeout: 60}}
+	busy := &tabSession{auto: true, lastUsedAt: now.Add(-time.Hour)}
+	busy.mu.Lock()
+	a.tabSessions["auto-busy"] = busy
+
+	idle := a.tabSessions["auto-idle"]
+	a.releaseIdleTabSessions(now)
+	busy.mu.Unlock()
+	if !idle.closed {
+		t.Fatal("expected released session to be marked closed")
+	}
+
+	for _, tabID := range []string{"auto-idle", "pinned-timeout"} {
+		if _, ok := a.tabSessions[tabID]; ok {
+			t.Fatalf("expected %s to be released", tabID)
+		}
+	}
+	for _, tabID := range []string{"auto-active", "pinned", "auto-busy"} {
+		if _, ok := a.tabSessions[tabID]; !ok {
+			t.Fatalf("expected %s to be kept", tabID)
+		}
+	}
+}
+
+func TestDBQueryInTabRejectsClosedSession(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	runConfig := normalizeRunConfig(config, "shop")
+	a.tabSessions["tab"] = &tabSession{cacheKey: getCacheKey(applyCustomDriverType(runConfig)), config: runConfig, closed: true}
+
+	res := a.DBQueryInTab(config, "shop", "tab", "SELECT 1")
+	if res.Success || res.Message != "标签页会话已释放，请重新执行" {
+		t.Fatalf("expected closed session to be rejected, got %+v", res)
+	}
+}
@@ -0,0 +1,252 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/utils"
+)
+
+const (
+	defaultTablePageSize = 100
+	maxTablePageSize     = 10000
+	oracleRowNumAlias    = "GONAVI_RN__"
+)
+
+// TableSort 单列排序。
+type TableSort struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc"`
+}
+
+// TableFilter 单列过滤条件。Op 支持 = != > >= < <= like、not like、in、not in、is null、is not null；
+// in/not in 的 Value 为数组。
+type TableFilter struct {
+	Column string      `json:"column"`
+	Op     string      `json:"op"`
+	Value  interface{} `json:"value"`
+}
+
+// TablePage 分页结果，Total 为过滤后的总行数。
+type TablePage struct {
+	Rows     []map[string]interface{} `json:"rows"`
+	Total    int64                    `json:"total"`
+	Page     int                      `json:"page"`
+	PageSize int                      `json:"pageSize"`
+}
+
+// pageFilterLiteral 生成过滤值字面量；字符串按方言转义，不走 formatSQLValue 的十六进制透传。
+func pageFilterLiteral(dbType string, value interface{}) string {
+	text, ok := value.(string)
+	if !ok {
+		return formatSQLValue(dbType, value)
+	}
+	return dialect.QuoteLiteral(dbType, text)
+}
+
+func buildTablePageWhere(dbType string, filters []TableFilter) (string, error) {
+	conds := make([]string, 0, len(filters))
+	for _, f := range filters {
+		column := strings.TrimSpace(f.Column)
+		if column == "" {
+			continue
+		}
+		col := quoteIdentByType(dbType, column)
+		op := strings.ToLower(strings.Join(strings.Fields(f.Op), " "))
+		switch op {
+		case "", "=":
+			if f.Value == nil {
+				conds = append(conds, col+" IS NULL")
+				continue
+			}
+			conds = append(conds, fmt.Sprintf("%s = %s", col, pageFilterLiteral(dbType, f.Value)))
+		case "!=", "<>", ">", ">=", "<", "<=":
+			if op == "!=" {
+				op = "<>"
+			}
+			conds = append(conds, fmt.Sprintf("%s %s %s", col, op, pageFilterLiteral(dbType, f.Value)))
+		case "like", "not like":
+			conds = append(conds, fmt.Sprintf("%s %s %s", col, strings.ToUpper(op), pageFilterLiteral(dbType, fmt.Sprintf("%v", f.Value))))
+		case "is null", "is not null":
+			conds = append(conds, col+" "+strings.ToUpper(op))
+		case "in", "not in":
+			values, ok := f.Value.([]interface{})
+			if !ok || len(values) == 0 {
+				return "", fmt.Errorf("过滤条件 %s 的值必须是非空数组", column)
+			}
+			items := make([]string, 0, len(values))
+			for _, v := range values {
+				items = append(items, pageFilterLiteral(dbType, v))
+			}
+			conds = append(conds, fmt.Sprintf("%s %s (%s)", col, strings.ToUpper(op), strings.Join(items, ", ")))
+		default:
+			return "", fmt.Errorf("不支持的过滤运算符：%s", f.Op)
+		}
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), nil
+}
+
+func buildTablePageOrderBy(dbType string, sorts []TableSort) string {
+	items := make([]string, 0, len(sorts))
+	for _, s := range sorts {
+		column := strings.TrimSpace(s.Column)
+		if column == "" {
+			continue
+		}
+		dir := "ASC"
+		if s.Desc {
+			dir = "DESC"
+		}
+		items = append(items, quoteIdentByType(dbType, column)+" "+dir)
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return " ORDER BY " + strings.Join(items, ", ")
+}
+
+// buildTablePageSQL 生成分页查询与计数语句。Oracle/达梦使用 ROWNUM 包装（兼容 12c 以前版本），
+// 结果中会多出 oracleRowNumAlias 列，由调用方移除。
+func buildTablePageSQL(dbType string, table string, where string, orderBy string, page int, pageSize int) (string, string) {
+	offset := (page - 1) * pageSize
+	base := fmt.Sprintf("SELECT * FROM %s%s", table, where)
+	countSQL := fmt.Sprintf("SELECT COUNT(*) AS total FROM %s%s", table, where)
+	switch dbType {
+	case "sqlserver":
+		if orderBy == "" {
+			orderBy = " ORDER BY (SELECT NULL)"
+		}
+		return fmt.Sprintf("%s%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", base, orderBy, offset, pageSize), countSQL
+	case "oracle", "dameng":
+		return fmt.Sprintf("SELECT * FROM (SELECT page_t.*, ROWNUM AS %s FROM (%s%s) page_t WHERE ROWNUM <= %d) WHERE %s > %d",
+			oracleRowNumAlias, base, orderBy, offset+pageSize, oracleRowNumAlias, offset), countSQL
+	default:
+		return fmt.Sprintf("%s%s LIMIT %d OFFSET %d", base, orderBy, pageSize, offset), countSQL
+	}
+}
+
+// buildKeysetPageSQL 生成按键列续读的分页语句 WHERE (k) > (last) ORDER BY k，读取靠后的数据时不会像 OFFSET 那样越来越慢。
+// after 为上一批最后一行键值的字面量，为空时读取第一批；行值比较展开为 OR 链，兼容不支持 (a, b) > (x, y) 的数据库。
+func buildKeysetPageSQL(dbType string, table string, keys []string, after []string, limit int) string {
+	quoted := make([]string, len(keys))
+	sorts := make([]TableSort, len(keys))
+	for i, key := range keys {
+		quoted[i] = quoteIdentByType(dbType, key)
+		sorts[i] = TableSort{Column: key}
+	}
+	where := ""
+	if len(after) == len(keys) && len(keys) > 0 {
+		ors := make([]string, 0, len(keys))
+		for i := range keys {
+			ands := make([]string, 0, i+1)
+			for j := 0; j < i; j++ {
+				ands = append(ands, quoted[j]+" = "+after[j])
+			}
+			ands = append(ands, quoted[i]+" > "+after[i])
+			ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+		}
+		where = " WHERE " + strings.Join(ors, " OR ")
+	}
+	query := fmt.Sprintf("SELECT * FROM %s%s%s", table, where, buildTablePageOrderBy(dbType, sorts))
+	switch dbType {
+	case "sqlserver":
+		return fmt.Sprintf("%s OFFSET 0 ROWS FETCH NEXT %d ROWS ONLY", query, limit)
+	case "oracle", "dameng":
+		return fmt.Sprintf("SELECT * FROM (%s) WHERE ROWNUM <= %d", query, limit)
+	default:
+		return fmt.Sprintf("%s LIMIT %d", query, limit)
+	}
+}
+
+// DBQueryTablePage 按页读取表数据，排序与过滤在服务端完成，同时返回过滤后的总行数。page 从 1 开始。
+func (a *App) DBQueryTablePage(config connection.ConnectionConfig, dbName string, tableName string, page int, pageSize int, sort []TableSort, filters []TableFilter) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbType := resolveDDLDBType(runConfig)
+	switch dbType {
+	case "mongodb", "redis":
+		return connection.QueryResult{Success: false, Message: "该数据源不支持 SQL 分页查询"}
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultTablePageSize
+	}
+	if pageSize > maxTablePageSize {
+		pageSize = maxTablePageSize
+	}
+
+	where, err := buildTablePageWhere(dbType, filters)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTable(runConfig, dbName, tableName)
+	table := quoteTableIdentByType(dbType, schemaName, pureTableName)
+	dataSQL, countSQL := buildTablePageSQL(dbType, table, where, buildTablePageOrderBy(dbType, sort), page, pageSize)
+
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		logger.Error(err, "DBQueryTablePage 获取连接失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	timeoutSeconds := runConfig.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 30
+	}
+	ctx, cancel := utils.ContextWithTimeout(time.Duration(timeoutSeconds) * time.Second)
+	defer cancel()
+
+	result := TablePage{Page: page, PageSize: pageSize}
+	var columns []string
+	warning, err := a.runWithReconnect(runConfig, dbInst, "DBQueryTablePage", func(inst db.Database) error {
+		query := func(sql string) ([]map[string]interface{}, []string, error) {
+			if q, ok := inst.(interface {
+				QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+			}); ok {
+				return q.QueryContext(ctx, sql)
+			}
+			return inst.Query(sql)
+		}
+		countRows, countCols, err := query(countSQL)
+		if err != nil {
+			return err
+		}
+		if len(countRows) > 0 && len(countCols) > 0 {
+			result.Total, _ = strconv.ParseInt(fmt.Sprintf("%v", countRows[0][countCols[0]]), 10, 64)
+		}
+		result.Rows, columns, err = query(dataSQL)
+		return err
+	})
+	if err != nil {
+		logger.Error(err, "DBQueryTablePage 查询失败：%s 表=%s SQL片段=%q", formatConnSummary(runConfig), tableName, sqlSnippet(dataSQL))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	if dbType == "oracle" || dbType == "dameng" {
+		filtered := columns[:0]
+		for _, col := range columns {
+			if !strings.EqualFold(col, oracleRowNumAlias) {
+				filtered = append(filtered, col)
+			}
+		}
+		columns = filtered
+		for _, row := range result.Rows {
+			delete(row, oracleRowNumAlias)
+		}
+	}
+	if result.Rows == nil {
+		result.Rows = []map[string]interface{}{}
+	}
+	return connection.QueryResult{Success: true, Message: warning, Data: result, Fields: columns}
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildTablePageSQLByDialect(t *testing.T) {
+	data, count := buildTablePageSQL("mysql", "`shop`.`orders`", " WHERE `id` > 10", " ORDER BY `id` DESC", 3, 50)
+	if data != "SELECT * FROM `shop`.`orders` WHERE `id` > 10 ORDER BY `id` DESC LIMIT 50 OFFSET 100" {
+		t.Fatalf("mysql data SQL = %s", data)
+	}
+	if count != "SELECT COUNT(*) AS total FROM `shop`.`orders` WHERE `id` > 10" {
+		t.Fatalf("mysql count SQL = %s", count)
+	}
+
+	data, _ = buildTablePageSQL("sqlserver", "[dbo].[orders]", "", "", 2, 20)
+	if data != "SELECT * FROM [dbo].[orders] ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 20 ROWS ONLY" {
+		t.Fatalf("sqlserver data SQL = %s", data)
+	}
+
+	data, _ = buildTablePageSQL("oracle", `"HR"."EMP"`, "", ` ORDER BY "ID" ASC`, 2, 10)
+	if !strings.Contains(data, "ROWNUM <= 20") || !strings.Contains(data, oracleRowNumAlias+" > 10") || !strings.Contains(data, `ORDER BY "ID" ASC) page_t`) {
+		t.Fatalf("oracle data SQL = %s", data)
+	}
+}
+
+func TestBuildKeysetPageSQL(t *testing.T) {
+	first := buildKeysetPageSQL("mysql", "`orders`", []string{"id"}, nil, 100)
+	if first != "SELECT * FROM `orders` ORDER BY `id` ASC LIMIT 100" {
+		t.Fatalf("mysql first page = %s", first)
+	}
+	next := buildKeysetPageSQL("postgres", `"orders"`, []string{"a", "b"}, []string{"1", "'x'"}, 50)
+	if next != `SELECT * FROM "orders" WHERE ("a" > 1) OR ("a" = 1 AND "b" > 'x') ORDER BY "a" ASC, "b" ASC LIMIT 50` {
+		t.Fatalf("postgres next page = %s", next)
+	}
+	if got := buildKeysetPageSQL("sqlserver", "[t]", []string{"id"}, []string{"5"}, 10); !strings.HasSuffix(got, "WHERE ([id] > 5) ORDER BY [id] ASC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY") {
+		t.Fatalf("sqlserver next page = %s", got)
+	}
+}
+
+func TestBuildTablePageWhere(t *testing.T) {
+	where, err := buildTablePageWhere("mysql", []TableFilter{
+		{Column: "name", Op: "like", Value: `a\b'%`},
+		{Column: "status", Op: "in", Value: []interface{}{"paid", float64(2)}},
+		{Column: "deleted_at", Op: "is  null"},
+		{Column: "qty", Op: "!=", Value: float64(0)},
+	})
+	if err != nil {
+		t.Fatalf("buildTablePageWhere: %v", err)
+	}
+	want := " WHERE `name` LIKE 'a\\\\b''%' AND `status` IN ('paid', 2) AND `deleted_at` IS NULL AND `qty` <> 0"
+	if where != want {
+		t.Fatalf("where = %s\nwant  = %s", where, want)
+	}
+
+	if _, err := buildTablePageWhere("postgres", []TableFilter{{Column: "id", Op: "; drop"}}); err == nil {
+		t.Fatal("unknown operator should be rejected")
+	}
+	if _, err := buildTablePageWhere("postgres", []TableFilter{{Column: "id", Op: "in", Value: "1,2"}}); err == nil {
+		t.Fatal("in requires an array value")
+	}
+}
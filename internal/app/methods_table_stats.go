@@ -0,0 +1,202 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+// TableStat 表概览中的单表统计。行数与大小来自统计信息，-1 表示数据库未提供。
+type TableStat struct {
+	Schema     string `json:"schema,omitempty"`
+	Name       string `json:"name"`
+	Rows       int64  `json:"rows"`       // 估算行数（SQLite/DuckDB 以外多为统计值，可能滞后）
+	DataBytes  int64  `json:"dataBytes"`  // 数据大小
+	IndexBytes int64  `json:"indexBytes"` // 索引大小
+	TotalBytes int64  `json:"totalBytes"` // 数据与索引合计，两者均未知时为 -1
+	Engine     string `json:"engine,omitempty"`
+	Collation  string `json:"collation,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// GetTableStats 一次性读取库中各表的行数估算、数据与索引大小、存储引擎、排序规则与注释，供表概览页排序展示。
+// 统计来自 information_schema.TABLES、pg_class、sys.dm_db_partition_stats、ALL_TABLES、system.tables 与 SQLite 的 dbstat，
+// 不扫描表数据；SQLite 与 DuckDB 本地文件库的行数直接计数。
+func (a *App) GetTableStats(config connection.ConnectionConfig, dbName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+	var stats []TableStat
+	warning, err := a.runWithReconnect(runConfig, dbInst, "GetTableStats", func(inst db.Database) (statsErr error) {
+		stats, statsErr = loadTableStats(inst, dbType, dbName)
+		return statsErr
+	})
+	if err != nil {
+		logger.Error(err, "读取表统计信息失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Schema != stats[j].Schema {
+			return stats[i].Schema < stats[j].Schema
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return connection.QueryResult{Success: true, Message: warning, Data: stats}
+}
+
+// tableStatsSQL 返回读取全部表统计的查询，列别名统一为 schema_name、table_name、row_count、data_bytes、
+// index_bytes、engine、collation_name、table_comment。返回空串表示该数据源没有可用的统计视图。
+func tableStatsSQL(dbType string, dbName string) string {
+	schema := dialect.QuoteLiteral(dbType, dbName)
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		return fmt.Sprintf("SELECT TABLE_SCHEMA AS schema_name, TABLE_NAME AS table_name, TABLE_ROWS AS row_count, "+
+			"DATA_LENGTH AS data_bytes, INDEX_LENGTH AS index_bytes, ENGINE AS engine, TABLE_COLLATION AS collation_name, "+
+			"TABLE_COMMENT AS table_comment FROM information_schema.TABLES WHERE TABLE_SCHEMA = %s AND TABLE_TYPE = 'BASE TABLE'", schema)
+	case "postgres", "kingbase", "highgo", "vastbase":
+		// reltuples 为 -1 表示从未 ANALYZE；分区父表的大小为 0，统计在各分区上
+		return "SELECT n.nspname AS schema_name, c.relname AS table_name, c.reltuples::bigint AS row_count, " +
+			"pg_table_size(c.oid) AS data_bytes, pg_indexes_size(c.oid) AS index_bytes, am.amname AS engine, " +
+			"(SELECT datcollate FROM pg_database WHERE datname = current_database()) AS collation_name, " +
+			"obj_description(c.oid, 'pg_class') AS table_comment " +
+			"FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace LEFT JOIN pg_am am ON am.oid = c.relam " +
+			"WHERE c.relkind IN ('r', 'p') AND n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg_toast%'"
+	case "sqlserver":
+		return "SELECT s.name AS schema_name, t.name AS table_name, " +
+			"(SELECT SUM(p.rows) FROM sys.partitions p WHERE p.object_id = t.object_id AND p.index_id IN (0, 1)) AS row_count, " +
+			"(SELECT SUM(ps.used_page_count) * 8192 FROM sys.dm_db_partition_stats ps WHERE ps.object_id = t.object_id AND ps.index_id IN (0, 1)) AS data_bytes, " +
+			"(SELECT SUM(ps.used_page_count) * 8192 FROM sys.dm_db_partition_stats ps WHERE ps.object_id = t.object_id AND ps.index_id > 1) AS index_bytes, " +
+			"CAST(DATABASEPROPERTYEX(DB_NAME(), 'Collation') AS NVARCHAR(128)) AS collation_name, " +
+			"CAST(ep.value AS NVARCHAR(4000)) AS table_comment " +
+			"FROM sys.tables t JOIN sys.schemas s ON s.schema_id = t.schema_id " +
+			"LEFT JOIN sys.extended_properties ep ON ep.major_id = t.object_id AND ep.minor_id = 0 AND ep.class = 1 AND ep.name = 'MS_Description'"
+	case "oracle", "dameng":
+		// ALL_SEGMENTS 不存在，按统计信息的平均行长与叶块数估算大小
+		owner := dialect.QuoteLiteral(dbType, strings.ToUpper(dbName))
+		return fmt.Sprintf("SELECT t.OWNER AS schema_name, t.TABLE_NAME AS table_name, t.NUM_ROWS AS row_count, "+
+			"t.NUM_ROWS * t.AVG_ROW_LEN AS data_bytes, "+
+			"(SELECT SUM(i.LEAF_BLOCKS) * 8192 FROM ALL_INDEXES i WHERE i.TABLE_OWNER = t.OWNER AND i.TABLE_NAME = t.TABLE_NAME) AS index_bytes, "+
+			"c.COMMENTS AS table_comment FROM ALL_TABLES t "+
+			"LEFT JOIN ALL_TAB_COMMENTS c ON c.OWNER = t.OWNER AND c.TABLE_NAME = t.TABLE_NAME "+
+			"WHERE t.OWNER = %s", owner)
+	case "clickhouse":
+		return fmt.Sprintf("SELECT database AS schema_name, name AS table_name, total_rows AS row_count, total_bytes AS data_bytes, "+
+			"engine, comment AS table_comment FROM system.tables WHERE database = %s AND NOT is_temporary AND engine NOT LIKE '%%View'", schema)
+	case "duckdb":
+		return "SELECT schema_name, table_name, estimated_size AS row_count, comment AS table_comment FROM duckdb_tables() WHERE NOT internal"
+	case "sqlite":
+		// dbstat 需要编译时开启 SQLITE_ENABLE_DBSTAT_VTAB，不可用时改用 sqliteTableStatsFallbackSQL
+		return "SELECT m.name AS table_name, " +
+			"(SELECT SUM(pgsize) FROM dbstat WHERE name = m.name) AS data_bytes, " +
+			"(SELECT SUM(d.pgsize) FROM dbstat d JOIN sqlite_master i ON i.name = d.name WHERE i.type = 'index' AND i.tbl_name = m.name) AS index_bytes " +
+			"FROM sqlite_master m WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'"
+	}
+	return ""
+}
+
+const sqliteTableStatsFallbackSQL = "SELECT name AS table_name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"
+
+func loadTableStats(inst db.Database, dbType string, dbName string) ([]TableStat, error) {
+	query := tableStatsSQL(dbType, dbName)
+	if query == "" {
+		// 没有统计视图的数据源只列出表名
+		tables, err := inst.GetTables(dbName)
+		if err != nil {
+			return nil, err
+		}
+		stats := make([]TableStat, 0, len(tables))
+		for _, table := range tables {
+			schema, name := normalizeSchemaAndTableByType(dbType, dbName, table)
+			stats = append(stats, TableStat{Schema: schema, Name: name, Rows: -1, DataBytes: -1, IndexBytes: -1, TotalBytes: -1})
+		}
+		return stats, nil
+	}
+	rows, _, err := inst.Query(query)
+	if err != nil && dbType == "sqlite" {
+		rows, _, err = inst.Query(sqliteTableStatsFallbackSQL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]TableStat, 0, len(rows))
+	for _, row := range rows {
+		stat := tableStatFromRow(row)
+		if stat.Name == "" {
+			continue
+		}
+		if dbType == "sqlite" || dbType == "duckdb" {
+			// 本地文件库没有可靠的行数统计，直接计数
+			count := queryFirstString(inst, fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteTableIdentByType(dbType, stat.Schema, stat.Name)))
+			if n, ok := tableStatInt(count); ok {
+				stat.Rows = n
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// statRowText 按列名（不区分大小写）取统计结果中的文本值，NULL 返回空串。
+func statRowText(row map[string]interface{}, column string) string {
+	value, ok := archiveRowValue(row, column)
+	if !ok || value == nil {
+		return ""
+	}
+	if b, ok := value.([]byte); ok {
+		return strings.TrimSpace(string(b))
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v", value))
+}
+
+func tableStatFromRow(row map[string]interface{}) TableStat {
+	text := func(column string) string {
+		return statRowText(row, column)
+	}
+	number := func(column string) int64 {
+		if n, ok := tableStatInt(text(column)); ok && n >= 0 {
+			return n
+		}
+		return -1
+	}
+	stat := TableStat{
+		Schema:     text("schema_name"),
+		Name:       text("table_name"),
+		Rows:       number("row_count"),
+		DataBytes:  number("data_bytes"),
+		IndexBytes: number("index_bytes"),
+		Engine:     text("engine"),
+		Collation:  text("collation_name"),
+		Comment:    text("table_comment"),
+	}
+	switch {
+	case stat.DataBytes < 0 && stat.IndexBytes < 0:
+		stat.TotalBytes = -1
+	default:
+		stat.TotalBytes = max(stat.DataBytes, 0) + max(stat.IndexBytes, 0)
+	}
+	return stat
+}
+
+// tableStatInt 解析统计值，兼容 DECIMAL 与浮点形式（如 SUM 结果 "8192.0"）。
+func tableStatInt(text string) (int64, bool) {
+	if text == "" {
+		return 0, false
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n, true
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int64(f), true
+}
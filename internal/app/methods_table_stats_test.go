@@ -0,0 +1,46 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/db"
+)
+
+type tableStatsFakeDB struct {
+	db.Database
+	queries []string
+}
+
+func (f *tableStatsFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	f.queries = append(f.queries, query)
+	return []map[string]interface{}{
+		{"SCHEMA_NAME": "HR", "TABLE_NAME": "EMPLOYEES", "ROW_COUNT": "107", "DATA_BYTES": "7383.0", "INDEX_BYTES": nil, "TABLE_COMMENT": []byte("员工")},
+		{"SCHEMA_NAME": "HR", "TABLE_NAME": "JOBS", "ROW_COUNT": nil, "DATA_BYTES": nil, "INDEX_BYTES": nil},
+	}, nil, nil
+}
+
+func TestLoadTableStats(t *testing.T) {
+	fake := &tableStatsFakeDB{}
+	stats, err := loadTableStats(fake, "oracle", "hr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.queries) != 1 || !strings.Contains(fake.queries[0], "t.OWNER = 'HR'") {
+		t.Fatalf("queries = %v", fake.queries)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	emp := stats[0]
+	if emp.Name != "EMPLOYEES" || emp.Rows != 107 || emp.DataBytes != 7383 || emp.IndexBytes != -1 || emp.TotalBytes != 7383 || emp.Comment != "员工" {
+		t.Fatalf("employees = %+v", emp)
+	}
+	if jobs := stats[1]; jobs.Rows != -1 || jobs.TotalBytes != -1 {
+		t.Fatalf("jobs = %+v", jobs)
+	}
+
+	if !strings.Contains(tableStatsSQL("mysql", "o'shop"), "TABLE_SCHEMA = 'o''shop'") {
+		t.Fatal("mysql schema literal should be escaped")
+	}
+}
@@ -0,0 +1,350 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/utils"
+)
+
+const (
+	tenantIdentPlaceholder     = "{{tenant}}"      // 替换为已加引号的标识符
+	tenantNamePlaceholder      = "{{tenant_name}}" // 替换为转义后的名称，用于字符串字面量内
+	defaultTenantSourceColumn  = "_tenant"
+	defaultTenantRowsPerTarget = 1000
+	defaultTenantConcurrency   = 4
+	maxTenantConcurrency       = 16
+	maxTenantTargets           = 500
+)
+
+// TenantQueryRequest 按名称模式遍历 schema/数据库并逐个执行同一查询。
+type TenantQueryRequest struct {
+	Pattern          string `json:"pattern"`                    // 通配符，如 tenant_*（* 与 ? 可用，亦接受 %），不区分大小写
+	Scope            string `json:"scope,omitempty"`            // schema 或 database；为空时 MySQL 系为 database，其余为 schema
+	Query            string `json:"query"`                      // 只读查询，可使用 {{tenant}} 与 {{tenant_name}} 占位符
+	SourceColumn     string `json:"sourceColumn,omitempty"`     // 结果中标记来源的列名，默认 _tenant
+	MaxRowsPerTenant int    `json:"maxRowsPerTenant,omitempty"` // 每个租户最多保留的行数，默认 1000
+	Concurrency      int    `json:"concurrency,omitempty"`      // 并发数，默认 4
+	QueryID          string `json:"queryId,omitempty"`          // 指定后可通过 CancelQuery 中止
+}
+
+// TenantQueryError 单个租户的执行错误。
+type TenantQueryError struct {
+	Tenant  string `json:"tenant"`
+	Message string `json:"message"`
+}
+
+// TenantQueryResult 合并后的结果；Columns 首列为来源列。
+type TenantQueryResult struct {
+	Tenants   []string                 `json:"tenants"`
+	Columns   []string                 `json:"columns"`
+	Rows      []map[string]interface{} `json:"rows"`
+	Errors    []TenantQueryError       `json:"errors,omitempty"`
+	Truncated []string                 `json:"truncated,omitempty"` // 行数超过上限被截断的租户
+}
+
+// tenantScope 返回实际遍历的层级：database 或 schema。
+func tenantScope(dbType string, scope string) string {
+	scope = strings.ToLower(strings.TrimSpace(scope))
+	if scope == "database" || scope == "schema" {
+		return scope
+	}
+	switch dbType {
+	case "mysql", "mariadb", "diros", "clickhouse":
+		return "database"
+	}
+	return "schema"
+}
+
+// tenantSchemaQuery 返回列出 schema 的语句；MySQL 系 schema 即数据库，返回空。
+func tenantSchemaQuery(dbType string) string {
+	switch dbType {
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return "SELECT nspname AS name FROM pg_namespace WHERE nspname NOT LIKE 'pg\\_%' AND nspname <> 'information_schema' ORDER BY nspname"
+	case "sqlserver":
+		return "SELECT name FROM sys.schemas ORDER BY name"
+	case "oracle", "dameng":
+		return "SELECT USERNAME AS NAME FROM ALL_USERS ORDER BY USERNAME"
+	case "duckdb":
+		return "SELECT schema_name AS name FROM information_schema.schemata ORDER BY schema_name"
+	}
+	return ""
+}
+
+// isSystemTenantName 排除各数据库自带的系统库/schema，避免模式为 * 时误扫。
+func isSystemTenantName(name string) bool {
+	switch strings.ToLower(name) {
+	case "information_schema", "performance_schema", "mysql", "sys", "pg_catalog", "pg_toast",
+		"guest", "db_owner", "db_accessadmin", "db_securityadmin", "db_ddladmin", "db_backupoperator",
+		"db_datareader", "db_datawriter", "db_denydatareader", "db_denydatawriter":
+		return true
+	}
+	return false
+}
+
+// matchTenantNames 按通配符筛选名称并保持原顺序；% 视同 *。
+func matchTenantNames(names []string, pattern string) ([]string, error) {
+	pattern = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(pattern, "%", "*")))
+	if pattern == "" {
+		pattern = "*"
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("名称模式无效：%s", pattern)
+	}
+	matched := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || isSystemTenantName(name) {
+			continue
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		if ok, _ := path.Match(pattern, strings.ToLower(name)); ok {
+			seen[name] = struct{}{}
+			matched = append(matched, name)
+		}
+	}
+	return matched, nil
+}
+
+// renderTenantQuery 替换占位符；不含 {{tenant}} 的查询在各租户上原样执行（依赖连接/会话的默认库）。
+func renderTenantQuery(dbType string, query string, tenant string) string {
+	return strings.NewReplacer(
+		tenantIdentPlaceholder, quoteIdentByType(dbType, tenant),
+		tenantNamePlaceholder, literalContent(dbType, tenant),
+	).Replace(query)
+}
+
+// literalContent 返回 value 转义后去掉两侧引号的内容，用于替换用户写在字符串字面量内的占位符。
+func literalContent(dbType string, value string) string {
+	quoted := dialect.QuoteLiteral(dbType, value)
+	return quoted[1 : len(quoted)-1]
+}
+
+// mergeTenantRows 为每行加上来源列，并按首次出现顺序合并列名。
+func mergeTenantRows(result *TenantQueryResult, sourceColumn string, tenant string, rows []map[string]interface{}, columns []string, limit int) {
+	if len(result.Columns) == 0 {
+		result.Columns = append(result.Columns, sourceColumn)
+	}
+	for _, col := range columns {
+		if col == sourceColumn {
+			continue
+		}
+		exists := false
+		for _, existing := range result.Columns {
+			if existing == col {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			result.Columns = append(result.Columns, col)
+		}
+	}
+	if limit > 0 && len(rows) > limit {
+		rows = rows[:limit]
+		result.Truncated = append(result.Truncated, tenant)
+	}
+	for _, row := range rows {
+		merged := make(map[string]interface{}, len(row)+1)
+		for k, v := range row {
+			merged[k] = v
+		}
+		merged[sourceColumn] = tenant
+		result.Rows = append(result.Rows, merged)
+	}
+}
+
+// listTenantTargets 列出当前层级下的全部候选名称。
+func listTenantTargets(ctx context.Context, inst db.Database, dbType string, scope string) ([]string, error) {
+	query := ""
+	if scope == "schema" {
+		query = tenantSchemaQuery(dbType)
+	}
+	if query == "" {
+		return inst.GetDatabases()
+	}
+	var rows []map[string]interface{}
+	var err error
+	if q, ok := inst.(interface {
+		QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+	}); ok {
+		rows, _, err = q.QueryContext(ctx, query)
+	} else {
+		rows, _, err = inst.Query(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if name := backupRowString(row, "name"); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// QueryAcrossTenants 枚举名称匹配模式的 schema/数据库，逐个执行只读查询并合并结果，来源写入 SourceColumn 列。
+// 单个租户失败不影响其余租户，错误在 Errors 中返回。
+func (a *App) QueryAcrossTenants(config connection.ConnectionConfig, dbName string, req TenantQueryRequest) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbType := resolveDDLDBType(runConfig)
+	if strings.TrimSpace(req.Query) == "" {
+		return connection.QueryResult{Success: false, Message: "查询语句不能为空"}
+	}
+	if !isReadQuery(dbType, req.Query) || readOnlyViolation(dbType, req.Query) != "" {
+		return connection.QueryResult{Success: false, Message: "多租户遍历仅支持只读查询"}
+	}
+	scope := tenantScope(dbType, req.Scope)
+	sourceColumn := strings.TrimSpace(req.SourceColumn)
+	if sourceColumn == "" {
+		sourceColumn = defaultTenantSourceColumn
+	}
+	limit := req.MaxRowsPerTenant
+	if limit <= 0 {
+		limit = defaultTenantRowsPerTarget
+	}
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTenantConcurrency
+	}
+	if concurrency > maxTenantConcurrency {
+		concurrency = maxTenantConcurrency
+	}
+
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		logger.Error(err, "QueryAcrossTenants 获取连接失败：%s", formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	ctx := context.Background()
+	if queryID := strings.TrimSpace(req.QueryID); queryID != "" {
+		var tracked *runningQuery
+		ctx, tracked, err = a.registerQuery(ctx, queryID, runConfig)
+		if err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		defer a.unregisterQuery(tracked)
+	}
+
+	names, err := listTenantTargets(ctx, dbInst, dbType, scope)
+	if err != nil {
+		logger.Error(err, "QueryAcrossTenants 列出%s失败：%s", scope, formatConnSummary(runConfig))
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	tenants, err := matchTenantNames(names, req.Pattern)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if len(tenants) == 0 {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("没有名称匹配 %s 的%s", req.Pattern, scope)}
+	}
+	if len(tenants) > maxTenantTargets {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("匹配到 %d 个目标，超过上限 %d，请缩小名称模式", len(tenants), maxTenantTargets)}
+	}
+
+	timeoutSeconds := runConfig.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 30
+	}
+	type tenantOutput struct {
+		rows    []map[string]interface{}
+		columns []string
+		err     error
+	}
+	outputs := make([]tenantOutput, len(tenants))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, tenant := range tenants {
+		wg.Add(1)
+		go func(i int, tenant string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				outputs[i].err = errQueryCanceled
+				return
+			}
+			tctx, cancel := utils.ContextWithTimeout(time.Duration(timeoutSeconds) * time.Second)
+			defer cancel()
+			stop := context.AfterFunc(ctx, cancel)
+			defer stop()
+			query := renderTenantQuery(dbType, req.Query, tenant)
+			started := time.Now()
+			outputs[i].rows, outputs[i].columns, outputs[i].err = a.queryTenant(tctx, runConfig, dbInst, dbType, scope, tenant, query)
+			a.wireLog.record(runConfig, tenant, "query", query, started, int64(len(outputs[i].rows)), outputs[i].err)
+		}(i, tenant)
+	}
+	wg.Wait()
+
+	result := TenantQueryResult{Tenants: tenants, Rows: []map[string]interface{}{}}
+	for i, tenant := range tenants {
+		if outputs[i].err != nil {
+			result.Errors = append(result.Errors, TenantQueryError{Tenant: tenant, Message: outputs[i].err.Error()})
+			continue
+		}
+		mergeTenantRows(&result, sourceColumn, tenant, outputs[i].rows, outputs[i].columns, limit)
+	}
+	if len(result.Columns) == 0 {
+		result.Columns = []string{sourceColumn}
+	}
+	if ctx.Err() != nil {
+		return connection.QueryResult{Success: false, Message: "已取消查询", Data: result}
+	}
+	logger.Infof("多租户查询完成：%s 层级=%s 目标=%d 失败=%d 行数=%d", formatConnSummary(runConfig), scope, len(tenants), len(result.Errors), len(result.Rows))
+	msg := fmt.Sprintf("已查询 %d 个%s，共 %d 行", len(tenants)-len(result.Errors), scope, len(result.Rows))
+	if len(result.Errors) > 0 {
+		msg += fmt.Sprintf("，%d 个失败", len(result.Errors))
+	}
+	return connection.QueryResult{Success: len(result.Errors) < len(tenants), Message: msg, Data: result, Fields: result.Columns}
+}
+
+// queryTenant 在单个租户上执行查询：schema 层级及带 {{tenant}} 限定的查询复用当前连接；
+// MySQL 系数据库层级在专用会话上 USE 后执行（会话用后丢弃，避免默认库串到连接池）；其他数据库层级按库建立连接。
+func (a *App) queryTenant(ctx context.Context, config connection.ConnectionConfig, baseInst db.Database, dbType string, scope string, tenant string, query string) ([]map[string]interface{}, []string, error) {
+	if scope == "schema" {
+		return queryTenantOn(ctx, baseInst, query)
+	}
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		opener, ok := baseInst.(db.SessionOpener)
+		if !ok {
+			break
+		}
+		session, err := opener.OpenSession(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer session.Close()
+		if _, err := session.ExecContext(ctx, "USE "+quoteIdentByType(dbType, tenant)); err != nil {
+			return nil, nil, err
+		}
+		return session.QueryContext(ctx, query)
+	}
+	inst, err := a.getDatabase(normalizeRunConfig(config, tenant))
+	if err != nil {
+		return nil, nil, err
+	}
+	return queryTenantOn(ctx, inst, query)
+}
+
+func queryTenantOn(ctx context.Context, inst db.Database, query string) ([]map[string]interface{}, []string, error) {
+	if q, ok := inst.(interface {
+		QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+	}); ok {
+		return q.QueryContext(ctx, query)
+	}
+	return inst.Query(query)
+}
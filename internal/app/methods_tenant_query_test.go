@@ -0,0 +1,50 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchTenantNames(t *testing.T) {
+	names := []string{"tenant_a", "Tenant_B", "information_schema", "public", "tenant_a", "tenantx"}
+	got, err := matchTenantNames(names, "tenant_*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"tenant_a", "Tenant_B"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	got, _ = matchTenantNames(names, "tenant%")
+	if want := []string{"tenant_a", "Tenant_B", "tenantx"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, err := matchTenantNames(names, "tenant_["); err == nil {
+		t.Fatal("expected invalid pattern error")
+	}
+}
+
+func TestRenderTenantQuery(t *testing.T) {
+	got := renderTenantQuery("postgres", "SELECT '{{tenant_name}}' AS t, count(*) FROM {{tenant}}.orders", `o'hara"x`)
+	want := `SELECT 'o''hara"x' AS t, count(*) FROM "o'hara""x".orders`
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got := renderTenantQuery("mysql", "SELECT * FROM {{tenant}}.users", "t1"); got != "SELECT * FROM `t1`.users" {
+		t.Fatalf("unexpected mysql query: %s", got)
+	}
+}
+
+func TestMergeTenantRows(t *testing.T) {
+	var result TenantQueryResult
+	mergeTenantRows(&result, "_tenant", "a", []map[string]interface{}{{"id": 1}, {"id": 2}}, []string{"id"}, 1)
+	mergeTenantRows(&result, "_tenant", "b", []map[string]interface{}{{"id": 3, "name": "x"}}, []string{"id", "name"}, 1)
+	if want := []string{"_tenant", "id", "name"}; !reflect.DeepEqual(result.Columns, want) {
+		t.Fatalf("columns = %v, want %v", result.Columns, want)
+	}
+	if len(result.Rows) != 2 || result.Rows[0]["_tenant"] != "a" || result.Rows[1]["_tenant"] != "b" {
+		t.Fatalf("unexpected rows: %v", result.Rows)
+	}
+	if !reflect.DeepEqual(result.Truncated, []string{"a"}) {
+		t.Fatalf("truncated = %v", result.Truncated)
+	}
+}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	timeTravelDefaultLimit = 1000
+	timeTravelMaxLimit     = 100000
+)
+
+// TimeTravelCapability 描述当前连接/表是否支持时间点查询及其方式。
+type TimeTravelCapability struct {
+	Supported  bool   `json:"supported"`
+	Engine     string `json:"engine"`               // mariadb/tidb/oracle
+	Syntax     string `json:"syntax,omitempty"`     // FOR SYSTEM_TIME AS OF / AS OF TIMESTAMP / FLASHBACK
+	EarliestAt string `json:"earliestAt,omitempty"` // 可回溯的最早时间（已知时）
+	Reason     string `json:"reason,omitempty"`
+}
+
+// GetTimeTravelCapability 检测时间点查询能力：MariaDB 系统版本表、TiDB AS OF TIMESTAMP、Oracle 闪回查询。
+func (a *App) GetTimeTravelCapability(config connection.ConnectionConfig, dbName string, tableName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	capability := detectTimeTravelCapability(dbInst, resolveDDLDBType(runConfig), dbName, tableName)
+	return connection.QueryResult{Success: true, Message: capability.Reason, Data: capability}
+}
+
+// TimeTravelQuery 读取指定表在 asOf 时间点的数据。asOf 支持 “2006-01-02 15:04:05” 或 RFC3339，
+// 不含时区时按本机时区解释；必须早于当前时间且不早于引擎可回溯的最早时间。
+func (a *App) TimeTravelQuery(config connection.ConnectionConfig, dbName string, tableName string, asOf string, limit int) connection.QueryResult {
+	tableName = strings.TrimSpace(tableName)
+	if tableName == "" {
+		return connection.QueryResult{Success: false, Message: "表名不能为空"}
+	}
+	at, err := parseTimeTravelTimestamp(asOf, time.Now())
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if limit <= 0 {
+		limit = timeTravelDefaultLimit
+	}
+	if limit > timeTravelMaxLimit {
+		limit = timeTravelMaxLimit
+	}
+
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	dbType := resolveDDLDBType(runConfig)
+	capability := detectTimeTravelCapability(dbInst, dbType, dbName, tableName)
+	if !capability.Supported {
+		return connection.QueryResult{Success: false, Message: capability.Reason, Data: capability}
+	}
+	if capability.EarliestAt != "" {
+		if earliest, ok := parseTimeTravelEarliest(capability.EarliestAt); ok && at.Before(earliest) {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("时间点早于可回溯的最早时间 %s", capability.EarliestAt), Data: capability}
+		}
+	}
+
+	schemaName, pureTableName := normalizeSchemaAndTableByType(dbType, dbName, tableName)
+	query := buildTimeTravelQuery(capability.Engine, quoteTableIdentByType(dbType, schemaName, pureTableName), at, limit)
+	data, columns, err := dbInst.Query(query)
+	if err != nil {
+		logger.Error(err, "时间点查询失败：%s SQL片段=%q", formatConnSummary(runConfig), sqlSnippet(query))
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已读取 %s（UTC %s）时刻的数据", at.Format("2006-01-02 15:04:05.999999 -07:00"), at.UTC().Format("2006-01-02 15:04:05.999999")), Data: data, Fields: columns}
+}
+
+func parseTimeTravelTimestamp(raw string, now time.Time) (time.Time, error) {
+	text := strings.TrimSpace(raw)
+	if text == "" {
+		return time.Time{}, fmt.Errorf("请指定时间点")
+	}
+
+	var at time.Time
+	parsed := false
+	for _, layout := range []string{time.RFC3339Nano, time.RFC3339, "2006-01-02 15:04:05 -0700"} {
+		if t, err := time.Parse(layout, text); err == nil {
+			at, parsed = t.In(time.Local), true
+			break
+		}
+	}
+	if !parsed {
+		for _, layout := range []string{"2006-01-02 15:04:05.999999", "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04"} {
+			if t, err := time.ParseInLocation(layout, text, time.Local); err == nil {
+				at, parsed = t, true
+				break
+			}
+		}
+	}
+	if !parsed {
+		return time.Time{}, fmt.Errorf("时间格式不正确：%s（示例：2024-01-02 15:04:05）", text)
+	}
+	if !at.Before(now) {
+		return time.Time{}, fmt.Errorf("时间点必须早于当前时间")
+	}
+	if at.Year() < 1970 {
+		return time.Time{}, fmt.Errorf("时间点超出范围")
+	}
+	return at, nil
+}
+
+// buildTimeTravelQuery 时间点统一按 UTC 写出并带上时区，不依赖会话时区与应用所在机器的时区一致。
+func buildTimeTravelQuery(engine string, qualifiedTable string, at time.Time, limit int) string {
+	utc := at.UTC().Format("2006-01-02 15:04:05.000000")
+	switch engine {
+	case "mariadb":
+		return fmt.Sprintf("SELECT * FROM %s FOR SYSTEM_TIME AS OF TIMESTAMP '%s+00:00' LIMIT %d", qualifiedTable, utc, limit)
+	case "tidb":
+		// MySQL 协议的 TIMESTAMP 按会话时区解释，需从 UTC 换算
+		return fmt.Sprintf("SELECT * FROM %s AS OF TIMESTAMP CONVERT_TZ('%s', '+00:00', @@session.time_zone) LIMIT %d", qualifiedTable, utc, limit)
+	case "oracle":
+		return fmt.Sprintf("SELECT * FROM %s AS OF TIMESTAMP TIMESTAMP '%s +00:00' WHERE ROWNUM <= %d", qualifiedTable, utc, limit)
+	default:
+		return ""
+	}
+}
+
+func detectTimeTravelCapability(dbInst db.Database, dbType string, dbName string, tableName string) TimeTravelCapability {
+	switch dbType {
+	case "mysql", "mariadb":
+		version := queryFirstString(dbInst, "SELECT VERSION() AS v")
+		lowerVersion := strings.ToLower(version)
+		switch {
+		case strings.Contains(lowerVersion, "tidb"):
+			capability := TimeTravelCapability{Supported: true, Engine: "tidb", Syntax: "AS OF TIMESTAMP"}
+			if safePoint := queryFirstString(dbInst, "SELECT VARIABLE_VALUE FROM mysql.tidb WHERE VARIABLE_NAME = 'tikv_gc_safe_point'"); safePoint != "" {
+				capability.EarliestAt = safePoint
+			}
+			return capability
+		case strings.Contains(lowerVersion, "mariadb") || dbType == "mariadb":
+			capability := TimeTravelCapability{Engine: "mariadb", Syntax: "FOR SYSTEM_TIME AS OF"}
+			if strings.TrimSpace(tableName) == "" {
+				capability.Supported = true
+				return capability
+			}
+			schemaName, pureTableName := normalizeSchemaAndTableByType(dbType, dbName, tableName)
+			tableType := queryFirstString(dbInst, fmt.Sprintf(
+				"SELECT TABLE_TYPE FROM information_schema.TABLES WHERE TABLE_SCHEMA = %s AND TABLE_NAME = %s",
+				dialect.QuoteLiteral(dbType, schemaName), dialect.QuoteLiteral(dbType, pureTableName)))
+			if strings.EqualFold(tableType, "SYSTEM VERSIONED") {
+				capability.Supported = true
+				return capability
+			}
+			capability.Reason = "该表未启用系统版本（WITH SYSTEM VERSIONING），无法进行时间点查询"
+			return capability
+		default:
+			return TimeTravelCapability{Engine: dbType, Reason: "MySQL 不支持时间点查询（仅 MariaDB 系统版本表与 TiDB 支持）"}
+		}
+	case "oracle":
+		return TimeTravelCapability{Supported: true, Engine: "oracle", Syntax: "FLASHBACK (AS OF TIMESTAMP)",
+			Reason: "可回溯范围受 UNDO_RETENTION 限制，超出范围时数据库将返回 ORA-01555/ORA-08180"}
+	default:
+		return TimeTravelCapability{Engine: dbType, Reason: fmt.Sprintf("当前数据源(%s)不支持时间点查询", dbType)}
+	}
+}
+
+// parseTimeTravelEarliest 解析 TiDB GC safe point（形如 20240102-15:04:05.000 +0800）。
+func parseTimeTravelEarliest(text string) (time.Time, bool) {
+	for _, layout := range []string{"20060102-15:04:05.000 -0700", "20060102-15:04:05 -0700"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(text)); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func queryFirstString(dbInst db.Database, query string) string {
+	data, columns, err := dbInst.Query(query)
+	if err != nil || len(data) == 0 || len(columns) == 0 {
+		return ""
+	}
+	value := data[0][columns[0]]
+	if value == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v", value))
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/db"
+)
+
+type timeTravelFakeDB struct {
+	db.Database
+	version   string
+	safePoint string
+	tableType string
+}
+
+func (f *timeTravelFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	var value string
+	switch {
+	case strings.Contains(query, "VERSION()"):
+		value = f.version
+	case strings.Contains(query, "tikv_gc_safe_point"):
+		value = f.safePoint
+	case strings.Contains(query, "information_schema.TABLES"):
+		value = f.tableType
+	}
+	if value == "" {
+		return nil, []string{"v"}, nil
+	}
+	return []map[string]interface{}{{"v": value}}, []string{"v"}, nil
+}
+
+func TestDetectTimeTravelCapability(t *testing.T) {
+	capability := detectTimeTravelCapability(&timeTravelFakeDB{version: "8.0.11-TiDB-v7.5.0", safePoint: "20240102-15:04:05.000 +0800"}, "mysql", "shop", "orders")
+	if !capability.Supported || capability.Engine != "tidb" || capability.EarliestAt == "" {
+		t.Fatalf("tidb capability = %+v", capability)
+	}
+	if earliest, ok := parseTimeTravelEarliest(capability.EarliestAt); !ok || earliest.UTC().Hour() != 7 {
+		t.Fatalf("safe point = %v %v", earliest, ok)
+	}
+
+	capability = detectTimeTravelCapability(&timeTravelFakeDB{version: "10.11.6-MariaDB", tableType: "SYSTEM VERSIONED"}, "mysql", "shop", "orders")
+	if !capability.Supported || capability.Engine != "mariadb" {
+		t.Fatalf("mariadb capability = %+v", capability)
+	}
+	capability = detectTimeTravelCapability(&timeTravelFakeDB{version: "10.11.6-MariaDB", tableType: "BASE TABLE"}, "mariadb", "shop", "orders")
+	if capability.Supported || capability.Reason == "" {
+		t.Fatalf("unversioned table capability = %+v", capability)
+	}
+
+	if capability := detectTimeTravelCapability(&timeTravelFakeDB{version: "8.0.36"}, "mysql", "shop", "orders"); capability.Supported {
+		t.Fatalf("mysql capability = %+v", capability)
+	}
+	if capability := detectTimeTravelCapability(&timeTravelFakeDB{}, "postgres", "shop", "orders"); capability.Supported {
+		t.Fatalf("postgres capability = %+v", capability)
+	}
+}
+
+func TestParseTimeTravelTimestamp(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	at, err := parseTimeTravelTimestamp(" 2024-06-01 11:30:00 ", now)
+	if err != nil || !at.Equal(time.Date(2024, 6, 1, 11, 30, 0, 0, time.Local)) {
+		t.Fatalf("local timestamp = %v %v", at, err)
+	}
+	if _, err := parseTimeTravelTimestamp("2024-05-01T00:00:00Z", now); err != nil {
+		t.Fatalf("rfc3339 timestamp: %v", err)
+	}
+	for _, raw := range []string{"", "yesterday", "2024-06-01 12:00:00", "1969-12-31 00:00:00"} {
+		if _, err := parseTimeTravelTimestamp(raw, now); err == nil {
+			t.Errorf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestBuildTimeTravelQuery(t *testing.T) {
+	at := time.Date(2024, 1, 2, 11, 4, 5, 250000000, time.FixedZone("CST", 8*3600))
+	cases := map[string]string{
+		"mariadb": "SELECT * FROM `t` FOR SYSTEM_TIME AS OF TIMESTAMP '2024-01-02 03:04:05.250000+00:00' LIMIT 10",
+		"tidb":    "SELECT * FROM `t` AS OF TIMESTAMP CONVERT_TZ('2024-01-02 03:04:05.250000', '+00:00', @@session.time_zone) LIMIT 10",
+		"oracle":  "SELECT * FROM `t` AS OF TIMESTAMP TIMESTAMP '2024-01-02 03:04:05.250000 +00:00' WHERE ROWNUM <= 10",
+	}
+	for engine, want := range cases {
+		if got := buildTimeTravelQuery(engine, "`t`", at, 10); got != want {
+			t.Errorf("%s query = %s", engine, got)
+		}
+	}
+}
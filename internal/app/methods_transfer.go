@@ -0,0 +1,658 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	transferProgressEvent    = "transfer:progress"
+	transferDoneEvent        = "transfer:done"
+	defaultTransferBatchRows = 1000
+	maxTransferBatchRows     = 20000
+	maxTransferRowErrors     = 100
+
+	transferPolicyAbort = "abort"
+	transferPolicySkip  = "skip"
+)
+
+// TransferOptions 跨连接传输表的选项。
+type TransferOptions struct {
+	// JobID 非空时作为进度事件与取消的标识，CancelQuery(JobID) 会在当前批次结束后停止传输。
+	JobID    string `json:"jobId,omitempty"`
+	SourceDB string `json:"sourceDb,omitempty"` // 源库（Oracle/达梦为 schema），为空时使用连接配置中的库
+	TargetDB string `json:"targetDb,omitempty"`
+	Content  string `json:"content,omitempty"` // both（默认）/schema/data
+	// Existing 目标表已存在时的处理：append（默认，追加数据）/truncate（先清空）/replace（删除后按源表重建）。
+	Existing string `json:"existing,omitempty"`
+	// ErrorPolicy abort（默认）遇错即停止整个任务；skip 跳过写入失败的行与失败的表，继续传输其余数据。
+	ErrorPolicy  string            `json:"errorPolicy,omitempty"`
+	BatchRows    int               `json:"batchRows,omitempty"`    // 每批读取与写入的行数，默认 1000
+	TargetTables map[string]string `json:"targetTables,omitempty"` // 源表 → 目标表名，未列出的沿用源表名
+}
+
+// TransferTableResult 单张表的传输结果。
+type TransferTableResult struct {
+	Source     string   `json:"source"`
+	Target     string   `json:"target"`
+	Status     string   `json:"status"` // done/failed/skipped/canceled
+	Created    bool     `json:"created,omitempty"`
+	CreateSQL  string   `json:"createSql,omitempty"`
+	Method     string   `json:"method,omitempty"` // 写入方式：INSERT 或驱动的高速通道
+	Rows       int64    `json:"rows"`             // 已写入行数
+	FailedRows int64    `json:"failedRows,omitempty"`
+	Errors     []string `json:"errors,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
+	DurationMs int64    `json:"durationMs"`
+}
+
+// TransferSummary 传输任务汇总。
+type TransferSummary struct {
+	JobID      string                `json:"jobId"`
+	Tables     []TransferTableResult `json:"tables"`
+	Succeeded  int                   `json:"succeeded"`
+	Failed     int                   `json:"failed"`
+	Skipped    int                   `json:"skipped"`
+	Rows       int64                 `json:"rows"`
+	FailedRows int64                 `json:"failedRows"`
+	Canceled   bool                  `json:"canceled,omitempty"`
+	DurationMs int64                 `json:"durationMs"`
+}
+
+// TransferProgressEvent 传输进度，随 transfer:progress 事件发出。
+type TransferProgressEvent struct {
+	JobID     string `json:"jobId"`
+	Table     string `json:"table"`
+	Index     int    `json:"index"` // 当前表序号，从 0 开始
+	Total     int    `json:"total"` // 表数量
+	Stage     string `json:"stage"` // schema/data/done/failed
+	Rows      int64  `json:"rows"`  // 当前表已写入行数
+	TableRows int64  `json:"tableRows"`
+}
+
+// transferJob 一次传输任务的上下文。
+type transferJob struct {
+	id           string
+	sourceConfig connection.ConnectionConfig
+	targetConfig connection.ConnectionConfig
+	sourceDB     string
+	targetDB     string
+	sourceType   string
+	targetType   string
+	sourceInst   db.Database
+	targetInst   db.Database
+	options      TransferOptions
+	schema       bool
+	data         bool
+	total        int
+}
+
+// TransferTables 把源连接中的表（结构与数据）复制到另一个连接，源与目标可以是不同类型的数据库，列类型按通用类型转换。
+// 数据按主键顺序分批读取、分批写入，不在一个事务中完成：中途失败或取消时已写入的批次会保留。
+func (a *App) TransferTables(sourceConfig connection.ConnectionConfig, targetConfig connection.ConnectionConfig, tables []string, options TransferOptions) connection.QueryResult {
+	if len(tables) == 0 {
+		return connection.QueryResult{Success: false, Message: "请选择要传输的表"}
+	}
+	if err := ensureWritable(targetConfig, "作为传输目标"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	job := transferJob{options: options, total: len(tables)}
+	switch strings.ToLower(strings.TrimSpace(options.Content)) {
+	case "", "both":
+		job.schema, job.data = true, true
+	case "schema":
+		job.schema = true
+	case "data":
+		job.data = true
+	default:
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("未知的传输内容：%s", options.Content)}
+	}
+	switch strings.ToLower(strings.TrimSpace(options.Existing)) {
+	case "", "append", "truncate", "replace":
+	default:
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("未知的已存在表处理方式：%s", options.Existing)}
+	}
+	switch strings.ToLower(strings.TrimSpace(options.ErrorPolicy)) {
+	case "", transferPolicyAbort, transferPolicySkip:
+	default:
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("未知的错误处理策略：%s", options.ErrorPolicy)}
+	}
+
+	job.sourceDB = strings.TrimSpace(options.SourceDB)
+	if job.sourceDB == "" {
+		job.sourceDB = strings.TrimSpace(sourceConfig.Database)
+	}
+	job.targetDB = strings.TrimSpace(options.TargetDB)
+	if job.targetDB == "" {
+		job.targetDB = strings.TrimSpace(targetConfig.Database)
+	}
+	job.sourceConfig = normalizeRunConfig(sourceConfig, job.sourceDB)
+	job.targetConfig = normalizeRunConfig(targetConfig, job.targetDB)
+	job.sourceType = resolveDDLDBType(job.sourceConfig)
+	job.targetType = resolveDDLDBType(job.targetConfig)
+	for _, dbType := range []string{job.sourceType, job.targetType} {
+		if dbType == "mongodb" || dbType == "redis" {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("%s 不支持表传输", dbType)}
+		}
+	}
+	if job.schema {
+		if _, err := renderTransferColumnType(job.targetType, transferColumnType{kind: "text"}); err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+	}
+
+	var err error
+	if job.sourceInst, err = a.getDatabase(job.sourceConfig); err != nil {
+		return connection.QueryResult{Success: false, Message: "连接源数据库失败：" + err.Error()}
+	}
+	if job.targetInst, err = a.getDatabase(job.targetConfig); err != nil {
+		return connection.QueryResult{Success: false, Message: "连接目标数据库失败：" + err.Error()}
+	}
+
+	job.id = strings.TrimSpace(options.JobID)
+	if job.id == "" {
+		job.id = fmt.Sprintf("transfer-%d", time.Now().UnixNano())
+	}
+	ctx, tracked, err := a.registerQuery(context.Background(), job.id, job.sourceConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	defer a.unregisterQuery(tracked)
+
+	logger.Infof("开始表传输：源=%s 目标=%s 表数量=%d", formatConnSummary(job.sourceConfig), formatConnSummary(job.targetConfig), len(tables))
+	started := time.Now()
+	summary := TransferSummary{JobID: job.id, Tables: make([]TransferTableResult, 0, len(tables))}
+	stopped := false
+	for i, name := range tables {
+		if stopped || ctx.Err() != nil {
+			summary.Tables = append(summary.Tables, TransferTableResult{Source: name, Status: "skipped"})
+			summary.Skipped++
+			continue
+		}
+		result := a.transferTable(ctx, &job, i, name)
+		summary.Tables = append(summary.Tables, result)
+		summary.Rows += result.Rows
+		summary.FailedRows += result.FailedRows
+		switch result.Status {
+		case "done":
+			summary.Succeeded++
+		case "canceled":
+			summary.Canceled = true
+			stopped = true
+		default:
+			summary.Failed++
+			stopped = !strings.EqualFold(strings.TrimSpace(options.ErrorPolicy), transferPolicySkip)
+		}
+	}
+	if ctx.Err() != nil {
+		summary.Canceled = true
+	}
+	summary.DurationMs = time.Since(started).Milliseconds()
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, transferDoneEvent, summary)
+	}
+
+	message := fmt.Sprintf("共 %d 张表，成功 %d 张，失败 %d 张，写入 %d 行", len(tables), summary.Succeeded, summary.Failed, summary.Rows)
+	if summary.Skipped > 0 {
+		message += fmt.Sprintf("，跳过 %d 张", summary.Skipped)
+	}
+	if summary.FailedRows > 0 {
+		message += fmt.Sprintf("，%d 行写入失败", summary.FailedRows)
+	}
+	if summary.Canceled {
+		message = "传输已取消：" + message
+	}
+	logger.Infof("表传输结束：%s 耗时=%dms", message, summary.DurationMs)
+	return connection.QueryResult{Success: summary.Failed == 0 && !summary.Canceled, Message: message, Data: summary}
+}
+
+func (a *App) emitTransferProgress(job *transferJob, index int, table string, stage string, rows int64, tableRows int64) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, transferProgressEvent, TransferProgressEvent{
+		JobID: job.id, Table: table, Index: index, Total: job.total, Stage: stage, Rows: rows, TableRows: tableRows,
+	})
+}
+
+// transferTable 传输单张表：按需建表，再分页读取源表写入目标表。
+func (a *App) transferTable(ctx context.Context, job *transferJob, index int, name string) (result TransferTableResult) {
+	started := time.Now()
+	sourceSchema, sourceTable := normalizeSchemaAndTable(job.sourceConfig, job.sourceDB, name)
+	targetName := sourceTable
+	if mapped := strings.TrimSpace(job.options.TargetTables[name]); mapped != "" {
+		targetName = mapped
+	}
+	targetSchema, targetTable := normalizeSchemaAndTable(job.targetConfig, job.targetDB, targetName)
+	targetQualified := qualifyTable(targetSchema, targetTable)
+	result = TransferTableResult{Source: name, Target: targetQualified, Status: "failed"}
+	defer func() {
+		result.DurationMs = time.Since(started).Milliseconds()
+		stage := "done"
+		if result.Status != "done" {
+			stage = "failed"
+		}
+		a.emitTransferProgress(job, index, name, stage, result.Rows, -1)
+	}()
+	fail := func(format string, args ...interface{}) TransferTableResult {
+		msg := fmt.Sprintf(format, args...)
+		logger.Warnf("表传输失败：表=%s %s", name, msg)
+		result.Errors = append(result.Errors, msg)
+		return result
+	}
+
+	if getCacheKey(applyCustomDriverType(job.sourceConfig)) == getCacheKey(applyCustomDriverType(job.targetConfig)) &&
+		strings.EqualFold(qualifyTable(sourceSchema, sourceTable), targetQualified) {
+		return fail("源表与目标表相同")
+	}
+	sourceColumns, err := job.sourceInst.GetColumns(sourceSchema, sourceTable)
+	if err != nil || len(sourceColumns) == 0 {
+		if err == nil {
+			err = errors.New("源表没有列")
+		}
+		return fail("读取源表字段失败：%s", normalizeErrorMessage(err))
+	}
+
+	exec := databaseExecFunc(job.targetInst)
+	targetColumns, targetErr := job.targetInst.GetColumns(targetSchema, targetTable)
+	exists := targetErr == nil && len(targetColumns) > 0
+	existing := strings.ToLower(strings.TrimSpace(job.options.Existing))
+	if exists && existing == "replace" && job.schema {
+		dropSQL := "DROP TABLE " + quoteQualifiedIdentByType(job.targetType, targetQualified)
+		if _, err := exec(ctx, dropSQL); err != nil {
+			return fail("删除目标表失败：%s", normalizeErrorMessage(err))
+		}
+		exists = false
+	}
+
+	if !exists {
+		if !job.schema {
+			return fail("目标表 %s 不存在，仅传输数据时需要先创建目标表", targetQualified)
+		}
+		a.emitTransferProgress(job, index, name, "schema", 0, -1)
+		createSQL, warnings, err := buildTransferCreateSQL(job.sourceType, job.targetType, targetQualified, sourceColumns)
+		if err != nil {
+			return fail("%s", err.Error())
+		}
+		result.Warnings = append(result.Warnings, warnings...)
+		result.CreateSQL = createSQL
+		if _, err := exec(ctx, createSQL); err != nil {
+			logger.Error(err, "表传输建表失败：%s SQL片段=%q", formatConnSummary(job.targetConfig), sqlSnippet(createSQL))
+			return fail("创建目标表失败：%s", normalizeErrorMessage(err))
+		}
+		result.Created = true
+		if targetColumns, err = job.targetInst.GetColumns(targetSchema, targetTable); err != nil || len(targetColumns) == 0 {
+			// 读不到新表字段时按建表语句推断目标列类型
+			targetColumns = transferTargetColumns(job, sourceColumns)
+		}
+	} else if existing == "truncate" && job.data {
+		clearSQL := "DELETE FROM " + quoteQualifiedIdentByType(job.targetType, targetQualified)
+		if job.targetType != "sqlite" && job.targetType != "duckdb" {
+			clearSQL = "TRUNCATE TABLE " + quoteQualifiedIdentByType(job.targetType, targetQualified)
+		}
+		if _, err := exec(ctx, clearSQL); err != nil {
+			return fail("清空目标表失败：%s", normalizeErrorMessage(err))
+		}
+	}
+
+	if !job.data {
+		result.Status = "done"
+		return result
+	}
+	columns, targetKinds, warnings := matchTransferColumns(job.targetType, sourceColumns, targetColumns)
+	result.Warnings = append(result.Warnings, warnings...)
+	if len(columns) == 0 {
+		return fail("源表与目标表没有同名列")
+	}
+	a.copyTransferRows(ctx, job, index, name, quoteTableIdentByType(job.sourceType, sourceSchema, sourceTable), targetQualified, sourceColumns, columns, targetKinds, &result)
+	return result
+}
+
+// transferTargetColumns 返回按建表语句转换后的目标列定义。
+func transferTargetColumns(job *transferJob, sourceColumns []connection.ColumnDefinition) []connection.ColumnDefinition {
+	out := make([]connection.ColumnDefinition, 0, len(sourceColumns))
+	for _, col := range sourceColumns {
+		if strings.TrimSpace(col.Generated) != "" {
+			continue
+		}
+		colType, _, _ := transferColumnDDLType(job.sourceType, job.targetType, col)
+		out = append(out, connection.ColumnDefinition{Name: col.Name, Type: colType})
+	}
+	return out
+}
+
+// matchTransferColumns 按列名（忽略大小写）匹配源列与目标列，返回要复制的源列名与对应目标列的通用类型。
+// 生成列、目标表中不存在的列不复制。
+func matchTransferColumns(targetType string, sourceColumns, targetColumns []connection.ColumnDefinition) ([]string, map[string]string, []string) {
+	targets := make(map[string]connection.ColumnDefinition, len(targetColumns))
+	for _, col := range targetColumns {
+		targets[normalizeColumnName(col.Name)] = col
+	}
+	var columns, missing []string
+	kinds := make(map[string]string, len(sourceColumns))
+	for _, col := range sourceColumns {
+		if strings.TrimSpace(col.Generated) != "" {
+			continue
+		}
+		target, ok := targets[normalizeColumnName(col.Name)]
+		if !ok || strings.TrimSpace(target.Generated) != "" {
+			missing = append(missing, col.Name)
+			continue
+		}
+		parsed, _ := parseTransferColumnType(targetType, target.Type)
+		columns = append(columns, col.Name)
+		kinds[col.Name] = parsed.kind
+	}
+	var warnings []string
+	if len(missing) > 0 {
+		warnings = append(warnings, fmt.Sprintf("目标表中没有列 %s，已跳过", strings.Join(missing, ", ")))
+	}
+	return columns, kinds, warnings
+}
+
+// copyTransferRows 分页读取源表并写入目标表。有主键时按主键排序，保证分页之间不重复、不遗漏。
+func (a *App) copyTransferRows(ctx context.Context, job *transferJob, index int, name string, sourceTable string, targetTable string, sourceColumns []connection.ColumnDefinition, columns []string, kinds map[string]string, result *TransferTableResult) {
+	batchRows := job.options.BatchRows
+	if batchRows <= 0 {
+		batchRows = defaultTransferBatchRows
+	}
+	batchRows = min(batchRows, maxTransferBatchRows)
+
+	var sorts []TableSort
+	for _, col := range sourceColumns {
+		if strings.EqualFold(col.Key, "PRI") {
+			sorts = append(sorts, TableSort{Column: col.Name})
+		}
+	}
+	if len(sorts) == 0 {
+		result.Warnings = append(result.Warnings, "源表没有主键，分页读取依赖数据库返回顺序，传输期间请避免写入源表")
+	}
+	orderBy := buildTablePageOrderBy(job.sourceType, sorts)
+
+	query := func(sql string) ([]map[string]interface{}, error) {
+		if q, ok := job.sourceInst.(interface {
+			QueryContext(context.Context, string) ([]map[string]interface{}, []string, error)
+		}); ok {
+			data, _, err := q.QueryContext(ctx, sql)
+			return data, err
+		}
+		data, _, err := job.sourceInst.Query(sql)
+		return data, err
+	}
+
+	tableRows := int64(-1)
+	if data, err := query(fmt.Sprintf("SELECT COUNT(*) AS total FROM %s", sourceTable)); err == nil && len(data) > 0 {
+		for _, v := range data[0] {
+			if n, err := strconv.ParseInt(fmt.Sprintf("%v", v), 10, 64); err == nil {
+				tableRows = n
+			}
+		}
+	}
+	a.emitTransferProgress(job, index, name, "data", 0, tableRows)
+
+	writer := newTransferWriter(job, targetTable, columns, kinds)
+	skip := strings.EqualFold(strings.TrimSpace(job.options.ErrorPolicy), transferPolicySkip)
+	for page := 1; ; page++ {
+		if ctx.Err() != nil {
+			result.Status = "canceled"
+			result.Method = writer.method
+			return
+		}
+		pageSQL, _ := buildTablePageSQL(job.sourceType, sourceTable, "", orderBy, page, batchRows)
+		data, err := query(pageSQL)
+		if err != nil {
+			if ctx.Err() != nil {
+				result.Status = "canceled"
+				return
+			}
+			result.Errors = append(result.Errors, fmt.Sprintf("读取源表第 %d 批失败：%s", page, normalizeErrorMessage(err)))
+			return
+		}
+		rows := make([][]interface{}, len(data))
+		for r, record := range data {
+			row := make([]interface{}, len(columns))
+			for i, col := range columns {
+				row[i] = record[col]
+			}
+			rows[r] = row
+		}
+
+		if len(rows) > 0 {
+			written, err := writer.write(ctx, rows)
+			if err != nil && ctx.Err() == nil && skip {
+				// 整批失败时逐行重试，仅跳过写入失败的行
+				written = 0
+				for r := range rows {
+					n, rowErr := writer.write(ctx, rows[r:r+1])
+					if rowErr != nil {
+						result.FailedRows++
+						if len(result.Errors) < maxTransferRowErrors {
+							result.Errors = append(result.Errors, fmt.Sprintf("第 %d 行：%s", int64(page-1)*int64(batchRows)+int64(r)+1, normalizeErrorMessage(rowErr)))
+						}
+						continue
+					}
+					written += n
+				}
+				err = nil
+			}
+			result.Rows += written
+			result.Method = writer.method
+			if err != nil {
+				if ctx.Err() != nil {
+					result.Status = "canceled"
+					return
+				}
+				result.Errors = append(result.Errors, fmt.Sprintf("写入第 %d-%d 行失败：%s", int64(page-1)*int64(batchRows)+1, int64(page-1)*int64(batchRows)+int64(len(rows)), normalizeErrorMessage(err)))
+				return
+			}
+			a.emitTransferProgress(job, index, name, "data", result.Rows, tableRows)
+		}
+		if len(data) < batchRows {
+			break
+		}
+	}
+	result.Method = writer.method
+	if result.FailedRows == 0 {
+		result.Status = "done"
+	}
+}
+
+// transferWriter 向目标表写入一批行：优先使用驱动的高速通道，其次参数化多行 INSERT，最后回退为字面量 INSERT。
+type transferWriter struct {
+	dbType  string
+	table   string
+	columns []string
+	kinds   []string
+	exec    sqlExecFunc
+	loader  db.BulkLoader
+	querier db.ParamQuerier
+	dialect importDialect
+	method  string
+}
+
+func newTransferWriter(job *transferJob, table string, columns []string, kinds map[string]string) *transferWriter {
+	w := &transferWriter{dbType: job.targetType, table: table, columns: columns, exec: databaseExecFunc(job.targetInst), method: "INSERT"}
+	w.kinds = make([]string, len(columns))
+	for i, col := range columns {
+		w.kinds[i] = kinds[col]
+	}
+	if l, ok := job.targetInst.(db.BulkLoader); ok && strings.TrimSpace(l.BulkLoadMethod()) != "" {
+		w.loader = l
+	}
+	if dialect, ok := resolveImportDialect(w.dbType); ok {
+		if q, ok := job.targetInst.(db.ParamQuerier); ok {
+			w.querier, w.dialect = q, dialect
+		}
+	}
+	return w
+}
+
+func (w *transferWriter) write(ctx context.Context, rows [][]interface{}) (int64, error) {
+	if w.loader != nil {
+		converted := make([][]interface{}, len(rows))
+		for r, row := range rows {
+			values := make([]interface{}, len(row))
+			for i, v := range row {
+				values[i] = transferParamValue(w.dbType, w.kinds[i], v)
+			}
+			converted[r] = values
+		}
+		affected, err := w.loader.BulkLoad(ctx, w.table, w.columns, converted)
+		if err == nil {
+			w.method = w.loader.BulkLoadMethod()
+			if affected <= 0 {
+				affected = int64(len(rows))
+			}
+			return affected, nil
+		}
+		if w.method != "INSERT" || ctx.Err() != nil {
+			return 0, err
+		}
+		logger.Warnf("表传输高速通道 %s 不可用，回退为批量 INSERT：%v", w.loader.BulkLoadMethod(), err)
+		w.loader = nil
+	}
+
+	if w.querier != nil {
+		perStatement := w.dialect.rowsPerStatement(len(rows), len(w.columns))
+		var written int64
+		for start := 0; start < len(rows); start += perStatement {
+			end := min(start+perStatement, len(rows))
+			args := make([]interface{}, 0, (end-start)*len(w.columns))
+			for _, row := range rows[start:end] {
+				for i, v := range row {
+					args = append(args, transferParamValue(w.dbType, w.kinds[i], v))
+				}
+			}
+			if _, err := w.querier.ExecParams(ctx, buildImportBatchInsert(w.dbType, w.dialect, w.table, w.columns, end-start), args); err != nil {
+				return written, err
+			}
+			written += int64(end - start)
+		}
+		return written, nil
+	}
+
+	values := make([][]string, len(rows))
+	for r, row := range rows {
+		literals := make([]string, len(row))
+		for i, v := range row {
+			literals[i] = transferLiteral(w.dbType, w.kinds[i], v)
+		}
+		values[r] = literals
+	}
+	if _, err := w.exec(ctx, buildInsertRowsSQL(w.dbType, w.table, w.columns, values)); err != nil {
+		return 0, err
+	}
+	return int64(len(rows)), nil
+}
+
+// transferParamValue 把源库读出的值转换为目标列可以绑定的参数。
+func transferParamValue(dbType string, kind string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if kind != "blob" {
+			return string(v)
+		}
+		return v
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return nil
+		}
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil
+		}
+	case map[string]interface{}, []interface{}:
+		if text, err := json.Marshal(v); err == nil {
+			return string(text)
+		}
+	case time.Time:
+		if dbType == "sqlite" {
+			return v.Format("2006-01-02 15:04:05.999999999")
+		}
+		if kind == "text" || kind == "varchar" || kind == "char" {
+			return v.Format("2006-01-02 15:04:05.999999")
+		}
+	}
+	if kind == "bool" {
+		if b, ok := transferBool(value); ok {
+			switch transferTypeFamily(dbType) {
+			case "postgres", "duckdb", "clickhouse", "sqlserver":
+				return b
+			}
+			if b {
+				return int64(1)
+			}
+			return int64(0)
+		}
+	}
+	if b, ok := value.(bool); ok && kind != "bool" {
+		if b {
+			return int64(1)
+		}
+		return int64(0)
+	}
+	return value
+}
+
+// transferBool 识别布尔列的常见取值：布尔、0/1 整数与 true/false/t/f/1/0 文本。
+func transferBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v) != "0", true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "t", "1":
+			return true, true
+		case "false", "f", "0":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// transferLiteral 不支持参数绑定的目标库使用字面量写入。
+func transferLiteral(dbType string, kind string, value interface{}) string {
+	value = transferParamValue(dbType, kind, value)
+	switch v := value.(type) {
+	case bool:
+		if transferTypeFamily(dbType) == "postgres" || dbType == "duckdb" {
+			if v {
+				return "TRUE"
+			}
+			return "FALSE"
+		}
+	case time.Time:
+		generic := "TIMESTAMP"
+		if kind == "date" {
+			generic = "DATE"
+		}
+		return formatMaterializeValue(dbType, generic, v)
+	case []byte:
+		switch transferTypeFamily(dbType) {
+		case "mysql", "sqlite", "clickhouse":
+			return fmt.Sprintf("X'%x'", v)
+		case "postgres":
+			return fmt.Sprintf("'\\x%x'", v)
+		case "sqlserver":
+			return fmt.Sprintf("0x%x", v)
+		}
+	}
+	return formatSQLValue(dbType, value)
+}
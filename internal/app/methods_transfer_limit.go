@@ -0,0 +1,91 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+const transferRateWindow = time.Minute
+
+type transferRecord struct {
+	at   time.Time
+	rows int64
+}
+
+// transferLimitFor 根据连接配置计算本次查询可用的拉取额度。
+// exhausted 为 true 表示最近一分钟的行数额度已用完，不应再执行查询。
+func (a *App) transferLimitFor(config connection.ConnectionConfig, now time.Time) (limit db.TransferLimit, used int64, exhausted bool) {
+	if config.MaxFetchMB > 0 {
+		limit.MaxBytes = int64(config.MaxFetchMB) << 20
+	}
+	if config.MaxRowsPerMinute <= 0 {
+		return limit, 0, false
+	}
+	used = a.transferredRows(getCacheKey(config), now)
+	remaining := int64(config.MaxRowsPerMinute) - used
+	if remaining <= 0 {
+		return limit, used, true
+	}
+	limit.MaxRows = remaining
+	return limit, used, false
+}
+
+// transferredRows 返回最近一分钟内该连接已拉取的行数，并清理过期记录。
+func (a *App) transferredRows(key string, now time.Time) int64 {
+	a.transferMu.Lock()
+	defer a.transferMu.Unlock()
+	records := a.transfers[key]
+	kept := records[:0]
+	var total int64
+	for _, r := range records {
+		if now.Sub(r.at) < transferRateWindow {
+			kept = append(kept, r)
+			total += r.rows
+		}
+	}
+	if len(kept) == 0 {
+		delete(a.transfers, key)
+	} else {
+		a.transfers[key] = kept
+	}
+	return total
+}
+
+func (a *App) recordTransferredRows(config connection.ConnectionConfig, rows int, now time.Time) {
+	if config.MaxRowsPerMinute <= 0 || rows <= 0 {
+		return
+	}
+	a.transferMu.Lock()
+	defer a.transferMu.Unlock()
+	if a.transfers == nil {
+		a.transfers = make(map[string][]transferRecord)
+	}
+	key := getCacheKey(config)
+	a.transfers[key] = append(a.transfers[key], transferRecord{at: now, rows: int64(rows)})
+}
+
+// transferLimitResult 将触发限制的部分结果包装为“已达上限，是否继续？”响应。
+func transferLimitResult(config connection.ConnectionConfig, err error, data []map[string]interface{}, columns []string) (connection.QueryResult, bool) {
+	var limitErr *db.TransferLimitError
+	if !errors.As(err, &limitErr) {
+		return connection.QueryResult{}, false
+	}
+	logger.Warnf("查询触发拉取限制：%s %v", formatConnSummary(config), limitErr)
+	return connection.QueryResult{
+		Success:      true,
+		Message:      fmt.Sprintf("%s，已停止读取并返回部分结果。是否继续完整执行？", limitErr.Error()),
+		Data:         data,
+		Fields:       columns,
+		LimitReached: true,
+	}, true
+}
+
+// DBQueryIgnoreLimit 在用户确认“继续”后执行查询，本次不受连接级拉取限制约束（行数仍计入每分钟统计）。
+func (a *App) DBQueryIgnoreLimit(config connection.ConnectionConfig, dbName string, query string) connection.QueryResult {
+	return a.dbQuery(config, dbName, query, dbQueryOptions{route: queryRouteAuto, ignoreLimit: true})
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestTransferLimitForRowsPerMinute(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "db", MaxRowsPerMinute: 1000, MaxFetchMB: 2}
+	now := time.Now()
+
+	limit, used, exhausted := a.transferLimitFor(config, now)
+	if exhausted || used != 0 || limit.MaxRows != 1000 || limit.MaxBytes != 2<<20 {
+		t.Fatalf("initial limit = %+v used=%d exhausted=%v", limit, used, exhausted)
+	}
+
+	a.recordTransferredRows(config, 600, now.Add(-70*time.Second))
+	a.recordTransferredRows(config, 700, now.Add(-10*time.Second))
+	limit, used, exhausted = a.transferLimitFor(config, now)
+	if exhausted || used != 700 || limit.MaxRows != 300 {
+		t.Fatalf("expired record should not count: limit=%+v used=%d", limit, used)
+	}
+
+	a.recordTransferredRows(config, 300, now)
+	if _, used, exhausted = a.transferLimitFor(config, now); !exhausted || used != 1000 {
+		t.Fatalf("expected exhausted budget, used=%d exhausted=%v", used, exhausted)
+	}
+}
+
+func TestTransferLimitForDisabled(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "postgres", Host: "db"}
+	a.recordTransferredRows(config, 5000, time.Now())
+	limit, _, exhausted := a.transferLimitFor(config, time.Now())
+	if exhausted || limit.MaxRows != 0 || limit.MaxBytes != 0 || len(a.transfers) != 0 {
+		t.Fatalf("limits should be disabled: %+v", limit)
+	}
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+func TestTransferColumnDDLType(t *testing.T) {
+	cases := []struct {
+		source, target, colType, want string
+	}{
+		{"mysql", "postgres", "tinyint(1)", "BOOLEAN"},
+		{"mysql", "postgres", "int(10) unsigned", "BIGINT"},
+		{"mysql", "postgres", "bigint unsigned", "NUMERIC(20,0)"},
+		{"mysql", "postgres", "varchar(64)", "VARCHAR(64)"},
+		{"mysql", "postgres", "decimal(10,2)", "NUMERIC(10,2)"},
+		{"mysql", "postgres", "datetime(3)", "TIMESTAMP"},
+		{"mysql", "postgres", "longblob", "BYTEA"},
+		{"mysql", "mariadb", "enum('a','b')", "enum('a','b')"},
+		{"postgres", "mysql", "character varying(20)", "VARCHAR(20)"},
+		{"postgres", "mysql", "timestamp with time zone", "DATETIME(6)"},
+		{"postgres", "mysql", "int8", "BIGINT"},
+		{"postgres", "mysql", "jsonb", "JSON"},
+		{"oracle", "mysql", "NUMBER(8)", "INT"},
+		{"oracle", "mysql", "NUMBER(12,2)", "DECIMAL(12,2)"},
+		{"oracle", "postgres", "DATE", "TIMESTAMP"},
+		{"sqlserver", "postgres", "nvarchar(max)", "TEXT"},
+		{"sqlserver", "mysql", "bit", "TINYINT(1)"},
+		{"clickhouse", "postgres", "Nullable(UInt32)", "BIGINT"},
+		{"mysql", "sqlserver", "text", "NVARCHAR(MAX)"},
+		{"mysql", "oracle", "varchar(5000)", "CLOB"},
+	}
+	for _, c := range cases {
+		got, warning, err := transferColumnDDLType(c.source, c.target, connection.ColumnDefinition{Name: "c", Type: c.colType})
+		if err != nil || got != c.want || warning != "" {
+			t.Errorf("%s %s -> %s = %q (%q, %v), want %q", c.source, c.colType, c.target, got, warning, err, c.want)
+		}
+	}
+	if got, warning, _ := transferColumnDDLType("postgres", "mysql", connection.ColumnDefinition{Name: "area", Type: "geometry"}); got != "LONGTEXT" || warning == "" {
+		t.Fatalf("unknown type = %q warning=%q", got, warning)
+	}
+}
+
+func TestBuildTransferCreateSQL(t *testing.T) {
+	columns := []connection.ColumnDefinition{
+		{Name: "id", Type: "int(11)", Nullable: "NO", Key: "PRI"},
+		{Name: "name", Type: "varchar(20)", Nullable: "YES"},
+		{Name: "total", Type: "int", Generated: "STORED"},
+	}
+	got, _, err := buildTransferCreateSQL("mysql", "postgres", "public.users", columns)
+	if err != nil || got != `CREATE TABLE "public"."users" ("id" INTEGER NOT NULL, "name" VARCHAR(20), PRIMARY KEY ("id"))` {
+		t.Fatalf("postgres = %s %v", got, err)
+	}
+	got, _, _ = buildTransferCreateSQL("mysql", "clickhouse", "users", columns)
+	if got != "CREATE TABLE `users` (`id` Int32, `name` Nullable(String)) ENGINE = MergeTree ORDER BY (`id`)" {
+		t.Fatalf("clickhouse = %s", got)
+	}
+}
+
+var transferOffsetPattern = regexp.MustCompile(`LIMIT (\d+) OFFSET (\d+)`)
+
+type transferSourceFakeDB struct {
+	db.Database
+	rows []map[string]interface{}
+}
+
+func (f *transferSourceFakeDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	return []connection.ColumnDefinition{
+		{Name: "id", Type: "int(11)", Nullable: "NO", Key: "PRI"},
+		{Name: "active", Type: "tinyint(1)", Nullable: "YES"},
+		{Name: "name", Type: "varchar(20)", Nullable: "YES"},
+	}, nil
+}
+
+func (f *transferSourceFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	if strings.HasPrefix(query, "SELECT COUNT(*)") {
+		return []map[string]interface{}{{"total": int64(len(f.rows))}}, []string{"total"}, nil
+	}
+	m := transferOffsetPattern.FindStringSubmatch(query)
+	if m == nil || !strings.Contains(query, "ORDER BY `id` ASC") {
+		return nil, nil, fmt.Errorf("unexpected query %s", query)
+	}
+	limit, _ := strconv.Atoi(m[1])
+	offset, _ := strconv.Atoi(m[2])
+	end := min(offset+limit, len(f.rows))
+	if offset >= end {
+		return nil, []string{"id", "active", "name"}, nil
+	}
+	return f.rows[offset:end], []string{"id", "active", "name"}, nil
+}
+
+type transferTargetFakeDB struct {
+	db.Database
+	execs   []string
+	inserts []string
+	args    [][]interface{}
+	failOn  interface{} // 参数中包含该值的 INSERT 返回错误
+}
+
+func (f *transferTargetFakeDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	return nil, errors.New("relation does not exist")
+}
+
+func (f *transferTargetFakeDB) Exec(query string) (int64, error) {
+	f.execs = append(f.execs, query)
+	return 0, nil
+}
+
+func (f *transferTargetFakeDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return nil, nil, errors.New("unexpected query")
+}
+
+func (f *transferTargetFakeDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	for _, arg := range args {
+		if f.failOn != nil && arg == f.failOn {
+			return 0, errors.New("value too long")
+		}
+	}
+	f.inserts = append(f.inserts, query)
+	f.args = append(f.args, args)
+	return int64(len(args) / 3), nil
+}
+
+func TestTransferTables(t *testing.T) {
+	a := NewApp()
+	sourceConfig := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root", Database: "shop"}
+	targetConfig := connection.ConnectionConfig{Type: "postgres", Host: "127.0.0.1", Port: 5432, User: "postgres", Database: "crm"}
+	source := &transferSourceFakeDB{rows: []map[string]interface{}{
+		{"id": int64(1), "active": int64(1), "name": "a"},
+		{"id": int64(2), "active": int64(0), "name": []byte("b")},
+		{"id": int64(3), "active": nil, "name": "c"},
+	}}
+	target := &transferTargetFakeDB{}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(sourceConfig, "shop")))] = cachedDatabase{inst: source, lastPing: time.Now()}
+	a.dbCache[getCacheKey(applyCustomDriverType(normalizeRunConfig(targetConfig, "crm")))] = cachedDatabase{inst: target, lastPing: time.Now()}
+
+	res := a.TransferTables(sourceConfig, targetConfig, []string{"users"}, TransferOptions{BatchRows: 2, TargetTables: map[string]string{"users": "members"}})
+	summary := res.Data.(TransferSummary)
+	if !res.Success || summary.Rows != 3 || summary.Succeeded != 1 {
+		t.Fatalf("result = %+v %+v", res, summary)
+	}
+	table := summary.Tables[0]
+	if table.Target != "public.members" || !table.Created || len(target.execs) != 1 ||
+		target.execs[0] != `CREATE TABLE "public"."members" ("id" INTEGER NOT NULL, "active" BOOLEAN, "name" VARCHAR(20), PRIMARY KEY ("id"))` {
+		t.Fatalf("table = %+v execs=%q", table, target.execs)
+	}
+	if len(target.inserts) != 2 || target.inserts[0] != `INSERT INTO "public"."members" ("id", "active", "name") VALUES ($1, $2, $3), ($4, $5, $6)` {
+		t.Fatalf("inserts = %q", target.inserts)
+	}
+	if target.args[0][1] != true || target.args[0][4] != false || target.args[0][5] != "b" || target.args[1][1] != nil {
+		t.Fatalf("args = %#v", target.args)
+	}
+
+	// skip 策略：整批失败后逐行重试，只跳过失败的行
+	target.execs, target.inserts, target.args, target.failOn = nil, nil, nil, "b"
+	res = a.TransferTables(sourceConfig, targetConfig, []string{"users"}, TransferOptions{BatchRows: 2, ErrorPolicy: "skip", Content: "both"})
+	summary = res.Data.(TransferSummary)
+	if res.Success || summary.Rows != 2 || summary.FailedRows != 1 || len(summary.Tables[0].Errors) != 1 ||
+		!strings.HasPrefix(summary.Tables[0].Errors[0], "第 2 行") {
+		t.Fatalf("skip result = %+v %+v", res, summary)
+	}
+
+	res = a.TransferTables(sourceConfig, targetConfig, []string{"users", "orders"}, TransferOptions{BatchRows: 2, Content: "data"})
+	summary = res.Data.(TransferSummary)
+	if res.Success || summary.Failed != 1 || summary.Skipped != 1 || summary.Tables[1].Status != "skipped" {
+		t.Fatalf("abort result = %+v", summary)
+	}
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+// resolveTriggerManager 取得连接对应的触发器维护实现，数据源不支持时返回用户可读的错误。
+func (a *App) resolveTriggerManager(runConfig connection.ConnectionConfig) (db.Database, db.TriggerManager, error) {
+	dbInst, err := a.getDatabase(runConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	dbType := resolveDDLDBType(runConfig)
+	manager, ok := db.TriggerManagerFor(dbInst, dbType)
+	if !ok {
+		return nil, nil, fmt.Errorf("当前数据源(%s)暂不支持维护触发器", dbType)
+	}
+	return dbInst, manager, nil
+}
+
+// GetTriggerDefinition 返回触发器的完整创建语句；PostgreSQL 系同时返回触发器函数的定义。
+func (a *App) GetTriggerDefinition(config connection.ConnectionConfig, dbName string, tableName string, triggerName string) connection.QueryResult {
+	runConfig := normalizeRunConfig(config, dbName)
+	dbInst, _, err := a.resolveTriggerManager(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	dbType := resolveDDLDBType(runConfig)
+	var definition string
+	warning, err := a.runWithReconnect(runConfig, dbInst, "GetTriggerDefinition", func(inst db.Database) error {
+		manager, ok := db.TriggerManagerFor(inst, dbType)
+		if !ok {
+			return fmt.Errorf("当前数据源(%s)暂不支持维护触发器", dbType)
+		}
+		var getErr error
+		definition, getErr = manager.GetTriggerDefinition(schemaName, pureTableName, strings.TrimSpace(triggerName))
+		return getErr
+	})
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Message: warning, Data: definition}
+}
+
+// CreateTrigger 按 spec 创建触发器；spec.Replace 为 true 时先删除同名触发器，用于编辑。
+// spec.TableName 可带 schema 前缀，未填写 spec.Schema 时按数据源默认 schema 处理。
+func (a *App) CreateTrigger(config connection.ConnectionConfig, dbName string, spec connection.TriggerSpec) connection.QueryResult {
+	if err := ensureWritable(config, "创建触发器"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	_, manager, err := a.resolveTriggerManager(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, spec.TableName)
+	if strings.TrimSpace(spec.Schema) == "" {
+		spec.Schema = schemaName
+	}
+	spec.TableName = pureTableName
+	if err := manager.CreateTrigger(spec); err != nil {
+		logger.Error(err, "创建触发器失败：%s 表=%s 触发器=%s", formatConnSummary(runConfig), pureTableName, spec.Name)
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Message: "触发器已保存"}
+}
+
+// DropTrigger 删除触发器。PostgreSQL 系生成的触发器函数不会一并删除。
+func (a *App) DropTrigger(config connection.ConnectionConfig, dbName string, tableName string, triggerName string) connection.QueryResult {
+	if err := ensureWritable(config, "删除触发器"); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	runConfig := normalizeRunConfig(config, dbName)
+	_, manager, err := a.resolveTriggerManager(runConfig)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+	if err := manager.DropTrigger(schemaName, pureTableName, strings.TrimSpace(triggerName)); err != nil {
+		logger.Error(err, "删除触发器失败：%s 表=%s 触发器=%s", formatConnSummary(runConfig), pureTableName, triggerName)
+		return connection.QueryResult{Success: false, Message: normalizeErrorMessage(err)}
+	}
+	return connection.QueryResult{Success: true, Message: "触发器已删除"}
+}
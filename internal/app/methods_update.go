@@ -521,7 +521,11 @@ func (w *downloadProgressWriter) Write(p []byte) (int, error) {
 }
 
 func downloadFileWithHash(url, filePath string, onProgress func(downloaded, total int64)) (string, error) {
-	client := &http.Client{Timeout: 10 * time.Minute}
+	return downloadFileWithHashTimeout(url, filePath, 10*time.Minute, onProgress)
+}
+
+func downloadFileWithHashTimeout(url, filePath string, timeout time.Duration, onProgress func(downloaded, total int64)) (string, error) {
+	client := &http.Client{Timeout: timeout}
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return "", err
@@ -535,7 +539,7 @@ func downloadFileWithHash(url, filePath string, onProgress func(downloaded, tota
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("下载更新包失败：HTTP %d", resp.StatusCode)
+		return "", &httpStatusError{action: "下载更新包失败", code: resp.StatusCode}
 	}
 
 	// Windows 上旧文件可能被杀毒软件/索引服务占用，先尝试删除并重试
@@ -0,0 +1,558 @@
+package app
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/geo"
+	"GoNavi-Wails/internal/logger"
+)
+
+// 值渲染器：在后端把二进制 UUID、压缩存储的 IP、时间戳整数、ENUM/SET 等解码为可读文本，
+// 原始值保持不变，可读值单独返回，前端按需切换显示。
+const (
+	valueRendererAuto     = "auto"
+	valueRendererRaw      = "raw"
+	valueRendererUUID     = "uuid"
+	valueRendererUUIDSwap = "uuid_swap" // MySQL UUID_TO_BIN(uuid, 1) 交换了时间字段
+	valueRendererIP       = "ip"
+	valueRendererEpochS   = "epoch_s"
+	valueRendererEpochMS  = "epoch_ms"
+	valueRendererEpochUS  = "epoch_us"
+	valueRendererEpoch    = "epoch" // 按数量级自动判断秒/毫秒/微秒
+	valueRendererEnum     = "enum"
+	valueRendererSet      = "set"
+	valueRendererWKT      = "wkt"     // 空间类型：WKB/EWKB 解码为 WKT
+	valueRendererGeoJSON  = "geojson" // 空间类型：WKB/EWKB 解码为 GeoJSON
+)
+
+var valueRendererNames = map[string]struct{}{
+	valueRendererAuto: {}, valueRendererRaw: {}, valueRendererUUID: {}, valueRendererUUIDSwap: {},
+	valueRendererIP: {}, valueRendererEpochS: {}, valueRendererEpochMS: {}, valueRendererEpochUS: {},
+	valueRendererEpoch: {}, valueRendererEnum: {}, valueRendererSet: {},
+	valueRendererWKT: {}, valueRendererGeoJSON: {},
+}
+
+// ValueRendererRule 单列的渲染配置。Values 用于 enum/set（为空时尝试从列类型中解析）。
+type ValueRendererRule struct {
+	Column   string   `json:"column"`
+	Renderer string   `json:"renderer"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// RenderedQueryData 带可读值的查询结果。Display 与 Rows 按下标对应，只包含被解码的单元格。
+type RenderedQueryData struct {
+	Rows      []map[string]interface{} `json:"rows"`
+	Display   []map[string]string      `json:"display"`
+	Renderers map[string]string        `json:"renderers"`
+}
+
+// GetValueRenderers 读取指定表已保存的列渲染配置。
+func (a *App) GetValueRenderers(config connection.ConnectionConfig, dbName string, tableName string) connection.QueryResult {
+	rules, err := loadValueRendererRules(valueRendererStoreKey(config, dbName, tableName))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: rules}
+}
+
+// SaveValueRenderers 保存指定表的列渲染配置（~/.gonavi/value_renderers.json），renderer 为 auto 的列不落盘。
+func (a *App) SaveValueRenderers(config connection.ConnectionConfig, dbName string, tableName string, rules []ValueRendererRule) connection.QueryResult {
+	if strings.TrimSpace(tableName) == "" {
+		return connection.QueryResult{Success: false, Message: "表名不能为空"}
+	}
+	cleaned := make([]ValueRendererRule, 0, len(rules))
+	for _, rule := range rules {
+		rule.Column = strings.TrimSpace(rule.Column)
+		rule.Renderer = strings.ToLower(strings.TrimSpace(rule.Renderer))
+		if rule.Column == "" {
+			continue
+		}
+		if _, ok := valueRendererNames[rule.Renderer]; !ok {
+			return connection.QueryResult{Success: false, Message: fmt.Sprintf("不支持的渲染方式：%s", rule.Renderer)}
+		}
+		if rule.Renderer == valueRendererAuto {
+			continue
+		}
+		cleaned = append(cleaned, rule)
+	}
+	if err := saveValueRendererRules(valueRendererStoreKey(config, dbName, tableName), cleaned); err != nil {
+		logger.Error(err, "保存值渲染配置失败：%s 表=%s", formatConnSummary(config), tableName)
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: "渲染配置已保存", Data: cleaned}
+}
+
+// DBQueryRendered 执行查询并为每个单元格附加可读值。tableName 非空时结合列类型与已保存配置选择渲染器，
+// 否则仅按列名做保守推断。
+func (a *App) DBQueryRendered(config connection.ConnectionConfig, dbName string, tableName string, query string) connection.QueryResult {
+	result := a.DBQuery(config, dbName, query)
+	if !result.Success {
+		return result
+	}
+	rows, ok := result.Data.([]map[string]interface{})
+	if !ok {
+		return result
+	}
+
+	var columnDefs []connection.ColumnDefinition
+	if strings.TrimSpace(tableName) != "" {
+		runConfig := normalizeRunConfig(config, dbName)
+		if dbInst, err := a.getDatabase(runConfig); err == nil {
+			schemaName, pureTableName := normalizeSchemaAndTable(config, dbName, tableName)
+			if defs, err := dbInst.GetColumns(schemaName, pureTableName); err == nil {
+				columnDefs = defs
+			}
+		}
+	}
+	saved, err := loadValueRendererRules(valueRendererStoreKey(config, dbName, tableName))
+	if err != nil {
+		logger.Warnf("读取值渲染配置失败：%v", err)
+	}
+
+	rules := resolveValueRenderers(result.Fields, columnDefs, saved)
+	display := renderQueryRows(rows, rules)
+	applied := make(map[string]string, len(rules))
+	for col, rule := range rules {
+		applied[col] = rule.Renderer
+	}
+	return connection.QueryResult{
+		Success: true,
+		Message: result.Message,
+		Data:    RenderedQueryData{Rows: rows, Display: display, Renderers: applied},
+		Fields:  result.Fields,
+	}
+}
+
+// DecodeSpatialValue 将单元格中的空间值（WKB/EWKB 十六进制）解码为 WKT 与 GeoJSON，供结果表格查看。
+func (a *App) DecodeSpatialValue(value string) connection.QueryResult {
+	g, err := geo.Decode(value)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: map[string]interface{}{
+		"type":    g.TypeName(),
+		"srid":    g.SRID,
+		"wkt":     g.WKT(),
+		"geojson": g.GeoJSONText(),
+	}}
+}
+
+var (
+	valueRendererEnumPattern  = regexp.MustCompile(`(?i)^\s*(enum|set)\s*\((.*)\)\s*$`)
+	valueRendererEpochPattern = regexp.MustCompile(`(?i)(_at|_time|_ts|time|timestamp|created|updated|deleted|expire[sd]?)$`)
+	valueRendererIPPattern    = regexp.MustCompile(`(?i)(^|_)ip(v[46])?(_|$)|(^|_)(addr|address)$`)
+	valueRendererUUIDPattern  = regexp.MustCompile(`(?i)(uuid|guid)`)
+	valueRendererGeoPattern   = regexp.MustCompile(`(?i)(^|_)(geom|geometry|geog|geography|shape|location)$`)
+)
+
+// resolveValueRenderers 合并保存的配置与自动推断结果，返回需要渲染的列。
+func resolveValueRenderers(fields []string, columnDefs []connection.ColumnDefinition, saved []ValueRendererRule) map[string]ValueRendererRule {
+	types := make(map[string]string, len(columnDefs))
+	for _, def := range columnDefs {
+		types[strings.ToLower(def.Name)] = def.Type
+	}
+	savedByColumn := make(map[string]ValueRendererRule, len(saved))
+	for _, rule := range saved {
+		savedByColumn[strings.ToLower(rule.Column)] = rule
+	}
+
+	rules := make(map[string]ValueRendererRule)
+	for _, field := range fields {
+		lower := strings.ToLower(field)
+		columnType := types[lower]
+		rule, ok := savedByColumn[lower]
+		if !ok || rule.Renderer == valueRendererAuto {
+			rule = inferValueRenderer(field, columnType)
+		}
+		if rule.Renderer == "" || rule.Renderer == valueRendererRaw {
+			continue
+		}
+		if (rule.Renderer == valueRendererEnum || rule.Renderer == valueRendererSet) && len(rule.Values) == 0 {
+			if _, values, ok := parseEnumColumnType(columnType); ok {
+				rule.Values = values
+			}
+		}
+		rule.Column = field
+		rules[field] = rule
+	}
+	return rules
+}
+
+func inferValueRenderer(column string, columnType string) ValueRendererRule {
+	lowerType := strings.ToLower(strings.TrimSpace(columnType))
+	if kind, values, ok := parseEnumColumnType(columnType); ok {
+		return ValueRendererRule{Renderer: kind, Values: values}
+	}
+	if geo.IsSpatialType(lowerType) {
+		return ValueRendererRule{Renderer: valueRendererWKT}
+	}
+	switch {
+	case lowerType == "binary(16)":
+		if valueRendererIPPattern.MatchString(column) {
+			return ValueRendererRule{Renderer: valueRendererIP}
+		}
+		return ValueRendererRule{Renderer: valueRendererUUID}
+	case lowerType == "binary(4)" || lowerType == "varbinary(16)" || lowerType == "varbinary(4)":
+		if valueRendererIPPattern.MatchString(column) {
+			return ValueRendererRule{Renderer: valueRendererIP}
+		}
+	case strings.Contains(lowerType, "int"):
+		if valueRendererIPPattern.MatchString(column) && strings.Contains(lowerType, "unsigned") {
+			return ValueRendererRule{Renderer: valueRendererIP}
+		}
+		if valueRendererEpochPattern.MatchString(column) && !strings.HasPrefix(lowerType, "tinyint") && !strings.HasPrefix(lowerType, "smallint") {
+			return ValueRendererRule{Renderer: valueRendererEpoch}
+		}
+	case lowerType == "":
+		// 无列类型（任意查询）时仅按列名保守推断，解码失败的单元格保持原样
+		if valueRendererUUIDPattern.MatchString(column) {
+			return ValueRendererRule{Renderer: valueRendererUUID}
+		}
+		if valueRendererIPPattern.MatchString(column) {
+			return ValueRendererRule{Renderer: valueRendererIP}
+		}
+		if valueRendererGeoPattern.MatchString(column) {
+			return ValueRendererRule{Renderer: valueRendererWKT}
+		}
+	}
+	return ValueRendererRule{}
+}
+
+// parseEnumColumnType 解析 enum('a','b') / set('x','y') 列类型。
+func parseEnumColumnType(columnType string) (string, []string, bool) {
+	match := valueRendererEnumPattern.FindStringSubmatch(columnType)
+	if match == nil {
+		return "", nil, false
+	}
+	var values []string
+	body := match[2]
+	for i := 0; i < len(body); i++ {
+		if body[i] != '\'' {
+			continue
+		}
+		var b strings.Builder
+		j := i + 1
+		for ; j < len(body); j++ {
+			if body[j] == '\'' {
+				if j+1 < len(body) && body[j+1] == '\'' {
+					b.WriteByte('\'')
+					j++
+					continue
+				}
+				break
+			}
+			b.WriteByte(body[j])
+		}
+		values = append(values, b.String())
+		i = j
+	}
+	return strings.ToLower(match[1]), values, true
+}
+
+func renderQueryRows(rows []map[string]interface{}, rules map[string]ValueRendererRule) []map[string]string {
+	display := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		cells := map[string]string{}
+		for col, rule := range rules {
+			value, ok := row[col]
+			if !ok || value == nil {
+				continue
+			}
+			if text, ok := renderCellValue(value, rule); ok {
+				cells[col] = text
+			}
+		}
+		display[i] = cells
+	}
+	return display
+}
+
+// renderCellValue 按规则解码单元格；无法解码时返回 false，前端继续显示原始值。
+func renderCellValue(value interface{}, rule ValueRendererRule) (string, bool) {
+	switch rule.Renderer {
+	case valueRendererUUID:
+		return decodeUUIDValue(value, false)
+	case valueRendererUUIDSwap:
+		return decodeUUIDValue(value, true)
+	case valueRendererIP:
+		return decodeIPValue(value)
+	case valueRendererEpochS, valueRendererEpochMS, valueRendererEpochUS, valueRendererEpoch:
+		return decodeEpochValue(value, rule.Renderer)
+	case valueRendererEnum:
+		return decodeEnumValue(value, rule.Values)
+	case valueRendererSet:
+		return decodeSetValue(value, rule.Values)
+	case valueRendererWKT, valueRendererGeoJSON:
+		g, err := geo.Decode(value)
+		if err != nil {
+			return "", false
+		}
+		if rule.Renderer == valueRendererGeoJSON {
+			text := g.GeoJSONText()
+			return text, text != ""
+		}
+		return g.WKT(), true
+	default:
+		return "", false
+	}
+}
+
+// valueRendererBytes 还原二进制单元格：驱动层已把不可读字节转为 0x 前缀十六进制。
+func valueRendererBytes(value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case []byte:
+		return v, true
+	case string:
+		text := strings.TrimSpace(v)
+		if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+			b, err := hex.DecodeString(text[2:])
+			return b, err == nil
+		}
+		return []byte(v), true
+	default:
+		return nil, false
+	}
+}
+
+func decodeUUIDValue(value interface{}, swap bool) (string, bool) {
+	b, ok := valueRendererBytes(value)
+	if !ok {
+		return "", false
+	}
+	if len(b) == 32 || len(b) == 36 {
+		// 文本形式的 UUID（可能没有连字符），仅规范化显示
+		compact := strings.ReplaceAll(string(b), "-", "")
+		decoded, err := hex.DecodeString(compact)
+		if err != nil || len(decoded) != 16 {
+			return "", false
+		}
+		b = decoded
+	}
+	if len(b) != 16 {
+		return "", false
+	}
+	if swap {
+		// UUID_TO_BIN(u, 1) 存储顺序为 time_hi(2) time_mid(2) time_low(4) 其余(8)
+		b = append(append(append(append([]byte{}, b[4:8]...), b[2:4]...), b[0:2]...), b[8:]...)
+	}
+	h := hex.EncodeToString(b)
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32]), true
+}
+
+func decodeIPValue(value interface{}) (string, bool) {
+	if n, ok := valueRendererInteger(value); ok {
+		// INET_ATON 存储的 IPv4 整数
+		if n < 0 || n > math.MaxUint32 {
+			return "", false
+		}
+		return net.IPv4(byte(n>>24), byte(n>>16), byte(n>>8), byte(n)).String(), true
+	}
+	b, ok := valueRendererBytes(value)
+	if !ok {
+		return "", false
+	}
+	if len(b) == net.IPv4len || len(b) == net.IPv6len {
+		if ip := net.IP(b); ip != nil && net.ParseIP(string(b)) == nil {
+			return ip.String(), true
+		}
+	}
+	return "", false
+}
+
+func valueRendererInteger(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int64(v), true
+	case string:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+var (
+	valueRendererEpochMin = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	valueRendererEpochMax = time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func decodeEpochValue(value interface{}, renderer string) (string, bool) {
+	n, ok := valueRendererInteger(value)
+	if !ok || n <= 0 {
+		return "", false
+	}
+	if renderer == valueRendererEpoch {
+		switch {
+		case n < 100000000000:
+			renderer = valueRendererEpochS
+		case n < 100000000000000:
+			renderer = valueRendererEpochMS
+		default:
+			renderer = valueRendererEpochUS
+		}
+	}
+	var t time.Time
+	layout := "2006-01-02 15:04:05"
+	switch renderer {
+	case valueRendererEpochS:
+		t = time.Unix(n, 0)
+	case valueRendererEpochMS:
+		t = time.UnixMilli(n)
+		layout = "2006-01-02 15:04:05.000"
+	case valueRendererEpochUS:
+		t = time.UnixMicro(n)
+		layout = "2006-01-02 15:04:05.000000"
+	default:
+		return "", false
+	}
+	if t.Before(valueRendererEpochMin) || t.After(valueRendererEpochMax) {
+		return "", false
+	}
+	return t.Local().Format(layout), true
+}
+
+// decodeEnumValue 驱动返回序号（1 起）时映射为标签；返回文本时校验是否为合法取值。
+func decodeEnumValue(value interface{}, values []string) (string, bool) {
+	if n, ok := value.(int64); ok {
+		if n >= 1 && int(n) <= len(values) {
+			return values[n-1], true
+		}
+		return "", false
+	}
+	text := fmt.Sprintf("%v", value)
+	if len(values) == 0 {
+		return "", false
+	}
+	for _, v := range values {
+		if v == text {
+			return text, true
+		}
+	}
+	if text == "" {
+		return "（空值）", true
+	}
+	return text + "（非法值）", true
+}
+
+// decodeSetValue 支持位掩码与逗号分隔文本两种形式。
+func decodeSetValue(value interface{}, values []string) (string, bool) {
+	if n, ok := value.(int64); ok && len(values) > 0 {
+		var labels []string
+		for i, v := range values {
+			if n&(1<<uint(i)) != 0 {
+				labels = append(labels, v)
+			}
+		}
+		return strings.Join(labels, ", "), true
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", false
+	}
+	if text == "" {
+		return "（空集合）", true
+	}
+	return strings.Join(strings.Split(text, ","), ", "), true
+}
+
+var valueRendererStoreMu sync.Mutex
+
+func valueRendererStorePath() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "value_renderers.json")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-value_renderers.json")
+}
+
+func valueRendererStoreKey(config connection.ConnectionConfig, dbName string, tableName string) string {
+	return connectionStoreKey(config, dbName) + "/" + strings.ToLower(strings.TrimSpace(tableName))
+}
+
+func readValueRendererStore() (map[string][]ValueRendererRule, error) {
+	store := map[string][]ValueRendererRule{}
+	content, err := os.ReadFile(valueRendererStorePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("读取值渲染配置失败：%w", err)
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return store, nil
+	}
+	if err := json.Unmarshal(content, &store); err != nil {
+		return nil, fmt.Errorf("解析值渲染配置失败：%w", err)
+	}
+	return store, nil
+}
+
+func loadValueRendererRules(key string) ([]ValueRendererRule, error) {
+	valueRendererStoreMu.Lock()
+	defer valueRendererStoreMu.Unlock()
+	store, err := readValueRendererStore()
+	if err != nil {
+		return []ValueRendererRule{}, err
+	}
+	rules := store[key]
+	if rules == nil {
+		rules = []ValueRendererRule{}
+	}
+	return rules, nil
+}
+
+func saveValueRendererRules(key string, rules []ValueRendererRule) error {
+	valueRendererStoreMu.Lock()
+	defer valueRendererStoreMu.Unlock()
+	store, err := readValueRendererStore()
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		delete(store, key)
+	} else {
+		sort.SliceStable(rules, func(i, j int) bool { return rules[i].Column < rules[j].Column })
+		store[key] = rules
+	}
+	content, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := valueRendererStorePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("创建配置目录失败：%w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o644); err != nil {
+		return fmt.Errorf("写入值渲染配置失败：%w", err)
+	}
+	return os.Rename(tmp, path)
+}
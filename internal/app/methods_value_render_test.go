@@ -0,0 +1,68 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestRenderCellValue_Decoders(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		rule  ValueRendererRule
+		want  string
+	}{
+		{"0x6ccd780cbaba102695645b8c656024db", ValueRendererRule{Renderer: valueRendererUUID}, "6ccd780c-baba-1026-9564-5b8c656024db"},
+		// UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1)
+		{"0x1026baba6ccd780c95645b8c656024db", ValueRendererRule{Renderer: valueRendererUUIDSwap}, "6ccd780c-baba-1026-9564-5b8c656024db"},
+		{"0xc0a80001", ValueRendererRule{Renderer: valueRendererIP}, "192.168.0.1"},
+		{int64(3232235521), ValueRendererRule{Renderer: valueRendererIP}, "192.168.0.1"},
+		{"0x20010db8000000000000000000000001", ValueRendererRule{Renderer: valueRendererIP}, "2001:db8::1"},
+		{int64(5), ValueRendererRule{Renderer: valueRendererSet, Values: []string{"a", "b", "c"}}, "a, c"},
+		{"x", ValueRendererRule{Renderer: valueRendererEnum, Values: []string{"a"}}, "x（非法值）"},
+		// MySQL POINT(1 2)，SRID 4326：4 字节 SRID + WKB
+		{"0xE61000000101000000000000000000F03F0000000000000040", ValueRendererRule{Renderer: valueRendererWKT}, "SRID=4326;POINT(1 2)"},
+		{"0101000000000000000000F03F0000000000000040", ValueRendererRule{Renderer: valueRendererGeoJSON}, `{"coordinates":[1,2],"type":"Point"}`},
+	}
+	for _, tc := range cases {
+		got, ok := renderCellValue(tc.value, tc.rule)
+		if !ok || got != tc.want {
+			t.Fatalf("render %v with %s: got %q ok=%v, want %q", tc.value, tc.rule.Renderer, got, ok, tc.want)
+		}
+	}
+
+	want := time.UnixMilli(1700000000123).Local().Format("2006-01-02 15:04:05.000")
+	if got, ok := renderCellValue("1700000000123", ValueRendererRule{Renderer: valueRendererEpoch}); !ok || got != want {
+		t.Fatalf("epoch ms: got %q ok=%v, want %q", got, ok, want)
+	}
+	if _, ok := renderCellValue("hello", ValueRendererRule{Renderer: valueRendererUUID}); ok {
+		t.Fatalf("expected non-uuid text to stay raw")
+	}
+}
+
+func TestResolveValueRenderers_FromColumnTypes(t *testing.T) {
+	rules := resolveValueRenderers(
+		[]string{"id", "status", "created_at", "client_ip", "name"},
+		[]connection.ColumnDefinition{
+			{Name: "id", Type: "binary(16)"},
+			{Name: "status", Type: "enum('new','it''s')"},
+			{Name: "created_at", Type: "bigint"},
+			{Name: "client_ip", Type: "int unsigned"},
+			{Name: "name", Type: "varchar(20)"},
+		},
+		[]ValueRendererRule{{Column: "name", Renderer: valueRendererRaw}},
+	)
+	want := map[string]string{"id": valueRendererUUID, "status": valueRendererEnum, "created_at": valueRendererEpoch, "client_ip": valueRendererIP}
+	if len(rules) != len(want) {
+		t.Fatalf("unexpected rules: %#v", rules)
+	}
+	for col, renderer := range want {
+		if rules[col].Renderer != renderer {
+			t.Fatalf("column %s: got %s, want %s", col, rules[col].Renderer, renderer)
+		}
+	}
+	if got := rules["status"].Values; len(got) != 2 || got[1] != "it's" {
+		t.Fatalf("enum values = %#v", got)
+	}
+}
@@ -0,0 +1,198 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+)
+
+// queryExecutor 编辑器语句的执行目标：共享连接池（poolExecutor）或标签页固定的会话（sessionExecutor）。
+// 只读校验、读写分流、拉取限制、线路日志、历史记录与结果组装由 runQuery 统一处理。
+type queryExecutor interface {
+	// open 取得执行所需的连接，在只读校验通过后调用
+	open() error
+	// routeRead 选定读语句的执行节点，返回其连接配置与路由说明；之后的 query 在该节点上执行
+	routeRead(route string) (connection.ConnectionConfig, string, error)
+	query(ctx context.Context, sql string) ([]map[string]interface{}, []string, string, error)
+	exec(ctx context.Context, sql string) (int64, error)
+	// metadata 读取 DDL 备份所需元数据的连接
+	metadata() (db.Database, error)
+	// errorMessage 失败结果中展示的错误信息
+	errorMessage(err error) string
+	// pinnedSession 语句是否在同一条连接上连续执行，决定超时设置能否用 SET LOCAL
+	pinnedSession() bool
+}
+
+// queryRequest 一次 DBQuery / DBQueryInTab 调用中与执行目标无关的部分。
+type queryRequest struct {
+	config connection.ConnectionConfig // normalizeRunConfig 之后的配置
+	dbName string
+	tabID  string
+	query  string // 已经过 sanitizeSQLForPgLike
+	opts   dbQueryOptions
+}
+
+// runQuery 在 ex 上执行 req：返回结果集的语句走查询，其余走执行。
+func (a *App) runQuery(ctx context.Context, req queryRequest, ex queryExecutor) connection.QueryResult {
+	runConfig, dbName, query := req.config, req.dbName, req.query
+	if err := checkReadOnlySQL(runConfig, query); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	op := "DBQuery"
+	if req.tabID != "" {
+		op = fmt.Sprintf("DBQueryInTab 标签页=%s", req.tabID)
+	}
+	if err := ex.open(); err != nil {
+		logger.Error(err, "%s 获取连接失败：%s", op, formatConnSummary(runConfig))
+		return connectErrorResult(err)
+	}
+	execSQL := tagQuery(runConfig, dbName, req.tabID, query)
+
+	if !isReadQuery(runConfig.Type, query) {
+		var backup *connection.SchemaChangeBackup
+		var backupWarning string
+		if metaInst, err := ex.metadata(); err == nil {
+			backup, backupWarning = backupBeforeSchemaChange(metaInst, runConfig, dbName, query)
+		}
+		started := time.Now()
+		affected, err := ex.exec(ctx, execSQL)
+		a.wireLog.record(runConfig, dbName, "exec", execSQL, started, affected, err)
+		a.recordHistory(runConfig, dbName, "exec", query, started, affected, err)
+		if err != nil {
+			discardSchemaBackup(backup)
+			logger.Error(err, "%s 执行失败：%s SQL片段=%q", op, formatConnSummary(runConfig), sqlSnippet(query))
+			a.recentErrors.record(recentErrorKindQuery, runConfig, dbName, err, query)
+			res := queryErrorResult(err, execSQL, query)
+			res.Message = ex.errorMessage(err)
+			return res
+		}
+		if sqlstmt.Classify(runConfig.Type, query).Kind == sqlstmt.KindDDL {
+			markERModelDirty(runConfig, dbName)
+		}
+		res := connection.QueryResult{Success: true, Data: map[string]int64{"affectedRows": affected}}
+		return withSchemaBackup(res, backup, backupWarning)
+	}
+
+	route := req.opts.route
+	if readOnlyViolation(runConfig.Type, query) != "" {
+		// INSERT ... RETURNING 等返回结果集的写语句仍在主库执行
+		route = queryRoutePrimary
+	}
+	readConfig, routeNote, err := ex.routeRead(route)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	started := time.Now()
+	limit, used, exhausted := a.transferLimitFor(runConfig, started)
+	if req.opts.ignoreLimit {
+		limit = db.TransferLimit{}
+	} else if exhausted {
+		return connection.QueryResult{
+			Success:      true,
+			Message:      fmt.Sprintf("最近一分钟已读取 %d 行，达到每分钟行数上限 %d。是否继续执行？", used, runConfig.MaxRowsPerMinute),
+			Data:         []map[string]interface{}{},
+			LimitReached: true,
+		}
+	}
+	readSQL := limitReadQuery(runConfig, execSQL, ex.pinnedSession())
+	data, columns, warning, err := ex.query(db.WithTransferLimit(ctx, limit), readSQL)
+	a.wireLog.record(readConfig, dbName, "query", readSQL, started, int64(len(data)), err)
+	a.recordHistory(readConfig, dbName, "query", query, started, int64(len(data)), err)
+	a.recordTransferredRows(runConfig, len(data), started)
+	if res, ok := transferLimitResult(readConfig, err, data, columns); ok {
+		return res
+	}
+	if err != nil {
+		logger.Error(err, "%s 查询失败：%s SQL片段=%q", op, formatConnSummary(readConfig), sqlSnippet(query))
+		a.recentErrors.record(recentErrorKindQuery, readConfig, dbName, err, query)
+		res := queryErrorResult(err, limitedQueryErrorSQL(readSQL, execSQL, query), query)
+		res.Message = ex.errorMessage(err)
+		return res
+	}
+	if routeNote != "" {
+		warning = strings.TrimSpace(routeNote + " " + warning)
+	}
+	return connection.QueryResult{Success: true, Message: warning, Data: data, Fields: columns}
+}
+
+// poolExecutor 在共享连接池上执行，读语句按读写分离路由，连接断开时按配置自动重连重试。
+type poolExecutor struct {
+	app        *App
+	config     connection.ConnectionConfig
+	primary    db.Database
+	tracked    *runningQuery // 非空时语句可被 CancelQuery 中止
+	readInst   db.Database
+	readConfig connection.ConnectionConfig
+}
+
+func (e *poolExecutor) open() error {
+	primary, err := e.app.getDatabase(e.config)
+	e.primary = primary
+	return err
+}
+
+func (e *poolExecutor) routeRead(route string) (connection.ConnectionConfig, string, error) {
+	inst, readConfig, note, err := e.app.resolveReadTarget(e.config, e.primary, route)
+	if err != nil {
+		return readConfig, "", err
+	}
+	e.readInst, e.readConfig = inst, readConfig
+	return readConfig, note, nil
+}
+
+func (e *poolExecutor) query(ctx context.Context, sql string) (data []map[string]interface{}, columns []string, warning string, err error) {
+	warning, err = e.app.runWithReconnect(e.readConfig, e.readInst, "DBQuery", func(inst db.Database) (queryErr error) {
+		data, columns, queryErr = e.app.queryTracked(ctx, e.tracked, inst, sql)
+		return queryErr
+	})
+	return data, columns, warning, err
+}
+
+func (e *poolExecutor) exec(ctx context.Context, sql string) (int64, error) {
+	return e.app.execTracked(ctx, e.tracked, e.primary, sql)
+}
+
+func (e *poolExecutor) metadata() (db.Database, error) { return e.primary, nil }
+
+func (e *poolExecutor) errorMessage(err error) string { return err.Error() }
+
+func (e *poolExecutor) pinnedSession() bool { return false }
+
+// sessionExecutor 在标签页固定的会话上执行，调用方需持有 pinned.mu。会话状态无法恢复，不做自动重连。
+type sessionExecutor struct {
+	app    *App
+	tabID  string
+	pinned *tabSession
+	config connection.ConnectionConfig
+}
+
+func (e *sessionExecutor) open() error { return nil }
+
+func (e *sessionExecutor) routeRead(string) (connection.ConnectionConfig, string, error) {
+	// 会话变量只存在于这条连接上，读语句不走从库
+	return e.config, "", nil
+}
+
+func (e *sessionExecutor) query(ctx context.Context, sql string) ([]map[string]interface{}, []string, string, error) {
+	data, columns, err := e.pinned.session.QueryContext(ctx, sql)
+	return data, columns, "", err
+}
+
+func (e *sessionExecutor) exec(ctx context.Context, sql string) (int64, error) {
+	return e.pinned.session.ExecContext(ctx, sql)
+}
+
+// metadata 元数据从共享连接池读取，不占用标签页会话。
+func (e *sessionExecutor) metadata() (db.Database, error) { return e.app.getDatabase(e.config) }
+
+func (e *sessionExecutor) errorMessage(err error) string {
+	return e.app.tabSessionError(e.tabID, e.pinned, err)
+}
+
+func (e *sessionExecutor) pinnedSession() bool { return true }
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+// fakeQueryExecutor 记录 runQuery 对执行目标的调用。
+type fakeQueryExecutor struct {
+	opened  bool
+	queries []string
+	execs   []string
+	rows    []map[string]interface{}
+	err     error
+}
+
+func (e *fakeQueryExecutor) open() error { e.opened = true; return nil }
+
+func (e *fakeQueryExecutor) routeRead(string) (connection.ConnectionConfig, string, error) {
+	return connection.ConnectionConfig{Type: "mysql"}, "", nil
+}
+
+func (e *fakeQueryExecutor) query(_ context.Context, sql string) ([]map[string]interface{}, []string, string, error) {
+	e.queries = append(e.queries, sql)
+	return e.rows, []string{"id"}, "", e.err
+}
+
+func (e *fakeQueryExecutor) exec(_ context.Context, sql string) (int64, error) {
+	e.execs = append(e.execs, sql)
+	return 3, e.err
+}
+
+func (e *fakeQueryExecutor) metadata() (db.Database, error) { return nil, errors.New("无元数据") }
+
+func (e *fakeQueryExecutor) errorMessage(err error) string { return "会话：" + err.Error() }
+
+func (e *fakeQueryExecutor) pinnedSession() bool { return true }
+
+func TestRunQuerySharedSteps(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, Database: "shop", ReadOnly: true, MaxRowsPerMinute: 2}
+
+	ex := &fakeQueryExecutor{}
+	res := a.runQuery(context.Background(), queryRequest{config: config, dbName: "shop", query: "UPDATE t SET a = 1"}, ex)
+	if res.Success || ex.opened || len(ex.execs) > 0 {
+		t.Fatalf("read-only connection should reject before opening, got %+v", res)
+	}
+
+	ex = &fakeQueryExecutor{rows: []map[string]interface{}{{"id": 1}, {"id": 2}}}
+	res = a.runQuery(context.Background(), queryRequest{config: config, dbName: "shop", query: "SELECT id FROM t"}, ex)
+	if !res.Success || len(ex.queries) != 1 || len(res.Fields) != 1 {
+		t.Fatalf("read = %+v queries=%v", res, ex.queries)
+	}
+	// 两行已用尽每分钟配额，下一次读取直接提示而不执行
+	res = a.runQuery(context.Background(), queryRequest{config: config, dbName: "shop", query: "SELECT id FROM t"}, ex)
+	if !res.LimitReached || len(ex.queries) != 1 {
+		t.Fatalf("expected transfer limit prompt, got %+v queries=%v", res, ex.queries)
+	}
+	res = a.runQuery(context.Background(), queryRequest{config: config, dbName: "shop", query: "SELECT id FROM t", opts: dbQueryOptions{ignoreLimit: true}}, ex)
+	if !res.Success || res.LimitReached || len(ex.queries) != 2 {
+		t.Fatalf("ignoreLimit should run the query, got %+v", res)
+	}
+
+	config.ReadOnly = false
+	ex = &fakeQueryExecutor{}
+	res = a.runQuery(context.Background(), queryRequest{config: config, dbName: "shop", query: "DELETE FROM t"}, ex)
+	if affected, _ := res.Data.(map[string]int64); !res.Success || affected["affectedRows"] != 3 {
+		t.Fatalf("exec = %+v", res)
+	}
+	ex.err = errors.New("lock wait timeout")
+	res = a.runQuery(context.Background(), queryRequest{config: config, dbName: "shop", query: "DELETE FROM t"}, ex)
+	if res.Success || res.Message != "会话：lock wait timeout" || res.Error == nil {
+		t.Fatalf("executor should shape the error message, got %+v", res)
+	}
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/queryhistory"
+)
+
+var errHistoryUnavailable = errors.New("执行历史尚未初始化")
+
+// newHistoryEntry 构造一条执行记录；语句中的口令类字面量在落盘前屏蔽。
+func newHistoryEntry(config connection.ConnectionConfig, dbName string, kind string, query string, started time.Time, rows int64, err error) queryhistory.Entry {
+	entry := queryhistory.Entry{
+		ConnType:   strings.ToLower(strings.TrimSpace(config.Type)),
+		ConnAddr:   historyConnAddr(config),
+		ConnUser:   config.User,
+		Database:   dbName,
+		SQL:        redactSQLSecrets(strings.TrimSpace(query)),
+		Kind:       kind,
+		DurationMs: float64(time.Since(started).Microseconds()) / 1000,
+		Rows:       rows,
+		Success:    err == nil,
+		ExecutedAt: started.UnixMilli(),
+	}
+	if err != nil {
+		entry.Error = normalizeErrorMessage(err)
+	}
+	return entry
+}
+
+// historyConnAddr 本地文件库取文件路径，其余取 host:port。
+func historyConnAddr(config connection.ConnectionConfig) string {
+	switch strings.ToLower(strings.TrimSpace(config.Type)) {
+	case "sqlite", "duckdb":
+		return strings.TrimSpace(config.Host)
+	}
+	if config.Port > 0 {
+		return fmt.Sprintf("%s:%d", config.Host, config.Port)
+	}
+	return config.Host
+}
+
+// recordHistory 记录用户发起的一次执行；写入失败只记日志，不影响语句结果。
+func (a *App) recordHistory(config connection.ConnectionConfig, dbName string, kind string, query string, started time.Time, rows int64, err error) {
+	if a.history == nil || strings.TrimSpace(query) == "" {
+		return
+	}
+	if _, recordErr := a.history.Record(newHistoryEntry(config, dbName, kind, query, started, rows, err)); recordErr != nil {
+		logger.Warnf("写入执行历史失败：%v", recordErr)
+	}
+}
+
+// SearchQueryHistory 按关键字、连接、库、执行结果与时间范围检索执行历史，按执行时间倒序分页返回。
+func (a *App) SearchQueryHistory(filter queryhistory.Filter) connection.QueryResult {
+	if a.history == nil {
+		return connection.QueryResult{Success: false, Message: errHistoryUnavailable.Error()}
+	}
+	page, err := a.history.Search(filter)
+	if err != nil {
+		logger.Error(err, "检索执行历史失败")
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: page}
+}
+
+// SetQueryHistoryFavorite 收藏或取消收藏一条执行记录；收藏的记录不会被自动清理。
+func (a *App) SetQueryHistoryFavorite(id int64, favorite bool) connection.QueryResult {
+	if a.history == nil {
+		return connection.QueryResult{Success: false, Message: errHistoryUnavailable.Error()}
+	}
+	if err := a.history.SetFavorite(id, favorite); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true}
+}
+
+// DeleteQueryHistory 删除指定的执行记录。
+func (a *App) DeleteQueryHistory(ids []int64) connection.QueryResult {
+	if a.history == nil {
+		return connection.QueryResult{Success: false, Message: errHistoryUnavailable.Error()}
+	}
+	removed, err := a.history.Delete(ids)
+	if err != nil {
+		logger.Error(err, "删除执行历史失败")
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已删除 %d 条记录", removed), Data: map[string]int64{"removed": removed}}
+}
+
+// PruneQueryHistory 删除早于指定时间的记录，或只保留最近的 N 条；条件均为空时清空历史。
+func (a *App) PruneQueryHistory(opts queryhistory.PruneOptions) connection.QueryResult {
+	if a.history == nil {
+		return connection.QueryResult{Success: false, Message: errHistoryUnavailable.Error()}
+	}
+	removed, err := a.history.Prune(opts)
+	if err != nil {
+		logger.Error(err, "清理执行历史失败")
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	logger.Infof("已清理执行历史 %d 条", removed)
+	return connection.QueryResult{Success: true, Message: fmt.Sprintf("已清理 %d 条记录", removed), Data: map[string]int64{"removed": removed}}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/queryhistory"
+	"GoNavi-Wails/internal/redis"
+)
+
+func TestNewHistoryEntry(t *testing.T) {
+	started := time.UnixMilli(1700000000000)
+	config := connection.ConnectionConfig{Type: "MySQL", Host: "db", Port: 3306, User: "root"}
+	entry := newHistoryEntry(config, "shop", "exec", " ALTER USER app IDENTIFIED BY 'secret' ", started, 0, errors.New("denied"))
+	if entry.ConnType != "mysql" || entry.ConnAddr != "db:3306" || entry.Database != "shop" || entry.ExecutedAt != 1700000000000 {
+		t.Fatalf("entry = %+v", entry)
+	}
+	if strings.Contains(entry.SQL, "secret") || entry.Success || entry.Error == "" {
+		t.Fatalf("entry = %+v", entry)
+	}
+	if addr := historyConnAddr(connection.ConnectionConfig{Type: "sqlite", Host: "/tmp/a.db"}); addr != "/tmp/a.db" {
+		t.Fatalf("sqlite addr = %q", addr)
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+	a := &App{}
+	// 未启动时不记录
+	a.recordHistory(connection.ConnectionConfig{Type: "mysql"}, "", "query", "SELECT 1", time.Now(), 1, nil)
+	if res := a.SearchQueryHistory(queryhistory.Filter{}); res.Success {
+		t.Fatalf("expected unavailable history")
+	}
+
+	a.history = queryhistory.New(filepath.Join(t.TempDir(), "history.db"))
+	defer a.history.Close()
+	a.recordHistory(connection.ConnectionConfig{Type: "mysql", Host: "db", Port: 3306}, "shop", "query", "SELECT 1", time.Now(), 1, nil)
+	res := a.SearchQueryHistory(queryhistory.Filter{ConnAddr: "db:3306"})
+	page, ok := res.Data.(queryhistory.Page)
+	if !res.Success || !ok || page.Total != 1 || page.Entries[0].SQL != "SELECT 1" {
+		t.Fatalf("search = %+v", res)
+	}
+}
+
+func TestRedisHistoryRows(t *testing.T) {
+	array := &redis.CommandReply{Type: "array", Elements: []redis.CommandReply{{Type: "string"}, {Type: "string"}}}
+	if n := redisReplyRows("query", array); n != 2 {
+		t.Fatalf("array rows = %d", n)
+	}
+	if n := redisReplyRows("exec", &redis.CommandReply{Type: "integer", Value: int64(3)}); n != 3 {
+		t.Fatalf("integer rows = %d", n)
+	}
+	if got := redisHistoryCommand(redis.CommandInfo{Name: "AUTH"}, "AUTH admin secret"); strings.Contains(got, "secret") {
+		t.Fatalf("history command = %q", got)
+	}
+}
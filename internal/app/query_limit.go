@@ -0,0 +1,93 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/sqlstmt"
+)
+
+// limitReadQuery 在配置了 ServerQueryTimeout 的连接上为只读查询注入服务端执行时间限制，
+// 即使客户端取消未能送达，服务端也会自行终止超时的查询：
+//   - MySQL：在主 SELECT 之后加 /*+ MAX_EXECUTION_TIME(ms) */ 优化器提示；Doris 用 /*+ SET_VAR(query_timeout=秒) */
+//   - MariaDB：SET STATEMENT max_statement_time=秒 FOR ...
+//   - PostgreSQL 系：SET LOCAL statement_timeout，随 simple query 的隐式事务结束失效
+//   - SQL Server：SET QUERY_GOVERNOR_COST_LIMIT，按估算成本（约等于秒数）拒绝执行过重的查询
+//
+// SET 类设置作用于整个会话，会随连接回到连接池影响之后的语句，因此在查询之后立即恢复：
+// SQL Server 追加 SET QUERY_GOVERNOR_COST_LIMIT 0；固定会话（标签页）可能处于用户开启的事务中，
+// SET LOCAL 会一直生效到事务结束，PostgreSQL 系改用 SET statement_timeout 与 RESET 成对执行。
+// 查询出错时 PostgreSQL 的隐式事务回滚会一并撤销 SET。
+func limitReadQuery(config connection.ConnectionConfig, query string, pinnedSession bool) string {
+	seconds := config.ServerQueryTimeout
+	if seconds <= 0 || strings.TrimSpace(query) == "" {
+		return query
+	}
+	switch dbType := dialect.Normalize(config.Type); dbType {
+	case "mysql", "diros", "doris":
+		// 提示只对 SELECT 生效，WITH 语句加在 CTE 之后的主 SELECT 上
+		end := sqlstmt.MainKeywordEnd(dbType, query)
+		if end < 0 || !strings.HasSuffix(strings.ToUpper(query[:end]), "SELECT") {
+			return query
+		}
+		hint := fmt.Sprintf(" /*+ MAX_EXECUTION_TIME(%d) */", seconds*1000)
+		if dbType != "mysql" {
+			hint = fmt.Sprintf(" /*+ SET_VAR(query_timeout=%d) */", seconds)
+		}
+		return query[:end] + hint + query[end:]
+	case "mariadb":
+		return fmt.Sprintf("SET STATEMENT max_statement_time=%d FOR ", seconds) + query
+	case "postgres", "kingbase", "highgo", "vastbase":
+		if pinnedSession {
+			return fmt.Sprintf("SET statement_timeout = %d; ", seconds*1000) + query + limitResetSeparator(query) + "RESET statement_timeout"
+		}
+		return fmt.Sprintf("SET LOCAL statement_timeout = %d; ", seconds*1000) + query
+	case "sqlserver":
+		return fmt.Sprintf("SET QUERY_GOVERNOR_COST_LIMIT %d; ", seconds) + query + limitResetSeparator(query) + "SET QUERY_GOVERNOR_COST_LIMIT 0"
+	}
+	return query
+}
+
+// limitResetSeparator 返回追加恢复语句前需要的分隔：原语句以分号结尾时只换行，末尾是行注释时换行后仍能成为独立语句。
+func limitResetSeparator(query string) string {
+	if strings.HasSuffix(strings.TrimSpace(query), ";") {
+		return "\n"
+	}
+	return "\n;"
+}
+
+// limitParamReadQuery 为参数化只读查询注入服务端执行时间限制。参数化语句走扩展协议或 sp_executesql，
+// 不能像 limitReadQuery 那样在同一语句中前后拼接 SET，因此返回需在独立会话上先执行的设置语句；
+// 会话用后丢弃，设置不会回到连接池。MySQL/Doris 的优化器提示仍直接写入语句。
+func limitParamReadQuery(config connection.ConnectionConfig, query string) (string, string) {
+	seconds := config.ServerQueryTimeout
+	if seconds <= 0 || strings.TrimSpace(query) == "" {
+		return query, ""
+	}
+	switch dialect.Normalize(config.Type) {
+	case "mysql", "diros", "doris":
+		return limitReadQuery(config, query, false), ""
+	case "mariadb":
+		return query, fmt.Sprintf("SET SESSION max_statement_time = %d", seconds)
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return query, fmt.Sprintf("SET statement_timeout = %d", seconds*1000)
+	case "sqlserver":
+		return query, fmt.Sprintf("SET QUERY_GOVERNOR_COST_LIMIT %d", seconds)
+	}
+	return query, ""
+}
+
+// limitedQueryErrorSQL 返回用于定位错误位置的已发送语句：前缀式注入以原语句结尾，可直接映射；
+// MySQL 提示插在语句中间，改用未注入的语句（MySQL 只报告行号与附近文本，不受提示影响）。
+func limitedQueryErrorSQL(sentSQL string, execSQL string, query string) string {
+	if strings.HasSuffix(sentSQL, query) {
+		return sentSQL
+	}
+	// 追加了恢复设置的语句：截去末尾的恢复部分，错误位置按前缀偏移映射
+	if idx := strings.LastIndex(sentSQL, execSQL); idx >= 0 && strings.HasSuffix(execSQL, query) {
+		return sentSQL[:idx+len(execSQL)]
+	}
+	return execSQL
+}
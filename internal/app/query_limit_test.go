@@ -0,0 +1,61 @@
+package app
+
+import (
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestLimitReadQuery(t *testing.T) {
+	cases := []struct {
+		dbType string
+		pinned bool
+		query  string
+		want   string
+	}{
+		{"mysql", false, "/* gonavi */ select * from t", "/* gonavi */ select /*+ MAX_EXECUTION_TIME(5000) */ * from t"},
+		{"mysql", false, "WITH c AS (SELECT 1) SELECT * FROM c", "WITH c AS (SELECT 1) SELECT /*+ MAX_EXECUTION_TIME(5000) */ * FROM c"},
+		{"mysql", false, "SHOW TABLES", "SHOW TABLES"},
+		{"mariadb", false, "SELECT 1", "SET STATEMENT max_statement_time=5 FOR SELECT 1"},
+		{"diros", false, "SELECT 1", "SELECT /*+ SET_VAR(query_timeout=5) */ 1"},
+		{"postgres", false, "SELECT 1", "SET LOCAL statement_timeout = 5000; SELECT 1"},
+		{"postgresql", true, "SELECT 1", "SET statement_timeout = 5000; SELECT 1\n;RESET statement_timeout"},
+		{"kingbase", true, "SELECT 1;", "SET statement_timeout = 5000; SELECT 1;\nRESET statement_timeout"},
+		{"sqlserver", false, "SELECT 1", "SET QUERY_GOVERNOR_COST_LIMIT 5; SELECT 1\n;SET QUERY_GOVERNOR_COST_LIMIT 0"},
+		{"sqlite", false, "SELECT 1", "SELECT 1"},
+	}
+	for _, c := range cases {
+		config := connection.ConnectionConfig{Type: c.dbType, ServerQueryTimeout: 5}
+		if got := limitReadQuery(config, c.query, c.pinned); got != c.want {
+			t.Errorf("limitReadQuery(%s, %q) = %q, want %q", c.dbType, c.query, got, c.want)
+		}
+	}
+	if got := limitReadQuery(connection.ConnectionConfig{Type: "mysql"}, "SELECT 1", false); got != "SELECT 1" {
+		t.Errorf("limit should be off by default, got %q", got)
+	}
+	if got := limitedQueryErrorSQL("SELECT /*+ MAX_EXECUTION_TIME(5000) */ x", "SELECT x", "SELECT x"); got != "SELECT x" {
+		t.Errorf("limitedQueryErrorSQL = %q", got)
+	}
+	if got := limitedQueryErrorSQL("SET QUERY_GOVERNOR_COST_LIMIT 5; SELECT x\n;SET QUERY_GOVERNOR_COST_LIMIT 0", "SELECT x", "SELECT x"); got != "SET QUERY_GOVERNOR_COST_LIMIT 5; SELECT x" {
+		t.Errorf("limitedQueryErrorSQL should drop the reset suffix, got %q", got)
+	}
+}
+
+func TestLimitParamReadQuery(t *testing.T) {
+	cases := []struct {
+		dbType, wantSQL, wantSetup string
+	}{
+		{"mysql", "SELECT /*+ MAX_EXECUTION_TIME(5000) */ * FROM t WHERE id = ?", ""},
+		{"mariadb", "SELECT * FROM t WHERE id = ?", "SET SESSION max_statement_time = 5"},
+		{"postgres", "SELECT * FROM t WHERE id = ?", "SET statement_timeout = 5000"},
+		{"sqlserver", "SELECT * FROM t WHERE id = ?", "SET QUERY_GOVERNOR_COST_LIMIT 5"},
+		{"sqlite", "SELECT * FROM t WHERE id = ?", ""},
+	}
+	for _, c := range cases {
+		config := connection.ConnectionConfig{Type: c.dbType, ServerQueryTimeout: 5}
+		gotSQL, gotSetup := limitParamReadQuery(config, "SELECT * FROM t WHERE id = ?")
+		if gotSQL != c.wantSQL || gotSetup != c.wantSetup {
+			t.Errorf("limitParamReadQuery(%s) = %q, %q; want %q, %q", c.dbType, gotSQL, gotSetup, c.wantSQL, c.wantSetup)
+		}
+	}
+}
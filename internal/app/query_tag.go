@@ -0,0 +1,81 @@
+package app
+
+import (
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+// defaultQueryTagTemplate 默认查询标记模板，可用变量：{user} {host} {db} {tab} {version}。
+const defaultQueryTagTemplate = "gonavi user={user} tab={tab}"
+
+var queryTagOSUser = sync.OnceValue(func() string {
+	if u, err := user.Current(); err == nil && strings.TrimSpace(u.Username) != "" {
+		return u.Username
+	}
+	for _, key := range []string{"USER", "USERNAME"} {
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			return v
+		}
+	}
+	return ""
+})
+
+var queryTagHostname = sync.OnceValue(func() string {
+	name, _ := os.Hostname()
+	return name
+})
+
+// sanitizeQueryTagText 去掉可能提前闭合注释或换行的字符，避免标记内容改变语句语义。
+func sanitizeQueryTagText(text string) string {
+	text = strings.NewReplacer("*/", "", "/*", "", "\r", " ", "\n", " ").Replace(text)
+	return strings.TrimSpace(text)
+}
+
+// renderQueryTag 按模板生成 /* ... */ 注释；变量为空时以 - 占位。模板可带或不带注释符。
+func renderQueryTag(template string, vars map[string]string) string {
+	tpl := strings.TrimSpace(template)
+	if tpl == "" {
+		tpl = defaultQueryTagTemplate
+	}
+	tpl = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(tpl, "/*"), "*/"))
+	pairs := make([]string, 0, len(vars)*2)
+	for key, value := range vars {
+		value = strings.Join(strings.Fields(sanitizeQueryTagText(value)), "_")
+		if value == "" {
+			value = "-"
+		}
+		pairs = append(pairs, "{"+key+"}", value)
+	}
+	body := sanitizeQueryTagText(strings.NewReplacer(pairs...).Replace(tpl))
+	if body == "" {
+		return ""
+	}
+	return "/* " + body + " */"
+}
+
+// tagQuery 在开启查询标记的连接上为语句加上标识注释，便于 DBA 在服务端会话/慢日志中追溯来源。
+// 非 SQL 数据源（MongoDB/Redis）原样返回。
+func tagQuery(config connection.ConnectionConfig, dbName string, tabID string, query string) string {
+	if !config.QueryTag || strings.TrimSpace(query) == "" {
+		return query
+	}
+	switch strings.ToLower(strings.TrimSpace(config.Type)) {
+	case "mongodb", "redis":
+		return query
+	}
+	tag := renderQueryTag(config.QueryTagTemplate, map[string]string{
+		"user":    queryTagOSUser(),
+		"host":    queryTagHostname(),
+		"db":      dbName,
+		"tab":     tabID,
+		"version": getCurrentVersion(),
+	})
+	if tag == "" {
+		return query
+	}
+	return tag + " " + query
+}
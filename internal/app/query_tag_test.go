@@ -0,0 +1,34 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestRenderQueryTag(t *testing.T) {
+	got := renderQueryTag("", map[string]string{"user": "alice smith", "tab": ""})
+	if got != "/* gonavi user=alice_smith tab=- */" {
+		t.Fatalf("默认模板渲染错误：%s", got)
+	}
+	got = renderQueryTag("/* app=gonavi db={db} */", map[string]string{"db": "shop*/ DROP TABLE x; /*"})
+	if strings.Count(got, "*/") != 1 || !strings.HasSuffix(got, " */") {
+		t.Fatalf("变量中的注释符未被清理：%s", got)
+	}
+}
+
+func TestTagQuery(t *testing.T) {
+	cfg := connection.ConnectionConfig{Type: "mysql", QueryTag: true, QueryTagTemplate: "gonavi tab={tab} db={db}"}
+	if got := tagQuery(cfg, "shop", "t1", "SELECT 1"); got != "/* gonavi tab=t1 db=shop */ SELECT 1" {
+		t.Fatalf("标记结果不符合预期：%s", got)
+	}
+	cfg.Type = "redis"
+	if got := tagQuery(cfg, "", "", "GET k"); got != "GET k" {
+		t.Fatalf("非 SQL 数据源不应加标记：%s", got)
+	}
+	cfg = connection.ConnectionConfig{Type: "postgres"}
+	if got := tagQuery(cfg, "", "", "SELECT 1"); got != "SELECT 1" {
+		t.Fatalf("未开启时不应加标记：%s", got)
+	}
+}
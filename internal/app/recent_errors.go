@@ -0,0 +1,173 @@
+package app
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+const (
+	recentErrorCapacity    = 500
+	recentErrorMaxSQLBytes = 2 * 1024
+	recentErrorGroupSample = 5 // 每组返回的最近明细条数
+)
+
+// 错误类别。
+const (
+	recentErrorKindQuery   = "query"   // 语句执行失败
+	recentErrorKindConnect = "connect" // 建立连接失败
+	recentErrorKindAgent   = "agent"   // 驱动代理进程崩溃或退出
+)
+
+// recentErrorPlaceholderPattern 分组时把错误中的字面量、名称与数字替换为占位符，
+// 如 “Table 'shop.a' doesn't exist” 与 “Table 'shop.b' doesn't exist” 归为同一组。
+var recentErrorPlaceholderPattern = regexp.MustCompile("'[^']*'|\"[^\"]*\"|`[^`]*`|\\b0x[0-9a-fA-F]+\\b|\\d+")
+
+// RecentError 一次后端错误。
+type RecentError struct {
+	Time       int64  `json:"time"`
+	Kind       string `json:"kind"`
+	Connection string `json:"connection"`
+	Database   string `json:"database,omitempty"`
+	Message    string `json:"message"`
+	SQL        string `json:"sql,omitempty"`
+}
+
+// RecentErrorGroup 按规范化消息合并的错误。
+type RecentErrorGroup struct {
+	Kind        string        `json:"kind"`
+	Pattern     string        `json:"pattern"` // 分组用的规范化消息
+	Count       int           `json:"count"`
+	FirstSeen   int64         `json:"firstSeen"`
+	LastSeen    int64         `json:"lastSeen"`
+	Connections []string      `json:"connections"`
+	Samples     []RecentError `json:"samples"` // 最近几次，按时间倒序
+}
+
+// recentErrorLog 内存中的最近错误环形缓冲区，超过容量后覆盖最早的记录；进程退出后不保留。
+type recentErrorLog struct {
+	mu      sync.Mutex
+	entries []RecentError
+	next    int
+	full    bool
+}
+
+func newRecentErrorLog(capacity int) *recentErrorLog {
+	return &recentErrorLog{entries: make([]RecentError, capacity)}
+}
+
+// record 记录一条错误；驱动代理通信失败的错误会归为 agent 类别，用户主动取消的语句不记录。
+func (l *recentErrorLog) record(kind string, config connection.ConnectionConfig, dbName string, err error, query string) {
+	if l == nil || err == nil || len(l.entries) == 0 || errors.Is(err, errQueryCanceled) {
+		return
+	}
+	if errors.Is(err, db.ErrDriverAgentExited) {
+		kind = recentErrorKindAgent
+	}
+	entry := RecentError{
+		Time:       time.Now().UnixMilli(),
+		Kind:       kind,
+		Connection: formatConnSummary(config),
+		Database:   strings.TrimSpace(dbName),
+		Message:    normalizeErrorMessage(err),
+	}
+	if query = strings.TrimSpace(query); query != "" {
+		entry.SQL = truncateRecentErrorSQL(redactWireSQL(query))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// snapshot 返回按时间正序排列的全部记录。
+func (l *recentErrorLog) snapshot() []RecentError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.full {
+		return append([]RecentError(nil), l.entries[:l.next]...)
+	}
+	out := make([]RecentError, 0, len(l.entries))
+	out = append(out, l.entries[l.next:]...)
+	return append(out, l.entries[:l.next]...)
+}
+
+func (l *recentErrorLog) clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	clear(l.entries)
+	l.next = 0
+	l.full = false
+}
+
+func truncateRecentErrorSQL(query string) string {
+	if len(query) <= recentErrorMaxSQLBytes {
+		return query
+	}
+	cut := recentErrorMaxSQLBytes
+	for cut > 0 && !isUTF8Start(query[cut]) {
+		cut--
+	}
+	return query[:cut] + "..."
+}
+
+// groupRecentErrors 按类别与规范化消息分组，最近出现的组排在前面。
+func groupRecentErrors(entries []RecentError) []RecentErrorGroup {
+	index := make(map[string]int)
+	groups := make([]RecentErrorGroup, 0)
+	// 倒序遍历，分组、样本与连接列表自然按最近优先
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		pattern := recentErrorPlaceholderPattern.ReplaceAllString(entry.Message, "?")
+		key := entry.Kind + "\x00" + pattern
+		pos, ok := index[key]
+		if !ok {
+			pos = len(groups)
+			index[key] = pos
+			groups = append(groups, RecentErrorGroup{Kind: entry.Kind, Pattern: pattern, LastSeen: entry.Time, Connections: []string{}})
+		}
+		g := &groups[pos]
+		g.Count++
+		g.FirstSeen = entry.Time
+		if len(g.Samples) < recentErrorGroupSample {
+			g.Samples = append(g.Samples, entry)
+		}
+		if entry.Connection != "" && !slices.Contains(g.Connections, entry.Connection) {
+			g.Connections = append(g.Connections, entry.Connection)
+		}
+	}
+	return groups
+}
+
+// GetRecentErrors 返回最近的后端错误（语句执行失败、连接失败、驱动代理崩溃），按规范化消息分组。
+// kind 为空时返回全部类别，可选 query、connect、agent。
+func (a *App) GetRecentErrors(kind string) connection.QueryResult {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	entries := a.recentErrors.snapshot()
+	if kind != "" {
+		filtered := entries[:0]
+		for _, entry := range entries {
+			if entry.Kind == kind {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+	return connection.QueryResult{Success: true, Data: groupRecentErrors(entries)}
+}
+
+// ClearRecentErrors 清空最近错误记录。
+func (a *App) ClearRecentErrors() connection.QueryResult {
+	a.recentErrors.clear()
+	return connection.QueryResult{Success: true, Message: "已清空最近错误"}
+}
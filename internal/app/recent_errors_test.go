@@ -0,0 +1,38 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+)
+
+func TestRecentErrorLogGroupsAndWraps(t *testing.T) {
+	log := newRecentErrorLog(3)
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "root"}
+	log.record(recentErrorKindQuery, config, "shop", errors.New("Table 'shop.a' doesn't exist"), "SELECT * FROM a")
+	log.record(recentErrorKindQuery, config, "shop", errors.New("Table 'shop.b' doesn't exist"), "SELECT * FROM b")
+	log.record(recentErrorKindQuery, config, "shop", errQueryCanceled, "SELECT SLEEP(10)")
+	log.record(recentErrorKindQuery, config, "shop", fmt.Errorf("读取 MySQL 驱动代理响应失败：%w", agentExitedForTest()), "SELECT 1")
+	log.record(recentErrorKindConnect, config, "", errors.New("dial tcp 10.0.0.1:3306: connection refused"), "IDENTIFIED BY 'secret'")
+
+	entries := log.snapshot()
+	if len(entries) != 3 || entries[0].Message != "Table 'shop.b' doesn't exist" {
+		t.Fatalf("ring buffer should keep the latest 3 entries: %+v", entries)
+	}
+	if entries[2].SQL != "IDENTIFIED BY '***'" {
+		t.Fatalf("sql should be redacted: %q", entries[2].SQL)
+	}
+
+	groups := groupRecentErrors(append(entries, RecentError{Kind: recentErrorKindQuery, Message: "Table 'shop.c' doesn't exist", Time: 1}))
+	if len(groups) != 3 || groups[0].Pattern != "Table ? doesn't exist" || groups[0].Count != 2 || groups[1].Kind != recentErrorKindConnect || groups[2].Kind != recentErrorKindAgent {
+		t.Fatalf("groups = %+v", groups)
+	}
+}
+
+// agentExitedForTest 模拟驱动代理进程退出后读取响应得到的错误。
+func agentExitedForTest() error {
+	return fmt.Errorf("EOF: %w", db.ErrDriverAgentExited)
+}
@@ -0,0 +1,368 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/sqlstmt"
+)
+
+const (
+	schemaBackupMetaFile     = "meta.json"
+	schemaBackupBeforeFile   = "before.sql"
+	schemaBackupRollbackFile = "rollback.sql"
+	schemaBackupMaxEntries   = 500
+)
+
+func schemaBackupDirectory() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "schema_backups")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-schema_backups")
+}
+
+// schemaBackupEntryDir 只接受 ListSchemaBackups 返回的 ID，防止路径穿越。
+func schemaBackupEntryDir(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || id != filepath.Base(id) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("无效的结构变更备份：%s", id)
+	}
+	return filepath.Join(schemaBackupDirectory(), id), nil
+}
+
+// schemaChangeStep 单个对象的变更前定义与回退语句。
+type schemaChangeStep struct {
+	label    string // 如 DROP TABLE public.orders
+	before   string
+	rollback string
+}
+
+// backupBeforeSchemaChange 执行 ALTER/DROP/RENAME 前读取受影响对象的当前定义写入本地备份目录，并生成回退脚本。
+// 语句不是结构修改时返回 nil；读取定义或写入备份失败只返回提示，不阻止语句执行。
+func backupBeforeSchemaChange(dbInst db.Database, config connection.ConnectionConfig, dbName string, statement string) (*connection.SchemaChangeBackup, string) {
+	dbType := resolveDDLDBType(config)
+	if sqlstmt.Classify(dbType, statement).Kind != sqlstmt.KindDDL {
+		return nil, ""
+	}
+	return backupSchemaChanges(dbInst, config, dbType, dbName, statement, sqlstmt.ParseSchemaChanges(dbType, statement))
+}
+
+func backupSchemaChanges(dbInst db.Database, config connection.ConnectionConfig, dbType string, dbName string, statement string, changes []sqlstmt.SchemaChange) (*connection.SchemaChangeBackup, string) {
+	if len(changes) == 0 {
+		return nil, ""
+	}
+	steps := make([]schemaChangeStep, 0, len(changes))
+	var warnings []string
+	for _, change := range changes {
+		step, err := captureSchemaChange(dbInst, dbType, dbName, change)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s：%s", step.label, normalizeErrorMessage(err)))
+			continue
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 {
+		logger.Warnf("结构变更前未能备份对象定义：%s 原因=%s", formatConnSummary(config), strings.Join(warnings, "；"))
+		return nil, "未能备份变更前的对象定义：" + strings.Join(warnings, "；")
+	}
+
+	backup, err := writeSchemaBackup(config, dbType, dbName, statement, steps, warnings)
+	if err != nil {
+		logger.Error(err, "写入结构变更备份失败：%s", formatConnSummary(config))
+		return nil, "写入结构变更备份失败：" + err.Error()
+	}
+	warning := ""
+	if len(warnings) > 0 {
+		warning = "部分对象未能备份：" + strings.Join(warnings, "；")
+	}
+	return backup, warning
+}
+
+// captureSchemaChange 读取对象的当前定义并生成对应的回退语句。
+func captureSchemaChange(dbInst db.Database, dbType string, dbName string, change sqlstmt.SchemaChange) (schemaChangeStep, error) {
+	schema, name := change.Schema, change.Name
+	if schema == "" {
+		schema, name = normalizeSchemaAndTableByType(dbType, dbName, name)
+	}
+	step := schemaChangeStep{label: fmt.Sprintf("%s %s %s", change.Action, change.Object, qualifyTable(schema, name))}
+	qualified := quoteTableIdentByType(dbType, schema, name)
+
+	if change.Object == "INDEX" {
+		table := change.Table
+		def, err := backupIndexDDL(dbInst, dbType, schema, table, name)
+		if err != nil {
+			return step, err
+		}
+		step.before, step.rollback = def, def
+		return step, nil
+	}
+
+	var ddl string
+	var err error
+	if change.Object == "VIEW" {
+		ddl, err = backupViewDDL(dbInst, dbType, schema, name)
+	} else {
+		ddl, err = backupTableDDL(dbInst, dbType, schema, name)
+	}
+	if err != nil {
+		return step, err
+	}
+	step.before = ddl
+
+	switch change.Action {
+	case "DROP":
+		step.rollback = ddl
+	case "RENAME":
+		newSchema := change.NewSchema
+		if newSchema == "" {
+			newSchema = schema
+		}
+		step.rollback = ensureSQLTerminator(schemaRenameSQL(dbType, change.Object, newSchema, change.NewName, schema, name))
+	case "ALTER", "REPLACE":
+		if change.Object == "VIEW" {
+			step.rollback = fmt.Sprintf("DROP VIEW %s;\n%s", qualified, ddl)
+			break
+		}
+		// 修改列、约束等无法从语句推出逆操作，附上变更前的完整定义供对照回退
+		lines := []string{"-- ALTER TABLE 无法自动生成逆向语句，以下为变更前的完整定义，请对照当前结构手工回退："}
+		for _, line := range strings.Split(ddl, "\n") {
+			lines = append(lines, "-- "+line)
+		}
+		step.rollback = strings.Join(lines, "\n")
+	}
+	return step, nil
+}
+
+// schemaRenameSQL 生成把 schema.from 重命名为 to 的语句，与 RenameTable/RenameView 的写法一致。
+func schemaRenameSQL(dbType string, object string, schema string, from string, toSchema string, to string) string {
+	switch dbType {
+	case "mysql", "mariadb", "diros", "sphinx":
+		return fmt.Sprintf("RENAME TABLE %s TO %s", quoteTableIdentByType(dbType, schema, from), quoteTableIdentByType(dbType, toSchema, to))
+	case "sqlserver":
+		return fmt.Sprintf("EXEC sp_rename %s, %s", dialect.QuoteLiteral(dbType, qualifyTable(schema, from)), dialect.QuoteLiteral(dbType, to))
+	case "postgres", "kingbase", "highgo", "vastbase":
+		if object == "VIEW" {
+			return fmt.Sprintf("ALTER VIEW %s RENAME TO %s", quoteTableIdentByType(dbType, schema, from), quoteIdentByType(dbType, to))
+		}
+	}
+	return fmt.Sprintf("ALTER TABLE %s RENAME TO %s", quoteTableIdentByType(dbType, schema, from), quoteIdentByType(dbType, to))
+}
+
+// backupIndexDDL 返回重建索引的语句。有系统函数或系统表保存原始定义的数据库直接读取，
+// 其余数据库按 GetIndexes 的列信息重新生成（需语句中写明所属表）。
+func backupIndexDDL(dbInst db.Database, dbType string, schema string, table string, index string) (string, error) {
+	var query string
+	switch dbType {
+	case "postgres", "kingbase", "highgo", "vastbase":
+		query = fmt.Sprintf("SELECT indexdef FROM pg_indexes WHERE schemaname = %s AND indexname = %s", dialect.QuoteLiteral(dbType, schema), dialect.QuoteLiteral(dbType, index))
+	case "sqlite":
+		query = fmt.Sprintf("SELECT sql FROM sqlite_master WHERE type = 'index' AND name = %s", dialect.QuoteLiteral(dbType, index))
+	case "duckdb":
+		query = fmt.Sprintf("SELECT sql FROM duckdb_indexes() WHERE index_name = %s", dialect.QuoteLiteral(dbType, index))
+	case "oracle", "dameng":
+		query = fmt.Sprintf("SELECT DBMS_METADATA.GET_DDL('INDEX', %s, %s) FROM DUAL", dialect.QuoteLiteral(dbType, index), dialect.QuoteLiteral(dbType, schema))
+	}
+	if query != "" {
+		def := strings.TrimSpace(queryFirstString(dbInst, query))
+		if def == "" {
+			return "", fmt.Errorf("未找到索引定义：%s", index)
+		}
+		return ensureSQLTerminator(def), nil
+	}
+
+	if strings.TrimSpace(table) == "" {
+		return "", fmt.Errorf("语句未指定索引所属的表，无法读取索引定义")
+	}
+	rows, err := dbInst.GetIndexes(schema, table)
+	if err != nil {
+		return "", err
+	}
+	spec := connection.IndexSpec{Name: index}
+	var matched []connection.IndexDefinition
+	for _, row := range rows {
+		if strings.EqualFold(row.Name, index) {
+			matched = append(matched, row)
+		}
+	}
+	if len(matched) == 0 {
+		return "", fmt.Errorf("未找到索引定义：%s", index)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].SeqInIndex < matched[j].SeqInIndex })
+	for _, row := range matched {
+		spec.Columns = append(spec.Columns, row.ColumnName)
+	}
+	spec.Unique = matched[0].NonUnique == 0
+	if method := strings.ToUpper(matched[0].IndexType); method == "FULLTEXT" || method == "SPATIAL" {
+		spec.Method = method
+	}
+	statements, err := db.BuildCreateIndexSQL(dbType, schema, table, spec)
+	if err != nil {
+		return "", err
+	}
+	for i, stmt := range statements {
+		statements[i] = ensureSQLTerminator(stmt)
+	}
+	return strings.Join(statements, "\n"), nil
+}
+
+// writeSchemaBackup 写入变更前定义、回退脚本与元数据，并清理超出保留数量的旧备份。
+func writeSchemaBackup(config connection.ConnectionConfig, dbType string, dbName string, statement string, steps []schemaChangeStep, warnings []string) (*connection.SchemaChangeBackup, error) {
+	now := time.Now()
+	id := fmt.Sprintf("%s-%s", now.Format("20060102-150405.000"), snapshotNameSanitizer.ReplaceAllString(steps[0].label, "_"))
+	dir := filepath.Join(schemaBackupDirectory(), id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建备份目录失败：%w", err)
+	}
+	backup := &connection.SchemaChangeBackup{
+		ID:           id,
+		CreatedAt:    now.UnixMilli(),
+		DBType:       dbType,
+		Host:         config.Host,
+		Database:     dbName,
+		Statement:    strings.TrimSpace(statement),
+		BackupPath:   filepath.Join(dir, schemaBackupBeforeFile),
+		RollbackPath: filepath.Join(dir, schemaBackupRollbackFile),
+		Warnings:     warnings,
+	}
+
+	header := fmt.Sprintf("-- 时间：%s\n-- 变更语句：%s\n\n", now.Format("2006-01-02 15:04:05"), sqlSnippet(statement))
+	var before, rollback strings.Builder
+	before.WriteString("-- GoNavi 结构变更前的对象定义\n" + header)
+	rollback.WriteString("-- GoNavi 结构变更回退脚本，执行前请确认当前结构与数据\n" + header)
+	for _, step := range steps {
+		backup.Objects = append(backup.Objects, step.label)
+		fmt.Fprintf(&before, "-- %s\n%s\n\n", step.label, step.before)
+	}
+	// 多个对象按变更的相反顺序回退
+	for i := len(steps) - 1; i >= 0; i-- {
+		fmt.Fprintf(&rollback, "-- 回退 %s\n%s\n\n", steps[i].label, steps[i].rollback)
+	}
+	backup.Rollback = rollback.String()
+
+	err := os.WriteFile(backup.BackupPath, []byte(before.String()), 0o644)
+	if err == nil {
+		err = os.WriteFile(backup.RollbackPath, []byte(backup.Rollback), 0o644)
+	}
+	if err == nil {
+		meta := *backup
+		meta.Rollback = ""
+		var content []byte
+		if content, err = json.MarshalIndent(meta, "", "  "); err == nil {
+			err = os.WriteFile(filepath.Join(dir, schemaBackupMetaFile), content, 0o644)
+		}
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	pruneSchemaBackups(schemaBackupMaxEntries)
+	return backup, nil
+}
+
+// discardSchemaBackup 语句执行失败时删除已写入的备份。
+func discardSchemaBackup(backup *connection.SchemaChangeBackup) {
+	if backup == nil {
+		return
+	}
+	if dir, err := schemaBackupEntryDir(backup.ID); err == nil {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// pruneSchemaBackups 只保留最近的 keep 个备份；目录名以时间开头，按名称排序即按时间排序。
+func pruneSchemaBackups(keep int) {
+	items, err := os.ReadDir(schemaBackupDirectory())
+	if err != nil {
+		return
+	}
+	var dirs []string
+	for _, item := range items {
+		if item.IsDir() {
+			dirs = append(dirs, item.Name())
+		}
+	}
+	if len(dirs) <= keep {
+		return
+	}
+	sort.Strings(dirs)
+	for _, name := range dirs[:len(dirs)-keep] {
+		_ = os.RemoveAll(filepath.Join(schemaBackupDirectory(), name))
+	}
+}
+
+// withSchemaBackup 把备份与备份提示附加到执行结果上。
+func withSchemaBackup(res connection.QueryResult, backup *connection.SchemaChangeBackup, warning string) connection.QueryResult {
+	if !res.Success {
+		discardSchemaBackup(backup)
+		return res
+	}
+	res.SchemaBackup = backup
+	if warning != "" {
+		res.Message = strings.TrimSpace(res.Message + " " + warning)
+	}
+	return res
+}
+
+// ListSchemaBackups 按时间倒序列出结构变更备份。
+func (a *App) ListSchemaBackups() connection.QueryResult {
+	items, err := os.ReadDir(schemaBackupDirectory())
+	if err != nil && !os.IsNotExist(err) {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	entries := make([]connection.SchemaChangeBackup, 0, len(items))
+	for _, item := range items {
+		if !item.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(schemaBackupDirectory(), item.Name(), schemaBackupMetaFile))
+		if err != nil {
+			continue
+		}
+		var entry connection.SchemaChangeBackup
+		if json.Unmarshal(content, &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CreatedAt > entries[j].CreatedAt })
+	return connection.QueryResult{Success: true, Data: entries}
+}
+
+// GetSchemaBackupRollback 返回结构变更备份的回退脚本，由前端在编辑器中打开后确认执行。
+func (a *App) GetSchemaBackupRollback(id string) connection.QueryResult {
+	dir, err := schemaBackupEntryDir(id)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	content, err := os.ReadFile(filepath.Join(dir, schemaBackupRollbackFile))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("读取回退脚本失败：%s", err.Error())}
+	}
+	return connection.QueryResult{Success: true, Data: string(content)}
+}
+
+// DeleteSchemaBackup 删除结构变更备份；id 为空时清空全部备份。
+func (a *App) DeleteSchemaBackup(id string) connection.QueryResult {
+	if strings.TrimSpace(id) == "" {
+		if err := os.RemoveAll(schemaBackupDirectory()); err != nil {
+			return connection.QueryResult{Success: false, Message: err.Error()}
+		}
+		return connection.QueryResult{Success: true, Message: "结构变更备份已清空"}
+	}
+	dir, err := schemaBackupEntryDir(id)
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	return connection.QueryResult{Success: true, Message: "已删除"}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBackupBeforeSchemaChange(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config := connection.ConnectionConfig{Type: "mysql", Host: "db"}
+	fake := &recycleFakeDB{}
+
+	if backup, warning := backupBeforeSchemaChange(fake, config, "shop", "UPDATE orders SET note = 'x'"); backup != nil || warning != "" {
+		t.Fatalf("non-DDL statement should not be backed up: %+v %q", backup, warning)
+	}
+
+	backup, warning := backupBeforeSchemaChange(fake, config, "shop", "DROP TABLE orders")
+	if backup == nil || warning != "" {
+		t.Fatalf("drop: backup=%+v warning=%q", backup, warning)
+	}
+	if len(backup.Objects) != 1 || backup.Objects[0] != "DROP TABLE shop.orders" || !strings.Contains(backup.Rollback, "CREATE TABLE `orders`") {
+		t.Fatalf("drop backup = %+v", backup)
+	}
+	if content, err := os.ReadFile(backup.BackupPath); err != nil || !strings.Contains(string(content), "CREATE TABLE `orders`") {
+		t.Fatalf("before.sql = %q, %v", content, err)
+	}
+
+	backup, _ = backupBeforeSchemaChange(fake, config, "shop", "RENAME TABLE orders TO orders_old")
+	if backup == nil || !strings.Contains(backup.Rollback, "RENAME TABLE `shop`.`orders_old` TO `shop`.`orders`;") {
+		t.Fatalf("rename backup = %+v", backup)
+	}
+
+	backup, _ = backupBeforeSchemaChange(fake, config, "shop", "ALTER TABLE orders DROP COLUMN note")
+	if backup == nil || !strings.Contains(backup.Rollback, "-- CREATE TABLE `orders`") {
+		t.Fatalf("alter backup = %+v", backup)
+	}
+
+	// 语句执行失败时不保留备份
+	res := withSchemaBackup(connection.QueryResult{Success: false}, backup, "")
+	if res.SchemaBackup != nil {
+		t.Fatal("failed result should not carry a backup")
+	}
+	if _, err := os.Stat(backup.RollbackPath); !os.IsNotExist(err) {
+		t.Fatalf("backup of failed statement should be removed: %v", err)
+	}
+
+	list := (&App{}).ListSchemaBackups()
+	if entries, _ := list.Data.([]connection.SchemaChangeBackup); len(entries) != 2 || entries[0].Rollback != "" {
+		t.Fatalf("list = %+v", list.Data)
+	}
+}
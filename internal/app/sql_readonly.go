@@ -0,0 +1,228 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/sqlstmt"
+)
+
+// 只读连接（ConnectionConfig.ReadOnly）的写操作拦截。按语句分类而不是前缀判断：脚本逐条拆分并跳过注释与字符串，
+// 识别 WITH 中的数据修改 CTE、EXPLAIN ANALYZE、SELECT ... INTO 等会落盘的读语句写法，无法识别的语句一律视为写入。
+// 调用有副作用的函数（如 SELECT nextval(...)）无法从语法上识别，由驱动在支持的数据库上开启的只读会话兜底。
+
+var sqlWriteKeywords = map[string]struct{}{
+	"INSERT": {}, "UPDATE": {}, "DELETE": {}, "MERGE": {}, "UPSERT": {}, "REPLACE": {},
+}
+
+// readOnlySessionVariables 修改后会解除会话只读状态的变量。
+var readOnlySessionVariables = map[string]struct{}{
+	"TRANSACTION_READ_ONLY": {}, "TX_READ_ONLY": {}, "DEFAULT_TRANSACTION_READ_ONLY": {},
+	"READ_ONLY": {}, "SUPER_READ_ONLY": {}, "QUERY_ONLY": {},
+}
+
+var mongoReadCommands = map[string]struct{}{
+	"find": {}, "count": {}, "distinct": {}, "listCollections": {}, "listIndexes": {}, "listDatabases": {},
+	"dbStats": {}, "collStats": {}, "serverStatus": {}, "buildInfo": {}, "ping": {}, "hello": {}, "isMaster": {},
+	"ismaster": {}, "explain": {}, "connectionStatus": {}, "hostInfo": {}, "replSetGetStatus": {},
+}
+
+// checkReadOnlySQL 只读连接执行写语句时返回错误。
+func checkReadOnlySQL(config connection.ConnectionConfig, query string) error {
+	if !config.ReadOnly {
+		return nil
+	}
+	if keyword := readOnlyViolation(resolveDDLDBType(config), query); keyword != "" {
+		return fmt.Errorf("当前连接为只读模式，已拒绝执行 %s 语句", keyword)
+	}
+	return nil
+}
+
+// ensureWritable 只读连接拒绝导入、提交修改、删除对象等写操作。action 为操作描述，如“删除表”。
+func ensureWritable(config connection.ConnectionConfig, action string) error {
+	if config.ReadOnly {
+		return fmt.Errorf("当前连接为只读模式，不允许%s", action)
+	}
+	return nil
+}
+
+// readOnlyViolation 返回脚本中第一条会修改数据或结构的语句的关键字；全部为只读语句时返回空字符串。
+func readOnlyViolation(dbType string, query string) string {
+	dbType = strings.ToLower(strings.TrimSpace(dbType))
+	if dbType == "mongodb" && strings.HasPrefix(strings.TrimSpace(query), "{") {
+		return mongoWriteCommand(query)
+	}
+	for _, stmt := range sqlstmt.Split(query, dbType) {
+		if keyword := statementWriteKeyword(dbType, tokenizeSQL(stmt)); keyword != "" {
+			return keyword
+		}
+	}
+	return ""
+}
+
+// statementWriteKeyword 对单条语句分类，写语句返回其关键字。
+func statementWriteKeyword(dbType string, tokens []sqlToken) string {
+	i := 0
+	for i < len(tokens) && tokens[i].text == "(" {
+		// (SELECT ...) UNION (SELECT ...)
+		i++
+	}
+	if i >= len(tokens) {
+		return ""
+	}
+	head, rest := tokens[i], tokens[i+1:]
+	if head.kind != sqlTokenIdent {
+		return head.text
+	}
+	switch head.upper {
+	case "SELECT", "WITH", "VALUES", "TABLE":
+		return queryWriteKeyword(tokens[i:])
+	case "SHOW", "DESCRIBE", "DESC", "HELP", "USE", "COMMIT", "ROLLBACK":
+		return ""
+	case "BEGIN", "START":
+		if dbType == "oracle" || dbType == "dameng" {
+			// PL/SQL 匿名块
+			return head.upper
+		}
+		if len(rest) > 0 {
+			switch rest[0].upper {
+			case "TRANSACTION", "TRAN", "WORK", "READ", "ISOLATION", "DEFERRED", "IMMEDIATE", "EXCLUSIVE":
+			default:
+				// T-SQL BEGIN ... END 语句块
+				return head.upper
+			}
+		}
+		if containsTokenSequence(rest, "READ", "WRITE") {
+			return head.upper + " READ WRITE"
+		}
+		return ""
+	case "EXPLAIN":
+		return explainWriteKeyword(dbType, rest)
+	case "SET":
+		return setWriteKeyword(rest)
+	case "PRAGMA":
+		for _, tok := range rest {
+			if tok.text == "=" {
+				return "PRAGMA"
+			}
+		}
+		if len(rest) > 0 {
+			switch rest[0].upper {
+			case "OPTIMIZE", "WAL_CHECKPOINT", "INCREMENTAL_VACUUM", "SHRINK_MEMORY":
+				return "PRAGMA " + rest[0].upper
+			}
+		}
+		return ""
+	}
+	return head.upper
+}
+
+// queryWriteKeyword 检查查询语句中的写入：顶层 INTO（SELECT INTO 建表、INTO OUTFILE，MySQL 的 INTO @var 除外），
+// 以及 WITH 主语句或括号内的数据修改语句（PostgreSQL 数据修改 CTE）。
+func queryWriteKeyword(tokens []sqlToken) string {
+	depth := 0
+	for i, tok := range tokens {
+		switch tok.text {
+		case "(":
+			depth++
+			continue
+		case ")":
+			depth--
+			continue
+		}
+		if tok.kind != sqlTokenIdent {
+			continue
+		}
+		var prev, next string
+		if i > 0 {
+			prev = tokens[i-1].upper
+		}
+		if i+1 < len(tokens) {
+			next = tokens[i+1].text
+		}
+		if tok.upper == "INTO" && depth == 0 && !strings.HasPrefix(next, "@") {
+			return "SELECT INTO"
+		}
+		if _, ok := sqlWriteKeywords[tok.upper]; ok && next != "(" && prev != "FOR" && (depth == 0 || prev == "(") {
+			// 后跟括号的是同名函数，如 MySQL 的 INSERT(str, pos, len, newstr)、REPLACE(...)
+			return tok.upper
+		}
+	}
+	return ""
+}
+
+// explainWriteKeyword EXPLAIN 只生成计划不执行；带 ANALYZE 时会真正执行被解释的语句，需按该语句分类。
+func explainWriteKeyword(dbType string, tokens []sqlToken) string {
+	analyze := false
+	for i, tok := range tokens {
+		switch tok.upper {
+		case "ANALYZE", "ANALYSE":
+			analyze = true
+		case "SELECT", "WITH", "VALUES", "TABLE", "INSERT", "UPDATE", "DELETE", "MERGE", "REPLACE", "CREATE", "EXECUTE":
+			if tok.kind != sqlTokenIdent || !analyze {
+				continue
+			}
+			return statementWriteKeyword(dbType, tokens[i:])
+		}
+	}
+	return ""
+}
+
+// setWriteKeyword 允许设置会话变量，拒绝修改全局变量与解除只读的设置。
+func setWriteKeyword(tokens []sqlToken) string {
+	for _, tok := range tokens {
+		name := strings.TrimLeft(tok.upper, "@")
+		switch name {
+		case "GLOBAL", "PERSIST", "PERSIST_ONLY":
+			return "SET " + name
+		}
+		if _, ok := readOnlySessionVariables[name]; ok {
+			return "SET " + name
+		}
+	}
+	if containsTokenSequence(tokens, "READ", "WRITE") {
+		return "SET READ WRITE"
+	}
+	return ""
+}
+
+func containsTokenSequence(tokens []sqlToken, words ...string) bool {
+	for i := 0; i+len(words) <= len(tokens); i++ {
+		matched := true
+		for j, word := range words {
+			if tokens[i+j].upper != word {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// mongoWriteCommand 按 JSON 命令的第一个键判断 MongoDB 命令是否只读；aggregate 含 $out/$merge 阶段时会写入集合。
+func mongoWriteCommand(query string) string {
+	dec := json.NewDecoder(strings.NewReader(query))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return "无法识别的命令"
+	}
+	tok, err := dec.Token()
+	name, ok := tok.(string)
+	if err != nil || !ok {
+		return "无法识别的命令"
+	}
+	if name == "aggregate" {
+		if strings.Contains(query, `"$out"`) || strings.Contains(query, `"$merge"`) {
+			return "aggregate（$out/$merge）"
+		}
+		return ""
+	}
+	if _, ok := mongoReadCommands[name]; ok {
+		return ""
+	}
+	return name
+}
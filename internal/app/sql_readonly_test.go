@@ -0,0 +1,63 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestReadOnlyViolation(t *testing.T) {
+	cases := []struct {
+		dbType string
+		query  string
+		want   string
+	}{
+		{"mysql", "SELECT * FROM users WHERE name = 'DELETE'", ""},
+		{"mysql", "/* report */ -- daily\nSELECT id FROM t FOR UPDATE", ""},
+		{"mysql", "SELECT REPLACE(name, 'a', 'b'), INSERT(name, 1, 2, 'x') FROM t", ""},
+		{"mysql", "SELECT COUNT(*) INTO @total FROM t", ""},
+		{"mysql", "SELECT * INTO OUTFILE '/tmp/x' FROM t", "SELECT INTO"},
+		{"mysql", "SELECT 1; DELETE FROM t", "DELETE"},
+		{"mysql", "SHOW TABLES; USE shop; SET NAMES utf8mb4", ""},
+		{"mysql", "SET GLOBAL max_connections = 10", "SET GLOBAL"},
+		{"mysql", "SET SESSION transaction_read_only = 0", "SET TRANSACTION_READ_ONLY"},
+		{"mysql", "CALL refresh_stats()", "CALL"},
+		{"mysql", "EXPLAIN ANALYZE SELECT * FROM t", ""},
+		{"postgres", "WITH recent AS (SELECT * FROM orders) SELECT * FROM recent", ""},
+		{"postgres", "WITH gone AS (DELETE FROM orders RETURNING *) SELECT count(*) FROM gone", "DELETE"},
+		{"postgres", "WITH src AS (SELECT 1 AS id) INSERT INTO t SELECT id FROM src", "INSERT"},
+		{"postgres", "EXPLAIN (ANALYZE, BUFFERS) UPDATE t SET a = 1", "UPDATE"},
+		{"postgres", "EXPLAIN UPDATE t SET a = 1", ""},
+		{"postgres", "VALUES (1), (2)", ""},
+		{"postgres", "BEGIN READ WRITE", "BEGIN READ WRITE"},
+		{"postgres", "TRUNCATE t", "TRUNCATE"},
+		{"sqlserver", "SELECT * INTO #tmp FROM t", "SELECT INTO"},
+		{"sqlserver", "BEGIN TRAN", ""},
+		{"oracle", "BEGIN DBMS_STATS.GATHER_TABLE_STATS('A', 'T'); END;", "BEGIN"},
+		{"sqlite", "PRAGMA table_info(t)", ""},
+		{"sqlite", "PRAGMA journal_mode = WAL", "PRAGMA"},
+		{"mongodb", `{"find": "users", "filter": {"name": "insert"}}`, ""},
+		{"mongodb", `{"aggregate": "users", "pipeline": [{"$out": "copy"}]}`, "aggregate（$out/$merge）"},
+		{"mongodb", `{"delete": "users", "deletes": []}`, "delete"},
+	}
+	for _, c := range cases {
+		if got := readOnlyViolation(c.dbType, c.query); got != c.want {
+			t.Errorf("readOnlyViolation(%s, %q) = %q, want %q", c.dbType, c.query, got, c.want)
+		}
+	}
+}
+
+func TestReadOnlyConnectionGuards(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, ReadOnly: true}
+	if res := a.DBQuery(config, "shop", "UPDATE users SET name = 'x'"); res.Success || !strings.Contains(res.Message, "只读") {
+		t.Fatalf("只读连接应拒绝 UPDATE：%+v", res)
+	}
+	if res := a.ApplyChanges(config, "shop", "users", connection.ChangeSet{}); res.Success || !strings.Contains(res.Message, "只读") {
+		t.Fatalf("只读连接应拒绝提交修改：%+v", res)
+	}
+	if _, err := a.registerSQLPlan(SQLPlanRequest{Config: config, Statements: []string{"ALTER TABLE users ADD c INT"}}, nil); err == nil {
+		t.Fatal("只读连接不应登记 DDL 计划")
+	}
+}
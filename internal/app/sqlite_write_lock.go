@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/utils"
+)
+
+// sqliteWriteLocks 按数据库文件在应用内串行化 SQLite 写语句。SQLite 同一时刻只允许一个写入者，
+// 多个标签页同时提交时在应用内排队，等待受语句超时约束，而不是各自撞上 database is locked。
+type sqliteWriteLocks struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+func newSQLiteWriteLocks() *sqliteWriteLocks {
+	return &sqliteWriteLocks{locks: make(map[string]chan struct{})}
+}
+
+// sqliteLockKey 返回 SQLite 连接对应的数据库文件；非 SQLite 连接与内存库返回空串。
+func sqliteLockKey(config connection.ConnectionConfig) string {
+	if resolveDDLDBType(config) != "sqlite" || strings.TrimSpace(config.DSN) != "" {
+		return ""
+	}
+	path := strings.TrimSpace(config.Host)
+	if path == "" {
+		path = strings.TrimSpace(config.Database)
+	}
+	if path == "" || strings.EqualFold(path, ":memory:") {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return filepath.Clean(path)
+}
+
+// acquire 等待写锁，ctx 结束时放弃等待。非 SQLite 连接直接返回空操作的释放函数。
+func (l *sqliteWriteLocks) acquire(ctx context.Context, config connection.ConnectionConfig) (func(), error) {
+	key := sqliteLockKey(config)
+	if l == nil || key == "" {
+		return func() {}, nil
+	}
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		l.locks[key] = lock
+	}
+	l.mu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("等待 SQLite 写锁超时，其他标签页的写入仍在进行：%w", ctx.Err())
+	}
+}
+
+// lockSQLiteWrite 语句会修改数据时获取所在 SQLite 文件的写锁；只读语句不排队。
+func (a *App) lockSQLiteWrite(ctx context.Context, config connection.ConnectionConfig, query string) (func(), error) {
+	if sqliteLockKey(config) == "" || readOnlyViolation(config.Type, query) == "" {
+		return func() {}, nil
+	}
+	return a.sqliteLocks.acquire(ctx, config)
+}
+
+// withSQLiteWriteLock 在写锁内执行 fn，等待时间不超过连接超时。用于数据编辑提交、导入等没有语句上下文的写入。
+func (a *App) withSQLiteWriteLock(config connection.ConnectionConfig, fn func() error) error {
+	timeoutSeconds := config.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 30
+	}
+	ctx, cancel := utils.ContextWithTimeout(time.Duration(timeoutSeconds) * time.Second)
+	defer cancel()
+	unlock, err := a.sqliteLocks.acquire(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return fn()
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestSQLiteWriteLockSerializesWrites(t *testing.T) {
+	a := NewApp()
+	config := connection.ConnectionConfig{Type: "sqlite", Host: "/tmp/demo.sqlite"}
+
+	unlock, err := a.lockSQLiteWrite(context.Background(), config, "UPDATE t SET a = 1")
+	if err != nil {
+		t.Fatalf("获取写锁失败: %v", err)
+	}
+
+	// 读语句、其他文件与非 SQLite 连接不受影响
+	for _, tc := range []struct {
+		config connection.ConnectionConfig
+		query  string
+	}{
+		{config, "SELECT * FROM t"},
+		{connection.ConnectionConfig{Type: "sqlite", Host: "/tmp/other.sqlite"}, "DELETE FROM t"},
+		{connection.ConnectionConfig{Type: "sqlite", Host: ":memory:"}, "DELETE FROM t"},
+		{connection.ConnectionConfig{Type: "mysql", Host: "/tmp/demo.sqlite"}, "DELETE FROM t"},
+	} {
+		release, err := a.lockSQLiteWrite(context.Background(), tc.config, tc.query)
+		if err != nil {
+			t.Fatalf("%s %q 不应等待写锁: %v", tc.config.Host, tc.query, err)
+		}
+		release()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := a.lockSQLiteWrite(ctx, config, "INSERT INTO t VALUES (1)"); err == nil {
+		t.Fatalf("写锁被占用时期望等待超时")
+	}
+
+	unlock()
+	release, err := a.lockSQLiteWrite(context.Background(), connection.ConnectionConfig{Type: "sqlite", Database: "/tmp/../tmp/demo.sqlite"}, "INSERT INTO t VALUES (1)")
+	if err != nil {
+		t.Fatalf("释放后获取写锁失败: %v", err)
+	}
+	release()
+}
@@ -0,0 +1,293 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/db"
+	"GoNavi-Wails/internal/logger"
+)
+
+const defaultStatusServerAddr = "127.0.0.1:17380"
+
+// StatusServerSettings 本地监控端点设置，保存在 ~/.gonavi/status_server.json。
+// 开启后在本机地址提供 /healthz 与 /metrics（Prometheus 文本格式），便于把长期运行的 GoNavi 作为查询网关时做存活检查与监控。
+type StatusServerSettings struct {
+	Enabled bool   `json:"enabled"`
+	Addr    string `json:"addr,omitempty"` // 监听地址，仅允许回环地址，默认 127.0.0.1:17380
+}
+
+// statusServer 运行中的监控端点。
+type statusServer struct {
+	srv  *http.Server
+	addr string
+}
+
+var (
+	statusServerSettingsMu sync.RWMutex
+	statusServerSettings   *StatusServerSettings // 首次使用时从磁盘加载
+)
+
+func statusServerSettingsPath() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "status_server.json")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-status_server.json")
+}
+
+// withDefaults 未设置的监听地址回落到默认值。
+func (s StatusServerSettings) withDefaults() StatusServerSettings {
+	s.Addr = strings.TrimSpace(s.Addr)
+	if s.Addr == "" {
+		s.Addr = defaultStatusServerAddr
+	}
+	return s
+}
+
+// currentStatusServerSettings 返回生效中的监控端点设置；配置文件损坏时按默认值（关闭）处理。
+func currentStatusServerSettings() StatusServerSettings {
+	statusServerSettingsMu.RLock()
+	loaded := statusServerSettings
+	statusServerSettingsMu.RUnlock()
+	if loaded != nil {
+		return loaded.withDefaults()
+	}
+
+	settings := StatusServerSettings{}
+	if content, err := os.ReadFile(statusServerSettingsPath()); err == nil && len(strings.TrimSpace(string(content))) > 0 {
+		if err := json.Unmarshal(content, &settings); err != nil {
+			logger.Warnf("解析监控端点设置失败，使用默认值：%v", err)
+			settings = StatusServerSettings{}
+		}
+	}
+	statusServerSettingsMu.Lock()
+	if statusServerSettings == nil {
+		statusServerSettings = &settings
+	}
+	settings = *statusServerSettings
+	statusServerSettingsMu.Unlock()
+	return settings.withDefaults()
+}
+
+// validateStatusServerAddr 监控端点不做鉴权，只允许监听回环地址。
+func validateStatusServerAddr(addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || port == "" {
+		return fmt.Errorf("监听地址格式应为 主机:端口，例如 %s", defaultStatusServerAddr)
+	}
+	if strings.EqualFold(host, "localhost") {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("监控端点没有鉴权，只允许监听本机回环地址：%s", host)
+}
+
+// startStatusServer 按设置启动监控端点；已在运行时先停止旧实例。
+func (a *App) startStatusServer(settings StatusServerSettings) error {
+	a.stopStatusServer()
+	settings = settings.withDefaults()
+	if !settings.Enabled {
+		return nil
+	}
+	if err := validateStatusServerAddr(settings.Addr); err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", settings.Addr)
+	if err != nil {
+		return fmt.Errorf("监听 %s 失败：%w", settings.Addr, err)
+	}
+
+	// 同时接受 HTTP/1.1 与明文 HTTP/2（h2c），探针和采集器可任选
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{
+		Handler:           a.statusHandler(),
+		Protocols:         protocols,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	a.statusMu.Lock()
+	a.status = &statusServer{srv: srv, addr: listener.Addr().String()}
+	a.statusMu.Unlock()
+
+	go func() {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "监控端点异常退出：%s", settings.Addr)
+		}
+	}()
+	logger.Infof("监控端点已启动：http://%s/healthz", listener.Addr().String())
+	return nil
+}
+
+func (a *App) stopStatusServer() {
+	a.statusMu.Lock()
+	running := a.status
+	a.status = nil
+	a.statusMu.Unlock()
+	if running == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := running.srv.Shutdown(ctx); err != nil {
+		logger.Warnf("关闭监控端点失败：%v", err)
+	}
+	logger.Infof("监控端点已关闭：%s", running.addr)
+}
+
+func (a *App) statusHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":        "ok",
+			"version":       getCurrentVersion(),
+			"uptimeSeconds": int64(a.uptime().Seconds()),
+		})
+	})
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write([]byte(a.renderMetrics()))
+	})
+	return mux
+}
+
+func (a *App) uptime() time.Duration {
+	if a.startedAt.IsZero() {
+		return 0
+	}
+	return time.Since(a.startedAt)
+}
+
+// renderMetrics 以 Prometheus 文本格式输出运行指标；只读取计数，不访问数据库。
+func (a *App) renderMetrics() string {
+	openByType := map[string]int{}
+	a.mu.RLock()
+	for _, entry := range a.dbCache {
+		if entry.inst == nil {
+			continue
+		}
+		dbType := strings.ToLower(strings.TrimSpace(entry.config.Type))
+		if dbType == "" {
+			dbType = "unknown"
+		}
+		openByType[dbType]++
+	}
+	pendingConnects := len(a.connecting)
+	a.mu.RUnlock()
+
+	a.sessionMu.Lock()
+	pinnedSessions := len(a.tabSessions)
+	a.sessionMu.Unlock()
+
+	a.queryMu.Lock()
+	runningQueries := len(a.runningQueries)
+	a.queryMu.Unlock()
+
+	a.payloadMu.Lock()
+	payloads := len(a.payloads)
+	payloadBytes := 0
+	for _, payload := range a.payloads {
+		payloadBytes += len(payload.data)
+	}
+	a.payloadMu.Unlock()
+
+	var workspaceBytes int64
+	for _, usage := range a.tempWorkspaces.usage() {
+		workspaceBytes += usage.UsedBytes
+	}
+
+	var b strings.Builder
+	gauge := func(name, help string, value interface{}) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", name, help, name, name, value)
+	}
+	gauge("gonavi_up", "Whether the GoNavi instance is running.", 1)
+	gauge("gonavi_uptime_seconds", "Seconds since the application started.", int64(a.uptime().Seconds()))
+
+	b.WriteString("# HELP gonavi_open_connections Cached database connections by driver type.\n# TYPE gonavi_open_connections gauge\n")
+	types := make([]string, 0, len(openByType))
+	for dbType := range openByType {
+		types = append(types, dbType)
+	}
+	sort.Strings(types)
+	for _, dbType := range types {
+		fmt.Fprintf(&b, "gonavi_open_connections{type=%q} %d\n", dbType, openByType[dbType])
+	}
+
+	gauge("gonavi_pending_connects", "Connections currently being established.", pendingConnects)
+	gauge("gonavi_pinned_sessions", "Editor tabs holding a dedicated session.", pinnedSessions)
+	gauge("gonavi_running_queries", "Queries currently executing.", runningQueries)
+	gauge("gonavi_driver_agent_processes", "Running driver agent processes.", db.DriverAgentProcessCount())
+	gauge("gonavi_result_payloads", "Compressed results waiting to be fetched.", payloads)
+	gauge("gonavi_result_payload_bytes", "Total size of compressed results waiting to be fetched.", payloadBytes)
+	gauge("gonavi_temp_workspace_bytes", "Bytes used by per-connection temp workspaces.", workspaceBytes)
+	gauge("go_goroutines", "Number of goroutines that currently exist.", runtime.NumGoroutine())
+	return b.String()
+}
+
+// GetStatusServerSettings 返回本地监控端点设置与当前运行状态。
+func (a *App) GetStatusServerSettings() connection.QueryResult {
+	settings := currentStatusServerSettings()
+	a.statusMu.Lock()
+	running := ""
+	if a.status != nil {
+		running = a.status.addr
+	}
+	a.statusMu.Unlock()
+	return connection.QueryResult{Success: true, Data: map[string]interface{}{
+		"enabled":     settings.Enabled,
+		"addr":        settings.Addr,
+		"running":     running != "",
+		"runningAddr": running,
+	}}
+}
+
+// SaveStatusServerSettings 保存监控端点设置并立即按新设置启停。
+func (a *App) SaveStatusServerSettings(settings StatusServerSettings) connection.QueryResult {
+	settings = settings.withDefaults()
+	if err := validateStatusServerAddr(settings.Addr); err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+
+	path := statusServerSettingsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("创建配置目录失败：%v", err)}
+	}
+	content, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("保存监控端点设置失败：%v", err)}
+	}
+	statusServerSettingsMu.Lock()
+	saved := settings
+	statusServerSettings = &saved
+	statusServerSettingsMu.Unlock()
+
+	if err := a.startStatusServer(settings); err != nil {
+		logger.Error(err, "启动监控端点失败")
+		return connection.QueryResult{Success: false, Message: fmt.Sprintf("设置已保存，但启动监控端点失败：%v", err)}
+	}
+	message := "监控端点已关闭"
+	if settings.Enabled {
+		message = "监控端点已启动"
+	}
+	return connection.QueryResult{Success: true, Message: message, Data: settings}
+}
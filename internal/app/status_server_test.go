@@ -0,0 +1,71 @@
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestStatusHandler(t *testing.T) {
+	a := NewApp()
+	a.startedAt = time.Now().Add(-time.Minute)
+	config := normalizeRunConfig(connection.ConnectionConfig{Type: "postgres", Host: "pg", Port: 5432}, "shop")
+	a.dbCache[getCacheKey(config)] = cachedDatabase{inst: &poolFakeDB{}, lastPing: time.Now(), config: config}
+	a.runningQueries["q1"] = &runningQuery{id: "q1"}
+	a.payloads["p1"] = &resultPayload{data: make([]byte, 42)}
+
+	srv := httptest.NewServer(a.statusHandler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var health map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if health["status"] != "ok" || health["uptimeSeconds"].(float64) < 59 {
+		t.Fatalf("healthz = %v", health)
+	}
+
+	resp, err = http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	for _, line := range []string{
+		"gonavi_up 1",
+		`gonavi_open_connections{type="postgres"} 1`,
+		"gonavi_running_queries 1",
+		"gonavi_result_payload_bytes 42",
+		"# TYPE gonavi_driver_agent_processes gauge",
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Fatalf("metrics missing %q:\n%s", line, body)
+		}
+	}
+}
+
+func TestValidateStatusServerAddr(t *testing.T) {
+	for addr, ok := range map[string]bool{
+		"127.0.0.1:17380": true,
+		"localhost:9000":  true,
+		"[::1]:9000":      true,
+		"0.0.0.0:17380":   false,
+		"10.0.0.5:17380":  false,
+		"17380":           false,
+	} {
+		if err := validateStatusServerAddr(addr); (err == nil) != ok {
+			t.Fatalf("%s: err = %v", addr, err)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	tempWorkspaceConnectionQuota = 512 << 20 // 单个连接的临时文件上限
+	tempWorkspaceTotalQuota      = 2 << 30   // 全部连接合计上限
+	tempWorkspaceStaleAfter      = 24 * time.Hour
+)
+
+var errTempWorkspaceQuota = errors.New("临时工作区空间不足")
+
+// TempWorkspaceUsage 单个连接临时工作区的占用情况。
+type TempWorkspaceUsage struct {
+	Connection string `json:"connection"` // 连接摘要，仅用于展示
+	Dir        string `json:"dir"`
+	Files      int    `json:"files"`
+	UsedBytes  int64  `json:"usedBytes"`
+	QuotaBytes int64  `json:"quotaBytes"`
+}
+
+// tempWorkspaceManager 按连接管理中间产物（落盘结果、导出预览、BLOB 下载等）所在的临时目录。
+// 目录位于 <系统临时目录>/gonavi-workspace/<进程号>，断开连接时删除对应子目录，应用退出时整体删除。
+type tempWorkspaceManager struct {
+	mu              sync.Mutex
+	root            string
+	connectionQuota int64
+	totalQuota      int64
+	totalUsed       int64
+	spaces          map[string]*tempWorkspace
+}
+
+type tempWorkspace struct {
+	label string
+	dir   string
+	used  int64
+	files map[string]int64 // 文件路径 -> 已计入的字节数
+}
+
+func tempWorkspaceBaseDir() string {
+	return filepath.Join(os.TempDir(), "gonavi-workspace")
+}
+
+func newTempWorkspaceManager(root string) *tempWorkspaceManager {
+	if strings.TrimSpace(root) == "" {
+		root = filepath.Join(tempWorkspaceBaseDir(), strconv.Itoa(os.Getpid()))
+	}
+	return &tempWorkspaceManager{
+		root:            root,
+		connectionQuota: tempWorkspaceConnectionQuota,
+		totalQuota:      tempWorkspaceTotalQuota,
+		spaces:          make(map[string]*tempWorkspace),
+	}
+}
+
+// tempWorkspaceKey 同一连接切换数据库时共用一个工作区。
+func tempWorkspaceKey(config connection.ConnectionConfig) string {
+	config.Database = ""
+	return getCacheKey(applyCustomDriverType(config))
+}
+
+// createFile 在连接的工作区中创建临时文件，写入量计入配额，超出配额时写入失败。
+func (m *tempWorkspaceManager) createFile(config connection.ConnectionConfig, pattern string) (*tempWorkspaceFile, error) {
+	key := tempWorkspaceKey(config)
+	m.mu.Lock()
+	space, ok := m.spaces[key]
+	if !ok {
+		space = &tempWorkspace{
+			label: formatConnSummary(config),
+			dir:   filepath.Join(m.root, key[:16]),
+			files: make(map[string]int64),
+		}
+		m.spaces[key] = space
+	}
+	dir := space.dir
+	m.mu.Unlock()
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("创建临时工作区失败：%w", err)
+	}
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("创建临时文件失败：%w", err)
+	}
+	m.mu.Lock()
+	if m.spaces[key] == space {
+		space.files[f.Name()] = 0
+	}
+	m.mu.Unlock()
+	return &tempWorkspaceFile{File: f, manager: m, key: key}, nil
+}
+
+// reserve 为即将写入的 n 字节占用配额。
+func (m *tempWorkspaceManager) reserve(key, path string, n int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	space, ok := m.spaces[key]
+	if !ok {
+		return fmt.Errorf("临时工作区已释放")
+	}
+	if space.used+n > m.connectionQuota {
+		return fmt.Errorf("%w：当前连接已使用 %s，上限 %s", errTempWorkspaceQuota, formatByteSize(space.used), formatByteSize(m.connectionQuota))
+	}
+	if m.totalUsed+n > m.totalQuota {
+		return fmt.Errorf("%w：全部连接已使用 %s，上限 %s", errTempWorkspaceQuota, formatByteSize(m.totalUsed), formatByteSize(m.totalQuota))
+	}
+	space.used += n
+	space.files[path] += n
+	m.totalUsed += n
+	return nil
+}
+
+func (m *tempWorkspaceManager) unreserve(key, path string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	space, ok := m.spaces[key]
+	if !ok || n <= 0 {
+		return
+	}
+	if _, tracked := space.files[path]; tracked {
+		space.files[path] -= n
+	}
+	space.used -= n
+	m.totalUsed -= n
+}
+
+// removeFile 删除工作区中的文件并归还其占用的配额。
+func (m *tempWorkspaceManager) removeFile(config connection.ConnectionConfig, path string) {
+	key := tempWorkspaceKey(config)
+	m.mu.Lock()
+	if space, ok := m.spaces[key]; ok {
+		if size, tracked := space.files[path]; tracked {
+			space.used -= size
+			m.totalUsed -= size
+			delete(space.files, path)
+		}
+	}
+	m.mu.Unlock()
+	_ = os.Remove(path)
+}
+
+// release 删除连接的工作区目录。
+func (m *tempWorkspaceManager) release(config connection.ConnectionConfig) (int, int64) {
+	if m == nil {
+		return 0, 0
+	}
+	key := tempWorkspaceKey(config)
+	m.mu.Lock()
+	space, ok := m.spaces[key]
+	if ok {
+		delete(m.spaces, key)
+		m.totalUsed -= space.used
+	}
+	m.mu.Unlock()
+	if !ok {
+		return 0, 0
+	}
+	if err := os.RemoveAll(space.dir); err != nil {
+		logger.Warnf("清理临时工作区失败：%s 目录=%s：%v", space.label, space.dir, err)
+	}
+	return len(space.files), space.used
+}
+
+// releaseAll 删除本进程的全部工作区，应用退出时调用。
+func (m *tempWorkspaceManager) releaseAll() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.spaces = make(map[string]*tempWorkspace)
+	m.totalUsed = 0
+	m.mu.Unlock()
+	if err := os.RemoveAll(m.root); err != nil {
+		logger.Warnf("清理临时工作区失败：目录=%s：%v", m.root, err)
+	}
+}
+
+// sweepStale 删除其它进程遗留且超过 tempWorkspaceStaleAfter 未修改的工作区（例如上次异常退出）。
+func (m *tempWorkspaceManager) sweepStale(now time.Time) int {
+	if m == nil {
+		return 0
+	}
+	base := filepath.Dir(m.root)
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return 0
+	}
+	removed := 0
+	for _, entry := range entries {
+		path := filepath.Join(base, entry.Name())
+		if !entry.IsDir() || path == m.root {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < tempWorkspaceStaleAfter {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			logger.Warnf("清理遗留临时工作区失败：目录=%s：%v", path, err)
+			continue
+		}
+		removed++
+	}
+	return removed
+}
+
+func (m *tempWorkspaceManager) usage() []TempWorkspaceUsage {
+	if m == nil {
+		return []TempWorkspaceUsage{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	items := make([]TempWorkspaceUsage, 0, len(m.spaces))
+	for _, space := range m.spaces {
+		items = append(items, TempWorkspaceUsage{
+			Connection: space.label,
+			Dir:        space.dir,
+			Files:      len(space.files),
+			UsedBytes:  space.used,
+			QuotaBytes: m.connectionQuota,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].UsedBytes > items[j].UsedBytes })
+	return items
+}
+
+// tempWorkspaceFile 写入时按字节计入所属工作区的配额。
+type tempWorkspaceFile struct {
+	*os.File
+	manager *tempWorkspaceManager
+	key     string
+}
+
+func (f *tempWorkspaceFile) Write(p []byte) (int, error) {
+	if err := f.manager.reserve(f.key, f.Name(), int64(len(p))); err != nil {
+		return 0, err
+	}
+	n, err := f.File.Write(p)
+	f.manager.unreserve(f.key, f.Name(), int64(len(p)-n))
+	return n, err
+}
+
+func (f *tempWorkspaceFile) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
+
+func formatByteSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.2f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// GetTempWorkspaceUsage 返回各连接临时工作区的占用情况。
+func (a *App) GetTempWorkspaceUsage() connection.QueryResult {
+	return connection.QueryResult{Success: true, Data: a.tempWorkspaces.usage()}
+}
+
+// ReleaseTempWorkspace 删除连接的临时工作区，前端断开连接时调用。
+func (a *App) ReleaseTempWorkspace(config connection.ConnectionConfig) connection.QueryResult {
+	files, used := a.tempWorkspaces.release(config)
+	if files > 0 {
+		logger.Infof("已清理临时工作区：%s 文件=%d 大小=%s", formatConnSummary(config), files, formatByteSize(used))
+	}
+	return connection.QueryResult{Success: true, Data: map[string]interface{}{"files": files, "bytes": used}}
+}
+
+// SaveBinaryValueToWorkspace 把单元格中的二进制值（0x 十六进制或原始文本）写入连接的临时工作区，
+// 返回文件路径供前端预览或另存；文件随断开连接一并删除。
+func (a *App) SaveBinaryValueToWorkspace(config connection.ConnectionConfig, value string, nameHint string) connection.QueryResult {
+	if a.tempWorkspaces == nil {
+		return connection.QueryResult{Success: false, Message: "临时工作区未初始化"}
+	}
+	content, _ := binaryValueBytes(value)
+	base := strings.Trim(sidecarUnsafeChars.ReplaceAllString(strings.TrimSpace(nameHint), "_"), "_")
+	if base == "" {
+		base = "value"
+	}
+	f, err := a.tempWorkspaces.createFile(config, base+"-*"+sidecarExtension(content))
+	if err != nil {
+		return connection.QueryResult{Success: false, Message: err.Error()}
+	}
+	_, writeErr := f.Write(content)
+	closeErr := f.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		a.tempWorkspaces.removeFile(config, f.Name())
+		return connection.QueryResult{Success: false, Message: writeErr.Error()}
+	}
+	return connection.QueryResult{Success: true, Data: map[string]interface{}{"path": f.Name(), "size": len(content)}}
+}
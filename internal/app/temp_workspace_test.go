@@ -0,0 +1,113 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestTempWorkspaceQuotaAndRelease(t *testing.T) {
+	m := newTempWorkspaceManager(filepath.Join(t.TempDir(), "1234"))
+	m.connectionQuota = 10
+	m.totalQuota = 15
+	mysqlConfig := connection.ConnectionConfig{Type: "mysql", Host: "db1", Port: 3306, Database: "shop"}
+	pgConfig := connection.ConnectionConfig{Type: "postgres", Host: "db2", Port: 5432}
+
+	f, err := m.createFile(mysqlConfig, "blob-*.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("12345678")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("123")); !errors.Is(err, errTempWorkspaceQuota) {
+		t.Fatalf("expected connection quota error, got %v", err)
+	}
+	f.Close()
+
+	// 切换数据库仍是同一连接的工作区
+	mysqlConfig.Database = "other"
+	g, err := m.createFile(mysqlConfig, "preview-*.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Close()
+	if usage := m.usage(); len(usage) != 1 || usage[0].Files != 2 || usage[0].UsedBytes != 8 {
+		t.Fatalf("usage = %+v", usage)
+	}
+
+	h, err := m.createFile(pgConfig, "spill-*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.WriteString("12345678"); !errors.Is(err, errTempWorkspaceQuota) {
+		t.Fatalf("expected total quota error, got %v", err)
+	}
+	h.Close()
+
+	m.removeFile(mysqlConfig, f.Name())
+	if _, err := os.Stat(f.Name()); !os.IsNotExist(err) {
+		t.Fatalf("file should be removed, stat err = %v", err)
+	}
+	if files, used := m.release(mysqlConfig); files != 1 || used != 0 {
+		t.Fatalf("release = %d files %d bytes", files, used)
+	}
+	if _, err := os.Stat(filepath.Dir(g.Name())); !os.IsNotExist(err) {
+		t.Fatal("workspace dir should be removed")
+	}
+	if m.totalUsed != 0 {
+		t.Fatalf("totalUsed = %d", m.totalUsed)
+	}
+
+	m.releaseAll()
+	if _, err := os.Stat(m.root); !os.IsNotExist(err) {
+		t.Fatal("root should be removed")
+	}
+}
+
+func TestTempWorkspaceSweepStale(t *testing.T) {
+	base := t.TempDir()
+	m := newTempWorkspaceManager(filepath.Join(base, "100"))
+	old := time.Now().Add(-48 * time.Hour)
+	for _, name := range []string{"100", "200", "300"} {
+		if err := os.MkdirAll(filepath.Join(base, name), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if name != "300" {
+			os.Chtimes(filepath.Join(base, name), old, old)
+		}
+	}
+	if removed := m.sweepStale(time.Now()); removed != 1 {
+		t.Fatalf("removed = %d", removed)
+	}
+	entries, _ := os.ReadDir(base)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, ",") != "100,300" {
+		t.Fatalf("remaining = %v", names)
+	}
+}
+
+func TestSaveBinaryValueToWorkspace(t *testing.T) {
+	a := &App{tempWorkspaces: newTempWorkspaceManager(filepath.Join(t.TempDir(), "1"))}
+	config := connection.ConnectionConfig{Type: "sqlite", Host: "/tmp/a.db"}
+	res := a.SaveBinaryValueToWorkspace(config, "0x89504e470d0a1a0a0000000d49484452", "avatar/1")
+	if !res.Success {
+		t.Fatal(res.Message)
+	}
+	path := res.Data.(map[string]interface{})["path"].(string)
+	if !strings.HasPrefix(filepath.Base(path), "avatar_1-") || filepath.Ext(path) != ".png" {
+		t.Fatalf("path = %s", path)
+	}
+	a.ReleaseTempWorkspace(config)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("file should be removed with workspace")
+	}
+}
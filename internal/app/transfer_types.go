@@ -0,0 +1,413 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+// transferColumnType 跨库传输时源列类型归一化后的通用类型。
+type transferColumnType struct {
+	kind      string // bool/int8/int16/int32/int64/decimal/float32/float64/char/varchar/text/blob/date/time/datetime/timestamptz/json/uuid
+	length    int    // char/varchar 的长度，0 表示未指定
+	precision int    // decimal 的精度，0 表示未指定
+	scale     int
+}
+
+var transferTypeArgsPattern = regexp.MustCompile(`\(\s*(\d+|max)\s*(?:,\s*(\d+)\s*)?\)`)
+
+// transferTypeFamily 类型写法可以互通的数据库归为一族，同族之间直接沿用源类型。
+func transferTypeFamily(dbType string) string {
+	switch dbType {
+	case "mysql", "mariadb", "diros":
+		return "mysql"
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return "postgres"
+	}
+	return dbType
+}
+
+// parseTransferColumnType 把源库的列类型归一为通用类型；无法识别时返回 false，调用方按大文本处理。
+func parseTransferColumnType(dbType string, raw string) (transferColumnType, bool) {
+	lower := strings.ToLower(strings.TrimSpace(raw))
+	if dbType == "clickhouse" {
+		for _, wrapper := range []string{"nullable(", "lowcardinality("} {
+			for strings.HasPrefix(lower, wrapper) && strings.HasSuffix(lower, ")") {
+				lower = strings.TrimSpace(lower[len(wrapper) : len(lower)-1])
+			}
+		}
+	}
+	unsigned := strings.Contains(lower, "unsigned")
+	var length, scale int
+	if m := transferTypeArgsPattern.FindStringSubmatch(lower); m != nil {
+		if m[1] == "max" {
+			length = -1
+		} else {
+			length, _ = strconv.Atoi(m[1])
+		}
+		scale, _ = strconv.Atoi(m[2])
+	}
+	base := lower
+	if idx := strings.IndexAny(base, "("); idx >= 0 {
+		base = base[:idx] + base[strings.LastIndex(base, ")")+1:]
+	}
+	base = strings.Join(strings.Fields(strings.NewReplacer("unsigned", "", "zerofill", "").Replace(base)), " ")
+
+	integer := func(kind string) (transferColumnType, bool) {
+		if unsigned {
+			// 无符号整数在不支持无符号的库中需要更宽的类型
+			switch kind {
+			case "int8":
+				kind = "int16"
+			case "int16":
+				kind = "int32"
+			case "int32":
+				kind = "int64"
+			case "int64":
+				return transferColumnType{kind: "decimal", precision: 20}, true
+			}
+		}
+		return transferColumnType{kind: kind}, true
+	}
+
+	if dbType == "clickhouse" {
+		switch base {
+		case "int8":
+			return integer("int8")
+		case "uint8":
+			return transferColumnType{kind: "int16"}, true
+		case "int16":
+			return integer("int16")
+		case "uint16":
+			return transferColumnType{kind: "int32"}, true
+		case "int32":
+			return integer("int32")
+		case "uint32":
+			return transferColumnType{kind: "int64"}, true
+		case "int64":
+			return integer("int64")
+		case "uint64":
+			return transferColumnType{kind: "decimal", precision: 20}, true
+		case "float32":
+			return transferColumnType{kind: "float32"}, true
+		case "float64":
+			return transferColumnType{kind: "float64"}, true
+		case "string":
+			return transferColumnType{kind: "text"}, true
+		case "fixedstring":
+			return transferColumnType{kind: "char", length: length}, true
+		case "date32":
+			return transferColumnType{kind: "date"}, true
+		case "datetime64":
+			return transferColumnType{kind: "datetime"}, true
+		}
+	}
+
+	switch base {
+	case "bool", "boolean":
+		return transferColumnType{kind: "bool"}, true
+	case "bit":
+		if dbType == "sqlserver" || length <= 1 {
+			return transferColumnType{kind: "bool"}, true
+		}
+		return transferColumnType{kind: "int64"}, true
+	case "tinyint":
+		if transferTypeFamily(dbType) == "mysql" && length == 1 {
+			return transferColumnType{kind: "bool"}, true
+		}
+		if dbType == "sqlserver" {
+			// SQL Server 的 tinyint 为 0~255
+			return transferColumnType{kind: "int16"}, true
+		}
+		return integer("int8")
+	case "smallint", "int2", "year", "smallserial":
+		return integer("int16")
+	case "mediumint", "int", "integer", "int4", "serial":
+		if dbType == "sqlite" {
+			return transferColumnType{kind: "int64"}, true
+		}
+		return integer("int32")
+	case "bigint", "bigserial":
+		return integer("int64")
+	case "int8":
+		if transferTypeFamily(dbType) == "postgres" {
+			return integer("int64")
+		}
+		return integer("int8")
+	case "decimal", "numeric", "dec", "number":
+		if dbType == "oracle" && length > 0 && scale == 0 {
+			switch {
+			case length <= 4:
+				return transferColumnType{kind: "int16"}, true
+			case length <= 9:
+				return transferColumnType{kind: "int32"}, true
+			case length <= 18:
+				return transferColumnType{kind: "int64"}, true
+			}
+		}
+		return transferColumnType{kind: "decimal", precision: max(length, 0), scale: scale}, true
+	case "money", "smallmoney":
+		return transferColumnType{kind: "decimal", precision: 19, scale: 4}, true
+	case "real", "float4", "binary_float":
+		return transferColumnType{kind: "float32"}, true
+	case "float":
+		if transferTypeFamily(dbType) == "mysql" && (length == 0 || length > 24) && scale == 0 {
+			return transferColumnType{kind: "float32"}, true
+		}
+		return transferColumnType{kind: "float64"}, true
+	case "double", "double precision", "float8", "binary_double":
+		return transferColumnType{kind: "float64"}, true
+	case "char", "nchar", "character", "bpchar":
+		return transferColumnType{kind: "char", length: max(length, 1)}, true
+	case "varchar", "nvarchar", "varchar2", "nvarchar2", "character varying", "varying character":
+		if length < 0 {
+			return transferColumnType{kind: "text"}, true
+		}
+		return transferColumnType{kind: "varchar", length: length}, true
+	case "enum", "set":
+		return transferColumnType{kind: "varchar", length: 255}, true
+	case "text", "tinytext", "mediumtext", "longtext", "clob", "nclob", "ntext", "citext", "long", "string", "xml":
+		return transferColumnType{kind: "text"}, true
+	case "binary", "varbinary", "bytea", "blob", "tinyblob", "mediumblob", "longblob", "raw", "long raw", "image":
+		return transferColumnType{kind: "blob"}, true
+	case "date":
+		if dbType == "oracle" {
+			// Oracle 的 DATE 精确到秒
+			return transferColumnType{kind: "datetime"}, true
+		}
+		return transferColumnType{kind: "date"}, true
+	case "time", "time without time zone":
+		return transferColumnType{kind: "time"}, true
+	case "datetime", "datetime2", "smalldatetime", "timestamp", "timestamp without time zone":
+		return transferColumnType{kind: "datetime"}, true
+	case "timestamptz", "timestamp with time zone", "timestamp with local time zone", "datetimeoffset":
+		return transferColumnType{kind: "timestamptz"}, true
+	case "json", "jsonb":
+		return transferColumnType{kind: "json"}, true
+	case "uuid", "uniqueidentifier":
+		return transferColumnType{kind: "uuid"}, true
+	}
+	return transferColumnType{kind: "text"}, false
+}
+
+// renderTransferColumnType 生成目标库的列类型。
+func renderTransferColumnType(dbType string, t transferColumnType) (string, error) {
+	decimal := func(maxPrecision int, fallback string) string {
+		if t.precision <= 0 {
+			return fallback
+		}
+		p := min(t.precision, maxPrecision)
+		return fmt.Sprintf("DECIMAL(%d,%d)", p, min(t.scale, p))
+	}
+	switch transferTypeFamily(dbType) {
+	case "mysql":
+		switch t.kind {
+		case "varchar":
+			if t.length == 0 {
+				return "VARCHAR(255)", nil
+			}
+			if t.length > 16383 {
+				return "LONGTEXT", nil
+			}
+			return fmt.Sprintf("VARCHAR(%d)", t.length), nil
+		case "char":
+			if t.length > 255 {
+				return fmt.Sprintf("VARCHAR(%d)", min(t.length, 16383)), nil
+			}
+			return fmt.Sprintf("CHAR(%d)", t.length), nil
+		case "decimal":
+			return decimal(65, "DECIMAL(65,30)"), nil
+		}
+		return map[string]string{
+			"bool": "TINYINT(1)", "int8": "TINYINT", "int16": "SMALLINT", "int32": "INT", "int64": "BIGINT",
+			"float32": "FLOAT", "float64": "DOUBLE", "text": "LONGTEXT", "blob": "LONGBLOB",
+			"date": "DATE", "time": "TIME(6)", "datetime": "DATETIME(6)", "timestamptz": "DATETIME(6)", "json": "JSON", "uuid": "CHAR(36)",
+		}[t.kind], nil
+	case "postgres":
+		switch t.kind {
+		case "varchar":
+			if t.length == 0 || t.length > 10485760 {
+				return "TEXT", nil
+			}
+			return fmt.Sprintf("VARCHAR(%d)", t.length), nil
+		case "char":
+			return fmt.Sprintf("CHAR(%d)", t.length), nil
+		case "decimal":
+			if t.precision <= 0 {
+				return "NUMERIC", nil
+			}
+			return fmt.Sprintf("NUMERIC(%d,%d)", min(t.precision, 1000), t.scale), nil
+		}
+		return map[string]string{
+			"bool": "BOOLEAN", "int8": "SMALLINT", "int16": "SMALLINT", "int32": "INTEGER", "int64": "BIGINT",
+			"float32": "REAL", "float64": "DOUBLE PRECISION", "text": "TEXT", "blob": "BYTEA",
+			"date": "DATE", "time": "TIME", "datetime": "TIMESTAMP", "timestamptz": "TIMESTAMPTZ", "json": "JSONB", "uuid": "UUID",
+		}[t.kind], nil
+	case "sqlserver":
+		switch t.kind {
+		case "varchar":
+			if t.length == 0 || t.length > 4000 {
+				return "NVARCHAR(MAX)", nil
+			}
+			return fmt.Sprintf("NVARCHAR(%d)", t.length), nil
+		case "char":
+			if t.length > 4000 {
+				return "NVARCHAR(MAX)", nil
+			}
+			return fmt.Sprintf("NCHAR(%d)", t.length), nil
+		case "decimal":
+			return decimal(38, "DECIMAL(38,10)"), nil
+		}
+		return map[string]string{
+			"bool": "BIT", "int8": "SMALLINT", "int16": "SMALLINT", "int32": "INT", "int64": "BIGINT",
+			"float32": "REAL", "float64": "FLOAT", "text": "NVARCHAR(MAX)", "blob": "VARBINARY(MAX)",
+			"date": "DATE", "time": "TIME", "datetime": "DATETIME2", "timestamptz": "DATETIMEOFFSET", "json": "NVARCHAR(MAX)", "uuid": "UNIQUEIDENTIFIER",
+		}[t.kind], nil
+	case "oracle":
+		switch t.kind {
+		case "varchar", "char":
+			if t.length == 0 {
+				return "VARCHAR2(255 CHAR)", nil
+			}
+			if t.length > 4000 {
+				return "CLOB", nil
+			}
+			if t.kind == "char" && t.length <= 2000 {
+				return fmt.Sprintf("CHAR(%d CHAR)", t.length), nil
+			}
+			return fmt.Sprintf("VARCHAR2(%d CHAR)", t.length), nil
+		case "decimal":
+			if t.precision <= 0 {
+				return "NUMBER", nil
+			}
+			p := min(t.precision, 38)
+			return fmt.Sprintf("NUMBER(%d,%d)", p, min(t.scale, p)), nil
+		}
+		return map[string]string{
+			"bool": "NUMBER(1)", "int8": "NUMBER(3)", "int16": "NUMBER(5)", "int32": "NUMBER(10)", "int64": "NUMBER(19)",
+			"float32": "BINARY_FLOAT", "float64": "BINARY_DOUBLE", "text": "CLOB", "blob": "BLOB",
+			"date": "DATE", "time": "VARCHAR2(32 CHAR)", "datetime": "TIMESTAMP", "timestamptz": "TIMESTAMP WITH TIME ZONE", "json": "CLOB", "uuid": "VARCHAR2(36 CHAR)",
+		}[t.kind], nil
+	case "dameng":
+		switch t.kind {
+		case "varchar", "char":
+			if t.length == 0 {
+				return "VARCHAR(255)", nil
+			}
+			if t.length > 8188 {
+				return "CLOB", nil
+			}
+			if t.kind == "char" {
+				return fmt.Sprintf("CHAR(%d)", t.length), nil
+			}
+			return fmt.Sprintf("VARCHAR(%d)", t.length), nil
+		case "decimal":
+			return decimal(38, "DECIMAL"), nil
+		}
+		return map[string]string{
+			"bool": "BIT", "int8": "TINYINT", "int16": "SMALLINT", "int32": "INT", "int64": "BIGINT",
+			"float32": "REAL", "float64": "DOUBLE", "text": "CLOB", "blob": "BLOB",
+			"date": "DATE", "time": "TIME", "datetime": "TIMESTAMP", "timestamptz": "TIMESTAMP WITH TIME ZONE", "json": "CLOB", "uuid": "VARCHAR(36)",
+		}[t.kind], nil
+	case "sqlite":
+		return map[string]string{
+			"bool": "INTEGER", "int8": "INTEGER", "int16": "INTEGER", "int32": "INTEGER", "int64": "INTEGER",
+			"decimal": "NUMERIC", "float32": "REAL", "float64": "REAL", "char": "TEXT", "varchar": "TEXT", "text": "TEXT", "blob": "BLOB",
+			"date": "TEXT", "time": "TEXT", "datetime": "TEXT", "timestamptz": "TEXT", "json": "TEXT", "uuid": "TEXT",
+		}[t.kind], nil
+	case "duckdb":
+		if t.kind == "decimal" {
+			return decimal(38, "DOUBLE"), nil
+		}
+		return map[string]string{
+			"bool": "BOOLEAN", "int8": "TINYINT", "int16": "SMALLINT", "int32": "INTEGER", "int64": "BIGINT",
+			"float32": "FLOAT", "float64": "DOUBLE", "char": "VARCHAR", "varchar": "VARCHAR", "text": "VARCHAR", "blob": "BLOB",
+			"date": "DATE", "time": "TIME", "datetime": "TIMESTAMP", "timestamptz": "TIMESTAMPTZ", "json": "JSON", "uuid": "UUID",
+		}[t.kind], nil
+	case "clickhouse":
+		if t.kind == "decimal" {
+			if t.precision <= 0 {
+				return "Decimal(38,10)", nil
+			}
+			p := min(t.precision, 76)
+			return fmt.Sprintf("Decimal(%d,%d)", p, min(t.scale, p)), nil
+		}
+		return map[string]string{
+			"bool": "Bool", "int8": "Int8", "int16": "Int16", "int32": "Int32", "int64": "Int64",
+			"float32": "Float32", "float64": "Float64", "char": "String", "varchar": "String", "text": "String", "blob": "String",
+			"date": "Date32", "time": "String", "datetime": "DateTime64(6)", "timestamptz": "DateTime64(6)", "json": "String", "uuid": "UUID",
+		}[t.kind], nil
+	}
+	return "", fmt.Errorf("暂不支持向 %s 传输表结构", dbType)
+}
+
+// transferColumnDDLType 返回目标列类型；同族数据库沿用源类型，否则经通用类型转换。
+// 第二个返回值非空表示源类型无法识别、已按大文本处理。
+func transferColumnDDLType(sourceType, targetType string, col connection.ColumnDefinition) (string, string, error) {
+	if transferTypeFamily(sourceType) == transferTypeFamily(targetType) && strings.TrimSpace(col.Type) != "" {
+		return col.Type, "", nil
+	}
+	parsed, known := parseTransferColumnType(sourceType, col.Type)
+	rendered, err := renderTransferColumnType(targetType, parsed)
+	if err != nil {
+		return "", "", err
+	}
+	warning := ""
+	if !known {
+		warning = fmt.Sprintf("列 %s 的类型 %s 无法识别，已按 %s 创建", col.Name, col.Type, rendered)
+	}
+	return rendered, warning, nil
+}
+
+// buildTransferCreateSQL 按源表字段生成目标表的建表语句，仅包含列类型、非空约束与主键；
+// 默认值、自增、索引与外键依赖各库的表达式写法，不做转换。
+func buildTransferCreateSQL(sourceType, targetType string, qualifiedTable string, columns []connection.ColumnDefinition) (string, []string, error) {
+	defs := make([]string, 0, len(columns)+1)
+	var primaryKeys, warnings []string
+	for _, col := range columns {
+		if strings.TrimSpace(col.Generated) != "" {
+			continue
+		}
+		colType, warning, err := transferColumnDDLType(sourceType, targetType, col)
+		if err != nil {
+			return "", nil, err
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		isKey := strings.EqualFold(col.Key, "PRI")
+		if isKey {
+			primaryKeys = append(primaryKeys, quoteIdentByType(targetType, col.Name))
+		}
+		notNull := isKey || strings.EqualFold(col.Nullable, "NO")
+		def := quoteIdentByType(targetType, col.Name) + " " + colType
+		switch {
+		case targetType == "clickhouse":
+			if !notNull && !strings.HasPrefix(colType, "Nullable(") {
+				def = quoteIdentByType(targetType, col.Name) + " Nullable(" + colType + ")"
+			}
+		case notNull:
+			def += " NOT NULL"
+		}
+		defs = append(defs, def)
+	}
+	if len(defs) == 0 {
+		return "", nil, fmt.Errorf("源表没有可复制的列")
+	}
+
+	suffix := ""
+	if targetType == "clickhouse" {
+		orderBy := "tuple()"
+		if len(primaryKeys) > 0 {
+			orderBy = "(" + strings.Join(primaryKeys, ", ") + ")"
+		}
+		suffix = " ENGINE = MergeTree ORDER BY " + orderBy
+	} else if len(primaryKeys) > 0 {
+		defs = append(defs, "PRIMARY KEY ("+strings.Join(primaryKeys, ", ")+")")
+	}
+	createSQL := fmt.Sprintf("CREATE TABLE %s (%s)%s", quoteQualifiedIdentByType(targetType, qualifiedTable), strings.Join(defs, ", "), suffix)
+	return createSQL, warnings, nil
+}
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+)
+
+const (
+	wireLogFileName    = "gonavi-wire.log"
+	wireLogMaxBytes    = 20 * 1024 * 1024 // 超过后轮转为 .1，只保留一份
+	wireLogMaxSQLBytes = 8 * 1024
+)
+
+// wireLogger 按连接开关的调试日志：记录实际发往数据库的每条语句及耗时，
+// 写入独立文件，便于复现“应用发了奇怪的 SQL”类问题。默认全部关闭。
+type wireLogger struct {
+	mu      sync.RWMutex
+	enabled map[string]string // 连接键 -> 连接摘要
+	file    *os.File
+	path    string
+	size    int64
+}
+
+func newWireLogger() *wireLogger {
+	return &wireLogger{enabled: make(map[string]string)}
+}
+
+// wireConnKey 连接标识不含数据库名，切换库后调试开关仍然生效。
+func wireConnKey(config connection.ConnectionConfig) string {
+	return strings.ToLower(fmt.Sprintf("%s://%s@%s:%d",
+		strings.TrimSpace(config.Type), strings.TrimSpace(config.User), strings.TrimSpace(config.Host), config.Port))
+}
+
+func wireLogPath() string {
+	return filepath.Join(filepath.Dir(logger.Path()), wireLogFileName)
+}
+
+func (w *wireLogger) isEnabled(config connection.ConnectionConfig) bool {
+	if w == nil {
+		return false
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if len(w.enabled) == 0 {
+		return false
+	}
+	_, ok := w.enabled[wireConnKey(config)]
+	return ok
+}
+
+func (w *wireLogger) set(config connection.ConnectionConfig, enabled bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	key := wireConnKey(config)
+	if enabled {
+		w.enabled[key] = formatConnSummary(config)
+		return
+	}
+	delete(w.enabled, key)
+	if len(w.enabled) == 0 && w.file != nil {
+		_ = w.file.Close()
+		w.file = nil
+	}
+}
+
+func (w *wireLogger) connections() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	out := make([]string, 0, len(w.enabled))
+	for _, summary := range w.enabled {
+		out = append(out, summary)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// record 写入一条记录；调用方应先用 isEnabled 判断，避免关闭状态下格式化开销。
+func (w *wireLogger) record(config connection.ConnectionConfig, dbName string, kind string, query string, started time.Time, rows int64, err error) {
+	if !w.isEnabled(config) {
+		return
+	}
+	elapsed := time.Since(started)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s [%s] %s 库=%s 耗时=%.1fms",
+		started.Format("2006-01-02 15:04:05.000"), kind, formatConnSummary(config), dbName, float64(elapsed.Microseconds())/1000)
+	if err != nil {
+		fmt.Fprintf(&b, " 错误=%q", normalizeErrorMessage(err))
+	} else if kind == "query" {
+		fmt.Fprintf(&b, " 行数=%d", rows)
+	} else {
+		fmt.Fprintf(&b, " 影响行数=%d", rows)
+	}
+	b.WriteString("\n")
+	b.WriteString(redactWireSQL(query))
+	b.WriteString("\n\n")
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.ensureFileLocked(); err != nil {
+		logger.Error(err, "写入调试语句日志失败")
+		return
+	}
+	n, _ := w.file.WriteString(b.String())
+	w.size += int64(n)
+}
+
+// ensureFileLocked 按需打开日志文件并在超过上限时轮转；调用方需持有 w.mu。
+func (w *wireLogger) ensureFileLocked() error {
+	if w.file != nil && w.size < wireLogMaxBytes {
+		return nil
+	}
+	if w.path == "" {
+		w.path = wireLogPath()
+	}
+	if w.file != nil {
+		_ = w.file.Close()
+		w.file = nil
+		_ = os.Rename(w.path, w.path+".1")
+	}
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+func (w *wireLogger) close() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file != nil {
+		_ = w.file.Close()
+		w.file = nil
+	}
+}
+
+var (
+	// CREATE USER ... IDENTIFIED BY 'x' / PASSWORD 'x' / SET PASSWORD = 'x' 等字面量
+	wireSecretLiteralPattern = regexp.MustCompile(`(?i)\b(identified\s+(?:with\s+\S+\s+)?by|password|passwd|secret|token)(\s*(?:=|:)?\s*)('(?:[^']|'')*'|"(?:[^"]|"")*")`)
+	// 连接串/DSN 中的 password=xxx、pwd=xxx
+	wireSecretPairPattern = regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|token)=([^;&\s'"]+)`)
+	// URI 中的 user:pass@host
+	wireURICredentialPattern = regexp.MustCompile(`(://[^:/@\s'"]+):([^@/\s'"]+)@`)
+)
+
+// redactSQLSecrets 屏蔽语句中的口令类字面量与 URI 中的密码。
+func redactSQLSecrets(query string) string {
+	query = wireSecretLiteralPattern.ReplaceAllString(query, "$1$2'***'")
+	query = wireSecretPairPattern.ReplaceAllString(query, "$1=***")
+	return wireURICredentialPattern.ReplaceAllString(query, "$1:***@")
+}
+
+// redactWireSQL 屏蔽语句中的口令类字面量，并截断过长语句。
+func redactWireSQL(query string) string {
+	query = redactSQLSecrets(query)
+	if len(query) > wireLogMaxSQLBytes {
+		cut := wireLogMaxSQLBytes
+		for cut > 0 && !isUTF8Start(query[cut]) {
+			cut--
+		}
+		query = fmt.Sprintf("%s\n...（已截断，共 %d 字节）", query[:cut], len(query))
+	}
+	return query
+}
+
+func isUTF8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// SetWireDebug 开启或关闭指定连接的调试语句日志，返回日志文件路径。
+func (a *App) SetWireDebug(config connection.ConnectionConfig, enabled bool) connection.QueryResult {
+	if strings.TrimSpace(config.Type) == "" {
+		return connection.QueryResult{Success: false, Message: "连接类型不能为空"}
+	}
+	a.wireLog.set(config, enabled)
+	path := wireLogPath()
+	if enabled {
+		logger.Infof("已开启调试语句日志：%s 文件=%s", formatConnSummary(config), path)
+		return connection.QueryResult{Success: true, Message: "已开启调试语句日志", Data: map[string]interface{}{"enabled": true, "path": path}}
+	}
+	logger.Infof("已关闭调试语句日志：%s", formatConnSummary(config))
+	return connection.QueryResult{Success: true, Message: "已关闭调试语句日志", Data: map[string]interface{}{"enabled": false, "path": path}}
+}
+
+// GetWireDebugStatus 返回指定连接是否开启调试日志，以及当前所有已开启的连接。
+func (a *App) GetWireDebugStatus(config connection.ConnectionConfig) connection.QueryResult {
+	return connection.QueryResult{Success: true, Data: map[string]interface{}{
+		"enabled":     a.wireLog.isEnabled(config),
+		"path":        wireLogPath(),
+		"connections": a.wireLog.connections(),
+	}}
+}
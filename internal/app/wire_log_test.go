@@ -0,0 +1,66 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestRedactWireSQLMasksSecrets(t *testing.T) {
+	cases := map[string]string{
+		"CREATE USER 'bob'@'%' IDENTIFIED BY 'p@ss''word'":                   `CREATE USER 'bob'@'%' IDENTIFIED BY '***'`,
+		"ALTER USER bob WITH PASSWORD 'hunter2'":                             `ALTER USER bob WITH PASSWORD '***'`,
+		"SET PASSWORD = 'abc'":                                               `SET PASSWORD = '***'`,
+		"SELECT * FROM dblink('host=db user=u password=s3cret', 'select 1')": `SELECT * FROM dblink('host=db user=u password=***', 'select 1')`,
+		"COPY t FROM 'postgres://u:s3cret@db/x'":                             `COPY t FROM 'postgres://u:***@db/x'`,
+		"SELECT name FROM users WHERE id = 1":                                `SELECT name FROM users WHERE id = 1`,
+	}
+	for input, want := range cases {
+		if got := redactWireSQL(input); got != want {
+			t.Errorf("redactWireSQL(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestRedactWireSQLTruncatesOnRuneBoundary(t *testing.T) {
+	got := redactWireSQL(strings.Repeat("中", wireLogMaxSQLBytes))
+	if !strings.Contains(got, "已截断") || !strings.HasPrefix(got, strings.Repeat("中", wireLogMaxSQLBytes/3)) {
+		t.Fatalf("unexpected truncation: %q", got[len(got)-64:])
+	}
+}
+
+func TestWireLoggerRecordsOnlyEnabledConnections(t *testing.T) {
+	path := t.TempDir() + "/wire.log"
+	w := newWireLogger()
+	w.path = path
+	on := connection.ConnectionConfig{Type: "mysql", Host: "db1", Port: 3306, User: "root"}
+	off := connection.ConnectionConfig{Type: "mysql", Host: "db2", Port: 3306, User: "root"}
+	w.set(on, true)
+
+	started := time.Now()
+	w.record(on, "shop", "exec", "UPDATE t SET a = 1", started, 3, nil)
+	w.record(off, "shop", "exec", "UPDATE t SET a = 2", started, 3, nil)
+	w.record(on, "other", "query", "SELECT 1", started, 0, errors.New("boom"))
+	w.close()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(raw)
+	if !strings.Contains(content, "UPDATE t SET a = 1") || strings.Contains(content, "SET a = 2") {
+		t.Fatalf("unexpected log content:\n%s", content)
+	}
+	if !strings.Contains(content, "影响行数=3") || !strings.Contains(content, `错误="boom"`) {
+		t.Fatalf("missing metadata:\n%s", content)
+	}
+
+	w.set(on, false)
+	if w.isEnabled(on) {
+		t.Fatal("connection should be disabled")
+	}
+}
@@ -7,43 +7,111 @@ type SSHConfig struct {
 	User     string `json:"user"`
 	Password string `json:"password"`
 	KeyPath  string `json:"keyPath"`
+	// JumpHosts 依次经过的跳板机（ProxyJump），最后一跳再连接本 SSH 主机；跳板机自身的 JumpHosts 忽略。
+	JumpHosts []SSHConfig `json:"jumpHosts,omitempty"`
 }
 
 // ConnectionConfig holds database connection details including SSH
 type ConnectionConfig struct {
-	Type                 string    `json:"type"`
-	Host                 string    `json:"host"`
-	Port                 int       `json:"port"`
-	User                 string    `json:"user"`
-	Password             string    `json:"password"`
-	SavePassword         bool      `json:"savePassword,omitempty"` // Persist password in saved connection
-	Database             string    `json:"database"`
-	UseSSH               bool      `json:"useSSH"`
-	SSH                  SSHConfig `json:"ssh"`
-	Driver               string    `json:"driver,omitempty"`               // For custom connection
-	DSN                  string    `json:"dsn,omitempty"`                  // For custom connection
-	Timeout              int       `json:"timeout,omitempty"`              // Connection timeout in seconds (default: 30)
-	RedisDB              int       `json:"redisDB,omitempty"`              // Redis database index (0-15)
-	URI                  string    `json:"uri,omitempty"`                  // Connection URI for copy/paste
-	Hosts                []string  `json:"hosts,omitempty"`                // Multi-host addresses: host:port
-	Topology             string    `json:"topology,omitempty"`             // single | replica
-	MySQLReplicaUser     string    `json:"mysqlReplicaUser,omitempty"`     // MySQL replica auth user
-	MySQLReplicaPassword string    `json:"mysqlReplicaPassword,omitempty"` // MySQL replica auth password
-	ReplicaSet           string    `json:"replicaSet,omitempty"`           // MongoDB replica set name
-	AuthSource           string    `json:"authSource,omitempty"`           // MongoDB authSource
-	ReadPreference       string    `json:"readPreference,omitempty"`       // MongoDB readPreference
-	MongoSRV             bool      `json:"mongoSrv,omitempty"`             // MongoDB use mongodb+srv URI scheme
-	MongoAuthMechanism   string    `json:"mongoAuthMechanism,omitempty"`   // MongoDB authMechanism
-	MongoReplicaUser     string    `json:"mongoReplicaUser,omitempty"`     // MongoDB replica auth user
-	MongoReplicaPassword string    `json:"mongoReplicaPassword,omitempty"` // MongoDB replica auth password
+	Type                  string    `json:"type"`
+	Host                  string    `json:"host"`
+	Port                  int       `json:"port"`
+	User                  string    `json:"user"`
+	Password              string    `json:"password"`
+	SavePassword          bool      `json:"savePassword,omitempty"` // Persist password in saved connection
+	Database              string    `json:"database"`
+	UseSSH                bool      `json:"useSSH"`
+	SSH                   SSHConfig `json:"ssh"`
+	Driver                string    `json:"driver,omitempty"`                // For custom connection
+	DSN                   string    `json:"dsn,omitempty"`                   // For custom connection
+	Timeout               int       `json:"timeout,omitempty"`               // Connection timeout in seconds (default: 30)
+	RedisDB               int       `json:"redisDB,omitempty"`               // Redis database index (0-15)
+	URI                   string    `json:"uri,omitempty"`                   // Connection URI for copy/paste
+	Hosts                 []string  `json:"hosts,omitempty"`                 // Multi-host addresses: host:port
+	Topology              string    `json:"topology,omitempty"`              // single | replica
+	MySQLReplicaUser      string    `json:"mysqlReplicaUser,omitempty"`      // MySQL replica auth user
+	MySQLReplicaPassword  string    `json:"mysqlReplicaPassword,omitempty"`  // MySQL replica auth password
+	ReplicaSet            string    `json:"replicaSet,omitempty"`            // MongoDB replica set name
+	AuthSource            string    `json:"authSource,omitempty"`            // MongoDB authSource
+	ReadPreference        string    `json:"readPreference,omitempty"`        // MongoDB readPreference
+	MongoSRV              bool      `json:"mongoSrv,omitempty"`              // MongoDB use mongodb+srv URI scheme
+	MongoAuthMechanism    string    `json:"mongoAuthMechanism,omitempty"`    // MongoDB authMechanism
+	MongoReplicaUser      string    `json:"mongoReplicaUser,omitempty"`      // MongoDB replica auth user
+	MongoReplicaPassword  string    `json:"mongoReplicaPassword,omitempty"`  // MongoDB replica auth password
+	LDAPAuth              bool      `json:"ldapAuth,omitempty"`              // Authenticate with LDAP/AD directory credentials
+	LDAPDomain            string    `json:"ldapDomain,omitempty"`            // AD domain for SQL Server (DOMAIN\user)
+	AutoReconnect         bool      `json:"autoReconnect,omitempty"`         // Reconnect and retry read-only statements once when the connection drops
+	Charset               string    `json:"charset,omitempty"`               // Connection character set (MySQL SET NAMES), default utf8mb4
+	Collation             string    `json:"collation,omitempty"`             // Connection collation, e.g. gbk_chinese_ci
+	LegacyTextEncoding    string    `json:"legacyTextEncoding,omitempty"`    // Actual encoding of stored text (gbk/gb18030/big5), decoded to UTF-8 on read
+	ReadFromReplica       bool      `json:"readFromReplica,omitempty"`       // Route read-only statements to replica hosts (Hosts[1:]) when topology is replica
+	MaxRowsPerMinute      int       `json:"maxRowsPerMinute,omitempty"`      // Soft limit on rows fetched per minute across queries (0 = unlimited)
+	MaxFetchMB            int       `json:"maxFetchMB,omitempty"`            // Soft limit on data fetched by a single query in MB (0 = unlimited)
+	ServerQueryTimeout    int       `json:"serverQueryTimeout,omitempty"`    // Seconds a read query may run on the server, enforced by engine hints (0 = off)
+	QueryTag              bool      `json:"queryTag,omitempty"`              // Prepend an identifying comment to statements sent from the editor
+	QueryTagTemplate      string    `json:"queryTagTemplate,omitempty"`      // Comment template, e.g. "gonavi user={user} tab={tab}"; empty uses the default
+	SSLMode               string    `json:"sslMode,omitempty"`               // disable | require | verify-ca | verify-full (MySQL/PostgreSQL/SQL Server/MongoDB)
+	SSLCA                 string    `json:"sslCA,omitempty"`                 // CA certificate (PEM) path; empty uses system roots
+	SSLCert               string    `json:"sslCert,omitempty"`               // Client certificate (PEM) path for mutual TLS
+	SSLKey                string    `json:"sslKey,omitempty"`                // Client private key (PEM) path
+	SSLSkipVerify         bool      `json:"sslSkipVerify,omitempty"`         // Encrypt without verifying the server certificate
+	SSLServerName         string    `json:"sslServerName,omitempty"`         // Host name expected in the server certificate; defaults to Host
+	KeepaliveInterval     int       `json:"keepaliveInterval,omitempty"`     // Seconds between keepalive pings on idle cached connections (0 = disabled, min 10)
+	KeepaliveSQL          string    `json:"keepaliveSQL,omitempty"`          // Keepalive statement, e.g. "SELECT 1"; empty uses the driver ping
+	TabSessionAffinity    bool      `json:"tabSessionAffinity,omitempty"`    // Check out a dedicated session on a tab's first DBQueryInTab so session state persists across executions
+	TabSessionIdleTimeout int       `json:"tabSessionIdleTimeout,omitempty"` // Seconds an idle tab session is kept before release (0 = 30 minutes for automatic sessions, never for pinned ones)
+	MaxOpenConns          int       `json:"maxOpenConns,omitempty"`          // database/sql pool: max open connections (0 = driver default)
+	MaxIdleConns          int       `json:"maxIdleConns,omitempty"`          // database/sql pool: max idle connections (0 = driver default)
+	ConnMaxLifetime       int       `json:"connMaxLifetime,omitempty"`       // database/sql pool: seconds before a connection is recycled (0 = unlimited)
+	ReadOnly              bool      `json:"readOnly,omitempty"`              // Reject writes and DDL in the backend; sessions are opened read-only where the driver supports it
+	SQLiteJournalMode     string    `json:"sqliteJournalMode,omitempty"`     // SQLite journal_mode: wal | delete | truncate | persist | memory; empty keeps the file's current mode
+	SQLiteBusyTimeout     int       `json:"sqliteBusyTimeout,omitempty"`     // Milliseconds SQLite waits for a lock held by another connection before "database is locked" (0 = 5000)
+}
+
+// DatabaseListOptions controls which databases/schemas GetDatabases returns for a connection
+type DatabaseListOptions struct {
+	HideSystem bool   `json:"hideSystem,omitempty"` // Hide built-in databases/schemas (information_schema, mysql, pg_catalog, master, SYS...)
+	Pattern    string `json:"pattern,omitempty"`    // Comma-separated glob patterns, e.g. "app_*, !app_test*"; empty shows all
 }
 
 // QueryResult is the standard response format for Wails methods
 type QueryResult struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
-	Fields  []string    `json:"fields,omitempty"`
+	Success      bool        `json:"success"`
+	Message      string      `json:"message"`
+	Data         interface{} `json:"data"`
+	Fields       []string    `json:"fields,omitempty"`
+	LimitReached bool        `json:"limitReached,omitempty"` // A connection fetch limit stopped the scan; Data holds the partial rows
+	Error        *QueryError `json:"error,omitempty"`        // Structured details of a failed statement; Message keeps the flat text
+	// SchemaBackup is set when an ALTER/DROP/RENAME was executed after capturing the affected definitions
+	SchemaBackup *SchemaChangeBackup `json:"schemaBackup,omitempty"`
+}
+
+// SchemaChangeBackup records the definitions captured before a structural change
+// and a script that reverts it.
+type SchemaChangeBackup struct {
+	ID           string   `json:"id"`
+	CreatedAt    int64    `json:"createdAt"` // Unix milli
+	DBType       string   `json:"dbType"`
+	Host         string   `json:"host,omitempty"`
+	Database     string   `json:"database,omitempty"`
+	Statement    string   `json:"statement"`
+	Objects      []string `json:"objects"`      // e.g. "DROP TABLE public.orders"
+	BackupPath   string   `json:"backupPath"`   // Definitions before the change
+	RollbackPath string   `json:"rollbackPath"` // Script that reverts the change
+	Rollback     string   `json:"rollback,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"` // Objects whose definition could not be captured
+}
+
+// QueryError describes a failed statement so the editor can mark the failing token
+// and the UI can branch on the error class.
+type QueryError struct {
+	Class    string `json:"class"`              // syntax | auth | permission | not_found | constraint | timeout | canceled | connection | other
+	Code     string `json:"code,omitempty"`     // Vendor error code, e.g. 1064, ORA-00942
+	SQLState string `json:"sqlState,omitempty"` // Five-character SQLSTATE when the driver reports one
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`     // 1-based line in the submitted statement (0 = unknown)
+	Column   int    `json:"column,omitempty"`   // 1-based column in characters (0 = unknown)
+	Position int    `json:"position,omitempty"` // 1-based character offset in the submitted statement (0 = unknown)
 }
 
 // ColumnDefinition represents a table column
@@ -55,6 +123,11 @@ type ColumnDefinition struct {
 	Default  *string `json:"default"`
 	Extra    string  `json:"extra"` // auto_increment
 	Comment  string  `json:"comment"`
+
+	DefaultIsExpression bool   `json:"defaultIsExpression,omitempty"` // Default is an expression (CURRENT_TIMESTAMP, nextval(...)) rather than a literal
+	Generated           string `json:"generated,omitempty"`           // STORED / VIRTUAL for generated (computed) columns
+	GenerationExpr      string `json:"generationExpr,omitempty"`
+	Identity            string `json:"identity,omitempty"` // ALWAYS / BY DEFAULT; auto_increment and serial are reported as BY DEFAULT
 }
 
 // IndexDefinition represents a table index
@@ -66,6 +139,24 @@ type IndexDefinition struct {
 	IndexType  string `json:"indexType"`
 }
 
+// IndexSpec describes an index to create
+type IndexSpec struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique,omitempty"`
+	Method  string   `json:"method,omitempty"` // Optional access method: BTREE/HASH/FULLTEXT/SPATIAL (MySQL), btree/gin/gist/brin (PostgreSQL), CLUSTERED/NONCLUSTERED (SQL Server), BITMAP (Oracle), skip index type (ClickHouse)
+	Where   string   `json:"where,omitempty"`  // Partial index predicate (PostgreSQL, SQLite, SQL Server filtered index)
+}
+
+// IndexSizeEstimate is a catalog-based estimate of what building an index would cost
+type IndexSizeEstimate struct {
+	Rows           int64  `json:"rows"`           // Estimated table rows from statistics, -1 if unknown
+	TableBytes     int64  `json:"tableBytes"`     // Current table data size, 0 if unknown
+	KeyBytes       int64  `json:"keyBytes"`       // Estimated average key width per entry
+	EstimatedBytes int64  `json:"estimatedBytes"` // Estimated index size on disk
+	Note           string `json:"note,omitempty"`
+}
+
 // ForeignKeyDefinition represents a foreign key
 type ForeignKeyDefinition struct {
 	Name           string `json:"name"`
@@ -83,11 +174,100 @@ type TriggerDefinition struct {
 	Statement string `json:"statement"`
 }
 
+// TriggerSpec describes a trigger to create, or identifies one by Schema/TableName/Name
+type TriggerSpec struct {
+	Schema    string   `json:"schema,omitempty"` // Database on MySQL, schema/owner elsewhere; ignored by SQLite
+	TableName string   `json:"tableName"`
+	Name      string   `json:"name"`
+	Timing    string   `json:"timing,omitempty"`  // BEFORE/AFTER/INSTEAD OF
+	Events    []string `json:"events,omitempty"`  // INSERT/UPDATE/DELETE; MySQL and SQLite accept exactly one
+	ForEach   string   `json:"forEach,omitempty"` // ROW (default) or STATEMENT; ignored by MySQL and SQL Server
+	When      string   `json:"when,omitempty"`    // Optional row condition; not supported by MySQL and SQL Server
+	Body      string   `json:"body,omitempty"`    // Trigger statements; the trigger function body on PostgreSQL
+	Replace   bool     `json:"replace,omitempty"` // Drop an existing trigger with the same name first (edit)
+}
+
+// RoutineParameter represents a stored procedure or function parameter
+type RoutineParameter struct {
+	Name     string `json:"name"` // Without the @ prefix on SQL Server; $N for unnamed PostgreSQL parameters
+	Mode     string `json:"mode"` // IN/OUT/INOUT/VARIADIC; SQL Server OUTPUT parameters are reported as INOUT
+	Type     string `json:"type"`
+	Position int    `json:"position"`
+}
+
+// RoutineDefinition represents a stored procedure or function
+type RoutineDefinition struct {
+	ID         string             `json:"id"` // pg_proc oid on PostgreSQL, object_id on SQL Server, name elsewhere; tells overloads apart
+	Schema     string             `json:"schema"`
+	Name       string             `json:"name"`
+	Type       string             `json:"type"`                 // PROCEDURE/FUNCTION
+	ReturnType string             `json:"returnType,omitempty"` // TABLE for table-valued functions
+	Arguments  string             `json:"arguments,omitempty"`  // Argument signature for display (PostgreSQL overloads)
+	Comment    string             `json:"comment,omitempty"`
+	Parameters []RoutineParameter `json:"parameters,omitempty"` // Filled by GetRoutineDefinition
+	Definition string             `json:"definition,omitempty"` // Filled by GetRoutineDefinition
+}
+
+// RoutineCall describes a stored procedure or function invocation
+type RoutineCall struct {
+	Routine RoutineDefinition      `json:"routine"` // Located by ID when set, otherwise by schema, name and type
+	Args    map[string]interface{} `json:"args"`    // IN/INOUT values keyed by parameter name; missing ones are passed as NULL
+}
+
+// RoutineResultSet is one result set returned by a routine call
+type RoutineResultSet struct {
+	Columns []string                 `json:"columns"`
+	Rows    []map[string]interface{} `json:"rows"`
+}
+
+// RoutineCallResult holds everything a routine call returned
+type RoutineCallResult struct {
+	SQL         string                 `json:"sql"` // The statement that was executed, with placeholders
+	ResultSets  []RoutineResultSet     `json:"resultSets"`
+	OutParams   map[string]interface{} `json:"outParams,omitempty"`
+	ReturnValue interface{}            `json:"returnValue,omitempty"`
+}
+
+// EventDefinition represents a scheduled database job: a MySQL event or a pg_cron job
+type EventDefinition struct {
+	ID           string `json:"id"`               // jobid on pg_cron, event name on MySQL
+	Schema       string `json:"schema,omitempty"` // MySQL event schema
+	Name         string `json:"name"`
+	Enabled      bool   `json:"enabled"`
+	Status       string `json:"status"`                 // Raw status, e.g. ENABLED/DISABLED/SLAVESIDE_DISABLED on MySQL
+	Schedule     string `json:"schedule"`               // "EVERY 1 DAY" / "AT ..." on MySQL, cron expression on pg_cron
+	Starts       string `json:"starts,omitempty"`       // MySQL recurring events only
+	Ends         string `json:"ends,omitempty"`         // MySQL recurring events only
+	LastExecuted string `json:"lastExecuted,omitempty"` // MySQL LAST_EXECUTED
+	Definition   string `json:"definition"`             // Event body or pg_cron command
+	Comment      string `json:"comment,omitempty"`
+	Owner        string `json:"owner,omitempty"`    // DEFINER on MySQL, username on pg_cron
+	Database     string `json:"database,omitempty"` // Database a pg_cron job runs in
+}
+
+// SequenceDefinition represents a sequence on PostgreSQL-family databases and Oracle.
+// Numeric fields are decimal strings because Oracle bounds exceed int64.
+type SequenceDefinition struct {
+	Schema     string `json:"schema"`
+	Name       string `json:"name"`
+	DataType   string `json:"dataType,omitempty"` // smallint/integer/bigint on PostgreSQL
+	StartValue string `json:"startValue,omitempty"`
+	MinValue   string `json:"minValue,omitempty"`
+	MaxValue   string `json:"maxValue,omitempty"`
+	Increment  string `json:"increment,omitempty"`
+	Cache      string `json:"cache,omitempty"`
+	Cycle      bool   `json:"cycle"`
+	LastValue  string `json:"lastValue,omitempty"` // Empty before first nextval on PostgreSQL; LAST_NUMBER on Oracle
+	OwnedBy    string `json:"ownedBy,omitempty"`   // table.column owning the sequence (serial/identity) on PostgreSQL
+}
+
 // ColumnDefinitionWithTable represents a column with its table name (for search/autocomplete)
 type ColumnDefinitionWithTable struct {
-	TableName string `json:"tableName"`
+	TableName string `json:"tableName"` // Qualified as schema.table on schema-aware databases
 	Name      string `json:"name"`
 	Type      string `json:"type"`
+	Schema    string `json:"schema,omitempty"` // Owning schema, empty when the database has no schema level
+	Table     string `json:"table,omitempty"`  // Bare table name without schema
 }
 
 // UpdateRow represents a row update with keys (WHERE) and values (SET)
@@ -0,0 +1,142 @@
+package connectionstore
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GridSort 表格的排序列。
+type GridSort struct {
+	Column string `json:"column"`
+	Order  string `json:"order"` // ascend / descend，与前端表格一致
+}
+
+// GridFilter 表格的一条筛选条件，与前端 FilterCondition 结构一致。
+type GridFilter struct {
+	Enabled *bool  `json:"enabled,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Op      string `json:"op,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Value2  string `json:"value2,omitempty"`
+}
+
+// GridPreference 单张表的数据表格视图偏好，按连接 ID + 库 + 表保存。
+// 不含敏感信息，明文写在连接配置文件中，随连接一起删除。
+type GridPreference struct {
+	ConnectionID  string         `json:"connectionId"`
+	Database      string         `json:"database,omitempty"`
+	Table         string         `json:"table"`
+	HiddenColumns []string       `json:"hiddenColumns,omitempty"`
+	ColumnOrder   []string       `json:"columnOrder,omitempty"`
+	ColumnWidths  map[string]int `json:"columnWidths,omitempty"`
+	Sort          []GridSort     `json:"sort,omitempty"`
+	Filters       []GridFilter   `json:"filters,omitempty"`
+	UpdatedAt     int64          `json:"updatedAt"`
+}
+
+func (p GridPreference) sameTable(connectionID, database, table string) bool {
+	// 表名在部分数据库中区分大小写，按原样比较
+	return p.ConnectionID == connectionID && p.Database == database && p.Table == table
+}
+
+func normalizeGridPreference(p GridPreference) (GridPreference, error) {
+	p.ConnectionID = strings.TrimSpace(p.ConnectionID)
+	p.Database = strings.TrimSpace(p.Database)
+	p.Table = strings.TrimSpace(p.Table)
+	if p.ConnectionID == "" || p.Table == "" {
+		return p, fmt.Errorf("表格偏好缺少连接 ID 或表名")
+	}
+	return p, nil
+}
+
+// GridPreference 返回指定表的视图偏好；不存在时第二个返回值为 false。不需要解锁密钥。
+func (s *Store) GridPreference(connectionID, database, table string) (GridPreference, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := s.read()
+	if err != nil {
+		return GridPreference{}, false, err
+	}
+	connectionID, database, table = strings.TrimSpace(connectionID), strings.TrimSpace(database), strings.TrimSpace(table)
+	for _, p := range file.GridPreferences {
+		if p.sameTable(connectionID, database, table) {
+			return p, true, nil
+		}
+	}
+	return GridPreference{}, false, nil
+}
+
+// GridPreferences 返回连接的全部表格偏好，connectionID 为空时返回所有连接的，用于导出。
+func (s *Store) GridPreferences(connectionID string) ([]GridPreference, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	connectionID = strings.TrimSpace(connectionID)
+	result := make([]GridPreference, 0, len(file.GridPreferences))
+	for _, p := range file.GridPreferences {
+		if connectionID == "" || p.ConnectionID == connectionID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// SaveGridPreferences 新增或覆盖表格偏好（同一张表只保留一条），导入时可一次写入多条。
+func (s *Store) SaveGridPreferences(prefs ...GridPreference) ([]GridPreference, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	saved := make([]GridPreference, 0, len(prefs))
+	for _, pref := range prefs {
+		pref, err := normalizeGridPreference(pref)
+		if err != nil {
+			return nil, err
+		}
+		if pref.UpdatedAt == 0 {
+			pref.UpdatedAt = now
+		}
+		replaced := false
+		for i, existing := range file.GridPreferences {
+			if existing.sameTable(pref.ConnectionID, pref.Database, pref.Table) {
+				file.GridPreferences[i] = pref
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			file.GridPreferences = append(file.GridPreferences, pref)
+		}
+		saved = append(saved, pref)
+	}
+	return saved, s.write(file)
+}
+
+// DeleteGridPreference 删除指定表的视图偏好，不存在时不报错。
+func (s *Store) DeleteGridPreference(connectionID, database, table string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	connectionID, database, table = strings.TrimSpace(connectionID), strings.TrimSpace(database), strings.TrimSpace(table)
+	kept := file.GridPreferences[:0]
+	for _, p := range file.GridPreferences {
+		if !p.sameTable(connectionID, database, table) {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == len(file.GridPreferences) {
+		return nil
+	}
+	file.GridPreferences = kept
+	return s.write(file)
+}
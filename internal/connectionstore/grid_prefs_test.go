@@ -0,0 +1,54 @@
+package connectionstore
+
+import (
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestGridPreferences(t *testing.T) {
+	kr := &memoryKeyring{values: map[string]string{}}
+	s := newTestStore(t, kr)
+	conn, err := s.Save(Profile{Name: "dev", Config: connection.ConnectionConfig{Type: "mysql", Password: "pw"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := s.GridPreference(conn.ID, "shop", "orders"); ok || err != nil {
+		t.Fatalf("ok=%v err=%v", ok, err)
+	}
+	pref := GridPreference{ConnectionID: conn.ID, Database: "shop", Table: "orders", ColumnWidths: map[string]int{"id": 80}, Sort: []GridSort{{Column: "id", Order: "descend"}}}
+	if _, err := s.SaveGridPreferences(pref); err != nil {
+		t.Fatal(err)
+	}
+	pref.HiddenColumns = []string{"note"}
+	if _, err := s.SaveGridPreferences(pref, GridPreference{ConnectionID: conn.ID, Database: "shop", Table: "Orders"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重新打开后仍在，且同一张表只保留最新一条；写偏好不影响已加密的连接
+	reopened := New(s.path)
+	reopened.keyring = kr
+	got, ok, err := reopened.GridPreference(conn.ID, "shop", "orders")
+	if err != nil || !ok || got.ColumnWidths["id"] != 80 || len(got.HiddenColumns) != 1 || got.UpdatedAt == 0 {
+		t.Fatalf("got %+v ok=%v err=%v", got, ok, err)
+	}
+	if all, _ := reopened.GridPreferences(conn.ID); len(all) != 2 {
+		t.Fatalf("prefs = %+v", all)
+	}
+	if list, err := reopened.List(); err != nil || list[0].Config.Password != "pw" {
+		t.Fatalf("list = %+v err=%v", list, err)
+	}
+
+	if err := reopened.DeleteGridPreference(conn.ID, "shop", "Orders"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Delete(conn.ID); err != nil {
+		t.Fatal(err)
+	}
+	if all, _ := reopened.GridPreferences(""); len(all) != 0 {
+		t.Fatalf("prefs should be removed with connection: %+v", all)
+	}
+	if _, err := reopened.SaveGridPreferences(GridPreference{ConnectionID: conn.ID}); err == nil {
+		t.Fatal("expected error for missing table")
+	}
+}
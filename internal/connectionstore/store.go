@@ -0,0 +1,489 @@
+// Package connectionstore 在后端持久化已保存的连接配置。
+// 密码类字段使用 AES-256-GCM 加密后写入 ~/.gonavi/connections.json，密钥来源按优先级为：
+// 主密码派生（scrypt）、系统钥匙串（macOS Keychain / Windows 凭据管理器 / Secret Service），
+// 钥匙串不可用时退化为与配置同目录、仅当前用户可读的密钥文件。
+package connectionstore
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	ModeKeychain = "keychain" // 密钥保存在系统钥匙串
+	ModeMaster   = "master"   // 密钥由主密码派生，启动后需 Unlock
+	ModeKeyFile  = "keyfile"  // 钥匙串不可用时的本地密钥文件
+
+	storeVersion   = 1
+	keyringService = "GoNavi"
+	keyringUser    = "connection-store-key"
+)
+
+// ErrLocked 主密码模式下尚未解锁。
+var ErrLocked = errors.New("连接配置已加密，请先输入主密码解锁")
+
+// Profile 一条已保存的连接，与前端 SavedConnection 结构一致。
+type Profile struct {
+	ID                    string                      `json:"id"`
+	Name                  string                      `json:"name"`
+	Config                connection.ConnectionConfig `json:"config"`
+	IncludeDatabases      []string                    `json:"includeDatabases,omitempty"`
+	IncludeRedisDatabases []int                       `json:"includeRedisDatabases,omitempty"`
+	// DatabaseList 数据库列表的系统库隐藏与名称过滤，由后端在 DBGetDatabasesWithOptions 中应用。
+	DatabaseList *connection.DatabaseListOptions `json:"databaseList,omitempty"`
+}
+
+// Status 存储状态，供前端决定是否弹出解锁框。
+type Status struct {
+	Path   string `json:"path"`
+	Mode   string `json:"mode"`
+	Locked bool   `json:"locked"`
+	Count  int    `json:"count"`
+}
+
+type encryptionHeader struct {
+	Mode  string `json:"mode"`
+	Salt  string `json:"salt,omitempty"`
+	Check string `json:"check,omitempty"`
+}
+
+type storeFile struct {
+	Version     int              `json:"version"`
+	Encryption  encryptionHeader `json:"encryption"`
+	Connections []Profile        `json:"connections"`
+	// GridPreferences 数据表格的视图偏好，见 grid_prefs.go。
+	GridPreferences []GridPreference `json:"gridPreferences,omitempty"`
+}
+
+// secretKeyring 系统钥匙串接口，测试中可替换。
+type secretKeyring interface {
+	Get(service, user string) (string, error)
+	Set(service, user, password string) error
+	Delete(service, user string) error
+}
+
+type systemKeyring struct{}
+
+func (systemKeyring) Get(service, user string) (string, error) {
+	return keyring.Get(service, user)
+}
+
+func (systemKeyring) Set(service, user, password string) error {
+	return keyring.Set(service, user, password)
+}
+
+func (systemKeyring) Delete(service, user string) error {
+	return keyring.Delete(service, user)
+}
+
+// Store 连接配置存储，方法可并发调用。
+type Store struct {
+	path    string
+	keyPath string
+	keyring secretKeyring
+
+	mu     sync.Mutex
+	key    []byte           // 已解锁的数据密钥
+	header encryptionHeader // 与 key 对应的加密头，新文件首次写入时使用
+}
+
+// DefaultPath 返回默认存储路径 ~/.gonavi/connections.json。
+func DefaultPath() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "connections.json")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-connections.json")
+}
+
+// New 创建存储；path 为空时使用 DefaultPath。不会立即读写磁盘。
+func New(path string) *Store {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{
+		path:    path,
+		keyPath: filepath.Join(filepath.Dir(path), "connections.key"),
+		keyring: systemKeyring{},
+	}
+}
+
+// secretFields 返回需要加密保存的字段；URI/DSN 可能内嵌密码，一并加密。
+// 跳板机列表会先复制一份，避免加解密时改写调用方共享的切片。
+func secretFields(config *connection.ConnectionConfig) []*string {
+	fields := []*string{
+		&config.Password,
+		&config.SSH.Password,
+		&config.MySQLReplicaPassword,
+		&config.MongoReplicaPassword,
+		&config.URI,
+		&config.DSN,
+	}
+	if len(config.SSH.JumpHosts) > 0 {
+		config.SSH.JumpHosts = append([]connection.SSHConfig(nil), config.SSH.JumpHosts...)
+		for i := range config.SSH.JumpHosts {
+			fields = append(fields, &config.SSH.JumpHosts[i].Password)
+		}
+	}
+	return fields
+}
+
+func (s *Store) read() (storeFile, error) {
+	file := storeFile{Version: storeVersion}
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return file, nil
+		}
+		return file, fmt.Errorf("读取连接配置失败：%w", err)
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return file, nil
+	}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return file, fmt.Errorf("解析连接配置失败：%w", err)
+	}
+	return file, nil
+}
+
+// write 先写临时文件再改名；文件含密文，权限仅当前用户可读写。
+func (s *Store) write(file storeFile) error {
+	file.Version = storeVersion
+	if file.Connections == nil {
+		file.Connections = []Profile{}
+	}
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("创建配置目录失败：%w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		return fmt.Errorf("写入连接配置失败：%w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("写入连接配置失败：%w", err)
+	}
+	return nil
+}
+
+// loadKey 按文件记录的模式取得数据密钥；新文件优先使用系统钥匙串。
+func (s *Store) loadKey(file *storeFile) error {
+	if s.key != nil {
+		if file.Encryption.Mode == "" {
+			file.Encryption = s.header
+		}
+		return nil
+	}
+	switch file.Encryption.Mode {
+	case ModeMaster:
+		return ErrLocked
+	case ModeKeychain:
+		encoded, err := s.keyring.Get(keyringService, keyringUser)
+		if err != nil {
+			return fmt.Errorf("读取系统钥匙串失败：%w", err)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("系统钥匙串中的密钥无效：%w", err)
+		}
+		return s.acceptKey(file, key)
+	case ModeKeyFile:
+		content, err := os.ReadFile(s.keyPath)
+		if err != nil {
+			return fmt.Errorf("读取密钥文件失败：%w", err)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+		if err != nil {
+			return fmt.Errorf("密钥文件无效：%w", err)
+		}
+		return s.acceptKey(file, key)
+	case "":
+		return s.createKey(file)
+	}
+	return fmt.Errorf("未知的加密模式：%s", file.Encryption.Mode)
+}
+
+// acceptKey 用校验串确认密钥与文件匹配后再启用。
+func (s *Store) acceptKey(file *storeFile, key []byte) error {
+	if file.Encryption.Check != "" {
+		text, err := decryptSecret(key, file.Encryption.Check)
+		if err != nil || text != checkPlaintext {
+			return errWrongKey
+		}
+	}
+	s.key = key
+	s.header = file.Encryption
+	return nil
+}
+
+// createKey 生成新的数据密钥并保存到系统钥匙串，失败时写入本地密钥文件。
+func (s *Store) createKey(file *storeFile) error {
+	key, err := randomBytes(keySize)
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(key)
+	mode := ModeKeychain
+	if err := s.keyring.Set(keyringService, keyringUser, encoded); err != nil {
+		logger.Warnf("系统钥匙串不可用，连接密码改用本地密钥文件加密：%v", err)
+		if err := os.MkdirAll(filepath.Dir(s.keyPath), 0o700); err != nil {
+			return fmt.Errorf("创建配置目录失败：%w", err)
+		}
+		if err := os.WriteFile(s.keyPath, []byte(encoded), 0o600); err != nil {
+			return fmt.Errorf("写入密钥文件失败：%w", err)
+		}
+		mode = ModeKeyFile
+	}
+	check, err := encryptSecret(key, checkPlaintext)
+	if err != nil {
+		return err
+	}
+	file.Encryption = encryptionHeader{Mode: mode, Check: check}
+	s.key = key
+	s.header = file.Encryption
+	return nil
+}
+
+func (s *Store) decryptProfile(p Profile) (Profile, error) {
+	for _, field := range secretFields(&p.Config) {
+		plain, err := decryptSecret(s.key, *field)
+		if err != nil {
+			return p, fmt.Errorf("解密连接 %s 失败：%w", p.Name, err)
+		}
+		*field = plain
+	}
+	return p, nil
+}
+
+func (s *Store) encryptProfile(p Profile) (Profile, error) {
+	for _, field := range secretFields(&p.Config) {
+		enc, err := encryptSecret(s.key, *field)
+		if err != nil {
+			return p, err
+		}
+		*field = enc
+	}
+	return p, nil
+}
+
+// loadProfiles 读取并解密全部连接；forWrite 为 false 且尚无配置文件时不生成密钥。调用方需持有 s.mu。
+func (s *Store) loadProfiles(forWrite bool) (storeFile, []Profile, error) {
+	file, err := s.read()
+	if err != nil {
+		return file, nil, err
+	}
+	if !forWrite && file.Encryption.Mode == "" && len(file.Connections) == 0 {
+		return file, []Profile{}, nil
+	}
+	if err := s.loadKey(&file); err != nil {
+		return file, nil, err
+	}
+	profiles := make([]Profile, 0, len(file.Connections))
+	for _, p := range file.Connections {
+		plain, err := s.decryptProfile(p)
+		if err != nil {
+			return file, nil, err
+		}
+		profiles = append(profiles, plain)
+	}
+	return file, profiles, nil
+}
+
+// saveProfiles 加密并写回全部连接。调用方需持有 s.mu 且密钥已就绪。
+func (s *Store) saveProfiles(file storeFile, profiles []Profile) error {
+	file.Connections = make([]Profile, 0, len(profiles))
+	for _, p := range profiles {
+		enc, err := s.encryptProfile(p)
+		if err != nil {
+			return err
+		}
+		file.Connections = append(file.Connections, enc)
+	}
+	return s.write(file)
+}
+
+// List 返回全部连接（密码已解密），顺序与保存顺序一致。
+func (s *Store) List() ([]Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, profiles, err := s.loadProfiles(false)
+	return profiles, err
+}
+
+// Save 新增或按 ID 覆盖一条连接；ID 为空时自动生成。
+func (s *Store) Save(profile Profile) (Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, profiles, err := s.loadProfiles(true)
+	if err != nil {
+		return profile, err
+	}
+	profile.ID = strings.TrimSpace(profile.ID)
+	if profile.ID == "" {
+		raw, err := randomBytes(8)
+		if err != nil {
+			return profile, err
+		}
+		profile.ID = "conn-" + hex.EncodeToString(raw)
+	}
+	replaced := false
+	for i := range profiles {
+		if profiles[i].ID == profile.ID {
+			profiles[i] = profile
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		profiles = append(profiles, profile)
+	}
+	return profile, s.saveProfiles(file, profiles)
+}
+
+// Delete 按 ID 删除连接。
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, profiles, err := s.loadProfiles(false)
+	if err != nil {
+		return err
+	}
+	id = strings.TrimSpace(id)
+	kept := profiles[:0]
+	for _, p := range profiles {
+		if p.ID != id {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == len(profiles) {
+		return fmt.Errorf("连接不存在：%s", id)
+	}
+	prefs := file.GridPreferences[:0]
+	for _, p := range file.GridPreferences {
+		if p.ConnectionID != id {
+			prefs = append(prefs, p)
+		}
+	}
+	file.GridPreferences = prefs
+	return s.saveProfiles(file, kept)
+}
+
+// Unlock 主密码模式下验证主密码并解锁。
+func (s *Store) Unlock(password string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	if file.Encryption.Mode != ModeMaster {
+		return s.loadKey(&file)
+	}
+	salt, err := base64.StdEncoding.DecodeString(file.Encryption.Salt)
+	if err != nil {
+		return fmt.Errorf("主密码盐值无效：%w", err)
+	}
+	key, err := deriveMasterKey(password, salt)
+	if err != nil {
+		return err
+	}
+	return s.acceptKey(&file, key)
+}
+
+// SetMasterPassword 启用、修改或关闭（next 为空）主密码，并用新密钥重新加密全部连接。
+// 当前为主密码模式时 current 必须正确；关闭后改回系统钥匙串保存密钥。
+func (s *Store) SetMasterPassword(current string, next string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	if file.Encryption.Mode == ModeMaster {
+		salt, err := base64.StdEncoding.DecodeString(file.Encryption.Salt)
+		if err != nil {
+			return fmt.Errorf("主密码盐值无效：%w", err)
+		}
+		key, err := deriveMasterKey(current, salt)
+		if err != nil {
+			return err
+		}
+		s.key = nil
+		if err := s.acceptKey(&file, key); err != nil {
+			return err
+		}
+	}
+	file, profiles, err := s.loadProfiles(true)
+	if err != nil {
+		return err
+	}
+	previousMode := file.Encryption.Mode
+
+	s.key = nil
+	if next == "" {
+		file.Encryption = encryptionHeader{}
+		if err := s.createKey(&file); err != nil {
+			return err
+		}
+	} else {
+		salt, err := randomBytes(saltSize)
+		if err != nil {
+			return err
+		}
+		key, err := deriveMasterKey(next, salt)
+		if err != nil {
+			return err
+		}
+		check, err := encryptSecret(key, checkPlaintext)
+		if err != nil {
+			return err
+		}
+		file.Encryption = encryptionHeader{Mode: ModeMaster, Salt: base64.StdEncoding.EncodeToString(salt), Check: check}
+		s.key = key
+		s.header = file.Encryption
+	}
+	if err := s.saveProfiles(file, profiles); err != nil {
+		// 文件未更新，丢弃新密钥，下次按原模式重新加载
+		s.key = nil
+		return err
+	}
+	// 旧密钥不再使用，清理残留
+	if next != "" && previousMode == ModeKeychain {
+		_ = s.keyring.Delete(keyringService, keyringUser)
+	}
+	if file.Encryption.Mode != ModeKeyFile && previousMode == ModeKeyFile {
+		_ = os.Remove(s.keyPath)
+	}
+	return nil
+}
+
+// Status 返回存储路径、加密模式与是否需要解锁。
+func (s *Store) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := Status{Path: s.path}
+	file, err := s.read()
+	if err != nil {
+		return status
+	}
+	status.Mode = file.Encryption.Mode
+	status.Count = len(file.Connections)
+	status.Locked = file.Encryption.Mode == ModeMaster && s.key == nil
+	return status
+}
@@ -0,0 +1,157 @@
+package connectionstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+type memoryKeyring struct {
+	values map[string]string
+	fail   bool
+}
+
+func (m *memoryKeyring) Get(service, user string) (string, error) {
+	v, ok := m.values[service+"/"+user]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return v, nil
+}
+
+func (m *memoryKeyring) Set(service, user, password string) error {
+	if m.fail {
+		return errors.New("keyring unavailable")
+	}
+	m.values[service+"/"+user] = password
+	return nil
+}
+
+func (m *memoryKeyring) Delete(service, user string) error {
+	delete(m.values, service+"/"+user)
+	return nil
+}
+
+func newTestStore(t *testing.T, kr *memoryKeyring) *Store {
+	t.Helper()
+	s := New(filepath.Join(t.TempDir(), "connections.json"))
+	s.keyring = kr
+	return s
+}
+
+func TestStoreEncryptsSecretsAtRest(t *testing.T) {
+	kr := &memoryKeyring{values: map[string]string{}}
+	s := newTestStore(t, kr)
+	if list, err := s.List(); err != nil || len(list) != 0 {
+		t.Fatalf("expected empty store, got %+v err=%v", list, err)
+	}
+	saved, err := s.Save(Profile{Name: "prod", Config: connection.ConnectionConfig{Type: "mysql", Host: "db", User: "root", Password: "hunter2", SSH: connection.SSHConfig{Password: "sshpw"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ID == "" {
+		t.Fatal("expected generated id")
+	}
+	content, _ := os.ReadFile(s.path)
+	if strings.Contains(string(content), "hunter2") || strings.Contains(string(content), "sshpw") {
+		t.Fatalf("plaintext secret written to disk: %s", content)
+	}
+
+	// 新实例从钥匙串取回密钥
+	reopened := New(s.path)
+	reopened.keyring = kr
+	list, err := reopened.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Config.Password != "hunter2" || list[0].Config.SSH.Password != "sshpw" || reopened.Status().Mode != ModeKeychain {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+	if err := reopened.Delete(saved.ID); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := reopened.List(); len(list) != 0 {
+		t.Fatalf("expected empty list, got %+v", list)
+	}
+}
+
+func TestStoreEncryptsJumpHostPasswords(t *testing.T) {
+	s := newTestStore(t, &memoryKeyring{values: map[string]string{}})
+	jumps := []connection.SSHConfig{{Host: "jump1", Password: "hop1pw"}, {Host: "jump2", Password: "hop2pw"}}
+	profile := Profile{Name: "deep", Config: connection.ConnectionConfig{Type: "postgres", UseSSH: true, SSH: connection.SSHConfig{Host: "bastion", JumpHosts: jumps}}}
+	if _, err := s.Save(profile); err != nil {
+		t.Fatal(err)
+	}
+	if jumps[0].Password != "hop1pw" {
+		t.Fatal("caller's jump host slice was modified")
+	}
+	content, _ := os.ReadFile(s.path)
+	if strings.Contains(string(content), "hop1pw") || strings.Contains(string(content), "hop2pw") {
+		t.Fatalf("plaintext jump host secret written to disk: %s", content)
+	}
+	list, err := s.List()
+	if err != nil || len(list) != 1 {
+		t.Fatalf("unexpected list: %+v err=%v", list, err)
+	}
+	if got := list[0].Config.SSH.JumpHosts; len(got) != 2 || got[0].Password != "hop1pw" || got[1].Password != "hop2pw" {
+		t.Fatalf("unexpected jump hosts: %+v", got)
+	}
+}
+
+func TestStoreFallsBackToKeyFile(t *testing.T) {
+	kr := &memoryKeyring{values: map[string]string{}, fail: true}
+	s := newTestStore(t, kr)
+	if _, err := s.Save(Profile{ID: "a", Name: "a", Config: connection.ConnectionConfig{Password: "pw"}}); err != nil {
+		t.Fatal(err)
+	}
+	if s.Status().Mode != ModeKeyFile {
+		t.Fatalf("expected keyfile mode, got %+v", s.Status())
+	}
+	if _, err := os.Stat(s.keyPath); err != nil {
+		t.Fatalf("key file missing: %v", err)
+	}
+}
+
+func TestStoreMasterPassword(t *testing.T) {
+	kr := &memoryKeyring{values: map[string]string{}}
+	s := newTestStore(t, kr)
+	if _, err := s.Save(Profile{ID: "a", Name: "a", Config: connection.ConnectionConfig{Password: "pw"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetMasterPassword("", "m@ster"); err != nil {
+		t.Fatal(err)
+	}
+	if len(kr.values) != 0 {
+		t.Fatal("keychain key should be removed after enabling master password")
+	}
+
+	reopened := New(s.path)
+	reopened.keyring = kr
+	if !reopened.Status().Locked {
+		t.Fatal("expected locked store")
+	}
+	if _, err := reopened.List(); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+	if err := reopened.Unlock("wrong"); err == nil {
+		t.Fatal("expected wrong password error")
+	}
+	if err := reopened.Unlock("m@ster"); err != nil {
+		t.Fatal(err)
+	}
+	list, err := reopened.List()
+	if err != nil || len(list) != 1 || list[0].Config.Password != "pw" {
+		t.Fatalf("unexpected list: %+v err=%v", list, err)
+	}
+
+	if err := reopened.SetMasterPassword("m@ster", ""); err != nil {
+		t.Fatal(err)
+	}
+	if st := reopened.Status(); st.Mode != ModeKeychain || st.Locked {
+		t.Fatalf("unexpected status after disabling master password: %+v", st)
+	}
+}
@@ -0,0 +1,227 @@
+package connimport
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+const (
+	dbeaverDataSourcesFile = "data-sources.json"
+	dbeaverCredentialsFile = "credentials-config.json"
+)
+
+// DBeaver 对 credentials-config.json 使用固定密钥的 AES-128-CBC，文件前 16 字节为 IV。
+var dbeaverCredentialsKey, _ = hex.DecodeString("babb4a9f774ab853c96c2d653dfe544a")
+
+type dbeaverDataSources struct {
+	Connections map[string]dbeaverConnection `json:"connections"`
+}
+
+type dbeaverConnection struct {
+	Provider      string               `json:"provider"`
+	Driver        string               `json:"driver"`
+	Name          string               `json:"name"`
+	Configuration dbeaverConfiguration `json:"configuration"`
+}
+
+type dbeaverConfiguration struct {
+	Host     string                    `json:"host"`
+	Port     json.RawMessage           `json:"port"`
+	Database string                    `json:"database"`
+	URL      string                    `json:"url"`
+	User     string                    `json:"user"`
+	Password string                    `json:"password"`
+	Handlers map[string]dbeaverHandler `json:"handlers"`
+}
+
+type dbeaverHandler struct {
+	Enabled    bool                   `json:"enabled"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type dbeaverCredential struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// parseDBeaverFile 读取 data-sources.json（或其所在目录），同目录存在 credentials-config.json 时合并账号密码。
+func parseDBeaverFile(path string) (*Result, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, dbeaverDataSourcesFile)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var credentials []byte
+	credPath := filepath.Join(filepath.Dir(path), dbeaverCredentialsFile)
+	if data, err := os.ReadFile(credPath); err == nil {
+		credentials = data
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return parseDBeaver(raw, credentials)
+}
+
+func parseDBeaver(raw []byte, encryptedCredentials []byte) (*Result, error) {
+	var sources dbeaverDataSources
+	if err := json.Unmarshal(raw, &sources); err != nil {
+		return nil, fmt.Errorf("解析 DBeaver data-sources.json 失败：%w", err)
+	}
+	result := &Result{Format: FormatDBeaver}
+	credentials := map[string]map[string]dbeaverCredential{}
+	if len(encryptedCredentials) > 0 {
+		plain, err := decryptDBeaverCredentials(encryptedCredentials)
+		if err == nil {
+			err = json.Unmarshal(plain, &credentials)
+		}
+		if err != nil {
+			result.warnf("无法解密 %s，导入的连接不含密码：%v", dbeaverCredentialsFile, err)
+		}
+	}
+
+	ids := make([]string, 0, len(sources.Connections))
+	for id := range sources.Connections {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		item := sources.Connections[id]
+		name := item.Name
+		if name == "" {
+			name = id
+		}
+		config, err := dbeaverConfig(item)
+		if err != nil {
+			result.warnf("连接 %s：%v，已跳过", name, err)
+			continue
+		}
+		if cred, ok := credentials[id]["#connection"]; ok {
+			if cred.User != "" {
+				config.User = cred.User
+			}
+			if cred.Password != "" {
+				config.Password = cred.Password
+			}
+		}
+		config.SavePassword = config.Password != ""
+		if tunnel, ok := item.Configuration.Handlers["ssh_tunnel"]; ok && tunnel.Enabled {
+			config.UseSSH = true
+			config.SSH.Host = propertyString(tunnel.Properties, "host")
+			config.SSH.Port, _ = strconv.Atoi(propertyString(tunnel.Properties, "port"))
+			if config.SSH.Port <= 0 {
+				config.SSH.Port = 22
+			}
+			config.SSH.User = propertyString(tunnel.Properties, "user")
+			if strings.EqualFold(propertyString(tunnel.Properties, "authType"), "PUBLIC_KEY") {
+				config.SSH.KeyPath = propertyString(tunnel.Properties, "keyPath")
+			}
+			if cred, ok := credentials[id]["network/ssh_tunnel"]; ok {
+				if cred.User != "" {
+					config.SSH.User = cred.User
+				}
+				if config.SSH.KeyPath == "" {
+					config.SSH.Password = cred.Password
+				}
+			}
+		}
+		result.add(name, config)
+	}
+	return result, nil
+}
+
+func dbeaverConfig(item dbeaverConnection) (connection.ConnectionConfig, error) {
+	dbType := normalizeType(item.Driver)
+	if dbType == "" {
+		dbType = normalizeType(item.Provider)
+	}
+	if dbType == "" {
+		// generic 驱动：从驱动 ID 中识别，例如 sqlite_jdbc、duckdb_jdbc、com_clickhouse
+		lower := strings.ToLower(item.Driver)
+		for _, candidate := range []string{"sqlite", "duckdb", "clickhouse", "dameng", "kingbase", "highgo", "vastbase", "tdengine", "mariadb"} {
+			if strings.Contains(lower, candidate) {
+				dbType = normalizeType(candidate)
+				break
+			}
+		}
+	}
+	if dbType == "" {
+		return connection.ConnectionConfig{}, fmt.Errorf("暂不支持的驱动 %s/%s", item.Provider, item.Driver)
+	}
+
+	cfg := item.Configuration
+	config := connection.ConnectionConfig{
+		Type:     dbType,
+		Host:     strings.TrimSpace(cfg.Host),
+		Database: strings.TrimSpace(cfg.Database),
+		User:     cfg.User,
+		Password: cfg.Password,
+	}
+	config.Port, _ = strconv.Atoi(strings.Trim(string(cfg.Port), `" `))
+	if isFileDatabase(dbType) {
+		if config.Database != "" {
+			config.Host = config.Database
+		}
+		config.Database = ""
+		config.Port = 0
+	}
+	if config.Host == "" && cfg.URL != "" {
+		parsed, _, err := parseConnectionURI(cfg.URL)
+		if err != nil {
+			return connection.ConnectionConfig{}, fmt.Errorf("无法解析连接 URL：%w", err)
+		}
+		parsed.Type = dbType
+		if config.User != "" {
+			parsed.User = config.User
+		}
+		if config.Password != "" {
+			parsed.Password = config.Password
+		}
+		config = parsed
+	}
+	if config.Host == "" {
+		return connection.ConnectionConfig{}, fmt.Errorf("缺少主机地址")
+	}
+	if !isFileDatabase(dbType) && config.Port <= 0 {
+		config.Port = defaultPort(dbType)
+	}
+	return config, nil
+}
+
+func decryptDBeaverCredentials(raw []byte) ([]byte, error) {
+	if len(raw) < 2*aes.BlockSize || len(raw)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("文件长度无效")
+	}
+	block, err := aes.NewCipher(dbeaverCredentialsKey)
+	if err != nil {
+		return nil, err
+	}
+	iv, data := raw[:aes.BlockSize], raw[aes.BlockSize:]
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+	return pkcs7Unpad(plain, aes.BlockSize)
+}
+
+func propertyString(props map[string]interface{}, key string) string {
+	switch value := props[key].(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case float64:
+		return strconv.Itoa(int(value))
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
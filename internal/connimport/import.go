@@ -0,0 +1,200 @@
+// Package connimport 将其他客户端导出的连接配置（Navicat NCX、DBeaver data-sources.json、
+// URI 列表）转换为 GoNavi 的连接配置。
+package connimport
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/connectionstore"
+)
+
+const (
+	FormatAuto    = "auto"
+	FormatNavicat = "navicat"
+	FormatDBeaver = "dbeaver"
+	FormatURI     = "uri"
+)
+
+// Result 解析结果；无法识别或解密失败的条目记录在 Warnings 中，不中断整体导入。
+type Result struct {
+	Format      string                    `json:"format"`
+	Connections []connectionstore.Profile `json:"connections"`
+	Warnings    []string                  `json:"warnings,omitempty"`
+}
+
+func (r *Result) warnf(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (r *Result) add(name string, config connection.ConnectionConfig) {
+	if strings.TrimSpace(name) == "" {
+		name = defaultName(config)
+	}
+	r.Connections = append(r.Connections, connectionstore.Profile{ID: newID(), Name: name, Config: config})
+}
+
+// ParseFile 按 format 解析文件；format 为空或 auto 时根据扩展名与内容判断。
+// DBeaver 格式可以传 data-sources.json 或其所在目录，同目录的 credentials-config.json 会自动解密合并。
+func ParseFile(path string, format string) (*Result, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, fmt.Errorf("文件路径不能为空")
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" || format == FormatAuto {
+		detected, err := detectFormat(path)
+		if err != nil {
+			return nil, err
+		}
+		format = detected
+	}
+	switch format {
+	case FormatNavicat:
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return parseNavicat(raw)
+	case FormatDBeaver:
+		return parseDBeaverFile(path)
+	case FormatURI:
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return parseURIList(string(raw)), nil
+	default:
+		return nil, fmt.Errorf("不支持的导入格式：%s", format)
+	}
+}
+
+func detectFormat(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return FormatDBeaver, nil
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ncx", ".xml":
+		return FormatNavicat, nil
+	case ".json":
+		return FormatDBeaver, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf")))
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return FormatNavicat, nil
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return FormatDBeaver, nil
+	default:
+		return FormatURI, nil
+	}
+}
+
+// normalizeType 将外部工具的数据库类型名映射为 GoNavi 类型；无法识别时返回空串。
+func normalizeType(raw string) string {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	switch value {
+	case "mysql", "mysql8", "mysql5":
+		return "mysql"
+	case "mariadb":
+		return "mariadb"
+	case "postgres", "postgresql", "pgsql", "pg", "postgres-jdbc":
+		return "postgres"
+	case "oracle", "oracle_thin":
+		return "oracle"
+	case "sqlserver", "mssql", "microsoft", "mssql_jdbc_ms_new", "jtds":
+		return "sqlserver"
+	case "sqlite", "sqlite3", "sqlite_jdbc":
+		return "sqlite"
+	case "duckdb", "duckdb_jdbc":
+		return "duckdb"
+	case "mongodb", "mongo", "mongodb+srv":
+		return "mongodb"
+	case "redis", "rediss":
+		return "redis"
+	case "clickhouse", "com_clickhouse", "yandex_clickhouse":
+		return "clickhouse"
+	case "dameng", "dm", "dm8":
+		return "dameng"
+	case "kingbase", "kingbase8", "kingbasees":
+		return "kingbase"
+	case "highgo":
+		return "highgo"
+	case "vastbase":
+		return "vastbase"
+	case "doris", "diros":
+		return "diros"
+	case "tdengine", "taos":
+		return "tdengine"
+	}
+	return ""
+}
+
+func defaultPort(dbType string) int {
+	switch dbType {
+	case "mysql", "mariadb":
+		return 3306
+	case "diros":
+		return 9030
+	case "postgres", "vastbase":
+		return 5432
+	case "redis":
+		return 6379
+	case "tdengine":
+		return 6041
+	case "clickhouse":
+		return 9000
+	case "oracle":
+		return 1521
+	case "dameng":
+		return 5236
+	case "kingbase":
+		return 54321
+	case "sqlserver":
+		return 1433
+	case "mongodb":
+		return 27017
+	case "highgo":
+		return 5866
+	default:
+		return 0
+	}
+}
+
+func isFileDatabase(dbType string) bool {
+	return dbType == "sqlite" || dbType == "duckdb"
+}
+
+func defaultName(config connection.ConnectionConfig) string {
+	if isFileDatabase(config.Type) {
+		return filepath.Base(config.Host)
+	}
+	name := config.Host
+	if config.Port > 0 {
+		name = fmt.Sprintf("%s:%d", name, config.Port)
+	}
+	if config.Database != "" {
+		name += "/" + config.Database
+	}
+	return name
+}
+
+func newID() string {
+	raw := make([]byte, 8)
+	_, _ = rand.Read(raw)
+	return "conn-" + hex.EncodeToString(raw)
+}
@@ -0,0 +1,198 @@
+package connimport
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"GoNavi-Wails/internal/connection"
+
+	"golang.org/x/crypto/blowfish"
+)
+
+// Navicat 导出连接时使用的固定密钥：12 及以上版本为 AES-128-CBC，11 及以前为 Blowfish 变种 CBC。
+var (
+	navicatAESKey      = []byte("libcckeylibcckey")
+	navicatAESIV       = []byte("libcciv libcciv ")
+	navicatBlowfishKey = sha1.Sum([]byte("3DC5CA39"))
+)
+
+type navicatFile struct {
+	Version     string              `xml:"Ver,attr"`
+	Connections []navicatConnection `xml:"Connection"`
+}
+
+type navicatConnection struct {
+	Attrs []xml.Attr `xml:",any,attr"`
+}
+
+// attr 属性名大小写在不同版本间不一致，统一按不区分大小写读取。
+func (c navicatConnection) attr(names ...string) string {
+	for _, name := range names {
+		for _, a := range c.Attrs {
+			if strings.EqualFold(a.Name.Local, name) {
+				if value := strings.TrimSpace(a.Value); value != "" {
+					return value
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (c navicatConnection) flag(name string) bool {
+	return strings.EqualFold(c.attr(name), "true")
+}
+
+func parseNavicat(raw []byte) (*Result, error) {
+	var file navicatFile
+	if err := xml.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("解析 Navicat NCX 文件失败：%w", err)
+	}
+	result := &Result{Format: FormatNavicat}
+	legacy := strings.HasPrefix(strings.TrimSpace(file.Version), "1.1")
+	for _, item := range file.Connections {
+		name := item.attr("ConnectionName")
+		dbType := normalizeType(item.attr("ConnType"))
+		if dbType == "" {
+			result.warnf("连接 %s：暂不支持的类型 %s，已跳过", name, item.attr("ConnType"))
+			continue
+		}
+		config := connection.ConnectionConfig{
+			Type:     dbType,
+			Host:     item.attr("Host"),
+			User:     item.attr("UserName"),
+			Database: item.attr("Database", "InitialDatabase", "ServiceName"),
+		}
+		config.Port, _ = strconv.Atoi(item.attr("Port"))
+		if isFileDatabase(dbType) {
+			config.Host = item.attr("DatabaseFileName", "Host")
+			config.Port = 0
+			config.Database = ""
+		} else if config.Port <= 0 {
+			config.Port = defaultPort(dbType)
+		}
+		if secret := item.attr("Password"); secret != "" {
+			password, err := decryptNavicatPassword(secret, legacy)
+			if err != nil {
+				result.warnf("连接 %s：密码解密失败，请导入后重新填写", name)
+			}
+			config.Password = password
+			config.SavePassword = password != ""
+		}
+		if item.flag("SSH") {
+			config.UseSSH = true
+			config.SSH.Host = item.attr("SSH_Host")
+			config.SSH.Port, _ = strconv.Atoi(item.attr("SSH_Port"))
+			if config.SSH.Port <= 0 {
+				config.SSH.Port = 22
+			}
+			config.SSH.User = item.attr("SSH_UserName")
+			if strings.EqualFold(item.attr("SSH_AuthenMethod"), "PUBLICKEY") {
+				config.SSH.KeyPath = item.attr("SSH_PrivateKey")
+			} else if secret := item.attr("SSH_Password"); secret != "" {
+				password, err := decryptNavicatPassword(secret, legacy)
+				if err != nil {
+					result.warnf("连接 %s：SSH 密码解密失败，请导入后重新填写", name)
+				}
+				config.SSH.Password = password
+			}
+		}
+		result.add(name, config)
+	}
+	return result, nil
+}
+
+// decryptNavicatPassword 解密 NCX 中的十六进制密码；先按文件版本选择算法，失败再尝试另一种。
+func decryptNavicatPassword(secret string, legacy bool) (string, error) {
+	raw, err := hex.DecodeString(secret)
+	if err != nil {
+		return "", fmt.Errorf("密码格式错误：%w", err)
+	}
+	decoders := []func([]byte) (string, error){decryptNavicatAES, decryptNavicatBlowfish}
+	if legacy {
+		decoders[0], decoders[1] = decoders[1], decoders[0]
+	}
+	for _, decode := range decoders {
+		if plain, err := decode(raw); err == nil {
+			return plain, nil
+		}
+	}
+	return "", fmt.Errorf("无法解密 Navicat 密码")
+}
+
+func decryptNavicatAES(raw []byte) (string, error) {
+	if len(raw) == 0 || len(raw)%aes.BlockSize != 0 {
+		return "", fmt.Errorf("密文长度无效")
+	}
+	block, err := aes.NewCipher(navicatAESKey)
+	if err != nil {
+		return "", err
+	}
+	plain := make([]byte, len(raw))
+	cipher.NewCBCDecrypter(block, navicatAESIV).CryptBlocks(plain, raw)
+	plain, err = pkcs7Unpad(plain, aes.BlockSize)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(plain) {
+		return "", fmt.Errorf("解密结果无效")
+	}
+	return string(plain), nil
+}
+
+// decryptNavicatBlowfish 解密 Navicat 11 的 Blowfish 变种：IV 为全 0xFF 块加密结果，
+// 整块按 P = D(C) ^ CV、CV ^= C 推进，末尾不足一块的部分与 E(CV) 异或。
+func decryptNavicatBlowfish(raw []byte) (string, error) {
+	block, err := blowfish.NewCipher(navicatBlowfishKey[:])
+	if err != nil {
+		return "", err
+	}
+	cv := bytes.Repeat([]byte{0xFF}, blowfish.BlockSize)
+	block.Encrypt(cv, cv)
+	plain := make([]byte, 0, len(raw))
+	buf := make([]byte, blowfish.BlockSize)
+	full := len(raw) / blowfish.BlockSize * blowfish.BlockSize
+	for i := 0; i < full; i += blowfish.BlockSize {
+		chunk := raw[i : i+blowfish.BlockSize]
+		block.Decrypt(buf, chunk)
+		for j := range buf {
+			buf[j] ^= cv[j]
+			cv[j] ^= chunk[j]
+		}
+		plain = append(plain, buf...)
+	}
+	if rest := raw[full:]; len(rest) > 0 {
+		block.Encrypt(cv, cv)
+		for j := range rest {
+			plain = append(plain, rest[j]^cv[j])
+		}
+	}
+	if !utf8.Valid(plain) {
+		return "", fmt.Errorf("解密结果无效")
+	}
+	return string(plain), nil
+}
+
+func pkcs7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 || len(data)%blockSize != 0 {
+		return nil, fmt.Errorf("填充无效")
+	}
+	pad := int(data[len(data)-1])
+	if pad == 0 || pad > blockSize || pad > len(data) {
+		return nil, fmt.Errorf("填充无效")
+	}
+	for _, b := range data[len(data)-pad:] {
+		if int(b) != pad {
+			return nil, fmt.Errorf("填充无效")
+		}
+	}
+	return data[:len(data)-pad], nil
+}
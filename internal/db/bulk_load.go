@@ -0,0 +1,26 @@
+package db
+
+import (
+	"context"
+	"strings"
+)
+
+// BulkLoader 由具备高速批量写入通道的驱动实现（MySQL LOAD DATA、PostgreSQL COPY、SQL Server bulk copy）。
+// BulkLoadMethod 返回空字符串表示当前连接不支持，调用方应回退为批量 INSERT。
+type BulkLoader interface {
+	BulkLoadMethod() string
+	BulkLoad(ctx context.Context, tableName string, columns []string, rows [][]interface{}) (int64, error)
+}
+
+// splitBulkLoadTable 拆分 schema.table 形式的表名，并去除标识符外层引号。
+func splitBulkLoadTable(tableName string) (string, string) {
+	trim := func(s string) string {
+		s = strings.TrimSpace(s)
+		return strings.Trim(s, "`\"[]")
+	}
+	raw := strings.TrimSpace(tableName)
+	if parts := strings.SplitN(raw, ".", 2); len(parts) == 2 {
+		return trim(parts[0]), trim(parts[1])
+	}
+	return "", trim(raw)
+}
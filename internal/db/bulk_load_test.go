@@ -0,0 +1,39 @@
+package db
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWriteMySQLLoadDataValue(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, `\N`},
+		{true, "1"},
+		{false, "0"},
+		{"a\tb\nc\\d\r\x00", `a\tb\nc\\d\r\0`},
+		{[]byte("x\ty"), `x\ty`},
+		{0.1, "0.1"},
+		{int64(42), "42"},
+		{time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), "2024-05-06 07:08:09"},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		writeMySQLLoadDataValue(&buf, c.value)
+		if buf.String() != c.want {
+			t.Errorf("writeMySQLLoadDataValue(%#v) = %q, want %q", c.value, buf.String(), c.want)
+		}
+	}
+}
+
+func TestSplitBulkLoadTable(t *testing.T) {
+	if schema, table := splitBulkLoadTable("`shop`.`orders`"); schema != "shop" || table != "orders" {
+		t.Fatalf("got %q.%q", schema, table)
+	}
+	if schema, table := splitBulkLoadTable(" [orders] "); schema != "" || table != "orders" {
+		t.Fatalf("got %q.%q", schema, table)
+	}
+}
@@ -0,0 +1,268 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+)
+
+// ChangeStatement 表格编辑提交时执行的一条语句。SQL 与 Args 即实际发送给驱动的内容；
+// Rendered 为把参数内联为字面量后的文本，仅供预览与复制，执行时不使用。
+type ChangeStatement struct {
+	Kind     string        `json:"kind"` // delete / update / insert
+	SQL      string        `json:"sql"`
+	Args     []interface{} `json:"args"`
+	Rendered string        `json:"rendered"`
+}
+
+// ChangePlanner 由通过 SQL 提交 ChangeSet 的驱动实现，返回 ApplyChanges 将在同一事务中按顺序执行的语句。
+type ChangePlanner interface {
+	PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error)
+}
+
+// changeSQLStyle 生成变更语句时的方言：标识符引用与占位符取自 dialect，normalizeArg 为驱动特有的参数预处理。
+type changeSQLStyle struct {
+	dbType        string
+	defaultSchema string // 表名未带 schema 时补上的 schema，为空则只引用表名
+	normalizeArg  func(value interface{}) interface{}
+}
+
+var (
+	// MySQL 族的日期时间值统一为 MySQL 可接受的格式
+	mysqlChangeStyle     = changeSQLStyle{dbType: "mysql", normalizeArg: normalizeMySQLDateTimeValue}
+	postgresChangeStyle  = changeSQLStyle{dbType: "postgres"}
+	sqlServerChangeStyle = changeSQLStyle{dbType: "sqlserver", defaultSchema: "dbo"}
+	oracleChangeStyle    = changeSQLStyle{dbType: "oracle"}
+	sqliteChangeStyle    = changeSQLStyle{dbType: "sqlite"}
+	duckdbChangeStyle    = changeSQLStyle{dbType: "duckdb"}
+)
+
+// changeSQLStyleFor 返回内置驱动的变更语句风格；不通过 SQL 提交变更的类型返回 false。
+func changeSQLStyleFor(dbType string) (changeSQLStyle, bool) {
+	switch normalizeRuntimeDriverType(dbType) {
+	case "mysql", "mariadb", "diros", "sphinx":
+		return mysqlChangeStyle, true
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return postgresChangeStyle, true
+	case "sqlserver":
+		return sqlServerChangeStyle, true
+	case "oracle", "dameng":
+		return oracleChangeStyle, true
+	case "sqlite":
+		return sqliteChangeStyle, true
+	case "duckdb":
+		return duckdbChangeStyle, true
+	}
+	return changeSQLStyle{}, false
+}
+
+// customChangeSQLStyle 按自定义连接的驱动名推断方言，无法识别时按 SQLite 的写法（双引号、? 占位符）。
+func customChangeSQLStyle(driver string) changeSQLStyle {
+	driver = strings.ToLower(strings.TrimSpace(driver))
+	switch {
+	case strings.Contains(driver, "mysql"):
+		return changeSQLStyle{dbType: "mysql"}
+	case strings.Contains(driver, "postgres") || strings.Contains(driver, "kingbase") || strings.Contains(driver, "pg"):
+		return postgresChangeStyle
+	case strings.Contains(driver, "oracle") || strings.Contains(driver, "ora") || strings.Contains(driver, "dm") || strings.Contains(driver, "dameng"):
+		return oracleChangeStyle
+	}
+	return sqliteChangeStyle
+}
+
+// quoteIdent 先去掉名称两侧已有的引号再按方言引用，兼容前端传入已引用的名称。
+func (s changeSQLStyle) quoteIdent(name string) string {
+	open, close := dialect.IdentQuotes(s.dbType)
+	n := strings.Trim(strings.TrimSpace(name), open+close)
+	if n == "" {
+		return open + close
+	}
+	return dialect.QuoteIdent(s.dbType, n)
+}
+
+// qualifyTable 按第一个 "." 拆分 schema.table 后分别引用。
+func (s changeSQLStyle) qualifyTable(tableName string) string {
+	schema := s.defaultSchema
+	table := strings.TrimSpace(tableName)
+	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
+		schema = strings.TrimSpace(parts[0])
+		table = strings.TrimSpace(parts[1])
+	}
+	if schema != "" {
+		return fmt.Sprintf("%s.%s", s.quoteIdent(schema), s.quoteIdent(table))
+	}
+	return s.quoteIdent(table)
+}
+
+// statement 用同一个拼接函数分别生成参数化 SQL 与内联字面量后的预览文本，保证两者结构一致。
+func (s changeSQLStyle) statement(kind string, build func(bind func(value interface{}) string) string) ChangeStatement {
+	normalize := func(value interface{}) interface{} {
+		if s.normalizeArg != nil {
+			return s.normalizeArg(value)
+		}
+		return value
+	}
+	stmt := ChangeStatement{Kind: kind, Args: []interface{}{}}
+	stmt.SQL = build(func(value interface{}) string {
+		stmt.Args = append(stmt.Args, normalize(value))
+		return dialect.Placeholder(s.dbType, len(stmt.Args))
+	})
+	stmt.Rendered = build(func(value interface{}) string {
+		return changeValueLiteral(s.dbType, normalize(value))
+	})
+	return stmt
+}
+
+// assignments 按列名顺序生成 col = ? 列表，用 sep 连接，既用于 SET 也用于 WHERE。
+func (s changeSQLStyle) assignments(row map[string]interface{}, sep string, bind func(interface{}) string) string {
+	parts := make([]string, 0, len(row))
+	for _, col := range sortedChangeColumns(row) {
+		parts = append(parts, fmt.Sprintf("%s = %s", s.quoteIdent(col), bind(row[col])))
+	}
+	return strings.Join(parts, sep)
+}
+
+// buildChangeStatements 按删除、更新、插入的顺序生成语句，列按名称排序，同一 ChangeSet 的输出稳定。
+func buildChangeStatements(style changeSQLStyle, tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	table := style.qualifyTable(tableName)
+	var statements []ChangeStatement
+
+	for _, pk := range changes.Deletes {
+		if len(pk) == 0 {
+			continue
+		}
+		statements = append(statements, style.statement("delete", func(bind func(interface{}) string) string {
+			return fmt.Sprintf("DELETE FROM %s WHERE %s", table, style.assignments(pk, " AND ", bind))
+		}))
+	}
+
+	for _, update := range changes.Updates {
+		if len(update.Values) == 0 {
+			continue
+		}
+		if len(update.Keys) == 0 {
+			return nil, fmt.Errorf("update requires keys")
+		}
+		statements = append(statements, style.statement("update", func(bind func(interface{}) string) string {
+			sets := style.assignments(update.Values, ", ", bind)
+			return fmt.Sprintf("UPDATE %s SET %s WHERE %s", table, sets, style.assignments(update.Keys, " AND ", bind))
+		}))
+	}
+
+	for _, row := range changes.Inserts {
+		if len(row) == 0 {
+			continue
+		}
+		columns := sortedChangeColumns(row)
+		statements = append(statements, style.statement("insert", func(bind func(interface{}) string) string {
+			cols := make([]string, 0, len(columns))
+			values := make([]string, 0, len(columns))
+			for _, col := range columns {
+				cols = append(cols, style.quoteIdent(col))
+				values = append(values, bind(row[col]))
+			}
+			return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(cols, ", "), strings.Join(values, ", "))
+		}))
+	}
+
+	return statements, nil
+}
+
+func sortedChangeColumns(row map[string]interface{}) []string {
+	cols := make([]string, 0, len(row))
+	for col := range row {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+	return cols
+}
+
+// execChangeStatements 依次执行语句，错误信息沿用各驱动原有的 "<kind> error" 格式；
+// requireAffected 为 true 时未影响任何行视为失败，用于发现目标行已被他人修改或删除。
+func execChangeStatements(statements []ChangeStatement, exec func(query string, args []interface{}) (sql.Result, error), requireAffected bool) error {
+	for _, stmt := range statements {
+		res, err := exec(stmt.SQL, stmt.Args)
+		if err != nil {
+			return fmt.Errorf("%s error: %v", stmt.Kind, err)
+		}
+		if !requireAffected {
+			continue
+		}
+		if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+			switch stmt.Kind {
+			case "delete":
+				return fmt.Errorf("删除未生效：未匹配到任何行")
+			case "update":
+				return fmt.Errorf("更新未生效：未匹配到任何行")
+			default:
+				return fmt.Errorf("插入未生效：未影响任何行")
+			}
+		}
+	}
+	return nil
+}
+
+// changeValueLiteral 按 dbType 的方言把参数格式化为 SQL 字面量，仅用于预览文本。
+func changeValueLiteral(dbType string, value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		// SQL Server、Oracle 没有布尔字面量；PostgreSQL 的 boolean 列不接受 1/0
+		switch dbType {
+		case "sqlserver", "oracle":
+			if v {
+				return "1"
+			}
+			return "0"
+		}
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	case float32:
+		return changeFloatLiteral(float64(v), 32)
+	case float64:
+		return changeFloatLiteral(v, 64)
+	case time.Time:
+		// 保留小数秒与时区偏移，预览文本与实际绑定的参数表示同一时刻
+		return dialect.QuoteLiteral(dbType, v.Format("2006-01-02 15:04:05.999999999-07:00"))
+	case []byte:
+		return changeBytesLiteral(dbType, v)
+	case string:
+		return dialect.QuoteLiteral(dbType, v)
+	default:
+		return dialect.QuoteLiteral(dbType, fmt.Sprintf("%v", v))
+	}
+}
+
+// changeBytesLiteral 二进制值使用各方言的十六进制字面量。
+func changeBytesLiteral(dbType string, v []byte) string {
+	switch dbType {
+	case "postgres":
+		return fmt.Sprintf("'\\x%x'", v)
+	case "sqlserver":
+		return fmt.Sprintf("0x%x", v)
+	case "oracle":
+		return fmt.Sprintf("HEXTORAW('%x')", v)
+	case "duckdb":
+		return fmt.Sprintf("from_hex('%x')", v)
+	}
+	return fmt.Sprintf("X'%x'", v)
+}
+
+func changeFloatLiteral(f float64, bitSize int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "NULL"
+	}
+	return strconv.FormatFloat(f, 'f', -1, bitSize)
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBuildChangeStatementsOrderAndPlaceholders(t *testing.T) {
+	changes := connection.ChangeSet{
+		Inserts: []map[string]interface{}{{"name": "O'Brien", "id": 3}},
+		Updates: []connection.UpdateRow{{
+			Keys:   map[string]interface{}{"id": 1},
+			Values: map[string]interface{}{"name": "b", "age": nil},
+		}},
+		Deletes: []map[string]interface{}{{"id": 2}, {}},
+	}
+
+	statements, err := buildChangeStatements(postgresChangeStyle, "public.users", changes)
+	if err != nil {
+		t.Fatalf("buildChangeStatements: %v", err)
+	}
+	wantSQL := []string{
+		`DELETE FROM "public"."users" WHERE "id" = $1`,
+		`UPDATE "public"."users" SET "age" = $1, "name" = $2 WHERE "id" = $3`,
+		`INSERT INTO "public"."users" ("id", "name") VALUES ($1, $2)`,
+	}
+	if len(statements) != len(wantSQL) {
+		t.Fatalf("expected %d statements, got %d", len(wantSQL), len(statements))
+	}
+	for i, stmt := range statements {
+		if stmt.SQL != wantSQL[i] {
+			t.Fatalf("statement %d: got %q, want %q", i, stmt.SQL, wantSQL[i])
+		}
+	}
+	if !reflect.DeepEqual(statements[1].Args, []interface{}{nil, "b", 1}) {
+		t.Fatalf("unexpected update args: %#v", statements[1].Args)
+	}
+	if got := statements[2].Rendered; got != `INSERT INTO "public"."users" ("id", "name") VALUES (3, 'O''Brien')` {
+		t.Fatalf("unexpected rendered insert: %s", got)
+	}
+}
+
+func TestBuildChangeStatementsDialects(t *testing.T) {
+	changes := connection.ChangeSet{Deletes: []map[string]interface{}{{"id": 1, "created_at": "2024-01-02T03:04:05"}}}
+
+	sqlServer, err := buildChangeStatements(sqlServerChangeStyle, "orders", changes)
+	if err != nil {
+		t.Fatalf("sqlserver: %v", err)
+	}
+	if got := sqlServer[0].SQL; got != "DELETE FROM [dbo].[orders] WHERE [created_at] = @p1 AND [id] = @p2" {
+		t.Fatalf("unexpected sqlserver SQL: %s", got)
+	}
+
+	mysql, err := buildChangeStatements(mysqlChangeStyle, "orders", changes)
+	if err != nil {
+		t.Fatalf("mysql: %v", err)
+	}
+	if got := mysql[0].SQL; got != "DELETE FROM `orders` WHERE `created_at` = ? AND `id` = ?" {
+		t.Fatalf("unexpected mysql SQL: %s", got)
+	}
+	if got := mysql[0].Args[0]; got != "2024-01-02 03:04:05" {
+		t.Fatalf("expected mysql datetime arg to be normalized, got %#v", got)
+	}
+	if !strings.Contains(mysql[0].Rendered, "'2024-01-02 03:04:05'") {
+		t.Fatalf("expected rendered SQL to use normalized value: %s", mysql[0].Rendered)
+	}
+
+	escaped, err := buildChangeStatements(mysqlChangeStyle, "shop.odd`name", connection.ChangeSet{Deletes: []map[string]interface{}{{"a`b": 1}}})
+	if err != nil {
+		t.Fatalf("mysql escaping: %v", err)
+	}
+	if got := escaped[0].SQL; got != "DELETE FROM `shop`.`odd``name` WHERE `a``b` = ?" {
+		t.Fatalf("unexpected escaped mysql SQL: %s", got)
+	}
+}
+
+func TestBuildChangeStatementsRejectsUpdateWithoutKeys(t *testing.T) {
+	changes := connection.ChangeSet{Updates: []connection.UpdateRow{{Values: map[string]interface{}{"name": "x"}}}}
+	if _, err := buildChangeStatements(sqliteChangeStyle, "t", changes); err == nil {
+		t.Fatal("expected error for update without keys")
+	}
+}
+
+func TestBuiltinDriversPlanChanges(t *testing.T) {
+	changes := connection.ChangeSet{Updates: []connection.UpdateRow{{
+		Keys:   map[string]interface{}{"ID": 1},
+		Values: map[string]interface{}{"NAME": "x"},
+	}}}
+	cases := []struct {
+		planner ChangePlanner
+		want    string
+	}{
+		{&PostgresDB{}, `UPDATE "app"."users" SET "NAME" = $1 WHERE "ID" = $2`},
+		{&OracleDB{}, `UPDATE "app"."users" SET "NAME" = :1 WHERE "ID" = :2`},
+	}
+	for _, c := range cases {
+		statements, err := c.planner.PlanChanges("app.users", changes)
+		if err != nil {
+			t.Fatalf("%T: %v", c.planner, err)
+		}
+		if len(statements) != 1 || statements[0].SQL != c.want {
+			t.Fatalf("%T: unexpected statements %+v", c.planner, statements)
+		}
+		if err := c.planner.(BatchApplier).ApplyChanges("app.users", changes); err == nil {
+			t.Fatalf("%T: expected error without connection", c.planner)
+		}
+	}
+}
+
+func TestIsAgentMethodUnsupported(t *testing.T) {
+	if !isAgentMethodUnsupported(errors.New(agentUnsupportedMethodText)) {
+		t.Fatal("expected unsupported method error to be recognized")
+	}
+	if isAgentMethodUnsupported(errors.New("update requires keys")) || isAgentMethodUnsupported(nil) {
+		t.Fatal("unexpected match")
+	}
+}
+
+func TestChangeValueLiteral(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.FixedZone("", 8*3600))
+	cases := []struct {
+		dbType string
+		value  interface{}
+		want   string
+	}{
+		{"postgres", true, "TRUE"},
+		{"sqlserver", true, "1"},
+		{"oracle", false, "0"},
+		{"postgres", at, "'2024-01-02 03:04:05.123456+08:00'"},
+		{"mysql", []byte{0xca, 0xfe}, "X'cafe'"},
+		{"postgres", []byte{0xca, 0xfe}, `'\xcafe'`},
+		{"sqlserver", []byte{0xca, 0xfe}, "0xcafe"},
+		{"mysql", `a\'b`, `'a\\''b'`},
+		{"postgres", `a\'b`, `'a\''b'`},
+	}
+	for _, tc := range cases {
+		if got := changeValueLiteral(tc.dbType, tc.value); got != tc.want {
+			t.Errorf("changeValueLiteral(%s, %#v) = %s, want %s", tc.dbType, tc.value, got, tc.want)
+		}
+	}
+}
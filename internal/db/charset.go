@@ -0,0 +1,158 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"GoNavi-Wails/internal/connection"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// 老旧 GBK 库的字符集处理：
+//   - Charset：连接字符集（MySQL SET NAMES），默认 utf8mb4；设为 gbk/gb18030 时服务端按该编码收发文本；
+//   - Collation：连接排序规则，如 gbk_chinese_ci；
+//   - LegacyTextEncoding：历史数据的实际编码。常见于把 GBK 字节存进 latin1 列的老系统，此时应将 Charset 设为 latin1，
+//     读取时按 GBK 解码，写入时把 SQL 文本与参数编码为 GBK 字节。
+// 无法解码的字节以 U+FFFD（�）占位，便于用户识别。
+
+var mysqlCharsetNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// textCodec 负责在 UTF-8 与遗留编码之间转换查询文本与结果值；nil 表示无需转换。
+type textCodec struct {
+	name string
+	enc  encoding.Encoding
+	// decodeAll 为 true 时所有文本列都按遗留编码解码（连接字符集非 UTF-8）；
+	// 否则只解码不是合法 UTF-8 的值。
+	decodeAll bool
+}
+
+func resolveTextEncoding(name string) encoding.Encoding {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "gbk", "cp936":
+		return simplifiedchinese.GBK
+	case "gb18030":
+		return simplifiedchinese.GB18030
+	case "gb2312", "euc-cn":
+		// GBK 是 GB2312 的超集，按 GBK 解码可兼容
+		return simplifiedchinese.GBK
+	case "big5":
+		return traditionalchinese.Big5
+	default:
+		return nil
+	}
+}
+
+func isUTF8Charset(name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return name == "" || strings.HasPrefix(name, "utf8") || name == "utf-8"
+}
+
+// newTextCodec 根据连接配置构造编码转换器；连接与数据均为 UTF-8 时返回 nil。
+func newTextCodec(config connection.ConnectionConfig) *textCodec {
+	name := strings.TrimSpace(config.LegacyTextEncoding)
+	if name == "" {
+		name = strings.TrimSpace(config.Charset)
+	}
+	enc := resolveTextEncoding(name)
+	if enc == nil {
+		return nil
+	}
+	return &textCodec{name: strings.ToLower(name), enc: enc, decodeAll: !isUTF8Charset(config.Charset)}
+}
+
+// encodeQuery 将 UTF-8 SQL 文本转换为连接字符集字节。连接为 UTF-8 时原样发送。
+func (c *textCodec) encodeQuery(query string) (string, error) {
+	if c == nil || !c.decodeAll {
+		return query, nil
+	}
+	encoded, err := c.enc.NewEncoder().String(query)
+	if err != nil {
+		return "", fmt.Errorf("SQL 文本包含 %s 无法表示的字符：%w", c.name, err)
+	}
+	return encoded, nil
+}
+
+// encodeArgs 转换参数中的字符串值，规则与 encodeQuery 一致。
+func (c *textCodec) encodeArgs(args []interface{}) ([]interface{}, error) {
+	if c == nil || !c.decodeAll {
+		return args, nil
+	}
+	result := make([]interface{}, len(args))
+	for i, arg := range args {
+		text, ok := arg.(string)
+		if !ok {
+			result[i] = arg
+			continue
+		}
+		encoded, err := c.enc.NewEncoder().String(text)
+		if err != nil {
+			return nil, fmt.Errorf("参数包含 %s 无法表示的字符：%w", c.name, err)
+		}
+		result[i] = encoded
+	}
+	return result, nil
+}
+
+// execTx 在事务中执行参数化语句，SQL 与字符串参数按连接字符集编码。
+func (c *textCodec) execTx(tx *sql.Tx, query string, args []interface{}) (sql.Result, error) {
+	query, err := c.encodeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	args, err = c.encodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Exec(query, args...)
+}
+
+// decodeValue 将驱动返回的文本字节转换为 UTF-8。二进制类型列保持原样交给通用规则处理。
+func (c *textCodec) decodeValue(v interface{}, databaseTypeName string) interface{} {
+	if c == nil {
+		return v
+	}
+	raw, ok := v.([]byte)
+	if !ok {
+		return v
+	}
+	if raw == nil || isBinaryDBType(databaseTypeName) {
+		return v
+	}
+	if !c.decodeAll && utf8.Valid(raw) {
+		return v
+	}
+	// 解码器遇到非法字节时输出 U+FFFD，作为无法转换的占位标记
+	decoded, err := c.enc.NewDecoder().Bytes(raw)
+	if err != nil {
+		return v
+	}
+	return string(decoded)
+}
+
+func isBinaryDBType(databaseTypeName string) bool {
+	switch strings.ToUpper(strings.TrimSpace(databaseTypeName)) {
+	case "BINARY", "VARBINARY", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BIT", "GEOMETRY":
+		return true
+	default:
+		return false
+	}
+}
+
+// mysqlCharsetDSNParams 返回 MySQL DSN 的 charset/collation 参数（不含前导 &），默认 charset=utf8mb4。
+func mysqlCharsetDSNParams(config connection.ConnectionConfig) string {
+	charset := strings.TrimSpace(config.Charset)
+	if charset == "" || !mysqlCharsetNamePattern.MatchString(charset) {
+		charset = "utf8mb4"
+	}
+	params := "charset=" + charset
+	if collation := strings.TrimSpace(config.Collation); collation != "" && mysqlCharsetNamePattern.MatchString(collation) {
+		params += "&collation=" + collation
+	}
+	return params
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"GoNavi-Wails/internal/connection"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestTextCodecGBKConnection(t *testing.T) {
+	codec := newTextCodec(connection.ConnectionConfig{Charset: "gbk"})
+	if codec == nil || !codec.decodeAll {
+		t.Fatalf("expected decodeAll codec, got %+v", codec)
+	}
+	encoded, err := codec.encodeQuery("SELECT '中文'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := simplifiedchinese.GBK.NewEncoder().String("SELECT '中文'")
+	if encoded != want {
+		t.Fatalf("encodeQuery = %q, want %q", encoded, want)
+	}
+	if got := codec.decodeValue([]byte(want), "VARCHAR"); got != "SELECT '中文'" {
+		t.Fatalf("decodeValue = %v", got)
+	}
+	raw := []byte{0xd6, 0xd0}
+	if got, ok := codec.decodeValue(raw, "VARBINARY").([]byte); !ok || len(got) != 2 {
+		t.Fatalf("binary column should stay raw, got %v", got)
+	}
+}
+
+func TestTextCodecLegacyLatin1Columns(t *testing.T) {
+	codec := newTextCodec(connection.ConnectionConfig{Charset: "utf8mb4", LegacyTextEncoding: "gb18030"})
+	if codec == nil || codec.decodeAll {
+		t.Fatalf("expected fallback-only codec, got %+v", codec)
+	}
+	if got, _ := codec.encodeQuery("中文"); got != "中文" {
+		t.Fatalf("utf8 connection should not re-encode query, got %q", got)
+	}
+	if got := codec.decodeValue([]byte("已是UTF-8"), "TEXT"); string(got.([]byte)) != "已是UTF-8" {
+		t.Fatalf("valid utf8 should be kept, got %v", got)
+	}
+	if got := codec.decodeValue([]byte{0xd6, 0xd0, 0xce, 0xc4}, "TEXT"); got != "中文" {
+		t.Fatalf("legacy bytes should be decoded, got %v", got)
+	}
+	if got, _ := codec.decodeValue([]byte{0xd6, 0xff}, "TEXT").(string); !strings.ContainsRune(got, utf8.RuneError) {
+		t.Fatalf("undecodable bytes should be marked with U+FFFD, got %q", got)
+	}
+}
+
+func TestMySQLCharsetDSNParams(t *testing.T) {
+	if got := mysqlCharsetDSNParams(connection.ConnectionConfig{}); got != "charset=utf8mb4" {
+		t.Fatalf("default = %q", got)
+	}
+	if got := mysqlCharsetDSNParams(connection.ConnectionConfig{Charset: "gbk", Collation: "gbk_chinese_ci"}); got != "charset=gbk&collation=gbk_chinese_ci" {
+		t.Fatalf("gbk = %q", got)
+	}
+	if got := mysqlCharsetDSNParams(connection.ConnectionConfig{Charset: "gbk&x=1"}); got != "charset=utf8mb4" {
+		t.Fatalf("invalid charset should fall back, got %q", got)
+	}
+	if newTextCodec(connection.ConnectionConfig{}) != nil {
+		t.Fatal("utf8 connection should not need a codec")
+	}
+}
@@ -0,0 +1,380 @@
+//go:build gonavi_full_drivers || gonavi_clickhouse_driver
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+	"GoNavi-Wails/internal/logger"
+	"GoNavi-Wails/internal/ssh"
+	"GoNavi-Wails/internal/utils"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+const defaultClickHousePort = 9000
+
+// ClickHouseDB implements Database interface for ClickHouse.
+// 使用 clickhouse-go 原生 TCP 协议（默认端口 9000），元数据来自 system.databases/tables/columns。
+type ClickHouseDB struct {
+	conn        *sql.DB
+	pingTimeout time.Duration
+	forwarder   *ssh.LocalForwarder
+}
+
+func (c *ClickHouseDB) getOptions(config connection.ConnectionConfig) *clickhouse.Options {
+	port := config.Port
+	if port <= 0 {
+		port = defaultClickHousePort
+	}
+	user := strings.TrimSpace(config.User)
+	if user == "" {
+		user = "default"
+	}
+	database := strings.TrimSpace(config.Database)
+	if database == "" {
+		database = "default"
+	}
+	timeout := getConnectTimeout(config)
+	return &clickhouse.Options{
+		Protocol: clickhouse.Native,
+		Addr:     []string{net.JoinHostPort(config.Host, strconv.Itoa(port))},
+		Auth: clickhouse.Auth{
+			Database: database,
+			Username: user,
+			Password: config.Password,
+		},
+		DialTimeout: timeout,
+		ReadTimeout: 5 * time.Minute,
+		Compression: &clickhouse.Compression{Method: clickhouse.CompressionLZ4},
+	}
+}
+
+func (c *ClickHouseDB) Connect(config connection.ConnectionConfig) error {
+	runConfig := config
+	if config.UseSSH {
+		logger.Infof("ClickHouse 使用 SSH 连接：地址=%s:%d 用户=%s", config.Host, config.Port, config.User)
+		port := config.Port
+		if port <= 0 {
+			port = defaultClickHousePort
+		}
+		forwarder, err := ssh.GetOrCreateLocalForwarder(config.SSH, config.Host, port)
+		if err != nil {
+			return fmt.Errorf("创建 SSH 隧道失败：%w", err)
+		}
+		c.forwarder = forwarder
+
+		host, portStr, err := net.SplitHostPort(forwarder.LocalAddr)
+		if err != nil {
+			return fmt.Errorf("解析本地转发地址失败：%w", err)
+		}
+		localPort, err := strconv.Atoi(portStr)
+		if err != nil {
+			return fmt.Errorf("解析本地端口失败：%w", err)
+		}
+		runConfig.Host = host
+		runConfig.Port = localPort
+		runConfig.UseSSH = false
+		logger.Infof("ClickHouse 通过本地端口转发连接：%s -> %s:%d", forwarder.LocalAddr, config.Host, port)
+	}
+
+	c.conn = clickhouse.OpenDB(c.getOptions(runConfig))
+	applyPoolOptions(c.conn, runConfig)
+	c.pingTimeout = getConnectTimeout(config)
+
+	if err := c.Ping(); err != nil {
+		return fmt.Errorf("连接建立后验证失败：%w", err)
+	}
+	return nil
+}
+
+func (c *ClickHouseDB) Close() error {
+	if c.forwarder != nil {
+		if err := c.forwarder.Close(); err != nil {
+			logger.Warnf("关闭 ClickHouse SSH 端口转发失败：%v", err)
+		}
+		c.forwarder = nil
+	}
+
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+func (c *ClickHouseDB) Ping() error {
+	if c.conn == nil {
+		return fmt.Errorf("connection not open")
+	}
+	timeout := c.pingTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := utils.ContextWithTimeout(timeout)
+	defer cancel()
+	return c.conn.PingContext(ctx)
+}
+
+func (c *ClickHouseDB) QueryContext(ctx context.Context, query string) ([]map[string]interface{}, []string, error) {
+	if c.conn == nil {
+		return nil, nil, fmt.Errorf("connection not open")
+	}
+
+	rows, err := c.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	return scanRowsContext(ctx, rows, nil)
+}
+
+func (c *ClickHouseDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	if c.conn == nil {
+		return nil, nil, fmt.Errorf("connection not open")
+	}
+
+	rows, err := c.conn.Query(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	return scanRows(rows)
+}
+
+func (c *ClickHouseDB) ExecContext(ctx context.Context, query string) (int64, error) {
+	if c.conn == nil {
+		return 0, fmt.Errorf("connection not open")
+	}
+	res, err := c.conn.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (c *ClickHouseDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, c.conn, nil, query, args)
+}
+
+func (c *ClickHouseDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, c.conn, nil, query, args)
+}
+
+func (c *ClickHouseDB) Exec(query string) (int64, error) {
+	if c.conn == nil {
+		return 0, fmt.Errorf("connection not open")
+	}
+	res, err := c.conn.Exec(query)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (c *ClickHouseDB) GetDatabases() ([]string, error) {
+	data, _, err := c.Query("SELECT name FROM system.databases ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	dbs := make([]string, 0, len(data))
+	for _, row := range data {
+		if val, ok := getValueFromRow(row, "name"); ok && val != nil {
+			dbs = append(dbs, fmt.Sprintf("%v", val))
+		}
+	}
+	return dbs, nil
+}
+
+func (c *ClickHouseDB) GetTables(dbName string) ([]string, error) {
+	query := "SELECT name FROM system.tables WHERE database = currentDatabase() ORDER BY name"
+	if strings.TrimSpace(dbName) != "" {
+		query = fmt.Sprintf("SELECT name FROM system.tables WHERE database = '%s' ORDER BY name", escapeClickHouseString(dbName))
+	}
+	data, _, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]string, 0, len(data))
+	for _, row := range data {
+		if val, ok := getValueFromRow(row, "name"); ok && val != nil {
+			tables = append(tables, fmt.Sprintf("%v", val))
+		}
+	}
+	return tables, nil
+}
+
+func (c *ClickHouseDB) GetCreateStatement(dbName, tableName string) (string, error) {
+	schema, table := splitClickHouseTable(dbName, tableName)
+	data, _, err := c.Query("SHOW CREATE TABLE " + dialect.QualifyTable("clickhouse", schema, table))
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 0 {
+		return "", fmt.Errorf("create statement not found")
+	}
+	if val, ok := getValueFromRow(data[0], "statement"); ok && val != nil {
+		return fmt.Sprintf("%v", val), nil
+	}
+	for _, val := range data[0] {
+		return fmt.Sprintf("%v", val), nil
+	}
+	return "", fmt.Errorf("create statement not found")
+}
+
+func (c *ClickHouseDB) columnsQuery(dbName string, tableName string) string {
+	database := "currentDatabase()"
+	if strings.TrimSpace(dbName) != "" {
+		database = fmt.Sprintf("'%s'", escapeClickHouseString(dbName))
+	}
+	query := fmt.Sprintf("SELECT table, name, type, default_kind, default_expression, comment, is_in_primary_key, is_in_sorting_key "+
+		"FROM system.columns WHERE database = %s", database)
+	if tableName != "" {
+		query += fmt.Sprintf(" AND table = '%s'", escapeClickHouseString(tableName))
+	}
+	return query + " ORDER BY table, position"
+}
+
+func (c *ClickHouseDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
+	dbName, tableName = splitClickHouseTable(dbName, tableName)
+	data, _, err := c.Query(c.columnsQuery(dbName, tableName))
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]connection.ColumnDefinition, 0, len(data))
+	for _, row := range data {
+		columns = append(columns, clickHouseColumnFromRow(row))
+	}
+	return columns, nil
+}
+
+func clickHouseColumnFromRow(row map[string]interface{}) connection.ColumnDefinition {
+	text := func(key string) string {
+		if val, ok := getValueFromRow(row, key); ok && val != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return ""
+	}
+	colType := text("type")
+	col := connection.ColumnDefinition{
+		Name:     text("name"),
+		Type:     colType,
+		Nullable: "NO",
+		Comment:  text("comment"),
+	}
+	if strings.HasPrefix(colType, "Nullable(") {
+		col.Nullable = "YES"
+	}
+	if text("is_in_primary_key") == "1" {
+		col.Key = "PRI"
+	} else if text("is_in_sorting_key") == "1" {
+		col.Key = "MUL"
+	}
+	kind := strings.ToUpper(text("default_kind"))
+	expr := text("default_expression")
+	switch kind {
+	case "DEFAULT":
+		if expr != "" {
+			col.Default = &expr
+		}
+	case "MATERIALIZED", "ALIAS", "EPHEMERAL":
+		// 物化/别名列不可直接写入，放在 Extra 中提示
+		col.Extra = strings.TrimSpace(kind + " " + expr)
+	}
+	return col
+}
+
+func (c *ClickHouseDB) GetAllColumns(dbName string) ([]connection.ColumnDefinitionWithTable, error) {
+	data, _, err := c.Query(c.columnsQuery(dbName, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]connection.ColumnDefinitionWithTable, 0, len(data))
+	for _, row := range data {
+		table, _ := getValueFromRow(row, "table")
+		col := clickHouseColumnFromRow(row)
+		cols = append(cols, connection.ColumnDefinitionWithTable{
+			TableName: fmt.Sprintf("%v", table),
+			Name:      col.Name,
+			Type:      col.Type,
+		})
+	}
+	return cols, nil
+}
+
+// GetIndexes 返回数据跳数索引（ClickHouse 无二级唯一索引；主键/排序键见列的 Key 标记）。
+func (c *ClickHouseDB) GetIndexes(dbName, tableName string) ([]connection.IndexDefinition, error) {
+	dbName, tableName = splitClickHouseTable(dbName, tableName)
+	database := "currentDatabase()"
+	if strings.TrimSpace(dbName) != "" {
+		database = fmt.Sprintf("'%s'", escapeClickHouseString(dbName))
+	}
+	data, _, err := c.Query(fmt.Sprintf("SELECT name, expr, type FROM system.data_skipping_indices WHERE database = %s AND table = '%s'",
+		database, escapeClickHouseString(tableName)))
+	if err != nil {
+		// 旧版本没有 system.data_skipping_indices
+		return []connection.IndexDefinition{}, nil
+	}
+	indexes := make([]connection.IndexDefinition, 0, len(data))
+	for _, row := range data {
+		name, _ := getValueFromRow(row, "name")
+		expr, _ := getValueFromRow(row, "expr")
+		indexType, _ := getValueFromRow(row, "type")
+		indexes = append(indexes, connection.IndexDefinition{
+			Name:       fmt.Sprintf("%v", name),
+			ColumnName: fmt.Sprintf("%v", expr),
+			NonUnique:  1,
+			SeqInIndex: 1,
+			IndexType:  fmt.Sprintf("%v", indexType),
+		})
+	}
+	return indexes, nil
+}
+
+func (c *ClickHouseDB) GetForeignKeys(dbName, tableName string) ([]connection.ForeignKeyDefinition, error) {
+	return []connection.ForeignKeyDefinition{}, nil
+}
+
+func (c *ClickHouseDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDefinition, error) {
+	return []connection.TriggerDefinition{}, nil
+}
+
+func (c *ClickHouseDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(c, "clickhouse", dbName, tableName, index)
+}
+
+func (c *ClickHouseDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(c, "clickhouse", dbName, tableName, indexName)
+}
+
+func (c *ClickHouseDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(c, "clickhouse", dbName, tableName, index)
+}
+
+// escapeClickHouseString 转义字符串字面量（ClickHouse 支持反斜杠转义）。
+func escapeClickHouseString(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), `\`, `\\`)
+	return strings.ReplaceAll(text, "'", `\'`)
+}
+
+// splitClickHouseTable 兼容 db.table 形式的表名。
+func splitClickHouseTable(dbName, tableName string) (string, string) {
+	tableName = strings.TrimSpace(tableName)
+	if parts := strings.SplitN(tableName, ".", 2); len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return strings.Trim(parts[0], "`"), strings.Trim(parts[1], "`")
+	}
+	return dbName, strings.Trim(tableName, "`")
+}
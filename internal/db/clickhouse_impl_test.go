@@ -0,0 +1,74 @@
+//go:build gonavi_full_drivers || gonavi_clickhouse_driver
+
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+func TestClickHouseOptions(t *testing.T) {
+	opts := (&ClickHouseDB{}).getOptions(connection.ConnectionConfig{Host: "ch.local", Timeout: 7})
+	if len(opts.Addr) != 1 || opts.Addr[0] != "ch.local:9000" {
+		t.Fatalf("addr = %v", opts.Addr)
+	}
+	if opts.Auth.Username != "default" || opts.Auth.Database != "default" || opts.Protocol != clickhouse.Native {
+		t.Fatalf("auth = %+v protocol = %v", opts.Auth, opts.Protocol)
+	}
+	if opts.DialTimeout != 7*time.Second {
+		t.Fatalf("dial timeout = %s", opts.DialTimeout)
+	}
+
+	opts = (&ClickHouseDB{}).getOptions(connection.ConnectionConfig{Host: "::1", Port: 9440, User: " reader ", Password: "p", Database: "logs"})
+	if opts.Addr[0] != "[::1]:9440" || opts.Auth.Username != "reader" || opts.Auth.Password != "p" || opts.Auth.Database != "logs" {
+		t.Fatalf("options = %+v", opts)
+	}
+}
+
+func TestClickHouseQuoting(t *testing.T) {
+	if got := escapeClickHouseString(` it's a\b `); got != `it\'s a\\b` {
+		t.Fatalf("escape = %s", got)
+	}
+	schema, table := splitClickHouseTable("default", "`logs`.`events`")
+	if schema != "logs" || table != "events" {
+		t.Fatalf("split = %q.%q", schema, table)
+	}
+	if got := dialect.QualifyTable("clickhouse", schema, "ev`ents"); got != "`logs`.`ev``ents`" {
+		t.Fatalf("qualified = %s", got)
+	}
+	query := (&ClickHouseDB{}).columnsQuery("", "o'rders")
+	if !strings.Contains(query, "database = currentDatabase()") || !strings.Contains(query, `table = 'o\'rders'`) {
+		t.Fatalf("columns query = %s", query)
+	}
+}
+
+func TestClickHouseColumnFromRow(t *testing.T) {
+	col := clickHouseColumnFromRow(map[string]interface{}{
+		"name": "id", "type": "UInt64", "is_in_primary_key": uint8(1), "is_in_sorting_key": uint8(1),
+		"default_kind": "", "default_expression": "", "comment": "主键",
+	})
+	if col.Name != "id" || col.Type != "UInt64" || col.Nullable != "NO" || col.Key != "PRI" || col.Comment != "主键" || col.Default != nil {
+		t.Fatalf("id column = %+v", col)
+	}
+
+	col = clickHouseColumnFromRow(map[string]interface{}{
+		"name": "city", "type": "Nullable(String)", "is_in_primary_key": uint8(0), "is_in_sorting_key": uint8(1),
+		"default_kind": "DEFAULT", "default_expression": "'n/a'",
+	})
+	if col.Nullable != "YES" || col.Key != "MUL" || col.Default == nil || *col.Default != "'n/a'" {
+		t.Fatalf("city column = %+v", col)
+	}
+
+	col = clickHouseColumnFromRow(map[string]interface{}{
+		"name": "day", "type": "Date", "default_kind": "MATERIALIZED", "default_expression": "toDate(ts)",
+	})
+	if col.Extra != "MATERIALIZED toDate(ts)" || col.Default != nil {
+		t.Fatalf("day column = %+v", col)
+	}
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+// isLiteralDefault 判断列默认值文本是否为字面量：数字、带引号字符串、NULL 或布尔值。
+// 兼容 PostgreSQL 的 '...'::type 类型转换与 SQL Server 的外层括号，例如 ((0))、(N'abc')。
+func isLiteralDefault(def string) bool {
+	text := strings.TrimSpace(def)
+	for len(text) >= 2 && text[0] == '(' && text[len(text)-1] == ')' {
+		text = strings.TrimSpace(text[1 : len(text)-1])
+	}
+	if text == "" {
+		return true
+	}
+	if strings.HasPrefix(text, "N'") || strings.HasPrefix(text, "n'") {
+		text = text[1:]
+	}
+	if text[0] == '\'' {
+		end := 1
+		for end < len(text) {
+			if text[end] == '\'' {
+				if end+1 < len(text) && text[end+1] == '\'' {
+					end += 2
+					continue
+				}
+				break
+			}
+			end++
+		}
+		if end >= len(text) {
+			return false
+		}
+		rest := strings.TrimSpace(text[end+1:])
+		return rest == "" || strings.HasPrefix(rest, "::")
+	}
+	if idx := strings.Index(text, "::"); idx > 0 {
+		text = strings.TrimSpace(text[:idx])
+	}
+	switch strings.ToUpper(text) {
+	case "NULL", "TRUE", "FALSE":
+		return true
+	}
+	_, err := strconv.ParseFloat(text, 64)
+	return err == nil
+}
+
+// applyMySQLColumnExtra 根据 SHOW FULL COLUMNS 的 Extra 识别生成列、表达式默认值与自增列（MySQL/MariaDB 通用）。
+func applyMySQLColumnExtra(col *connection.ColumnDefinition) {
+	extra := strings.ToUpper(col.Extra)
+	switch {
+	case strings.Contains(extra, "STORED GENERATED"), strings.Contains(extra, "PERSISTENT"):
+		col.Generated = "STORED"
+	case strings.Contains(extra, "VIRTUAL"):
+		col.Generated = "VIRTUAL"
+	}
+	if strings.Contains(extra, "AUTO_INCREMENT") {
+		col.Identity = "BY DEFAULT"
+	}
+	if col.Default == nil {
+		return
+	}
+	// MySQL 8.0.13+ 用 DEFAULT_GENERATED 标记表达式默认值；更早版本只有 CURRENT_TIMESTAMP 一种
+	def := strings.ToUpper(strings.TrimSpace(*col.Default))
+	if strings.Contains(extra, "DEFAULT_GENERATED") || strings.HasPrefix(def, "CURRENT_TIMESTAMP") {
+		col.DefaultIsExpression = true
+	}
+}
+
+// fillMySQLGenerationExprs 为生成列补充表达式；SHOW COLUMNS 不返回表达式，需要查 information_schema。
+// 仅在存在生成列时查询，失败时忽略（老版本没有 GENERATION_EXPRESSION 列）。
+func fillMySQLGenerationExprs(query func(string) ([]map[string]interface{}, []string, error), dbName, tableName string, columns []connection.ColumnDefinition) {
+	byName := make(map[string]int)
+	for i, col := range columns {
+		if col.Generated != "" {
+			byName[strings.ToLower(col.Name)] = i
+		}
+	}
+	if len(byName) == 0 {
+		return
+	}
+	esc := func(s string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", "''")
+	}
+	schemaCond := "DATABASE()"
+	if strings.TrimSpace(dbName) != "" {
+		schemaCond = "'" + esc(dbName) + "'"
+	}
+	data, _, err := query(fmt.Sprintf(`SELECT COLUMN_NAME AS column_name, GENERATION_EXPRESSION AS generation_expr
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = %s AND TABLE_NAME = '%s'`, schemaCond, esc(tableName)))
+	if err != nil {
+		return
+	}
+	for _, row := range data {
+		name, _ := getValueFromRow(row, "column_name")
+		expr, _ := getValueFromRow(row, "generation_expr")
+		if name == nil || expr == nil {
+			continue
+		}
+		if idx, ok := byName[strings.ToLower(fmt.Sprintf("%v", name))]; ok {
+			columns[idx].GenerationExpr = fmt.Sprintf("%v", expr)
+		}
+	}
+}
+
+// applyPostgresColumnMeta 补充 PostgreSQL 系列的标识列（PG10+ attidentity）与生成列（PG12+ attgenerated）信息。
+// 生成列的表达式保存在 pg_attrdef 中，会被主查询当作默认值读出，这里移到 GenerationExpr。
+// 不支持对应系统列的版本（含部分国产衍生版）查询失败时逐级降级，只保留默认值表达式判断。
+func applyPostgresColumnMeta(query func(string) ([]map[string]interface{}, []string, error), schema, table string, columns []connection.ColumnDefinition) {
+	for i := range columns {
+		if columns[i].Default == nil {
+			continue
+		}
+		columns[i].DefaultIsExpression = !isLiteralDefault(*columns[i].Default)
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(*columns[i].Default)), "nextval(") {
+			columns[i].Identity = "BY DEFAULT"
+		}
+	}
+
+	esc := func(s string) string { return strings.ReplaceAll(s, "'", "''") }
+	metaQuery := func(identityExpr, generatedExpr string) string {
+		return fmt.Sprintf(`SELECT a.attname AS column_name, %s AS identity_kind, %s AS generated_kind
+FROM pg_attribute a
+JOIN pg_class c ON c.oid = a.attrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = '%s' AND c.relname = '%s' AND a.attnum > 0 AND NOT a.attisdropped`, identityExpr, generatedExpr, esc(schema), esc(table))
+	}
+	data, _, err := query(metaQuery("a.attidentity::text", "a.attgenerated::text"))
+	if err != nil {
+		data, _, err = query(metaQuery("a.attidentity::text", "''"))
+	}
+	if err != nil {
+		return
+	}
+
+	byName := make(map[string]int, len(columns))
+	for i, col := range columns {
+		byName[col.Name] = i
+	}
+	for _, row := range data {
+		name, _ := getValueFromRow(row, "column_name")
+		idx, ok := byName[fmt.Sprintf("%v", name)]
+		if !ok {
+			continue
+		}
+		col := &columns[idx]
+		identity, _ := getValueFromRow(row, "identity_kind")
+		switch fmt.Sprintf("%v", identity) {
+		case "a":
+			col.Identity = "ALWAYS"
+		case "d":
+			col.Identity = "BY DEFAULT"
+		}
+		generated, _ := getValueFromRow(row, "generated_kind")
+		if fmt.Sprintf("%v", generated) == "s" {
+			col.Generated = "STORED"
+			if col.Default != nil {
+				col.GenerationExpr = *col.Default
+			}
+			col.Default = nil
+			col.DefaultIsExpression = false
+		}
+	}
+}
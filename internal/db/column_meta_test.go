@@ -0,0 +1,93 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestIsLiteralDefault(t *testing.T) {
+	cases := map[string]bool{
+		"0":                             true,
+		"-1.5":                          true,
+		"NULL":                          true,
+		"'abc'":                         true,
+		"'it''s'::character varying":    true,
+		"((0))":                         true,
+		"(N'abc')":                      true,
+		"CURRENT_TIMESTAMP":             false,
+		"now()":                         false,
+		"nextval('t_id_seq'::regclass)": false,
+		"(getdate())":                   false,
+		"('a' || 'b')":                  false,
+	}
+	for input, want := range cases {
+		if got := isLiteralDefault(input); got != want {
+			t.Errorf("isLiteralDefault(%q)=%v，期望 %v", input, got, want)
+		}
+	}
+}
+
+func TestApplyMySQLColumnExtra(t *testing.T) {
+	now := "CURRENT_TIMESTAMP"
+	cols := []connection.ColumnDefinition{
+		{Name: "id", Extra: "auto_increment"},
+		{Name: "total", Extra: "STORED GENERATED"},
+		{Name: "label", Extra: "VIRTUAL GENERATED"},
+		{Name: "created_at", Default: &now, Extra: "DEFAULT_GENERATED"},
+	}
+	for i := range cols {
+		applyMySQLColumnExtra(&cols[i])
+	}
+	if cols[0].Identity != "BY DEFAULT" || cols[1].Generated != "STORED" || cols[2].Generated != "VIRTUAL" || !cols[3].DefaultIsExpression {
+		t.Fatalf("识别结果不符合预期：%+v", cols)
+	}
+
+	var captured string
+	query := func(sql string) ([]map[string]interface{}, []string, error) {
+		captured = sql
+		return []map[string]interface{}{
+			{"COLUMN_NAME": "total", "GENERATION_EXPRESSION": ""},
+			{"column_name": "total", "generation_expr": "(`price` * `qty`)"},
+		}, nil, nil
+	}
+	fillMySQLGenerationExprs(query, "shop", "orders", cols)
+	if !strings.Contains(captured, "TABLE_SCHEMA = 'shop'") {
+		t.Fatalf("查询未限定库名：%s", captured)
+	}
+	if cols[1].GenerationExpr != "(`price` * `qty`)" {
+		t.Fatalf("未填充生成表达式：%+v", cols[1])
+	}
+}
+
+func TestApplyPostgresColumnMeta(t *testing.T) {
+	expr := "(price * qty)"
+	seq := "nextval('orders_id_seq'::regclass)"
+	cols := []connection.ColumnDefinition{
+		{Name: "id", Default: &seq},
+		{Name: "code"},
+		{Name: "total", Default: &expr},
+	}
+	calls := 0
+	query := func(sql string) ([]map[string]interface{}, []string, error) {
+		calls++
+		return []map[string]interface{}{
+			{"column_name": "code", "identity_kind": "a", "generated_kind": ""},
+			{"column_name": "total", "identity_kind": "", "generated_kind": "s"},
+		}, nil, nil
+	}
+	applyPostgresColumnMeta(query, "public", "orders", cols)
+	if calls != 1 {
+		t.Fatalf("期望查询 1 次，实际 %d", calls)
+	}
+	if cols[0].Identity != "BY DEFAULT" || !cols[0].DefaultIsExpression {
+		t.Fatalf("serial 列识别错误：%+v", cols[0])
+	}
+	if cols[1].Identity != "ALWAYS" {
+		t.Fatalf("标识列识别错误：%+v", cols[1])
+	}
+	if cols[2].Generated != "STORED" || cols[2].GenerationExpr != expr || cols[2].Default != nil {
+		t.Fatalf("生成列识别错误：%+v", cols[2])
+	}
+}
@@ -29,6 +29,7 @@ func (c *CustomDB) Connect(config connection.ConnectionConfig) error {
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	c.conn = db
 	c.driver = config.Driver
 	c.pingTimeout = getConnectTimeout(config)
@@ -69,7 +70,7 @@ func (c *CustomDB) QueryContext(ctx context.Context, query string) ([]map[string
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (c *CustomDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -96,6 +97,14 @@ func (c *CustomDB) ExecContext(ctx context.Context, query string) (int64, error)
 	return res.RowsAffected()
 }
 
+func (c *CustomDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, c.conn, nil, query, args)
+}
+
+func (c *CustomDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, c.conn, nil, query, args)
+}
+
 func (c *CustomDB) Exec(query string) (int64, error) {
 	if c.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -107,6 +116,10 @@ func (c *CustomDB) Exec(query string) (int64, error) {
 	return res.RowsAffected()
 }
 
+func (c *CustomDB) OpenSession(ctx context.Context) (*Session, error) {
+	return openSQLSession(ctx, c.conn, nil)
+}
+
 func (c *CustomDB) GetDatabases() ([]string, error) {
 	// Try standard information_schema or some known patterns if we can't guess
 	// For "custom", we can't easily know.
@@ -247,10 +260,30 @@ func (c *CustomDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDe
 	return nil, fmt.Errorf("not implemented for custom")
 }
 
+func (c *CustomDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return errIndexUnsupported("custom")
+}
+
+func (c *CustomDB) DropIndex(dbName, tableName, indexName string) error {
+	return errIndexUnsupported("custom")
+}
+
+func (c *CustomDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return connection.IndexSizeEstimate{}, errIndexUnsupported("custom")
+}
+
+func (c *CustomDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(customChangeSQLStyle(c.driver), tableName, changes)
+}
+
 func (c *CustomDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if c.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := c.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := c.conn.Begin()
 	if err != nil {
@@ -258,130 +291,12 @@ func (c *CustomDB) ApplyChanges(tableName string, changes connection.ChangeSet)
 	}
 	defer tx.Rollback()
 
-	driver := strings.ToLower(strings.TrimSpace(c.driver))
-	isMySQL := strings.Contains(driver, "mysql")
-	isPostgres := strings.Contains(driver, "postgres") || strings.Contains(driver, "kingbase") || strings.Contains(driver, "pg")
-	isOracle := strings.Contains(driver, "oracle") || strings.Contains(driver, "ora") || strings.Contains(driver, "dm") || strings.Contains(driver, "dameng")
-
-	quoteIdent := func(name string) string {
-		n := strings.TrimSpace(name)
-		if isMySQL {
-			n = strings.Trim(n, "`")
-			n = strings.ReplaceAll(n, "`", "``")
-			if n == "" {
-				return "``"
-			}
-			return "`" + n + "`"
-		}
-		n = strings.Trim(n, "\"")
-		n = strings.ReplaceAll(n, "\"", "\"\"")
-		if n == "" {
-			return "\"\""
-		}
-		return `"` + n + `"`
-	}
-
-	placeholder := func(idx int) string {
-		if isPostgres {
-			return fmt.Sprintf("$%d", idx)
-		}
-		if isOracle {
-			return fmt.Sprintf(":%d", idx)
-		}
-		// MySQL / SQLite / default
-		return "?"
-	}
-
-	schema := ""
-	table := strings.TrimSpace(tableName)
-	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
-		schema = strings.TrimSpace(parts[0])
-		table = strings.TrimSpace(parts[1])
-	}
-
-	qualifiedTable := ""
-	if schema != "" {
-		qualifiedTable = fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
-	} else {
-		qualifiedTable = quoteIdent(table)
-	}
-
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		idx := 0
-		for k, v := range pk {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = %s", quoteIdent(k), placeholder(idx)))
-			args = append(args, v)
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedTable, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range update.Values {
-			idx++
-			sets = append(sets, fmt.Sprintf("%s = %s", quoteIdent(k), placeholder(idx)))
-			args = append(args, v)
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = %s", quoteIdent(k), placeholder(idx)))
-			args = append(args, v)
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", qualifiedTable, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range row {
-			idx++
-			cols = append(cols, quoteIdent(k))
-			placeholders = append(placeholders, placeholder(idx))
-			args = append(args, v)
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qualifiedTable, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
 
@@ -90,6 +90,7 @@ func (d *DamengDB) Connect(config connection.ConnectionConfig) error {
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	d.conn = db
 	d.pingTimeout = getConnectTimeout(config)
 	if err := d.Ping(); err != nil {
@@ -138,7 +139,7 @@ func (d *DamengDB) QueryContext(ctx context.Context, query string) ([]map[string
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (d *DamengDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -165,6 +166,14 @@ func (d *DamengDB) ExecContext(ctx context.Context, query string) (int64, error)
 	return res.RowsAffected()
 }
 
+func (d *DamengDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, d.conn, nil, query, args)
+}
+
+func (d *DamengDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, d.conn, nil, query, args)
+}
+
 func (d *DamengDB) Exec(query string) (int64, error) {
 	if d.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -374,10 +383,30 @@ func (d *DamengDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDe
 	return triggers, nil
 }
 
+func (d *DamengDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(d, "dameng", dbName, tableName, index)
+}
+
+func (d *DamengDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(d, "dameng", dbName, tableName, indexName)
+}
+
+func (d *DamengDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(d, "dameng", dbName, tableName, index)
+}
+
+func (d *DamengDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(oracleChangeStyle, tableName, changes)
+}
+
 func (d *DamengDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if d.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := d.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := d.conn.Begin()
 	if err != nil {
@@ -385,106 +414,12 @@ func (d *DamengDB) ApplyChanges(tableName string, changes connection.ChangeSet)
 	}
 	defer tx.Rollback()
 
-	quoteIdent := func(name string) string {
-		n := strings.TrimSpace(name)
-		n = strings.Trim(n, "\"")
-		n = strings.ReplaceAll(n, "\"", "\"\"")
-		if n == "" {
-			return "\"\""
-		}
-		return `"` + n + `"`
-	}
-
-	schema := ""
-	table := strings.TrimSpace(tableName)
-	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
-		schema = strings.TrimSpace(parts[0])
-		table = strings.TrimSpace(parts[1])
-	}
-
-	qualifiedTable := ""
-	if schema != "" {
-		qualifiedTable = fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
-	} else {
-		qualifiedTable = quoteIdent(table)
-	}
-
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		idx := 0
-		for k, v := range pk {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = :%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedTable, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range update.Values {
-			idx++
-			sets = append(sets, fmt.Sprintf("%s = :%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = :%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", qualifiedTable, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range row {
-			idx++
-			cols = append(cols, quoteIdent(k))
-			placeholders = append(placeholders, fmt.Sprintf(":%d", idx))
-			args = append(args, v)
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qualifiedTable, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
 
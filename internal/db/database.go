@@ -20,12 +20,22 @@ type Database interface {
 	GetIndexes(dbName, tableName string) ([]connection.IndexDefinition, error)
 	GetForeignKeys(dbName, tableName string) ([]connection.ForeignKeyDefinition, error)
 	GetTriggers(dbName, tableName string) ([]connection.TriggerDefinition, error)
+	// CreateIndex、DropIndex、EstimateIndexSize 的 dbName 含义与 GetIndexes 一致；不支持维护索引的驱动返回错误。
+	CreateIndex(dbName, tableName string, index connection.IndexSpec) error
+	DropIndex(dbName, tableName, indexName string) error
+	EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error)
 }
 
 type BatchApplier interface {
 	ApplyChanges(tableName string, changes connection.ChangeSet) error
 }
 
+// SchemaColumnsLister 由有 schema 层级的驱动实现：按 schema 过滤列，并返回当前会话的默认 schema。
+type SchemaColumnsLister interface {
+	GetAllColumnsInSchema(dbName, schema string) ([]connection.ColumnDefinitionWithTable, error)
+	GetDefaultSchema() (string, error)
+}
+
 type databaseFactory func() Database
 
 var databaseFactories = map[string]databaseFactory{
@@ -61,6 +71,10 @@ func registerDatabaseFactory(factory databaseFactory, dbTypes ...string) {
 }
 
 func normalizeDatabaseType(dbType string) string {
+	return normalizeBuiltinDatabaseType(ResolveDriverFamily(dbType))
+}
+
+func normalizeBuiltinDatabaseType(dbType string) string {
 	normalized := strings.ToLower(strings.TrimSpace(dbType))
 	switch normalized {
 	case "doris":
@@ -152,8 +152,8 @@ func (d *DirosDB) getDSN(config connection.ConnectionConfig) string {
 
 	timeout := getConnectTimeoutSeconds(config)
 
-	return fmt.Sprintf("%s:%s@%s(%s)/%s?charset=utf8mb4&parseTime=True&loc=Local&timeout=%ds",
-		config.User, config.Password, protocol, address, database, timeout)
+	return fmt.Sprintf("%s:%s@%s(%s)/%s?%s&parseTime=True&loc=Local&timeout=%ds%s",
+		config.User, config.Password, protocol, address, database, mysqlCharsetDSNParams(config), timeout, mysqlLDAPDSNParams(config))
 }
 
 func resolveDirosCredential(config connection.ConnectionConfig, addressIndex int) (string, string) {
@@ -197,6 +197,7 @@ func (d *DirosDB) Connect(config connection.ConnectionConfig) error {
 			errorDetails = append(errorDetails, fmt.Sprintf("%s 打开失败: %v", address, err))
 			continue
 		}
+		applyPoolOptions(db, candidateConfig)
 
 		timeout := getConnectTimeout(candidateConfig)
 		ctx, cancel := utils.ContextWithTimeout(timeout)
@@ -210,6 +211,7 @@ func (d *DirosDB) Connect(config connection.ConnectionConfig) error {
 
 		d.conn = db
 		d.pingTimeout = timeout
+		d.codec = newTextCodec(candidateConfig)
 		return nil
 	}
 
@@ -0,0 +1,171 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CustomDriverType 描述用户通过配置文件注册的自定义数据源类型。
+// 自定义类型复用已有协议族（如 mysql/postgres）的驱动实现，仅在展示名与默认端口上区分，
+// 用于让 PolarDB、TDSQL、OceanBase(MySQL 模式) 等兼容产品以独立品牌类型出现。
+type CustomDriverType struct {
+	Type        string `json:"type"`
+	Family      string `json:"family"`
+	DisplayName string `json:"displayName"`
+	DefaultPort int    `json:"defaultPort,omitempty"`
+}
+
+type customDriverTypesFile struct {
+	DriverTypes []CustomDriverType `json:"driverTypes"`
+}
+
+var (
+	customDriverTypesMu sync.RWMutex
+	customDriverTypes   = map[string]CustomDriverType{}
+
+	customDriverTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+)
+
+// DefaultCustomDriverTypesPath 返回自定义类型配置文件默认路径（~/.gonavi/driver_types.json）。
+func DefaultCustomDriverTypesPath() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "driver_types.json")
+	}
+	return filepath.Join(".gonavi", "driver_types.json")
+}
+
+// ReloadCustomDriverTypes 从配置文件重新加载自定义类型；文件不存在时清空注册表。
+// 单个条目非法不会影响其它条目，非法原因通过 error 汇总返回。
+func ReloadCustomDriverTypes(path string) ([]CustomDriverType, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = DefaultCustomDriverTypesPath()
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			setCustomDriverTypes(nil)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取自定义类型配置失败：%w", err)
+	}
+
+	var file customDriverTypesFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		// 兼容直接以数组形式书写的配置
+		var list []CustomDriverType
+		if errList := json.Unmarshal(content, &list); errList != nil {
+			return nil, fmt.Errorf("解析自定义类型配置失败：%w", err)
+		}
+		file.DriverTypes = list
+	}
+
+	return RegisterCustomDriverTypes(file.DriverTypes)
+}
+
+// RegisterCustomDriverTypes 校验并替换当前注册的自定义类型，返回生效的条目。
+func RegisterCustomDriverTypes(items []CustomDriverType) ([]CustomDriverType, error) {
+	accepted := make([]CustomDriverType, 0, len(items))
+	var problems []string
+	seen := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		normalized, err := validateCustomDriverType(item)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if _, dup := seen[normalized.Type]; dup {
+			problems = append(problems, fmt.Sprintf("类型 %s 重复定义，已忽略", normalized.Type))
+			continue
+		}
+		seen[normalized.Type] = struct{}{}
+		accepted = append(accepted, normalized)
+	}
+	setCustomDriverTypes(accepted)
+
+	if len(problems) > 0 {
+		return accepted, fmt.Errorf("%s", strings.Join(problems, "；"))
+	}
+	return accepted, nil
+}
+
+func validateCustomDriverType(item CustomDriverType) (CustomDriverType, error) {
+	item.Type = strings.ToLower(strings.TrimSpace(item.Type))
+	item.Family = normalizeBuiltinDatabaseType(item.Family)
+	item.DisplayName = strings.TrimSpace(item.DisplayName)
+
+	if !customDriverTypePattern.MatchString(item.Type) {
+		return item, fmt.Errorf("类型 %q 不合法（仅允许小写字母开头的字母、数字、-、_）", item.Type)
+	}
+	builtinType := normalizeBuiltinDatabaseType(item.Type)
+	_, isFactory := databaseFactories[builtinType]
+	_, isOptional := optionalGoDrivers[builtinType]
+	if isFactory || isOptional || builtinType == "redis" {
+		return item, fmt.Errorf("类型 %s 与内置类型冲突", item.Type)
+	}
+	if item.Family == "" || item.Family == "custom" {
+		return item, fmt.Errorf("类型 %s 未指定有效的协议族", item.Type)
+	}
+	_, familyFactory := databaseFactories[item.Family]
+	_, familyOptional := optionalGoDrivers[item.Family]
+	if !familyFactory && !familyOptional {
+		return item, fmt.Errorf("类型 %s 的协议族 %s 不受支持", item.Type, item.Family)
+	}
+	if item.DefaultPort < 0 || item.DefaultPort > 65535 {
+		return item, fmt.Errorf("类型 %s 的默认端口 %d 不合法", item.Type, item.DefaultPort)
+	}
+	if item.DisplayName == "" {
+		item.DisplayName = strings.ToUpper(item.Type)
+	}
+	return item, nil
+}
+
+func setCustomDriverTypes(items []CustomDriverType) {
+	next := make(map[string]CustomDriverType, len(items))
+	for _, item := range items {
+		next[item.Type] = item
+	}
+	customDriverTypesMu.Lock()
+	customDriverTypes = next
+	customDriverTypesMu.Unlock()
+}
+
+// CustomDriverTypes 返回当前生效的自定义类型（按类型名排序）。
+func CustomDriverTypes() []CustomDriverType {
+	customDriverTypesMu.RLock()
+	out := make([]CustomDriverType, 0, len(customDriverTypes))
+	for _, item := range customDriverTypes {
+		out = append(out, item)
+	}
+	customDriverTypesMu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Type < out[j].Type })
+	return out
+}
+
+// LookupCustomDriverType 查询自定义类型定义。
+func LookupCustomDriverType(driverType string) (CustomDriverType, bool) {
+	key := strings.ToLower(strings.TrimSpace(driverType))
+	if key == "" {
+		return CustomDriverType{}, false
+	}
+	customDriverTypesMu.RLock()
+	item, ok := customDriverTypes[key]
+	customDriverTypesMu.RUnlock()
+	return item, ok
+}
+
+// ResolveDriverFamily 将自定义类型映射为其协议族；非自定义类型原样返回。
+func ResolveDriverFamily(driverType string) string {
+	if item, ok := LookupCustomDriverType(driverType); ok {
+		return item.Family
+	}
+	return driverType
+}
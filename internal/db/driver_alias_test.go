@@ -0,0 +1,52 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReloadCustomDriverTypes_MapsToFamily(t *testing.T) {
+	t.Cleanup(func() { setCustomDriverTypes(nil) })
+
+	path := filepath.Join(t.TempDir(), "driver_types.json")
+	content := `{"driverTypes":[
+		{"type":"PolarDB","family":"mysql","displayName":"PolarDB MySQL","defaultPort":3306},
+		{"type":"mysql","family":"postgres"},
+		{"type":"broken","family":"unknown"}
+	]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	types, err := ReloadCustomDriverTypes(path)
+	if err == nil {
+		t.Fatalf("expected validation error for invalid entries")
+	}
+	if len(types) != 1 || types[0].Type != "polardb" {
+		t.Fatalf("unexpected accepted types: %#v", types)
+	}
+
+	inst, err := NewDatabase("polardb")
+	if err != nil {
+		t.Fatalf("NewDatabase(polardb) failed: %v", err)
+	}
+	if _, ok := inst.(*MySQLDB); !ok {
+		t.Fatalf("expected MySQLDB for polardb, got %T", inst)
+	}
+	if supported, reason := DriverRuntimeSupportStatus("polardb"); !supported {
+		t.Fatalf("expected polardb runtime available, got reason=%q", reason)
+	}
+}
+
+func TestReloadCustomDriverTypes_MissingFileClearsRegistry(t *testing.T) {
+	t.Cleanup(func() { setCustomDriverTypes(nil) })
+
+	setCustomDriverTypes([]CustomDriverType{{Type: "tdsql", Family: "mysql", DisplayName: "TDSQL"}})
+	if _, err := ReloadCustomDriverTypes(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := LookupCustomDriverType("tdsql"); ok {
+		t.Fatalf("expected registry cleared")
+	}
+}
@@ -87,3 +87,17 @@ func TestMySQLBuiltinRuntimeSupportAvailable(t *testing.T) {
 		t.Fatalf("mysql 属于免安装内置驱动，应可用，reason=%s", reason)
 	}
 }
+
+func TestClickHouseIsOptionalDriver(t *testing.T) {
+	SetExternalDriverDownloadDirectory(t.TempDir())
+
+	if !IsOptionalGoDriver("ClickHouse") || IsBuiltinDriver("clickhouse") {
+		t.Fatalf("clickhouse 应为可选驱动")
+	}
+	if name := driverDisplayName("clickhouse"); name != "ClickHouse" {
+		t.Fatalf("display name = %s", name)
+	}
+	if supported, _ := DriverRuntimeSupportStatus("clickhouse"); supported {
+		t.Fatalf("clickhouse 未安装时不应可用")
+	}
+}
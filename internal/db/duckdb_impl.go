@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
 	"GoNavi-Wails/internal/utils"
 )
 
@@ -30,11 +31,13 @@ func (d *DuckDB) Connect(config connection.ConnectionConfig) error {
 	if dsn == "" {
 		dsn = ":memory:"
 	}
+	dsn = readOnlyFileDSN(dsn, config, "access_mode=read_only")
 
 	db, err := sql.Open("duckdb", dsn)
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	d.conn = db
 	d.pingTimeout = getConnectTimeout(config)
 
@@ -75,7 +78,7 @@ func (d *DuckDB) QueryContext(ctx context.Context, query string) ([]map[string]i
 		return nil, nil, err
 	}
 	defer rows.Close()
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (d *DuckDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -101,6 +104,14 @@ func (d *DuckDB) ExecContext(ctx context.Context, query string) (int64, error) {
 	return res.RowsAffected()
 }
 
+func (d *DuckDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, d.conn, nil, query, args)
+}
+
+func (d *DuckDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, d.conn, nil, query, args)
+}
+
 func (d *DuckDB) Exec(query string) (int64, error) {
 	if d.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -183,7 +194,7 @@ func (d *DuckDB) GetCreateStatement(dbName, tableName string) (string, error) {
 	queryCandidates := []string{
 		fmt.Sprintf("SELECT sql FROM duckdb_tables() WHERE table_name = '%s' AND schema_name = '%s' LIMIT 1", escapedTable, escapedSchema),
 		fmt.Sprintf("SELECT sql FROM duckdb_tables() WHERE table_name = '%s' LIMIT 1", escapedTable),
-		fmt.Sprintf("SHOW CREATE TABLE %s", quoteDuckDBQualifiedTable(schema, pureTable)),
+		fmt.Sprintf("SHOW CREATE TABLE %s", dialect.QualifyTable("duckdb", schema, pureTable)),
 	}
 
 	for _, query := range queryCandidates {
@@ -301,10 +312,30 @@ func (d *DuckDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDefi
 	return []connection.TriggerDefinition{}, nil
 }
 
+func (d *DuckDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(d, "duckdb", dbName, tableName, index)
+}
+
+func (d *DuckDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(d, "duckdb", dbName, tableName, indexName)
+}
+
+func (d *DuckDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(d, "duckdb", dbName, tableName, index)
+}
+
+func (d *DuckDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(duckdbChangeStyle, tableName, changes)
+}
+
 func (d *DuckDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if d.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := d.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := d.conn.Begin()
 	if err != nil {
@@ -312,90 +343,12 @@ func (d *DuckDB) ApplyChanges(tableName string, changes connection.ChangeSet) er
 	}
 	defer tx.Rollback()
 
-	quoteIdent := func(name string) string {
-		n := strings.TrimSpace(name)
-		n = strings.Trim(n, "\"")
-		n = strings.ReplaceAll(n, "\"", "\"\"")
-		if n == "" {
-			return "\"\""
-		}
-		return `"` + n + `"`
-	}
-
-	schema := ""
-	table := strings.TrimSpace(tableName)
-	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
-		schema = strings.TrimSpace(parts[0])
-		table = strings.TrimSpace(parts[1])
-	}
-
-	qualifiedTable := quoteIdent(table)
-	if schema != "" {
-		qualifiedTable = fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
-	}
-
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		for k, v := range pk {
-			wheres = append(wheres, fmt.Sprintf("%s = ?", quoteIdent(k)))
-			args = append(args, v)
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedTable, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-		for k, v := range update.Values {
-			sets = append(sets, fmt.Sprintf("%s = ?", quoteIdent(k)))
-			args = append(args, v)
-		}
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			wheres = append(wheres, fmt.Sprintf("%s = ?", quoteIdent(k)))
-			args = append(args, v)
-		}
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", qualifiedTable, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-
-		for k, v := range row {
-			cols = append(cols, quoteIdent(k))
-			placeholders = append(placeholders, "?")
-			args = append(args, v)
-		}
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qualifiedTable, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
 
@@ -435,20 +388,6 @@ func normalizeDuckDBIdentifier(raw string) string {
 	return text
 }
 
-func quoteDuckDBIdentifier(raw string) string {
-	text := normalizeDuckDBIdentifier(raw)
-	return `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
-}
-
-func quoteDuckDBQualifiedTable(schema string, table string) string {
-	s := strings.TrimSpace(schema)
-	t := strings.TrimSpace(table)
-	if s == "" {
-		return quoteDuckDBIdentifier(t)
-	}
-	return quoteDuckDBIdentifier(s) + "." + quoteDuckDBIdentifier(t)
-}
-
 func duckDBRowString(row map[string]interface{}, keys ...string) string {
 	for _, key := range keys {
 		for rowKey, value := range row {
@@ -86,6 +86,7 @@ func (h *HighGoDB) Connect(config connection.ConnectionConfig) error {
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	h.conn = db
 	h.pingTimeout = getConnectTimeout(config)
 
@@ -133,7 +134,7 @@ func (h *HighGoDB) QueryContext(ctx context.Context, query string) ([]map[string
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (h *HighGoDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -160,6 +161,14 @@ func (h *HighGoDB) ExecContext(ctx context.Context, query string) (int64, error)
 	return res.RowsAffected()
 }
 
+func (h *HighGoDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, h.conn, nil, query, args)
+}
+
+func (h *HighGoDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, h.conn, nil, query, args)
+}
+
 func (h *HighGoDB) Exec(query string) (int64, error) {
 	if h.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -278,6 +287,7 @@ ORDER BY a.attnum`, esc(schema), esc(table))
 
 		columns = append(columns, col)
 	}
+	applyPostgresColumnMeta(h.Query, schema, table, columns)
 	return columns, nil
 }
 
@@ -483,6 +493,18 @@ ORDER BY trigger_name, event_manipulation`, esc(table), esc(schema))
 	return triggers, nil
 }
 
+func (h *HighGoDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(h, "highgo", dbName, tableName, index)
+}
+
+func (h *HighGoDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(h, "highgo", dbName, tableName, indexName)
+}
+
+func (h *HighGoDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(h, "highgo", dbName, tableName, index)
+}
+
 func (h *HighGoDB) GetAllColumns(dbName string) ([]connection.ColumnDefinitionWithTable, error) {
 	query := `
 SELECT table_schema, table_name, column_name, data_type
@@ -507,6 +529,8 @@ ORDER BY table_schema, table_name, ordinal_position`
 
 		col := connection.ColumnDefinitionWithTable{
 			TableName: tableName,
+			Schema:    schema,
+			Table:     table,
 			Name:      fmt.Sprintf("%v", row["column_name"]),
 			Type:      fmt.Sprintf("%v", row["data_type"]),
 		}
@@ -515,10 +539,18 @@ ORDER BY table_schema, table_name, ordinal_position`
 	return cols, nil
 }
 
+func (h *HighGoDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(postgresChangeStyle, tableName, changes)
+}
+
 func (h *HighGoDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if h.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := h.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := h.conn.Begin()
 	if err != nil {
@@ -526,105 +558,11 @@ func (h *HighGoDB) ApplyChanges(tableName string, changes connection.ChangeSet)
 	}
 	defer tx.Rollback()
 
-	quoteIdent := func(name string) string {
-		n := strings.TrimSpace(name)
-		n = strings.Trim(n, "\"")
-		n = strings.ReplaceAll(n, "\"", "\"\"")
-		if n == "" {
-			return "\"\""
-		}
-		return `"` + n + `"`
-	}
-
-	schema := ""
-	table := strings.TrimSpace(tableName)
-	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
-		schema = strings.TrimSpace(parts[0])
-		table = strings.TrimSpace(parts[1])
-	}
-
-	qualifiedTable := ""
-	if schema != "" {
-		qualifiedTable = fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
-	} else {
-		qualifiedTable = quoteIdent(table)
-	}
-
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		idx := 0
-		for k, v := range pk {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedTable, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range update.Values {
-			idx++
-			sets = append(sets, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", qualifiedTable, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range row {
-			idx++
-			cols = append(cols, quoteIdent(k))
-			placeholders = append(placeholders, fmt.Sprintf("$%d", idx))
-			args = append(args, v)
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qualifiedTable, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
@@ -0,0 +1,396 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+)
+
+var indexMethodPattern = regexp.MustCompile(`^[A-Za-z_]+$`)
+
+// indexFamily 把数据库类型归并为索引 DDL 语法族，不支持索引维护时返回空串。
+func indexFamily(dbType string) string {
+	switch t := dialect.Normalize(dbType); t {
+	case "mysql", "mariadb", "diros":
+		return "mysql"
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return "postgres"
+	case "oracle", "dameng":
+		return "oracle"
+	case "sqlserver", "sqlite", "duckdb", "clickhouse":
+		return t
+	}
+	return ""
+}
+
+func errIndexUnsupported(dbType string) error {
+	return fmt.Errorf("当前数据源(%s)不支持维护索引", dbType)
+}
+
+// indexColumnList 逐列加引号，列名后可带 ASC/DESC。
+func indexColumnList(dbType string, columns []string) (string, error) {
+	parts := make([]string, 0, len(columns))
+	for _, column := range columns {
+		fields := strings.Fields(column)
+		if len(fields) == 0 {
+			continue
+		}
+		order := ""
+		if last := strings.ToUpper(fields[len(fields)-1]); len(fields) > 1 && (last == "ASC" || last == "DESC") {
+			order = " " + last
+			fields = fields[:len(fields)-1]
+		}
+		parts = append(parts, dialect.QuoteIdent(dbType, strings.Join(fields, " "))+order)
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("请至少选择一个索引字段")
+	}
+	return "(" + strings.Join(parts, ", ") + ")", nil
+}
+
+// BuildCreateIndexSQL 生成创建索引需要依次执行的语句。schemaName 与 GetIndexes 的 dbName 含义一致。
+func BuildCreateIndexSQL(dbType, schemaName, tableName string, index connection.IndexSpec) ([]string, error) {
+	family := indexFamily(dbType)
+	if family == "" {
+		return nil, errIndexUnsupported(dbType)
+	}
+	name := strings.TrimSpace(index.Name)
+	table := strings.TrimSpace(tableName)
+	if name == "" {
+		return nil, fmt.Errorf("索引名称不能为空")
+	}
+	if table == "" {
+		return nil, fmt.Errorf("表名不能为空")
+	}
+	columns, err := indexColumnList(dbType, index.Columns)
+	if err != nil {
+		return nil, err
+	}
+	method := strings.TrimSpace(index.Method)
+	if method != "" && !indexMethodPattern.MatchString(method) {
+		return nil, fmt.Errorf("不支持的索引类型：%s", method)
+	}
+	upperMethod := strings.ToUpper(method)
+	where := strings.TrimSpace(index.Where)
+	if where != "" && family != "postgres" && family != "sqlite" && family != "sqlserver" {
+		return nil, fmt.Errorf("当前数据源(%s)不支持条件索引", dbType)
+	}
+	unique := ""
+	if index.Unique {
+		unique = "UNIQUE "
+	}
+	target := dialect.QualifyTable(dbType, schemaName, table)
+	quotedName := dialect.QuoteIdent(dbType, name)
+
+	switch family {
+	case "mysql":
+		using := ""
+		switch upperMethod {
+		case "", "DEFAULT":
+		case "BTREE", "HASH":
+			using = " USING " + upperMethod
+		case "FULLTEXT", "SPATIAL":
+			if index.Unique {
+				return nil, fmt.Errorf("%s 索引不能同时是唯一索引", upperMethod)
+			}
+			unique = upperMethod + " "
+		default:
+			return nil, fmt.Errorf("不支持的索引类型：%s", method)
+		}
+		return []string{fmt.Sprintf("CREATE %sINDEX %s ON %s %s%s", unique, quotedName, target, columns, using)}, nil
+	case "postgres":
+		using := ""
+		if method != "" && !strings.EqualFold(method, "DEFAULT") {
+			using = " USING " + strings.ToLower(method)
+		}
+		statement := fmt.Sprintf("CREATE %sINDEX %s ON %s%s %s", unique, quotedName, target, using, columns)
+		if where != "" {
+			statement += " WHERE " + where
+		}
+		return []string{statement}, nil
+	case "sqlserver":
+		kind := ""
+		switch upperMethod {
+		case "", "DEFAULT":
+		case "CLUSTERED", "NONCLUSTERED":
+			kind = upperMethod + " "
+		default:
+			return nil, fmt.Errorf("不支持的索引类型：%s", method)
+		}
+		statement := fmt.Sprintf("CREATE %s%sINDEX %s ON %s %s", unique, kind, quotedName, target, columns)
+		if where != "" {
+			statement += " WHERE " + where
+		}
+		return []string{statement}, nil
+	case "oracle":
+		switch upperMethod {
+		case "", "DEFAULT", "NORMAL":
+		case "BITMAP":
+			if index.Unique {
+				return nil, fmt.Errorf("位图索引不能同时是唯一索引")
+			}
+			unique = "BITMAP "
+		default:
+			return nil, fmt.Errorf("不支持的索引类型：%s", method)
+		}
+		// Oracle/达梦的索引属于 schema，与表同 owner
+		return []string{fmt.Sprintf("CREATE %sINDEX %s ON %s %s", unique, dialect.QualifyTable(dbType, schemaName, name), target, columns)}, nil
+	case "sqlite":
+		statement := fmt.Sprintf("CREATE %sINDEX %s ON %s %s", unique, quotedName, dialect.QuoteIdent(dbType, table), columns)
+		if where != "" {
+			statement += " WHERE " + where
+		}
+		return []string{statement}, nil
+	case "duckdb":
+		return []string{fmt.Sprintf("CREATE %sINDEX %s ON %s %s", unique, quotedName, target, columns)}, nil
+	case "clickhouse":
+		if index.Unique {
+			return nil, fmt.Errorf("ClickHouse 不支持唯一索引")
+		}
+		skipType := strings.ToLower(method)
+		if skipType == "" || skipType == "default" {
+			skipType = "minmax"
+		}
+		// 数据跳过索引只对新写入的数据生效，创建后补一次 MATERIALIZE 覆盖存量数据
+		return []string{
+			fmt.Sprintf("ALTER TABLE %s ADD INDEX %s %s TYPE %s GRANULARITY 1", target, quotedName, columns, skipType),
+			fmt.Sprintf("ALTER TABLE %s MATERIALIZE INDEX %s", target, quotedName),
+		}, nil
+	}
+	return nil, errIndexUnsupported(dbType)
+}
+
+// BuildDropIndexSQL 生成删除索引的语句；MySQL 族的 PRIMARY 删除主键。
+func BuildDropIndexSQL(dbType, schemaName, tableName, indexName string) (string, error) {
+	name := strings.TrimSpace(indexName)
+	if name == "" {
+		return "", fmt.Errorf("索引名称不能为空")
+	}
+	target := dialect.QualifyTable(dbType, schemaName, strings.TrimSpace(tableName))
+	switch indexFamily(dbType) {
+	case "mysql":
+		if strings.EqualFold(name, "PRIMARY") {
+			return "ALTER TABLE " + target + " DROP PRIMARY KEY", nil
+		}
+		return "DROP INDEX " + dialect.QuoteIdent(dbType, name) + " ON " + target, nil
+	case "postgres", "oracle", "duckdb":
+		return "DROP INDEX " + dialect.QualifyTable(dbType, schemaName, name), nil
+	case "sqlserver":
+		return "DROP INDEX " + dialect.QuoteIdent(dbType, name) + " ON " + target, nil
+	case "sqlite":
+		return "DROP INDEX " + dialect.QuoteIdent(dbType, name), nil
+	case "clickhouse":
+		return "ALTER TABLE " + target + " DROP INDEX " + dialect.QuoteIdent(dbType, name), nil
+	}
+	return "", errIndexUnsupported(dbType)
+}
+
+// createIndexWith 供各驱动的 CreateIndex 复用：按方言生成 DDL 后依次执行。
+func createIndexWith(inst Database, dbType, schemaName, tableName string, index connection.IndexSpec) error {
+	statements, err := BuildCreateIndexSQL(dbType, schemaName, tableName, index)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err := inst.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropIndexWith(inst Database, dbType, schemaName, tableName, indexName string) error {
+	statement, err := BuildDropIndexSQL(dbType, schemaName, tableName, indexName)
+	if err != nil {
+		return err
+	}
+	_, err = inst.Exec(statement)
+	return err
+}
+
+// indexEntryOverhead 每条索引项除键值外的大致开销（行指针、记录头等），单位字节。
+var indexEntryOverhead = map[string]int64{
+	"mysql":     13, // 记录头 5 字节 + 按 8 字节估计的主键
+	"postgres":  16, // IndexTuple 头 8 字节 + 行指针 4 字节 + 对齐
+	"sqlserver": 12,
+	"oracle":    12,
+	"sqlite":    12,
+	"duckdb":    16,
+	"mongodb":   20,
+}
+
+// finishIndexEstimate 按“行数 × 单项大小 ÷ 70% 页填充率”折算索引体积。
+func finishIndexEstimate(family string, estimate connection.IndexSizeEstimate) connection.IndexSizeEstimate {
+	if estimate.Rows < 0 {
+		estimate.Note = "表尚无统计信息，无法估算行数；可先收集统计信息（如 ANALYZE）后重试"
+		return estimate
+	}
+	estimate.EstimatedBytes = estimate.Rows * (estimate.KeyBytes + indexEntryOverhead[family]) * 10 / 7
+	estimate.Note = "按统计信息估算，页填充率约 70%，实际大小受数据分布与存储引擎影响"
+	return estimate
+}
+
+// estimateColumnWidth 根据列类型推测平均键宽；变长字符串按声明长度的一半、最多 255 字节计。
+func estimateColumnWidth(dataType string, octetLength, precision int64) int64 {
+	t := strings.ToLower(strings.TrimSpace(dataType))
+	switch {
+	case strings.Contains(t, "bigint") || t == "int8" || strings.Contains(t, "double") || t == "float8" || strings.Contains(t, "bigserial"):
+		return 8
+	case strings.Contains(t, "smallint") || t == "int2":
+		return 2
+	case strings.Contains(t, "mediumint"):
+		return 3
+	case strings.Contains(t, "tinyint") || strings.Contains(t, "bool") || t == "bit":
+		return 1
+	case strings.Contains(t, "int") || strings.Contains(t, "serial"):
+		return 4
+	case strings.Contains(t, "float") || t == "real":
+		return 4
+	case strings.Contains(t, "decimal") || strings.Contains(t, "numeric") || t == "number" || t == "money":
+		if precision > 0 {
+			return precision/2 + 1
+		}
+		return 8
+	case strings.Contains(t, "uuid") || strings.Contains(t, "uniqueidentifier"):
+		return 16
+	case strings.Contains(t, "timestamp") || strings.Contains(t, "datetime"):
+		return 8
+	case t == "date":
+		return 4
+	case strings.HasPrefix(t, "time"):
+		return 6
+	case strings.Contains(t, "char") || strings.Contains(t, "text") || strings.Contains(t, "clob") ||
+		strings.Contains(t, "string") || strings.Contains(t, "binary") || strings.Contains(t, "blob"):
+		if octetLength <= 0 {
+			return 32
+		}
+		width := octetLength / 2
+		if width > 255 {
+			width = 255
+		}
+		if width < 1 {
+			width = 1
+		}
+		return width + 2
+	}
+	return 8
+}
+
+func indexRowInt(row map[string]interface{}, key string) int64 {
+	var n float64
+	if _, err := fmt.Sscan(routineString(row[key]), &n); err != nil {
+		return 0
+	}
+	return int64(n)
+}
+
+// estimateIndexSizeWith 供各驱动的 EstimateIndexSize 复用：只读统计信息与列元数据，不扫描表数据（SQLite 除外）。
+func estimateIndexSizeWith(inst Database, dbType, schemaName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	family := indexFamily(dbType)
+	if family == "" {
+		return connection.IndexSizeEstimate{}, errIndexUnsupported(dbType)
+	}
+	table := strings.TrimSpace(tableName)
+	if table == "" {
+		return connection.IndexSizeEstimate{}, fmt.Errorf("表名不能为空")
+	}
+	schema := strings.TrimSpace(schemaName)
+	esc := func(v string) string { return strings.ReplaceAll(v, "'", "''") }
+	estimate := connection.IndexSizeEstimate{Rows: -1}
+
+	var statsQuery, columnsQuery string
+	switch family {
+	case "mysql":
+		statsQuery = fmt.Sprintf("SELECT TABLE_ROWS AS row_count, DATA_LENGTH AS data_bytes FROM information_schema.TABLES WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s'", esc(schema), esc(table))
+		columnsQuery = fmt.Sprintf("SELECT COLUMN_NAME AS column_name, DATA_TYPE AS data_type, CHARACTER_OCTET_LENGTH AS octet_length, NUMERIC_PRECISION AS numeric_precision FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s'", esc(schema), esc(table))
+	case "postgres":
+		if schema == "" {
+			schema = "public"
+		}
+		// reltuples 为 -1（PG14+）或 0 时表示尚未分析
+		statsQuery = fmt.Sprintf("SELECT CASE WHEN c.reltuples < 0 THEN NULL ELSE c.reltuples::bigint END AS row_count, pg_relation_size(c.oid) AS data_bytes FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = '%s' AND c.relname = '%s'", esc(schema), esc(table))
+		columnsQuery = fmt.Sprintf("SELECT a.attname AS column_name, format_type(a.atttypid, a.atttypmod) AS data_type, CASE WHEN a.atttypmod > 4 THEN a.atttypmod - 4 ELSE 0 END AS octet_length, 0 AS numeric_precision, s.avg_width AS avg_width "+
+			"FROM pg_attribute a JOIN pg_class c ON c.oid = a.attrelid JOIN pg_namespace n ON n.oid = c.relnamespace "+
+			"LEFT JOIN pg_stats s ON s.schemaname = n.nspname AND s.tablename = c.relname AND s.attname = a.attname "+
+			"WHERE n.nspname = '%s' AND c.relname = '%s' AND a.attnum > 0 AND NOT a.attisdropped", esc(schema), esc(table))
+	case "sqlserver":
+		if schema == "" {
+			schema = "dbo"
+		}
+		statsQuery = fmt.Sprintf("SELECT SUM(CASE WHEN a.type = 1 THEN p.rows ELSE 0 END) AS row_count, SUM(a.used_pages) * 8192 AS data_bytes FROM sys.partitions p JOIN sys.allocation_units a ON a.container_id = p.partition_id WHERE p.object_id = OBJECT_ID(N'%s') AND p.index_id IN (0, 1)", esc(dialect.QualifyTable(dbType, schema, table)))
+		columnsQuery = fmt.Sprintf("SELECT COLUMN_NAME AS column_name, DATA_TYPE AS data_type, CHARACTER_OCTET_LENGTH AS octet_length, NUMERIC_PRECISION AS numeric_precision FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s'", esc(schema), esc(table))
+	case "oracle":
+		statsQuery = fmt.Sprintf("SELECT NUM_ROWS AS ROW_COUNT, BLOCKS * 8192 AS DATA_BYTES FROM ALL_TABLES WHERE OWNER = '%s' AND TABLE_NAME = '%s'", esc(strings.ToUpper(schema)), esc(table))
+		columnsQuery = fmt.Sprintf("SELECT COLUMN_NAME, DATA_TYPE, DATA_LENGTH AS OCTET_LENGTH, DATA_PRECISION AS NUMERIC_PRECISION, AVG_COL_LEN AS AVG_WIDTH FROM ALL_TAB_COLUMNS WHERE OWNER = '%s' AND TABLE_NAME = '%s'", esc(strings.ToUpper(schema)), esc(table))
+	case "sqlite":
+		statsQuery = fmt.Sprintf("SELECT COUNT(*) AS row_count FROM %s", dialect.QuoteIdent(dbType, table))
+		columnsQuery = fmt.Sprintf("SELECT name AS column_name, type AS data_type, 0 AS octet_length, 0 AS numeric_precision FROM pragma_table_info('%s')", esc(table))
+	case "duckdb":
+		if schema == "" {
+			schema = "main"
+		}
+		statsQuery = fmt.Sprintf("SELECT estimated_size AS row_count FROM duckdb_tables() WHERE schema_name = '%s' AND table_name = '%s'", esc(schema), esc(table))
+		columnsQuery = fmt.Sprintf("SELECT column_name, data_type, character_octet_length AS octet_length, numeric_precision FROM information_schema.columns WHERE table_schema = '%s' AND table_name = '%s'", esc(schema), esc(table))
+	case "clickhouse":
+		rows, _, err := inst.Query(fmt.Sprintf("SELECT total_rows AS row_count, total_bytes AS data_bytes FROM system.tables WHERE database = '%s' AND name = '%s'", esc(schema), esc(table)))
+		if err != nil {
+			return estimate, err
+		}
+		if len(rows) > 0 {
+			estimate.Rows = indexRowInt(rows[0], "row_count")
+			estimate.TableBytes = indexRowInt(rows[0], "data_bytes")
+		}
+		estimate.Note = "ClickHouse 数据跳过索引只为每个颗粒存储摘要，体积通常远小于表数据"
+		return estimate, nil
+	}
+
+	statsRows, _, err := inst.Query(statsQuery)
+	if err != nil {
+		return estimate, err
+	}
+	if len(statsRows) == 0 {
+		return estimate, fmt.Errorf("表 %s 不存在", table)
+	}
+	stats := lowerKeys(statsRows[0])
+	if routineString(stats["row_count"]) != "" {
+		estimate.Rows = indexRowInt(stats, "row_count")
+	}
+	estimate.TableBytes = indexRowInt(stats, "data_bytes")
+
+	columnRows, _, err := inst.Query(columnsQuery)
+	if err != nil {
+		return estimate, err
+	}
+	widths := make(map[string]int64, len(columnRows))
+	for _, raw := range columnRows {
+		row := lowerKeys(raw)
+		width := estimateColumnWidth(routineString(row["data_type"]), indexRowInt(row, "octet_length"), indexRowInt(row, "numeric_precision"))
+		if avg := indexRowInt(row, "avg_width"); avg > 0 {
+			width = avg
+		}
+		widths[strings.ToLower(routineString(row["column_name"]))] = width
+	}
+	for _, column := range index.Columns {
+		fields := strings.Fields(column)
+		if len(fields) == 0 {
+			continue
+		}
+		width, ok := widths[strings.ToLower(fields[0])]
+		if !ok {
+			return estimate, fmt.Errorf("字段 %s 不存在", fields[0])
+		}
+		estimate.KeyBytes += width
+	}
+	return finishIndexEstimate(family, estimate), nil
+}
+
+func lowerKeys(row map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		out[strings.ToLower(k)] = v
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBuildCreateIndexSQL(t *testing.T) {
+	cases := []struct {
+		dbType, schema string
+		index          connection.IndexSpec
+		want           string
+	}{
+		{"mysql", "shop", connection.IndexSpec{Name: "idx_user", Columns: []string{"user_id", "created_at desc"}, Method: "BTREE"},
+			"CREATE INDEX `idx_user` ON `shop`.`orders` (`user_id`, `created_at` DESC) USING BTREE"},
+		{"mariadb", "shop", connection.IndexSpec{Name: "ft_note", Columns: []string{"note"}, Method: "fulltext"},
+			"CREATE FULLTEXT INDEX `ft_note` ON `shop`.`orders` (`note`)"},
+		{"kingbase", "public", connection.IndexSpec{Name: "uk_no", Columns: []string{"order_no"}, Unique: true, Method: "btree", Where: "deleted_at IS NULL"},
+			`CREATE UNIQUE INDEX "uk_no" ON "public"."orders" USING btree ("order_no") WHERE deleted_at IS NULL`},
+		{"sqlserver", "dbo", connection.IndexSpec{Name: "ix_user", Columns: []string{"user_id"}, Method: "nonclustered"},
+			"CREATE NONCLUSTERED INDEX [ix_user] ON [dbo].[orders] ([user_id])"},
+		{"dameng", "APP", connection.IndexSpec{Name: "IDX_STATUS", Columns: []string{"STATUS"}, Method: "bitmap"},
+			`CREATE BITMAP INDEX "APP"."IDX_STATUS" ON "APP"."orders" ("STATUS")`},
+		{"sqlite", "main", connection.IndexSpec{Name: "idx_user", Columns: []string{"user_id"}},
+			`CREATE INDEX "idx_user" ON "orders" ("user_id")`},
+	}
+	for _, tc := range cases {
+		got, err := BuildCreateIndexSQL(tc.dbType, tc.schema, "orders", tc.index)
+		if err != nil || len(got) != 1 || got[0] != tc.want {
+			t.Fatalf("%s: got %q err %v", tc.dbType, got, err)
+		}
+	}
+
+	got, err := BuildCreateIndexSQL("clickhouse", "logs", "events", connection.IndexSpec{Name: "idx_ts", Columns: []string{"ts"}})
+	if err != nil || len(got) != 2 || !strings.Contains(got[0], "TYPE minmax GRANULARITY 1") || !strings.Contains(got[1], "MATERIALIZE INDEX `idx_ts`") {
+		t.Fatalf("clickhouse: got %q err %v", got, err)
+	}
+
+	invalid := []struct {
+		dbType string
+		index  connection.IndexSpec
+	}{
+		{"mysql", connection.IndexSpec{Name: "i", Columns: []string{"a"}, Where: "a > 0"}},
+		{"postgres", connection.IndexSpec{Name: "i", Columns: []string{"a"}, Method: "gin; DROP TABLE t"}},
+		{"oracle", connection.IndexSpec{Name: "i", Columns: []string{"a"}, Method: "BITMAP", Unique: true}},
+		{"postgres", connection.IndexSpec{Name: "i"}},
+		{"tdengine", connection.IndexSpec{Name: "i", Columns: []string{"a"}}},
+	}
+	for _, tc := range invalid {
+		if _, err := BuildCreateIndexSQL(tc.dbType, "s", "t", tc.index); err == nil {
+			t.Fatalf("%s %+v: expected error", tc.dbType, tc.index)
+		}
+	}
+}
+
+func TestBuildDropIndexSQL(t *testing.T) {
+	cases := map[string]string{
+		"mysql":      "DROP INDEX `idx` ON `shop`.`orders`",
+		"postgres":   `DROP INDEX "shop"."idx"`,
+		"sqlserver":  "DROP INDEX [idx] ON [shop].[orders]",
+		"sqlite":     `DROP INDEX "idx"`,
+		"clickhouse": "ALTER TABLE `shop`.`orders` DROP INDEX `idx`",
+	}
+	for dbType, want := range cases {
+		got, err := BuildDropIndexSQL(dbType, "shop", "orders", "idx")
+		if err != nil || got != want {
+			t.Fatalf("%s: got %q err %v", dbType, got, err)
+		}
+	}
+	if got, _ := BuildDropIndexSQL("mysql", "shop", "orders", "PRIMARY"); got != "ALTER TABLE `shop`.`orders` DROP PRIMARY KEY" {
+		t.Fatalf("mysql primary: got %q", got)
+	}
+}
+
+type indexStatsFakeDB struct {
+	Database
+	results map[string][]map[string]interface{}
+}
+
+func (f *indexStatsFakeDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	for marker, rows := range f.results {
+		if strings.Contains(query, marker) {
+			return rows, nil, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+func TestEstimateIndexSizeWith(t *testing.T) {
+	fake := &indexStatsFakeDB{results: map[string][]map[string]interface{}{
+		"information_schema.TABLES": {{"row_count": int64(1000000), "data_bytes": int64(200 << 20)}},
+		"information_schema.COLUMNS": {
+			{"column_name": "user_id", "data_type": "bigint"},
+			{"column_name": "email", "data_type": "varchar", "octet_length": int64(1020)},
+		},
+	}}
+	estimate, err := estimateIndexSizeWith(fake, "mysql", "shop", "users", connection.IndexSpec{Columns: []string{"user_id", "email"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// bigint 8 + varchar(255) utf8mb4 按一半 510 截到 255 再加 2 字节长度前缀
+	if estimate.Rows != 1000000 || estimate.KeyBytes != 265 || estimate.EstimatedBytes != 1000000*(265+13)*10/7 {
+		t.Fatalf("estimate = %+v", estimate)
+	}
+
+	fake.results = map[string][]map[string]interface{}{
+		"pg_class c JOIN": {{"row_count": nil, "data_bytes": int64(8192)}},
+		"pg_attribute":    {{"column_name": "id", "data_type": "integer"}},
+	}
+	estimate, err = estimateIndexSizeWith(fake, "postgres", "", "users", connection.IndexSpec{Columns: []string{"id"}})
+	if err != nil || estimate.Rows != -1 || estimate.EstimatedBytes != 0 || estimate.Note == "" {
+		t.Fatalf("unanalyzed estimate = %+v err %v", estimate, err)
+	}
+
+	if _, err := estimateIndexSizeWith(fake, "postgres", "", "users", connection.IndexSpec{Columns: []string{"missing"}}); err == nil {
+		t.Fatal("expected unknown column error")
+	}
+}
@@ -119,6 +119,7 @@ func (k *KingbaseDB) Connect(config connection.ConnectionConfig) error {
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	k.conn = db
 	k.pingTimeout = getConnectTimeout(config)
 	if err := k.Ping(); err != nil {
@@ -167,7 +168,7 @@ func (k *KingbaseDB) QueryContext(ctx context.Context, query string) ([]map[stri
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (k *KingbaseDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -194,6 +195,14 @@ func (k *KingbaseDB) ExecContext(ctx context.Context, query string) (int64, erro
 	return res.RowsAffected()
 }
 
+func (k *KingbaseDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, k.conn, nil, query, args)
+}
+
+func (k *KingbaseDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, k.conn, nil, query, args)
+}
+
 func (k *KingbaseDB) Exec(query string) (int64, error) {
 	if k.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -598,10 +607,30 @@ func (k *KingbaseDB) GetTriggers(dbName, tableName string) ([]connection.Trigger
 	return triggers, nil
 }
 
+func (k *KingbaseDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(k, "kingbase", dbName, tableName, index)
+}
+
+func (k *KingbaseDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(k, "kingbase", dbName, tableName, indexName)
+}
+
+func (k *KingbaseDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(k, "kingbase", dbName, tableName, index)
+}
+
+func (k *KingbaseDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(postgresChangeStyle, tableName, changes)
+}
+
 func (k *KingbaseDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if k.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := k.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := k.conn.Begin()
 	if err != nil {
@@ -609,106 +638,12 @@ func (k *KingbaseDB) ApplyChanges(tableName string, changes connection.ChangeSet
 	}
 	defer tx.Rollback()
 
-	quoteIdent := func(name string) string {
-		n := strings.TrimSpace(name)
-		n = strings.Trim(n, "\"")
-		n = strings.ReplaceAll(n, "\"", "\"\"")
-		if n == "" {
-			return "\"\""
-		}
-		return `"` + n + `"`
-	}
-
-	schema := ""
-	table := strings.TrimSpace(tableName)
-	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
-		schema = strings.TrimSpace(parts[0])
-		table = strings.TrimSpace(parts[1])
-	}
-
-	qualifiedTable := ""
-	if schema != "" {
-		qualifiedTable = fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
-	} else {
-		qualifiedTable = quoteIdent(table)
-	}
-
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		idx := 0
-		for k, v := range pk {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedTable, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range update.Values {
-			idx++
-			sets = append(sets, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", qualifiedTable, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range row {
-			idx++
-			cols = append(cols, quoteIdent(k))
-			placeholders = append(placeholders, fmt.Sprintf("$%d", idx))
-			args = append(args, v)
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qualifiedTable, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
 
@@ -736,6 +671,8 @@ func (k *KingbaseDB) GetAllColumns(dbName string) ([]connection.ColumnDefinition
 		}
 		col := connection.ColumnDefinitionWithTable{
 			TableName: tableName,
+			Schema:    schema,
+			Table:     table,
 			Name:      fmt.Sprintf("%v", row["column_name"]),
 			Type:      fmt.Sprintf("%v", row["data_type"]),
 		}
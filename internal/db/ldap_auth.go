@@ -0,0 +1,63 @@
+package db
+
+import (
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+)
+
+// LDAP/AD 目录账号认证的客户端参数。服务端（如 PostgreSQL ldap、MySQL authentication_ldap_simple）
+// 负责校验，客户端只需以对应方式提交凭据：
+//   - MySQL/MariaDB：启用 mysql_clear_password 明文插件；
+//   - MongoDB：authMechanism=PLAIN，authSource=$external；
+//   - SQL Server：以 DOMAIN\user 形式登录，驱动自动使用 NTLM。
+
+const mongoExternalAuthSource = "$external"
+
+// mysqlLDAPDSNParams 返回需追加到 MySQL DSN 的参数（含前导 &），未启用时为空。
+func mysqlLDAPDSNParams(config connection.ConnectionConfig) string {
+	if !config.LDAPAuth {
+		return ""
+	}
+	if !config.UseSSH {
+		logger.Warnf("LDAP 认证将以明文提交密码，建议通过 SSH 隧道或 TLS 连接：地址=%s:%d 用户=%s", config.Host, config.Port, config.User)
+	}
+	return "&allowCleartextPasswords=true"
+}
+
+// mongoLDAPAuth 返回 LDAP 认证使用的 authMechanism 与 authSource；显式配置的值优先。
+func mongoLDAPAuth(config connection.ConnectionConfig) (string, string, bool) {
+	mechanism := strings.TrimSpace(config.MongoAuthMechanism)
+	if !config.LDAPAuth && !strings.EqualFold(mechanism, "PLAIN") {
+		return "", "", false
+	}
+	if mechanism == "" {
+		mechanism = "PLAIN"
+	}
+	source := strings.TrimSpace(config.AuthSource)
+	if source == "" {
+		source = mongoExternalAuthSource
+	}
+	return mechanism, source, true
+}
+
+// sqlServerLDAPUser 将用户名转换为 DOMAIN\user；user@domain 形式取 @ 之后的域名。
+func sqlServerLDAPUser(config connection.ConnectionConfig) string {
+	user := strings.TrimSpace(config.User)
+	if !config.LDAPAuth || user == "" || strings.Contains(user, `\`) {
+		return config.User
+	}
+	domain := strings.TrimSpace(config.LDAPDomain)
+	if at := strings.LastIndex(user, "@"); at > 0 {
+		if domain == "" {
+			domain = user[at+1:]
+		}
+		user = user[:at]
+	}
+	if domain == "" {
+		logger.Warnf("SQL Server LDAP 认证未配置域名，将按 SQL 账号登录：用户=%s", user)
+		return config.User
+	}
+	return domain + `\` + user
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestLDAPAuthClientParams(t *testing.T) {
+	m := &MySQLDB{}
+	cfg := connection.ConnectionConfig{Type: "mysql", Host: "127.0.0.1", Port: 3306, User: "alice", Password: "pw", LDAPAuth: true}
+	if dsn := m.getDSN(cfg); !strings.HasSuffix(dsn, "&allowCleartextPasswords=true") {
+		t.Fatalf("mysql dsn 未启用明文认证插件：%s", dsn)
+	}
+	cfg.LDAPAuth = false
+	if dsn := m.getDSN(cfg); strings.Contains(dsn, "allowCleartextPasswords") {
+		t.Fatalf("未启用 LDAP 时不应追加明文认证参数：%s", dsn)
+	}
+
+	mechanism, source, ok := mongoLDAPAuth(connection.ConnectionConfig{LDAPAuth: true, Database: "app"})
+	if !ok || mechanism != "PLAIN" || source != "$external" {
+		t.Fatalf("mongo ldap auth = %q %q %v", mechanism, source, ok)
+	}
+
+	cases := map[string]connection.ConnectionConfig{
+		`CORP\alice`:     {User: "alice", LDAPAuth: true, LDAPDomain: "CORP"},
+		`corp.local\bob`: {User: "bob@corp.local", LDAPAuth: true},
+		`EXISTING\x`:     {User: `EXISTING\x`, LDAPAuth: true, LDAPDomain: "CORP"},
+		"sa":             {User: "sa", LDAPDomain: "CORP"},
+	}
+	for want, cfg := range cases {
+		if got := sqlServerLDAPUser(cfg); got != want {
+			t.Fatalf("sqlServerLDAPUser(%+v) = %q, want %q", cfg, got, want)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strings"
 	"time"
 
 	"GoNavi-Wails/internal/connection"
@@ -22,6 +21,7 @@ import (
 type MariaDB struct {
 	conn        *sql.DB
 	pingTimeout time.Duration
+	codec       *textCodec // 非 UTF-8 连接字符集/遗留 GBK 数据的编码转换，nil 表示无需转换
 }
 
 func (m *MariaDB) getDSN(config connection.ConnectionConfig) string {
@@ -41,18 +41,24 @@ func (m *MariaDB) getDSN(config connection.ConnectionConfig) string {
 
 	timeout := getConnectTimeoutSeconds(config)
 
-	return fmt.Sprintf("%s:%s@%s(%s)/%s?charset=utf8mb4&parseTime=True&loc=Local&timeout=%ds",
-		config.User, config.Password, protocol, address, database, timeout)
+	return fmt.Sprintf("%s:%s@%s(%s)/%s?%s&parseTime=True&loc=Local&timeout=%ds%s%s%s",
+		config.User, config.Password, protocol, address, database, mysqlCharsetDSNParams(config), timeout, mysqlTLSDSNParams(config), mysqlLDAPDSNParams(config),
+		mysqlReadOnlyDSNParams(config, "tx_read_only"))
 }
 
 func (m *MariaDB) Connect(config connection.ConnectionConfig) error {
+	if err := registerMySQLTLS(config); err != nil {
+		return fmt.Errorf("SSL 配置无效：%w", err)
+	}
 	dsn := m.getDSN(config)
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	m.conn = db
 	m.pingTimeout = getConnectTimeout(config)
+	m.codec = newTextCodec(config)
 
 	if err := m.Ping(); err != nil {
 		return fmt.Errorf("连接建立后验证失败：%w", err)
@@ -85,13 +91,17 @@ func (m *MariaDB) QueryContext(ctx context.Context, query string) ([]map[string]
 		return nil, nil, fmt.Errorf("connection not open")
 	}
 
+	query, err := m.codec.encodeQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
 	rows, err := m.conn.QueryContext(ctx, query)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, m.codec)
 }
 
 func (m *MariaDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -99,18 +109,26 @@ func (m *MariaDB) Query(query string) ([]map[string]interface{}, []string, error
 		return nil, nil, fmt.Errorf("connection not open")
 	}
 
+	query, err := m.codec.encodeQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
 	rows, err := m.conn.Query(query)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer rows.Close()
-	return scanRows(rows)
+	return scanRowsWithCodec(rows, m.codec)
 }
 
 func (m *MariaDB) ExecContext(ctx context.Context, query string) (int64, error) {
 	if m.conn == nil {
 		return 0, fmt.Errorf("connection not open")
 	}
+	query, err := m.codec.encodeQuery(query)
+	if err != nil {
+		return 0, err
+	}
 	res, err := m.conn.ExecContext(ctx, query)
 	if err != nil {
 		return 0, err
@@ -118,10 +136,22 @@ func (m *MariaDB) ExecContext(ctx context.Context, query string) (int64, error)
 	return res.RowsAffected()
 }
 
+func (m *MariaDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, m.conn, m.codec, query, args)
+}
+
+func (m *MariaDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, m.conn, m.codec, query, args)
+}
+
 func (m *MariaDB) Exec(query string) (int64, error) {
 	if m.conn == nil {
 		return 0, fmt.Errorf("connection not open")
 	}
+	query, err := m.codec.encodeQuery(query)
+	if err != nil {
+		return 0, err
+	}
 	res, err := m.conn.Exec(query)
 	if err != nil {
 		return 0, err
@@ -211,9 +241,11 @@ func (m *MariaDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefin
 			d := fmt.Sprintf("%v", row["Default"])
 			col.Default = &d
 		}
+		applyMySQLColumnExtra(&col)
 
 		columns = append(columns, col)
 	}
+	fillMySQLGenerationExprs(m.Query, dbName, tableName, columns)
 	return columns, nil
 }
 
@@ -304,10 +336,30 @@ func (m *MariaDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDef
 	return triggers, nil
 }
 
+func (m *MariaDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(m, "mariadb", dbName, tableName, index)
+}
+
+func (m *MariaDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(m, "mariadb", dbName, tableName, indexName)
+}
+
+func (m *MariaDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(m, "mariadb", dbName, tableName, index)
+}
+
+func (m *MariaDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(mysqlChangeStyle, tableName, changes)
+}
+
 func (m *MariaDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if m.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := m.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := m.conn.Begin()
 	if err != nil {
@@ -315,75 +367,12 @@ func (m *MariaDB) ApplyChanges(tableName string, changes connection.ChangeSet) e
 	}
 	defer tx.Rollback()
 
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		for k, v := range pk {
-			wheres = append(wheres, fmt.Sprintf("`%s` = ?", k))
-			args = append(args, normalizeMySQLDateTimeValue(v))
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM `%s` WHERE %s", tableName, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-
-		for k, v := range update.Values {
-			sets = append(sets, fmt.Sprintf("`%s` = ?", k))
-			args = append(args, normalizeMySQLDateTimeValue(v))
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			wheres = append(wheres, fmt.Sprintf("`%s` = ?", k))
-			args = append(args, normalizeMySQLDateTimeValue(v))
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE `%s` SET %s WHERE %s", tableName, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-
-		for k, v := range row {
-			cols = append(cols, fmt.Sprintf("`%s`", k))
-			placeholders = append(placeholders, "?")
-			args = append(args, normalizeMySQLDateTimeValue(v))
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO `%s` (%s) VALUES (%s)", tableName, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return m.codec.execTx(tx, query, args)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
 
@@ -240,6 +240,10 @@ func (m *MongoDB) getURI(config connection.ConnectionConfig) string {
 	if authMechanism := strings.TrimSpace(config.MongoAuthMechanism); authMechanism != "" {
 		params.Set("authMechanism", authMechanism)
 	}
+	if mechanism, source, ok := mongoLDAPAuth(config); ok {
+		params.Set("authMechanism", mechanism)
+		params.Set("authSource", source)
+	}
 
 	if encoded := params.Encode(); encoded != "" {
 		uri += "?" + encoded
@@ -308,6 +312,9 @@ func (m *MongoDB) Connect(config connection.ConnectionConfig) error {
 		localConfig.UseSSH = false
 		localConfig.URI = ""
 		localConfig.Hosts = []string{normalizeMongoAddress(host, port)}
+		if strings.TrimSpace(localConfig.SSLServerName) == "" {
+			localConfig.SSLServerName = targetHost
+		}
 		connectConfig = localConfig
 		logger.Infof("MongoDB 通过本地端口转发连接：%s -> %s:%d", forwarder.LocalAddr, targetHost, targetPort)
 	}
@@ -318,6 +325,12 @@ func (m *MongoDB) Connect(config connection.ConnectionConfig) error {
 		m.database = "admin"
 	}
 
+	// 未显式指定证书主机名时留空，由驱动按副本集各成员的地址校验
+	tlsConf, err := buildTLSConfig(connectConfig, strings.TrimSpace(connectConfig.SSLServerName))
+	if err != nil {
+		return fmt.Errorf("SSL 配置无效：%w", err)
+	}
+
 	attemptConfigs := buildMongoAuthAttempts(connectConfig)
 	var errorDetails []string
 	for index, attemptConfig := range attemptConfigs {
@@ -328,6 +341,9 @@ func (m *MongoDB) Connect(config connection.ConnectionConfig) error {
 
 		uri := m.getURI(attemptConfig)
 		clientOpts := options.Client().ApplyURI(uri)
+		if tlsConf != nil {
+			clientOpts.SetTLSConfig(tlsConf)
+		}
 		client, err := mongo.Connect(clientOpts)
 		if err != nil {
 			errorDetails = append(errorDetails, fmt.Sprintf("%s连接失败: %v", authLabel, err))
@@ -1085,6 +1101,88 @@ func (m *MongoDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDef
 	return []connection.TriggerDefinition{}, nil
 }
 
+func (m *MongoDB) collectionFor(dbName, tableName string) (*mongo.Collection, error) {
+	if m.client == nil {
+		return nil, fmt.Errorf("connection not open")
+	}
+	targetDB := dbName
+	if targetDB == "" {
+		targetDB = m.database
+	}
+	return m.client.Database(targetDB).Collection(tableName), nil
+}
+
+// CreateIndex 字段后可带 DESC 表示降序；Method 为 text/hashed/2dsphere 等特殊索引类型，Where 为 JSON 形式的 partialFilterExpression。
+func (m *MongoDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	collection, err := m.collectionFor(dbName, tableName)
+	if err != nil {
+		return err
+	}
+	keys := bson.D{}
+	for _, column := range index.Columns {
+		fields := strings.Fields(column)
+		if len(fields) == 0 {
+			continue
+		}
+		var value interface{} = 1
+		if n := len(fields); n > 1 {
+			switch strings.ToUpper(fields[n-1]) {
+			case "DESC":
+				value, fields = -1, fields[:n-1]
+			case "ASC":
+				fields = fields[:n-1]
+			}
+		}
+		if method := strings.ToLower(strings.TrimSpace(index.Method)); method != "" && method != "default" {
+			value = method
+		}
+		keys = append(keys, bson.E{Key: strings.Join(fields, " "), Value: value})
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("请至少选择一个索引字段")
+	}
+	opts := options.Index().SetUnique(index.Unique)
+	if name := strings.TrimSpace(index.Name); name != "" {
+		opts.SetName(name)
+	}
+	if where := strings.TrimSpace(index.Where); where != "" {
+		var filter bson.D
+		if err := bson.UnmarshalExtJSON([]byte(where), false, &filter); err != nil {
+			return fmt.Errorf("部分索引条件需为 JSON 对象：%w", err)
+		}
+		opts.SetPartialFilterExpression(filter)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: opts})
+	return err
+}
+
+func (m *MongoDB) DropIndex(dbName, tableName, indexName string) error {
+	collection, err := m.collectionFor(dbName, tableName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return collection.Indexes().DropOne(ctx, strings.TrimSpace(indexName))
+}
+
+// EstimateIndexSize 文档数取自集合元数据，键宽按每个字段 16 字节粗略估计。
+func (m *MongoDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	collection, err := m.collectionFor(dbName, tableName)
+	if err != nil {
+		return connection.IndexSizeEstimate{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	count, err := collection.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return connection.IndexSizeEstimate{}, err
+	}
+	return finishIndexEstimate("mongodb", connection.IndexSizeEstimate{Rows: count, KeyBytes: int64(16 * len(index.Columns))}), nil
+}
+
 // ApplyChanges implements batch changes for MongoDB
 func (m *MongoDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if m.client == nil {
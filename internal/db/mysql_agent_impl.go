@@ -29,7 +29,14 @@ const (
 	mysqlAgentMethodGetIndexes       = "getIndexes"
 	mysqlAgentMethodGetForeignKeys   = "getForeignKeys"
 	mysqlAgentMethodGetTriggers      = "getTriggers"
+	mysqlAgentMethodGetTriggerDef    = "getTriggerDefinition"
+	mysqlAgentMethodCreateTrigger    = "createTrigger"
+	mysqlAgentMethodDropTrigger      = "dropTrigger"
+	mysqlAgentMethodCreateIndex      = "createIndex"
+	mysqlAgentMethodDropIndex        = "dropIndex"
+	mysqlAgentMethodEstimateIndex    = "estimateIndexSize"
 	mysqlAgentMethodApplyChanges     = "applyChanges"
+	mysqlAgentMethodPlanChanges      = "planChanges"
 	mysqlAgentDefaultScannerMaxBytes = 8 << 20
 )
 
@@ -41,6 +48,9 @@ type mysqlAgentRequest struct {
 	DBName    string                       `json:"dbName,omitempty"`
 	TableName string                       `json:"tableName,omitempty"`
 	Changes   *connection.ChangeSet        `json:"changes,omitempty"`
+	Trigger   *connection.TriggerSpec      `json:"trigger,omitempty"`
+	Index     *connection.IndexSpec        `json:"index,omitempty"`
+	Params    []interface{}                `json:"params,omitempty"`
 }
 
 type mysqlAgentResponse struct {
@@ -92,6 +102,7 @@ func newMySQLAgentClient(executablePath string) (*mysqlAgentClient, error) {
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("启动 MySQL 驱动代理失败：%w", err)
 	}
+	driverAgentProcesses.Add(1)
 
 	client := &mysqlAgentClient{
 		cmd:    cmd,
@@ -141,18 +152,18 @@ func (c *mysqlAgentClient) call(req mysqlAgentRequest, out interface{}, fields *
 	if _, err := c.stdin.Write(payload); err != nil {
 		stderrText := c.stderrText()
 		if stderrText == "" {
-			return fmt.Errorf("调用 MySQL 驱动代理失败：%w", err)
+			return fmt.Errorf("调用 MySQL 驱动代理失败：%w", agentPipeError{err})
 		}
-		return fmt.Errorf("调用 MySQL 驱动代理失败：%w（stderr: %s）", err, stderrText)
+		return fmt.Errorf("调用 MySQL 驱动代理失败：%w（stderr: %s）", agentPipeError{err}, stderrText)
 	}
 
 	line, err := c.reader.ReadBytes('\n')
 	if err != nil {
 		stderrText := c.stderrText()
 		if stderrText == "" {
-			return fmt.Errorf("读取 MySQL 驱动代理响应失败：%w", err)
+			return fmt.Errorf("读取 MySQL 驱动代理响应失败：%w", agentPipeError{err})
 		}
-		return fmt.Errorf("读取 MySQL 驱动代理响应失败：%w（stderr: %s）", err, stderrText)
+		return fmt.Errorf("读取 MySQL 驱动代理响应失败：%w（stderr: %s）", agentPipeError{err}, stderrText)
 	}
 
 	var resp mysqlAgentResponse
@@ -195,6 +206,8 @@ func (c *mysqlAgentClient) close() error {
 	}
 	if c.cmd != nil {
 		_ = c.cmd.Wait()
+		c.cmd = nil
+		driverAgentProcesses.Add(-1)
 	}
 	return closeErr
 }
@@ -250,10 +263,21 @@ func (m *MySQLAgentDB) QueryContext(ctx context.Context, query string) ([]map[st
 	if err := ctx.Err(); err != nil {
 		return nil, nil, err
 	}
-	return m.Query(query)
+	return m.query(query, nil)
+}
+
+func (m *MySQLAgentDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return m.query(query, args)
 }
 
 func (m *MySQLAgentDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	return m.query(query, nil)
+}
+
+func (m *MySQLAgentDB) query(query string, args []interface{}) ([]map[string]interface{}, []string, error) {
 	client, err := m.requireClient()
 	if err != nil {
 		return nil, nil, err
@@ -263,6 +287,7 @@ func (m *MySQLAgentDB) Query(query string) ([]map[string]interface{}, []string,
 	if err := client.call(mysqlAgentRequest{
 		Method: mysqlAgentMethodQuery,
 		Query:  query,
+		Params: args,
 	}, &data, &fields, nil); err != nil {
 		return nil, nil, err
 	}
@@ -273,10 +298,21 @@ func (m *MySQLAgentDB) ExecContext(ctx context.Context, query string) (int64, er
 	if err := ctx.Err(); err != nil {
 		return 0, err
 	}
-	return m.Exec(query)
+	return m.exec(query, nil)
+}
+
+func (m *MySQLAgentDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return m.exec(query, args)
 }
 
 func (m *MySQLAgentDB) Exec(query string) (int64, error) {
+	return m.exec(query, nil)
+}
+
+func (m *MySQLAgentDB) exec(query string, args []interface{}) (int64, error) {
 	client, err := m.requireClient()
 	if err != nil {
 		return 0, err
@@ -285,6 +321,7 @@ func (m *MySQLAgentDB) Exec(query string) (int64, error) {
 	if err := client.call(mysqlAgentRequest{
 		Method: mysqlAgentMethodExec,
 		Query:  query,
+		Params: args,
 	}, nil, nil, &affected); err != nil {
 		return 0, err
 	}
@@ -415,6 +452,102 @@ func (m *MySQLAgentDB) GetTriggers(dbName, tableName string) ([]connection.Trigg
 	return triggers, nil
 }
 
+func (m *MySQLAgentDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	client, err := m.requireClient()
+	if err != nil {
+		return err
+	}
+	return client.call(mysqlAgentRequest{
+		Method:    mysqlAgentMethodCreateIndex,
+		DBName:    dbName,
+		TableName: tableName,
+		Index:     &index,
+	}, nil, nil, nil)
+}
+
+func (m *MySQLAgentDB) DropIndex(dbName, tableName, indexName string) error {
+	client, err := m.requireClient()
+	if err != nil {
+		return err
+	}
+	return client.call(mysqlAgentRequest{
+		Method:    mysqlAgentMethodDropIndex,
+		DBName:    dbName,
+		TableName: tableName,
+		Index:     &connection.IndexSpec{Name: indexName},
+	}, nil, nil, nil)
+}
+
+func (m *MySQLAgentDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	client, err := m.requireClient()
+	if err != nil {
+		return connection.IndexSizeEstimate{}, err
+	}
+	var estimate connection.IndexSizeEstimate
+	err = client.call(mysqlAgentRequest{
+		Method:    mysqlAgentMethodEstimateIndex,
+		DBName:    dbName,
+		TableName: tableName,
+		Index:     &index,
+	}, &estimate, nil, nil)
+	return estimate, err
+}
+
+func (m *MySQLAgentDB) GetTriggerDefinition(schemaName, tableName, triggerName string) (string, error) {
+	client, err := m.requireClient()
+	if err != nil {
+		return "", err
+	}
+	var definition string
+	if err := client.call(mysqlAgentRequest{
+		Method:  mysqlAgentMethodGetTriggerDef,
+		Trigger: &connection.TriggerSpec{Schema: schemaName, TableName: tableName, Name: triggerName},
+	}, &definition, nil, nil); err != nil {
+		return "", err
+	}
+	return definition, nil
+}
+
+func (m *MySQLAgentDB) CreateTrigger(spec connection.TriggerSpec) error {
+	client, err := m.requireClient()
+	if err != nil {
+		return err
+	}
+	return client.call(mysqlAgentRequest{
+		Method:  mysqlAgentMethodCreateTrigger,
+		Trigger: &spec,
+	}, nil, nil, nil)
+}
+
+func (m *MySQLAgentDB) DropTrigger(schemaName, tableName, triggerName string) error {
+	client, err := m.requireClient()
+	if err != nil {
+		return err
+	}
+	return client.call(mysqlAgentRequest{
+		Method:  mysqlAgentMethodDropTrigger,
+		Trigger: &connection.TriggerSpec{Schema: schemaName, TableName: tableName, Name: triggerName},
+	}, nil, nil, nil)
+}
+
+// PlanChanges 由代理进程生成语句，保证与代理实际执行的一致；旧版代理不认识该方法时在本进程按同一规则生成。
+func (m *MySQLAgentDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	client, err := m.requireClient()
+	if err != nil {
+		return nil, err
+	}
+	var statements []ChangeStatement
+	err = client.call(mysqlAgentRequest{
+		Method:    mysqlAgentMethodPlanChanges,
+		TableName: tableName,
+		Changes:   &changes,
+	}, &statements, nil, nil)
+	if err == nil || !isAgentMethodUnsupported(err) {
+		return statements, err
+	}
+	return buildChangeStatements(mysqlChangeStyle, tableName, changes)
+}
+
 func (m *MySQLAgentDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	client, err := m.requireClient()
 	if err != nil {
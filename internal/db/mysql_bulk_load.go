@@ -0,0 +1,114 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"GoNavi-Wails/internal/dialect"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+var mysqlBulkLoadSeq uint64
+
+func (m *MySQLDB) BulkLoadMethod() string {
+	return "LOAD DATA LOCAL INFILE"
+}
+
+// BulkLoad 通过 LOAD DATA LOCAL INFILE 'Reader::<name>' 写入数据，数据在内存中编码为 TSV，不落盘。
+// 需要服务端开启 local_infile，未开启时返回错误，由调用方回退为批量 INSERT。
+func (m *MySQLDB) BulkLoad(ctx context.Context, tableName string, columns []string, rows [][]interface{}) (int64, error) {
+	if m.conn == nil {
+		return 0, fmt.Errorf("connection not open")
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("未指定写入列")
+	}
+
+	var buf bytes.Buffer
+	for _, row := range rows {
+		for i, value := range row {
+			if i > 0 {
+				buf.WriteByte('\t')
+			}
+			writeMySQLLoadDataValue(&buf, value)
+		}
+		buf.WriteByte('\n')
+	}
+
+	handlerName := fmt.Sprintf("gonavi-bulk-%d", atomic.AddUint64(&mysqlBulkLoadSeq, 1))
+	payload := buf.Bytes()
+	mysql.RegisterReaderHandler(handlerName, func() io.Reader {
+		return bytes.NewReader(payload)
+	})
+	defer mysql.DeregisterReaderHandler(handlerName)
+
+	schema, table := splitBulkLoadTable(tableName)
+	qualifiedTable := dialect.QualifyTable("mysql", schema, table)
+	quotedCols := make([]string, len(columns))
+	for i, col := range columns {
+		quotedCols[i] = dialect.QuoteIdent("mysql", col)
+	}
+
+	query := fmt.Sprintf("LOAD DATA LOCAL INFILE 'Reader::%s' INTO TABLE %s CHARACTER SET utf8mb4 FIELDS TERMINATED BY '\\t' ESCAPED BY '\\\\' LINES TERMINATED BY '\\n' (%s)",
+		handlerName, qualifiedTable, strings.Join(quotedCols, ", "))
+	res, err := m.conn.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// writeMySQLLoadDataValue 按 LOAD DATA 默认转义规则写入单个字段（NULL 写作 \N）。
+func writeMySQLLoadDataValue(buf *bytes.Buffer, value interface{}) {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString(`\N`)
+		return
+	case bool:
+		if v {
+			text = "1"
+		} else {
+			text = "0"
+		}
+	case []byte:
+		text = string(v)
+	case time.Time:
+		text = formatMySQLDateTime(v)
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		text = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case string:
+		text = v
+	default:
+		text = fmt.Sprintf("%v", v)
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case 0:
+			buf.WriteString(`\0`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
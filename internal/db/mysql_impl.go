@@ -20,6 +20,7 @@ import (
 type MySQLDB struct {
 	conn        *sql.DB
 	pingTimeout time.Duration
+	codec       *textCodec // 非 UTF-8 连接字符集/遗留 GBK 数据的编码转换，nil 表示无需转换
 }
 
 const defaultMySQLPort = 3306
@@ -185,8 +186,9 @@ func (m *MySQLDB) getDSN(config connection.ConnectionConfig) string {
 
 	timeout := getConnectTimeoutSeconds(config)
 
-	return fmt.Sprintf("%s:%s@%s(%s)/%s?charset=utf8mb4&parseTime=True&loc=Local&timeout=%ds",
-		config.User, config.Password, protocol, address, database, timeout)
+	return fmt.Sprintf("%s:%s@%s(%s)/%s?%s&parseTime=True&loc=Local&timeout=%ds%s%s%s",
+		config.User, config.Password, protocol, address, database, mysqlCharsetDSNParams(config), timeout, mysqlTLSDSNParams(config), mysqlLDAPDSNParams(config),
+		mysqlReadOnlyDSNParams(config, "transaction_read_only"))
 }
 
 func resolveMySQLCredential(config connection.ConnectionConfig, addressIndex int) (string, string) {
@@ -224,12 +226,16 @@ func (m *MySQLDB) Connect(config connection.ConnectionConfig) error {
 		candidateConfig.Port = port
 		candidateConfig.User, candidateConfig.Password = resolveMySQLCredential(runConfig, index)
 
+		if err := registerMySQLTLS(candidateConfig); err != nil {
+			return fmt.Errorf("SSL 配置无效：%w", err)
+		}
 		dsn := m.getDSN(candidateConfig)
 		db, err := sql.Open("mysql", dsn)
 		if err != nil {
 			errorDetails = append(errorDetails, fmt.Sprintf("%s 打开失败: %v", address, err))
 			continue
 		}
+		applyPoolOptions(db, candidateConfig)
 
 		timeout := getConnectTimeout(candidateConfig)
 		ctx, cancel := utils.ContextWithTimeout(timeout)
@@ -243,6 +249,7 @@ func (m *MySQLDB) Connect(config connection.ConnectionConfig) error {
 
 		m.conn = db
 		m.pingTimeout = timeout
+		m.codec = newTextCodec(candidateConfig)
 		return nil
 	}
 
@@ -277,13 +284,17 @@ func (m *MySQLDB) QueryContext(ctx context.Context, query string) ([]map[string]
 		return nil, nil, fmt.Errorf("connection not open")
 	}
 
+	query, err := m.codec.encodeQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
 	rows, err := m.conn.QueryContext(ctx, query)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, m.codec)
 }
 
 func (m *MySQLDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -291,18 +302,26 @@ func (m *MySQLDB) Query(query string) ([]map[string]interface{}, []string, error
 		return nil, nil, fmt.Errorf("connection not open")
 	}
 
+	query, err := m.codec.encodeQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
 	rows, err := m.conn.Query(query)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer rows.Close()
-	return scanRows(rows)
+	return scanRowsWithCodec(rows, m.codec)
 }
 
 func (m *MySQLDB) ExecContext(ctx context.Context, query string) (int64, error) {
 	if m.conn == nil {
 		return 0, fmt.Errorf("connection not open")
 	}
+	query, err := m.codec.encodeQuery(query)
+	if err != nil {
+		return 0, err
+	}
 	res, err := m.conn.ExecContext(ctx, query)
 	if err != nil {
 		return 0, err
@@ -310,10 +329,22 @@ func (m *MySQLDB) ExecContext(ctx context.Context, query string) (int64, error)
 	return res.RowsAffected()
 }
 
+func (m *MySQLDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, m.conn, m.codec, query, args)
+}
+
+func (m *MySQLDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, m.conn, m.codec, query, args)
+}
+
 func (m *MySQLDB) Exec(query string) (int64, error) {
 	if m.conn == nil {
 		return 0, fmt.Errorf("connection not open")
 	}
+	query, err := m.codec.encodeQuery(query)
+	if err != nil {
+		return 0, err
+	}
 	res, err := m.conn.Exec(query)
 	if err != nil {
 		return 0, err
@@ -321,6 +352,10 @@ func (m *MySQLDB) Exec(query string) (int64, error) {
 	return res.RowsAffected()
 }
 
+func (m *MySQLDB) OpenSession(ctx context.Context) (*Session, error) {
+	return openSQLSession(ctx, m.conn, m.codec)
+}
+
 func (m *MySQLDB) GetDatabases() ([]string, error) {
 	data, _, err := m.Query("SHOW DATABASES")
 	if err != nil {
@@ -403,9 +438,11 @@ func (m *MySQLDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefin
 			d := fmt.Sprintf("%v", row["Default"])
 			col.Default = &d
 		}
+		applyMySQLColumnExtra(&col)
 
 		columns = append(columns, col)
 	}
+	fillMySQLGenerationExprs(m.Query, dbName, tableName, columns)
 	return columns, nil
 }
 
@@ -496,10 +533,30 @@ func (m *MySQLDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDef
 	return triggers, nil
 }
 
+func (m *MySQLDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(m, "mysql", dbName, tableName, index)
+}
+
+func (m *MySQLDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(m, "mysql", dbName, tableName, indexName)
+}
+
+func (m *MySQLDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(m, "mysql", dbName, tableName, index)
+}
+
+func (m *MySQLDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(mysqlChangeStyle, tableName, changes)
+}
+
 func (m *MySQLDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if m.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := m.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := m.conn.Begin()
 	if err != nil {
@@ -507,87 +564,12 @@ func (m *MySQLDB) ApplyChanges(tableName string, changes connection.ChangeSet) e
 	}
 	defer tx.Rollback()
 
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		for k, v := range pk {
-			wheres = append(wheres, fmt.Sprintf("`%s` = ?", k))
-			args = append(args, normalizeMySQLDateTimeValue(v))
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM `%s` WHERE %s", tableName, strings.Join(wheres, " AND "))
-		res, err := tx.Exec(query, args...)
-		if err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-		if affected, err := res.RowsAffected(); err == nil && affected == 0 {
-			return fmt.Errorf("删除未生效：未匹配到任何行")
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-
-		for k, v := range update.Values {
-			sets = append(sets, fmt.Sprintf("`%s` = ?", k))
-			args = append(args, normalizeMySQLDateTimeValue(v))
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			wheres = append(wheres, fmt.Sprintf("`%s` = ?", k))
-			args = append(args, normalizeMySQLDateTimeValue(v))
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE `%s` SET %s WHERE %s", tableName, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		res, err := tx.Exec(query, args...)
-		if err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-		if affected, err := res.RowsAffected(); err == nil && affected == 0 {
-			return fmt.Errorf("更新未生效：未匹配到任何行")
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-
-		for k, v := range row {
-			cols = append(cols, fmt.Sprintf("`%s`", k))
-			placeholders = append(placeholders, "?")
-			args = append(args, normalizeMySQLDateTimeValue(v))
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO `%s` (%s) VALUES (%s)", tableName, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		res, err := tx.Exec(query, args...)
-		if err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
-		if affected, err := res.RowsAffected(); err == nil && affected == 0 {
-			return fmt.Errorf("插入未生效：未影响任何行")
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return m.codec.execTx(tx, query, args)
+	}, true)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
 
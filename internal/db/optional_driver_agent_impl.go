@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"GoNavi-Wails/internal/connection"
 )
@@ -29,10 +30,38 @@ const (
 	optionalAgentMethodGetIndexes       = "getIndexes"
 	optionalAgentMethodGetForeignKeys   = "getForeignKeys"
 	optionalAgentMethodGetTriggers      = "getTriggers"
+	optionalAgentMethodGetTriggerDef    = "getTriggerDefinition"
+	optionalAgentMethodCreateTrigger    = "createTrigger"
+	optionalAgentMethodDropTrigger      = "dropTrigger"
+	optionalAgentMethodCreateIndex      = "createIndex"
+	optionalAgentMethodDropIndex        = "dropIndex"
+	optionalAgentMethodEstimateIndex    = "estimateIndexSize"
 	optionalAgentMethodApplyChanges     = "applyChanges"
+	optionalAgentMethodPlanChanges      = "planChanges"
+	optionalAgentMethodBulkLoad         = "bulkLoad"
 	optionalAgentDefaultScannerMaxBytes = 8 << 20
 )
 
+// ErrDriverAgentExited 与驱动代理进程的标准输入输出通信失败，通常是代理进程已崩溃或被终止。
+// 代理调用返回的错误可用 errors.Is 判断，错误文本保持原样。
+var ErrDriverAgentExited = errors.New("驱动代理进程已退出")
+
+// driverAgentProcesses 当前运行中的驱动代理进程数。
+var driverAgentProcesses atomic.Int64
+
+// DriverAgentProcessCount 返回当前运行中的驱动代理进程数（含 MySQL 代理）。
+func DriverAgentProcessCount() int64 {
+	return driverAgentProcesses.Load()
+}
+
+type agentPipeError struct {
+	error
+}
+
+func (e agentPipeError) Unwrap() []error {
+	return []error{e.error, ErrDriverAgentExited}
+}
+
 type optionalAgentRequest struct {
 	ID        int64                        `json:"id"`
 	Method    string                       `json:"method"`
@@ -41,15 +70,27 @@ type optionalAgentRequest struct {
 	DBName    string                       `json:"dbName,omitempty"`
 	TableName string                       `json:"tableName,omitempty"`
 	Changes   *connection.ChangeSet        `json:"changes,omitempty"`
+	Trigger   *connection.TriggerSpec      `json:"trigger,omitempty"`
+	Index     *connection.IndexSpec        `json:"index,omitempty"`
+	Columns   []string                     `json:"columns,omitempty"`
+	Rows      [][]interface{}              `json:"rows,omitempty"`
+	Limit     *TransferLimit               `json:"limit,omitempty"`
+	Params    []interface{}                `json:"params,omitempty"`
+}
+
+// optionalAgentBulkLoadMethods 列出驱动代理侧实现了 BulkLoader 的驱动及其写入方式。
+var optionalAgentBulkLoadMethods = map[string]string{
+	"sqlserver": "BULK COPY",
 }
 
 type optionalAgentResponse struct {
-	ID           int64           `json:"id"`
-	Success      bool            `json:"success"`
-	Error        string          `json:"error,omitempty"`
-	Data         json.RawMessage `json:"data,omitempty"`
-	Fields       []string        `json:"fields,omitempty"`
-	RowsAffected int64           `json:"rowsAffected,omitempty"`
+	ID           int64               `json:"id"`
+	Success      bool                `json:"success"`
+	Error        string              `json:"error,omitempty"`
+	Data         json.RawMessage     `json:"data,omitempty"`
+	Fields       []string            `json:"fields,omitempty"`
+	RowsAffected int64               `json:"rowsAffected,omitempty"`
+	LimitReached *TransferLimitError `json:"limitReached,omitempty"`
 }
 
 type optionalDriverAgentClient struct {
@@ -93,6 +134,7 @@ func newOptionalDriverAgentClient(driverType string, executablePath string) (*op
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("启动 %s 驱动代理失败：%w", driverDisplayName(driverType), err)
 	}
+	driverAgentProcesses.Add(1)
 
 	client := &optionalDriverAgentClient{
 		cmd:    cmd,
@@ -143,18 +185,18 @@ func (c *optionalDriverAgentClient) call(req optionalAgentRequest, out interface
 	if _, err := c.stdin.Write(payload); err != nil {
 		stderrText := c.stderrText()
 		if stderrText == "" {
-			return fmt.Errorf("调用 %s 驱动代理失败：%w", driverDisplayName(c.driver), err)
+			return fmt.Errorf("调用 %s 驱动代理失败：%w", driverDisplayName(c.driver), agentPipeError{err})
 		}
-		return fmt.Errorf("调用 %s 驱动代理失败：%w（stderr: %s）", driverDisplayName(c.driver), err, stderrText)
+		return fmt.Errorf("调用 %s 驱动代理失败：%w（stderr: %s）", driverDisplayName(c.driver), agentPipeError{err}, stderrText)
 	}
 
 	line, err := c.reader.ReadBytes('\n')
 	if err != nil {
 		stderrText := c.stderrText()
 		if stderrText == "" {
-			return fmt.Errorf("读取 %s 驱动代理响应失败：%w", driverDisplayName(c.driver), err)
+			return fmt.Errorf("读取 %s 驱动代理响应失败：%w", driverDisplayName(c.driver), agentPipeError{err})
 		}
-		return fmt.Errorf("读取 %s 驱动代理响应失败：%w（stderr: %s）", driverDisplayName(c.driver), err, stderrText)
+		return fmt.Errorf("读取 %s 驱动代理响应失败：%w（stderr: %s）", driverDisplayName(c.driver), agentPipeError{err}, stderrText)
 	}
 
 	var resp optionalAgentResponse
@@ -180,6 +222,9 @@ func (c *optionalDriverAgentClient) call(req optionalAgentRequest, out interface
 			return fmt.Errorf("解析 %s 驱动代理数据失败：%w", driverDisplayName(c.driver), err)
 		}
 	}
+	if resp.LimitReached != nil {
+		return resp.LimitReached
+	}
 	return nil
 }
 
@@ -197,6 +242,8 @@ func (c *optionalDriverAgentClient) close() error {
 	}
 	if c.cmd != nil {
 		_ = c.cmd.Wait()
+		c.cmd = nil
+		driverAgentProcesses.Add(-1)
 	}
 	return closeErr
 }
@@ -260,10 +307,29 @@ func (d *OptionalDriverAgentDB) QueryContext(ctx context.Context, query string)
 	if err := ctx.Err(); err != nil {
 		return nil, nil, err
 	}
-	return d.Query(query)
+	var limit *TransferLimit
+	if l, ok := transferLimitFromContext(ctx); ok {
+		limit = &l
+	}
+	return d.query(query, nil, limit)
 }
 
 func (d *OptionalDriverAgentDB) Query(query string) ([]map[string]interface{}, []string, error) {
+	return d.query(query, nil, nil)
+}
+
+func (d *OptionalDriverAgentDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	var limit *TransferLimit
+	if l, ok := transferLimitFromContext(ctx); ok {
+		limit = &l
+	}
+	return d.query(query, args, limit)
+}
+
+func (d *OptionalDriverAgentDB) query(query string, args []interface{}, limit *TransferLimit) ([]map[string]interface{}, []string, error) {
 	client, err := d.requireClient()
 	if err != nil {
 		return nil, nil, err
@@ -273,7 +339,14 @@ func (d *OptionalDriverAgentDB) Query(query string) ([]map[string]interface{}, [
 	if err := client.call(optionalAgentRequest{
 		Method: optionalAgentMethodQuery,
 		Query:  query,
+		Limit:  limit,
+		Params: args,
 	}, &data, &fields, nil); err != nil {
+		var limitErr *TransferLimitError
+		if errors.As(err, &limitErr) {
+			// 触发拉取限制时代理仍返回已读取的部分数据
+			return data, fields, err
+		}
 		return nil, nil, err
 	}
 	return data, fields, nil
@@ -283,10 +356,21 @@ func (d *OptionalDriverAgentDB) ExecContext(ctx context.Context, query string) (
 	if err := ctx.Err(); err != nil {
 		return 0, err
 	}
-	return d.Exec(query)
+	return d.exec(query, nil)
+}
+
+func (d *OptionalDriverAgentDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return d.exec(query, args)
 }
 
 func (d *OptionalDriverAgentDB) Exec(query string) (int64, error) {
+	return d.exec(query, nil)
+}
+
+func (d *OptionalDriverAgentDB) exec(query string, args []interface{}) (int64, error) {
 	client, err := d.requireClient()
 	if err != nil {
 		return 0, err
@@ -295,6 +379,7 @@ func (d *OptionalDriverAgentDB) Exec(query string) (int64, error) {
 	if err := client.call(optionalAgentRequest{
 		Method: optionalAgentMethodExec,
 		Query:  query,
+		Params: args,
 	}, nil, nil, &affected); err != nil {
 		return 0, err
 	}
@@ -425,6 +510,113 @@ func (d *OptionalDriverAgentDB) GetTriggers(dbName, tableName string) ([]connect
 	return triggers, nil
 }
 
+func (d *OptionalDriverAgentDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	client, err := d.requireClient()
+	if err != nil {
+		return err
+	}
+	return client.call(optionalAgentRequest{
+		Method:    optionalAgentMethodCreateIndex,
+		DBName:    dbName,
+		TableName: tableName,
+		Index:     &index,
+	}, nil, nil, nil)
+}
+
+func (d *OptionalDriverAgentDB) DropIndex(dbName, tableName, indexName string) error {
+	client, err := d.requireClient()
+	if err != nil {
+		return err
+	}
+	return client.call(optionalAgentRequest{
+		Method:    optionalAgentMethodDropIndex,
+		DBName:    dbName,
+		TableName: tableName,
+		Index:     &connection.IndexSpec{Name: indexName},
+	}, nil, nil, nil)
+}
+
+func (d *OptionalDriverAgentDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	client, err := d.requireClient()
+	if err != nil {
+		return connection.IndexSizeEstimate{}, err
+	}
+	var estimate connection.IndexSizeEstimate
+	err = client.call(optionalAgentRequest{
+		Method:    optionalAgentMethodEstimateIndex,
+		DBName:    dbName,
+		TableName: tableName,
+		Index:     &index,
+	}, &estimate, nil, nil)
+	return estimate, err
+}
+
+func (d *OptionalDriverAgentDB) GetTriggerDefinition(schemaName, tableName, triggerName string) (string, error) {
+	client, err := d.requireClient()
+	if err != nil {
+		return "", err
+	}
+	var definition string
+	if err := client.call(optionalAgentRequest{
+		Method:  optionalAgentMethodGetTriggerDef,
+		Trigger: &connection.TriggerSpec{Schema: schemaName, TableName: tableName, Name: triggerName},
+	}, &definition, nil, nil); err != nil {
+		return "", err
+	}
+	return definition, nil
+}
+
+func (d *OptionalDriverAgentDB) CreateTrigger(spec connection.TriggerSpec) error {
+	client, err := d.requireClient()
+	if err != nil {
+		return err
+	}
+	return client.call(optionalAgentRequest{
+		Method:  optionalAgentMethodCreateTrigger,
+		Trigger: &spec,
+	}, nil, nil, nil)
+}
+
+func (d *OptionalDriverAgentDB) DropTrigger(schemaName, tableName, triggerName string) error {
+	client, err := d.requireClient()
+	if err != nil {
+		return err
+	}
+	return client.call(optionalAgentRequest{
+		Method:  optionalAgentMethodDropTrigger,
+		Trigger: &connection.TriggerSpec{Schema: schemaName, TableName: tableName, Name: triggerName},
+	}, nil, nil, nil)
+}
+
+// PlanChanges 由代理端驱动生成语句，保证与代理实际执行的一致；旧版代理不认识该方法时在本进程按同一规则生成。
+func (d *OptionalDriverAgentDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	client, err := d.requireClient()
+	if err != nil {
+		return nil, err
+	}
+	var statements []ChangeStatement
+	err = client.call(optionalAgentRequest{
+		Method:    optionalAgentMethodPlanChanges,
+		TableName: tableName,
+		Changes:   &changes,
+	}, &statements, nil, nil)
+	if err == nil || !isAgentMethodUnsupported(err) {
+		return statements, err
+	}
+	style, ok := changeSQLStyleFor(d.driverType)
+	if !ok {
+		return nil, fmt.Errorf("%s 不通过 SQL 提交数据修改，无法预览语句", driverDisplayName(d.driverType))
+	}
+	return buildChangeStatements(style, tableName, changes)
+}
+
+// agentUnsupportedMethodText 代理收到不认识的方法时返回的错误文本，用于兼容未升级的代理。
+const agentUnsupportedMethodText = "不支持的方法"
+
+func isAgentMethodUnsupported(err error) bool {
+	return err != nil && strings.TrimSpace(err.Error()) == agentUnsupportedMethodText
+}
+
 func (d *OptionalDriverAgentDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	client, err := d.requireClient()
 	if err != nil {
@@ -437,6 +629,31 @@ func (d *OptionalDriverAgentDB) ApplyChanges(tableName string, changes connectio
 	}, nil, nil, nil)
 }
 
+func (d *OptionalDriverAgentDB) BulkLoadMethod() string {
+	return optionalAgentBulkLoadMethods[d.driverType]
+}
+
+func (d *OptionalDriverAgentDB) BulkLoad(ctx context.Context, tableName string, columns []string, rows [][]interface{}) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if d.BulkLoadMethod() == "" {
+		return 0, fmt.Errorf("%s 驱动不支持批量写入通道", driverDisplayName(d.driverType))
+	}
+	client, err := d.requireClient()
+	if err != nil {
+		return 0, err
+	}
+	var affected int64
+	err = client.call(optionalAgentRequest{
+		Method:    optionalAgentMethodBulkLoad,
+		TableName: tableName,
+		Columns:   columns,
+		Rows:      rows,
+	}, nil, nil, &affected)
+	return affected, err
+}
+
 func (d *OptionalDriverAgentDB) requireClient() (*optionalDriverAgentClient, error) {
 	if d.client == nil {
 		return nil, fmt.Errorf("connection not open")
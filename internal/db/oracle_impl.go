@@ -82,6 +82,7 @@ func (o *OracleDB) Connect(config connection.ConnectionConfig) error {
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	o.conn = db
 	o.pingTimeout = getConnectTimeout(config)
 	if err := o.Ping(); err != nil {
@@ -130,7 +131,7 @@ func (o *OracleDB) QueryContext(ctx context.Context, query string) ([]map[string
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (o *OracleDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -157,6 +158,14 @@ func (o *OracleDB) ExecContext(ctx context.Context, query string) (int64, error)
 	return res.RowsAffected()
 }
 
+func (o *OracleDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, o.conn, nil, query, args)
+}
+
+func (o *OracleDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, o.conn, nil, query, args)
+}
+
 func (o *OracleDB) Exec(query string) (int64, error) {
 	if o.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -168,6 +177,10 @@ func (o *OracleDB) Exec(query string) (int64, error) {
 	return res.RowsAffected()
 }
 
+func (o *OracleDB) OpenSession(ctx context.Context) (*Session, error) {
+	return openSQLSession(ctx, o.conn, nil)
+}
+
 func (o *OracleDB) GetDatabases() ([]string, error) {
 	// Oracle treats Users/Schemas as "Databases" in this context
 	data, _, err := o.Query("SELECT username FROM all_users ORDER BY username")
@@ -264,6 +277,7 @@ func (o *OracleDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefi
 		if row["DATA_DEFAULT"] != nil {
 			d := fmt.Sprintf("%v", row["DATA_DEFAULT"])
 			col.Default = &d
+			col.DefaultIsExpression = !isLiteralDefault(d)
 		}
 
 		columns = append(columns, col)
@@ -362,10 +376,30 @@ func (o *OracleDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDe
 	return triggers, nil
 }
 
+func (o *OracleDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(o, "oracle", dbName, tableName, index)
+}
+
+func (o *OracleDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(o, "oracle", dbName, tableName, indexName)
+}
+
+func (o *OracleDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(o, "oracle", dbName, tableName, index)
+}
+
+func (o *OracleDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(oracleChangeStyle, tableName, changes)
+}
+
 func (o *OracleDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if o.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := o.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := o.conn.Begin()
 	if err != nil {
@@ -373,106 +407,12 @@ func (o *OracleDB) ApplyChanges(tableName string, changes connection.ChangeSet)
 	}
 	defer tx.Rollback()
 
-	quoteIdent := func(name string) string {
-		n := strings.TrimSpace(name)
-		n = strings.Trim(n, "\"")
-		n = strings.ReplaceAll(n, "\"", "\"\"")
-		if n == "" {
-			return "\"\""
-		}
-		return `"` + n + `"`
-	}
-
-	schema := ""
-	table := strings.TrimSpace(tableName)
-	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
-		schema = strings.TrimSpace(parts[0])
-		table = strings.TrimSpace(parts[1])
-	}
-
-	qualifiedTable := ""
-	if schema != "" {
-		qualifiedTable = fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
-	} else {
-		qualifiedTable = quoteIdent(table)
-	}
-
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		idx := 0
-		for k, v := range pk {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = :%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedTable, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range update.Values {
-			idx++
-			sets = append(sets, fmt.Sprintf("%s = :%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = :%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", qualifiedTable, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range row {
-			idx++
-			cols = append(cols, quoteIdent(k))
-			placeholders = append(placeholders, fmt.Sprintf(":%d", idx))
-			args = append(args, v)
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qualifiedTable, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
 
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ParamQuerier 由支持占位符参数的驱动实现，参数由驱动绑定而不拼接进 SQL 文本。
+// 占位符写法由数据库决定：MySQL/MariaDB/SQLite/ClickHouse/TDengine 为 ?，PostgreSQL 系与 DuckDB 为 $1，
+// SQL Server 为 @p1，Oracle/达梦为 :1。
+type ParamQuerier interface {
+	QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error)
+	ExecParams(ctx context.Context, query string, args []interface{}) (int64, error)
+}
+
+// ErrParamsUnsupported 数据源未实现 ParamQuerier 时返回。
+var ErrParamsUnsupported = errors.New("当前驱动不支持参数化查询")
+
+// queryPoolParams 在连接池上执行参数化查询，SQL 与字符串参数按连接字符集编码（codec 为 nil 时原样发送）。
+func queryPoolParams(ctx context.Context, pool *sql.DB, codec *textCodec, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	if pool == nil {
+		return nil, nil, fmt.Errorf("connection not open")
+	}
+	query, args, err := encodeQueryParams(codec, query, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := pool.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	return scanRowsContext(ctx, rows, codec)
+}
+
+func execPoolParams(ctx context.Context, pool *sql.DB, codec *textCodec, query string, args []interface{}) (int64, error) {
+	if pool == nil {
+		return 0, fmt.Errorf("connection not open")
+	}
+	query, args, err := encodeQueryParams(codec, query, args)
+	if err != nil {
+		return 0, err
+	}
+	res, err := pool.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func encodeQueryParams(codec *textCodec, query string, args []interface{}) (string, []interface{}, error) {
+	query, err := codec.encodeQuery(query)
+	if err != nil {
+		return "", nil, err
+	}
+	args, err = codec.encodeArgs(args)
+	if err != nil {
+		return "", nil, err
+	}
+	return query, args, nil
+}
+
+// NormalizeJSONParams 将 JSON 解码得到的整数值 float64 还原为 int64，避免驱动按浮点绑定整数参数；
+// 对象与数组参数无法直接绑定，按 JSON 文本传入。
+func NormalizeJSONParams(args []interface{}) []interface{} {
+	for i, value := range args {
+		switch v := value.(type) {
+		case float64:
+			if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+				args[i] = int64(v)
+			}
+		case map[string]interface{}, []interface{}:
+			if text, err := json.Marshal(v); err == nil {
+				args[i] = string(text)
+			}
+		}
+	}
+	return args
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+// applyPoolOptions 按连接配置设置 database/sql 连接池；各项为 0 时保持驱动默认值。
+// 需在 sql.Open 之后、首次 Ping 之前调用。
+func applyPoolOptions(pool *sql.DB, config connection.ConnectionConfig) {
+	if pool == nil {
+		return
+	}
+	if config.MaxOpenConns > 0 {
+		pool.SetMaxOpenConns(config.MaxOpenConns)
+	}
+	if config.MaxIdleConns > 0 {
+		maxIdle := config.MaxIdleConns
+		if config.MaxOpenConns > 0 && maxIdle > config.MaxOpenConns {
+			maxIdle = config.MaxOpenConns
+		}
+		pool.SetMaxIdleConns(maxIdle)
+	}
+	if config.ConnMaxLifetime > 0 {
+		pool.SetConnMaxLifetime(time.Duration(config.ConnMaxLifetime) * time.Second)
+	}
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+func (p *PostgresDB) BulkLoadMethod() string {
+	return "COPY"
+}
+
+// BulkLoad 使用 COPY FROM STDIN 在单个事务内写入数据。
+func (p *PostgresDB) BulkLoad(ctx context.Context, tableName string, columns []string, rows [][]interface{}) (int64, error) {
+	if p.conn == nil {
+		return 0, fmt.Errorf("connection not open")
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("未指定写入列")
+	}
+
+	schema, table := splitBulkLoadTable(tableName)
+	if schema == "" {
+		schema = "public"
+	}
+
+	tx, err := p.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyInSchema(schema, table, columns...))
+	if err != nil {
+		return 0, err
+	}
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			_ = stmt.Close()
+			return 0, err
+		}
+	}
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		_ = stmt.Close()
+		return 0, err
+	}
+	if err := stmt.Close(); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int64(len(rows)), nil
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+)
+
+type pgQueryFunc func(string) ([]map[string]interface{}, []string, error)
+
+func pgRowString(row map[string]interface{}, key string) string {
+	v, ok := getValueFromRow(row, key)
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// buildPostgresCreateTable 按 pg_catalog 重建建表脚本（类似 pg_dump 的单表输出）：
+// 自有序列、字段、约束、分区键、表/字段注释以及非约束索引。
+// columns 为 GetColumns 的结果，标识列与生成列信息由其提供。
+func buildPostgresCreateTable(query pgQueryFunc, schema, table string, columns []connection.ColumnDefinition) (string, error) {
+	if len(columns) == 0 {
+		return "", fmt.Errorf("表 %s.%s 不存在或没有字段", schema, table)
+	}
+	quote := func(name string) string { return dialect.QuoteIdent("postgres", name) }
+	qualified := dialect.QualifyTable("postgres", schema, table)
+	relFilter := fmt.Sprintf("n.nspname = %s AND c.relname = %s", dialect.QuoteLiteral("postgres", schema), dialect.QuoteLiteral("postgres", table))
+
+	var b strings.Builder
+
+	// serial 列依赖的序列需先于表创建，建表后再声明归属
+	type ownedSequence struct{ name, column string }
+	var owned []ownedSequence
+	seqRows, _, err := query(fmt.Sprintf(`
+SELECT sn.nspname AS seq_schema, s.relname AS seq_name, a.attname AS column_name,
+	sq.seqstart AS start_value, sq.seqincrement AS increment_by, sq.seqmin AS min_value,
+	sq.seqmax AS max_value, sq.seqcache AS cache_size, sq.seqcycle AS cycle
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_depend d ON d.refobjid = c.oid AND d.classid = 'pg_class'::regclass AND d.deptype = 'a'
+JOIN pg_class s ON s.oid = d.objid AND s.relkind = 'S'
+JOIN pg_namespace sn ON sn.oid = s.relnamespace
+JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.refobjsubid
+LEFT JOIN pg_sequence sq ON sq.seqrelid = s.oid
+WHERE %s
+ORDER BY a.attnum`, relFilter))
+	if err == nil {
+		for _, row := range seqRows {
+			seqName := dialect.QualifyTable("postgres", pgRowString(row, "seq_schema"), pgRowString(row, "seq_name"))
+			b.WriteString("CREATE SEQUENCE IF NOT EXISTS " + seqName)
+			if inc := pgRowString(row, "increment_by"); inc != "" {
+				fmt.Fprintf(&b, "\n\tINCREMENT BY %s\n\tMINVALUE %s\n\tMAXVALUE %s\n\tSTART WITH %s\n\tCACHE %s",
+					inc, pgRowString(row, "min_value"), pgRowString(row, "max_value"), pgRowString(row, "start_value"), pgRowString(row, "cache_size"))
+				if cycle := strings.ToLower(pgRowString(row, "cycle")); cycle == "true" || cycle == "t" {
+					b.WriteString("\n\tCYCLE")
+				}
+			}
+			b.WriteString(";\n\n")
+			owned = append(owned, ownedSequence{name: seqName, column: pgRowString(row, "column_name")})
+		}
+	}
+
+	lines := make([]string, 0, len(columns))
+	for _, col := range columns {
+		line := quote(col.Name) + " " + col.Type
+		switch {
+		case col.Generated != "":
+			line += fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", col.GenerationExpr)
+		case col.Identity == "ALWAYS" || (col.Identity == "BY DEFAULT" && (col.Default == nil || !strings.HasPrefix(strings.ToLower(*col.Default), "nextval("))):
+			line += " GENERATED " + col.Identity + " AS IDENTITY"
+		case col.Default != nil:
+			line += " DEFAULT " + *col.Default
+		}
+		if strings.EqualFold(col.Nullable, "NO") {
+			line += " NOT NULL"
+		}
+		lines = append(lines, line)
+	}
+
+	conRows, _, err := query(fmt.Sprintf(`
+SELECT con.conname AS constraint_name, pg_get_constraintdef(con.oid, true) AS definition
+FROM pg_constraint con
+JOIN pg_class c ON c.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE %s AND con.contype IN ('p', 'u', 'c', 'f', 'x')
+ORDER BY CASE con.contype WHEN 'p' THEN 0 WHEN 'u' THEN 1 WHEN 'c' THEN 2 WHEN 'x' THEN 3 ELSE 4 END, con.conname`, relFilter))
+	if err != nil {
+		return "", err
+	}
+	for _, row := range conRows {
+		lines = append(lines, fmt.Sprintf("CONSTRAINT %s %s", quote(pgRowString(row, "constraint_name")), pgRowString(row, "definition")))
+	}
+
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n\t%s\n)", qualified, strings.Join(lines, ",\n\t"))
+	// 分区表追加分区键；旧版本没有 pg_get_partkeydef 时忽略
+	if partRows, _, err := query(fmt.Sprintf(`
+SELECT pg_get_partkeydef(c.oid) AS partition_key
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE %s AND c.relkind = 'p'`, relFilter)); err == nil && len(partRows) > 0 {
+		if key := pgRowString(partRows[0], "partition_key"); key != "" {
+			b.WriteString(" PARTITION BY " + key)
+		}
+	}
+	b.WriteString(";\n")
+
+	for _, seq := range owned {
+		fmt.Fprintf(&b, "\nALTER SEQUENCE %s OWNED BY %s.%s;\n", seq.name, qualified, quote(seq.column))
+	}
+
+	if commentRows, _, err := query(fmt.Sprintf(`
+SELECT obj_description(c.oid, 'pg_class') AS comment
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE %s`, relFilter)); err == nil && len(commentRows) > 0 {
+		if comment := pgRowString(commentRows[0], "comment"); comment != "" {
+			fmt.Fprintf(&b, "\nCOMMENT ON TABLE %s IS %s;\n", qualified, dialect.QuoteLiteral("postgres", comment))
+		}
+	}
+	for _, col := range columns {
+		if col.Comment != "" {
+			fmt.Fprintf(&b, "\nCOMMENT ON COLUMN %s.%s IS %s;\n", qualified, quote(col.Name), dialect.QuoteLiteral("postgres", col.Comment))
+		}
+	}
+
+	// 主键/唯一/排除约束自带的索引已由约束定义，这里只输出独立索引
+	idxRows, _, err := query(fmt.Sprintf(`
+SELECT pg_get_indexdef(i.indexrelid) AS definition
+FROM pg_index i
+JOIN pg_class c ON c.oid = i.indrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_class ic ON ic.oid = i.indexrelid
+WHERE %s
+  AND NOT EXISTS (SELECT 1 FROM pg_constraint con WHERE con.conindid = i.indexrelid AND con.contype IN ('p', 'u', 'x'))
+ORDER BY ic.relname`, relFilter))
+	if err != nil {
+		return "", err
+	}
+	for i, row := range idxRows {
+		if i == 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(pgRowString(row, "definition") + ";\n")
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBuildPostgresCreateTable(t *testing.T) {
+	results := map[string][]map[string]interface{}{
+		"pg_depend": {{
+			"seq_schema": "public", "seq_name": "orders_id_seq", "column_name": "id",
+			"start_value": int64(1), "increment_by": int64(1), "min_value": int64(1),
+			"max_value": int64(2147483647), "cache_size": int64(1), "cycle": false,
+		}},
+		"pg_get_constraintdef": {
+			{"constraint_name": "orders_pkey", "definition": "PRIMARY KEY (id)"},
+			{"constraint_name": "orders_user_fk", "definition": "FOREIGN KEY (user_id) REFERENCES users(id)"},
+		},
+		"pg_get_partkeydef": {},
+		"obj_description":   {{"comment": "订单's 表"}},
+		"pg_get_indexdef":   {{"definition": "CREATE INDEX idx_orders_user ON public.orders USING btree (user_id)"}},
+	}
+	query := func(sql string) ([]map[string]interface{}, []string, error) {
+		for marker, rows := range results {
+			if strings.Contains(sql, marker) {
+				return rows, nil, nil
+			}
+		}
+		return nil, nil, nil
+	}
+	seqDefault := "nextval('orders_id_seq'::regclass)"
+	columns := []connection.ColumnDefinition{
+		{Name: "id", Type: "integer", Nullable: "NO", Default: &seqDefault, Identity: "BY DEFAULT"},
+		{Name: "user_id", Type: "bigint", Nullable: "YES", Comment: "下单用户"},
+		{Name: "total", Type: "numeric(10,2)", Nullable: "NO", Generated: "STORED", GenerationExpr: "price * qty"},
+		{Name: "no", Type: "bigint", Nullable: "NO", Identity: "ALWAYS"},
+	}
+
+	ddl, err := buildPostgresCreateTable(query, "public", "orders", columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"CREATE SEQUENCE IF NOT EXISTS \"public\".\"orders_id_seq\"\n\tINCREMENT BY 1\n\tMINVALUE 1\n\tMAXVALUE 2147483647\n\tSTART WITH 1\n\tCACHE 1;",
+		"CREATE TABLE \"public\".\"orders\" (\n\t\"id\" integer DEFAULT nextval('orders_id_seq'::regclass) NOT NULL,",
+		"\"total\" numeric(10,2) GENERATED ALWAYS AS (price * qty) STORED NOT NULL,",
+		"\"no\" bigint GENERATED ALWAYS AS IDENTITY NOT NULL,",
+		"CONSTRAINT \"orders_pkey\" PRIMARY KEY (id),\n\tCONSTRAINT \"orders_user_fk\" FOREIGN KEY (user_id) REFERENCES users(id)\n);",
+		"ALTER SEQUENCE \"public\".\"orders_id_seq\" OWNED BY \"public\".\"orders\".\"id\";",
+		"COMMENT ON TABLE \"public\".\"orders\" IS '订单''s 表';",
+		"COMMENT ON COLUMN \"public\".\"orders\".\"user_id\" IS '下单用户';",
+		"CREATE INDEX idx_orders_user ON public.orders USING btree (user_id);",
+	}
+	for _, part := range want {
+		if !strings.Contains(ddl, part) {
+			t.Fatalf("ddl missing %q:\n%s", part, ddl)
+		}
+	}
+
+	if _, err := buildPostgresCreateTable(query, "public", "missing", nil); err == nil {
+		t.Fatal("expected error for table without columns")
+	}
+}
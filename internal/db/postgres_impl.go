@@ -38,8 +38,9 @@ func (p *PostgresDB) getDSN(config connection.ConnectionConfig) string {
 	}
 	u.User = url.UserPassword(config.User, config.Password)
 	q := url.Values{}
-	q.Set("sslmode", "disable")
+	q.Set("sslmode", postgresSSLMode(config))
 	q.Set("connect_timeout", strconv.Itoa(getConnectTimeoutSeconds(config)))
+	applyPostgresReadOnly(q, config)
 	u.RawQuery = q.Encode()
 
 	return u.String()
@@ -82,10 +83,17 @@ func (p *PostgresDB) Connect(config connection.ConnectionConfig) error {
 		localConfig.Host = host
 		localConfig.Port = port
 		localConfig.UseSSH = false // Disable SSH flag for DSN generation
+		localConfig.SSLServerName = sslServerName(config)
+		if err := registerPostgresTLS(localConfig); err != nil {
+			return fmt.Errorf("SSL 配置无效：%w", err)
+		}
 
 		dsn = p.getDSN(localConfig)
 		logger.Infof("PostgreSQL 通过本地端口转发连接：%s -> %s:%d", forwarder.LocalAddr, config.Host, config.Port)
 	} else {
+		if err := registerPostgresTLS(config); err != nil {
+			return fmt.Errorf("SSL 配置无效：%w", err)
+		}
 		dsn = p.getDSN(config)
 	}
 
@@ -93,6 +101,7 @@ func (p *PostgresDB) Connect(config connection.ConnectionConfig) error {
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	p.conn = db
 	p.pingTimeout = getConnectTimeout(config)
 
@@ -143,7 +152,7 @@ func (p *PostgresDB) QueryContext(ctx context.Context, query string) ([]map[stri
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (p *PostgresDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -170,6 +179,14 @@ func (p *PostgresDB) ExecContext(ctx context.Context, query string) (int64, erro
 	return res.RowsAffected()
 }
 
+func (p *PostgresDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, p.conn, nil, query, args)
+}
+
+func (p *PostgresDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, p.conn, nil, query, args)
+}
+
 func (p *PostgresDB) Exec(query string) (int64, error) {
 	if p.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -181,6 +198,10 @@ func (p *PostgresDB) Exec(query string) (int64, error) {
 	return res.RowsAffected()
 }
 
+func (p *PostgresDB) OpenSession(ctx context.Context) (*Session, error) {
+	return openSQLSession(ctx, p.conn, nil)
+}
+
 func (p *PostgresDB) GetDatabases() ([]string, error) {
 	data, _, err := p.Query("SELECT datname FROM pg_database WHERE datistemplate = false")
 	if err != nil {
@@ -218,7 +239,15 @@ func (p *PostgresDB) GetTables(dbName string) ([]string, error) {
 }
 
 func (p *PostgresDB) GetCreateStatement(dbName, tableName string) (string, error) {
-	return fmt.Sprintf("-- SHOW CREATE TABLE not fully supported for PostgreSQL in this MVP.\n-- Table: %s", tableName), nil
+	schema := strings.TrimSpace(dbName)
+	if schema == "" {
+		schema = "public"
+	}
+	columns, err := p.GetColumns(schema, tableName)
+	if err != nil {
+		return "", err
+	}
+	return buildPostgresCreateTable(p.Query, schema, strings.TrimSpace(tableName), columns)
 }
 
 func (p *PostgresDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefinition, error) {
@@ -288,6 +317,7 @@ ORDER BY a.attnum`, esc(schema), esc(table))
 
 		columns = append(columns, col)
 	}
+	applyPostgresColumnMeta(p.Query, schema, table, columns)
 	return columns, nil
 }
 
@@ -494,13 +524,34 @@ ORDER BY trigger_name, event_manipulation`, esc(table), esc(schema))
 	return triggers, nil
 }
 
+func (p *PostgresDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(p, "postgres", dbName, tableName, index)
+}
+
+func (p *PostgresDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(p, "postgres", dbName, tableName, indexName)
+}
+
+func (p *PostgresDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(p, "postgres", dbName, tableName, index)
+}
+
 func (p *PostgresDB) GetAllColumns(dbName string) ([]connection.ColumnDefinitionWithTable, error) {
-	query := `
+	return p.GetAllColumnsInSchema(dbName, "")
+}
+
+// GetAllColumnsInSchema 只返回指定 schema 的列；schema 为空时返回全部用户 schema。
+func (p *PostgresDB) GetAllColumnsInSchema(dbName, schema string) ([]connection.ColumnDefinitionWithTable, error) {
+	filter := `table_schema NOT IN ('pg_catalog', 'information_schema')
+  AND table_schema NOT LIKE 'pg_%'`
+	if s := strings.TrimSpace(schema); s != "" {
+		filter = fmt.Sprintf("table_schema = '%s'", strings.ReplaceAll(s, "'", "''"))
+	}
+	query := fmt.Sprintf(`
 SELECT table_schema, table_name, column_name, data_type
 FROM information_schema.columns
-WHERE table_schema NOT IN ('pg_catalog', 'information_schema')
-  AND table_schema NOT LIKE 'pg_%'
-ORDER BY table_schema, table_name, ordinal_position`
+WHERE %s
+ORDER BY table_schema, table_name, ordinal_position`, filter)
 
 	data, _, err := p.Query(query)
 	if err != nil {
@@ -518,6 +569,8 @@ ORDER BY table_schema, table_name, ordinal_position`
 
 		col := connection.ColumnDefinitionWithTable{
 			TableName: tableName,
+			Schema:    schema,
+			Table:     table,
 			Name:      fmt.Sprintf("%v", row["column_name"]),
 			Type:      fmt.Sprintf("%v", row["data_type"]),
 		}
@@ -526,10 +579,30 @@ ORDER BY table_schema, table_name, ordinal_position`
 	return cols, nil
 }
 
+// GetDefaultSchema 返回 search_path 中第一个存在的 schema。
+func (p *PostgresDB) GetDefaultSchema() (string, error) {
+	data, _, err := p.Query("SELECT current_schema() AS schema_name")
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 0 || data[0]["schema_name"] == nil {
+		return "public", nil
+	}
+	return fmt.Sprintf("%v", data[0]["schema_name"]), nil
+}
+
+func (p *PostgresDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(postgresChangeStyle, tableName, changes)
+}
+
 func (p *PostgresDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if p.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := p.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -537,105 +610,11 @@ func (p *PostgresDB) ApplyChanges(tableName string, changes connection.ChangeSet
 	}
 	defer tx.Rollback()
 
-	quoteIdent := func(name string) string {
-		n := strings.TrimSpace(name)
-		n = strings.Trim(n, "\"")
-		n = strings.ReplaceAll(n, "\"", "\"\"")
-		if n == "" {
-			return "\"\""
-		}
-		return `"` + n + `"`
-	}
-
-	schema := ""
-	table := strings.TrimSpace(tableName)
-	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
-		schema = strings.TrimSpace(parts[0])
-		table = strings.TrimSpace(parts[1])
-	}
-
-	qualifiedTable := ""
-	if schema != "" {
-		qualifiedTable = fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
-	} else {
-		qualifiedTable = quoteIdent(table)
-	}
-
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		idx := 0
-		for k, v := range pk {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedTable, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range update.Values {
-			idx++
-			sets = append(sets, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, v)
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", qualifiedTable, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-		idx := 0
-
-		for k, v := range row {
-			idx++
-			cols = append(cols, quoteIdent(k))
-			placeholders = append(placeholders, fmt.Sprintf("$%d", idx))
-			args = append(args, v)
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qualifiedTable, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
@@ -0,0 +1,245 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"GoNavi-Wails/internal/connection"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+const (
+	QueryErrorSyntax     = "syntax"
+	QueryErrorAuth       = "auth"
+	QueryErrorPermission = "permission"
+	QueryErrorNotFound   = "not_found"
+	QueryErrorConstraint = "constraint"
+	QueryErrorTimeout    = "timeout"
+	QueryErrorCanceled   = "canceled"
+	QueryErrorConnection = "connection"
+	QueryErrorOther      = "other"
+)
+
+var (
+	sqlStatePattern     = regexp.MustCompile(`SQLSTATE[ =:\[(]*([0-9A-Z]{5})`)
+	mysqlErrorPattern   = regexp.MustCompile(`Error (\d{4,5})(?: \(([0-9A-Z]{5})\))?:`)
+	oracleCodePattern   = regexp.MustCompile(`\b((?:ORA|DPI|PLS)-\d{5})\b`)
+	errorLinePattern    = regexp.MustCompile(`(?i)\bat line (\d+)`)
+	oraclePosPattern    = regexp.MustCompile(`error occur at position: (\d+)`)
+	nearQuotedPattern   = regexp.MustCompile(`near '((?s).*?)' at line \d+`)
+	nearDoubleQuotedPat = regexp.MustCompile(`near "([^"]+)"`)
+)
+
+// DescribeQueryError 把驱动错误整理为结构化信息。execSQL 为实际发送的语句，query 为用户提交的语句；
+// 当 execSQL 以 query 结尾（前面拼接了标记注释）时，行列位置会换算回 query 中的位置。
+func DescribeQueryError(err error, execSQL, query string) *connection.QueryError {
+	if err == nil {
+		return nil
+	}
+	qe := &connection.QueryError{Message: err.Error()}
+	position := 0 // execSQL 中从 1 开始的字符位置
+	line := 0     // execSQL 中从 1 开始的行号
+
+	var myErr *mysql.MySQLError
+	var pqErr *pq.Error
+	var posErr interface{ ErrPos() int }
+	switch {
+	case errors.As(err, &myErr):
+		qe.Code = strconv.Itoa(int(myErr.Number))
+		if state := strings.TrimRight(string(myErr.SQLState[:]), "\x00"); state != "" {
+			qe.SQLState = state
+		}
+	case errors.As(err, &pqErr):
+		qe.SQLState = string(pqErr.Code)
+		position, _ = strconv.Atoi(pqErr.Position)
+	case errors.As(err, &posErr):
+		if pos := posErr.ErrPos(); pos >= 0 {
+			position = pos + 1
+		}
+	}
+
+	// 经驱动代理转发或其它驱动的错误只有文本，从文本中补齐
+	text := qe.Message
+	if qe.SQLState == "" {
+		if m := sqlStatePattern.FindStringSubmatch(text); m != nil {
+			qe.SQLState = m[1]
+		}
+	}
+	if qe.Code == "" {
+		if m := mysqlErrorPattern.FindStringSubmatch(text); m != nil {
+			qe.Code = m[1]
+			if qe.SQLState == "" {
+				qe.SQLState = m[2]
+			}
+		} else if m := oracleCodePattern.FindStringSubmatch(text); m != nil {
+			qe.Code = m[1]
+		}
+	}
+	if position == 0 {
+		// go-ora 的位置从 0 开始
+		if m := oraclePosPattern.FindStringSubmatch(text); m != nil {
+			if pos, convErr := strconv.Atoi(m[1]); convErr == nil {
+				position = pos + 1
+			}
+		}
+	}
+	if position == 0 {
+		if m := errorLinePattern.FindStringSubmatch(text); m != nil {
+			line, _ = strconv.Atoi(m[1])
+		}
+	}
+
+	prefix := ""
+	if query != "" && strings.HasSuffix(execSQL, query) {
+		prefix = execSQL[:len(execSQL)-len(query)]
+	} else {
+		query = execSQL
+	}
+	switch {
+	case position > 0:
+		if userPos := position - utf8.RuneCountInString(prefix); userPos > 0 && userPos <= utf8.RuneCountInString(query)+1 {
+			qe.Position = userPos
+			qe.Line, qe.Column = lineColumnAt(query, userPos)
+		}
+	case line > 0:
+		if userLine := line - strings.Count(prefix, "\n"); userLine > 0 {
+			qe.Line = userLine
+			if near := nearToken(text); near != "" {
+				if pos := locateNear(query, userLine, near); pos > 0 {
+					qe.Position = pos
+					qe.Line, qe.Column = lineColumnAt(query, pos)
+				}
+			}
+		}
+	default:
+		if near := nearToken(text); near != "" {
+			if idx := strings.Index(query, near); idx >= 0 {
+				qe.Position = utf8.RuneCountInString(query[:idx]) + 1
+				qe.Line, qe.Column = lineColumnAt(query, qe.Position)
+			}
+		}
+	}
+
+	qe.Class = classifyQueryError(err, qe.SQLState, qe.Code, text)
+	return qe
+}
+
+// lineColumnAt 把从 1 开始的字符位置换算为行号和列号。
+func lineColumnAt(text string, position int) (int, int) {
+	line, column := 1, 1
+	for i, r := range []rune(text) {
+		if i+1 >= position {
+			break
+		}
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+// nearToken 取 MySQL "near '...'" 或 SQLite `near "..."` 中出错位置之后的文本。
+func nearToken(text string) string {
+	if m := nearQuotedPattern.FindStringSubmatch(text); m != nil {
+		// MySQL 最多截取 80 个字符，只用第一行定位
+		near, _, _ := strings.Cut(m[1], "\n")
+		return near
+	}
+	if m := nearDoubleQuotedPat.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// locateNear 从指定行开始查找 near 文本，返回从 1 开始的字符位置，找不到时返回 0。
+func locateNear(query string, line int, near string) int {
+	lines := strings.SplitAfter(query, "\n")
+	if line > len(lines) {
+		return 0
+	}
+	offset := 0
+	for _, l := range lines[:line-1] {
+		offset += len(l)
+	}
+	idx := strings.Index(query[offset:], near)
+	if idx < 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(query[:offset+idx]) + 1
+}
+
+func classifyQueryError(err error, sqlState, code, text string) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return QueryErrorCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return QueryErrorTimeout
+	}
+	switch code {
+	case "1045", "1698", "ORA-01017", "ORA-28000", "ORA-28001":
+		return QueryErrorAuth
+	case "1044", "1142", "1143", "1227", "ORA-01031", "ORA-01749":
+		return QueryErrorPermission
+	case "1064", "1149", "ORA-00900", "ORA-00923", "ORA-00933", "ORA-00936", "PLS-00103":
+		return QueryErrorSyntax
+	case "1049", "1051", "1054", "1146", "ORA-00904", "ORA-00942", "ORA-04043":
+		return QueryErrorNotFound
+	case "1048", "1062", "1216", "1217", "1451", "1452", "3819", "ORA-00001", "ORA-01400", "ORA-02291", "ORA-02292":
+		return QueryErrorConstraint
+	case "1205", "3024", "ORA-01013":
+		return QueryErrorTimeout
+	case "1317":
+		return QueryErrorCanceled
+	case "2002", "2003", "2006", "2013", "ORA-03113", "ORA-03114", "ORA-12154", "ORA-12170", "ORA-12541":
+		return QueryErrorConnection
+	}
+	if len(sqlState) == 5 {
+		switch {
+		case sqlState == "57014":
+			return QueryErrorCanceled
+		case sqlState == "42501":
+			return QueryErrorPermission
+		case sqlState == "42601" || sqlState == "42000":
+			return QueryErrorSyntax
+		case sqlState == "42P01" || sqlState == "42703" || sqlState == "42883" || sqlState == "42S02" || sqlState == "42S22" || sqlState == "3D000" || sqlState == "3F000":
+			return QueryErrorNotFound
+		case strings.HasPrefix(sqlState, "28"):
+			return QueryErrorAuth
+		case strings.HasPrefix(sqlState, "08"):
+			return QueryErrorConnection
+		case strings.HasPrefix(sqlState, "23"):
+			return QueryErrorConstraint
+		case strings.HasPrefix(sqlState, "42"):
+			return QueryErrorSyntax
+		}
+	}
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "timeout") || strings.Contains(lower, "timed out") || strings.Contains(text, "超时"):
+		return QueryErrorTimeout
+	case strings.Contains(lower, "password authentication failed") || strings.Contains(lower, "access denied") ||
+		strings.Contains(lower, "login failed") || strings.Contains(lower, "authentication failed"):
+		return QueryErrorAuth
+	case strings.Contains(lower, "permission denied") || strings.Contains(lower, "not authorized"):
+		return QueryErrorPermission
+	case strings.Contains(lower, "syntax"):
+		return QueryErrorSyntax
+	case strings.Contains(lower, "no such table") || strings.Contains(lower, "does not exist") || strings.Contains(lower, "invalid object name"):
+		return QueryErrorNotFound
+	case strings.Contains(lower, "constraint") || strings.Contains(lower, "duplicate key"):
+		return QueryErrorConstraint
+	case strings.Contains(lower, "connection refused") || strings.Contains(lower, "broken pipe") ||
+		strings.Contains(lower, "connection reset") || strings.Contains(lower, "bad connection"):
+		return QueryErrorConnection
+	}
+	return QueryErrorOther
+}
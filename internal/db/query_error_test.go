@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+func TestDescribeQueryError(t *testing.T) {
+	tagged := "/* gonavi */\nSELECT id\nFROM users\nWHERE"
+	query := "SELECT id\nFROM users\nWHERE"
+
+	mysqlErr := fmt.Errorf("查询失败：%w", &mysql.MySQLError{
+		Number:   1064,
+		SQLState: [5]byte{'4', '2', '0', '0', '0'},
+		Message:  "You have an error in your SQL syntax; check the manual near 'users\nWHERE' at line 3",
+	})
+	qe := DescribeQueryError(mysqlErr, tagged, query)
+	if qe.Class != QueryErrorSyntax || qe.Code != "1064" || qe.SQLState != "42000" || qe.Line != 2 || qe.Column != 6 || qe.Position != 16 {
+		t.Fatalf("mysql = %+v", qe)
+	}
+
+	pgErr := &pq.Error{Code: "42P01", Message: `relation "users" does not exist`, Position: "29"}
+	qe = DescribeQueryError(pgErr, tagged, query)
+	if qe.Class != QueryErrorNotFound || qe.SQLState != "42P01" || qe.Line != 2 || qe.Column != 6 {
+		t.Fatalf("postgres = %+v", qe)
+	}
+
+	// 驱动代理只转发错误文本
+	qe = DescribeQueryError(errors.New("ORA-00942: table or view does not exist error occur at position: 15"), query, query)
+	if qe.Class != QueryErrorNotFound || qe.Code != "ORA-00942" || qe.Line != 2 || qe.Column != 6 {
+		t.Fatalf("oracle = %+v", qe)
+	}
+	qe = DescribeQueryError(errors.New(`near "WHERE": syntax error`), query, query)
+	if qe.Class != QueryErrorSyntax || qe.Line != 3 || qe.Column != 1 {
+		t.Fatalf("sqlite = %+v", qe)
+	}
+
+	cases := map[error]string{
+		fmt.Errorf("执行失败：%w", context.DeadlineExceeded):                    QueryErrorTimeout,
+		errors.New(`pq: password authentication failed for user "app"`):    QueryErrorAuth,
+		errors.New("Error 1045 (28000): Access denied for user 'app'"):     QueryErrorAuth,
+		errors.New("dial tcp 127.0.0.1:5432: connect: connection refused"): QueryErrorConnection,
+		errors.New("something odd"):                                        QueryErrorOther,
+	}
+	for err, want := range cases {
+		if got := DescribeQueryError(err, "", "").Class; got != want {
+			t.Fatalf("%v: class = %s, want %s", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"net/url"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+// 只读连接（ConnectionConfig.ReadOnly）在驱动层的会话设置。后端已按语句分类拦截写操作，
+// 这里作为第二道防线，让数据库本身拒绝语法上无法识别的写入（如调用有副作用的函数）：
+//   - MySQL：transaction_read_only=1（5.7.20+）；MariaDB：tx_read_only=1；
+//   - PostgreSQL/Vastbase：default_transaction_read_only=on（启动参数）；
+//   - SQL Server：ApplicationIntent=ReadOnly，可用性组会路由到只读副本；
+//   - SQLite：PRAGMA query_only；DuckDB：以 access_mode=read_only 打开文件。
+
+// mysqlReadOnlyDSNParams 返回需追加到 MySQL DSN 的会话变量（含前导 &），variable 为服务端的只读变量名。
+func mysqlReadOnlyDSNParams(config connection.ConnectionConfig, variable string) string {
+	if !config.ReadOnly {
+		return ""
+	}
+	return "&" + variable + "=1"
+}
+
+// applyPostgresReadOnly 写入 lib/pq 的启动参数；lib/pq 会把不认识的 DSN 参数原样发送给服务端。
+func applyPostgresReadOnly(q url.Values, config connection.ConnectionConfig) {
+	if config.ReadOnly {
+		q.Set("default_transaction_read_only", "on")
+	}
+}
+
+func applySQLServerReadOnly(q url.Values, config connection.ConnectionConfig) {
+	if config.ReadOnly {
+		q.Set("ApplicationIntent", "ReadOnly")
+	}
+}
+
+// readOnlyFileDSN 为 SQLite/DuckDB 的文件 DSN 追加只读参数；内存库不追加（DuckDB 无法以只读方式打开内存库）。
+func readOnlyFileDSN(dsn string, config connection.ConnectionConfig, param string) string {
+	if !config.ReadOnly || strings.EqualFold(strings.TrimSpace(dsn), ":memory:") {
+		return dsn
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&" + param
+	}
+	return dsn + "?" + param
+}
@@ -0,0 +1,444 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+)
+
+// RoutineBrowser 由支持存储过程/函数的驱动实现：列出、读取参数与定义、调用并取回结果集与 OUT 参数。
+type RoutineBrowser interface {
+	GetRoutines(dbName string) ([]connection.RoutineDefinition, error)
+	// GetRoutineDefinition 按 routine.ID（有则优先）或 schema、名称与类型定位，补全参数列表与定义文本。
+	GetRoutineDefinition(dbName string, routine connection.RoutineDefinition) (connection.RoutineDefinition, error)
+	CallRoutine(ctx context.Context, dbName string, call connection.RoutineCall) (connection.RoutineCallResult, error)
+}
+
+type routineStatement struct {
+	sql  string
+	args []interface{}
+}
+
+// routinePlan 一次调用需要在同一条连接上依次执行的语句。
+type routinePlan struct {
+	setup          []routineStatement // MySQL：OUT/INOUT 参数先写入会话变量
+	call           routineStatement
+	outSelect      string   // MySQL：调用后读取 OUT 参数所在的会话变量
+	outInLastSet   bool     // SQL Server：批处理的最后一个结果集是输出参数与返回值
+	outInFirstRow  bool     // PostgreSQL 存储过程：CALL 返回的一行即 OUT/INOUT 参数
+	outParamNames  []string // 输出行中按参数名取值的列
+	returnValueCol string   // 输出行中 RETURN 值所在列（SQL Server 存储过程）
+	returnColumn   string   // 标量函数：第一个结果集中返回值所在列
+}
+
+func routineString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(val)
+	case string:
+		return val
+	}
+	return fmt.Sprint(v)
+}
+
+func routineInt(v interface{}) int {
+	var n int
+	fmt.Sscan(routineString(v), &n)
+	return n
+}
+
+// routineArg 取参数值；前端未填写的参数按 NULL 传入。
+func routineArg(args map[string]interface{}, p connection.RoutineParameter) interface{} {
+	if v, ok := args[p.Name]; ok {
+		if normalized := NormalizeJSONParams([]interface{}{v}); len(normalized) == 1 {
+			return normalized[0]
+		}
+	}
+	return nil
+}
+
+func routineIsOut(p connection.RoutineParameter) bool {
+	return p.Mode == "OUT" || p.Mode == "INOUT"
+}
+
+func scanAllResultSets(ctx context.Context, rows *sql.Rows, codec *textCodec) ([]connection.RoutineResultSet, error) {
+	var sets []connection.RoutineResultSet
+	for {
+		data, columns, err := scanRowsContext(ctx, rows, codec)
+		if err != nil {
+			return sets, err
+		}
+		if len(columns) > 0 {
+			sets = append(sets, connection.RoutineResultSet{Columns: columns, Rows: data})
+		}
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	return sets, rows.Err()
+}
+
+// runRoutinePlan 在一条专用连接上执行调用，保证会话变量与调用在同一连接上。
+func runRoutinePlan(ctx context.Context, pool *sql.DB, codec *textCodec, plan routinePlan) (connection.RoutineCallResult, error) {
+	result := connection.RoutineCallResult{SQL: plan.call.sql, ResultSets: []connection.RoutineResultSet{}}
+	if pool == nil {
+		return result, fmt.Errorf("connection not open")
+	}
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return result, err
+	}
+	defer conn.Close()
+
+	for _, stmt := range plan.setup {
+		query, args, err := encodeQueryParams(codec, stmt.sql, stmt.args)
+		if err != nil {
+			return result, err
+		}
+		if _, err := conn.ExecContext(ctx, query, args...); err != nil {
+			return result, err
+		}
+	}
+	query, args, err := encodeQueryParams(codec, plan.call.sql, plan.call.args)
+	if err != nil {
+		return result, err
+	}
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+	sets, err := scanAllResultSets(ctx, rows, codec)
+	rows.Close()
+	if err != nil {
+		return result, err
+	}
+
+	var outRow map[string]interface{}
+	switch {
+	case plan.outInLastSet && len(sets) > 0:
+		last := sets[len(sets)-1]
+		sets = sets[:len(sets)-1]
+		if len(last.Rows) > 0 {
+			outRow = last.Rows[0]
+		}
+	case plan.outInFirstRow && len(sets) > 0 && len(sets[0].Rows) > 0:
+		outRow = sets[0].Rows[0]
+		sets = sets[1:]
+	case plan.returnColumn != "" && len(sets) > 0 && len(sets[0].Rows) > 0:
+		result.ReturnValue = sets[0].Rows[0][plan.returnColumn]
+		sets = sets[1:]
+	}
+	if plan.outSelect != "" {
+		data, _, err := queryConnRows(ctx, conn, codec, plan.outSelect)
+		if err != nil {
+			return result, fmt.Errorf("读取 OUT 参数失败：%w", err)
+		}
+		if len(data) > 0 {
+			outRow = data[0]
+		}
+	}
+	if outRow != nil {
+		if plan.returnValueCol != "" {
+			result.ReturnValue = outRow[plan.returnValueCol]
+		}
+		if len(plan.outParamNames) > 0 {
+			result.OutParams = make(map[string]interface{}, len(plan.outParamNames))
+			for _, name := range plan.outParamNames {
+				result.OutParams[name] = outRow[name]
+			}
+		}
+	}
+	result.ResultSets = append(result.ResultSets, sets...)
+	return result, nil
+}
+
+func queryConnRows(ctx context.Context, conn *sql.Conn, codec *textCodec, query string) ([]map[string]interface{}, []string, error) {
+	query, err := codec.encodeQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	return scanRowsContext(ctx, rows, codec)
+}
+
+// ---- MySQL / MariaDB ----
+
+func mysqlGetRoutines(pool *sql.DB, codec *textCodec, dbName string) ([]connection.RoutineDefinition, error) {
+	data, _, err := queryPoolParams(context.Background(), pool, codec,
+		"SELECT ROUTINE_SCHEMA AS routine_schema, ROUTINE_NAME AS routine_name, ROUTINE_TYPE AS routine_type, "+
+			"COALESCE(DTD_IDENTIFIER, '') AS return_type, COALESCE(ROUTINE_COMMENT, '') AS routine_comment "+
+			"FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA = ? ORDER BY ROUTINE_TYPE, ROUTINE_NAME", []interface{}{dbName})
+	if err != nil {
+		return nil, err
+	}
+	routines := make([]connection.RoutineDefinition, 0, len(data))
+	for _, row := range data {
+		name := routineString(row["routine_name"])
+		routines = append(routines, connection.RoutineDefinition{
+			ID:         name,
+			Schema:     routineString(row["routine_schema"]),
+			Name:       name,
+			Type:       strings.ToUpper(routineString(row["routine_type"])),
+			ReturnType: routineString(row["return_type"]),
+			Comment:    routineString(row["routine_comment"]),
+		})
+	}
+	return routines, nil
+}
+
+func mysqlGetRoutineDefinition(pool *sql.DB, codec *textCodec, dbName string, routine connection.RoutineDefinition) (connection.RoutineDefinition, error) {
+	schema := strings.TrimSpace(routine.Schema)
+	if schema == "" {
+		schema = dbName
+	}
+	routines, err := mysqlGetRoutines(pool, codec, schema)
+	if err != nil {
+		return routine, err
+	}
+	found, err := pickRoutine(routines, routine)
+	if err != nil {
+		return routine, err
+	}
+	data, _, err := queryPoolParams(context.Background(), pool, codec,
+		"SELECT COALESCE(PARAMETER_NAME, '') AS parameter_name, COALESCE(PARAMETER_MODE, 'IN') AS parameter_mode, "+
+			"DTD_IDENTIFIER AS parameter_type, ORDINAL_POSITION AS ordinal_position FROM information_schema.PARAMETERS "+
+			"WHERE SPECIFIC_SCHEMA = ? AND SPECIFIC_NAME = ? AND ROUTINE_TYPE = ? AND ORDINAL_POSITION > 0 ORDER BY ORDINAL_POSITION",
+		[]interface{}{found.Schema, found.Name, found.Type})
+	if err != nil {
+		return found, err
+	}
+	for _, row := range data {
+		found.Parameters = append(found.Parameters, connection.RoutineParameter{
+			Name:     routineString(row["parameter_name"]),
+			Mode:     strings.ToUpper(routineString(row["parameter_mode"])),
+			Type:     routineString(row["parameter_type"]),
+			Position: routineInt(row["ordinal_position"]),
+		})
+	}
+	// 没有权限时 SHOW CREATE 的定义列为 NULL，不影响调用
+	show, _, err := queryPoolParams(context.Background(), pool, codec,
+		fmt.Sprintf("SHOW CREATE %s %s", found.Type, dialect.QualifyTable("mysql", found.Schema, found.Name)), nil)
+	if err == nil && len(show) > 0 {
+		column := "Create Procedure"
+		if found.Type == "FUNCTION" {
+			column = "Create Function"
+		}
+		found.Definition = routineString(show[0][column])
+	}
+	return found, nil
+}
+
+// buildMySQLRoutinePlan OUT/INOUT 参数通过会话变量传递，调用后再 SELECT 取回。
+func buildMySQLRoutinePlan(routine connection.RoutineDefinition, args map[string]interface{}) routinePlan {
+	target := dialect.QualifyTable("mysql", routine.Schema, routine.Name)
+	var plan routinePlan
+	var placeholders, outs []string
+	for i, p := range routine.Parameters {
+		if !routineIsOut(p) {
+			placeholders = append(placeholders, "?")
+			plan.call.args = append(plan.call.args, routineArg(args, p))
+			continue
+		}
+		variable := fmt.Sprintf("@_gonavi_p%d", i+1)
+		if p.Mode == "INOUT" {
+			plan.setup = append(plan.setup, routineStatement{sql: "SET " + variable + " = ?", args: []interface{}{routineArg(args, p)}})
+		} else {
+			plan.setup = append(plan.setup, routineStatement{sql: "SET " + variable + " = NULL"})
+		}
+		placeholders = append(placeholders, variable)
+		outs = append(outs, variable+" AS "+dialect.QuoteIdent("mysql", p.Name))
+		plan.outParamNames = append(plan.outParamNames, p.Name)
+	}
+	if routine.Type == "FUNCTION" {
+		plan.call.sql = fmt.Sprintf("SELECT %s(%s) AS `result`", target, strings.Join(placeholders, ", "))
+		plan.returnColumn = "result"
+		return plan
+	}
+	plan.call.sql = fmt.Sprintf("CALL %s(%s)", target, strings.Join(placeholders, ", "))
+	if len(outs) > 0 {
+		plan.outSelect = "SELECT " + strings.Join(outs, ", ")
+	}
+	return plan
+}
+
+func mysqlCallRoutine(ctx context.Context, pool *sql.DB, codec *textCodec, dbName string, call connection.RoutineCall) (connection.RoutineCallResult, error) {
+	routine, err := mysqlGetRoutineDefinition(pool, codec, dbName, call.Routine)
+	if err != nil {
+		return connection.RoutineCallResult{}, err
+	}
+	return runRoutinePlan(ctx, pool, codec, buildMySQLRoutinePlan(routine, call.Args))
+}
+
+func (m *MySQLDB) GetRoutines(dbName string) ([]connection.RoutineDefinition, error) {
+	return mysqlGetRoutines(m.conn, m.codec, dbName)
+}
+
+func (m *MySQLDB) GetRoutineDefinition(dbName string, routine connection.RoutineDefinition) (connection.RoutineDefinition, error) {
+	return mysqlGetRoutineDefinition(m.conn, m.codec, dbName, routine)
+}
+
+func (m *MySQLDB) CallRoutine(ctx context.Context, dbName string, call connection.RoutineCall) (connection.RoutineCallResult, error) {
+	return mysqlCallRoutine(ctx, m.conn, m.codec, dbName, call)
+}
+
+// pickRoutine 在列表中按 ID 或 schema+名称+类型定位；同名重载需指定 ID。
+func pickRoutine(routines []connection.RoutineDefinition, ref connection.RoutineDefinition) (connection.RoutineDefinition, error) {
+	var matches []connection.RoutineDefinition
+	for _, r := range routines {
+		if ref.ID != "" {
+			if r.ID == ref.ID {
+				return r, nil
+			}
+			continue
+		}
+		if r.Name != ref.Name || (ref.Schema != "" && r.Schema != ref.Schema) || (ref.Type != "" && !strings.EqualFold(r.Type, ref.Type)) {
+			continue
+		}
+		matches = append(matches, r)
+	}
+	switch len(matches) {
+	case 0:
+		return ref, fmt.Errorf("存储过程或函数不存在：%s", ref.Name)
+	case 1:
+		return matches[0], nil
+	}
+	return ref, fmt.Errorf("%s 存在 %d 个重载，请指定要调用的版本", ref.Name, len(matches))
+}
+
+// ---- PostgreSQL ----
+
+const postgresRoutinesSQL = `SELECT p.oid::text AS id, n.nspname AS schema_name, p.proname AS routine_name,
+CASE WHEN p.prokind = 'p' THEN 'PROCEDURE' ELSE 'FUNCTION' END AS routine_type,
+COALESCE(pg_get_function_result(p.oid), '') AS return_type,
+COALESCE(pg_get_function_identity_arguments(p.oid), '') AS arguments,
+COALESCE(obj_description(p.oid, 'pg_proc'), '') AS routine_comment
+FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace
+WHERE p.prokind IN ('f', 'p') AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+AND n.nspname NOT LIKE 'pg\_toast%' AND n.nspname NOT LIKE 'pg\_temp%'`
+
+func postgresRoutineRows(data []map[string]interface{}) []connection.RoutineDefinition {
+	routines := make([]connection.RoutineDefinition, 0, len(data))
+	for _, row := range data {
+		routines = append(routines, connection.RoutineDefinition{
+			ID:         routineString(row["id"]),
+			Schema:     routineString(row["schema_name"]),
+			Name:       routineString(row["routine_name"]),
+			Type:       routineString(row["routine_type"]),
+			ReturnType: routineString(row["return_type"]),
+			Arguments:  routineString(row["arguments"]),
+			Comment:    routineString(row["routine_comment"]),
+		})
+	}
+	return routines
+}
+
+func (p *PostgresDB) GetRoutines(dbName string) ([]connection.RoutineDefinition, error) {
+	data, _, err := queryPoolParams(context.Background(), p.conn, nil, postgresRoutinesSQL+" ORDER BY n.nspname, p.proname, arguments", nil)
+	if err != nil {
+		return nil, err
+	}
+	return postgresRoutineRows(data), nil
+}
+
+func (p *PostgresDB) GetRoutineDefinition(dbName string, routine connection.RoutineDefinition) (connection.RoutineDefinition, error) {
+	var data []map[string]interface{}
+	var err error
+	if routine.ID != "" {
+		data, _, err = queryPoolParams(context.Background(), p.conn, nil, postgresRoutinesSQL+" AND p.oid = $1::oid", []interface{}{routine.ID})
+	} else {
+		schema := strings.TrimSpace(routine.Schema)
+		if schema == "" {
+			schema = "public"
+		}
+		data, _, err = queryPoolParams(context.Background(), p.conn, nil, postgresRoutinesSQL+" AND n.nspname = $1 AND p.proname = $2", []interface{}{schema, routine.Name})
+	}
+	if err != nil {
+		return routine, err
+	}
+	found, err := pickRoutine(postgresRoutineRows(data), routine)
+	if err != nil {
+		return routine, err
+	}
+	params, _, err := queryPoolParams(context.Background(), p.conn, nil,
+		"SELECT COALESCE(parameter_name, '') AS parameter_name, parameter_mode, "+
+			"CASE WHEN data_type IN ('USER-DEFINED', 'ARRAY') THEN udt_schema || '.' || udt_name ELSE data_type END AS parameter_type, "+
+			"ordinal_position FROM information_schema.parameters WHERE specific_schema = $1 AND specific_name = $2 ORDER BY ordinal_position",
+		[]interface{}{found.Schema, found.Name + "_" + found.ID})
+	if err != nil {
+		return found, err
+	}
+	for _, row := range params {
+		param := connection.RoutineParameter{
+			Name:     routineString(row["parameter_name"]),
+			Mode:     strings.ToUpper(routineString(row["parameter_mode"])),
+			Type:     routineString(row["parameter_type"]),
+			Position: routineInt(row["ordinal_position"]),
+		}
+		if param.Name == "" {
+			param.Name = fmt.Sprintf("$%d", param.Position)
+		}
+		found.Parameters = append(found.Parameters, param)
+	}
+	if def, _, err := queryPoolParams(context.Background(), p.conn, nil, "SELECT pg_get_functiondef($1::oid) AS definition", []interface{}{found.ID}); err == nil && len(def) > 0 {
+		found.Definition = routineString(def[0]["definition"])
+	}
+	return found, nil
+}
+
+// postgresParamType 参数类型用于显式转换，避免重载解析时把文本参数匹配到错误的版本。
+func postgresParamType(t string) string {
+	if schema, name, ok := strings.Cut(t, "."); ok {
+		return dialect.QualifyTable("postgres", schema, name)
+	}
+	return t
+}
+
+// buildPostgresRoutinePlan 存储过程用 CALL，OUT 参数位置传 NULL、结果行即输出参数；函数用 SELECT * FROM，OUT 参数体现为结果列。
+func buildPostgresRoutinePlan(routine connection.RoutineDefinition, args map[string]interface{}) routinePlan {
+	target := dialect.QualifyTable("postgres", routine.Schema, routine.Name)
+	var plan routinePlan
+	var placeholders []string
+	for _, p := range routine.Parameters {
+		if p.Mode == "OUT" {
+			if routine.Type == "PROCEDURE" {
+				placeholders = append(placeholders, "NULL")
+				plan.outParamNames = append(plan.outParamNames, p.Name)
+			}
+			continue
+		}
+		plan.call.args = append(plan.call.args, routineArg(args, p))
+		placeholder := fmt.Sprintf("$%d::%s", len(plan.call.args), postgresParamType(p.Type))
+		if p.Mode == "VARIADIC" {
+			placeholder = "VARIADIC " + placeholder
+		}
+		placeholders = append(placeholders, placeholder)
+		if p.Mode == "INOUT" && routine.Type == "PROCEDURE" {
+			plan.outParamNames = append(plan.outParamNames, p.Name)
+		}
+	}
+	if routine.Type == "PROCEDURE" {
+		plan.call.sql = fmt.Sprintf("CALL %s(%s)", target, strings.Join(placeholders, ", "))
+		plan.outInFirstRow = len(plan.outParamNames) > 0
+		return plan
+	}
+	plan.call.sql = fmt.Sprintf("SELECT * FROM %s(%s)", target, strings.Join(placeholders, ", "))
+	return plan
+}
+
+func (p *PostgresDB) CallRoutine(ctx context.Context, dbName string, call connection.RoutineCall) (connection.RoutineCallResult, error) {
+	routine, err := p.GetRoutineDefinition(dbName, call.Routine)
+	if err != nil {
+		return connection.RoutineCallResult{}, err
+	}
+	return runRoutinePlan(ctx, p.conn, nil, buildPostgresRoutinePlan(routine, call.Args))
+}
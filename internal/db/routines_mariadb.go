@@ -0,0 +1,21 @@
+//go:build gonavi_full_drivers || gonavi_mariadb_driver
+
+package db
+
+import (
+	"context"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func (m *MariaDB) GetRoutines(dbName string) ([]connection.RoutineDefinition, error) {
+	return mysqlGetRoutines(m.conn, m.codec, dbName)
+}
+
+func (m *MariaDB) GetRoutineDefinition(dbName string, routine connection.RoutineDefinition) (connection.RoutineDefinition, error) {
+	return mysqlGetRoutineDefinition(m.conn, m.codec, dbName, routine)
+}
+
+func (m *MariaDB) CallRoutine(ctx context.Context, dbName string, call connection.RoutineCall) (connection.RoutineCallResult, error) {
+	return mysqlCallRoutine(ctx, m.conn, m.codec, dbName, call)
+}
@@ -0,0 +1,148 @@
+//go:build gonavi_full_drivers || gonavi_sqlserver_driver
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+)
+
+func sqlServerRoutinesSQL(dbName string) string {
+	db := dialect.QuoteIdent("sqlserver", dbName)
+	return fmt.Sprintf(`SELECT CAST(o.object_id AS varchar(20)) AS id, s.name AS schema_name, o.name AS routine_name,
+CASE WHEN o.type = 'P' THEN 'PROCEDURE' ELSE 'FUNCTION' END AS routine_type,
+CASE WHEN o.type IN ('IF', 'TF') THEN 'TABLE' ELSE COALESCE((SELECT TOP 1 t.name FROM %[1]s.sys.parameters p JOIN %[1]s.sys.types t ON t.user_type_id = p.user_type_id WHERE p.object_id = o.object_id AND p.parameter_id = 0), '') END AS return_type
+FROM %[1]s.sys.objects o JOIN %[1]s.sys.schemas s ON s.schema_id = o.schema_id
+WHERE o.type IN ('P', 'FN', 'IF', 'TF') AND o.is_ms_shipped = 0`, db)
+}
+
+// sqlServerTypeName 由 sys.types 的类型名与长度、精度拼出可用于 DECLARE 的类型。
+func sqlServerTypeName(name string, maxLength, precision, scale int) string {
+	switch strings.ToLower(name) {
+	case "varchar", "char", "varbinary", "binary":
+		if maxLength < 0 {
+			return name + "(max)"
+		}
+		return fmt.Sprintf("%s(%d)", name, maxLength)
+	case "nvarchar", "nchar":
+		if maxLength < 0 {
+			return name + "(max)"
+		}
+		return fmt.Sprintf("%s(%d)", name, maxLength/2)
+	case "decimal", "numeric":
+		return fmt.Sprintf("%s(%d, %d)", name, precision, scale)
+	case "datetime2", "time", "datetimeoffset":
+		return fmt.Sprintf("%s(%d)", name, scale)
+	}
+	return name
+}
+
+func (s *SqlServerDB) GetRoutines(dbName string) ([]connection.RoutineDefinition, error) {
+	data, _, err := queryPoolParams(context.Background(), s.conn, nil, sqlServerRoutinesSQL(dbName)+" ORDER BY o.type, s.name, o.name", nil)
+	if err != nil {
+		return nil, err
+	}
+	routines := make([]connection.RoutineDefinition, 0, len(data))
+	for _, row := range data {
+		routines = append(routines, connection.RoutineDefinition{
+			ID:         routineString(row["id"]),
+			Schema:     routineString(row["schema_name"]),
+			Name:       routineString(row["routine_name"]),
+			Type:       routineString(row["routine_type"]),
+			ReturnType: routineString(row["return_type"]),
+		})
+	}
+	return routines, nil
+}
+
+func (s *SqlServerDB) GetRoutineDefinition(dbName string, routine connection.RoutineDefinition) (connection.RoutineDefinition, error) {
+	routines, err := s.GetRoutines(dbName)
+	if err != nil {
+		return routine, err
+	}
+	found, err := pickRoutine(routines, routine)
+	if err != nil {
+		return routine, err
+	}
+	db := dialect.QuoteIdent("sqlserver", dbName)
+	params, _, err := queryPoolParams(context.Background(), s.conn, nil, fmt.Sprintf(
+		"SELECT p.name AS parameter_name, p.is_output, t.name AS type_name, p.max_length, p.precision, p.scale, p.parameter_id "+
+			"FROM %[1]s.sys.parameters p JOIN %[1]s.sys.types t ON t.user_type_id = p.user_type_id "+
+			"WHERE p.object_id = @p1 AND p.parameter_id > 0 ORDER BY p.parameter_id", db), []interface{}{found.ID})
+	if err != nil {
+		return found, err
+	}
+	for _, row := range params {
+		mode := "IN"
+		if routineInt(row["is_output"]) == 1 || strings.EqualFold(routineString(row["is_output"]), "true") {
+			mode = "INOUT"
+		}
+		found.Parameters = append(found.Parameters, connection.RoutineParameter{
+			Name:     strings.TrimPrefix(routineString(row["parameter_name"]), "@"),
+			Mode:     mode,
+			Type:     sqlServerTypeName(routineString(row["type_name"]), routineInt(row["max_length"]), routineInt(row["precision"]), routineInt(row["scale"])),
+			Position: routineInt(row["parameter_id"]),
+		})
+	}
+	if def, _, err := queryPoolParams(context.Background(), s.conn, nil,
+		fmt.Sprintf("SELECT definition FROM %s.sys.sql_modules WHERE object_id = @p1", db), []interface{}{found.ID}); err == nil && len(def) > 0 {
+		found.Definition = routineString(def[0]["definition"])
+	}
+	return found, nil
+}
+
+// buildSqlServerRoutinePlan 存储过程以批处理调用：OUTPUT 参数与返回值放在变量中，最后 SELECT 出来作为单独的结果集。
+func buildSqlServerRoutinePlan(dbName string, routine connection.RoutineDefinition, args map[string]interface{}) routinePlan {
+	target := dialect.QuoteIdent("sqlserver", dbName) + "." + dialect.QuoteIdent("sqlserver", routine.Schema) + "." + dialect.QuoteIdent("sqlserver", routine.Name)
+	var plan routinePlan
+	if routine.Type == "FUNCTION" {
+		var placeholders []string
+		for _, p := range routine.Parameters {
+			plan.call.args = append(plan.call.args, routineArg(args, p))
+			placeholders = append(placeholders, fmt.Sprintf("@p%d", len(plan.call.args)))
+		}
+		if strings.EqualFold(routine.ReturnType, "TABLE") {
+			plan.call.sql = fmt.Sprintf("SELECT * FROM %s(%s)", target, strings.Join(placeholders, ", "))
+			return plan
+		}
+		plan.call.sql = fmt.Sprintf("SELECT %s(%s) AS [result]", target, strings.Join(placeholders, ", "))
+		plan.returnColumn = "result"
+		return plan
+	}
+
+	var declares, assigns, outs []string
+	declares = append(declares, "DECLARE @gonavi_ret int;")
+	for i, p := range routine.Parameters {
+		plan.call.args = append(plan.call.args, routineArg(args, p))
+		placeholder := fmt.Sprintf("@p%d", len(plan.call.args))
+		if !routineIsOut(p) {
+			assigns = append(assigns, fmt.Sprintf("@%s = %s", p.Name, placeholder))
+			continue
+		}
+		variable := fmt.Sprintf("@gonavi_o%d", i+1)
+		declares = append(declares, fmt.Sprintf("DECLARE %s %s = %s;", variable, p.Type, placeholder))
+		assigns = append(assigns, fmt.Sprintf("@%s = %s OUTPUT", p.Name, variable))
+		outs = append(outs, fmt.Sprintf("%s AS %s", variable, dialect.QuoteIdent("sqlserver", p.Name)))
+		plan.outParamNames = append(plan.outParamNames, p.Name)
+	}
+	call := "EXEC @gonavi_ret = " + target
+	if len(assigns) > 0 {
+		call += " " + strings.Join(assigns, ", ")
+	}
+	plan.call.sql = strings.Join(declares, "\n") + "\n" + call + ";\nSELECT " + strings.Join(append([]string{"@gonavi_ret AS [RETURN_VALUE]"}, outs...), ", ") + ";"
+	plan.outInLastSet = true
+	plan.returnValueCol = "RETURN_VALUE"
+	return plan
+}
+
+func (s *SqlServerDB) CallRoutine(ctx context.Context, dbName string, call connection.RoutineCall) (connection.RoutineCallResult, error) {
+	routine, err := s.GetRoutineDefinition(dbName, call.Routine)
+	if err != nil {
+		return connection.RoutineCallResult{}, err
+	}
+	return runRoutinePlan(ctx, s.conn, nil, buildSqlServerRoutinePlan(dbName, routine, call.Args))
+}
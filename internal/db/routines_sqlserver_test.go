@@ -0,0 +1,28 @@
+//go:build gonavi_full_drivers || gonavi_sqlserver_driver
+
+package db
+
+import (
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBuildSqlServerRoutinePlan(t *testing.T) {
+	routine := connection.RoutineDefinition{Schema: "dbo", Name: "calc", Type: "PROCEDURE", Parameters: []connection.RoutineParameter{
+		{Name: "a", Mode: "IN", Type: "int"},
+		{Name: "total", Mode: "INOUT", Type: "decimal(10, 2)"},
+	}}
+	plan := buildSqlServerRoutinePlan("shop", routine, map[string]interface{}{"a": 1})
+	want := "DECLARE @gonavi_ret int;\nDECLARE @gonavi_o2 decimal(10, 2) = @p2;\n" +
+		"EXEC @gonavi_ret = [shop].[dbo].[calc] @a = @p1, @total = @gonavi_o2 OUTPUT;\n" +
+		"SELECT @gonavi_ret AS [RETURN_VALUE], @gonavi_o2 AS [total];"
+	if plan.call.sql != want || len(plan.call.args) != 2 || !plan.outInLastSet || plan.returnValueCol != "RETURN_VALUE" {
+		t.Fatalf("plan = %+v\n%s", plan, plan.call.sql)
+	}
+
+	fn := connection.RoutineDefinition{Schema: "dbo", Name: "orders_of", Type: "FUNCTION", ReturnType: "TABLE", Parameters: routine.Parameters[:1]}
+	if plan := buildSqlServerRoutinePlan("shop", fn, nil); plan.call.sql != "SELECT * FROM [shop].[dbo].[orders_of](@p1)" {
+		t.Fatalf("sql = %s", plan.call.sql)
+	}
+}
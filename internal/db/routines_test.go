@@ -0,0 +1,67 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBuildMySQLRoutinePlan(t *testing.T) {
+	routine := connection.RoutineDefinition{Schema: "shop", Name: "calc", Type: "PROCEDURE", Parameters: []connection.RoutineParameter{
+		{Name: "a", Mode: "IN", Type: "int"},
+		{Name: "b", Mode: "INOUT", Type: "int"},
+		{Name: "total", Mode: "OUT", Type: "int"},
+	}}
+	plan := buildMySQLRoutinePlan(routine, map[string]interface{}{"a": float64(1), "b": "2"})
+	if plan.call.sql != "CALL `shop`.`calc`(?, @_gonavi_p2, @_gonavi_p3)" || !reflect.DeepEqual(plan.call.args, []interface{}{int64(1)}) {
+		t.Fatalf("call = %+v", plan.call)
+	}
+	if len(plan.setup) != 2 || plan.setup[0].sql != "SET @_gonavi_p2 = ?" || plan.setup[1].sql != "SET @_gonavi_p3 = NULL" {
+		t.Fatalf("setup = %+v", plan.setup)
+	}
+	if plan.outSelect != "SELECT @_gonavi_p2 AS `b`, @_gonavi_p3 AS `total`" {
+		t.Fatalf("outSelect = %s", plan.outSelect)
+	}
+
+	routine.Type = "FUNCTION"
+	routine.Parameters = routine.Parameters[:1]
+	plan = buildMySQLRoutinePlan(routine, nil)
+	if plan.call.sql != "SELECT `shop`.`calc`(?) AS `result`" || plan.returnColumn != "result" || plan.call.args[0] != nil {
+		t.Fatalf("plan = %+v", plan)
+	}
+}
+
+func TestBuildPostgresRoutinePlan(t *testing.T) {
+	routine := connection.RoutineDefinition{Schema: "public", Name: "transfer", Type: "PROCEDURE", Parameters: []connection.RoutineParameter{
+		{Name: "src", Mode: "IN", Type: "integer"},
+		{Name: "amount", Mode: "INOUT", Type: "numeric"},
+		{Name: "status", Mode: "OUT", Type: "public.status_t"},
+	}}
+	plan := buildPostgresRoutinePlan(routine, map[string]interface{}{"src": 1, "amount": "9.5"})
+	if plan.call.sql != `CALL "public"."transfer"($1::integer, $2::numeric, NULL)` || len(plan.call.args) != 2 {
+		t.Fatalf("call = %+v", plan.call)
+	}
+	if !plan.outInFirstRow || !reflect.DeepEqual(plan.outParamNames, []string{"amount", "status"}) {
+		t.Fatalf("plan = %+v", plan)
+	}
+
+	routine.Type = "FUNCTION"
+	plan = buildPostgresRoutinePlan(routine, nil)
+	if plan.call.sql != `SELECT * FROM "public"."transfer"($1::integer, $2::numeric)` || plan.outInFirstRow || len(plan.outParamNames) != 0 {
+		t.Fatalf("plan = %+v", plan)
+	}
+}
+
+func TestPickRoutineOverloads(t *testing.T) {
+	routines := []connection.RoutineDefinition{
+		{ID: "10", Schema: "public", Name: "f", Type: "FUNCTION"},
+		{ID: "11", Schema: "public", Name: "f", Type: "FUNCTION"},
+	}
+	if _, err := pickRoutine(routines, connection.RoutineDefinition{Name: "f"}); err == nil {
+		t.Fatal("expected ambiguous overload error")
+	}
+	if r, err := pickRoutine(routines, connection.RoutineDefinition{ID: "11", Name: "f"}); err != nil || r.ID != "11" {
+		t.Fatalf("r = %+v err = %v", r, err)
+	}
+}
@@ -0,0 +1,26 @@
+package db
+
+import "strings"
+
+// getValueFromRow 按候选列名取值，精确匹配优先，其次忽略大小写匹配。
+func getValueFromRow(row map[string]interface{}, keys ...string) (interface{}, bool) {
+	if len(row) == 0 {
+		return nil, false
+	}
+
+	for _, key := range keys {
+		if val, ok := row[key]; ok {
+			return val, true
+		}
+	}
+
+	for existingKey, val := range row {
+		for _, key := range keys {
+			if strings.EqualFold(existingKey, key) {
+				return val, true
+			}
+		}
+	}
+
+	return nil, false
+}
@@ -1,21 +1,70 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 )
 
+type columnTypesKey struct{}
+
+// WithColumnTypes 返回的 context 在查询扫描结果集时把各列的驱动类型名（DatabaseTypeName）写入 types，
+// 与返回的列名一一对应；驱动不提供列类型时 types 保持不变。
+func WithColumnTypes(ctx context.Context, types *[]string) context.Context {
+	return context.WithValue(ctx, columnTypesKey{}, types)
+}
+
+func columnTypesFromContext(ctx context.Context) (*[]string, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	types, ok := ctx.Value(columnTypesKey{}).(*[]string)
+	return types, ok && types != nil
+}
+
 func scanRows(rows *sql.Rows) ([]map[string]interface{}, []string, error) {
+	return scanRowsWithCodec(rows, nil)
+}
+
+// scanRowsWithCodec 在通用值归一化之前先按连接字符集把文本字节转为 UTF-8。
+func scanRowsWithCodec(rows *sql.Rows, codec *textCodec) ([]map[string]interface{}, []string, error) {
+	return scanRowsContext(context.Background(), rows, codec)
+}
+
+// scanRowsContext 与 scanRowsWithCodec 相同，另外遵守 context 上的拉取限制：
+// 达到限制时停止读取，返回已读取的部分数据和 *TransferLimitError。
+func scanRowsContext(ctx context.Context, rows *sql.Rows, codec *textCodec) ([]map[string]interface{}, []string, error) {
+	resultData := make([]map[string]interface{}, 0)
+	columns, err := scanRowsEach(ctx, rows, codec, func(_ []string, entry map[string]interface{}) error {
+		resultData = append(resultData, entry)
+		return nil
+	})
+	return resultData, columns, err
+}
+
+// scanRowsEach 逐行解码并交给 fn 处理，不在内存中保留整个结果集；fn 返回错误时停止读取。
+func scanRowsEach(ctx context.Context, rows *sql.Rows, codec *textCodec, fn func(columns []string, row map[string]interface{}) error) ([]string, error) {
 	columns, err := rows.Columns()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	colTypes, err := rows.ColumnTypes()
 	if err != nil || len(colTypes) != len(columns) {
 		colTypes = nil
 	}
+	if types, ok := columnTypesFromContext(ctx); ok && colTypes != nil {
+		*types = make([]string, len(colTypes))
+		for i, ct := range colTypes {
+			if ct != nil {
+				(*types)[i] = ct.DatabaseTypeName()
+			}
+		}
+	}
 
-	resultData := make([]map[string]interface{}, 0)
+	var meter *transferMeter
+	if limit, ok := transferLimitFromContext(ctx); ok {
+		meter = &transferMeter{limit: limit}
+	}
 
 	for rows.Next() {
 		values := make([]interface{}, len(columns))
@@ -34,13 +83,19 @@ func scanRows(rows *sql.Rows) ([]map[string]interface{}, []string, error) {
 			if colTypes != nil && i < len(colTypes) && colTypes[i] != nil {
 				dbTypeName = colTypes[i].DatabaseTypeName()
 			}
-			entry[col] = normalizeQueryValueWithDBType(values[i], dbTypeName)
+			entry[col] = normalizeQueryValueWithDBType(codec.decodeValue(values[i], dbTypeName), dbTypeName)
+		}
+		if err := fn(columns, entry); err != nil {
+			return columns, err
+		}
+
+		if meter != nil && !meter.add(values) {
+			return columns, meter.err()
 		}
-		resultData = append(resultData, entry)
 	}
 
 	if err := rows.Err(); err != nil {
-		return resultData, columns, err
+		return columns, err
 	}
-	return resultData, columns, nil
+	return columns, nil
 }
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// SessionOpener 由基于 database/sql 连接池的驱动实现，用于检出一条专用物理连接。
+// 会话变量（SET @var、SET search_path、临时表等）只在该连接上生效，不会串到其他编辑器标签页。
+type SessionOpener interface {
+	OpenSession(ctx context.Context) (*Session, error)
+}
+
+// Session 独占的一条池内连接，使用完毕必须 Close 归还连接池。
+type Session struct {
+	conn  *sql.Conn
+	codec *textCodec
+}
+
+func openSQLSession(ctx context.Context, pool *sql.DB, codec *textCodec) (*Session, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("connection not open")
+	}
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{conn: conn, codec: codec}, nil
+}
+
+func (s *Session) QueryContext(ctx context.Context, query string) ([]map[string]interface{}, []string, error) {
+	query, err := s.codec.encodeQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := s.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	return scanRowsContext(ctx, rows, s.codec)
+}
+
+// QueryEach 在会话连接上执行查询并逐行回调，用于导出、备份等需要读取整张表的场景，避免一次性载入内存。
+// 返回结果集的列名；fn 返回错误时停止读取并返回该错误。
+func (s *Session) QueryEach(ctx context.Context, query string, fn func(columns []string, row map[string]interface{}) error) ([]string, error) {
+	query, err := s.codec.encodeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRowsEach(ctx, rows, s.codec, fn)
+}
+
+func (s *Session) ExecContext(ctx context.Context, query string) (int64, error) {
+	query, err := s.codec.encodeQuery(query)
+	if err != nil {
+		return 0, err
+	}
+	res, err := s.conn.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// QueryParams 在会话连接上执行带占位符参数的查询。
+func (s *Session) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	query, args, err := encodeQueryParams(s.codec, query, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := s.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	return scanRowsContext(ctx, rows, s.codec)
+}
+
+func (s *Session) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	query, args, err := encodeQueryParams(s.codec, query, args)
+	if err != nil {
+		return 0, err
+	}
+	res, err := s.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (s *Session) Ping(ctx context.Context) error {
+	return s.conn.PingContext(ctx)
+}
+
+// Release 将连接放回连接池复用，仅用于未修改会话状态的场景（如可取消的单条查询）。
+func (s *Session) Release() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// Close 归还连接。会话状态可能残留在连接上，因此直接丢弃该物理连接而不是放回池中复用。
+func (s *Session) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	// 在 Raw 回调中返回 ErrBadConn 会让 database/sql 关闭该连接而不是放回池中
+	err := s.conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	s.conn = nil
+	if err != nil && !errors.Is(err, driver.ErrBadConn) {
+		return err
+	}
+	return nil
+}
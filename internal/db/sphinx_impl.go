@@ -197,3 +197,15 @@ func (s *SphinxDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDe
 	}
 	return triggers, err
 }
+
+func (s *SphinxDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return errIndexUnsupported("sphinx")
+}
+
+func (s *SphinxDB) DropIndex(dbName, tableName, indexName string) error {
+	return errIndexUnsupported("sphinx")
+}
+
+func (s *SphinxDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return connection.IndexSizeEstimate{}, errIndexUnsupported("sphinx")
+}
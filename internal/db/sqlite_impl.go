@@ -31,11 +31,17 @@ func (s *SQLiteDB) Connect(config connection.ConnectionConfig) error {
 	if err := ensureSQLiteParentDir(dsn); err != nil {
 		return err
 	}
+	dsn = readOnlyFileDSN(dsn, config, "_pragma=query_only(1)")
+	dsn, err = applySQLiteLockingOptions(dsn, config)
+	if err != nil {
+		return err
+	}
 
 	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	s.conn = db
 	s.pingTimeout = getConnectTimeout(config)
 
@@ -48,6 +54,40 @@ func (s *SQLiteDB) Connect(config connection.ConnectionConfig) error {
 	return nil
 }
 
+const defaultSQLiteBusyTimeoutMs = 5000
+
+var sqliteJournalModes = map[string]struct{}{
+	"wal": {}, "delete": {}, "truncate": {}, "persist": {}, "memory": {},
+}
+
+// applySQLiteLockingOptions 为每个连接设置 busy_timeout，遇到其他连接持有的锁时等待而不是立即返回 database is locked；
+// 写事务以 BEGIN IMMEDIATE 开启，避免读锁升级为写锁时因死锁检测直接失败。
+// 配置了 journal_mode 时一并设置，WAL 模式下读写互不阻塞。只读连接与内存库不修改日志模式。
+func applySQLiteLockingOptions(dsn string, config connection.ConnectionConfig) (string, error) {
+	busyTimeout := config.SQLiteBusyTimeout
+	if busyTimeout <= 0 {
+		busyTimeout = defaultSQLiteBusyTimeoutMs
+	}
+	params := []string{fmt.Sprintf("_pragma=busy_timeout(%d)", busyTimeout)}
+
+	memory := strings.EqualFold(strings.TrimSpace(dsn), ":memory:")
+	if mode := strings.ToLower(strings.TrimSpace(config.SQLiteJournalMode)); mode != "" && !config.ReadOnly && !memory {
+		if _, ok := sqliteJournalModes[mode]; !ok {
+			return "", fmt.Errorf("不支持的 SQLite 日志模式：%s（可选 wal、delete、truncate、persist、memory）", config.SQLiteJournalMode)
+		}
+		params = append(params, "_pragma=journal_mode("+mode+")")
+	}
+	if !config.ReadOnly {
+		params = append(params, "_txlock=immediate")
+	}
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return dsn + sep + strings.Join(params, "&"), nil
+}
+
 func resolveSQLiteDSN(config connection.ConnectionConfig) (string, error) {
 	dsn := strings.TrimSpace(config.Host)
 	if dsn == "" {
@@ -206,7 +246,7 @@ func (s *SQLiteDB) QueryContext(ctx context.Context, query string) ([]map[string
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (s *SQLiteDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -233,6 +273,19 @@ func (s *SQLiteDB) ExecContext(ctx context.Context, query string) (int64, error)
 	return res.RowsAffected()
 }
 
+func (s *SQLiteDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, s.conn, nil, query, args)
+}
+
+func (s *SQLiteDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, s.conn, nil, query, args)
+}
+
+// OpenSession 检出一条专用连接，使 BEGIN/COMMIT 等事务语句落在同一连接上。
+func (s *SQLiteDB) OpenSession(ctx context.Context) (*Session, error) {
+	return openSQLSession(ctx, s.conn, nil)
+}
+
 func (s *SQLiteDB) Exec(query string) (int64, error) {
 	if s.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -286,10 +339,14 @@ func (s *SQLiteDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefi
 
 	esc := func(v string) string { return strings.ReplaceAll(v, "'", "''") }
 
-	// cid, name, type, notnull, dflt_value, pk
-	data, _, err := s.Query(fmt.Sprintf("PRAGMA table_info('%s')", esc(table)))
+	// cid, name, type, notnull, dflt_value, pk[, hidden]
+	// table_xinfo（3.26+）额外返回 hidden：1 为虚拟表隐藏列，2/3 为 VIRTUAL/STORED 生成列
+	data, _, err := s.Query(fmt.Sprintf("PRAGMA table_xinfo('%s')", esc(table)))
 	if err != nil {
-		return nil, err
+		data, _, err = s.Query(fmt.Sprintf("PRAGMA table_info('%s')", esc(table)))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	parseInt := func(v interface{}) int {
@@ -323,6 +380,14 @@ func (s *SQLiteDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefi
 
 	var columns []connection.ColumnDefinition
 	for _, row := range data {
+		hidden := 0
+		if v, ok := row["hidden"]; ok && v != nil {
+			hidden = parseInt(v)
+		}
+		if hidden == 1 {
+			continue
+		}
+
 		notnull := 0
 		if v, ok := row["notnull"]; ok && v != nil {
 			notnull = parseInt(v)
@@ -363,6 +428,15 @@ func (s *SQLiteDB) GetColumns(dbName, tableName string) ([]connection.ColumnDefi
 			def := fmt.Sprintf("%v", v)
 			col.Default = &def
 		}
+		if col.Default != nil {
+			col.DefaultIsExpression = !isLiteralDefault(*col.Default)
+		}
+		switch hidden {
+		case 2:
+			col.Generated = "VIRTUAL"
+		case 3:
+			col.Generated = "STORED"
+		}
 
 		columns = append(columns, col)
 	}
@@ -586,10 +660,30 @@ func (s *SQLiteDB) GetTriggers(dbName, tableName string) ([]connection.TriggerDe
 	return triggers, nil
 }
 
+func (s *SQLiteDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(s, "sqlite", dbName, tableName, index)
+}
+
+func (s *SQLiteDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(s, "sqlite", dbName, tableName, indexName)
+}
+
+func (s *SQLiteDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(s, "sqlite", dbName, tableName, index)
+}
+
+func (s *SQLiteDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(sqliteChangeStyle, tableName, changes)
+}
+
 func (s *SQLiteDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if s.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := s.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := s.conn.Begin()
 	if err != nil {
@@ -597,99 +691,12 @@ func (s *SQLiteDB) ApplyChanges(tableName string, changes connection.ChangeSet)
 	}
 	defer tx.Rollback()
 
-	quoteIdent := func(name string) string {
-		n := strings.TrimSpace(name)
-		n = strings.Trim(n, "\"")
-		n = strings.ReplaceAll(n, "\"", "\"\"")
-		if n == "" {
-			return "\"\""
-		}
-		return `"` + n + `"`
-	}
-
-	schema := ""
-	table := strings.TrimSpace(tableName)
-	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
-		schema = strings.TrimSpace(parts[0])
-		table = strings.TrimSpace(parts[1])
-	}
-
-	qualifiedTable := ""
-	if schema != "" {
-		qualifiedTable = fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
-	} else {
-		qualifiedTable = quoteIdent(table)
-	}
-
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		for k, v := range pk {
-			wheres = append(wheres, fmt.Sprintf("%s = ?", quoteIdent(k)))
-			args = append(args, v)
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedTable, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-
-		for k, v := range update.Values {
-			sets = append(sets, fmt.Sprintf("%s = ?", quoteIdent(k)))
-			args = append(args, v)
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, v := range update.Keys {
-			wheres = append(wheres, fmt.Sprintf("%s = ?", quoteIdent(k)))
-			args = append(args, v)
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", qualifiedTable, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-
-		for k, v := range row {
-			cols = append(cols, quoteIdent(k))
-			placeholders = append(placeholders, "?")
-			args = append(args, v)
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qualifiedTable, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
 
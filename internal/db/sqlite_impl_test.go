@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"GoNavi-Wails/internal/connection"
 )
@@ -77,3 +78,92 @@ func TestLooksLikeHostPort(t *testing.T) {
 		t.Fatalf("Windows 路径不应识别为 host:port")
 	}
 }
+
+func TestSQLiteReadOnlyConnectionRejectsWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.sqlite")
+	writer := &SQLiteDB{}
+	if err := writer.Connect(connection.ConnectionConfig{Type: "sqlite", Host: path}); err != nil {
+		t.Fatalf("连接失败: %v", err)
+	}
+	if _, err := writer.Exec("CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("建表失败: %v", err)
+	}
+	_ = writer.Close()
+
+	reader := &SQLiteDB{}
+	if err := reader.Connect(connection.ConnectionConfig{Type: "sqlite", Host: path, ReadOnly: true}); err != nil {
+		t.Fatalf("只读连接失败: %v", err)
+	}
+	defer reader.Close()
+	if _, _, err := reader.Query("SELECT COUNT(*) FROM t"); err != nil {
+		t.Fatalf("只读连接应允许查询: %v", err)
+	}
+	if _, err := reader.Exec("INSERT INTO t VALUES (1)"); err == nil {
+		t.Fatalf("只读连接应拒绝写入")
+	}
+}
+
+func TestApplySQLiteLockingOptions(t *testing.T) {
+	dsn, err := applySQLiteLockingOptions("/tmp/demo.sqlite", connection.ConnectionConfig{Type: "sqlite", SQLiteJournalMode: "WAL"})
+	if err != nil {
+		t.Fatalf("追加锁参数失败: %v", err)
+	}
+	if dsn != "/tmp/demo.sqlite?_pragma=busy_timeout(5000)&_pragma=journal_mode(wal)&_txlock=immediate" {
+		t.Fatalf("DSN 不符合预期: %s", dsn)
+	}
+
+	dsn, err = applySQLiteLockingOptions("/tmp/demo.sqlite?_pragma=query_only(1)", connection.ConnectionConfig{Type: "sqlite", ReadOnly: true, SQLiteJournalMode: "wal", SQLiteBusyTimeout: 200})
+	if err != nil {
+		t.Fatalf("追加锁参数失败: %v", err)
+	}
+	if dsn != "/tmp/demo.sqlite?_pragma=query_only(1)&_pragma=busy_timeout(200)" {
+		t.Fatalf("只读连接不应修改日志模式: %s", dsn)
+	}
+
+	if _, err := applySQLiteLockingOptions("/tmp/demo.sqlite", connection.ConnectionConfig{Type: "sqlite", SQLiteJournalMode: "off"}); err == nil {
+		t.Fatalf("期望拒绝不支持的日志模式")
+	}
+}
+
+func TestSQLiteWALAllowsConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal.sqlite")
+	config := connection.ConnectionConfig{Type: "sqlite", Host: path, SQLiteJournalMode: "wal"}
+	first, second := &SQLiteDB{}, &SQLiteDB{}
+	if err := first.Connect(config); err != nil {
+		t.Fatalf("连接失败: %v", err)
+	}
+	defer first.Close()
+	if err := second.Connect(config); err != nil {
+		t.Fatalf("连接失败: %v", err)
+	}
+	defer second.Close()
+
+	if _, err := first.Exec("CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("建表失败: %v", err)
+	}
+	data, _, err := first.Query("PRAGMA journal_mode")
+	if err != nil || len(data) != 1 || data[0]["journal_mode"] != "wal" {
+		t.Fatalf("journal_mode = %v, err = %v", data, err)
+	}
+
+	// 第一个连接持有写事务期间，第二个连接的写入应等待而不是立即报 database is locked
+	tx, err := first.conn.Begin()
+	if err != nil {
+		t.Fatalf("开启事务失败: %v", err)
+	}
+	if _, err := tx.Exec("INSERT INTO t VALUES (1)"); err != nil {
+		t.Fatalf("写入失败: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := second.Exec("INSERT INTO t VALUES (2)")
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("提交失败: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("并发写入失败: %v", err)
+	}
+}
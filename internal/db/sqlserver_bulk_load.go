@@ -0,0 +1,61 @@
+//go:build gonavi_full_drivers || gonavi_sqlserver_driver
+
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"GoNavi-Wails/internal/dialect"
+
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+func (s *SqlServerDB) BulkLoadMethod() string {
+	return "BULK COPY"
+}
+
+// BulkLoad 使用 TDS bulk copy 协议在单个事务内写入数据。
+func (s *SqlServerDB) BulkLoad(ctx context.Context, tableName string, columns []string, rows [][]interface{}) (int64, error) {
+	if s.conn == nil {
+		return 0, fmt.Errorf("connection not open")
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("未指定写入列")
+	}
+
+	schema, table := splitBulkLoadTable(tableName)
+	qualifiedTable := dialect.QualifyTable("sqlserver", schema, table)
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, mssql.CopyIn(qualifiedTable, mssql.BulkOptions{}, columns...))
+	if err != nil {
+		return 0, err
+	}
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			_ = stmt.Close()
+			return 0, err
+		}
+	}
+	res, err := stmt.ExecContext(ctx)
+	if err != nil {
+		_ = stmt.Close()
+		return 0, err
+	}
+	if err := stmt.Close(); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
 	"GoNavi-Wails/internal/logger"
 	"GoNavi-Wails/internal/ssh"
 	"GoNavi-Wails/internal/utils"
@@ -78,6 +79,7 @@ func (t *TDengineDB) Connect(config connection.ConnectionConfig) error {
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	t.conn = db
 	t.pingTimeout = getConnectTimeout(config)
 
@@ -125,7 +127,7 @@ func (t *TDengineDB) QueryContext(ctx context.Context, query string) ([]map[stri
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (t *TDengineDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -153,6 +155,14 @@ func (t *TDengineDB) ExecContext(ctx context.Context, query string) (int64, erro
 	return res.RowsAffected()
 }
 
+func (t *TDengineDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, t.conn, nil, query, args)
+}
+
+func (t *TDengineDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, t.conn, nil, query, args)
+}
+
 func (t *TDengineDB) Exec(query string) (int64, error) {
 	if t.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -187,7 +197,7 @@ func (t *TDengineDB) GetDatabases() ([]string, error) {
 func (t *TDengineDB) GetTables(dbName string) ([]string, error) {
 	queries := make([]string, 0, 2)
 	if strings.TrimSpace(dbName) != "" {
-		queries = append(queries, fmt.Sprintf("SHOW TABLES FROM `%s`", escapeBacktickIdent(dbName)))
+		queries = append(queries, "SHOW TABLES FROM "+dialect.QuoteIdent("tdengine", strings.TrimSpace(dbName)))
 	}
 	queries = append(queries, "SHOW TABLES")
 
@@ -346,55 +356,25 @@ func (t *TDengineDB) GetTriggers(dbName, tableName string) ([]connection.Trigger
 	return []connection.TriggerDefinition{}, nil
 }
 
-func getValueFromRow(row map[string]interface{}, keys ...string) (interface{}, bool) {
-	if len(row) == 0 {
-		return nil, false
-	}
-
-	for _, key := range keys {
-		if val, ok := row[key]; ok {
-			return val, true
-		}
-	}
-
-	for existingKey, val := range row {
-		for _, key := range keys {
-			if strings.EqualFold(existingKey, key) {
-				return val, true
-			}
-		}
-	}
+func (t *TDengineDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return errIndexUnsupported("tdengine")
+}
 
-	return nil, false
+func (t *TDengineDB) DropIndex(dbName, tableName, indexName string) error {
+	return errIndexUnsupported("tdengine")
 }
 
-func escapeBacktickIdent(ident string) string {
-	return strings.ReplaceAll(strings.TrimSpace(ident), "`", "``")
+func (t *TDengineDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return connection.IndexSizeEstimate{}, errIndexUnsupported("tdengine")
 }
 
 func quoteTDengineTable(dbName, tableName string) string {
-	t := escapeBacktickIdent(tableName)
+	t := strings.TrimSpace(tableName)
 	if t == "" {
 		return "``"
 	}
-	if strings.Contains(t, ".") {
-		parts := strings.Split(t, ".")
-		quoted := make([]string, 0, len(parts))
-		for _, part := range parts {
-			part = strings.TrimSpace(part)
-			if part == "" {
-				continue
-			}
-			quoted = append(quoted, fmt.Sprintf("`%s`", escapeBacktickIdent(part)))
-		}
-		if len(quoted) > 0 {
-			return strings.Join(quoted, ".")
-		}
-	}
-
-	db := escapeBacktickIdent(dbName)
-	if db == "" {
-		return fmt.Sprintf("`%s`", t)
+	if strings.Contains(strings.Trim(t, "."), ".") {
+		return dialect.QuoteQualified("tdengine", t)
 	}
-	return fmt.Sprintf("`%s`.`%s`", db, t)
+	return dialect.QualifyTable("tdengine", dbName, t)
 }
@@ -0,0 +1,218 @@
+package db
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// SSL 模式，语义与 libpq 的 sslmode 一致：
+//   - disable：不加密（默认，保持旧连接行为）；
+//   - require：加密；未配置 CA 时不校验证书，配置了 CA 时等同 verify-ca；
+//   - verify-ca：校验证书链（自定义 CA 或系统根证书），不校验主机名；
+//   - verify-full：校验证书链与主机名（SSLServerName 优先，否则为连接主机）。
+//
+// SSLSkipVerify 在任何模式下都跳过证书校验，仅用于自签名证书的测试环境。
+const (
+	sslModeDisable    = "disable"
+	sslModeRequire    = "require"
+	sslModeVerifyCA   = "verify-ca"
+	sslModeVerifyFull = "verify-full"
+)
+
+// normalizeSSLMode 规范化 SSL 模式，兼容 MySQL 的 REQUIRED/VERIFY_IDENTITY 等写法。
+func normalizeSSLMode(mode string) (string, error) {
+	switch strings.ReplaceAll(strings.ToLower(strings.TrimSpace(mode)), "_", "-") {
+	case "", "disable", "disabled", "false", "off":
+		return sslModeDisable, nil
+	case "require", "required", "true", "on":
+		return sslModeRequire, nil
+	case "verify-ca":
+		return sslModeVerifyCA, nil
+	case "verify-full", "verify-identity":
+		return sslModeVerifyFull, nil
+	default:
+		return "", fmt.Errorf("不支持的 SSL 模式：%s", mode)
+	}
+}
+
+// sslEnabled 判断连接是否启用 TLS；无法识别的模式按启用处理，由 buildTLSConfig 报错，避免静默退回明文。
+func sslEnabled(config connection.ConnectionConfig) bool {
+	mode, err := normalizeSSLMode(config.SSLMode)
+	return err != nil || mode != sslModeDisable
+}
+
+// sslServerName 返回校验证书时使用的主机名。
+func sslServerName(config connection.ConnectionConfig) string {
+	if name := strings.TrimSpace(config.SSLServerName); name != "" {
+		return name
+	}
+	return strings.TrimSpace(config.Host)
+}
+
+// EffectiveSSLMode 返回连接实际生效的 SSL 模式，供调用外部客户端工具（mysqldump、pg_dump）时传递：
+// SSLSkipVerify 或 require 未配置 CA 时为 require（不校验证书），require 配置了 CA 时为 verify-ca。
+func EffectiveSSLMode(config connection.ConnectionConfig) (string, error) {
+	mode, err := normalizeSSLMode(config.SSLMode)
+	if err != nil || mode == sslModeDisable {
+		return mode, err
+	}
+	caPath := strings.TrimSpace(config.SSLCA)
+	if config.SSLSkipVerify || (mode == sslModeRequire && caPath == "") {
+		return sslModeRequire, nil
+	}
+	if mode == sslModeRequire {
+		return sslModeVerifyCA, nil
+	}
+	return mode, nil
+}
+
+// tlsConfigKey 为需要按名称注册 tls.Config 的驱动（MySQL、PostgreSQL）生成稳定的注册名。
+func tlsConfigKey(config connection.ConnectionConfig) string {
+	mode, _ := normalizeSSLMode(config.SSLMode)
+	parts := []string{
+		mode,
+		strings.TrimSpace(config.SSLCA),
+		strings.TrimSpace(config.SSLCert),
+		strings.TrimSpace(config.SSLKey),
+		fmt.Sprint(config.SSLSkipVerify),
+		sslServerName(config),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return "gonavi-" + hex.EncodeToString(sum[:8])
+}
+
+// buildTLSConfig 按连接配置构造 tls.Config；未启用 SSL 时返回 nil。
+// serverName 为空时由驱动按实际连接的主机填充（如 MongoDB 副本集的各成员）。
+func buildTLSConfig(config connection.ConnectionConfig, serverName string) (*tls.Config, error) {
+	mode, err := normalizeSSLMode(config.SSLMode)
+	if err != nil {
+		return nil, err
+	}
+	if mode == sslModeDisable {
+		return nil, nil
+	}
+
+	tlsConf := &tls.Config{ServerName: serverName}
+	if caPath := strings.TrimSpace(config.SSLCA); caPath != "" {
+		pem, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("读取 SSL CA 证书失败：%w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("SSL CA 证书不是有效的 PEM 格式：%s", caPath)
+		}
+		tlsConf.RootCAs = pool
+	}
+
+	certPath := strings.TrimSpace(config.SSLCert)
+	keyPath := strings.TrimSpace(config.SSLKey)
+	if (certPath == "") != (keyPath == "") {
+		return nil, fmt.Errorf("客户端证书与私钥需同时配置")
+	}
+	if certPath != "" {
+		pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("加载 SSL 客户端证书失败：%w", err)
+		}
+		tlsConf.Certificates = []tls.Certificate{pair}
+	}
+
+	if config.SSLSkipVerify || (mode == sslModeRequire && tlsConf.RootCAs == nil) {
+		tlsConf.InsecureSkipVerify = true
+		return tlsConf, nil
+	}
+	if mode != sslModeVerifyFull {
+		// crypto/tls 的默认校验包含主机名，verify-ca 需关闭后自行校验证书链
+		tlsConf.InsecureSkipVerify = true
+		tlsConf.VerifyConnection = verifyCertificateChain(tlsConf.RootCAs)
+	}
+	return tlsConf, nil
+}
+
+// verifyCertificateChain 仅校验服务端证书链，不校验主机名；roots 为 nil 时使用系统根证书。
+func verifyCertificateChain(roots *x509.CertPool) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return errors.New("服务端未提供 SSL 证书")
+		}
+		opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+		for _, cert := range state.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		if _, err := state.PeerCertificates[0].Verify(opts); err != nil {
+			return fmt.Errorf("SSL 证书校验失败：%w", err)
+		}
+		return nil
+	}
+}
+
+// mysqlTLSDSNParams 返回需追加到 MySQL DSN 的 tls 参数（含前导 &），未启用时为空；对应配置需先经 registerMySQLTLS 注册。
+func mysqlTLSDSNParams(config connection.ConnectionConfig) string {
+	if !sslEnabled(config) {
+		return ""
+	}
+	return "&tls=" + tlsConfigKey(config)
+}
+
+func registerMySQLTLS(config connection.ConnectionConfig) error {
+	tlsConf, err := buildTLSConfig(config, sslServerName(config))
+	if err != nil || tlsConf == nil {
+		return err
+	}
+	return mysql.RegisterTLSConfig(tlsConfigKey(config), tlsConf)
+}
+
+// postgresSSLMode 返回 lib/pq 的 sslmode；启用时使用 pqgo-<name> 引用经 registerPostgresTLS 注册的配置。
+func postgresSSLMode(config connection.ConnectionConfig) string {
+	if !sslEnabled(config) {
+		return sslModeDisable
+	}
+	return "pqgo-" + tlsConfigKey(config)
+}
+
+func registerPostgresTLS(config connection.ConnectionConfig) error {
+	tlsConf, err := buildTLSConfig(config, sslServerName(config))
+	if err != nil || tlsConf == nil {
+		return err
+	}
+	return pq.RegisterTLSConfig(tlsConfigKey(config), tlsConf)
+}
+
+// applySQLServerTLSParams 写入 go-mssqldb 的加密参数。该驱动只能通过 DSN 配置 TLS：
+// 不支持客户端证书，且校验证书时总会校验主机名（verify-ca 与 verify-full 等效）。
+func applySQLServerTLSParams(q url.Values, config connection.ConnectionConfig) {
+	if !sslEnabled(config) {
+		q.Set("encrypt", "disable")
+		q.Set("TrustServerCertificate", "true")
+		return
+	}
+	mode, _ := normalizeSSLMode(config.SSLMode)
+	caPath := strings.TrimSpace(config.SSLCA)
+	trust := config.SSLSkipVerify || (mode == sslModeRequire && caPath == "")
+	q.Set("encrypt", "true")
+	q.Set("TrustServerCertificate", strconv.FormatBool(trust))
+	if caPath != "" {
+		q.Set("certificate", caPath)
+	}
+	if !trust {
+		q.Set("hostNameInCertificate", sslServerName(config))
+	}
+	if strings.TrimSpace(config.SSLCert) != "" {
+		logger.Warnf("SQL Server 驱动不支持 SSL 客户端证书，已忽略：地址=%s:%d", config.Host, config.Port)
+	}
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func writeSelfSignedCA(t *testing.T) (string, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "db.internal"},
+		DNSNames:              []string{"db.internal"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path, cert
+}
+
+func TestBuildTLSConfigModes(t *testing.T) {
+	base := connection.ConnectionConfig{Host: "db.internal", Port: 3306}
+	if conf, err := buildTLSConfig(base, "db.internal"); err != nil || conf != nil {
+		t.Fatalf("默认应不启用 TLS：%v %v", conf, err)
+	}
+
+	base.SSLMode = "REQUIRED"
+	conf, err := buildTLSConfig(base, "db.internal")
+	if err != nil || conf == nil || !conf.InsecureSkipVerify || conf.VerifyConnection != nil {
+		t.Fatalf("require 无 CA 时应仅加密不校验：%+v %v", conf, err)
+	}
+
+	caPath, caCert := writeSelfSignedCA(t)
+	base.SSLCA = caPath
+	conf, err = buildTLSConfig(base, "db.internal")
+	if err != nil || conf.VerifyConnection == nil {
+		t.Fatalf("require 配置 CA 后应校验证书链：%v", err)
+	}
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{caCert}}
+	if err := conf.VerifyConnection(state); err != nil {
+		t.Fatalf("证书链校验失败：%v", err)
+	}
+
+	base.SSLMode = "verify-full"
+	conf, err = buildTLSConfig(base, "db.internal")
+	if err != nil || conf.InsecureSkipVerify || conf.ServerName != "db.internal" || conf.RootCAs == nil {
+		t.Fatalf("verify-full 应交给 crypto/tls 完整校验：%+v %v", conf, err)
+	}
+
+	base.SSLCert = caPath
+	if _, err := buildTLSConfig(base, ""); err == nil {
+		t.Fatal("仅配置客户端证书未配置私钥时应报错")
+	}
+	if _, err := buildTLSConfig(connection.ConnectionConfig{SSLMode: "sometimes"}, ""); err == nil {
+		t.Fatal("无效的 SSL 模式应报错")
+	}
+}
+
+func TestTLSDSNParams(t *testing.T) {
+	cfg := connection.ConnectionConfig{Type: "mysql", Host: "db.internal", Port: 3306, User: "u", Password: "p"}
+	if dsn := (&MySQLDB{}).getDSN(cfg); strings.Contains(dsn, "tls=") {
+		t.Fatalf("未启用 SSL 时不应追加 tls 参数：%s", dsn)
+	}
+	if mode := postgresSSLMode(cfg); mode != "disable" {
+		t.Fatalf("postgres sslmode = %s", mode)
+	}
+
+	cfg.SSLMode = "verify-full"
+	if dsn := (&MySQLDB{}).getDSN(cfg); !strings.Contains(dsn, "&tls="+tlsConfigKey(cfg)) {
+		t.Fatalf("mysql dsn 缺少 tls 参数：%s", dsn)
+	}
+	if mode := postgresSSLMode(cfg); mode != "pqgo-"+tlsConfigKey(cfg) {
+		t.Fatalf("postgres sslmode = %s", mode)
+	}
+	other := cfg
+	other.Host = "replica.internal"
+	if tlsConfigKey(other) == tlsConfigKey(cfg) {
+		t.Fatal("不同主机名的 TLS 配置不应共用注册名")
+	}
+
+	q := url.Values{}
+	cfg.SSLCA = "/etc/ssl/rds-ca.pem"
+	applySQLServerTLSParams(q, cfg)
+	if q.Get("encrypt") != "true" || q.Get("TrustServerCertificate") != "false" || q.Get("certificate") != cfg.SSLCA || q.Get("hostNameInCertificate") != "db.internal" {
+		t.Fatalf("sqlserver tls 参数 = %v", q)
+	}
+	q = url.Values{}
+	cfg.SSLSkipVerify = true
+	applySQLServerTLSParams(q, cfg)
+	if q.Get("TrustServerCertificate") != "true" || q.Get("hostNameInCertificate") != "" {
+		t.Fatalf("sqlserver skip-verify 参数 = %v", q)
+	}
+}
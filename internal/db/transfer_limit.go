@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// TransferLimit 单次查询的数据拉取软限制，0 表示不限制。
+// 由调用方通过 WithTransferLimit 挂到 context 上，扫描结果集时逐行检查。
+type TransferLimit struct {
+	MaxRows  int64 `json:"maxRows,omitempty"`
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+}
+
+func (l TransferLimit) enabled() bool {
+	return l.MaxRows > 0 || l.MaxBytes > 0
+}
+
+// TransferLimitError 表示扫描因触发限制而提前停止，此时返回的数据为已读取的部分结果。
+type TransferLimitError struct {
+	Rows  int64         `json:"rows"`
+	Bytes int64         `json:"bytes"`
+	Limit TransferLimit `json:"limit"`
+}
+
+func (e *TransferLimitError) Error() string {
+	if e.Limit.MaxBytes > 0 && e.Bytes >= e.Limit.MaxBytes {
+		return fmt.Sprintf("已读取 %d 行（约 %.1f MB），达到单次查询数据量上限 %.1f MB", e.Rows, float64(e.Bytes)/(1<<20), float64(e.Limit.MaxBytes)/(1<<20))
+	}
+	return fmt.Sprintf("已读取 %d 行，达到每分钟行数上限", e.Rows)
+}
+
+type transferLimitKey struct{}
+
+// WithTransferLimit 返回携带拉取限制的 context；限制为空时原样返回。
+func WithTransferLimit(ctx context.Context, limit TransferLimit) context.Context {
+	if !limit.enabled() {
+		return ctx
+	}
+	return context.WithValue(ctx, transferLimitKey{}, limit)
+}
+
+func transferLimitFromContext(ctx context.Context) (TransferLimit, bool) {
+	if ctx == nil {
+		return TransferLimit{}, false
+	}
+	limit, ok := ctx.Value(transferLimitKey{}).(TransferLimit)
+	return limit, ok && limit.enabled()
+}
+
+// transferMeter 按行累计行数与估算字节数。
+type transferMeter struct {
+	limit TransferLimit
+	rows  int64
+	bytes int64
+}
+
+// add 记录一行，返回 false 表示该行之后已达到限制，应停止继续读取。
+func (m *transferMeter) add(values []interface{}) bool {
+	m.rows++
+	for _, v := range values {
+		m.bytes += estimateValueBytes(v)
+	}
+	if m.limit.MaxRows > 0 && m.rows >= m.limit.MaxRows {
+		return false
+	}
+	if m.limit.MaxBytes > 0 && m.bytes >= m.limit.MaxBytes {
+		return false
+	}
+	return true
+}
+
+func (m *transferMeter) err() *TransferLimitError {
+	return &TransferLimitError{Rows: m.rows, Bytes: m.bytes, Limit: m.limit}
+}
+
+func estimateValueBytes(v interface{}) int64 {
+	switch val := v.(type) {
+	case nil:
+		return 0
+	case []byte:
+		return int64(len(val))
+	case string:
+		return int64(len(val))
+	default:
+		return 8
+	}
+}
@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTransferMeterStopsAtLimit(t *testing.T) {
+	m := &transferMeter{limit: TransferLimit{MaxRows: 3}}
+	for i := 0; i < 2; i++ {
+		if !m.add([]interface{}{int64(i), "x"}) {
+			t.Fatalf("row %d should not hit the limit", i)
+		}
+	}
+	if m.add([]interface{}{int64(2), "x"}) {
+		t.Fatal("third row should hit the row limit")
+	}
+
+	m = &transferMeter{limit: TransferLimit{MaxBytes: 10}}
+	if !m.add([]interface{}{[]byte("12345")}) {
+		t.Fatal("5 bytes should be under the limit")
+	}
+	if m.add([]interface{}{"123456"}) {
+		t.Fatal("11 bytes should hit the byte limit")
+	}
+	if err := m.err(); err.Rows != 2 || err.Bytes != 11 {
+		t.Fatalf("unexpected error state: %+v", err)
+	}
+}
+
+func TestWithTransferLimitIgnoresEmptyLimit(t *testing.T) {
+	ctx := WithTransferLimit(context.Background(), TransferLimit{})
+	if _, ok := transferLimitFromContext(ctx); ok {
+		t.Fatal("empty limit should not be attached")
+	}
+	ctx = WithTransferLimit(context.Background(), TransferLimit{MaxRows: 10})
+	if limit, ok := transferLimitFromContext(ctx); !ok || limit.MaxRows != 10 {
+		t.Fatalf("limit = %+v ok=%v", limit, ok)
+	}
+}
@@ -0,0 +1,295 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/dialect"
+)
+
+// TriggerManager 由支持触发器维护的驱动实现：读取完整定义、创建（或替换）与删除。
+// schemaName 的含义与 GetTriggers 的 dbName 一致：MySQL 为数据库，其余为 schema/owner。
+type TriggerManager interface {
+	GetTriggerDefinition(schemaName, tableName, triggerName string) (string, error)
+	CreateTrigger(spec connection.TriggerSpec) error
+	DropTrigger(schemaName, tableName, triggerName string) error
+}
+
+// TriggerManagerFor 返回 inst 的触发器维护实现：驱动自身实现时直接使用（驱动代理经协议转发），
+// 否则按 dbType 所属方言生成 DDL，通过 Query/Exec 执行。方言不支持时返回 false。
+func TriggerManagerFor(inst Database, dbType string) (TriggerManager, bool) {
+	if manager, ok := inst.(TriggerManager); ok {
+		return manager, true
+	}
+	family := triggerFamily(dbType)
+	if family == "" {
+		return nil, false
+	}
+	return &sqlTriggerManager{inst: inst, dbType: dialect.Normalize(dbType), family: family}, true
+}
+
+// triggerFamily 把数据库类型归并为触发器语法族：mysql、postgres、sqlserver、oracle（含达梦）、sqlite。
+func triggerFamily(dbType string) string {
+	switch dialect.Normalize(dbType) {
+	case "mysql", "mariadb", "diros":
+		return "mysql"
+	case "postgres", "kingbase", "highgo", "vastbase":
+		return "postgres"
+	case "sqlserver":
+		return "sqlserver"
+	case "oracle", "dameng":
+		return "oracle"
+	case "sqlite":
+		return "sqlite"
+	}
+	return ""
+}
+
+type sqlTriggerManager struct {
+	inst   Database
+	dbType string
+	family string
+}
+
+func (m *sqlTriggerManager) GetTriggerDefinition(schemaName, tableName, triggerName string) (string, error) {
+	name := strings.TrimSpace(triggerName)
+	if name == "" {
+		return "", fmt.Errorf("触发器名称不能为空")
+	}
+	esc := func(v string) string { return strings.ReplaceAll(strings.TrimSpace(v), "'", "''") }
+	var query, column string
+	switch m.family {
+	case "mysql":
+		query = fmt.Sprintf("SHOW CREATE TRIGGER %s", dialect.QualifyTable(m.dbType, schemaName, name))
+		column = "SQL Original Statement"
+	case "postgres":
+		schema := strings.TrimSpace(schemaName)
+		if schema == "" {
+			schema = "public"
+		}
+		query = fmt.Sprintf(`SELECT pg_get_functiondef(t.tgfoid) || E';\n\n' || pg_get_triggerdef(t.oid, true) || ';' AS definition
+FROM pg_trigger t
+JOIN pg_class c ON c.oid = t.tgrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE NOT t.tgisinternal AND t.tgname = '%s' AND c.relname = '%s' AND n.nspname = '%s'`, esc(name), esc(tableName), esc(schema))
+		column = "definition"
+	case "sqlserver":
+		query = fmt.Sprintf("SELECT OBJECT_DEFINITION(OBJECT_ID(N'%s', N'TR')) AS definition", esc(dialect.QualifyTable(m.dbType, schemaName, name)))
+		column = "definition"
+	case "oracle":
+		query = fmt.Sprintf(`SELECT DESCRIPTION, WHEN_CLAUSE, TRIGGER_BODY FROM ALL_TRIGGERS WHERE OWNER = '%s' AND TRIGGER_NAME = '%s'`,
+			esc(strings.ToUpper(schemaName)), esc(name))
+	case "sqlite":
+		query = fmt.Sprintf("SELECT sql AS definition FROM sqlite_master WHERE type = 'trigger' AND name = '%s'", esc(name))
+		column = "definition"
+	default:
+		return "", fmt.Errorf("当前数据源不支持维护触发器")
+	}
+
+	rows, _, err := m.inst.Query(query)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", fmt.Errorf("触发器 %s 不存在", name)
+	}
+	if m.family == "oracle" {
+		// ALL_TRIGGERS.DESCRIPTION 形如 "TRG BEFORE INSERT ON T FOR EACH ROW"，WHEN 条件与触发体分列存放
+		definition := "CREATE OR REPLACE TRIGGER " + strings.TrimSpace(routineString(rows[0]["DESCRIPTION"]))
+		if when := strings.TrimSpace(routineString(rows[0]["WHEN_CLAUSE"])); when != "" {
+			definition += "\nWHEN (" + when + ")"
+		}
+		return definition + "\n" + strings.TrimSpace(routineString(rows[0]["TRIGGER_BODY"])), nil
+	}
+	definition := strings.TrimSpace(routineString(rows[0][column]))
+	if definition == "" {
+		return "", fmt.Errorf("无法读取触发器 %s 的定义，可能缺少查看权限", name)
+	}
+	return definition, nil
+}
+
+func (m *sqlTriggerManager) CreateTrigger(spec connection.TriggerSpec) error {
+	statements, err := BuildCreateTriggerSQL(m.dbType, spec)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err := m.inst.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *sqlTriggerManager) DropTrigger(schemaName, tableName, triggerName string) error {
+	statement, err := BuildDropTriggerSQL(m.dbType, schemaName, tableName, triggerName)
+	if err != nil {
+		return err
+	}
+	_, err = m.inst.Exec(statement)
+	return err
+}
+
+// BuildDropTriggerSQL 生成删除触发器的语句；PostgreSQL 系的触发器隶属于表，需要表名。
+func BuildDropTriggerSQL(dbType, schemaName, tableName, triggerName string) (string, error) {
+	name := strings.TrimSpace(triggerName)
+	if name == "" {
+		return "", fmt.Errorf("触发器名称不能为空")
+	}
+	switch triggerFamily(dbType) {
+	case "mysql", "sqlserver", "oracle":
+		return "DROP TRIGGER " + dialect.QualifyTable(dbType, schemaName, name), nil
+	case "postgres":
+		if strings.TrimSpace(tableName) == "" {
+			return "", fmt.Errorf("表名不能为空")
+		}
+		return "DROP TRIGGER " + dialect.QuoteIdent(dbType, name) + " ON " + dialect.QualifyTable(dbType, schemaName, tableName), nil
+	case "sqlite":
+		return "DROP TRIGGER " + dialect.QuoteIdent(dbType, name), nil
+	}
+	return "", fmt.Errorf("当前数据源(%s)不支持维护触发器", dbType)
+}
+
+// BuildCreateTriggerSQL 按方言生成创建触发器需要依次执行的语句。
+// spec.Replace 时先删除同名触发器；PostgreSQL 系额外生成名为 <触发器>_fn 的触发器函数。
+func BuildCreateTriggerSQL(dbType string, spec connection.TriggerSpec) ([]string, error) {
+	family := triggerFamily(dbType)
+	if family == "" {
+		return nil, fmt.Errorf("当前数据源(%s)不支持维护触发器", dbType)
+	}
+	name := strings.TrimSpace(spec.Name)
+	table := strings.TrimSpace(spec.TableName)
+	body := strings.TrimSpace(spec.Body)
+	switch {
+	case name == "":
+		return nil, fmt.Errorf("触发器名称不能为空")
+	case table == "":
+		return nil, fmt.Errorf("表名不能为空")
+	case body == "":
+		return nil, fmt.Errorf("触发器内容不能为空")
+	}
+
+	timing := strings.ToUpper(strings.Join(strings.Fields(spec.Timing), " "))
+	switch timing {
+	case "BEFORE", "AFTER", "INSTEAD OF":
+	case "":
+		timing = "AFTER"
+	default:
+		return nil, fmt.Errorf("不支持的触发时机：%s", spec.Timing)
+	}
+	events := make([]string, 0, len(spec.Events))
+	for _, event := range spec.Events {
+		event = strings.ToUpper(strings.TrimSpace(event))
+		switch event {
+		case "INSERT", "UPDATE", "DELETE":
+			events = append(events, event)
+		default:
+			return nil, fmt.Errorf("不支持的触发事件：%s", event)
+		}
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("至少选择一个触发事件")
+	}
+	forEach := strings.ToUpper(strings.TrimSpace(spec.ForEach))
+	switch forEach {
+	case "", "ROW":
+		forEach = "ROW"
+	case "STATEMENT":
+	default:
+		return nil, fmt.Errorf("不支持的触发粒度：%s", spec.ForEach)
+	}
+	when := strings.TrimSpace(spec.When)
+
+	schema := strings.TrimSpace(spec.Schema)
+	target := dialect.QualifyTable(dbType, schema, table)
+	var statements []string
+	if spec.Replace && family != "oracle" {
+		drop, err := BuildDropTriggerSQL(dbType, schema, table, name)
+		if err != nil {
+			return nil, err
+		}
+		switch family {
+		case "sqlserver":
+			drop = fmt.Sprintf("IF OBJECT_ID(N'%s', N'TR') IS NOT NULL %s",
+				strings.ReplaceAll(dialect.QualifyTable(dbType, schema, name), "'", "''"), drop)
+		default:
+			drop = strings.Replace(drop, "DROP TRIGGER ", "DROP TRIGGER IF EXISTS ", 1)
+		}
+		statements = append(statements, drop)
+	}
+
+	switch family {
+	case "mysql":
+		if len(events) != 1 {
+			return nil, fmt.Errorf("MySQL 触发器只能指定一个触发事件")
+		}
+		if timing == "INSTEAD OF" || forEach != "ROW" || when != "" {
+			return nil, fmt.Errorf("MySQL 触发器只支持 BEFORE/AFTER 的行级触发，且不支持 WHEN 条件")
+		}
+		statements = append(statements, fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH ROW\n%s",
+			dialect.QualifyTable(dbType, schema, name), timing, events[0], target, wrapTriggerBlock(body, "END")))
+	case "postgres":
+		if strings.Contains(body, "$gonavi$") {
+			return nil, fmt.Errorf("触发器内容不能包含 $gonavi$")
+		}
+		function := dialect.QualifyTable(dbType, schema, name+"_fn")
+		statements = append(statements, fmt.Sprintf("CREATE OR REPLACE FUNCTION %s() RETURNS trigger LANGUAGE plpgsql AS $gonavi$\n%s\n$gonavi$",
+			function, wrapTriggerBlock(body, "END;")))
+		clause := fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH %s",
+			dialect.QuoteIdent(dbType, name), timing, strings.Join(events, " OR "), target, forEach)
+		if when != "" {
+			clause += " WHEN (" + when + ")"
+		}
+		// EXECUTE PROCEDURE 兼容 PostgreSQL 11 之前的版本与金仓、瀚高等兼容库
+		statements = append(statements, clause+" EXECUTE PROCEDURE "+function+"()")
+	case "sqlserver":
+		if timing == "BEFORE" {
+			return nil, fmt.Errorf("SQL Server 不支持 BEFORE 触发器，请使用 AFTER 或 INSTEAD OF")
+		}
+		if when != "" {
+			return nil, fmt.Errorf("SQL Server 触发器不支持 WHEN 条件，请在触发器内容中判断")
+		}
+		statements = append(statements, fmt.Sprintf("CREATE TRIGGER %s ON %s %s %s AS\n%s",
+			dialect.QualifyTable(dbType, schema, name), target, timing, strings.Join(events, ", "), wrapTriggerBlock(body, "END")))
+	case "oracle":
+		create := "CREATE TRIGGER "
+		if spec.Replace {
+			create = "CREATE OR REPLACE TRIGGER "
+		}
+		clause := fmt.Sprintf("%s%s %s %s ON %s", create, dialect.QualifyTable(dbType, schema, name), timing, strings.Join(events, " OR "), target)
+		if forEach == "ROW" {
+			clause += " FOR EACH ROW"
+		}
+		if when != "" {
+			clause += " WHEN (" + when + ")"
+		}
+		statements = append(statements, clause+"\n"+wrapTriggerBlock(body, "END;"))
+	case "sqlite":
+		if len(events) != 1 {
+			return nil, fmt.Errorf("SQLite 触发器只能指定一个触发事件")
+		}
+		if forEach != "ROW" {
+			return nil, fmt.Errorf("SQLite 只支持行级触发器")
+		}
+		clause := fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH ROW",
+			dialect.QuoteIdent(dbType, name), timing, events[0], dialect.QuoteIdent(dbType, table))
+		if when != "" {
+			clause += " WHEN " + when
+		}
+		statements = append(statements, clause+"\n"+wrapTriggerBlock(body, "END"))
+	}
+	return statements, nil
+}
+
+// wrapTriggerBlock 触发器内容未以 BEGIN（或 PL/SQL、PL/pgSQL 的 DECLARE）开头时补上 BEGIN ... END 块。
+func wrapTriggerBlock(body string, end string) string {
+	upper := strings.ToUpper(body)
+	if strings.HasPrefix(upper, "BEGIN") || strings.HasPrefix(upper, "DECLARE") {
+		return body
+	}
+	if !strings.HasSuffix(body, ";") {
+		body += ";"
+	}
+	return "BEGIN\n" + body + "\n" + end
+}
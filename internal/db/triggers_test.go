@@ -0,0 +1,112 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+)
+
+func TestBuildCreateTriggerSQL(t *testing.T) {
+	spec := connection.TriggerSpec{
+		Schema:    "shop",
+		TableName: "orders",
+		Name:      "trg_orders_audit",
+		Timing:    "before",
+		Events:    []string{"insert"},
+		Body:      "SET NEW.created_at = NOW()",
+		Replace:   true,
+	}
+	got, err := BuildCreateTriggerSQL("mariadb", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"DROP TRIGGER IF EXISTS `shop`.`trg_orders_audit`",
+		"CREATE TRIGGER `shop`.`trg_orders_audit` BEFORE INSERT ON `shop`.`orders` FOR EACH ROW\nBEGIN\nSET NEW.created_at = NOW();\nEND",
+	}
+	if strings.Join(got, "\n--\n") != strings.Join(want, "\n--\n") {
+		t.Fatalf("mysql statements = %q", got)
+	}
+
+	spec.Schema = "public"
+	spec.Events = []string{"INSERT", "UPDATE"}
+	spec.When = "NEW.total > 0"
+	spec.Body = "NEW.updated_at := now();\nRETURN NEW;"
+	got, err = BuildCreateTriggerSQL("kingbase", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0] != `DROP TRIGGER IF EXISTS "trg_orders_audit" ON "public"."orders"` ||
+		!strings.HasPrefix(got[1], `CREATE OR REPLACE FUNCTION "public"."trg_orders_audit_fn"() RETURNS trigger LANGUAGE plpgsql AS $gonavi$`+"\nBEGIN\n") ||
+		got[2] != `CREATE TRIGGER "trg_orders_audit" BEFORE INSERT OR UPDATE ON "public"."orders" FOR EACH ROW WHEN (NEW.total > 0) EXECUTE PROCEDURE "public"."trg_orders_audit_fn"()` {
+		t.Fatalf("postgres statements = %q", got)
+	}
+
+	spec.Schema = "dbo"
+	spec.When = ""
+	spec.Timing = "AFTER"
+	spec.Body = "BEGIN INSERT INTO audit(id) SELECT id FROM inserted; END"
+	got, err = BuildCreateTriggerSQL("sqlserver", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != `IF OBJECT_ID(N'[dbo].[trg_orders_audit]', N'TR') IS NOT NULL DROP TRIGGER [dbo].[trg_orders_audit]` ||
+		got[1] != "CREATE TRIGGER [dbo].[trg_orders_audit] ON [dbo].[orders] AFTER INSERT, UPDATE AS\nBEGIN INSERT INTO audit(id) SELECT id FROM inserted; END" {
+		t.Fatalf("sqlserver statements = %q", got)
+	}
+
+	spec.Schema = "APP"
+	spec.Body = ":NEW.UPDATED_AT := SYSDATE"
+	got, err = BuildCreateTriggerSQL("oracle", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != "CREATE OR REPLACE TRIGGER \"APP\".\"trg_orders_audit\" AFTER INSERT OR UPDATE ON \"APP\".\"orders\" FOR EACH ROW\nBEGIN\n:NEW.UPDATED_AT := SYSDATE;\nEND;" {
+		t.Fatalf("oracle statements = %q", got)
+	}
+}
+
+func TestBuildCreateTriggerSQLRejectsUnsupportedOptions(t *testing.T) {
+	base := connection.TriggerSpec{TableName: "t", Name: "trg", Timing: "AFTER", Events: []string{"INSERT"}, Body: "SELECT 1"}
+
+	multi := base
+	multi.Events = []string{"INSERT", "DELETE"}
+	if _, err := BuildCreateTriggerSQL("mysql", multi); err == nil {
+		t.Fatal("expected mysql multi-event error")
+	}
+	before := base
+	before.Timing = "BEFORE"
+	if _, err := BuildCreateTriggerSQL("sqlserver", before); err == nil {
+		t.Fatal("expected sqlserver BEFORE error")
+	}
+	bad := base
+	bad.Events = []string{"TRUNCATE; DROP TABLE t"}
+	if _, err := BuildCreateTriggerSQL("postgres", bad); err == nil {
+		t.Fatal("expected invalid event error")
+	}
+	if _, err := BuildCreateTriggerSQL("mongodb", base); err == nil {
+		t.Fatal("expected unsupported dialect error")
+	}
+	if _, ok := TriggerManagerFor(&MySQLDB{}, "clickhouse"); ok {
+		t.Fatal("clickhouse should not support trigger management")
+	}
+	if _, ok := TriggerManagerFor(&OptionalDriverAgentDB{}, "sqlserver"); !ok {
+		t.Fatal("driver agent should forward trigger management")
+	}
+}
+
+func TestBuildDropTriggerSQL(t *testing.T) {
+	cases := map[string]string{
+		"mysql":    "DROP TRIGGER `shop`.`trg`",
+		"postgres": `DROP TRIGGER "trg" ON "shop"."orders"`,
+		"dameng":   `DROP TRIGGER "shop"."trg"`,
+		"sqlite":   `DROP TRIGGER "trg"`,
+	}
+	for dbType, want := range cases {
+		got, err := BuildDropTriggerSQL(dbType, "shop", "orders", "trg")
+		if err != nil || got != want {
+			t.Fatalf("%s: got %q err %v", dbType, got, err)
+		}
+	}
+}
@@ -43,6 +43,7 @@ func (v *VastbaseDB) getDSN(config connection.ConnectionConfig) string {
 	q := url.Values{}
 	q.Set("sslmode", "disable")
 	q.Set("connect_timeout", strconv.Itoa(getConnectTimeoutSeconds(config)))
+	applyPostgresReadOnly(q, config)
 	u.RawQuery = q.Encode()
 
 	return u.String()
@@ -85,6 +86,7 @@ func (v *VastbaseDB) Connect(config connection.ConnectionConfig) error {
 	if err != nil {
 		return fmt.Errorf("打开数据库连接失败：%w", err)
 	}
+	applyPoolOptions(db, config)
 	v.conn = db
 	v.pingTimeout = getConnectTimeout(config)
 
@@ -132,7 +134,7 @@ func (v *VastbaseDB) QueryContext(ctx context.Context, query string) ([]map[stri
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	return scanRowsContext(ctx, rows, nil)
 }
 
 func (v *VastbaseDB) Query(query string) ([]map[string]interface{}, []string, error) {
@@ -159,6 +161,14 @@ func (v *VastbaseDB) ExecContext(ctx context.Context, query string) (int64, erro
 	return res.RowsAffected()
 }
 
+func (v *VastbaseDB) QueryParams(ctx context.Context, query string, args []interface{}) ([]map[string]interface{}, []string, error) {
+	return queryPoolParams(ctx, v.conn, nil, query, args)
+}
+
+func (v *VastbaseDB) ExecParams(ctx context.Context, query string, args []interface{}) (int64, error) {
+	return execPoolParams(ctx, v.conn, nil, query, args)
+}
+
 func (v *VastbaseDB) Exec(query string) (int64, error) {
 	if v.conn == nil {
 		return 0, fmt.Errorf("connection not open")
@@ -277,6 +287,7 @@ ORDER BY a.attnum`, esc(schema), esc(table))
 
 		columns = append(columns, col)
 	}
+	applyPostgresColumnMeta(v.Query, schema, table, columns)
 	return columns, nil
 }
 
@@ -482,6 +493,18 @@ ORDER BY trigger_name, event_manipulation`, esc(table), esc(schema))
 	return triggers, nil
 }
 
+func (v *VastbaseDB) CreateIndex(dbName, tableName string, index connection.IndexSpec) error {
+	return createIndexWith(v, "vastbase", dbName, tableName, index)
+}
+
+func (v *VastbaseDB) DropIndex(dbName, tableName, indexName string) error {
+	return dropIndexWith(v, "vastbase", dbName, tableName, indexName)
+}
+
+func (v *VastbaseDB) EstimateIndexSize(dbName, tableName string, index connection.IndexSpec) (connection.IndexSizeEstimate, error) {
+	return estimateIndexSizeWith(v, "vastbase", dbName, tableName, index)
+}
+
 func (v *VastbaseDB) GetAllColumns(dbName string) ([]connection.ColumnDefinitionWithTable, error) {
 	query := `
 SELECT table_schema, table_name, column_name, data_type
@@ -506,6 +529,8 @@ ORDER BY table_schema, table_name, ordinal_position`
 
 		col := connection.ColumnDefinitionWithTable{
 			TableName: tableName,
+			Schema:    schema,
+			Table:     table,
 			Name:      fmt.Sprintf("%v", row["column_name"]),
 			Type:      fmt.Sprintf("%v", row["data_type"]),
 		}
@@ -514,10 +539,18 @@ ORDER BY table_schema, table_name, ordinal_position`
 	return cols, nil
 }
 
+func (v *VastbaseDB) PlanChanges(tableName string, changes connection.ChangeSet) ([]ChangeStatement, error) {
+	return buildChangeStatements(postgresChangeStyle, tableName, changes)
+}
+
 func (v *VastbaseDB) ApplyChanges(tableName string, changes connection.ChangeSet) error {
 	if v.conn == nil {
 		return fmt.Errorf("connection not open")
 	}
+	statements, err := v.PlanChanges(tableName, changes)
+	if err != nil {
+		return err
+	}
 
 	tx, err := v.conn.Begin()
 	if err != nil {
@@ -525,105 +558,11 @@ func (v *VastbaseDB) ApplyChanges(tableName string, changes connection.ChangeSet
 	}
 	defer tx.Rollback()
 
-	quoteIdent := func(name string) string {
-		n := strings.TrimSpace(name)
-		n = strings.Trim(n, "\"")
-		n = strings.ReplaceAll(n, "\"", "\"\"")
-		if n == "" {
-			return "\"\""
-		}
-		return `"` + n + `"`
-	}
-
-	schema := ""
-	table := strings.TrimSpace(tableName)
-	if parts := strings.SplitN(table, ".", 2); len(parts) == 2 {
-		schema = strings.TrimSpace(parts[0])
-		table = strings.TrimSpace(parts[1])
-	}
-
-	qualifiedTable := ""
-	if schema != "" {
-		qualifiedTable = fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
-	} else {
-		qualifiedTable = quoteIdent(table)
-	}
-
-	// 1. Deletes
-	for _, pk := range changes.Deletes {
-		var wheres []string
-		var args []interface{}
-		idx := 0
-		for k, val := range pk {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, val)
-		}
-		if len(wheres) == 0 {
-			continue
-		}
-		query := fmt.Sprintf("DELETE FROM %s WHERE %s", qualifiedTable, strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("delete error: %v", err)
-		}
-	}
-
-	// 2. Updates
-	for _, update := range changes.Updates {
-		var sets []string
-		var args []interface{}
-		idx := 0
-
-		for k, val := range update.Values {
-			idx++
-			sets = append(sets, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, val)
-		}
-
-		if len(sets) == 0 {
-			continue
-		}
-
-		var wheres []string
-		for k, val := range update.Keys {
-			idx++
-			wheres = append(wheres, fmt.Sprintf("%s = $%d", quoteIdent(k), idx))
-			args = append(args, val)
-		}
-
-		if len(wheres) == 0 {
-			return fmt.Errorf("update requires keys")
-		}
-
-		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", qualifiedTable, strings.Join(sets, ", "), strings.Join(wheres, " AND "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("update error: %v", err)
-		}
-	}
-
-	// 3. Inserts
-	for _, row := range changes.Inserts {
-		var cols []string
-		var placeholders []string
-		var args []interface{}
-		idx := 0
-
-		for k, val := range row {
-			idx++
-			cols = append(cols, quoteIdent(k))
-			placeholders = append(placeholders, fmt.Sprintf("$%d", idx))
-			args = append(args, val)
-		}
-
-		if len(cols) == 0 {
-			continue
-		}
-
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qualifiedTable, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
-		if _, err := tx.Exec(query, args...); err != nil {
-			return fmt.Errorf("insert error: %v", err)
-		}
+	err = execChangeStatements(statements, func(query string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	}, false)
+	if err != nil {
+		return err
 	}
-
 	return tx.Commit()
 }
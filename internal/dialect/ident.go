@@ -0,0 +1,73 @@
+// Package dialect 汇总各数据库在生成 SQL 时的方言差异，供后端各处 SQL 生成代码共用。
+package dialect
+
+import "strings"
+
+// Normalize 把数据库类型统一为小写，并把常见别名映射为内部使用的类型名。
+func Normalize(dbType string) string {
+	t := strings.ToLower(strings.TrimSpace(dbType))
+	switch t {
+	case "postgresql", "pg":
+		return "postgres"
+	case "mssql":
+		return "sqlserver"
+	case "sqlite3":
+		return "sqlite"
+	}
+	return t
+}
+
+// IdentQuotes 返回标识符的左右引号：MySQL 族、ClickHouse、TDengine 用反引号，SQL Server 用方括号，其余用双引号。
+func IdentQuotes(dbType string) (string, string) {
+	switch Normalize(dbType) {
+	case "mysql", "mariadb", "diros", "sphinx", "tdengine", "clickhouse":
+		return "`", "`"
+	case "sqlserver":
+		return "[", "]"
+	}
+	return `"`, `"`
+}
+
+// QuoteIdent 为单个标识符加引号，名称中的右引号按方言转义；空名称原样返回。
+func QuoteIdent(dbType string, name string) string {
+	if name == "" {
+		return name
+	}
+	open, close := IdentQuotes(dbType)
+	return open + strings.ReplaceAll(name, close, close+close) + close
+}
+
+// QuoteQualified 按 "." 拆分限定名（如 schema.table）后逐段加引号，空段忽略。
+// 名称本身含 "." 时请改用 QualifyTable。
+func QuoteQualified(dbType string, ident string) string {
+	raw := strings.TrimSpace(ident)
+	if raw == "" {
+		return raw
+	}
+	parts := strings.Split(raw, ".")
+	if len(parts) <= 1 {
+		return QuoteIdent(dbType, raw)
+	}
+	quoted := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		quoted = append(quoted, QuoteIdent(dbType, part))
+	}
+	if len(quoted) == 0 {
+		return QuoteIdent(dbType, raw)
+	}
+	return strings.Join(quoted, ".")
+}
+
+// QualifyTable 生成 schema.table 形式的表引用；schema 为空时只返回表名。
+func QualifyTable(dbType string, schema string, table string) string {
+	s := strings.TrimSpace(schema)
+	t := strings.TrimSpace(table)
+	if s == "" {
+		return QuoteIdent(dbType, t)
+	}
+	return QuoteIdent(dbType, s) + "." + QuoteIdent(dbType, t)
+}
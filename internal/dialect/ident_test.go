@@ -0,0 +1,32 @@
+package dialect
+
+import "testing"
+
+func TestQuoteIdent(t *testing.T) {
+	cases := []struct {
+		dbType, name, want string
+	}{
+		{"mysql", "order", "`order`"},
+		{"ClickHouse", "a`b", "`a``b`"},
+		{"tdengine", "meters", "`meters`"},
+		{"sqlserver", "a]b", "[a]]b]"},
+		{"mssql", "t", "[t]"},
+		{"postgres", `Mixed"Case`, `"Mixed""Case"`},
+		{"oracle", "T", `"T"`},
+		{"duckdb", "", ""},
+	}
+	for _, c := range cases {
+		if got := QuoteIdent(c.dbType, c.name); got != c.want {
+			t.Errorf("QuoteIdent(%s, %s) = %s, want %s", c.dbType, c.name, got, c.want)
+		}
+	}
+	if got := QuoteQualified("sqlserver", "dbo. orders"); got != "[dbo].[orders]" {
+		t.Errorf("QuoteQualified = %s", got)
+	}
+	if got := QualifyTable("postgres", "public", "a.b"); got != `"public"."a.b"` {
+		t.Errorf("QualifyTable = %s", got)
+	}
+	if got := QualifyTable("mysql", " ", "t"); got != "`t`" {
+		t.Errorf("QualifyTable = %s", got)
+	}
+}
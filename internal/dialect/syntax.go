@@ -0,0 +1,97 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuoteLiteral 生成字符串字面量：单引号加倍；MySQL 族与 ClickHouse 默认把反斜杠当作转义符，同时转义反斜杠。
+func QuoteLiteral(dbType string, value string) string {
+	escaped := value
+	if backslashEscapes(dbType) {
+		escaped = strings.ReplaceAll(escaped, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(escaped, "'", "''") + "'"
+}
+
+func backslashEscapes(dbType string) bool {
+	return IsMySQLFamily(dbType) || Normalize(dbType) == "clickhouse"
+}
+
+// IsMySQLFamily 判断是否为 MySQL 协议兼容的数据库（MySQL、MariaDB、Doris、Sphinx）。
+func IsMySQLFamily(dbType string) bool {
+	switch Normalize(dbType) {
+	case "mysql", "mariadb", "diros", "doris", "sphinx":
+		return true
+	}
+	return false
+}
+
+// PlaceholderStyle 参数化语句中的占位符写法。
+type PlaceholderStyle int
+
+const (
+	PlaceholderQuestion PlaceholderStyle = iota // ?
+	PlaceholderDollar                           // $1
+	PlaceholderAtP                              // @p1
+	PlaceholderColon                            // :1
+)
+
+// Placeholders 返回数据库的占位符写法：PostgreSQL 系与 DuckDB 为 $1，SQL Server 为 @p1，Oracle/达梦为 :1，其余为 ?。
+func Placeholders(dbType string) PlaceholderStyle {
+	switch Normalize(dbType) {
+	case "postgres", "kingbase", "highgo", "vastbase", "duckdb":
+		return PlaceholderDollar
+	case "sqlserver":
+		return PlaceholderAtP
+	case "oracle", "dameng":
+		return PlaceholderColon
+	}
+	return PlaceholderQuestion
+}
+
+// Placeholder 返回第 idx 个参数（从 1 开始）的占位符。
+func Placeholder(dbType string, idx int) string {
+	return Placeholders(dbType).Format(idx)
+}
+
+// Format 返回第 idx 个参数（从 1 开始）的占位符。
+func (s PlaceholderStyle) Format(idx int) string {
+	switch s {
+	case PlaceholderDollar:
+		return fmt.Sprintf("$%d", idx)
+	case PlaceholderAtP:
+		return fmt.Sprintf("@p%d", idx)
+	case PlaceholderColon:
+		return fmt.Sprintf(":%d", idx)
+	}
+	return "?"
+}
+
+// LimitClause 返回追加在 ORDER BY 之后的分页子句，limit <= 0 时返回空串。
+// SQL Server 使用 OFFSET ... FETCH，语句必须带 ORDER BY；Oracle/达梦使用 12c 起支持的同一写法，
+// 需要兼容旧版本时改用 LimitQuery。
+func LimitClause(dbType string, limit int, offset int) string {
+	if limit <= 0 {
+		return ""
+	}
+	switch Normalize(dbType) {
+	case "sqlserver", "oracle", "dameng":
+		return fmt.Sprintf(" OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", max(offset, 0), limit)
+	}
+	if offset > 0 {
+		return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+	}
+	return fmt.Sprintf(" LIMIT %d", limit)
+}
+
+// LimitQuery 把查询包装为最多返回 limit 行：SQL Server 用 TOP，Oracle/达梦用 ROWNUM，其余追加 LIMIT。
+func LimitQuery(dbType string, query string, limit int) string {
+	switch Normalize(dbType) {
+	case "sqlserver":
+		return fmt.Sprintf("SELECT TOP %d * FROM (%s) AS limited_result", limit, query)
+	case "oracle", "dameng":
+		return fmt.Sprintf("SELECT * FROM (%s) WHERE ROWNUM <= %d", query, limit)
+	}
+	return query + LimitClause(dbType, limit, 0)
+}
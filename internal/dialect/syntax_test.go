@@ -0,0 +1,53 @@
+package dialect
+
+import "testing"
+
+func TestQuoteLiteral(t *testing.T) {
+	cases := []struct {
+		dbType, value, want string
+	}{
+		{"mysql", `it's C:\tmp`, `'it''s C:\\tmp'`},
+		{"clickhouse", `a\b`, `'a\\b'`},
+		{"postgres", `it's C:\tmp`, `'it''s C:\tmp'`},
+		{"sqlserver", "", "''"},
+	}
+	for _, c := range cases {
+		if got := QuoteLiteral(c.dbType, c.value); got != c.want {
+			t.Errorf("QuoteLiteral(%s, %q) = %s, want %s", c.dbType, c.value, got, c.want)
+		}
+	}
+}
+
+func TestPlaceholder(t *testing.T) {
+	cases := map[string]string{
+		"mysql":      "?",
+		"sqlite3":    "?",
+		"postgresql": "$2",
+		"duckdb":     "$2",
+		"mssql":      "@p2",
+		"dameng":     ":2",
+	}
+	for dbType, want := range cases {
+		if got := Placeholder(dbType, 2); got != want {
+			t.Errorf("Placeholder(%s, 2) = %s, want %s", dbType, got, want)
+		}
+	}
+}
+
+func TestLimit(t *testing.T) {
+	if got := LimitClause("mysql", 10, 20); got != " LIMIT 10 OFFSET 20" {
+		t.Errorf("LimitClause(mysql) = %q", got)
+	}
+	if got := LimitClause("sqlserver", 10, 0); got != " OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY" {
+		t.Errorf("LimitClause(sqlserver) = %q", got)
+	}
+	if got := LimitClause("postgres", 0, 5); got != "" {
+		t.Errorf("LimitClause without limit = %q", got)
+	}
+	if got := LimitQuery("dameng", "SELECT 1 FROM t", 5); got != "SELECT * FROM (SELECT 1 FROM t) WHERE ROWNUM <= 5" {
+		t.Errorf("LimitQuery(dameng) = %q", got)
+	}
+	if got := LimitQuery("duckdb", "SELECT 1", 5); got != "SELECT 1 LIMIT 5" {
+		t.Errorf("LimitQuery(duckdb) = %q", got)
+	}
+}
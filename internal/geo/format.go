@@ -0,0 +1,195 @@
+package geo
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// WKT 输出 WKT 文本；SRID 非 0 时按 EWKT 加上 “SRID=n;” 前缀。
+func (g *Geometry) WKT() string {
+	var b strings.Builder
+	if g.SRID != 0 {
+		b.WriteString("SRID=")
+		b.WriteString(strconv.FormatUint(uint64(g.SRID), 10))
+		b.WriteByte(';')
+	}
+	g.writeWKT(&b)
+	return b.String()
+}
+
+func (g *Geometry) writeWKT(b *strings.Builder) {
+	b.WriteString(strings.ToUpper(g.TypeName()))
+	switch {
+	case g.HasZ && g.HasM:
+		b.WriteString(" ZM")
+	case g.HasZ:
+		b.WriteString(" Z")
+	case g.HasM:
+		b.WriteString(" M")
+	}
+	if g.isEmpty() {
+		b.WriteString(" EMPTY")
+		return
+	}
+	if g.HasZ || g.HasM {
+		b.WriteByte(' ')
+	}
+	switch g.Type {
+	case TypePoint:
+		b.WriteByte('(')
+		writeCoord(b, g.Point)
+		b.WriteByte(')')
+	case TypeLineString:
+		writePointList(b, g.Line)
+	case TypePolygon:
+		writeRings(b, g.Rings)
+	case TypeMultiPoint:
+		b.WriteByte('(')
+		for i, part := range g.Parts {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('(')
+			writeCoord(b, part.Point)
+			b.WriteByte(')')
+		}
+		b.WriteByte(')')
+	case TypeMultiLineString:
+		b.WriteByte('(')
+		for i, part := range g.Parts {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writePointList(b, part.Line)
+		}
+		b.WriteByte(')')
+	case TypeMultiPolygon:
+		b.WriteByte('(')
+		for i, part := range g.Parts {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeRings(b, part.Rings)
+		}
+		b.WriteByte(')')
+	case TypeGeometryCollection:
+		b.WriteByte('(')
+		for i, part := range g.Parts {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			part.writeWKT(b)
+		}
+		b.WriteByte(')')
+	}
+}
+
+func (g *Geometry) isEmpty() bool {
+	switch g.Type {
+	case TypePoint:
+		return g.Point == nil
+	case TypeLineString:
+		return len(g.Line) == 0
+	case TypePolygon:
+		return len(g.Rings) == 0
+	default:
+		return len(g.Parts) == 0
+	}
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func writeCoord(b *strings.Builder, pt []float64) {
+	for i, v := range pt {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(formatFloat(v))
+	}
+}
+
+func writePointList(b *strings.Builder, points [][]float64) {
+	b.WriteByte('(')
+	for i, pt := range points {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeCoord(b, pt)
+	}
+	b.WriteByte(')')
+}
+
+func writeRings(b *strings.Builder, rings [][][]float64) {
+	b.WriteByte('(')
+	for i, ring := range rings {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writePointList(b, ring)
+	}
+	b.WriteByte(')')
+}
+
+// GeoJSON 返回 GeoJSON geometry 对象。GeoJSON 不支持 M 值，输出时丢弃；SRID 不写入（RFC 7946 固定为 WGS84）。
+func (g *Geometry) GeoJSON() map[string]interface{} {
+	if g.Type == TypeGeometryCollection {
+		geometries := make([]interface{}, 0, len(g.Parts))
+		for _, part := range g.Parts {
+			geometries = append(geometries, part.GeoJSON())
+		}
+		return map[string]interface{}{"type": g.TypeName(), "geometries": geometries}
+	}
+	return map[string]interface{}{"type": g.TypeName(), "coordinates": g.geoJSONCoordinates()}
+}
+
+// GeoJSONText 返回紧凑的 GeoJSON 文本。
+func (g *Geometry) GeoJSONText() string {
+	b, err := json.Marshal(g.GeoJSON())
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func (g *Geometry) geoJSONCoordinates() interface{} {
+	switch g.Type {
+	case TypePoint:
+		if g.Point == nil {
+			return []float64{}
+		}
+		return g.position(g.Point)
+	case TypeLineString:
+		return g.positions(g.Line)
+	case TypePolygon:
+		rings := make([]interface{}, 0, len(g.Rings))
+		for _, ring := range g.Rings {
+			rings = append(rings, g.positions(ring))
+		}
+		return rings
+	default:
+		parts := make([]interface{}, 0, len(g.Parts))
+		for _, part := range g.Parts {
+			parts = append(parts, part.geoJSONCoordinates())
+		}
+		return parts
+	}
+}
+
+// position 去掉 M 值，保留 x y [z]。
+func (g *Geometry) position(pt []float64) []float64 {
+	if g.HasZ {
+		return pt[:3]
+	}
+	return pt[:2]
+}
+
+func (g *Geometry) positions(points [][]float64) [][]float64 {
+	out := make([][]float64, 0, len(points))
+	for _, pt := range points {
+		out = append(out, g.position(pt))
+	}
+	return out
+}
@@ -0,0 +1,294 @@
+// Package geo 解码数据库返回的空间值（WKB / PostGIS EWKB / MySQL 内部格式），并输出 WKT 与 GeoJSON。
+package geo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// 几何类型编号，与 OGC WKB 一致。
+const (
+	TypePoint              = 1
+	TypeLineString         = 2
+	TypePolygon            = 3
+	TypeMultiPoint         = 4
+	TypeMultiLineString    = 5
+	TypeMultiPolygon       = 6
+	TypeGeometryCollection = 7
+)
+
+var typeNames = map[int]string{
+	TypePoint:              "Point",
+	TypeLineString:         "LineString",
+	TypePolygon:            "Polygon",
+	TypeMultiPoint:         "MultiPoint",
+	TypeMultiLineString:    "MultiLineString",
+	TypeMultiPolygon:       "MultiPolygon",
+	TypeGeometryCollection: "GeometryCollection",
+}
+
+// EWKB 类型字段中的标志位。
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// 防止损坏数据声明超大元素个数导致过量分配。
+const maxElements = 1 << 24
+
+// ErrNotSpatial 值不是可识别的空间二进制。
+var ErrNotSpatial = errors.New("不是可识别的空间数据")
+
+// Geometry 解码后的几何对象。坐标按 x y [z] [m] 存放。
+type Geometry struct {
+	Type  int
+	SRID  uint32
+	HasZ  bool
+	HasM  bool
+	Point []float64     // Point，空点为 nil
+	Line  [][]float64   // LineString
+	Rings [][][]float64 // Polygon
+	Parts []*Geometry   // Multi* 与 GeometryCollection
+}
+
+// TypeName 返回 GeoJSON 风格的类型名，如 Point、MultiPolygon。
+func (g *Geometry) TypeName() string {
+	return typeNames[g.Type]
+}
+
+var spatialTypePattern = regexp.MustCompile(`(?i)^\s*(geometry|geography|point|linestring|polygon|multipoint|multilinestring|multipolygon|geometrycollection|geomcollection|sdo_geometry|st_geometry)\b`)
+
+// IsSpatialType 判断列类型是否为空间类型（MySQL、PostGIS 的 geometry(Point,4326) 等写法均可）。
+func IsSpatialType(columnType string) bool {
+	return spatialTypePattern.MatchString(columnType)
+}
+
+// Decode 解码单元格值：[]byte 原始二进制、0x 前缀十六进制（驱动对二进制的文本化结果）或 PostGIS 的十六进制 EWKB。
+// 先按 WKB/EWKB 解析，失败时再按 MySQL 内部格式（4 字节 SRID + WKB）解析；均要求恰好消费全部字节。
+func Decode(value interface{}) (*Geometry, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case []byte:
+		raw = v
+		if text, ok := hexText(string(v)); ok {
+			raw = text
+		}
+	case string:
+		text, ok := hexText(v)
+		if !ok {
+			return nil, ErrNotSpatial
+		}
+		raw = text
+	default:
+		return nil, ErrNotSpatial
+	}
+	if len(raw) < 5 {
+		return nil, ErrNotSpatial
+	}
+	if g, err := parseWKB(raw); err == nil {
+		return g, nil
+	}
+	if len(raw) > 9 {
+		if g, err := parseWKB(raw[4:]); err == nil {
+			if g.SRID == 0 {
+				g.SRID = binary.LittleEndian.Uint32(raw[:4])
+			}
+			return g, nil
+		}
+	}
+	return nil, ErrNotSpatial
+}
+
+// hexText 识别 0x 前缀或纯十六进制文本（PostGIS 文本协议输出 EWKB 十六进制）。
+func hexText(text string) ([]byte, bool) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+		text = text[2:]
+	} else if !strings.HasPrefix(text, "00") && !strings.HasPrefix(text, "01") {
+		return nil, false
+	}
+	if len(text) < 10 || len(text)%2 != 0 {
+		return nil, false
+	}
+	b, err := hex.DecodeString(text)
+	return b, err == nil
+}
+
+func parseWKB(data []byte) (*Geometry, error) {
+	r := &wkbReader{data: data}
+	g, err := r.geometry(0)
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(data) {
+		return nil, fmt.Errorf("WKB 末尾有 %d 字节多余数据", len(data)-r.pos)
+	}
+	return g, nil
+}
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, errTruncated("uint32")
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *wkbReader) float64() (float64, error) {
+	if r.pos+8 > len(r.data) {
+		return 0, errTruncated("double")
+	}
+	v := math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+	r.pos += 8
+	return v, nil
+}
+
+func (r *wkbReader) count() (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if n > maxElements {
+		return 0, fmt.Errorf("WKB 元素个数异常：%d", n)
+	}
+	return int(n), nil
+}
+
+func errTruncated(what string) error {
+	return fmt.Errorf("WKB 数据不完整：读取 %s 越界", what)
+}
+
+func (r *wkbReader) geometry(depth int) (*Geometry, error) {
+	if depth > 32 {
+		return nil, fmt.Errorf("WKB 嵌套层级过深")
+	}
+	if r.pos >= len(r.data) {
+		return nil, errTruncated("字节序")
+	}
+	switch r.data[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("无效的 WKB 字节序标记：%d", r.data[r.pos])
+	}
+	r.pos++
+	rawType, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	g := &Geometry{}
+	if rawType&ewkbSRID != 0 {
+		if g.SRID, err = r.uint32(); err != nil {
+			return nil, err
+		}
+	}
+	g.HasZ = rawType&ewkbZ != 0
+	g.HasM = rawType&ewkbM != 0
+	base := rawType &^ (ewkbZ | ewkbM | ewkbSRID)
+	// ISO WKB：1000 + 类型为 Z，2000 为 M，3000 为 ZM
+	switch base / 1000 {
+	case 1:
+		g.HasZ = true
+	case 2:
+		g.HasM = true
+	case 3:
+		g.HasZ, g.HasM = true, true
+	}
+	g.Type = int(base % 1000)
+	if _, ok := typeNames[g.Type]; !ok || base >= 4000 {
+		return nil, fmt.Errorf("不支持的 WKB 几何类型：%d", rawType)
+	}
+	dims := 2
+	if g.HasZ {
+		dims++
+	}
+	if g.HasM {
+		dims++
+	}
+
+	switch g.Type {
+	case TypePoint:
+		pt, err := r.coords(dims)
+		if err != nil {
+			return nil, err
+		}
+		if !math.IsNaN(pt[0]) || !math.IsNaN(pt[1]) {
+			g.Point = pt
+		}
+	case TypeLineString:
+		if g.Line, err = r.pointList(dims); err != nil {
+			return nil, err
+		}
+	case TypePolygon:
+		n, err := r.count()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			ring, err := r.pointList(dims)
+			if err != nil {
+				return nil, err
+			}
+			g.Rings = append(g.Rings, ring)
+		}
+	default:
+		n, err := r.count()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			part, err := r.geometry(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			g.Parts = append(g.Parts, part)
+		}
+	}
+	return g, nil
+}
+
+func (r *wkbReader) coords(dims int) ([]float64, error) {
+	pt := make([]float64, dims)
+	for i := range pt {
+		v, err := r.float64()
+		if err != nil {
+			return nil, err
+		}
+		pt[i] = v
+	}
+	return pt, nil
+}
+
+func (r *wkbReader) pointList(dims int) ([][]float64, error) {
+	n, err := r.count()
+	if err != nil {
+		return nil, err
+	}
+	if r.pos+n*dims*8 > len(r.data) {
+		return nil, errTruncated("坐标")
+	}
+	points := make([][]float64, 0, n)
+	for i := 0; i < n; i++ {
+		pt, err := r.coords(dims)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+	}
+	return points, nil
+}
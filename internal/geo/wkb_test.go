@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+)
+
+type wkbBuilder struct {
+	buf   bytes.Buffer
+	order binary.ByteOrder
+}
+
+func newWKB(order binary.ByteOrder) *wkbBuilder {
+	return &wkbBuilder{order: order}
+}
+
+func (w *wkbBuilder) header(typ uint32) *wkbBuilder {
+	if w.order == binary.LittleEndian {
+		w.buf.WriteByte(1)
+	} else {
+		w.buf.WriteByte(0)
+	}
+	return w.u32(typ)
+}
+
+func (w *wkbBuilder) u32(v uint32) *wkbBuilder {
+	_ = binary.Write(&w.buf, w.order, v)
+	return w
+}
+
+func (w *wkbBuilder) f64(values ...float64) *wkbBuilder {
+	for _, v := range values {
+		_ = binary.Write(&w.buf, w.order, math.Float64bits(v))
+	}
+	return w
+}
+
+func (w *wkbBuilder) bytes() []byte { return w.buf.Bytes() }
+
+func TestDecodeMySQLInternalFormat(t *testing.T) {
+	// MySQL：4 字节小端 SRID + WKB，驱动层以 0x 十六进制文本返回
+	payload := newWKB(binary.LittleEndian).header(TypePoint).f64(116.397, 39.908).bytes()
+	raw := append([]byte{0xE6, 0x10, 0, 0}, payload...)
+	g, err := Decode("0x" + strings.ToUpper(hex.EncodeToString(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.WKT() != "SRID=4326;POINT(116.397 39.908)" {
+		t.Fatalf("wkt = %s", g.WKT())
+	}
+	if g.GeoJSONText() != `{"coordinates":[116.397,39.908],"type":"Point"}` {
+		t.Fatalf("geojson = %s", g.GeoJSONText())
+	}
+
+	// SRID 为 0 时首字节为 0，不能被误判为大端 WKB
+	zero := append([]byte{0, 0, 0, 0}, payload...)
+	if g, err := Decode(zero); err != nil || g.WKT() != "POINT(116.397 39.908)" {
+		t.Fatalf("srid 0: %v %v", g, err)
+	}
+}
+
+func TestDecodePostGISEWKBPolygon(t *testing.T) {
+	w := newWKB(binary.LittleEndian).header(TypePolygon | ewkbSRID).u32(3857).u32(2)
+	w.u32(4).f64(0, 0, 10, 0, 10, 10, 0, 0)
+	w.u32(4).f64(1, 1, 2, 1, 2, 2, 1, 1)
+	g, err := Decode(hex.EncodeToString(w.bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "SRID=3857;POLYGON((0 0,10 0,10 10,0 0),(1 1,2 1,2 2,1 1))"
+	if g.WKT() != want {
+		t.Fatalf("wkt = %s", g.WKT())
+	}
+}
+
+func TestDecodeNestedBigEndianAndZ(t *testing.T) {
+	w := newWKB(binary.BigEndian).header(TypeGeometryCollection).u32(2)
+	w.header(1000+TypePoint).f64(1, 2, 3) // ISO Z
+	w.header(TypeMultiLineString).u32(1).header(TypeLineString).u32(2).f64(0, 0, 1.5, -2)
+	g, err := Decode(w.bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.WKT() != "GEOMETRYCOLLECTION(POINT Z (1 2 3),MULTILINESTRING((0 0,1.5 -2)))" {
+		t.Fatalf("wkt = %s", g.WKT())
+	}
+	want := `{"geometries":[{"coordinates":[1,2,3],"type":"Point"},{"coordinates":[[[0,0],[1.5,-2]]],"type":"MultiLineString"}],"type":"GeometryCollection"}`
+	if g.GeoJSONText() != want {
+		t.Fatalf("geojson = %s", g.GeoJSONText())
+	}
+}
+
+func TestDecodeRejectsNonSpatial(t *testing.T) {
+	truncated := newWKB(binary.LittleEndian).header(TypeLineString).u32(1000).f64(1, 2).bytes()
+	for _, value := range []interface{}{"hello", "12345", "0x00", truncated, int64(7), "POINT(1 2)"} {
+		if _, err := Decode(value); err == nil {
+			t.Errorf("Decode(%v) should fail", value)
+		}
+	}
+	if !IsSpatialType("geometry(Point,4326)") || !IsSpatialType("MULTIPOLYGON") || IsSpatialType("varchar(20)") || IsSpatialType("pointer_id") {
+		t.Fatal("IsSpatialType mismatch")
+	}
+}
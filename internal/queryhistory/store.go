@@ -0,0 +1,356 @@
+// Package queryhistory 在后端持久化执行过的语句，保存在本地 SQLite 数据库 ~/.gonavi/history.db 中，
+// 支持按关键字、连接、库与执行结果检索、分页，收藏常用语句，以及按时间或条数清理。
+package queryhistory
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+
+	// 超过 maxEntries 条时自动删除最旧的未收藏记录，每 autoPruneEvery 次写入检查一次
+	maxEntries     = 50000
+	autoPruneEvery = 500
+)
+
+const schemaSQL = `CREATE TABLE IF NOT EXISTS history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	conn_type TEXT NOT NULL,
+	conn_addr TEXT NOT NULL,
+	conn_user TEXT NOT NULL DEFAULT '',
+	database_name TEXT NOT NULL DEFAULT '',
+	sql_text TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	duration_ms REAL NOT NULL DEFAULT 0,
+	row_count INTEGER NOT NULL DEFAULT 0,
+	success INTEGER NOT NULL,
+	error_text TEXT NOT NULL DEFAULT '',
+	executed_at INTEGER NOT NULL,
+	favorite INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_history_executed_at ON history (executed_at);
+CREATE INDEX IF NOT EXISTS idx_history_conn ON history (conn_type, conn_addr, executed_at);`
+
+// Entry 一条执行记录。
+type Entry struct {
+	ID         int64   `json:"id"`
+	ConnType   string  `json:"connType"`
+	ConnAddr   string  `json:"connAddr"` // host:port，本地文件库为文件路径
+	ConnUser   string  `json:"connUser,omitempty"`
+	Database   string  `json:"database,omitempty"`
+	SQL        string  `json:"sql"`
+	Kind       string  `json:"kind"` // query/exec
+	DurationMs float64 `json:"durationMs"`
+	Rows       int64   `json:"rows"` // 查询返回的行数或执行影响的行数
+	Success    bool    `json:"success"`
+	Error      string  `json:"error,omitempty"`
+	ExecutedAt int64   `json:"executedAt"` // Unix milli
+	Favorite   bool    `json:"favorite"`
+}
+
+// Filter 检索条件，空字段表示不限。Page 从 1 开始。
+type Filter struct {
+	Keyword       string `json:"keyword,omitempty"` // 语句包含的文本，ASCII 不区分大小写
+	ConnType      string `json:"connType,omitempty"`
+	ConnAddr      string `json:"connAddr,omitempty"`
+	Database      string `json:"database,omitempty"`
+	Status        string `json:"status,omitempty"` // success/error
+	OnlyFavorites bool   `json:"onlyFavorites,omitempty"`
+	Since         int64  `json:"since,omitempty"` // Unix milli
+	Until         int64  `json:"until,omitempty"`
+	Page          int    `json:"page,omitempty"`
+	PageSize      int    `json:"pageSize,omitempty"`
+}
+
+// Page 一页检索结果，按执行时间倒序。
+type Page struct {
+	Entries  []Entry `json:"entries"`
+	Total    int64   `json:"total"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"pageSize"`
+}
+
+// PruneOptions 清理条件；两个条件同时给出时删除满足任一条件的记录。
+type PruneOptions struct {
+	Before        int64 `json:"before,omitempty"`     // 删除早于该时间（Unix milli）的记录
+	KeepLatest    int   `json:"keepLatest,omitempty"` // 只保留最近的 N 条
+	KeepFavorites bool  `json:"keepFavorites"`        // 收藏的记录不参与清理
+}
+
+// Store 历史记录存储，方法可并发调用。数据库在首次使用时才创建。
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	db      *sql.DB
+	written int
+}
+
+// DefaultPath 返回默认存储路径 ~/.gonavi/history.db。
+func DefaultPath() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "history.db")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-history.db")
+}
+
+// New 创建存储；path 为空时使用 DefaultPath。不会立即读写磁盘。
+func New(path string) *Store {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{path: path}
+}
+
+// Path 返回数据库文件路径。
+func (s *Store) Path() string {
+	return s.path
+}
+
+// openLocked 按需打开数据库并建表；调用方需持有 s.mu。
+func (s *Store) openLocked() (*sql.DB, error) {
+	if s.db != nil {
+		return s.db, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return nil, fmt.Errorf("创建历史记录目录失败：%w", err)
+	}
+	conn, err := sql.Open("sqlite", "file:"+filepath.ToSlash(s.path)+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("打开历史记录库失败：%w", err)
+	}
+	// 所有访问都经由 s.mu 串行化，单连接即可
+	conn.SetMaxOpenConns(1)
+	if _, err := conn.Exec(schemaSQL); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("初始化历史记录库失败：%w", err)
+	}
+	s.db = conn
+	return conn, nil
+}
+
+// Record 写入一条记录并返回其 ID。
+func (s *Store) Record(entry Entry) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, err := s.openLocked()
+	if err != nil {
+		return 0, err
+	}
+	res, err := conn.Exec(`INSERT INTO history (conn_type, conn_addr, conn_user, database_name, sql_text, kind, duration_ms, row_count, success, error_text, executed_at, favorite)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ConnType, entry.ConnAddr, entry.ConnUser, entry.Database, entry.SQL, entry.Kind,
+		entry.DurationMs, entry.Rows, boolInt(entry.Success), entry.Error, entry.ExecutedAt, boolInt(entry.Favorite))
+	if err != nil {
+		return 0, fmt.Errorf("写入历史记录失败：%w", err)
+	}
+	id, _ := res.LastInsertId()
+	s.written++
+	if s.written%autoPruneEvery == 0 {
+		if _, err := pruneLocked(conn, PruneOptions{KeepLatest: maxEntries, KeepFavorites: true}); err != nil {
+			return id, err
+		}
+	}
+	return id, nil
+}
+
+// Search 按条件检索，结果按执行时间倒序分页。
+func (s *Store) Search(filter Filter) (Page, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultPageSize
+	}
+	if filter.PageSize > maxPageSize {
+		filter.PageSize = maxPageSize
+	}
+	page := Page{Entries: []Entry{}, Page: filter.Page, PageSize: filter.PageSize}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, err := s.openLocked()
+	if err != nil {
+		return page, err
+	}
+	where, args := filterClause(filter)
+	if err := conn.QueryRow("SELECT COUNT(*) FROM history"+where, args...).Scan(&page.Total); err != nil {
+		return page, fmt.Errorf("检索历史记录失败：%w", err)
+	}
+	rows, err := conn.Query(`SELECT id, conn_type, conn_addr, conn_user, database_name, sql_text, kind, duration_ms, row_count, success, error_text, executed_at, favorite
+		FROM history`+where+" ORDER BY executed_at DESC, id DESC LIMIT ? OFFSET ?",
+		append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)...)
+	if err != nil {
+		return page, fmt.Errorf("检索历史记录失败：%w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e Entry
+		var success, favorite int
+		if err := rows.Scan(&e.ID, &e.ConnType, &e.ConnAddr, &e.ConnUser, &e.Database, &e.SQL, &e.Kind,
+			&e.DurationMs, &e.Rows, &success, &e.Error, &e.ExecutedAt, &favorite); err != nil {
+			return page, fmt.Errorf("读取历史记录失败：%w", err)
+		}
+		e.Success, e.Favorite = success != 0, favorite != 0
+		page.Entries = append(page.Entries, e)
+	}
+	return page, rows.Err()
+}
+
+func filterClause(filter Filter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if keyword := strings.TrimSpace(filter.Keyword); keyword != "" {
+		conds = append(conds, `sql_text LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(keyword)+"%")
+	}
+	for _, field := range []struct{ column, value string }{
+		{"conn_type", filter.ConnType}, {"conn_addr", filter.ConnAddr}, {"database_name", filter.Database},
+	} {
+		if field.value != "" {
+			conds = append(conds, field.column+" = ?")
+			args = append(args, field.value)
+		}
+	}
+	switch filter.Status {
+	case "success":
+		conds = append(conds, "success = 1")
+	case "error":
+		conds = append(conds, "success = 0")
+	}
+	if filter.OnlyFavorites {
+		conds = append(conds, "favorite = 1")
+	}
+	if filter.Since > 0 {
+		conds = append(conds, "executed_at >= ?")
+		args = append(args, filter.Since)
+	}
+	if filter.Until > 0 {
+		conds = append(conds, "executed_at < ?")
+		args = append(args, filter.Until)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+}
+
+// SetFavorite 收藏或取消收藏一条记录。
+func (s *Store) SetFavorite(id int64, favorite bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, err := s.openLocked()
+	if err != nil {
+		return err
+	}
+	res, err := conn.Exec("UPDATE history SET favorite = ? WHERE id = ?", boolInt(favorite), id)
+	if err != nil {
+		return fmt.Errorf("更新历史记录失败：%w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("历史记录 %d 不存在", id)
+	}
+	return nil
+}
+
+// Delete 删除指定记录，返回删除条数。
+func (s *Store) Delete(ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, err := s.openLocked()
+	if err != nil {
+		return 0, err
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	res, err := conn.Exec("DELETE FROM history WHERE id IN ("+placeholders+")", args...)
+	if err != nil {
+		return 0, fmt.Errorf("删除历史记录失败：%w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// Prune 按时间或条数清理，返回删除条数。两项条件都为空时清空全部（按 KeepFavorites 保留收藏）。
+func (s *Store) Prune(opts PruneOptions) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn, err := s.openLocked()
+	if err != nil {
+		return 0, err
+	}
+	return pruneLocked(conn, opts)
+}
+
+func pruneLocked(conn *sql.DB, opts PruneOptions) (int64, error) {
+	var conds []string
+	var args []interface{}
+	if opts.Before > 0 {
+		conds = append(conds, "executed_at < ?")
+		args = append(args, opts.Before)
+	}
+	if opts.KeepLatest > 0 {
+		keep := "SELECT id FROM history"
+		if opts.KeepFavorites {
+			keep += " WHERE favorite = 0"
+		}
+		conds = append(conds, "id NOT IN ("+keep+" ORDER BY executed_at DESC, id DESC LIMIT ?)")
+		args = append(args, opts.KeepLatest)
+	}
+	query := "DELETE FROM history"
+	var where []string
+	if len(conds) > 0 {
+		where = append(where, "("+strings.Join(conds, " OR ")+")")
+	}
+	if opts.KeepFavorites {
+		where = append(where, "favorite = 0")
+	}
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	res, err := conn.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("清理历史记录失败：%w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// Close 关闭数据库，之后再次使用会重新打开。
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
+
+func boolInt(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+package queryhistory
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	store := New(filepath.Join(t.TempDir(), "history.db"))
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestStoreRecordSearchAndFavorite(t *testing.T) {
+	store := newTestStore(t)
+	entries := []Entry{
+		{ConnType: "mysql", ConnAddr: "db:3306", Database: "shop", SQL: "SELECT * FROM orders", Kind: "query", Rows: 3, Success: true, ExecutedAt: 1000},
+		{ConnType: "mysql", ConnAddr: "db:3306", Database: "shop", SQL: "UPDATE orders SET paid = 1", Kind: "exec", Rows: 2, Success: true, ExecutedAt: 2000},
+		{ConnType: "postgres", ConnAddr: "pg:5432", Database: "app", SQL: "SELECT 100%_done FROM t", Kind: "query", Error: "syntax error", ExecutedAt: 3000},
+	}
+	var ids []int64
+	for _, e := range entries {
+		id, err := store.Record(e)
+		if err != nil {
+			t.Fatalf("record: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	page, err := store.Search(Filter{Keyword: "orders", PageSize: 1})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if page.Total != 2 || len(page.Entries) != 1 || page.Entries[0].ID != ids[1] {
+		t.Fatalf("first page = %+v", page)
+	}
+	page, _ = store.Search(Filter{Keyword: "orders", PageSize: 1, Page: 2})
+	if len(page.Entries) != 1 || page.Entries[0].ID != ids[0] {
+		t.Fatalf("second page = %+v", page)
+	}
+	// LIKE 通配符按字面匹配
+	if page, _ = store.Search(Filter{Keyword: "100%_"}); page.Total != 1 {
+		t.Fatalf("literal keyword total = %d", page.Total)
+	}
+	if page, _ = store.Search(Filter{Status: "error"}); page.Total != 1 || page.Entries[0].Error != "syntax error" || page.Entries[0].Success {
+		t.Fatalf("error filter = %+v", page)
+	}
+
+	if err := store.SetFavorite(ids[0], true); err != nil {
+		t.Fatalf("favorite: %v", err)
+	}
+	if page, _ = store.Search(Filter{OnlyFavorites: true}); page.Total != 1 || !page.Entries[0].Favorite {
+		t.Fatalf("favorites = %+v", page)
+	}
+	if err := store.SetFavorite(999, true); err == nil {
+		t.Fatalf("expected error for missing entry")
+	}
+}
+
+func TestStorePrune(t *testing.T) {
+	store := newTestStore(t)
+	for i := int64(1); i <= 5; i++ {
+		if _, err := store.Record(Entry{ConnType: "sqlite", ConnAddr: "a.db", SQL: "SELECT 1", Kind: "query", Success: true, ExecutedAt: i * 1000, Favorite: i == 1}); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	removed, err := store.Prune(PruneOptions{KeepLatest: 2, KeepFavorites: true})
+	if err != nil || removed != 2 {
+		t.Fatalf("prune keep latest removed=%d err=%v", removed, err)
+	}
+	page, _ := store.Search(Filter{})
+	if page.Total != 3 || page.Entries[2].ExecutedAt != 1000 {
+		t.Fatalf("after prune = %+v", page)
+	}
+	if removed, _ = store.Prune(PruneOptions{Before: 5000}); removed != 2 {
+		t.Fatalf("prune before removed=%d", removed)
+	}
+	if removed, _ = store.Delete([]int64{999}); removed != 0 {
+		t.Fatalf("delete missing removed=%d", removed)
+	}
+}
@@ -0,0 +1,207 @@
+package redis
+
+import "strings"
+
+// 命令安全分类
+const (
+	CommandRead        = "read"        // 只读取数据
+	CommandWrite       = "write"       // 修改数据
+	CommandAdmin       = "admin"       // 服务器管理
+	CommandDangerous   = "dangerous"   // 可能清空数据、阻塞服务器或改变复制拓扑，执行前需确认
+	CommandUnsupported = "unsupported" // 订阅/监听类命令会长期占用连接，控制台不支持
+)
+
+// CommandInfo 命令元数据。Name 为大写命令名，带子命令时为 "CONFIG SET" 形式。
+type CommandInfo struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Reason   string `json:"reason,omitempty"` // dangerous/unsupported 的原因
+}
+
+var dangerousCommands = map[string]string{
+	"FLUSHALL":          "清空所有库的全部数据",
+	"FLUSHDB":           "清空当前库的全部数据",
+	"KEYS":              "遍历全部键，键多时会阻塞服务器，建议改用 SCAN",
+	"SHUTDOWN":          "关闭 Redis 服务",
+	"DEBUG":             "调试命令可能导致服务崩溃或阻塞",
+	"SWAPDB":            "交换两个库的全部数据",
+	"MIGRATE":           "把键迁移到其他实例并从当前实例删除",
+	"REPLICAOF":         "改变复制拓扑，可能清空当前实例的数据",
+	"SLAVEOF":           "改变复制拓扑，可能清空当前实例的数据",
+	"FAILOVER":          "触发主从切换",
+	"CONFIG SET":        "修改运行中的服务器配置",
+	"CONFIG REWRITE":    "改写服务器配置文件",
+	"CONFIG RESETSTAT":  "重置服务器统计信息",
+	"SCRIPT FLUSH":      "清空全部 Lua 脚本缓存",
+	"FUNCTION FLUSH":    "删除全部函数库",
+	"FUNCTION DELETE":   "删除函数库",
+	"CLIENT KILL":       "断开其他客户端连接",
+	"CLIENT PAUSE":      "暂停所有客户端的命令处理",
+	"CLUSTER RESET":     "重置集群节点状态",
+	"CLUSTER FAILOVER":  "触发集群主从切换",
+	"CLUSTER FORGET":    "从集群中移除节点",
+	"ACL DELUSER":       "删除 ACL 用户",
+	"ACL SETUSER":       "修改 ACL 用户权限",
+	"MODULE UNLOAD":     "卸载模块",
+	"MODULE LOAD":       "加载模块",
+	"LATENCY RESET":     "重置延迟监控数据",
+	"SLOWLOG RESET":     "清空慢查询日志",
+	"MEMORY PURGE":      "主动回收内存，可能短暂阻塞",
+	"BGREWRITEAOF":      "后台重写 AOF 文件，占用较多 IO",
+	"SAVE":              "同步保存 RDB，期间阻塞服务器",
+	"SENTINEL RESET":    "重置哨兵监控的主节点状态",
+	"SENTINEL REMOVE":   "停止监控主节点",
+	"SENTINEL FAILOVER": "触发哨兵主从切换",
+}
+
+var unsupportedCommands = map[string]string{
+	"SUBSCRIBE":    "订阅命令会长期占用连接",
+	"PSUBSCRIBE":   "订阅命令会长期占用连接",
+	"SSUBSCRIBE":   "订阅命令会长期占用连接",
+	"UNSUBSCRIBE":  "当前连接没有订阅",
+	"PUNSUBSCRIBE": "当前连接没有订阅",
+	"MONITOR":      "MONITOR 会持续输出服务器收到的全部命令",
+	"SYNC":         "复制协议命令",
+	"PSYNC":        "复制协议命令",
+	"QUIT":         "会断开共享连接",
+	"RESET":        "会重置共享连接的状态",
+	"SELECT":       "SELECT 只作用于连接池中的单个连接，请在左侧切换库",
+}
+
+var readCommands = toCommandSet(
+	"GET", "MGET", "GETRANGE", "STRLEN", "EXISTS", "TYPE", "TTL", "PTTL", "EXPIRETIME", "PEXPIRETIME", "RANDOMKEY", "DUMP", "SUBSTR", "LCS",
+	"HGET", "HMGET", "HGETALL", "HKEYS", "HVALS", "HLEN", "HEXISTS", "HSTRLEN", "HRANDFIELD", "HSCAN", "HTTL",
+	"LRANGE", "LLEN", "LINDEX", "LPOS",
+	"SMEMBERS", "SCARD", "SISMEMBER", "SMISMEMBER", "SRANDMEMBER", "SINTER", "SINTERCARD", "SUNION", "SDIFF", "SSCAN",
+	"ZRANGE", "ZRANGEBYSCORE", "ZRANGEBYLEX", "ZREVRANGE", "ZREVRANGEBYSCORE", "ZREVRANGEBYLEX", "ZSCORE", "ZMSCORE", "ZCARD",
+	"ZCOUNT", "ZLEXCOUNT", "ZRANK", "ZREVRANK", "ZRANDMEMBER", "ZINTER", "ZUNION", "ZDIFF", "ZINTERCARD", "ZSCAN",
+	"XRANGE", "XREVRANGE", "XLEN", "XINFO", "XPENDING", "XREAD",
+	"BITCOUNT", "BITPOS", "GETBIT", "BITFIELD_RO", "PFCOUNT",
+	"GEOPOS", "GEODIST", "GEOHASH", "GEORADIUS_RO", "GEORADIUSBYMEMBER_RO", "GEOSEARCH",
+	"SCAN", "DBSIZE", "INFO", "PING", "ECHO", "TIME", "LASTSAVE", "ROLE", "COMMAND", "LOLWUT",
+	"OBJECT", "MEMORY USAGE", "MEMORY STATS", "MEMORY DOCTOR", "CONFIG GET", "CLIENT LIST", "CLIENT INFO", "CLIENT GETNAME",
+	"CLIENT ID", "SLOWLOG GET", "SLOWLOG LEN", "LATENCY LATEST", "LATENCY HISTORY", "LATENCY DOCTOR", "CLUSTER INFO",
+	"CLUSTER NODES", "CLUSTER SLOTS", "CLUSTER SHARDS", "CLUSTER KEYSLOT", "CLUSTER COUNTKEYSINSLOT", "CLUSTER GETKEYSINSLOT",
+	"ACL WHOAMI", "ACL LIST", "ACL USERS", "ACL CAT", "ACL GETUSER", "ACL LOG", "MODULE LIST", "FUNCTION LIST", "FUNCTION DUMP",
+	"FUNCTION STATS", "SCRIPT EXISTS", "EVAL_RO", "EVALSHA_RO", "FCALL_RO", "SENTINEL MASTERS", "SENTINEL MASTER",
+	"SENTINEL REPLICAS", "SENTINEL SLAVES", "SENTINEL SENTINELS", "SENTINEL GET-MASTER-ADDR-BY-NAME", "PUBSUB CHANNELS",
+	"PUBSUB NUMSUB", "PUBSUB NUMPAT", "PUBSUB SHARDCHANNELS", "PUBSUB SHARDNUMSUB",
+)
+
+var adminCommands = toCommandSet("CONFIG", "CLIENT", "SLOWLOG", "LATENCY", "CLUSTER", "ACL", "MODULE", "BGSAVE", "SCRIPT",
+	"FUNCTION", "MEMORY", "SENTINEL", "WAIT", "WAITAOF", "READONLY", "READWRITE", "AUTH", "HELLO")
+
+// containerCommands 带子命令的命令，按 "命令 子命令" 分类
+var containerCommands = toCommandSet("CONFIG", "CLIENT", "SLOWLOG", "LATENCY", "CLUSTER", "ACL", "MODULE", "SCRIPT",
+	"FUNCTION", "MEMORY", "SENTINEL", "OBJECT", "XINFO", "PUBSUB", "COMMAND")
+
+func toCommandSet(names ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+// ClassifyCommand 按命令名（及子命令）返回安全分类；未收录的命令视为写命令。
+func ClassifyCommand(args []string) CommandInfo {
+	if len(args) == 0 {
+		return CommandInfo{}
+	}
+	name := strings.ToUpper(strings.TrimSpace(args[0]))
+	full := name
+	if _, ok := containerCommands[name]; ok && len(args) > 1 {
+		full = name + " " + strings.ToUpper(strings.TrimSpace(args[1]))
+	}
+	for _, key := range []string{full, name} {
+		if reason, ok := unsupportedCommands[key]; ok {
+			return CommandInfo{Name: full, Category: CommandUnsupported, Reason: reason}
+		}
+		if reason, ok := dangerousCommands[key]; ok {
+			return CommandInfo{Name: full, Category: CommandDangerous, Reason: reason}
+		}
+	}
+	for _, key := range []string{full, name} {
+		// OBJECT、XINFO、COMMAND 等整组只读的命令只收录了命令名
+		if _, ok := readCommands[key]; ok {
+			return CommandInfo{Name: full, Category: CommandRead}
+		}
+	}
+	if _, ok := adminCommands[name]; ok {
+		return CommandInfo{Name: full, Category: CommandAdmin}
+	}
+	return CommandInfo{Name: full, Category: CommandWrite}
+}
+
+// CommandCatalog 返回收录的命令分类，供前端在发送前标记与确认。
+// 键为大写命令名（带子命令时为 "CONFIG SET" 形式），未收录的命令按写命令处理。
+func CommandCatalog() map[string]CommandInfo {
+	catalog := make(map[string]CommandInfo, len(readCommands)+len(dangerousCommands)+len(unsupportedCommands)+len(adminCommands))
+	for name := range readCommands {
+		catalog[name] = CommandInfo{Name: name, Category: CommandRead}
+	}
+	for name := range adminCommands {
+		if _, ok := catalog[name]; !ok {
+			catalog[name] = CommandInfo{Name: name, Category: CommandAdmin}
+		}
+	}
+	for name, reason := range dangerousCommands {
+		catalog[name] = CommandInfo{Name: name, Category: CommandDangerous, Reason: reason}
+	}
+	for name, reason := range unsupportedCommands {
+		catalog[name] = CommandInfo{Name: name, Category: CommandUnsupported, Reason: reason}
+	}
+	return catalog
+}
+
+// CommandReply 按 RESP 回复类型组织的命令结果，便于前端按类型展示。
+type CommandReply struct {
+	Type     string         `json:"type"`               // nil, string, integer, double, boolean, array, map, error
+	Value    interface{}    `json:"value,omitempty"`    // 标量回复的值
+	Elements []CommandReply `json:"elements,omitempty"` // array 的元素；map 按 key、value 交替排列
+}
+
+// newCommandReply 把 go-redis 解析出的回复转换为 CommandReply。
+func newCommandReply(v interface{}) CommandReply {
+	switch val := v.(type) {
+	case nil:
+		return CommandReply{Type: "nil"}
+	case string:
+		return CommandReply{Type: "string", Value: val}
+	case []byte:
+		return CommandReply{Type: "string", Value: string(val)}
+	case int64:
+		return CommandReply{Type: "integer", Value: val}
+	case float64:
+		return CommandReply{Type: "double", Value: val}
+	case bool:
+		return CommandReply{Type: "boolean", Value: val}
+	case error:
+		return CommandReply{Type: "error", Value: val.Error()}
+	case []interface{}:
+		elements := make([]CommandReply, len(val))
+		for i, item := range val {
+			elements[i] = newCommandReply(item)
+		}
+		return CommandReply{Type: "array", Elements: elements}
+	case map[interface{}]interface{}:
+		elements := make([]CommandReply, 0, len(val)*2)
+		for key, item := range val {
+			elements = append(elements, newCommandReply(key), newCommandReply(item))
+		}
+		return CommandReply{Type: "map", Elements: elements}
+	case map[string]interface{}:
+		elements := make([]CommandReply, 0, len(val)*2)
+		for key, item := range val {
+			elements = append(elements, CommandReply{Type: "string", Value: key}, newCommandReply(item))
+		}
+		return CommandReply{Type: "map", Elements: elements}
+	case map[string]string:
+		elements := make([]CommandReply, 0, len(val)*2)
+		for key, item := range val {
+			elements = append(elements, CommandReply{Type: "string", Value: key}, CommandReply{Type: "string", Value: item})
+		}
+		return CommandReply{Type: "map", Elements: elements}
+	}
+	return CommandReply{Type: "string", Value: formatCommandResult(v)}
+}
@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClassifyCommand(t *testing.T) {
+	cases := map[string]string{
+		"get":             CommandRead,
+		"OBJECT ENCODING": CommandRead,
+		"config get":      CommandRead,
+		"CONFIG SET":      CommandDangerous,
+		"flushall":        CommandDangerous,
+		"KEYS":            CommandDangerous,
+		"SET":             CommandWrite,
+		"CLIENT SETNAME":  CommandAdmin,
+		"subscribe":       CommandUnsupported,
+		"SELECT":          CommandUnsupported,
+	}
+	for input, want := range cases {
+		if got := ClassifyCommand(strings.Fields(input)).Category; got != want {
+			t.Errorf("ClassifyCommand(%q)=%s，期望 %s", input, got, want)
+		}
+	}
+	if info := ClassifyCommand([]string{"config", "set", "maxmemory", "1gb"}); info.Name != "CONFIG SET" || info.Reason == "" {
+		t.Fatalf("info = %+v", info)
+	}
+}
+
+func TestNewCommandReply(t *testing.T) {
+	reply := newCommandReply([]interface{}{"a", int64(2), nil, errors.New("WRONGTYPE"), []interface{}{1.5}})
+	if reply.Type != "array" || len(reply.Elements) != 5 {
+		t.Fatalf("reply = %+v", reply)
+	}
+	types := []string{"string", "integer", "nil", "error", "array"}
+	for i, want := range types {
+		if reply.Elements[i].Type != want {
+			t.Errorf("elements[%d].Type=%s，期望 %s", i, reply.Elements[i].Type, want)
+		}
+	}
+	if m := newCommandReply(map[interface{}]interface{}{"k": "v"}); m.Type != "map" || len(m.Elements) != 2 || m.Elements[1].Value != "v" {
+		t.Fatalf("map reply = %+v", m)
+	}
+}
@@ -79,6 +79,7 @@ type RedisClient interface {
 
 	// Command execution
 	ExecuteCommand(args []string) (interface{}, error)
+	RunCommand(args []string) (*CommandReply, error)
 
 	// Server information
 	GetServerInfo() (map[string]string, error)
@@ -654,6 +654,34 @@ func (r *RedisClientImpl) ExecuteCommand(args []string) (interface{}, error) {
 	return formatCommandResult(result), nil
 }
 
+// RunCommand executes a raw command and keeps the reply type; a nil reply is not treated as an error
+func (r *RedisClientImpl) RunCommand(args []string) (*CommandReply, error) {
+	if r.client == nil {
+		return nil, fmt.Errorf("Redis 客户端未连接")
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("命令不能为空")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmdArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		cmdArgs[i] = arg
+	}
+
+	result, err := r.client.Do(ctx, cmdArgs...).Result()
+	if err == redis.Nil {
+		return &CommandReply{Type: "nil"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	reply := newCommandReply(result)
+	return &reply, nil
+}
+
 // formatCommandResult formats the command result for display
 func formatCommandResult(result interface{}) interface{} {
 	switch v := result.(type) {
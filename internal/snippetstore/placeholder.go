@@ -0,0 +1,128 @@
+package snippetstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Variable 片段中的一个占位符。写法为 ${name} 或带默认值的 ${name:default}，$${ 表示字面量 ${。
+type Variable struct {
+	Name       string `json:"name"`
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"hasDefault,omitempty"`
+}
+
+type placeholder struct {
+	start, end int // 在原文中的字节区间，含 ${ 与 }
+	escaped    bool
+	variable   Variable
+}
+
+// scanPlaceholders 按出现顺序扫描占位符；名称不合法或未闭合的 ${ 按普通文本处理。
+func scanPlaceholders(text string) []placeholder {
+	var result []placeholder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '$' {
+			continue
+		}
+		if strings.HasPrefix(text[i:], "$${") {
+			result = append(result, placeholder{start: i, end: i + 1, escaped: true})
+			i += 2
+			continue
+		}
+		if !strings.HasPrefix(text[i:], "${") {
+			continue
+		}
+		closing := strings.IndexByte(text[i+2:], '}')
+		if closing < 0 {
+			break
+		}
+		body := text[i+2 : i+2+closing]
+		v := Variable{Name: body}
+		if idx := strings.IndexByte(body, ':'); idx >= 0 {
+			v = Variable{Name: body[:idx], Default: body[idx+1:], HasDefault: true}
+		}
+		v.Name = strings.TrimSpace(v.Name)
+		if !validVariableName(v.Name) {
+			continue
+		}
+		end := i + 2 + closing + 1
+		result = append(result, placeholder{start: i, end: end, variable: v})
+		i = end - 1
+	}
+	return result
+}
+
+func validVariableName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '.' || r == '-'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Variables 返回片段中的占位符，同名只保留第一次出现（默认值也以第一次为准）。
+func Variables(text string) []Variable {
+	var vars []Variable
+	seen := map[string]bool{}
+	for _, p := range scanPlaceholders(text) {
+		if p.escaped || seen[p.variable.Name] {
+			continue
+		}
+		seen[p.variable.Name] = true
+		vars = append(vars, p.variable)
+	}
+	return vars
+}
+
+// Render 用 values 替换占位符，未提供的变量使用默认值；既未提供又无默认值的变量报错。
+// 替换为原样文本，字符串字面量所需的引号应写在片段中，如 WHERE name = '${name}'。
+func Render(text string, values map[string]string) (string, error) {
+	defaults := map[string]Variable{}
+	for _, v := range Variables(text) {
+		defaults[v.Name] = v
+	}
+	var missing []string
+	var b strings.Builder
+	last := 0
+	for _, p := range scanPlaceholders(text) {
+		b.WriteString(text[last:p.start])
+		last = p.end
+		if p.escaped {
+			// $${ 去掉一个 $，其余原样保留
+			continue
+		}
+		if value, ok := values[p.variable.Name]; ok {
+			b.WriteString(value)
+			continue
+		}
+		if v := defaults[p.variable.Name]; v.HasDefault {
+			b.WriteString(v.Default)
+			continue
+		}
+		if !containsString(missing, p.variable.Name) {
+			missing = append(missing, p.variable.Name)
+		}
+	}
+	b.WriteString(text[last:])
+	if len(missing) > 0 {
+		return "", fmt.Errorf("缺少变量：%s", strings.Join(missing, "、"))
+	}
+	return b.String(), nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
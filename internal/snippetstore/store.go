@@ -0,0 +1,325 @@
+// Package snippetstore 保存命名的 SQL 片段，按文件夹组织，写入 ~/.gonavi/snippets.json。
+// 片段中的 ${name} 占位符在执行时由用户填写后替换（见 Render）；片段文件可整体或部分导出，在其他机器上导入共享。
+package snippetstore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const storeVersion = 1
+
+// Snippet 一条命名的 SQL 片段。Variables 由 SQL 中的占位符解析得到，保存时重新计算。
+type Snippet struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Folder      string     `json:"folder,omitempty"` // 以 / 分隔的文件夹路径，空表示根目录
+	SQL         string     `json:"sql"`
+	Description string     `json:"description,omitempty"`
+	DBType      string     `json:"dbType,omitempty"` // 适用的数据源类型，空表示通用
+	CreatedAt   int64      `json:"createdAt"`        // Unix milli
+	UpdatedAt   int64      `json:"updatedAt"`
+	Variables   []Variable `json:"variables,omitempty"`
+}
+
+// ImportResult 导入结果：Added 为新增条数，Replaced 为按 ID 覆盖的条数，Renamed 为 ID 冲突时另存的条数。
+type ImportResult struct {
+	Added    int `json:"added"`
+	Replaced int `json:"replaced"`
+	Renamed  int `json:"renamed"`
+}
+
+type storeFile struct {
+	Version  int       `json:"version"`
+	Snippets []Snippet `json:"snippets"`
+}
+
+// Store 片段存储，方法可并发调用。
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DefaultPath 返回默认存储路径 ~/.gonavi/snippets.json。
+func DefaultPath() string {
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".gonavi", "snippets.json")
+	}
+	return filepath.Join(os.TempDir(), "gonavi-snippets.json")
+}
+
+// New 创建存储；path 为空时使用 DefaultPath。不会立即读写磁盘。
+func New(path string) *Store {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{path: path}
+}
+
+// NormalizeFolder 去掉多余的分隔符与空白，统一使用 /。
+func NormalizeFolder(folder string) string {
+	parts := strings.FieldsFunc(strings.ReplaceAll(folder, "\\", "/"), func(r rune) bool { return r == '/' })
+	kept := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, "/")
+}
+
+// List 返回全部片段，按文件夹、名称排序。
+func (s *Store) List() ([]Snippet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := readFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	sortSnippets(file.Snippets)
+	return file.Snippets, nil
+}
+
+// Get 按 ID 读取片段。
+func (s *Store) Get(id string) (Snippet, error) {
+	snippets, err := s.List()
+	if err != nil {
+		return Snippet{}, err
+	}
+	for _, snippet := range snippets {
+		if snippet.ID == id {
+			return snippet, nil
+		}
+	}
+	return Snippet{}, fmt.Errorf("片段 %s 不存在", id)
+}
+
+// Save 新增或按 ID 覆盖一条片段。
+func (s *Store) Save(snippet Snippet) (Snippet, error) {
+	snippet.Name = strings.TrimSpace(snippet.Name)
+	if snippet.Name == "" {
+		return snippet, errors.New("片段名称不能为空")
+	}
+	if strings.TrimSpace(snippet.SQL) == "" {
+		return snippet, errors.New("片段内容不能为空")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := readFile(s.path)
+	if err != nil {
+		return snippet, err
+	}
+	now := time.Now().UnixMilli()
+	snippet.ID = strings.TrimSpace(snippet.ID)
+	snippet.Folder = NormalizeFolder(snippet.Folder)
+	snippet.Variables = Variables(snippet.SQL)
+	snippet.UpdatedAt = now
+	for i := range file.Snippets {
+		if file.Snippets[i].ID == snippet.ID && snippet.ID != "" {
+			snippet.CreatedAt = file.Snippets[i].CreatedAt
+			file.Snippets[i] = snippet
+			return snippet, writeFile(s.path, file)
+		}
+	}
+	if snippet.ID == "" {
+		if snippet.ID, err = newID(); err != nil {
+			return snippet, err
+		}
+	}
+	snippet.CreatedAt = now
+	file.Snippets = append(file.Snippets, snippet)
+	return snippet, writeFile(s.path, file)
+}
+
+// Delete 按 ID 删除片段。
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := readFile(s.path)
+	if err != nil {
+		return err
+	}
+	kept := file.Snippets[:0]
+	for _, snippet := range file.Snippets {
+		if snippet.ID != id {
+			kept = append(kept, snippet)
+		}
+	}
+	file.Snippets = kept
+	return writeFile(s.path, file)
+}
+
+// RenameFolder 重命名文件夹（含其子文件夹），返回移动的片段数。to 为空表示移到根目录。
+func (s *Store) RenameFolder(from string, to string) (int, error) {
+	from, to = NormalizeFolder(from), NormalizeFolder(to)
+	if from == "" {
+		return 0, errors.New("不能重命名根目录")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := readFile(s.path)
+	if err != nil {
+		return 0, err
+	}
+	moved := 0
+	now := time.Now().UnixMilli()
+	for i := range file.Snippets {
+		folder := file.Snippets[i].Folder
+		if folder != from && !strings.HasPrefix(folder, from+"/") {
+			continue
+		}
+		file.Snippets[i].Folder = NormalizeFolder(to + folder[len(from):])
+		file.Snippets[i].UpdatedAt = now
+		moved++
+	}
+	if moved == 0 {
+		return 0, nil
+	}
+	return moved, writeFile(s.path, file)
+}
+
+// Export 将指定片段（ids 为空时全部）写入 path，格式与存储文件相同，返回导出条数。
+func (s *Store) Export(path string, ids []string) (int, error) {
+	snippets, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) > 0 {
+		wanted := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			wanted[id] = true
+		}
+		selected := snippets[:0]
+		for _, snippet := range snippets {
+			if wanted[snippet.ID] {
+				selected = append(selected, snippet)
+			}
+		}
+		snippets = selected
+	}
+	return len(snippets), writeFile(path, storeFile{Snippets: snippets})
+}
+
+// Import 从导出文件合并片段。ID 相同时 overwrite 为 true 则覆盖，否则以新 ID 另存一份。
+func (s *Store) Import(path string, overwrite bool) (ImportResult, error) {
+	var result ImportResult
+	incoming, err := readFile(path)
+	if err != nil {
+		return result, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := readFile(s.path)
+	if err != nil {
+		return result, err
+	}
+	index := make(map[string]int, len(file.Snippets))
+	for i, snippet := range file.Snippets {
+		index[snippet.ID] = i
+	}
+	now := time.Now().UnixMilli()
+	for _, snippet := range incoming.Snippets {
+		if strings.TrimSpace(snippet.Name) == "" || strings.TrimSpace(snippet.SQL) == "" {
+			continue
+		}
+		snippet.Folder = NormalizeFolder(snippet.Folder)
+		snippet.Variables = Variables(snippet.SQL)
+		if snippet.CreatedAt == 0 {
+			snippet.CreatedAt = now
+		}
+		if snippet.UpdatedAt == 0 {
+			snippet.UpdatedAt = now
+		}
+		i, exists := index[snippet.ID]
+		switch {
+		case exists && overwrite:
+			file.Snippets[i] = snippet
+			result.Replaced++
+			continue
+		case exists:
+			result.Renamed++
+		default:
+			result.Added++
+		}
+		if exists || snippet.ID == "" {
+			if snippet.ID, err = newID(); err != nil {
+				return result, err
+			}
+		}
+		index[snippet.ID] = len(file.Snippets)
+		file.Snippets = append(file.Snippets, snippet)
+	}
+	return result, writeFile(s.path, file)
+}
+
+func sortSnippets(snippets []Snippet) {
+	sort.SliceStable(snippets, func(i, j int) bool {
+		if snippets[i].Folder != snippets[j].Folder {
+			return snippets[i].Folder < snippets[j].Folder
+		}
+		return strings.ToLower(snippets[i].Name) < strings.ToLower(snippets[j].Name)
+	})
+}
+
+func newID() (string, error) {
+	raw := make([]byte, 8)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("生成片段 ID 失败：%w", err)
+	}
+	return "snip-" + hex.EncodeToString(raw), nil
+}
+
+func readFile(path string) (storeFile, error) {
+	file := storeFile{Version: storeVersion}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return file, nil
+		}
+		return file, fmt.Errorf("读取片段文件失败：%w", err)
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return file, nil
+	}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return file, fmt.Errorf("解析片段文件失败：%w", err)
+	}
+	if file.Version > storeVersion {
+		return file, fmt.Errorf("片段文件版本 %d 高于当前支持的版本 %d", file.Version, storeVersion)
+	}
+	return file, nil
+}
+
+// writeFile 先写临时文件再改名，避免写入中断损坏已有片段。
+func writeFile(path string, file storeFile) error {
+	file.Version = storeVersion
+	if file.Snippets == nil {
+		file.Snippets = []Snippet{}
+	}
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("创建片段目录失败：%w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		return fmt.Errorf("写入片段文件失败：%w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("写入片段文件失败：%w", err)
+	}
+	return nil
+}
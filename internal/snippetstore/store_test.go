@@ -0,0 +1,77 @@
+package snippetstore
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestVariablesAndRender(t *testing.T) {
+	text := "SELECT * FROM ${table} WHERE status = '${status:paid}' AND id > ${ min_id } AND note = '$${literal}' AND total > ${min_id}"
+	want := []Variable{{Name: "table"}, {Name: "status", Default: "paid", HasDefault: true}, {Name: "min_id"}}
+	if got := Variables(text); !reflect.DeepEqual(got, want) {
+		t.Fatalf("variables = %#v", got)
+	}
+	rendered, err := Render(text, map[string]string{"table": "orders", "min_id": "10"})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if rendered != "SELECT * FROM orders WHERE status = 'paid' AND id > 10 AND note = '${literal}' AND total > 10" {
+		t.Fatalf("rendered = %q", rendered)
+	}
+	if _, err := Render(text, map[string]string{"table": "orders"}); err == nil || err.Error() != "缺少变量：min_id" {
+		t.Fatalf("expected missing variable error, got %v", err)
+	}
+	// 非法名称与未闭合的占位符按普通文本处理
+	if got := Variables("SELECT '${1x}', '${open"); len(got) != 0 {
+		t.Fatalf("variables = %#v", got)
+	}
+}
+
+func TestStoreSaveRenameExportImport(t *testing.T) {
+	dir := t.TempDir()
+	store := New(filepath.Join(dir, "snippets.json"))
+	first, err := store.Save(Snippet{Name: "Paid orders", Folder: "/reports//daily/", SQL: "SELECT * FROM orders WHERE day = '${day}'"})
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if first.ID == "" || first.Folder != "reports/daily" || len(first.Variables) != 1 {
+		t.Fatalf("saved = %#v", first)
+	}
+	if _, err := store.Save(Snippet{Name: "Users", Folder: "admin", SQL: "SELECT * FROM users"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if moved, err := store.RenameFolder("reports", "shared/reports"); err != nil || moved != 1 {
+		t.Fatalf("rename moved=%d err=%v", moved, err)
+	}
+	got, err := store.Get(first.ID)
+	if err != nil || got.Folder != "shared/reports/daily" || got.CreatedAt != first.CreatedAt {
+		t.Fatalf("get = %#v err=%v", got, err)
+	}
+
+	exportPath := filepath.Join(dir, "export.json")
+	if n, err := store.Export(exportPath, []string{first.ID}); err != nil || n != 1 {
+		t.Fatalf("export n=%d err=%v", n, err)
+	}
+	result, err := store.Import(exportPath, false)
+	if err != nil || result != (ImportResult{Renamed: 1}) {
+		t.Fatalf("import = %#v err=%v", result, err)
+	}
+	if result, _ = store.Import(exportPath, true); result != (ImportResult{Replaced: 1}) {
+		t.Fatalf("overwrite import = %#v", result)
+	}
+	other := New(filepath.Join(dir, "other.json"))
+	if result, _ = other.Import(exportPath, false); result != (ImportResult{Added: 1}) {
+		t.Fatalf("fresh import = %#v", result)
+	}
+	all, _ := store.List()
+	if len(all) != 3 {
+		t.Fatalf("list = %#v", all)
+	}
+	if err := store.Delete(first.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := store.Get(first.ID); err == nil {
+		t.Fatalf("expected deleted snippet to be missing")
+	}
+}
@@ -0,0 +1,271 @@
+package sqlstmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AffectedRows 把 UPDATE/DELETE 改写为等价范围的 SELECT 所需的片段，均为原文截取。
+// 只做词法层面的切分：表引用、JOIN 与 WHERE 条件原样搬到 SELECT 中，不验证语法。
+type AffectedRows struct {
+	Keyword    string // UPDATE 或 DELETE
+	With       string // 主语句前的 WITH 子句
+	From       string // SELECT 的 FROM 部分：目标表，以及 JOIN、USING、UPDATE ... FROM 引入的表
+	Where      string // WHERE 条件，不含 WHERE 关键字；为空表示影响全表
+	OrderLimit string // MySQL/SQLite 的 ORDER BY ... LIMIT n
+	Top        string // SQL Server 的 TOP (n) [PERCENT]
+
+	dialect dialect
+}
+
+// ErrNotUpdateOrDelete 语句不是 UPDATE 或 DELETE。
+var ErrNotUpdateOrDelete = errors.New("仅支持预览 UPDATE 与 DELETE 语句")
+
+// dmlClauseWords UPDATE/DELETE 顶层子句关键字，用于确定各片段的边界。
+var dmlClauseWords = map[string]struct{}{
+	"SET": {}, "FROM": {}, "USING": {}, "WHERE": {}, "ORDER": {}, "LIMIT": {}, "RETURNING": {}, "OUTPUT": {}, "OPTION": {},
+}
+
+// ParseAffectedRows 解析 query 中的第一条语句。
+func ParseAffectedRows(dbType string, query string) (AffectedRows, error) {
+	d := newDialect(dbType)
+	runes := []rune(query)
+	tokens := lex(d, query)
+	for len(tokens) > 0 && tokens[0].kind == tokenPunct && tokens[0].text == ";" {
+		tokens = tokens[1:]
+	}
+	for i, tok := range tokens {
+		if tok.kind == tokenPunct && tok.text == ";" && tok.depth == 0 {
+			tokens = tokens[:i]
+			break
+		}
+	}
+	if len(tokens) == 0 || tokens[0].kind != tokenWord {
+		return AffectedRows{}, ErrNotUpdateOrDelete
+	}
+	text := func(from, to int) string {
+		return strings.TrimSpace(string(runes[from:to]))
+	}
+
+	result := AffectedRows{dialect: d}
+	head := 0
+	if tokens[0].text == "WITH" {
+		head = -1
+		for i, tok := range tokens {
+			if i > 0 && tok.kind == tokenWord && tok.depth == 0 && (tok.text == "UPDATE" || tok.text == "DELETE") {
+				head = i
+				break
+			}
+		}
+		if head < 0 {
+			return AffectedRows{}, ErrNotUpdateOrDelete
+		}
+		result.With = text(tokens[0].start, tokens[head].start)
+	}
+	result.Keyword = tokens[head].text
+	if result.Keyword != "UPDATE" && result.Keyword != "DELETE" {
+		return AffectedRows{}, ErrNotUpdateOrDelete
+	}
+
+	rest := tokens[head+1:]
+	// 跳过 MySQL 的修饰词与 SQL Server 的 TOP (n)
+	for len(rest) > 0 && rest[0].kind == tokenWord {
+		switch rest[0].text {
+		case "LOW_PRIORITY", "QUICK", "IGNORE":
+			rest = rest[1:]
+			continue
+		case "TOP":
+			end := 2 // 旧写法 TOP n
+			if len(rest) > 1 && rest[1].text == "(" {
+				for end < len(rest) && rest[end].depth > 0 {
+					end++
+				}
+				end++ // 右括号
+			}
+			end = min(end, len(rest))
+			if end < len(rest) && rest[end].text == "PERCENT" {
+				end++
+			}
+			result.Top = text(rest[0].start, rest[end-1].end)
+			rest = rest[end:]
+			continue
+		}
+		break
+	}
+	if len(rest) == 0 {
+		return AffectedRows{}, fmt.Errorf("%s 语句不完整", result.Keyword)
+	}
+
+	// 顶层子句关键字的位置；同名关键字只记第一次出现（SQL Server 的 DELETE FROM t FROM ... 除外）
+	clauses := make(map[string]int)
+	var fromPositions []int
+	for i, tok := range rest {
+		if tok.kind != tokenWord || tok.depth != 0 {
+			continue
+		}
+		if _, ok := dmlClauseWords[tok.text]; !ok {
+			continue
+		}
+		if tok.text == "FROM" {
+			fromPositions = append(fromPositions, i)
+		}
+		if _, seen := clauses[tok.text]; !seen {
+			clauses[tok.text] = i
+		}
+	}
+	// segment 返回从 start 起到下一个顶层子句关键字之前的原文
+	segment := func(start int) string {
+		if start >= len(rest) {
+			return ""
+		}
+		end := len(rest)
+		for i := start; i < len(rest); i++ {
+			if rest[i].kind != tokenWord || rest[i].depth != 0 {
+				continue
+			}
+			if _, ok := dmlClauseWords[rest[i].text]; ok {
+				end = i
+				break
+			}
+		}
+		if end == start {
+			return ""
+		}
+		return text(rest[start].start, rest[end-1].end)
+	}
+
+	if where, ok := clauses["WHERE"]; ok {
+		if where+2 < len(rest) && rest[where+1].text == "CURRENT" && rest[where+2].text == "OF" {
+			return AffectedRows{}, errors.New("基于游标的 WHERE CURRENT OF 语句无法预览")
+		}
+		result.Where = segment(where + 1)
+		if result.Where == "" {
+			return AffectedRows{}, errors.New("WHERE 条件为空")
+		}
+	}
+	orderLimit := -1
+	for _, word := range []string{"ORDER", "LIMIT"} {
+		if pos, ok := clauses[word]; ok && (orderLimit < 0 || pos < orderLimit) {
+			orderLimit = pos
+		}
+	}
+	if orderLimit >= 0 {
+		end := len(rest)
+		for _, word := range []string{"RETURNING", "OPTION"} {
+			if pos, ok := clauses[word]; ok && pos > orderLimit && pos < end {
+				end = pos
+			}
+		}
+		result.OrderLimit = text(rest[orderLimit].start, rest[end-1].end)
+	}
+
+	if result.Keyword == "UPDATE" {
+		set, ok := clauses["SET"]
+		if !ok {
+			return AffectedRows{}, errors.New("UPDATE 语句缺少 SET 子句")
+		}
+		target := segment(0)
+		from := -1
+		for _, pos := range fromPositions {
+			if pos > set {
+				from = pos
+				break
+			}
+		}
+		switch {
+		case from < 0:
+			result.From = target
+		case d.name == "sqlserver":
+			// T-SQL 的 UPDATE 目标通常是 FROM 中的别名，FROM 本身已包含目标表
+			result.From = segment(from + 1)
+		default:
+			// PostgreSQL/SQLite 的 UPDATE ... FROM 不重复列出目标表
+			result.From = target + ", " + segment(from+1)
+		}
+	} else {
+		using, hasUsing := clauses["USING"]
+		switch {
+		case rest[0].text == "FROM":
+			result.From = segment(1)
+			if len(fromPositions) > 1 {
+				// SQL Server：DELETE FROM t FROM t JOIN ...
+				result.From = segment(fromPositions[1] + 1)
+			}
+			if hasUsing {
+				if d.mysqlLike {
+					// MySQL：DELETE FROM t1 USING t1 JOIN t2 ...，USING 中是完整的表引用
+					result.From = segment(using + 1)
+				} else {
+					result.From += ", " + segment(using+1)
+				}
+			}
+		case len(fromPositions) > 0:
+			// MySQL/SQL Server 多表删除：DELETE t1 FROM t1 JOIN t2 ...
+			result.From = segment(fromPositions[0] + 1)
+		default:
+			// Oracle/SQL Server 允许省略 FROM：DELETE t WHERE ...
+			result.From = segment(0)
+		}
+	}
+	if strings.TrimSpace(result.From) == "" {
+		return AffectedRows{}, fmt.Errorf("无法识别 %s 语句的目标表", result.Keyword)
+	}
+	return result, nil
+}
+
+// Bounded 语句自身带有 LIMIT 或 TOP，实际影响行数不超过该上限。
+func (r AffectedRows) Bounded() bool {
+	return r.Top != "" || r.OrderLimit != ""
+}
+
+func (r AffectedRows) selectBody(columns string, top string) string {
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	if top != "" {
+		b.WriteString(top + " ")
+	}
+	b.WriteString(columns + " FROM " + r.From)
+	if r.Where != "" {
+		b.WriteString(" WHERE " + r.Where)
+	}
+	if r.OrderLimit != "" {
+		b.WriteString(" " + r.OrderLimit)
+	}
+	return b.String()
+}
+
+func (r AffectedRows) withPrefix(query string) string {
+	if r.With == "" {
+		return query
+	}
+	return r.With + " " + query
+}
+
+// SelectSQL 返回选出受影响行的查询；limit > 0 时按方言限制返回的行数。
+func (r AffectedRows) SelectSQL(limit int) string {
+	body := r.selectBody("*", r.Top)
+	if limit <= 0 {
+		return r.withPrefix(body)
+	}
+	switch {
+	case r.dialect.name == "sqlserver":
+		if r.Top == "" {
+			return r.withPrefix(r.selectBody("*", fmt.Sprintf("TOP (%d)", limit)))
+		}
+		return r.withPrefix(fmt.Sprintf("SELECT TOP (%d) * FROM (%s) affected_rows", limit, body))
+	case r.dialect.oracleLike:
+		return r.withPrefix(fmt.Sprintf("SELECT * FROM (%s) WHERE ROWNUM <= %d", body, limit))
+	case r.Bounded():
+		return r.withPrefix(fmt.Sprintf("SELECT * FROM (%s) affected_rows LIMIT %d", body, limit))
+	}
+	return r.withPrefix(fmt.Sprintf("%s LIMIT %d", body, limit))
+}
+
+// CountSQL 返回统计受影响行数的查询。
+func (r AffectedRows) CountSQL() string {
+	if r.Bounded() {
+		return r.withPrefix(fmt.Sprintf("SELECT COUNT(*) FROM (%s) affected_rows", r.selectBody("1", r.Top)))
+	}
+	return r.withPrefix(r.selectBody("COUNT(*)", ""))
+}
@@ -0,0 +1,77 @@
+package sqlstmt
+
+import "testing"
+
+func TestParseAffectedRows(t *testing.T) {
+	cases := []struct {
+		dbType string
+		query  string
+		sel    string
+		count  string
+	}{
+		{
+			"mysql", "UPDATE `orders` SET status = 'done' WHERE created_at < '2024-01-01';",
+			"SELECT * FROM `orders` WHERE created_at < '2024-01-01' LIMIT 50",
+			"SELECT COUNT(*) FROM `orders` WHERE created_at < '2024-01-01'",
+		},
+		{
+			"mysql", "/* cleanup */ DELETE LOW_PRIORITY FROM logs WHERE level = 'debug' ORDER BY id LIMIT 1000",
+			"SELECT * FROM (SELECT * FROM logs WHERE level = 'debug' ORDER BY id LIMIT 1000) affected_rows LIMIT 50",
+			"SELECT COUNT(*) FROM (SELECT 1 FROM logs WHERE level = 'debug' ORDER BY id LIMIT 1000) affected_rows",
+		},
+		{
+			"mysql", "UPDATE orders o JOIN users u ON u.id = o.user_id SET o.flag = 1 WHERE u.vip = 1",
+			"SELECT * FROM orders o JOIN users u ON u.id = o.user_id WHERE u.vip = 1 LIMIT 50",
+			"SELECT COUNT(*) FROM orders o JOIN users u ON u.id = o.user_id WHERE u.vip = 1",
+		},
+		{
+			"mysql", "DELETE o FROM orders o JOIN users u ON u.id = o.user_id WHERE u.deleted = 1",
+			"SELECT * FROM orders o JOIN users u ON u.id = o.user_id WHERE u.deleted = 1 LIMIT 50",
+			"SELECT COUNT(*) FROM orders o JOIN users u ON u.id = o.user_id WHERE u.deleted = 1",
+		},
+		{
+			"postgres", "WITH old AS (SELECT id FROM users WHERE last_login < now() - interval '1 year') UPDATE users SET active = false FROM old WHERE users.id = old.id RETURNING users.id",
+			"WITH old AS (SELECT id FROM users WHERE last_login < now() - interval '1 year') SELECT * FROM users, old WHERE users.id = old.id LIMIT 50",
+			"WITH old AS (SELECT id FROM users WHERE last_login < now() - interval '1 year') SELECT COUNT(*) FROM users, old WHERE users.id = old.id",
+		},
+		{
+			"postgres", `DELETE FROM "public"."t" USING s WHERE t.id = s.id AND s.note = 'where'`,
+			`SELECT * FROM "public"."t", s WHERE t.id = s.id AND s.note = 'where' LIMIT 50`,
+			`SELECT COUNT(*) FROM "public"."t", s WHERE t.id = s.id AND s.note = 'where'`,
+		},
+		{
+			"sqlserver", "UPDATE TOP (10) [dbo].[t] SET a = 1 OUTPUT inserted.id WHERE b = 2",
+			"SELECT TOP (50) * FROM (SELECT TOP (10) * FROM [dbo].[t] WHERE b = 2) affected_rows",
+			"SELECT COUNT(*) FROM (SELECT TOP (10) 1 FROM [dbo].[t] WHERE b = 2) affected_rows",
+		},
+		{
+			"sqlserver", "UPDATE o SET o.flag = 1 FROM orders o JOIN users u ON u.id = o.user_id",
+			"SELECT TOP (50) * FROM orders o JOIN users u ON u.id = o.user_id",
+			"SELECT COUNT(*) FROM orders o JOIN users u ON u.id = o.user_id",
+		},
+		{
+			"oracle", `DELETE "T" WHERE ID > 10`,
+			`SELECT * FROM (SELECT * FROM "T" WHERE ID > 10) WHERE ROWNUM <= 50`,
+			`SELECT COUNT(*) FROM "T" WHERE ID > 10`,
+		},
+	}
+	for _, c := range cases {
+		got, err := ParseAffectedRows(c.dbType, c.query)
+		if err != nil {
+			t.Errorf("%s: %v", c.query, err)
+			continue
+		}
+		if sel := got.SelectSQL(50); sel != c.sel {
+			t.Errorf("%s\nselect = %s", c.query, sel)
+		}
+		if count := got.CountSQL(); count != c.count {
+			t.Errorf("%s\ncount = %s", c.query, count)
+		}
+	}
+
+	for _, query := range []string{"SELECT 1", "INSERT INTO t VALUES (1)", "UPDATE t SET a = 1 WHERE CURRENT OF c", "UPDATE t"} {
+		if _, err := ParseAffectedRows("postgres", query); err == nil {
+			t.Errorf("%s: expected error", query)
+		}
+	}
+}
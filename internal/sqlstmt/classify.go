@@ -0,0 +1,245 @@
+package sqlstmt
+
+// Kind 语句类别。
+type Kind int
+
+const (
+	KindOther       Kind = iota
+	KindQuery            // SELECT、WITH ... SELECT、VALUES、TABLE 等查询
+	KindShow             // SHOW、DESCRIBE、EXPLAIN、PRAGMA 等元数据与执行计划查询
+	KindDML              // INSERT、UPDATE、DELETE、MERGE 等数据修改
+	KindDDL              // CREATE、ALTER、DROP、TRUNCATE 等结构修改
+	KindCall             // CALL、EXEC 存储过程调用
+	KindTransaction      // BEGIN、COMMIT、ROLLBACK 等事务控制
+	KindSession          // SET、USE 等会话设置
+)
+
+// Info 单条语句的分类结果。
+type Info struct {
+	Kind    Kind
+	Keyword string // 主语句关键字（大写）；WITH 语句为 CTE 之后的主语句
+	// ReturnsRows 语句是否返回结果集，决定走 Query 还是 Exec。
+	ReturnsRows bool
+}
+
+// withMainKeywords WITH 子句之后可能出现的主语句关键字。
+var withMainKeywords = map[string]struct{}{
+	"SELECT": {}, "VALUES": {}, "TABLE": {}, "INSERT": {}, "UPDATE": {}, "DELETE": {}, "MERGE": {},
+}
+
+var beginTransactionWords = map[string]struct{}{
+	"TRANSACTION": {}, "TRAN": {}, "WORK": {}, "DEFERRED": {}, "IMMEDIATE": {}, "EXCLUSIVE": {},
+	"ISOLATION": {}, "READ": {}, "DISTRIBUTED": {},
+}
+
+// Classify 对 query 中的第一条语句分类。前导注释、分号与括号（如 (SELECT ...) UNION (SELECT ...)）会被跳过；
+// 无法识别时返回 KindOther，ReturnsRows 为 false。
+func Classify(dbType string, query string) Info {
+	d := newDialect(dbType)
+	tokens := lex(d, query)
+	for len(tokens) > 0 && tokens[0].kind == tokenPunct && (tokens[0].text == ";" || tokens[0].text == "(") {
+		tokens = tokens[1:]
+	}
+	for i, tok := range tokens {
+		if tok.kind == tokenPunct && tok.text == ";" && tok.depth == 0 {
+			tokens = tokens[:i]
+			break
+		}
+	}
+	if len(tokens) == 0 || tokens[0].kind != tokenWord {
+		return Info{}
+	}
+
+	head := tokens[0]
+	rest := tokens[1:]
+	if head.text == "WITH" {
+		for i, tok := range rest {
+			if tok.kind != tokenWord || tok.depth != head.depth {
+				continue
+			}
+			if _, ok := withMainKeywords[tok.text]; ok {
+				head, rest = tok, rest[i+1:]
+				break
+			}
+		}
+		if head.text == "WITH" {
+			return Info{Keyword: "WITH"}
+		}
+	}
+
+	info := Info{Keyword: head.text}
+	switch head.text {
+	case "SELECT", "VALUES", "TABLE":
+		info.Kind, info.ReturnsRows = KindQuery, true
+	case "FROM":
+		// DuckDB 允许省略 SELECT 的 FROM 开头查询
+		if d.name == "duckdb" {
+			info.Kind, info.ReturnsRows = KindQuery, true
+		}
+	case "FETCH":
+		// PostgreSQL 游标取数
+		if d.pgLike {
+			info.Kind, info.ReturnsRows = KindQuery, true
+		}
+	case "INSERT", "UPDATE", "DELETE", "MERGE", "REPLACE", "UPSERT":
+		info.Kind = KindDML
+		info.ReturnsRows = dmlReturnsRows(d, rest, head.depth)
+	case "SHOW", "DESCRIBE", "DESC", "HELP", "SUMMARIZE":
+		info.Kind, info.ReturnsRows = KindShow, true
+	case "EXPLAIN":
+		// Oracle/达梦的 EXPLAIN PLAN FOR 只把计划写入 PLAN_TABLE
+		info.Kind, info.ReturnsRows = KindShow, !d.oracleLike
+	case "EXISTS":
+		if d.name == "clickhouse" {
+			info.Kind, info.ReturnsRows = KindShow, true
+		}
+	case "PRAGMA":
+		info.Kind, info.ReturnsRows = KindShow, true
+		if containsPunct(rest, "=") {
+			info.Kind, info.ReturnsRows = KindSession, false
+		}
+	case "CHECK", "CHECKSUM", "ANALYZE", "OPTIMIZE", "REPAIR":
+		// MySQL 的表维护语句以结果集返回每张表的处理状态
+		info.ReturnsRows = d.mysqlLike && len(rest) > 0 && (rest[0].text == "TABLE" || rest[0].text == "TABLES")
+	case "CALL":
+		info.Kind, info.ReturnsRows = KindCall, !d.oracleLike
+	case "EXEC", "EXECUTE":
+		info.Kind, info.ReturnsRows = KindCall, d.name == "sqlserver" || d.pgLike
+	case "BEGIN":
+		// Oracle/达梦的 BEGIN 与 T-SQL 的 BEGIN ... END 是语句块而不是事务
+		if d.oracleLike {
+			break
+		}
+		if len(rest) > 0 {
+			if _, ok := beginTransactionWords[rest[0].text]; !ok {
+				break
+			}
+		}
+		info.Kind = KindTransaction
+	case "START", "COMMIT", "ROLLBACK", "SAVEPOINT", "RELEASE", "END", "ABORT":
+		info.Kind = KindTransaction
+	case "SET", "USE", "RESET":
+		info.Kind = KindSession
+	case "CREATE", "ALTER", "DROP", "TRUNCATE", "RENAME", "COMMENT", "GRANT", "REVOKE":
+		info.Kind = KindDDL
+	}
+	return info
+}
+
+// dmlReturnsRows 判断数据修改语句是否带返回结果集的子句：PostgreSQL/SQLite/MariaDB 的 RETURNING，
+// SQL Server 的 OUTPUT（OUTPUT ... INTO 写入表变量时不返回）。Oracle 的 RETURNING ... INTO 只给绑定变量赋值。
+func dmlReturnsRows(d dialect, tokens []token, depth int) bool {
+	output := false
+	for _, tok := range tokens {
+		if tok.kind != tokenWord || tok.depth != depth {
+			continue
+		}
+		switch tok.text {
+		case "RETURNING":
+			if !d.oracleLike {
+				return true
+			}
+		case "OUTPUT":
+			if d.name == "sqlserver" {
+				output = true
+			}
+		case "INTO":
+			if output {
+				return false
+			}
+		}
+	}
+	return output
+}
+
+func containsPunct(tokens []token, text string) bool {
+	for _, tok := range tokens {
+		if tok.kind == tokenPunct && tok.text == text {
+			return true
+		}
+	}
+	return false
+}
+
+// MainKeywordEnd 返回第一条语句主关键字（WITH 语句为 CTE 之后的主语句）结束处的字节偏移，
+// 用于在关键字之后插入优化器提示；找不到时返回 -1。
+func MainKeywordEnd(dbType string, query string) int {
+	d := newDialect(dbType)
+	tokens := lex(d, query)
+	for len(tokens) > 0 && tokens[0].kind == tokenPunct && tokens[0].text == "(" {
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 || tokens[0].kind != tokenWord {
+		return -1
+	}
+	head := tokens[0]
+	if head.text == "WITH" {
+		for _, tok := range tokens[1:] {
+			if tok.kind == tokenPunct && tok.text == ";" && tok.depth == 0 {
+				break
+			}
+			if tok.kind != tokenWord || tok.depth != head.depth {
+				continue
+			}
+			if _, ok := withMainKeywords[tok.text]; ok {
+				head = tok
+				break
+			}
+		}
+		if head.text == "WITH" {
+			return -1
+		}
+	}
+	return len(string([]rune(query)[:head.end]))
+}
+
+// blockRoutineWords CREATE 之后表示带过程体的对象。
+var blockRoutineWords = map[string]struct{}{
+	"PROCEDURE": {}, "FUNCTION": {}, "TRIGGER": {}, "PACKAGE": {}, "EVENT": {},
+}
+
+// IsBlock 判断 query 的第一条语句是否为过程块：Oracle/达梦的匿名块（DECLARE/BEGIN ... END;）、
+// T-SQL 的 BEGIN ... END，以及过程体含 BEGIN ... END 的 CREATE PROCEDURE/FUNCTION/TRIGGER/EVENT 与 PL/SQL 包、类型体。
+// 这类语句内部含分号，结尾的 END; 属于语句本身，不能当作分隔符去掉。
+func IsBlock(dbType string, query string) bool {
+	d := newDialect(dbType)
+	tokens := lex(d, query)
+	if len(tokens) == 0 || tokens[0].kind != tokenWord {
+		return false
+	}
+	switch tokens[0].text {
+	case "DECLARE":
+		return d.oracleLike
+	case "BEGIN":
+		return Classify(dbType, query).Kind != KindTransaction
+	case "CREATE":
+	default:
+		return false
+	}
+	routine := false
+	for i, tok := range tokens[1:] {
+		if tok.kind != tokenWord || tok.depth != 0 {
+			continue
+		}
+		if !routine {
+			if _, ok := blockRoutineWords[tok.text]; ok {
+				routine = true
+				if tok.text == "PACKAGE" && d.oracleLike {
+					return true
+				}
+				continue
+			}
+			if tok.text == "TYPE" && i+2 < len(tokens) && tokens[i+2].text == "BODY" && d.oracleLike {
+				return true
+			}
+			if tok.text == "AS" || tok.text == "IS" || tok.text == "ON" {
+				return false
+			}
+			continue
+		}
+		if tok.text == "BEGIN" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package sqlstmt
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		dbType string
+		query  string
+		kind   Kind
+		rows   bool
+	}{
+		{"mysql", "/* report */\n-- daily\nSELECT * FROM t", KindQuery, true},
+		{"mysql", "# note\n  select 1;", KindQuery, true},
+		{"mysql", "(SELECT 1) UNION (SELECT 2)", KindQuery, true},
+		{"mysql", "WITH recent AS (SELECT * FROM orders) SELECT * FROM recent", KindQuery, true},
+		{"mysql", "WITH src AS (SELECT 1 AS id) UPDATE t JOIN src USING (id) SET a = 1", KindDML, false},
+		{"mysql", "CALL refresh_stats(1)", KindCall, true},
+		{"mysql", "CHECK TABLE orders", KindOther, true},
+		{"mysql", "SET @a = 'SELECT'; SELECT @a", KindSession, false},
+		{"mysql", "INSERT INTO t (a) VALUES ('x;y')", KindDML, false},
+		{"postgres", "VALUES (1), (2)", KindQuery, true},
+		{"postgres", "INSERT INTO t (a) VALUES ($$ RETURNING $$) RETURNING id", KindDML, true},
+		{"postgres", "UPDATE t SET a = (SELECT 1) WHERE b = 'returning'", KindDML, false},
+		{"postgres", "WITH gone AS (DELETE FROM t RETURNING *) SELECT count(*) FROM gone", KindQuery, true},
+		{"postgres", "EXPLAIN ANALYZE SELECT 1", KindShow, true},
+		{"oracle", "EXPLAIN PLAN FOR SELECT * FROM dual", KindShow, false},
+		{"oracle", "BEGIN dbms_stats.gather_table_stats('A', 'T'); END;", KindOther, false},
+		{"oracle", "UPDATE t SET a = 1 RETURNING id INTO :id", KindDML, false},
+		{"sqlserver", ";WITH c AS (SELECT 1 AS id) SELECT * FROM c", KindQuery, true},
+		{"sqlserver", "INSERT INTO [t] OUTPUT inserted.id VALUES (1)", KindDML, true},
+		{"sqlserver", "DELETE FROM t OUTPUT deleted.id INTO @ids WHERE a = 1", KindDML, false},
+		{"sqlserver", "EXEC sp_who", KindCall, true},
+		{"sqlserver", "BEGIN TRAN", KindTransaction, false},
+		{"sqlite", "PRAGMA table_info(t)", KindShow, true},
+		{"sqlite", "PRAGMA journal_mode = WAL", KindSession, false},
+		{"duckdb", "FROM orders LIMIT 10", KindQuery, true},
+		{"postgres", "CREATE TABLE t AS SELECT 1", KindDDL, false},
+		{"mysql", "-- only a comment", KindOther, false},
+	}
+	for _, c := range cases {
+		got := Classify(c.dbType, c.query)
+		if got.Kind != c.kind || got.ReturnsRows != c.rows {
+			t.Errorf("Classify(%s, %q) = %+v, want kind %d rows %v", c.dbType, c.query, got, c.kind, c.rows)
+		}
+	}
+}
+
+func TestIsBlock(t *testing.T) {
+	cases := []struct {
+		dbType string
+		query  string
+		want   bool
+	}{
+		{"oracle", "BEGIN dbms_stats.gather_table_stats('A', 'T'); END;", true},
+		{"oracle", "DECLARE n NUMBER; BEGIN n := 1; END;", true},
+		{"oracle", "CREATE OR REPLACE TRIGGER trg BEFORE INSERT ON t FOR EACH ROW BEGIN :new.id := 1; END;", true},
+		{"oracle", "CREATE OR REPLACE PACKAGE pkg AS PROCEDURE p; END pkg;", true},
+		{"mysql", "CREATE DEFINER=`root`@`%` PROCEDURE p() BEGIN SELECT 1; END;", true},
+		{"mysql", "CREATE TRIGGER trg BEFORE INSERT ON t FOR EACH ROW SET NEW.a = 1;", false},
+		{"mysql", "BEGIN;", false},
+		{"sqlserver", "DECLARE @n int;", false},
+		{"postgres", "CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql;", false},
+		{"postgres", "CREATE TABLE t (event text, function text);", false},
+		{"mysql", "CREATE VIEW v AS SELECT 1 AS begin_at;", false},
+	}
+	for _, c := range cases {
+		if got := IsBlock(c.dbType, c.query); got != c.want {
+			t.Errorf("IsBlock(%s, %q) = %v, want %v", c.dbType, c.query, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package sqlstmt
+
+import (
+	"strings"
+	"unicode"
+)
+
+// dialect 拆分与分类时需要区分的方言特性。
+type dialect struct {
+	name       string
+	mysqlLike  bool // 反引号标识符、# 注释、字符串反斜杠转义、DELIMITER
+	pgLike     bool // 美元引号
+	oracleLike bool // PL/SQL 块与 “/” 结束行
+}
+
+func newDialect(dbType string) dialect {
+	name := strings.ToLower(strings.TrimSpace(dbType))
+	return dialect{
+		name:       name,
+		mysqlLike:  name == "mysql" || name == "mariadb" || name == "diros" || name == "sphinx" || name == "",
+		pgLike:     name == "postgres" || name == "kingbase" || name == "highgo" || name == "vastbase" || name == "duckdb",
+		oracleLike: name == "oracle" || name == "dameng",
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // 关键字或未加引号的标识符，text 为大写
+	tokenQuoted                  // 带引号标识符
+	tokenString                  // 字符串、美元引号串
+	tokenNumber
+	tokenPunct
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	depth int // 所在括号层级，括号本身记为外层
+	start int // 在语句中的起止位置（rune 下标），用于截取原文
+	end   int
+}
+
+// lex 将语句切分为记号，跳过空白与注释；只用于分类，字符串与标识符的内容不做还原。
+func lex(d dialect, sql string) []token {
+	tokens := make([]token, 0, 32)
+	runes := []rune(sql)
+	n := len(runes)
+	depth := 0
+	for i := 0; i < n; {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < n && runes[i+1] == '-', r == '#' && d.mysqlLike:
+			for i < n && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < n && runes[i+1] == '*':
+			i += 2
+			for i < n && !(runes[i] == '*' && i+1 < n && runes[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case r == '\'' || r == '"' || r == '`' || (r == '[' && d.name == "sqlserver"):
+			closing := r
+			if r == '[' {
+				closing = ']'
+			}
+			j := i + 1
+			for j < n {
+				if runes[j] == '\\' && d.mysqlLike && r != '`' && j+1 < n {
+					j += 2
+					continue
+				}
+				if runes[j] == closing {
+					if j+1 < n && runes[j+1] == closing {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			kind := tokenQuoted
+			if r == '\'' {
+				kind = tokenString
+			}
+			tokens = append(tokens, token{kind: kind, text: string(runes[i:min(j+1, n)]), depth: depth, start: i, end: min(j+1, n)})
+			i = j + 1
+		case r == '$' && d.pgLike && readDollarQuoteTag(runes[i:]) != "":
+			tag := []rune(readDollarQuoteTag(runes[i:]))
+			j := i + len(tag)
+			for j < n && !hasRunePrefix(runes, j, tag) {
+				j++
+			}
+			j = min(j+len(tag), n)
+			tokens = append(tokens, token{kind: tokenString, text: string(runes[i:j]), depth: depth, start: i, end: j})
+			i = j
+		case unicode.IsLetter(r) || r == '_' || r == '@':
+			j := i + 1
+			for j < n && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '$' || runes[j] == '@') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: strings.ToUpper(string(runes[i:j])), depth: depth, start: i, end: j})
+			i = j
+		case unicode.IsDigit(r):
+			j := i + 1
+			for j < n && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[i:j]), depth: depth, start: i, end: j})
+			i = j
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenPunct, text: "(", depth: depth, start: i, end: i + 1})
+			depth++
+			i++
+		case r == ')':
+			if depth > 0 {
+				depth--
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: ")", depth: depth, start: i, end: i + 1})
+			i++
+		default:
+			tokens = append(tokens, token{kind: tokenPunct, text: string(r), depth: depth, start: i, end: i + 1})
+			i++
+		}
+	}
+	return tokens
+}
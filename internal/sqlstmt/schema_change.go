@@ -0,0 +1,226 @@
+package sqlstmt
+
+import "strings"
+
+// SchemaChange 结构修改语句作用的一个已有对象。名称均已去除引号。
+type SchemaChange struct {
+	Action    string // ALTER、DROP、RENAME，或 REPLACE（CREATE OR REPLACE / CREATE OR ALTER VIEW）
+	Object    string // TABLE、VIEW 或 INDEX
+	Schema    string // 语句中写明的 schema/库前缀，未写明时为空
+	Name      string
+	NewSchema string // 重命名的目标 schema，未写明时为空
+	NewName   string // 重命名后的名称
+	Table     string // DROP INDEX ... ON table 中的表（MySQL、SQL Server）
+}
+
+// ParseSchemaChanges 识别 query 中的第一条语句是否修改、替换或删除已有的表、视图或索引，返回受影响的对象：
+// ALTER TABLE/VIEW（含 RENAME TO）、DROP TABLE/VIEW/INDEX、MySQL 的 RENAME TABLE 与 CREATE OR REPLACE VIEW。
+// DROP TABLE a, b 与 RENAME TABLE a TO b, c TO d 返回多个对象；其他语句返回 nil。
+func ParseSchemaChanges(dbType string, query string) []SchemaChange {
+	d := newDialect(dbType)
+	runes := []rune(query)
+	tokens := lex(d, query)
+	for len(tokens) > 0 && tokens[0].kind == tokenPunct && tokens[0].text == ";" {
+		tokens = tokens[1:]
+	}
+	for i, tok := range tokens {
+		if tok.kind == tokenPunct && tok.text == ";" && tok.depth == 0 {
+			tokens = tokens[:i]
+			break
+		}
+	}
+	if len(tokens) == 0 || tokens[0].kind != tokenWord {
+		return nil
+	}
+	p := &schemaChangeParser{tokens: tokens, runes: runes, pos: 1}
+
+	switch tokens[0].text {
+	case "ALTER":
+		p.skipWords("IGNORE", "ONLINE")
+		object := p.word()
+		if object != "TABLE" && object != "VIEW" {
+			return nil
+		}
+		p.pos++
+		p.skipIfExists()
+		p.skipWords("ONLY")
+		change := SchemaChange{Action: "ALTER", Object: object}
+		var ok bool
+		if change.Schema, change.Name, ok = p.name(); !ok {
+			return nil
+		}
+		if newSchema, newName, ok := p.renameTarget(d); ok {
+			change.Action, change.NewSchema, change.NewName = "RENAME", newSchema, newName
+		}
+		return []SchemaChange{change}
+	case "DROP":
+		p.skipWords("TEMPORARY")
+		object := p.word()
+		if object != "TABLE" && object != "VIEW" && object != "INDEX" {
+			return nil
+		}
+		p.pos++
+		p.skipWords("CONCURRENTLY")
+		p.skipIfExists()
+		var changes []SchemaChange
+		for {
+			schema, name, ok := p.name()
+			if !ok {
+				break
+			}
+			changes = append(changes, SchemaChange{Action: "DROP", Object: object, Schema: schema, Name: name})
+			if !p.punct(",") {
+				break
+			}
+			p.pos++
+		}
+		if object == "INDEX" && len(changes) == 1 && p.word() == "ON" {
+			p.pos++
+			if tableSchema, table, ok := p.name(); ok {
+				changes[0].Table = table
+				if changes[0].Schema == "" {
+					changes[0].Schema = tableSchema
+				}
+			}
+		}
+		return changes
+	case "RENAME":
+		if !d.mysqlLike || p.word() != "TABLE" {
+			return nil
+		}
+		p.pos++
+		var changes []SchemaChange
+		for {
+			schema, name, ok := p.name()
+			if !ok || p.word() != "TO" {
+				break
+			}
+			p.pos++
+			newSchema, newName, ok := p.name()
+			if !ok {
+				break
+			}
+			changes = append(changes, SchemaChange{Action: "RENAME", Object: "TABLE", Schema: schema, Name: name, NewSchema: newSchema, NewName: newName})
+			if !p.punct(",") {
+				break
+			}
+			p.pos++
+		}
+		return changes
+	case "CREATE":
+		if p.word() != "OR" {
+			return nil
+		}
+		p.pos++
+		if next := p.word(); next != "REPLACE" && next != "ALTER" {
+			return nil
+		}
+		// 跳过 MySQL 的 ALGORITHM=、DEFINER=、SQL SECURITY 等修饰，直到 VIEW
+		for p.pos < len(p.tokens) && p.word() != "VIEW" {
+			if p.word() == "TABLE" || p.word() == "AS" {
+				return nil
+			}
+			p.pos++
+		}
+		if p.pos >= len(p.tokens) {
+			return nil
+		}
+		p.pos++
+		schema, name, ok := p.name()
+		if !ok {
+			return nil
+		}
+		return []SchemaChange{{Action: "REPLACE", Object: "VIEW", Schema: schema, Name: name}}
+	}
+	return nil
+}
+
+type schemaChangeParser struct {
+	tokens []token
+	runes  []rune
+	pos    int
+}
+
+// word 返回当前位置的关键字，不是关键字时返回空串。
+func (p *schemaChangeParser) word() string {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenWord {
+		return p.tokens[p.pos].text
+	}
+	return ""
+}
+
+func (p *schemaChangeParser) punct(text string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenPunct && p.tokens[p.pos].text == text
+}
+
+func (p *schemaChangeParser) skipWords(words ...string) {
+	for _, w := range words {
+		if p.word() == w {
+			p.pos++
+		}
+	}
+}
+
+func (p *schemaChangeParser) skipIfExists() {
+	if p.word() == "IF" && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].text == "EXISTS" {
+		p.pos += 2
+	}
+}
+
+// name 读取 [catalog.][schema.]name 形式的对象名，返回最后两段。
+func (p *schemaChangeParser) name() (string, string, bool) {
+	var parts []string
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		if tok.kind != tokenWord && tok.kind != tokenQuoted {
+			break
+		}
+		parts = append(parts, p.identifier(tok))
+		p.pos++
+		if !p.punct(".") {
+			break
+		}
+		p.pos++
+	}
+	switch len(parts) {
+	case 0:
+		return "", "", false
+	case 1:
+		return "", parts[0], true
+	}
+	return parts[len(parts)-2], parts[len(parts)-1], true
+}
+
+// identifier 取记号原文，带引号的标识符去掉引号并还原转义的引号。
+func (p *schemaChangeParser) identifier(tok token) string {
+	text := string(p.runes[tok.start:tok.end])
+	if tok.kind != tokenQuoted || len(text) < 2 {
+		return text
+	}
+	closing := text[len(text)-1:]
+	return strings.ReplaceAll(text[1:len(text)-1], closing+closing, closing)
+}
+
+// renameTarget 在 ALTER TABLE/VIEW 的顶层子句中查找重命名对象本身的 RENAME [TO|AS] name；
+// RENAME COLUMN/INDEX/KEY/CONSTRAINT 与 PostgreSQL 省略 COLUMN 的 RENAME a TO b 不算。
+func (p *schemaChangeParser) renameTarget(d dialect) (string, string, bool) {
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		p.pos++
+		if tok.kind != tokenWord || tok.depth != 0 || tok.text != "RENAME" {
+			continue
+		}
+		switch p.word() {
+		case "COLUMN", "INDEX", "KEY", "CONSTRAINT":
+			continue
+		case "TO", "AS":
+			p.pos++
+		default:
+			if !d.mysqlLike {
+				continue
+			}
+		}
+		return p.name()
+	}
+	return "", "", false
+}
@@ -0,0 +1,40 @@
+package sqlstmt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSchemaChanges(t *testing.T) {
+	cases := []struct {
+		dbType string
+		query  string
+		want   []SchemaChange
+	}{
+		{"mysql", "ALTER TABLE `shop`.`orders` ADD COLUMN note varchar(20)", []SchemaChange{{Action: "ALTER", Object: "TABLE", Schema: "shop", Name: "orders"}}},
+		{"mysql", "alter table orders rename as orders_old", []SchemaChange{{Action: "RENAME", Object: "TABLE", Name: "orders", NewName: "orders_old"}}},
+		{"mysql", "ALTER TABLE orders RENAME COLUMN a TO b", []SchemaChange{{Action: "ALTER", Object: "TABLE", Name: "orders"}}},
+		{"postgres", `ALTER TABLE IF EXISTS ONLY public."Orders" RENAME a TO b`, []SchemaChange{{Action: "ALTER", Object: "TABLE", Schema: "public", Name: "Orders"}}},
+		{"postgres", `ALTER VIEW v_sales RENAME TO "v""sales"`, []SchemaChange{{Action: "RENAME", Object: "VIEW", Name: "v_sales", NewName: `v"sales`}}},
+		{"postgres", "-- cleanup\nDROP TABLE IF EXISTS a, s.b CASCADE;", []SchemaChange{
+			{Action: "DROP", Object: "TABLE", Name: "a"},
+			{Action: "DROP", Object: "TABLE", Schema: "s", Name: "b"},
+		}},
+		{"postgres", "DROP INDEX CONCURRENTLY idx_orders_user", []SchemaChange{{Action: "DROP", Object: "INDEX", Name: "idx_orders_user"}}},
+		{"sqlserver", "DROP INDEX [ix_user] ON [dbo].[orders]", []SchemaChange{{Action: "DROP", Object: "INDEX", Schema: "dbo", Name: "ix_user", Table: "orders"}}},
+		{"mysql", "RENAME TABLE a TO a_bak, b TO shop.b2", []SchemaChange{
+			{Action: "RENAME", Object: "TABLE", Name: "a", NewName: "a_bak"},
+			{Action: "RENAME", Object: "TABLE", Name: "b", NewSchema: "shop", NewName: "b2"},
+		}},
+		{"mysql", "CREATE OR REPLACE ALGORITHM=MERGE DEFINER=`root`@`%` SQL SECURITY DEFINER VIEW v AS SELECT 1", []SchemaChange{{Action: "REPLACE", Object: "VIEW", Name: "v"}}},
+		{"sqlserver", "CREATE OR ALTER VIEW dbo.v AS SELECT 1", []SchemaChange{{Action: "REPLACE", Object: "VIEW", Schema: "dbo", Name: "v"}}},
+		{"mysql", "CREATE TABLE t (id int)", nil},
+		{"mysql", "DROP DATABASE shop", nil},
+		{"mysql", "SELECT * FROM orders", nil},
+	}
+	for _, c := range cases {
+		if got := ParseSchemaChanges(c.dbType, c.query); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ParseSchemaChanges(%q, %q) = %+v; want %+v", c.dbType, c.query, got, c.want)
+		}
+	}
+}
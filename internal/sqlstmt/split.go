@@ -0,0 +1,236 @@
+// Package sqlstmt 提供轻量的 SQL 脚本拆分与语句分类：识别注释、字符串、带引号标识符以及各方言的分隔符写法，
+// 不做完整语法解析。
+package sqlstmt
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	delimiterPattern = regexp.MustCompile(`(?i)^\s*DELIMITER\s+(\S+)\s*$`)
+	goPattern        = regexp.MustCompile(`(?i)^\s*GO\s*$`)
+	plsqlPattern     = regexp.MustCompile(`(?is)^\s*(DECLARE\b|BEGIN\b|CREATE\s+(OR\s+REPLACE\s+)?(EDITIONABLE\s+|NONEDITIONABLE\s+)?(PROCEDURE|FUNCTION|TRIGGER|PACKAGE|TYPE\s+BODY)\b)`)
+)
+
+// Split 将脚本拆分为独立语句，识别字符串/标识符引号、注释、PostgreSQL 美元引号，
+// 以及 MySQL DELIMITER、SQL Server GO 批分隔符和 Oracle/达梦 PL/SQL 块的 “/” 结束行。
+// 返回的语句不含结尾分隔符（PL/SQL 块保留 END; 本身的分号），纯注释片段会被丢弃。
+func Split(script string, dbType string) []string {
+	located := SplitWithLines(script, dbType)
+	statements := make([]string, 0, len(located))
+	for _, stmt := range located {
+		statements = append(statements, stmt.SQL)
+	}
+	return statements
+}
+
+// Statement 拆分出的单条语句。
+type Statement struct {
+	SQL  string
+	Line int // 起始行号，从 1 开始
+}
+
+// SplitWithLines 与 Split 相同，同时返回每条语句在脚本中的起始行号。
+func SplitWithLines(script string, dbType string) []Statement {
+	lines := strings.SplitAfter(strings.ReplaceAll(script, "\r\n", "\n"), "\n")
+	// T-SQL 含 GO 时按批拆分，批内分号不拆，避免截断存储过程体
+	goBatches := false
+	if strings.ToLower(strings.TrimSpace(dbType)) == "sqlserver" {
+		for _, line := range lines {
+			if IsGoSeparator(line) {
+				goBatches = true
+				break
+			}
+		}
+	}
+
+	var statements []Statement
+	splitter := NewSplitter(dbType, goBatches, func(stmt string, line int) {
+		statements = append(statements, Statement{SQL: stmt, Line: line})
+	})
+	for _, line := range lines {
+		splitter.FeedLine(line)
+	}
+	splitter.Finish()
+	return statements
+}
+
+// Splitter 逐行增量拆分脚本，供 Split 与大文件流式执行共用。
+// 每拆出一条语句调用 emit，line 为语句起始行号（从 1 开始）。
+type Splitter struct {
+	dialect
+	goBatches bool
+	emit      func(stmt string, line int)
+
+	current    strings.Builder
+	delimiter  []rune
+	quote      rune // ' " ` ]
+	dollarTag  []rune
+	inBlock    bool // /* */
+	plsqlBlock bool
+	lineNo     int
+	stmtLine   int
+}
+
+// NewSplitter 创建增量拆分器；goBatches 为 true 时按 SQL Server 的 GO 行拆批，批内分号不拆。
+func NewSplitter(dbType string, goBatches bool, emit func(stmt string, line int)) *Splitter {
+	return &Splitter{
+		dialect:   newDialect(dbType),
+		goBatches: goBatches,
+		emit:      emit,
+		delimiter: []rune(";"),
+	}
+}
+
+func (s *Splitter) flush() {
+	stmt := strings.TrimSpace(s.current.String())
+	s.current.Reset()
+	s.plsqlBlock = false
+	line := s.stmtLine
+	s.stmtLine = 0
+	if stmt != "" && len(lex(s.dialect, stmt)) > 0 {
+		s.emit(stmt, line)
+	}
+}
+
+// IsGoSeparator 判断一行是否为 SQL Server 的 GO 批分隔行。
+func IsGoSeparator(line string) bool {
+	return goPattern.MatchString(strings.TrimSpace(line))
+}
+
+// hasRunePrefix 判断 runes[i:] 是否以 prefix 开头；避免对长行反复做 string 转换。
+func hasRunePrefix(runes []rune, i int, prefix []rune) bool {
+	if len(prefix) == 0 || i+len(prefix) > len(runes) {
+		return false
+	}
+	for j, r := range prefix {
+		if runes[i+j] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// FeedLine 输入一行（可带结尾换行符）。
+func (s *Splitter) FeedLine(line string) {
+	s.lineNo++
+	idle := s.quote == 0 && len(s.dollarTag) == 0 && !s.inBlock
+	if idle {
+		trimmedLine := strings.TrimSpace(line)
+		if s.mysqlLike {
+			if m := delimiterPattern.FindStringSubmatch(trimmedLine); m != nil {
+				s.flush()
+				s.delimiter = []rune(m[1])
+				return
+			}
+		}
+		if s.goBatches && goPattern.MatchString(trimmedLine) {
+			s.flush()
+			return
+		}
+		if s.oracleLike && trimmedLine == "/" {
+			s.flush()
+			return
+		}
+		if s.oracleLike && !s.plsqlBlock && strings.TrimSpace(s.current.String()) == "" && plsqlPattern.MatchString(line) {
+			s.plsqlBlock = true
+		}
+	}
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if s.stmtLine == 0 && !unicode.IsSpace(r) {
+			s.stmtLine = s.lineNo
+		}
+		switch {
+		case s.inBlock:
+			s.current.WriteRune(r)
+			if r == '*' && i+1 < len(runes) && runes[i+1] == '/' {
+				s.current.WriteRune('/')
+				i++
+				s.inBlock = false
+			}
+			continue
+		case len(s.dollarTag) > 0:
+			if r == '$' && hasRunePrefix(runes, i, s.dollarTag) {
+				s.current.WriteString(string(s.dollarTag))
+				i += len(s.dollarTag) - 1
+				s.dollarTag = nil
+				continue
+			}
+			s.current.WriteRune(r)
+			continue
+		case s.quote != 0:
+			s.current.WriteRune(r)
+			if r == '\\' && (s.quote == '\'' || s.quote == '"') && s.mysqlLike && i+1 < len(runes) {
+				s.current.WriteRune(runes[i+1])
+				i++
+				continue
+			}
+			if r == s.quote {
+				if i+1 < len(runes) && runes[i+1] == s.quote {
+					s.current.WriteRune(runes[i+1])
+					i++
+					continue
+				}
+				s.quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-', r == '#' && s.mysqlLike:
+			s.current.WriteString(string(runes[i:]))
+			i = len(runes)
+			continue
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			s.current.WriteString("/*")
+			i++
+			s.inBlock = true
+			continue
+		case r == '\'' || r == '"' || (r == '`' && s.mysqlLike):
+			s.quote = r
+		case r == '[' && s.name == "sqlserver":
+			s.quote = ']'
+		case r == '$' && s.pgLike:
+			if tag := readDollarQuoteTag(runes[i:]); tag != "" {
+				s.current.WriteString(tag)
+				s.dollarTag = []rune(tag)
+				i += len(s.dollarTag) - 1
+				continue
+			}
+		}
+
+		if !s.goBatches && !s.plsqlBlock && hasRunePrefix(runes, i, s.delimiter) {
+			s.flush()
+			i += len(s.delimiter) - 1
+			continue
+		}
+		s.current.WriteRune(r)
+	}
+}
+
+// Finish 输出末尾未以分隔符结束的语句。
+func (s *Splitter) Finish() {
+	s.flush()
+}
+
+// readDollarQuoteTag 识别 $$ 或 $tag$ 起始。
+func readDollarQuoteTag(runes []rune) string {
+	if len(runes) < 2 || runes[0] != '$' {
+		return ""
+	}
+	for i := 1; i < len(runes); i++ {
+		r := runes[i]
+		if r == '$' {
+			return string(runes[:i+1])
+		}
+		if !(r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 1 && r >= '0' && r <= '9')) {
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package sqlstmt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	cases := []struct {
+		name   string
+		dbType string
+		script string
+		want   []string
+	}{
+		{
+			name:   "mysql quotes, comments and delimiter",
+			dbType: "mysql",
+			script: "-- seed; data\nINSERT INTO t VALUES ('a;b', \"c\\\";\");\n# note;\nDELIMITER $$\nCREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW BEGIN SET NEW.a = 1; END$$\nDELIMITER ;\nSELECT `x;y` FROM t;",
+			want: []string{
+				"-- seed; data\nINSERT INTO t VALUES ('a;b', \"c\\\";\")",
+				"CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW BEGIN SET NEW.a = 1; END",
+				"SELECT `x;y` FROM t",
+			},
+		},
+		{
+			name:   "postgres dollar quoting",
+			dbType: "postgres",
+			script: "CREATE FUNCTION f() RETURNS int AS $body$ BEGIN RETURN 1; END; $body$ LANGUAGE plpgsql;\nSELECT 1;\n/* only comment; */",
+			want: []string{
+				"CREATE FUNCTION f() RETURNS int AS $body$ BEGIN RETURN 1; END; $body$ LANGUAGE plpgsql",
+				"SELECT 1",
+			},
+		},
+		{
+			name:   "sqlserver go batches",
+			dbType: "sqlserver",
+			script: "CREATE PROCEDURE p AS BEGIN SELECT 1; SELECT 2; END\nGO\nINSERT INTO [a;b] VALUES (1);\ngo\n",
+			want: []string{
+				"CREATE PROCEDURE p AS BEGIN SELECT 1; SELECT 2; END",
+				"INSERT INTO [a;b] VALUES (1);",
+			},
+		},
+		{
+			name:   "oracle plsql block",
+			dbType: "oracle",
+			script: "CREATE TABLE t (id NUMBER);\nBEGIN\n  INSERT INTO t VALUES (1);\nEND;\n/\nINSERT INTO t VALUES (2);",
+			want: []string{
+				"CREATE TABLE t (id NUMBER)",
+				"BEGIN\n  INSERT INTO t VALUES (1);\nEND;",
+				"INSERT INTO t VALUES (2)",
+			},
+		},
+	}
+	for _, tc := range cases {
+		got := Split(tc.script, tc.dbType)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%s: got %#v, want %#v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSplitterReportsStartLines(t *testing.T) {
+	type emitted struct {
+		stmt string
+		line int
+	}
+	var got []emitted
+	splitter := NewSplitter("mysql", false, func(stmt string, line int) {
+		got = append(got, emitted{stmt, line})
+	})
+	for _, line := range strings.SplitAfter("SELECT 1;\n\nSELECT\n  'a;\nb';\nDELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; END//\n", "\n") {
+		splitter.FeedLine(line)
+	}
+	splitter.Finish()
+	want := []emitted{
+		{"SELECT 1", 1},
+		{"SELECT\n  'a;\nb'", 3},
+		{"CREATE PROCEDURE p() BEGIN SELECT 1; END", 7},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package sqlstmt
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DatabaseSwitch 识别切换当前数据库的语句并返回目标库名：MySQL 系、SQL Server、ClickHouse、TDengine 的 USE db，
+// 以及 PostgreSQL 系的 psql 元命令 \c db / \connect db。标识符引号会被去除；语句带有其他内容时不视为切换。
+func DatabaseSwitch(dbType string, query string) (string, bool) {
+	d := newDialect(dbType)
+	text := strings.TrimSpace(stripLeadingComments(d, query))
+	text = strings.TrimSpace(strings.TrimRight(text, "; \t\r\n"))
+
+	var arg string
+	switch {
+	case d.pgLike && d.name != "duckdb":
+		lower := strings.ToLower(text)
+		switch {
+		case strings.HasPrefix(lower, `\connect`):
+			arg = text[len(`\connect`):]
+		case strings.HasPrefix(lower, `\c`):
+			arg = text[len(`\c`):]
+		default:
+			return "", false
+		}
+		if arg != "" && !unicode.IsSpace(rune(arg[0])) {
+			return "", false
+		}
+		// \c db user host port：只取数据库，“-” 表示沿用当前库
+		name, _ := readSwitchIdentifier(strings.TrimSpace(arg))
+		if name == "" || name == "-" {
+			return "", false
+		}
+		return name, true
+	case d.mysqlLike || d.name == "sqlserver" || d.name == "clickhouse" || d.name == "tdengine":
+		if len(text) < 4 || !strings.EqualFold(text[:3], "USE") || !unicode.IsSpace(rune(text[3])) {
+			return "", false
+		}
+		name, rest := readSwitchIdentifier(strings.TrimSpace(text[3:]))
+		if name == "" || strings.TrimSpace(rest) != "" {
+			return "", false
+		}
+		return name, true
+	}
+	return "", false
+}
+
+// stripLeadingComments 去掉语句开头的空白与注释。
+func stripLeadingComments(d dialect, query string) string {
+	text := query
+	for {
+		text = strings.TrimLeftFunc(text, unicode.IsSpace)
+		switch {
+		case strings.HasPrefix(text, "--"), d.mysqlLike && strings.HasPrefix(text, "#"):
+			idx := strings.IndexByte(text, '\n')
+			if idx < 0 {
+				return ""
+			}
+			text = text[idx+1:]
+		case strings.HasPrefix(text, "/*"):
+			idx := strings.Index(text[2:], "*/")
+			if idx < 0 {
+				return ""
+			}
+			text = text[idx+4:]
+		default:
+			return text
+		}
+	}
+}
+
+// readSwitchIdentifier 读取一个可能带引号（`x`、"x"、[x]）的标识符，返回去引号后的名称与剩余文本。
+func readSwitchIdentifier(text string) (string, string) {
+	if text == "" {
+		return "", ""
+	}
+	closing := map[byte]byte{'`': '`', '"': '"', '[': ']'}[text[0]]
+	if closing == 0 {
+		end := strings.IndexFunc(text, unicode.IsSpace)
+		if end < 0 {
+			return text, ""
+		}
+		return text[:end], text[end:]
+	}
+	var sb strings.Builder
+	for i := 1; i < len(text); i++ {
+		if text[i] != closing {
+			sb.WriteByte(text[i])
+			continue
+		}
+		// 连续两个结束引号表示引号本身
+		if i+1 < len(text) && text[i+1] == closing {
+			sb.WriteByte(closing)
+			i++
+			continue
+		}
+		return sb.String(), text[i+1:]
+	}
+	return "", ""
+}
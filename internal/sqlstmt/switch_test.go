@@ -0,0 +1,33 @@
+package sqlstmt
+
+import "testing"
+
+func TestDatabaseSwitch(t *testing.T) {
+	cases := []struct {
+		dbType string
+		query  string
+		target string
+		ok     bool
+	}{
+		{"mysql", "USE shop", "shop", true},
+		{"mysql", "-- switch\n use `order db`;", "order db", true},
+		{"mysql", "USE shop; SELECT 1", "", false},
+		{"mysql", "user_defined()", "", false},
+		{"sqlserver", "USE [Sales]", "Sales", true},
+		{"sqlserver", `USE "a""b"`, `a"b`, true},
+		{"clickhouse", "USE default", "default", true},
+		{"postgres", `\c analytics`, "analytics", true},
+		{"postgres", `\connect "Reports" postgres`, "Reports", true},
+		{"postgres", `\c - other_user`, "", false},
+		{"postgres", `\copy t FROM 'x.csv'`, "", false},
+		{"postgres", "USE analytics", "", false},
+		{"oracle", "USE hr", "", false},
+		{"sqlite", "USE main", "", false},
+	}
+	for _, c := range cases {
+		target, ok := DatabaseSwitch(c.dbType, c.query)
+		if target != c.target || ok != c.ok {
+			t.Errorf("DatabaseSwitch(%q, %q) = %q, %v; want %q, %v", c.dbType, c.query, target, ok, c.target, c.ok)
+		}
+	}
+}
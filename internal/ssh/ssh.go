@@ -6,7 +6,9 @@ import (
 	"io"
 	"net"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"GoNavi-Wails/internal/connection"
@@ -51,9 +53,8 @@ func dialContext(ctx context.Context, client *ssh.Client, network, addr string)
 	}
 }
 
-// connectSSH establishes an SSH connection and returns a Dialer
-func connectSSH(config connection.SSHConfig) (*ssh.Client, error) {
-	logger.Infof("开始建立 SSH 连接：地址=%s:%d 用户=%s", config.Host, config.Port, config.User)
+// sshClientConfig 构造单跳 SSH 的认证配置。
+func sshClientConfig(config connection.SSHConfig) *ssh.ClientConfig {
 	authMethods := []ssh.AuthMethod{}
 
 	if config.KeyPath != "" {
@@ -69,20 +70,29 @@ func connectSSH(config connection.SSHConfig) (*ssh.Client, error) {
 			}
 		}
 	}
-	
+
 	if config.Password != "" {
 		authMethods = append(authMethods, ssh.Password(config.Password))
 	}
 	if len(authMethods) == 0 {
-		logger.Warnf("SSH 未配置认证方式（密码或私钥）")
+		logger.Warnf("SSH 未配置认证方式（密码或私钥）：%s@%s:%d", config.User, config.Host, config.Port)
 	}
 
-	sshConfig := &ssh.ClientConfig{
+	return &ssh.ClientConfig{
 		User:            config.User,
 		Auth:            authMethods,
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // Use strict checking in production!
 		Timeout:         5 * time.Second,
 	}
+}
+
+// connectSSH establishes an SSH connection and returns a Dialer
+func connectSSH(config connection.SSHConfig) (*ssh.Client, error) {
+	if len(config.JumpHosts) > 0 {
+		return connectSSHViaJump(config)
+	}
+	logger.Infof("开始建立 SSH 连接：地址=%s:%d 用户=%s", config.Host, config.Port, config.User)
+	sshConfig := sshClientConfig(config)
 
 	addr := fmt.Sprintf("%s:%d", config.Host, config.Port)
 	client, err := ssh.Dial("tcp", addr, sshConfig)
@@ -94,19 +104,80 @@ func connectSSH(config connection.SSHConfig) (*ssh.Client, error) {
 	return client, nil
 }
 
+// parentHop 返回到达 config 之前的最后一跳，其 JumpHosts 为更靠前的跳板机，
+// 因而每一跳都有自己的缓存客户端，可被其他经过同一跳板链的连接复用。
+func parentHop(config connection.SSHConfig) connection.SSHConfig {
+	jumps := config.JumpHosts
+	parent := jumps[len(jumps)-1]
+	parent.JumpHosts = nil
+	if len(jumps) > 1 {
+		parent.JumpHosts = jumps[:len(jumps)-1]
+	}
+	return parent
+}
+
+// connectSSHViaJump 先连上前一跳，再通过其转发通道与本跳完成 SSH 握手。
+func connectSSHViaJump(config connection.SSHConfig) (*ssh.Client, error) {
+	chain := describeSSHChain(config)
+	logger.Infof("开始经跳板机建立 SSH 连接：%s", chain)
+	parent, err := GetOrCreateSSHClient(parentHop(config))
+	if err != nil {
+		return nil, fmt.Errorf("连接跳板机失败：%w", err)
+	}
+
+	sshConfig := sshClientConfig(config)
+	addr := fmt.Sprintf("%s:%d", config.Host, config.Port)
+	ctx, cancel := context.WithTimeout(context.Background(), sshConfig.Timeout)
+	defer cancel()
+	conn, err := dialContext(ctx, parent, "tcp", addr)
+	if err != nil {
+		logger.Error(err, "经跳板机连接 SSH 主机失败：%s", chain)
+		return nil, fmt.Errorf("经跳板机连接 %s 失败：%w", addr, err)
+	}
+
+	// 转发通道不支持读写超时，握手超时时直接关闭通道使 NewClientConn 返回
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, addr, sshConfig)
+	if !stop() && err == nil {
+		_ = clientConn.Close()
+		err = fmt.Errorf("SSH 握手超时")
+	}
+	if err != nil {
+		_ = conn.Close()
+		logger.Error(err, "SSH 连接建立失败：%s", chain)
+		return nil, err
+	}
+	logger.Infof("SSH 连接建立成功：%s", chain)
+	return ssh.NewClient(clientConn, chans, reqs), nil
+}
+
+// describeSSHChain 以 “user@host:port -> ...” 形式描述完整跳板链，用于日志与隧道状态。
+func describeSSHChain(config connection.SSHConfig) string {
+	hops := make([]string, 0, len(config.JumpHosts)+1)
+	for _, jump := range config.JumpHosts {
+		hops = append(hops, fmt.Sprintf("%s@%s:%d", jump.User, jump.Host, jump.Port))
+	}
+	hops = append(hops, fmt.Sprintf("%s@%s:%d", config.User, config.Host, config.Port))
+	return strings.Join(hops, " -> ")
+}
+
 // RegisterSSHNetwork registers a unique network name for a specific SSH tunnel
 // Returns the network name to use in DSN
+// 同一跳板机的多个连接共享缓存的 SSH 客户端，拨号时按需重建已断开的客户端
 func RegisterSSHNetwork(sshConfig connection.SSHConfig) (string, error) {
-	client, err := connectSSH(sshConfig)
-	if err != nil {
+	if _, err := GetOrCreateSSHClient(sshConfig); err != nil {
 		return "", err
 	}
 
 	// Generate unique network name
 	netName := fmt.Sprintf("ssh_%s_%d", sshConfig.Host, time.Now().UnixNano())
 	logger.Infof("注册 SSH 网络：%s（地址=%s:%d 用户=%s）", netName, sshConfig.Host, sshConfig.Port, sshConfig.User)
-	
+
 	mysql.RegisterDialContext(netName, func(ctx context.Context, addr string) (net.Conn, error) {
+		client, err := GetOrCreateSSHClient(sshConfig)
+		if err != nil {
+			return nil, err
+		}
 		return dialContext(ctx, client, "tcp", addr)
 	})
 
@@ -131,18 +202,35 @@ type LocalForwarder struct {
 	closeOnce  sync.Once // 防止重复关闭
 	closed     bool      // 关闭状态标记
 	closedMu   sync.RWMutex
+
+	key       string
+	manual    bool
+	label     string
+	sshConfig connection.SSHConfig
+	createdAt time.Time
+	clientMu  sync.RWMutex
+	// 流量统计：sent 为本地->远程，received 为远程->本地
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+	activeConns   atomic.Int64
+	totalConns    atomic.Int64
 }
 
 // NewLocalForwarder creates a new local port forwarder
 // It listens on a random local port and forwards all connections through SSH tunnel
 func NewLocalForwarder(sshConfig connection.SSHConfig, remoteHost string, remotePort int) (*LocalForwarder, error) {
+	// Listen on localhost with a random port
+	return newLocalForwarderOn(sshConfig, remoteHost, remotePort, "127.0.0.1:0")
+}
+
+// newLocalForwarderOn 在指定本地地址上创建转发；重连时复用原端口，已使用该地址的连接无需重新配置。
+func newLocalForwarderOn(sshConfig connection.SSHConfig, remoteHost string, remotePort int, listenAddr string) (*LocalForwarder, error) {
 	client, err := GetOrCreateSSHClient(sshConfig)
 	if err != nil {
 		return nil, fmt.Errorf("建立 SSH 连接失败：%w", err)
 	}
 
-	// Listen on localhost with a random port
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	listener, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		return nil, fmt.Errorf("创建本地监听器失败：%w", err)
 	}
@@ -156,6 +244,9 @@ func NewLocalForwarder(sshConfig connection.SSHConfig, remoteHost string, remote
 		SSHClient:  client,
 		listener:   listener,
 		closeChan:  make(chan struct{}),
+		key:        forwarderKey(sshConfig, remoteHost, remotePort),
+		sshConfig:  sshConfig,
+		createdAt:  time.Now(),
 	}
 
 	// Start forwarding in background
@@ -188,12 +279,24 @@ func (f *LocalForwarder) forward() {
 // handleConnection handles a single connection
 func (f *LocalForwarder) handleConnection(localConn net.Conn) {
 	defer localConn.Close()
+	f.totalConns.Add(1)
+	f.activeConns.Add(1)
+	defer f.activeConns.Add(-1)
 
 	// Connect to remote through SSH with timeout
-	remoteConn, err := f.SSHClient.Dial("tcp", f.RemoteAddr)
+	remoteConn, err := f.client().Dial("tcp", f.RemoteAddr)
 	if err != nil {
-		logger.Warnf("通过 SSH 连接到远程 %s 失败：%v", f.RemoteAddr, err)
-		return
+		// 跳板机连接可能已断开：重建共享客户端后重试一次
+		client, refreshErr := f.refreshClient(false)
+		if refreshErr != nil {
+			logger.Warnf("通过 SSH 连接到远程 %s 失败：%v", f.RemoteAddr, err)
+			return
+		}
+		remoteConn, err = client.Dial("tcp", f.RemoteAddr)
+		if err != nil {
+			logger.Warnf("通过 SSH 连接到远程 %s 失败：%v", f.RemoteAddr, err)
+			return
+		}
 	}
 	defer remoteConn.Close()
 
@@ -202,19 +305,24 @@ func (f *LocalForwarder) handleConnection(localConn net.Conn) {
 
 	// Copy from local to remote
 	go func() {
-		_, err := io.Copy(remoteConn, localConn)
+		n, err := io.Copy(remoteConn, localConn)
+		f.bytesSent.Add(n)
 		if err != nil {
 			logger.Warnf("本地->远程数据复制错误：%v", err)
 		}
+		// 半关闭写端让对方收到 EOF，否则另一方向的复制会一直阻塞，连接无法释放
+		closeWrite(remoteConn)
 		errc <- err
 	}()
 
 	// Copy from remote to local
 	go func() {
-		_, err := io.Copy(localConn, remoteConn)
+		n, err := io.Copy(localConn, remoteConn)
+		f.bytesReceived.Add(n)
 		if err != nil {
 			logger.Warnf("远程->本地数据复制错误：%v", err)
 		}
+		closeWrite(localConn)
 		errc <- err
 	}()
 
@@ -223,6 +331,15 @@ func (f *LocalForwarder) handleConnection(localConn net.Conn) {
 	<-errc
 }
 
+// closeWrite 关闭连接的写方向（TCP 连接与 SSH 通道均支持），不支持时直接关闭连接。
+func closeWrite(conn net.Conn) {
+	if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+		_ = cw.CloseWrite()
+		return
+	}
+	_ = conn.Close()
+}
+
 // Close closes the forwarder (thread-safe, can be called multiple times)
 func (f *LocalForwarder) Close() error {
 	var err error
@@ -249,9 +366,7 @@ func (f *LocalForwarder) IsClosed() bool {
 
 // GetOrCreateLocalForwarder returns a cached forwarder or creates a new one
 func GetOrCreateLocalForwarder(sshConfig connection.SSHConfig, remoteHost string, remotePort int) (*LocalForwarder, error) {
-	key := fmt.Sprintf("%s:%d:%s->%s:%d",
-		sshConfig.Host, sshConfig.Port, sshConfig.User,
-		remoteHost, remotePort)
+	key := forwarderKey(sshConfig, remoteHost, remotePort)
 
 	forwarderMu.RLock()
 	forwarder, exists := localForwarders[key]
@@ -296,10 +411,18 @@ func CloseAllForwarders() {
 	localForwarders = make(map[string]*LocalForwarder)
 }
 
-
 // getSSHClientCacheKey generates a unique cache key for SSH config
+// 经跳板机的连接在末尾追加跳板链，不同链路到达同一主机时不共享客户端。
 func getSSHClientCacheKey(config connection.SSHConfig) string {
-	return fmt.Sprintf("%s:%d:%s", config.Host, config.Port, config.User)
+	key := fmt.Sprintf("%s:%d:%s", config.Host, config.Port, config.User)
+	if len(config.JumpHosts) == 0 {
+		return key
+	}
+	hops := make([]string, 0, len(config.JumpHosts))
+	for _, jump := range config.JumpHosts {
+		hops = append(hops, fmt.Sprintf("%s:%d:%s", jump.Host, jump.Port, jump.User))
+	}
+	return key + " via " + strings.Join(hops, ",")
 }
 
 // GetOrCreateSSHClient returns a cached SSH client or creates a new one
@@ -372,4 +495,3 @@ func CloseAllSSHClients() {
 	}
 	sshClientCache = make(map[string]*ssh.Client)
 }
-
@@ -0,0 +1,210 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"GoNavi-Wails/internal/connection"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// testSSHServer 仅支持密码认证与 direct-tcpip 转发的最小 SSH 服务端。
+type testSSHServer struct {
+	addr     string
+	forwards atomic.Int64
+}
+
+func startTestSSHServer(t *testing.T, password string) *testSSHServer {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if string(pass) != password {
+				return nil, fmt.Errorf("denied")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	server := &testSSHServer{addr: ln.Addr().String()}
+	go func() {
+		for {
+			nc, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(nc, config)
+		}
+	}()
+	return server
+}
+
+func (s *testSSHServer) serve(nc net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(nc, config)
+	if err != nil {
+		_ = nc.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newCh := range chans {
+		switch newCh.ChannelType() {
+		case "session":
+			ch, chReqs, err := newCh.Accept()
+			if err == nil {
+				go ssh.DiscardRequests(chReqs)
+				_ = ch.Close()
+			}
+		case "direct-tcpip":
+			var target struct {
+				Host     string
+				Port     uint32
+				OrigHost string
+				OrigPort uint32
+			}
+			if err := ssh.Unmarshal(newCh.ExtraData(), &target); err != nil {
+				_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+			upstream, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+			if err != nil {
+				_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+			ch, chReqs, err := newCh.Accept()
+			if err != nil {
+				_ = upstream.Close()
+				continue
+			}
+			s.forwards.Add(1)
+			go ssh.DiscardRequests(chReqs)
+			go func() {
+				_, _ = io.Copy(ch, upstream)
+				_ = ch.Close()
+			}()
+			go func() {
+				_, _ = io.Copy(upstream, ch)
+				_ = upstream.Close()
+			}()
+		default:
+			_ = newCh.Reject(ssh.UnknownChannelType, "unsupported")
+		}
+	}
+}
+
+func (s *testSSHServer) config(t *testing.T, user, password string) connection.SSHConfig {
+	t.Helper()
+	host, portText, _ := net.SplitHostPort(s.addr)
+	port, _ := strconv.Atoi(portText)
+	return connection.SSHConfig{Host: host, Port: port, User: user, Password: password}
+}
+
+func startEchoServer(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				_, _ = io.Copy(c, c)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestDialThroughSSHJumpHosts(t *testing.T) {
+	t.Cleanup(CloseAllSSHClients)
+	first := startTestSSHServer(t, "pw1")
+	second := startTestSSHServer(t, "pw2")
+	target := startTestSSHServer(t, "pw3")
+	echo := startEchoServer(t)
+
+	config := target.config(t, "db", "pw3")
+	config.JumpHosts = []connection.SSHConfig{first.config(t, "ops", "pw1"), second.config(t, "ops", "pw2")}
+
+	conn, err := DialThroughSSH(config, "tcp", echo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != "ping" {
+		t.Fatalf("echo = %q, %v", buf, err)
+	}
+	// first 转发到 second，second 转发到 target，target 转发到 echo
+	if first.forwards.Load() != 1 || second.forwards.Load() != 1 || target.forwards.Load() != 1 {
+		t.Fatalf("forwards = %d/%d/%d", first.forwards.Load(), second.forwards.Load(), target.forwards.Load())
+	}
+
+	// 再次拨号复用缓存的整条链路
+	again, err := DialThroughSSH(config, "tcp", echo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = again.Close()
+	if first.forwards.Load() != 1 || target.forwards.Load() != 2 {
+		t.Fatalf("chain not reused: forwards = %d/%d", first.forwards.Load(), target.forwards.Load())
+	}
+}
+
+func TestDialThroughSSHJumpHostAuthFailure(t *testing.T) {
+	t.Cleanup(CloseAllSSHClients)
+	jump := startTestSSHServer(t, "right")
+	target := startTestSSHServer(t, "pw")
+
+	config := target.config(t, "db", "pw")
+	config.JumpHosts = []connection.SSHConfig{jump.config(t, "ops", "wrong")}
+	if _, err := DialThroughSSH(config, "tcp", "127.0.0.1:1"); err == nil {
+		t.Fatal("expected jump host auth failure")
+	}
+	if target.forwards.Load() != 0 {
+		t.Fatal("target should not be reached")
+	}
+}
+
+func TestSSHClientCacheKeyIncludesJumpChain(t *testing.T) {
+	direct := connection.SSHConfig{Host: "db-bastion", Port: 22, User: "ops"}
+	viaA := direct
+	viaA.JumpHosts = []connection.SSHConfig{{Host: "a", Port: 22, User: "u"}}
+	viaB := direct
+	viaB.JumpHosts = []connection.SSHConfig{{Host: "b", Port: 22, User: "u"}}
+	if getSSHClientCacheKey(direct) != "db-bastion:22:ops" {
+		t.Fatalf("direct key changed: %s", getSSHClientCacheKey(direct))
+	}
+	if getSSHClientCacheKey(viaA) == getSSHClientCacheKey(viaB) || getSSHClientCacheKey(viaA) == getSSHClientCacheKey(direct) {
+		t.Fatal("jump chains must not share a cache key")
+	}
+	if got := describeSSHChain(viaA); got != "u@a:22 -> ops@db-bastion:22" {
+		t.Fatalf("chain = %s", got)
+	}
+}
@@ -0,0 +1,219 @@
+package ssh
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"GoNavi-Wails/internal/connection"
+	"GoNavi-Wails/internal/logger"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// TunnelStatus describes an active local forwarder and its traffic counters.
+type TunnelStatus struct {
+	ID            string `json:"id"`
+	Bastion       string `json:"bastion"` // user@host:port，多跳时为 “跳板 -> ... -> 目标” 链路
+	LocalAddr     string `json:"localAddr"`
+	RemoteAddr    string `json:"remoteAddr"`
+	BytesSent     int64  `json:"bytesSent"`
+	BytesReceived int64  `json:"bytesReceived"`
+	ActiveConns   int64  `json:"activeConns"`
+	TotalConns    int64  `json:"totalConns"`
+	CreatedAt     int64  `json:"createdAt"`
+	Closed        bool   `json:"closed"`
+	Manual        bool   `json:"manual"` // 独立端口转发（非数据库连接创建）
+	Label         string `json:"label,omitempty"`
+}
+
+// BastionStatus describes a cached SSH client shared by all tunnels/dialers to the same bastion.
+type BastionStatus struct {
+	Key     string `json:"key"`
+	Alive   bool   `json:"alive"`
+	Tunnels int    `json:"tunnels"`
+}
+
+// forwarderKey 同一跳板机 + 同一远程目标共享一个本地转发。
+func forwarderKey(sshConfig connection.SSHConfig, remoteHost string, remotePort int) string {
+	return fmt.Sprintf("%s->%s:%d", getSSHClientCacheKey(sshConfig), remoteHost, remotePort)
+}
+
+// ForwarderKey returns the tunnel ID used for the given SSH profile and remote target.
+func ForwarderKey(sshConfig connection.SSHConfig, remoteHost string, remotePort int) string {
+	return forwarderKey(sshConfig, remoteHost, remotePort)
+}
+
+func (f *LocalForwarder) client() *ssh.Client {
+	f.clientMu.RLock()
+	defer f.clientMu.RUnlock()
+	return f.SSHClient
+}
+
+// refreshClient 重新获取共享 SSH 客户端；force 为 true 时先丢弃缓存客户端强制重连。
+func (f *LocalForwarder) refreshClient(force bool) (*ssh.Client, error) {
+	if force {
+		dropSSHClient(f.sshConfig)
+	}
+	client, err := GetOrCreateSSHClient(f.sshConfig)
+	if err != nil {
+		return nil, err
+	}
+	f.clientMu.Lock()
+	f.SSHClient = client
+	f.clientMu.Unlock()
+	return client, nil
+}
+
+func (f *LocalForwarder) status() TunnelStatus {
+	return TunnelStatus{
+		ID:            f.key,
+		Bastion:       describeSSHChain(f.sshConfig),
+		LocalAddr:     f.LocalAddr,
+		RemoteAddr:    f.RemoteAddr,
+		BytesSent:     f.bytesSent.Load(),
+		BytesReceived: f.bytesReceived.Load(),
+		ActiveConns:   f.activeConns.Load(),
+		TotalConns:    f.totalConns.Load(),
+		CreatedAt:     f.createdAt.UnixMilli(),
+		Closed:        f.IsClosed(),
+		Manual:        f.manual,
+		Label:         f.label,
+	}
+}
+
+// ListTunnels returns all cached local forwarders sorted by ID.
+func ListTunnels() []TunnelStatus {
+	forwarderMu.RLock()
+	result := make([]TunnelStatus, 0, len(localForwarders))
+	for _, forwarder := range localForwarders {
+		if forwarder != nil {
+			result = append(result, forwarder.status())
+		}
+	}
+	forwarderMu.RUnlock()
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// ListBastions returns cached SSH clients and how many tunnels share each of them.
+func ListBastions() []BastionStatus {
+	counts := map[string]int{}
+	forwarderMu.RLock()
+	for _, forwarder := range localForwarders {
+		if forwarder != nil && !forwarder.IsClosed() {
+			counts[getSSHClientCacheKey(forwarder.sshConfig)]++
+		}
+	}
+	forwarderMu.RUnlock()
+
+	sshClientCacheMu.RLock()
+	clients := make(map[string]*ssh.Client, len(sshClientCache))
+	for key, client := range sshClientCache {
+		clients[key] = client
+	}
+	sshClientCacheMu.RUnlock()
+
+	result := make([]BastionStatus, 0, len(clients))
+	for key, client := range clients {
+		alive := false
+		if client != nil {
+			// keepalive 请求不会创建会话，比 NewSession 更轻
+			_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+			alive = err == nil
+		}
+		result = append(result, BastionStatus{Key: key, Alive: alive, Tunnels: counts[key]})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// ReconnectTunnel re-establishes the bastion connection behind a tunnel and, if the local
+// listener was closed, listens again on the same local address. Other tunnels sharing the
+// bastion are switched to the new SSH client on their next connection.
+func ReconnectTunnel(id string) (TunnelStatus, error) {
+	forwarderMu.RLock()
+	forwarder, exists := localForwarders[id]
+	forwarderMu.RUnlock()
+	if !exists || forwarder == nil {
+		return TunnelStatus{}, fmt.Errorf("隧道不存在：%s", id)
+	}
+
+	if _, err := forwarder.refreshClient(true); err != nil {
+		return forwarder.status(), fmt.Errorf("重连跳板机失败：%w", err)
+	}
+	if !forwarder.IsClosed() {
+		logger.Infof("已重连 SSH 隧道：%s", id)
+		return forwarder.status(), nil
+	}
+
+	host, portText, err := net.SplitHostPort(forwarder.RemoteAddr)
+	if err != nil {
+		return forwarder.status(), fmt.Errorf("解析远程地址失败：%w", err)
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return forwarder.status(), fmt.Errorf("解析远程端口失败：%w", err)
+	}
+	replacement, err := newLocalForwarderOn(forwarder.sshConfig, host, port, forwarder.LocalAddr)
+	if err != nil {
+		return forwarder.status(), err
+	}
+	replacement.key, replacement.manual, replacement.label = forwarder.key, forwarder.manual, forwarder.label
+	forwarderMu.Lock()
+	localForwarders[id] = replacement
+	forwarderMu.Unlock()
+	logger.Infof("已重建 SSH 隧道：%s 本地=%s", id, replacement.LocalAddr)
+	return replacement.status(), nil
+}
+
+// CloseTunnel closes a local forwarder and removes it from the cache.
+func CloseTunnel(id string) error {
+	forwarderMu.Lock()
+	forwarder, exists := localForwarders[id]
+	delete(localForwarders, id)
+	forwarderMu.Unlock()
+	if !exists || forwarder == nil {
+		return fmt.Errorf("隧道不存在：%s", id)
+	}
+	logger.Infof("手动关闭 SSH 隧道：%s 运行时长=%s", id, time.Since(forwarder.createdAt).Round(time.Second))
+	return forwarder.Close()
+}
+
+// OpenPortForward opens a standalone local forward to remoteHost:remotePort over the SSH profile.
+// bindAddr 为空时监听 127.0.0.1:0（随机端口）；指定端口被占用时返回错误。
+func OpenPortForward(sshConfig connection.SSHConfig, remoteHost string, remotePort int, bindAddr string, label string) (TunnelStatus, error) {
+	if bindAddr == "" {
+		bindAddr = "127.0.0.1:0"
+	}
+	forwarder, err := newLocalForwarderOn(sshConfig, remoteHost, remotePort, bindAddr)
+	if err != nil {
+		return TunnelStatus{}, err
+	}
+	forwarder.key = "forward:" + forwarder.LocalAddr
+	forwarder.manual = true
+	forwarder.label = label
+
+	forwarderMu.Lock()
+	if existing, exists := localForwarders[forwarder.key]; exists && existing != nil {
+		_ = existing.Close()
+	}
+	localForwarders[forwarder.key] = forwarder
+	forwarderMu.Unlock()
+	return forwarder.status(), nil
+}
+
+// dropSSHClient 关闭并移除指定跳板机的缓存客户端。
+func dropSSHClient(config connection.SSHConfig) {
+	key := getSSHClientCacheKey(config)
+	sshClientCacheMu.Lock()
+	client, exists := sshClientCache[key]
+	delete(sshClientCache, key)
+	sshClientCacheMu.Unlock()
+	if exists && client != nil {
+		_ = client.Close()
+		logger.Infof("已断开 SSH 连接以便重连：%s", key)
+	}
+}
@@ -0,0 +1,127 @@
+package ssh
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// echoThrough 经本地转发地址发送一次数据并等待连接处理结束，便于读取流量计数。
+func echoThrough(t *testing.T, forwarder *LocalForwarder, payload string) {
+	t.Helper()
+	conn, err := net.Dial("tcp", forwarder.LocalAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Write([]byte(payload)); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len(payload))
+	if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != payload {
+		t.Fatalf("echo = %q, %v", buf, err)
+	}
+	_ = conn.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for forwarder.activeConns.Load() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTunnelManagerSharesBastionAndReconnects(t *testing.T) {
+	t.Cleanup(CloseAllSSHClients)
+	t.Cleanup(CloseAllForwarders)
+	bastion := startTestSSHServer(t, "pw")
+	echoHost, echoPortText, _ := net.SplitHostPort(startEchoServer(t))
+	echoPort, _ := strconv.Atoi(echoPortText)
+	config := bastion.config(t, "ops", "pw")
+
+	first, err := GetOrCreateLocalForwarder(config, echoHost, echoPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := GetOrCreateLocalForwarder(config, echoHost, echoPort)
+	if err != nil || again != first {
+		t.Fatalf("same target should reuse the forwarder: %v", err)
+	}
+	other, err := GetOrCreateLocalForwarder(config, echoHost, echoPort+1)
+	if err != nil || other == first {
+		t.Fatalf("different target should get its own forwarder: %v", err)
+	}
+
+	echoThrough(t, first, "ping")
+	var status TunnelStatus
+	for _, s := range ListTunnels() {
+		if s.ID == ForwarderKey(config, echoHost, echoPort) {
+			status = s
+		}
+	}
+	if status.LocalAddr != first.LocalAddr || status.BytesSent != 4 || status.BytesReceived != 4 || status.TotalConns != 1 || status.ActiveConns != 0 {
+		t.Fatalf("status = %+v", status)
+	}
+	if status.Bastion != "ops@"+bastion.addr || status.Manual {
+		t.Fatalf("bastion = %s manual = %v", status.Bastion, status.Manual)
+	}
+	bastions := ListBastions()
+	if len(bastions) != 1 || !bastions[0].Alive || bastions[0].Tunnels != 2 {
+		t.Fatalf("bastions = %+v", bastions)
+	}
+
+	// 本地监听关闭后重连，重新监听同一地址
+	_ = first.Close()
+	status, err = ReconnectTunnel(status.ID)
+	if err != nil || status.Closed || status.LocalAddr != first.LocalAddr {
+		t.Fatalf("reconnect = %+v, %v", status, err)
+	}
+	forwarderMu.RLock()
+	replacement := localForwarders[status.ID]
+	forwarderMu.RUnlock()
+	echoThrough(t, replacement, "pong")
+
+	if err := CloseTunnel(status.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := CloseTunnel(status.ID); err == nil {
+		t.Fatal("closing an unknown tunnel should fail")
+	}
+	if _, err := ReconnectTunnel(status.ID); err == nil {
+		t.Fatal("reconnecting an unknown tunnel should fail")
+	}
+	if tunnels := ListTunnels(); len(tunnels) != 1 || tunnels[0].ID != ForwarderKey(config, echoHost, echoPort+1) {
+		t.Fatalf("tunnels = %+v", tunnels)
+	}
+}
+
+func TestOpenPortForward(t *testing.T) {
+	t.Cleanup(CloseAllSSHClients)
+	t.Cleanup(CloseAllForwarders)
+	bastion := startTestSSHServer(t, "pw")
+	echoHost, echoPortText, _ := net.SplitHostPort(startEchoServer(t))
+	echoPort, _ := strconv.Atoi(echoPortText)
+	config := bastion.config(t, "ops", "pw")
+
+	status, err := OpenPortForward(config, echoHost, echoPort, "", "redis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Manual || status.Label != "redis" || status.ID != "forward:"+status.LocalAddr {
+		t.Fatalf("status = %+v", status)
+	}
+	forwarderMu.RLock()
+	forwarder := localForwarders[status.ID]
+	forwarderMu.RUnlock()
+	echoThrough(t, forwarder, "hello")
+
+	// 指定的本地端口已被占用时直接报错
+	if _, err := OpenPortForward(config, echoHost, echoPort, status.LocalAddr, ""); err == nil {
+		t.Fatal("expected error for a local port in use")
+	}
+	if err := CloseTunnel(status.ID); err != nil {
+		t.Fatal(err)
+	}
+	if conn, err := net.DialTimeout("tcp", status.LocalAddr, time.Second); err == nil {
+		_ = conn.Close()
+		t.Fatal("closed forward should stop listening")
+	}
+}
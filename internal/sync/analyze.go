@@ -140,13 +140,11 @@ func (s *SyncEngine) Analyze(config SyncConfig) SyncAnalyzeResult {
 			}
 
 			pkCol := summary.PKColumn
+			comparer := newRowComparer(tableName, config.CompareRules)
 			targetMap := make(map[string]map[string]interface{}, len(targetRows))
 			for _, row := range targetRows {
-				if row[pkCol] == nil {
-					continue
-				}
-				pkVal := strings.TrimSpace(fmt.Sprintf("%v", row[pkCol]))
-				if pkVal == "" || pkVal == "<nil>" {
+				pkVal := comparer.pkKey(pkCol, row)
+				if pkVal == "" {
 					continue
 				}
 				targetMap[pkVal] = row
@@ -154,24 +152,14 @@ func (s *SyncEngine) Analyze(config SyncConfig) SyncAnalyzeResult {
 
 			sourcePKSet := make(map[string]struct{}, len(sourceRows))
 			for _, sRow := range sourceRows {
-				if sRow[pkCol] == nil {
-					continue
-				}
-				pkVal := strings.TrimSpace(fmt.Sprintf("%v", sRow[pkCol]))
-				if pkVal == "" || pkVal == "<nil>" {
+				pkVal := comparer.pkKey(pkCol, sRow)
+				if pkVal == "" {
 					continue
 				}
 				sourcePKSet[pkVal] = struct{}{}
 
 				if tRow, exists := targetMap[pkVal]; exists {
-					changed := false
-					for k, v := range sRow {
-						if fmt.Sprintf("%v", v) != fmt.Sprintf("%v", tRow[k]) {
-							changed = true
-							break
-						}
-					}
-					if changed {
+					if len(comparer.changedColumns(sRow, tRow)) > 0 {
 						summary.Updates++
 					} else {
 						summary.Same++
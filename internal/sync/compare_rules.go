@@ -0,0 +1,116 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompareRule controls how a single column is compared between source and target.
+// 不同数据库的排序规则（collation）不一致时（如 MySQL *_ci 与 PostgreSQL 默认区分大小写、CHAR 列尾部补空格），
+// 按字面值比较会产生大量“伪差异”，可按列放宽比较方式。
+type CompareRule struct {
+	IgnoreCase          bool `json:"ignoreCase,omitempty"`          // 忽略大小写
+	IgnoreTrailingSpace bool `json:"ignoreTrailingSpace,omitempty"` // 忽略尾部空格（PAD SPACE 语义）
+	Skip                bool `json:"skip,omitempty"`                // 不参与差异比较（如 updated_at）
+}
+
+// CompareRules holds the default rule and per-column overrides.
+// Columns 的键可以是 "列名" 或 "表名.列名"（后者优先），均不区分大小写。
+type CompareRules struct {
+	Default CompareRule            `json:"default"`
+	Columns map[string]CompareRule `json:"columns,omitempty"`
+}
+
+// rowComparer compares rows of one table using the configured rules.
+type rowComparer struct {
+	table string
+	rules CompareRules
+	cache map[string]CompareRule
+}
+
+func newRowComparer(table string, rules CompareRules) *rowComparer {
+	return &rowComparer{table: table, rules: rules, cache: make(map[string]CompareRule)}
+}
+
+func (c *rowComparer) rule(column string) CompareRule {
+	if r, ok := c.cache[column]; ok {
+		return r
+	}
+	r := c.rules.Default
+	tableKey := c.table + "." + column
+	matchedTable := false
+	for key, override := range c.rules.Columns {
+		if strings.EqualFold(key, tableKey) {
+			r = override
+			matchedTable = true
+			break
+		}
+	}
+	if !matchedTable {
+		for key, override := range c.rules.Columns {
+			if strings.EqualFold(key, column) {
+				r = override
+				break
+			}
+		}
+	}
+	c.cache[column] = r
+	return r
+}
+
+// normalizeCompareValue 按规则归一化取值，用于比较与主键匹配。
+func normalizeCompareValue(v interface{}, rule CompareRule) string {
+	text := fmt.Sprintf("%v", v)
+	if rule.IgnoreTrailingSpace {
+		text = strings.TrimRight(text, " ")
+	}
+	if rule.IgnoreCase {
+		text = strings.ToLower(text)
+	}
+	return text
+}
+
+// pkKey 返回主键匹配用的键；主键为空时返回空字符串。
+func (c *rowComparer) pkKey(pkCol string, row map[string]interface{}) string {
+	if row[pkCol] == nil {
+		return ""
+	}
+	raw := strings.TrimSpace(fmt.Sprintf("%v", row[pkCol]))
+	if raw == "" || raw == "<nil>" {
+		return ""
+	}
+	return normalizeCompareValue(raw, c.rule(pkCol))
+}
+
+// changedColumns 返回源行与目标行取值不同的列（按列名排序）。
+func (c *rowComparer) changedColumns(source, target map[string]interface{}) []string {
+	changed := make([]string, 0)
+	for k, v := range source {
+		rule := c.rule(k)
+		if rule.Skip {
+			continue
+		}
+		if normalizeCompareValue(v, rule) != normalizeCompareValue(target[k], rule) {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// lessPK 对主键排序：两侧均为数字时按数值比较，否则按归一化后的字符串比较，避免 "10" 排在 "9" 前面。
+func (c *rowComparer) lessPK(pkCol, a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return fa < fb
+	}
+	rule := c.rule(pkCol)
+	na, nb := normalizeCompareValue(a, rule), normalizeCompareValue(b, rule)
+	if na != nb {
+		return na < nb
+	}
+	return a < b
+}
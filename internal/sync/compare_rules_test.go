@@ -0,0 +1,46 @@
+package sync
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRowComparerAppliesColumnRules(t *testing.T) {
+	rules := CompareRules{
+		Default: CompareRule{IgnoreTrailingSpace: true},
+		Columns: map[string]CompareRule{
+			"name":            {IgnoreCase: true},
+			"users.nickname":  {IgnoreCase: true, IgnoreTrailingSpace: true},
+			"USERS.UpdatedAt": {Skip: true},
+		},
+	}
+	c := newRowComparer("users", rules)
+	source := map[string]interface{}{"id": "A1", "name": "Alice", "nickname": "Al ", "code": "x ", "updatedAt": "2024-01-01"}
+	target := map[string]interface{}{"id": "a1", "name": "alice", "nickname": "al", "code": "x", "updatedAt": "2025-01-01"}
+	if changed := c.changedColumns(source, target); len(changed) != 1 || changed[0] != "id" {
+		t.Fatalf("差异列不符合预期：%v", changed)
+	}
+
+	// 名称列规则仅忽略大小写，不继承默认的尾部空格规则
+	if changed := c.changedColumns(map[string]interface{}{"name": "Bob "}, map[string]interface{}{"name": "bob"}); !reflect.DeepEqual(changed, []string{"name"}) {
+		t.Fatalf("列级规则应覆盖默认规则：%v", changed)
+	}
+
+	ci := newRowComparer("users", CompareRules{Default: CompareRule{IgnoreCase: true}})
+	if ci.pkKey("id", source) != ci.pkKey("id", target) {
+		t.Fatalf("忽略大小写时主键应匹配")
+	}
+	if c.pkKey("id", map[string]interface{}{"id": nil}) != "" {
+		t.Fatalf("空主键应返回空键")
+	}
+}
+
+func TestRowComparerLessPK(t *testing.T) {
+	c := newRowComparer("t", CompareRules{})
+	if !c.lessPK("id", "9", "10") {
+		t.Fatalf("数字主键应按数值排序")
+	}
+	if !c.lessPK("id", "a", "b") || c.lessPK("id", "b", "a") {
+		t.Fatalf("字符串主键排序错误")
+	}
+}
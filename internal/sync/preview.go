@@ -3,6 +3,7 @@ package sync
 import (
 	"GoNavi-Wails/internal/db"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -89,13 +90,14 @@ func (s *SyncEngine) Preview(config SyncConfig, tableName string, limit int) (Ta
 		return TableDiffPreview{}, fmt.Errorf("读取目标表失败: %w", err)
 	}
 
+	comparer := newRowComparer(tableName, config.CompareRules)
+	pkText := func(row map[string]interface{}) string {
+		return strings.TrimSpace(fmt.Sprintf("%v", row[pkCol]))
+	}
 	targetMap := make(map[string]map[string]interface{}, len(targetRows))
 	for _, row := range targetRows {
-		if row[pkCol] == nil {
-			continue
-		}
-		pkVal := strings.TrimSpace(fmt.Sprintf("%v", row[pkCol]))
-		if pkVal == "" || pkVal == "<nil>" {
+		pkVal := comparer.pkKey(pkCol, row)
+		if pkVal == "" {
 			continue
 		}
 		targetMap[pkVal] = row
@@ -114,50 +116,49 @@ func (s *SyncEngine) Preview(config SyncConfig, tableName string, limit int) (Ta
 
 	sourcePKSet := make(map[string]struct{}, len(sourceRows))
 	for _, sRow := range sourceRows {
-		if sRow[pkCol] == nil {
-			continue
-		}
-		pkVal := strings.TrimSpace(fmt.Sprintf("%v", sRow[pkCol]))
-		if pkVal == "" || pkVal == "<nil>" {
+		pkVal := comparer.pkKey(pkCol, sRow)
+		if pkVal == "" {
 			continue
 		}
 		sourcePKSet[pkVal] = struct{}{}
 
 		if tRow, exists := targetMap[pkVal]; exists {
-			changedColumns := make([]string, 0)
-			for k, v := range sRow {
-				if fmt.Sprintf("%v", v) != fmt.Sprintf("%v", tRow[k]) {
-					changedColumns = append(changedColumns, k)
-				}
-			}
+			changedColumns := comparer.changedColumns(sRow, tRow)
 			if len(changedColumns) > 0 {
-				out.TotalUpdates++
-				if len(out.Updates) < limit {
-					out.Updates = append(out.Updates, PreviewUpdateRow{
-						PK:             pkVal,
-						ChangedColumns: changedColumns,
-						Source:         sRow,
-						Target:         tRow,
-					})
-				}
+				out.Updates = append(out.Updates, PreviewUpdateRow{
+					PK:             pkText(sRow),
+					ChangedColumns: changedColumns,
+					Source:         sRow,
+					Target:         tRow,
+				})
 			}
 			continue
 		}
 
-		out.TotalInserts++
-		if len(out.Inserts) < limit {
-			out.Inserts = append(out.Inserts, PreviewRow{PK: pkVal, Row: sRow})
-		}
+		out.Inserts = append(out.Inserts, PreviewRow{PK: pkText(sRow), Row: sRow})
 	}
 
 	for pkVal, row := range targetMap {
 		if _, ok := sourcePKSet[pkVal]; ok {
 			continue
 		}
-		out.TotalDeletes++
-		if len(out.Deletes) < limit {
-			out.Deletes = append(out.Deletes, PreviewRow{PK: pkVal, Row: row})
+		out.Deletes = append(out.Deletes, PreviewRow{PK: pkText(row), Row: row})
+	}
+
+	// 先按主键排序再截断，保证多次预览结果稳定
+	out.TotalInserts, out.TotalUpdates, out.TotalDeletes = len(out.Inserts), len(out.Updates), len(out.Deletes)
+	sortPreviewRows := func(rows []PreviewRow) []PreviewRow {
+		sort.SliceStable(rows, func(i, j int) bool { return comparer.lessPK(pkCol, rows[i].PK, rows[j].PK) })
+		if len(rows) > limit {
+			rows = rows[:limit]
 		}
+		return rows
+	}
+	out.Inserts = sortPreviewRows(out.Inserts)
+	out.Deletes = sortPreviewRows(out.Deletes)
+	sort.SliceStable(out.Updates, func(i, j int) bool { return comparer.lessPK(pkCol, out.Updates[i].PK, out.Updates[j].PK) })
+	if len(out.Updates) > limit {
+		out.Updates = out.Updates[:limit]
 	}
 
 	return out, nil
@@ -1,6 +1,10 @@
 package sync
 
-import "strings"
+import (
+	"strings"
+
+	"GoNavi-Wails/internal/dialect"
+)
 
 func normalizeSyncMode(mode string) string {
 	m := strings.ToLower(strings.TrimSpace(mode))
@@ -17,45 +21,11 @@ func normalizeSyncMode(mode string) string {
 }
 
 func quoteIdentByType(dbType string, ident string) string {
-	if ident == "" {
-		return ident
-	}
-
-	switch dbType {
-	case "mysql", "mariadb", "diros", "sphinx":
-		return "`" + strings.ReplaceAll(ident, "`", "``") + "`"
-	case "sqlserver":
-		escaped := strings.ReplaceAll(ident, "]", "]]")
-		return "[" + escaped + "]"
-	default:
-		return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
-	}
+	return dialect.QuoteIdent(dbType, ident)
 }
 
 func quoteQualifiedIdentByType(dbType string, ident string) string {
-	raw := strings.TrimSpace(ident)
-	if raw == "" {
-		return raw
-	}
-
-	parts := strings.Split(raw, ".")
-	if len(parts) <= 1 {
-		return quoteIdentByType(dbType, raw)
-	}
-
-	quotedParts := make([]string, 0, len(parts))
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			continue
-		}
-		quotedParts = append(quotedParts, quoteIdentByType(dbType, part))
-	}
-
-	if len(quotedParts) == 0 {
-		return quoteIdentByType(dbType, raw)
-	}
-	return strings.Join(quotedParts, ".")
+	return dialect.QuoteQualified(dbType, ident)
 }
 
 func normalizeSchemaAndTable(dbType string, dbName string, tableName string) (string, string) {
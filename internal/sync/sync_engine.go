@@ -20,6 +20,7 @@ type SyncConfig struct {
 	JobID          string                      `json:"jobId,omitempty"`
 	AutoAddColumns bool                        `json:"autoAddColumns,omitempty"` // 自动补齐缺失字段（当前仅 MySQL 目标支持）
 	TableOptions   map[string]TableOptions     `json:"tableOptions,omitempty"`
+	CompareRules   CompareRules                `json:"compareRules,omitempty"` // 数据对比时的列级比较规则
 }
 
 // SyncResult holds the result of the sync operation
@@ -208,13 +209,11 @@ func (s *SyncEngine) RunSync(config SyncConfig) SyncResult {
 
 				// 3. Compare (In-Memory Hash Map)
 				s.progress(config.JobID, i, totalTables, tableName, "对比差异")
+				comparer := newRowComparer(tableName, config.CompareRules)
 				targetMap := make(map[string]map[string]interface{})
 				for _, row := range targetRows {
-					if row[pkCol] == nil {
-						continue
-					}
-					pkVal := fmt.Sprintf("%v", row[pkCol])
-					if strings.TrimSpace(pkVal) == "" || pkVal == "<nil>" {
+					pkVal := comparer.pkKey(pkCol, row)
+					if pkVal == "" {
 						continue
 					}
 					targetMap[pkVal] = row
@@ -222,21 +221,16 @@ func (s *SyncEngine) RunSync(config SyncConfig) SyncResult {
 				sourcePKSet := make(map[string]struct{}, len(sourceRows))
 
 				for _, sRow := range sourceRows {
-					if sRow[pkCol] == nil {
-						continue
-					}
-					pkVal := fmt.Sprintf("%v", sRow[pkCol])
-					if strings.TrimSpace(pkVal) == "" || pkVal == "<nil>" {
+					pkVal := comparer.pkKey(pkCol, sRow)
+					if pkVal == "" {
 						continue
 					}
 					sourcePKSet[pkVal] = struct{}{}
 
 					if tRow, exists := targetMap[pkVal]; exists {
 						changes := make(map[string]interface{})
-						for k, v := range sRow {
-							if fmt.Sprintf("%v", v) != fmt.Sprintf("%v", tRow[k]) {
-								changes[k] = v
-							}
+						for _, k := range comparer.changedColumns(sRow, tRow) {
+							changes[k] = sRow[k]
 						}
 						if len(changes) > 0 {
 							updates = append(updates, connection.UpdateRow{